@@ -14,7 +14,7 @@ import (
 	"github.com/spf13/viper"
 )
 
-const VERSION = "22.6"
+const VERSION = "23.0"
 
 type Config struct {
 	Server          ServerConfig
@@ -25,7 +25,11 @@ type Config struct {
 	SMTPRelay       SMTPRelayConfig
 	Demo            DemoConfig
 	Broadcast       BroadcastConfig
+	Contacts        ContactsConfig
 	TaskScheduler   TaskSchedulerConfig
+	InboundWebhook  InboundWebhookConfig
+	WebhookDelivery WebhookDeliveryConfig
+	APIRateLimit    APIRateLimitConfig
 	Telemetry       bool
 	CheckForUpdates bool
 	RootEmail       string
@@ -83,6 +87,7 @@ type DatabaseConfig struct {
 	MaxConnectionsPerDB   int           // Max connections per individual workspace database
 	ConnectionMaxLifetime time.Duration // Maximum lifetime of a connection
 	ConnectionMaxIdleTime time.Duration // Maximum idle time before closing
+	MigrationConcurrency  int           // Max workspace databases migrated in parallel on startup
 }
 
 type SecurityConfig struct {
@@ -91,6 +96,10 @@ type SecurityConfig struct {
 
 	// SecretKey for DB encryption AND JWT signing
 	SecretKey string
+
+	// DeriveWorkspaceKeys encrypts new message data with a key derived from the workspace secret key
+	// instead of the raw secret (data encrypted either way can always be read)
+	DeriveWorkspaceKeys bool
 }
 
 type SSLConfig struct {
@@ -144,6 +153,21 @@ type SMTPConfig struct {
 	UseTLS    bool
 }
 
+// Validate checks that a configured SMTP provider has a host and a valid port. An SMTP provider with
+// none of its fields set is not configured yet (e.g. before the setup wizard) and is valid.
+func (c SMTPConfig) Validate() error {
+	if c.Host == "" && c.Username == "" && c.Password == "" && c.FromEmail == "" {
+		return nil
+	}
+	if c.Host == "" {
+		return fmt.Errorf("SMTP provider is misconfigured: SMTP_HOST is required")
+	}
+	if c.Port < 1 || c.Port > 65535 {
+		return fmt.Errorf("SMTP provider is misconfigured: SMTP_PORT must be between 1 and 65535 (got %d)", c.Port)
+	}
+	return nil
+}
+
 type SMTPRelayConfig struct {
 	Enabled       bool   // Enable SMTP relay server for receiving emails
 	Port          int    // Port to listen on (default: 587)
@@ -154,7 +178,21 @@ type SMTPRelayConfig struct {
 }
 
 type BroadcastConfig struct {
-	DefaultRateLimit int // Default rate limit per minute for broadcasts (0 means use service default)
+	DefaultRateLimit         int           // Default rate limit per minute for broadcasts (0 means use service default)
+	StatusUpdateRetries      int           // Retries of the final broadcast status write before the task fails (default: 3)
+	StatusUpdateRetryBackoff time.Duration // Delay before the first status write retry, doubled on each retry (default: 500ms)
+	MaxSendsPerSecond        int           // Max recipients sent per second by each broadcast, 0 disables throttling (default: 0)
+	RenderTimeout            time.Duration // Max time to render one recipient's message before it is skipped, 0 disables (default: 10s)
+	BatchRetries             int           // Retries of a batch interrupted by a transient provider error, 0 disables (default: 3)
+	BatchRetryBackoff        time.Duration // Delay before the first batch retry, doubled on each retry with jitter (default: 1s)
+	AdaptiveBatchSize        bool          // Tune the batch size from the provider latency and failures (default: false)
+	AdaptiveBatchMinSize     int           // Smallest batch size of adaptive batch sizing (default: 10)
+	AdaptiveBatchMaxSize     int           // Largest batch size of adaptive batch sizing (default: 500)
+}
+
+type ContactsConfig struct {
+	BulkGetMax             int           // Max emails or external IDs per contacts.bulkGet request (default: 500)
+	AudienceCountsCacheTTL time.Duration // How long contacts.audienceCounts results are cached per workspace (0 disables caching, default: 1m)
 }
 
 type TaskSchedulerConfig struct {
@@ -163,6 +201,25 @@ type TaskSchedulerConfig struct {
 	MaxTasks int           // Max tasks per execution (default: 100)
 }
 
+type InboundWebhookConfig struct {
+	PayloadRetention       time.Duration // How long raw provider webhook payloads are kept for reprocessing (0 disables storage, default: 72h)
+	IngestionWorkers       int           // Workers applying message status updates asynchronously (0 applies them synchronously, default: 0)
+	IngestionQueueSize     int           // Max queued webhook status updates before providers are asked to retry (default: 10000)
+	IngestionBatchSize     int           // Max status updates written per database call (default: 500)
+	IngestionFlushInterval time.Duration // Max delay before queued status updates are written (default: 200ms)
+	HealthWindow           time.Duration // Max delay without webhook events after sends before an integration is alerted as stale (0 disables monitoring, default: 6h)
+}
+
+type WebhookDeliveryConfig struct {
+	MaxConcurrentPerEndpoint int // Max outbound webhook deliveries sent at once to the same endpoint URL (default: 4)
+	MaxQueuedPerEndpoint     int // Max deliveries waiting for a busy endpoint, the rest stay pending until a later poll (default: 100)
+}
+
+type APIRateLimitConfig struct {
+	RequestsPerSecond float64 // Authenticated API requests refilled per second in the bucket of each workspace (0 disables rate limiting, default: 0)
+	Burst             int     // Requests a workspace can send at once before being limited to RequestsPerSecond (default: 50)
+}
+
 // LoadOptions contains options for loading configuration
 type LoadOptions struct {
 	EnvFile string // Optional environment file to load (e.g., ".env", ".env.test")
@@ -353,6 +410,7 @@ func LoadWithOptions(opts LoadOptions) (*Config, error) {
 	v.SetDefault("DB_MAX_CONNECTIONS_PER_DB", 3)
 	v.SetDefault("DB_CONNECTION_MAX_LIFETIME", "10m")
 	v.SetDefault("DB_CONNECTION_MAX_IDLE_TIME", "5m")
+	v.SetDefault("DB_MIGRATION_CONCURRENCY", 4)
 	v.SetDefault("ENVIRONMENT", "production")
 	v.SetDefault("LOG_LEVEL", "info")
 	v.SetDefault("VERSION", VERSION)
@@ -403,6 +461,38 @@ func LoadWithOptions(opts LoadOptions) (*Config, error) {
 	v.SetDefault("TASK_SCHEDULER_INTERVAL", "20s")
 	v.SetDefault("TASK_SCHEDULER_MAX_TASKS", 100)
 
+	// Inbound webhook defaults
+	v.SetDefault("INBOUND_WEBHOOK_PAYLOAD_RETENTION", "72h")
+	v.SetDefault("INBOUND_WEBHOOK_INGESTION_WORKERS", 0)
+	v.SetDefault("INBOUND_WEBHOOK_INGESTION_QUEUE_SIZE", 10000)
+	v.SetDefault("INBOUND_WEBHOOK_INGESTION_BATCH_SIZE", 500)
+	v.SetDefault("INBOUND_WEBHOOK_INGESTION_FLUSH_INTERVAL", "200ms")
+	v.SetDefault("INBOUND_WEBHOOK_HEALTH_WINDOW", "6h")
+
+	// Outbound webhook delivery defaults
+	v.SetDefault("WEBHOOK_DELIVERY_MAX_CONCURRENT_PER_ENDPOINT", 4)
+	v.SetDefault("WEBHOOK_DELIVERY_MAX_QUEUED_PER_ENDPOINT", 100)
+
+	// API rate limiting defaults
+	v.SetDefault("API_RATE_LIMIT_RPS", 0)
+	v.SetDefault("API_RATE_LIMIT_BURST", 50)
+
+	// Contacts API defaults
+	v.SetDefault("CONTACTS_BULK_GET_MAX", 500)
+	v.SetDefault("CONTACTS_AUDIENCE_COUNTS_CACHE_TTL", "1m")
+
+	// Broadcast defaults
+	v.SetDefault("BROADCAST_STATUS_UPDATE_RETRIES", 3)
+	v.SetDefault("BROADCAST_STATUS_UPDATE_RETRY_BACKOFF", "500ms")
+	v.SetDefault("DERIVE_WORKSPACE_KEYS", true)
+	v.SetDefault("BROADCAST_MAX_SENDS_PER_SECOND", 0)
+	v.SetDefault("BROADCAST_RENDER_TIMEOUT", "10s")
+	v.SetDefault("BROADCAST_BATCH_RETRIES", 3)
+	v.SetDefault("BROADCAST_BATCH_RETRY_BACKOFF", "1s")
+	v.SetDefault("BROADCAST_ADAPTIVE_BATCH_SIZE", false)
+	v.SetDefault("BROADCAST_ADAPTIVE_BATCH_MIN", 10)
+	v.SetDefault("BROADCAST_ADAPTIVE_BATCH_MAX", 500)
+
 	// Load environment file if specified
 	if opts.EnvFile != "" {
 		v.SetConfigName(opts.EnvFile)
@@ -440,6 +530,7 @@ func LoadWithOptions(opts LoadOptions) (*Config, error) {
 		MaxConnectionsPerDB:   v.GetInt("DB_MAX_CONNECTIONS_PER_DB"),
 		ConnectionMaxLifetime: v.GetDuration("DB_CONNECTION_MAX_LIFETIME"),
 		ConnectionMaxIdleTime: v.GetDuration("DB_CONNECTION_MAX_IDLE_TIME"),
+		MigrationConcurrency:  v.GetInt("DB_MIGRATION_CONCURRENCY"),
 	}
 
 	// Validate database connection settings
@@ -455,6 +546,95 @@ func LoadWithOptions(opts LoadOptions) (*Config, error) {
 	if dbConfig.MaxConnectionsPerDB > 50 {
 		return nil, fmt.Errorf("DB_MAX_CONNECTIONS_PER_DB cannot exceed 50 (got %d)", dbConfig.MaxConnectionsPerDB)
 	}
+	if dbConfig.MigrationConcurrency < 1 {
+		return nil, fmt.Errorf("DB_MIGRATION_CONCURRENCY must be at least 1 (got %d)", dbConfig.MigrationConcurrency)
+	}
+	if dbConfig.MigrationConcurrency > 50 {
+		return nil, fmt.Errorf("DB_MIGRATION_CONCURRENCY cannot exceed 50 (got %d)", dbConfig.MigrationConcurrency)
+	}
+
+	ingestionWorkers := v.GetInt("INBOUND_WEBHOOK_INGESTION_WORKERS")
+	if ingestionWorkers < 0 {
+		return nil, fmt.Errorf("INBOUND_WEBHOOK_INGESTION_WORKERS must be at least 0 (got %d)", ingestionWorkers)
+	}
+	ingestionQueueSize := v.GetInt("INBOUND_WEBHOOK_INGESTION_QUEUE_SIZE")
+	if ingestionQueueSize < 1 {
+		return nil, fmt.Errorf("INBOUND_WEBHOOK_INGESTION_QUEUE_SIZE must be at least 1 (got %d)", ingestionQueueSize)
+	}
+	ingestionBatchSize := v.GetInt("INBOUND_WEBHOOK_INGESTION_BATCH_SIZE")
+	if ingestionBatchSize < 1 {
+		return nil, fmt.Errorf("INBOUND_WEBHOOK_INGESTION_BATCH_SIZE must be at least 1 (got %d)", ingestionBatchSize)
+	}
+	ingestionFlushInterval := v.GetDuration("INBOUND_WEBHOOK_INGESTION_FLUSH_INTERVAL")
+	if ingestionFlushInterval <= 0 {
+		return nil, fmt.Errorf("INBOUND_WEBHOOK_INGESTION_FLUSH_INTERVAL must be positive (got %s)", ingestionFlushInterval)
+	}
+	webhookHealthWindow := v.GetDuration("INBOUND_WEBHOOK_HEALTH_WINDOW")
+	if webhookHealthWindow < 0 {
+		return nil, fmt.Errorf("INBOUND_WEBHOOK_HEALTH_WINDOW cannot be negative (got %s)", webhookHealthWindow)
+	}
+	webhookMaxConcurrentPerEndpoint := v.GetInt("WEBHOOK_DELIVERY_MAX_CONCURRENT_PER_ENDPOINT")
+	if webhookMaxConcurrentPerEndpoint < 1 {
+		return nil, fmt.Errorf("WEBHOOK_DELIVERY_MAX_CONCURRENT_PER_ENDPOINT must be at least 1 (got %d)", webhookMaxConcurrentPerEndpoint)
+	}
+	webhookMaxQueuedPerEndpoint := v.GetInt("WEBHOOK_DELIVERY_MAX_QUEUED_PER_ENDPOINT")
+	if webhookMaxQueuedPerEndpoint < 0 {
+		return nil, fmt.Errorf("WEBHOOK_DELIVERY_MAX_QUEUED_PER_ENDPOINT cannot be negative (got %d)", webhookMaxQueuedPerEndpoint)
+	}
+
+	apiRateLimitRPS := v.GetFloat64("API_RATE_LIMIT_RPS")
+	if apiRateLimitRPS < 0 {
+		return nil, fmt.Errorf("API_RATE_LIMIT_RPS cannot be negative (got %v)", apiRateLimitRPS)
+	}
+	apiRateLimitBurst := v.GetInt("API_RATE_LIMIT_BURST")
+	if apiRateLimitBurst < 1 {
+		return nil, fmt.Errorf("API_RATE_LIMIT_BURST must be at least 1 (got %d)", apiRateLimitBurst)
+	}
+
+	contactsBulkGetMax := v.GetInt("CONTACTS_BULK_GET_MAX")
+	if contactsBulkGetMax < 1 {
+		return nil, fmt.Errorf("CONTACTS_BULK_GET_MAX must be at least 1 (got %d)", contactsBulkGetMax)
+	}
+	if contactsBulkGetMax > 5000 {
+		return nil, fmt.Errorf("CONTACTS_BULK_GET_MAX cannot exceed 5000 (got %d)", contactsBulkGetMax)
+	}
+	contactsAudienceCountsCacheTTL := v.GetDuration("CONTACTS_AUDIENCE_COUNTS_CACHE_TTL")
+	if contactsAudienceCountsCacheTTL < 0 {
+		return nil, fmt.Errorf("CONTACTS_AUDIENCE_COUNTS_CACHE_TTL cannot be negative (got %s)", contactsAudienceCountsCacheTTL)
+	}
+
+	broadcastStatusUpdateRetries := v.GetInt("BROADCAST_STATUS_UPDATE_RETRIES")
+	if broadcastStatusUpdateRetries < 0 {
+		return nil, fmt.Errorf("BROADCAST_STATUS_UPDATE_RETRIES cannot be negative (got %d)", broadcastStatusUpdateRetries)
+	}
+	broadcastStatusUpdateRetryBackoff := v.GetDuration("BROADCAST_STATUS_UPDATE_RETRY_BACKOFF")
+	if broadcastStatusUpdateRetryBackoff < 0 {
+		return nil, fmt.Errorf("BROADCAST_STATUS_UPDATE_RETRY_BACKOFF cannot be negative (got %s)", broadcastStatusUpdateRetryBackoff)
+	}
+	broadcastMaxSendsPerSecond := v.GetInt("BROADCAST_MAX_SENDS_PER_SECOND")
+	if broadcastMaxSendsPerSecond < 0 {
+		return nil, fmt.Errorf("BROADCAST_MAX_SENDS_PER_SECOND cannot be negative (got %d)", broadcastMaxSendsPerSecond)
+	}
+	broadcastRenderTimeout := v.GetDuration("BROADCAST_RENDER_TIMEOUT")
+	if broadcastRenderTimeout < 0 {
+		return nil, fmt.Errorf("BROADCAST_RENDER_TIMEOUT cannot be negative (got %s)", broadcastRenderTimeout)
+	}
+	broadcastBatchRetries := v.GetInt("BROADCAST_BATCH_RETRIES")
+	if broadcastBatchRetries < 0 {
+		return nil, fmt.Errorf("BROADCAST_BATCH_RETRIES cannot be negative (got %d)", broadcastBatchRetries)
+	}
+	broadcastBatchRetryBackoff := v.GetDuration("BROADCAST_BATCH_RETRY_BACKOFF")
+	if broadcastBatchRetryBackoff < 0 {
+		return nil, fmt.Errorf("BROADCAST_BATCH_RETRY_BACKOFF cannot be negative (got %s)", broadcastBatchRetryBackoff)
+	}
+	broadcastAdaptiveBatchMin := v.GetInt("BROADCAST_ADAPTIVE_BATCH_MIN")
+	if broadcastAdaptiveBatchMin < 1 {
+		return nil, fmt.Errorf("BROADCAST_ADAPTIVE_BATCH_MIN must be at least 1 (got %d)", broadcastAdaptiveBatchMin)
+	}
+	broadcastAdaptiveBatchMax := v.GetInt("BROADCAST_ADAPTIVE_BATCH_MAX")
+	if broadcastAdaptiveBatchMax < broadcastAdaptiveBatchMin {
+		return nil, fmt.Errorf("BROADCAST_ADAPTIVE_BATCH_MAX cannot be lower than BROADCAST_ADAPTIVE_BATCH_MIN (got %d < %d)", broadcastAdaptiveBatchMax, broadcastAdaptiveBatchMin)
+	}
 
 	// SECRET_KEY resolution (CRITICAL for decryption and JWT signing)
 	secretKey := v.GetString("SECRET_KEY")
@@ -656,6 +836,11 @@ func LoadWithOptions(opts LoadOptions) (*Config, error) {
 		}
 	}
 
+	// A misconfigured system mailer fails at startup rather than on its first email
+	if err := smtpConfig.Validate(); err != nil {
+		return nil, err
+	}
+
 	// Telemetry and check for updates settings - env var overrides database
 	var telemetryEnabled, checkForUpdates bool
 	if isInstalled && systemSettings != nil {
@@ -691,8 +876,9 @@ func LoadWithOptions(opts LoadOptions) (*Config, error) {
 		SMTP:      smtpConfig,
 		SMTPRelay: smtpRelayConfig,
 		Security: SecurityConfig{
-			JWTSecret: jwtSecret,
-			SecretKey: secretKey,
+			JWTSecret:           jwtSecret,
+			SecretKey:           secretKey,
+			DeriveWorkspaceKeys: v.GetBool("DERIVE_WORKSPACE_KEYS"),
 		},
 		Demo: DemoConfig{
 			FileManagerEndpoint:  v.GetString("DEMO_FILE_MANAGER_ENDPOINT"),
@@ -737,13 +923,42 @@ func LoadWithOptions(opts LoadOptions) (*Config, error) {
 			PrometheusPort:  v.GetInt("TRACING_PROMETHEUS_PORT"),
 		},
 		Broadcast: BroadcastConfig{
-			DefaultRateLimit: v.GetInt("BROADCAST_DEFAULT_RATE_LIMIT"),
+			DefaultRateLimit:         v.GetInt("BROADCAST_DEFAULT_RATE_LIMIT"),
+			StatusUpdateRetries:      broadcastStatusUpdateRetries,
+			StatusUpdateRetryBackoff: broadcastStatusUpdateRetryBackoff,
+			MaxSendsPerSecond:        broadcastMaxSendsPerSecond,
+			RenderTimeout:            broadcastRenderTimeout,
+			BatchRetries:             broadcastBatchRetries,
+			BatchRetryBackoff:        broadcastBatchRetryBackoff,
+			AdaptiveBatchSize:        v.GetBool("BROADCAST_ADAPTIVE_BATCH_SIZE"),
+			AdaptiveBatchMinSize:     broadcastAdaptiveBatchMin,
+			AdaptiveBatchMaxSize:     broadcastAdaptiveBatchMax,
+		},
+		Contacts: ContactsConfig{
+			BulkGetMax:             contactsBulkGetMax,
+			AudienceCountsCacheTTL: contactsAudienceCountsCacheTTL,
 		},
 		TaskScheduler: TaskSchedulerConfig{
 			Enabled:  v.GetBool("TASK_SCHEDULER_ENABLED"),
 			Interval: v.GetDuration("TASK_SCHEDULER_INTERVAL"),
 			MaxTasks: v.GetInt("TASK_SCHEDULER_MAX_TASKS"),
 		},
+		InboundWebhook: InboundWebhookConfig{
+			PayloadRetention:       v.GetDuration("INBOUND_WEBHOOK_PAYLOAD_RETENTION"),
+			IngestionWorkers:       ingestionWorkers,
+			IngestionQueueSize:     ingestionQueueSize,
+			IngestionBatchSize:     ingestionBatchSize,
+			IngestionFlushInterval: ingestionFlushInterval,
+			HealthWindow:           webhookHealthWindow,
+		},
+		WebhookDelivery: WebhookDeliveryConfig{
+			MaxConcurrentPerEndpoint: webhookMaxConcurrentPerEndpoint,
+			MaxQueuedPerEndpoint:     webhookMaxQueuedPerEndpoint,
+		},
+		APIRateLimit: APIRateLimitConfig{
+			RequestsPerSecond: apiRateLimitRPS,
+			Burst:             apiRateLimitBurst,
+		},
 
 		RootEmail:       rootEmail,
 		Environment:     v.GetString("ENVIRONMENT"),
@@ -174,6 +174,7 @@ func TestDatabaseConnectionConfig_Defaults(t *testing.T) {
 	assert.Equal(t, 3, cfg.Database.MaxConnectionsPerDB)
 	assert.Equal(t, 10*time.Minute, cfg.Database.ConnectionMaxLifetime)
 	assert.Equal(t, 5*time.Minute, cfg.Database.ConnectionMaxIdleTime)
+	assert.Equal(t, 4, cfg.Database.MigrationConcurrency)
 }
 
 func TestDatabaseConnectionConfig_CustomValues(t *testing.T) {
@@ -247,6 +248,213 @@ func TestDatabaseConnectionConfig_ValidationPerDBMinimum(t *testing.T) {
 	assert.Contains(t, err.Error(), "DB_MAX_CONNECTIONS_PER_DB must be at least 1")
 }
 
+func TestDatabaseConnectionConfig_MigrationConcurrency(t *testing.T) {
+	_ = os.Setenv("SECRET_KEY", "test-secret-key-for-testing")
+	_ = os.Setenv("DB_PASSWORD", "testpass")
+	defer func() { _ = os.Unsetenv("SECRET_KEY") }()
+	defer func() { _ = os.Unsetenv("DB_PASSWORD") }()
+	defer func() { _ = os.Unsetenv("DB_MIGRATION_CONCURRENCY") }()
+
+	_ = os.Setenv("DB_MIGRATION_CONCURRENCY", "8")
+	cfg, err := LoadWithOptions(LoadOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 8, cfg.Database.MigrationConcurrency)
+
+	_ = os.Setenv("DB_MIGRATION_CONCURRENCY", "0")
+	_, err = LoadWithOptions(LoadOptions{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "DB_MIGRATION_CONCURRENCY must be at least 1")
+
+	_ = os.Setenv("DB_MIGRATION_CONCURRENCY", "51")
+	_, err = LoadWithOptions(LoadOptions{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "DB_MIGRATION_CONCURRENCY cannot exceed 50")
+}
+
+func TestContactsConfig_BulkGetMax(t *testing.T) {
+	_ = os.Setenv("SECRET_KEY", "test-secret-key-for-testing")
+	_ = os.Setenv("DB_PASSWORD", "testpass")
+	defer func() { _ = os.Unsetenv("SECRET_KEY") }()
+	defer func() { _ = os.Unsetenv("DB_PASSWORD") }()
+	defer func() { _ = os.Unsetenv("CONTACTS_BULK_GET_MAX") }()
+
+	cfg, err := LoadWithOptions(LoadOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 500, cfg.Contacts.BulkGetMax)
+
+	_ = os.Setenv("CONTACTS_BULK_GET_MAX", "1000")
+	cfg, err = LoadWithOptions(LoadOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 1000, cfg.Contacts.BulkGetMax)
+
+	_ = os.Setenv("CONTACTS_BULK_GET_MAX", "0")
+	_, err = LoadWithOptions(LoadOptions{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "CONTACTS_BULK_GET_MAX must be at least 1")
+
+	_ = os.Setenv("CONTACTS_BULK_GET_MAX", "5001")
+	_, err = LoadWithOptions(LoadOptions{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "CONTACTS_BULK_GET_MAX cannot exceed 5000")
+}
+
+func TestContactsConfig_AudienceCountsCacheTTL(t *testing.T) {
+	_ = os.Setenv("SECRET_KEY", "test-secret-key-for-testing")
+	_ = os.Setenv("DB_PASSWORD", "testpass")
+	defer func() { _ = os.Unsetenv("SECRET_KEY") }()
+	defer func() { _ = os.Unsetenv("DB_PASSWORD") }()
+	defer func() { _ = os.Unsetenv("CONTACTS_AUDIENCE_COUNTS_CACHE_TTL") }()
+
+	cfg, err := LoadWithOptions(LoadOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, time.Minute, cfg.Contacts.AudienceCountsCacheTTL)
+
+	_ = os.Setenv("CONTACTS_AUDIENCE_COUNTS_CACHE_TTL", "0")
+	cfg, err = LoadWithOptions(LoadOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, time.Duration(0), cfg.Contacts.AudienceCountsCacheTTL)
+
+	_ = os.Setenv("CONTACTS_AUDIENCE_COUNTS_CACHE_TTL", "-1s")
+	_, err = LoadWithOptions(LoadOptions{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "CONTACTS_AUDIENCE_COUNTS_CACHE_TTL cannot be negative")
+}
+
+func TestInboundWebhookConfig_Ingestion(t *testing.T) {
+	_ = os.Setenv("SECRET_KEY", "test-secret-key-for-testing")
+	_ = os.Setenv("DB_PASSWORD", "testpass")
+	defer func() { _ = os.Unsetenv("SECRET_KEY") }()
+	defer func() { _ = os.Unsetenv("DB_PASSWORD") }()
+	defer func() { _ = os.Unsetenv("INBOUND_WEBHOOK_INGESTION_WORKERS") }()
+	defer func() { _ = os.Unsetenv("INBOUND_WEBHOOK_INGESTION_QUEUE_SIZE") }()
+
+	cfg, err := LoadWithOptions(LoadOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 0, cfg.InboundWebhook.IngestionWorkers)
+	assert.Equal(t, 10000, cfg.InboundWebhook.IngestionQueueSize)
+	assert.Equal(t, 500, cfg.InboundWebhook.IngestionBatchSize)
+	assert.Equal(t, 200*time.Millisecond, cfg.InboundWebhook.IngestionFlushInterval)
+
+	_ = os.Setenv("INBOUND_WEBHOOK_INGESTION_WORKERS", "4")
+	cfg, err = LoadWithOptions(LoadOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 4, cfg.InboundWebhook.IngestionWorkers)
+
+	_ = os.Setenv("INBOUND_WEBHOOK_INGESTION_QUEUE_SIZE", "0")
+	_, err = LoadWithOptions(LoadOptions{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "INBOUND_WEBHOOK_INGESTION_QUEUE_SIZE must be at least 1")
+}
+
+func TestInboundWebhookConfig_HealthWindow(t *testing.T) {
+	_ = os.Setenv("SECRET_KEY", "test-secret-key-for-testing")
+	_ = os.Setenv("DB_PASSWORD", "testpass")
+	defer func() { _ = os.Unsetenv("SECRET_KEY") }()
+	defer func() { _ = os.Unsetenv("DB_PASSWORD") }()
+	defer func() { _ = os.Unsetenv("INBOUND_WEBHOOK_HEALTH_WINDOW") }()
+
+	cfg, err := LoadWithOptions(LoadOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 6*time.Hour, cfg.InboundWebhook.HealthWindow)
+
+	_ = os.Setenv("INBOUND_WEBHOOK_HEALTH_WINDOW", "0")
+	cfg, err = LoadWithOptions(LoadOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, time.Duration(0), cfg.InboundWebhook.HealthWindow)
+
+	_ = os.Setenv("INBOUND_WEBHOOK_HEALTH_WINDOW", "-1h")
+	_, err = LoadWithOptions(LoadOptions{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "INBOUND_WEBHOOK_HEALTH_WINDOW cannot be negative")
+}
+
+func TestBroadcastConfig_StatusUpdateRetries(t *testing.T) {
+	_ = os.Setenv("SECRET_KEY", "test-secret-key-for-testing")
+	_ = os.Setenv("DB_PASSWORD", "testpass")
+	defer func() { _ = os.Unsetenv("SECRET_KEY") }()
+	defer func() { _ = os.Unsetenv("DB_PASSWORD") }()
+	defer func() { _ = os.Unsetenv("BROADCAST_STATUS_UPDATE_RETRIES") }()
+	defer func() { _ = os.Unsetenv("BROADCAST_STATUS_UPDATE_RETRY_BACKOFF") }()
+
+	cfg, err := LoadWithOptions(LoadOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 3, cfg.Broadcast.StatusUpdateRetries)
+	assert.Equal(t, 500*time.Millisecond, cfg.Broadcast.StatusUpdateRetryBackoff)
+
+	_ = os.Setenv("BROADCAST_STATUS_UPDATE_RETRIES", "5")
+	_ = os.Setenv("BROADCAST_STATUS_UPDATE_RETRY_BACKOFF", "2s")
+	cfg, err = LoadWithOptions(LoadOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 5, cfg.Broadcast.StatusUpdateRetries)
+	assert.Equal(t, 2*time.Second, cfg.Broadcast.StatusUpdateRetryBackoff)
+
+	_ = os.Setenv("BROADCAST_STATUS_UPDATE_RETRIES", "-1")
+	_, err = LoadWithOptions(LoadOptions{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "BROADCAST_STATUS_UPDATE_RETRIES cannot be negative")
+}
+
+func TestBroadcastConfig_MaxSendsPerSecond(t *testing.T) {
+	_ = os.Setenv("SECRET_KEY", "test-secret-key-for-testing")
+	_ = os.Setenv("DB_PASSWORD", "testpass")
+	defer func() { _ = os.Unsetenv("SECRET_KEY") }()
+	defer func() { _ = os.Unsetenv("DB_PASSWORD") }()
+	defer func() { _ = os.Unsetenv("BROADCAST_MAX_SENDS_PER_SECOND") }()
+
+	cfg, err := LoadWithOptions(LoadOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 0, cfg.Broadcast.MaxSendsPerSecond)
+
+	_ = os.Setenv("BROADCAST_MAX_SENDS_PER_SECOND", "20")
+	cfg, err = LoadWithOptions(LoadOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 20, cfg.Broadcast.MaxSendsPerSecond)
+
+	_ = os.Setenv("BROADCAST_MAX_SENDS_PER_SECOND", "-1")
+	_, err = LoadWithOptions(LoadOptions{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "BROADCAST_MAX_SENDS_PER_SECOND cannot be negative")
+}
+
+func TestBroadcastConfig_RenderTimeout(t *testing.T) {
+	_ = os.Setenv("SECRET_KEY", "test-secret-key-for-testing")
+	_ = os.Setenv("DB_PASSWORD", "testpass")
+	defer func() { _ = os.Unsetenv("SECRET_KEY") }()
+	defer func() { _ = os.Unsetenv("DB_PASSWORD") }()
+	defer func() { _ = os.Unsetenv("BROADCAST_RENDER_TIMEOUT") }()
+
+	cfg, err := LoadWithOptions(LoadOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 10*time.Second, cfg.Broadcast.RenderTimeout)
+
+	_ = os.Setenv("BROADCAST_RENDER_TIMEOUT", "2s")
+	cfg, err = LoadWithOptions(LoadOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 2*time.Second, cfg.Broadcast.RenderTimeout)
+
+	_ = os.Setenv("BROADCAST_RENDER_TIMEOUT", "-1s")
+	_, err = LoadWithOptions(LoadOptions{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "BROADCAST_RENDER_TIMEOUT cannot be negative")
+}
+
+func TestSecurityConfig_DeriveWorkspaceKeys(t *testing.T) {
+	_ = os.Setenv("SECRET_KEY", "test-secret-key-for-testing")
+	_ = os.Setenv("DB_PASSWORD", "testpass")
+	defer func() { _ = os.Unsetenv("SECRET_KEY") }()
+	defer func() { _ = os.Unsetenv("DB_PASSWORD") }()
+	defer func() { _ = os.Unsetenv("DERIVE_WORKSPACE_KEYS") }()
+
+	cfg, err := LoadWithOptions(LoadOptions{})
+	require.NoError(t, err)
+	assert.True(t, cfg.Security.DeriveWorkspaceKeys)
+
+	_ = os.Setenv("DERIVE_WORKSPACE_KEYS", "false")
+	cfg, err = LoadWithOptions(LoadOptions{})
+	require.NoError(t, err)
+	assert.False(t, cfg.Security.DeriveWorkspaceKeys)
+}
+
 func TestDatabaseConnectionConfig_ValidationPerDBMaximum(t *testing.T) {
 	// Test that MaxConnectionsPerDB above maximum fails
 	_ = os.Setenv("SECRET_KEY", "test-secret-key-for-testing")
@@ -261,3 +469,177 @@ func TestDatabaseConnectionConfig_ValidationPerDBMaximum(t *testing.T) {
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "DB_MAX_CONNECTIONS_PER_DB cannot exceed 50")
 }
+
+func TestBroadcastConfig_BatchRetries(t *testing.T) {
+	_ = os.Setenv("SECRET_KEY", "test-secret-key-for-testing")
+	_ = os.Setenv("DB_PASSWORD", "testpass")
+	defer func() { _ = os.Unsetenv("SECRET_KEY") }()
+	defer func() { _ = os.Unsetenv("DB_PASSWORD") }()
+	defer func() { _ = os.Unsetenv("BROADCAST_BATCH_RETRIES") }()
+	defer func() { _ = os.Unsetenv("BROADCAST_BATCH_RETRY_BACKOFF") }()
+
+	cfg, err := LoadWithOptions(LoadOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 3, cfg.Broadcast.BatchRetries)
+	assert.Equal(t, time.Second, cfg.Broadcast.BatchRetryBackoff)
+
+	_ = os.Setenv("BROADCAST_BATCH_RETRIES", "0")
+	_ = os.Setenv("BROADCAST_BATCH_RETRY_BACKOFF", "250ms")
+	cfg, err = LoadWithOptions(LoadOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 0, cfg.Broadcast.BatchRetries)
+	assert.Equal(t, 250*time.Millisecond, cfg.Broadcast.BatchRetryBackoff)
+
+	_ = os.Setenv("BROADCAST_BATCH_RETRY_BACKOFF", "-1s")
+	_, err = LoadWithOptions(LoadOptions{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "BROADCAST_BATCH_RETRY_BACKOFF cannot be negative")
+}
+
+func TestBroadcastConfig_AdaptiveBatchSize(t *testing.T) {
+	_ = os.Setenv("SECRET_KEY", "test-secret-key-for-testing")
+	_ = os.Setenv("DB_PASSWORD", "testpass")
+	defer func() { _ = os.Unsetenv("SECRET_KEY") }()
+	defer func() { _ = os.Unsetenv("DB_PASSWORD") }()
+	defer func() { _ = os.Unsetenv("BROADCAST_ADAPTIVE_BATCH_SIZE") }()
+	defer func() { _ = os.Unsetenv("BROADCAST_ADAPTIVE_BATCH_MIN") }()
+	defer func() { _ = os.Unsetenv("BROADCAST_ADAPTIVE_BATCH_MAX") }()
+
+	cfg, err := LoadWithOptions(LoadOptions{})
+	require.NoError(t, err)
+	assert.False(t, cfg.Broadcast.AdaptiveBatchSize)
+	assert.Equal(t, 10, cfg.Broadcast.AdaptiveBatchMinSize)
+	assert.Equal(t, 500, cfg.Broadcast.AdaptiveBatchMaxSize)
+
+	_ = os.Setenv("BROADCAST_ADAPTIVE_BATCH_SIZE", "true")
+	_ = os.Setenv("BROADCAST_ADAPTIVE_BATCH_MIN", "50")
+	_ = os.Setenv("BROADCAST_ADAPTIVE_BATCH_MAX", "2000")
+	cfg, err = LoadWithOptions(LoadOptions{})
+	require.NoError(t, err)
+	assert.True(t, cfg.Broadcast.AdaptiveBatchSize)
+	assert.Equal(t, 50, cfg.Broadcast.AdaptiveBatchMinSize)
+	assert.Equal(t, 2000, cfg.Broadcast.AdaptiveBatchMaxSize)
+
+	_ = os.Setenv("BROADCAST_ADAPTIVE_BATCH_MAX", "20")
+	_, err = LoadWithOptions(LoadOptions{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "BROADCAST_ADAPTIVE_BATCH_MAX cannot be lower than BROADCAST_ADAPTIVE_BATCH_MIN")
+
+	_ = os.Setenv("BROADCAST_ADAPTIVE_BATCH_MIN", "0")
+	_, err = LoadWithOptions(LoadOptions{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "BROADCAST_ADAPTIVE_BATCH_MIN must be at least 1")
+}
+
+func TestWebhookDeliveryConfig_EndpointLimits(t *testing.T) {
+	_ = os.Setenv("SECRET_KEY", "test-secret-key-for-testing")
+	_ = os.Setenv("DB_PASSWORD", "testpass")
+	defer func() { _ = os.Unsetenv("SECRET_KEY") }()
+	defer func() { _ = os.Unsetenv("DB_PASSWORD") }()
+	defer func() { _ = os.Unsetenv("WEBHOOK_DELIVERY_MAX_CONCURRENT_PER_ENDPOINT") }()
+	defer func() { _ = os.Unsetenv("WEBHOOK_DELIVERY_MAX_QUEUED_PER_ENDPOINT") }()
+
+	cfg, err := LoadWithOptions(LoadOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 4, cfg.WebhookDelivery.MaxConcurrentPerEndpoint)
+	assert.Equal(t, 100, cfg.WebhookDelivery.MaxQueuedPerEndpoint)
+
+	_ = os.Setenv("WEBHOOK_DELIVERY_MAX_CONCURRENT_PER_ENDPOINT", "1")
+	_ = os.Setenv("WEBHOOK_DELIVERY_MAX_QUEUED_PER_ENDPOINT", "0")
+	cfg, err = LoadWithOptions(LoadOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, cfg.WebhookDelivery.MaxConcurrentPerEndpoint)
+	assert.Equal(t, 0, cfg.WebhookDelivery.MaxQueuedPerEndpoint)
+
+	_ = os.Setenv("WEBHOOK_DELIVERY_MAX_CONCURRENT_PER_ENDPOINT", "0")
+	_, err = LoadWithOptions(LoadOptions{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "WEBHOOK_DELIVERY_MAX_CONCURRENT_PER_ENDPOINT must be at least 1")
+}
+
+func TestAPIRateLimitConfig(t *testing.T) {
+	_ = os.Setenv("SECRET_KEY", "test-secret-key-for-testing")
+	_ = os.Setenv("DB_PASSWORD", "testpass")
+	defer func() { _ = os.Unsetenv("SECRET_KEY") }()
+	defer func() { _ = os.Unsetenv("DB_PASSWORD") }()
+	defer func() { _ = os.Unsetenv("API_RATE_LIMIT_RPS") }()
+	defer func() { _ = os.Unsetenv("API_RATE_LIMIT_BURST") }()
+
+	cfg, err := LoadWithOptions(LoadOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, float64(0), cfg.APIRateLimit.RequestsPerSecond)
+	assert.Equal(t, 50, cfg.APIRateLimit.Burst)
+
+	_ = os.Setenv("API_RATE_LIMIT_RPS", "2.5")
+	_ = os.Setenv("API_RATE_LIMIT_BURST", "10")
+	cfg, err = LoadWithOptions(LoadOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, 2.5, cfg.APIRateLimit.RequestsPerSecond)
+	assert.Equal(t, 10, cfg.APIRateLimit.Burst)
+
+	_ = os.Setenv("API_RATE_LIMIT_BURST", "0")
+	_, err = LoadWithOptions(LoadOptions{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "API_RATE_LIMIT_BURST must be at least 1")
+
+	_ = os.Setenv("API_RATE_LIMIT_BURST", "10")
+	_ = os.Setenv("API_RATE_LIMIT_RPS", "-1")
+	_, err = LoadWithOptions(LoadOptions{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "API_RATE_LIMIT_RPS cannot be negative")
+}
+
+func TestSMTPConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  SMTPConfig
+		wantErr string
+	}{
+		{name: "not configured", config: SMTPConfig{Port: 587, FromName: "Notifuse", UseTLS: true}},
+		{name: "configured", config: SMTPConfig{Host: "smtp.example.com", Port: 587, Username: "user", FromEmail: "noreply@example.com"}},
+		{name: "missing host", config: SMTPConfig{Port: 587, Username: "user", Password: "pass"}, wantErr: "SMTP_HOST is required"},
+		{name: "missing port", config: SMTPConfig{Host: "smtp.example.com"}, wantErr: "SMTP_PORT must be between 1 and 65535 (got 0)"},
+		{name: "port out of range", config: SMTPConfig{Host: "smtp.example.com", Port: 70000}, wantErr: "SMTP_PORT must be between 1 and 65535 (got 70000)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "SMTP provider is misconfigured")
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestLoad_MisconfiguredSMTP(t *testing.T) {
+	_ = os.Setenv("SECRET_KEY", "test-secret-key-for-testing")
+	_ = os.Setenv("DB_PASSWORD", "testpass")
+	defer func() { _ = os.Unsetenv("SECRET_KEY") }()
+	defer func() { _ = os.Unsetenv("DB_PASSWORD") }()
+	defer func() { _ = os.Unsetenv("SMTP_USERNAME") }()
+	defer func() { _ = os.Unsetenv("SMTP_HOST") }()
+	defer func() { _ = os.Unsetenv("SMTP_PORT") }()
+
+	// Credentials without a host fail at startup
+	_ = os.Setenv("SMTP_USERNAME", "user")
+	_, err := LoadWithOptions(LoadOptions{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "SMTP_HOST is required")
+
+	_ = os.Setenv("SMTP_HOST", "smtp.example.com")
+	_ = os.Setenv("SMTP_PORT", "70000")
+	_, err = LoadWithOptions(LoadOptions{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "SMTP_PORT must be between 1 and 65535")
+
+	_ = os.Setenv("SMTP_PORT", "465")
+	cfg, err := LoadWithOptions(LoadOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "smtp.example.com", cfg.SMTP.Host)
+	assert.Equal(t, 465, cfg.SMTP.Port)
+}
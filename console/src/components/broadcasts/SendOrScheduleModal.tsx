@@ -80,6 +80,12 @@ export function SendOrScheduleModal({
     setIsScheduled(false)
   }
 
+  // Run the preflight to get the confirmation token required by workspaces with send confirmation
+  const getConfirmationToken = async (broadcastId: string) => {
+    const { preflight } = await broadcastApi.preflight({ workspace_id: workspaceId, id: broadcastId })
+    return preflight.confirmation_token
+  }
+
   // Send broadcast immediately
   const handleSendNow = async () => {
     if (!broadcast) return
@@ -89,7 +95,8 @@ export function SendOrScheduleModal({
       await broadcastApi.schedule({
         workspace_id: workspaceId,
         id: broadcast.id,
-        send_now: true
+        send_now: true,
+        confirmation_token: await getConfirmationToken(broadcast.id)
       })
       message.success(`Broadcast "${broadcast.name}" sending started`)
       onSuccess()
@@ -136,7 +143,8 @@ export function SendOrScheduleModal({
           scheduled_date: scheduledDate,
           scheduled_time: scheduledTime,
           timezone: values.timezone,
-          use_recipient_timezone: values.use_recipient_timezone
+          use_recipient_timezone: values.use_recipient_timezone,
+          confirmation_token: await getConfirmationToken(broadcast.id)
         })
 
         message.success(`Broadcast "${broadcast.name}" scheduled successfully`)
@@ -48,7 +48,9 @@ const ABTestingConfig = ({ form }: { form: ReturnType<typeof Form.useForm>[0] })
           <Select
             options={[
               { value: 'open_rate', label: 'Open Rate' },
-              { value: 'click_rate', label: 'Click Rate' }
+              { value: 'click_rate', label: 'Click Rate' },
+              { value: 'click_to_open_rate', label: 'Click-to-Open Rate' },
+              { value: 'lowest_unsubscribe_rate', label: 'Lowest Unsubscribe Rate' }
             ]}
           />
         </Form.Item>
@@ -62,6 +64,15 @@ const ABTestingConfig = ({ form }: { form: ReturnType<typeof Form.useForm>[0] })
           <InputNumber min={1} />
         </Form.Item>
       </Col>
+      <Col span={12}>
+        <Form.Item
+          name={['test_settings', 'min_confidence']}
+          label="Minimum confidence"
+          tooltip="When set, the winner is only selected automatically if its lead over the runner-up is statistically significant at this confidence (e.g. 0.95). Otherwise you pick the winner manually."
+        >
+          <InputNumber min={0} max={0.99} step={0.01} placeholder="0.95" />
+        </Form.Item>
+      </Col>
     </Row>
   )
 }
@@ -110,6 +110,13 @@ export const ContactsTableSchema: TableSchema = {
       type: 'string',
       shown: true
     },
+    engagement_score: {
+      name: 'engagement_score',
+      title: 'Engagement Score',
+      description: 'Opens (1) and clicks (3) of the last 180 days, halved every 30 days',
+      type: 'number',
+      shown: true
+    },
     // Custom string fields
     custom_string_1: {
       name: 'custom_string_1',
@@ -1505,6 +1505,15 @@ export function Integrations({ workspace, onSave, loading, isOwner }: Integratio
             <Form.Item name={['ses', 'secret_key']} label="AWS Secret Key">
               <Input.Password placeholder="Secret Key" disabled={!isOwner} />
             </Form.Item>
+            <Form.Item
+              name={['ses', 'verify_webhook_signature']}
+              valuePropName="checked"
+              label="Verify Webhook Signatures"
+              tooltip="Reject SNS notifications whose signature does not match the AWS signing certificate"
+              initialValue={false}
+            >
+              <Switch disabled={!isOwner} />
+            </Form.Item>
           </>
         )}
 
@@ -1544,6 +1553,13 @@ export function Integrations({ workspace, onSave, loading, isOwner }: Integratio
                 </Form.Item>
               </Col>
             </Row>
+            <Form.Item
+              name={['smtp', 'max_connections']}
+              label="Max Connections"
+              tooltip="Connections kept open and reused to send emails, leave empty to open a connection per email"
+            >
+              <InputNumber min={0} max={100} placeholder="0" disabled={!isOwner} />
+            </Form.Item>
           </>
         )}
 
@@ -1578,13 +1594,31 @@ export function Integrations({ workspace, onSave, loading, isOwner }: Integratio
         )}
 
         {providerType === 'postmark' && (
-          <Form.Item
-            name={['postmark', 'server_token']}
-            label="Server Token"
-            rules={[{ required: true }]}
-          >
-            <Input.Password placeholder="Server Token" disabled={!isOwner} />
-          </Form.Item>
+          <>
+            <Form.Item
+              name={['postmark', 'server_token']}
+              label="Server Token"
+              rules={[{ required: true }]}
+            >
+              <Input.Password placeholder="Server Token" disabled={!isOwner} />
+            </Form.Item>
+            <Row gutter={16}>
+              <Col span={12}>
+                <Form.Item
+                  name={['postmark', 'webhook_username']}
+                  label="Webhook Username"
+                  tooltip="Basic auth credentials sent by Postmark with webhook events, events without them are rejected"
+                >
+                  <Input placeholder="Username (optional)" disabled={!isOwner} />
+                </Form.Item>
+              </Col>
+              <Col span={12}>
+                <Form.Item name={['postmark', 'webhook_password']} label="Webhook Password">
+                  <Input.Password placeholder="Password (optional)" disabled={!isOwner} />
+                </Form.Item>
+              </Col>
+            </Row>
+          </>
         )}
 
         {providerType === 'mailgun' && (
@@ -1595,6 +1629,13 @@ export function Integrations({ workspace, onSave, loading, isOwner }: Integratio
             <Form.Item name={['mailgun', 'api_key']} label="API Key" rules={[{ required: true }]}>
               <Input.Password placeholder="API Key" disabled={!isOwner} />
             </Form.Item>
+            <Form.Item
+              name={['mailgun', 'webhook_signing_key']}
+              label="Webhook Signing Key"
+              tooltip="Verifies the signature of Mailgun webhook events, events with a missing or invalid signature are rejected"
+            >
+              <Input.Password placeholder="Webhook Signing Key (optional)" disabled={!isOwner} />
+            </Form.Item>
             <Form.Item name={['mailgun', 'region']} label="Region" initialValue="US">
               <Select
                 placeholder="Select Mailgun Region"
@@ -1763,6 +1804,9 @@ export function Integrations({ workspace, onSave, loading, isOwner }: Integratio
         </Descriptions.Item>,
         <Descriptions.Item key="tls" label="TLS Enabled">
           {provider.smtp.use_tls ? 'Yes' : 'No'}
+        </Descriptions.Item>,
+        <Descriptions.Item key="max_connections" label="Max Connections">
+          {provider.smtp.max_connections || 'One per email'}
         </Descriptions.Item>
       )
     } else if (provider.kind === 'ses' && provider.ses) {
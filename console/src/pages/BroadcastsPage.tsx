@@ -102,6 +102,17 @@ const getStatusBadge = (broadcast: Broadcast, remainingTime?: string | null) =>
       return <Badge status="default" text="Draft" />
     case 'scheduled':
       return <Badge status="processing" text="Scheduled" />
+    case 'starting_soon':
+      return (
+        <Badge
+          status="warning"
+          text={
+            broadcast.schedule.cool_off_until
+              ? `Starting ${dayjs(broadcast.schedule.cool_off_until).fromNow()}`
+              : 'Starting soon'
+          }
+        />
+      )
     case 'processing':
       return <Badge status="processing" text="Processing" />
     case 'paused':
@@ -119,6 +130,12 @@ const getStatusBadge = (broadcast: Broadcast, remainingTime?: string | null) =>
           )}
         </Space>
       )
+    case 'quota_exceeded':
+      return (
+        <Tooltip title="The workspace daily send quota is reached, sending resumes the next day (UTC)">
+          <Badge status="warning" text="Quota exceeded" />
+        </Tooltip>
+      )
     case 'processed':
       return <Badge status="success" text="Processed" />
     case 'cancelled':
@@ -140,6 +157,12 @@ const getStatusBadge = (broadcast: Broadcast, remainingTime?: string | null) =>
       return <Badge status="success" text="Test Completed" />
     case 'winner_selected':
       return <Badge status="success" text="Winner Selected" />
+    case 'seed_pending':
+      return (
+        <Tooltip title="The seed test was sent, the broadcast is sent to its audience once the seed test is approved">
+          <Badge status="warning" text="Seed Test Pending" />
+        </Tooltip>
+      )
     default:
       return <Badge status="default" text={broadcast.status} />
   }
@@ -198,7 +221,7 @@ const BroadcastCard: React.FC<BroadcastCardProps> = ({
     refetchInterval:
       broadcast.status === 'processing'
         ? 5000 // Refetch every 5 seconds for processing broadcasts
-        : broadcast.status === 'scheduled'
+        : broadcast.status === 'scheduled' || broadcast.status === 'starting_soon'
           ? 30000 // Refetch every 30 seconds for scheduled broadcasts
           : false // Don't auto-refetch for other statuses
   })
@@ -461,7 +484,9 @@ const BroadcastCard: React.FC<BroadcastCardProps> = ({
               </Popconfirm>
             </Tooltip>
           )}
-          {broadcast.status === 'scheduled' && (
+          {(broadcast.status === 'scheduled' ||
+            broadcast.status === 'starting_soon' ||
+            broadcast.status === 'quota_exceeded') && (
             <Tooltip
               title={
                 !permissions?.broadcasts?.write
@@ -1098,17 +1123,29 @@ export function BroadcastsPage() {
     }
   }
 
-  const handleResumeBroadcast = async (broadcast: Broadcast) => {
+  const handleResumeBroadcast = async (broadcast: Broadcast, acknowledgeTemplateChanges = false) => {
     try {
       await broadcastApi.resume({
         workspace_id: workspaceId,
-        id: broadcast.id
+        id: broadcast.id,
+        acknowledge_template_changes: acknowledgeTemplateChanges
       })
       message.success(`Broadcast "${broadcast.name}" resumed successfully`)
       queryClient.invalidateQueries({
         queryKey: ['broadcasts', workspaceId, currentPage, pageSize]
       })
     } catch (error) {
+      // Templates edited while paused require an explicit confirmation
+      if ((error as { status?: number }).status === 409 && !acknowledgeTemplateChanges) {
+        Modal.confirm({
+          title: 'Templates changed since sending started',
+          content:
+            'Recipients not reached yet will receive the edited content. Resume the broadcast anyway?',
+          okText: 'Resume',
+          onOk: () => handleResumeBroadcast(broadcast, true)
+        })
+        return
+      }
       message.error('Failed to resume broadcast')
       console.error(error)
     }
@@ -0,0 +1,47 @@
+import { api } from './client'
+
+// Scopes are formatted as "<resource>:<action>", e.g. contacts:write, broadcasts:read or transactional:send
+export type APIKeyScope = string
+
+export interface APIKey {
+  id: string
+  workspace_id: string
+  name: string
+  key_prefix: string
+  scopes: APIKeyScope[]
+  user_id: string
+  created_by: string
+  created_at: string
+  revoked_at?: string
+}
+
+export interface CreateScopedAPIKeyRequest {
+  workspace_id: string
+  name: string
+  scopes: APIKeyScope[]
+}
+
+export interface CreateScopedAPIKeyResponse {
+  api_key: APIKey
+  // The key is only returned once, at creation
+  key: string
+}
+
+export const apiKeyApi = {
+  create: async (params: CreateScopedAPIKeyRequest): Promise<CreateScopedAPIKeyResponse> => {
+    return api.post('/api/apiKeys.create', params)
+  },
+
+  list: async (workspaceId: string): Promise<{ api_keys: APIKey[] }> => {
+    const searchParams = new URLSearchParams()
+    searchParams.append('workspace_id', workspaceId)
+    return api.get<{ api_keys: APIKey[] }>(`/api/apiKeys.list?${searchParams.toString()}`)
+  },
+
+  revoke: async (workspaceId: string, id: string): Promise<{ success: boolean }> => {
+    return api.post('/api/apiKeys.revoke', {
+      workspace_id: workspaceId,
+      id
+    })
+  }
+}
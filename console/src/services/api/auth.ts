@@ -43,9 +43,19 @@ export interface LogoutResponse {
   message: string
 }
 
+export interface RefreshTokenResponse {
+  token: string
+  user: {
+    id: string
+    email: string
+  }
+  expires_at: string
+}
+
 export const authService = {
   signIn: (data: SignInRequest) => api.post<SignInResponse>('/api/user.signin', data),
   verifyCode: (data: VerifyCodeRequest) => api.post<VerifyResponse>('/api/user.verify', data),
   getCurrentUser: () => api.get<GetCurrentUserResponse>('/api/user.me'),
-  logout: () => api.post<LogoutResponse>('/api/user.logout', {})
+  logout: () => api.post<LogoutResponse>('/api/user.logout', {}),
+  refreshToken: () => api.post<RefreshTokenResponse>('/api/user.refresh', {})
 }
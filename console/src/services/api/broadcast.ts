@@ -1,4 +1,5 @@
 import { api } from './client'
+import type { MessageHistoryStatusSum } from './messages_history'
 
 export interface UTMParameters {
   source?: string
@@ -55,6 +56,7 @@ export interface BroadcastVariation {
   variation_name: string
   template_id: string
   metrics?: VariationMetrics
+  template_version?: number // Template version pinned when sending started
   template?: Template // Template joined from server when with_templates is true
 }
 
@@ -62,15 +64,40 @@ export interface BroadcastTestSettings {
   enabled: boolean
   sample_percentage: number
   auto_send_winner: boolean
-  auto_send_winner_metric?: 'open_rate' | 'click_rate'
+  auto_send_winner_metric?:
+    | 'open_rate'
+    | 'click_rate'
+    | 'click_to_open_rate'
+    | 'lowest_unsubscribe_rate'
   test_duration_hours?: number
+  min_confidence?: number // e.g. 0.95, the winner is only auto-selected when its lead is significant
   variations: BroadcastVariation[]
 }
 
+export interface SeedTestResult {
+  email: string
+  mailbox_provider: string // Domain of the seed address, e.g. gmail.com
+  status: 'sent' | 'failed'
+}
+
+export interface BroadcastSeedTest {
+  enabled: boolean
+  emails?: string[]
+  require_approval: boolean // Hold the main send in seed_pending until approved
+  sent_at?: string
+  approved_at?: string
+  results?: SeedTestResult[]
+}
+
 export interface AudienceSettings {
   list?: string
+  lists?: string[] // In priority order, a contact in several lists is sent once
+  list_templates?: Record<string, string> // List ID to template ID overrides
   segments?: string[]
   exclude_unsubscribed: boolean
+  csv?: boolean
+  min_hours_since_last_message?: number
+  optimize_send_time?: boolean
 }
 
 export interface ScheduleSettings {
@@ -79,19 +106,26 @@ export interface ScheduleSettings {
   scheduled_time?: string // Format: HH:mm
   timezone?: string // IANA timezone format, e.g. "America/New_York"
   use_recipient_timezone: boolean
+  ignore_quiet_hours?: boolean
+  send_cutoff_at?: string // RFC3339: recipients not sent to by then are skipped
+  max_sends_per_second?: number // Send rate cap of this broadcast, 0 uses the instance default
+  cool_off_until?: string // RFC3339: when a broadcast starting soon begins sending
 }
 
 export type BroadcastStatus =
   | 'draft'
   | 'scheduled'
+  | 'starting_soon'
   | 'processing'
   | 'paused'
+  | 'quota_exceeded'
   | 'processed'
   | 'cancelled'
   | 'failed'
   | 'testing'
   | 'test_completed'
   | 'winner_selected'
+  | 'seed_pending'
 
 export interface BroadcastChannels {
   email: boolean
@@ -106,14 +140,19 @@ export interface Broadcast {
   audience: AudienceSettings
   schedule: ScheduleSettings
   test_settings: BroadcastTestSettings
+  seed_test?: BroadcastSeedTest
   utm_parameters?: UTMParameters
   metadata?: Record<string, unknown>
+  tags?: string[]
+  dry_run?: boolean
+  plain_text_only?: boolean
   channels?: BroadcastChannels // Legacy/frontend-only field
   winning_template?: string
   test_sent_at?: string
   winner_sent_at?: string
   test_phase_recipient_count: number
   winner_phase_recipient_count: number
+  skipped_count?: number
   created_at: string
   updated_at: string
   started_at?: string
@@ -129,9 +168,14 @@ export interface CreateBroadcastRequest {
   audience: AudienceSettings
   schedule: ScheduleSettings
   test_settings: BroadcastTestSettings
+  seed_test?: BroadcastSeedTest
   tracking_enabled?: boolean
   utm_parameters?: UTMParameters
   metadata?: Record<string, unknown>
+  tags?: string[]
+  dry_run?: boolean
+  plain_text_only?: boolean
+  channel_type?: 'email' | 'sms' // defaults to email
 }
 
 export interface UpdateBroadcastRequest {
@@ -141,22 +185,63 @@ export interface UpdateBroadcastRequest {
   audience: AudienceSettings
   schedule: ScheduleSettings
   test_settings: BroadcastTestSettings
+  seed_test?: BroadcastSeedTest
   tracking_enabled?: boolean
   utm_parameters?: UTMParameters
   metadata?: Record<string, unknown>
+  tags?: string[]
+  dry_run?: boolean
+  plain_text_only?: boolean
+  channel_type?: 'email' | 'sms' // defaults to email
 }
 
 export interface ListBroadcastsRequest {
   workspace_id: string
   status?: BroadcastStatus
+  tag?: string
+  created_after?: string // RFC3339
+  created_before?: string // RFC3339
+  completed_after?: string // RFC3339
+  completed_before?: string // RFC3339
+  search?: string
   limit?: number
   offset?: number
+  cursor?: string
   with_templates?: boolean
 }
 
 export interface ListBroadcastsResponse {
   broadcasts: Broadcast[]
   total_count: number
+  next_cursor?: string
+}
+
+export interface BroadcastTagCount {
+  tag: string
+  count: number
+}
+
+export interface SetBroadcastTagsRequest {
+  workspace_id: string
+  id: string
+  tags: string[]
+}
+
+export interface DeleteBroadcastTagRequest {
+  workspace_id: string
+  tag: string
+}
+
+export interface UploadBroadcastAudienceRequest {
+  workspace_id: string
+  broadcast_id: string
+  csv: string
+  upsert_contacts?: boolean
+}
+
+export interface UploadBroadcastAudienceResponse {
+  recipients: number
+  upserted_contacts: number
 }
 
 export interface GetBroadcastRequest {
@@ -177,6 +262,10 @@ export interface ScheduleBroadcastRequest {
   scheduled_time?: string
   timezone?: string
   use_recipient_timezone?: boolean
+  ignore_quiet_hours?: boolean
+  send_cutoff_at?: string
+  max_sends_per_second?: number
+  confirmation_token?: string // From the preflight, when the workspace requires send confirmation
 }
 
 export interface PauseBroadcastRequest {
@@ -187,6 +276,13 @@ export interface PauseBroadcastRequest {
 export interface ResumeBroadcastRequest {
   workspace_id: string
   id: string
+  acknowledge_template_changes?: boolean
+}
+
+export interface BroadcastTemplateChange {
+  template_id: string
+  pinned_version: number
+  current_version: number
 }
 
 export interface CancelBroadcastRequest {
@@ -211,12 +307,68 @@ export interface GetTestResultsRequest {
   id: string
 }
 
+export interface BroadcastPreflightRequest {
+  workspace_id: string
+  id: string
+}
+
+export interface AudiencePreflight {
+  raw_count: number
+  deliverable_count: number
+  deliverable_ratio: number
+  min_ratio: number
+  below_threshold: boolean
+  blocking: boolean
+  confirmation_token?: string
+  confirmation_expires_at?: string
+}
+
+export interface GetBroadcastStatsRequest {
+  workspace_id: string
+  id: string
+}
+
+// Rates are relative to the messages sent
+export interface BroadcastStatsRates {
+  delivery_rate: number
+  open_rate: number
+  click_rate: number
+  bounce_rate: number
+}
+
+export interface BroadcastStats {
+  broadcast_id: string
+  stats: MessageHistoryStatusSum
+  rates: BroadcastStatsRates
+}
+
+export interface BroadcastProgressRequest {
+  workspace_id: string
+  id: string
+}
+
+export interface BroadcastProgress {
+  broadcast_id: string
+  processed: number
+  total: number
+  percent: number
+  sent: number
+  failed: number
+  eta_seconds?: number
+  message: string
+}
+
 export interface SelectWinnerRequest {
   workspace_id: string
   id: string
   template_id: string
 }
 
+export interface ApproveSeedTestRequest {
+  workspace_id: string
+  id: string
+}
+
 export interface VariationResult {
   template_id: string
   template_name: string
@@ -244,8 +396,15 @@ export const broadcastApi = {
     const searchParams = new URLSearchParams()
     searchParams.append('workspace_id', params.workspace_id)
     if (params.status) searchParams.append('status', params.status)
+    if (params.tag) searchParams.append('tag', params.tag)
+    if (params.created_after) searchParams.append('created_after', params.created_after)
+    if (params.created_before) searchParams.append('created_before', params.created_before)
+    if (params.completed_after) searchParams.append('completed_after', params.completed_after)
+    if (params.completed_before) searchParams.append('completed_before', params.completed_before)
+    if (params.search) searchParams.append('search', params.search)
     if (params.limit) searchParams.append('limit', params.limit.toString())
     if (params.offset) searchParams.append('offset', params.offset.toString())
+    if (params.cursor) searchParams.append('cursor', params.cursor)
     if (params.with_templates !== undefined)
       searchParams.append('with_templates', params.with_templates.toString())
 
@@ -294,6 +453,27 @@ export const broadcastApi = {
     return api.post<{ success: boolean }>('/api/broadcasts.delete', params)
   },
 
+  listTags: async (workspaceId: string): Promise<{ tags: BroadcastTagCount[] }> => {
+    const searchParams = new URLSearchParams()
+    searchParams.append('workspace_id', workspaceId)
+
+    return api.get<{ tags: BroadcastTagCount[] }>(`/api/broadcasts.tags?${searchParams.toString()}`)
+  },
+
+  setTags: async (params: SetBroadcastTagsRequest): Promise<GetBroadcastResponse> => {
+    return api.post<GetBroadcastResponse>('/api/broadcasts.setTags', params)
+  },
+
+  deleteTag: async (params: DeleteBroadcastTagRequest): Promise<{ success: boolean }> => {
+    return api.post<{ success: boolean }>('/api/broadcasts.deleteTag', params)
+  },
+
+  uploadAudience: async (
+    params: UploadBroadcastAudienceRequest
+  ): Promise<UploadBroadcastAudienceResponse> => {
+    return api.post<UploadBroadcastAudienceResponse>('/api/broadcasts.uploadAudience', params)
+  },
+
   getTestResults: async (params: GetTestResultsRequest): Promise<TestResultsResponse> => {
     const searchParams = new URLSearchParams()
     searchParams.append('workspace_id', params.workspace_id)
@@ -302,7 +482,101 @@ export const broadcastApi = {
     return api.get<TestResultsResponse>(`/api/broadcasts.getTestResults?${searchParams.toString()}`)
   },
 
+  /**
+   * Stream the progress of a sending broadcast over Server-Sent Events until the signal aborts
+   * or the server ends the stream
+   */
+  streamProgress: async (
+    params: BroadcastProgressRequest,
+    onProgress: (progress: BroadcastProgress) => void,
+    signal?: AbortSignal
+  ): Promise<void> => {
+    const authToken = localStorage.getItem('auth_token')
+
+    let defaultOrigin = window.location.origin
+    if (defaultOrigin.includes('notifusedev.com')) {
+      defaultOrigin = 'https://localapi.notifuse.com:4000'
+    }
+    const apiEndpoint = window.API_ENDPOINT?.trim() || defaultOrigin
+
+    const searchParams = new URLSearchParams()
+    searchParams.append('workspace_id', params.workspace_id)
+    searchParams.append('id', params.id)
+
+    try {
+      const response = await fetch(
+        `${apiEndpoint}/api/broadcasts.progress?${searchParams.toString()}`,
+        {
+          headers: authToken ? { Authorization: `Bearer ${authToken}` } : {},
+          signal
+        }
+      )
+
+      if (!response.ok) {
+        const errorData = await response.json().catch(() => null)
+        throw new Error(errorData?.error || `HTTP error: ${response.status}`)
+      }
+
+      if (!response.body) {
+        throw new Error('No response body')
+      }
+
+      const reader = response.body.getReader()
+      const decoder = new TextDecoder()
+      let buffer = ''
+
+      try {
+        while (true) {
+          const { done, value } = await reader.read()
+          if (done) break
+
+          buffer += decoder.decode(value, { stream: true })
+          const lines = buffer.split('\n')
+          buffer = lines.pop() || ''
+
+          for (const line of lines) {
+            if (line.startsWith('data: ')) {
+              try {
+                onProgress(JSON.parse(line.slice(6)))
+              } catch {
+                // Ignore JSON parse errors for incomplete data
+              }
+            }
+          }
+        }
+      } finally {
+        reader.releaseLock()
+      }
+    } catch (error) {
+      // Aborting is how the caller stops following the broadcast
+      if (error instanceof Error && error.name === 'AbortError') {
+        return
+      }
+      throw error
+    }
+  },
+
+  preflight: async (params: BroadcastPreflightRequest): Promise<{ preflight: AudiencePreflight }> => {
+    const searchParams = new URLSearchParams()
+    searchParams.append('workspace_id', params.workspace_id)
+    searchParams.append('id', params.id)
+
+    return api.get<{ preflight: AudiencePreflight }>(`/api/broadcasts.preflight?${searchParams.toString()}`)
+  },
+
+  stats: async (params: GetBroadcastStatsRequest): Promise<BroadcastStats> => {
+    const searchParams = new URLSearchParams()
+    searchParams.append('workspace_id', params.workspace_id)
+    searchParams.append('id', params.id)
+
+    return api.get<BroadcastStats>(`/api/broadcasts.stats?${searchParams.toString()}`)
+  },
+
   selectWinner: async (params: SelectWinnerRequest): Promise<{ success: boolean }> => {
     return api.post<{ success: boolean }>('/api/broadcasts.selectWinner', params)
+  },
+
+  approveSeedTest: async (params: ApproveSeedTestRequest): Promise<{ success: boolean }> => {
+    return api.post<{ success: boolean }>('/api/broadcasts.approveSeedTest', params)
   }
 }
@@ -87,9 +87,13 @@ export interface ListContactsResponse {
 export enum UpsertContactOperationAction {
   Create = 'create',
   Update = 'update',
+  Valid = 'valid',
+  Skip = 'skip',
   Error = 'error'
 }
 
+export type ImportConflictPolicy = 'overwrite' | 'fill_empty' | 'skip'
+
 export interface UpsertContactOperation {
   action: UpsertContactOperationAction
   email?: string
@@ -98,6 +102,7 @@ export interface UpsertContactOperation {
 
 export interface BatchImportContactsResponse {
   operations: UpsertContactOperation[]
+  validate_only?: boolean
   error?: string
 }
 
@@ -109,6 +114,34 @@ export interface GetTotalContactsResponse {
   total_contacts: number
 }
 
+export interface ListContactCount {
+  list_id: string
+  name: string
+  active_count: number
+}
+
+export interface SegmentContactCount {
+  segment_id: string
+  name: string
+  contact_count: number
+}
+
+export interface GetAudienceCountsResponse {
+  lists: ListContactCount[]
+  segments: SegmentContactCount[]
+}
+
+export interface BulkGetContactsRequest {
+  workspace_id: string
+  emails?: string[]
+  external_ids?: string[]
+}
+
+export interface BulkGetContactsResponse {
+  contacts: Contact[]
+  not_found: string[]
+}
+
 export const contactsApi = {
   list: async (params: ListContactsRequest): Promise<ListContactsResponse> => {
     const searchParams = new URLSearchParams()
@@ -152,14 +185,22 @@ export const contactsApi = {
     workspace_id: string
     contacts: Partial<Contact>[]
     subscribe_to_lists?: string[]
+    validate_only?: boolean
+    on_conflict?: ImportConflictPolicy
   }): Promise<BatchImportContactsResponse> => {
     return api.post('/api/contacts.import', {
       workspace_id: params.workspace_id,
       contacts: params.contacts,
-      subscribe_to_lists: params.subscribe_to_lists
+      subscribe_to_lists: params.subscribe_to_lists,
+      validate_only: params.validate_only,
+      on_conflict: params.on_conflict
     })
   },
 
+  bulkGet: async (params: BulkGetContactsRequest): Promise<BulkGetContactsResponse> => {
+    return api.post('/api/contacts.bulkGet', params)
+  },
+
   delete: async (params: {
     workspace_id: string
     email: string
@@ -174,5 +215,13 @@ export const contactsApi = {
     const searchParams = new URLSearchParams()
     searchParams.append('workspace_id', params.workspace_id)
     return api.get<GetTotalContactsResponse>(`/api/contacts.count?${searchParams.toString()}`)
+  },
+
+  getAudienceCounts: async (params: { workspace_id: string }): Promise<GetAudienceCountsResponse> => {
+    const searchParams = new URLSearchParams()
+    searchParams.append('workspace_id', params.workspace_id)
+    return api.get<GetAudienceCountsResponse>(
+      `/api/contacts.audienceCounts?${searchParams.toString()}`
+    )
   }
 }
@@ -1,7 +1,15 @@
 import { api } from './client'
 
 export type EmailEventType = 'delivered' | 'bounce' | 'complaint' | 'auth_email' | 'before_user_created'
-export type WebhookSource = 'ses' | 'sparkpost' | 'mailgun' | 'mailjet' | 'postmark' | 'smtp' | 'supabase'
+export type WebhookSource =
+  | 'ses'
+  | 'sparkpost'
+  | 'mailgun'
+  | 'mailjet'
+  | 'postmark'
+  | 'sendgrid'
+  | 'smtp'
+  | 'supabase'
 
 export interface InboundWebhookEvent {
   id: string
@@ -48,6 +56,20 @@ export interface InboundWebhookEventListResult {
   has_more: boolean
 }
 
+export interface ProviderWebhookHealth {
+  integration_id: string
+  last_sent_at?: string
+  last_event_at?: string
+  pending_since?: string
+  alerted_at?: string
+  updated_at: string
+  stale: boolean
+}
+
+export interface ProviderWebhookHealthResult {
+  integrations: ProviderWebhookHealth[]
+}
+
 /**
  * Lists inbound webhook events with pagination and filtering
  */
@@ -72,3 +94,12 @@ export function listInboundWebhookEvents(
 
   return api.get<InboundWebhookEventListResult>(`/api/inboundWebhookEvents.list?${queryParams.toString()}`)
 }
+
+/**
+ * Gets the last send and webhook event times of the email integrations
+ */
+export function getWebhookHealth(workspaceId: string): Promise<ProviderWebhookHealthResult> {
+  return api.get<ProviderWebhookHealthResult>(
+    `/api/inboundWebhookEvents.health?workspace_id=${encodeURIComponent(workspaceId)}`
+  )
+}
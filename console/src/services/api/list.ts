@@ -92,6 +92,27 @@ export interface GetListStatsResponse {
   stats: ListStats
 }
 
+export interface ListGrowthDay {
+  date: string // YYYY-MM-DD
+  subscribes: number
+  unsubscribes: number
+  net: number
+}
+
+export interface GetListGrowthRequest {
+  workspace_id: string
+  list_id: string
+  from: string // YYYY-MM-DD
+  to: string // YYYY-MM-DD
+  timezone?: string
+}
+
+export interface GetListGrowthResponse {
+  list_id: string
+  timezone: string
+  days: ListGrowthDay[]
+}
+
 export type ContactListTotalType = 'pending' | 'unsubscribed' | 'bounced' | 'complained' | 'active'
 
 export interface SubscribeToListsRequest {
@@ -142,6 +163,18 @@ export const listsApi = {
     return api.get<GetListStatsResponse>(`/api/lists.stats?${searchParams.toString()}`)
   },
 
+  growth: async (params: GetListGrowthRequest): Promise<GetListGrowthResponse> => {
+    const searchParams = new URLSearchParams()
+
+    searchParams.append('workspace_id', params.workspace_id)
+    searchParams.append('list_id', params.list_id)
+    searchParams.append('from', params.from)
+    searchParams.append('to', params.to)
+    if (params.timezone) searchParams.append('timezone', params.timezone)
+
+    return api.get<GetListGrowthResponse>(`/api/lists.growth?${searchParams.toString()}`)
+  },
+
   subscribe: async (params: SubscribeToListsRequest): Promise<{ success: boolean }> => {
     return api.post('/api/lists.subscribe', params)
   }
@@ -10,6 +10,7 @@ export interface ChannelOptions {
   cc?: string[]
   bcc?: string[]
   reply_to?: string
+  integration_id?: string // integration that delivered a queued email
 }
 
 export interface MessageData {
@@ -157,3 +158,38 @@ export function getBroadcastStats(
 
   return api.get<BroadcastStatsResult>(`/api/messages.broadcastStats?${queryParams.toString()}`)
 }
+
+/**
+ * Stats for the message statuses reached on one day of a broadcast
+ */
+export interface MessageHistoryDailyStatusSum extends MessageHistoryStatusSum {
+  date: string // YYYY-MM-DD
+}
+
+/**
+ * Response from the broadcast stats by day endpoint
+ */
+export interface BroadcastStatsByDayResult {
+  broadcast_id: string
+  days: MessageHistoryDailyStatusSum[]
+}
+
+/**
+ * Gets the daily statistics of a broadcast, in the workspace timezone unless one is given
+ */
+export function getBroadcastStatsByDay(
+  workspaceId: string,
+  broadcastId: string,
+  timezone?: string
+): Promise<BroadcastStatsByDayResult> {
+  const queryParams = new URLSearchParams()
+  queryParams.append('workspace_id', workspaceId)
+  queryParams.append('broadcast_id', broadcastId)
+  if (timezone) {
+    queryParams.append('timezone', timezone)
+  }
+
+  return api.get<BroadcastStatsByDayResult>(
+    `/api/messages.broadcastStatsByDay?${queryParams.toString()}`
+  )
+}
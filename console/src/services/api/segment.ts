@@ -17,7 +17,12 @@ export type SegmentStatus = 'active' | 'deleted' | 'building'
 // Tree structure types
 export type TreeNodeKind = 'branch' | 'leaf'
 export type BooleanOperator = 'and' | 'or'
-export type SourceType = 'contacts' | 'contact_lists' | 'contact_timeline' | 'custom_events_goals'
+export type SourceType =
+  | 'contacts'
+  | 'contact_lists'
+  | 'contact_timeline'
+  | 'custom_events_goals'
+  | 'message_activity'
 
 // Dimension filter types
 export type FieldType = 'string' | 'number' | 'time' | 'json'
@@ -112,12 +117,29 @@ export interface CustomEventsGoalCondition {
   timeframe_values?: string[]
 }
 
+// Message events read from message_history for message activity conditions
+export type MessageActivityEvent =
+  | 'sent'
+  | 'delivered'
+  | 'opened'
+  | 'clicked'
+  | 'bounced'
+  | 'complained'
+  | 'unsubscribed'
+
+export interface MessageActivityCondition {
+  event: MessageActivityEvent
+  operator: 'any' | 'none' // at least one message with the event, or none
+  days?: number // Only the events of the last N days, anytime when omitted
+}
+
 export interface TreeNodeLeaf {
   source: SourceType
   contact?: ContactCondition
   contact_list?: ContactListCondition
   contact_timeline?: ContactTimelineCondition
   custom_events_goal?: CustomEventsGoalCondition
+  message_activity?: MessageActivityCondition
 }
 
 export interface TreeNodeBranch {
@@ -0,0 +1,58 @@
+import { api } from './client'
+import type { EmailProviderKind } from './workspace'
+
+export type SenderIdentityStatus = 'pending' | 'verified'
+
+export interface SenderDomainAuth {
+  domain: string
+  provider_kind: EmailProviderKind
+  txt_record_name: string // Host of the TXT record proving the ownership of the domain
+  txt_record_value: string // Value of the TXT record proving the ownership of the domain
+  spf_include?: string // Mechanism the SPF record needs for the provider, empty for SMTP
+  spf_configured: boolean
+  last_checked_at?: string
+  last_check_failure?: string
+}
+
+export interface SenderIdentity {
+  email: string
+  name: string
+  integration_id: string
+  status: SenderIdentityStatus
+  domain_auth: SenderDomainAuth
+  verified_at?: string
+  created_at: string
+}
+
+export interface ListSenderIdentitiesResponse {
+  sender_identities: SenderIdentity[]
+}
+
+export interface VerifySenderIdentityRequest {
+  workspace_id: string
+  email: string
+}
+
+export interface VerifySenderIdentityResponse {
+  sender_identity: SenderIdentity
+}
+
+/**
+ * List the sender identities of a workspace
+ */
+export async function listSenderIdentities(
+  workspaceId: string
+): Promise<ListSenderIdentitiesResponse> {
+  return api.get<ListSenderIdentitiesResponse>(
+    `/api/senderIdentities.list?workspace_id=${workspaceId}`
+  )
+}
+
+/**
+ * Register the identity of a sender, or check the DNS record of its domain when it is pending verification
+ */
+export async function verifySenderIdentity(
+  request: VerifySenderIdentityRequest
+): Promise<VerifySenderIdentityResponse> {
+  return api.post<VerifySenderIdentityResponse>('/api/senderIdentities.verify', request)
+}
@@ -11,6 +11,7 @@ export interface SendBroadcastState {
   failed_count: number
   channel_type: string
   recipient_offset: number
+  duplicate_count?: number
 }
 
 export interface BuildSegmentState {
@@ -24,11 +25,33 @@ export interface BuildSegmentState {
   started_at: string
 }
 
+export interface RecomputeSegmentState {
+  segment_id: string
+  version: number
+  total_contacts: number
+  processed_count: number
+  added_count: number
+  removed_count: number
+  contact_offset: number
+  batch_size: number
+  started_at: string
+}
+
+export interface PruneMessageHistoryState {
+  cutoff: string
+  keep_statuses?: string[]
+  batch_size: number
+  deleted_count: number
+  started_at: string
+}
+
 export interface TaskState {
   progress?: number
   message?: string
   send_broadcast?: SendBroadcastState
   build_segment?: BuildSegmentState
+  recompute_segment?: RecomputeSegmentState
+  prune_message_history?: PruneMessageHistoryState
 }
 
 // Task interfaces
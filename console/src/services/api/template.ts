@@ -2,15 +2,17 @@ import { api } from './client'
 import type { EmailBlock } from '../../components/email_builder/types'
 import type { EmailOptions } from './transactional_notifications'
 import type { EmailProvider } from './workspace'
+import type { Contact } from './contacts'
 
 // Template types
 export interface Template {
   id: string
   name: string
   version: number
-  channel: 'email' | 'web'
+  channel: 'email' | 'web' | 'sms'
   email?: EmailTemplate
   web?: WebTemplate
+  sms?: SMSTemplate
   category: string
   template_macro_id?: string
   integration_id?: string
@@ -31,6 +33,26 @@ export interface EmailTemplate {
   compiled_preview: string // compiled html
   visual_editor_tree: EmailBlock
   text?: string
+  translations?: Record<string, EmailTemplate> // keyed by language code, e.g. "pt" or "pt-BR"
+  open_tracking?: boolean // overrides the workspace open tracking default
+  click_tracking?: boolean // overrides the workspace click tracking default
+  disable_open_tracking?: boolean // never track opens, whatever the defaults and overrides
+  disable_click_tracking?: boolean // never track clicks, whatever the defaults and overrides
+  provider_tags?: Record<string, string> // passed through to the email provider with every message
+  personalization?: PersonalizationRules // checked for every broadcast recipient
+  raw_merge_fields?: string[] // contact fields rendered as trusted HTML, other contact values are escaped
+}
+
+export type PersonalizationPolicy = 'skip' | 'fallback'
+
+export interface RequiredMergeField {
+  path: string // template data path, e.g. "contact.first_name"
+  fallback?: string // required with the fallback policy
+}
+
+export interface PersonalizationRules {
+  policy: PersonalizationPolicy
+  required_fields: RequiredMergeField[]
 }
 
 export interface WebTemplate {
@@ -39,6 +61,10 @@ export interface WebTemplate {
   plain_text?: string // Extracted text for search indexing
 }
 
+export interface SMSTemplate {
+  body: string // Liquid template rendered with the contact data, up to 1600 characters
+}
+
 export interface GetTemplatesRequest {
   workspace_id: string
   category?: string
@@ -58,6 +84,7 @@ export interface CreateTemplateRequest {
   channel: string
   email?: EmailTemplate
   web?: WebTemplate
+  sms?: SMSTemplate
   category: string
   template_macro_id?: string
   utm_source?: string
@@ -74,6 +101,7 @@ export interface UpdateTemplateRequest {
   channel: string
   email?: EmailTemplate
   web?: WebTemplate
+  sms?: SMSTemplate
   category: string
   template_macro_id?: string
   utm_source?: string
@@ -174,6 +202,23 @@ export interface TestTemplateResponse {
   error?: string
 }
 
+// Preview template types
+export interface PreviewTemplateRequest {
+  workspace_id: string
+  template_id: string
+  version?: number
+  contact?: Partial<Contact>
+  list_id?: string
+  list_name?: string
+  data?: Record<string, any>
+}
+
+export interface PreviewTemplateResponse {
+  subject: string
+  html: string
+  unresolved_merge_tags: string[]
+}
+
 // Define the API interfaces
 export interface TemplatesApi {
   list: (params: GetTemplatesRequest) => Promise<GetTemplatesResponse>
@@ -182,6 +227,7 @@ export interface TemplatesApi {
   update: (params: UpdateTemplateRequest) => Promise<UpdateTemplateResponse>
   delete: (params: DeleteTemplateRequest) => Promise<DeleteTemplateResponse>
   compile: (params: CompileTemplateRequest) => Promise<CompileTemplateResponse>
+  preview: (params: PreviewTemplateRequest) => Promise<PreviewTemplateResponse>
 }
 
 export const templatesApi: TemplatesApi = {
@@ -216,5 +262,9 @@ export const templatesApi: TemplatesApi = {
   compile: async (params: CompileTemplateRequest): Promise<CompileTemplateResponse> => {
     const response = await api.post<CompileTemplateResponse>(`/api/templates.compile`, params)
     return response
+  },
+  preview: async (params: PreviewTemplateRequest): Promise<PreviewTemplateResponse> => {
+    const response = await api.post<PreviewTemplateResponse>(`/api/templates.preview`, params)
+    return response
   }
 }
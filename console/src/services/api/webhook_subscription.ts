@@ -8,6 +8,7 @@ export interface CustomEventFilters {
 export interface WebhookSubscriptionSettings {
   event_types: string[]
   custom_event_filters?: CustomEventFilters
+  contact_activity_checkpoint?: string
 }
 
 export interface WebhookSubscription {
@@ -66,6 +67,12 @@ export interface ToggleWebhookSubscriptionRequest {
   enabled: boolean
 }
 
+export interface ReplayContactActivityRequest {
+  workspace_id: string
+  id: string
+  checkpoint: string
+}
+
 export interface TestWebhookResponse {
   success: boolean
   status_code: number
@@ -136,6 +143,12 @@ export const webhookSubscriptionApi = {
     })
   },
 
+  replayContactActivity: async (
+    params: ReplayContactActivityRequest
+  ): Promise<{ subscription: WebhookSubscription }> => {
+    return api.post('/api/webhookSubscriptions.replayContactActivity', params)
+  },
+
   getDeliveries: async (
     workspaceId: string,
     subscriptionId?: string,
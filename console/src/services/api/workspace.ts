@@ -1,5 +1,6 @@
 import { api } from './client'
 import type { EmailBlock } from '../../components/email_builder/types'
+import type { SenderIdentity } from './sender_identity'
 
 // Template Block type
 export interface TemplateBlock {
@@ -41,12 +42,78 @@ export interface WorkspaceSettings {
   file_manager?: FileManagerSettings
   transactional_email_provider_id?: string
   marketing_email_provider_id?: string
+  marketing_failover_provider_ids?: string[] // integrations tried in order when the marketing provider is unavailable
+  sms_provider_id?: string // integration sending sms broadcasts
   email_tracking_enabled: boolean
+  open_tracking_default?: boolean // falls back to email_tracking_enabled when unset
+  click_tracking_default?: boolean // falls back to email_tracking_enabled when unset
   template_blocks?: TemplateBlock[]
   custom_endpoint_url?: string
   custom_field_labels?: Record<string, string>
   blog_enabled?: boolean
   blog_settings?: BlogSettings
+  sandbox_mode?: boolean
+  sandbox_allowlist?: string[]
+  quiet_hours?: QuietHoursSettings
+  deliverable_audience?: DeliverableAudienceSettings
+  link_shortening?: LinkShorteningSettings
+  send_confirmation?: SendConfirmationSettings
+  complaint_spike?: ComplaintSpikeSettings
+  sending_block?: SendingBlock // Set when a complaint spike blocked sending, read-only
+  send_cool_off?: SendCoolOffSettings
+  daily_send_quota?: number // Messages sent per UTC day before broadcasts wait for the next day, 0 for no quota
+  require_unsubscribe_link?: boolean // Add an unsubscribe footer to broadcast emails whose template has no unsubscribe link
+  tracking_domain?: string // Branded host of the open and click tracking URLs, e.g. track.example.com
+  rate_limit?: WorkspaceRateLimitSettings // API rate limit overrides, only changed by the root user
+  require_verified_senders?: boolean // Fail broadcasts whose sender has no verified sender identity
+  sender_identities?: SenderIdentity[] // Managed with the senderIdentities endpoints, read-only
+}
+
+export interface WorkspaceRateLimitSettings {
+  requests_per_second?: number // Refill rate of the workspace bucket, 0 for the server default
+  burst?: number // Size of the workspace bucket, 0 for the server default
+}
+
+export interface SendCoolOffSettings {
+  enabled: boolean
+  minutes?: number // Delay before a sent broadcast starts, 0 to 60 (default 5)
+}
+
+export interface ComplaintSpikeSettings {
+  enabled: boolean
+  max_rate: number // Maximum complaints/sent ratio over the window, between 0 and 1 (e.g. 0.003)
+  window_minutes?: number // Rolling window (default 60)
+  min_sent?: number // Minimum emails sent in the window to evaluate the rate (default 500)
+}
+
+export interface SendingBlock {
+  reason: string
+  complaint_rate: number
+  sent: number
+  complaints: number
+  blocked_at: string
+}
+
+export interface SendConfirmationSettings {
+  enabled: boolean
+  ttl_seconds?: number // Lifetime of the preflight confirmation token, 30 to 3600 (default 300)
+}
+
+export interface LinkShorteningSettings {
+  enabled: boolean
+  domain?: string // Base URL such as https://go.example.com, must point to the API
+}
+
+export interface DeliverableAudienceSettings {
+  enabled: boolean
+  min_ratio: number // Minimum deliverable/raw audience ratio, between 0 and 1
+  block: boolean // Refuse to schedule instead of only warning
+}
+
+export interface QuietHoursSettings {
+  enabled: boolean
+  start: string // Format: HH:mm
+  end: string // Format: HH:mm
 }
 
 export interface FileManagerSettings {
@@ -61,7 +128,14 @@ export interface FileManagerSettings {
   force_path_style?: boolean
 }
 
-export type EmailProviderKind = 'smtp' | 'ses' | 'sparkpost' | 'postmark' | 'mailgun' | 'mailjet'
+export type EmailProviderKind =
+  | 'smtp'
+  | 'ses'
+  | 'sparkpost'
+  | 'postmark'
+  | 'mailgun'
+  | 'mailjet'
+  | 'sendgrid'
 
 export interface Sender {
   id: string
@@ -78,6 +152,7 @@ export interface EmailProvider {
   postmark?: PostmarkSettings
   mailgun?: MailgunSettings
   mailjet?: MailjetSettings
+  sendgrid?: SendGridSettings
   senders: Sender[]
   rate_limit_per_minute: number
 }
@@ -87,6 +162,7 @@ export interface AmazonSES {
   access_key: string
   secret_key?: string
   encrypted_secret_key?: string
+  verify_webhook_signature?: boolean
 }
 
 export interface SMTPSettings {
@@ -96,6 +172,7 @@ export interface SMTPSettings {
   password?: string
   encrypted_password?: string
   use_tls: boolean
+  max_connections?: number
 }
 
 export interface SparkPostSettings {
@@ -108,6 +185,9 @@ export interface SparkPostSettings {
 export interface PostmarkSettings {
   server_token?: string
   encrypted_server_token?: string
+  webhook_username?: string
+  webhook_password?: string
+  encrypted_webhook_password?: string
 }
 
 export interface MailgunSettings {
@@ -115,6 +195,8 @@ export interface MailgunSettings {
   encrypted_api_key?: string
   domain: string
   region?: 'US' | 'EU'
+  webhook_signing_key?: string
+  encrypted_webhook_signing_key?: string
 }
 
 export interface MailjetSettings {
@@ -125,6 +207,13 @@ export interface MailjetSettings {
   sandbox_mode: boolean
 }
 
+export interface SendGridSettings {
+  api_key?: string
+  encrypted_api_key?: string
+  ip_pool_name?: string
+  sandbox_mode: boolean
+}
+
 export type IntegrationType = 'email' | 'sms' | 'whatsapp' | 'supabase' | 'llm' | 'firecrawl'
 
 // LLM Provider types
@@ -141,6 +230,21 @@ export interface LLMProvider {
   anthropic?: AnthropicSettings
 }
 
+// SMS Provider types
+export type SMSProviderKind = 'twilio'
+
+export interface TwilioSettings {
+  account_sid: string
+  auth_token?: string
+  encrypted_auth_token?: string
+  from_number: string
+}
+
+export interface SMSProvider {
+  kind: SMSProviderKind
+  twilio?: TwilioSettings
+}
+
 // Firecrawl settings for web scraping and search
 export interface FirecrawlSettings {
   api_key?: string
@@ -174,6 +278,7 @@ export interface Integration {
   supabase_settings?: SupabaseIntegrationSettings
   llm_provider?: LLMProvider
   firecrawl_settings?: FirecrawlSettings
+  sms_provider?: SMSProvider
   created_at: string
   updated_at: string
 }
@@ -252,6 +357,7 @@ export interface CreateIntegrationRequest {
   supabase_settings?: SupabaseIntegrationSettings
   llm_provider?: LLMProvider
   firecrawl_settings?: FirecrawlSettings
+  sms_provider?: SMSProvider
 }
 
 export interface UpdateIntegrationRequest {
@@ -262,6 +368,7 @@ export interface UpdateIntegrationRequest {
   supabase_settings?: SupabaseIntegrationSettings
   llm_provider?: LLMProvider
   firecrawl_settings?: FirecrawlSettings
+  sms_provider?: SMSProvider
 }
 
 export interface DeleteIntegrationRequest {
@@ -389,6 +496,14 @@ export interface DeleteInvitationResponse {
   message: string
 }
 
+export interface ClearSendingBlockRequest {
+  workspace_id: string
+}
+
+export interface ClearSendingBlockResponse {
+  workspace: Workspace
+}
+
 interface DetectFaviconResponse {
   iconUrl: string
   coverUrl?: string
@@ -443,5 +558,8 @@ export const workspaceService = {
     api.post<DeleteInvitationResponse>('/api/workspaces.deleteInvitation', data),
 
   setUserPermissions: (data: SetUserPermissionsRequest) =>
-    api.post<SetUserPermissionsResponse>('/api/workspaces.setUserPermissions', data)
+    api.post<SetUserPermissionsResponse>('/api/workspaces.setUserPermissions', data),
+
+  clearSendingBlock: (data: ClearSendingBlockRequest) =>
+    api.post<ClearSendingBlockResponse>('/api/workspaces.clearSendingBlock', data)
 }
@@ -104,6 +104,9 @@ type App struct {
 	transactionalNotificationRepo domain.TransactionalNotificationRepository
 	messageHistoryRepo            domain.MessageHistoryRepository
 	inboundWebhookEventRepo       domain.InboundWebhookEventRepository
+	providerWebhookHealthRepo     domain.ProviderWebhookHealthRepository
+	messageStatusRetryRepo        domain.MessageStatusRetryRepository
+	broadcastAudienceRepo         domain.BroadcastAudienceRepository
 	telemetryRepo                 domain.TelemetryRepository
 	analyticsRepo                 domain.AnalyticsRepository
 	contactTimelineRepo           domain.ContactTimelineRepository
@@ -117,6 +120,8 @@ type App struct {
 	webhookDeliveryRepo           domain.WebhookDeliveryRepository
 	automationRepo                domain.AutomationRepository
 	emailQueueRepo                domain.EmailQueueRepository
+	shortLinkRepo                 domain.ShortLinkRepository
+	apiKeyRepo                    domain.APIKeyRepository
 
 	// Services
 	authService                      *service.AuthService
@@ -128,12 +133,16 @@ type App struct {
 	templateService                  *service.TemplateService
 	templateBlockService             *service.TemplateBlockService
 	emailService                     *service.EmailService
+	linkShortenerService             *service.LinkShortenerService
 	broadcastService                 *service.BroadcastService
 	taskService                      *service.TaskService
 	transactionalNotificationService *service.TransactionalNotificationService
 	systemNotificationService        *service.SystemNotificationService
+	broadcastProgressHub             *service.BroadcastProgressHub
 	inboundWebhookEventService       *service.InboundWebhookEventService
+	messageStatusBatcher             *service.MessageStatusBatcher
 	webhookRegistrationService       *service.WebhookRegistrationService
+	senderIdentityService            *service.SenderIdentityService
 	messageHistoryService            *service.MessageHistoryService
 	notificationCenterService        *service.NotificationCenterService
 	demoService                      *service.DemoService
@@ -149,7 +158,12 @@ type App struct {
 	dnsVerificationService           *service.DNSVerificationService
 	customEventService               *service.CustomEventService
 	webhookSubscriptionService       *service.WebhookSubscriptionService
+	apiKeyService                    *service.APIKeyService
 	webhookDeliveryWorker            *service.WebhookDeliveryWorker
+	contactActivityWorker            *service.ContactActivityWorker
+	messageStatusRetryWorker         *service.MessageStatusRetryWorker
+	webhookHealthMonitor             *service.WebhookHealthMonitor
+	complaintSpikeMonitor            *service.ComplaintSpikeMonitor
 	automationService                *service.AutomationService
 	automationScheduler              *service.AutomationScheduler
 	llmService                       *service.LLMService
@@ -162,7 +176,8 @@ type App struct {
 	sesService       *service.SESService
 
 	// Cache
-	blogCache cache.Cache // Dedicated cache for blog rendering
+	blogCache           cache.Cache // Dedicated cache for blog rendering
+	audienceCountsCache cache.Cache // Per-workspace contact counts of lists and segments, nil when disabled
 
 	// HTTP handlers
 	mux    *http.ServeMux
@@ -188,6 +203,9 @@ type App struct {
 	activeRequests  int64          // atomic counter for active HTTP requests
 	requestWg       sync.WaitGroup // wait group for active requests
 	shutdownTimeout time.Duration  // configurable shutdown timeout
+
+	// broadcastShutdown stops running broadcasts with a final checkpoint on shutdown
+	broadcastShutdown *broadcast.ShutdownNotice
 }
 
 // AppOption defines a functional option for configuring the App
@@ -401,6 +419,7 @@ func (a *App) InitRepositories() error {
 	a.userRepo = repository.NewUserRepository(a.db)
 	a.taskRepo = repository.NewTaskRepository(a.db)
 	a.authRepo = repository.NewSQLAuthRepository(a.db)
+	a.apiKeyRepo = repository.NewAPIKeyRepository(a.db)
 	a.settingRepo = repository.NewSQLSettingRepository(a.db)
 	a.workspaceRepo = repository.NewWorkspaceRepository(a.db, &a.config.Database, a.config.Security.SecretKey, connManager)
 	a.contactRepo = repository.NewContactRepository(a.workspaceRepo)
@@ -408,9 +427,12 @@ func (a *App) InitRepositories() error {
 	a.contactListRepo = repository.NewContactListRepository(a.workspaceRepo)
 	a.templateRepo = repository.NewTemplateRepository(a.workspaceRepo)
 	a.broadcastRepo = repository.NewBroadcastRepository(a.workspaceRepo)
+	a.broadcastAudienceRepo = repository.NewBroadcastAudienceRepository(a.workspaceRepo)
 	a.transactionalNotificationRepo = repository.NewTransactionalNotificationRepository(a.workspaceRepo)
-	a.messageHistoryRepo = repository.NewMessageHistoryRepository(a.workspaceRepo)
+	a.messageHistoryRepo = repository.NewMessageHistoryRepository(a.workspaceRepo, a.config.Security.DeriveWorkspaceKeys)
 	a.inboundWebhookEventRepo = repository.NewInboundWebhookEventRepository(a.workspaceRepo)
+	a.providerWebhookHealthRepo = repository.NewProviderWebhookHealthRepository(a.workspaceRepo)
+	a.messageStatusRetryRepo = repository.NewMessageStatusRetryRepository(a.workspaceRepo)
 	a.telemetryRepo = repository.NewTelemetryRepository(a.workspaceRepo)
 	a.analyticsRepo = repository.NewAnalyticsRepository(a.workspaceRepo, a.logger)
 	a.contactTimelineRepo = repository.NewContactTimelineRepository(a.workspaceRepo)
@@ -431,6 +453,9 @@ func (a *App) InitRepositories() error {
 	// Initialize email queue repository
 	a.emailQueueRepo = repository.NewEmailQueueRepository(a.workspaceRepo)
 
+	// Initialize short link repository
+	a.shortLinkRepo = repository.NewShortLinkRepository(a.workspaceRepo)
+
 	// Initialize setting service
 	a.settingService = service.NewSettingService(a.settingRepo)
 
@@ -526,6 +551,7 @@ func (a *App) InitServices() error {
 		a.logger,
 		a.config.APIEndpoint,
 	)
+	a.templateService.SetWorkspaceRepository(a.workspaceRepo)
 
 	// Initialize template block service
 	a.templateBlockService = service.NewTemplateBlockService(
@@ -545,6 +571,11 @@ func (a *App) InitServices() error {
 		a.contactTimelineRepo,
 		a.logger,
 	)
+	a.contactService.SetBulkGetMax(a.config.Contacts.BulkGetMax)
+	if a.config.Contacts.AudienceCountsCacheTTL > 0 {
+		a.audienceCountsCache = cache.NewInMemoryCache(a.config.Contacts.AudienceCountsCacheTTL)
+		a.contactService.SetAudienceCountsCache(a.audienceCountsCache, a.config.Contacts.AudienceCountsCacheTTL)
+	}
 
 	// Initialize contact list service
 	a.contactListService = service.NewContactListService(
@@ -598,6 +629,14 @@ func (a *App) InitServices() error {
 		a.config.APIEndpoint,
 	)
 
+	// Initialize link shortener service for click-tracked URLs
+	a.linkShortenerService = service.NewLinkShortenerService(a.shortLinkRepo, a.workspaceRepo, a.config.APIEndpoint, a.logger)
+	a.emailService.SetLinkShortener(a.linkShortenerService)
+
+	// Opens, clicks and synchronous webhook status updates are de-duplicated per message
+	statusApplier := service.NewIdempotentStatusApplier(a.messageHistoryRepo, a.logger, 50*time.Millisecond)
+	a.emailService.SetStatusApplier(statusApplier)
+
 	// Initialize webhook registration service
 	a.webhookRegistrationService = service.NewWebhookRegistrationService(
 		a.workspaceRepo,
@@ -611,6 +650,9 @@ func (a *App) InitServices() error {
 		a.config.WebhookEndpoint,
 	)
 
+	// Initialize sender identity service
+	a.senderIdentityService = service.NewSenderIdentityService(a.workspaceRepo, a.authService, a.logger)
+
 	// Initialize list service after webhook registration service
 	a.listService = service.NewListService(
 		a.listRepo,
@@ -651,12 +693,51 @@ func (a *App) InitServices() error {
 		a.config.APIEndpoint,
 	)
 
+	// Broadcast stats are cached for polling dashboards and invalidated by webhook status updates
+	broadcastStatsCache := service.NewBroadcastStatsCache()
+
 	a.inboundWebhookEventService = service.NewInboundWebhookEventService(
 		a.inboundWebhookEventRepo,
 		a.authService,
 		a.logger,
 		a.workspaceRepo,
 		a.messageHistoryRepo,
+		a.config.InboundWebhook.PayloadRetention,
+	)
+	a.inboundWebhookEventService.SetSuppressionRepository(a.contactRepo)
+	a.inboundWebhookEventService.SetStatusRetryRepository(a.messageStatusRetryRepo)
+	a.inboundWebhookEventService.SetBroadcastStatsCache(broadcastStatsCache)
+	a.inboundWebhookEventService.SetStatusApplier(statusApplier)
+	if a.config.InboundWebhook.IngestionWorkers > 0 {
+		a.messageStatusBatcher = service.NewMessageStatusBatcher(
+			a.messageHistoryRepo,
+			a.logger,
+			a.config.InboundWebhook.IngestionWorkers,
+			a.config.InboundWebhook.IngestionQueueSize,
+			a.config.InboundWebhook.IngestionBatchSize,
+			a.config.InboundWebhook.IngestionFlushInterval,
+		)
+		a.messageStatusBatcher.SetRetryRepository(a.messageStatusRetryRepo)
+		a.messageStatusBatcher.SetBroadcastStatsCache(broadcastStatsCache)
+		a.messageStatusBatcher.Start()
+		a.inboundWebhookEventService.SetStatusBatcher(a.messageStatusBatcher)
+	}
+	if a.config.InboundWebhook.HealthWindow > 0 {
+		a.webhookHealthMonitor = service.NewWebhookHealthMonitor(
+			a.providerWebhookHealthRepo,
+			a.workspaceRepo,
+			a.eventBus,
+			a.logger,
+			a.config.InboundWebhook.HealthWindow,
+		)
+		a.inboundWebhookEventService.SetWebhookHealthMonitor(a.webhookHealthMonitor)
+	}
+	a.complaintSpikeMonitor = service.NewComplaintSpikeMonitor(
+		a.workspaceRepo,
+		a.messageHistoryRepo,
+		a.broadcastRepo,
+		a.eventBus,
+		a.logger,
 	)
 
 	// Initialize Supabase service (before workspace service)
@@ -690,6 +771,8 @@ func (a *App) InitServices() error {
 		a.listService,        // List service for web publication validation
 		a.config.APIEndpoint, // API endpoint for tracking URLs
 	)
+	a.broadcastService.SetAudienceRepository(a.broadcastAudienceRepo)
+	a.broadcastService.SetStatsCache(broadcastStatsCache)
 
 	// Create broadcast factory with refactored components
 	broadcastConfig := broadcast.DefaultConfig()
@@ -697,6 +780,15 @@ func (a *App) InitServices() error {
 	if a.config.Broadcast.DefaultRateLimit > 0 {
 		broadcastConfig.DefaultRateLimit = a.config.Broadcast.DefaultRateLimit
 	}
+	broadcastConfig.StatusUpdateRetries = a.config.Broadcast.StatusUpdateRetries
+	broadcastConfig.StatusUpdateRetryBackoff = a.config.Broadcast.StatusUpdateRetryBackoff
+	broadcastConfig.MaxSendsPerSecond = a.config.Broadcast.MaxSendsPerSecond
+	broadcastConfig.RenderTimeout = a.config.Broadcast.RenderTimeout
+	broadcastConfig.BatchRetries = a.config.Broadcast.BatchRetries
+	broadcastConfig.BatchRetryBackoff = a.config.Broadcast.BatchRetryBackoff
+	broadcastConfig.AdaptiveBatchSize = a.config.Broadcast.AdaptiveBatchSize
+	broadcastConfig.AdaptiveBatchMinSize = a.config.Broadcast.AdaptiveBatchMinSize
+	broadcastConfig.AdaptiveBatchMaxSize = a.config.Broadcast.AdaptiveBatchMaxSize
 	broadcastFactory := broadcast.NewFactory(
 		a.broadcastRepo,
 		a.messageHistoryRepo,
@@ -713,6 +805,14 @@ func (a *App) InitServices() error {
 		true, // useQueueSender - use queue-based message sender for broadcasts
 	)
 
+	broadcastFactory.SetLinkShortener(a.linkShortenerService)
+	broadcastFactory.SetAudienceRepository(a.broadcastAudienceRepo)
+	broadcastFactory.SetSMSProviders(map[domain.SMSProviderKind]domain.SMSProviderService{
+		domain.SMSProviderKindTwilio: service.NewTwilioService(httpClient, a.logger),
+	})
+	a.broadcastShutdown = broadcast.NewShutdownNotice()
+	broadcastFactory.SetShutdownNotice(a.broadcastShutdown)
+
 	// Register the broadcast factory with the task service
 	broadcastFactory.RegisterWithTaskService(a.taskService)
 
@@ -744,6 +844,10 @@ func (a *App) InitServices() error {
 	// Register system notification service with event bus
 	a.systemNotificationService.RegisterWithEventBus(a.eventBus)
 
+	// Fan out the broadcast progress events to the dashboards following a broadcast
+	a.broadcastProgressHub = service.NewBroadcastProgressHub(a.logger)
+	a.broadcastProgressHub.RegisterWithEventBus(a.eventBus)
+
 	// Initialize segment service (before demo service since it depends on it)
 	a.segmentService = service.NewSegmentService(
 		a.segmentRepo,
@@ -785,6 +889,7 @@ func (a *App) InitServices() error {
 		a.dnsVerificationService,
 		a.blogService,
 	)
+	a.workspaceService.SetFileStorageService(service.NewFileStorageService(a.logger))
 
 	// Initialize and register segment build processor
 	segmentBuildProcessor := service.NewSegmentBuildProcessor(
@@ -796,6 +901,16 @@ func (a *App) InitServices() error {
 	)
 	a.taskService.RegisterProcessor(segmentBuildProcessor)
 
+	// Initialize and register segment recompute processor
+	recomputeSegmentProcessor := service.NewSegmentRecomputeProcessor(
+		a.segmentRepo,
+		a.contactRepo,
+		a.taskRepo,
+		a.workspaceRepo,
+		a.logger,
+	)
+	a.taskService.RegisterProcessor(recomputeSegmentProcessor)
+
 	// Initialize and register segment recompute task processor
 	segmentRecomputeProcessor := service.NewSegmentRecomputeTaskProcessor(
 		a.segmentRepo,
@@ -805,6 +920,14 @@ func (a *App) InitServices() error {
 	)
 	a.taskService.RegisterProcessor(segmentRecomputeProcessor)
 
+	// Initialize and register message history prune processor
+	messageHistoryPruneProcessor := service.NewMessageHistoryPruneProcessor(
+		a.messageHistoryRepo,
+		a.taskRepo,
+		a.logger,
+	)
+	a.taskService.RegisterProcessor(messageHistoryPruneProcessor)
+
 	// Initialize contact segment queue processor
 	contactSegmentQueueProcessor := service.NewContactSegmentQueueProcessor(
 		a.contactSegmentQueueRepo,
@@ -830,6 +953,21 @@ func (a *App) InitServices() error {
 		a.logger,
 	)
 
+	// Initialize scoped API key service
+	a.apiKeyService = service.NewAPIKeyService(
+		a.apiKeyRepo,
+		a.userRepo,
+		a.workspaceRepo,
+		a.authService,
+		a.logger,
+		a.config.APIEndpoint,
+	)
+
+	// Queue outgoing webhooks for broadcast phase changes
+	a.webhookSubscriptionService.SubscribeToBroadcastEvents(a.eventBus)
+	a.webhookSubscriptionService.SubscribeToContactActivity(a.eventBus)
+	a.webhookSubscriptionService.SubscribeToIntegrationEvents(a.eventBus)
+
 	// Initialize demo service
 	a.demoService = service.NewDemoService(
 		a.logger,
@@ -887,6 +1025,23 @@ func (a *App) InitServices() error {
 		a.config.TaskScheduler.MaxTasks,
 	)
 
+	// Initialize contact activity worker feeding contact.activity webhooks
+	a.contactActivityWorker = service.NewContactActivityWorker(
+		a.workspaceRepo,
+		a.webhookSubscriptionRepo,
+		a.contactTimelineRepo,
+		a.eventBus,
+		a.logger,
+	)
+
+	// Initialize message status retry worker applying the webhook status updates that failed
+	a.messageStatusRetryWorker = service.NewMessageStatusRetryWorker(
+		a.workspaceRepo,
+		a.messageStatusRetryRepo,
+		a.messageHistoryRepo,
+		a.logger,
+	)
+
 	// Initialize webhook delivery worker
 	a.webhookDeliveryWorker = service.NewWebhookDeliveryWorker(
 		a.webhookSubscriptionRepo,
@@ -895,6 +1050,7 @@ func (a *App) InitServices() error {
 		a.logger,
 		httpClient,
 	)
+	a.webhookDeliveryWorker.SetEndpointLimits(a.config.WebhookDelivery.MaxConcurrentPerEndpoint, a.config.WebhookDelivery.MaxQueuedPerEndpoint)
 
 	// Initialize email queue worker for processing marketing emails (broadcasts & automations)
 	// Worker creates message_history entries via UPSERT after each send attempt
@@ -906,6 +1062,9 @@ func (a *App) InitServices() error {
 		queue.DefaultWorkerConfig(),
 		a.logger,
 	)
+	if a.webhookHealthMonitor != nil {
+		a.emailQueueWorker.SetWebhookHealthRecorder(a.webhookHealthMonitor)
+	}
 
 	// Initialize automation service
 	a.automationService = service.NewAutomationService(
@@ -941,6 +1100,7 @@ func (a *App) InitServices() error {
 		a.logger,
 		a.config.APIEndpoint,
 	)
+	automationExecutor.SetLinkShortener(a.linkShortenerService)
 	a.automationScheduler = service.NewAutomationScheduler(
 		automationExecutor,
 		a.logger,
@@ -1020,6 +1180,35 @@ func (a *App) InitHandlers() error {
 		return a.config.Security.JWTSecret, nil
 	}
 
+	// Reject revoked user tokens in every handler's auth middleware
+	if a.authService != nil {
+		middleware.SetTokenRevocationChecker(a.authService.IsTokenRevoked)
+	}
+	// Accept scoped API keys in place of JWT tokens
+	if a.apiKeyService != nil {
+		middleware.SetAPIKeyAuthenticator(a.apiKeyService.Authenticate)
+	}
+	// Limit the authenticated requests of each workspace, workspaces can override the server defaults
+	if a.workspaceRepo != nil {
+		middleware.SetWorkspaceRateLimiter(middleware.NewWorkspaceRateLimiter(
+			a.config.APIRateLimit.RequestsPerSecond,
+			a.config.APIRateLimit.Burst,
+			func(ctx context.Context, workspaceID string) (*domain.WorkspaceRateLimitSettings, error) {
+				workspace, err := a.workspaceRepo.GetByID(ctx, workspaceID)
+				if err != nil {
+					return nil, err
+				}
+				return workspace.Settings.RateLimit, nil
+			},
+			func(ctx context.Context, userID, workspaceID string) bool {
+				// Users that are not members, or whose membership can't be checked, are charged to their own bucket
+				_, err := a.workspaceRepo.GetUserWorkspace(ctx, userID, workspaceID)
+				return err == nil
+			},
+			a.logger,
+		))
+	}
+
 	// Initialize handlers (pass callback instead of static JWT secret)
 	userHandler := httpHandler.NewUserHandler(
 		a.userService,
@@ -1065,7 +1254,11 @@ func (a *App) InitHandlers() error {
 	templateHandler := httpHandler.NewTemplateHandler(a.templateService, getJWTSecret, a.logger)
 	templateBlockHandler := httpHandler.NewTemplateBlockHandler(a.templateBlockService, getJWTSecret, a.logger)
 	emailHandler := httpHandler.NewEmailHandler(a.emailService, getJWTSecret, a.logger, a.config.Security.SecretKey)
+	shortLinkHandler := httpHandler.NewShortLinkHandler(a.linkShortenerService, a.emailService, a.logger)
 	broadcastHandler := httpHandler.NewBroadcastHandler(a.broadcastService, a.templateService, getJWTSecret, a.logger, a.config.IsDemo())
+	if a.broadcastProgressHub != nil {
+		broadcastHandler.SetProgressSubscriber(a.broadcastProgressHub)
+	}
 	blogHandler := httpHandler.NewBlogHandler(a.blogService, getJWTSecret, a.logger, a.config.IsDemo())
 	blogThemeHandler := httpHandler.NewBlogThemeHandler(a.blogService, getJWTSecret, a.logger)
 	taskHandler := httpHandler.NewTaskHandler(
@@ -1077,6 +1270,7 @@ func (a *App) InitHandlers() error {
 	transactionalHandler := httpHandler.NewTransactionalNotificationHandler(a.transactionalNotificationService, getJWTSecret, a.logger, a.config.IsDemo())
 	inboundWebhookEventHandler := httpHandler.NewInboundWebhookEventHandler(a.inboundWebhookEventService, getJWTSecret, a.logger)
 	webhookRegistrationHandler := httpHandler.NewWebhookRegistrationHandler(a.webhookRegistrationService, getJWTSecret, a.logger)
+	senderIdentityHandler := httpHandler.NewSenderIdentityHandler(a.senderIdentityService, getJWTSecret, a.logger)
 	supabaseWebhookHandler := httpHandler.NewSupabaseWebhookHandler(a.supabaseService, a.logger)
 	messageHistoryHandler := httpHandler.NewMessageHistoryHandler(
 		a.messageHistoryService,
@@ -1127,6 +1321,11 @@ func (a *App) InitHandlers() error {
 		getJWTSecret,
 		a.logger,
 	)
+	apiKeyHandler := httpHandler.NewAPIKeyHandler(
+		a.apiKeyService,
+		getJWTSecret,
+		a.logger,
+	)
 	if !a.config.IsProduction() {
 		demoHandler := httpHandler.NewDemoHandler(a.demoService, a.logger)
 		demoHandler.RegisterRoutes(a.mux)
@@ -1143,6 +1342,7 @@ func (a *App) InitHandlers() error {
 	templateHandler.RegisterRoutes(a.mux)
 	templateBlockHandler.RegisterRoutes(a.mux)
 	emailHandler.RegisterRoutes(a.mux)
+	shortLinkHandler.RegisterRoutes(a.mux)
 	broadcastHandler.RegisterRoutes(a.mux)
 	blogHandler.RegisterRoutes(a.mux)
 	blogThemeHandler.RegisterRoutes(a.mux)
@@ -1150,6 +1350,7 @@ func (a *App) InitHandlers() error {
 	transactionalHandler.RegisterRoutes(a.mux)
 	inboundWebhookEventHandler.RegisterRoutes(a.mux)
 	webhookRegistrationHandler.RegisterRoutes(a.mux)
+	senderIdentityHandler.RegisterRoutes(a.mux)
 	supabaseWebhookHandler.RegisterRoutes(a.mux)
 	messageHistoryHandler.RegisterRoutes(a.mux)
 	notificationCenterHandler.RegisterRoutes(a.mux)
@@ -1160,6 +1361,7 @@ func (a *App) InitHandlers() error {
 	webhookSubscriptionHandler.RegisterRoutes(a.mux)
 	automationHandler.RegisterRoutes(a.mux)
 	llmHandler.RegisterRoutes(a.mux)
+	apiKeyHandler.RegisterRoutes(a.mux)
 
 	return nil
 }
@@ -1267,6 +1469,16 @@ func (a *App) Start() error {
 					return
 				}
 				a.logger.Info("Starting webhook delivery worker now")
+				if a.contactActivityWorker != nil {
+					go a.contactActivityWorker.Start(ctx)
+				}
+				if a.messageStatusRetryWorker != nil {
+					go a.messageStatusRetryWorker.Start(ctx)
+				}
+				if a.webhookHealthMonitor != nil {
+					go a.webhookHealthMonitor.Start(ctx)
+				}
+				go a.complaintSpikeMonitor.Start(ctx)
 				a.webhookDeliveryWorker.Start(ctx)
 			case <-ctx.Done():
 				a.logger.Info("Server shutdown initiated during webhook worker delay, worker will not start")
@@ -1340,6 +1552,13 @@ func (a *App) Start() error {
 func (a *App) Shutdown(ctx context.Context) error {
 	a.logger.Info("Starting graceful shutdown...")
 
+	// Running broadcasts checkpoint their progress at the next batch boundary, within the shutdown timeout
+	broadcastDeadline := time.Now().Add(a.shutdownTimeout)
+	if deadline, ok := ctx.Deadline(); ok && deadline.Before(broadcastDeadline) {
+		broadcastDeadline = deadline
+	}
+	a.broadcastShutdown.Begin(broadcastDeadline)
+
 	// Signal shutdown to all components
 	a.shutdownCancel()
 
@@ -1348,6 +1567,9 @@ func (a *App) Shutdown(ctx context.Context) error {
 		a.logger.Info("Stopping blog cache...")
 		a.blogCache.Stop()
 	}
+	if a.audienceCountsCache != nil {
+		a.audienceCountsCache.Stop()
+	}
 
 	// Stop task scheduler first (before stopping server)
 	if a.taskScheduler != nil {
@@ -1371,6 +1593,12 @@ func (a *App) Shutdown(ctx context.Context) error {
 		a.rateLimiter.Stop()
 	}
 
+	// Write queued webhook status updates
+	if a.messageStatusBatcher != nil {
+		a.logger.Info("Stopping message status batcher...")
+		a.messageStatusBatcher.Stop()
+	}
+
 	// Shutdown SMTP relay server if running
 	if a.smtpRelayServer != nil {
 		a.logger.Info("Shutting down SMTP relay server...")
@@ -141,6 +141,7 @@ func InitializeWorkspaceDatabase(db *sql.DB) error {
 			channel VARCHAR(20) NOT NULL,
 			email JSONB,
 			web JSONB,
+			sms JSONB,
 			category VARCHAR(20) NOT NULL,
 			template_macro_id VARCHAR(32),
 			integration_id VARCHAR(255),
@@ -167,6 +168,12 @@ func InitializeWorkspaceDatabase(db *sql.DB) error {
 			test_phase_recipient_count INTEGER DEFAULT 0,
 			winner_phase_recipient_count INTEGER DEFAULT 0,
 			enqueued_count INTEGER DEFAULT 0,
+			skipped_count INTEGER DEFAULT 0,
+			tags TEXT[],
+			dry_run BOOLEAN NOT NULL DEFAULT FALSE,
+			plain_text_only BOOLEAN NOT NULL DEFAULT FALSE,
+			channel_type VARCHAR(20) NOT NULL DEFAULT 'email',
+			seed_test JSONB,
 			created_at TIMESTAMP WITH TIME ZONE NOT NULL,
 			updated_at TIMESTAMP WITH TIME ZONE NOT NULL,
 			started_at TIMESTAMP WITH TIME ZONE,
@@ -180,6 +187,7 @@ func InitializeWorkspaceDatabase(db *sql.DB) error {
 			id VARCHAR(255) NOT NULL PRIMARY KEY,
 			contact_email VARCHAR(255) NOT NULL,
 			external_id VARCHAR(255),
+			idempotency_key VARCHAR(255),
 			broadcast_id VARCHAR(255),
 			automation_id VARCHAR(36),
 			list_id VARCHAR(32),
@@ -187,6 +195,7 @@ func InitializeWorkspaceDatabase(db *sql.DB) error {
 			template_version INTEGER NOT NULL,
 			channel VARCHAR(20) NOT NULL,
 			status_info VARCHAR(255),
+			bounce_category VARCHAR(20),
 			message_data JSONB NOT NULL,
 			channel_options JSONB,
 			attachments JSONB,
@@ -206,6 +215,7 @@ func InitializeWorkspaceDatabase(db *sql.DB) error {
 		`CREATE INDEX IF NOT EXISTS idx_message_history_automation_id ON message_history(automation_id) WHERE automation_id IS NOT NULL`,
 		`CREATE INDEX IF NOT EXISTS idx_message_history_template_id ON message_history(template_id, template_version)`,
 		`CREATE INDEX IF NOT EXISTS idx_message_history_created_at_id ON message_history(created_at DESC, id DESC)`,
+		`CREATE UNIQUE INDEX IF NOT EXISTS idx_message_history_idempotency_key ON message_history(idempotency_key) WHERE idempotency_key IS NOT NULL`,
 		`CREATE TABLE IF NOT EXISTS transactional_notifications (
 			id VARCHAR(32) NOT NULL PRIMARY KEY,
 			name VARCHAR(255) NOT NULL,
@@ -237,7 +247,17 @@ func InitializeWorkspaceDatabase(db *sql.DB) error {
 		`CREATE INDEX IF NOT EXISTS inbound_webhook_events_type_idx ON inbound_webhook_events (type)`,
 		`CREATE INDEX IF NOT EXISTS inbound_webhook_events_timestamp_idx ON inbound_webhook_events (timestamp DESC)`,
 		`CREATE INDEX IF NOT EXISTS inbound_webhook_events_recipient_email_idx ON inbound_webhook_events (recipient_email)`,
+		`CREATE TABLE IF NOT EXISTS inbound_webhook_payloads (
+			id UUID PRIMARY KEY,
+			integration_id VARCHAR(255) NOT NULL,
+			payload TEXT NOT NULL,
+			received_at TIMESTAMPTZ NOT NULL,
+			processed_at TIMESTAMPTZ,
+			error TEXT
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_inbound_webhook_payloads_received_at ON inbound_webhook_payloads(received_at, id)`,
 		`CREATE INDEX IF NOT EXISTS idx_broadcasts_status_testing ON broadcasts(status) WHERE status IN ('testing', 'test_completed', 'winner_selected')`,
+		`CREATE INDEX IF NOT EXISTS idx_broadcasts_tags ON broadcasts USING GIN (tags)`,
 		`CREATE TABLE IF NOT EXISTS contact_timeline (
 			id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
 			email VARCHAR(255) NOT NULL,
@@ -252,6 +272,7 @@ func InitializeWorkspaceDatabase(db *sql.DB) error {
 		`CREATE INDEX IF NOT EXISTS idx_contact_timeline_email_created_at ON contact_timeline(email, created_at DESC, id DESC)`,
 		`CREATE INDEX IF NOT EXISTS idx_contact_timeline_kind ON contact_timeline(kind)`,
 		`CREATE INDEX IF NOT EXISTS idx_contact_timeline_entity_id ON contact_timeline(entity_id) WHERE entity_id IS NOT NULL`,
+		`CREATE INDEX IF NOT EXISTS idx_contact_timeline_db_created_at ON contact_timeline(db_created_at, id)`,
 		`CREATE TABLE IF NOT EXISTS segments (
 			id VARCHAR(32) PRIMARY KEY,
 			name VARCHAR(255) NOT NULL,
@@ -277,11 +298,60 @@ func InitializeWorkspaceDatabase(db *sql.DB) error {
 		)`,
 		`CREATE INDEX IF NOT EXISTS idx_contact_segments_segment_id ON contact_segments(segment_id)`,
 		`CREATE INDEX IF NOT EXISTS idx_contact_segments_version ON contact_segments(segment_id, version)`,
+		`CREATE TABLE IF NOT EXISTS contact_segment_evaluations (
+			segment_id VARCHAR(32) NOT NULL,
+			email VARCHAR(255) NOT NULL,
+			version INTEGER NOT NULL,
+			is_member BOOLEAN NOT NULL,
+			evaluated_at TIMESTAMP WITH TIME ZONE NOT NULL,
+			PRIMARY KEY (segment_id, email)
+		)`,
 		`CREATE TABLE IF NOT EXISTS contact_segment_queue (
 			email VARCHAR(255) PRIMARY KEY,
 			queued_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
 		)`,
 		`CREATE INDEX IF NOT EXISTS idx_contact_segment_queue_queued_at ON contact_segment_queue(queued_at ASC)`,
+		`CREATE TABLE IF NOT EXISTS short_links (
+			code VARCHAR(32) PRIMARY KEY,
+			message_id VARCHAR(255) NOT NULL,
+			url TEXT NOT NULL,
+			sent_at TIMESTAMP WITH TIME ZONE NOT NULL,
+			created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE TABLE IF NOT EXISTS provider_webhook_health (
+			integration_id VARCHAR(255) PRIMARY KEY,
+			last_sent_at TIMESTAMP WITH TIME ZONE,
+			last_event_at TIMESTAMP WITH TIME ZONE,
+			pending_since TIMESTAMP WITH TIME ZONE,
+			alerted_at TIMESTAMP WITH TIME ZONE,
+			updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE TABLE IF NOT EXISTS broadcast_audience_recipients (
+			broadcast_id VARCHAR(255) NOT NULL,
+			email VARCHAR(255) NOT NULL,
+			data JSONB,
+			created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (broadcast_id, email)
+		)`,
+		`CREATE TABLE IF NOT EXISTS email_suppressions (
+			email VARCHAR(255) PRIMARY KEY,
+			reason VARCHAR(20) NOT NULL,
+			message_id VARCHAR(255),
+			created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE TABLE IF NOT EXISTS message_status_retries (
+			external_id VARCHAR(255) NOT NULL,
+			event VARCHAR(20) NOT NULL,
+			event_timestamp TIMESTAMP WITH TIME ZONE NOT NULL,
+			status_info VARCHAR(255),
+			bounce_category VARCHAR(20),
+			attempts INTEGER NOT NULL DEFAULT 0,
+			next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL,
+			last_error TEXT,
+			created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (external_id, event, event_timestamp)
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_message_status_retries_next_attempt_at ON message_status_retries(next_attempt_at)`,
 		`CREATE TABLE IF NOT EXISTS message_attachments (
 			checksum VARCHAR(64) PRIMARY KEY,
 			content BYTEA NOT NULL,
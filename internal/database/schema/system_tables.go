@@ -76,6 +76,25 @@ var TableDefinitions = []string{
 		updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
 		UNIQUE (key)
 	)`,
+	`CREATE TABLE IF NOT EXISTS revoked_tokens (
+		token_id VARCHAR(64) PRIMARY KEY,
+		expires_at TIMESTAMPTZ NOT NULL,
+		revoked_at TIMESTAMPTZ NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_revoked_tokens_expires_at ON revoked_tokens (expires_at)`,
+	`CREATE TABLE IF NOT EXISTS api_keys (
+		id UUID PRIMARY KEY,
+		workspace_id VARCHAR(20) NOT NULL,
+		name VARCHAR(255) NOT NULL,
+		key_prefix VARCHAR(16) NOT NULL,
+		key_hash VARCHAR(64) NOT NULL UNIQUE,
+		scopes JSONB NOT NULL DEFAULT '[]'::jsonb,
+		user_id UUID NOT NULL,
+		created_by UUID NOT NULL,
+		created_at TIMESTAMPTZ NOT NULL,
+		revoked_at TIMESTAMPTZ
+	)`,
+	`CREATE INDEX IF NOT EXISTS idx_api_keys_workspace_id ON api_keys (workspace_id)`,
 	`CREATE INDEX IF NOT EXISTS idx_tasks_workspace_id ON tasks (workspace_id)`,
 	`CREATE INDEX IF NOT EXISTS idx_tasks_status ON tasks (status)`,
 	`CREATE INDEX IF NOT EXISTS idx_tasks_type ON tasks (type)`,
@@ -127,4 +146,6 @@ var TableNames = []string{
 	"broadcasts",
 	"tasks",
 	"settings",
+	"revoked_tokens",
+	"api_keys",
 }
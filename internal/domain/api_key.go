@@ -0,0 +1,268 @@
+package domain
+
+//go:generate mockgen -destination mocks/mock_api_key_repository.go -package mocks github.com/Notifuse/notifuse/internal/domain APIKeyRepository
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// APIKeyPrefix starts every scoped API key, it tells them apart from JWT tokens in the Authorization header
+const APIKeyPrefix = "nfk_"
+
+// APIKeyScope grants an API key access to a resource, formatted as "<resource>:<action>"
+type APIKeyScope string
+
+const (
+	// APIKeyActionRead allows the read routes of a resource
+	APIKeyActionRead = "read"
+	// APIKeyActionWrite allows every route of a resource
+	APIKeyActionWrite = "write"
+	// APIKeyActionSend only exists for transactional notifications and allows sending them
+	APIKeyActionSend = "send"
+
+	// APIKeyScopeTransactionalSend allows sending transactional notifications without managing them
+	APIKeyScopeTransactionalSend APIKeyScope = "transactional:send"
+)
+
+// NewAPIKeyScope builds the scope of an action on a resource
+func NewAPIKeyScope(resource PermissionResource, action string) APIKeyScope {
+	return APIKeyScope(string(resource) + ":" + action)
+}
+
+// Resource returns the resource part of the scope
+func (s APIKeyScope) Resource() PermissionResource {
+	resource, _, _ := strings.Cut(string(s), ":")
+	return PermissionResource(resource)
+}
+
+// Action returns the action part of the scope
+func (s APIKeyScope) Action() string {
+	_, action, _ := strings.Cut(string(s), ":")
+	return action
+}
+
+// Validate checks the scope names a known resource and an action it supports
+func (s APIKeyScope) Validate() error {
+	if _, ok := FullPermissions[s.Resource()]; !ok {
+		return fmt.Errorf("invalid scope %q: unknown resource", s)
+	}
+	switch s.Action() {
+	case APIKeyActionRead, APIKeyActionWrite:
+		return nil
+	case APIKeyActionSend:
+		if s == APIKeyScopeTransactionalSend {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid scope %q: unknown action", s)
+}
+
+// APIKeyScopes is the scope set of an API key
+type APIKeyScopes []APIKeyScope
+
+// Allows reports whether the scope set grants the required scope.
+// A write scope also grants the read and send scopes of its resource.
+func (ss APIKeyScopes) Allows(required APIKeyScope) bool {
+	for _, scope := range ss {
+		if scope == required {
+			return true
+		}
+		if scope.Resource() == required.Resource() && scope.Action() == APIKeyActionWrite {
+			return true
+		}
+	}
+	return false
+}
+
+// Permissions returns the workspace permissions of the user backing an API key with this scope set,
+// the services keep enforcing them once the middleware allowed the route
+func (ss APIKeyScopes) Permissions() UserPermissions {
+	permissions := make(UserPermissions)
+	for _, scope := range ss {
+		resourcePermissions := permissions[scope.Resource()]
+		switch scope.Action() {
+		case APIKeyActionRead:
+			resourcePermissions.Read = true
+		case APIKeyActionWrite:
+			resourcePermissions.Read = true
+			resourcePermissions.Write = true
+		case APIKeyActionSend:
+			// Sending is checked as a transactional write by the services
+			resourcePermissions.Write = true
+		}
+		permissions[scope.Resource()] = resourcePermissions
+	}
+	return permissions
+}
+
+// Value implements the driver.Valuer interface for database serialization
+func (ss APIKeyScopes) Value() (driver.Value, error) {
+	if ss == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(ss)
+}
+
+// Scan implements the sql.Scanner interface for database deserialization
+func (ss *APIKeyScopes) Scan(value interface{}) error {
+	if value == nil {
+		*ss = APIKeyScopes{}
+		return nil
+	}
+
+	v, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("type assertion to []byte failed")
+	}
+
+	cloned := bytes.Clone(v)
+	return json.Unmarshal(cloned, ss)
+}
+
+// apiKeyRouteResources maps the route prefixes usable with an API key to the resource they belong to.
+// Routes missing here (users, setup, tasks, API keys themselves...) can't be called with an API key.
+var apiKeyRouteResources = map[string]PermissionResource{
+	"contacts":             PermissionResourceContacts,
+	"contactLists":         PermissionResourceLists,
+	"lists":                PermissionResourceLists,
+	"segments":             PermissionResourceContacts,
+	"customEvents":         PermissionResourceContacts,
+	"timeline":             PermissionResourceContacts,
+	"templates":            PermissionResourceTemplates,
+	"templateBlocks":       PermissionResourceTemplates,
+	"broadcasts":           PermissionResourceBroadcasts,
+	"transactional":        PermissionResourceTransactional,
+	"messages":             PermissionResourceMessageHistory,
+	"analytics":            PermissionResourceMessageHistory,
+	"blogCategories":       PermissionResourceBlog,
+	"blogPosts":            PermissionResourceBlog,
+	"blogThemes":           PermissionResourceBlog,
+	"automations":          PermissionResourceAutomations,
+	"llm":                  PermissionResourceLLM,
+	"workspaces":           PermissionResourceWorkspace,
+	"webhookSubscriptions": PermissionResourceWorkspace,
+}
+
+// APIKeyScopeForRoute returns the scope an API key needs to call a route.
+// GET routes need the read scope of their resource, the others the write scope,
+// except /api/transactional.send that only needs transactional:send.
+// ok is false for the routes API keys can't call.
+func APIKeyScopeForRoute(method, path string) (scope APIKeyScope, ok bool) {
+	name, found := strings.CutPrefix(path, "/api/")
+	if !found {
+		return "", false
+	}
+	prefix, operation, _ := strings.Cut(name, ".")
+	resource, ok := apiKeyRouteResources[prefix]
+	if !ok {
+		return "", false
+	}
+
+	if resource == PermissionResourceTransactional && operation == "send" {
+		return APIKeyScopeTransactionalSend, true
+	}
+	if method == http.MethodGet || method == http.MethodHead {
+		return NewAPIKeyScope(resource, APIKeyActionRead), true
+	}
+	return NewAPIKeyScope(resource, APIKeyActionWrite), true
+}
+
+// APIKey is a long-lived key giving programmatic access to a workspace within its scopes.
+// Only a hash of the key is stored, the key itself is returned once at creation.
+type APIKey struct {
+	ID          string `json:"id"`
+	WorkspaceID string `json:"workspace_id"`
+	Name        string `json:"name"`
+	// KeyPrefix holds the first characters of the key to recognize it in the console
+	KeyPrefix string       `json:"key_prefix"`
+	KeyHash   string       `json:"-"`
+	Scopes    APIKeyScopes `json:"scopes"`
+	// UserID is the API user the key authenticates as, a workspace member with the permissions of the scopes
+	UserID    string     `json:"user_id"`
+	CreatedBy string     `json:"created_by"`
+	CreatedAt time.Time  `json:"created_at"`
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+}
+
+// IsRevoked reports whether the key was revoked
+func (k *APIKey) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
+
+// CreateScopedAPIKeyRequest defines the request structure for creating a scoped API key
+type CreateScopedAPIKeyRequest struct {
+	WorkspaceID string        `json:"workspace_id"`
+	Name        string        `json:"name"`
+	Scopes      []APIKeyScope `json:"scopes"`
+}
+
+// Validate validates the create scoped API key request
+func (r *CreateScopedAPIKeyRequest) Validate() error {
+	if r.WorkspaceID == "" {
+		return fmt.Errorf("workspace_id is required")
+	}
+	if r.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+	if len(r.Name) > 255 {
+		return fmt.Errorf("name must be less than 255 characters")
+	}
+	if len(r.Scopes) == 0 {
+		return fmt.Errorf("at least one scope is required")
+	}
+	for _, scope := range r.Scopes {
+		if err := scope.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CreateScopedAPIKeyResponse returns the key once, it can't be retrieved afterwards
+type CreateScopedAPIKeyResponse struct {
+	APIKey *APIKey `json:"api_key"`
+	Key    string  `json:"key"`
+}
+
+// RevokeAPIKeyRequest defines the request structure for revoking an API key
+type RevokeAPIKeyRequest struct {
+	WorkspaceID string `json:"workspace_id"`
+	ID          string `json:"id"`
+}
+
+// Validate validates the revoke API key request
+func (r *RevokeAPIKeyRequest) Validate() error {
+	if r.WorkspaceID == "" {
+		return fmt.Errorf("workspace_id is required")
+	}
+	if r.ID == "" {
+		return fmt.Errorf("id is required")
+	}
+	return nil
+}
+
+// ErrAPIKeyNotFound is returned when an API key doesn't exist or belongs to another workspace
+type ErrAPIKeyNotFound struct {
+	Message string
+}
+
+func (e *ErrAPIKeyNotFound) Error() string {
+	return e.Message
+}
+
+// APIKeyRepository defines the interface for API key data access
+type APIKeyRepository interface {
+	Create(ctx context.Context, key *APIKey) error
+	// GetByHash returns the key with the given hash, revoked or not
+	GetByHash(ctx context.Context, keyHash string) (*APIKey, error)
+	GetByID(ctx context.Context, workspaceID, id string) (*APIKey, error)
+	List(ctx context.Context, workspaceID string) ([]*APIKey, error)
+	Revoke(ctx context.Context, workspaceID, id string, revokedAt time.Time) error
+}
@@ -0,0 +1,109 @@
+package domain
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIKeyScope_Validate(t *testing.T) {
+	valid := []APIKeyScope{"contacts:read", "contacts:write", "broadcasts:read", "transactional:send", "message_history:read"}
+	for _, scope := range valid {
+		assert.NoError(t, scope.Validate(), scope)
+	}
+
+	invalid := []APIKeyScope{"", "contacts", "contacts:delete", "unknown:read", "contacts:send", ":read"}
+	for _, scope := range invalid {
+		assert.Error(t, scope.Validate(), scope)
+	}
+}
+
+func TestAPIKeyScopes_Allows(t *testing.T) {
+	sendOnly := APIKeyScopes{APIKeyScopeTransactionalSend}
+	assert.True(t, sendOnly.Allows(APIKeyScopeTransactionalSend))
+	assert.False(t, sendOnly.Allows("transactional:write"))
+	assert.False(t, sendOnly.Allows("transactional:read"))
+	assert.False(t, sendOnly.Allows("contacts:write"))
+
+	// A write scope grants the read and send scopes of its resource
+	writer := APIKeyScopes{"transactional:write", "contacts:read"}
+	assert.True(t, writer.Allows("transactional:read"))
+	assert.True(t, writer.Allows(APIKeyScopeTransactionalSend))
+	assert.True(t, writer.Allows("contacts:read"))
+	assert.False(t, writer.Allows("contacts:write"))
+
+	assert.False(t, APIKeyScopes{}.Allows("contacts:read"))
+}
+
+func TestAPIKeyScopes_Permissions(t *testing.T) {
+	permissions := APIKeyScopes{"contacts:read", "broadcasts:write", APIKeyScopeTransactionalSend}.Permissions()
+
+	assert.Equal(t, UserPermissions{
+		PermissionResourceContacts:      ResourcePermissions{Read: true},
+		PermissionResourceBroadcasts:    ResourcePermissions{Read: true, Write: true},
+		PermissionResourceTransactional: ResourcePermissions{Write: true},
+	}, permissions)
+}
+
+func TestAPIKeyScopes_ValueScan(t *testing.T) {
+	scopes := APIKeyScopes{"contacts:read", APIKeyScopeTransactionalSend}
+	value, err := scopes.Value()
+	assert.NoError(t, err)
+
+	var scanned APIKeyScopes
+	assert.NoError(t, scanned.Scan(value))
+	assert.Equal(t, scopes, scanned)
+
+	assert.NoError(t, scanned.Scan(nil))
+	assert.Empty(t, scanned)
+	assert.Error(t, scanned.Scan("not bytes"))
+}
+
+func TestAPIKeyScopeForRoute(t *testing.T) {
+	tests := []struct {
+		method string
+		path   string
+		scope  APIKeyScope
+		ok     bool
+	}{
+		{http.MethodPost, "/api/contacts.upsert", "contacts:write", true},
+		{http.MethodGet, "/api/contacts.list", "contacts:read", true},
+		{http.MethodGet, "/api/broadcasts.get", "broadcasts:read", true},
+		{http.MethodPost, "/api/transactional.send", APIKeyScopeTransactionalSend, true},
+		{http.MethodPost, "/api/transactional.create", "transactional:write", true},
+		{http.MethodPost, "/api/contactLists.updateStatus", "lists:write", true},
+		{http.MethodGet, "/api/messages.list", "message_history:read", true},
+		{http.MethodPost, "/api/apiKeys.create", "", false},
+		{http.MethodGet, "/api/user.me", "", false},
+		{http.MethodGet, "/api/tasks.list", "", false},
+		{http.MethodGet, "/visit", "", false},
+	}
+
+	for _, tt := range tests {
+		scope, ok := APIKeyScopeForRoute(tt.method, tt.path)
+		assert.Equal(t, tt.ok, ok, tt.path)
+		assert.Equal(t, tt.scope, scope, tt.path)
+	}
+}
+
+func TestCreateScopedAPIKeyRequest_Validate(t *testing.T) {
+	valid := CreateScopedAPIKeyRequest{WorkspaceID: "ws1", Name: "Sender", Scopes: []APIKeyScope{APIKeyScopeTransactionalSend}}
+	assert.NoError(t, valid.Validate())
+
+	missingWorkspace := valid
+	missingWorkspace.WorkspaceID = ""
+	assert.EqualError(t, missingWorkspace.Validate(), "workspace_id is required")
+
+	missingName := valid
+	missingName.Name = ""
+	assert.EqualError(t, missingName.Validate(), "name is required")
+
+	noScopes := valid
+	noScopes.Scopes = nil
+	assert.EqualError(t, noScopes.Validate(), "at least one scope is required")
+
+	badScope := valid
+	badScope.Scopes = []APIKeyScope{"contacts:delete"}
+	assert.Error(t, badScope.Validate())
+}
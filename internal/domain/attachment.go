@@ -200,6 +200,32 @@ func (a *Attachment) ToMetadata(checksum string) *AttachmentMetadata {
 	}
 }
 
+// AttachmentsMetadata returns the metadata recorded in message_history for the attachments of a message,
+// the content itself isn't kept
+func AttachmentsMetadata(attachments []Attachment) ([]AttachmentMetadata, error) {
+	if len(attachments) == 0 {
+		return nil, nil
+	}
+
+	metadata := make([]AttachmentMetadata, 0, len(attachments))
+	for i, att := range attachments {
+		if err := att.DetectContentType(); err != nil {
+			return nil, fmt.Errorf("attachment %d: failed to detect content type: %w", i, err)
+		}
+		if att.Disposition == "" {
+			att.Disposition = "attachment"
+		}
+
+		checksum, err := att.CalculateChecksum()
+		if err != nil {
+			return nil, fmt.Errorf("attachment %d: failed to calculate checksum: %w", i, err)
+		}
+		metadata = append(metadata, *att.ToMetadata(checksum))
+	}
+
+	return metadata, nil
+}
+
 // ValidateAttachments validates a slice of attachments
 func ValidateAttachments(attachments []Attachment) error {
 	if len(attachments) == 0 {
@@ -1,7 +1,9 @@
 package domain
 
 import (
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"strings"
 	"testing"
 
@@ -406,6 +408,34 @@ func TestAttachment_ToMetadata(t *testing.T) {
 	assert.Equal(t, att.Disposition, metadata.Disposition)
 }
 
+func TestAttachmentsMetadata(t *testing.T) {
+	pdf := []byte("%PDF-1.4\n1 0 obj<</Type/Catalog>>endobj\ntrailer<</Root 1 0 R>>\n%%EOF")
+	pdfChecksum := sha256.Sum256(pdf)
+
+	metadata, err := AttachmentsMetadata([]Attachment{
+		{Filename: "invoice.pdf", Content: base64.StdEncoding.EncodeToString(pdf)},
+		{Filename: "logo.png", Content: base64.StdEncoding.EncodeToString([]byte("png")), ContentType: "image/png", Disposition: "inline"},
+	})
+	require.NoError(t, err)
+	require.Len(t, metadata, 2)
+
+	assert.Equal(t, AttachmentMetadata{
+		Checksum:    hex.EncodeToString(pdfChecksum[:]),
+		Filename:    "invoice.pdf",
+		ContentType: "application/pdf",
+		Disposition: "attachment",
+	}, metadata[0])
+	assert.Equal(t, "image/png", metadata[1].ContentType)
+	assert.Equal(t, "inline", metadata[1].Disposition)
+
+	metadata, err = AttachmentsMetadata(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, metadata)
+
+	_, err = AttachmentsMetadata([]Attachment{{Filename: "invoice.pdf", Content: "not base64!"}})
+	assert.Error(t, err)
+}
+
 func TestValidateAttachments(t *testing.T) {
 	validContent := base64.StdEncoding.EncodeToString([]byte("test content"))
 	largeContent := base64.StdEncoding.EncodeToString(make([]byte, 4*1024*1024)) // 4MB
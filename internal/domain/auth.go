@@ -19,6 +19,10 @@ const WorkspaceIDKey ContextKey = "workspace_id"
 type AuthRepository interface {
 	GetSessionByID(ctx context.Context, sessionID string, userID string) (*time.Time, error)
 	GetUserByID(ctx context.Context, userID string) (*User, error)
+	// RevokeToken adds a token ID to the revocation list until the token expires
+	RevokeToken(ctx context.Context, tokenID string, expiresAt time.Time) error
+	// IsTokenRevoked reports whether an unexpired token ID is on the revocation list
+	IsTokenRevoked(ctx context.Context, tokenID string) (bool, error)
 }
 
 type AuthService interface {
@@ -30,4 +34,6 @@ type AuthService interface {
 	GenerateInvitationToken(invitation *WorkspaceInvitation) string
 	ValidateInvitationToken(token string) (invitationID, workspaceID, email string, err error)
 	InvalidateSecretCache()
+	RevokeToken(ctx context.Context, tokenID string, expiresAt time.Time) error
+	IsTokenRevoked(ctx context.Context, tokenID string) (bool, error)
 }
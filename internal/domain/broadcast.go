@@ -3,12 +3,21 @@ package domain
 import (
 	"bytes"
 	"context"
+	"crypto/hmac"
 	"database/sql"
 	"database/sql/driver"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/url"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
+
+	"github.com/Notifuse/notifuse/pkg/crypto"
+	"github.com/asaskevich/govalidator"
 )
 
 //go:generate mockgen -destination mocks/mock_broadcast_service.go -package mocks github.com/Notifuse/notifuse/internal/domain BroadcastService
@@ -21,24 +30,80 @@ type BroadcastStatus string
 const (
 	BroadcastStatusDraft          BroadcastStatus = "draft"
 	BroadcastStatusScheduled      BroadcastStatus = "scheduled"
-	BroadcastStatusProcessing     BroadcastStatus = "processing"      // Orchestrator is enqueueing emails
+	BroadcastStatusStartingSoon   BroadcastStatus = "starting_soon" // Workspace send cool-off, can still be cancelled
+	BroadcastStatusProcessing     BroadcastStatus = "processing"    // Orchestrator is enqueueing emails
 	BroadcastStatusPaused         BroadcastStatus = "paused"
-	BroadcastStatusProcessed      BroadcastStatus = "processed"       // Enqueueing complete
+	BroadcastStatusQuotaExceeded  BroadcastStatus = "quota_exceeded" // Workspace daily send quota reached, resumes the next UTC day
+	BroadcastStatusProcessed      BroadcastStatus = "processed"      // Enqueueing complete
 	BroadcastStatusCancelled      BroadcastStatus = "cancelled"
 	BroadcastStatusFailed         BroadcastStatus = "failed"
 	BroadcastStatusTesting        BroadcastStatus = "testing"         // A/B test in progress
 	BroadcastStatusTestCompleted  BroadcastStatus = "test_completed"  // Test done, awaiting winner selection
 	BroadcastStatusWinnerSelected BroadcastStatus = "winner_selected" // Winner chosen, enqueueing to remaining
+	BroadcastStatusSeedPending    BroadcastStatus = "seed_pending"    // Seed test sent, awaiting approval before the main send
 )
 
+// BroadcastPhase returns the phase name published for a broadcast status, where
+// processing is "sending" and processed is "sent"
+func BroadcastPhase(status BroadcastStatus) string {
+	switch status {
+	case BroadcastStatusProcessing:
+		return "sending"
+	case BroadcastStatusProcessed:
+		return "sent"
+	}
+	return string(status)
+}
+
+// NewBroadcastPhaseChangedEvent builds the event published when a broadcast moves from one status to another
+func NewBroadcastPhaseChangedEvent(broadcast *Broadcast, from BroadcastStatus, occurredAt time.Time) EventPayload {
+	return EventPayload{
+		Type:        EventBroadcastPhaseChanged,
+		WorkspaceID: broadcast.WorkspaceID,
+		EntityID:    broadcast.ID,
+		Data: map[string]interface{}{
+			"broadcast_id":   broadcast.ID,
+			"broadcast_name": broadcast.Name,
+			"old_status":     string(from),
+			"new_status":     string(broadcast.Status),
+			"old_phase":      BroadcastPhase(from),
+			"phase":          BroadcastPhase(broadcast.Status),
+			"occurred_at":    occurredAt.UTC().Format(time.RFC3339),
+		},
+	}
+}
+
 // TestWinnerMetric defines the metric used to determine the winning A/B test variation
 type TestWinnerMetric string
 
 const (
-	TestWinnerMetricOpenRate  TestWinnerMetric = "open_rate"
-	TestWinnerMetricClickRate TestWinnerMetric = "click_rate"
+	TestWinnerMetricOpenRate              TestWinnerMetric = "open_rate"
+	TestWinnerMetricClickRate             TestWinnerMetric = "click_rate"
+	TestWinnerMetricClickToOpenRate       TestWinnerMetric = "click_to_open_rate"      // Clicks among opened emails
+	TestWinnerMetricLowestUnsubscribeRate TestWinnerMetric = "lowest_unsubscribe_rate" // Fewest unsubscribes among delivered emails
 )
 
+// TestWinnerMetricManual is reported when the winning variation was selected by a user
+const TestWinnerMetricManual TestWinnerMetric = "manual"
+
+// BroadcastVariationOutcome holds the stats of an A/B test variation when the broadcast completes
+type BroadcastVariationOutcome struct {
+	VariationName string `json:"variation_name"`
+	TemplateID    string `json:"template_id"`
+	Sent          int    `json:"sent"`
+	Delivered     int    `json:"delivered"`
+	Opens         int    `json:"opens"`
+	Clicks        int    `json:"clicks"`
+}
+
+// BroadcastTestOutcome summarizes the A/B test of a completed broadcast, it is added to the
+// broadcast.phase_changed event published when an A/B broadcast is sent
+type BroadcastTestOutcome struct {
+	Variations      []BroadcastVariationOutcome `json:"variations"`
+	WinningTemplate string                      `json:"winning_template,omitempty"`
+	WinnerMetric    TestWinnerMetric            `json:"winner_metric"`
+}
+
 // BroadcastTestSettings contains configuration for A/B testing
 type BroadcastTestSettings struct {
 	Enabled              bool                 `json:"enabled"`
@@ -46,6 +111,7 @@ type BroadcastTestSettings struct {
 	AutoSendWinner       bool                 `json:"auto_send_winner"`
 	AutoSendWinnerMetric TestWinnerMetric     `json:"auto_send_winner_metric,omitempty"`
 	TestDurationHours    int                  `json:"test_duration_hours,omitempty"`
+	MinConfidence        float64              `json:"min_confidence,omitempty"` // e.g. 0.95, the auto winner must beat the runner-up with this confidence
 	Variations           []BroadcastVariation `json:"variations"`
 }
 
@@ -54,6 +120,15 @@ func (b BroadcastTestSettings) Value() (driver.Value, error) {
 	return json.Marshal(b)
 }
 
+// TemplateIDs returns the template IDs of the variations, in the order of the variations
+func (b BroadcastTestSettings) TemplateIDs() []string {
+	templateIDs := make([]string, 0, len(b.Variations))
+	for _, variation := range b.Variations {
+		templateIDs = append(templateIDs, variation.TemplateID)
+	}
+	return templateIDs
+}
+
 // Scan implements the sql.Scanner interface for database deserialization
 func (b *BroadcastTestSettings) Scan(value interface{}) error {
 	if value == nil {
@@ -74,6 +149,8 @@ type BroadcastVariation struct {
 	VariationName string            `json:"variation_name"`
 	TemplateID    string            `json:"template_id"`
 	Metrics       *VariationMetrics `json:"metrics,omitempty"`
+	// TemplateVersion is the template version pinned when sending started, 0 until then
+	TemplateVersion int64 `json:"template_version,omitempty"`
 	// joined servers-side
 	Template *Template `json:"template,omitempty"`
 }
@@ -98,6 +175,108 @@ func (v *BroadcastVariation) Scan(value interface{}) error {
 	return json.Unmarshal(cloned, v)
 }
 
+const (
+	// MaxSeedTestEmails is the maximum number of seed addresses of a broadcast seed test
+	MaxSeedTestEmails = 50
+
+	SeedTestStatusSent   = "sent"
+	SeedTestStatusFailed = "failed"
+)
+
+// BroadcastSeedTest configures the seed test of a broadcast: before any recipient, the broadcast is sent
+// to internal seed addresses, e.g. one inbox per mailbox provider, to check where it lands. When approval
+// is required, the main send is held in the seed_pending status until the seed test is approved.
+type BroadcastSeedTest struct {
+	Enabled         bool     `json:"enabled"`
+	Emails          []string `json:"emails,omitempty"`
+	RequireApproval bool     `json:"require_approval"`
+	// Set when the seed addresses have been sent to, the outcome of each is kept in Results
+	SentAt     *time.Time       `json:"sent_at,omitempty"`
+	ApprovedAt *time.Time       `json:"approved_at,omitempty"`
+	Results    []SeedTestResult `json:"results,omitempty"`
+}
+
+// SeedTestResult is the outcome of the seed test message of a seed address. Its delivery events are
+// recorded in the message history of the broadcast, like those of any recipient.
+type SeedTestResult struct {
+	Email           string `json:"email"`
+	MailboxProvider string `json:"mailbox_provider"` // Domain of the seed address, e.g. gmail.com
+	Status          string `json:"status"`           // sent or failed
+}
+
+// Value implements the driver.Valuer interface for database serialization
+func (s BroadcastSeedTest) Value() (driver.Value, error) {
+	return json.Marshal(s)
+}
+
+// Scan implements the sql.Scanner interface for database deserialization
+func (s *BroadcastSeedTest) Scan(value interface{}) error {
+	if value == nil {
+		return nil
+	}
+
+	b, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("type assertion to []byte failed")
+	}
+
+	cloned := bytes.Clone(b)
+	return json.Unmarshal(cloned, s)
+}
+
+// Validate checks the seed addresses of an enabled seed test
+func (s BroadcastSeedTest) Validate() error {
+	if len(s.Emails) == 0 {
+		return fmt.Errorf("at least one seed email is required for the seed test")
+	}
+	if len(s.Emails) > MaxSeedTestEmails {
+		return fmt.Errorf("maximum %d seed emails are allowed for the seed test", MaxSeedTestEmails)
+	}
+
+	seen := make(map[string]bool, len(s.Emails))
+	for _, email := range s.Emails {
+		if !govalidator.IsEmail(email) {
+			return fmt.Errorf("invalid seed email: %s", email)
+		}
+		key := strings.ToLower(email)
+		if seen[key] {
+			return fmt.Errorf("duplicate seed email: %s", email)
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+// withOutcomeFrom keeps the send and approval of an existing seed test, they are not set by requests
+func (s BroadcastSeedTest) withOutcomeFrom(existing BroadcastSeedTest) BroadcastSeedTest {
+	s.SentAt = existing.SentAt
+	s.ApprovedAt = existing.ApprovedAt
+	s.Results = existing.Results
+	return s
+}
+
+// NewSeedTestResults returns the outcome of each seed address from the result of their batch,
+// the addresses the batch did not process are failed
+func NewSeedTestResults(emails []string, result BatchSendResult) []SeedTestResult {
+	sent := make(map[string]bool, len(result.SentEmails))
+	for _, email := range result.SentEmails {
+		sent[email] = true
+	}
+
+	results := make([]SeedTestResult, 0, len(emails))
+	for _, email := range emails {
+		seedResult := SeedTestResult{Email: email, Status: SeedTestStatusFailed}
+		if at := strings.LastIndex(email, "@"); at >= 0 {
+			seedResult.MailboxProvider = strings.ToLower(email[at+1:])
+		}
+		if sent[email] {
+			seedResult.Status = SeedTestStatusSent
+		}
+		results = append(results, seedResult)
+	}
+	return results
+}
+
 // VariationMetrics contains performance metrics for a variation
 type VariationMetrics struct {
 	Recipients   int `json:"recipients"`
@@ -134,6 +313,21 @@ type AudienceSettings struct {
 	List                string   `json:"list,omitempty"`
 	Segments            []string `json:"segments,omitempty"`
 	ExcludeUnsubscribed bool     `json:"exclude_unsubscribed"`
+	// Lists sends to the members of several lists, in priority order: a contact member of several of them
+	// receives a single email, as a member of the first one. When set, List is empty or the first of Lists.
+	Lists []string `json:"lists,omitempty"`
+	// ListTemplates overrides the template sent to the members of a list, by list ID. The members of the
+	// other lists receive the template of the broadcast.
+	ListTemplates map[string]string `json:"list_templates,omitempty"`
+	// CSV sends to the recipients uploaded with broadcasts.uploadAudience instead of the list members,
+	// the list is still used for unsubscribe links and to exclude its unsubscribed contacts
+	CSV bool `json:"csv,omitempty"`
+	// MinHoursSinceLastMessage excludes the contacts that received any message within this many hours,
+	// 0 disables the rule
+	MinHoursSinceLastMessage int `json:"min_hours_since_last_message,omitempty"`
+	// OptimizeSendTime delivers each email within the next 24 hours at the hour of day the contact most
+	// frequently opened past messages, contacts without open history are sent to immediately
+	OptimizeSendTime bool `json:"optimize_send_time,omitempty"`
 }
 
 // Value implements the driver.Valuer interface for database serialization
@@ -156,6 +350,67 @@ func (a *AudienceSettings) Scan(value interface{}) error {
 	return json.Unmarshal(cloned, a)
 }
 
+// TargetLists returns the lists of the audience in priority order
+func (a AudienceSettings) TargetLists() []string {
+	if len(a.Lists) > 0 {
+		return a.Lists
+	}
+	if a.List != "" {
+		return []string{a.List}
+	}
+	return nil
+}
+
+// PrimaryList returns the list of highest priority. It is the list of the recipients that are not
+// selected as members of a list, such as the seed addresses and the CSV recipients.
+func (a AudienceSettings) PrimaryList() string {
+	if len(a.Lists) > 0 {
+		return a.Lists[0]
+	}
+	return a.List
+}
+
+// TemplateForList returns the template sent to the members of a list, templateID when the list has no override
+func (a AudienceSettings) TemplateForList(listID, templateID string) string {
+	if override, ok := a.ListTemplates[listID]; ok {
+		return override
+	}
+	return templateID
+}
+
+// validateLists validates the lists of the audience and their template overrides
+func (a AudienceSettings) validateLists() error {
+	if len(a.Lists) > 0 && a.List != "" && a.List != a.Lists[0] {
+		return fmt.Errorf("list must be the first of lists")
+	}
+
+	seen := make(map[string]bool, len(a.Lists))
+	for _, listID := range a.Lists {
+		if listID == "" {
+			return fmt.Errorf("lists cannot contain an empty list ID")
+		}
+		if seen[listID] {
+			return fmt.Errorf("list %s is repeated in lists", listID)
+		}
+		seen[listID] = true
+	}
+
+	if a.CSV && len(a.Lists) > 1 {
+		return fmt.Errorf("a CSV audience can only use one list")
+	}
+
+	targets := a.TargetLists()
+	for listID, templateID := range a.ListTemplates {
+		if templateID == "" {
+			return fmt.Errorf("template_id is required for the template of list %s", listID)
+		}
+		if !slices.Contains(targets, listID) {
+			return fmt.Errorf("list_templates references list %s which is not in the audience", listID)
+		}
+	}
+	return nil
+}
+
 // ScheduleSettings defines when a broadcast will be sent
 type ScheduleSettings struct {
 	IsScheduled          bool   `json:"is_scheduled"`
@@ -163,6 +418,22 @@ type ScheduleSettings struct {
 	ScheduledTime        string `json:"scheduled_time,omitempty"` // Format: HH:mm
 	Timezone             string `json:"timezone,omitempty"`       // IANA timezone format, e.g. "America/New_York"
 	UseRecipientTimezone bool   `json:"use_recipient_timezone"`
+	IgnoreQuietHours     bool   `json:"ignore_quiet_hours"` // Send immediately even during workspace quiet hours
+	// SendCutoffAt is a hard stop: recipients not sent to by then are skipped
+	SendCutoffAt *time.Time `json:"send_cutoff_at,omitempty"`
+	// MaxSendsPerSecond caps the send rate of this broadcast, 0 uses the instance default
+	MaxSendsPerSecond int `json:"max_sends_per_second,omitempty"`
+	// CoolOffUntil is when a broadcast sent during the workspace send cool-off starts sending
+	CoolOffUntil *time.Time `json:"cool_off_until,omitempty"`
+}
+
+// ErrBroadcastSendCutoffReached is reported when a queued broadcast email is dropped
+// because the broadcast send cutoff passed before it could be sent
+var ErrBroadcastSendCutoffReached = errors.New("broadcast send cutoff reached: email skipped")
+
+// CutoffReached returns true when the broadcast has a send cutoff and it is at or before now
+func (s *ScheduleSettings) CutoffReached(now time.Time) bool {
+	return s.SendCutoffAt != nil && !now.Before(*s.SendCutoffAt)
 }
 
 // Value implements the driver.Valuer interface for database serialization
@@ -185,7 +456,8 @@ func (s *ScheduleSettings) Scan(value interface{}) error {
 	return json.Unmarshal(cloned, s)
 }
 
-// ParseScheduledDateTime parses the ScheduledDate and ScheduledTime fields and returns a time.Time
+// ParseScheduledDateTime parses the ScheduledDate and ScheduledTime fields and returns a time.Time,
+// the wall-clock time is read in the schedule timezone
 func (s *ScheduleSettings) ParseScheduledDateTime() (time.Time, error) {
 	if s.ScheduledDate == "" || s.ScheduledTime == "" {
 		return time.Time{}, nil
@@ -210,6 +482,15 @@ func (s *ScheduleSettings) ParseScheduledDateTime() (time.Time, error) {
 		if err != nil {
 			return time.Time{}, err
 		}
+
+		// A wall-clock time skipped by a DST transition is moved forward by the gap, so that
+		// 02:30 on a spring-forward day sends at 03:30 rather than an hour early. Ambiguous
+		// times repeated by a fall-back transition resolve to their first occurrence.
+		if t.Format("2006-01-02 15:04") != datetime {
+			_, offset := t.Zone()
+			naive, _ := time.Parse("2006-01-02 15:04", datetime)
+			t = naive.Add(-time.Duration(offset) * time.Second).In(loc)
+		}
 	}
 
 	return t, nil
@@ -244,19 +525,24 @@ type Broadcast struct {
 	ID                        string                `json:"id"`
 	WorkspaceID               string                `json:"workspace_id"`
 	Name                      string                `json:"name"`
-	ChannelType               string                `json:"channel_type"` // email, sms, push, etc.
+	ChannelType               string                `json:"channel_type"` // email or sms, defaults to email
 	Status                    BroadcastStatus       `json:"status"`       // pending, sending, completed, failed
 	Audience                  AudienceSettings      `json:"audience"`
 	Schedule                  ScheduleSettings      `json:"schedule"`
 	TestSettings              BroadcastTestSettings `json:"test_settings"`
+	SeedTest                  BroadcastSeedTest     `json:"seed_test"`
 	UTMParameters             *UTMParameters        `json:"utm_parameters,omitempty"`
 	Metadata                  MapOfAny              `json:"metadata,omitempty"`
+	Tags                      []string              `json:"tags,omitempty"`  // Free-form workspace labels used to organize and filter broadcasts
+	DryRun                    bool                  `json:"dry_run"`         // Render and record messages in message history without delivering them
+	PlainTextOnly             bool                  `json:"plain_text_only"` // Send only a text/plain part, without HTML or tracking pixel
 	WinningTemplate           *string               `json:"winning_template,omitempty"`
 	TestSentAt                *time.Time            `json:"test_sent_at,omitempty"`
 	WinnerSentAt              *time.Time            `json:"winner_sent_at,omitempty"`
 	TestPhaseRecipientCount   int                   `json:"test_phase_recipient_count"`
 	WinnerPhaseRecipientCount int                   `json:"winner_phase_recipient_count"`
 	EnqueuedCount             int                   `json:"enqueued_count"` // Emails added to queue
+	SkippedCount              int                   `json:"skipped_count"`  // Recipients skipped because the send cutoff was reached
 	CreatedAt                 time.Time             `json:"created_at"`
 	UpdatedAt                 time.Time             `json:"updated_at"`
 	StartedAt                 *time.Time            `json:"started_at,omitempty"`
@@ -295,6 +581,42 @@ func (u *UTMParameters) Scan(value interface{}) error {
 	return json.Unmarshal(cloned, u)
 }
 
+const (
+	// MaxBroadcastTags is the maximum number of tags on a single broadcast
+	MaxBroadcastTags = 20
+	// MaxBroadcastTagLength is the maximum length of a broadcast tag
+	MaxBroadcastTagLength = 50
+)
+
+// NormalizeBroadcastTags trims the tags, drops empty ones and case-insensitive duplicates
+// (keeping the first spelling) and checks the tag count and length limits.
+// It returns nil when no tag is left.
+func NormalizeBroadcastTags(tags []string) ([]string, error) {
+	var normalized []string
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" {
+			continue
+		}
+		if len(tag) > MaxBroadcastTagLength {
+			return nil, fmt.Errorf("tag %q must be at most %d characters", tag, MaxBroadcastTagLength)
+		}
+		key := strings.ToLower(tag)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		normalized = append(normalized, tag)
+	}
+
+	if len(normalized) > MaxBroadcastTags {
+		return nil, fmt.Errorf("a broadcast can have at most %d tags", MaxBroadcastTags)
+	}
+
+	return normalized, nil
+}
+
 // Validate validates the broadcast struct
 func (b *Broadcast) Validate() error {
 	if b.WorkspaceID == "" {
@@ -311,10 +633,10 @@ func (b *Broadcast) Validate() error {
 
 	// Validate status
 	switch b.Status {
-	case BroadcastStatusDraft, BroadcastStatusScheduled, BroadcastStatusProcessing,
-		BroadcastStatusPaused, BroadcastStatusProcessed, BroadcastStatusCancelled,
+	case BroadcastStatusDraft, BroadcastStatusScheduled, BroadcastStatusStartingSoon, BroadcastStatusProcessing,
+		BroadcastStatusPaused, BroadcastStatusQuotaExceeded, BroadcastStatusProcessed, BroadcastStatusCancelled,
 		BroadcastStatusFailed, BroadcastStatusTesting, BroadcastStatusTestCompleted,
-		BroadcastStatusWinnerSelected:
+		BroadcastStatusWinnerSelected, BroadcastStatusSeedPending:
 		// Valid status
 	default:
 		return fmt.Errorf("invalid broadcast status: %s", b.Status)
@@ -349,11 +671,16 @@ func (b *Broadcast) Validate() error {
 			}
 
 			switch b.TestSettings.AutoSendWinnerMetric {
-			case TestWinnerMetricOpenRate, TestWinnerMetricClickRate:
+			case TestWinnerMetricOpenRate, TestWinnerMetricClickRate,
+				TestWinnerMetricClickToOpenRate, TestWinnerMetricLowestUnsubscribeRate:
 				// Valid metric
 			default:
 				return fmt.Errorf("invalid test winner metric: %s", b.TestSettings.AutoSendWinnerMetric)
 			}
+
+			if b.TestSettings.MinConfidence < 0 || b.TestSettings.MinConfidence >= 1 {
+				return fmt.Errorf("min confidence must be at least 0 and less than 1")
+			}
 		}
 
 		// Validate variations
@@ -364,12 +691,53 @@ func (b *Broadcast) Validate() error {
 		}
 	}
 
+	// Validate seed test settings if enabled
+	if b.SeedTest.Enabled {
+		if err := b.SeedTest.Validate(); err != nil {
+			return err
+		}
+	}
+
 	// Validate audience settings
 	// CHANGED: List is required (for all broadcasts, not just web)
-	if b.Audience.List == "" {
+	if b.Audience.PrimaryList() == "" {
 		return fmt.Errorf("list is required")
 	}
 
+	if err := b.Audience.validateLists(); err != nil {
+		return err
+	}
+
+	// The variations of an A/B test are sent to every list
+	if b.TestSettings.Enabled && len(b.Audience.ListTemplates) > 0 {
+		return fmt.Errorf("list templates cannot be used with A/B testing")
+	}
+
+	if b.Audience.CSV && len(b.Audience.Segments) > 0 {
+		return fmt.Errorf("segments cannot be used with a CSV audience")
+	}
+
+	if b.Audience.MinHoursSinceLastMessage < 0 {
+		return fmt.Errorf("min_hours_since_last_message cannot be negative")
+	}
+
+	// Validate channel, SMS messages are sent by the workspace SMS provider
+	switch b.ChannelType {
+	case "", ChannelEmail:
+	case ChannelSMS:
+		if b.DryRun {
+			return fmt.Errorf("dry run is not supported for sms broadcasts")
+		}
+		if b.PlainTextOnly {
+			return fmt.Errorf("plain_text_only is not supported for sms broadcasts")
+		}
+		if b.SeedTest.Enabled {
+			return fmt.Errorf("seed test is not supported for sms broadcasts")
+		}
+	default:
+		return fmt.Errorf("invalid channel type: %s", b.ChannelType)
+	}
+
 	// Validate schedule settings
 	if b.Schedule.IsScheduled && (b.Schedule.ScheduledDate == "" || b.Schedule.ScheduledTime == "") {
 		return fmt.Errorf("scheduled date and time are required when not sending immediately")
@@ -405,22 +773,42 @@ type CreateBroadcastRequest struct {
 	Name            string                `json:"name"`
 	Audience        AudienceSettings      `json:"audience"`
 	TestSettings    BroadcastTestSettings `json:"test_settings"`
+	SeedTest        BroadcastSeedTest     `json:"seed_test"`
 	TrackingEnabled bool                  `json:"tracking_enabled"`
 	UTMParameters   *UTMParameters        `json:"utm_parameters,omitempty"`
 	Metadata        MapOfAny              `json:"metadata,omitempty"`
+	Tags            []string              `json:"tags,omitempty"`
+	DryRun          bool                  `json:"dry_run"`
+	PlainTextOnly   bool                  `json:"plain_text_only"`
+	ChannelType     string                `json:"channel_type,omitempty"` // email or sms, defaults to email
 }
 
 // Validate validates the create broadcast request
 func (r *CreateBroadcastRequest) Validate() (*Broadcast, error) {
+	tags, err := NormalizeBroadcastTags(r.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	channelType := r.ChannelType
+	if channelType == "" {
+		channelType = ChannelEmail
+	}
+
 	broadcast := &Broadcast{
 		WorkspaceID:   r.WorkspaceID,
 		Name:          r.Name,
+		ChannelType:   channelType,
 		Status:        BroadcastStatusDraft,
 		Audience:      r.Audience,
 		Schedule:      ScheduleSettings{}, // Empty schedule - must use broadcasts.schedule endpoint
 		TestSettings:  r.TestSettings,
+		SeedTest:      r.SeedTest.withOutcomeFrom(BroadcastSeedTest{}),
 		UTMParameters: r.UTMParameters,
 		Metadata:      r.Metadata,
+		Tags:          tags,
+		DryRun:        r.DryRun,
+		PlainTextOnly: r.PlainTextOnly,
 		CreatedAt:     time.Now().UTC(),
 		UpdatedAt:     time.Now().UTC(),
 	}
@@ -440,9 +828,14 @@ type UpdateBroadcastRequest struct {
 	Audience        AudienceSettings      `json:"audience"`
 	Schedule        ScheduleSettings      `json:"schedule"`
 	TestSettings    BroadcastTestSettings `json:"test_settings"`
+	SeedTest        BroadcastSeedTest     `json:"seed_test"`
 	TrackingEnabled bool                  `json:"tracking_enabled"`
 	UTMParameters   *UTMParameters        `json:"utm_parameters,omitempty"`
 	Metadata        MapOfAny              `json:"metadata,omitempty"`
+	Tags            []string              `json:"tags,omitempty"`
+	DryRun          bool                  `json:"dry_run"`
+	PlainTextOnly   bool                  `json:"plain_text_only"`
+	ChannelType     string                `json:"channel_type,omitempty"` // email or sms, defaults to email
 }
 
 // Validate validates the update broadcast request
@@ -462,13 +855,25 @@ func (r *UpdateBroadcastRequest) Validate(existingBroadcast *Broadcast) (*Broadc
 		return nil, fmt.Errorf("cannot update broadcast with status: %s", existingBroadcast.Status)
 	}
 
+	tags, err := NormalizeBroadcastTags(r.Tags)
+	if err != nil {
+		return nil, err
+	}
+
 	// Update the existing broadcast
 	existingBroadcast.Name = r.Name
 	existingBroadcast.Audience = r.Audience
 	existingBroadcast.Schedule = r.Schedule
-	existingBroadcast.TestSettings = r.TestSettings
+	existingBroadcast.TestSettings = r.TestSettings.withPinnedVersionsFrom(existingBroadcast.TestSettings)
+	existingBroadcast.SeedTest = r.SeedTest.withOutcomeFrom(existingBroadcast.SeedTest)
 	existingBroadcast.UTMParameters = r.UTMParameters
 	existingBroadcast.Metadata = r.Metadata
+	existingBroadcast.Tags = tags
+	existingBroadcast.DryRun = r.DryRun
+	existingBroadcast.PlainTextOnly = r.PlainTextOnly
+	if r.ChannelType != "" {
+		existingBroadcast.ChannelType = r.ChannelType
+	}
 	existingBroadcast.UpdatedAt = time.Now().UTC()
 
 	if err := existingBroadcast.Validate(); err != nil {
@@ -480,13 +885,17 @@ func (r *UpdateBroadcastRequest) Validate(existingBroadcast *Broadcast) (*Broadc
 
 // ScheduleBroadcastRequest defines the request to schedule a broadcast
 type ScheduleBroadcastRequest struct {
-	WorkspaceID          string `json:"workspace_id"`
-	ID                   string `json:"id"`
-	SendNow              bool   `json:"send_now"`
-	ScheduledDate        string `json:"scheduled_date,omitempty"`
-	ScheduledTime        string `json:"scheduled_time,omitempty"`
-	Timezone             string `json:"timezone,omitempty"`
-	UseRecipientTimezone bool   `json:"use_recipient_timezone"`
+	WorkspaceID          string     `json:"workspace_id"`
+	ID                   string     `json:"id"`
+	SendNow              bool       `json:"send_now"`
+	ScheduledDate        string     `json:"scheduled_date,omitempty"`
+	ScheduledTime        string     `json:"scheduled_time,omitempty"`
+	Timezone             string     `json:"timezone,omitempty"`
+	UseRecipientTimezone bool       `json:"use_recipient_timezone"`
+	IgnoreQuietHours     bool       `json:"ignore_quiet_hours"`
+	SendCutoffAt         *time.Time `json:"send_cutoff_at,omitempty"`
+	MaxSendsPerSecond    int        `json:"max_sends_per_second,omitempty"` // 0 uses the instance default
+	ConfirmationToken    string     `json:"confirmation_token,omitempty"`   // From the preflight, when the workspace requires send confirmation
 }
 
 // Validate validates the schedule broadcast request
@@ -524,6 +933,26 @@ func (r *ScheduleBroadcastRequest) Validate() error {
 		}
 	}
 
+	if r.MaxSendsPerSecond < 0 {
+		return fmt.Errorf("max_sends_per_second cannot be negative")
+	}
+
+	if r.SendCutoffAt != nil {
+		startAt := time.Now()
+		if !r.SendNow {
+			schedule := ScheduleSettings{ScheduledDate: r.ScheduledDate, ScheduledTime: r.ScheduledTime, Timezone: r.Timezone}
+			scheduledAt, err := schedule.ParseScheduledDateTime()
+			if err != nil {
+				return fmt.Errorf("invalid scheduled date and time: %w", err)
+			}
+			startAt = scheduledAt
+		}
+
+		if !r.SendCutoffAt.After(startAt) {
+			return fmt.Errorf("send_cutoff_at must be after the broadcast start time")
+		}
+	}
+
 	return nil
 }
 
@@ -550,6 +979,8 @@ func (r *PauseBroadcastRequest) Validate() error {
 type ResumeBroadcastRequest struct {
 	WorkspaceID string `json:"workspace_id"`
 	ID          string `json:"id"`
+	// AcknowledgeTemplateChanges resumes the broadcast even if its templates were edited while paused
+	AcknowledgeTemplateChanges bool `json:"acknowledge_template_changes,omitempty"`
 }
 
 // Validate validates the resume broadcast request
@@ -603,19 +1034,105 @@ func (r *DeleteBroadcastRequest) Validate() error {
 	return nil
 }
 
+// SetBroadcastTagsRequest defines the request to replace the tags of a broadcast, whatever its status
+type SetBroadcastTagsRequest struct {
+	WorkspaceID string   `json:"workspace_id"`
+	ID          string   `json:"id"`
+	Tags        []string `json:"tags"`
+}
+
+// Validate validates the set broadcast tags request and normalizes its tags
+func (r *SetBroadcastTagsRequest) Validate() error {
+	if r.WorkspaceID == "" {
+		return fmt.Errorf("workspace_id is required")
+	}
+
+	if r.ID == "" {
+		return fmt.Errorf("broadcast id is required")
+	}
+
+	tags, err := NormalizeBroadcastTags(r.Tags)
+	if err != nil {
+		return err
+	}
+	r.Tags = tags
+
+	return nil
+}
+
+// DeleteBroadcastTagRequest defines the request to remove a tag from every broadcast of a workspace
+type DeleteBroadcastTagRequest struct {
+	WorkspaceID string `json:"workspace_id"`
+	Tag         string `json:"tag"`
+}
+
+// Validate validates the delete broadcast tag request
+func (r *DeleteBroadcastTagRequest) Validate() error {
+	if r.WorkspaceID == "" {
+		return fmt.Errorf("workspace_id is required")
+	}
+
+	r.Tag = strings.TrimSpace(r.Tag)
+	if r.Tag == "" {
+		return fmt.Errorf("tag is required")
+	}
+
+	return nil
+}
+
+// BroadcastTagCount is a tag used in a workspace with the number of broadcasts carrying it
+type BroadcastTagCount struct {
+	Tag   string `json:"tag"`
+	Count int    `json:"count"`
+}
+
 // ListBroadcastsParams defines parameters for listing broadcasts with pagination
 type ListBroadcastsParams struct {
-	WorkspaceID   string
-	Status        BroadcastStatus
-	Limit         int
-	Offset        int
-	WithTemplates bool // Whether to fetch and include template details for each variation
+	WorkspaceID     string
+	Status          BroadcastStatus
+	Tag             string     // Only broadcasts carrying this tag
+	CreatedAfter    *time.Time // Only broadcasts created at or after this time
+	CreatedBefore   *time.Time // Only broadcasts created before this time
+	CompletedAfter  *time.Time // Only broadcasts completed at or after this time
+	CompletedBefore *time.Time // Only broadcasts completed before this time
+	Search          string     // Case-insensitive match on the name
+	Limit           int
+	Offset          int
+	Cursor          string // Keyset cursor of the previous page, Offset is ignored when set
+	WithTemplates   bool   // Whether to fetch and include template details for each variation
 }
 
 // BroadcastListResponse defines the response for listing broadcasts
 type BroadcastListResponse struct {
 	Broadcasts []*Broadcast `json:"broadcasts"`
 	TotalCount int          `json:"total_count"`
+	NextCursor string       `json:"next_cursor,omitempty"`
+}
+
+// EncodeBroadcastCursor returns the keyset cursor of the page following a broadcast,
+// broadcasts being listed most recent first
+func EncodeBroadcastCursor(broadcast *Broadcast) string {
+	cursor := fmt.Sprintf("%s~%s", broadcast.CreatedAt.UTC().Format(time.RFC3339Nano), broadcast.ID)
+	return base64.StdEncoding.EncodeToString([]byte(cursor))
+}
+
+// DecodeBroadcastCursor returns the creation time and ID of the broadcast a cursor points at
+func DecodeBroadcastCursor(cursor string) (time.Time, string, error) {
+	decoded, err := base64.StdEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, "", fmt.Errorf("invalid cursor encoding: %w", err)
+	}
+
+	parts := strings.SplitN(string(decoded), "~", 2)
+	if len(parts) != 2 || parts[1] == "" {
+		return time.Time{}, "", fmt.Errorf("invalid cursor format: expected timestamp~id")
+	}
+
+	createdAt, err := time.Parse(time.RFC3339Nano, parts[0])
+	if err != nil {
+		return time.Time{}, "", fmt.Errorf("invalid cursor timestamp format: %w", err)
+	}
+	return createdAt, parts[1], nil
 }
 
 // SendToIndividualRequest defines the request to send a broadcast to an individual
@@ -645,11 +1162,18 @@ func (r *SendToIndividualRequest) Validate() error {
 
 // GetBroadcastsRequest is used to extract query parameters for listing broadcasts
 type GetBroadcastsRequest struct {
-	WorkspaceID   string `json:"workspace_id"`
-	Status        string `json:"status,omitempty"`
-	Limit         int    `json:"limit,omitempty"`
-	Offset        int    `json:"offset,omitempty"`
-	WithTemplates bool   `json:"with_templates,omitempty"`
+	WorkspaceID     string     `json:"workspace_id"`
+	Status          string     `json:"status,omitempty"`
+	Tag             string     `json:"tag,omitempty"`
+	CreatedAfter    *time.Time `json:"created_after,omitempty"`
+	CreatedBefore   *time.Time `json:"created_before,omitempty"`
+	CompletedAfter  *time.Time `json:"completed_after,omitempty"`
+	CompletedBefore *time.Time `json:"completed_before,omitempty"`
+	Search          string     `json:"search,omitempty"`
+	Limit           int        `json:"limit,omitempty"`
+	Offset          int        `json:"offset,omitempty"`
+	Cursor          string     `json:"cursor,omitempty"`
+	WithTemplates   bool       `json:"with_templates,omitempty"`
 }
 
 // FromURLParams parses URL query parameters into the request
@@ -683,6 +1207,29 @@ func (r *GetBroadcastsRequest) FromURLParams(values url.Values) error {
 	}
 
 	r.Status = values.Get("status")
+	r.Tag = strings.TrimSpace(values.Get("tag"))
+
+	if err := parseTimeParam(values, "created_after", &r.CreatedAfter); err != nil {
+		return err
+	}
+	if err := parseTimeParam(values, "created_before", &r.CreatedBefore); err != nil {
+		return err
+	}
+	if err := parseTimeParam(values, "completed_after", &r.CompletedAfter); err != nil {
+		return err
+	}
+	if err := parseTimeParam(values, "completed_before", &r.CompletedBefore); err != nil {
+		return err
+	}
+
+	r.Search = strings.TrimSpace(values.Get("search"))
+
+	r.Cursor = values.Get("cursor")
+	if r.Cursor != "" {
+		if _, _, err := DecodeBroadcastCursor(r.Cursor); err != nil {
+			return err
+		}
+	}
 
 	if limitStr := values.Get("limit"); limitStr != "" {
 		var err error
@@ -759,6 +1306,23 @@ func (r *SelectWinnerRequest) Validate() error {
 	return nil
 }
 
+// ApproveSeedTestRequest represents the request to approve the seed test of a broadcast
+type ApproveSeedTestRequest struct {
+	WorkspaceID string `json:"workspace_id"`
+	ID          string `json:"id"`
+}
+
+// Validate validates the approve seed test request
+func (r *ApproveSeedTestRequest) Validate() error {
+	if r.WorkspaceID == "" {
+		return fmt.Errorf("workspace_id is required")
+	}
+	if r.ID == "" {
+		return fmt.Errorf("broadcast id is required")
+	}
+	return nil
+}
+
 // GetTestResultsRequest represents the request to get A/B test results
 type GetTestResultsRequest struct {
 	WorkspaceID string `json:"workspace_id"`
@@ -783,6 +1347,199 @@ func (r *GetTestResultsRequest) FromURLParams(values url.Values) error {
 	return nil
 }
 
+// BroadcastProgressRequest represents the request to stream the progress of a broadcast
+type BroadcastProgressRequest struct {
+	WorkspaceID string `json:"workspace_id"`
+	ID          string `json:"id"`
+}
+
+// Validate validates the broadcast progress request
+func (r *BroadcastProgressRequest) Validate() error {
+	if r.WorkspaceID == "" {
+		return fmt.Errorf("workspace_id is required")
+	}
+	if r.ID == "" {
+		return fmt.Errorf("broadcast id is required")
+	}
+	return nil
+}
+
+// FromURLParams parses URL parameters into the request
+func (r *BroadcastProgressRequest) FromURLParams(values url.Values) error {
+	r.WorkspaceID = values.Get("workspace_id")
+	r.ID = values.Get("id")
+	return nil
+}
+
+// BroadcastProgress is a progress update of a sending broadcast, published each time the
+// orchestrator saves the state of its task
+type BroadcastProgress struct {
+	BroadcastID string  `json:"broadcast_id"`
+	Processed   int     `json:"processed"`
+	Total       int     `json:"total"`
+	Percent     float64 `json:"percent"`
+	Sent        int     `json:"sent"`
+	Failed      int     `json:"failed"`
+	ETASeconds  int     `json:"eta_seconds,omitempty"`
+	Message     string  `json:"message"`
+}
+
+// BroadcastProgressSubscriber streams the progress updates of sending broadcasts
+type BroadcastProgressSubscriber interface {
+	// SubscribeProgress returns a channel receiving the progress updates of a broadcast and a
+	// function that ends the subscription and closes the channel
+	SubscribeProgress(workspaceID, broadcastID string) (<-chan BroadcastProgress, func())
+}
+
+// BatchSendResult reports which recipients of a batch were processed by the broadcast message senders
+type BatchSendResult struct {
+	// SentEmails are the recipients whose message was sent or enqueued
+	SentEmails []string
+	// FailedEmails are the recipients whose message failed for good, such as on a missing
+	// template; they are not sent again. Recipients without a contact are listed with an empty email.
+	FailedEmails []string
+}
+
+// Sent returns the number of messages sent
+func (r BatchSendResult) Sent() int {
+	return len(r.SentEmails)
+}
+
+// Failed returns the number of messages that failed
+func (r BatchSendResult) Failed() int {
+	return len(r.FailedEmails)
+}
+
+// Processed returns the number of recipients processed
+func (r BatchSendResult) Processed() int {
+	return len(r.SentEmails) + len(r.FailedEmails)
+}
+
+// BroadcastPreflightRequest defines the request to run the audience preflight check of a broadcast
+type BroadcastPreflightRequest struct {
+	WorkspaceID string `json:"workspace_id"`
+	ID          string `json:"id"`
+}
+
+// Validate validates the broadcast preflight request
+func (r *BroadcastPreflightRequest) Validate() error {
+	if r.WorkspaceID == "" {
+		return fmt.Errorf("workspace_id is required")
+	}
+	if r.ID == "" {
+		return fmt.Errorf("broadcast id is required")
+	}
+	return nil
+}
+
+// FromURLParams parses URL parameters into the request
+func (r *BroadcastPreflightRequest) FromURLParams(values url.Values) error {
+	r.WorkspaceID = values.Get("workspace_id")
+	r.ID = values.Get("id")
+	return nil
+}
+
+// AudiencePreflight compares the raw size of a broadcast audience with the number of
+// contacts that can actually receive it (not unsubscribed, bounced or complained)
+type AudiencePreflight struct {
+	RawCount         int     `json:"raw_count"`
+	DeliverableCount int     `json:"deliverable_count"`
+	DeliverableRatio float64 `json:"deliverable_ratio"`
+	MinRatio         float64 `json:"min_ratio"`
+	BelowThreshold   bool    `json:"below_threshold"`
+	Blocking         bool    `json:"blocking"` // Scheduling is refused while the check fails
+
+	// Set when the workspace requires send confirmation, to pass when scheduling the broadcast
+	ConfirmationToken     string     `json:"confirmation_token,omitempty"`
+	ConfirmationExpiresAt *time.Time `json:"confirmation_expires_at,omitempty"`
+}
+
+// NewAudiencePreflight evaluates the audience counts against the workspace settings.
+// An empty audience or disabled settings never fall below the threshold.
+func NewAudiencePreflight(rawCount, deliverableCount int, settings *DeliverableAudienceSettings) *AudiencePreflight {
+	preflight := &AudiencePreflight{
+		RawCount:         rawCount,
+		DeliverableCount: deliverableCount,
+		DeliverableRatio: 1,
+	}
+	if rawCount > 0 {
+		preflight.DeliverableRatio = float64(deliverableCount) / float64(rawCount)
+	}
+	if settings == nil || !settings.Enabled {
+		return preflight
+	}
+
+	preflight.MinRatio = settings.MinRatio
+	preflight.BelowThreshold = rawCount > 0 && preflight.DeliverableRatio < settings.MinRatio
+	preflight.Blocking = preflight.BelowThreshold && settings.Block
+	return preflight
+}
+
+// ErrDeliverableAudienceTooLow is returned when a broadcast is scheduled while the
+// deliverable share of its audience is below the blocking workspace threshold
+type ErrDeliverableAudienceTooLow struct {
+	Preflight *AudiencePreflight
+}
+
+// Error returns the error message
+func (e *ErrDeliverableAudienceTooLow) Error() string {
+	return fmt.Sprintf("only %d of %d audience contacts are deliverable (%.0f%%), below the required %.0f%%",
+		e.Preflight.DeliverableCount, e.Preflight.RawCount, e.Preflight.DeliverableRatio*100, e.Preflight.MinRatio*100)
+}
+
+// NewSendConfirmationToken signs a token confirming the send of a broadcast until expiresAt,
+// with a key derived from the workspace secret key for this purpose only
+func NewSendConfirmationToken(secretKey, workspaceID, broadcastID string, expiresAt time.Time) (string, error) {
+	key, err := crypto.DeriveKey(secretKey, crypto.KeyPurposeSendConfirmation)
+	if err != nil {
+		return "", fmt.Errorf("failed to derive send confirmation key: %w", err)
+	}
+	expires := strconv.FormatInt(expiresAt.Unix(), 10)
+	return expires + "." + crypto.ComputeHMAC256(sendConfirmationPayload(workspaceID, broadcastID, expires), key), nil
+}
+
+// VerifySendConfirmationToken checks that the token was issued for the broadcast and has not expired
+func VerifySendConfirmationToken(secretKey, token, workspaceID, broadcastID string, now time.Time) error {
+	if token == "" {
+		return &ErrSendConfirmationRequired{Reason: "a confirmation token from the broadcast preflight is required"}
+	}
+	expires, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return &ErrSendConfirmationRequired{Reason: "invalid confirmation token"}
+	}
+	key, err := crypto.DeriveKey(secretKey, crypto.KeyPurposeSendConfirmation)
+	if err != nil {
+		return fmt.Errorf("failed to derive send confirmation key: %w", err)
+	}
+	expected := crypto.ComputeHMAC256(sendConfirmationPayload(workspaceID, broadcastID, expires), key)
+	if !hmac.Equal([]byte(signature), []byte(expected)) {
+		return &ErrSendConfirmationRequired{Reason: "invalid confirmation token"}
+	}
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil {
+		return &ErrSendConfirmationRequired{Reason: "invalid confirmation token"}
+	}
+	if now.Unix() > expiresAt {
+		return &ErrSendConfirmationRequired{Reason: "confirmation token expired, run the preflight again"}
+	}
+	return nil
+}
+
+func sendConfirmationPayload(workspaceID, broadcastID, expires string) []byte {
+	return []byte("broadcast_send:" + workspaceID + ":" + broadcastID + ":" + expires)
+}
+
+// ErrSendConfirmationRequired is returned when a broadcast is scheduled without a valid
+// confirmation token while the workspace requires send confirmation
+type ErrSendConfirmationRequired struct {
+	Reason string
+}
+
+// Error returns the error message
+func (e *ErrSendConfirmationRequired) Error() string {
+	return e.Reason
+}
+
 // VariationResult represents the results for a single A/B test variation
 type VariationResult struct {
 	TemplateID   string  `json:"template_id"`
@@ -807,6 +1564,60 @@ type TestResultsResponse struct {
 	IsAutoSendWinner  bool                        `json:"is_auto_send_winner"`
 }
 
+// GetBroadcastStatsRequest is the request to retrieve the live stats of a broadcast
+type GetBroadcastStatsRequest struct {
+	WorkspaceID string `json:"workspace_id"`
+	ID          string `json:"id"`
+}
+
+// Validate validates the broadcast stats request
+func (r *GetBroadcastStatsRequest) Validate() error {
+	if r.WorkspaceID == "" {
+		return fmt.Errorf("workspace_id is required")
+	}
+	if r.ID == "" {
+		return fmt.Errorf("broadcast id is required")
+	}
+	return nil
+}
+
+// FromURLParams parses URL parameters into the request
+func (r *GetBroadcastStatsRequest) FromURLParams(values url.Values) error {
+	r.WorkspaceID = values.Get("workspace_id")
+	r.ID = values.Get("id")
+	return nil
+}
+
+// BroadcastStatsRates holds the rates of a broadcast, all relative to the messages sent
+type BroadcastStatsRates struct {
+	DeliveryRate float64 `json:"delivery_rate"`
+	OpenRate     float64 `json:"open_rate"`
+	ClickRate    float64 `json:"click_rate"`
+	BounceRate   float64 `json:"bounce_rate"`
+}
+
+// BroadcastStats holds the message statuses of a broadcast and the rates computed from them
+type BroadcastStats struct {
+	BroadcastID string                   `json:"broadcast_id"`
+	Stats       *MessageHistoryStatusSum `json:"stats"`
+	Rates       BroadcastStatsRates      `json:"rates"`
+}
+
+// NewBroadcastStats computes the rates of the given message statuses
+func NewBroadcastStats(broadcastID string, stats *MessageHistoryStatusSum) *BroadcastStats {
+	result := &BroadcastStats{BroadcastID: broadcastID, Stats: stats}
+	if stats != nil && stats.TotalSent > 0 {
+		sent := float64(stats.TotalSent)
+		result.Rates = BroadcastStatsRates{
+			DeliveryRate: float64(stats.TotalDelivered) / sent,
+			OpenRate:     float64(stats.TotalOpened) / sent,
+			ClickRate:    float64(stats.TotalClicked) / sent,
+			BounceRate:   float64(stats.TotalBounced) / sent,
+		}
+	}
+	return result
+}
+
 // BroadcastService defines the interface for broadcast operations
 type BroadcastService interface {
 	// CreateBroadcast creates a new broadcast
@@ -844,6 +1655,27 @@ type BroadcastService interface {
 
 	// SelectWinner manually selects the winning variation for an A/B test
 	SelectWinner(ctx context.Context, workspaceID, broadcastID, templateID string) error
+
+	// ApproveSeedTest releases the main send of a broadcast held after its seed test
+	ApproveSeedTest(ctx context.Context, workspaceID, broadcastID string) error
+
+	// PreflightBroadcast compares the deliverable audience of a broadcast with its raw size
+	PreflightBroadcast(ctx context.Context, workspaceID, broadcastID string) (*AudiencePreflight, error)
+
+	// GetBroadcastStats retrieves the live message statuses of a broadcast with their rates
+	GetBroadcastStats(ctx context.Context, workspaceID, broadcastID string) (*BroadcastStats, error)
+
+	// SetBroadcastTags replaces the tags of a broadcast
+	SetBroadcastTags(ctx context.Context, request *SetBroadcastTagsRequest) (*Broadcast, error)
+
+	// ListBroadcastTags lists the tags used by the broadcasts of a workspace
+	ListBroadcastTags(ctx context.Context, workspaceID string) ([]*BroadcastTagCount, error)
+
+	// DeleteBroadcastTag removes a tag from every broadcast of a workspace
+	DeleteBroadcastTag(ctx context.Context, request *DeleteBroadcastTagRequest) error
+
+	// UploadBroadcastAudience replaces the uploaded recipients of a broadcast with a CSV audience
+	UploadBroadcastAudience(ctx context.Context, request *UploadBroadcastAudienceRequest) (*UploadBroadcastAudienceResponse, error)
 }
 
 // BroadcastSender is a minimal interface needed for sending broadcasts,
@@ -865,6 +1697,11 @@ type BroadcastRepository interface {
 	DeleteBroadcast(ctx context.Context, workspaceID, broadcastID string) error
 	ListBroadcasts(ctx context.Context, params ListBroadcastsParams) (*BroadcastListResponse, error)
 
+	// Tag management, tags are stored on the broadcasts of the workspace database
+	SetBroadcastTags(ctx context.Context, workspaceID, broadcastID string, tags []string) error
+	ListBroadcastTags(ctx context.Context, workspaceID string) ([]*BroadcastTagCount, error)
+	DeleteBroadcastTag(ctx context.Context, workspaceID, tag string) (int64, error)
+
 	// Transaction management
 	WithTransaction(ctx context.Context, workspaceID string, fn func(*sql.Tx) error) error
 
@@ -886,6 +1723,66 @@ func (e *ErrBroadcastNotFound) Error() string {
 	return fmt.Sprintf("Broadcast not found with ID: %s", e.ID)
 }
 
+// BroadcastTemplateChange describes a template edited since it was pinned to a broadcast
+type BroadcastTemplateChange struct {
+	TemplateID     string `json:"template_id"`
+	PinnedVersion  int64  `json:"pinned_version"`
+	CurrentVersion int64  `json:"current_version"`
+}
+
+// ErrBroadcastTemplateChanged is returned when resuming a broadcast whose templates
+// were edited after sending started, unless the changes are acknowledged
+type ErrBroadcastTemplateChanged struct {
+	Changes []BroadcastTemplateChange
+}
+
+// Error returns the error message
+func (e *ErrBroadcastTemplateChanged) Error() string {
+	ids := make([]string, len(e.Changes))
+	for i, change := range e.Changes {
+		ids[i] = change.TemplateID
+	}
+	return fmt.Sprintf("templates changed since the broadcast started sending: %s", strings.Join(ids, ", "))
+}
+
+// PinTemplateVersions records the version of the loaded templates on variations that are not pinned yet.
+// It returns true when at least one variation was pinned.
+func (b *Broadcast) PinTemplateVersions(templates map[string]*Template) bool {
+	pinned := false
+	for i, variation := range b.TestSettings.Variations {
+		template, ok := templates[variation.TemplateID]
+		if !ok || template == nil || template.Version == 0 || variation.TemplateVersion != 0 {
+			continue
+		}
+		b.TestSettings.Variations[i].TemplateVersion = template.Version
+		pinned = true
+	}
+	return pinned
+}
+
+// withPinnedVersionsFrom keeps the pinned versions of variations whose template is unchanged
+func (s BroadcastTestSettings) withPinnedVersionsFrom(existing BroadcastTestSettings) BroadcastTestSettings {
+	pinned := make(map[string]int64, len(existing.Variations))
+	for _, variation := range existing.Variations {
+		if variation.TemplateVersion != 0 {
+			pinned[variation.TemplateID] = variation.TemplateVersion
+		}
+	}
+	if len(pinned) == 0 {
+		return s
+	}
+
+	variations := make([]BroadcastVariation, len(s.Variations))
+	copy(variations, s.Variations)
+	for i, variation := range variations {
+		if version, ok := pinned[variation.TemplateID]; ok {
+			variations[i].TemplateVersion = version
+		}
+	}
+	s.Variations = variations
+	return s
+}
+
 // SetTemplateForVariation assigns a template to a specific variation
 func (b *Broadcast) SetTemplateForVariation(variationIndex int, template *Template) {
 	if b == nil || variationIndex < 0 || variationIndex >= len(b.TestSettings.Variations) {
@@ -0,0 +1,222 @@
+package domain
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/asaskevich/govalidator"
+)
+
+//go:generate mockgen -destination mocks/mock_broadcast_audience_repository.go -package mocks github.com/Notifuse/notifuse/internal/domain BroadcastAudienceRepository
+
+// MaxBroadcastAudienceCSVRows is the maximum number of recipients of an uploaded CSV audience
+const MaxBroadcastAudienceCSVRows = 100000
+
+// ErrBroadcastAudienceUploadRejected is returned when the audience of a broadcast cannot be uploaded,
+// because the broadcast has no CSV audience or already started sending
+var ErrBroadcastAudienceUploadRejected = errors.New("broadcast audience upload rejected")
+
+// BroadcastAudienceRecipient is a recipient of a broadcast whose audience is an uploaded CSV.
+// Recipients are stored apart from the contacts of the workspace, Data holds the contact
+// fields given by the other columns of the CSV and is used as merge data.
+type BroadcastAudienceRecipient struct {
+	BroadcastID string    `json:"broadcast_id"`
+	Email       string    `json:"email"`
+	Data        MapOfAny  `json:"data,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// ToContact builds the contact the broadcast is rendered for from the recipient merge data
+func (r *BroadcastAudienceRecipient) ToContact() (*Contact, error) {
+	fields := map[string]interface{}{}
+	for key, value := range r.Data {
+		fields[key] = value
+	}
+	fields["email"] = r.Email
+
+	data, err := json.Marshal(fields)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal recipient data: %w", err)
+	}
+
+	return FromJSON(data)
+}
+
+// ParseBroadcastAudienceCSV parses an uploaded CSV audience. The header row must have an "email"
+// column, the other columns are contact fields (first_name, custom_string_1, ...) used as merge data.
+// Empty cells are ignored and duplicate emails are only kept once.
+func ParseBroadcastAudienceCSV(content string) ([]*BroadcastAudienceRecipient, error) {
+	reader := csv.NewReader(strings.NewReader(content))
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("csv is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid csv header: %w", err)
+	}
+
+	emailIndex := -1
+	for i, column := range header {
+		column = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(column, "\ufeff")))
+		header[i] = column
+
+		if column == "email" {
+			emailIndex = i
+			continue
+		}
+		if !isBroadcastAudienceCSVColumn(column) {
+			return nil, fmt.Errorf("csv column %q is not a contact field", column)
+		}
+	}
+	if emailIndex == -1 {
+		return nil, fmt.Errorf("csv must have an email column")
+	}
+
+	recipients := []*BroadcastAudienceRecipient{}
+	seen := map[string]bool{}
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("invalid csv: %w", err)
+		}
+
+		email := strings.TrimSpace(record[emailIndex])
+		if email == "" {
+			continue
+		}
+		if !govalidator.IsEmail(email) {
+			return nil, fmt.Errorf("invalid email %q on line %d", email, line)
+		}
+		if seen[email] {
+			continue
+		}
+		seen[email] = true
+
+		data := MapOfAny{}
+		for i, column := range header {
+			value := strings.TrimSpace(record[i])
+			if i == emailIndex || value == "" {
+				continue
+			}
+			parsed, err := parseBroadcastAudienceCSVValue(column, value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s on line %d: %w", column, line, err)
+			}
+			data[column] = parsed
+		}
+
+		recipient := &BroadcastAudienceRecipient{Email: email}
+		if len(data) > 0 {
+			recipient.Data = data
+		}
+
+		// Check the merge data renders into a valid contact
+		if _, err := recipient.ToContact(); err != nil {
+			return nil, fmt.Errorf("invalid recipient on line %d: %w", line, err)
+		}
+
+		recipients = append(recipients, recipient)
+		if len(recipients) > MaxBroadcastAudienceCSVRows {
+			return nil, fmt.Errorf("csv cannot have more than %d recipients", MaxBroadcastAudienceCSVRows)
+		}
+	}
+
+	if len(recipients) == 0 {
+		return nil, fmt.Errorf("csv has no recipients")
+	}
+
+	return recipients, nil
+}
+
+// isBroadcastAudienceCSVColumn returns true for the contact fields a CSV audience can set
+func isBroadcastAudienceCSVColumn(column string) bool {
+	if column == "created_at" || column == "updated_at" {
+		return false
+	}
+	return contactMergeFields[column]
+}
+
+// parseBroadcastAudienceCSVValue converts a CSV cell to the type of its contact field
+func parseBroadcastAudienceCSVValue(column, value string) (interface{}, error) {
+	switch {
+	case strings.HasPrefix(column, "custom_number_"):
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, errors.New("expected a number")
+		}
+		return number, nil
+	case strings.HasPrefix(column, "custom_json_"):
+		var data interface{}
+		if err := json.Unmarshal([]byte(value), &data); err != nil {
+			return nil, errors.New("expected a JSON object or array")
+		}
+		return data, nil
+	default:
+		return value, nil
+	}
+}
+
+// UploadBroadcastAudienceRequest uploads the CSV audience of a broadcast, replacing the previous upload.
+// When UpsertContacts is set the recipients are also created or updated as contacts of the workspace.
+type UploadBroadcastAudienceRequest struct {
+	WorkspaceID    string `json:"workspace_id"`
+	BroadcastID    string `json:"broadcast_id"`
+	CSV            string `json:"csv"`
+	UpsertContacts bool   `json:"upsert_contacts"`
+}
+
+// Validate validates the request and returns the parsed recipients
+func (r *UploadBroadcastAudienceRequest) Validate() ([]*BroadcastAudienceRecipient, error) {
+	if r.WorkspaceID == "" {
+		return nil, fmt.Errorf("workspace_id is required")
+	}
+	if r.BroadcastID == "" {
+		return nil, fmt.Errorf("broadcast_id is required")
+	}
+	if strings.TrimSpace(r.CSV) == "" {
+		return nil, fmt.Errorf("csv is required")
+	}
+
+	recipients, err := ParseBroadcastAudienceCSV(r.CSV)
+	if err != nil {
+		return nil, err
+	}
+	for _, recipient := range recipients {
+		recipient.BroadcastID = r.BroadcastID
+	}
+
+	return recipients, nil
+}
+
+// UploadBroadcastAudienceResponse reports the result of a CSV audience upload
+type UploadBroadcastAudienceResponse struct {
+	Recipients       int `json:"recipients"`
+	UpsertedContacts int `json:"upserted_contacts"`
+}
+
+// BroadcastAudienceRepository stores the uploaded CSV audiences of broadcasts
+type BroadcastAudienceRepository interface {
+	// ReplaceRecipients replaces the uploaded recipients of a broadcast
+	ReplaceRecipients(ctx context.Context, workspaceID, broadcastID string, recipients []*BroadcastAudienceRecipient) error
+
+	// CountRecipients counts the recipients of a broadcast, applying the audience unsubscribe exclusion
+	CountRecipients(ctx context.Context, workspaceID, broadcastID string, audience AudienceSettings) (int, error)
+
+	// GetContactsForBroadcast retrieves a batch of recipients as contacts, ordered by email after afterEmail
+	GetContactsForBroadcast(ctx context.Context, workspaceID, broadcastID string, audience AudienceSettings, limit int, afterEmail string) ([]*ContactWithList, error)
+
+	// DeleteRecipients deletes the uploaded recipients of a broadcast
+	DeleteRecipients(ctx context.Context, workspaceID, broadcastID string) error
+}
@@ -0,0 +1,140 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseBroadcastAudienceCSV(t *testing.T) {
+	t.Run("emails with merge data", func(t *testing.T) {
+		content := "\ufeffEmail, First_Name,custom_number_1,custom_json_1\n" +
+			"alice@example.com,Alice,42,\"{\"\"plan\"\":\"\"pro\"\"}\"\n" +
+			"bob@example.com,,,\n" +
+			"alice@example.com,Duplicate,,\n" +
+			",Nobody,,\n"
+
+		recipients, err := ParseBroadcastAudienceCSV(content)
+		require.NoError(t, err)
+		require.Len(t, recipients, 2)
+
+		assert.Equal(t, "alice@example.com", recipients[0].Email)
+		assert.Equal(t, MapOfAny{
+			"first_name":      "Alice",
+			"custom_number_1": float64(42),
+			"custom_json_1":   map[string]interface{}{"plan": "pro"},
+		}, recipients[0].Data)
+
+		assert.Equal(t, "bob@example.com", recipients[1].Email)
+		assert.Nil(t, recipients[1].Data)
+	})
+
+	t.Run("emails only", func(t *testing.T) {
+		recipients, err := ParseBroadcastAudienceCSV("email\nalice@example.com\nbob@example.com\n")
+		require.NoError(t, err)
+		require.Len(t, recipients, 2)
+	})
+
+	errorCases := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{"empty", "", "csv is empty"},
+		{"missing email column", "first_name\nAlice\n", "csv must have an email column"},
+		{"unknown column", "email,nickname\nalice@example.com,Al\n", `csv column "nickname" is not a contact field`},
+		{"timestamp column", "email,created_at\nalice@example.com,2026-01-01\n", `csv column "created_at" is not a contact field`},
+		{"invalid email", "email\nalice@example.com\nnot-an-email\n", `invalid email "not-an-email" on line 3`},
+		{"invalid number", "email,custom_number_1\nalice@example.com,many\n", "invalid custom_number_1 on line 2"},
+		{"invalid JSON", "email,custom_json_1\nalice@example.com,{plan\n", "invalid custom_json_1 on line 2"},
+		{"JSON scalar", "email,custom_json_1\nalice@example.com,42\n", "invalid recipient on line 2"},
+		{"wrong field count", "email,first_name\nalice@example.com\n", "invalid csv"},
+		{"no recipients", "email\n\n", "csv has no recipients"},
+	}
+	for _, tc := range errorCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := ParseBroadcastAudienceCSV(tc.content)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expected)
+		})
+	}
+
+	t.Run("too many recipients", func(t *testing.T) {
+		var sb strings.Builder
+		sb.WriteString("email\n")
+		for i := 0; i <= MaxBroadcastAudienceCSVRows; i++ {
+			sb.WriteString(fmt.Sprintf("user%d@example.com\n", i))
+		}
+
+		_, err := ParseBroadcastAudienceCSV(sb.String())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "csv cannot have more than")
+	})
+}
+
+func TestBroadcastAudienceRecipient_ToContact(t *testing.T) {
+	recipient := &BroadcastAudienceRecipient{
+		Email: "alice@example.com",
+		Data:  MapOfAny{"first_name": "Alice", "custom_number_1": float64(7), "email": "ignored@example.com"},
+	}
+
+	contact, err := recipient.ToContact()
+	require.NoError(t, err)
+	assert.Equal(t, "alice@example.com", contact.Email)
+	assert.Equal(t, "Alice", contact.FirstName.String)
+	assert.Equal(t, float64(7), contact.CustomNumber1.Float64)
+	assert.Nil(t, contact.LastName)
+}
+
+func TestUploadBroadcastAudienceRequest_Validate(t *testing.T) {
+	request := &UploadBroadcastAudienceRequest{
+		WorkspaceID: "ws1",
+		BroadcastID: "broadcast1",
+		CSV:         "email\nalice@example.com\n",
+	}
+
+	recipients, err := request.Validate()
+	require.NoError(t, err)
+	require.Len(t, recipients, 1)
+	assert.Equal(t, "broadcast1", recipients[0].BroadcastID)
+
+	_, err = (&UploadBroadcastAudienceRequest{BroadcastID: "broadcast1", CSV: "email\n"}).Validate()
+	assert.EqualError(t, err, "workspace_id is required")
+
+	_, err = (&UploadBroadcastAudienceRequest{WorkspaceID: "ws1", CSV: "email\n"}).Validate()
+	assert.EqualError(t, err, "broadcast_id is required")
+
+	_, err = (&UploadBroadcastAudienceRequest{WorkspaceID: "ws1", BroadcastID: "broadcast1", CSV: "  "}).Validate()
+	assert.EqualError(t, err, "csv is required")
+}
+
+func TestBroadcast_Validate_CSVAudience(t *testing.T) {
+	broadcast := &Broadcast{
+		ID:          "broadcast1",
+		WorkspaceID: "ws1",
+		Name:        "One-off",
+		Status:      BroadcastStatusDraft,
+		Audience:    AudienceSettings{List: "list1", CSV: true},
+	}
+	assert.NoError(t, broadcast.Validate())
+
+	broadcast.Audience.Segments = []string{"segment1"}
+	assert.EqualError(t, broadcast.Validate(), "segments cannot be used with a CSV audience")
+}
+
+func TestBroadcast_Validate_MinHoursSinceLastMessage(t *testing.T) {
+	broadcast := &Broadcast{
+		ID:          "broadcast1",
+		WorkspaceID: "ws1",
+		Name:        "Weekly",
+		Status:      BroadcastStatusDraft,
+		Audience:    AudienceSettings{List: "list1", MinHoursSinceLastMessage: 24},
+	}
+	assert.NoError(t, broadcast.Validate())
+
+	broadcast.Audience.MinHoursSinceLastMessage = -1
+	assert.EqualError(t, broadcast.Validate(), "min_hours_since_last_message cannot be negative")
+}
@@ -1,7 +1,9 @@
 package domain_test
 
 import (
+	"fmt"
 	"net/url"
+	"strings"
 	"testing"
 	"time"
 
@@ -9,6 +11,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/pkg/crypto"
 )
 
 func TestBroadcastStatus_Values(t *testing.T) {
@@ -26,6 +29,8 @@ func TestTestWinnerMetric_Values(t *testing.T) {
 	// Verify all metric constants are defined
 	assert.Equal(t, domain.TestWinnerMetric("open_rate"), domain.TestWinnerMetricOpenRate)
 	assert.Equal(t, domain.TestWinnerMetric("click_rate"), domain.TestWinnerMetricClickRate)
+	assert.Equal(t, domain.TestWinnerMetric("click_to_open_rate"), domain.TestWinnerMetricClickToOpenRate)
+	assert.Equal(t, domain.TestWinnerMetric("lowest_unsubscribe_rate"), domain.TestWinnerMetricLowestUnsubscribeRate)
 }
 
 func createValidBroadcast() domain.Broadcast {
@@ -176,6 +181,57 @@ func TestBroadcast_Validate(t *testing.T) {
 			}(),
 			wantErr: false,
 		},
+		{
+			name: "several lists with list templates",
+			broadcast: func() domain.Broadcast {
+				b := createValidBroadcast()
+				b.Audience.List = ""
+				b.Audience.Lists = []string{"list-vip", "list123"}
+				b.Audience.ListTemplates = map[string]string{"list-vip": "template-vip"}
+				return b
+			}(),
+			wantErr: false,
+		},
+		{
+			name: "list other than the first of lists",
+			broadcast: func() domain.Broadcast {
+				b := createValidBroadcast()
+				b.Audience.Lists = []string{"list-vip", "list123"}
+				return b
+			}(),
+			wantErr: true,
+			errMsg:  "list must be the first of lists",
+		},
+		{
+			name: "repeated list",
+			broadcast: func() domain.Broadcast {
+				b := createValidBroadcast()
+				b.Audience.Lists = []string{"list123", "list123"}
+				return b
+			}(),
+			wantErr: true,
+			errMsg:  "list list123 is repeated in lists",
+		},
+		{
+			name: "list template of a list outside the audience",
+			broadcast: func() domain.Broadcast {
+				b := createValidBroadcast()
+				b.Audience.ListTemplates = map[string]string{"list-other": "template-other"}
+				return b
+			}(),
+			wantErr: true,
+			errMsg:  "list_templates references list list-other which is not in the audience",
+		},
+		{
+			name: "list templates with A/B testing",
+			broadcast: func() domain.Broadcast {
+				b := createValidBroadcastWithTest()
+				b.Audience.ListTemplates = map[string]string{b.Audience.List: "template-vip"}
+				return b
+			}(),
+			wantErr: true,
+			errMsg:  "list templates cannot be used with A/B testing",
+		},
 		{
 			name: "scheduled time required when not sending immediately",
 			broadcast: func() domain.Broadcast {
@@ -281,6 +337,25 @@ func TestBroadcast_Validate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "invalid test winner metric",
 		},
+		{
+			name: "min confidence of 1",
+			broadcast: func() domain.Broadcast {
+				b := createValidBroadcastWithTest()
+				b.TestSettings.MinConfidence = 1
+				return b
+			}(),
+			wantErr: true,
+			errMsg:  "min confidence must be at least 0 and less than 1",
+		},
+		{
+			name: "valid min confidence",
+			broadcast: func() domain.Broadcast {
+				b := createValidBroadcastWithTest()
+				b.TestSettings.MinConfidence = 0.95
+				return b
+			}(),
+			wantErr: false,
+		},
 		{
 			name: "test duration must be positive",
 			broadcast: func() domain.Broadcast {
@@ -313,6 +388,36 @@ func TestBroadcast_Validate(t *testing.T) {
 			}(),
 			wantErr: false,
 		},
+		{
+			name: "valid sms broadcast",
+			broadcast: func() domain.Broadcast {
+				b := createValidBroadcast()
+				b.ChannelType = domain.ChannelSMS
+				return b
+			}(),
+			wantErr: false,
+		},
+		{
+			name: "dry run sms broadcast",
+			broadcast: func() domain.Broadcast {
+				b := createValidBroadcast()
+				b.ChannelType = domain.ChannelSMS
+				b.DryRun = true
+				return b
+			}(),
+			wantErr: true,
+			errMsg:  "dry run is not supported for sms broadcasts",
+		},
+		{
+			name: "invalid channel type",
+			broadcast: func() domain.Broadcast {
+				b := createValidBroadcast()
+				b.ChannelType = "push"
+				return b
+			}(),
+			wantErr: true,
+			errMsg:  "invalid channel type: push",
+		},
 	}
 
 	for _, tt := range tests {
@@ -561,6 +666,10 @@ func TestUpdateBroadcastRequest_Validate(t *testing.T) {
 }
 
 func TestScheduleBroadcastRequest_Validate(t *testing.T) {
+	cutoffAfterSchedule := time.Date(2023, 12, 31, 18, 0, 0, 0, time.UTC)
+	cutoffBeforeSchedule := time.Date(2023, 12, 31, 15, 0, 0, 0, time.UTC)
+	cutoffInPast := time.Now().Add(-time.Hour)
+
 	tests := []struct {
 		name    string
 		request domain.ScheduleBroadcastRequest
@@ -658,6 +767,42 @@ func TestScheduleBroadcastRequest_Validate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "invalid timezone",
 		},
+		{
+			name: "send cutoff after scheduled time",
+			request: domain.ScheduleBroadcastRequest{
+				WorkspaceID:   "workspace123",
+				ID:            "broadcast123",
+				ScheduledDate: "2023-12-31",
+				ScheduledTime: "15:30",
+				Timezone:      "UTC",
+				SendCutoffAt:  &cutoffAfterSchedule,
+			},
+			wantErr: false,
+		},
+		{
+			name: "send cutoff before scheduled time",
+			request: domain.ScheduleBroadcastRequest{
+				WorkspaceID:   "workspace123",
+				ID:            "broadcast123",
+				ScheduledDate: "2023-12-31",
+				ScheduledTime: "15:30",
+				Timezone:      "UTC",
+				SendCutoffAt:  &cutoffBeforeSchedule,
+			},
+			wantErr: true,
+			errMsg:  "send_cutoff_at must be after the broadcast start time",
+		},
+		{
+			name: "send cutoff in the past when sending now",
+			request: domain.ScheduleBroadcastRequest{
+				WorkspaceID:  "workspace123",
+				ID:           "broadcast123",
+				SendNow:      true,
+				SendCutoffAt: &cutoffInPast,
+			},
+			wantErr: true,
+			errMsg:  "send_cutoff_at must be after the broadcast start time",
+		},
 	}
 
 	for _, tt := range tests {
@@ -947,6 +1092,72 @@ func TestDeleteBroadcastRequestValidate(t *testing.T) {
 	}
 }
 
+func TestNormalizeBroadcastTags(t *testing.T) {
+	tooMany := make([]string, domain.MaxBroadcastTags+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("tag-%d", i)
+	}
+
+	tests := []struct {
+		name    string
+		tags    []string
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "nil tags",
+			tags: nil,
+			want: nil,
+		},
+		{
+			name: "trims and drops empty and duplicate tags",
+			tags: []string{" newsletter", "promo ", "", "  ", "Newsletter"},
+			want: []string{"newsletter", "promo"},
+		},
+		{
+			name:    "tag too long",
+			tags:    []string{strings.Repeat("a", domain.MaxBroadcastTagLength+1)},
+			wantErr: "must be at most",
+		},
+		{
+			name:    "too many tags",
+			tags:    tooMany,
+			wantErr: "at most 20 tags",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := domain.NormalizeBroadcastTags(tt.tags)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestSetBroadcastTagsRequest_Validate(t *testing.T) {
+	req := domain.SetBroadcastTagsRequest{WorkspaceID: "workspace123", ID: "broadcast123", Tags: []string{" promo ", "promo"}}
+	require.NoError(t, req.Validate())
+	assert.Equal(t, []string{"promo"}, req.Tags)
+
+	req = domain.SetBroadcastTagsRequest{WorkspaceID: "workspace123"}
+	assert.EqualError(t, req.Validate(), "broadcast id is required")
+}
+
+func TestDeleteBroadcastTagRequest_Validate(t *testing.T) {
+	req := domain.DeleteBroadcastTagRequest{WorkspaceID: "workspace123", Tag: " promo "}
+	require.NoError(t, req.Validate())
+	assert.Equal(t, "promo", req.Tag)
+
+	req = domain.DeleteBroadcastTagRequest{WorkspaceID: "workspace123", Tag: " "}
+	assert.EqualError(t, req.Validate(), "tag is required")
+}
+
 // TestScheduleSettings_ParseScheduledDateTime tests the ParseScheduledDateTime method
 func TestScheduleSettings_ParseScheduledDateTime(t *testing.T) {
 	tests := []struct {
@@ -1239,6 +1450,22 @@ func TestAudienceSettings_ValueScan(t *testing.T) {
 }
 
 // TestScheduleSettings_SetScheduledDateTime tests the SetScheduledDateTime method
+func TestAudienceSettings_Lists(t *testing.T) {
+	single := domain.AudienceSettings{List: "list-all"}
+	assert.Equal(t, []string{"list-all"}, single.TargetLists())
+	assert.Equal(t, "list-all", single.PrimaryList())
+	assert.Nil(t, domain.AudienceSettings{}.TargetLists())
+
+	multi := domain.AudienceSettings{
+		Lists:         []string{"list-vip", "list-all"},
+		ListTemplates: map[string]string{"list-vip": "template-vip"},
+	}
+	assert.Equal(t, []string{"list-vip", "list-all"}, multi.TargetLists())
+	assert.Equal(t, "list-vip", multi.PrimaryList())
+	assert.Equal(t, "template-vip", multi.TemplateForList("list-vip", "template-1"))
+	assert.Equal(t, "template-1", multi.TemplateForList("list-all", "template-1"))
+}
+
 func TestScheduleSettings_SetScheduledDateTime(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -1315,6 +1542,9 @@ func TestScheduleSettings_SetScheduledDateTime(t *testing.T) {
 
 // TestGetBroadcastsRequest_FromURLParams tests the FromURLParams method of GetBroadcastsRequest
 func TestGetBroadcastsRequest_FromURLParams(t *testing.T) {
+	createdAfter := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	createdBefore := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+
 	tests := []struct {
 		name       string
 		urlParams  map[string][]string
@@ -1340,6 +1570,58 @@ func TestGetBroadcastsRequest_FromURLParams(t *testing.T) {
 				WithTemplates: true,
 			},
 		},
+		{
+			name: "tag and creation date filters",
+			urlParams: map[string][]string{
+				"workspace_id":   {"workspace123"},
+				"tag":            {" newsletter "},
+				"created_after":  {"2026-01-01T00:00:00Z"},
+				"created_before": {"2026-02-01T00:00:00Z"},
+			},
+			wantErr: false,
+			wantResult: domain.GetBroadcastsRequest{
+				WorkspaceID:   "workspace123",
+				Tag:           "newsletter",
+				CreatedAfter:  &createdAfter,
+				CreatedBefore: &createdBefore,
+			},
+		},
+		{
+			name: "invalid created_after parameter",
+			urlParams: map[string][]string{
+				"workspace_id":  {"workspace123"},
+				"created_after": {"yesterday"},
+			},
+			wantErr: true,
+			errMsg:  "invalid created_after time format",
+		},
+		{
+			name: "search, completion date and cursor",
+			urlParams: map[string][]string{
+				"workspace_id":     {"workspace123"},
+				"search":           {" spring sale "},
+				"completed_after":  {"2026-01-01T00:00:00Z"},
+				"completed_before": {"2026-02-01T00:00:00Z"},
+				"cursor":           {domain.EncodeBroadcastCursor(&domain.Broadcast{ID: "bc123", CreatedAt: createdAfter})},
+			},
+			wantErr: false,
+			wantResult: domain.GetBroadcastsRequest{
+				WorkspaceID:     "workspace123",
+				Search:          "spring sale",
+				CompletedAfter:  &createdAfter,
+				CompletedBefore: &createdBefore,
+				Cursor:          domain.EncodeBroadcastCursor(&domain.Broadcast{ID: "bc123", CreatedAt: createdAfter}),
+			},
+		},
+		{
+			name: "invalid cursor",
+			urlParams: map[string][]string{
+				"workspace_id": {"workspace123"},
+				"cursor":       {"bm90LWEtY3Vyc29y"},
+			},
+			wantErr: true,
+			errMsg:  "invalid cursor format",
+		},
 		{
 			name: "missing workspace_id",
 			urlParams: map[string][]string{
@@ -1692,6 +1974,53 @@ func TestParseBoolParam(t *testing.T) {
 }
 
 // TestScheduleSettings_ValueScan tests the Value and Scan methods for ScheduleSettings
+func TestNewAudiencePreflight(t *testing.T) {
+	enabled := &domain.DeliverableAudienceSettings{Enabled: true, MinRatio: 0.5}
+	blocking := &domain.DeliverableAudienceSettings{Enabled: true, MinRatio: 0.5, Block: true}
+
+	testCases := []struct {
+		name             string
+		rawCount         int
+		deliverableCount int
+		settings         *domain.DeliverableAudienceSettings
+		expectedRatio    float64
+		belowThreshold   bool
+		blocking         bool
+	}{
+		{name: "heavily suppressed audience warns", rawCount: 1000, deliverableCount: 100, settings: enabled, expectedRatio: 0.1, belowThreshold: true},
+		{name: "heavily suppressed audience blocks", rawCount: 1000, deliverableCount: 100, settings: blocking, expectedRatio: 0.1, belowThreshold: true, blocking: true},
+		{name: "healthy audience passes", rawCount: 1000, deliverableCount: 900, settings: blocking, expectedRatio: 0.9},
+		{name: "ratio equal to the minimum passes", rawCount: 10, deliverableCount: 5, settings: blocking, expectedRatio: 0.5},
+		{name: "empty audience passes", settings: blocking, expectedRatio: 1},
+		{name: "disabled settings never warn", rawCount: 1000, deliverableCount: 1, settings: &domain.DeliverableAudienceSettings{MinRatio: 0.5}, expectedRatio: 0.001},
+		{name: "missing settings never warn", rawCount: 1000, deliverableCount: 1, expectedRatio: 0.001},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			preflight := domain.NewAudiencePreflight(tc.rawCount, tc.deliverableCount, tc.settings)
+			assert.InDelta(t, tc.expectedRatio, preflight.DeliverableRatio, 0.0001)
+			assert.Equal(t, tc.belowThreshold, preflight.BelowThreshold)
+			assert.Equal(t, tc.blocking, preflight.Blocking)
+		})
+	}
+
+	err := &domain.ErrDeliverableAudienceTooLow{Preflight: domain.NewAudiencePreflight(1000, 100, blocking)}
+	assert.Equal(t, "only 100 of 1000 audience contacts are deliverable (10%), below the required 50%", err.Error())
+}
+
+func TestScheduleSettings_CutoffReached(t *testing.T) {
+	cutoffAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	noCutoff := domain.ScheduleSettings{}
+	assert.False(t, noCutoff.CutoffReached(cutoffAt.Add(24*time.Hour)))
+
+	withCutoff := domain.ScheduleSettings{SendCutoffAt: &cutoffAt}
+	assert.False(t, withCutoff.CutoffReached(cutoffAt.Add(-time.Second)))
+	assert.True(t, withCutoff.CutoffReached(cutoffAt))
+	assert.True(t, withCutoff.CutoffReached(cutoffAt.Add(time.Second)))
+}
+
 func TestScheduleSettings_ValueScan(t *testing.T) {
 	// Test serialization
 	original := domain.ScheduleSettings{
@@ -2108,3 +2437,207 @@ func TestGetTestResultsRequest_FromURLParams(t *testing.T) {
 		assert.Equal(t, "broadcast123", req.ID)
 	})
 }
+
+func TestBroadcast_PinTemplateVersions(t *testing.T) {
+	broadcast := &domain.Broadcast{
+		TestSettings: domain.BroadcastTestSettings{
+			Variations: []domain.BroadcastVariation{
+				{VariationName: "A", TemplateID: "tplA"},
+				{VariationName: "B", TemplateID: "tplB", TemplateVersion: 2},
+				{VariationName: "C", TemplateID: "tplC"},
+			},
+		},
+	}
+
+	pinned := broadcast.PinTemplateVersions(map[string]*domain.Template{
+		"tplA": {ID: "tplA", Version: 4},
+		"tplB": {ID: "tplB", Version: 5},
+	})
+
+	assert.True(t, pinned)
+	assert.Equal(t, int64(4), broadcast.TestSettings.Variations[0].TemplateVersion)
+	// Already pinned versions are kept
+	assert.Equal(t, int64(2), broadcast.TestSettings.Variations[1].TemplateVersion)
+	// Templates that were not loaded stay unpinned
+	assert.Equal(t, int64(0), broadcast.TestSettings.Variations[2].TemplateVersion)
+
+	assert.False(t, broadcast.PinTemplateVersions(map[string]*domain.Template{"tplA": {ID: "tplA", Version: 6}}))
+}
+
+func TestUpdateBroadcastRequest_Validate_KeepsPinnedTemplateVersions(t *testing.T) {
+	existing := createValidBroadcastWithTest()
+	existing.Status = domain.BroadcastStatusPaused
+	existing.TestSettings.Variations[0].TemplateID = "template123"
+	existing.TestSettings.Variations[0].TemplateVersion = 3
+
+	// Variations sent back by the client don't carry the pinned versions
+	testSettings := existing.TestSettings
+	testSettings.Variations = []domain.BroadcastVariation{
+		{VariationName: "variation1", TemplateID: "template123"},
+		{VariationName: "variation2", TemplateID: "template456"},
+	}
+	request := domain.UpdateBroadcastRequest{
+		WorkspaceID:  existing.WorkspaceID,
+		ID:           existing.ID,
+		Name:         existing.Name,
+		Audience:     existing.Audience,
+		Schedule:     existing.Schedule,
+		TestSettings: testSettings,
+	}
+
+	updated, err := request.Validate(&existing)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), updated.TestSettings.Variations[0].TemplateVersion)
+	assert.Equal(t, int64(0), updated.TestSettings.Variations[1].TemplateVersion)
+}
+
+func TestErrBroadcastTemplateChanged_Error(t *testing.T) {
+	err := &domain.ErrBroadcastTemplateChanged{Changes: []domain.BroadcastTemplateChange{
+		{TemplateID: "tplA", PinnedVersion: 1, CurrentVersion: 2},
+		{TemplateID: "tplB", PinnedVersion: 3, CurrentVersion: 5},
+	}}
+
+	assert.Equal(t, "templates changed since the broadcast started sending: tplA, tplB", err.Error())
+}
+
+func TestSendConfirmationToken(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	token, err := domain.NewSendConfirmationToken("secret", "w1", "b1", now.Add(5*time.Minute))
+	require.NoError(t, err)
+
+	assert.NoError(t, domain.VerifySendConfirmationToken("secret", token, "w1", "b1", now))
+
+	// The token is signed with a key derived for send confirmations, not with the raw secret key
+	expires, _, _ := strings.Cut(token, ".")
+	signedWithSecret := expires + "." + crypto.ComputeHMAC256([]byte("broadcast_send:w1:b1:"+expires), "secret")
+
+	rejected := map[string]error{
+		"missing":          domain.VerifySendConfirmationToken("secret", "", "w1", "b1", now),
+		"expired":          domain.VerifySendConfirmationToken("secret", token, "w1", "b1", now.Add(6*time.Minute)),
+		"other broadcast":  domain.VerifySendConfirmationToken("secret", token, "w1", "b2", now),
+		"other workspace":  domain.VerifySendConfirmationToken("secret", token, "w2", "b1", now),
+		"other secret key": domain.VerifySendConfirmationToken("other", token, "w1", "b1", now),
+		"malformed":        domain.VerifySendConfirmationToken("secret", "not-a-token", "w1", "b1", now),
+		"raw secret key":   domain.VerifySendConfirmationToken("secret", signedWithSecret, "w1", "b1", now),
+	}
+	for name, err := range rejected {
+		var confirmationErr *domain.ErrSendConfirmationRequired
+		assert.ErrorAs(t, err, &confirmationErr, name)
+	}
+	assert.Contains(t, rejected["expired"].Error(), "expired")
+
+	_, err = domain.NewSendConfirmationToken("", "w1", "b1", now)
+	assert.Error(t, err)
+}
+
+func TestNewBroadcastPhaseChangedEvent(t *testing.T) {
+	broadcast := &domain.Broadcast{ID: "b1", WorkspaceID: "w1", Name: "Launch", Status: domain.BroadcastStatusProcessed}
+	event := domain.NewBroadcastPhaseChangedEvent(broadcast, domain.BroadcastStatusWinnerSelected, time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC))
+
+	assert.Equal(t, domain.EventBroadcastPhaseChanged, event.Type)
+	assert.Equal(t, "w1", event.WorkspaceID)
+	assert.Equal(t, "b1", event.EntityID)
+	assert.Equal(t, "winner_selected", event.Data["old_status"])
+	assert.Equal(t, "processed", event.Data["new_status"])
+	assert.Equal(t, "winner_selected", event.Data["old_phase"])
+	assert.Equal(t, "sent", event.Data["phase"])
+	assert.Equal(t, "2026-03-01T12:00:00Z", event.Data["occurred_at"])
+	assert.Equal(t, "sending", domain.BroadcastPhase(domain.BroadcastStatusProcessing))
+}
+
+func TestScheduleSettings_ParseScheduledDateTime_DST(t *testing.T) {
+	tests := []struct {
+		name string
+		date string
+		time string
+		want time.Time
+	}{
+		{name: "standard time", date: "2026-03-07", time: "09:00", want: time.Date(2026, 3, 7, 14, 0, 0, 0, time.UTC)},
+		{name: "daylight time on the day DST starts", date: "2026-03-08", time: "09:00", want: time.Date(2026, 3, 8, 13, 0, 0, 0, time.UTC)},
+		// 02:30 does not exist on that day, clocks jump from 02:00 EST to 03:00 EDT
+		{name: "time skipped by DST moves forward", date: "2026-03-08", time: "02:30", want: time.Date(2026, 3, 8, 7, 30, 0, 0, time.UTC)},
+		// 01:30 happens twice on that day, first in EDT then in EST
+		{name: "repeated time uses its first occurrence", date: "2026-11-01", time: "01:30", want: time.Date(2026, 11, 1, 5, 30, 0, 0, time.UTC)},
+		{name: "standard time after DST ends", date: "2026-11-01", time: "09:00", want: time.Date(2026, 11, 1, 14, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := domain.ScheduleSettings{ScheduledDate: tt.date, ScheduledTime: tt.time, Timezone: "America/New_York"}
+
+			got, err := settings.ParseScheduledDateTime()
+			require.NoError(t, err)
+			assert.True(t, tt.want.Equal(got), "got %s, want %s", got.UTC(), tt.want)
+			assert.Equal(t, "America/New_York", got.Location().String())
+		})
+	}
+}
+
+func TestBroadcastSeedTest_Validate(t *testing.T) {
+	withSeedTest := func(seedTest domain.BroadcastSeedTest) domain.Broadcast {
+		b := createValidBroadcast()
+		b.SeedTest = seedTest
+		return b
+	}
+
+	b := withSeedTest(domain.BroadcastSeedTest{Enabled: true, Emails: []string{"seed@gmail.com", "seed@outlook.com"}, RequireApproval: true})
+	assert.NoError(t, b.Validate())
+
+	// A disabled seed test is not checked
+	b = withSeedTest(domain.BroadcastSeedTest{Emails: []string{"not-an-email"}})
+	assert.NoError(t, b.Validate())
+
+	tooMany := make([]string, domain.MaxSeedTestEmails+1)
+	for i := range tooMany {
+		tooMany[i] = fmt.Sprintf("seed%d@example.com", i)
+	}
+	invalid := map[string]domain.BroadcastSeedTest{
+		"at least one seed email is required":  {Enabled: true},
+		"maximum 50 seed emails are allowed":   {Enabled: true, Emails: tooMany},
+		"invalid seed email: not-an-email":     {Enabled: true, Emails: []string{"not-an-email"}},
+		"duplicate seed email: Seed@Gmail.com": {Enabled: true, Emails: []string{"seed@gmail.com", "Seed@Gmail.com"}},
+	}
+	for errMsg, seedTest := range invalid {
+		b := withSeedTest(seedTest)
+		err := b.Validate()
+		require.Error(t, err, errMsg)
+		assert.Contains(t, err.Error(), errMsg)
+	}
+
+	b = withSeedTest(domain.BroadcastSeedTest{Enabled: true, Emails: []string{"seed@gmail.com"}})
+	b.ChannelType = domain.ChannelSMS
+	err := b.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "seed test is not supported for sms broadcasts")
+}
+
+func TestUpdateBroadcastRequest_Validate_KeepsSeedTestOutcome(t *testing.T) {
+	sentAt := time.Now().UTC()
+	existing := createValidBroadcast()
+	existing.Status = domain.BroadcastStatusPaused
+	existing.SeedTest = domain.BroadcastSeedTest{
+		Enabled: true,
+		Emails:  []string{"seed@gmail.com"},
+		SentAt:  &sentAt,
+		Results: []domain.SeedTestResult{{Email: "seed@gmail.com", MailboxProvider: "gmail.com", Status: domain.SeedTestStatusSent}},
+	}
+
+	req := domain.UpdateBroadcastRequest{
+		WorkspaceID: existing.WorkspaceID,
+		ID:          existing.ID,
+		Name:        existing.Name,
+		Audience:    existing.Audience,
+		SeedTest:    domain.BroadcastSeedTest{Enabled: true, Emails: []string{"seed@gmail.com", "seed@yahoo.com"}},
+	}
+	updated, err := req.Validate(&existing)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"seed@gmail.com", "seed@yahoo.com"}, updated.SeedTest.Emails)
+	assert.Equal(t, &sentAt, updated.SeedTest.SentAt)
+	assert.Len(t, updated.SeedTest.Results, 1)
+}
+
+func TestApproveSeedTestRequest_Validate(t *testing.T) {
+	assert.NoError(t, (&domain.ApproveSeedTestRequest{WorkspaceID: "workspace123", ID: "broadcast123"}).Validate())
+	assert.EqualError(t, (&domain.ApproveSeedTestRequest{ID: "broadcast123"}).Validate(), "workspace_id is required")
+	assert.EqualError(t, (&domain.ApproveSeedTestRequest{WorkspaceID: "workspace123"}).Validate(), "broadcast id is required")
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/url"
 	"strconv"
 	"time"
@@ -16,7 +17,8 @@ import (
 )
 
 var (
-	ErrContactNotFound = errors.New("contact not found")
+	ErrContactNotFound     = errors.New("contact not found")
+	ErrSuppressionNotFound = errors.New("suppression not found")
 )
 
 //go:generate mockgen -destination mocks/mock_contact_service.go -package mocks github.com/Notifuse/notifuse/internal/domain ContactService
@@ -81,10 +83,16 @@ type Contact struct {
 	ContactSegments []*ContactSegment `json:"contact_segments"`
 	// Not persisted
 	EmailHMAC string `json:"email_hmac,omitempty"`
+	// ImportError holds the parse failure of an imported row so Validate reports the real cause
+	ImportError string `json:"-"`
 }
 
 // Validate ensures that the contact has all required fields
 func (c *Contact) Validate() error {
+	if c.ImportError != "" {
+		return errors.New(c.ImportError)
+	}
+
 	// Email is required
 	if c.Email == "" {
 		return fmt.Errorf("email is required")
@@ -481,6 +489,100 @@ type GetContactByExternalIDRequest struct {
 	ExternalID  string `json:"external_id" valid:"required"`
 }
 
+// DefaultBulkGetContactsMax is the default number of emails or external IDs accepted by a bulk get
+const DefaultBulkGetContactsMax = 500
+
+// BulkGetContactsRequest fetches many contacts at once by email or by external ID
+type BulkGetContactsRequest struct {
+	WorkspaceID string   `json:"workspace_id"`
+	Emails      []string `json:"emails,omitempty"`
+	ExternalIDs []string `json:"external_ids,omitempty"`
+}
+
+// Validate validates the bulk get request against the maximum number of keys
+func (r *BulkGetContactsRequest) Validate(max int) error {
+	if r.WorkspaceID == "" {
+		return fmt.Errorf("workspace_id is required")
+	}
+	if len(r.Emails) > 0 && len(r.ExternalIDs) > 0 {
+		return fmt.Errorf("provide either emails or external_ids, not both")
+	}
+	keys := r.Keys()
+	if len(keys) == 0 {
+		return fmt.Errorf("emails or external_ids is required")
+	}
+	if len(keys) > max {
+		return fmt.Errorf("at most %d emails or external_ids are allowed, got %d", max, len(keys))
+	}
+	for _, key := range keys {
+		if key == "" {
+			return fmt.Errorf("emails and external_ids cannot be empty")
+		}
+	}
+	return nil
+}
+
+// Keys returns the requested emails or external IDs
+func (r *BulkGetContactsRequest) Keys() []string {
+	if len(r.Emails) > 0 {
+		return r.Emails
+	}
+	return r.ExternalIDs
+}
+
+// BulkGetContactsResponse lists the matching contacts in request order and the keys without a contact
+type BulkGetContactsResponse struct {
+	Contacts []*Contact `json:"contacts"`
+	NotFound []string   `json:"not_found"`
+}
+
+// NewBulkGetContactsResponse orders the contacts as requested. Duplicate keys return the contact once.
+func NewBulkGetContactsResponse(req *BulkGetContactsRequest, contacts []*Contact) *BulkGetContactsResponse {
+	byKey := make(map[string]*Contact, len(contacts))
+	for _, contact := range contacts {
+		if len(req.Emails) > 0 {
+			byKey[contact.Email] = contact
+		} else if contact.ExternalID != nil && !contact.ExternalID.IsNull {
+			byKey[contact.ExternalID.String] = contact
+		}
+	}
+
+	response := &BulkGetContactsResponse{Contacts: []*Contact{}, NotFound: []string{}}
+	seen := make(map[string]bool, len(req.Keys()))
+	for _, key := range req.Keys() {
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		if contact, ok := byKey[key]; ok {
+			response.Contacts = append(response.Contacts, contact)
+		} else {
+			response.NotFound = append(response.NotFound, key)
+		}
+	}
+	return response
+}
+
+// ListContactCount is the number of active subscribers of a list
+type ListContactCount struct {
+	ListID      string `json:"list_id"`
+	Name        string `json:"name"`
+	ActiveCount int    `json:"active_count"`
+}
+
+// SegmentContactCount is the number of contacts matching the current version of a segment
+type SegmentContactCount struct {
+	SegmentID    string `json:"segment_id"`
+	Name         string `json:"name"`
+	ContactCount int    `json:"contact_count"`
+}
+
+// AudienceCounts lists the contact counts of every list and segment of a workspace, deleted ones excluded
+type AudienceCounts struct {
+	Lists    []ListContactCount    `json:"lists"`
+	Segments []SegmentContactCount `json:"segments"`
+}
+
 type DeleteContactRequest struct {
 	WorkspaceID string `json:"workspace_id" valid:"required"`
 	Email       string `json:"email" valid:"required,email"`
@@ -499,11 +601,31 @@ func (r *DeleteContactRequest) Validate() error {
 	return nil
 }
 
+// ImportConflictPolicy decides what an import does with a row whose email matches an existing contact
+type ImportConflictPolicy string
+
+const (
+	ImportConflictOverwrite ImportConflictPolicy = "overwrite"  // Provided fields replace the existing values
+	ImportConflictFillEmpty ImportConflictPolicy = "fill_empty" // Provided fields only set the existing contact's empty fields
+	ImportConflictSkip      ImportConflictPolicy = "skip"       // The existing contact is left unchanged
+)
+
+// IsValid checks if the import conflict policy is supported
+func (p ImportConflictPolicy) IsValid() bool {
+	switch p {
+	case ImportConflictOverwrite, ImportConflictFillEmpty, ImportConflictSkip:
+		return true
+	}
+	return false
+}
+
 // Add the request type for batch importing contacts
 type BatchImportContactsRequest struct {
-	WorkspaceID      string          `json:"workspace_id" valid:"required"`
-	Contacts         json.RawMessage `json:"contacts" valid:"required"`
-	SubscribeToLists []string        `json:"subscribe_to_lists,omitempty"` // Optional: subscribe contacts to these lists
+	WorkspaceID      string               `json:"workspace_id" valid:"required"`
+	Contacts         json.RawMessage      `json:"contacts" valid:"required"`
+	SubscribeToLists []string             `json:"subscribe_to_lists,omitempty"` // Optional: subscribe contacts to these lists
+	ValidateOnly     bool                 `json:"validate_only,omitempty"`      // Optional: validate every row without writing anything
+	OnConflict       ImportConflictPolicy `json:"on_conflict,omitempty"`        // Optional: policy for rows matching an existing contact, overwrite by default
 }
 
 func (r *BatchImportContactsRequest) Validate() (contacts []*Contact, workspaceID string, err error) {
@@ -511,6 +633,12 @@ func (r *BatchImportContactsRequest) Validate() (contacts []*Contact, workspaceI
 		return nil, "", fmt.Errorf("workspace_id is required")
 	}
 
+	if r.OnConflict == "" {
+		r.OnConflict = ImportConflictOverwrite
+	} else if !r.OnConflict.IsValid() {
+		return nil, "", fmt.Errorf("invalid on_conflict: %s, must be overwrite, fill_empty or skip", r.OnConflict)
+	}
+
 	// Parse the raw JSON bytes directly as an array
 	jsonResult := gjson.ParseBytes(r.Contacts)
 	if !jsonResult.IsArray() {
@@ -529,8 +657,11 @@ func (r *BatchImportContactsRequest) Validate() (contacts []*Contact, workspaceI
 	for _, contactJson := range contactsArray {
 		contact, err := FromJSON(contactJson)
 		if err != nil {
-			// For unparseable contacts, create an empty contact that will fail validation in the service
-			contact = &Contact{}
+			// For unparseable contacts, keep the parse error so the row fails validation in the service
+			contact = &Contact{
+				Email:       contactJson.Get("email").String(),
+				ImportError: err.Error(),
+			}
 		}
 		contacts = append(contacts, contact)
 	}
@@ -558,19 +689,42 @@ func (r *UpsertContactRequest) Validate() (contact *Contact, workspaceID string,
 }
 
 type BatchImportContactsResponse struct {
-	Operations []*UpsertContactOperation `json:"operations"`
-	Error      string                    `json:"error,omitempty"`
+	Operations   []*UpsertContactOperation `json:"operations"`
+	ValidateOnly bool                      `json:"validate_only,omitempty"`
+	Error        string                    `json:"error,omitempty"`
+}
+
+// ContactImportLineError reports a line of a JSON Lines contact import that was not imported
+type ContactImportLineError struct {
+	Line  int    `json:"line"` // 1-based line number in the request body
+	Error string `json:"error"`
+}
+
+// ImportContactLinesResponse summarizes a JSON Lines contact import. Every non-empty line is counted
+// once in Created, Updated, Skipped, Valid or Failed.
+type ImportContactLinesResponse struct {
+	Lines           int                      `json:"lines"`
+	Created         int                      `json:"created"`
+	Updated         int                      `json:"updated"`
+	Skipped         int                      `json:"skipped"` // Existing contacts left as is by the ignore_existing strategy
+	Valid           int                      `json:"valid"`   // Dry run only: lines that would be imported
+	Failed          int                      `json:"failed"`
+	Errors          []ContactImportLineError `json:"errors"`
+	ErrorsTruncated bool                     `json:"errors_truncated,omitempty"` // More lines failed than reported in Errors
+	DryRun          bool                     `json:"dry_run,omitempty"`
 }
 
 const (
 	UpsertContactOperationCreate = "create"
 	UpsertContactOperationUpdate = "update"
+	UpsertContactOperationValid  = "valid"
+	UpsertContactOperationSkip   = "skip"
 	UpsertContactOperationError  = "error"
 )
 
 type UpsertContactOperation struct {
 	Email  string `json:"email"`
-	Action string `json:"action"` // create or update or error
+	Action string `json:"action"` // create, update, skip or error, valid in validate-only imports
 	Error  string `json:"error,omitempty"`
 }
 
@@ -585,17 +739,52 @@ type ContactService interface {
 	// GetContacts retrieves contacts with filters and pagination
 	GetContacts(ctx context.Context, req *GetContactsRequest) (*GetContactsResponse, error)
 
+	// BulkGetContacts retrieves the contacts matching a list of emails or external IDs
+	BulkGetContacts(ctx context.Context, req *BulkGetContactsRequest) (*BulkGetContactsResponse, error)
+
 	// DeleteContact deletes a contact by email
 	DeleteContact(ctx context.Context, workspaceID string, email string) error
 
-	// BatchImportContacts imports a batch of contacts (create or update)
-	BatchImportContacts(ctx context.Context, workspaceID string, contacts []*Contact, listIDs []string) *BatchImportContactsResponse
+	// BatchImportContacts imports a batch of contacts (create or update), applying the conflict policy to existing contacts
+	BatchImportContacts(ctx context.Context, workspaceID string, contacts []*Contact, listIDs []string, onConflict ImportConflictPolicy) *BatchImportContactsResponse
+
+	// ValidateImportContacts runs the batch import validation without writing anything
+	ValidateImportContacts(ctx context.Context, workspaceID string, contacts []*Contact, listIDs []string) *BatchImportContactsResponse
+
+	// ImportContactLines imports the contacts of a JSON Lines stream, one contact object per line, in batches.
+	// Invalid lines are reported in the response without stopping the import. With dryRun nothing is written.
+	ImportContactLines(ctx context.Context, workspaceID string, body io.Reader, strategy MergeStrategy, dryRun bool) (*ImportContactLinesResponse, error)
 
 	// UpsertContact creates a new contact or updates an existing one
 	UpsertContact(ctx context.Context, workspaceID string, contact *Contact) UpsertContactOperation
 
 	// CountContacts returns the total number of contacts in a workspace
 	CountContacts(ctx context.Context, workspaceID string) (int, error)
+
+	// GetAudienceCounts returns the contact counts per list and per segment of a workspace
+	GetAudienceCounts(ctx context.Context, workspaceID string) (*AudienceCounts, error)
+}
+
+// ContactRedactionResult reports the rows affected per table by a contact redaction
+type ContactRedactionResult struct {
+	Contacts        int64 `json:"contacts"`         // Contact rows whose personal data was scrubbed
+	ContactLists    int64 `json:"contact_lists"`    // List memberships deleted
+	ContactSegments int64 `json:"contact_segments"` // Segment memberships deleted
+}
+
+// ContactDuplicateGroup is a set of contacts sharing the same external ID, usually left by imports
+// that matched rows on email while the external ID identified the same person
+type ContactDuplicateGroup struct {
+	ExternalID string     `json:"external_id"`
+	Contacts   []*Contact `json:"contacts"` // Oldest first
+}
+
+// ContactMergeResult reports the rows moved to the primary contact by a merge
+type ContactMergeResult struct {
+	ContactLists    int64 `json:"contact_lists"`    // List memberships added to the primary contact
+	ContactSegments int64 `json:"contact_segments"` // Segment memberships added to the primary contact
+	MessageHistory  int64 `json:"message_history"`  // Messages reassigned to the primary contact
+	Redacted        int64 `json:"redacted"`         // Duplicate contacts whose personal data was scrubbed
 }
 
 // ContactRepository is the interface for contact operations
@@ -605,6 +794,29 @@ type BulkUpsertResult struct {
 	IsNew bool // true if inserted, false if updated
 }
 
+// MergeStrategy controls how an upsert merges an incoming contact into an existing row with the same email
+type MergeStrategy string
+
+const (
+	// MergeStrategyOverwriteAll replaces existing values with the incoming ones. Fields the incoming contact
+	// does not provide keep their existing value.
+	MergeStrategyOverwriteAll MergeStrategy = "overwrite_all"
+	// MergeStrategyFillEmptyOnly only sets the fields that are null or empty on the existing row
+	MergeStrategyFillEmptyOnly MergeStrategy = "fill_empty_only"
+	// MergeStrategyIgnoreExisting leaves existing rows untouched and only inserts new contacts
+	MergeStrategyIgnoreExisting MergeStrategy = "ignore_existing"
+)
+
+// Validate checks that the merge strategy is supported
+func (s MergeStrategy) Validate() error {
+	switch s {
+	case MergeStrategyOverwriteAll, MergeStrategyFillEmptyOnly, MergeStrategyIgnoreExisting:
+		return nil
+	default:
+		return fmt.Errorf("invalid merge strategy: %s", s)
+	}
+}
+
 type ContactRepository interface {
 	// GetContactByEmail retrieves a contact by email
 	GetContactByEmail(ctx context.Context, workspaceID, email string) (*Contact, error)
@@ -615,15 +827,36 @@ type ContactRepository interface {
 	// GetContacts retrieves contacts with filtering and pagination
 	GetContacts(ctx context.Context, req *GetContactsRequest) (*GetContactsResponse, error)
 
+	// GetContactsByEmails retrieves the contacts matching the emails, with their lists and segments
+	GetContactsByEmails(ctx context.Context, workspaceID string, emails []string) ([]*Contact, error)
+
+	// GetContactsByExternalIDs retrieves the contacts matching the external IDs, with their lists and segments
+	GetContactsByExternalIDs(ctx context.Context, workspaceID string, externalIDs []string) ([]*Contact, error)
+
 	// DeleteContact deletes a contact
 	DeleteContact(ctx context.Context, workspaceID string, email string) error
 
+	// RedactContact scrubs the personal data of a contact and deletes its list and segment memberships
+	// in a single transaction. Redacting an already redacted contact succeeds with zero counts.
+	RedactContact(ctx context.Context, workspaceID string, email string) (*ContactRedactionResult, error)
+
+	// FindDuplicatesByExternalID returns the groups of contacts sharing an external ID
+	FindDuplicatesByExternalID(ctx context.Context, workspaceID string) ([]*ContactDuplicateGroup, error)
+
+	// MergeContacts moves the list and segment memberships and the message history of the duplicate
+	// contacts to the primary contact, then redacts the duplicates, in a single transaction
+	MergeContacts(ctx context.Context, workspaceID string, primaryEmail string, duplicateEmails []string) (*ContactMergeResult, error)
+
 	// UpsertContact creates or updates a contact
 	UpsertContact(ctx context.Context, workspaceID string, contact *Contact) (bool, error)
 
 	// BulkUpsertContacts creates or updates multiple contacts in a single operation
 	BulkUpsertContacts(ctx context.Context, workspaceID string, contacts []*Contact) ([]BulkUpsertResult, error)
 
+	// UpsertContacts creates or updates multiple contacts in a single statement, merging them into existing rows
+	// according to the strategy. Contacts skipped by MergeStrategyIgnoreExisting are not part of the results.
+	UpsertContacts(ctx context.Context, workspaceID string, contacts []*Contact, strategy MergeStrategy) ([]BulkUpsertResult, error)
+
 	// GetContactsForBroadcast retrieves contacts based on broadcast audience settings
 	// Uses cursor-based pagination: afterEmail is the last email from the previous batch (empty for first batch)
 	GetContactsForBroadcast(ctx context.Context, workspaceID string, audience AudienceSettings, limit int, afterEmail string) ([]*ContactWithList, error)
@@ -631,11 +864,31 @@ type ContactRepository interface {
 	// CountContactsForBroadcast counts contacts based on broadcast audience settings
 	CountContactsForBroadcast(ctx context.Context, workspaceID string, audience AudienceSettings) (int, error)
 
+	// CountDeliverableContactsForBroadcast counts the raw audience (including unsubscribed contacts)
+	// and the part of it that can receive email: not unsubscribed, bounced or complained
+	CountDeliverableContactsForBroadcast(ctx context.Context, workspaceID string, audience AudienceSettings) (rawCount int, deliverableCount int, err error)
+
 	// Count returns the total number of contacts in a workspace
 	Count(ctx context.Context, workspaceID string) (int, error)
 
+	// GetAudienceCounts counts active list subscriptions and segment memberships with one aggregate query each
+	GetAudienceCounts(ctx context.Context, workspaceID string) (*AudienceCounts, error)
+
 	// GetBatchForSegment retrieves a batch of email addresses for segment processing
 	GetBatchForSegment(ctx context.Context, workspaceID string, offset int64, limit int) ([]string, error)
+
+	// GetOptimalSendHours returns, for each of the emails with open history, the UTC hour of day (0-23)
+	// the contact most frequently opened messages at. Emails that never opened a message are absent.
+	GetOptimalSendHours(ctx context.Context, workspaceID string, emails []string) (map[string]int, error)
+
+	// AddSuppressions adds emails to the workspace suppression list, already suppressed emails keep their first reason
+	AddSuppressions(ctx context.Context, workspaceID string, suppressions []*EmailSuppression) error
+
+	// ListSuppressions lists the suppressed emails ordered by email, after afterEmail when it is set
+	ListSuppressions(ctx context.Context, workspaceID string, limit int, afterEmail string) ([]*EmailSuppression, error)
+
+	// RemoveSuppression removes an email from the suppression list so that broadcasts reach it again
+	RemoveSuppression(ctx context.Context, workspaceID string, email string) error
 }
 
 // FromJSON parses JSON data into a Contact struct
@@ -857,137 +1110,147 @@ func parseNullableTime(result gjson.Result, field string, target **NullableTime)
 
 // Merge updates non-nil fields from another contact
 func (c *Contact) Merge(other *Contact) {
+	c.merge(other, false)
+}
+
+// FillEmpty sets the nil fields of the contact from another contact and keeps the ones already set
+func (c *Contact) FillEmpty(other *Contact) {
+	c.merge(other, true)
+}
+
+// merge copies the fields provided by other, only into the nil or zero fields when fillEmptyOnly is set
+func (c *Contact) merge(other *Contact, fillEmptyOnly bool) {
 	if other == nil {
 		return
 	}
 
 	// Required fields
-	if other.Email != "" {
+	if other.Email != "" && (!fillEmptyOnly || c.Email == "") {
 		c.Email = other.Email
 	}
 
 	// Optional fields
-	if other.ExternalID != nil {
+	if other.ExternalID != nil && (!fillEmptyOnly || c.ExternalID == nil) {
 		c.ExternalID = other.ExternalID
 	}
-	if other.Timezone != nil {
+	if other.Timezone != nil && (!fillEmptyOnly || c.Timezone == nil) {
 		c.Timezone = other.Timezone
 	}
-	if other.Language != nil {
+	if other.Language != nil && (!fillEmptyOnly || c.Language == nil) {
 		c.Language = other.Language
 	}
-	if other.FirstName != nil {
+	if other.FirstName != nil && (!fillEmptyOnly || c.FirstName == nil) {
 		c.FirstName = other.FirstName
 	}
-	if other.LastName != nil {
+	if other.LastName != nil && (!fillEmptyOnly || c.LastName == nil) {
 		c.LastName = other.LastName
 	}
-	if other.FullName != nil {
+	if other.FullName != nil && (!fillEmptyOnly || c.FullName == nil) {
 		c.FullName = other.FullName
 	}
-	if other.Phone != nil {
+	if other.Phone != nil && (!fillEmptyOnly || c.Phone == nil) {
 		c.Phone = other.Phone
 	}
-	if other.AddressLine1 != nil {
+	if other.AddressLine1 != nil && (!fillEmptyOnly || c.AddressLine1 == nil) {
 		c.AddressLine1 = other.AddressLine1
 	}
-	if other.AddressLine2 != nil {
+	if other.AddressLine2 != nil && (!fillEmptyOnly || c.AddressLine2 == nil) {
 		c.AddressLine2 = other.AddressLine2
 	}
-	if other.Country != nil {
+	if other.Country != nil && (!fillEmptyOnly || c.Country == nil) {
 		c.Country = other.Country
 	}
-	if other.Postcode != nil {
+	if other.Postcode != nil && (!fillEmptyOnly || c.Postcode == nil) {
 		c.Postcode = other.Postcode
 	}
-	if other.State != nil {
+	if other.State != nil && (!fillEmptyOnly || c.State == nil) {
 		c.State = other.State
 	}
-	if other.JobTitle != nil {
+	if other.JobTitle != nil && (!fillEmptyOnly || c.JobTitle == nil) {
 		c.JobTitle = other.JobTitle
 	}
 
 	// Custom string fields
-	if other.CustomString1 != nil {
+	if other.CustomString1 != nil && (!fillEmptyOnly || c.CustomString1 == nil) {
 		c.CustomString1 = other.CustomString1
 	}
-	if other.CustomString2 != nil {
+	if other.CustomString2 != nil && (!fillEmptyOnly || c.CustomString2 == nil) {
 		c.CustomString2 = other.CustomString2
 	}
-	if other.CustomString3 != nil {
+	if other.CustomString3 != nil && (!fillEmptyOnly || c.CustomString3 == nil) {
 		c.CustomString3 = other.CustomString3
 	}
-	if other.CustomString4 != nil {
+	if other.CustomString4 != nil && (!fillEmptyOnly || c.CustomString4 == nil) {
 		c.CustomString4 = other.CustomString4
 	}
-	if other.CustomString5 != nil {
+	if other.CustomString5 != nil && (!fillEmptyOnly || c.CustomString5 == nil) {
 		c.CustomString5 = other.CustomString5
 	}
 
 	// Custom number fields
-	if other.CustomNumber1 != nil {
+	if other.CustomNumber1 != nil && (!fillEmptyOnly || c.CustomNumber1 == nil) {
 		c.CustomNumber1 = other.CustomNumber1
 	}
-	if other.CustomNumber2 != nil {
+	if other.CustomNumber2 != nil && (!fillEmptyOnly || c.CustomNumber2 == nil) {
 		c.CustomNumber2 = other.CustomNumber2
 	}
-	if other.CustomNumber3 != nil {
+	if other.CustomNumber3 != nil && (!fillEmptyOnly || c.CustomNumber3 == nil) {
 		c.CustomNumber3 = other.CustomNumber3
 	}
-	if other.CustomNumber4 != nil {
+	if other.CustomNumber4 != nil && (!fillEmptyOnly || c.CustomNumber4 == nil) {
 		c.CustomNumber4 = other.CustomNumber4
 	}
-	if other.CustomNumber5 != nil {
+	if other.CustomNumber5 != nil && (!fillEmptyOnly || c.CustomNumber5 == nil) {
 		c.CustomNumber5 = other.CustomNumber5
 	}
 
 	// Custom datetime fields
-	if other.CustomDatetime1 != nil {
+	if other.CustomDatetime1 != nil && (!fillEmptyOnly || c.CustomDatetime1 == nil) {
 		c.CustomDatetime1 = other.CustomDatetime1
 	}
-	if other.CustomDatetime2 != nil {
+	if other.CustomDatetime2 != nil && (!fillEmptyOnly || c.CustomDatetime2 == nil) {
 		c.CustomDatetime2 = other.CustomDatetime2
 	}
-	if other.CustomDatetime3 != nil {
+	if other.CustomDatetime3 != nil && (!fillEmptyOnly || c.CustomDatetime3 == nil) {
 		c.CustomDatetime3 = other.CustomDatetime3
 	}
-	if other.CustomDatetime4 != nil {
+	if other.CustomDatetime4 != nil && (!fillEmptyOnly || c.CustomDatetime4 == nil) {
 		c.CustomDatetime4 = other.CustomDatetime4
 	}
-	if other.CustomDatetime5 != nil {
+	if other.CustomDatetime5 != nil && (!fillEmptyOnly || c.CustomDatetime5 == nil) {
 		c.CustomDatetime5 = other.CustomDatetime5
 	}
 
 	// Custom JSON fields
-	if other.CustomJSON1 != nil {
+	if other.CustomJSON1 != nil && (!fillEmptyOnly || c.CustomJSON1 == nil) {
 		c.CustomJSON1 = other.CustomJSON1
 	}
-	if other.CustomJSON2 != nil {
+	if other.CustomJSON2 != nil && (!fillEmptyOnly || c.CustomJSON2 == nil) {
 		c.CustomJSON2 = other.CustomJSON2
 	}
-	if other.CustomJSON3 != nil {
+	if other.CustomJSON3 != nil && (!fillEmptyOnly || c.CustomJSON3 == nil) {
 		c.CustomJSON3 = other.CustomJSON3
 	}
-	if other.CustomJSON4 != nil {
+	if other.CustomJSON4 != nil && (!fillEmptyOnly || c.CustomJSON4 == nil) {
 		c.CustomJSON4 = other.CustomJSON4
 	}
-	if other.CustomJSON5 != nil {
+	if other.CustomJSON5 != nil && (!fillEmptyOnly || c.CustomJSON5 == nil) {
 		c.CustomJSON5 = other.CustomJSON5
 	}
 
 	// Update timestamps
-	if !other.CreatedAt.IsZero() {
+	if !other.CreatedAt.IsZero() && (!fillEmptyOnly || c.CreatedAt.IsZero()) {
 		c.CreatedAt = other.CreatedAt
 	}
-	if !other.UpdatedAt.IsZero() {
+	if !other.UpdatedAt.IsZero() && (!fillEmptyOnly || c.UpdatedAt.IsZero()) {
 		c.UpdatedAt = other.UpdatedAt
 	}
 
 	// Update DB timestamps
-	if !other.DBCreatedAt.IsZero() {
+	if !other.DBCreatedAt.IsZero() && (!fillEmptyOnly || c.DBCreatedAt.IsZero()) {
 		c.DBCreatedAt = other.DBCreatedAt
 	}
-	if !other.DBUpdatedAt.IsZero() {
+	if !other.DBUpdatedAt.IsZero() && (!fillEmptyOnly || c.DBUpdatedAt.IsZero()) {
 		c.DBUpdatedAt = other.DBUpdatedAt
 	}
 }
@@ -1036,4 +1299,15 @@ type ContactWithList struct {
 	Contact  *Contact `json:"contact"`   // The contact
 	ListID   string   `json:"list_id"`   // ID of the list that the contact belongs to
 	ListName string   `json:"list_name"` // Name of the list that the contact belongs to
+	// SendAt defers the delivery of a broadcast message to the contact, nil sends it immediately
+	SendAt *time.Time `json:"-"`
+}
+
+// EmailSuppression is an email excluded from all the broadcasts of a workspace after a hard bounce
+// or a spam complaint, until it is removed manually
+type EmailSuppression struct {
+	Email     string         `json:"email"`
+	Reason    BounceCategory `json:"reason"`
+	MessageID *string        `json:"message_id,omitempty"` // Message the bounce or complaint was received for
+	CreatedAt time.Time      `json:"created_at"`
 }
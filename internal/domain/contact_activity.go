@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ContactActivityType classifies the contact activities pushed to contact.activity webhooks
+type ContactActivityType string
+
+const (
+	// ContactActivityCreated is emitted when a contact is created
+	ContactActivityCreated ContactActivityType = "contact_created"
+
+	// ContactActivityFieldUpdate is emitted when fields of a contact change
+	ContactActivityFieldUpdate ContactActivityType = "field_update"
+
+	// ContactActivityListChange is emitted when a contact joins a list or its subscription status changes
+	ContactActivityListChange ContactActivityType = "list_change"
+
+	// ContactActivitySegmentChange is emitted when a contact joins or leaves a segment
+	ContactActivitySegmentChange ContactActivityType = "segment_change"
+
+	// ContactActivityEngagement is emitted when a message is sent to a contact or the contact engages with it
+	ContactActivityEngagement ContactActivityType = "engagement"
+)
+
+// ContactActivity is the envelope of a contact activity.
+// Activities of a contact are delivered in checkpoint order.
+type ContactActivity struct {
+	ID         string                 `json:"id"` // Timeline entry ID, stable across replays
+	Type       ContactActivityType    `json:"type"`
+	Kind       string                 `json:"kind"` // Timeline kind (e.g. update_contact, open_email)
+	Email      string                 `json:"email"`
+	EntityType string                 `json:"entity_type"`
+	EntityID   *string                `json:"entity_id,omitempty"`
+	Changes    map[string]interface{} `json:"changes,omitempty"`
+	OccurredAt time.Time              `json:"occurred_at"`
+	Checkpoint string                 `json:"checkpoint"` // Position to replay from
+}
+
+// ContactActivityTypeForKind maps a contact timeline kind to its activity type.
+// It returns false for kinds that are not contact activities.
+func ContactActivityTypeForKind(kind string) (ContactActivityType, bool) {
+	switch kind {
+	case "insert_contact":
+		return ContactActivityCreated, true
+	case "update_contact":
+		return ContactActivityFieldUpdate, true
+	case "insert_contact_list", "update_contact_list":
+		return ContactActivityListChange, true
+	case "join_segment", "leave_segment":
+		return ContactActivitySegmentChange, true
+	case "insert_message_history":
+		return ContactActivityEngagement, true
+	}
+
+	// Engagement kinds are suffixed with the channel (e.g. open_email, click_email)
+	for _, prefix := range []string{"open_", "click_", "bounce_", "complain_", "unsubscribe_"} {
+		if strings.HasPrefix(kind, prefix) {
+			return ContactActivityEngagement, true
+		}
+	}
+
+	return "", false
+}
+
+// NewContactActivity builds the activity of a contact timeline entry.
+// It returns false for entries that are not contact activities.
+func NewContactActivity(entry *ContactTimelineEntry) (*ContactActivity, bool) {
+	activityType, ok := ContactActivityTypeForKind(entry.Kind)
+	if !ok {
+		return nil, false
+	}
+
+	return &ContactActivity{
+		ID:         entry.ID,
+		Type:       activityType,
+		Kind:       entry.Kind,
+		Email:      entry.Email,
+		EntityType: entry.EntityType,
+		EntityID:   entry.EntityID,
+		Changes:    entry.Changes,
+		OccurredAt: entry.CreatedAt,
+		Checkpoint: NewContactActivityCheckpoint(entry.DBCreatedAt, entry.ID),
+	}, true
+}
+
+// NewContactActivityCheckpoint encodes a contact timeline position.
+// Checkpoints sort lexicographically in timeline order.
+func NewContactActivityCheckpoint(dbCreatedAt time.Time, id string) string {
+	return fmt.Sprintf("%020d-%s", dbCreatedAt.UnixNano(), id)
+}
+
+// ParseContactActivityCheckpoint decodes a checkpoint into its timeline insertion time and entry ID
+func ParseContactActivityCheckpoint(checkpoint string) (time.Time, string, error) {
+	nanos, id, found := strings.Cut(checkpoint, "-")
+	if !found {
+		return time.Time{}, "", fmt.Errorf("invalid checkpoint: %s", checkpoint)
+	}
+	n, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil || n < 0 {
+		return time.Time{}, "", fmt.Errorf("invalid checkpoint: %s", checkpoint)
+	}
+	return time.Unix(0, n).UTC(), id, nil
+}
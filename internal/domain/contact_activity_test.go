@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContactActivityTypeForKind(t *testing.T) {
+	tests := []struct {
+		kind     string
+		expected ContactActivityType
+		ok       bool
+	}{
+		{"insert_contact", ContactActivityCreated, true},
+		{"update_contact", ContactActivityFieldUpdate, true},
+		{"insert_contact_list", ContactActivityListChange, true},
+		{"update_contact_list", ContactActivityListChange, true},
+		{"join_segment", ContactActivitySegmentChange, true},
+		{"leave_segment", ContactActivitySegmentChange, true},
+		{"insert_message_history", ContactActivityEngagement, true},
+		{"open_email", ContactActivityEngagement, true},
+		{"click_email", ContactActivityEngagement, true},
+		{"update_message_history", "", false},
+		{"delete_contact", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.kind, func(t *testing.T) {
+			activityType, ok := ContactActivityTypeForKind(tt.kind)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.expected, activityType)
+		})
+	}
+}
+
+func TestNewContactActivity(t *testing.T) {
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	listID := "newsletter"
+
+	t.Run("field update", func(t *testing.T) {
+		activity, ok := NewContactActivity(&ContactTimelineEntry{
+			ID: "entry1", Email: "john@example.com", EntityType: "contact", Kind: "update_contact",
+			Changes:   map[string]interface{}{"first_name": map[string]interface{}{"old": "Jon", "new": "John"}},
+			CreatedAt: at, DBCreatedAt: at,
+		})
+		require.True(t, ok)
+		assert.Equal(t, ContactActivityFieldUpdate, activity.Type)
+		assert.Equal(t, "entry1", activity.ID)
+		assert.Equal(t, "john@example.com", activity.Email)
+		assert.Contains(t, activity.Changes, "first_name")
+		assert.Equal(t, at, activity.OccurredAt)
+		assert.Equal(t, NewContactActivityCheckpoint(at, "entry1"), activity.Checkpoint)
+	})
+
+	t.Run("list change", func(t *testing.T) {
+		activity, ok := NewContactActivity(&ContactTimelineEntry{
+			ID: "entry2", Email: "john@example.com", EntityType: "contact_list", Kind: "insert_contact_list",
+			EntityID: &listID, CreatedAt: at, DBCreatedAt: at,
+		})
+		require.True(t, ok)
+		assert.Equal(t, ContactActivityListChange, activity.Type)
+		assert.Equal(t, listID, *activity.EntityID)
+	})
+
+	t.Run("not an activity", func(t *testing.T) {
+		_, ok := NewContactActivity(&ContactTimelineEntry{ID: "entry3", Kind: "update_message_history"})
+		assert.False(t, ok)
+	})
+}
+
+func TestContactActivityCheckpoint(t *testing.T) {
+	at := time.Date(2026, 3, 1, 12, 0, 0, 123456789, time.UTC)
+
+	checkpoint := NewContactActivityCheckpoint(at, "00000000-0000-0000-0000-000000000001")
+	parsedAt, id, err := ParseContactActivityCheckpoint(checkpoint)
+	require.NoError(t, err)
+	assert.True(t, at.Equal(parsedAt))
+	assert.Equal(t, "00000000-0000-0000-0000-000000000001", id)
+
+	// Checkpoints sort in timeline order
+	assert.Less(t, NewContactActivityCheckpoint(at, ""), checkpoint)
+	assert.Less(t, checkpoint, NewContactActivityCheckpoint(at, "00000000-0000-0000-0000-000000000002"))
+	assert.Less(t, checkpoint, NewContactActivityCheckpoint(at.Add(time.Nanosecond), "00000000-0000-0000-0000-000000000000"))
+
+	for _, invalid := range []string{"", "abc", "abc-def", "-1-id"} {
+		_, _, err := ParseContactActivityCheckpoint(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
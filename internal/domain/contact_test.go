@@ -740,6 +740,38 @@ func TestContact_Merge(t *testing.T) {
 	}
 }
 
+func TestContact_FillEmpty(t *testing.T) {
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	contact := &Contact{
+		Email:         "test@example.com",
+		FirstName:     &NullableString{String: "Jane", IsNull: false},
+		CustomNumber1: &NullableFloat64{Float64: 1, IsNull: false},
+		CreatedAt:     created,
+	}
+
+	contact.FillEmpty(&Contact{
+		Email:         "other@example.com",
+		FirstName:     &NullableString{String: "Imported", IsNull: false},
+		LastName:      &NullableString{String: "Doe", IsNull: false},
+		CustomNumber1: &NullableFloat64{Float64: 2, IsNull: false},
+		CustomNumber2: &NullableFloat64{Float64: 3, IsNull: false},
+		CreatedAt:     created.Add(time.Hour),
+		UpdatedAt:     created.Add(time.Hour),
+	})
+
+	// Fields already set are kept
+	assert.Equal(t, "test@example.com", contact.Email)
+	assert.Equal(t, "Jane", contact.FirstName.String)
+	assert.Equal(t, float64(1), contact.CustomNumber1.Float64)
+	assert.Equal(t, created, contact.CreatedAt)
+
+	// Empty fields are filled
+	assert.Equal(t, "Doe", contact.LastName.String)
+	assert.Equal(t, float64(3), contact.CustomNumber2.Float64)
+	assert.Equal(t, created.Add(time.Hour), contact.UpdatedAt)
+	assert.Nil(t, contact.Phone)
+}
+
 func compareCustomFields(t *testing.T, base, expected *Contact) {
 	// Compare CustomString fields
 	for i := 1; i <= 5; i++ {
@@ -1273,7 +1305,7 @@ func TestBatchImportContactsRequest_Validate(t *testing.T) {
 				Contacts:    json.RawMessage(invalidContacts),
 			},
 			wantErr:       false, // Request parsing is lenient - validation happens in service layer
-			expectedEmail: "",    // FromJSON fails for invalid email, creates empty contact
+			expectedEmail: "",    // FromJSON fails for invalid email, the row keeps the parse error
 		},
 		{
 			name: "empty contacts array",
@@ -1283,6 +1315,25 @@ func TestBatchImportContactsRequest_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "fill_empty conflict policy",
+			request: BatchImportContactsRequest{
+				WorkspaceID: "workspace123",
+				Contacts:    json.RawMessage(validContacts),
+				OnConflict:  ImportConflictFillEmpty,
+			},
+			wantErr:       false,
+			expectedEmail: "test@example.com",
+		},
+		{
+			name: "invalid conflict policy",
+			request: BatchImportContactsRequest{
+				WorkspaceID: "workspace123",
+				Contacts:    json.RawMessage(validContacts),
+				OnConflict:  "merge",
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -1297,6 +1348,7 @@ func TestBatchImportContactsRequest_Validate(t *testing.T) {
 				assert.Equal(t, tt.request.WorkspaceID, workspaceID)
 				assert.NotNil(t, contacts)
 				assert.Len(t, contacts, 1)
+				assert.True(t, tt.request.OnConflict.IsValid())
 				if tt.expectedEmail != "" {
 					assert.Equal(t, tt.expectedEmail, contacts[0].Email)
 				}
@@ -2571,6 +2623,24 @@ func TestBatchImportContactsRequest_Validate_ErrorIndex(t *testing.T) {
 	assert.Len(t, contacts, 2, "Should parse both contacts even if one has invalid data")
 }
 
+func TestBatchImportContactsRequest_Validate_KeepsParseError(t *testing.T) {
+	req := BatchImportContactsRequest{
+		WorkspaceID: "ws_123",
+		Contacts:    json.RawMessage(`[{"email":"valid@example.com"},{"email":"typed@example.com","custom_number_1":"not-a-number"}]`),
+	}
+	contacts, _, err := req.Validate()
+	require.NoError(t, err)
+	require.Len(t, contacts, 2)
+
+	assert.NoError(t, contacts[0].Validate())
+
+	// The unparseable row keeps its email and fails validation with the parse error
+	assert.Equal(t, "typed@example.com", contacts[1].Email)
+	err = contacts[1].Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "custom_number_1")
+}
+
 func TestComputeEmailHMAC_DeterministicAndKeySensitive(t *testing.T) {
 	email := "test@example.com"
 	key1 := "k1"
@@ -2585,3 +2655,11 @@ func TestComputeEmailHMAC_DeterministicAndKeySensitive(t *testing.T) {
 	h3 := ComputeEmailHMAC(email, key2)
 	assert.NotEqual(t, h1, h3)
 }
+
+func TestMergeStrategy_Validate(t *testing.T) {
+	assert.NoError(t, MergeStrategyOverwriteAll.Validate())
+	assert.NoError(t, MergeStrategyFillEmptyOnly.Validate())
+	assert.NoError(t, MergeStrategyIgnoreExisting.Validate())
+	assert.EqualError(t, MergeStrategy("").Validate(), "invalid merge strategy: ")
+	assert.EqualError(t, MergeStrategy("replace").Validate(), "invalid merge strategy: replace")
+}
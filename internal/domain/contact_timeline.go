@@ -87,6 +87,9 @@ type ContactTimelineRepository interface {
 	List(ctx context.Context, workspaceID string, email string, limit int, cursor *string) ([]*ContactTimelineEntry, *string, error)
 	// DeleteForEmail deletes all timeline entries for a contact
 	DeleteForEmail(ctx context.Context, workspaceID string, email string) error
+	// ListSince retrieves entries of all contacts after a contact activity checkpoint and inserted
+	// no later than until, in insertion order
+	ListSince(ctx context.Context, workspaceID string, checkpoint string, until time.Time, limit int) ([]*ContactTimelineEntry, error)
 }
 
 // ContactTimelineService defines business logic for contact timeline
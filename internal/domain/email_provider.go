@@ -2,8 +2,10 @@ package domain
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"regexp"
 
 	"github.com/Notifuse/notifuse/pkg/notifuse_mjml"
 	"github.com/asaskevich/govalidator"
@@ -30,6 +32,7 @@ const (
 	EmailProviderKindPostmark  EmailProviderKind = "postmark"
 	EmailProviderKindMailgun   EmailProviderKind = "mailgun"
 	EmailProviderKindMailjet   EmailProviderKind = "mailjet"
+	EmailProviderKindSendGrid  EmailProviderKind = "sendgrid"
 )
 
 // EmailSender represents an email sender with name and email address
@@ -59,6 +62,7 @@ type EmailProvider struct {
 	Postmark           *PostmarkSettings  `json:"postmark,omitempty"`
 	Mailgun            *MailgunSettings   `json:"mailgun,omitempty"`
 	Mailjet            *MailjetSettings   `json:"mailjet,omitempty"`
+	SendGrid           *SendGridSettings  `json:"sendgrid,omitempty"`
 	Senders            []EmailSender      `json:"senders"`
 	RateLimitPerMinute int                `json:"rate_limit_per_minute"`
 }
@@ -129,6 +133,11 @@ func (e *EmailProvider) Validate(passphrase string) error {
 			return fmt.Errorf("mailjet settings required when email provider kind is mailjet")
 		}
 		return e.Mailjet.Validate(passphrase)
+	case EmailProviderKindSendGrid:
+		if e.SendGrid == nil {
+			return fmt.Errorf("sendgrid settings required when email provider kind is sendgrid")
+		}
+		return e.SendGrid.Validate(passphrase)
 	default:
 		return fmt.Errorf("invalid email provider kind: %s", e.Kind)
 	}
@@ -176,18 +185,36 @@ func (e *EmailProvider) EncryptSecretKeys(passphrase string) error {
 		e.SparkPost.APIKey = ""
 	}
 
-	if e.Kind == EmailProviderKindPostmark && e.Postmark != nil && e.Postmark.ServerToken != "" {
-		if err := e.Postmark.EncryptServerToken(passphrase); err != nil {
-			return err
+	if e.Kind == EmailProviderKindPostmark && e.Postmark != nil {
+		if e.Postmark.ServerToken != "" {
+			if err := e.Postmark.EncryptServerToken(passphrase); err != nil {
+				return err
+			}
+			e.Postmark.ServerToken = ""
+		}
+
+		if e.Postmark.WebhookPassword != "" {
+			if err := e.Postmark.EncryptWebhookPassword(passphrase); err != nil {
+				return err
+			}
+			e.Postmark.WebhookPassword = ""
 		}
-		e.Postmark.ServerToken = ""
 	}
 
-	if e.Kind == EmailProviderKindMailgun && e.Mailgun != nil && e.Mailgun.APIKey != "" {
-		if err := e.Mailgun.EncryptAPIKey(passphrase); err != nil {
-			return err
+	if e.Kind == EmailProviderKindMailgun && e.Mailgun != nil {
+		if e.Mailgun.APIKey != "" {
+			if err := e.Mailgun.EncryptAPIKey(passphrase); err != nil {
+				return err
+			}
+			e.Mailgun.APIKey = ""
+		}
+
+		if e.Mailgun.WebhookSigningKey != "" {
+			if err := e.Mailgun.EncryptWebhookSigningKey(passphrase); err != nil {
+				return err
+			}
+			e.Mailgun.WebhookSigningKey = ""
 		}
-		e.Mailgun.APIKey = ""
 	}
 
 	if e.Kind == EmailProviderKindMailjet && e.Mailjet != nil {
@@ -206,6 +233,13 @@ func (e *EmailProvider) EncryptSecretKeys(passphrase string) error {
 		}
 	}
 
+	if e.Kind == EmailProviderKindSendGrid && e.SendGrid != nil && e.SendGrid.APIKey != "" {
+		if err := e.SendGrid.EncryptAPIKey(passphrase); err != nil {
+			return err
+		}
+		e.SendGrid.APIKey = ""
+	}
+
 	return nil
 }
 
@@ -236,15 +270,31 @@ func (e *EmailProvider) DecryptSecretKeys(passphrase string) error {
 		}
 	}
 
-	if e.Kind == EmailProviderKindPostmark && e.Postmark != nil && e.Postmark.EncryptedServerToken != "" {
-		if err := e.Postmark.DecryptServerToken(passphrase); err != nil {
-			return err
+	if e.Kind == EmailProviderKindPostmark && e.Postmark != nil {
+		if e.Postmark.EncryptedServerToken != "" {
+			if err := e.Postmark.DecryptServerToken(passphrase); err != nil {
+				return err
+			}
+		}
+
+		if e.Postmark.EncryptedWebhookPassword != "" {
+			if err := e.Postmark.DecryptWebhookPassword(passphrase); err != nil {
+				return err
+			}
 		}
 	}
 
-	if e.Kind == EmailProviderKindMailgun && e.Mailgun != nil && e.Mailgun.EncryptedAPIKey != "" {
-		if err := e.Mailgun.DecryptAPIKey(passphrase); err != nil {
-			return err
+	if e.Kind == EmailProviderKindMailgun && e.Mailgun != nil {
+		if e.Mailgun.EncryptedAPIKey != "" {
+			if err := e.Mailgun.DecryptAPIKey(passphrase); err != nil {
+				return err
+			}
+		}
+
+		if e.Mailgun.EncryptedWebhookSigningKey != "" {
+			if err := e.Mailgun.DecryptWebhookSigningKey(passphrase); err != nil {
+				return err
+			}
 		}
 	}
 
@@ -262,6 +312,12 @@ func (e *EmailProvider) DecryptSecretKeys(passphrase string) error {
 		}
 	}
 
+	if e.Kind == EmailProviderKindSendGrid && e.SendGrid != nil && e.SendGrid.EncryptedAPIKey != "" {
+		if err := e.SendGrid.DecryptAPIKey(passphrase); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -272,6 +328,9 @@ type EmailOptions struct {
 	ReplyTo            string       `json:"reply_to,omitempty"`
 	Attachments        []Attachment `json:"attachments,omitempty"`
 	ListUnsubscribeURL string       `json:"list_unsubscribe_url,omitempty"` // RFC-8058 one-click unsubscribe URL
+	// ProviderTags are passed through to the provider API in its native format
+	// (SES message tags, Postmark metadata, Mailgun variables, SparkPost metadata, Mailjet event payload, SendGrid custom args)
+	ProviderTags map[string]string `json:"provider_tags,omitempty"`
 }
 
 // IsEmpty returns true if no email options are set
@@ -309,6 +368,8 @@ type SendEmailProviderRequest struct {
 	Content       string         `validate:"required"`
 	Provider      *EmailProvider `validate:"required"`
 	EmailOptions  EmailOptions
+	// PlainTextOnly sends Content as the only text/plain part of the email, without an HTML part
+	PlainTextOnly bool
 }
 
 // Validate ensures all required fields are present and valid
@@ -340,17 +401,106 @@ func (r *SendEmailProviderRequest) Validate() error {
 	if r.Provider == nil {
 		return fmt.Errorf("email provider is required")
 	}
+	if err := ValidateProviderTags(r.Provider.Kind, r.EmailOptions.ProviderTags); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ProviderTagMessageIDKey is the tag every provider already receives with the Notifuse message ID
+const ProviderTagMessageIDKey = "notifuse_message_id"
+
+// MaxProviderTags is the number of provider tags a template can define, whatever the provider
+const MaxProviderTags = 50
+
+var sesTagRegex = regexp.MustCompile(`^[A-Za-z0-9_\-]+$`)
+
+// ValidateProviderTags checks provider tags against the limits of the provider API they are
+// sent to. The limits account for the message ID tag that is always added by Notifuse.
+func ValidateProviderTags(kind EmailProviderKind, tags map[string]string) error {
+	if len(tags) == 0 {
+		return nil
+	}
+	if len(tags) > MaxProviderTags {
+		return fmt.Errorf("provider tags: at most %d tags are allowed", MaxProviderTags)
+	}
+	for key := range tags {
+		if key == "" {
+			return fmt.Errorf("provider tags: tag name is required")
+		}
+		if key == ProviderTagMessageIDKey {
+			return fmt.Errorf("provider tags: %q is reserved", ProviderTagMessageIDKey)
+		}
+	}
+
+	switch kind {
+	case EmailProviderKindSES:
+		// SES allows 50 message tags per email
+		if len(tags) > 49 {
+			return fmt.Errorf("provider tags: SES allows at most 49 custom tags")
+		}
+		for key, value := range tags {
+			if len(key) > 256 || !sesTagRegex.MatchString(key) {
+				return fmt.Errorf("provider tags: SES tag name %q must be 1-256 characters of letters, digits, '_' or '-'", key)
+			}
+			if len(value) > 256 || !sesTagRegex.MatchString(value) {
+				return fmt.Errorf("provider tags: SES tag %q value must be 1-256 characters of letters, digits, '_' or '-'", key)
+			}
+		}
+	case EmailProviderKindPostmark:
+		// Postmark allows 10 metadata fields per message
+		if len(tags) > 9 {
+			return fmt.Errorf("provider tags: Postmark allows at most 9 custom metadata fields")
+		}
+		for key, value := range tags {
+			if len(key) > 20 {
+				return fmt.Errorf("provider tags: Postmark metadata key %q exceeds 20 characters", key)
+			}
+			if len(value) > 80 {
+				return fmt.Errorf("provider tags: Postmark metadata %q value exceeds 80 characters", key)
+			}
+		}
+	case EmailProviderKindMailgun:
+		// Mailgun limits custom variables to 4KB per message
+		size := 0
+		for key, value := range tags {
+			size += len(key) + len(value)
+		}
+		if size > 4096 {
+			return fmt.Errorf("provider tags: Mailgun custom variables exceed 4KB")
+		}
+	case EmailProviderKindSparkPost, EmailProviderKindMailjet:
+		// SparkPost metadata and the Mailjet event payload are limited to 1000 bytes of JSON
+		encoded, err := json.Marshal(tags)
+		if err != nil {
+			return fmt.Errorf("provider tags: %w", err)
+		}
+		if len(encoded) > 1000 {
+			return fmt.Errorf("provider tags: %s tags exceed 1000 bytes", kind)
+		}
+	case EmailProviderKindSendGrid:
+		// SendGrid custom args are limited to 10000 bytes per message
+		encoded, err := json.Marshal(tags)
+		if err != nil {
+			return fmt.Errorf("provider tags: %w", err)
+		}
+		if len(encoded) > 10000 {
+			return fmt.Errorf("provider tags: SendGrid custom args exceed 10000 bytes")
+		}
+	}
+
 	return nil
 }
 
 // SendEmailRequest encapsulates all parameters needed to send an email using a template
 type SendEmailRequest struct {
 	// Core identification
-	WorkspaceID   string `validate:"required"`
-	IntegrationID string `validate:"required"`
-	MessageID     string `validate:"required"`
-	ExternalID    *string
-	AutomationID  *string // Automation this email is sent from (nullable for broadcasts/transactional)
+	WorkspaceID    string `validate:"required"`
+	IntegrationID  string `validate:"required"`
+	MessageID      string `validate:"required"`
+	ExternalID     *string
+	IdempotencyKey *string // Claimed when recording the message, a key already held aborts the send
+	AutomationID   *string // Automation this email is sent from (nullable for broadcasts/transactional)
 
 	// Target and content
 	Contact        *Contact        `validate:"required"`
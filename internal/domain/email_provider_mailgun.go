@@ -97,9 +97,13 @@ type MailgunSettings struct {
 	EncryptedAPIKey string `json:"encrypted_api_key,omitempty"`
 	Domain          string `json:"domain"`
 	Region          string `json:"region,omitempty"` // "US" or "EU"
+	// EncryptedWebhookSigningKey verifies the signature of incoming webhook events when set
+	EncryptedWebhookSigningKey string `json:"encrypted_webhook_signing_key,omitempty"`
 
 	// decoded API key, not stored in the database
 	APIKey string `json:"api_key,omitempty"`
+	// decoded webhook signing key, not stored in the database
+	WebhookSigningKey string `json:"webhook_signing_key,omitempty"`
 }
 
 func (m *MailgunSettings) DecryptAPIKey(passphrase string) error {
@@ -120,6 +124,24 @@ func (m *MailgunSettings) EncryptAPIKey(passphrase string) error {
 	return nil
 }
 
+func (m *MailgunSettings) DecryptWebhookSigningKey(passphrase string) error {
+	signingKey, err := crypto.DecryptFromHexString(m.EncryptedWebhookSigningKey, passphrase)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt Mailgun webhook signing key: %w", err)
+	}
+	m.WebhookSigningKey = signingKey
+	return nil
+}
+
+func (m *MailgunSettings) EncryptWebhookSigningKey(passphrase string) error {
+	encryptedSigningKey, err := crypto.EncryptString(m.WebhookSigningKey, passphrase)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt Mailgun webhook signing key: %w", err)
+	}
+	m.EncryptedWebhookSigningKey = encryptedSigningKey
+	return nil
+}
+
 func (m *MailgunSettings) Validate(passphrase string) error {
 	if m.Domain == "" {
 		return fmt.Errorf("domain is required for Mailgun configuration")
@@ -133,6 +155,13 @@ func (m *MailgunSettings) Validate(passphrase string) error {
 		m.APIKey = "" // Clear the API key after encryption
 	}
 
+	if m.WebhookSigningKey != "" {
+		if err := m.EncryptWebhookSigningKey(passphrase); err != nil {
+			return err
+		}
+		m.WebhookSigningKey = ""
+	}
+
 	return nil
 }
 
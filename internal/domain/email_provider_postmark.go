@@ -150,6 +150,13 @@ type PostmarkListWebhooksResponse struct {
 type PostmarkSettings struct {
 	EncryptedServerToken string `json:"encrypted_server_token,omitempty"`
 	ServerToken          string `json:"server_token,omitempty"`
+	// WebhookUsername and the webhook password are the basic auth credentials Postmark sends
+	// with webhook events, incoming events are only accepted with them when they are set
+	WebhookUsername          string `json:"webhook_username,omitempty"`
+	EncryptedWebhookPassword string `json:"encrypted_webhook_password,omitempty"`
+
+	// decoded webhook password, not stored in the database
+	WebhookPassword string `json:"webhook_password,omitempty"`
 }
 
 func (p *PostmarkSettings) DecryptServerToken(passphrase string) error {
@@ -170,6 +177,32 @@ func (p *PostmarkSettings) EncryptServerToken(passphrase string) error {
 	return nil
 }
 
+func (p *PostmarkSettings) DecryptWebhookPassword(passphrase string) error {
+	password, err := crypto.DecryptFromHexString(p.EncryptedWebhookPassword, passphrase)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt Postmark webhook password: %w", err)
+	}
+	p.WebhookPassword = password
+	return nil
+}
+
+func (p *PostmarkSettings) EncryptWebhookPassword(passphrase string) error {
+	encryptedPassword, err := crypto.EncryptString(p.WebhookPassword, passphrase)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt Postmark webhook password: %w", err)
+	}
+	p.EncryptedWebhookPassword = encryptedPassword
+	return nil
+}
+
+// WebhookHttpAuth returns the basic auth credentials Postmark must send with webhook events, nil when none are set
+func (p *PostmarkSettings) WebhookHttpAuth() *HttpAuth {
+	if p.WebhookUsername == "" || p.WebhookPassword == "" {
+		return nil
+	}
+	return &HttpAuth{Username: p.WebhookUsername, Password: p.WebhookPassword}
+}
+
 func (p *PostmarkSettings) Validate(passphrase string) error {
 	// Encrypt server token if it's not empty
 	if p.ServerToken != "" {
@@ -178,6 +211,15 @@ func (p *PostmarkSettings) Validate(passphrase string) error {
 		}
 	}
 
+	if p.WebhookPassword != "" {
+		if p.WebhookUsername == "" {
+			return fmt.Errorf("webhook username is required with a Postmark webhook password")
+		}
+		if err := p.EncryptWebhookPassword(passphrase); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"fmt"
+
+	"github.com/Notifuse/notifuse/pkg/crypto"
+)
+
+// SendGridWebhookEvent represents a single event of the SendGrid Event Webhook
+// The webhook posts a JSON array of events, see https://www.twilio.com/docs/sendgrid/for-developers/tracking-events/event
+type SendGridWebhookEvent struct {
+	Email       string `json:"email"`
+	Timestamp   int64  `json:"timestamp"`
+	Event       string `json:"event"`
+	SGEventID   string `json:"sg_event_id"`
+	SGMessageID string `json:"sg_message_id"`
+
+	// Custom arguments set when sending, contains notifuse_message_id
+	NotifuseMessageID string `json:"notifuse_message_id,omitempty"`
+
+	// Bounce, dropped and deferred specific fields
+	Type     string `json:"type,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+	Status   string `json:"status,omitempty"`
+	Response string `json:"response,omitempty"`
+}
+
+// SendGridSettings contains configuration for SendGrid
+type SendGridSettings struct {
+	EncryptedAPIKey string `json:"encrypted_api_key,omitempty"`
+	IPPoolName      string `json:"ip_pool_name,omitempty"`
+	SandboxMode     bool   `json:"sandbox_mode"`
+
+	// decoded API key, not stored in the database
+	APIKey string `json:"api_key,omitempty"`
+}
+
+func (s *SendGridSettings) DecryptAPIKey(passphrase string) error {
+	apiKey, err := crypto.DecryptFromHexString(s.EncryptedAPIKey, passphrase)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt SendGrid API key: %w", err)
+	}
+	s.APIKey = apiKey
+	return nil
+}
+
+func (s *SendGridSettings) EncryptAPIKey(passphrase string) error {
+	encryptedAPIKey, err := crypto.EncryptString(s.APIKey, passphrase)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt SendGrid API key: %w", err)
+	}
+	s.EncryptedAPIKey = encryptedAPIKey
+	return nil
+}
+
+func (s *SendGridSettings) Validate(passphrase string) error {
+	// SendGrid IP pool names are limited to 64 characters
+	if len(s.IPPoolName) > 64 {
+		return fmt.Errorf("SendGrid IP pool name must be at most 64 characters")
+	}
+
+	// Encrypt API key if it's not empty
+	if s.APIKey != "" {
+		if err := s.EncryptAPIKey(passphrase); err != nil {
+			return fmt.Errorf("failed to encrypt SendGrid API key: %w", err)
+		}
+	}
+
+	return nil
+}
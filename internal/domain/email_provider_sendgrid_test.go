@@ -0,0 +1,80 @@
+package domain_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/pkg/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSendGridSettings_EncryptDecryptAPIKey(t *testing.T) {
+	passphrase := "test-passphrase"
+	apiKey := "SG.test-api-key"
+
+	settings := domain.SendGridSettings{APIKey: apiKey}
+
+	err := settings.EncryptAPIKey(passphrase)
+	require.NoError(t, err)
+	assert.NotEmpty(t, settings.EncryptedAPIKey)
+
+	decrypted, err := crypto.DecryptFromHexString(settings.EncryptedAPIKey, passphrase)
+	require.NoError(t, err)
+	assert.Equal(t, apiKey, decrypted)
+
+	settings.APIKey = ""
+	require.NoError(t, settings.DecryptAPIKey(passphrase))
+	assert.Equal(t, apiKey, settings.APIKey)
+
+	// Test with invalid passphrase
+	err = settings.DecryptAPIKey("wrong-passphrase")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to decrypt SendGrid API key")
+}
+
+func TestSendGridSettings_Validate(t *testing.T) {
+	passphrase := "test-passphrase"
+
+	t.Run("encrypts the API key", func(t *testing.T) {
+		settings := domain.SendGridSettings{APIKey: "SG.test-api-key", IPPoolName: "marketing"}
+		require.NoError(t, settings.Validate(passphrase))
+		assert.NotEmpty(t, settings.EncryptedAPIKey)
+	})
+
+	t.Run("rejects long IP pool names", func(t *testing.T) {
+		settings := domain.SendGridSettings{IPPoolName: strings.Repeat("a", 65)}
+		err := settings.Validate(passphrase)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "IP pool name")
+	})
+}
+
+func TestEmailProvider_SendGridSecretKeys(t *testing.T) {
+	passphrase := "test-passphrase"
+
+	provider := domain.EmailProvider{
+		Kind:               domain.EmailProviderKindSendGrid,
+		SendGrid:           &domain.SendGridSettings{APIKey: "SG.test-api-key"},
+		Senders:            []domain.EmailSender{domain.NewEmailSender("sender@example.com", "Sender")},
+		RateLimitPerMinute: 600,
+	}
+	require.NoError(t, provider.Validate(passphrase))
+
+	require.NoError(t, provider.EncryptSecretKeys(passphrase))
+	assert.Empty(t, provider.SendGrid.APIKey)
+	assert.NotEmpty(t, provider.SendGrid.EncryptedAPIKey)
+
+	require.NoError(t, provider.DecryptSecretKeys(passphrase))
+	assert.Equal(t, "SG.test-api-key", provider.SendGrid.APIKey)
+
+	// Settings are required for the sendgrid kind
+	err := (&domain.EmailProvider{
+		Kind:               domain.EmailProviderKindSendGrid,
+		Senders:            provider.Senders,
+		RateLimitPerMinute: 600,
+	}).Validate(passphrase)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "sendgrid settings required")
+}
@@ -41,6 +41,7 @@ type SESWebhookPayload struct {
 	MessageID         string                         `json:"MessageId"`
 	TopicARN          string                         `json:"TopicArn"`
 	Message           string                         `json:"Message"`
+	Subject           string                         `json:"Subject,omitempty"`
 	Timestamp         string                         `json:"Timestamp"`
 	SignatureVersion  string                         `json:"SignatureVersion"`
 	Signature         string                         `json:"Signature"`
@@ -167,6 +168,8 @@ type AmazonSESSettings struct {
 	Region             string `json:"region"`
 	AccessKey          string `json:"access_key"`
 	EncryptedSecretKey string `json:"encrypted_secret_key,omitempty"`
+	// VerifyWebhookSignature rejects incoming SNS messages whose signature does not match the AWS signing certificate
+	VerifyWebhookSignature bool `json:"verify_webhook_signature,omitempty"`
 
 	// decoded secret key, not stored in the database
 	SecretKey string `json:"secret_key,omitempty"`
@@ -31,6 +31,9 @@ type SMTPSettings struct {
 	EncryptedPassword string `json:"encrypted_password,omitempty"`
 	UseTLS            bool   `json:"use_tls"`
 
+	// MaxConnections is how many connections are kept open and reused to send emails, 0 opens one per email
+	MaxConnections int `json:"max_connections,omitempty"`
+
 	// decoded username, not stored in the database
 	// decoded password , not stored in the database
 	Username string `json:"username"`
@@ -82,6 +85,10 @@ func (s *SMTPSettings) Validate(passphrase string) error {
 		return fmt.Errorf("invalid port number for SMTP configuration: %d", s.Port)
 	}
 
+	if s.MaxConnections < 0 || s.MaxConnections > 100 {
+		return fmt.Errorf("max connections for SMTP configuration must be between 0 and 100: %d", s.MaxConnections)
+	}
+
 	// Username is optional - only encrypt if provided
 	if s.Username != "" {
 		if err := s.EncryptUsername(passphrase); err != nil {
@@ -235,6 +235,35 @@ func TestSMTPSettings_Validate(t *testing.T) {
 			},
 			wantErr: false, // Empty password is allowed
 		},
+		{
+			name: "pooled connections",
+			settings: domain.SMTPSettings{
+				Host:           "smtp.example.com",
+				Port:           587,
+				MaxConnections: 10,
+			},
+			wantErr: false,
+		},
+		{
+			name: "negative max connections",
+			settings: domain.SMTPSettings{
+				Host:           "smtp.example.com",
+				Port:           587,
+				MaxConnections: -1,
+			},
+			wantErr: true,
+			errMsg:  "max connections for SMTP configuration must be between 0 and 100",
+		},
+		{
+			name: "too many max connections",
+			settings: domain.SMTPSettings{
+				Host:           "smtp.example.com",
+				Port:           587,
+				MaxConnections: 101,
+			},
+			wantErr: true,
+			errMsg:  "max connections for SMTP configuration must be between 0 and 100",
+		},
 	}
 
 	for _, tt := range tests {
@@ -2,6 +2,8 @@ package domain
 
 import (
 	"encoding/json"
+	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -1525,3 +1527,48 @@ func TestEmailOptions_ToChannelOptions(t *testing.T) {
 		assert.Empty(t, channelOptions.ReplyTo)
 	})
 }
+
+func TestValidateProviderTags(t *testing.T) {
+	tagSet := func(count int) map[string]string {
+		tags := make(map[string]string, count)
+		for i := 0; i < count; i++ {
+			tags[fmt.Sprintf("tag_%d", i)] = "value"
+		}
+		return tags
+	}
+
+	testCases := []struct {
+		name          string
+		kind          EmailProviderKind
+		tags          map[string]string
+		expectedError string
+	}{
+		{name: "no tags", kind: EmailProviderKindSES},
+		{name: "valid SES tags", kind: EmailProviderKindSES, tags: map[string]string{"campaign": "spring-sale"}},
+		{name: "SES tag count", kind: EmailProviderKindSES, tags: tagSet(50), expectedError: "at most 49 custom tags"},
+		{name: "SES tag characters", kind: EmailProviderKindSES, tags: map[string]string{"campaign": "spring sale"}, expectedError: "SES tag"},
+		{name: "SES tag length", kind: EmailProviderKindSES, tags: map[string]string{"campaign": strings.Repeat("a", 257)}, expectedError: "SES tag"},
+		{name: "Postmark field count", kind: EmailProviderKindPostmark, tags: tagSet(10), expectedError: "at most 9 custom metadata fields"},
+		{name: "Postmark key length", kind: EmailProviderKindPostmark, tags: map[string]string{strings.Repeat("k", 21): "value"}, expectedError: "exceeds 20 characters"},
+		{name: "Postmark value length", kind: EmailProviderKindPostmark, tags: map[string]string{"campaign": strings.Repeat("a", 81)}, expectedError: "exceeds 80 characters"},
+		{name: "Mailgun size", kind: EmailProviderKindMailgun, tags: map[string]string{"campaign": strings.Repeat("a", 4096)}, expectedError: "exceed 4KB"},
+		{name: "SparkPost size", kind: EmailProviderKindSparkPost, tags: map[string]string{"campaign": strings.Repeat("a", 1000)}, expectedError: "exceed 1000 bytes"},
+		{name: "Mailjet size", kind: EmailProviderKindMailjet, tags: map[string]string{"campaign": strings.Repeat("a", 1000)}, expectedError: "exceed 1000 bytes"},
+		{name: "SMTP ignores tags", kind: EmailProviderKindSMTP, tags: map[string]string{"campaign": "spring sale"}},
+		{name: "global tag count", kind: EmailProviderKindSMTP, tags: tagSet(MaxProviderTags + 1), expectedError: "at most 50 tags"},
+		{name: "reserved key", kind: EmailProviderKindMailgun, tags: map[string]string{ProviderTagMessageIDKey: "value"}, expectedError: "is reserved"},
+		{name: "empty key", kind: EmailProviderKindMailgun, tags: map[string]string{"": "value"}, expectedError: "tag name is required"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := ValidateProviderTags(tc.kind, tc.tags)
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.expectedError)
+		})
+	}
+}
@@ -68,6 +68,8 @@ type EmailQueuePayload struct {
 	FromName    string `json:"from_name"`
 	Subject     string `json:"subject"`
 	HTMLContent string `json:"html_content"`
+	// TextContent replaces the HTML content with a single text/plain part when set
+	TextContent string `json:"text_content,omitempty"`
 
 	// Options
 	EmailOptions EmailOptions `json:"email_options"`
@@ -82,12 +84,19 @@ type EmailQueuePayload struct {
 	TemplateVersion int                    `json:"template_version"`         // Needed for message_history
 	ListID          string                 `json:"list_id,omitempty"`        // For broadcasts
 	TemplateData    map[string]interface{} `json:"template_data,omitempty"`  // For message history logging
+
+	// Quiet hours handling (broadcasts only)
+	IgnoreQuietHours  bool   `json:"ignore_quiet_hours,omitempty"`
+	RecipientTimezone string `json:"recipient_timezone,omitempty"`
+
+	// Broadcast send cutoff: the email is skipped instead of sent after this time
+	SendCutoffAt *time.Time `json:"send_cutoff_at,omitempty"`
 }
 
 // ToSendEmailProviderRequest converts the payload to a SendEmailProviderRequest
 // The provider must be passed in separately as it's not stored in the payload
 func (p *EmailQueuePayload) ToSendEmailProviderRequest(workspaceID, integrationID, messageID, toEmail string, provider *EmailProvider) *SendEmailProviderRequest {
-	return &SendEmailProviderRequest{
+	request := &SendEmailProviderRequest{
 		WorkspaceID:   workspaceID,
 		IntegrationID: integrationID,
 		MessageID:     messageID,
@@ -99,6 +108,11 @@ func (p *EmailQueuePayload) ToSendEmailProviderRequest(workspaceID, integrationI
 		Provider:      provider,
 		EmailOptions:  p.EmailOptions,
 	}
+	if p.TextContent != "" {
+		request.Content = p.TextContent
+		request.PlainTextOnly = true
+	}
+	return request
 }
 
 // EmailQueueStats provides queue statistics for a workspace
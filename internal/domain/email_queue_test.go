@@ -260,6 +260,21 @@ func TestEmailQueuePayload_ToSendEmailProviderRequest(t *testing.T) {
 		assert.Equal(t, "https://example.com/unsubscribe", result.EmailOptions.ListUnsubscribeURL)
 	})
 
+	t.Run("text content replaces the HTML content", func(t *testing.T) {
+		payload := EmailQueuePayload{
+			FromAddress: "sender@example.com",
+			FromName:    "Test Sender",
+			Subject:     "Test Subject",
+			HTMLContent: "<html><body>Test</body></html>",
+			TextContent: "Test",
+		}
+
+		result := payload.ToSendEmailProviderRequest("workspace123", "integration456", "message789", "recipient@example.com", nil)
+
+		assert.Equal(t, "Test", result.Content)
+		assert.True(t, result.PlainTextOnly)
+	})
+
 	t.Run("handles nil provider", func(t *testing.T) {
 		payload := EmailQueuePayload{
 			FromAddress: "sender@example.com",
@@ -21,6 +21,11 @@ const (
 	EventBroadcastFailed         EventType = "broadcast.failed"
 	EventBroadcastCancelled      EventType = "broadcast.cancelled"
 	EventBroadcastCircuitBreaker EventType = "broadcast.circuit_breaker"
+	EventBroadcastPhaseChanged   EventType = "broadcast.phase_changed"
+	EventBroadcastProgress       EventType = "broadcast.progress"
+	EventContactActivity         EventType = "contact.activity"
+	EventIntegrationWebhookStale EventType = "integration.webhook_stale"
+	EventWorkspaceSendingBlocked EventType = "workspace.sending_blocked"
 )
 
 // EventPayload represents the data associated with an event
@@ -3,11 +3,15 @@ package domain
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/asaskevich/govalidator"
+	"github.com/google/uuid"
 )
 
 //go:generate mockgen -destination mocks/mock_inbound_webhook_event_repository.go -package mocks github.com/Notifuse/notifuse/internal/domain InboundWebhookEventRepository
@@ -52,6 +56,9 @@ const (
 	// WebhookSourceMailjet indicates webhook from Mailjet
 	WebhookSourceMailjet WebhookSource = "mailjet"
 
+	// WebhookSourceSendGrid indicates webhook from SendGrid
+	WebhookSourceSendGrid WebhookSource = "sendgrid"
+
 	// WebhookSourceSMTP indicates webhook from SMTP
 	WebhookSourceSMTP WebhookSource = "smtp"
 
@@ -114,6 +121,9 @@ func (e *ErrInboundWebhookEventNotFound) Error() string {
 	return fmt.Sprintf("inbound webhook event with ID %s not found", e.ID)
 }
 
+// ErrIngestionQueueFull is returned when webhook ingestion is saturated and the provider should retry later
+var ErrIngestionQueueFull = errors.New("inbound webhook ingestion queue is full")
+
 // GetEventByIDRequest defines the parameters for retrieving a webhook event by ID
 type GetEventByIDRequest struct {
 	ID string `json:"id"`
@@ -231,6 +241,16 @@ type InboundWebhookEventServiceInterface interface {
 
 	// ListEvents retrieves all inbound webhook events for a workspace
 	ListEvents(ctx context.Context, workspaceID string, params InboundWebhookEventListParams) (*InboundWebhookEventListResult, error)
+
+	// ReprocessPayloads runs stored raw webhook payloads of a time range through the current ingest logic
+	ReprocessPayloads(ctx context.Context, request ReprocessInboundWebhooksRequest) (*ReprocessInboundWebhooksResult, error)
+
+	// GetWebhookHealth retrieves the last send and webhook event times of the integrations of a workspace
+	GetWebhookHealth(ctx context.Context, workspaceID string) ([]*ProviderWebhookHealth, error)
+
+	// VerifyWebhook verifies the signature of a webhook event with the secret of its integration,
+	// returning ErrInvalidWebhookSignature when it is unsigned or does not match
+	VerifyWebhook(ctx context.Context, workspaceID, integrationID string, header http.Header, rawPayload []byte) error
 }
 
 // InboundWebhookEventRepository is the interface for inbound webhook event operations
@@ -243,4 +263,83 @@ type InboundWebhookEventRepository interface {
 
 	// DeleteForEmail deletes all inbound webhook events for a specific email
 	DeleteForEmail(ctx context.Context, workspaceID, email string) error
+
+	// StorePayload stores a raw inbound webhook payload for later reprocessing
+	StorePayload(ctx context.Context, workspaceID string, payload *InboundWebhookPayload) error
+
+	// MarkPayloadProcessed records the outcome of the last ingestion of a raw payload
+	MarkPayloadProcessed(ctx context.Context, workspaceID, id string, processedAt time.Time, processingError *string) error
+
+	// ListPayloads retrieves raw payloads received within a time range, oldest first
+	ListPayloads(ctx context.Context, workspaceID string, params InboundWebhookPayloadListParams) ([]*InboundWebhookPayload, error)
+
+	// DeletePayloadsBefore deletes raw payloads received before the given time
+	DeletePayloadsBefore(ctx context.Context, workspaceID string, before time.Time) (int64, error)
+}
+
+// InboundWebhookPayload is a raw webhook body as received from an email provider.
+// Payloads are kept for a limited retention period so they can be reprocessed.
+type InboundWebhookPayload struct {
+	ID            string     `json:"id"`
+	IntegrationID string     `json:"integration_id"`
+	Payload       string     `json:"payload"`
+	ReceivedAt    time.Time  `json:"received_at"`
+	ProcessedAt   *time.Time `json:"processed_at,omitempty"`
+	Error         *string    `json:"error,omitempty"`
+}
+
+// InboundWebhookEventID derives the ID of the index-th event extracted from a raw payload.
+// IDs are stable so that ingesting the same payload again never duplicates events.
+func InboundWebhookEventID(payloadID string, index int) string {
+	namespace, err := uuid.Parse(payloadID)
+	if err != nil {
+		namespace = uuid.NewSHA1(uuid.NameSpaceOID, []byte(payloadID))
+	}
+	return uuid.NewSHA1(namespace, []byte(strconv.Itoa(index))).String()
+}
+
+// InboundWebhookPayloadListParams selects raw payloads to reprocess.
+// Results are ordered by (received_at, id) and paginated with the After* cursor.
+type InboundWebhookPayloadListParams struct {
+	IntegrationID   string
+	ReceivedAfter   time.Time
+	ReceivedBefore  time.Time
+	AfterReceivedAt *time.Time
+	AfterID         string
+	Limit           int
+}
+
+// ReprocessInboundWebhooksRequest defines the time range of raw payloads to reprocess
+type ReprocessInboundWebhooksRequest struct {
+	WorkspaceID   string    `json:"workspace_id"`
+	IntegrationID string    `json:"integration_id,omitempty"`
+	From          time.Time `json:"from"`
+	To            time.Time `json:"to"`
+}
+
+// MaxReprocessWindow is the widest time range accepted by a single reprocess request
+const MaxReprocessWindow = 31 * 24 * time.Hour
+
+// Validate validates the reprocess request
+func (r *ReprocessInboundWebhooksRequest) Validate() error {
+	if r.WorkspaceID == "" {
+		return fmt.Errorf("workspace_id is required")
+	}
+	if r.From.IsZero() || r.To.IsZero() {
+		return fmt.Errorf("from and to are required")
+	}
+	if !r.From.Before(r.To) {
+		return fmt.Errorf("from must be before to")
+	}
+	if r.To.Sub(r.From) > MaxReprocessWindow {
+		return fmt.Errorf("time range cannot exceed %d days", int(MaxReprocessWindow.Hours()/24))
+	}
+	return nil
+}
+
+// ReprocessInboundWebhooksResult summarizes a reprocess run
+type ReprocessInboundWebhooksResult struct {
+	Payloads int `json:"payloads"` // Raw payloads read
+	Events   int `json:"events"`   // Events extracted from the payloads
+	Failed   int `json:"failed"`   // Payloads the current ingest logic could not process
 }
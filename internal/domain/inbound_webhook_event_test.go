@@ -431,3 +431,65 @@ func TestInboundWebhookEventListResult(t *testing.T) {
 	assert.Equal(t, "next-cursor", result.NextCursor)
 	assert.True(t, result.HasMore)
 }
+
+func TestInboundWebhookEventID(t *testing.T) {
+	payloadID := "6f1c1c1e-8f0a-4c8e-9d55-3f5b8a3c2b11"
+
+	// Deterministic for the same payload and index
+	assert.Equal(t, InboundWebhookEventID(payloadID, 0), InboundWebhookEventID(payloadID, 0))
+
+	// Distinct per index and per payload
+	assert.NotEqual(t, InboundWebhookEventID(payloadID, 0), InboundWebhookEventID(payloadID, 1))
+	assert.NotEqual(t, InboundWebhookEventID(payloadID, 0), InboundWebhookEventID("0b7a4e0c-1d2f-4c5e-8a9b-0c1d2e3f4a5b", 0))
+
+	// Non-UUID payload IDs are still supported
+	assert.Equal(t, InboundWebhookEventID("legacy", 2), InboundWebhookEventID("legacy", 2))
+	assert.Len(t, InboundWebhookEventID("legacy", 2), 36)
+}
+
+func TestReprocessInboundWebhooksRequest_Validate(t *testing.T) {
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		request ReprocessInboundWebhooksRequest
+		wantErr string
+	}{
+		{
+			name:    "valid",
+			request: ReprocessInboundWebhooksRequest{WorkspaceID: "ws1", From: from, To: from.Add(time.Hour)},
+		},
+		{
+			name:    "missing workspace",
+			request: ReprocessInboundWebhooksRequest{From: from, To: from.Add(time.Hour)},
+			wantErr: "workspace_id is required",
+		},
+		{
+			name:    "missing range",
+			request: ReprocessInboundWebhooksRequest{WorkspaceID: "ws1"},
+			wantErr: "from and to are required",
+		},
+		{
+			name:    "inverted range",
+			request: ReprocessInboundWebhooksRequest{WorkspaceID: "ws1", From: from, To: from.Add(-time.Hour)},
+			wantErr: "from must be before to",
+		},
+		{
+			name:    "range too large",
+			request: ReprocessInboundWebhooksRequest{WorkspaceID: "ws1", From: from, To: from.Add(MaxReprocessWindow + time.Hour)},
+			wantErr: "time range cannot exceed 31 days",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.request.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
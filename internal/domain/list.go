@@ -280,6 +280,66 @@ type ListStats struct {
 	TotalComplained   int `json:"total_complained"`
 }
 
+// MaxListGrowthDays is the longest range of days returned by the list growth stats
+const MaxListGrowthDays = 366
+
+// ListGrowthDay holds the subscribes and unsubscribes of a list on a day of the requested timezone
+type ListGrowthDay struct {
+	Date         string `json:"date"` // YYYY-MM-DD
+	Subscribes   int    `json:"subscribes"`
+	Unsubscribes int    `json:"unsubscribes"`
+	Net          int    `json:"net"` // Subscribes minus unsubscribes
+}
+
+// GetListGrowthRequest is the request of the daily growth stats of a list
+type GetListGrowthRequest struct {
+	WorkspaceID string
+	ListID      string
+	Timezone    string
+	From        time.Time
+	To          time.Time
+}
+
+// FromURLParams parses the request from the workspace_id, list_id, timezone (UTC by default) and
+// from/to (YYYY-MM-DD, both included) query parameters
+func (r *GetListGrowthRequest) FromURLParams(queryParams url.Values) (err error) {
+	r.WorkspaceID = queryParams.Get("workspace_id")
+	r.ListID = queryParams.Get("list_id")
+	r.Timezone = queryParams.Get("timezone")
+	if r.Timezone == "" {
+		r.Timezone = "UTC"
+	}
+
+	if r.WorkspaceID == "" {
+		return fmt.Errorf("invalid list growth request: workspace_id is required")
+	}
+	if r.ListID == "" {
+		return fmt.Errorf("invalid list growth request: list_id is required")
+	}
+	if !govalidator.IsAlphanumeric(r.ListID) || len(r.ListID) > 32 {
+		return fmt.Errorf("invalid list growth request: list_id must be alphanumeric with at most 32 characters")
+	}
+
+	loc, err := time.LoadLocation(r.Timezone)
+	if err != nil {
+		return fmt.Errorf("invalid list growth request: invalid timezone %s", r.Timezone)
+	}
+	if r.From, err = time.ParseInLocation("2006-01-02", queryParams.Get("from"), loc); err != nil {
+		return fmt.Errorf("invalid list growth request: from must be a YYYY-MM-DD date")
+	}
+	if r.To, err = time.ParseInLocation("2006-01-02", queryParams.Get("to"), loc); err != nil {
+		return fmt.Errorf("invalid list growth request: to must be a YYYY-MM-DD date")
+	}
+	if r.To.Before(r.From) {
+		return fmt.Errorf("invalid list growth request: to must not be before from")
+	}
+	if r.To.Sub(r.From) >= MaxListGrowthDays*24*time.Hour {
+		return fmt.Errorf("invalid list growth request: range must not exceed %d days", MaxListGrowthDays)
+	}
+
+	return nil
+}
+
 type SubscribeToListsRequest struct {
 	WorkspaceID string   `json:"workspace_id"`
 	Contact     Contact  `json:"contact"`
@@ -361,6 +421,9 @@ type ListService interface {
 	DeleteList(ctx context.Context, workspaceID string, id string) error
 
 	GetListStats(ctx context.Context, workspaceID string, id string) (*ListStats, error)
+
+	// GetListGrowthStats retrieves the daily subscribes and unsubscribes of a list
+	GetListGrowthStats(ctx context.Context, workspaceID string, listID string, tz string, from time.Time, to time.Time) ([]*ListGrowthDay, error)
 }
 
 type ListRepository interface {
@@ -380,6 +443,10 @@ type ListRepository interface {
 	DeleteList(ctx context.Context, workspaceID string, id string) error
 
 	GetListStats(ctx context.Context, workspaceID string, id string) (*ListStats, error)
+
+	// GetGrowthStats retrieves the subscribes and unsubscribes of a list for each day from from to to,
+	// both included, in the timezone tz. Days without any change are returned with zero counts.
+	GetGrowthStats(ctx context.Context, workspaceID string, listID string, tz string, from time.Time, to time.Time) ([]*ListGrowthDay, error)
 }
 
 // ErrListNotFound is returned when a list is not found
@@ -555,6 +555,60 @@ func TestGetListRequest_FromURLParams(t *testing.T) {
 	}
 }
 
+func TestGetListGrowthRequest_FromURLParams(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	assert.NoError(t, err)
+
+	t.Run("valid params", func(t *testing.T) {
+		req := &GetListGrowthRequest{}
+		err := req.FromURLParams(url.Values{
+			"workspace_id": {"workspace123"},
+			"list_id":      {"list123"},
+			"timezone":     {"Europe/Paris"},
+			"from":         {"2026-03-01"},
+			"to":           {"2026-03-31"},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "workspace123", req.WorkspaceID)
+		assert.Equal(t, "list123", req.ListID)
+		assert.Equal(t, "Europe/Paris", req.Timezone)
+		assert.Equal(t, time.Date(2026, 3, 1, 0, 0, 0, 0, paris), req.From)
+		assert.Equal(t, time.Date(2026, 3, 31, 0, 0, 0, 0, paris), req.To)
+	})
+
+	t.Run("timezone defaults to UTC", func(t *testing.T) {
+		req := &GetListGrowthRequest{}
+		err := req.FromURLParams(url.Values{
+			"workspace_id": {"workspace123"},
+			"list_id":      {"list123"},
+			"from":         {"2026-03-01"},
+			"to":           {"2026-03-01"},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "UTC", req.Timezone)
+	})
+
+	tests := []struct {
+		name   string
+		params url.Values
+	}{
+		{"missing workspace ID", url.Values{"list_id": {"list123"}, "from": {"2026-03-01"}, "to": {"2026-03-02"}}},
+		{"missing list ID", url.Values{"workspace_id": {"workspace123"}, "from": {"2026-03-01"}, "to": {"2026-03-02"}}},
+		{"invalid list ID", url.Values{"workspace_id": {"workspace123"}, "list_id": {"invalid@list"}, "from": {"2026-03-01"}, "to": {"2026-03-02"}}},
+		{"invalid timezone", url.Values{"workspace_id": {"workspace123"}, "list_id": {"list123"}, "timezone": {"Mars/Olympus"}, "from": {"2026-03-01"}, "to": {"2026-03-02"}}},
+		{"missing from", url.Values{"workspace_id": {"workspace123"}, "list_id": {"list123"}, "to": {"2026-03-02"}}},
+		{"invalid to", url.Values{"workspace_id": {"workspace123"}, "list_id": {"list123"}, "from": {"2026-03-01"}, "to": {"2026-03-02T10:00:00Z"}}},
+		{"to before from", url.Values{"workspace_id": {"workspace123"}, "list_id": {"list123"}, "from": {"2026-03-02"}, "to": {"2026-03-01"}}},
+		{"range too long", url.Values{"workspace_id": {"workspace123"}, "list_id": {"list123"}, "from": {"2025-01-01"}, "to": {"2026-01-02"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &GetListGrowthRequest{}
+			assert.Error(t, req.FromURLParams(tt.params))
+		})
+	}
+}
+
 func TestUpdateListRequest_Validate(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -6,8 +6,11 @@ import (
 	"database/sql"
 	"database/sql/driver"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/asaskevich/govalidator"
@@ -31,12 +34,28 @@ const (
 	MessageEventUnsubscribed MessageEvent = "unsubscribed"
 )
 
+// MessageStatusInfoDryRun is the status info of the messages recorded by a dry-run broadcast, which were never delivered
+const MessageStatusInfoDryRun = "dry_run"
+
+// BounceCategory is the provider independent classification of a bounce or complaint,
+// stored on the message so that segments and suppression do not parse provider codes
+type BounceCategory string
+
+const (
+	BounceCategoryHardBounce    BounceCategory = "hard_bounce"
+	BounceCategorySoftBounce    BounceCategory = "soft_bounce"
+	BounceCategoryBlockList     BounceCategory = "block_list"
+	BounceCategorySpamComplaint BounceCategory = "spam_complaint"
+	BounceCategoryMailboxFull   BounceCategory = "mailbox_full"
+)
+
 // MessageEventUpdate represents a status update for a message
 type MessageEventUpdate struct {
-	ID         string       `json:"id"`
-	Event      MessageEvent `json:"event"`
-	Timestamp  time.Time    `json:"timestamp"`
-	StatusInfo *string      `json:"status_info,omitempty"`
+	ID             string          `json:"id"`
+	Event          MessageEvent    `json:"event"`
+	Timestamp      time.Time       `json:"timestamp"`
+	StatusInfo     *string         `json:"status_info,omitempty"`
+	BounceCategory *BounceCategory `json:"bounce_category,omitempty"` // Kept as is when nil
 }
 
 // ChannelOptions represents channel-specific delivery options
@@ -48,6 +67,10 @@ type ChannelOptions struct {
 	BCC      []string `json:"bcc,omitempty"`
 	ReplyTo  string   `json:"reply_to,omitempty"`
 
+	// IntegrationID is the email provider integration that delivered a queued email,
+	// which differs from the one it was queued for when the send failed over
+	IntegrationID string `json:"integration_id,omitempty"`
+
 	// Future: SMS options would go here
 	// Future: Push notification options would go here
 }
@@ -131,6 +154,53 @@ type MessageHistory struct {
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
+// ErrIdempotencyKeyUsed is returned when creating a message with an idempotency key
+// already held by another message
+var ErrIdempotencyKeyUsed = errors.New("idempotency key already used")
+
+// MessageExportColumns lists the columns of message history CSV exports
+var MessageExportColumns = []string{
+	"id", "external_id", "contact_email", "broadcast_id", "automation_id", "list_id",
+	"template_id", "template_version", "channel", "status_info",
+	"sent_at", "delivered_at", "failed_at", "opened_at", "clicked_at",
+	"bounced_at", "complained_at", "unsubscribed_at", "created_at", "updated_at",
+}
+
+// ExportRow returns the message as a CSV row matching MessageExportColumns, timestamps in RFC3339
+func (m *MessageHistory) ExportRow() []string {
+	return []string{
+		m.ID, exportStringPtr(m.ExternalID), m.ContactEmail, exportStringPtr(m.BroadcastID),
+		exportStringPtr(m.AutomationID), exportStringPtr(m.ListID),
+		m.TemplateID, strconv.FormatInt(m.TemplateVersion, 10), m.Channel, exportStringPtr(m.StatusInfo),
+		exportTime(&m.SentAt), exportTime(m.DeliveredAt), exportTime(m.FailedAt), exportTime(m.OpenedAt),
+		exportTime(m.ClickedAt), exportTime(m.BouncedAt), exportTime(m.ComplainedAt), exportTime(m.UnsubscribedAt),
+		exportTime(&m.CreatedAt), exportTime(&m.UpdatedAt),
+	}
+}
+
+func exportStringPtr(v *string) string {
+	if v == nil {
+		return ""
+	}
+	return *v
+}
+
+func exportTime(v *time.Time) string {
+	if v == nil || v.IsZero() {
+		return ""
+	}
+	return v.UTC().Format(time.RFC3339)
+}
+
+// TimelineEntry is a single event of a contact timeline, either a message event
+// (message.sent, message.opened...) or a list subscription change (list.active, list.removed...)
+type TimelineEntry struct {
+	ID        string                 `json:"id"`
+	Kind      string                 `json:"kind"`
+	Timestamp time.Time              `json:"timestamp"`
+	Payload   map[string]interface{} `json:"payload,omitempty"`
+}
+
 type MessageHistoryStatusSum struct {
 	TotalSent         int `json:"total_sent"`
 	TotalDelivered    int `json:"total_delivered"`
@@ -142,11 +212,30 @@ type MessageHistoryStatusSum struct {
 	TotalUnsubscribed int `json:"total_unsubscribed"`
 }
 
+// MessageHistoryDailyStatusSum holds the message statuses reached on one day, in the requested timezone
+type MessageHistoryDailyStatusSum struct {
+	Date string `json:"date"` // YYYY-MM-DD
+	MessageHistoryStatusSum
+}
+
+// The engagement score of a contact sums the opens and clicks of its messages over a trailing window,
+// each weighted and halved every EngagementScoreHalfLifeDays so recent engagement counts more
+const (
+	EngagementScoreWindowDays   = 180
+	EngagementScoreHalfLifeDays = 30
+	EngagementScoreOpenWeight   = 1
+	EngagementScoreClickWeight  = 3
+)
+
 // MessageHistoryRepository defines methods for message history persistence
 type MessageHistoryRepository interface {
 	// Create adds a new message history record
 	Create(ctx context.Context, workspaceID string, secretKey string, message *MessageHistory) error
 
+	// CreateWithIdempotencyKey adds a new message history record holding the idempotency key.
+	// It returns ErrIdempotencyKeyUsed without inserting when another message holds the key.
+	CreateWithIdempotencyKey(ctx context.Context, workspaceID string, secretKey string, message *MessageHistory, idempotencyKey string) error
+
 	// Upsert creates or updates a message history record (for retry handling)
 	// On conflict, updates failed_at, status_info, and updated_at fields
 	Upsert(ctx context.Context, workspaceID string, secretKey string, message *MessageHistory) error
@@ -160,6 +249,15 @@ type MessageHistoryRepository interface {
 	// GetByExternalID retrieves a message history by external ID for idempotency checks
 	GetByExternalID(ctx context.Context, workspaceID string, secretKey string, externalID string) (*MessageHistory, error)
 
+	// GetByExternalIDs retrieves the message histories of several external IDs in one query, keyed by external ID
+	GetByExternalIDs(ctx context.Context, workspaceID string, secretKey string, externalIDs []string) (map[string]*MessageHistory, error)
+
+	// GetByIdempotencyKey retrieves the message history holding an idempotency key
+	GetByIdempotencyKey(ctx context.Context, workspaceID string, secretKey string, idempotencyKey string) (*MessageHistory, error)
+
+	// ReleaseIdempotencyKey clears an idempotency key held by a message created before the given time
+	ReleaseIdempotencyKey(ctx context.Context, workspaceID string, idempotencyKey string, createdBefore time.Time) error
+
 	// GetByContact retrieves message history for a specific contact
 	GetByContact(ctx context.Context, workspaceID string, secretKey string, contactEmail string, limit, offset int) ([]*MessageHistory, int, error)
 
@@ -169,9 +267,17 @@ type MessageHistoryRepository interface {
 	// ListMessages retrieves message history with cursor-based pagination and filtering
 	ListMessages(ctx context.Context, workspaceID string, secretKey string, params MessageListParams) ([]*MessageHistory, string, error)
 
+	// ExportMessages streams the messages matching the list filters to w as CSV (MessageExportColumns),
+	// most recent first, paging internally with a keyset cursor. Cursor and Limit of params are ignored.
+	ExportMessages(ctx context.Context, workspaceID string, secretKey string, params MessageListParams, w io.Writer) error
+
 	// SetStatusesIfNotSet updates multiple message statuses in a batch if they haven't been set before
 	SetStatusesIfNotSet(ctx context.Context, workspaceID string, updates []MessageEventUpdate) error
 
+	// ApplyStatusesLocked applies status updates in one transaction holding a row lock on their
+	// messages, so that each status keeps the timestamp of the first update applied
+	ApplyStatusesLocked(ctx context.Context, workspaceID string, updates []MessageEventUpdate) error
+
 	// SetClicked sets the clicked_at timestamp and ensures opened_at is also set
 	SetClicked(ctx context.Context, workspaceID, id string, timestamp time.Time) error
 
@@ -181,11 +287,37 @@ type MessageHistoryRepository interface {
 	// GetBroadcastStats retrieves statistics for a broadcast
 	GetBroadcastStats(ctx context.Context, workspaceID, broadcastID string) (*MessageHistoryStatusSum, error)
 
+	// GetBroadcastStatsByDay retrieves statistics for a broadcast per day in the given timezone,
+	// from the first to the last day with activity, days without activity included
+	GetBroadcastStatsByDay(ctx context.Context, workspaceID, broadcastID, tz string) ([]*MessageHistoryDailyStatusSum, error)
+
 	// GetBroadcastVariationStats retrieves statistics for a specific variation of a broadcast
 	GetBroadcastVariationStats(ctx context.Context, workspaceID, broadcastID, templateID string) (*MessageHistoryStatusSum, error)
 
+	// GetBroadcastVariationStatsAll retrieves the statistics of the given variations of a broadcast in a
+	// single query, keyed by template ID. Variations without messages get zeroed statistics.
+	GetBroadcastVariationStatsAll(ctx context.Context, workspaceID, broadcastID string, templateIDs []string) (map[string]*MessageHistoryStatusSum, error)
+
+	// GetSentEmailsForBroadcast returns the emails among the given ones that already have a message for the broadcast
+	GetSentEmailsForBroadcast(ctx context.Context, workspaceID, broadcastID string, emails []string) ([]string, error)
+
+	// GetEngagementScores computes the engagement score of the given contacts in a single query, keyed by email.
+	// Contacts without opens or clicks in the window get a zero score.
+	GetEngagementScores(ctx context.Context, workspaceID string, emails []string) (map[string]float64, error)
+
+	// CountSentAndComplaintsSince counts the messages sent since the given time and the complaints received since then
+	CountSentAndComplaintsSince(ctx context.Context, workspaceID string, since time.Time) (sent int, complaints int, err error)
+
+	// GetContactTimeline merges the message events and list subscription changes of a contact
+	// into a single timeline, most recent first, with cursor-based pagination
+	GetContactTimeline(ctx context.Context, workspaceID string, secretKey string, email string, limit int, cursor string) ([]*TimelineEntry, string, error)
+
 	// DeleteForEmail deletes all message history records for a specific email
 	DeleteForEmail(ctx context.Context, workspaceID, email string) error
+
+	// PruneOlderThan deletes at most limit messages created before cutoff, oldest first, and returns
+	// the number deleted. Messages that reached any of keepStatuses are kept.
+	PruneOlderThan(ctx context.Context, workspaceID string, cutoff time.Time, keepStatuses []MessageEvent, limit int) (int64, error)
 }
 
 // MessageHistoryService defines methods for interacting with message history
@@ -193,9 +325,16 @@ type MessageHistoryService interface {
 	// ListMessages retrieves messages for a workspace with cursor-based pagination and filters
 	ListMessages(ctx context.Context, workspaceID string, params MessageListParams) (*MessageListResult, error)
 
+	// ExportMessages streams the messages matching the filters to w as CSV
+	ExportMessages(ctx context.Context, workspaceID string, params MessageListParams, w io.Writer) error
+
 	// GetBroadcastStats retrieves statistics for a broadcast
 	GetBroadcastStats(ctx context.Context, workspaceID, broadcastID string) (*MessageHistoryStatusSum, error)
 
+	// GetBroadcastStatsByDay retrieves statistics for a broadcast per day, in the given timezone
+	// or the workspace timezone when empty
+	GetBroadcastStatsByDay(ctx context.Context, workspaceID, broadcastID, tz string) ([]*MessageHistoryDailyStatusSum, error)
+
 	// GetBroadcastVariationStats retrieves statistics for a specific variation of a broadcast
 	GetBroadcastVariationStats(ctx context.Context, workspaceID, broadcastID, templateID string) (*MessageHistoryStatusSum, error)
 }
@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+//go:generate mockgen -destination mocks/mock_message_status_retry_repository.go -package mocks github.com/Notifuse/notifuse/internal/domain MessageStatusRetryRepository
+
+const (
+	// MessageStatusRetryMaxAttempts is the number of failed attempts after which a retry is dropped
+	MessageStatusRetryMaxAttempts = 10
+
+	messageStatusRetryBaseDelay = 30 * time.Second
+	messageStatusRetryMaxDelay  = time.Hour
+)
+
+// MessageStatusRetry is a message status update from a provider webhook that failed to apply to the
+// message history and is applied again later. Retries are unique per message, event and timestamp.
+type MessageStatusRetry struct {
+	Update        MessageEventUpdate `json:"update"`
+	Attempts      int                `json:"attempts"`
+	NextAttemptAt time.Time          `json:"next_attempt_at"`
+	LastError     string             `json:"last_error"`
+	CreatedAt     time.Time          `json:"created_at"`
+}
+
+// MessageStatusRetryDelay returns the delay before the next attempt of a retry that failed the given
+// number of times: 30s doubled on each failure, capped at an hour
+func MessageStatusRetryDelay(attempts int) time.Duration {
+	delay := messageStatusRetryBaseDelay
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= messageStatusRetryMaxDelay {
+			return messageStatusRetryMaxDelay
+		}
+	}
+	return delay
+}
+
+// MessageStatusRetryRepository stores the message status updates waiting to be applied again
+type MessageStatusRetryRepository interface {
+	// Enqueue stores failed updates for a first retry at nextAttemptAt. Updates already queued are left as is.
+	Enqueue(ctx context.Context, workspaceID string, updates []MessageEventUpdate, nextAttemptAt time.Time, lastError string) error
+
+	// ListDue retrieves at most limit retries whose next attempt is due, oldest first
+	ListDue(ctx context.Context, workspaceID string, now time.Time, limit int) ([]*MessageStatusRetry, error)
+
+	// Reschedule records a failed attempt of a retry with its attempts, next attempt and last error
+	Reschedule(ctx context.Context, workspaceID string, retry *MessageStatusRetry) error
+
+	// Delete removes retries that were applied or given up
+	Delete(ctx context.Context, workspaceID string, retries []*MessageStatusRetry) error
+}
@@ -0,0 +1,18 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessageStatusRetryDelay(t *testing.T) {
+	assert.Equal(t, 30*time.Second, MessageStatusRetryDelay(0))
+	assert.Equal(t, 30*time.Second, MessageStatusRetryDelay(1))
+	assert.Equal(t, time.Minute, MessageStatusRetryDelay(2))
+	assert.Equal(t, 4*time.Minute, MessageStatusRetryDelay(4))
+	assert.Equal(t, 32*time.Minute, MessageStatusRetryDelay(7))
+	assert.Equal(t, time.Hour, MessageStatusRetryDelay(8))
+	assert.Equal(t, time.Hour, MessageStatusRetryDelay(MessageStatusRetryMaxAttempts))
+}
@@ -122,6 +122,35 @@ func (mr *MockAuthServiceMockRecorder) InvalidateSecretCache() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InvalidateSecretCache", reflect.TypeOf((*MockAuthService)(nil).InvalidateSecretCache))
 }
 
+// IsTokenRevoked mocks base method.
+func (m *MockAuthService) IsTokenRevoked(arg0 context.Context, arg1 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsTokenRevoked", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsTokenRevoked indicates an expected call of IsTokenRevoked.
+func (mr *MockAuthServiceMockRecorder) IsTokenRevoked(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsTokenRevoked", reflect.TypeOf((*MockAuthService)(nil).IsTokenRevoked), arg0, arg1)
+}
+
+// RevokeToken mocks base method.
+func (m *MockAuthService) RevokeToken(arg0 context.Context, arg1 string, arg2 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeToken", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeToken indicates an expected call of RevokeToken.
+func (mr *MockAuthServiceMockRecorder) RevokeToken(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeToken", reflect.TypeOf((*MockAuthService)(nil).RevokeToken), arg0, arg1, arg2)
+}
+
 // ValidateInvitationToken mocks base method.
 func (m *MockAuthService) ValidateInvitationToken(arg0 string) (string, string, string, error) {
 	m.ctrl.T.Helper()
@@ -78,6 +78,21 @@ func (mr *MockBroadcastRepositoryMockRecorder) DeleteBroadcast(arg0, arg1, arg2
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBroadcast", reflect.TypeOf((*MockBroadcastRepository)(nil).DeleteBroadcast), arg0, arg1, arg2)
 }
 
+// DeleteBroadcastTag mocks base method.
+func (m *MockBroadcastRepository) DeleteBroadcastTag(arg0 context.Context, arg1, arg2 string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBroadcastTag", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteBroadcastTag indicates an expected call of DeleteBroadcastTag.
+func (mr *MockBroadcastRepositoryMockRecorder) DeleteBroadcastTag(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBroadcastTag", reflect.TypeOf((*MockBroadcastRepository)(nil).DeleteBroadcastTag), arg0, arg1, arg2)
+}
+
 // DeleteBroadcastTx mocks base method.
 func (m *MockBroadcastRepository) DeleteBroadcastTx(arg0 context.Context, arg1 *sql.Tx, arg2, arg3 string) error {
 	m.ctrl.T.Helper()
@@ -122,6 +137,21 @@ func (mr *MockBroadcastRepositoryMockRecorder) GetBroadcastTx(arg0, arg1, arg2,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBroadcastTx", reflect.TypeOf((*MockBroadcastRepository)(nil).GetBroadcastTx), arg0, arg1, arg2, arg3)
 }
 
+// ListBroadcastTags mocks base method.
+func (m *MockBroadcastRepository) ListBroadcastTags(arg0 context.Context, arg1 string) ([]*domain.BroadcastTagCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBroadcastTags", arg0, arg1)
+	ret0, _ := ret[0].([]*domain.BroadcastTagCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBroadcastTags indicates an expected call of ListBroadcastTags.
+func (mr *MockBroadcastRepositoryMockRecorder) ListBroadcastTags(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBroadcastTags", reflect.TypeOf((*MockBroadcastRepository)(nil).ListBroadcastTags), arg0, arg1)
+}
+
 // ListBroadcasts mocks base method.
 func (m *MockBroadcastRepository) ListBroadcasts(arg0 context.Context, arg1 domain.ListBroadcastsParams) (*domain.BroadcastListResponse, error) {
 	m.ctrl.T.Helper()
@@ -152,6 +182,20 @@ func (mr *MockBroadcastRepositoryMockRecorder) ListBroadcastsTx(arg0, arg1, arg2
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBroadcastsTx", reflect.TypeOf((*MockBroadcastRepository)(nil).ListBroadcastsTx), arg0, arg1, arg2)
 }
 
+// SetBroadcastTags mocks base method.
+func (m *MockBroadcastRepository) SetBroadcastTags(arg0 context.Context, arg1, arg2 string, arg3 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBroadcastTags", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetBroadcastTags indicates an expected call of SetBroadcastTags.
+func (mr *MockBroadcastRepositoryMockRecorder) SetBroadcastTags(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBroadcastTags", reflect.TypeOf((*MockBroadcastRepository)(nil).SetBroadcastTags), arg0, arg1, arg2, arg3)
+}
+
 // UpdateBroadcast mocks base method.
 func (m *MockBroadcastRepository) UpdateBroadcast(arg0 context.Context, arg1 *domain.Broadcast) error {
 	m.ctrl.T.Helper()
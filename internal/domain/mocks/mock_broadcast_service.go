@@ -35,6 +35,20 @@ func (m *MockBroadcastService) EXPECT() *MockBroadcastServiceMockRecorder {
 	return m.recorder
 }
 
+// ApproveSeedTest mocks base method.
+func (m *MockBroadcastService) ApproveSeedTest(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApproveSeedTest", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApproveSeedTest indicates an expected call of ApproveSeedTest.
+func (mr *MockBroadcastServiceMockRecorder) ApproveSeedTest(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApproveSeedTest", reflect.TypeOf((*MockBroadcastService)(nil).ApproveSeedTest), arg0, arg1, arg2)
+}
+
 // CancelBroadcast mocks base method.
 func (m *MockBroadcastService) CancelBroadcast(arg0 context.Context, arg1 *domain.CancelBroadcastRequest) error {
 	m.ctrl.T.Helper()
@@ -78,6 +92,20 @@ func (mr *MockBroadcastServiceMockRecorder) DeleteBroadcast(arg0, arg1 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBroadcast", reflect.TypeOf((*MockBroadcastService)(nil).DeleteBroadcast), arg0, arg1)
 }
 
+// DeleteBroadcastTag mocks base method.
+func (m *MockBroadcastService) DeleteBroadcastTag(arg0 context.Context, arg1 *domain.DeleteBroadcastTagRequest) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteBroadcastTag", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteBroadcastTag indicates an expected call of DeleteBroadcastTag.
+func (mr *MockBroadcastServiceMockRecorder) DeleteBroadcastTag(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteBroadcastTag", reflect.TypeOf((*MockBroadcastService)(nil).DeleteBroadcastTag), arg0, arg1)
+}
+
 // GetBroadcast mocks base method.
 func (m *MockBroadcastService) GetBroadcast(arg0 context.Context, arg1, arg2 string) (*domain.Broadcast, error) {
 	m.ctrl.T.Helper()
@@ -93,6 +121,21 @@ func (mr *MockBroadcastServiceMockRecorder) GetBroadcast(arg0, arg1, arg2 interf
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBroadcast", reflect.TypeOf((*MockBroadcastService)(nil).GetBroadcast), arg0, arg1, arg2)
 }
 
+// GetBroadcastStats mocks base method.
+func (m *MockBroadcastService) GetBroadcastStats(arg0 context.Context, arg1, arg2 string) (*domain.BroadcastStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBroadcastStats", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*domain.BroadcastStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBroadcastStats indicates an expected call of GetBroadcastStats.
+func (mr *MockBroadcastServiceMockRecorder) GetBroadcastStats(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBroadcastStats", reflect.TypeOf((*MockBroadcastService)(nil).GetBroadcastStats), arg0, arg1, arg2)
+}
+
 // GetTestResults mocks base method.
 func (m *MockBroadcastService) GetTestResults(arg0 context.Context, arg1, arg2 string) (*domain.TestResultsResponse, error) {
 	m.ctrl.T.Helper()
@@ -108,6 +151,21 @@ func (mr *MockBroadcastServiceMockRecorder) GetTestResults(arg0, arg1, arg2 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTestResults", reflect.TypeOf((*MockBroadcastService)(nil).GetTestResults), arg0, arg1, arg2)
 }
 
+// ListBroadcastTags mocks base method.
+func (m *MockBroadcastService) ListBroadcastTags(arg0 context.Context, arg1 string) ([]*domain.BroadcastTagCount, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListBroadcastTags", arg0, arg1)
+	ret0, _ := ret[0].([]*domain.BroadcastTagCount)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListBroadcastTags indicates an expected call of ListBroadcastTags.
+func (mr *MockBroadcastServiceMockRecorder) ListBroadcastTags(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListBroadcastTags", reflect.TypeOf((*MockBroadcastService)(nil).ListBroadcastTags), arg0, arg1)
+}
+
 // ListBroadcasts mocks base method.
 func (m *MockBroadcastService) ListBroadcasts(arg0 context.Context, arg1 domain.ListBroadcastsParams) (*domain.BroadcastListResponse, error) {
 	m.ctrl.T.Helper()
@@ -137,6 +195,21 @@ func (mr *MockBroadcastServiceMockRecorder) PauseBroadcast(arg0, arg1 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PauseBroadcast", reflect.TypeOf((*MockBroadcastService)(nil).PauseBroadcast), arg0, arg1)
 }
 
+// PreflightBroadcast mocks base method.
+func (m *MockBroadcastService) PreflightBroadcast(arg0 context.Context, arg1, arg2 string) (*domain.AudiencePreflight, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PreflightBroadcast", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*domain.AudiencePreflight)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PreflightBroadcast indicates an expected call of PreflightBroadcast.
+func (mr *MockBroadcastServiceMockRecorder) PreflightBroadcast(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreflightBroadcast", reflect.TypeOf((*MockBroadcastService)(nil).PreflightBroadcast), arg0, arg1, arg2)
+}
+
 // ResumeBroadcast mocks base method.
 func (m *MockBroadcastService) ResumeBroadcast(arg0 context.Context, arg1 *domain.ResumeBroadcastRequest) error {
 	m.ctrl.T.Helper()
@@ -193,6 +266,21 @@ func (mr *MockBroadcastServiceMockRecorder) SendToIndividual(arg0, arg1 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendToIndividual", reflect.TypeOf((*MockBroadcastService)(nil).SendToIndividual), arg0, arg1)
 }
 
+// SetBroadcastTags mocks base method.
+func (m *MockBroadcastService) SetBroadcastTags(arg0 context.Context, arg1 *domain.SetBroadcastTagsRequest) (*domain.Broadcast, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetBroadcastTags", arg0, arg1)
+	ret0, _ := ret[0].(*domain.Broadcast)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetBroadcastTags indicates an expected call of SetBroadcastTags.
+func (mr *MockBroadcastServiceMockRecorder) SetBroadcastTags(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetBroadcastTags", reflect.TypeOf((*MockBroadcastService)(nil).SetBroadcastTags), arg0, arg1)
+}
+
 // UpdateBroadcast mocks base method.
 func (m *MockBroadcastService) UpdateBroadcast(arg0 context.Context, arg1 *domain.UpdateBroadcastRequest) (*domain.Broadcast, error) {
 	m.ctrl.T.Helper()
@@ -207,3 +295,18 @@ func (mr *MockBroadcastServiceMockRecorder) UpdateBroadcast(arg0, arg1 interface
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateBroadcast", reflect.TypeOf((*MockBroadcastService)(nil).UpdateBroadcast), arg0, arg1)
 }
+
+// UploadBroadcastAudience mocks base method.
+func (m *MockBroadcastService) UploadBroadcastAudience(arg0 context.Context, arg1 *domain.UploadBroadcastAudienceRequest) (*domain.UploadBroadcastAudienceResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UploadBroadcastAudience", arg0, arg1)
+	ret0, _ := ret[0].(*domain.UploadBroadcastAudienceResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UploadBroadcastAudience indicates an expected call of UploadBroadcastAudience.
+func (mr *MockBroadcastServiceMockRecorder) UploadBroadcastAudience(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UploadBroadcastAudience", reflect.TypeOf((*MockBroadcastService)(nil).UploadBroadcastAudience), arg0, arg1)
+}
@@ -35,6 +35,20 @@ func (m *MockContactRepository) EXPECT() *MockContactRepositoryMockRecorder {
 	return m.recorder
 }
 
+// AddSuppressions mocks base method.
+func (m *MockContactRepository) AddSuppressions(arg0 context.Context, arg1 string, arg2 []*domain.EmailSuppression) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddSuppressions", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddSuppressions indicates an expected call of AddSuppressions.
+func (mr *MockContactRepositoryMockRecorder) AddSuppressions(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSuppressions", reflect.TypeOf((*MockContactRepository)(nil).AddSuppressions), arg0, arg1, arg2)
+}
+
 // BulkUpsertContacts mocks base method.
 func (m *MockContactRepository) BulkUpsertContacts(arg0 context.Context, arg1 string, arg2 []*domain.Contact) ([]domain.BulkUpsertResult, error) {
 	m.ctrl.T.Helper()
@@ -80,6 +94,22 @@ func (mr *MockContactRepositoryMockRecorder) CountContactsForBroadcast(arg0, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountContactsForBroadcast", reflect.TypeOf((*MockContactRepository)(nil).CountContactsForBroadcast), arg0, arg1, arg2)
 }
 
+// CountDeliverableContactsForBroadcast mocks base method.
+func (m *MockContactRepository) CountDeliverableContactsForBroadcast(arg0 context.Context, arg1 string, arg2 domain.AudienceSettings) (int, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountDeliverableContactsForBroadcast", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CountDeliverableContactsForBroadcast indicates an expected call of CountDeliverableContactsForBroadcast.
+func (mr *MockContactRepositoryMockRecorder) CountDeliverableContactsForBroadcast(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountDeliverableContactsForBroadcast", reflect.TypeOf((*MockContactRepository)(nil).CountDeliverableContactsForBroadcast), arg0, arg1, arg2)
+}
+
 // DeleteContact mocks base method.
 func (m *MockContactRepository) DeleteContact(arg0 context.Context, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
@@ -94,6 +124,36 @@ func (mr *MockContactRepositoryMockRecorder) DeleteContact(arg0, arg1, arg2 inte
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteContact", reflect.TypeOf((*MockContactRepository)(nil).DeleteContact), arg0, arg1, arg2)
 }
 
+// FindDuplicatesByExternalID mocks base method.
+func (m *MockContactRepository) FindDuplicatesByExternalID(arg0 context.Context, arg1 string) ([]*domain.ContactDuplicateGroup, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDuplicatesByExternalID", arg0, arg1)
+	ret0, _ := ret[0].([]*domain.ContactDuplicateGroup)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDuplicatesByExternalID indicates an expected call of FindDuplicatesByExternalID.
+func (mr *MockContactRepositoryMockRecorder) FindDuplicatesByExternalID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDuplicatesByExternalID", reflect.TypeOf((*MockContactRepository)(nil).FindDuplicatesByExternalID), arg0, arg1)
+}
+
+// GetAudienceCounts mocks base method.
+func (m *MockContactRepository) GetAudienceCounts(arg0 context.Context, arg1 string) (*domain.AudienceCounts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAudienceCounts", arg0, arg1)
+	ret0, _ := ret[0].(*domain.AudienceCounts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAudienceCounts indicates an expected call of GetAudienceCounts.
+func (mr *MockContactRepositoryMockRecorder) GetAudienceCounts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAudienceCounts", reflect.TypeOf((*MockContactRepository)(nil).GetAudienceCounts), arg0, arg1)
+}
+
 // GetBatchForSegment mocks base method.
 func (m *MockContactRepository) GetBatchForSegment(arg0 context.Context, arg1 string, arg2 int64, arg3 int) ([]string, error) {
 	m.ctrl.T.Helper()
@@ -154,6 +214,36 @@ func (mr *MockContactRepositoryMockRecorder) GetContacts(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContacts", reflect.TypeOf((*MockContactRepository)(nil).GetContacts), arg0, arg1)
 }
 
+// GetContactsByEmails mocks base method.
+func (m *MockContactRepository) GetContactsByEmails(arg0 context.Context, arg1 string, arg2 []string) ([]*domain.Contact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContactsByEmails", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*domain.Contact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContactsByEmails indicates an expected call of GetContactsByEmails.
+func (mr *MockContactRepositoryMockRecorder) GetContactsByEmails(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContactsByEmails", reflect.TypeOf((*MockContactRepository)(nil).GetContactsByEmails), arg0, arg1, arg2)
+}
+
+// GetContactsByExternalIDs mocks base method.
+func (m *MockContactRepository) GetContactsByExternalIDs(arg0 context.Context, arg1 string, arg2 []string) ([]*domain.Contact, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContactsByExternalIDs", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*domain.Contact)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetContactsByExternalIDs indicates an expected call of GetContactsByExternalIDs.
+func (mr *MockContactRepositoryMockRecorder) GetContactsByExternalIDs(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContactsByExternalIDs", reflect.TypeOf((*MockContactRepository)(nil).GetContactsByExternalIDs), arg0, arg1, arg2)
+}
+
 // GetContactsForBroadcast mocks base method.
 func (m *MockContactRepository) GetContactsForBroadcast(arg0 context.Context, arg1 string, arg2 domain.AudienceSettings, arg3 int, arg4 string) ([]*domain.ContactWithList, error) {
 	m.ctrl.T.Helper()
@@ -169,6 +259,80 @@ func (mr *MockContactRepositoryMockRecorder) GetContactsForBroadcast(arg0, arg1,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContactsForBroadcast", reflect.TypeOf((*MockContactRepository)(nil).GetContactsForBroadcast), arg0, arg1, arg2, arg3, arg4)
 }
 
+// GetOptimalSendHours mocks base method.
+func (m *MockContactRepository) GetOptimalSendHours(arg0 context.Context, arg1 string, arg2 []string) (map[string]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOptimalSendHours", arg0, arg1, arg2)
+	ret0, _ := ret[0].(map[string]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOptimalSendHours indicates an expected call of GetOptimalSendHours.
+func (mr *MockContactRepositoryMockRecorder) GetOptimalSendHours(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOptimalSendHours", reflect.TypeOf((*MockContactRepository)(nil).GetOptimalSendHours), arg0, arg1, arg2)
+}
+
+// ListSuppressions mocks base method.
+func (m *MockContactRepository) ListSuppressions(arg0 context.Context, arg1 string, arg2 int, arg3 string) ([]*domain.EmailSuppression, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSuppressions", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*domain.EmailSuppression)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSuppressions indicates an expected call of ListSuppressions.
+func (mr *MockContactRepositoryMockRecorder) ListSuppressions(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSuppressions", reflect.TypeOf((*MockContactRepository)(nil).ListSuppressions), arg0, arg1, arg2, arg3)
+}
+
+// MergeContacts mocks base method.
+func (m *MockContactRepository) MergeContacts(arg0 context.Context, arg1, arg2 string, arg3 []string) (*domain.ContactMergeResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MergeContacts", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*domain.ContactMergeResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MergeContacts indicates an expected call of MergeContacts.
+func (mr *MockContactRepositoryMockRecorder) MergeContacts(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MergeContacts", reflect.TypeOf((*MockContactRepository)(nil).MergeContacts), arg0, arg1, arg2, arg3)
+}
+
+// RedactContact mocks base method.
+func (m *MockContactRepository) RedactContact(arg0 context.Context, arg1, arg2 string) (*domain.ContactRedactionResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RedactContact", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*domain.ContactRedactionResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RedactContact indicates an expected call of RedactContact.
+func (mr *MockContactRepositoryMockRecorder) RedactContact(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RedactContact", reflect.TypeOf((*MockContactRepository)(nil).RedactContact), arg0, arg1, arg2)
+}
+
+// RemoveSuppression mocks base method.
+func (m *MockContactRepository) RemoveSuppression(arg0 context.Context, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveSuppression", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveSuppression indicates an expected call of RemoveSuppression.
+func (mr *MockContactRepositoryMockRecorder) RemoveSuppression(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveSuppression", reflect.TypeOf((*MockContactRepository)(nil).RemoveSuppression), arg0, arg1, arg2)
+}
+
 // UpsertContact mocks base method.
 func (m *MockContactRepository) UpsertContact(arg0 context.Context, arg1 string, arg2 *domain.Contact) (bool, error) {
 	m.ctrl.T.Helper()
@@ -183,3 +347,18 @@ func (mr *MockContactRepositoryMockRecorder) UpsertContact(arg0, arg1, arg2 inte
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertContact", reflect.TypeOf((*MockContactRepository)(nil).UpsertContact), arg0, arg1, arg2)
 }
+
+// UpsertContacts mocks base method.
+func (m *MockContactRepository) UpsertContacts(arg0 context.Context, arg1 string, arg2 []*domain.Contact, arg3 domain.MergeStrategy) ([]domain.BulkUpsertResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertContacts", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]domain.BulkUpsertResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertContacts indicates an expected call of UpsertContacts.
+func (mr *MockContactRepositoryMockRecorder) UpsertContacts(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertContacts", reflect.TypeOf((*MockContactRepository)(nil).UpsertContacts), arg0, arg1, arg2, arg3)
+}
@@ -6,6 +6,7 @@ package mocks
 
 import (
 	context "context"
+	io "io"
 	reflect "reflect"
 
 	domain "github.com/Notifuse/notifuse/internal/domain"
@@ -36,17 +37,32 @@ func (m *MockContactService) EXPECT() *MockContactServiceMockRecorder {
 }
 
 // BatchImportContacts mocks base method.
-func (m *MockContactService) BatchImportContacts(arg0 context.Context, arg1 string, arg2 []*domain.Contact, arg3 []string) *domain.BatchImportContactsResponse {
+func (m *MockContactService) BatchImportContacts(arg0 context.Context, arg1 string, arg2 []*domain.Contact, arg3 []string, arg4 domain.ImportConflictPolicy) *domain.BatchImportContactsResponse {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "BatchImportContacts", arg0, arg1, arg2, arg3)
+	ret := m.ctrl.Call(m, "BatchImportContacts", arg0, arg1, arg2, arg3, arg4)
 	ret0, _ := ret[0].(*domain.BatchImportContactsResponse)
 	return ret0
 }
 
 // BatchImportContacts indicates an expected call of BatchImportContacts.
-func (mr *MockContactServiceMockRecorder) BatchImportContacts(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+func (mr *MockContactServiceMockRecorder) BatchImportContacts(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchImportContacts", reflect.TypeOf((*MockContactService)(nil).BatchImportContacts), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BatchImportContacts", reflect.TypeOf((*MockContactService)(nil).BatchImportContacts), arg0, arg1, arg2, arg3, arg4)
+}
+
+// BulkGetContacts mocks base method.
+func (m *MockContactService) BulkGetContacts(arg0 context.Context, arg1 *domain.BulkGetContactsRequest) (*domain.BulkGetContactsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkGetContacts", arg0, arg1)
+	ret0, _ := ret[0].(*domain.BulkGetContactsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BulkGetContacts indicates an expected call of BulkGetContacts.
+func (mr *MockContactServiceMockRecorder) BulkGetContacts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkGetContacts", reflect.TypeOf((*MockContactService)(nil).BulkGetContacts), arg0, arg1)
 }
 
 // CountContacts mocks base method.
@@ -78,6 +94,21 @@ func (mr *MockContactServiceMockRecorder) DeleteContact(arg0, arg1, arg2 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteContact", reflect.TypeOf((*MockContactService)(nil).DeleteContact), arg0, arg1, arg2)
 }
 
+// GetAudienceCounts mocks base method.
+func (m *MockContactService) GetAudienceCounts(arg0 context.Context, arg1 string) (*domain.AudienceCounts, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAudienceCounts", arg0, arg1)
+	ret0, _ := ret[0].(*domain.AudienceCounts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAudienceCounts indicates an expected call of GetAudienceCounts.
+func (mr *MockContactServiceMockRecorder) GetAudienceCounts(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAudienceCounts", reflect.TypeOf((*MockContactService)(nil).GetAudienceCounts), arg0, arg1)
+}
+
 // GetContactByEmail mocks base method.
 func (m *MockContactService) GetContactByEmail(arg0 context.Context, arg1, arg2 string) (*domain.Contact, error) {
 	m.ctrl.T.Helper()
@@ -123,6 +154,21 @@ func (mr *MockContactServiceMockRecorder) GetContacts(arg0, arg1 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContacts", reflect.TypeOf((*MockContactService)(nil).GetContacts), arg0, arg1)
 }
 
+// ImportContactLines mocks base method.
+func (m *MockContactService) ImportContactLines(arg0 context.Context, arg1 string, arg2 io.Reader, arg3 domain.MergeStrategy, arg4 bool) (*domain.ImportContactLinesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportContactLines", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(*domain.ImportContactLinesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ImportContactLines indicates an expected call of ImportContactLines.
+func (mr *MockContactServiceMockRecorder) ImportContactLines(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportContactLines", reflect.TypeOf((*MockContactService)(nil).ImportContactLines), arg0, arg1, arg2, arg3, arg4)
+}
+
 // UpsertContact mocks base method.
 func (m *MockContactService) UpsertContact(arg0 context.Context, arg1 string, arg2 *domain.Contact) domain.UpsertContactOperation {
 	m.ctrl.T.Helper()
@@ -136,3 +182,17 @@ func (mr *MockContactServiceMockRecorder) UpsertContact(arg0, arg1, arg2 interfa
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertContact", reflect.TypeOf((*MockContactService)(nil).UpsertContact), arg0, arg1, arg2)
 }
+
+// ValidateImportContacts mocks base method.
+func (m *MockContactService) ValidateImportContacts(arg0 context.Context, arg1 string, arg2 []*domain.Contact, arg3 []string) *domain.BatchImportContactsResponse {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ValidateImportContacts", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*domain.BatchImportContactsResponse)
+	return ret0
+}
+
+// ValidateImportContacts indicates an expected call of ValidateImportContacts.
+func (mr *MockContactServiceMockRecorder) ValidateImportContacts(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateImportContacts", reflect.TypeOf((*MockContactService)(nil).ValidateImportContacts), arg0, arg1, arg2, arg3)
+}
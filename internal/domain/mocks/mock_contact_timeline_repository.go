@@ -7,6 +7,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	domain "github.com/Notifuse/notifuse/internal/domain"
 	gomock "github.com/golang/mock/gomock"
@@ -78,3 +79,18 @@ func (mr *MockContactTimelineRepositoryMockRecorder) List(arg0, arg1, arg2, arg3
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockContactTimelineRepository)(nil).List), arg0, arg1, arg2, arg3, arg4)
 }
+
+// ListSince mocks base method.
+func (m *MockContactTimelineRepository) ListSince(arg0 context.Context, arg1, arg2 string, arg3 time.Time, arg4 int) ([]*domain.ContactTimelineEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListSince", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].([]*domain.ContactTimelineEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListSince indicates an expected call of ListSince.
+func (mr *MockContactTimelineRepositoryMockRecorder) ListSince(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListSince", reflect.TypeOf((*MockContactTimelineRepository)(nil).ListSince), arg0, arg1, arg2, arg3, arg4)
+}
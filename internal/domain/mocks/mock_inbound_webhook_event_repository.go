@@ -7,6 +7,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	domain "github.com/Notifuse/notifuse/internal/domain"
 	gomock "github.com/golang/mock/gomock"
@@ -49,6 +50,21 @@ func (mr *MockInboundWebhookEventRepositoryMockRecorder) DeleteForEmail(arg0, ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteForEmail", reflect.TypeOf((*MockInboundWebhookEventRepository)(nil).DeleteForEmail), arg0, arg1, arg2)
 }
 
+// DeletePayloadsBefore mocks base method.
+func (m *MockInboundWebhookEventRepository) DeletePayloadsBefore(arg0 context.Context, arg1 string, arg2 time.Time) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePayloadsBefore", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeletePayloadsBefore indicates an expected call of DeletePayloadsBefore.
+func (mr *MockInboundWebhookEventRepositoryMockRecorder) DeletePayloadsBefore(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePayloadsBefore", reflect.TypeOf((*MockInboundWebhookEventRepository)(nil).DeletePayloadsBefore), arg0, arg1, arg2)
+}
+
 // ListEvents mocks base method.
 func (m *MockInboundWebhookEventRepository) ListEvents(arg0 context.Context, arg1 string, arg2 domain.InboundWebhookEventListParams) (*domain.InboundWebhookEventListResult, error) {
 	m.ctrl.T.Helper()
@@ -64,6 +80,35 @@ func (mr *MockInboundWebhookEventRepositoryMockRecorder) ListEvents(arg0, arg1,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListEvents", reflect.TypeOf((*MockInboundWebhookEventRepository)(nil).ListEvents), arg0, arg1, arg2)
 }
 
+// ListPayloads mocks base method.
+func (m *MockInboundWebhookEventRepository) ListPayloads(arg0 context.Context, arg1 string, arg2 domain.InboundWebhookPayloadListParams) ([]*domain.InboundWebhookPayload, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPayloads", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*domain.InboundWebhookPayload)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPayloads indicates an expected call of ListPayloads.
+func (mr *MockInboundWebhookEventRepositoryMockRecorder) ListPayloads(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPayloads", reflect.TypeOf((*MockInboundWebhookEventRepository)(nil).ListPayloads), arg0, arg1, arg2)
+}
+
+// MarkPayloadProcessed mocks base method.
+func (m *MockInboundWebhookEventRepository) MarkPayloadProcessed(arg0 context.Context, arg1, arg2 string, arg3 time.Time, arg4 *string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkPayloadProcessed", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkPayloadProcessed indicates an expected call of MarkPayloadProcessed.
+func (mr *MockInboundWebhookEventRepositoryMockRecorder) MarkPayloadProcessed(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkPayloadProcessed", reflect.TypeOf((*MockInboundWebhookEventRepository)(nil).MarkPayloadProcessed), arg0, arg1, arg2, arg3, arg4)
+}
+
 // StoreEvents mocks base method.
 func (m *MockInboundWebhookEventRepository) StoreEvents(arg0 context.Context, arg1 string, arg2 []*domain.InboundWebhookEvent) error {
 	m.ctrl.T.Helper()
@@ -77,3 +122,17 @@ func (mr *MockInboundWebhookEventRepositoryMockRecorder) StoreEvents(arg0, arg1,
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StoreEvents", reflect.TypeOf((*MockInboundWebhookEventRepository)(nil).StoreEvents), arg0, arg1, arg2)
 }
+
+// StorePayload mocks base method.
+func (m *MockInboundWebhookEventRepository) StorePayload(arg0 context.Context, arg1 string, arg2 *domain.InboundWebhookPayload) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StorePayload", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// StorePayload indicates an expected call of StorePayload.
+func (mr *MockInboundWebhookEventRepositoryMockRecorder) StorePayload(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StorePayload", reflect.TypeOf((*MockInboundWebhookEventRepository)(nil).StorePayload), arg0, arg1, arg2)
+}
@@ -6,6 +6,7 @@ package mocks
 
 import (
 	context "context"
+	http "net/http"
 	reflect "reflect"
 
 	domain "github.com/Notifuse/notifuse/internal/domain"
@@ -35,6 +36,21 @@ func (m *MockInboundWebhookEventServiceInterface) EXPECT() *MockInboundWebhookEv
 	return m.recorder
 }
 
+// GetWebhookHealth mocks base method.
+func (m *MockInboundWebhookEventServiceInterface) GetWebhookHealth(arg0 context.Context, arg1 string) ([]*domain.ProviderWebhookHealth, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWebhookHealth", arg0, arg1)
+	ret0, _ := ret[0].([]*domain.ProviderWebhookHealth)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWebhookHealth indicates an expected call of GetWebhookHealth.
+func (mr *MockInboundWebhookEventServiceInterfaceMockRecorder) GetWebhookHealth(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWebhookHealth", reflect.TypeOf((*MockInboundWebhookEventServiceInterface)(nil).GetWebhookHealth), arg0, arg1)
+}
+
 // ListEvents mocks base method.
 func (m *MockInboundWebhookEventServiceInterface) ListEvents(arg0 context.Context, arg1 string, arg2 domain.InboundWebhookEventListParams) (*domain.InboundWebhookEventListResult, error) {
 	m.ctrl.T.Helper()
@@ -63,3 +79,32 @@ func (mr *MockInboundWebhookEventServiceInterfaceMockRecorder) ProcessWebhook(ar
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ProcessWebhook", reflect.TypeOf((*MockInboundWebhookEventServiceInterface)(nil).ProcessWebhook), arg0, arg1, arg2, arg3)
 }
+
+// ReprocessPayloads mocks base method.
+func (m *MockInboundWebhookEventServiceInterface) ReprocessPayloads(arg0 context.Context, arg1 domain.ReprocessInboundWebhooksRequest) (*domain.ReprocessInboundWebhooksResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReprocessPayloads", arg0, arg1)
+	ret0, _ := ret[0].(*domain.ReprocessInboundWebhooksResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReprocessPayloads indicates an expected call of ReprocessPayloads.
+func (mr *MockInboundWebhookEventServiceInterfaceMockRecorder) ReprocessPayloads(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReprocessPayloads", reflect.TypeOf((*MockInboundWebhookEventServiceInterface)(nil).ReprocessPayloads), arg0, arg1)
+}
+
+// VerifyWebhook mocks base method.
+func (m *MockInboundWebhookEventServiceInterface) VerifyWebhook(arg0 context.Context, arg1, arg2 string, arg3 http.Header, arg4 []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyWebhook", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyWebhook indicates an expected call of VerifyWebhook.
+func (mr *MockInboundWebhookEventServiceInterfaceMockRecorder) VerifyWebhook(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyWebhook", reflect.TypeOf((*MockInboundWebhookEventServiceInterface)(nil).VerifyWebhook), arg0, arg1, arg2, arg3, arg4)
+}
@@ -7,6 +7,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	domain "github.com/Notifuse/notifuse/internal/domain"
 	gomock "github.com/golang/mock/gomock"
@@ -63,6 +64,21 @@ func (mr *MockListRepositoryMockRecorder) DeleteList(arg0, arg1, arg2 interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteList", reflect.TypeOf((*MockListRepository)(nil).DeleteList), arg0, arg1, arg2)
 }
 
+// GetGrowthStats mocks base method.
+func (m *MockListRepository) GetGrowthStats(arg0 context.Context, arg1, arg2, arg3 string, arg4, arg5 time.Time) ([]*domain.ListGrowthDay, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetGrowthStats", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].([]*domain.ListGrowthDay)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetGrowthStats indicates an expected call of GetGrowthStats.
+func (mr *MockListRepositoryMockRecorder) GetGrowthStats(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetGrowthStats", reflect.TypeOf((*MockListRepository)(nil).GetGrowthStats), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
 // GetListByID mocks base method.
 func (m *MockListRepository) GetListByID(arg0 context.Context, arg1, arg2 string) (*domain.List, error) {
 	m.ctrl.T.Helper()
@@ -7,6 +7,7 @@ package mocks
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	domain "github.com/Notifuse/notifuse/internal/domain"
 	gomock "github.com/golang/mock/gomock"
@@ -78,6 +79,21 @@ func (mr *MockListServiceMockRecorder) GetListByID(arg0, arg1, arg2 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListByID", reflect.TypeOf((*MockListService)(nil).GetListByID), arg0, arg1, arg2)
 }
 
+// GetListGrowthStats mocks base method.
+func (m *MockListService) GetListGrowthStats(arg0 context.Context, arg1, arg2, arg3 string, arg4, arg5 time.Time) ([]*domain.ListGrowthDay, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetListGrowthStats", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].([]*domain.ListGrowthDay)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetListGrowthStats indicates an expected call of GetListGrowthStats.
+func (mr *MockListServiceMockRecorder) GetListGrowthStats(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetListGrowthStats", reflect.TypeOf((*MockListService)(nil).GetListGrowthStats), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
 // GetListStats mocks base method.
 func (m *MockListService) GetListStats(arg0 context.Context, arg1, arg2 string) (*domain.ListStats, error) {
 	m.ctrl.T.Helper()
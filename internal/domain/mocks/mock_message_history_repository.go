@@ -6,6 +6,7 @@ package mocks
 
 import (
 	context "context"
+	io "io"
 	reflect "reflect"
 	time "time"
 
@@ -36,6 +37,36 @@ func (m *MockMessageHistoryRepository) EXPECT() *MockMessageHistoryRepositoryMoc
 	return m.recorder
 }
 
+// ApplyStatusesLocked mocks base method.
+func (m *MockMessageHistoryRepository) ApplyStatusesLocked(arg0 context.Context, arg1 string, arg2 []domain.MessageEventUpdate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyStatusesLocked", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ApplyStatusesLocked indicates an expected call of ApplyStatusesLocked.
+func (mr *MockMessageHistoryRepositoryMockRecorder) ApplyStatusesLocked(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyStatusesLocked", reflect.TypeOf((*MockMessageHistoryRepository)(nil).ApplyStatusesLocked), arg0, arg1, arg2)
+}
+
+// CountSentAndComplaintsSince mocks base method.
+func (m *MockMessageHistoryRepository) CountSentAndComplaintsSince(arg0 context.Context, arg1 string, arg2 time.Time) (int, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountSentAndComplaintsSince", arg0, arg1, arg2)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CountSentAndComplaintsSince indicates an expected call of CountSentAndComplaintsSince.
+func (mr *MockMessageHistoryRepositoryMockRecorder) CountSentAndComplaintsSince(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountSentAndComplaintsSince", reflect.TypeOf((*MockMessageHistoryRepository)(nil).CountSentAndComplaintsSince), arg0, arg1, arg2)
+}
+
 // Create mocks base method.
 func (m *MockMessageHistoryRepository) Create(arg0 context.Context, arg1, arg2 string, arg3 *domain.MessageHistory) error {
 	m.ctrl.T.Helper()
@@ -50,6 +81,20 @@ func (mr *MockMessageHistoryRepositoryMockRecorder) Create(arg0, arg1, arg2, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockMessageHistoryRepository)(nil).Create), arg0, arg1, arg2, arg3)
 }
 
+// CreateWithIdempotencyKey mocks base method.
+func (m *MockMessageHistoryRepository) CreateWithIdempotencyKey(arg0 context.Context, arg1, arg2 string, arg3 *domain.MessageHistory, arg4 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWithIdempotencyKey", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateWithIdempotencyKey indicates an expected call of CreateWithIdempotencyKey.
+func (mr *MockMessageHistoryRepositoryMockRecorder) CreateWithIdempotencyKey(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWithIdempotencyKey", reflect.TypeOf((*MockMessageHistoryRepository)(nil).CreateWithIdempotencyKey), arg0, arg1, arg2, arg3, arg4)
+}
+
 // DeleteForEmail mocks base method.
 func (m *MockMessageHistoryRepository) DeleteForEmail(arg0 context.Context, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
@@ -64,6 +109,20 @@ func (mr *MockMessageHistoryRepositoryMockRecorder) DeleteForEmail(arg0, arg1, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteForEmail", reflect.TypeOf((*MockMessageHistoryRepository)(nil).DeleteForEmail), arg0, arg1, arg2)
 }
 
+// ExportMessages mocks base method.
+func (m *MockMessageHistoryRepository) ExportMessages(arg0 context.Context, arg1, arg2 string, arg3 domain.MessageListParams, arg4 io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportMessages", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportMessages indicates an expected call of ExportMessages.
+func (mr *MockMessageHistoryRepositoryMockRecorder) ExportMessages(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportMessages", reflect.TypeOf((*MockMessageHistoryRepository)(nil).ExportMessages), arg0, arg1, arg2, arg3, arg4)
+}
+
 // Get mocks base method.
 func (m *MockMessageHistoryRepository) Get(arg0 context.Context, arg1, arg2, arg3 string) (*domain.MessageHistory, error) {
 	m.ctrl.T.Helper()
@@ -94,6 +153,21 @@ func (mr *MockMessageHistoryRepositoryMockRecorder) GetBroadcastStats(arg0, arg1
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBroadcastStats", reflect.TypeOf((*MockMessageHistoryRepository)(nil).GetBroadcastStats), arg0, arg1, arg2)
 }
 
+// GetBroadcastStatsByDay mocks base method.
+func (m *MockMessageHistoryRepository) GetBroadcastStatsByDay(arg0 context.Context, arg1, arg2, arg3 string) ([]*domain.MessageHistoryDailyStatusSum, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBroadcastStatsByDay", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*domain.MessageHistoryDailyStatusSum)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBroadcastStatsByDay indicates an expected call of GetBroadcastStatsByDay.
+func (mr *MockMessageHistoryRepositoryMockRecorder) GetBroadcastStatsByDay(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBroadcastStatsByDay", reflect.TypeOf((*MockMessageHistoryRepository)(nil).GetBroadcastStatsByDay), arg0, arg1, arg2, arg3)
+}
+
 // GetBroadcastVariationStats mocks base method.
 func (m *MockMessageHistoryRepository) GetBroadcastVariationStats(arg0 context.Context, arg1, arg2, arg3 string) (*domain.MessageHistoryStatusSum, error) {
 	m.ctrl.T.Helper()
@@ -109,6 +183,21 @@ func (mr *MockMessageHistoryRepositoryMockRecorder) GetBroadcastVariationStats(a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBroadcastVariationStats", reflect.TypeOf((*MockMessageHistoryRepository)(nil).GetBroadcastVariationStats), arg0, arg1, arg2, arg3)
 }
 
+// GetBroadcastVariationStatsAll mocks base method.
+func (m *MockMessageHistoryRepository) GetBroadcastVariationStatsAll(arg0 context.Context, arg1, arg2 string, arg3 []string) (map[string]*domain.MessageHistoryStatusSum, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBroadcastVariationStatsAll", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(map[string]*domain.MessageHistoryStatusSum)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBroadcastVariationStatsAll indicates an expected call of GetBroadcastVariationStatsAll.
+func (mr *MockMessageHistoryRepositoryMockRecorder) GetBroadcastVariationStatsAll(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBroadcastVariationStatsAll", reflect.TypeOf((*MockMessageHistoryRepository)(nil).GetBroadcastVariationStatsAll), arg0, arg1, arg2, arg3)
+}
+
 // GetByBroadcast mocks base method.
 func (m *MockMessageHistoryRepository) GetByBroadcast(arg0 context.Context, arg1, arg2, arg3 string, arg4, arg5 int) ([]*domain.MessageHistory, int, error) {
 	m.ctrl.T.Helper()
@@ -156,6 +245,82 @@ func (mr *MockMessageHistoryRepositoryMockRecorder) GetByExternalID(arg0, arg1,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByExternalID", reflect.TypeOf((*MockMessageHistoryRepository)(nil).GetByExternalID), arg0, arg1, arg2, arg3)
 }
 
+// GetByExternalIDs mocks base method.
+func (m *MockMessageHistoryRepository) GetByExternalIDs(arg0 context.Context, arg1, arg2 string, arg3 []string) (map[string]*domain.MessageHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByExternalIDs", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(map[string]*domain.MessageHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByExternalIDs indicates an expected call of GetByExternalIDs.
+func (mr *MockMessageHistoryRepositoryMockRecorder) GetByExternalIDs(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByExternalIDs", reflect.TypeOf((*MockMessageHistoryRepository)(nil).GetByExternalIDs), arg0, arg1, arg2, arg3)
+}
+
+// GetByIdempotencyKey mocks base method.
+func (m *MockMessageHistoryRepository) GetByIdempotencyKey(arg0 context.Context, arg1, arg2, arg3 string) (*domain.MessageHistory, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByIdempotencyKey", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(*domain.MessageHistory)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByIdempotencyKey indicates an expected call of GetByIdempotencyKey.
+func (mr *MockMessageHistoryRepositoryMockRecorder) GetByIdempotencyKey(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByIdempotencyKey", reflect.TypeOf((*MockMessageHistoryRepository)(nil).GetByIdempotencyKey), arg0, arg1, arg2, arg3)
+}
+
+// GetContactTimeline mocks base method.
+func (m *MockMessageHistoryRepository) GetContactTimeline(arg0 context.Context, arg1, arg2, arg3 string, arg4 int, arg5 string) ([]*domain.TimelineEntry, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetContactTimeline", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].([]*domain.TimelineEntry)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetContactTimeline indicates an expected call of GetContactTimeline.
+func (mr *MockMessageHistoryRepositoryMockRecorder) GetContactTimeline(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetContactTimeline", reflect.TypeOf((*MockMessageHistoryRepository)(nil).GetContactTimeline), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
+// GetEngagementScores mocks base method.
+func (m *MockMessageHistoryRepository) GetEngagementScores(arg0 context.Context, arg1 string, arg2 []string) (map[string]float64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEngagementScores", arg0, arg1, arg2)
+	ret0, _ := ret[0].(map[string]float64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEngagementScores indicates an expected call of GetEngagementScores.
+func (mr *MockMessageHistoryRepositoryMockRecorder) GetEngagementScores(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEngagementScores", reflect.TypeOf((*MockMessageHistoryRepository)(nil).GetEngagementScores), arg0, arg1, arg2)
+}
+
+// GetSentEmailsForBroadcast mocks base method.
+func (m *MockMessageHistoryRepository) GetSentEmailsForBroadcast(arg0 context.Context, arg1, arg2 string, arg3 []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSentEmailsForBroadcast", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSentEmailsForBroadcast indicates an expected call of GetSentEmailsForBroadcast.
+func (mr *MockMessageHistoryRepositoryMockRecorder) GetSentEmailsForBroadcast(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSentEmailsForBroadcast", reflect.TypeOf((*MockMessageHistoryRepository)(nil).GetSentEmailsForBroadcast), arg0, arg1, arg2, arg3)
+}
+
 // ListMessages mocks base method.
 func (m *MockMessageHistoryRepository) ListMessages(arg0 context.Context, arg1, arg2 string, arg3 domain.MessageListParams) ([]*domain.MessageHistory, string, error) {
 	m.ctrl.T.Helper()
@@ -172,6 +337,35 @@ func (mr *MockMessageHistoryRepositoryMockRecorder) ListMessages(arg0, arg1, arg
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListMessages", reflect.TypeOf((*MockMessageHistoryRepository)(nil).ListMessages), arg0, arg1, arg2, arg3)
 }
 
+// PruneOlderThan mocks base method.
+func (m *MockMessageHistoryRepository) PruneOlderThan(arg0 context.Context, arg1 string, arg2 time.Time, arg3 []domain.MessageEvent, arg4 int) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PruneOlderThan", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PruneOlderThan indicates an expected call of PruneOlderThan.
+func (mr *MockMessageHistoryRepositoryMockRecorder) PruneOlderThan(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PruneOlderThan", reflect.TypeOf((*MockMessageHistoryRepository)(nil).PruneOlderThan), arg0, arg1, arg2, arg3, arg4)
+}
+
+// ReleaseIdempotencyKey mocks base method.
+func (m *MockMessageHistoryRepository) ReleaseIdempotencyKey(arg0 context.Context, arg1, arg2 string, arg3 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReleaseIdempotencyKey", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReleaseIdempotencyKey indicates an expected call of ReleaseIdempotencyKey.
+func (mr *MockMessageHistoryRepositoryMockRecorder) ReleaseIdempotencyKey(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseIdempotencyKey", reflect.TypeOf((*MockMessageHistoryRepository)(nil).ReleaseIdempotencyKey), arg0, arg1, arg2, arg3)
+}
+
 // SetClicked mocks base method.
 func (m *MockMessageHistoryRepository) SetClicked(arg0 context.Context, arg1, arg2 string, arg3 time.Time) error {
 	m.ctrl.T.Helper()
@@ -6,6 +6,7 @@ package mocks
 
 import (
 	context "context"
+	io "io"
 	reflect "reflect"
 
 	domain "github.com/Notifuse/notifuse/internal/domain"
@@ -35,6 +36,20 @@ func (m *MockMessageHistoryService) EXPECT() *MockMessageHistoryServiceMockRecor
 	return m.recorder
 }
 
+// ExportMessages mocks base method.
+func (m *MockMessageHistoryService) ExportMessages(arg0 context.Context, arg1 string, arg2 domain.MessageListParams, arg3 io.Writer) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExportMessages", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ExportMessages indicates an expected call of ExportMessages.
+func (mr *MockMessageHistoryServiceMockRecorder) ExportMessages(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExportMessages", reflect.TypeOf((*MockMessageHistoryService)(nil).ExportMessages), arg0, arg1, arg2, arg3)
+}
+
 // GetBroadcastStats mocks base method.
 func (m *MockMessageHistoryService) GetBroadcastStats(arg0 context.Context, arg1, arg2 string) (*domain.MessageHistoryStatusSum, error) {
 	m.ctrl.T.Helper()
@@ -50,6 +65,21 @@ func (mr *MockMessageHistoryServiceMockRecorder) GetBroadcastStats(arg0, arg1, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBroadcastStats", reflect.TypeOf((*MockMessageHistoryService)(nil).GetBroadcastStats), arg0, arg1, arg2)
 }
 
+// GetBroadcastStatsByDay mocks base method.
+func (m *MockMessageHistoryService) GetBroadcastStatsByDay(arg0 context.Context, arg1, arg2, arg3 string) ([]*domain.MessageHistoryDailyStatusSum, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBroadcastStatsByDay", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]*domain.MessageHistoryDailyStatusSum)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBroadcastStatsByDay indicates an expected call of GetBroadcastStatsByDay.
+func (mr *MockMessageHistoryServiceMockRecorder) GetBroadcastStatsByDay(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBroadcastStatsByDay", reflect.TypeOf((*MockMessageHistoryService)(nil).GetBroadcastStatsByDay), arg0, arg1, arg2, arg3)
+}
+
 // GetBroadcastVariationStats mocks base method.
 func (m *MockMessageHistoryService) GetBroadcastVariationStats(arg0 context.Context, arg1, arg2, arg3 string) (*domain.MessageHistoryStatusSum, error) {
 	m.ctrl.T.Helper()
@@ -37,17 +37,17 @@ func (m *MockSegmentRepository) EXPECT() *MockSegmentRepositoryMockRecorder {
 }
 
 // AddContactToSegment mocks base method.
-func (m *MockSegmentRepository) AddContactToSegment(arg0 context.Context, arg1, arg2, arg3 string, arg4 int64) error {
+func (m *MockSegmentRepository) AddContactToSegment(arg0 context.Context, arg1, arg2, arg3 string, arg4 int64, arg5 time.Time) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "AddContactToSegment", arg0, arg1, arg2, arg3, arg4)
+	ret := m.ctrl.Call(m, "AddContactToSegment", arg0, arg1, arg2, arg3, arg4, arg5)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // AddContactToSegment indicates an expected call of AddContactToSegment.
-func (mr *MockSegmentRepositoryMockRecorder) AddContactToSegment(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+func (mr *MockSegmentRepositoryMockRecorder) AddContactToSegment(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddContactToSegment", reflect.TypeOf((*MockSegmentRepository)(nil).AddContactToSegment), arg0, arg1, arg2, arg3, arg4)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddContactToSegment", reflect.TypeOf((*MockSegmentRepository)(nil).AddContactToSegment), arg0, arg1, arg2, arg3, arg4, arg5)
 }
 
 // CreateSegment mocks base method.
@@ -153,6 +153,21 @@ func (mr *MockSegmentRepositoryMockRecorder) GetSegmentsDueForRecompute(arg0, ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSegmentsDueForRecompute", reflect.TypeOf((*MockSegmentRepository)(nil).GetSegmentsDueForRecompute), arg0, arg1, arg2)
 }
 
+// GetSegmentMembers mocks base method.
+func (m *MockSegmentRepository) GetSegmentMembers(arg0 context.Context, arg1, arg2 string, arg3 []string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSegmentMembers", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSegmentMembers indicates an expected call of GetSegmentMembers.
+func (mr *MockSegmentRepositoryMockRecorder) GetSegmentMembers(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSegmentMembers", reflect.TypeOf((*MockSegmentRepository)(nil).GetSegmentMembers), arg0, arg1, arg2, arg3)
+}
+
 // PreviewSegment mocks base method.
 func (m *MockSegmentRepository) PreviewSegment(arg0 context.Context, arg1, arg2 string, arg3 []interface{}, arg4 int) (int, error) {
 	m.ctrl.T.Helper()
@@ -169,17 +184,17 @@ func (mr *MockSegmentRepositoryMockRecorder) PreviewSegment(arg0, arg1, arg2, ar
 }
 
 // RemoveContactFromSegment mocks base method.
-func (m *MockSegmentRepository) RemoveContactFromSegment(arg0 context.Context, arg1, arg2, arg3 string) error {
+func (m *MockSegmentRepository) RemoveContactFromSegment(arg0 context.Context, arg1, arg2, arg3 string, arg4 int64, arg5 time.Time) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "RemoveContactFromSegment", arg0, arg1, arg2, arg3)
+	ret := m.ctrl.Call(m, "RemoveContactFromSegment", arg0, arg1, arg2, arg3, arg4, arg5)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // RemoveContactFromSegment indicates an expected call of RemoveContactFromSegment.
-func (mr *MockSegmentRepositoryMockRecorder) RemoveContactFromSegment(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+func (mr *MockSegmentRepositoryMockRecorder) RemoveContactFromSegment(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveContactFromSegment", reflect.TypeOf((*MockSegmentRepository)(nil).RemoveContactFromSegment), arg0, arg1, arg2, arg3)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveContactFromSegment", reflect.TypeOf((*MockSegmentRepository)(nil).RemoveContactFromSegment), arg0, arg1, arg2, arg3, arg4, arg5)
 }
 
 // RemoveOldMemberships mocks base method.
@@ -196,6 +211,20 @@ func (mr *MockSegmentRepositoryMockRecorder) RemoveOldMemberships(arg0, arg1, ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveOldMemberships", reflect.TypeOf((*MockSegmentRepository)(nil).RemoveOldMemberships), arg0, arg1, arg2, arg3)
 }
 
+// TouchSegmentMemberships mocks base method.
+func (m *MockSegmentRepository) TouchSegmentMemberships(arg0 context.Context, arg1, arg2 string, arg3 []string, arg4 int64, arg5 time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TouchSegmentMemberships", arg0, arg1, arg2, arg3, arg4, arg5)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TouchSegmentMemberships indicates an expected call of TouchSegmentMemberships.
+func (mr *MockSegmentRepositoryMockRecorder) TouchSegmentMemberships(arg0, arg1, arg2, arg3, arg4, arg5 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TouchSegmentMemberships", reflect.TypeOf((*MockSegmentRepository)(nil).TouchSegmentMemberships), arg0, arg1, arg2, arg3, arg4, arg5)
+}
+
 // UpdateRecomputeAfter mocks base method.
 func (m *MockSegmentRepository) UpdateRecomputeAfter(arg0 context.Context, arg1, arg2 string, arg3 *time.Time) error {
 	m.ctrl.T.Helper()
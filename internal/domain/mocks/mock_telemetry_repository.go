@@ -126,6 +126,21 @@ func (mr *MockTelemetryRepositoryMockRecorder) CountSegments(arg0, arg1 interfac
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountSegments", reflect.TypeOf((*MockTelemetryRepository)(nil).CountSegments), arg0, arg1)
 }
 
+// CountTemplateBlocks mocks base method.
+func (m *MockTelemetryRepository) CountTemplateBlocks(arg0 context.Context, arg1 *sql.DB) (map[string]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountTemplateBlocks", arg0, arg1)
+	ret0, _ := ret[0].(map[string]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountTemplateBlocks indicates an expected call of CountTemplateBlocks.
+func (mr *MockTelemetryRepositoryMockRecorder) CountTemplateBlocks(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountTemplateBlocks", reflect.TypeOf((*MockTelemetryRepository)(nil).CountTemplateBlocks), arg0, arg1)
+}
+
 // CountTransactional mocks base method.
 func (m *MockTelemetryRepository) CountTransactional(arg0 context.Context, arg1 *sql.DB) (int, error) {
 	m.ctrl.T.Helper()
@@ -171,6 +186,21 @@ func (mr *MockTelemetryRepositoryMockRecorder) GetLastMessageAt(arg0, arg1 inter
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLastMessageAt", reflect.TypeOf((*MockTelemetryRepository)(nil).GetLastMessageAt), arg0, arg1)
 }
 
+// GetSendMetrics mocks base method.
+func (m *MockTelemetryRepository) GetSendMetrics(arg0 context.Context, arg1 *sql.DB) (*domain.TelemetrySendMetrics, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSendMetrics", arg0, arg1)
+	ret0, _ := ret[0].(*domain.TelemetrySendMetrics)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSendMetrics indicates an expected call of GetSendMetrics.
+func (mr *MockTelemetryRepositoryMockRecorder) GetSendMetrics(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSendMetrics", reflect.TypeOf((*MockTelemetryRepository)(nil).GetSendMetrics), arg0, arg1)
+}
+
 // GetWorkspaceMetrics mocks base method.
 func (m *MockTelemetryRepository) GetWorkspaceMetrics(arg0 context.Context, arg1 string) (*domain.TelemetryMetrics, error) {
 	m.ctrl.T.Helper()
@@ -79,6 +79,21 @@ func (mr *MockUserServiceInterfaceMockRecorder) Logout(arg0, arg1 interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockUserServiceInterface)(nil).Logout), arg0, arg1)
 }
 
+// RefreshToken mocks base method.
+func (m *MockUserServiceInterface) RefreshToken(arg0 context.Context) (*domain.AuthResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshToken", arg0)
+	ret0, _ := ret[0].(*domain.AuthResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefreshToken indicates an expected call of RefreshToken.
+func (mr *MockUserServiceInterfaceMockRecorder) RefreshToken(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshToken", reflect.TypeOf((*MockUserServiceInterface)(nil).RefreshToken), arg0)
+}
+
 // RootSignin mocks base method.
 func (m *MockUserServiceInterface) RootSignin(arg0 context.Context, arg1 domain.RootSigninInput) (*domain.AuthResponse, error) {
 	m.ctrl.T.Helper()
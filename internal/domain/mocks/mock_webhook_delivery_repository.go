@@ -80,6 +80,21 @@ func (mr *MockWebhookDeliveryRepositoryMockRecorder) GetPendingForWorkspace(arg0
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPendingForWorkspace", reflect.TypeOf((*MockWebhookDeliveryRepository)(nil).GetPendingForWorkspace), arg0, arg1, arg2)
 }
 
+// HasPendingContactActivityBefore mocks base method.
+func (m *MockWebhookDeliveryRepository) HasPendingContactActivityBefore(arg0 context.Context, arg1, arg2, arg3, arg4 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasPendingContactActivityBefore", arg0, arg1, arg2, arg3, arg4)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// HasPendingContactActivityBefore indicates an expected call of HasPendingContactActivityBefore.
+func (mr *MockWebhookDeliveryRepositoryMockRecorder) HasPendingContactActivityBefore(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasPendingContactActivityBefore", reflect.TypeOf((*MockWebhookDeliveryRepository)(nil).HasPendingContactActivityBefore), arg0, arg1, arg2, arg3, arg4)
+}
+
 // ListAll mocks base method.
 func (m *MockWebhookDeliveryRepository) ListAll(arg0 context.Context, arg1 string, arg2 *string, arg3, arg4 int) ([]*domain.WebhookDelivery, int, error) {
 	m.ctrl.T.Helper()
@@ -108,6 +108,20 @@ func (mr *MockWebhookSubscriptionRepositoryMockRecorder) Update(arg0, arg1, arg2
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockWebhookSubscriptionRepository)(nil).Update), arg0, arg1, arg2)
 }
 
+// UpdateContactActivityCheckpoint mocks base method.
+func (m *MockWebhookSubscriptionRepository) UpdateContactActivityCheckpoint(arg0 context.Context, arg1, arg2, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateContactActivityCheckpoint", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateContactActivityCheckpoint indicates an expected call of UpdateContactActivityCheckpoint.
+func (mr *MockWebhookSubscriptionRepositoryMockRecorder) UpdateContactActivityCheckpoint(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateContactActivityCheckpoint", reflect.TypeOf((*MockWebhookSubscriptionRepository)(nil).UpdateContactActivityCheckpoint), arg0, arg1, arg2, arg3)
+}
+
 // UpdateLastDeliveryAt mocks base method.
 func (m *MockWebhookSubscriptionRepository) UpdateLastDeliveryAt(arg0 context.Context, arg1, arg2 string, arg3 time.Time) error {
 	m.ctrl.T.Helper()
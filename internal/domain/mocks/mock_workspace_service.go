@@ -64,6 +64,21 @@ func (mr *MockWorkspaceServiceInterfaceMockRecorder) AddUserToWorkspace(arg0, ar
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddUserToWorkspace", reflect.TypeOf((*MockWorkspaceServiceInterface)(nil).AddUserToWorkspace), arg0, arg1, arg2, arg3, arg4)
 }
 
+// ClearSendingBlock mocks base method.
+func (m *MockWorkspaceServiceInterface) ClearSendingBlock(arg0 context.Context, arg1 string) (*domain.Workspace, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClearSendingBlock", arg0, arg1)
+	ret0, _ := ret[0].(*domain.Workspace)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClearSendingBlock indicates an expected call of ClearSendingBlock.
+func (mr *MockWorkspaceServiceInterfaceMockRecorder) ClearSendingBlock(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClearSendingBlock", reflect.TypeOf((*MockWorkspaceServiceInterface)(nil).ClearSendingBlock), arg0, arg1)
+}
+
 // CreateAPIKey mocks base method.
 func (m *MockWorkspaceServiceInterface) CreateAPIKey(arg0 context.Context, arg1, arg2 string) (string, string, error) {
 	m.ctrl.T.Helper()
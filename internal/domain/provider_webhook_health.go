@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+//go:generate mockgen -destination mocks/mock_provider_webhook_health_repository.go -package mocks github.com/Notifuse/notifuse/internal/domain ProviderWebhookHealthRepository
+
+// ProviderWebhookHealth tracks whether the email provider of an integration still sends webhooks
+// for the emails sent through it
+type ProviderWebhookHealth struct {
+	IntegrationID string     `json:"integration_id"`
+	LastSentAt    *time.Time `json:"last_sent_at,omitempty"`
+	LastEventAt   *time.Time `json:"last_event_at,omitempty"`
+	PendingSince  *time.Time `json:"pending_since,omitempty"` // First send not followed by any webhook event yet
+	AlertedAt     *time.Time `json:"alerted_at,omitempty"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+	Stale         bool       `json:"stale"` // Computed: no event within the expected window despite sends
+}
+
+// IsStale reports whether emails were sent through the integration for longer than the window
+// without any webhook event received since
+func (h *ProviderWebhookHealth) IsStale(now time.Time, window time.Duration) bool {
+	return h.PendingSince != nil && now.Sub(*h.PendingSince) > window
+}
+
+// NeedsAlert reports whether the integration is stale and no alert was raised yet for the
+// current period without events
+func (h *ProviderWebhookHealth) NeedsAlert(now time.Time, window time.Duration) bool {
+	if !h.IsStale(now, window) {
+		return false
+	}
+	return h.AlertedAt == nil || h.AlertedAt.Before(*h.PendingSince)
+}
+
+// NewIntegrationWebhookStaleEvent builds the event published when an integration stops receiving webhooks
+func NewIntegrationWebhookStaleEvent(workspaceID string, integration *Integration, health *ProviderWebhookHealth, window time.Duration) EventPayload {
+	data := map[string]interface{}{
+		"integration_id":   health.IntegrationID,
+		"integration_name": integration.Name,
+		"provider":         string(integration.EmailProvider.Kind),
+		"window":           window.String(),
+	}
+	if health.PendingSince != nil {
+		data["pending_since"] = health.PendingSince.UTC().Format(time.RFC3339)
+	}
+	if health.LastSentAt != nil {
+		data["last_sent_at"] = health.LastSentAt.UTC().Format(time.RFC3339)
+	}
+	if health.LastEventAt != nil {
+		data["last_event_at"] = health.LastEventAt.UTC().Format(time.RFC3339)
+	}
+
+	return EventPayload{
+		Type:        EventIntegrationWebhookStale,
+		WorkspaceID: workspaceID,
+		EntityID:    health.IntegrationID,
+		Data:        data,
+	}
+}
+
+// ProviderWebhookHealthRepository stores the webhook health of the integrations of a workspace
+type ProviderWebhookHealthRepository interface {
+	// RecordSent records an email sent through the integration, starting a period without events if none is pending
+	RecordSent(ctx context.Context, workspaceID, integrationID string, sentAt time.Time) error
+
+	// RecordEvent records a webhook event received for the integration, ending the period without events
+	RecordEvent(ctx context.Context, workspaceID, integrationID string, receivedAt time.Time) error
+
+	// List retrieves the webhook health of every integration that sent emails or received events
+	List(ctx context.Context, workspaceID string) ([]*ProviderWebhookHealth, error)
+
+	// MarkAlerted records that an alert was raised for the current period without events
+	MarkAlerted(ctx context.Context, workspaceID, integrationID string, alertedAt time.Time) error
+}
+
+// ProviderWebhookHealthRecorder records the sends and webhook events of the integrations
+type ProviderWebhookHealthRecorder interface {
+	// RecordSent records an email sent through an integration of the given provider kind
+	RecordSent(ctx context.Context, workspaceID, integrationID string, providerKind EmailProviderKind)
+
+	// RecordEvent records a webhook event received for an integration
+	RecordEvent(ctx context.Context, workspaceID, integrationID string)
+}
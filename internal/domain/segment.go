@@ -428,15 +428,24 @@ type SegmentRepository interface {
 	// DeleteSegment deletes a segment
 	DeleteSegment(ctx context.Context, workspaceID string, id string) error
 
-	// AddContactToSegment adds a contact to a segment membership
-	AddContactToSegment(ctx context.Context, workspaceID string, email string, segmentID string, version int64) error
+	// AddContactToSegment adds a contact to a segment membership.
+	// It is a no-op when a newer evaluation (higher version, or same version evaluated later) already exists.
+	AddContactToSegment(ctx context.Context, workspaceID string, email string, segmentID string, version int64, evaluatedAt time.Time) error
 
-	// RemoveContactFromSegment removes a contact from a segment
-	RemoveContactFromSegment(ctx context.Context, workspaceID string, email string, segmentID string) error
+	// RemoveContactFromSegment removes a contact from a segment.
+	// It is a no-op when a newer evaluation (higher version, or same version evaluated later) already exists.
+	RemoveContactFromSegment(ctx context.Context, workspaceID string, email string, segmentID string, version int64, evaluatedAt time.Time) error
 
 	// RemoveOldMemberships removes contact_segment records with old versions
 	RemoveOldMemberships(ctx context.Context, workspaceID string, segmentID string, currentVersion int64) error
 
+	// GetSegmentMembers returns which of the given emails are members of a segment
+	GetSegmentMembers(ctx context.Context, workspaceID string, segmentID string, emails []string) ([]string, error)
+
+	// TouchSegmentMemberships updates the version and computed_at of existing memberships
+	// confirmed by a recompute, without removing and re-adding the contacts
+	TouchSegmentMemberships(ctx context.Context, workspaceID string, segmentID string, emails []string, version int64, computedAt time.Time) error
+
 	// GetContactSegments retrieves all segments a contact belongs to
 	GetContactSegments(ctx context.Context, workspaceID string, email string) ([]*Segment, error)
 
@@ -0,0 +1,165 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+//go:generate mockgen -destination mocks/mock_sender_identity_service.go -package mocks github.com/Notifuse/notifuse/internal/domain SenderIdentityService
+
+// SenderIdentityService defines the interface for the verified sender identities of a workspace
+type SenderIdentityService interface {
+	// ListSenderIdentities returns the sender identities registered in a workspace
+	ListSenderIdentities(ctx context.Context, workspaceID string) ([]SenderIdentity, error)
+
+	// VerifySenderIdentity registers the identity of a sender and returns the DNS record proving the ownership
+	// of its domain, or checks that record when the identity is pending verification
+	VerifySenderIdentity(ctx context.Context, req VerifySenderIdentityRequest) (*SenderIdentity, error)
+}
+
+// SenderIdentityStatus is the verification status of a sender identity
+type SenderIdentityStatus string
+
+const (
+	SenderIdentityStatusPending  SenderIdentityStatus = "pending"
+	SenderIdentityStatusVerified SenderIdentityStatus = "verified"
+)
+
+// SenderVerificationRecordPrefix is the prefix of the TXT record proving the ownership of a sender domain
+const SenderVerificationRecordPrefix = "_notifuse"
+
+// senderSPFIncludes are the include mechanisms the SPF record of a sender domain needs for each provider
+var senderSPFIncludes = map[EmailProviderKind]string{
+	EmailProviderKindSES:       "include:amazonses.com",
+	EmailProviderKindSparkPost: "include:sparkpostmail.com",
+	EmailProviderKindPostmark:  "include:spf.mtasv.net",
+	EmailProviderKindMailgun:   "include:mailgun.org",
+	EmailProviderKindMailjet:   "include:spf.mailjet.com",
+	EmailProviderKindSendGrid:  "include:sendgrid.net",
+}
+
+// SenderDomainAuth is the DNS configuration authenticating the domain of a sender identity
+type SenderDomainAuth struct {
+	Domain           string            `json:"domain"`
+	ProviderKind     EmailProviderKind `json:"provider_kind"`
+	TXTRecordName    string            `json:"txt_record_name"`       // Host of the TXT record proving the ownership of the domain
+	TXTRecordValue   string            `json:"txt_record_value"`      // Value of the TXT record proving the ownership of the domain
+	SPFInclude       string            `json:"spf_include,omitempty"` // Mechanism the SPF record needs for the provider, empty for SMTP
+	SPFConfigured    bool              `json:"spf_configured"`        // Whether the SPF record of the domain had the mechanism at the last check
+	LastCheckedAt    *time.Time        `json:"last_checked_at,omitempty"`
+	LastCheckFailure string            `json:"last_check_failure,omitempty"`
+}
+
+// SenderIdentity is a sender email address whose domain ownership is verified before broadcasts are sent from it
+type SenderIdentity struct {
+	Email         string               `json:"email"`
+	Name          string               `json:"name"`
+	IntegrationID string               `json:"integration_id"`
+	Status        SenderIdentityStatus `json:"status"`
+	DomainAuth    SenderDomainAuth     `json:"domain_auth"`
+	VerifiedAt    *time.Time           `json:"verified_at,omitempty"`
+	CreatedAt     time.Time            `json:"created_at"`
+}
+
+// NewSenderIdentity returns the pending identity of a sender of an email integration, token being the
+// value of the TXT record proving the ownership of its domain
+func NewSenderIdentity(sender EmailSender, integrationID string, providerKind EmailProviderKind, token string, now time.Time) (*SenderIdentity, error) {
+	at := strings.LastIndex(sender.Email, "@")
+	if at < 1 || at == len(sender.Email)-1 {
+		return nil, NewValidationError(fmt.Sprintf("invalid sender email: %s", sender.Email))
+	}
+	senderDomain := strings.ToLower(sender.Email[at+1:])
+
+	return &SenderIdentity{
+		Email:         strings.ToLower(sender.Email),
+		Name:          sender.Name,
+		IntegrationID: integrationID,
+		Status:        SenderIdentityStatusPending,
+		DomainAuth: SenderDomainAuth{
+			Domain:         senderDomain,
+			ProviderKind:   providerKind,
+			TXTRecordName:  SenderVerificationRecordPrefix + "." + senderDomain,
+			TXTRecordValue: "notifuse-verification=" + token,
+			SPFInclude:     senderSPFIncludes[providerKind],
+		},
+		CreatedAt: now,
+	}, nil
+}
+
+// IsVerified returns whether the ownership of the sender domain is verified
+func (s *SenderIdentity) IsVerified() bool {
+	return s.Status == SenderIdentityStatusVerified
+}
+
+// GetSenderIdentity returns the sender identity of an email address, or nil when it is not registered
+func (ws *WorkspaceSettings) GetSenderIdentity(email string) *SenderIdentity {
+	for i := range ws.SenderIdentities {
+		if strings.EqualFold(ws.SenderIdentities[i].Email, email) {
+			return &ws.SenderIdentities[i]
+		}
+	}
+	return nil
+}
+
+// ErrSenderNotFound is returned when the sender of a template resolves to no sender of the email provider
+type ErrSenderNotFound struct {
+	SenderID string
+}
+
+func (e *ErrSenderNotFound) Error() string {
+	if e.SenderID == "" {
+		return "no default sender configured for the email provider"
+	}
+	return fmt.Sprintf("sender %s is not configured for the email provider and no default sender is set", e.SenderID)
+}
+
+// ErrSenderUnverified is returned when a sender has no verified sender identity
+type ErrSenderUnverified struct {
+	Email  string
+	Status SenderIdentityStatus
+}
+
+func (e *ErrSenderUnverified) Error() string {
+	if e.Status == "" {
+		return fmt.Sprintf("sender %s has no sender identity, verify it before sending", e.Email)
+	}
+	return fmt.Sprintf("sender %s is not verified (status: %s)", e.Email, e.Status)
+}
+
+// ResolveVerifiedSender resolves a sender ID of a template, falling back to the default sender of the email
+// provider, and returns the sender when its email has a verified sender identity in the workspace
+func (ws *WorkspaceSettings) ResolveVerifiedSender(emailProvider *EmailProvider, senderID string) (*EmailSender, error) {
+	sender := emailProvider.GetSender(senderID)
+	if sender == nil {
+		return nil, &ErrSenderNotFound{SenderID: senderID}
+	}
+
+	identity := ws.GetSenderIdentity(sender.Email)
+	if identity == nil {
+		return nil, &ErrSenderUnverified{Email: sender.Email}
+	}
+	if !identity.IsVerified() {
+		return nil, &ErrSenderUnverified{Email: sender.Email, Status: identity.Status}
+	}
+
+	return sender, nil
+}
+
+// VerifySenderIdentityRequest defines the request to start or check the verification of a sender identity
+type VerifySenderIdentityRequest struct {
+	WorkspaceID string `json:"workspace_id"`
+	Email       string `json:"email"`
+}
+
+// Validate validates the VerifySenderIdentityRequest
+func (r *VerifySenderIdentityRequest) Validate() error {
+	if r.WorkspaceID == "" {
+		return NewValidationError("workspace_id is required")
+	}
+	if r.Email == "" {
+		return NewValidationError("email is required")
+	}
+	return nil
+}
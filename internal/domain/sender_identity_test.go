@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSenderIdentity(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	identity, err := NewSenderIdentity(EmailSender{ID: "sender-1", Email: "News@Example.com", Name: "News"}, "integration-1", EmailProviderKindSES, "abc123", now)
+	require.NoError(t, err)
+	assert.Equal(t, "news@example.com", identity.Email)
+	assert.Equal(t, "News", identity.Name)
+	assert.Equal(t, "integration-1", identity.IntegrationID)
+	assert.Equal(t, SenderIdentityStatusPending, identity.Status)
+	assert.False(t, identity.IsVerified())
+	assert.Equal(t, SenderDomainAuth{
+		Domain:         "example.com",
+		ProviderKind:   EmailProviderKindSES,
+		TXTRecordName:  "_notifuse.example.com",
+		TXTRecordValue: "notifuse-verification=abc123",
+		SPFInclude:     "include:amazonses.com",
+	}, identity.DomainAuth)
+	assert.Equal(t, now, identity.CreatedAt)
+
+	// SMTP servers have no SPF mechanism to recommend
+	identity, err = NewSenderIdentity(EmailSender{Email: "news@example.com"}, "integration-1", EmailProviderKindSMTP, "abc123", now)
+	require.NoError(t, err)
+	assert.Empty(t, identity.DomainAuth.SPFInclude)
+
+	for _, email := range []string{"news", "@example.com", "news@"} {
+		_, err = NewSenderIdentity(EmailSender{Email: email}, "integration-1", EmailProviderKindSES, "abc123", now)
+		assert.Error(t, err, email)
+	}
+}
+
+func TestWorkspaceSettings_ResolveVerifiedSender(t *testing.T) {
+	provider := &EmailProvider{
+		Kind: EmailProviderKindSES,
+		Senders: []EmailSender{
+			{ID: "sender-default", Email: "hello@example.com", IsDefault: true},
+			{ID: "sender-news", Email: "news@example.com"},
+		},
+	}
+	settings := &WorkspaceSettings{
+		SenderIdentities: []SenderIdentity{
+			{Email: "hello@example.com", Status: SenderIdentityStatusVerified},
+			{Email: "news@example.com", Status: SenderIdentityStatusPending},
+		},
+	}
+
+	t.Run("verified sender", func(t *testing.T) {
+		sender, err := settings.ResolveVerifiedSender(provider, "sender-default")
+		require.NoError(t, err)
+		assert.Equal(t, "hello@example.com", sender.Email)
+	})
+
+	t.Run("unknown sender falls back to the default sender", func(t *testing.T) {
+		sender, err := settings.ResolveVerifiedSender(provider, "sender-deleted")
+		require.NoError(t, err)
+		assert.Equal(t, "sender-default", sender.ID)
+	})
+
+	t.Run("pending sender", func(t *testing.T) {
+		_, err := settings.ResolveVerifiedSender(provider, "sender-news")
+		var unverifiedErr *ErrSenderUnverified
+		require.ErrorAs(t, err, &unverifiedErr)
+		assert.Equal(t, SenderIdentityStatusPending, unverifiedErr.Status)
+		assert.Equal(t, "sender news@example.com is not verified (status: pending)", err.Error())
+	})
+
+	t.Run("sender without identity", func(t *testing.T) {
+		withoutIdentities := &WorkspaceSettings{}
+		_, err := withoutIdentities.ResolveVerifiedSender(provider, "sender-default")
+		var unverifiedErr *ErrSenderUnverified
+		require.ErrorAs(t, err, &unverifiedErr)
+		assert.Equal(t, "sender hello@example.com has no sender identity, verify it before sending", err.Error())
+	})
+
+	t.Run("no sender", func(t *testing.T) {
+		_, err := settings.ResolveVerifiedSender(&EmailProvider{Kind: EmailProviderKindSES}, "sender-news")
+		var notFoundErr *ErrSenderNotFound
+		require.ErrorAs(t, err, &notFoundErr)
+		assert.Equal(t, "sender-news", notFoundErr.SenderID)
+	})
+}
@@ -0,0 +1,105 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Notifuse/notifuse/pkg/notifuse_mjml"
+)
+
+//go:generate mockgen -destination mocks/mock_short_link_repository.go -package mocks github.com/Notifuse/notifuse/internal/domain ShortLinkRepository
+//go:generate mockgen -destination mocks/mock_link_shortener_service.go -package mocks github.com/Notifuse/notifuse/internal/domain LinkShortenerService
+
+// ShortLinkPathPrefix is the path under which short links are served
+const ShortLinkPathPrefix = "/s/"
+
+// ErrShortLinkNotFound is returned when no short link matches a code
+var ErrShortLinkNotFound = errors.New("short link not found")
+
+// ShortLink maps a short code to the tracked destination of a message link
+type ShortLink struct {
+	Code      string    `json:"code"`
+	MessageID string    `json:"message_id"`
+	URL       string    `json:"url"`     // Destination including UTM parameters
+	SentAt    time.Time `json:"sent_at"` // Used for bot detection on click
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// ExpandURL returns the destination URL with the query parameters of the short link request
+// appended. Parameters already present on the destination, such as UTMs, are kept as stored.
+func (l *ShortLink) ExpandURL(passthrough url.Values) string {
+	if len(passthrough) == 0 {
+		return l.URL
+	}
+
+	parsed, err := url.Parse(l.URL)
+	if err != nil {
+		return l.URL
+	}
+
+	query := parsed.Query()
+	for key, values := range passthrough {
+		if query.Has(key) {
+			continue
+		}
+		for _, value := range values {
+			query.Add(key, value)
+		}
+	}
+	parsed.RawQuery = query.Encode()
+
+	return parsed.String()
+}
+
+// ShortLinkURL builds the public URL of a short link
+func ShortLinkURL(baseURL, workspaceID, code string) string {
+	return fmt.Sprintf("%s%s%s/%s", strings.TrimRight(baseURL, "/"), ShortLinkPathPrefix, url.PathEscape(workspaceID), code)
+}
+
+// ParseShortLinkPath extracts the workspace ID and code from a short link path
+func ParseShortLinkPath(path string) (workspaceID, code string, err error) {
+	rest, ok := strings.CutPrefix(path, ShortLinkPathPrefix)
+	if !ok {
+		return "", "", fmt.Errorf("invalid short link path")
+	}
+	escapedWorkspaceID, code, ok := strings.Cut(rest, "/")
+	if !ok || escapedWorkspaceID == "" || code == "" || strings.Contains(code, "/") {
+		return "", "", fmt.Errorf("invalid short link path")
+	}
+	workspaceID, err = url.PathUnescape(escapedWorkspaceID)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid short link path")
+	}
+	return workspaceID, code, nil
+}
+
+// ShortLinkRepository defines methods for short link persistence
+type ShortLinkRepository interface {
+	// Create stores a short link, returning false when the code is already taken
+	Create(ctx context.Context, workspaceID string, link *ShortLink) (bool, error)
+
+	// GetByCode retrieves a short link by code
+	GetByCode(ctx context.Context, workspaceID string, code string) (*ShortLink, error)
+}
+
+// LinkShortenerService creates and expands short links for click-tracked URLs
+type LinkShortenerService interface {
+	// ShortenerForWorkspace returns the shortener used when compiling messages of the workspace.
+	// The workspace settings are only loaded once a link has to be shortened.
+	ShortenerForWorkspace(ctx context.Context, workspaceID string) notifuse_mjml.LinkShortener
+
+	// ExpandShortLink retrieves the short link stored for a code
+	ExpandShortLink(ctx context.Context, workspaceID string, code string) (*ShortLink, error)
+}
+
+// WorkspaceLinkShortener returns the shortener of the workspace, or nil when service is not configured
+func WorkspaceLinkShortener(ctx context.Context, service LinkShortenerService, workspaceID string) notifuse_mjml.LinkShortener {
+	if service == nil {
+		return nil
+	}
+	return service.ShortenerForWorkspace(ctx, workspaceID)
+}
@@ -0,0 +1,70 @@
+package domain
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShortLink_ExpandURL(t *testing.T) {
+	link := &ShortLink{URL: "https://example.com/offer?id=42&utm_source=newsletter"}
+
+	t.Run("without passthrough parameters", func(t *testing.T) {
+		assert.Equal(t, link.URL, link.ExpandURL(nil))
+	})
+
+	t.Run("appends passthrough parameters and keeps stored ones", func(t *testing.T) {
+		passthrough := url.Values{
+			"ref":        {"partner"},
+			"utm_source": {"override"},
+		}
+		assert.Equal(t, "https://example.com/offer?id=42&ref=partner&utm_source=newsletter", link.ExpandURL(passthrough))
+	})
+}
+
+func TestShortLinkURL_ParseShortLinkPath(t *testing.T) {
+	shortURL := ShortLinkURL("https://go.example.com/", "ws1", "abc12345")
+	assert.Equal(t, "https://go.example.com/s/ws1/abc12345", shortURL)
+
+	parsed, err := url.Parse(shortURL)
+	require.NoError(t, err)
+	workspaceID, code, err := ParseShortLinkPath(parsed.Path)
+	require.NoError(t, err)
+	assert.Equal(t, "ws1", workspaceID)
+	assert.Equal(t, "abc12345", code)
+
+	for _, path := range []string{"/visit", "/s/", "/s/ws1", "/s/ws1/", "/s//abc", "/s/ws1/abc/extra"} {
+		_, _, err := ParseShortLinkPath(path)
+		assert.Error(t, err, path)
+	}
+}
+
+func TestLinkShorteningSettings_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		domain  string
+		wantErr bool
+	}{
+		{name: "no domain", domain: ""},
+		{name: "https domain", domain: "https://go.example.com"},
+		{name: "trailing slash", domain: "https://go.example.com/"},
+		{name: "missing scheme", domain: "go.example.com", wantErr: true},
+		{name: "unsupported scheme", domain: "ftp://go.example.com", wantErr: true},
+		{name: "with path", domain: "https://example.com/links", wantErr: true},
+		{name: "with query", domain: "https://go.example.com?a=1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			settings := &LinkShorteningSettings{Enabled: true, Domain: tt.domain}
+			err := settings.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Notifuse/notifuse/pkg/crypto"
+)
+
+//go:generate mockgen -destination mocks/mock_sms_provider_service.go -package mocks github.com/Notifuse/notifuse/internal/domain SMSProviderService
+
+// SMSProviderKind defines the type of SMS provider
+type SMSProviderKind string
+
+const (
+	SMSProviderKindTwilio SMSProviderKind = "twilio"
+)
+
+// SMSProvider contains configuration for an SMS service provider
+type SMSProvider struct {
+	Kind   SMSProviderKind `json:"kind"`
+	Twilio *TwilioSettings `json:"twilio,omitempty"`
+}
+
+// Validate validates the SMS provider settings
+func (s *SMSProvider) Validate(passphrase string) error {
+	if s.Kind == "" {
+		return fmt.Errorf("SMS provider kind is required")
+	}
+
+	switch s.Kind {
+	case SMSProviderKindTwilio:
+		if s.Twilio == nil {
+			return fmt.Errorf("Twilio settings required when SMS provider kind is twilio")
+		}
+		return s.Twilio.Validate(passphrase)
+	default:
+		return fmt.Errorf("invalid SMS provider kind: %s", s.Kind)
+	}
+}
+
+// EncryptSecretKeys encrypts all secret keys in the SMS provider
+func (s *SMSProvider) EncryptSecretKeys(passphrase string) error {
+	if s.Kind == SMSProviderKindTwilio && s.Twilio != nil && s.Twilio.AuthToken != "" {
+		if err := s.Twilio.EncryptAuthToken(passphrase); err != nil {
+			return err
+		}
+		s.Twilio.AuthToken = ""
+	}
+
+	return nil
+}
+
+// DecryptSecretKeys decrypts all encrypted secret keys in the SMS provider
+func (s *SMSProvider) DecryptSecretKeys(passphrase string) error {
+	if s.Kind == SMSProviderKindTwilio && s.Twilio != nil && s.Twilio.EncryptedAuthToken != "" {
+		if err := s.Twilio.DecryptAuthToken(passphrase); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// TwilioSettings contains configuration for Twilio
+type TwilioSettings struct {
+	AccountSID         string `json:"account_sid"`
+	EncryptedAuthToken string `json:"encrypted_auth_token,omitempty"`
+	FromNumber         string `json:"from_number"` // E.164 phone number or messaging service sender
+
+	// Decoded auth token, not stored in the database
+	AuthToken string `json:"auth_token,omitempty"`
+}
+
+// DecryptAuthToken decrypts the encrypted auth token
+func (t *TwilioSettings) DecryptAuthToken(passphrase string) error {
+	authToken, err := crypto.DecryptFromHexString(t.EncryptedAuthToken, passphrase)
+	if err != nil {
+		return fmt.Errorf("failed to decrypt Twilio auth token: %w", err)
+	}
+	t.AuthToken = authToken
+	return nil
+}
+
+// EncryptAuthToken encrypts the auth token
+func (t *TwilioSettings) EncryptAuthToken(passphrase string) error {
+	encryptedAuthToken, err := crypto.EncryptString(t.AuthToken, passphrase)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt Twilio auth token: %w", err)
+	}
+	t.EncryptedAuthToken = encryptedAuthToken
+	return nil
+}
+
+// Validate validates the Twilio settings
+func (t *TwilioSettings) Validate(passphrase string) error {
+	if t.AccountSID == "" {
+		return fmt.Errorf("account SID is required for Twilio configuration")
+	}
+	if t.FromNumber == "" {
+		return fmt.Errorf("from number is required for Twilio configuration")
+	}
+	if t.AuthToken == "" && t.EncryptedAuthToken == "" {
+		return fmt.Errorf("auth token is required for Twilio configuration")
+	}
+
+	// Encrypt auth token if it's not empty
+	if t.AuthToken != "" {
+		if err := t.EncryptAuthToken(passphrase); err != nil {
+			return fmt.Errorf("failed to encrypt Twilio auth token: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// SendSMSRequest contains the parameters for sending an SMS through a provider
+type SendSMSRequest struct {
+	WorkspaceID   string
+	IntegrationID string
+	MessageID     string
+	To            string
+	Body          string
+	Provider      *SMSProvider
+}
+
+// Validate ensures all required fields are present
+func (r *SendSMSRequest) Validate() error {
+	if r.WorkspaceID == "" {
+		return fmt.Errorf("workspace ID is required")
+	}
+	if r.IntegrationID == "" {
+		return fmt.Errorf("integration ID is required")
+	}
+	if r.MessageID == "" {
+		return fmt.Errorf("message ID is required")
+	}
+	if r.To == "" {
+		return fmt.Errorf("to phone number is required")
+	}
+	if r.Body == "" {
+		return fmt.Errorf("body is required")
+	}
+	if r.Provider == nil {
+		return fmt.Errorf("provider is required")
+	}
+	return nil
+}
+
+// SMSProviderService is the interface implemented by the SMS providers
+type SMSProviderService interface {
+	SendSMS(ctx context.Context, request SendSMSRequest) error
+}
@@ -40,8 +40,11 @@ type TaskState struct {
 	Message  string  `json:"message,omitempty"`
 
 	// Specialized states for different task types - only one will be used based on task type
-	SendBroadcast *SendBroadcastState `json:"send_broadcast,omitempty"`
-	BuildSegment  *BuildSegmentState  `json:"build_segment,omitempty"`
+	SendBroadcast    *SendBroadcastState    `json:"send_broadcast,omitempty"`
+	BuildSegment     *BuildSegmentState     `json:"build_segment,omitempty"`
+	RecomputeSegment *RecomputeSegmentState `json:"recompute_segment,omitempty"`
+
+	PruneMessageHistory *PruneMessageHistoryState `json:"prune_message_history,omitempty"`
 }
 
 // Value implements the driver.Valuer interface for TaskState
@@ -76,11 +79,34 @@ type SendBroadcastState struct {
 	// LastProcessedEmail is the cursor for keyset pagination - stores the last email processed
 	// to enable deterministic pagination across task executions (fixes Issue #157)
 	LastProcessedEmail string `json:"last_processed_email,omitempty"`
+	// ResumeSentEmails are the recipients past LastProcessedEmail that were already sent when a batch
+	// stopped midway, so that they are skipped when the rest of the batch is sent again
+	ResumeSentEmails []string `json:"resume_sent_emails,omitempty"`
 	// New fields for A/B testing phases
 	Phase                     string `json:"phase"` // "test", "winner", or "single"
 	TestPhaseCompleted        bool   `json:"test_phase_completed"`
 	TestPhaseRecipientCount   int    `json:"test_phase_recipient_count"`
 	WinnerPhaseRecipientCount int    `json:"winner_phase_recipient_count"`
+	// PublishedStatus is the last broadcast status published as a phase change,
+	// so that each transition is published once across task executions
+	PublishedStatus BroadcastStatus `json:"published_status,omitempty"`
+	// RecentSends are the batches sent during the last second of a throttled broadcast,
+	// kept so that a task resumed after a restart does not exceed the send rate
+	RecentSends []ThrottledSend `json:"recent_sends,omitempty"`
+	// DryRun is set from the broadcast when sending starts, its messages are rendered
+	// and recorded in message history but never delivered
+	DryRun bool `json:"dry_run,omitempty"`
+	// AdaptiveBatchSize is the batch size tuned from the latency of the provider when adaptive
+	// batch sizing is enabled, kept so that a resumed task starts from the tuned size
+	AdaptiveBatchSize int `json:"adaptive_batch_size,omitempty"`
+	// DuplicateCount counts the recipients skipped because they were already sent earlier in the run
+	DuplicateCount int `json:"duplicate_count,omitempty"`
+}
+
+// ThrottledSend records a batch of a throttled broadcast and when its last message was sent
+type ThrottledSend struct {
+	At    time.Time `json:"at"`
+	Count int       `json:"count"`
 }
 
 // BuildSegmentState contains state specific to segment building tasks
@@ -95,6 +121,53 @@ type BuildSegmentState struct {
 	StartedAt      string `json:"started_at"`
 }
 
+// RecomputeSegmentState contains state specific to segment recompute tasks, which update the
+// memberships of an active segment in place
+type RecomputeSegmentState struct {
+	SegmentID      string `json:"segment_id"`
+	Version        int64  `json:"version"`
+	TotalContacts  int    `json:"total_contacts"`
+	ProcessedCount int    `json:"processed_count"`
+	AddedCount     int    `json:"added_count"`
+	RemovedCount   int    `json:"removed_count"`
+	ContactOffset  int64  `json:"contact_offset"` // For resumable processing
+	BatchSize      int    `json:"batch_size"`
+	StartedAt      string `json:"started_at"`
+}
+
+// PruneMessageHistoryState contains state specific to message history pruning tasks, which delete
+// the messages created before a cutoff in batches
+type PruneMessageHistoryState struct {
+	Cutoff time.Time `json:"cutoff"`
+	// KeepStatuses are kept for compliance: messages that reached any of them are never deleted
+	KeepStatuses []MessageEvent `json:"keep_statuses,omitempty"`
+	BatchSize    int            `json:"batch_size"`
+	DeletedCount int64          `json:"deleted_count"`
+	StartedAt    string         `json:"started_at"`
+}
+
+// Validate checks that the cutoff is set and in the past, and that the kept statuses are recorded on messages
+func (s *PruneMessageHistoryState) Validate() error {
+	if s.Cutoff.IsZero() {
+		return fmt.Errorf("cutoff is required")
+	}
+	if s.Cutoff.After(time.Now()) {
+		return fmt.Errorf("cutoff must be in the past")
+	}
+	for _, status := range s.KeepStatuses {
+		switch status {
+		case MessageEventDelivered, MessageEventFailed, MessageEventOpened, MessageEventClicked,
+			MessageEventBounced, MessageEventComplained, MessageEventUnsubscribed:
+		default:
+			return fmt.Errorf("invalid keep status: %s", status)
+		}
+	}
+	if s.BatchSize < 0 {
+		return fmt.Errorf("batch_size must not be negative")
+	}
+	return nil
+}
+
 // Task represents a background task that can be executed in multiple steps
 type Task struct {
 	ID            string     `json:"id"`
@@ -717,3 +717,41 @@ func TestExecuteTaskRequest_Validate(t *testing.T) {
 		assert.Contains(t, err.Error(), "task id is required")
 	})
 }
+
+func TestPruneMessageHistoryState_Validate(t *testing.T) {
+	t.Run("valid state", func(t *testing.T) {
+		state := &PruneMessageHistoryState{
+			Cutoff:       time.Now().AddDate(0, -6, 0),
+			KeepStatuses: []MessageEvent{MessageEventComplained, MessageEventUnsubscribed, MessageEventBounced},
+		}
+		require.NoError(t, state.Validate())
+	})
+
+	t.Run("missing cutoff", func(t *testing.T) {
+		err := (&PruneMessageHistoryState{}).Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cutoff is required")
+	})
+
+	t.Run("cutoff in the future", func(t *testing.T) {
+		err := (&PruneMessageHistoryState{Cutoff: time.Now().Add(time.Hour)}).Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cutoff must be in the past")
+	})
+
+	t.Run("sent is not a status that can be kept", func(t *testing.T) {
+		// Every message is sent, keeping them would prune nothing
+		err := (&PruneMessageHistoryState{
+			Cutoff:       time.Now().AddDate(0, -6, 0),
+			KeepStatuses: []MessageEvent{MessageEventSent},
+		}).Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid keep status: sent")
+	})
+
+	t.Run("negative batch size", func(t *testing.T) {
+		err := (&PruneMessageHistoryState{Cutoff: time.Now().AddDate(0, -6, 0), BatchSize: -1}).Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "batch_size must not be negative")
+	})
+}
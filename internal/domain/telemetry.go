@@ -18,6 +18,39 @@ type TelemetryMetrics struct {
 	UsersCount         int    `json:"users_count"`
 	BlogPostsCount     int    `json:"blog_posts_count"`
 	LastMessageAt      string `json:"last_message_at"`
+
+	// Send performance over the last 24 hours
+	MessagesSentLast24h int     `json:"messages_sent_last_24h"`
+	AvgSendLatencyMs    float64 `json:"avg_send_latency_ms"`
+	BounceRate          float64 `json:"bounce_rate"`
+	ComplaintRate       float64 `json:"complaint_rate"`
+
+	// Number of blocks of each component type across the latest version of the templates, e.g. "mj-button"
+	BlockUsage map[string]int `json:"block_usage"`
+}
+
+// TelemetrySendMetrics represents the send performance of a workspace over the last 24 hours
+type TelemetrySendMetrics struct {
+	MessagesSent    int     // Messages sent, dry runs and failed sends excluded
+	AvgLatencyMs    float64 // Average time between the send and the delivery reported by the provider
+	BouncedCount    int     // Sent messages that bounced
+	ComplainedCount int     // Sent messages that were reported as spam
+}
+
+// BounceRate returns the share of sent messages that bounced, between 0 and 1
+func (m *TelemetrySendMetrics) BounceRate() float64 {
+	if m.MessagesSent == 0 {
+		return 0
+	}
+	return float64(m.BouncedCount) / float64(m.MessagesSent)
+}
+
+// ComplaintRate returns the share of sent messages that were reported as spam, between 0 and 1
+func (m *TelemetrySendMetrics) ComplaintRate() float64 {
+	if m.MessagesSent == 0 {
+		return 0
+	}
+	return float64(m.ComplainedCount) / float64(m.MessagesSent)
 }
 
 // TelemetryRepository defines the interface for telemetry data operations
@@ -51,4 +84,10 @@ type TelemetryRepository interface {
 
 	// GetLastMessageAt gets the timestamp of the last message sent from the workspace
 	GetLastMessageAt(ctx context.Context, db *sql.DB) (string, error)
+
+	// GetSendMetrics aggregates the send performance of the workspace over the last 24 hours
+	GetSendMetrics(ctx context.Context, db *sql.DB) (*TelemetrySendMetrics, error)
+
+	// CountTemplateBlocks counts the blocks of each component type across the visual editor templates of a workspace
+	CountTemplateBlocks(ctx context.Context, db *sql.DB) (map[string]int, error)
 }
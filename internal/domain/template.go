@@ -9,6 +9,7 @@ import (
 	"net/url"
 	"regexp"
 	"strconv"
+	"strings"
 	"time"
 
 	// Import the notifuse_mjml package
@@ -41,6 +42,7 @@ func validateTemplateID(id string) error {
 const (
 	ChannelEmail = "email"
 	ChannelWeb   = "web"
+	ChannelSMS   = "sms"
 )
 
 type TemplateCategory string
@@ -69,9 +71,10 @@ type Template struct {
 	ID              string         `json:"id"`
 	Name            string         `json:"name"`
 	Version         int64          `json:"version"`
-	Channel         string         `json:"channel"` // email or web
+	Channel         string         `json:"channel"` // email, web or sms
 	Email           *EmailTemplate `json:"email,omitempty"`
 	Web             *WebTemplate   `json:"web,omitempty"`
+	SMS             *SMSTemplate   `json:"sms,omitempty"`
 	Category        string         `json:"category"`
 	TemplateMacroID *string        `json:"template_macro_id,omitempty"`
 	IntegrationID   *string        `json:"integration_id,omitempty"` // Set if template is managed by an integration (e.g., Supabase)
@@ -106,9 +109,9 @@ func (t *Template) Validate() error {
 		return fmt.Errorf("invalid template: channel length must be between 1 and 20")
 	}
 
-	// Validate channel is either email or web
-	if t.Channel != ChannelEmail && t.Channel != ChannelWeb {
-		return fmt.Errorf("invalid template: channel must be either '%s' or '%s'", ChannelEmail, ChannelWeb)
+	// Validate channel is email, web or sms
+	if t.Channel != ChannelEmail && t.Channel != ChannelWeb && t.Channel != ChannelSMS {
+		return fmt.Errorf("invalid template: channel must be one of '%s', '%s' or '%s'", ChannelEmail, ChannelWeb, ChannelSMS)
 	}
 
 	if t.Category == "" {
@@ -146,6 +149,17 @@ func (t *Template) Validate() error {
 		if err := t.Web.Validate(t.TestData); err != nil {
 			return fmt.Errorf("invalid template: %w", err)
 		}
+	case ChannelSMS:
+		// SMS channel requires sms field, email and web must be nil
+		if t.SMS == nil {
+			return fmt.Errorf("invalid template: sms is required for channel '%s'", ChannelSMS)
+		}
+		if t.Email != nil || t.Web != nil {
+			return fmt.Errorf("invalid template: email and web must be nil for channel '%s'", ChannelSMS)
+		}
+		if err := t.SMS.Validate(); err != nil {
+			return fmt.Errorf("invalid template: %w", err)
+		}
 	}
 
 	return nil
@@ -200,6 +214,99 @@ type EmailTemplate struct {
 	CompiledPreview  string                   `json:"compiled_preview"` // compiled html
 	VisualEditorTree notifuse_mjml.EmailBlock `json:"visual_editor_tree"`
 	Text             *string                  `json:"text,omitempty"`
+	// Translations holds localized variants keyed by language code (e.g. "pt", "pt-BR").
+	// The main content above is the workspace default and is used when no variant matches.
+	Translations map[string]*EmailTemplate `json:"translations,omitempty"`
+	// OpenTracking and ClickTracking override the workspace tracking defaults when set
+	OpenTracking  *bool `json:"open_tracking,omitempty"`
+	ClickTracking *bool `json:"click_tracking,omitempty"`
+	// DisableOpenTracking and DisableClickTracking opt the template out of tracking whatever the
+	// workspace defaults and the overrides above, e.g. for password resets
+	DisableOpenTracking  bool `json:"disable_open_tracking,omitempty"`
+	DisableClickTracking bool `json:"disable_click_tracking,omitempty"`
+	// ProviderTags are passed through to the email provider with every message sent from this template
+	ProviderTags map[string]string `json:"provider_tags,omitempty"`
+	// Personalization checks required merge fields for every broadcast recipient
+	Personalization *PersonalizationRules `json:"personalization,omitempty"`
+	// RawMergeFields lists the contact fields trusted to render as HTML; other contact values are escaped
+	RawMergeFields []string `json:"raw_merge_fields,omitempty"`
+	// Language is the translation ForLanguage localized the content to, empty for the default content.
+	// It is not stored, translations share the ID and version of their template.
+	Language string `json:"-"`
+}
+
+// ValidateProviderTagsFor checks the provider tags of the template and of its translations against
+// the limits of the email providers it can be sent through. Validate only checks the limits common
+// to every provider, as the providers of the workspace are unknown to the template.
+func (e *EmailTemplate) ValidateProviderTagsFor(kinds ...EmailProviderKind) error {
+	for _, kind := range kinds {
+		if err := ValidateProviderTags(kind, e.ProviderTags); err != nil {
+			return err
+		}
+		for language, translation := range e.Translations {
+			if translation == nil {
+				continue
+			}
+			if err := ValidateProviderTags(kind, translation.ProviderTags); err != nil {
+				return fmt.Errorf("translation %s: %w", language, err)
+			}
+		}
+	}
+	return nil
+}
+
+// ApplyProviderTags copies the template provider tags into the email options
+func (e *EmailTemplate) ApplyProviderTags(opts *EmailOptions) {
+	if len(e.ProviderTags) == 0 {
+		return
+	}
+	tags := make(map[string]string, len(opts.ProviderTags)+len(e.ProviderTags))
+	for key, value := range opts.ProviderTags {
+		tags[key] = value
+	}
+	for key, value := range e.ProviderTags {
+		tags[key] = value
+	}
+	opts.ProviderTags = tags
+}
+
+// ApplyTrackingOverrides applies the template open and click tracking overrides on top of
+// the defaults already present in the tracking settings, then the template tracking opt-outs
+func (e *EmailTemplate) ApplyTrackingOverrides(settings *notifuse_mjml.TrackingSettings) {
+	open := settings.OpenTrackingEnabled()
+	click := settings.ClickTrackingEnabled()
+	if e.OpenTracking != nil {
+		open = *e.OpenTracking
+	}
+	if e.ClickTracking != nil {
+		click = *e.ClickTracking
+	}
+	if e.DisableOpenTracking {
+		open = false
+	}
+	if e.DisableClickTracking {
+		click = false
+	}
+	settings.SetTracking(open, click)
+}
+
+// unsubscribeMergeTagRegex matches the merge tags of the links letting a contact unsubscribe
+var unsubscribeMergeTagRegex = regexp.MustCompile(`\{\{-?\s*(?:unsubscribe_url|oneclick_unsubscribe_url|notification_center_url)\b`)
+
+// HasUnsubscribeLink reports whether the visual editor tree or the plain text version links to an
+// unsubscribe page through the unsubscribe_url, oneclick_unsubscribe_url or notification_center_url merge tags
+func (e *EmailTemplate) HasUnsubscribeLink() bool {
+	found := false
+	check := func(text string) {
+		if !found && unsubscribeMergeTagRegex.MatchString(text) {
+			found = true
+		}
+	}
+	walkBlockText(e.VisualEditorTree, check)
+	if e.Text != nil {
+		check(*e.Text)
+	}
+	return found
 }
 
 func (e *EmailTemplate) Validate(testData MapOfAny) error {
@@ -248,10 +355,157 @@ func (e *EmailTemplate) Validate(testData MapOfAny) error {
 	if e.SubjectPreview != nil && len(*e.SubjectPreview) > 255 {
 		return fmt.Errorf("invalid email template: subject_preview length must be between 1 and 255")
 	}
+	if err := ValidateProviderTags("", e.ProviderTags); err != nil {
+		return fmt.Errorf("invalid email template: %w", err)
+	}
+	if e.Personalization != nil {
+		if err := e.Personalization.Validate(); err != nil {
+			return fmt.Errorf("invalid email template: %w", err)
+		}
+	}
+	for _, field := range e.RawMergeFields {
+		if !contactMergeFields[field] {
+			return fmt.Errorf("invalid email template: raw merge field %q is not a contact field", field)
+		}
+	}
+
+	for language, translation := range e.Translations {
+		if !languageCodeRegex.MatchString(language) {
+			return fmt.Errorf("invalid email template: invalid translation language code %q", language)
+		}
+		if translation == nil {
+			return fmt.Errorf("invalid email template: translation %q is empty", language)
+		}
+		if len(translation.Translations) > 0 {
+			return fmt.Errorf("invalid email template: translation %q cannot have nested translations", language)
+		}
+		if err := translation.Validate(testData); err != nil {
+			return fmt.Errorf("translation %q: %w", language, err)
+		}
+	}
 
 	return nil
 }
 
+// languageCodeRegex matches BCP 47-like language codes such as "en", "pt-BR" or "zh_Hant"
+var languageCodeRegex = regexp.MustCompile(`^[a-zA-Z]{2,3}([-_][a-zA-Z0-9]{2,8})*$`)
+
+// normalizeLanguage lowercases a language code and uses "-" as the subtag separator
+func normalizeLanguage(language string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(language), "_", "-"))
+}
+
+// LanguageFallbackChain returns the normalized languages to try for a contact language,
+// most specific first: "pt-BR" yields ["pt-br", "pt"]
+func LanguageFallbackChain(language string) []string {
+	language = normalizeLanguage(language)
+	if language == "" {
+		return nil
+	}
+
+	chain := []string{language}
+	for i := strings.LastIndex(language, "-"); i > 0; i = strings.LastIndex(language, "-") {
+		language = language[:i]
+		chain = append(chain, language)
+	}
+	return chain
+}
+
+// ForLanguage returns the email variant matching the contact language. Lookup goes from the
+// exact language to its base language and finally to the template's default content.
+// Sender, reply-to, tracking overrides, provider tags and personalization rules are inherited from the default content when a variant leaves them empty.
+func (e *EmailTemplate) ForLanguage(language string) *EmailTemplate {
+	if len(e.Translations) == 0 {
+		return e
+	}
+
+	translations := make(map[string]*EmailTemplate, len(e.Translations))
+	for key, translation := range e.Translations {
+		if translation != nil {
+			translations[normalizeLanguage(key)] = translation
+		}
+	}
+
+	for _, candidate := range LanguageFallbackChain(language) {
+		translation, ok := translations[candidate]
+		if !ok {
+			continue
+		}
+		localized := *translation
+		localized.Language = candidate
+		if localized.SenderID == "" {
+			localized.SenderID = e.SenderID
+		}
+		if localized.ReplyTo == "" {
+			localized.ReplyTo = e.ReplyTo
+		}
+		if localized.OpenTracking == nil {
+			localized.OpenTracking = e.OpenTracking
+		}
+		if localized.ClickTracking == nil {
+			localized.ClickTracking = e.ClickTracking
+		}
+		// A translation cannot opt back into the tracking of a template that opted out
+		localized.DisableOpenTracking = localized.DisableOpenTracking || e.DisableOpenTracking
+		localized.DisableClickTracking = localized.DisableClickTracking || e.DisableClickTracking
+		if localized.ProviderTags == nil {
+			localized.ProviderTags = e.ProviderTags
+		}
+		if localized.Personalization == nil {
+			localized.Personalization = e.Personalization
+		}
+		if localized.RawMergeFields == nil {
+			localized.RawMergeFields = e.RawMergeFields
+		}
+		return &localized
+	}
+
+	return e
+}
+
+// ForLanguage returns a copy of the template whose email content is localized for the
+// contact language, see EmailTemplate.ForLanguage
+func (t *Template) ForLanguage(language string) *Template {
+	if t.Email == nil {
+		return t
+	}
+	email := t.Email.ForLanguage(language)
+	if email == t.Email {
+		return t
+	}
+	localized := *t
+	localized.Email = email
+	return &localized
+}
+
+// ForContact localizes the template for the contact's language, if any
+func (t *Template) ForContact(contact *Contact) *Template {
+	if contact == nil || contact.Language == nil || contact.Language.IsNull {
+		return t
+	}
+	return t.ForLanguage(contact.Language.String)
+}
+
+// WithTrackingDefaults returns a copy of the template whose unset tracking overrides
+// inherit the workspace open and click tracking defaults
+func (t *Template) WithTrackingDefaults(settings *WorkspaceSettings) *Template {
+	if t.Email == nil {
+		return t
+	}
+	email := *t.Email
+	if email.OpenTracking == nil {
+		open := settings.OpenTrackingEnabled()
+		email.OpenTracking = &open
+	}
+	if email.ClickTracking == nil {
+		click := settings.ClickTrackingEnabled()
+		email.ClickTracking = &click
+	}
+	withDefaults := *t
+	withDefaults.Email = &email
+	return &withDefaults
+}
+
 func (x *EmailTemplate) Scan(val interface{}) error {
 	var data []byte
 
@@ -368,6 +622,45 @@ func (w *WebTemplate) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// SMSMaxBodyLength bounds the body of SMS templates, long messages are split in segments by the carriers
+const SMSMaxBodyLength = 1600
+
+type SMSTemplate struct {
+	Body string `json:"body"` // Liquid template rendered with the contact data
+}
+
+func (s *SMSTemplate) Validate() error {
+	if strings.TrimSpace(s.Body) == "" {
+		return fmt.Errorf("invalid sms template: body is required")
+	}
+	if len(s.Body) > SMSMaxBodyLength {
+		return fmt.Errorf("invalid sms template: body length must not exceed %d characters", SMSMaxBodyLength)
+	}
+	return nil
+}
+
+func (s *SMSTemplate) Scan(val interface{}) error {
+	var data []byte
+
+	if b, ok := val.([]byte); ok {
+		data = bytes.Clone(b)
+	} else if str, ok := val.(string); ok {
+		data = []byte(str)
+	} else if val == nil {
+		return nil
+	}
+
+	type Alias SMSTemplate
+	if err := json.Unmarshal(data, (*Alias)(s)); err != nil {
+		return fmt.Errorf("failed to unmarshal SMSTemplate: %w", err)
+	}
+	return nil
+}
+
+func (s SMSTemplate) Value() (driver.Value, error) {
+	return json.Marshal(s)
+}
+
 //go:generate mockgen -destination mocks/mock_template_service.go -package mocks github.com/Notifuse/notifuse/internal/domain TemplateService
 //go:generate mockgen -destination mocks/mock_template_repository.go -package mocks github.com/Notifuse/notifuse/internal/domain TemplateRepository
 
@@ -379,6 +672,7 @@ type CreateTemplateRequest struct {
 	Channel         string         `json:"channel"`
 	Email           *EmailTemplate `json:"email,omitempty"`
 	Web             *WebTemplate   `json:"web,omitempty"`
+	SMS             *SMSTemplate   `json:"sms,omitempty"`
 	Category        string         `json:"category"`
 	TemplateMacroID *string        `json:"template_macro_id,omitempty"`
 	TestData        MapOfAny       `json:"test_data,omitempty"`
@@ -407,9 +701,9 @@ func (r *CreateTemplateRequest) Validate() (template *Template, workspaceID stri
 		return nil, "", fmt.Errorf("invalid create template request: channel length must be between 1 and 20")
 	}
 
-	// Validate channel is either email or web
-	if r.Channel != ChannelEmail && r.Channel != ChannelWeb {
-		return nil, "", fmt.Errorf("invalid create template request: channel must be either '%s' or '%s'", ChannelEmail, ChannelWeb)
+	// Validate channel is email, web or sms
+	if r.Channel != ChannelEmail && r.Channel != ChannelWeb && r.Channel != ChannelSMS {
+		return nil, "", fmt.Errorf("invalid create template request: channel must be one of '%s', '%s' or '%s'", ChannelEmail, ChannelWeb, ChannelSMS)
 	}
 
 	if r.Category == "" {
@@ -441,6 +735,16 @@ func (r *CreateTemplateRequest) Validate() (template *Template, workspaceID stri
 		if err := r.Web.Validate(r.TestData); err != nil {
 			return nil, "", fmt.Errorf("invalid create template request: %w", err)
 		}
+	case ChannelSMS:
+		if r.SMS == nil {
+			return nil, "", fmt.Errorf("invalid create template request: sms is required for channel '%s'", ChannelSMS)
+		}
+		if r.Email != nil || r.Web != nil {
+			return nil, "", fmt.Errorf("invalid create template request: email and web must be nil for channel '%s'", ChannelSMS)
+		}
+		if err := r.SMS.Validate(); err != nil {
+			return nil, "", fmt.Errorf("invalid create template request: %w", err)
+		}
 	}
 
 	return &Template{
@@ -450,6 +754,7 @@ func (r *CreateTemplateRequest) Validate() (template *Template, workspaceID stri
 		Channel:         r.Channel,
 		Email:           r.Email,
 		Web:             r.Web,
+		SMS:             r.SMS,
 		Category:        r.Category,
 		TemplateMacroID: r.TemplateMacroID,
 		TestData:        r.TestData,
@@ -527,6 +832,7 @@ type UpdateTemplateRequest struct {
 	Channel         string         `json:"channel"`
 	Email           *EmailTemplate `json:"email,omitempty"`
 	Web             *WebTemplate   `json:"web,omitempty"`
+	SMS             *SMSTemplate   `json:"sms,omitempty"`
 	Category        string         `json:"category"`
 	TemplateMacroID *string        `json:"template_macro_id,omitempty"`
 	TestData        MapOfAny       `json:"test_data,omitempty"`
@@ -555,9 +861,9 @@ func (r *UpdateTemplateRequest) Validate() (template *Template, workspaceID stri
 		return nil, "", fmt.Errorf("invalid update template request: channel length must be between 1 and 20")
 	}
 
-	// Validate channel is either email or web
-	if r.Channel != ChannelEmail && r.Channel != ChannelWeb {
-		return nil, "", fmt.Errorf("invalid update template request: channel must be either '%s' or '%s'", ChannelEmail, ChannelWeb)
+	// Validate channel is email, web or sms
+	if r.Channel != ChannelEmail && r.Channel != ChannelWeb && r.Channel != ChannelSMS {
+		return nil, "", fmt.Errorf("invalid update template request: channel must be one of '%s', '%s' or '%s'", ChannelEmail, ChannelWeb, ChannelSMS)
 	}
 
 	if r.Category == "" {
@@ -589,6 +895,16 @@ func (r *UpdateTemplateRequest) Validate() (template *Template, workspaceID stri
 		if err := r.Web.Validate(r.TestData); err != nil {
 			return nil, "", fmt.Errorf("invalid update template request: %w", err)
 		}
+	case ChannelSMS:
+		if r.SMS == nil {
+			return nil, "", fmt.Errorf("invalid update template request: sms is required for channel '%s'", ChannelSMS)
+		}
+		if r.Email != nil || r.Web != nil {
+			return nil, "", fmt.Errorf("invalid update template request: email and web must be nil for channel '%s'", ChannelSMS)
+		}
+		if err := r.SMS.Validate(); err != nil {
+			return nil, "", fmt.Errorf("invalid update template request: %w", err)
+		}
 	}
 
 	return &Template{
@@ -597,6 +913,7 @@ func (r *UpdateTemplateRequest) Validate() (template *Template, workspaceID stri
 		Channel:         r.Channel,
 		Email:           r.Email,
 		Web:             r.Web,
+		SMS:             r.SMS,
 		Category:        r.Category,
 		TemplateMacroID: r.TemplateMacroID,
 		TestData:        r.TestData,
@@ -627,6 +944,42 @@ func (r *DeleteTemplateRequest) Validate() (workspaceID string, id string, err e
 type CompileTemplateRequest = notifuse_mjml.CompileTemplateRequest
 type CompileTemplateResponse = notifuse_mjml.CompileTemplateResponse
 
+// --- Preview Request/Response ---
+
+// PreviewTemplateRequest renders a saved email template for a sample contact, as it would be sent to them
+type PreviewTemplateRequest struct {
+	WorkspaceID string   `json:"workspace_id"`
+	TemplateID  string   `json:"template_id"`
+	Version     int64    `json:"version,omitempty"`
+	Contact     *Contact `json:"contact,omitempty"`
+	ListID      string   `json:"list_id,omitempty"`
+	ListName    string   `json:"list_name,omitempty"`
+	// Data is merged into the template data, e.g. to preview transactional data
+	Data MapOfAny `json:"data,omitempty"`
+}
+
+// Validate validates the preview template request
+func (r *PreviewTemplateRequest) Validate() error {
+	if r.WorkspaceID == "" {
+		return fmt.Errorf("invalid preview template request: workspace_id is required")
+	}
+	if err := validateTemplateID(r.TemplateID); err != nil {
+		return fmt.Errorf("invalid preview template request: template %w", err)
+	}
+	if r.Version < 0 {
+		return fmt.Errorf("invalid preview template request: version must be positive")
+	}
+	return nil
+}
+
+// PreviewTemplateResponse is the email rendered for the sample contact
+type PreviewTemplateResponse struct {
+	Subject string `json:"subject"`
+	HTML    string `json:"html"`
+	// UnresolvedMergeTags lists the merge tags that rendered blank for the sample contact
+	UnresolvedMergeTags []string `json:"unresolved_merge_tags"`
+}
+
 // TemplateService provides operations for managing templates
 type TemplateService interface {
 	// CreateTemplate creates a new template
@@ -646,6 +999,9 @@ type TemplateService interface {
 
 	// CompileTemplate compiles a visual editor tree to MJML and HTML
 	CompileTemplate(ctx context.Context, payload CompileTemplateRequest) (*CompileTemplateResponse, error) // Use notifuse_mjml.EmailBlock
+
+	// PreviewTemplate renders a saved email template to HTML for a sample contact
+	PreviewTemplate(ctx context.Context, req PreviewTemplateRequest) (*PreviewTemplateResponse, error)
 }
 
 // TemplateRepository provides database operations for templates
@@ -833,7 +1189,7 @@ func BuildTemplateData(req TemplateDataRequest) (MapOfAny, error) {
 	// Tracking pixel for opens (with timestamp for bot detection)
 	sentTimestamp := time.Now().Unix()
 	trackingPixelURL := fmt.Sprintf("%s/opens?mid=%s&wid=%s&ts=%d",
-		req.TrackingSettings.Endpoint, messageID, workspaceID, sentTimestamp)
+		req.TrackingSettings.OpenClickEndpoint(), messageID, workspaceID, sentTimestamp)
 
 	templateData["tracking_opens_url"] = trackingPixelURL
 
@@ -0,0 +1,296 @@
+package domain
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Notifuse/notifuse/pkg/notifuse_mjml"
+)
+
+// PersonalizationPolicy defines what happens to a recipient whose required merge fields are empty
+type PersonalizationPolicy string
+
+const (
+	// PersonalizationPolicySkip does not send to the recipient
+	PersonalizationPolicySkip PersonalizationPolicy = "skip"
+	// PersonalizationPolicyFallback renders the field fallback instead of the empty value
+	PersonalizationPolicyFallback PersonalizationPolicy = "fallback"
+)
+
+// MaxRequiredMergeFields is the number of required merge fields a template can define
+const MaxRequiredMergeFields = 20
+
+var mergeFieldPathRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*(\.[a-zA-Z_][a-zA-Z0-9_]*)*$`)
+
+// PersonalizationRules lists the merge fields that must not render empty for a recipient
+type PersonalizationRules struct {
+	Policy         PersonalizationPolicy `json:"policy"`
+	RequiredFields []RequiredMergeField  `json:"required_fields"`
+}
+
+// RequiredMergeField is a template data path, e.g. "contact.first_name", that must have a value
+type RequiredMergeField struct {
+	Path     string `json:"path"`
+	Fallback string `json:"fallback,omitempty"`
+}
+
+// Validate validates the personalization rules
+func (r *PersonalizationRules) Validate() error {
+	switch r.Policy {
+	case PersonalizationPolicySkip, PersonalizationPolicyFallback:
+	default:
+		return fmt.Errorf("personalization: policy must be %q or %q", PersonalizationPolicySkip, PersonalizationPolicyFallback)
+	}
+	if len(r.RequiredFields) == 0 {
+		return fmt.Errorf("personalization: at least one required field is needed")
+	}
+	if len(r.RequiredFields) > MaxRequiredMergeFields {
+		return fmt.Errorf("personalization: at most %d required fields are allowed", MaxRequiredMergeFields)
+	}
+	for _, field := range r.RequiredFields {
+		if !mergeFieldPathRegex.MatchString(field.Path) {
+			return fmt.Errorf("personalization: invalid field path %q", field.Path)
+		}
+		if r.Policy == PersonalizationPolicyFallback && strings.TrimSpace(field.Fallback) == "" {
+			return fmt.Errorf("personalization: field %q requires a fallback", field.Path)
+		}
+	}
+	return nil
+}
+
+// ErrRequiredMergeFieldsEmpty is returned when a recipient is skipped because required merge fields are empty
+type ErrRequiredMergeFieldsEmpty struct {
+	Fields []string
+}
+
+// Error returns the error message
+func (e *ErrRequiredMergeFieldsEmpty) Error() string {
+	return fmt.Sprintf("required merge fields are empty: %s", strings.Join(e.Fields, ", "))
+}
+
+// Apply checks the required merge fields against the template data of a recipient.
+// With the fallback policy, empty fields are set to their fallback in data and their paths returned.
+// With the skip policy, an ErrRequiredMergeFieldsEmpty lists the empty fields.
+func (r *PersonalizationRules) Apply(data map[string]interface{}) ([]string, error) {
+	var empty []string
+	for _, field := range r.RequiredFields {
+		if !isEmptyMergeValue(lookupMergeField(data, field.Path)) {
+			continue
+		}
+		empty = append(empty, field.Path)
+		if r.Policy == PersonalizationPolicyFallback {
+			setMergeField(data, field.Path, field.Fallback)
+		}
+	}
+	if len(empty) > 0 && r.Policy == PersonalizationPolicySkip {
+		return empty, &ErrRequiredMergeFieldsEmpty{Fields: empty}
+	}
+	return empty, nil
+}
+
+// mergeFieldMap returns the value as a template data map, if it is one
+func mergeFieldMap(value interface{}) (map[string]interface{}, bool) {
+	switch m := value.(type) {
+	case map[string]interface{}:
+		return m, true
+	case MapOfAny:
+		return m, true
+	}
+	return nil, false
+}
+
+func lookupMergeField(data map[string]interface{}, path string) interface{} {
+	var current interface{} = data
+	for _, key := range strings.Split(path, ".") {
+		m, ok := mergeFieldMap(current)
+		if !ok {
+			return nil
+		}
+		current = m[key]
+	}
+	return current
+}
+
+// setMergeField sets the value at path, creating intermediate maps where missing
+func setMergeField(data map[string]interface{}, path string, value string) {
+	keys := strings.Split(path, ".")
+	current := data
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := mergeFieldMap(current[key])
+		if !ok {
+			next = map[string]interface{}{}
+			current[key] = next
+		}
+		current = next
+	}
+	current[keys[len(keys)-1]] = value
+}
+
+func isEmptyMergeValue(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return strings.TrimSpace(v) == ""
+	}
+	return false
+}
+
+var (
+	liquidExpressionRegex = regexp.MustCompile(`(?s)\{\{.*?\}\}|\{%.*?%\}`)
+	contactMergeTagRegex  = regexp.MustCompile(`\bcontact\.([a-zA-Z_][a-zA-Z0-9_]*)`)
+	contactMergeFields    = contactTemplateFields()
+	contactJSONFields     = contactJSONTemplateFields()
+)
+
+// contactTemplateFields returns the fields of the contact object in template data,
+// which are the JSON names of the Contact struct (see Contact.ToMapOfAny)
+func contactTemplateFields() map[string]bool {
+	fields := map[string]bool{}
+	contactType := reflect.TypeOf(Contact{})
+	for i := 0; i < contactType.NumField(); i++ {
+		name := strings.Split(contactType.Field(i).Tag.Get("json"), ",")[0]
+		if name != "" && name != "-" {
+			fields[name] = true
+		}
+	}
+	return fields
+}
+
+// UnknownContactMergeTags returns the contact merge tags, e.g. "contact.nickname", used in the Liquid
+// expressions of the subject and the visual editor tree that don't match a contact field.
+// They would always render blank. Nested paths are only checked on their first key (contact.custom_json_1.plan).
+func UnknownContactMergeTags(subject string, tree notifuse_mjml.EmailBlock) []string {
+	unknown := map[string]bool{}
+	collect := func(text string) {
+		for _, expression := range liquidExpressionRegex.FindAllString(text, -1) {
+			for _, match := range contactMergeTagRegex.FindAllStringSubmatch(expression, -1) {
+				if !contactMergeFields[match[1]] {
+					unknown["contact."+match[1]] = true
+				}
+			}
+		}
+	}
+
+	collect(subject)
+	walkBlockText(tree, collect)
+	for _, condition := range notifuse_mjml.ConditionalConditions(tree) {
+		collect("{% if " + condition + " %}")
+	}
+
+	tags := make([]string, 0, len(unknown))
+	for tag := range unknown {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// contactJSONTemplateFields returns the contact fields holding JSON, the only ones that can hold arrays
+func contactJSONTemplateFields() map[string]bool {
+	fields := map[string]bool{}
+	contactType := reflect.TypeOf(Contact{})
+	jsonType := reflect.TypeOf(&NullableJSON{})
+	for i := 0; i < contactType.NumField(); i++ {
+		field := contactType.Field(i)
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if field.Type == jsonType && name != "" && name != "-" {
+			fields[name] = true
+		}
+	}
+	return fields
+}
+
+// InvalidProductGridSources returns the sources of the product grids of the tree that don't reference
+// an array in a JSON contact field, e.g. contact.custom_json_1.products. Such grids would never render.
+func InvalidProductGridSources(tree notifuse_mjml.EmailBlock) []string {
+	var invalid []string
+	for _, source := range notifuse_mjml.ProductGridSources(tree) {
+		keys := strings.Split(source, ".")
+		if mergeTagPathRegex.FindString(source) != source || len(keys) < 2 || keys[0] != "contact" || !contactJSONFields[keys[1]] {
+			invalid = append(invalid, source)
+		}
+	}
+	return invalid
+}
+
+// InvalidConditions returns the errors of the conditional blocks of the tree whose condition can't be parsed
+func InvalidConditions(tree notifuse_mjml.EmailBlock) []string {
+	var invalid []string
+	for _, condition := range notifuse_mjml.ConditionalConditions(tree) {
+		if err := notifuse_mjml.ParseCondition(condition); err != nil {
+			invalid = append(invalid, err.Error())
+		}
+	}
+	return invalid
+}
+
+var (
+	liquidOutputRegex  = regexp.MustCompile(`(?s)\{\{-?(.*?)-?\}\}`)
+	liquidLocalRegex   = regexp.MustCompile(`\{%-?\s*(?:for|assign|capture)\s+([a-zA-Z_][a-zA-Z0-9_]*)`)
+	liquidDefaultRegex = regexp.MustCompile(`\|\s*default\b`)
+	mergeTagPathRegex  = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*(\.[a-zA-Z_][a-zA-Z0-9_]*)*`)
+	liquidLiterals     = map[string]bool{"true": true, "false": true, "nil": true, "null": true, "empty": true, "blank": true}
+)
+
+// UnresolvedMergeTags returns the merge tags output by the subject and the visual editor tree, e.g.
+// "contact.first_name" in {{ contact.first_name }}, that have no value in the template data and render
+// blank. Tags with a default filter and the variables defined by for, assign and capture tags are ignored.
+func UnresolvedMergeTags(subject string, tree notifuse_mjml.EmailBlock, data map[string]interface{}) []string {
+	var texts []string
+	collect := func(text string) {
+		texts = append(texts, text)
+	}
+	collect(subject)
+	walkBlockText(tree, collect)
+
+	// The cards of product grids see their item as product
+	locals := map[string]bool{"forloop": true, notifuse_mjml.ProductGridItemVariable: true}
+	for _, text := range texts {
+		for _, match := range liquidLocalRegex.FindAllStringSubmatch(text, -1) {
+			locals[match[1]] = true
+		}
+	}
+
+	unresolved := map[string]bool{}
+	for _, text := range texts {
+		for _, match := range liquidOutputRegex.FindAllStringSubmatch(text, -1) {
+			expression := strings.TrimSpace(match[1])
+			path := mergeTagPathRegex.FindString(expression)
+			if path == "" || liquidLiterals[path] || locals[strings.Split(path, ".")[0]] || liquidDefaultRegex.MatchString(expression) {
+				continue
+			}
+			if isEmptyMergeValue(lookupMergeField(data, path)) {
+				unresolved[path] = true
+			}
+		}
+	}
+
+	tags := make([]string, 0, len(unresolved))
+	for tag := range unresolved {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	return tags
+}
+
+// walkBlockText calls fn with the content and string attributes of every block of the tree
+func walkBlockText(block notifuse_mjml.EmailBlock, fn func(string)) {
+	if block == nil {
+		return
+	}
+	if content := block.GetContent(); content != nil {
+		fn(*content)
+	}
+	for _, value := range block.GetAttributes() {
+		if text, ok := value.(string); ok {
+			fn(text)
+		}
+	}
+	for _, child := range block.GetChildren() {
+		walkBlockText(child, fn)
+	}
+}
@@ -0,0 +1,278 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/Notifuse/notifuse/pkg/notifuse_mjml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPersonalizationRules_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		rules   PersonalizationRules
+		wantErr string
+	}{
+		{
+			name: "valid skip policy",
+			rules: PersonalizationRules{
+				Policy:         PersonalizationPolicySkip,
+				RequiredFields: []RequiredMergeField{{Path: "contact.first_name"}},
+			},
+		},
+		{
+			name: "valid fallback policy",
+			rules: PersonalizationRules{
+				Policy:         PersonalizationPolicyFallback,
+				RequiredFields: []RequiredMergeField{{Path: "contact.first_name", Fallback: "there"}},
+			},
+		},
+		{
+			name: "unknown policy",
+			rules: PersonalizationRules{
+				Policy:         "ignore",
+				RequiredFields: []RequiredMergeField{{Path: "contact.first_name"}},
+			},
+			wantErr: "policy must be",
+		},
+		{
+			name:    "no required fields",
+			rules:   PersonalizationRules{Policy: PersonalizationPolicySkip},
+			wantErr: "at least one required field",
+		},
+		{
+			name: "invalid path",
+			rules: PersonalizationRules{
+				Policy:         PersonalizationPolicySkip,
+				RequiredFields: []RequiredMergeField{{Path: "contact..first_name"}},
+			},
+			wantErr: "invalid field path",
+		},
+		{
+			name: "fallback policy without fallback",
+			rules: PersonalizationRules{
+				Policy:         PersonalizationPolicyFallback,
+				RequiredFields: []RequiredMergeField{{Path: "contact.first_name", Fallback: " "}},
+			},
+			wantErr: "requires a fallback",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.rules.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestPersonalizationRules_Apply(t *testing.T) {
+	newData := func() MapOfAny {
+		return MapOfAny{
+			"contact": map[string]interface{}{
+				"email":      "john@example.com",
+				"first_name": "  ",
+				"last_name":  "Doe",
+			},
+		}
+	}
+	fields := []RequiredMergeField{
+		{Path: "contact.first_name", Fallback: "there"},
+		{Path: "contact.last_name", Fallback: "customer"},
+		{Path: "company.name", Fallback: "your company"},
+	}
+
+	t.Run("skip policy reports empty fields", func(t *testing.T) {
+		rules := PersonalizationRules{Policy: PersonalizationPolicySkip, RequiredFields: fields}
+		data := newData()
+
+		empty, err := rules.Apply(data)
+
+		var skipErr *ErrRequiredMergeFieldsEmpty
+		require.ErrorAs(t, err, &skipErr)
+		assert.Equal(t, []string{"contact.first_name", "company.name"}, skipErr.Fields)
+		assert.Equal(t, skipErr.Fields, empty)
+		assert.Equal(t, "required merge fields are empty: contact.first_name, company.name", err.Error())
+		assert.Equal(t, newData(), data)
+	})
+
+	t.Run("fallback policy fills empty fields", func(t *testing.T) {
+		rules := PersonalizationRules{Policy: PersonalizationPolicyFallback, RequiredFields: fields}
+		data := newData()
+
+		empty, err := rules.Apply(data)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"contact.first_name", "company.name"}, empty)
+		contact := data["contact"].(map[string]interface{})
+		assert.Equal(t, "there", contact["first_name"])
+		assert.Equal(t, "Doe", contact["last_name"])
+		assert.Equal(t, "your company", data["company"].(map[string]interface{})["name"])
+	})
+
+	t.Run("complete data is left untouched", func(t *testing.T) {
+		rules := PersonalizationRules{
+			Policy:         PersonalizationPolicySkip,
+			RequiredFields: []RequiredMergeField{{Path: "contact.last_name"}},
+		}
+
+		empty, err := rules.Apply(newData())
+
+		require.NoError(t, err)
+		assert.Empty(t, empty)
+	})
+}
+
+func TestEmailTemplate_Validate_Personalization(t *testing.T) {
+	template := EmailTemplate{
+		Subject:          "Hi {{ contact.first_name }}",
+		CompiledPreview:  "<mjml></mjml>",
+		VisualEditorTree: createValidMJMLBlock(),
+		Personalization:  &PersonalizationRules{Policy: PersonalizationPolicyFallback, RequiredFields: []RequiredMergeField{{Path: "contact.first_name"}}},
+	}
+
+	err := template.Validate(nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "requires a fallback")
+}
+
+func TestUnknownContactMergeTags(t *testing.T) {
+	textBase := notifuse_mjml.NewBaseBlock("text", notifuse_mjml.MJMLComponentMjText)
+	textContent := `<p>Hi {{ contact.first_name | default: "there" }} {{contact.nickname}}</p>
+{% if contact.custom_number_1 > 10 and contact.loyalty_tier %}VIP{% endif %}
+<p>Reach us at contact.support@example.com</p>`
+	textBase.Content = &textContent
+
+	buttonBase := notifuse_mjml.NewBaseBlock("button", notifuse_mjml.MJMLComponentMjButton)
+	buttonBase.Attributes = map[string]interface{}{"href": "https://example.com/?plan={{ contact.custom_json_1.plan }}&ref={{ contact.referrer }}"}
+
+	rootBase := notifuse_mjml.NewBaseBlock("root", notifuse_mjml.MJMLComponentMjml)
+	rootBase.Children = []notifuse_mjml.EmailBlock{
+		&notifuse_mjml.MJTextBlock{BaseBlock: textBase},
+		&notifuse_mjml.MJButtonBlock{BaseBlock: buttonBase},
+	}
+	tree := &notifuse_mjml.MJMLBlock{BaseBlock: rootBase}
+
+	t.Run("lists unknown fields sorted and deduplicated", func(t *testing.T) {
+		tags := UnknownContactMergeTags("Hello {{ contact.nickname }} from {{ contact.company }}", tree)
+		assert.Equal(t, []string{"contact.company", "contact.loyalty_tier", "contact.nickname", "contact.referrer"}, tags)
+	})
+
+	t.Run("known fields only", func(t *testing.T) {
+		tags := UnknownContactMergeTags("Hi {{ contact.first_name }}, your {{ contact.custom_string_1 }} for {{ contact.email }}", nil)
+		assert.Empty(t, tags)
+	})
+}
+
+func TestUnresolvedMergeTags(t *testing.T) {
+	textBase := notifuse_mjml.NewBaseBlock("text", notifuse_mjml.MJMLComponentMjText)
+	textContent := `<p>Hi {{ contact.first_name }} {{contact.last_name | upcase}}, {{ contact.company | default: "friend" }}</p>
+{% for product in products %}{{ product.name }} {{ forloop.index }}{% endfor %}
+{% assign greeting = "Hello" %}{{ greeting }} {{ "literal" }} {{ 42 }} {{ true }}`
+	textBase.Content = &textContent
+
+	buttonBase := notifuse_mjml.NewBaseBlock("button", notifuse_mjml.MJMLComponentMjButton)
+	buttonBase.Attributes = map[string]interface{}{"href": "https://example.com/?plan={{ contact.custom_json_1.plan }}"}
+
+	// The tags of nested blocks are collected too
+	columnBase := notifuse_mjml.NewBaseBlock("column", notifuse_mjml.MJMLComponentMjColumn)
+	columnBase.Children = []notifuse_mjml.EmailBlock{&notifuse_mjml.MJButtonBlock{BaseBlock: buttonBase}}
+	rootBase := notifuse_mjml.NewBaseBlock("root", notifuse_mjml.MJMLComponentMjml)
+	rootBase.Children = []notifuse_mjml.EmailBlock{
+		&notifuse_mjml.MJTextBlock{BaseBlock: textBase},
+		&notifuse_mjml.MJColumnBlock{BaseBlock: columnBase},
+	}
+	tree := &notifuse_mjml.MJMLBlock{BaseBlock: rootBase}
+
+	t.Run("lists the tags without value sorted and deduplicated", func(t *testing.T) {
+		data := map[string]interface{}{
+			"contact": MapOfAny{"first_name": "John", "last_name": "  "},
+		}
+
+		tags := UnresolvedMergeTags("{{ contact.first_name }}, your {{ offer.code }} and {{ offer.code }}", tree, data)
+		assert.Equal(t, []string{"contact.custom_json_1.plan", "contact.last_name", "offer.code"}, tags)
+	})
+
+	t.Run("every tag resolved", func(t *testing.T) {
+		data := map[string]interface{}{
+			"contact": MapOfAny{
+				"first_name":    "John",
+				"last_name":     "Doe",
+				"custom_json_1": map[string]interface{}{"plan": "pro"},
+			},
+		}
+
+		assert.Empty(t, UnresolvedMergeTags("Hi {{ contact.first_name }}", tree, data))
+	})
+}
+
+func TestInvalidProductGridSources(t *testing.T) {
+	grid := func(id, source string) notifuse_mjml.EmailBlock {
+		base := notifuse_mjml.NewBaseBlock(id, notifuse_mjml.MJMLComponentProductGrid)
+		base.Attributes = map[string]interface{}{"source": source}
+		return &notifuse_mjml.MJProductGridBlock{BaseBlock: base}
+	}
+	newTree := func(grids ...notifuse_mjml.EmailBlock) notifuse_mjml.EmailBlock {
+		bodyBase := notifuse_mjml.NewBaseBlock("body", notifuse_mjml.MJMLComponentMjBody)
+		bodyBase.Children = grids
+		rootBase := notifuse_mjml.NewBaseBlock("root", notifuse_mjml.MJMLComponentMjml)
+		rootBase.Children = []notifuse_mjml.EmailBlock{&notifuse_mjml.MJBodyBlock{BaseBlock: bodyBase}}
+		return &notifuse_mjml.MJMLBlock{BaseBlock: rootBase}
+	}
+
+	t.Run("JSON contact fields", func(t *testing.T) {
+		tree := newTree(grid("grid1", "contact.custom_json_1.recommended_products"), grid("grid2", "{{ contact.custom_json_5 }}"))
+		assert.Empty(t, InvalidProductGridSources(tree))
+	})
+
+	t.Run("sources that can't hold an array", func(t *testing.T) {
+		tree := newTree(
+			grid("grid1", ""),
+			grid("grid2", "contact.recommended_products"),
+			grid("grid3", "contact.first_name"),
+			grid("grid4", "products"),
+			grid("grid5", "contact.custom_json_1 | first"),
+		)
+		assert.Equal(t, []string{"", "contact.recommended_products", "contact.first_name", "products", "contact.custom_json_1 | first"}, InvalidProductGridSources(tree))
+	})
+
+	t.Run("card items are local variables", func(t *testing.T) {
+		base := notifuse_mjml.NewBaseBlock("grid", notifuse_mjml.MJMLComponentProductGrid)
+		base.Attributes = map[string]interface{}{
+			"source":       "contact.custom_json_1.products",
+			"cardTemplate": "<p>{{ product.name }}</p>",
+		}
+		tree := newTree(&notifuse_mjml.MJProductGridBlock{BaseBlock: base})
+
+		assert.Empty(t, UnresolvedMergeTags("", tree, map[string]interface{}{}))
+	})
+}
+
+func TestInvalidConditions(t *testing.T) {
+	conditional := func(id, condition string) notifuse_mjml.EmailBlock {
+		base := notifuse_mjml.NewBaseBlock(id, notifuse_mjml.MJMLComponentConditional)
+		base.Attributes = map[string]interface{}{"condition": condition}
+		return &notifuse_mjml.MJConditionalBlock{BaseBlock: base}
+	}
+	bodyBase := notifuse_mjml.NewBaseBlock("body", notifuse_mjml.MJMLComponentMjBody)
+	bodyBase.Children = []notifuse_mjml.EmailBlock{
+		conditional("valid", "contact.custom_number_1 > 100"),
+		conditional("dangling", "contact.custom_number_1 >"),
+		conditional("empty", ""),
+	}
+	tree := &notifuse_mjml.MJBodyBlock{BaseBlock: bodyBase}
+
+	invalid := InvalidConditions(tree)
+	require.Len(t, invalid, 2)
+	assert.Contains(t, invalid[0], `invalid condition "contact.custom_number_1 >"`)
+	assert.Contains(t, invalid[1], "condition is empty")
+
+	// Conditions are checked for unknown contact fields like the other merge tags
+	assert.Equal(t, []string{"contact.lifetime_value"}, UnknownContactMergeTags("", conditional("ltv", "contact.lifetime_value > 100")))
+}
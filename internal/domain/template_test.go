@@ -4,11 +4,13 @@ import (
 	"encoding/json"
 	"net/url"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/Notifuse/notifuse/pkg/notifuse_mjml"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // createValidMJMLBlock creates a valid MJML EmailBlock for testing EmailTemplate
@@ -731,6 +733,69 @@ func TestWebTemplate_UnmarshalJSON(t *testing.T) {
 	})
 }
 
+func TestSMSTemplate_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		template *SMSTemplate
+		wantErr  bool
+	}{
+		{
+			name:     "valid sms template",
+			template: &SMSTemplate{Body: "Hello {{ contact.first_name }}"},
+			wantErr:  false,
+		},
+		{
+			name:     "invalid sms template - empty body",
+			template: &SMSTemplate{Body: "   "},
+			wantErr:  true,
+		},
+		{
+			name:     "invalid sms template - body too long",
+			template: &SMSTemplate{Body: strings.Repeat("a", SMSMaxBodyLength+1)},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.template.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestSMSTemplate_Scan_Value(t *testing.T) {
+	sms := SMSTemplate{Body: "Hello"}
+
+	value, err := sms.Value()
+	require.NoError(t, err)
+
+	scanned := &SMSTemplate{}
+	require.NoError(t, scanned.Scan(value))
+	assert.Equal(t, "Hello", scanned.Body)
+
+	require.NoError(t, (&SMSTemplate{}).Scan(nil))
+}
+
+func TestTemplate_Validate_SMSChannel(t *testing.T) {
+	template := &Template{
+		ID:       "sms-template",
+		Name:     "SMS Template",
+		Version:  1,
+		Channel:  ChannelSMS,
+		Category: string(TemplateCategoryMarketing),
+		SMS:      &SMSTemplate{Body: "Hello"},
+	}
+	assert.NoError(t, template.Validate())
+
+	template.SMS = nil
+	assert.ErrorContains(t, template.Validate(), "sms is required for channel 'sms'")
+}
+
 func TestCreateTemplateRequest_Validate(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -1744,3 +1809,360 @@ func TestEmailTemplate_UnmarshalJSON_Minimal_ExistingFile(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 }
+
+func TestLanguageFallbackChain(t *testing.T) {
+	assert.Equal(t, []string{"pt-br", "pt"}, LanguageFallbackChain("pt-BR"))
+	assert.Equal(t, []string{"zh-hant-tw", "zh-hant", "zh"}, LanguageFallbackChain("zh_Hant_TW"))
+	assert.Equal(t, []string{"fr"}, LanguageFallbackChain(" FR "))
+	assert.Nil(t, LanguageFallbackChain(""))
+}
+
+func TestEmailTemplate_ForLanguage(t *testing.T) {
+	base := &EmailTemplate{
+		SenderID: "sender-default",
+		ReplyTo:  "support@example.com",
+		Subject:  "Welcome",
+		Translations: map[string]*EmailTemplate{
+			"pt":    {Subject: "Bem-vindo"},
+			"fr-CA": {Subject: "Bienvenue", SenderID: "sender-quebec"},
+		},
+	}
+
+	testCases := []struct {
+		name            string
+		language        string
+		expectedSubject string
+	}{
+		{name: "exact match", language: "fr-CA", expectedSubject: "Bienvenue"},
+		{name: "exact match is case-insensitive", language: "fr_ca", expectedSubject: "Bienvenue"},
+		{name: "pt-BR falls back to pt", language: "pt-BR", expectedSubject: "Bem-vindo"},
+		{name: "base language", language: "pt", expectedSubject: "Bem-vindo"},
+		{name: "base language does not match regional variant", language: "fr", expectedSubject: "Welcome"},
+		{name: "unknown language falls back to default", language: "de-DE", expectedSubject: "Welcome"},
+		{name: "no language uses default", language: "", expectedSubject: "Welcome"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expectedSubject, base.ForLanguage(tc.language).Subject)
+		})
+	}
+
+	t.Run("variant inherits sender and reply-to", func(t *testing.T) {
+		localized := base.ForLanguage("pt-BR")
+		assert.Equal(t, "sender-default", localized.SenderID)
+		assert.Equal(t, "support@example.com", localized.ReplyTo)
+		// The stored translation is not mutated
+		assert.Empty(t, base.Translations["pt"].SenderID)
+
+		assert.Equal(t, "sender-quebec", base.ForLanguage("fr-CA").SenderID)
+	})
+
+	t.Run("variant records the translation it was localized to", func(t *testing.T) {
+		assert.Equal(t, "pt", base.ForLanguage("pt-BR").Language)
+		assert.Equal(t, "fr-ca", base.ForLanguage("fr_CA").Language)
+		assert.Empty(t, base.ForLanguage("de").Language)
+	})
+}
+
+func TestTemplate_ForContact(t *testing.T) {
+	template := &Template{
+		ID: "welcome",
+		Email: &EmailTemplate{
+			Subject:      "Welcome",
+			Translations: map[string]*EmailTemplate{"pt": {Subject: "Bem-vindo"}},
+		},
+	}
+
+	localized := template.ForContact(&Contact{Language: &NullableString{String: "pt-BR"}})
+	assert.Equal(t, "Bem-vindo", localized.Email.Subject)
+	assert.Equal(t, "welcome", localized.ID)
+	assert.Equal(t, "Welcome", template.Email.Subject)
+
+	assert.Same(t, template, template.ForContact(&Contact{Language: &NullableString{String: "ja"}}))
+	assert.Same(t, template, template.ForContact(&Contact{}))
+	assert.Same(t, template, template.ForContact(nil))
+}
+
+func TestEmailTemplate_Validate_Translations(t *testing.T) {
+	newEmail := func(translations map[string]*EmailTemplate) *EmailTemplate {
+		return &EmailTemplate{
+			Subject:          "Welcome",
+			CompiledPreview:  "<html></html>",
+			VisualEditorTree: createValidMJMLBlock(),
+			Translations:     translations,
+		}
+	}
+	translation := func(subject string) *EmailTemplate {
+		return &EmailTemplate{
+			Subject:          subject,
+			CompiledPreview:  "<html></html>",
+			VisualEditorTree: createValidMJMLBlock(),
+		}
+	}
+
+	assert.NoError(t, newEmail(map[string]*EmailTemplate{"pt-BR": translation("Bem-vindo")}).Validate(nil))
+	assert.Error(t, newEmail(map[string]*EmailTemplate{"portuguese!": translation("Bem-vindo")}).Validate(nil))
+	assert.Error(t, newEmail(map[string]*EmailTemplate{"pt": translation("")}).Validate(nil))
+	assert.Error(t, newEmail(map[string]*EmailTemplate{"pt": nil}).Validate(nil))
+}
+
+func TestEmailTemplate_ApplyTrackingOverrides(t *testing.T) {
+	html := `<html><body><a href="https://example.com/offer">Offer</a></body></html>`
+
+	render := func(t *testing.T, workspace WorkspaceSettings, email *EmailTemplate) string {
+		trackingSettings := notifuse_mjml.TrackingSettings{
+			Endpoint:    "https://api.example.com",
+			WorkspaceID: "ws1",
+			MessageID:   "msg1",
+		}
+		workspace.ApplyTrackingDefaults(&trackingSettings)
+		email.ApplyTrackingOverrides(&trackingSettings)
+
+		tracked, err := notifuse_mjml.TrackLinks(html, trackingSettings)
+		require.NoError(t, err)
+		return tracked
+	}
+
+	t.Run("open off and click on defaults only track clicks", func(t *testing.T) {
+		workspace := WorkspaceSettings{
+			EmailTrackingEnabled: true,
+			OpenTrackingDefault:  boolPtr(false),
+			ClickTrackingDefault: boolPtr(true),
+		}
+
+		tracked := render(t, workspace, &EmailTemplate{})
+		assert.Contains(t, tracked, "https://api.example.com/visit?mid=msg1&wid=ws1")
+		assert.NotContains(t, tracked, "/opens?")
+	})
+
+	t.Run("open on and click off defaults only inject the pixel", func(t *testing.T) {
+		workspace := WorkspaceSettings{
+			OpenTrackingDefault:  boolPtr(true),
+			ClickTrackingDefault: boolPtr(false),
+		}
+
+		tracked := render(t, workspace, &EmailTemplate{})
+		assert.Contains(t, tracked, `<a href="https://example.com/offer">`)
+		assert.Contains(t, tracked, "https://api.example.com/opens?mid=msg1&wid=ws1")
+		assert.NotContains(t, tracked, "/visit?")
+	})
+
+	t.Run("template overrides the workspace defaults", func(t *testing.T) {
+		workspace := WorkspaceSettings{
+			OpenTrackingDefault:  boolPtr(false),
+			ClickTrackingDefault: boolPtr(true),
+		}
+
+		tracked := render(t, workspace, &EmailTemplate{OpenTracking: boolPtr(true), ClickTracking: boolPtr(false)})
+		assert.Contains(t, tracked, "/opens?")
+		assert.NotContains(t, tracked, "/visit?")
+	})
+
+	t.Run("legacy EmailTrackingEnabled off disables both", func(t *testing.T) {
+		tracked := render(t, WorkspaceSettings{EmailTrackingEnabled: false}, &EmailTemplate{})
+		assert.Equal(t, html, tracked)
+	})
+
+	t.Run("template opt-outs win over defaults and overrides", func(t *testing.T) {
+		workspace := WorkspaceSettings{EmailTrackingEnabled: true}
+		email := &EmailTemplate{
+			OpenTracking:         boolPtr(true),
+			ClickTracking:        boolPtr(true),
+			DisableOpenTracking:  true,
+			DisableClickTracking: true,
+		}
+
+		// No pixel and the anchor keeps its original href
+		tracked := render(t, workspace, email)
+		assert.Equal(t, html, tracked)
+
+		email.DisableOpenTracking = false
+		tracked = render(t, workspace, email)
+		assert.Contains(t, tracked, "/opens?")
+		assert.Contains(t, tracked, `<a href="https://example.com/offer">`)
+	})
+
+	t.Run("translations keep the template opt-outs", func(t *testing.T) {
+		email := &EmailTemplate{
+			DisableOpenTracking:  true,
+			DisableClickTracking: true,
+			Translations:         map[string]*EmailTemplate{"fr": {Subject: "Réinitialiser le mot de passe"}},
+		}
+
+		localized := email.ForLanguage("fr")
+		require.NotSame(t, email, localized)
+		assert.True(t, localized.DisableOpenTracking)
+		assert.True(t, localized.DisableClickTracking)
+		assert.Equal(t, html, render(t, WorkspaceSettings{EmailTrackingEnabled: true}, localized))
+	})
+}
+
+func TestEmailTemplate_HasUnsubscribeLink(t *testing.T) {
+	treeWith := func(blocks ...notifuse_mjml.EmailBlock) notifuse_mjml.EmailBlock {
+		columnBase := notifuse_mjml.NewBaseBlock("column", notifuse_mjml.MJMLComponentMjColumn)
+		columnBase.Children = blocks
+		rootBase := notifuse_mjml.NewBaseBlock("root", notifuse_mjml.MJMLComponentMjml)
+		rootBase.Children = []notifuse_mjml.EmailBlock{&notifuse_mjml.MJColumnBlock{BaseBlock: columnBase}}
+		return &notifuse_mjml.MJMLBlock{BaseBlock: rootBase}
+	}
+	text := func(content string) notifuse_mjml.EmailBlock {
+		base := notifuse_mjml.NewBaseBlock("text", notifuse_mjml.MJMLComponentMjText)
+		base.Content = &content
+		return &notifuse_mjml.MJTextBlock{BaseBlock: base}
+	}
+	button := func(href string) notifuse_mjml.EmailBlock {
+		base := notifuse_mjml.NewBaseBlock("button", notifuse_mjml.MJMLComponentMjButton)
+		base.Attributes = map[string]interface{}{"href": href}
+		return &notifuse_mjml.MJButtonBlock{BaseBlock: base}
+	}
+
+	tests := []struct {
+		name     string
+		email    *EmailTemplate
+		expected bool
+	}{
+		{"link in a nested text block", &EmailTemplate{VisualEditorTree: treeWith(text(`<a href="{{ unsubscribe_url }}">Unsubscribe</a>`))}, true},
+		{"one-click link in a button", &EmailTemplate{VisualEditorTree: treeWith(button("{{oneclick_unsubscribe_url}}"))}, true},
+		{"notification center link", &EmailTemplate{VisualEditorTree: treeWith(text(`<a href="{{ notification_center_url }}">Preferences</a>`))}, true},
+		{"link in the plain text version", &EmailTemplate{VisualEditorTree: treeWith(text("Hello")), Text: stringPtr("Unsubscribe: {{ unsubscribe_url }}")}, true},
+		{"no unsubscribe merge tag", &EmailTemplate{VisualEditorTree: treeWith(text("Hello {{ contact.first_name }}, unsubscribe anytime"))}, false},
+		{"no content", &EmailTemplate{}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.email.HasUnsubscribeLink())
+		})
+	}
+}
+
+func TestTemplate_WithTrackingDefaults(t *testing.T) {
+	workspace := &WorkspaceSettings{
+		OpenTrackingDefault:  boolPtr(false),
+		ClickTrackingDefault: boolPtr(true),
+	}
+
+	template := &Template{ID: "tpl1", Email: &EmailTemplate{Subject: "Hi", ClickTracking: boolPtr(false)}}
+	withDefaults := template.WithTrackingDefaults(workspace)
+
+	require.NotNil(t, withDefaults.Email.OpenTracking)
+	assert.False(t, *withDefaults.Email.OpenTracking)
+	// The template override is kept
+	require.NotNil(t, withDefaults.Email.ClickTracking)
+	assert.False(t, *withDefaults.Email.ClickTracking)
+	// The original template is left untouched
+	assert.Nil(t, template.Email.OpenTracking)
+
+	// Translations inherit the resolved tracking of the default content
+	template.Email.Translations = map[string]*EmailTemplate{"fr": {Subject: "Salut"}}
+	localized := template.WithTrackingDefaults(workspace).ForLanguage("fr")
+	require.NotNil(t, localized.Email.OpenTracking)
+	assert.False(t, *localized.Email.OpenTracking)
+	require.NotNil(t, localized.Email.ClickTracking)
+	assert.False(t, *localized.Email.ClickTracking)
+
+	noEmail := &Template{ID: "tpl2"}
+	assert.Same(t, noEmail, noEmail.WithTrackingDefaults(workspace))
+}
+
+func TestEmailTemplate_ProviderTags(t *testing.T) {
+	t.Run("apply merges template tags into email options", func(t *testing.T) {
+		email := &EmailTemplate{ProviderTags: map[string]string{"campaign": "spring-sale"}}
+		opts := EmailOptions{ReplyTo: "reply@example.com", ProviderTags: map[string]string{"source": "api"}}
+
+		email.ApplyProviderTags(&opts)
+
+		assert.Equal(t, map[string]string{"campaign": "spring-sale", "source": "api"}, opts.ProviderTags)
+		assert.Equal(t, "reply@example.com", opts.ReplyTo)
+		assert.Len(t, email.ProviderTags, 1)
+	})
+
+	t.Run("apply without tags leaves options untouched", func(t *testing.T) {
+		opts := EmailOptions{}
+		(&EmailTemplate{}).ApplyProviderTags(&opts)
+		assert.Nil(t, opts.ProviderTags)
+	})
+
+	t.Run("translations inherit provider tags", func(t *testing.T) {
+		base := &EmailTemplate{
+			Subject:      "Welcome",
+			ProviderTags: map[string]string{"campaign": "welcome"},
+			Translations: map[string]*EmailTemplate{
+				"pt": {Subject: "Bem-vindo"},
+				"fr": {Subject: "Bienvenue", ProviderTags: map[string]string{"campaign": "bienvenue"}},
+			},
+		}
+		assert.Equal(t, "welcome", base.ForLanguage("pt").ProviderTags["campaign"])
+		assert.Equal(t, "bienvenue", base.ForLanguage("fr").ProviderTags["campaign"])
+	})
+
+	t.Run("validate rejects reserved and oversize tag sets", func(t *testing.T) {
+		newEmail := func(tags map[string]string) *EmailTemplate {
+			return &EmailTemplate{
+				Subject:          "Welcome",
+				CompiledPreview:  "<html></html>",
+				VisualEditorTree: createValidMJMLBlock(),
+				ProviderTags:     tags,
+			}
+		}
+		tooMany := make(map[string]string)
+		for i := 0; i <= MaxProviderTags; i++ {
+			tooMany["tag_"+strconv.Itoa(i)] = "value"
+		}
+
+		assert.NoError(t, newEmail(map[string]string{"campaign": "spring-sale"}).Validate(nil))
+		assert.Error(t, newEmail(map[string]string{"": "value"}).Validate(nil))
+		assert.Error(t, newEmail(map[string]string{ProviderTagMessageIDKey: "value"}).Validate(nil))
+		assert.Error(t, newEmail(tooMany).Validate(nil))
+	})
+
+	t.Run("validate for the limits of the providers", func(t *testing.T) {
+		email := &EmailTemplate{
+			ProviderTags: map[string]string{"campaign": "spring-sale"},
+			Translations: map[string]*EmailTemplate{
+				"fr": {ProviderTags: map[string]string{"campaign_identifier_fr": "soldes"}},
+			},
+		}
+
+		assert.NoError(t, email.ValidateProviderTagsFor(EmailProviderKindSES, EmailProviderKindSMTP))
+
+		// The translation key exceeds the 20 characters of a Postmark metadata key
+		err := email.ValidateProviderTagsFor(EmailProviderKindSES, EmailProviderKindPostmark)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "translation fr: provider tags: Postmark metadata key")
+
+		email.ProviderTags["campaign"] = "spring sale"
+		err = email.ValidateProviderTagsFor(EmailProviderKindSES)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "SES tag")
+	})
+}
+
+func TestEmailTemplate_RawMergeFields(t *testing.T) {
+	t.Run("validate accepts contact fields only", func(t *testing.T) {
+		newEmail := func(fields ...string) *EmailTemplate {
+			return &EmailTemplate{
+				Subject:          "Welcome",
+				CompiledPreview:  "<html></html>",
+				VisualEditorTree: createValidMJMLBlock(),
+				RawMergeFields:   fields,
+			}
+		}
+
+		assert.NoError(t, newEmail("custom_string_1", "custom_json_2").Validate(nil))
+		err := newEmail("nickname").Validate(nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `raw merge field "nickname" is not a contact field`)
+	})
+
+	t.Run("translations inherit raw merge fields", func(t *testing.T) {
+		base := &EmailTemplate{
+			Subject:        "Welcome",
+			RawMergeFields: []string{"custom_string_1"},
+			Translations: map[string]*EmailTemplate{
+				"pt": {Subject: "Bem-vindo"},
+			},
+		}
+		assert.Equal(t, []string{"custom_string_1"}, base.ForLanguage("pt").RawMergeFields)
+	})
+}
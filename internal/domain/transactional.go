@@ -109,15 +109,19 @@ type TransactionalNotificationUpdateParams struct {
 
 // TransactionalNotificationSendParams contains the parameters for sending a transactional notification
 type TransactionalNotificationSendParams struct {
-	ID           string                 `json:"id" validate:"required"`      // ID of the notification to send
-	ExternalID   *string                `json:"external_id,omitempty"`       // External ID for idempotency checks
-	Contact      *Contact               `json:"contact" validate:"required"` // Contact to send the notification to
-	Channels     []TransactionalChannel `json:"channels,omitempty"`          // Specific channels to send through (if empty, use all configured channels)
-	Data         MapOfAny               `json:"data,omitempty"`              // Data to populate the template with
-	Metadata     MapOfAny               `json:"metadata,omitempty"`          // Additional metadata for tracking
-	EmailOptions EmailOptions           `json:"email_options,omitempty"`     // Email options for the notification
+	ID             string                 `json:"id" validate:"required"`      // ID of the notification to send
+	ExternalID     *string                `json:"external_id,omitempty"`       // External ID for idempotency checks
+	IdempotencyKey *string                `json:"idempotency_key,omitempty"`   // Key deduplicating retried sends within TransactionalIdempotencyKeyTTL
+	Contact        *Contact               `json:"contact" validate:"required"` // Contact to send the notification to
+	Channels       []TransactionalChannel `json:"channels,omitempty"`          // Specific channels to send through (if empty, use all configured channels)
+	Data           MapOfAny               `json:"data,omitempty"`              // Data to populate the template with
+	Metadata       MapOfAny               `json:"metadata,omitempty"`          // Additional metadata for tracking
+	EmailOptions   EmailOptions           `json:"email_options,omitempty"`     // Email options for the notification
 }
 
+// TransactionalIdempotencyKeyTTL is how long an idempotency key returns the message it first sent
+const TransactionalIdempotencyKeyTTL = 24 * time.Hour
+
 // TestTemplateRequest represents a request to test a template
 type TestTemplateRequest struct {
 	WorkspaceID    string       `json:"workspace_id"`
@@ -368,6 +372,10 @@ func (req *SendTransactionalRequest) Validate() error {
 		return NewValidationError("notification must have at least one channel")
 	}
 
+	if req.Notification.IdempotencyKey != nil && len(*req.Notification.IdempotencyKey) > 255 {
+		return NewValidationError("notification.idempotency_key must be at most 255 characters")
+	}
+
 	// validate optional cc and bcc
 	for _, cc := range req.Notification.EmailOptions.CC {
 		if !govalidator.IsEmail(cc) {
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
 	"time"
 
@@ -769,6 +770,22 @@ func TestSendTransactionalRequest_Validate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "notification must have at least one channel",
 		},
+		{
+			name: "idempotency key too long",
+			req: SendTransactionalRequest{
+				WorkspaceID: "workspace-123",
+				Notification: TransactionalNotificationSendParams{
+					ID: "notification-456",
+					Contact: &Contact{
+						Email: "contact@example.com",
+					},
+					Channels:       []TransactionalChannel{TransactionalChannelEmail},
+					IdempotencyKey: func() *string { key := strings.Repeat("k", 256); return &key }(),
+				},
+			},
+			wantErr: true,
+			errMsg:  "notification.idempotency_key must be at most 255 characters",
+		},
 	}
 
 	for _, tt := range tests {
@@ -21,11 +21,12 @@ type TreeNodeBranch struct {
 
 // TreeNodeLeaf represents an actual condition on a data source
 type TreeNodeLeaf struct {
-	Source            string                      `json:"source"` // "contacts", "contact_lists", "contact_timeline", "custom_events_goals"
+	Source            string                      `json:"source"` // "contacts", "contact_lists", "contact_timeline", "custom_events_goals", "message_activity"
 	Contact           *ContactCondition           `json:"contact,omitempty"`
 	ContactList       *ContactListCondition       `json:"contact_list,omitempty"`
 	ContactTimeline   *ContactTimelineCondition   `json:"contact_timeline,omitempty"`
 	CustomEventsGoal  *CustomEventsGoalCondition  `json:"custom_events_goal,omitempty"`
+	MessageActivity   *MessageActivityCondition   `json:"message_activity,omitempty"`
 }
 
 // ContactCondition represents filters on the contacts table
@@ -63,6 +64,25 @@ type CustomEventsGoalCondition struct {
 	TimeframeValues   []string `json:"timeframe_values,omitempty"`
 }
 
+// MessageActivityCondition represents conditions on the messages received by a contact, read from message_history,
+// e.g. "opened any message in the last 30 days" or "never clicked"
+type MessageActivityCondition struct {
+	Event    string `json:"event"`          // sent, delivered, opened, clicked, bounced, complained, unsubscribed
+	Operator string `json:"operator"`       // "any": at least one message with the event, "none": no message with the event
+	Days     int    `json:"days,omitempty"` // Only counts the events of the last N days, 0 for anytime
+}
+
+// MessageActivityEvents maps each message activity event to its message_history timestamp column
+var MessageActivityEvents = map[string]string{
+	"sent":         "sent_at",
+	"delivered":    "delivered_at",
+	"opened":       "opened_at",
+	"clicked":      "clicked_at",
+	"bounced":      "bounced_at",
+	"complained":   "complained_at",
+	"unsubscribed": "unsubscribed_at",
+}
+
 // DimensionFilter represents a single filter condition on a field
 type DimensionFilter struct {
 	FieldName    string    `json:"field_name"`
@@ -148,8 +168,13 @@ func (l *TreeNodeLeaf) Validate() error {
 			return fmt.Errorf("leaf with source 'custom_events_goals' must have 'custom_events_goal' field")
 		}
 		return l.CustomEventsGoal.Validate()
+	case "message_activity":
+		if l.MessageActivity == nil {
+			return fmt.Errorf("leaf with source 'message_activity' must have 'message_activity' field")
+		}
+		return l.MessageActivity.Validate()
 	default:
-		return fmt.Errorf("invalid source: %s (must be 'contacts', 'contact_lists', 'contact_timeline', or 'custom_events_goals')", l.Source)
+		return fmt.Errorf("invalid source: %s (must be 'contacts', 'contact_lists', 'contact_timeline', 'custom_events_goals', or 'message_activity')", l.Source)
 	}
 }
 
@@ -290,6 +315,23 @@ func (g *CustomEventsGoalCondition) Validate() error {
 	return nil
 }
 
+// Validate validates message activity conditions
+func (c *MessageActivityCondition) Validate() error {
+	if _, ok := MessageActivityEvents[c.Event]; !ok {
+		return fmt.Errorf("invalid message_activity event: %s (must be 'sent', 'delivered', 'opened', 'clicked', 'bounced', 'complained', or 'unsubscribed')", c.Event)
+	}
+
+	if c.Operator != "any" && c.Operator != "none" {
+		return fmt.Errorf("invalid message_activity operator: %s (must be 'any' or 'none')", c.Operator)
+	}
+
+	if c.Days < 0 {
+		return fmt.Errorf("message_activity days must be non-negative")
+	}
+
+	return nil
+}
+
 // Validate validates a dimension filter
 func (f *DimensionFilter) Validate() error {
 	if f.FieldName == "" {
@@ -465,6 +507,10 @@ func (t *TreeNode) HasRelativeDates() bool {
 				if filter.Operator == "in_the_last_days" {
 					return true
 				}
+				// The engagement score decays every day
+				if filter.FieldName == "engagement_score" {
+					return true
+				}
 			}
 		}
 		// Check custom events goal conditions for relative date operators
@@ -473,6 +519,10 @@ func (t *TreeNode) HasRelativeDates() bool {
 				return true
 			}
 		}
+		// Message activity in the last N days expires as days pass
+		if t.Leaf.MessageActivity != nil && t.Leaf.MessageActivity.Days > 0 {
+			return true
+		}
 		return false
 
 	default:
@@ -680,6 +680,27 @@ func TestTreeNode_HasRelativeDates(t *testing.T) {
 		assert.False(t, node.HasRelativeDates())
 	})
 
+	t.Run("returns true for engagement score filters", func(t *testing.T) {
+		node := &TreeNode{
+			Kind: "leaf",
+			Leaf: &TreeNodeLeaf{
+				Source: "contacts",
+				Contact: &ContactCondition{
+					Filters: []*DimensionFilter{
+						{
+							FieldName:    "engagement_score",
+							FieldType:    "number",
+							Operator:     "gt",
+							NumberValues: []float64{2},
+						},
+					},
+				},
+			},
+		}
+
+		assert.True(t, node.HasRelativeDates())
+	})
+
 	t.Run("returns false for contact conditions without relative dates", func(t *testing.T) {
 		node := &TreeNode{
 			Kind: "leaf",
@@ -1011,3 +1032,71 @@ func TestContactTimelineCondition_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestMessageActivityCondition_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cond    MessageActivityCondition
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name:    "opened any message in the last 30 days",
+			cond:    MessageActivityCondition{Event: "opened", Operator: "any", Days: 30},
+			wantErr: false,
+		},
+		{
+			name:    "never clicked",
+			cond:    MessageActivityCondition{Event: "clicked", Operator: "none"},
+			wantErr: false,
+		},
+		{
+			name:    "unknown event",
+			cond:    MessageActivityCondition{Event: "replied", Operator: "any"},
+			wantErr: true,
+			errMsg:  "invalid message_activity event",
+		},
+		{
+			name:    "unknown operator",
+			cond:    MessageActivityCondition{Event: "opened", Operator: "at_least"},
+			wantErr: true,
+			errMsg:  "invalid message_activity operator",
+		},
+		{
+			name:    "negative days",
+			cond:    MessageActivityCondition{Event: "sent", Operator: "any", Days: -1},
+			wantErr: true,
+			errMsg:  "days must be non-negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cond.Validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+
+	t.Run("leaf requires the condition", func(t *testing.T) {
+		leaf := &TreeNodeLeaf{Source: "message_activity"}
+		err := leaf.Validate()
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "must have 'message_activity' field")
+	})
+
+	t.Run("days make the segment recompute daily", func(t *testing.T) {
+		node := &TreeNode{Kind: "leaf", Leaf: &TreeNodeLeaf{
+			Source:          "message_activity",
+			MessageActivity: &MessageActivityCondition{Event: "opened", Operator: "any", Days: 30},
+		}}
+		assert.True(t, node.HasRelativeDates())
+
+		node.Leaf.MessageActivity = &MessageActivityCondition{Event: "clicked", Operator: "none"}
+		assert.False(t, node.HasRelativeDates())
+	})
+}
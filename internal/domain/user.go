@@ -16,6 +16,9 @@ const (
 	SessionIDKey     contextKey = "session_id"
 	UserTypeKey      contextKey = "type"
 	UserWorkspaceKey contextKey = "user_workspace"
+	// TokenIDKey and TokenExpiresAtKey hold the ID and the expiration of the user token of the request
+	TokenIDKey        contextKey = "token_id"
+	TokenExpiresAtKey contextKey = "token_expires_at"
 )
 
 type UserType string
@@ -81,6 +84,7 @@ type UserServiceInterface interface {
 	GetUserByID(ctx context.Context, userID string) (*User, error)
 	GetUserByEmail(ctx context.Context, email string) (*User, error)
 	Logout(ctx context.Context, userID string) error
+	RefreshToken(ctx context.Context) (*AuthResponse, error)
 }
 
 type UserRepository interface {
@@ -0,0 +1,154 @@
+package domain
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// ErrInvalidWebhookSignature is returned when an incoming provider webhook is unsigned or its signature does not match
+var ErrInvalidWebhookSignature = errors.New("invalid webhook signature")
+
+// snsCertHostPattern matches the hosts AWS serves the SNS signing certificates from
+var snsCertHostPattern = regexp.MustCompile(`^sns\.[a-z0-9-]+\.amazonaws\.com(\.cn)?$`)
+
+// VerifyMailgunWebhookSignature verifies the HMAC-SHA256 of the timestamp and token of a Mailgun webhook
+// with the webhook signing key of the domain
+func VerifyMailgunWebhookSignature(payload []byte, signingKey string) error {
+	var webhook MailgunWebhookPayload
+	if err := json.Unmarshal(payload, &webhook); err != nil {
+		return fmt.Errorf("%w: failed to unmarshal Mailgun payload: %v", ErrInvalidWebhookSignature, err)
+	}
+
+	signature := webhook.Signature
+	if signature.Timestamp == "" || signature.Token == "" || signature.Signature == "" {
+		return fmt.Errorf("%w: Mailgun payload is not signed", ErrInvalidWebhookSignature)
+	}
+
+	mac := hmac.New(sha256.New, []byte(signingKey))
+	mac.Write([]byte(signature.Timestamp + signature.Token))
+	expected := hex.EncodeToString(mac.Sum(nil))
+
+	if !hmac.Equal([]byte(expected), []byte(strings.ToLower(signature.Signature))) {
+		return fmt.Errorf("%w: Mailgun signature mismatch", ErrInvalidWebhookSignature)
+	}
+
+	return nil
+}
+
+// VerifyPostmarkWebhookAuth verifies the basic auth credentials sent by Postmark with a webhook
+func VerifyPostmarkWebhookAuth(username, password string, ok bool, settings *PostmarkSettings) error {
+	if !ok {
+		return fmt.Errorf("%w: Postmark request has no basic auth credentials", ErrInvalidWebhookSignature)
+	}
+
+	usernameMatch := subtle.ConstantTimeCompare([]byte(username), []byte(settings.WebhookUsername))
+	passwordMatch := subtle.ConstantTimeCompare([]byte(password), []byte(settings.WebhookPassword))
+	if usernameMatch&passwordMatch != 1 {
+		return fmt.Errorf("%w: Postmark credentials mismatch", ErrInvalidWebhookSignature)
+	}
+
+	return nil
+}
+
+// ValidateSNSSigningCertURL checks that the signing certificate of an SNS message is served by AWS,
+// so that a forged message cannot bring its own certificate
+func ValidateSNSSigningCertURL(certURL string) error {
+	parsed, err := url.Parse(certURL)
+	if err != nil {
+		return fmt.Errorf("%w: invalid SNS signing certificate URL: %v", ErrInvalidWebhookSignature, err)
+	}
+	if parsed.Scheme != "https" || !snsCertHostPattern.MatchString(parsed.Hostname()) || !strings.HasSuffix(parsed.Path, ".pem") {
+		return fmt.Errorf("%w: untrusted SNS signing certificate URL %q", ErrInvalidWebhookSignature, certURL)
+	}
+	return nil
+}
+
+// SNSStringToSign builds the string AWS signs for an SNS message, its fields depend on the message type
+func SNSStringToSign(message *SESWebhookPayload) (string, error) {
+	var keys []string
+	switch message.Type {
+	case "Notification":
+		keys = []string{"Message", "MessageId", "Subject", "Timestamp", "TopicArn", "Type"}
+	case "SubscriptionConfirmation", "UnsubscribeConfirmation":
+		keys = []string{"Message", "MessageId", "SubscribeURL", "Timestamp", "Token", "TopicArn", "Type"}
+	default:
+		return "", fmt.Errorf("%w: unsupported SNS message type %q", ErrInvalidWebhookSignature, message.Type)
+	}
+
+	values := map[string]string{
+		"Message":      message.Message,
+		"MessageId":    message.MessageID,
+		"Subject":      message.Subject,
+		"SubscribeURL": message.SubscribeURL,
+		"Timestamp":    message.Timestamp,
+		"Token":        message.Token,
+		"TopicArn":     message.TopicARN,
+		"Type":         message.Type,
+	}
+
+	var sb strings.Builder
+	for _, key := range keys {
+		// The subject is only signed when the notification has one
+		if key == "Subject" && values[key] == "" {
+			continue
+		}
+		sb.WriteString(key + "\n" + values[key] + "\n")
+	}
+
+	return sb.String(), nil
+}
+
+// VerifySNSMessageSignature verifies the signature of an SNS message with its signing certificate.
+// Signature version 1 is signed with SHA1, version 2 with SHA256.
+func VerifySNSMessageSignature(message *SESWebhookPayload, cert *x509.Certificate) error {
+	if message.Signature == "" {
+		return fmt.Errorf("%w: SNS message is not signed", ErrInvalidWebhookSignature)
+	}
+
+	stringToSign, err := SNSStringToSign(message)
+	if err != nil {
+		return err
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(message.Signature)
+	if err != nil {
+		return fmt.Errorf("%w: invalid SNS signature encoding: %v", ErrInvalidWebhookSignature, err)
+	}
+
+	publicKey, ok := cert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return fmt.Errorf("%w: SNS signing certificate has no RSA public key", ErrInvalidWebhookSignature)
+	}
+
+	var hash crypto.Hash
+	var digest []byte
+	switch message.SignatureVersion {
+	case "1":
+		sum := sha1.Sum([]byte(stringToSign))
+		hash, digest = crypto.SHA1, sum[:]
+	case "2":
+		sum := sha256.Sum256([]byte(stringToSign))
+		hash, digest = crypto.SHA256, sum[:]
+	default:
+		return fmt.Errorf("%w: unsupported SNS signature version %q", ErrInvalidWebhookSignature, message.SignatureVersion)
+	}
+
+	if err := rsa.VerifyPKCS1v15(publicKey, hash, digest, signature); err != nil {
+		return fmt.Errorf("%w: SNS signature mismatch", ErrInvalidWebhookSignature)
+	}
+
+	return nil
+}
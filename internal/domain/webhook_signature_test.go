@@ -0,0 +1,195 @@
+package domain
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func mailgunTestPayload(signingKey, timestamp, token string) []byte {
+	mac := hmac.New(sha256.New, []byte(signingKey))
+	mac.Write([]byte(timestamp + token))
+	signature := hex.EncodeToString(mac.Sum(nil))
+	return []byte(fmt.Sprintf(`{"signature":{"timestamp":%q,"token":%q,"signature":%q},"event-data":{"event":"delivered"}}`, timestamp, token, signature))
+}
+
+func TestVerifyMailgunWebhookSignature(t *testing.T) {
+	t.Run("valid signature", func(t *testing.T) {
+		payload := mailgunTestPayload("signing-key", "1700000000", "token123")
+		assert.NoError(t, VerifyMailgunWebhookSignature(payload, "signing-key"))
+	})
+
+	t.Run("signed with another key", func(t *testing.T) {
+		payload := mailgunTestPayload("other-key", "1700000000", "token123")
+		err := VerifyMailgunWebhookSignature(payload, "signing-key")
+		assert.ErrorIs(t, err, ErrInvalidWebhookSignature)
+	})
+
+	t.Run("tampered timestamp", func(t *testing.T) {
+		mac := hmac.New(sha256.New, []byte("signing-key"))
+		mac.Write([]byte("1700000000token123"))
+		payload := []byte(fmt.Sprintf(`{"signature":{"timestamp":"1700000999","token":"token123","signature":%q}}`, hex.EncodeToString(mac.Sum(nil))))
+
+		err := VerifyMailgunWebhookSignature(payload, "signing-key")
+		assert.ErrorIs(t, err, ErrInvalidWebhookSignature)
+	})
+
+	t.Run("unsigned payload", func(t *testing.T) {
+		err := VerifyMailgunWebhookSignature([]byte(`{"event-data":{"event":"delivered"}}`), "signing-key")
+		assert.ErrorIs(t, err, ErrInvalidWebhookSignature)
+	})
+
+	t.Run("invalid JSON", func(t *testing.T) {
+		err := VerifyMailgunWebhookSignature([]byte(`not json`), "signing-key")
+		assert.ErrorIs(t, err, ErrInvalidWebhookSignature)
+	})
+}
+
+func TestVerifyPostmarkWebhookAuth(t *testing.T) {
+	settings := &PostmarkSettings{WebhookUsername: "notifuse", WebhookPassword: "s3cret"}
+
+	assert.NoError(t, VerifyPostmarkWebhookAuth("notifuse", "s3cret", true, settings))
+	assert.ErrorIs(t, VerifyPostmarkWebhookAuth("notifuse", "wrong", true, settings), ErrInvalidWebhookSignature)
+	assert.ErrorIs(t, VerifyPostmarkWebhookAuth("other", "s3cret", true, settings), ErrInvalidWebhookSignature)
+	assert.ErrorIs(t, VerifyPostmarkWebhookAuth("", "", false, settings), ErrInvalidWebhookSignature)
+}
+
+func TestValidateSNSSigningCertURL(t *testing.T) {
+	valid := []string{
+		"https://sns.us-east-1.amazonaws.com/SimpleNotificationService-abc.pem",
+		"https://sns.cn-north-1.amazonaws.com.cn/SimpleNotificationService-abc.pem",
+	}
+	for _, certURL := range valid {
+		assert.NoError(t, ValidateSNSSigningCertURL(certURL), certURL)
+	}
+
+	invalid := []string{
+		"",
+		"http://sns.us-east-1.amazonaws.com/SimpleNotificationService-abc.pem",
+		"https://sns.us-east-1.amazonaws.com.evil.com/cert.pem",
+		"https://evil.com/sns.us-east-1.amazonaws.com/cert.pem",
+		"https://sns.us-east-1.amazonaws.com/cert.txt",
+	}
+	for _, certURL := range invalid {
+		assert.ErrorIs(t, ValidateSNSSigningCertURL(certURL), ErrInvalidWebhookSignature, certURL)
+	}
+}
+
+// newSNSTestCertificate creates a self-signed certificate standing in for the AWS signing certificate
+func newSNSTestCertificate(t *testing.T) (*rsa.PrivateKey, *x509.Certificate) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "sns.amazonaws.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return key, cert
+}
+
+func signSNSTestMessage(t *testing.T, key *rsa.PrivateKey, message *SESWebhookPayload) {
+	stringToSign, err := SNSStringToSign(message)
+	require.NoError(t, err)
+
+	var signature []byte
+	if message.SignatureVersion == "1" {
+		sum := sha1.Sum([]byte(stringToSign))
+		signature, err = rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA1, sum[:])
+	} else {
+		sum := sha256.Sum256([]byte(stringToSign))
+		signature, err = rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, sum[:])
+	}
+	require.NoError(t, err)
+	message.Signature = base64.StdEncoding.EncodeToString(signature)
+}
+
+func TestVerifySNSMessageSignature(t *testing.T) {
+	key, cert := newSNSTestCertificate(t)
+
+	notification := func(version string) *SESWebhookPayload {
+		return &SESWebhookPayload{
+			Type:             "Notification",
+			MessageID:        "sns-message-1",
+			TopicARN:         "arn:aws:sns:us-east-1:123456789012:notifuse",
+			Message:          `{"eventType":"Delivery"}`,
+			Timestamp:        "2026-01-01T00:00:00.000Z",
+			SignatureVersion: version,
+			SigningCertURL:   "https://sns.us-east-1.amazonaws.com/SimpleNotificationService-abc.pem",
+		}
+	}
+
+	t.Run("valid signature version 1", func(t *testing.T) {
+		message := notification("1")
+		signSNSTestMessage(t, key, message)
+		assert.NoError(t, VerifySNSMessageSignature(message, cert))
+	})
+
+	t.Run("valid signature version 2 with subject", func(t *testing.T) {
+		message := notification("2")
+		message.Subject = "Amazon SES Email Event Notification"
+		signSNSTestMessage(t, key, message)
+		assert.NoError(t, VerifySNSMessageSignature(message, cert))
+	})
+
+	t.Run("valid subscription confirmation", func(t *testing.T) {
+		message := notification("2")
+		message.Type = "SubscriptionConfirmation"
+		message.SubscribeURL = "https://sns.us-east-1.amazonaws.com/?Action=ConfirmSubscription"
+		message.Token = "token123"
+		signSNSTestMessage(t, key, message)
+		assert.NoError(t, VerifySNSMessageSignature(message, cert))
+	})
+
+	t.Run("tampered message", func(t *testing.T) {
+		message := notification("2")
+		signSNSTestMessage(t, key, message)
+		message.Message = `{"eventType":"Bounce"}`
+
+		err := VerifySNSMessageSignature(message, cert)
+		assert.ErrorIs(t, err, ErrInvalidWebhookSignature)
+	})
+
+	t.Run("signed with another key", func(t *testing.T) {
+		otherKey, _ := newSNSTestCertificate(t)
+		message := notification("2")
+		signSNSTestMessage(t, otherKey, message)
+
+		err := VerifySNSMessageSignature(message, cert)
+		assert.ErrorIs(t, err, ErrInvalidWebhookSignature)
+	})
+
+	t.Run("unsigned message", func(t *testing.T) {
+		err := VerifySNSMessageSignature(notification("2"), cert)
+		assert.ErrorIs(t, err, ErrInvalidWebhookSignature)
+	})
+
+	t.Run("unsupported signature version", func(t *testing.T) {
+		message := notification("2")
+		signSNSTestMessage(t, key, message)
+		message.SignatureVersion = "3"
+
+		err := VerifySNSMessageSignature(message, cert)
+		assert.ErrorIs(t, err, ErrInvalidWebhookSignature)
+	})
+}
@@ -13,6 +13,8 @@ import (
 type WebhookSubscriptionSettings struct {
 	EventTypes         []string            `json:"event_types"`
 	CustomEventFilters *CustomEventFilters `json:"custom_event_filters,omitempty"`
+	// ContactActivityCheckpoint is the position of the last contact activity queued for delivery
+	ContactActivityCheckpoint string `json:"contact_activity_checkpoint,omitempty"`
 }
 
 // WebhookSubscription represents an outgoing webhook subscription configuration
@@ -81,6 +83,7 @@ var WebhookEventTypes = []string{
 	"contact.created",
 	"contact.updated",
 	"contact.deleted",
+	"contact.activity",
 	// List events
 	"list.subscribed",
 	"list.unsubscribed",
@@ -101,6 +104,10 @@ var WebhookEventTypes = []string{
 	"email.bounced",
 	"email.complained",
 	"email.unsubscribed",
+	// Broadcast events
+	"broadcast.phase_changed",
+	// Integration events
+	"integration.webhook_stale",
 	// Custom events (with optional filtering)
 	"custom_event.created",
 	"custom_event.updated",
@@ -115,6 +122,8 @@ type WebhookSubscriptionRepository interface {
 	Update(ctx context.Context, workspaceID string, sub *WebhookSubscription) error
 	Delete(ctx context.Context, workspaceID, id string) error
 	UpdateLastDeliveryAt(ctx context.Context, workspaceID, id string, deliveredAt time.Time) error
+	// UpdateContactActivityCheckpoint only updates the checkpoint, leaving other settings untouched
+	UpdateContactActivityCheckpoint(ctx context.Context, workspaceID, id, checkpoint string) error
 }
 
 // WebhookDeliveryRepository defines the interface for webhook delivery data access
@@ -127,6 +136,9 @@ type WebhookDeliveryRepository interface {
 	MarkFailed(ctx context.Context, workspaceID, id string, attempts int, lastError string, responseStatus *int, responseBody *string) error
 	Create(ctx context.Context, workspaceID string, delivery *WebhookDelivery) error
 	CleanupOldDeliveries(ctx context.Context, workspaceID string, retentionDays int) (int64, error)
+	// HasPendingContactActivityBefore reports whether an earlier contact activity of the same contact
+	// is still waiting to be delivered to the subscription
+	HasPendingContactActivityBefore(ctx context.Context, workspaceID, subscriptionID, email, checkpoint string) (bool, error)
 }
 
 // WebhookDeliveryWithSubscription contains a delivery with its associated subscription
@@ -14,6 +14,7 @@ func TestWebhookEventTypes_ContainsExpectedEvents(t *testing.T) {
 		"contact.created",
 		"contact.updated",
 		"contact.deleted",
+		"contact.activity",
 		// List events
 		"list.subscribed",
 		"list.unsubscribed",
@@ -34,6 +35,10 @@ func TestWebhookEventTypes_ContainsExpectedEvents(t *testing.T) {
 		"email.bounced",
 		"email.complained",
 		"email.unsubscribed",
+		// Broadcast events
+		"broadcast.phase_changed",
+		// Integration events
+		"integration.webhook_stale",
 		// Custom events
 		"custom_event.created",
 		"custom_event.updated",
@@ -8,9 +8,12 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/Notifuse/notifuse/pkg/crypto"
+	"github.com/Notifuse/notifuse/pkg/notifuse_mjml"
 	"github.com/asaskevich/govalidator"
 )
 
@@ -95,6 +98,7 @@ const (
 	IntegrationTypeSupabase  IntegrationType = "supabase"
 	IntegrationTypeLLM       IntegrationType = "llm"
 	IntegrationTypeFirecrawl IntegrationType = "firecrawl"
+	IntegrationTypeSMS       IntegrationType = "sms"
 )
 
 // Integrations is a slice of Integration with database serialization methods
@@ -133,6 +137,7 @@ type Integration struct {
 	SupabaseSettings  *SupabaseIntegrationSettings `json:"supabase_settings,omitempty"`
 	LLMProvider       *LLMProvider                 `json:"llm_provider,omitempty"`
 	FirecrawlSettings *FirecrawlSettings           `json:"firecrawl_settings,omitempty"`
+	SMSProvider       *SMSProvider                 `json:"sms_provider,omitempty"`
 	CreatedAt         time.Time                    `json:"created_at"`
 	UpdatedAt         time.Time                    `json:"updated_at"`
 }
@@ -182,6 +187,14 @@ func (i *Integration) Validate(passphrase string) error {
 		if err := i.FirecrawlSettings.Validate(passphrase); err != nil {
 			return fmt.Errorf("invalid firecrawl settings: %w", err)
 		}
+	case IntegrationTypeSMS:
+		// Validate SMS provider settings
+		if i.SMSProvider == nil {
+			return fmt.Errorf("sms provider settings are required for sms integration")
+		}
+		if err := i.SMSProvider.Validate(passphrase); err != nil {
+			return fmt.Errorf("invalid sms provider settings: %w", err)
+		}
 	default:
 		return fmt.Errorf("unsupported integration type: %s", i.Type)
 	}
@@ -215,6 +228,12 @@ func (i *Integration) BeforeSave(secretkey string) error {
 				return fmt.Errorf("failed to encrypt firecrawl secret keys: %w", err)
 			}
 		}
+	case IntegrationTypeSMS:
+		if i.SMSProvider != nil {
+			if err := i.SMSProvider.EncryptSecretKeys(secretkey); err != nil {
+				return fmt.Errorf("failed to encrypt sms provider secrets: %w", err)
+			}
+		}
 	}
 
 	return nil
@@ -246,6 +265,12 @@ func (i *Integration) AfterLoad(secretkey string) error {
 				return fmt.Errorf("failed to decrypt firecrawl secret keys: %w", err)
 			}
 		}
+	case IntegrationTypeSMS:
+		if i.SMSProvider != nil {
+			if err := i.SMSProvider.DecryptSecretKeys(secretkey); err != nil {
+				return fmt.Errorf("failed to decrypt sms provider secrets: %w", err)
+			}
+		}
 	}
 
 	return nil
@@ -317,22 +342,44 @@ func (b *BlogSettings) Scan(value interface{}) error {
 	return json.Unmarshal(cloned, b)
 }
 
+// MaxMarketingFailoverProviders is the number of integrations marketing sends can fail over to
+const MaxMarketingFailoverProviders = 3
+
 // WorkspaceSettings contains configurable workspace settings
 type WorkspaceSettings struct {
-	WebsiteURL                   string              `json:"website_url,omitempty"`
-	LogoURL                      string              `json:"logo_url,omitempty"`
-	CoverURL                     string              `json:"cover_url,omitempty"`
-	Timezone                     string              `json:"timezone"`
-	FileManager                  FileManagerSettings `json:"file_manager,omitempty"`
-	TransactionalEmailProviderID string              `json:"transactional_email_provider_id,omitempty"`
-	MarketingEmailProviderID     string              `json:"marketing_email_provider_id,omitempty"`
-	EncryptedSecretKey           string              `json:"encrypted_secret_key,omitempty"`
-	EmailTrackingEnabled         bool                `json:"email_tracking_enabled"`
-	TemplateBlocks               []TemplateBlock     `json:"template_blocks,omitempty"`
-	CustomEndpointURL            *string             `json:"custom_endpoint_url,omitempty"`
-	CustomFieldLabels            map[string]string   `json:"custom_field_labels,omitempty"`
-	BlogEnabled                  bool                `json:"blog_enabled"`            // Enable blog feature at workspace level
-	BlogSettings                 *BlogSettings       `json:"blog_settings,omitempty"` // Blog styling and SEO settings
+	WebsiteURL                   string                       `json:"website_url,omitempty"`
+	LogoURL                      string                       `json:"logo_url,omitempty"`
+	CoverURL                     string                       `json:"cover_url,omitempty"`
+	Timezone                     string                       `json:"timezone"`
+	FileManager                  FileManagerSettings          `json:"file_manager,omitempty"`
+	TransactionalEmailProviderID string                       `json:"transactional_email_provider_id,omitempty"`
+	MarketingEmailProviderID     string                       `json:"marketing_email_provider_id,omitempty"`
+	MarketingFailoverProviderIDs []string                     `json:"marketing_failover_provider_ids,omitempty"` // Email integrations tried in order when the marketing provider fails with a retryable error
+	SMSProviderID                string                       `json:"sms_provider_id,omitempty"`
+	EncryptedSecretKey           string                       `json:"encrypted_secret_key,omitempty"`
+	EmailTrackingEnabled         bool                         `json:"email_tracking_enabled"`
+	OpenTrackingDefault          *bool                        `json:"open_tracking_default,omitempty"`  // Falls back to EmailTrackingEnabled when unset
+	ClickTrackingDefault         *bool                        `json:"click_tracking_default,omitempty"` // Falls back to EmailTrackingEnabled when unset
+	TemplateBlocks               []TemplateBlock              `json:"template_blocks,omitempty"`
+	CustomEndpointURL            *string                      `json:"custom_endpoint_url,omitempty"`
+	CustomFieldLabels            map[string]string            `json:"custom_field_labels,omitempty"`
+	BlogEnabled                  bool                         `json:"blog_enabled"`                       // Enable blog feature at workspace level
+	BlogSettings                 *BlogSettings                `json:"blog_settings,omitempty"`            // Blog styling and SEO settings
+	SandboxMode                  bool                         `json:"sandbox_mode"`                       // Only deliver to recipients on SandboxAllowlist
+	SandboxAllowlist             []string                     `json:"sandbox_allowlist,omitempty"`        // Email addresses or "@domain" entries allowed in sandbox mode
+	QuietHours                   *QuietHoursSettings          `json:"quiet_hours,omitempty"`              // Window during which broadcast emails are deferred
+	DeliverableAudience          *DeliverableAudienceSettings `json:"deliverable_audience,omitempty"`     // Minimum deliverable share of a broadcast audience
+	LinkShortening               *LinkShorteningSettings      `json:"link_shortening,omitempty"`          // Short links for click-tracked URLs
+	SendConfirmation             *SendConfirmationSettings    `json:"send_confirmation,omitempty"`        // Require a preflight token to schedule broadcasts
+	ComplaintSpike               *ComplaintSpikeSettings      `json:"complaint_spike,omitempty"`          // Block broadcasts when the workspace complaint rate spikes
+	SendingBlock                 *SendingBlock                `json:"sending_block,omitempty"`            // Set by the complaint spike monitor, cleared by an owner
+	SendCoolOff                  *SendCoolOffSettings         `json:"send_cool_off,omitempty"`            // Delay during which a sent broadcast can still be cancelled
+	DailySendQuota               int                          `json:"daily_send_quota,omitempty"`         // Messages sent per UTC day before broadcasts wait for the next day, 0 for no quota
+	RequireUnsubscribeLink       bool                         `json:"require_unsubscribe_link,omitempty"` // Add an unsubscribe footer to broadcast emails whose template has no unsubscribe link
+	TrackingDomain               string                       `json:"tracking_domain,omitempty"`          // Branded host of the open and click tracking URLs, CNAME to the API endpoint
+	RateLimit                    *WorkspaceRateLimitSettings  `json:"rate_limit,omitempty"`               // API rate limit overriding the server defaults, set by the root user
+	RequireVerifiedSenders       bool                         `json:"require_verified_senders,omitempty"` // Fail broadcasts whose sender has no verified sender identity
+	SenderIdentities             []SenderIdentity             `json:"sender_identities,omitempty"`        // Managed by the sender identity service
 
 	// decoded secret key, not stored in the database
 	SecretKey string `json:"-"`
@@ -383,9 +430,461 @@ func (ws *WorkspaceSettings) Validate(passphrase string) error {
 		return fmt.Errorf("invalid custom field labels: %w", err)
 	}
 
+	// Validate sandbox allowlist entries
+	for i, entry := range ws.SandboxAllowlist {
+		if strings.HasPrefix(entry, "@") {
+			if !govalidator.IsDNSName(entry[1:]) {
+				return fmt.Errorf("sandbox allowlist entry at index %d: invalid domain %s", i, entry)
+			}
+			continue
+		}
+		if !govalidator.IsEmail(entry) {
+			return fmt.Errorf("sandbox allowlist entry at index %d: invalid email %s", i, entry)
+		}
+	}
+
+	if len(ws.MarketingFailoverProviderIDs) > MaxMarketingFailoverProviders {
+		return fmt.Errorf("at most %d marketing failover providers are allowed", MaxMarketingFailoverProviders)
+	}
+	failoverProviderIDs := make(map[string]bool, len(ws.MarketingFailoverProviderIDs))
+	for i, integrationID := range ws.MarketingFailoverProviderIDs {
+		if integrationID == "" {
+			return fmt.Errorf("marketing failover provider at index %d: integration id is required", i)
+		}
+		if integrationID == ws.MarketingEmailProviderID || failoverProviderIDs[integrationID] {
+			return fmt.Errorf("marketing failover provider at index %d: integration %s is already used", i, integrationID)
+		}
+		failoverProviderIDs[integrationID] = true
+	}
+
+	if ws.QuietHours != nil {
+		if err := ws.QuietHours.Validate(); err != nil {
+			return fmt.Errorf("invalid quiet hours: %w", err)
+		}
+	}
+
+	if ws.DeliverableAudience != nil {
+		if err := ws.DeliverableAudience.Validate(); err != nil {
+			return fmt.Errorf("invalid deliverable audience settings: %w", err)
+		}
+	}
+
+	if ws.LinkShortening != nil {
+		if err := ws.LinkShortening.Validate(); err != nil {
+			return fmt.Errorf("invalid link shortening settings: %w", err)
+		}
+	}
+
+	if ws.SendConfirmation != nil {
+		if err := ws.SendConfirmation.Validate(); err != nil {
+			return fmt.Errorf("invalid send confirmation settings: %w", err)
+		}
+	}
+
+	if ws.ComplaintSpike != nil {
+		if err := ws.ComplaintSpike.Validate(); err != nil {
+			return fmt.Errorf("invalid complaint spike settings: %w", err)
+		}
+	}
+
+	if ws.SendCoolOff != nil {
+		if err := ws.SendCoolOff.Validate(); err != nil {
+			return fmt.Errorf("invalid send cool-off settings: %w", err)
+		}
+	}
+
+	if ws.DailySendQuota < 0 {
+		return fmt.Errorf("daily send quota cannot be negative")
+	}
+
+	if ws.RateLimit != nil {
+		if err := ws.RateLimit.Validate(); err != nil {
+			return fmt.Errorf("invalid rate limit settings: %w", err)
+		}
+	}
+
+	if ws.TrackingDomain != "" && !IsValidTrackingDomain(ws.TrackingDomain) {
+		return fmt.Errorf("invalid tracking domain: %s", ws.TrackingDomain)
+	}
+
+	return nil
+}
+
+// IsValidTrackingDomain reports whether domain is a bare host name such as track.example.com,
+// without scheme, port or path
+func IsValidTrackingDomain(domain string) bool {
+	if domain != strings.ToLower(strings.TrimSpace(domain)) || !strings.Contains(domain, ".") {
+		return false
+	}
+	return govalidator.IsDNSName(domain)
+}
+
+// ErrSandboxRecipientNotAllowed is reported when a workspace in sandbox mode drops a
+// message addressed to a recipient outside its allowlist
+var ErrSandboxRecipientNotAllowed = errors.New("sandbox mode: recipient is not in the workspace allowlist")
+
+// IsRecipientAllowed reports whether a message may be delivered to the given email address.
+// Every recipient is allowed unless sandbox mode is enabled, in which case the address must
+// match an allowlist entry exactly or by "@domain" suffix (case-insensitive).
+func (ws *WorkspaceSettings) IsRecipientAllowed(email string) bool {
+	if !ws.SandboxMode {
+		return true
+	}
+
+	email = strings.ToLower(strings.TrimSpace(email))
+	for _, entry := range ws.SandboxAllowlist {
+		entry = strings.ToLower(strings.TrimSpace(entry))
+		if entry == "" {
+			continue
+		}
+		if strings.HasPrefix(entry, "@") {
+			if strings.HasSuffix(email, entry) {
+				return true
+			}
+			continue
+		}
+		if email == entry {
+			return true
+		}
+	}
+
+	return false
+}
+
+// QuietHoursSettings defines a daily window (in the recipient's local time) during which
+// broadcast emails are held back and delivered once the window ends
+type QuietHoursSettings struct {
+	Enabled bool   `json:"enabled"`
+	Start   string `json:"start"` // Format: HH:mm
+	End     string `json:"end"`   // Format: HH:mm, may be earlier than Start to span midnight
+}
+
+// Validate validates the quiet hours window
+func (q *QuietHoursSettings) Validate() error {
+	if !q.Enabled {
+		return nil
+	}
+	start, err := time.Parse("15:04", q.Start)
+	if err != nil {
+		return fmt.Errorf("invalid start time %q, expected HH:mm", q.Start)
+	}
+	end, err := time.Parse("15:04", q.End)
+	if err != nil {
+		return fmt.Errorf("invalid end time %q, expected HH:mm", q.End)
+	}
+	if start.Equal(end) {
+		return fmt.Errorf("start and end times must differ")
+	}
 	return nil
 }
 
+// DeferUntil reports whether now falls inside the quiet hours window in the given location
+// and, if so, returns the time at which the window ends
+func (q *QuietHoursSettings) DeferUntil(now time.Time, loc *time.Location) (time.Time, bool) {
+	if q == nil || !q.Enabled {
+		return time.Time{}, false
+	}
+	start, err := time.Parse("15:04", q.Start)
+	if err != nil {
+		return time.Time{}, false
+	}
+	end, err := time.Parse("15:04", q.End)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	local := now.In(loc)
+	minutes := local.Hour()*60 + local.Minute()
+	startMinutes := start.Hour()*60 + start.Minute()
+	endMinutes := end.Hour()*60 + end.Minute()
+
+	var inWindow bool
+	if startMinutes < endMinutes {
+		inWindow = minutes >= startMinutes && minutes < endMinutes
+	} else {
+		// Window spans midnight, e.g. 22:00 - 08:00
+		inWindow = minutes >= startMinutes || minutes < endMinutes
+	}
+	if !inWindow {
+		return time.Time{}, false
+	}
+
+	until := time.Date(local.Year(), local.Month(), local.Day(), end.Hour(), end.Minute(), 0, 0, loc)
+	if !until.After(local) {
+		until = until.AddDate(0, 0, 1)
+	}
+	return until, true
+}
+
+// OpenTrackingEnabled reports whether emails of the workspace get an open tracking pixel by default
+func (ws *WorkspaceSettings) OpenTrackingEnabled() bool {
+	if ws.OpenTrackingDefault != nil {
+		return *ws.OpenTrackingDefault
+	}
+	return ws.EmailTrackingEnabled
+}
+
+// ClickTrackingEnabled reports whether links of the workspace emails are tracked by default
+func (ws *WorkspaceSettings) ClickTrackingEnabled() bool {
+	if ws.ClickTrackingDefault != nil {
+		return *ws.ClickTrackingDefault
+	}
+	return ws.EmailTrackingEnabled
+}
+
+// TrackingEndpoint returns the base URL of the open and click tracking URLs on the workspace tracking
+// domain, empty when the workspace has none and the tracking URLs use the API endpoint
+func (ws *WorkspaceSettings) TrackingEndpoint() string {
+	if ws.TrackingDomain == "" {
+		return ""
+	}
+	return "https://" + ws.TrackingDomain
+}
+
+// ApplyTrackingDefaults sets the workspace open and click tracking defaults and tracking domain on the tracking settings
+func (ws *WorkspaceSettings) ApplyTrackingDefaults(settings *notifuse_mjml.TrackingSettings) {
+	settings.SetTracking(ws.OpenTrackingEnabled(), ws.ClickTrackingEnabled())
+	settings.TrackingEndpoint = ws.TrackingEndpoint()
+}
+
+// DeliverableAudienceSettings configures the broadcast preflight check that compares the
+// deliverable part of an audience with its raw size
+type DeliverableAudienceSettings struct {
+	Enabled  bool    `json:"enabled"`
+	MinRatio float64 `json:"min_ratio"` // Minimum deliverable/raw ratio, between 0 and 1
+	Block    bool    `json:"block"`     // Refuse to schedule instead of only warning
+}
+
+// Validate validates the deliverable audience settings
+func (d *DeliverableAudienceSettings) Validate() error {
+	if !d.Enabled {
+		return nil
+	}
+	if d.MinRatio <= 0 || d.MinRatio > 1 {
+		return fmt.Errorf("min_ratio must be greater than 0 and at most 1")
+	}
+	return nil
+}
+
+// DefaultSendConfirmationTTLSeconds is how long a broadcast send confirmation token stays valid by default
+const DefaultSendConfirmationTTLSeconds = 300
+
+// SendConfirmationSettings requires the token returned by the broadcast preflight to
+// schedule or send a broadcast, so that a send always follows a recent preflight
+type SendConfirmationSettings struct {
+	Enabled    bool `json:"enabled"`
+	TTLSeconds int  `json:"ttl_seconds,omitempty"` // Token lifetime, defaults to DefaultSendConfirmationTTLSeconds
+}
+
+// Validate validates the send confirmation settings
+func (s *SendConfirmationSettings) Validate() error {
+	if s.TTLSeconds != 0 && (s.TTLSeconds < 30 || s.TTLSeconds > 3600) {
+		return fmt.Errorf("ttl_seconds must be between 30 and 3600")
+	}
+	return nil
+}
+
+// TTL returns the lifetime of a send confirmation token
+func (s *SendConfirmationSettings) TTL() time.Duration {
+	if s.TTLSeconds == 0 {
+		return DefaultSendConfirmationTTLSeconds * time.Second
+	}
+	return time.Duration(s.TTLSeconds) * time.Second
+}
+
+// WorkspaceRateLimitSettings override the API rate limit of the server for a workspace,
+// zero values keep the server defaults (API_RATE_LIMIT_RPS and API_RATE_LIMIT_BURST)
+type WorkspaceRateLimitSettings struct {
+	RequestsPerSecond float64 `json:"requests_per_second,omitempty"`
+	Burst             int     `json:"burst,omitempty"`
+}
+
+// Validate validates the rate limit settings
+func (s *WorkspaceRateLimitSettings) Validate() error {
+	if s.RequestsPerSecond < 0 {
+		return fmt.Errorf("requests_per_second cannot be negative")
+	}
+	if s.Burst < 0 {
+		return fmt.Errorf("burst cannot be negative")
+	}
+	return nil
+}
+
+// DefaultSendCoolOffMinutes is the default delay between sending a broadcast and its first email
+const DefaultSendCoolOffMinutes = 5
+
+// SendCoolOffSettings delay the start of the broadcasts sent or scheduled in the workspace, so that a
+// last-minute mistake can be fixed by cancelling the broadcast before any email goes out
+type SendCoolOffSettings struct {
+	Enabled bool `json:"enabled"`
+	Minutes int  `json:"minutes,omitempty"` // Cool-off duration, defaults to DefaultSendCoolOffMinutes
+}
+
+// Validate validates the send cool-off settings
+func (s *SendCoolOffSettings) Validate() error {
+	if s.Minutes < 0 || s.Minutes > 60 {
+		return fmt.Errorf("minutes must be between 0 and 60")
+	}
+	return nil
+}
+
+// Duration returns the cool-off duration, zero when the cool-off is disabled
+func (s *SendCoolOffSettings) Duration() time.Duration {
+	if s == nil || !s.Enabled {
+		return 0
+	}
+	if s.Minutes == 0 {
+		return DefaultSendCoolOffMinutes * time.Minute
+	}
+	return time.Duration(s.Minutes) * time.Minute
+}
+
+const (
+	// DefaultComplaintSpikeWindowMinutes is the default rolling window of the complaint spike monitor
+	DefaultComplaintSpikeWindowMinutes = 60
+	// DefaultComplaintSpikeMinSent is the default number of emails sent in the window below which
+	// the complaint rate is not considered significant
+	DefaultComplaintSpikeMinSent = 500
+)
+
+// ComplaintSpikeSettings configures the monitor that blocks broadcasts when the complaint rate of
+// the whole workspace over a rolling window exceeds a threshold
+type ComplaintSpikeSettings struct {
+	Enabled       bool    `json:"enabled"`
+	MaxRate       float64 `json:"max_rate"`                 // Maximum complaints/sent ratio, between 0 and 1 (e.g. 0.003)
+	WindowMinutes int     `json:"window_minutes,omitempty"` // Rolling window, defaults to DefaultComplaintSpikeWindowMinutes
+	MinSent       int     `json:"min_sent,omitempty"`       // Minimum sends in the window, defaults to DefaultComplaintSpikeMinSent
+}
+
+// Validate validates the complaint spike settings
+func (c *ComplaintSpikeSettings) Validate() error {
+	if c.WindowMinutes < 0 || c.WindowMinutes > 7*24*60 {
+		return fmt.Errorf("window_minutes must be between 0 and %d", 7*24*60)
+	}
+	if c.MinSent < 0 {
+		return fmt.Errorf("min_sent cannot be negative")
+	}
+	if !c.Enabled {
+		return nil
+	}
+	if c.MaxRate <= 0 || c.MaxRate > 1 {
+		return fmt.Errorf("max_rate must be greater than 0 and at most 1")
+	}
+	return nil
+}
+
+// Window returns the rolling window over which the complaint rate is computed
+func (c *ComplaintSpikeSettings) Window() time.Duration {
+	if c.WindowMinutes == 0 {
+		return DefaultComplaintSpikeWindowMinutes * time.Minute
+	}
+	return time.Duration(c.WindowMinutes) * time.Minute
+}
+
+// IsSpike reports whether the complaints received for the emails sent in the window exceed the
+// maximum rate. Windows with fewer sends than MinSent are never a spike.
+func (c *ComplaintSpikeSettings) IsSpike(sent, complaints int) bool {
+	minSent := c.MinSent
+	if minSent == 0 {
+		minSent = DefaultComplaintSpikeMinSent
+	}
+	if sent == 0 || sent < minSent {
+		return false
+	}
+	return float64(complaints)/float64(sent) > c.MaxRate
+}
+
+// SendingBlock records why the broadcasts of a workspace were stopped. It stays set until an
+// owner clears it, and no broadcast can be scheduled, sent or resumed meanwhile.
+type SendingBlock struct {
+	Reason        string    `json:"reason"`
+	ComplaintRate float64   `json:"complaint_rate"`
+	Sent          int       `json:"sent"`
+	Complaints    int       `json:"complaints"`
+	BlockedAt     time.Time `json:"blocked_at"`
+}
+
+// ErrWorkspaceSendingBlocked is returned when sending is refused because of a workspace sending block
+type ErrWorkspaceSendingBlocked struct {
+	Block *SendingBlock
+}
+
+// Error returns the error message
+func (e *ErrWorkspaceSendingBlocked) Error() string {
+	return fmt.Sprintf("sending is blocked for this workspace since %s: %s", e.Block.BlockedAt.UTC().Format(time.RFC3339), e.Block.Reason)
+}
+
+// NewWorkspaceSendingBlockedEvent builds the event published when a complaint spike blocks the sending of a workspace
+func NewWorkspaceSendingBlockedEvent(workspaceID string, block *SendingBlock, pausedBroadcastIDs []string) EventPayload {
+	return EventPayload{
+		Type:        EventWorkspaceSendingBlocked,
+		WorkspaceID: workspaceID,
+		EntityID:    workspaceID,
+		Data: map[string]interface{}{
+			"reason":               block.Reason,
+			"complaint_rate":       block.ComplaintRate,
+			"sent":                 block.Sent,
+			"complaints":           block.Complaints,
+			"blocked_at":           block.BlockedAt.UTC().Format(time.RFC3339),
+			"paused_broadcast_ids": pausedBroadcastIDs,
+		},
+	}
+}
+
+// CheckSendingAllowed returns an ErrWorkspaceSendingBlocked while the workspace has a sending block
+func (ws *WorkspaceSettings) CheckSendingAllowed() error {
+	if ws.SendingBlock != nil {
+		return &ErrWorkspaceSendingBlocked{Block: ws.SendingBlock}
+	}
+	return nil
+}
+
+// LinkShorteningSettings replaces click-tracked links with short links served from
+// the workspace short domain, or from its tracking endpoint when no domain is set
+type LinkShorteningSettings struct {
+	Enabled bool   `json:"enabled"`
+	Domain  string `json:"domain,omitempty"` // Base URL such as https://go.example.com, must point to the API
+}
+
+// Validate validates the link shortening settings
+func (l *LinkShorteningSettings) Validate() error {
+	if l.Domain == "" {
+		return nil
+	}
+	parsed, err := url.Parse(l.Domain)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("domain must be an http(s) URL: %s", l.Domain)
+	}
+	if strings.Trim(parsed.Path, "/") != "" || parsed.RawQuery != "" || parsed.Fragment != "" {
+		return fmt.Errorf("domain must not contain a path, query or fragment: %s", l.Domain)
+	}
+	return nil
+}
+
+// LinkShorteningEnabled reports whether click-tracked links should be shortened.
+// Links are only shortened in messages that have click tracking enabled.
+func (ws *WorkspaceSettings) LinkShorteningEnabled() bool {
+	return ws.LinkShortening != nil && ws.LinkShortening.Enabled
+}
+
+// QuietHoursDeferral returns the time until which a broadcast email to a recipient in the given
+// timezone must be deferred. The workspace timezone is used when the recipient has none.
+func (ws *WorkspaceSettings) QuietHoursDeferral(now time.Time, recipientTimezone string) (time.Time, bool) {
+	if ws.QuietHours == nil || !ws.QuietHours.Enabled {
+		return time.Time{}, false
+	}
+
+	loc, err := time.LoadLocation(recipientTimezone)
+	if recipientTimezone == "" || err != nil {
+		loc, err = time.LoadLocation(ws.Timezone)
+		if err != nil {
+			loc = time.UTC
+		}
+	}
+
+	return ws.QuietHours.DeferUntil(now, loc)
+}
+
 // Value implements the driver.Valuer interface for database serialization
 func (b WorkspaceSettings) Value() (driver.Value, error) {
 	return json.Marshal(b)
@@ -569,6 +1068,19 @@ func (w *Workspace) GetIntegrationsByType(integrationType IntegrationType) []*In
 	return results
 }
 
+// EmailProviderKinds returns the kinds of the email integrations of the workspace, without duplicates
+func (w *Workspace) EmailProviderKinds() []EmailProviderKind {
+	var kinds []EmailProviderKind
+	seen := make(map[EmailProviderKind]bool)
+	for _, integration := range w.GetIntegrationsByType(IntegrationTypeEmail) {
+		if kind := integration.EmailProvider.Kind; !seen[kind] {
+			seen[kind] = true
+			kinds = append(kinds, kind)
+		}
+	}
+	return kinds
+}
+
 // AddIntegration adds a new integration to the workspace
 func (w *Workspace) AddIntegration(integration Integration) {
 	// Check if an integration with this ID already exists
@@ -620,6 +1132,23 @@ func (w *Workspace) GetEmailProvider(isMarketing bool) (*EmailProvider, error) {
 	return &integration.EmailProvider, nil
 }
 
+// MarketingFailoverIntegrations returns the email integrations marketing sends fail over to when the
+// integration they were sent through fails, in order. Integrations that no longer exist are skipped.
+func (w *Workspace) MarketingFailoverIntegrations(failedIntegrationID string) []*Integration {
+	var integrations []*Integration
+	for _, integrationID := range w.Settings.MarketingFailoverProviderIDs {
+		if integrationID == failedIntegrationID {
+			continue
+		}
+		integration := w.GetIntegrationByID(integrationID)
+		if integration == nil || integration.Type != IntegrationTypeEmail {
+			continue
+		}
+		integrations = append(integrations, integration)
+	}
+	return integrations
+}
+
 // GetEmailProviderWithIntegrationID returns both the email provider and integration ID based on provider type
 func (w *Workspace) GetEmailProviderWithIntegrationID(isMarketing bool) (*EmailProvider, string, error) {
 	var integrationID string
@@ -645,6 +1174,26 @@ func (w *Workspace) GetEmailProviderWithIntegrationID(isMarketing bool) (*EmailP
 	return &integration.EmailProvider, integrationID, nil
 }
 
+// GetSMSProviderWithIntegrationID returns the SMS provider used for SMS broadcasts and its integration ID
+func (w *Workspace) GetSMSProviderWithIntegrationID() (*SMSProvider, string, error) {
+	integrationID := w.Settings.SMSProviderID
+
+	// If no integration ID is configured, return nil
+	if integrationID == "" {
+		return nil, "", nil
+	}
+
+	integration := w.GetIntegrationByID(integrationID)
+	if integration == nil {
+		return nil, "", fmt.Errorf("integration with ID %s not found", integrationID)
+	}
+	if integration.Type != IntegrationTypeSMS || integration.SMSProvider == nil {
+		return nil, "", fmt.Errorf("integration with ID %s is not an sms integration", integrationID)
+	}
+
+	return integration.SMSProvider, integrationID, nil
+}
+
 func (w *Workspace) MarshalJSON() ([]byte, error) {
 	type Alias Workspace
 	if w.Integrations == nil {
@@ -935,6 +1484,9 @@ type WorkspaceServiceInterface interface {
 
 	// Permission management
 	SetUserPermissions(ctx context.Context, workspaceID, userID string, permissions UserPermissions) error
+
+	// ClearSendingBlock lifts the sending block set after a complaint spike
+	ClearSendingBlock(ctx context.Context, workspaceID string) (*Workspace, error)
 }
 
 // Request/Response types
@@ -965,6 +1517,7 @@ type CreateIntegrationRequest struct {
 	SupabaseSettings  *SupabaseIntegrationSettings `json:"supabase_settings,omitempty"`  // For Supabase integrations
 	LLMProvider       *LLMProvider                 `json:"llm_provider,omitempty"`       // For LLM integrations
 	FirecrawlSettings *FirecrawlSettings           `json:"firecrawl_settings,omitempty"` // For Firecrawl integrations
+	SMSProvider       *SMSProvider                 `json:"sms_provider,omitempty"`       // For SMS integrations
 }
 
 func (r *CreateIntegrationRequest) Validate(passphrase string) error {
@@ -1007,6 +1560,13 @@ func (r *CreateIntegrationRequest) Validate(passphrase string) error {
 		if err := r.FirecrawlSettings.Validate(passphrase); err != nil {
 			return fmt.Errorf("invalid firecrawl settings: %w", err)
 		}
+	case IntegrationTypeSMS:
+		if r.SMSProvider == nil {
+			return fmt.Errorf("sms provider settings are required for sms integration")
+		}
+		if err := r.SMSProvider.Validate(passphrase); err != nil {
+			return fmt.Errorf("invalid sms provider configuration: %w", err)
+		}
 	default:
 		return fmt.Errorf("unsupported integration type: %s", r.Type)
 	}
@@ -1023,6 +1583,7 @@ type UpdateIntegrationRequest struct {
 	SupabaseSettings  *SupabaseIntegrationSettings `json:"supabase_settings,omitempty"`  // For Supabase integrations
 	LLMProvider       *LLMProvider                 `json:"llm_provider,omitempty"`       // For LLM integrations
 	FirecrawlSettings *FirecrawlSettings           `json:"firecrawl_settings,omitempty"` // For Firecrawl integrations
+	SMSProvider       *SMSProvider                 `json:"sms_provider,omitempty"`       // For SMS integrations
 }
 
 func (r *UpdateIntegrationRequest) Validate(passphrase string) error {
@@ -1056,6 +1617,10 @@ func (r *UpdateIntegrationRequest) Validate(passphrase string) error {
 		if err := r.FirecrawlSettings.Validate(passphrase); err != nil {
 			return fmt.Errorf("invalid firecrawl settings: %w", err)
 		}
+	} else if r.SMSProvider != nil {
+		if err := r.SMSProvider.Validate(passphrase); err != nil {
+			return fmt.Errorf("invalid sms provider configuration: %w", err)
+		}
 	}
 
 	return nil
@@ -4792,3 +4792,388 @@ func TestWorkspaceSettings_ValidateTemplateBlocks(t *testing.T) {
 		})
 	}
 }
+
+func TestWorkspaceSettings_IsRecipientAllowed(t *testing.T) {
+	testCases := []struct {
+		name      string
+		settings  WorkspaceSettings
+		recipient string
+		expected  bool
+	}{
+		{
+			name:      "sandbox disabled allows everyone",
+			settings:  WorkspaceSettings{SandboxAllowlist: []string{"qa@example.com"}},
+			recipient: "customer@example.org",
+			expected:  true,
+		},
+		{
+			name:      "exact match is case-insensitive",
+			settings:  WorkspaceSettings{SandboxMode: true, SandboxAllowlist: []string{"QA@Example.com"}},
+			recipient: "qa@example.com",
+			expected:  true,
+		},
+		{
+			name:      "domain entry matches any address on the domain",
+			settings:  WorkspaceSettings{SandboxMode: true, SandboxAllowlist: []string{"@staging.example.com"}},
+			recipient: "anyone@staging.example.com",
+			expected:  true,
+		},
+		{
+			name:      "domain entry does not match other domains",
+			settings:  WorkspaceSettings{SandboxMode: true, SandboxAllowlist: []string{"@example.com"}},
+			recipient: "someone@notexample.com",
+			expected:  false,
+		},
+		{
+			name:      "address outside allowlist is rejected",
+			settings:  WorkspaceSettings{SandboxMode: true, SandboxAllowlist: []string{"qa@example.com"}},
+			recipient: "customer@example.com",
+			expected:  false,
+		},
+		{
+			name:      "empty allowlist rejects everyone",
+			settings:  WorkspaceSettings{SandboxMode: true},
+			recipient: "qa@example.com",
+			expected:  false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.settings.IsRecipientAllowed(tc.recipient))
+		})
+	}
+}
+
+func TestWorkspaceSettings_Validate_SandboxAllowlist(t *testing.T) {
+	settings := WorkspaceSettings{
+		Timezone:         "UTC",
+		SandboxMode:      true,
+		SandboxAllowlist: []string{"qa@example.com", "@staging.example.com"},
+	}
+	assert.NoError(t, settings.Validate("passphrase"))
+
+	settings.SandboxAllowlist = []string{"not-an-email"}
+	err := settings.Validate("passphrase")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "sandbox allowlist entry at index 0")
+
+	settings.SandboxAllowlist = []string{"@"}
+	assert.Error(t, settings.Validate("passphrase"))
+}
+
+func TestQuietHoursSettings_DeferUntil(t *testing.T) {
+	overnight := &QuietHoursSettings{Enabled: true, Start: "22:00", End: "08:00"}
+	daytime := &QuietHoursSettings{Enabled: true, Start: "12:00", End: "14:00"}
+
+	testCases := []struct {
+		name          string
+		quietHours    *QuietHoursSettings
+		now           time.Time
+		expectDefer   bool
+		expectedUntil time.Time
+	}{
+		{
+			name:          "overnight window before midnight",
+			quietHours:    overnight,
+			now:           time.Date(2024, 3, 10, 23, 30, 0, 0, time.UTC),
+			expectDefer:   true,
+			expectedUntil: time.Date(2024, 3, 11, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			name:          "overnight window after midnight",
+			quietHours:    overnight,
+			now:           time.Date(2024, 3, 10, 3, 0, 0, 0, time.UTC),
+			expectDefer:   true,
+			expectedUntil: time.Date(2024, 3, 10, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			name:        "outside overnight window",
+			quietHours:  overnight,
+			now:         time.Date(2024, 3, 10, 8, 0, 0, 0, time.UTC),
+			expectDefer: false,
+		},
+		{
+			name:          "inside daytime window",
+			quietHours:    daytime,
+			now:           time.Date(2024, 3, 10, 12, 15, 0, 0, time.UTC),
+			expectDefer:   true,
+			expectedUntil: time.Date(2024, 3, 10, 14, 0, 0, 0, time.UTC),
+		},
+		{
+			name:        "disabled",
+			quietHours:  &QuietHoursSettings{Enabled: false, Start: "00:00", End: "23:59"},
+			now:         time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC),
+			expectDefer: false,
+		},
+		{
+			name:        "nil settings",
+			quietHours:  nil,
+			now:         time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC),
+			expectDefer: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			until, deferred := tc.quietHours.DeferUntil(tc.now, time.UTC)
+			assert.Equal(t, tc.expectDefer, deferred)
+			if tc.expectDefer {
+				assert.True(t, tc.expectedUntil.Equal(until), "expected %s, got %s", tc.expectedUntil, until)
+			}
+		})
+	}
+}
+
+func TestWorkspaceSettings_QuietHoursDeferral(t *testing.T) {
+	settings := WorkspaceSettings{
+		Timezone:   "UTC",
+		QuietHours: &QuietHoursSettings{Enabled: true, Start: "22:00", End: "08:00"},
+	}
+
+	// 14:00 UTC is 23:00 in Tokyo and 09:00 in New York (EST)
+	now := time.Date(2024, 1, 15, 14, 0, 0, 0, time.UTC)
+
+	until, deferred := settings.QuietHoursDeferral(now, "Asia/Tokyo")
+	assert.True(t, deferred)
+	assert.True(t, time.Date(2024, 1, 15, 23, 0, 0, 0, time.UTC).Equal(until))
+
+	_, deferred = settings.QuietHoursDeferral(now, "America/New_York")
+	assert.False(t, deferred)
+
+	// Falls back to the workspace timezone when the recipient has none
+	_, deferred = settings.QuietHoursDeferral(now, "")
+	assert.False(t, deferred)
+
+	settings.Timezone = "Asia/Tokyo"
+	_, deferred = settings.QuietHoursDeferral(now, "")
+	assert.True(t, deferred)
+}
+
+func TestWorkspaceSettings_Validate_QuietHours(t *testing.T) {
+	settings := WorkspaceSettings{
+		Timezone:   "UTC",
+		QuietHours: &QuietHoursSettings{Enabled: true, Start: "22:00", End: "08:00"},
+	}
+	assert.NoError(t, settings.Validate("passphrase"))
+
+	settings.QuietHours = &QuietHoursSettings{Enabled: true, Start: "25:00", End: "08:00"}
+	err := settings.Validate("passphrase")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid quiet hours")
+
+	settings.QuietHours = &QuietHoursSettings{Enabled: true, Start: "08:00", End: "08:00"}
+	assert.Error(t, settings.Validate("passphrase"))
+
+	// Disabled windows are not validated
+	settings.QuietHours = &QuietHoursSettings{Enabled: false}
+	assert.NoError(t, settings.Validate("passphrase"))
+}
+
+func TestWorkspaceSettings_Validate_DeliverableAudience(t *testing.T) {
+	settings := WorkspaceSettings{
+		Timezone:            "UTC",
+		DeliverableAudience: &DeliverableAudienceSettings{Enabled: true, MinRatio: 0.5},
+	}
+	assert.NoError(t, settings.Validate("passphrase"))
+
+	settings.DeliverableAudience = &DeliverableAudienceSettings{Enabled: true, MinRatio: 0}
+	err := settings.Validate("passphrase")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid deliverable audience settings")
+
+	settings.DeliverableAudience = &DeliverableAudienceSettings{Enabled: true, MinRatio: 1.5}
+	assert.Error(t, settings.Validate("passphrase"))
+
+	// Disabled checks are not validated
+	settings.DeliverableAudience = &DeliverableAudienceSettings{Enabled: false}
+	assert.NoError(t, settings.Validate("passphrase"))
+}
+
+func TestWorkspaceSettings_TrackingDefaults(t *testing.T) {
+	t.Run("falls back to EmailTrackingEnabled", func(t *testing.T) {
+		settings := WorkspaceSettings{EmailTrackingEnabled: true}
+		assert.True(t, settings.OpenTrackingEnabled())
+		assert.True(t, settings.ClickTrackingEnabled())
+
+		settings.EmailTrackingEnabled = false
+		assert.False(t, settings.OpenTrackingEnabled())
+		assert.False(t, settings.ClickTrackingEnabled())
+	})
+
+	t.Run("split defaults take precedence", func(t *testing.T) {
+		settings := WorkspaceSettings{
+			EmailTrackingEnabled: true,
+			OpenTrackingDefault:  boolPtr(false),
+			ClickTrackingDefault: boolPtr(true),
+		}
+		assert.False(t, settings.OpenTrackingEnabled())
+		assert.True(t, settings.ClickTrackingEnabled())
+
+		trackingSettings := notifuse_mjml.TrackingSettings{}
+		settings.ApplyTrackingDefaults(&trackingSettings)
+		assert.True(t, trackingSettings.EnableTracking)
+		assert.False(t, trackingSettings.OpenTrackingEnabled())
+		assert.True(t, trackingSettings.ClickTrackingEnabled())
+	})
+}
+
+func TestWorkspaceSettings_Validate_ComplaintSpike(t *testing.T) {
+	settings := WorkspaceSettings{
+		Timezone:       "UTC",
+		ComplaintSpike: &ComplaintSpikeSettings{Enabled: true, MaxRate: 0.003, WindowMinutes: 60},
+	}
+	assert.NoError(t, settings.Validate("passphrase"))
+
+	settings.ComplaintSpike = &ComplaintSpikeSettings{Enabled: true, MaxRate: 0}
+	err := settings.Validate("passphrase")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid complaint spike settings")
+
+	settings.ComplaintSpike = &ComplaintSpikeSettings{Enabled: true, MaxRate: 0.003, WindowMinutes: 20000}
+	assert.Error(t, settings.Validate("passphrase"))
+
+	// Disabled checks only validate the window and volume
+	settings.ComplaintSpike = &ComplaintSpikeSettings{Enabled: false}
+	assert.NoError(t, settings.Validate("passphrase"))
+}
+
+func TestComplaintSpikeSettings_IsSpike(t *testing.T) {
+	settings := &ComplaintSpikeSettings{Enabled: true, MaxRate: 0.003, MinSent: 500}
+
+	assert.True(t, settings.IsSpike(1000, 4))
+	assert.False(t, settings.IsSpike(1000, 3))
+	assert.False(t, settings.IsSpike(499, 100), "volume under min_sent")
+	assert.False(t, settings.IsSpike(0, 0))
+
+	// Defaults apply to unset window and volume
+	settings = &ComplaintSpikeSettings{Enabled: true, MaxRate: 0.003}
+	assert.Equal(t, time.Hour, settings.Window())
+	assert.False(t, settings.IsSpike(DefaultComplaintSpikeMinSent-1, 100))
+}
+
+func TestWorkspaceSettings_CheckSendingAllowed(t *testing.T) {
+	settings := WorkspaceSettings{}
+	assert.NoError(t, settings.CheckSendingAllowed())
+
+	settings.SendingBlock = &SendingBlock{Reason: "complaint spike", BlockedAt: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)}
+	err := settings.CheckSendingAllowed()
+	var blockedErr *ErrWorkspaceSendingBlocked
+	require.ErrorAs(t, err, &blockedErr)
+	assert.Equal(t, "sending is blocked for this workspace since 2026-03-01T12:00:00Z: complaint spike", err.Error())
+}
+
+func TestSendCoolOffSettings(t *testing.T) {
+	settings := WorkspaceSettings{
+		Timezone:    "UTC",
+		SendCoolOff: &SendCoolOffSettings{Enabled: true, Minutes: 10},
+	}
+	assert.NoError(t, settings.Validate("passphrase"))
+	assert.Equal(t, 10*time.Minute, settings.SendCoolOff.Duration())
+
+	settings.SendCoolOff = &SendCoolOffSettings{Enabled: true, Minutes: 61}
+	err := settings.Validate("passphrase")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid send cool-off settings")
+
+	// Unset minutes use the default, no or disabled settings have no cool-off
+	assert.Equal(t, DefaultSendCoolOffMinutes*time.Minute, (&SendCoolOffSettings{Enabled: true}).Duration())
+	assert.Equal(t, time.Duration(0), (&SendCoolOffSettings{Minutes: 10}).Duration())
+	var unset *SendCoolOffSettings
+	assert.Equal(t, time.Duration(0), unset.Duration())
+}
+
+func TestWorkspaceSettings_DailySendQuota(t *testing.T) {
+	settings := WorkspaceSettings{Timezone: "UTC", DailySendQuota: 1000}
+	assert.NoError(t, settings.Validate("passphrase"))
+
+	settings.DailySendQuota = -1
+	err := settings.Validate("passphrase")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "daily send quota cannot be negative")
+}
+
+func TestWorkspaceSettings_TrackingDomain(t *testing.T) {
+	t.Run("validation", func(t *testing.T) {
+		for _, domain := range []string{"", "track.example.com", "links.mail.example.co.uk"} {
+			settings := WorkspaceSettings{Timezone: "UTC", TrackingDomain: domain}
+			assert.NoError(t, settings.Validate("passphrase"), domain)
+		}
+
+		for _, domain := range []string{"https://track.example.com", "track.example.com/path", "track.example.com:8080", "Track.Example.com", "localhost", "track example.com"} {
+			settings := WorkspaceSettings{Timezone: "UTC", TrackingDomain: domain}
+			err := settings.Validate("passphrase")
+			require.Error(t, err, domain)
+			assert.Contains(t, err.Error(), "invalid tracking domain")
+		}
+	})
+
+	t.Run("tracking endpoint", func(t *testing.T) {
+		settings := WorkspaceSettings{}
+		assert.Empty(t, settings.TrackingEndpoint())
+
+		settings.TrackingDomain = "track.example.com"
+		assert.Equal(t, "https://track.example.com", settings.TrackingEndpoint())
+
+		trackingSettings := notifuse_mjml.TrackingSettings{Endpoint: "https://api.notifuse.com"}
+		settings.ApplyTrackingDefaults(&trackingSettings)
+		assert.Equal(t, "https://api.notifuse.com", trackingSettings.Endpoint)
+		assert.Equal(t, "https://track.example.com", trackingSettings.OpenClickEndpoint())
+	})
+}
+
+func TestWorkspaceSettings_RateLimit(t *testing.T) {
+	settings := WorkspaceSettings{Timezone: "UTC", RateLimit: &WorkspaceRateLimitSettings{RequestsPerSecond: 20, Burst: 100}}
+	assert.NoError(t, settings.Validate("passphrase"))
+
+	settings.RateLimit = &WorkspaceRateLimitSettings{}
+	assert.NoError(t, settings.Validate("passphrase"))
+
+	settings.RateLimit = &WorkspaceRateLimitSettings{RequestsPerSecond: -1}
+	err := settings.Validate("passphrase")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "requests_per_second cannot be negative")
+
+	settings.RateLimit = &WorkspaceRateLimitSettings{Burst: -1}
+	err = settings.Validate("passphrase")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "burst cannot be negative")
+}
+
+func TestWorkspaceSettings_MarketingFailoverProviders(t *testing.T) {
+	settings := WorkspaceSettings{
+		Timezone:                     "UTC",
+		MarketingEmailProviderID:     "primary",
+		MarketingFailoverProviderIDs: []string{"secondary", "tertiary"},
+	}
+	assert.NoError(t, settings.Validate("passphrase"))
+
+	settings.MarketingFailoverProviderIDs = []string{"secondary", "primary"}
+	err := settings.Validate("passphrase")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "integration primary is already used")
+
+	settings.MarketingFailoverProviderIDs = []string{"secondary", "secondary"}
+	assert.Error(t, settings.Validate("passphrase"))
+
+	settings.MarketingFailoverProviderIDs = []string{""}
+	assert.Error(t, settings.Validate("passphrase"))
+
+	settings.MarketingFailoverProviderIDs = []string{"a", "b", "c", "d"}
+	assert.Error(t, settings.Validate("passphrase"))
+
+	workspace := &Workspace{
+		Settings: WorkspaceSettings{MarketingFailoverProviderIDs: []string{"sms", "deleted", "tertiary", "secondary"}},
+		Integrations: []Integration{
+			{ID: "secondary", Type: IntegrationTypeEmail},
+			{ID: "tertiary", Type: IntegrationTypeEmail},
+			{ID: "sms", Type: IntegrationTypeSMS},
+		},
+	}
+	var ids []string
+	for _, integration := range workspace.MarketingFailoverIntegrations("secondary") {
+		ids = append(ids, integration.ID)
+	}
+	assert.Equal(t, []string{"tertiary"}, ids)
+	assert.Len(t, workspace.MarketingFailoverIntegrations("primary"), 2)
+}
@@ -0,0 +1,139 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/http/middleware"
+	"github.com/Notifuse/notifuse/pkg/logger"
+)
+
+// APIKeyServiceInterface defines the scoped API key operations used by the handler
+type APIKeyServiceInterface interface {
+	Create(ctx context.Context, req *domain.CreateScopedAPIKeyRequest) (*domain.CreateScopedAPIKeyResponse, error)
+	List(ctx context.Context, workspaceID string) ([]*domain.APIKey, error)
+	Revoke(ctx context.Context, req *domain.RevokeAPIKeyRequest) error
+}
+
+// APIKeyHandler handles HTTP requests for scoped API keys
+type APIKeyHandler struct {
+	service      APIKeyServiceInterface
+	logger       logger.Logger
+	getJWTSecret func() ([]byte, error)
+}
+
+// NewAPIKeyHandler creates a new API key handler
+func NewAPIKeyHandler(svc APIKeyServiceInterface, getJWTSecret func() ([]byte, error), logger logger.Logger) *APIKeyHandler {
+	return &APIKeyHandler{
+		service:      svc,
+		logger:       logger,
+		getJWTSecret: getJWTSecret,
+	}
+}
+
+// RegisterRoutes registers the API key routes
+func (h *APIKeyHandler) RegisterRoutes(mux *http.ServeMux) {
+	authMiddleware := middleware.NewAuthMiddleware(h.getJWTSecret)
+	requireAuth := authMiddleware.RequireAuth()
+
+	mux.Handle("/api/apiKeys.create", requireAuth(http.HandlerFunc(h.handleCreate)))
+	mux.Handle("/api/apiKeys.list", requireAuth(http.HandlerFunc(h.handleList)))
+	mux.Handle("/api/apiKeys.revoke", requireAuth(http.HandlerFunc(h.handleRevoke)))
+}
+
+// writeAPIKeyError maps the errors of the API key service to a response
+func (h *APIKeyHandler) writeAPIKeyError(w http.ResponseWriter, err error, message string) {
+	var unauthorized *domain.ErrUnauthorized
+	var notFound *domain.ErrAPIKeyNotFound
+	switch {
+	case errors.As(err, &unauthorized):
+		WriteJSONError(w, "Only workspace owners can manage API keys", http.StatusForbidden)
+	case errors.As(err, &notFound):
+		WriteJSONError(w, err.Error(), http.StatusNotFound)
+	default:
+		h.logger.WithField("error", err.Error()).Error(message)
+		WriteJSONError(w, message, http.StatusInternalServerError)
+	}
+}
+
+// handleCreate handles POST /api/apiKeys.create
+func (h *APIKeyHandler) handleCreate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req domain.CreateScopedAPIKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteJSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.service.Create(r.Context(), &req)
+	if err != nil {
+		h.writeAPIKeyError(w, err, "Failed to create API key")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, response)
+}
+
+// handleList handles GET /api/apiKeys.list
+func (h *APIKeyHandler) handleList(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	workspaceID := r.URL.Query().Get("workspace_id")
+	if workspaceID == "" {
+		WriteJSONError(w, "workspace_id is required", http.StatusBadRequest)
+		return
+	}
+
+	keys, err := h.service.List(r.Context(), workspaceID)
+	if err != nil {
+		h.writeAPIKeyError(w, err, "Failed to list API keys")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"api_keys": keys,
+	})
+}
+
+// handleRevoke handles POST /api/apiKeys.revoke
+func (h *APIKeyHandler) handleRevoke(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req domain.RevokeAPIKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteJSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.Revoke(r.Context(), &req); err != nil {
+		h.writeAPIKeyError(w, err, "Failed to revoke API key")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+	})
+}
@@ -0,0 +1,121 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeAPIKeyService struct {
+	keys      []*domain.APIKey
+	createErr error
+	revokeErr error
+	revoked   *domain.RevokeAPIKeyRequest
+}
+
+func (f *fakeAPIKeyService) Create(_ context.Context, req *domain.CreateScopedAPIKeyRequest) (*domain.CreateScopedAPIKeyResponse, error) {
+	if f.createErr != nil {
+		return nil, f.createErr
+	}
+	apiKey := &domain.APIKey{ID: "key1", WorkspaceID: req.WorkspaceID, Name: req.Name, Scopes: req.Scopes}
+	return &domain.CreateScopedAPIKeyResponse{APIKey: apiKey, Key: domain.APIKeyPrefix + "secret"}, nil
+}
+
+func (f *fakeAPIKeyService) List(_ context.Context, _ string) ([]*domain.APIKey, error) {
+	return f.keys, nil
+}
+
+func (f *fakeAPIKeyService) Revoke(_ context.Context, req *domain.RevokeAPIKeyRequest) error {
+	f.revoked = req
+	return f.revokeErr
+}
+
+func TestAPIKeyHandler(t *testing.T) {
+	newHandler := func(svc *fakeAPIKeyService) *APIKeyHandler {
+		return NewAPIKeyHandler(svc, func() ([]byte, error) { return []byte("secret"), nil }, logger.NewLoggerWithLevel("disabled"))
+	}
+
+	t.Run("create returns the key once", func(t *testing.T) {
+		handler := newHandler(&fakeAPIKeyService{})
+		body, _ := json.Marshal(domain.CreateScopedAPIKeyRequest{
+			WorkspaceID: "ws1",
+			Name:        "Sender",
+			Scopes:      []domain.APIKeyScope{domain.APIKeyScopeTransactionalSend},
+		})
+		rec := httptest.NewRecorder()
+		handler.handleCreate(rec, httptest.NewRequest(http.MethodPost, "/api/apiKeys.create", bytes.NewReader(body)))
+
+		assert.Equal(t, http.StatusCreated, rec.Code)
+		var response domain.CreateScopedAPIKeyResponse
+		require.NoError(t, json.NewDecoder(rec.Body).Decode(&response))
+		assert.Equal(t, domain.APIKeyPrefix+"secret", response.Key)
+		assert.Equal(t, domain.APIKeyScopes{domain.APIKeyScopeTransactionalSend}, response.APIKey.Scopes)
+	})
+
+	t.Run("create rejects unknown scopes", func(t *testing.T) {
+		handler := newHandler(&fakeAPIKeyService{})
+		body := `{"workspace_id":"ws1","name":"Sender","scopes":["contacts:delete"]}`
+		rec := httptest.NewRecorder()
+		handler.handleCreate(rec, httptest.NewRequest(http.MethodPost, "/api/apiKeys.create", bytes.NewBufferString(body)))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("create by a non owner", func(t *testing.T) {
+		handler := newHandler(&fakeAPIKeyService{createErr: &domain.ErrUnauthorized{Message: "user is not an owner of the workspace"}})
+		body := `{"workspace_id":"ws1","name":"Sender","scopes":["contacts:read"]}`
+		rec := httptest.NewRecorder()
+		handler.handleCreate(rec, httptest.NewRequest(http.MethodPost, "/api/apiKeys.create", bytes.NewBufferString(body)))
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+
+	t.Run("list", func(t *testing.T) {
+		handler := newHandler(&fakeAPIKeyService{keys: []*domain.APIKey{{ID: "key1", KeyHash: "hash"}}})
+		rec := httptest.NewRecorder()
+		handler.handleList(rec, httptest.NewRequest(http.MethodGet, "/api/apiKeys.list?workspace_id=ws1", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), `"id":"key1"`)
+		assert.NotContains(t, rec.Body.String(), "hash")
+
+		rec = httptest.NewRecorder()
+		handler.handleList(rec, httptest.NewRequest(http.MethodGet, "/api/apiKeys.list", nil))
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("revoke", func(t *testing.T) {
+		svc := &fakeAPIKeyService{}
+		handler := newHandler(svc)
+		rec := httptest.NewRecorder()
+		handler.handleRevoke(rec, httptest.NewRequest(http.MethodPost, "/api/apiKeys.revoke", bytes.NewBufferString(`{"workspace_id":"ws1","id":"key1"}`)))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		assert.Equal(t, &domain.RevokeAPIKeyRequest{WorkspaceID: "ws1", ID: "key1"}, svc.revoked)
+	})
+
+	t.Run("revoke an unknown key", func(t *testing.T) {
+		handler := newHandler(&fakeAPIKeyService{revokeErr: &domain.ErrAPIKeyNotFound{Message: "API key not found"}})
+		rec := httptest.NewRecorder()
+		handler.handleRevoke(rec, httptest.NewRequest(http.MethodPost, "/api/apiKeys.revoke", bytes.NewBufferString(`{"workspace_id":"ws1","id":"key1"}`)))
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("revoke failure", func(t *testing.T) {
+		handler := newHandler(&fakeAPIKeyService{revokeErr: errors.New("database error")})
+		rec := httptest.NewRecorder()
+		handler.handleRevoke(rec, httptest.NewRequest(http.MethodPost, "/api/apiKeys.revoke", bytes.NewBufferString(`{"workspace_id":"ws1","id":"key1"}`)))
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	})
+}
@@ -2,8 +2,10 @@ package http
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/Notifuse/notifuse/internal/domain"
 	"github.com/Notifuse/notifuse/internal/http/middleware"
@@ -20,9 +22,14 @@ func (e *MissingParameterError) Error() string {
 	return fmt.Sprintf("Missing parameter: %s", e.Param)
 }
 
+// progressHeartbeatInterval is the interval of the comments keeping idle progress streams open
+// through proxies
+const progressHeartbeatInterval = 15 * time.Second
+
 type BroadcastHandler struct {
 	service      domain.BroadcastService
 	templateSvc  domain.TemplateService
+	progress     domain.BroadcastProgressSubscriber
 	logger       logger.Logger
 	getJWTSecret func() ([]byte, error)
 	isDemo       bool
@@ -38,6 +45,11 @@ func NewBroadcastHandler(service domain.BroadcastService, templateSvc domain.Tem
 	}
 }
 
+// SetProgressSubscriber sets the source of the broadcast progress updates streamed to clients
+func (h *BroadcastHandler) SetProgressSubscriber(progress domain.BroadcastProgressSubscriber) {
+	h.progress = progress
+}
+
 func (h *BroadcastHandler) RegisterRoutes(mux *http.ServeMux) {
 	// Create auth middleware
 	authMiddleware := middleware.NewAuthMiddleware(h.getJWTSecret)
@@ -56,9 +68,20 @@ func (h *BroadcastHandler) RegisterRoutes(mux *http.ServeMux) {
 	mux.Handle("/api/broadcasts.cancel", requireAuth(http.HandlerFunc(h.HandleCancel)))
 	mux.Handle("/api/broadcasts.sendToIndividual", requireAuth(http.HandlerFunc(h.HandleSendToIndividual)))
 	mux.Handle("/api/broadcasts.delete", requireAuth(http.HandlerFunc(h.HandleDelete)))
+	mux.Handle("/api/broadcasts.progress", requireAuth(http.HandlerFunc(h.HandleProgress)))
+	// Tag management endpoints
+	mux.Handle("/api/broadcasts.tags", requireAuth(http.HandlerFunc(h.HandleListTags)))
+	mux.Handle("/api/broadcasts.setTags", requireAuth(http.HandlerFunc(h.HandleSetTags)))
+	mux.Handle("/api/broadcasts.deleteTag", requireAuth(http.HandlerFunc(h.HandleDeleteTag)))
+	// CSV audience upload endpoint
+	mux.Handle("/api/broadcasts.uploadAudience", requireAuth(http.HandlerFunc(h.HandleUploadAudience)))
 	// A/B Testing endpoints
 	mux.Handle("/api/broadcasts.getTestResults", requireAuth(http.HandlerFunc(h.HandleGetTestResults)))
+	mux.Handle("/api/broadcasts.preflight", requireAuth(http.HandlerFunc(h.HandlePreflight)))
+	mux.Handle("/api/broadcasts.stats", requireAuth(http.HandlerFunc(h.HandleStats)))
 	mux.Handle("/api/broadcasts.selectWinner", restrictedInDemo(requireAuth(http.HandlerFunc(h.HandleSelectWinner))))
+	// Seed test endpoint
+	mux.Handle("/api/broadcasts.approveSeedTest", restrictedInDemo(requireAuth(http.HandlerFunc(h.HandleApproveSeedTest))))
 }
 
 // HandleList handles the broadcast list request
@@ -75,11 +98,18 @@ func (h *BroadcastHandler) HandleList(w http.ResponseWriter, r *http.Request) {
 	}
 
 	params := domain.ListBroadcastsParams{
-		WorkspaceID:   req.WorkspaceID,
-		Status:        domain.BroadcastStatus(req.Status),
-		Limit:         req.Limit,
-		Offset:        req.Offset,
-		WithTemplates: req.WithTemplates,
+		WorkspaceID:     req.WorkspaceID,
+		Status:          domain.BroadcastStatus(req.Status),
+		Tag:             req.Tag,
+		CreatedAfter:    req.CreatedAfter,
+		CreatedBefore:   req.CreatedBefore,
+		CompletedAfter:  req.CompletedAfter,
+		CompletedBefore: req.CompletedBefore,
+		Search:          req.Search,
+		Limit:           req.Limit,
+		Offset:          req.Offset,
+		Cursor:          req.Cursor,
+		WithTemplates:   req.WithTemplates,
 	}
 
 	response, err := h.service.ListBroadcasts(r.Context(), params)
@@ -89,10 +119,14 @@ func (h *BroadcastHandler) HandleList(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	writeJSON(w, http.StatusOK, map[string]interface{}{
+	result := map[string]interface{}{
 		"broadcasts":  response.Broadcasts,
 		"total_count": response.TotalCount,
-	})
+	}
+	if response.NextCursor != "" {
+		result["next_cursor"] = response.NextCursor
+	}
+	writeJSON(w, http.StatusOK, result)
 }
 
 // HandleGet handles the broadcast get request
@@ -148,6 +182,87 @@ func (h *BroadcastHandler) HandleGet(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// HandleProgress streams the progress updates of a sending broadcast as server-sent events until
+// the client disconnects
+func (h *BroadcastHandler) HandleProgress(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req domain.BroadcastProgressRequest
+	if err := req.FromURLParams(r.URL.Query()); err != nil {
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if h.progress == nil {
+		WriteJSONError(w, "Broadcast progress streaming is not available", http.StatusServiceUnavailable)
+		return
+	}
+
+	// Loading the broadcast checks the access of the user to the workspace
+	if _, err := h.service.GetBroadcast(r.Context(), req.WorkspaceID, req.ID); err != nil {
+		if _, ok := err.(*domain.ErrBroadcastNotFound); ok {
+			WriteJSONError(w, "Broadcast not found", http.StatusNotFound)
+			return
+		}
+		h.logger.WithField("error", err.Error()).Error("Failed to get broadcast")
+		WriteJSONError(w, "Failed to get broadcast", http.StatusInternalServerError)
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		h.logger.Error("Streaming not supported")
+		WriteJSONError(w, "Streaming not supported", http.StatusInternalServerError)
+		return
+	}
+
+	updates, unsubscribe := h.progress.SubscribeProgress(req.WorkspaceID, req.ID)
+	defer unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no") // Disable nginx buffering
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(progressHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+			flusher.Flush()
+		case progress, ok := <-updates:
+			if !ok {
+				return
+			}
+			data, err := json.Marshal(progress)
+			if err != nil {
+				h.logger.WithField("error", err.Error()).Error("Failed to marshal broadcast progress")
+				continue
+			}
+			if _, err := fmt.Fprintf(w, "data: %s\n\n", data); err != nil {
+				return
+			}
+			flusher.Flush()
+		}
+	}
+}
+
 // HandleCreate handles the broadcast create request
 func (h *BroadcastHandler) HandleCreate(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
@@ -249,6 +364,26 @@ func (h *BroadcastHandler) HandleSchedule(w http.ResponseWriter, r *http.Request
 			WriteJSONError(w, "Broadcast not found", http.StatusNotFound)
 			return
 		}
+		var audienceErr *domain.ErrDeliverableAudienceTooLow
+		if errors.As(err, &audienceErr) {
+			WriteJSONError(w, audienceErr.Error(), http.StatusUnprocessableEntity)
+			return
+		}
+		var confirmationErr *domain.ErrSendConfirmationRequired
+		if errors.As(err, &confirmationErr) {
+			WriteJSONError(w, confirmationErr.Error(), http.StatusPreconditionRequired)
+			return
+		}
+		var blockedErr *domain.ErrWorkspaceSendingBlocked
+		if errors.As(err, &blockedErr) {
+			WriteJSONError(w, blockedErr.Error(), http.StatusLocked)
+			return
+		}
+		var validationErr domain.ValidationError
+		if errors.As(err, &validationErr) {
+			WriteJSONError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 		h.logger.WithField("error", err.Error()).Error("Failed to schedule broadcast")
 		WriteJSONError(w, "Failed to schedule broadcast", http.StatusInternalServerError)
 		return
@@ -319,6 +454,19 @@ func (h *BroadcastHandler) HandleResume(w http.ResponseWriter, r *http.Request)
 			WriteJSONError(w, "Broadcast not found", http.StatusNotFound)
 			return
 		}
+		var templateErr *domain.ErrBroadcastTemplateChanged
+		if errors.As(err, &templateErr) {
+			writeJSON(w, http.StatusConflict, map[string]interface{}{
+				"error":            templateErr.Error(),
+				"template_changes": templateErr.Changes,
+			})
+			return
+		}
+		var blockedErr *domain.ErrWorkspaceSendingBlocked
+		if errors.As(err, &blockedErr) {
+			WriteJSONError(w, blockedErr.Error(), http.StatusLocked)
+			return
+		}
 		h.logger.WithField("error", err.Error()).Error("Failed to resume broadcast")
 		WriteJSONError(w, "Failed to resume broadcast", http.StatusInternalServerError)
 		return
@@ -434,6 +582,153 @@ func (h *BroadcastHandler) HandleDelete(w http.ResponseWriter, r *http.Request)
 	})
 }
 
+// HandleListTags handles the request listing the broadcast tags of a workspace
+func (h *BroadcastHandler) HandleListTags(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	workspaceID := r.URL.Query().Get("workspace_id")
+	if workspaceID == "" {
+		WriteJSONError(w, "workspace_id is required", http.StatusBadRequest)
+		return
+	}
+
+	tags, err := h.service.ListBroadcastTags(r.Context(), workspaceID)
+	if err != nil {
+		var permissionErr *domain.PermissionError
+		if errors.As(err, &permissionErr) {
+			WriteJSONError(w, permissionErr.Message, http.StatusForbidden)
+			return
+		}
+		h.logger.WithField("error", err.Error()).Error("Failed to list broadcast tags")
+		WriteJSONError(w, "Failed to list broadcast tags", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"tags": tags,
+	})
+}
+
+// HandleSetTags handles the request replacing the tags of a broadcast
+func (h *BroadcastHandler) HandleSetTags(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req domain.SetBroadcastTagsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.WithField("error", err.Error()).Error("Failed to decode request body")
+		WriteJSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	broadcast, err := h.service.SetBroadcastTags(r.Context(), &req)
+	if err != nil {
+		if _, ok := err.(*domain.ErrBroadcastNotFound); ok {
+			WriteJSONError(w, "Broadcast not found", http.StatusNotFound)
+			return
+		}
+		var permissionErr *domain.PermissionError
+		if errors.As(err, &permissionErr) {
+			WriteJSONError(w, permissionErr.Message, http.StatusForbidden)
+			return
+		}
+		h.logger.WithField("error", err.Error()).Error("Failed to set broadcast tags")
+		WriteJSONError(w, "Failed to set broadcast tags", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"broadcast": broadcast,
+	})
+}
+
+// HandleUploadAudience handles the request uploading the CSV audience of a broadcast
+func (h *BroadcastHandler) HandleUploadAudience(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req domain.UploadBroadcastAudienceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.WithField("error", err.Error()).Error("Failed to decode request body")
+		WriteJSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if _, err := req.Validate(); err != nil {
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.service.UploadBroadcastAudience(r.Context(), &req)
+	if err != nil {
+		if _, ok := err.(*domain.ErrBroadcastNotFound); ok {
+			WriteJSONError(w, "Broadcast not found", http.StatusNotFound)
+			return
+		}
+		if errors.Is(err, domain.ErrBroadcastAudienceUploadRejected) {
+			WriteJSONError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var permissionErr *domain.PermissionError
+		if errors.As(err, &permissionErr) {
+			WriteJSONError(w, permissionErr.Message, http.StatusForbidden)
+			return
+		}
+		h.logger.WithField("error", err.Error()).Error("Failed to upload broadcast audience")
+		WriteJSONError(w, "Failed to upload broadcast audience", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// HandleDeleteTag handles the request removing a tag from every broadcast of a workspace
+func (h *BroadcastHandler) HandleDeleteTag(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req domain.DeleteBroadcastTagRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.WithField("error", err.Error()).Error("Failed to decode request body")
+		WriteJSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := h.service.DeleteBroadcastTag(r.Context(), &req); err != nil {
+		var permissionErr *domain.PermissionError
+		if errors.As(err, &permissionErr) {
+			WriteJSONError(w, permissionErr.Message, http.StatusForbidden)
+			return
+		}
+		h.logger.WithField("error", err.Error()).Error("Failed to delete broadcast tag")
+		WriteJSONError(w, "Failed to delete broadcast tag", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+	})
+}
+
 // HandleGetTestResults handles the A/B test results request
 func (h *BroadcastHandler) HandleGetTestResults(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
@@ -466,6 +761,80 @@ func (h *BroadcastHandler) HandleGetTestResults(w http.ResponseWriter, r *http.R
 	writeJSON(w, http.StatusOK, results)
 }
 
+// HandlePreflight handles the broadcast audience preflight request
+func (h *BroadcastHandler) HandlePreflight(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req domain.BroadcastPreflightRequest
+	if err := req.FromURLParams(r.URL.Query()); err != nil {
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	preflight, err := h.service.PreflightBroadcast(r.Context(), req.WorkspaceID, req.ID)
+	if err != nil {
+		if _, ok := err.(*domain.ErrBroadcastNotFound); ok {
+			WriteJSONError(w, "Broadcast not found", http.StatusNotFound)
+			return
+		}
+		h.logger.WithFields(map[string]interface{}{
+			"workspace_id": req.WorkspaceID,
+			"broadcast_id": req.ID,
+			"error":        err.Error(),
+		}).Error("Failed to run broadcast preflight")
+		WriteJSONError(w, "Failed to run broadcast preflight", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"preflight": preflight,
+	})
+}
+
+// HandleStats handles the broadcast live stats request
+func (h *BroadcastHandler) HandleStats(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req domain.GetBroadcastStatsRequest
+	if err := req.FromURLParams(r.URL.Query()); err != nil {
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	stats, err := h.service.GetBroadcastStats(r.Context(), req.WorkspaceID, req.ID)
+	if err != nil {
+		if _, ok := err.(*domain.ErrBroadcastNotFound); ok {
+			WriteJSONError(w, "Broadcast not found", http.StatusNotFound)
+			return
+		}
+		h.logger.WithFields(map[string]interface{}{
+			"workspace_id": req.WorkspaceID,
+			"broadcast_id": req.ID,
+			"error":        err.Error(),
+		}).Error("Failed to get broadcast stats")
+		WriteJSONError(w, "Failed to get broadcast stats", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, stats)
+}
+
 // HandleSelectWinner handles the winner selection request
 func (h *BroadcastHandler) HandleSelectWinner(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
@@ -501,3 +870,38 @@ func (h *BroadcastHandler) HandleSelectWinner(w http.ResponseWriter, r *http.Req
 		"success": true,
 	})
 }
+
+// HandleApproveSeedTest handles the approval of the seed test of a broadcast, releasing its main send
+func (h *BroadcastHandler) HandleApproveSeedTest(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req domain.ApproveSeedTestRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.WithField("error", err.Error()).Error("Failed to decode request body")
+		WriteJSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	err := h.service.ApproveSeedTest(r.Context(), req.WorkspaceID, req.ID)
+	if err != nil {
+		h.logger.WithFields(map[string]interface{}{
+			"workspace_id": req.WorkspaceID,
+			"broadcast_id": req.ID,
+			"error":        err.Error(),
+		}).Error("Failed to approve seed test")
+		WriteJSONError(w, "Failed to approve seed test", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"success": true,
+	})
+}
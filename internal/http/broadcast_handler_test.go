@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -21,6 +22,7 @@ import (
 	"github.com/golang/mock/gomock"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // Helper function to create a test broadcast
@@ -141,6 +143,37 @@ func TestHandleList(t *testing.T) {
 		assert.Contains(t, response, "total_count")
 	})
 
+	// Test with tag and creation date filters
+	t.Run("WithTagAndDateFilters", func(t *testing.T) {
+		mockService.EXPECT().
+			ListBroadcasts(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, params domain.ListBroadcastsParams) (*domain.BroadcastListResponse, error) {
+				assert.Equal(t, "newsletter", params.Tag)
+				require.NotNil(t, params.CreatedAfter)
+				require.NotNil(t, params.CreatedBefore)
+				assert.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), params.CreatedAfter.UTC())
+				assert.Equal(t, time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), params.CreatedBefore.UTC())
+				return responseWithTotal, nil
+			})
+
+		req := httptest.NewRequest(http.MethodGet, "/api/broadcasts.list?workspace_id=workspace123&tag=newsletter&created_after=2026-01-01T00:00:00Z&created_before=2026-02-01T00:00:00Z", nil)
+		w := httptest.NewRecorder()
+
+		handler.HandleList(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	// Test invalid date filter
+	t.Run("InvalidDateFilter", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/broadcasts.list?workspace_id=workspace123&created_after=yesterday", nil)
+		w := httptest.NewRecorder()
+
+		handler.HandleList(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
 	// Test invalid pagination parameters
 	t.Run("InvalidPaginationParams", func(t *testing.T) {
 		// Create a test request with invalid pagination parameters
@@ -542,6 +575,58 @@ func TestHandleSchedule(t *testing.T) {
 		assert.True(t, response["success"].(bool))
 	})
 
+	// Test audience preflight blocking the schedule
+	t.Run("DeliverableAudienceTooLow", func(t *testing.T) {
+		preflight := domain.NewAudiencePreflight(1000, 120, &domain.DeliverableAudienceSettings{Enabled: true, MinRatio: 0.5, Block: true})
+		mockService.EXPECT().
+			ScheduleBroadcast(gomock.Any(), gomock.Any()).
+			Return(&domain.ErrDeliverableAudienceTooLow{Preflight: preflight})
+
+		requestBody, _ := json.Marshal(&domain.ScheduleBroadcastRequest{WorkspaceID: "workspace123", ID: "broadcast123", SendNow: true})
+		req := httptest.NewRequest(http.MethodPost, "/api/broadcasts.schedule", bytes.NewBuffer(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		handler.HandleSchedule(w, req)
+
+		assert.Equal(t, http.StatusUnprocessableEntity, w.Code)
+		assert.Contains(t, w.Body.String(), "only 120 of 1000 audience contacts are deliverable")
+	})
+
+	// Test send confirmation token missing or expired
+	t.Run("SendConfirmationRequired", func(t *testing.T) {
+		mockService.EXPECT().
+			ScheduleBroadcast(gomock.Any(), gomock.Any()).
+			Return(&domain.ErrSendConfirmationRequired{Reason: "confirmation token expired, run the preflight again"})
+
+		requestBody, _ := json.Marshal(&domain.ScheduleBroadcastRequest{WorkspaceID: "workspace123", ID: "broadcast123", SendNow: true, ConfirmationToken: "123.abc"})
+		req := httptest.NewRequest(http.MethodPost, "/api/broadcasts.schedule", bytes.NewBuffer(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		handler.HandleSchedule(w, req)
+
+		assert.Equal(t, http.StatusPreconditionRequired, w.Code)
+		assert.Contains(t, w.Body.String(), "confirmation token expired")
+	})
+
+	// Test complaint spike sending block
+	t.Run("SendingBlocked", func(t *testing.T) {
+		mockService.EXPECT().
+			ScheduleBroadcast(gomock.Any(), gomock.Any()).
+			Return(&domain.ErrWorkspaceSendingBlocked{Block: &domain.SendingBlock{Reason: "Complaint rate 0.80% over the last 1h0m0s exceeds 0.30%"}})
+
+		requestBody, _ := json.Marshal(&domain.ScheduleBroadcastRequest{WorkspaceID: "workspace123", ID: "broadcast123", SendNow: true})
+		req := httptest.NewRequest(http.MethodPost, "/api/broadcasts.schedule", bytes.NewBuffer(requestBody))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		handler.HandleSchedule(w, req)
+
+		assert.Equal(t, http.StatusLocked, w.Code)
+		assert.Contains(t, w.Body.String(), "sending is blocked for this workspace")
+	})
+
 	// Test validation error
 	t.Run("ValidationError", func(t *testing.T) {
 		request := &domain.ScheduleBroadcastRequest{
@@ -1432,6 +1517,35 @@ func TestHandleResume(t *testing.T) {
 		assert.True(t, response["success"].(bool))
 	})
 
+	// Test templates edited while paused
+	t.Run("TemplateChanged", func(t *testing.T) {
+		req := domain.ResumeBroadcastRequest{
+			WorkspaceID: "workspace123",
+			ID:          "broadcast123",
+		}
+
+		mockService.EXPECT().
+			ResumeBroadcast(gomock.Any(), &req).
+			Return(&domain.ErrBroadcastTemplateChanged{Changes: []domain.BroadcastTemplateChange{
+				{TemplateID: "template123", PinnedVersion: 2, CurrentVersion: 3},
+			}})
+
+		jsonData, _ := json.Marshal(req)
+		httpReq := httptest.NewRequest(http.MethodPost, "/api/broadcasts.resume", bytes.NewBuffer(jsonData))
+		httpReq.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		handler.HandleResume(w, httpReq)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		var response map[string]interface{}
+		_ = json.Unmarshal(w.Body.Bytes(), &response)
+		assert.Contains(t, response["error"], "template123")
+		changes := response["template_changes"].([]interface{})
+		require.Len(t, changes, 1)
+		assert.Equal(t, float64(3), changes[0].(map[string]interface{})["current_version"])
+	})
+
 	// Test invalid request (method not allowed)
 	t.Run("MethodNotAllowed", func(t *testing.T) {
 		httpReq := httptest.NewRequest(http.MethodGet, "/api/broadcasts.resume", nil)
@@ -1683,6 +1797,12 @@ func TestRegisterRoutes(t *testing.T) {
 		"/api/broadcasts.cancel",
 		"/api/broadcasts.sendToIndividual",
 		"/api/broadcasts.delete",
+		"/api/broadcasts.tags",
+		"/api/broadcasts.setTags",
+		"/api/broadcasts.deleteTag",
+		"/api/broadcasts.uploadAudience",
+		"/api/broadcasts.stats",
+		"/api/broadcasts.approveSeedTest",
 	}
 
 	// Verify all routes are registered
@@ -1752,6 +1872,230 @@ func TestHandleGetTestResults(t *testing.T) {
 	})
 }
 
+func TestHandleStats(t *testing.T) {
+	handler, mockService, _, _, ctrl := setupBroadcastHandler(t)
+	defer ctrl.Finish()
+
+	t.Run("Success", func(t *testing.T) {
+		stats := domain.NewBroadcastStats("broadcast123", &domain.MessageHistoryStatusSum{TotalSent: 200, TotalDelivered: 190, TotalOpened: 80, TotalClicked: 20, TotalBounced: 4})
+		mockService.EXPECT().GetBroadcastStats(gomock.Any(), "workspace123", "broadcast123").Return(stats, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/broadcasts.stats?workspace_id=workspace123&id=broadcast123", nil)
+		w := httptest.NewRecorder()
+		handler.HandleStats(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var body domain.BroadcastStats
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, "broadcast123", body.BroadcastID)
+		assert.Equal(t, 200, body.Stats.TotalSent)
+		assert.InDelta(t, 0.95, body.Rates.DeliveryRate, 0.0001)
+		assert.InDelta(t, 0.4, body.Rates.OpenRate, 0.0001)
+		assert.InDelta(t, 0.1, body.Rates.ClickRate, 0.0001)
+		assert.InDelta(t, 0.02, body.Rates.BounceRate, 0.0001)
+	})
+
+	t.Run("ValidationError", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/broadcasts.stats?workspace_id=workspace123", nil) // missing id
+		w := httptest.NewRecorder()
+		handler.HandleStats(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mockService.EXPECT().GetBroadcastStats(gomock.Any(), "workspace123", "missing").Return(nil, &domain.ErrBroadcastNotFound{ID: "missing"})
+
+		req := httptest.NewRequest(http.MethodGet, "/api/broadcasts.stats?workspace_id=workspace123&id=missing", nil)
+		w := httptest.NewRecorder()
+		handler.HandleStats(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("MethodNotAllowed", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/api/broadcasts.stats?workspace_id=workspace123&id=broadcast123", nil)
+		w := httptest.NewRecorder()
+		handler.HandleStats(w, req)
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+}
+
+func TestHandlePreflight(t *testing.T) {
+	handler, mockService, _, _, ctrl := setupBroadcastHandler(t)
+	defer ctrl.Finish()
+
+	t.Run("Success", func(t *testing.T) {
+		preflight := domain.NewAudiencePreflight(1000, 120, &domain.DeliverableAudienceSettings{Enabled: true, MinRatio: 0.5})
+		mockService.EXPECT().PreflightBroadcast(gomock.Any(), "workspace123", "broadcast123").Return(preflight, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/broadcasts.preflight?workspace_id=workspace123&id=broadcast123", nil)
+		w := httptest.NewRecorder()
+		handler.HandlePreflight(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var body struct {
+			Preflight domain.AudiencePreflight `json:"preflight"`
+		}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, 1000, body.Preflight.RawCount)
+		assert.True(t, body.Preflight.BelowThreshold)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mockService.EXPECT().PreflightBroadcast(gomock.Any(), "workspace123", "missing").Return(nil, &domain.ErrBroadcastNotFound{ID: "missing"})
+
+		req := httptest.NewRequest(http.MethodGet, "/api/broadcasts.preflight?workspace_id=workspace123&id=missing", nil)
+		w := httptest.NewRecorder()
+		handler.HandlePreflight(w, req)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("ValidationError", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/broadcasts.preflight?workspace_id=workspace123", nil)
+		w := httptest.NewRecorder()
+		handler.HandlePreflight(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestHandleBroadcastTags(t *testing.T) {
+	handler, mockService, _, _, ctrl := setupBroadcastHandler(t)
+	defer ctrl.Finish()
+
+	t.Run("ListTags", func(t *testing.T) {
+		mockService.EXPECT().ListBroadcastTags(gomock.Any(), "workspace123").
+			Return([]*domain.BroadcastTagCount{{Tag: "newsletter", Count: 2}}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/broadcasts.tags?workspace_id=workspace123", nil)
+		w := httptest.NewRecorder()
+		handler.HandleListTags(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var body struct {
+			Tags []domain.BroadcastTagCount `json:"tags"`
+		}
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+		assert.Equal(t, []domain.BroadcastTagCount{{Tag: "newsletter", Count: 2}}, body.Tags)
+	})
+
+	t.Run("ListTagsMissingWorkspace", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/broadcasts.tags", nil)
+		w := httptest.NewRecorder()
+		handler.HandleListTags(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("SetTags", func(t *testing.T) {
+		broadcast := createTestBroadcast()
+		broadcast.Tags = []string{"newsletter"}
+		mockService.EXPECT().SetBroadcastTags(gomock.Any(), &domain.SetBroadcastTagsRequest{
+			WorkspaceID: "workspace123",
+			ID:          "broadcast123",
+			Tags:        []string{"newsletter"},
+		}).Return(broadcast, nil)
+
+		body := `{"workspace_id":"workspace123","id":"broadcast123","tags":["newsletter"]}`
+		req := httptest.NewRequest(http.MethodPost, "/api/broadcasts.setTags", bytes.NewBufferString(body))
+		w := httptest.NewRecorder()
+		handler.HandleSetTags(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("SetTagsTooLong", func(t *testing.T) {
+		body := `{"workspace_id":"workspace123","id":"broadcast123","tags":["` + strings.Repeat("a", domain.MaxBroadcastTagLength+1) + `"]}`
+		req := httptest.NewRequest(http.MethodPost, "/api/broadcasts.setTags", bytes.NewBufferString(body))
+		w := httptest.NewRecorder()
+		handler.HandleSetTags(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("SetTagsNotFound", func(t *testing.T) {
+		mockService.EXPECT().SetBroadcastTags(gomock.Any(), gomock.Any()).Return(nil, &domain.ErrBroadcastNotFound{ID: "missing"})
+
+		body := `{"workspace_id":"workspace123","id":"missing","tags":["newsletter"]}`
+		req := httptest.NewRequest(http.MethodPost, "/api/broadcasts.setTags", bytes.NewBufferString(body))
+		w := httptest.NewRecorder()
+		handler.HandleSetTags(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("DeleteTag", func(t *testing.T) {
+		mockService.EXPECT().DeleteBroadcastTag(gomock.Any(), &domain.DeleteBroadcastTagRequest{
+			WorkspaceID: "workspace123",
+			Tag:         "promo",
+		}).Return(nil)
+
+		body := `{"workspace_id":"workspace123","tag":"promo"}`
+		req := httptest.NewRequest(http.MethodPost, "/api/broadcasts.deleteTag", bytes.NewBufferString(body))
+		w := httptest.NewRecorder()
+		handler.HandleDeleteTag(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("DeleteTagMethodNotAllowed", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/broadcasts.deleteTag", nil)
+		w := httptest.NewRecorder()
+		handler.HandleDeleteTag(w, req)
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+}
+
+func TestHandleUploadAudience(t *testing.T) {
+	handler, mockService, _, _, ctrl := setupBroadcastHandler(t)
+	defer ctrl.Finish()
+
+	t.Run("Success", func(t *testing.T) {
+		mockService.EXPECT().UploadBroadcastAudience(gomock.Any(), &domain.UploadBroadcastAudienceRequest{
+			WorkspaceID: "workspace123",
+			BroadcastID: "broadcast123",
+			CSV:         "email\nalice@example.com\n",
+		}).Return(&domain.UploadBroadcastAudienceResponse{Recipients: 1}, nil)
+
+		body := `{"workspace_id":"workspace123","broadcast_id":"broadcast123","csv":"email\nalice@example.com\n"}`
+		req := httptest.NewRequest(http.MethodPost, "/api/broadcasts.uploadAudience", bytes.NewBufferString(body))
+		w := httptest.NewRecorder()
+		handler.HandleUploadAudience(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response domain.UploadBroadcastAudienceResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, 1, response.Recipients)
+	})
+
+	t.Run("InvalidCSV", func(t *testing.T) {
+		body := `{"workspace_id":"workspace123","broadcast_id":"broadcast123","csv":"name\nAlice\n"}`
+		req := httptest.NewRequest(http.MethodPost, "/api/broadcasts.uploadAudience", bytes.NewBufferString(body))
+		w := httptest.NewRecorder()
+		handler.HandleUploadAudience(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "email column")
+	})
+
+	t.Run("Rejected", func(t *testing.T) {
+		mockService.EXPECT().UploadBroadcastAudience(gomock.Any(), gomock.Any()).
+			Return(nil, fmt.Errorf("%w: broadcast audience is not a CSV audience", domain.ErrBroadcastAudienceUploadRejected))
+
+		body := `{"workspace_id":"workspace123","broadcast_id":"broadcast123","csv":"email\nalice@example.com\n"}`
+		req := httptest.NewRequest(http.MethodPost, "/api/broadcasts.uploadAudience", bytes.NewBufferString(body))
+		w := httptest.NewRecorder()
+		handler.HandleUploadAudience(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		assert.Contains(t, w.Body.String(), "not a CSV audience")
+	})
+
+	t.Run("MethodNotAllowed", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/broadcasts.uploadAudience", nil)
+		w := httptest.NewRecorder()
+		handler.HandleUploadAudience(w, req)
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+}
+
 func TestHandleSelectWinner(t *testing.T) {
 	handler, mockService, _, mockLogger, ctrl := setupBroadcastHandler(t)
 	defer ctrl.Finish()
@@ -1821,6 +2165,59 @@ func TestHandleSelectWinner(t *testing.T) {
 	})
 }
 
+func TestHandleApproveSeedTest(t *testing.T) {
+	handler, mockService, _, mockLogger, ctrl := setupBroadcastHandler(t)
+	defer ctrl.Finish()
+
+	t.Run("Success", func(t *testing.T) {
+		reqBody := domain.ApproveSeedTestRequest{WorkspaceID: "workspace123", ID: "broadcast123"}
+		b, _ := json.Marshal(reqBody)
+		httpReq := httptest.NewRequest(http.MethodPost, "/api/broadcasts.approveSeedTest", bytes.NewBuffer(b))
+		httpReq.Header.Set("Content-Type", "application/json")
+
+		mockService.EXPECT().ApproveSeedTest(gomock.Any(), "workspace123", "broadcast123").Return(nil)
+
+		w := httptest.NewRecorder()
+		handler.HandleApproveSeedTest(w, httpReq)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var body map[string]interface{}
+		_ = json.Unmarshal(w.Body.Bytes(), &body)
+		assert.True(t, body["success"].(bool))
+	})
+
+	t.Run("ValidationError", func(t *testing.T) {
+		b, _ := json.Marshal(map[string]string{"workspace_id": "workspace123"})
+		httpReq := httptest.NewRequest(http.MethodPost, "/api/broadcasts.approveSeedTest", bytes.NewBuffer(b))
+		w := httptest.NewRecorder()
+		handler.HandleApproveSeedTest(w, httpReq)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("ServiceError", func(t *testing.T) {
+		withFields := pkgmocks.NewMockLogger(ctrl)
+		mockLogger.EXPECT().WithFields(gomock.Any()).Return(withFields)
+		withFields.EXPECT().Error("Failed to approve seed test")
+
+		reqBody := domain.ApproveSeedTestRequest{WorkspaceID: "workspace123", ID: "broadcast123"}
+		b, _ := json.Marshal(reqBody)
+		httpReq := httptest.NewRequest(http.MethodPost, "/api/broadcasts.approveSeedTest", bytes.NewBuffer(b))
+
+		mockService.EXPECT().ApproveSeedTest(gomock.Any(), "workspace123", "broadcast123").Return(errors.New("not awaiting approval"))
+
+		w := httptest.NewRecorder()
+		handler.HandleApproveSeedTest(w, httpReq)
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+
+	t.Run("MethodNotAllowed", func(t *testing.T) {
+		httpReq := httptest.NewRequest(http.MethodGet, "/api/broadcasts.approveSeedTest", nil)
+		w := httptest.NewRecorder()
+		handler.HandleApproveSeedTest(w, httpReq)
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+}
+
 func TestMissingParameterError_Error(t *testing.T) {
 	// Test MissingParameterError.Error - this was at 0% coverage
 	t.Run("returns formatted error message", func(t *testing.T) {
@@ -1839,3 +2236,91 @@ func TestMissingParameterError_Error(t *testing.T) {
 		assert.Equal(t, expected, err.Error())
 	})
 }
+
+// fakeProgressSubscriber hands out a progress channel controlled by the test
+type fakeProgressSubscriber struct {
+	updates      chan domain.BroadcastProgress
+	workspaceID  string
+	broadcastID  string
+	unsubscribed bool
+}
+
+func (f *fakeProgressSubscriber) SubscribeProgress(workspaceID, broadcastID string) (<-chan domain.BroadcastProgress, func()) {
+	f.workspaceID = workspaceID
+	f.broadcastID = broadcastID
+	return f.updates, func() { f.unsubscribed = true }
+}
+
+func TestHandleProgress(t *testing.T) {
+	t.Run("StreamsProgressUntilTheUpdatesEnd", func(t *testing.T) {
+		handler, mockService, _, _, ctrl := setupBroadcastHandler(t)
+		defer ctrl.Finish()
+
+		subscriber := &fakeProgressSubscriber{updates: make(chan domain.BroadcastProgress, 2)}
+		handler.SetProgressSubscriber(subscriber)
+		subscriber.updates <- domain.BroadcastProgress{BroadcastID: "broadcast123", Processed: 50, Total: 100, Percent: 50, Sent: 49, Failed: 1, ETASeconds: 60}
+		close(subscriber.updates)
+
+		mockService.EXPECT().GetBroadcast(gomock.Any(), "workspace123", "broadcast123").Return(createTestBroadcast(), nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/broadcasts.progress?workspace_id=workspace123&id=broadcast123", nil)
+		w := httptest.NewRecorder()
+
+		handler.HandleProgress(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "text/event-stream", w.Header().Get("Content-Type"))
+		assert.Equal(t, "data: {\"broadcast_id\":\"broadcast123\",\"processed\":50,\"total\":100,\"percent\":50,\"sent\":49,\"failed\":1,\"eta_seconds\":60,\"message\":\"\"}\n\n", w.Body.String())
+		assert.Equal(t, "workspace123", subscriber.workspaceID)
+		assert.Equal(t, "broadcast123", subscriber.broadcastID)
+		assert.True(t, subscriber.unsubscribed)
+	})
+
+	t.Run("StopsWhenTheClientDisconnects", func(t *testing.T) {
+		handler, mockService, _, _, ctrl := setupBroadcastHandler(t)
+		defer ctrl.Finish()
+
+		subscriber := &fakeProgressSubscriber{updates: make(chan domain.BroadcastProgress)}
+		handler.SetProgressSubscriber(subscriber)
+
+		mockService.EXPECT().GetBroadcast(gomock.Any(), "workspace123", "broadcast123").Return(createTestBroadcast(), nil)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		req := httptest.NewRequest(http.MethodGet, "/api/broadcasts.progress?workspace_id=workspace123&id=broadcast123", nil).WithContext(ctx)
+		w := httptest.NewRecorder()
+
+		handler.HandleProgress(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Empty(t, w.Body.String())
+		assert.True(t, subscriber.unsubscribed)
+	})
+
+	t.Run("BroadcastNotFound", func(t *testing.T) {
+		handler, mockService, _, _, ctrl := setupBroadcastHandler(t)
+		defer ctrl.Finish()
+
+		handler.SetProgressSubscriber(&fakeProgressSubscriber{})
+		mockService.EXPECT().GetBroadcast(gomock.Any(), "workspace123", "missing").Return(nil, &domain.ErrBroadcastNotFound{ID: "missing"})
+
+		req := httptest.NewRequest(http.MethodGet, "/api/broadcasts.progress?workspace_id=workspace123&id=missing", nil)
+		w := httptest.NewRecorder()
+
+		handler.HandleProgress(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("MissingBroadcastID", func(t *testing.T) {
+		handler, _, _, _, ctrl := setupBroadcastHandler(t)
+		defer ctrl.Finish()
+
+		req := httptest.NewRequest(http.MethodGet, "/api/broadcasts.progress?workspace_id=workspace123", nil)
+		w := httptest.NewRecorder()
+
+		handler.HandleProgress(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -2,13 +2,16 @@ package http
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/Notifuse/notifuse/internal/domain"
 	"github.com/Notifuse/notifuse/internal/http/middleware"
+	"github.com/Notifuse/notifuse/pkg/export"
 	"github.com/Notifuse/notifuse/pkg/logger"
 )
 
@@ -34,8 +37,11 @@ func (h *ContactHandler) RegisterRoutes(mux *http.ServeMux) {
 	// Register RPC-style endpoints with dot notation
 	mux.Handle("/api/contacts.list", requireAuth(http.HandlerFunc(h.handleList)))
 	mux.Handle("/api/contacts.count", requireAuth(http.HandlerFunc(h.handleCount)))
+	mux.Handle("/api/contacts.audienceCounts", requireAuth(http.HandlerFunc(h.handleAudienceCounts)))
+	mux.Handle("/api/contacts.export", requireAuth(http.HandlerFunc(h.handleExport)))
 	mux.Handle("/api/contacts.getByEmail", requireAuth(http.HandlerFunc(h.handleGetByEmail)))
 	mux.Handle("/api/contacts.getByExternalID", requireAuth(http.HandlerFunc(h.handleGetByExternalID)))
+	mux.Handle("/api/contacts.bulkGet", requireAuth(http.HandlerFunc(h.handleBulkGet)))
 	mux.Handle("/api/contacts.delete", requireAuth(http.HandlerFunc(h.handleDelete)))
 	mux.Handle("/api/contacts.import", requireAuth(http.HandlerFunc(h.handleImport)))
 	mux.Handle("/api/contacts.upsert", requireAuth(http.HandlerFunc(h.handleUpsert)))
@@ -71,6 +77,42 @@ func (h *ContactHandler) handleList(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// handleExport streams contacts matching the list filters as CSV, optionally gzip-compressed
+// (compress=gzip) and split into N-row parts bundled with a manifest (part_size=N)
+func (h *ContactHandler) handleExport(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	domainReq := &domain.GetContactsRequest{}
+	if err := domainReq.FromQueryParams(query); err != nil {
+		WriteJSONError(w, fmt.Sprintf("Invalid request: %v", err), http.StatusBadRequest)
+		return
+	}
+	domainReq.Limit = exportPageSize
+
+	opts, err := export.OptionsFromQuery(query)
+	if err != nil {
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	streamExport(w, r, h.logger, "contacts", contactExportHeader, opts, func(cursor string) ([][]string, string, error) {
+		domainReq.Cursor = cursor
+		response, err := h.service.GetContacts(r.Context(), domainReq)
+		if err != nil {
+			return nil, "", err
+		}
+		rows := make([][]string, 0, len(response.Contacts))
+		for _, contact := range response.Contacts {
+			rows = append(rows, contactExportRow(contact))
+		}
+		return rows, response.NextCursor, nil
+	})
+}
+
 func (h *ContactHandler) handleCount(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
@@ -96,6 +138,29 @@ func (h *ContactHandler) handleCount(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+func (h *ContactHandler) handleAudienceCounts(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	// Get workspace_id from query params
+	workspaceID := r.URL.Query().Get("workspace_id")
+	if workspaceID == "" {
+		WriteJSONError(w, "Missing workspace ID", http.StatusBadRequest)
+		return
+	}
+
+	counts, err := h.service.GetAudienceCounts(r.Context(), workspaceID)
+	if err != nil {
+		h.logger.WithField("error", err.Error()).Error("Failed to get audience counts")
+		WriteJSONError(w, "Failed to get audience counts", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, counts)
+}
+
 func (h *ContactHandler) handleGetByEmail(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
@@ -164,6 +229,39 @@ func (h *ContactHandler) handleGetByExternalID(w http.ResponseWriter, r *http.Re
 	})
 }
 
+// handleBulkGet returns the contacts matching a list of emails or external IDs, in request order
+func (h *ContactHandler) handleBulkGet(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req domain.BulkGetContactsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteJSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	response, err := h.service.BulkGetContacts(r.Context(), &req)
+	if err != nil {
+		var validationErr domain.ValidationError
+		if errors.As(err, &validationErr) {
+			WriteJSONError(w, validationErr.Message, http.StatusBadRequest)
+			return
+		}
+		var permissionErr *domain.PermissionError
+		if errors.As(err, &permissionErr) {
+			WriteJSONError(w, permissionErr.Message, http.StatusForbidden)
+			return
+		}
+		h.logger.WithField("error", err.Error()).Error("Failed to bulk get contacts")
+		WriteJSONError(w, "Failed to get contacts", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
 func (h *ContactHandler) handleDelete(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
@@ -203,6 +301,11 @@ func (h *ContactHandler) handleImport(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if isJSONLinesContentType(r.Header.Get("Content-Type")) {
+		h.handleImportLines(w, r)
+		return
+	}
+
 	// Read the request body
 	body, err := io.ReadAll(r.Body)
 	if err != nil {
@@ -224,7 +327,12 @@ func (h *ContactHandler) handleImport(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	result := h.service.BatchImportContacts(r.Context(), workspaceID, contacts, req.SubscribeToLists)
+	var result *domain.BatchImportContactsResponse
+	if req.ValidateOnly {
+		result = h.service.ValidateImportContacts(r.Context(), workspaceID, contacts, req.SubscribeToLists)
+	} else {
+		result = h.service.BatchImportContacts(r.Context(), workspaceID, contacts, req.SubscribeToLists, req.OnConflict)
+	}
 	if result.Error != "" {
 		h.logger.WithField("error", result.Error).Error("Failed to import contacts")
 		WriteJSONError(w, result.Error, http.StatusInternalServerError)
@@ -240,6 +348,61 @@ func (h *ContactHandler) handleImport(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// jsonLinesContentTypes are the content types of a JSON Lines contact import
+var jsonLinesContentTypes = []string{"application/x-ndjson", "application/jsonl", "application/x-jsonlines"}
+
+func isJSONLinesContentType(contentType string) bool {
+	mediaType := strings.ToLower(strings.TrimSpace(strings.Split(contentType, ";")[0]))
+	for _, jsonLinesType := range jsonLinesContentTypes {
+		if mediaType == jsonLinesType {
+			return true
+		}
+	}
+	return false
+}
+
+// handleImportLines streams a JSON Lines body, one contact object per line, into the workspace.
+// The workspace comes from the workspace_id query param, merge_strategy selects how existing
+// contacts are merged (overwrite_all by default) and dryRun=true only validates the lines.
+func (h *ContactHandler) handleImportLines(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	workspaceID := query.Get("workspace_id")
+	if workspaceID == "" {
+		WriteJSONError(w, "Missing workspace ID", http.StatusBadRequest)
+		return
+	}
+
+	dryRun := false
+	if value := query.Get("dryRun"); value != "" {
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			WriteJSONError(w, "Invalid dryRun value", http.StatusBadRequest)
+			return
+		}
+		dryRun = parsed
+	}
+
+	result, err := h.service.ImportContactLines(r.Context(), workspaceID, r.Body, domain.MergeStrategy(query.Get("merge_strategy")), dryRun)
+	if err != nil {
+		var validationErr domain.ValidationError
+		if errors.As(err, &validationErr) {
+			WriteJSONError(w, validationErr.Message, http.StatusBadRequest)
+			return
+		}
+		var permissionErr *domain.PermissionError
+		if errors.As(err, &permissionErr) {
+			WriteJSONError(w, permissionErr.Message, http.StatusForbidden)
+			return
+		}
+		h.logger.WithField("error", err.Error()).Error("Failed to import contact lines")
+		WriteJSONError(w, "Failed to import contacts", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
 func (h *ContactHandler) handleUpsert(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
@@ -1,7 +1,10 @@
 package http
 
 import (
+	"archive/zip"
 	"bytes"
+	"compress/gzip"
+	"encoding/csv"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -52,9 +55,12 @@ func TestContactHandler_RegisterRoutes(t *testing.T) {
 	endpoints := []string{
 		"/api/contacts.list",
 		"/api/contacts.count",
+		"/api/contacts.audienceCounts",
+		"/api/contacts.export",
 		"/api/contacts.get",
 		"/api/contacts.getByEmail",
 		"/api/contacts.getByExternalID",
+		"/api/contacts.bulkGet",
 		"/api/contacts.delete",
 		"/api/contacts.import",
 		"/api/contacts.upsert",
@@ -239,6 +245,76 @@ func TestContactHandler_HandleCount(t *testing.T) {
 	}
 }
 
+func TestContactHandler_HandleAudienceCounts(t *testing.T) {
+	counts := &domain.AudienceCounts{
+		Lists:    []domain.ListContactCount{{ListID: "newsletter", Name: "Newsletter", ActiveCount: 12}},
+		Segments: []domain.SegmentContactCount{{SegmentID: "vip", Name: "VIP", ContactCount: 3}},
+	}
+
+	testCases := []struct {
+		name           string
+		method         string
+		queryParams    string
+		setupMock      func(*mocks.MockContactService)
+		expectedStatus int
+	}{
+		{
+			name:        "Audience Counts Success",
+			method:      http.MethodGet,
+			queryParams: "workspace_id=workspace123",
+			setupMock: func(m *mocks.MockContactService) {
+				m.EXPECT().GetAudienceCounts(gomock.Any(), "workspace123").Return(counts, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "Audience Counts Service Error",
+			method:      http.MethodGet,
+			queryParams: "workspace_id=workspace123",
+			setupMock: func(m *mocks.MockContactService) {
+				m.EXPECT().GetAudienceCounts(gomock.Any(), "workspace123").Return(nil, errors.New("service error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "Missing Workspace ID",
+			method:         http.MethodGet,
+			queryParams:    "",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Method Not Allowed",
+			method:         http.MethodPost,
+			queryParams:    "workspace_id=workspace123",
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService, _, handler := setupContactHandlerTest(t)
+
+			if tc.setupMock != nil {
+				tc.setupMock(mockService)
+			}
+
+			req := httptest.NewRequest(tc.method, "/api/contacts.audienceCounts?"+tc.queryParams, nil)
+			rr := httptest.NewRecorder()
+
+			handler.handleAudienceCounts(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+
+			if tc.expectedStatus == http.StatusOK {
+				var response domain.AudienceCounts
+				err := json.NewDecoder(rr.Body).Decode(&response)
+				assert.NoError(t, err)
+				assert.Equal(t, *counts, response)
+			}
+		})
+	}
+}
+
 func TestContactHandler_HandleGet(t *testing.T) {
 	testCases := []struct {
 		name            string
@@ -449,6 +525,97 @@ func TestContactHandler_HandleGetByExternalID(t *testing.T) {
 	}
 }
 
+func TestContactHandler_HandleBulkGet(t *testing.T) {
+	testCases := []struct {
+		name           string
+		method         string
+		body           string
+		setupMock      func(*mocks.MockContactService)
+		expectedStatus int
+	}{
+		{
+			name:   "Bulk Get Success",
+			method: http.MethodPost,
+			body:   `{"workspace_id":"workspace123","emails":["a@example.com","b@example.com"]}`,
+			setupMock: func(m *mocks.MockContactService) {
+				m.EXPECT().
+					BulkGetContacts(gomock.Any(), gomock.Any()).
+					Return(&domain.BulkGetContactsResponse{
+						Contacts: []*domain.Contact{{Email: "a@example.com"}},
+						NotFound: []string{"b@example.com"},
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "Validation Error",
+			method: http.MethodPost,
+			body:   `{"workspace_id":"workspace123"}`,
+			setupMock: func(m *mocks.MockContactService) {
+				m.EXPECT().
+					BulkGetContacts(gomock.Any(), gomock.Any()).
+					Return(nil, domain.ValidationError{Message: "emails or external_ids is required"})
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:   "Permission Denied",
+			method: http.MethodPost,
+			body:   `{"workspace_id":"workspace123","emails":["a@example.com"]}`,
+			setupMock: func(m *mocks.MockContactService) {
+				m.EXPECT().
+					BulkGetContacts(gomock.Any(), gomock.Any()).
+					Return(nil, &domain.PermissionError{Message: "Insufficient permissions"})
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:   "Service Error",
+			method: http.MethodPost,
+			body:   `{"workspace_id":"workspace123","emails":["a@example.com"]}`,
+			setupMock: func(m *mocks.MockContactService) {
+				m.EXPECT().
+					BulkGetContacts(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("service error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:           "Invalid Body",
+			method:         http.MethodPost,
+			body:           `not json`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Method Not Allowed",
+			method:         http.MethodGet,
+			expectedStatus: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService, _, handler := setupContactHandlerTest(t)
+			if tc.setupMock != nil {
+				tc.setupMock(mockService)
+			}
+
+			req := httptest.NewRequest(tc.method, "/api/contacts.bulkGet", bytes.NewBufferString(tc.body))
+			rr := httptest.NewRecorder()
+
+			handler.handleBulkGet(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			if tc.expectedStatus == http.StatusOK {
+				var response domain.BulkGetContactsResponse
+				assert.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+				assert.Len(t, response.Contacts, 1)
+				assert.Equal(t, []string{"b@example.com"}, response.NotFound)
+			}
+		})
+	}
+}
+
 func TestContactHandler_HandleDelete(t *testing.T) {
 	testCases := []struct {
 		name            string
@@ -601,7 +768,7 @@ func TestContactHandler_HandleImport(t *testing.T) {
 			},
 			setupMock: func(m *mocks.MockContactService) {
 				m.EXPECT().
-					BatchImportContacts(gomock.Any(), "workspace123", gomock.Any(), gomock.Any()).
+					BatchImportContacts(gomock.Any(), "workspace123", gomock.Any(), gomock.Any(), domain.ImportConflictOverwrite).
 					Return(&domain.BatchImportContactsResponse{
 						Operations: []*domain.UpsertContactOperation{
 							{
@@ -630,7 +797,7 @@ func TestContactHandler_HandleImport(t *testing.T) {
 			},
 			setupMock: func(m *mocks.MockContactService) {
 				m.EXPECT().
-					BatchImportContacts(gomock.Any(), "workspace123", gomock.Any(), gomock.Any()).
+					BatchImportContacts(gomock.Any(), "workspace123", gomock.Any(), gomock.Any(), domain.ImportConflictOverwrite).
 					Return(&domain.BatchImportContactsResponse{
 						Error: "service error",
 					})
@@ -639,6 +806,35 @@ func TestContactHandler_HandleImport(t *testing.T) {
 			expectedMessage: "service error",
 			expectedCount:   0,
 		},
+		{
+			name:   "validate only",
+			method: http.MethodPost,
+			reqBody: map[string]interface{}{
+				"workspace_id":  "workspace123",
+				"validate_only": true,
+				"contacts": []map[string]interface{}{
+					{
+						"email": "contact1@example.com",
+					},
+				},
+			},
+			setupMock: func(m *mocks.MockContactService) {
+				m.EXPECT().
+					ValidateImportContacts(gomock.Any(), "workspace123", gomock.Any(), gomock.Any()).
+					Return(&domain.BatchImportContactsResponse{
+						ValidateOnly: true,
+						Operations: []*domain.UpsertContactOperation{
+							{
+								Email:  "contact1@example.com",
+								Action: domain.UpsertContactOperationValid,
+							},
+						},
+					})
+			},
+			expectedStatus:  http.StatusOK,
+			expectedMessage: "contact1@example.com",
+			expectedCount:   1,
+		},
 		{
 			name:   "invalid request - empty contacts",
 			method: http.MethodPost,
@@ -707,7 +903,124 @@ func TestContactHandler_HandleImport(t *testing.T) {
 				assert.NotEmpty(t, response.Operations)
 				assert.Equal(t, tc.expectedCount, len(response.Operations))
 				assert.Equal(t, tc.expectedMessage, response.Operations[0].Email)
-				assert.Equal(t, domain.UpsertContactOperationCreate, response.Operations[0].Action)
+				if response.ValidateOnly {
+					assert.Equal(t, domain.UpsertContactOperationValid, response.Operations[0].Action)
+				} else {
+					assert.Equal(t, domain.UpsertContactOperationCreate, response.Operations[0].Action)
+				}
+			}
+		})
+	}
+}
+
+func TestContactHandler_HandleImportLines(t *testing.T) {
+	body := "{\"email\":\"a@example.com\"}\n{\"email\":\n"
+
+	testCases := []struct {
+		name           string
+		query          string
+		contentType    string
+		setupMock      func(*mocks.MockContactService)
+		expectedStatus int
+	}{
+		{
+			name:        "Import Success",
+			query:       "workspace_id=workspace123&merge_strategy=fill_empty_only",
+			contentType: "application/x-ndjson",
+			setupMock: func(m *mocks.MockContactService) {
+				m.EXPECT().
+					ImportContactLines(gomock.Any(), "workspace123", gomock.Any(), domain.MergeStrategyFillEmptyOnly, false).
+					Return(&domain.ImportContactLinesResponse{
+						Lines:   2,
+						Created: 1,
+						Failed:  1,
+						Errors:  []domain.ContactImportLineError{{Line: 2, Error: "invalid JSON"}},
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:        "Dry Run",
+			query:       "workspace_id=workspace123&dryRun=true",
+			contentType: "application/jsonl; charset=utf-8",
+			setupMock: func(m *mocks.MockContactService) {
+				m.EXPECT().
+					ImportContactLines(gomock.Any(), "workspace123", gomock.Any(), domain.MergeStrategy(""), true).
+					Return(&domain.ImportContactLinesResponse{
+						Lines:  2,
+						Valid:  1,
+						Failed: 1,
+						Errors: []domain.ContactImportLineError{{Line: 2, Error: "invalid JSON"}},
+						DryRun: true,
+					}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Missing Workspace ID",
+			contentType:    "application/x-ndjson",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Invalid Dry Run",
+			query:          "workspace_id=workspace123&dryRun=maybe",
+			contentType:    "application/x-ndjson",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "Invalid Merge Strategy",
+			query:       "workspace_id=workspace123&merge_strategy=replace",
+			contentType: "application/x-ndjson",
+			setupMock: func(m *mocks.MockContactService) {
+				m.EXPECT().
+					ImportContactLines(gomock.Any(), "workspace123", gomock.Any(), domain.MergeStrategy("replace"), false).
+					Return(nil, domain.ValidationError{Message: "invalid merge strategy: replace"})
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "Permission Denied",
+			query:       "workspace_id=workspace123",
+			contentType: "application/x-ndjson",
+			setupMock: func(m *mocks.MockContactService) {
+				m.EXPECT().
+					ImportContactLines(gomock.Any(), "workspace123", gomock.Any(), gomock.Any(), false).
+					Return(nil, &domain.PermissionError{Message: "Insufficient permissions"})
+			},
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:        "Service Error",
+			query:       "workspace_id=workspace123",
+			contentType: "application/x-ndjson",
+			setupMock: func(m *mocks.MockContactService) {
+				m.EXPECT().
+					ImportContactLines(gomock.Any(), "workspace123", gomock.Any(), gomock.Any(), false).
+					Return(nil, errors.New("service error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService, _, handler := setupContactHandlerTest(t)
+			if tc.setupMock != nil {
+				tc.setupMock(mockService)
+			}
+
+			req := httptest.NewRequest(http.MethodPost, "/api/contacts.import?"+tc.query, bytes.NewBufferString(body))
+			req.Header.Set("Content-Type", tc.contentType)
+			rr := httptest.NewRecorder()
+
+			handler.handleImport(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+			if tc.expectedStatus == http.StatusOK {
+				var response domain.ImportContactLinesResponse
+				assert.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+				assert.Equal(t, 2, response.Lines)
+				assert.Equal(t, []domain.ContactImportLineError{{Line: 2, Error: "invalid JSON"}}, response.Errors)
 			}
 		})
 	}
@@ -881,3 +1194,112 @@ func TestContactHandler_HandleUpsert(t *testing.T) {
 		})
 	}
 }
+
+func TestContactHandler_HandleExport(t *testing.T) {
+	pages := map[string]*domain.GetContactsResponse{
+		"": {
+			Contacts: []*domain.Contact{
+				{Email: "a@example.com", FirstName: &domain.NullableString{String: "Ann"}},
+				{Email: "b@example.com"},
+			},
+			NextCursor: "page-2",
+		},
+		"page-2": {
+			Contacts: []*domain.Contact{
+				{Email: "c@example.com", CustomNumber1: &domain.NullableFloat64{Float64: 42}},
+			},
+		},
+	}
+
+	setupPages := func(mockService *mocks.MockContactService) {
+		mockService.EXPECT().
+			GetContacts(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ interface{}, req *domain.GetContactsRequest) (*domain.GetContactsResponse, error) {
+				assert.Equal(t, "workspace123", req.WorkspaceID)
+				assert.Equal(t, exportPageSize, req.Limit)
+				return pages[req.Cursor], nil
+			}).
+			Times(2)
+	}
+
+	t.Run("gzip output decompresses to all rows", func(t *testing.T) {
+		mockService, _, handler := setupContactHandlerTest(t)
+		setupPages(mockService)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/contacts.export?workspace_id=workspace123&compress=gzip", nil)
+		rr := httptest.NewRecorder()
+		handler.handleExport(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "application/gzip", rr.Header().Get("Content-Type"))
+		assert.Contains(t, rr.Header().Get("Content-Disposition"), ".csv.gz")
+
+		gz, err := gzip.NewReader(rr.Body)
+		if !assert.NoError(t, err) {
+			return
+		}
+		records, err := csv.NewReader(gz).ReadAll()
+		assert.NoError(t, err)
+		assert.Len(t, records, 4)
+		assert.Equal(t, contactExportHeader, records[0])
+		assert.Equal(t, "a@example.com", records[1][0])
+		assert.Equal(t, "Ann", records[1][2])
+		assert.Equal(t, "b@example.com", records[2][0])
+		assert.Equal(t, "c@example.com", records[3][0])
+		assert.Equal(t, "42", records[3][19])
+	})
+
+	t.Run("part_size splits rows into zip parts with a manifest", func(t *testing.T) {
+		mockService, _, handler := setupContactHandlerTest(t)
+		setupPages(mockService)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/contacts.export?workspace_id=workspace123&part_size=2", nil)
+		rr := httptest.NewRecorder()
+		handler.handleExport(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "application/zip", rr.Header().Get("Content-Type"))
+
+		archive, err := zip.NewReader(bytes.NewReader(rr.Body.Bytes()), int64(rr.Body.Len()))
+		if !assert.NoError(t, err) {
+			return
+		}
+		var names []string
+		for _, f := range archive.File {
+			names = append(names, f.Name)
+		}
+		assert.Equal(t, []string{"contacts-part-0001.csv", "contacts-part-0002.csv", "manifest.json"}, names)
+	})
+
+	t.Run("first page failure returns JSON error", func(t *testing.T) {
+		mockService, _, handler := setupContactHandlerTest(t)
+		mockService.EXPECT().GetContacts(gomock.Any(), gomock.Any()).Return(nil, errors.New("db error"))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/contacts.export?workspace_id=workspace123", nil)
+		rr := httptest.NewRecorder()
+		handler.handleExport(rr, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+		assert.Equal(t, "application/json", rr.Header().Get("Content-Type"))
+	})
+
+	t.Run("invalid export options", func(t *testing.T) {
+		_, _, handler := setupContactHandlerTest(t)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/contacts.export?workspace_id=workspace123&compress=zstd", nil)
+		rr := httptest.NewRecorder()
+		handler.handleExport(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("method not allowed", func(t *testing.T) {
+		_, _, handler := setupContactHandlerTest(t)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/contacts.export?workspace_id=workspace123", nil)
+		rr := httptest.NewRecorder()
+		handler.handleExport(rr, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+	})
+}
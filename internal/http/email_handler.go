@@ -97,33 +97,7 @@ func (h *EmailHandler) handleClickRedirection(w http.ResponseWriter, r *http.Req
 	}
 
 	// Bot detection: check time and user agent before recording
-	shouldRecord := true
-	userAgent := r.Header.Get("User-Agent")
-
-	// Check if bot based on user agent
-	if botdetection.IsBotUserAgent(userAgent) {
-		shouldRecord = false
-		h.logger.WithField("user_agent", userAgent).Debug("Bot detected by user agent - not recording click")
-	}
-
-	// Check if click is too fast (< 7 seconds) using timestamp from URL
-	if shouldRecord {
-		tsParam := r.URL.Query().Get("ts")
-		if tsParam != "" {
-			sentTimestamp, err := strconv.ParseInt(tsParam, 10, 64)
-			if err == nil {
-				sentAt := time.Unix(sentTimestamp, 0)
-				timeSinceSent := time.Since(sentAt)
-				if timeSinceSent < 7*time.Second {
-					shouldRecord = false
-					h.logger.WithFields(map[string]interface{}{
-						"time_since_sent": timeSinceSent.Seconds(),
-						"message_id":      messageID,
-					}).Debug("Click too fast - not recording (likely bot)")
-				}
-			}
-		}
-	}
+	shouldRecord := isHumanClick(r, r.URL.Query().Get("ts"), messageID, h.logger)
 
 	// Record click only if it passes bot detection
 	if shouldRecord {
@@ -184,3 +158,33 @@ func (h *EmailHandler) handleOpens(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 	_, _ = w.Write([]byte{0x89, 0x50, 0x4E, 0x47, 0x0D, 0x0A, 0x1A, 0x0A, 0x00, 0x00, 0x00, 0x0D, 0x49, 0x48, 0x44, 0x52, 0x00, 0x00, 0x00, 0x01, 0x00, 0x00, 0x00, 0x01, 0x08, 0x06, 0x00, 0x00, 0x00, 0x1F, 0x15, 0xC4, 0x89, 0x00, 0x00, 0x00, 0x0B, 0x49, 0x44, 0x41, 0x54, 0x08, 0xD7, 0x63, 0x60, 0x00, 0x00, 0x00, 0x02, 0x00, 0x01, 0xE2, 0x21, 0xBC, 0x33, 0x00, 0x00, 0x00, 0x00, 0x49, 0x45, 0x4E, 0x44, 0xAE, 0x42, 0x60, 0x82})
 }
+
+// isHumanClick applies bot detection on a tracked click, using the user agent and
+// the time elapsed since the message was sent (sentTimestamp, in Unix seconds)
+func isHumanClick(r *http.Request, sentTimestamp string, messageID string, log logger.Logger) bool {
+	userAgent := r.Header.Get("User-Agent")
+
+	// Check if bot based on user agent
+	if botdetection.IsBotUserAgent(userAgent) {
+		log.WithField("user_agent", userAgent).Debug("Bot detected by user agent - not recording click")
+		return false
+	}
+
+	// Check if click is too fast (< 7 seconds) using timestamp from URL
+	if sentTimestamp != "" {
+		sentUnix, err := strconv.ParseInt(sentTimestamp, 10, 64)
+		if err == nil {
+			sentAt := time.Unix(sentUnix, 0)
+			timeSinceSent := time.Since(sentAt)
+			if timeSinceSent < 7*time.Second {
+				log.WithFields(map[string]interface{}{
+					"time_since_sent": timeSinceSent.Seconds(),
+					"message_id":      messageID,
+				}).Debug("Click too fast - not recording (likely bot)")
+				return false
+			}
+		}
+	}
+
+	return true
+}
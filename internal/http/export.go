@@ -0,0 +1,258 @@
+package http
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/pkg/export"
+	"github.com/Notifuse/notifuse/pkg/logger"
+)
+
+// exportPageSize is the number of records fetched per page while streaming an export
+const exportPageSize = 100
+
+// exportFlushInterval is how often a streamed export is flushed to the client
+const exportFlushInterval = time.Second
+
+// exportPageFetcher returns the rows of the page at cursor and the cursor of the next page.
+// An empty next cursor ends the export.
+type exportPageFetcher func(cursor string) (rows [][]string, nextCursor string, err error)
+
+// streamExport streams an export download page by page so memory stays bounded by a single page.
+// The first page is fetched before any byte is written so early failures still produce a
+// proper JSON error; failures after streaming started can only be logged.
+func streamExport(w http.ResponseWriter, r *http.Request, log logger.Logger, name string, header []string, opts export.Options, fetch exportPageFetcher) {
+	rows, cursor, err := fetch("")
+	if err != nil {
+		log.WithField("error", err.Error()).Error(fmt.Sprintf("Failed to fetch %s export", name))
+		WriteJSONError(w, fmt.Sprintf("Failed to export %s", name), http.StatusInternalServerError)
+		return
+	}
+
+	filename := opts.Filename(fmt.Sprintf("%s-%s", name, time.Now().UTC().Format("20060102-150405")))
+	w.Header().Set("Content-Type", opts.ContentType())
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(http.StatusOK)
+
+	writer, err := export.NewWriter(w, name, header, opts)
+	if err != nil {
+		log.WithField("error", err.Error()).Error(fmt.Sprintf("Failed to start %s export", name))
+		return
+	}
+
+	flusher, _ := w.(http.Flusher)
+
+	for {
+		for _, row := range rows {
+			if err := writer.WriteRow(row); err != nil {
+				log.WithField("error", err.Error()).Error(fmt.Sprintf("Failed to write %s export row", name))
+				return
+			}
+		}
+
+		if err := writer.Flush(); err != nil {
+			log.WithField("error", err.Error()).Error(fmt.Sprintf("Failed to flush %s export", name))
+			return
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+
+		if cursor == "" {
+			break
+		}
+
+		if err := r.Context().Err(); err != nil {
+			log.WithField("error", err.Error()).Warn(fmt.Sprintf("Client went away during %s export", name))
+			return
+		}
+
+		rows, cursor, err = fetch(cursor)
+		if err != nil {
+			// Headers are already sent: leave the output truncated (no manifest / gzip trailer)
+			// so the client detects the failure instead of receiving a silently partial file
+			log.WithField("error", err.Error()).Error(fmt.Sprintf("Failed to fetch %s export page", name))
+			return
+		}
+	}
+
+	if err := writer.Close(); err != nil {
+		log.WithField("error", err.Error()).Error(fmt.Sprintf("Failed to finalize %s export", name))
+	}
+}
+
+// streamWriterExport streams an export the source writes as CSV itself, for sources that page
+// on their own and cannot be split into parts. Headers are only sent with the first byte so a
+// failure before any output still produces a proper JSON error.
+func streamWriterExport(w http.ResponseWriter, log logger.Logger, name string, opts export.Options, write func(io.Writer) error) {
+	out := &exportResponseWriter{w: w, name: name, opts: opts}
+	out.flusher, _ = w.(http.Flusher)
+
+	if err := write(out); err != nil {
+		if !out.started {
+			log.WithField("error", err.Error()).Error(fmt.Sprintf("Failed to fetch %s export", name))
+			WriteJSONError(w, fmt.Sprintf("Failed to export %s", name), http.StatusInternalServerError)
+			return
+		}
+		// Leave the output truncated (no gzip trailer) so the client detects the failure
+		log.WithField("error", err.Error()).Error(fmt.Sprintf("Failed to stream %s export", name))
+		return
+	}
+
+	if err := out.Close(); err != nil {
+		log.WithField("error", err.Error()).Error(fmt.Sprintf("Failed to finalize %s export", name))
+	}
+}
+
+// exportResponseWriter sends the download headers on the first write, compresses when
+// requested and flushes to the client every exportFlushInterval
+type exportResponseWriter struct {
+	w         http.ResponseWriter
+	name      string
+	opts      export.Options
+	out       io.Writer
+	gz        *gzip.Writer
+	flusher   http.Flusher
+	started   bool
+	lastFlush time.Time
+}
+
+func (e *exportResponseWriter) start() {
+	filename := e.opts.Filename(fmt.Sprintf("%s-%s", e.name, time.Now().UTC().Format("20060102-150405")))
+	e.w.Header().Set("Content-Type", e.opts.ContentType())
+	e.w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	e.w.Header().Set("Cache-Control", "no-store")
+	e.w.WriteHeader(http.StatusOK)
+
+	e.out = e.w
+	if e.opts.Gzip {
+		e.gz = gzip.NewWriter(e.w)
+		e.out = e.gz
+	}
+	e.started = true
+	e.lastFlush = time.Now()
+}
+
+func (e *exportResponseWriter) Write(p []byte) (int, error) {
+	if !e.started {
+		e.start()
+	}
+
+	n, err := e.out.Write(p)
+	if err != nil {
+		return n, err
+	}
+
+	if time.Since(e.lastFlush) >= exportFlushInterval {
+		if err := e.flush(); err != nil {
+			return n, err
+		}
+	}
+	return n, nil
+}
+
+func (e *exportResponseWriter) flush() error {
+	if e.gz != nil {
+		if err := e.gz.Flush(); err != nil {
+			return err
+		}
+	}
+	if e.flusher != nil {
+		e.flusher.Flush()
+	}
+	e.lastFlush = time.Now()
+	return nil
+}
+
+// Close writes the gzip trailer and flushes the remaining output
+func (e *exportResponseWriter) Close() error {
+	if !e.started {
+		e.start()
+	}
+	if e.gz != nil {
+		if err := e.gz.Close(); err != nil {
+			return err
+		}
+	}
+	if e.flusher != nil {
+		e.flusher.Flush()
+	}
+	return nil
+}
+
+// contactExportHeader lists the columns of a contact export
+var contactExportHeader = []string{
+	"email", "external_id", "first_name", "last_name", "full_name", "phone",
+	"address_line_1", "address_line_2", "country", "postcode", "state", "job_title",
+	"timezone", "language",
+	"custom_string_1", "custom_string_2", "custom_string_3", "custom_string_4", "custom_string_5",
+	"custom_number_1", "custom_number_2", "custom_number_3", "custom_number_4", "custom_number_5",
+	"custom_datetime_1", "custom_datetime_2", "custom_datetime_3", "custom_datetime_4", "custom_datetime_5",
+	"custom_json_1", "custom_json_2", "custom_json_3", "custom_json_4", "custom_json_5",
+	"created_at", "updated_at",
+}
+
+// contactExportRow converts a contact into a row matching contactExportHeader
+func contactExportRow(c *domain.Contact) []string {
+	return []string{
+		c.Email, exportString(c.ExternalID), exportString(c.FirstName), exportString(c.LastName),
+		exportString(c.FullName), exportString(c.Phone),
+		exportString(c.AddressLine1), exportString(c.AddressLine2), exportString(c.Country),
+		exportString(c.Postcode), exportString(c.State), exportString(c.JobTitle),
+		exportString(c.Timezone), exportString(c.Language),
+		exportString(c.CustomString1), exportString(c.CustomString2), exportString(c.CustomString3),
+		exportString(c.CustomString4), exportString(c.CustomString5),
+		exportFloat(c.CustomNumber1), exportFloat(c.CustomNumber2), exportFloat(c.CustomNumber3),
+		exportFloat(c.CustomNumber4), exportFloat(c.CustomNumber5),
+		exportNullableTime(c.CustomDatetime1), exportNullableTime(c.CustomDatetime2), exportNullableTime(c.CustomDatetime3),
+		exportNullableTime(c.CustomDatetime4), exportNullableTime(c.CustomDatetime5),
+		exportJSON(c.CustomJSON1), exportJSON(c.CustomJSON2), exportJSON(c.CustomJSON3),
+		exportJSON(c.CustomJSON4), exportJSON(c.CustomJSON5),
+		exportTime(&c.CreatedAt), exportTime(&c.UpdatedAt),
+	}
+}
+
+func exportString(v *domain.NullableString) string {
+	if v == nil || v.IsNull {
+		return ""
+	}
+	return v.String
+}
+
+func exportFloat(v *domain.NullableFloat64) string {
+	if v == nil || v.IsNull {
+		return ""
+	}
+	return strconv.FormatFloat(v.Float64, 'f', -1, 64)
+}
+
+func exportTime(v *time.Time) string {
+	if v == nil || v.IsZero() {
+		return ""
+	}
+	return v.UTC().Format(time.RFC3339)
+}
+
+func exportNullableTime(v *domain.NullableTime) string {
+	if v == nil || v.IsNull {
+		return ""
+	}
+	return exportTime(&v.Time)
+}
+
+func exportJSON(v *domain.NullableJSON) string {
+	if v == nil || v.IsNull {
+		return ""
+	}
+	data, err := v.MarshalJSON()
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
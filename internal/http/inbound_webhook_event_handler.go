@@ -1,6 +1,8 @@
 package http
 
 import (
+	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 
@@ -36,6 +38,8 @@ func (h *InboundWebhookEventHandler) RegisterRoutes(mux *http.ServeMux) {
 
 	// Authenticated endpoints for accessing inbound webhook event data
 	mux.Handle("/api/inboundWebhookEvents.list", requireAuth(http.HandlerFunc(h.handleList)))
+	mux.Handle("/api/inboundWebhookEvents.reprocess", requireAuth(http.HandlerFunc(h.handleReprocess)))
+	mux.Handle("/api/inboundWebhookEvents.health", requireAuth(http.HandlerFunc(h.handleHealth)))
 }
 
 // handleIncomingWebhook handles incoming webhook events from email providers
@@ -75,8 +79,37 @@ func (h *InboundWebhookEventHandler) handleIncomingWebhook(w http.ResponseWriter
 		return
 	}
 
+	// Reject forged events before they reach the message history
+	if err := h.service.VerifyWebhook(r.Context(), workspaceID, integrationID, r.Header, body); err != nil {
+		if errors.Is(err, domain.ErrInvalidWebhookSignature) {
+			h.logger.WithField("error", err.Error()).
+				WithField("workspace_id", workspaceID).
+				WithField("integration_id", integrationID).
+				WithField("provider", provider).
+				Warn("Rejected webhook with an invalid signature")
+			WriteJSONError(w, "Invalid webhook signature", http.StatusUnauthorized)
+			return
+		}
+		h.logger.WithField("error", err.Error()).
+			WithField("workspace_id", workspaceID).
+			WithField("integration_id", integrationID).
+			WithField("provider", provider).
+			Error("Failed to verify webhook")
+		WriteJSONError(w, "Failed to process webhook", http.StatusBadRequest)
+		return
+	}
+
 	// Process the webhook event
 	err = h.service.ProcessWebhook(r.Context(), workspaceID, integrationID, body)
+	if errors.Is(err, domain.ErrIngestionQueueFull) {
+		h.logger.WithField("workspace_id", workspaceID).
+			WithField("integration_id", integrationID).
+			WithField("provider", provider).
+			Warn("Webhook ingestion queue is full, asking the provider to retry")
+		w.Header().Set("Retry-After", "5")
+		WriteJSONError(w, "Webhook ingestion is saturated, retry later", http.StatusServiceUnavailable)
+		return
+	}
 	if err != nil {
 		h.logger.WithField("error", err.Error()).
 			WithField("workspace_id", workspaceID).
@@ -122,3 +155,64 @@ func (h *InboundWebhookEventHandler) handleList(w http.ResponseWriter, r *http.R
 	// Return the results
 	writeJSON(w, http.StatusOK, result)
 }
+
+// handleReprocess runs stored raw webhook payloads of a time range through the current ingest logic
+func (h *InboundWebhookEventHandler) handleReprocess(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req domain.ReprocessInboundWebhooksRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteJSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	result, err := h.service.ReprocessPayloads(r.Context(), req)
+	if err != nil {
+		if _, ok := err.(*domain.ErrUnauthorized); ok {
+			WriteJSONError(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		h.logger.WithField("error", err.Error()).
+			WithField("workspace_id", req.WorkspaceID).
+			Error("Failed to reprocess inbound webhook payloads")
+		WriteJSONError(w, "Failed to reprocess inbound webhook payloads", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+// handleHealth returns the last send and webhook event times of the integrations of a workspace
+func (h *InboundWebhookEventHandler) handleHealth(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	workspaceID := r.URL.Query().Get("workspace_id")
+	if workspaceID == "" {
+		WriteJSONError(w, "workspace_id is required", http.StatusBadRequest)
+		return
+	}
+
+	healths, err := h.service.GetWebhookHealth(r.Context(), workspaceID)
+	if err != nil {
+		h.logger.WithField("error", err.Error()).
+			WithField("workspace_id", workspaceID).
+			Error("Failed to get provider webhook health")
+		WriteJSONError(w, "Failed to get provider webhook health", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"integrations": healths,
+	})
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -30,6 +31,7 @@ func setupInboundWebhookEventHandlerTest(t *testing.T) (*InboundWebhookEventHand
 	// Set up logger mock expectations
 	mockLogger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(mockLogger).AnyTimes()
 	mockLogger.EXPECT().Info(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Warn(gomock.Any()).AnyTimes()
 	mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
 
 	// Create key pair for testing
@@ -131,6 +133,9 @@ func TestInboundWebhookEventHandler_handleIncomingWebhook_ProcessError(t *testin
 	w := httptest.NewRecorder()
 
 	// Mock service to return an error
+	mockService.EXPECT().
+		VerifyWebhook(gomock.Any(), "ws123", "int123", gomock.Any(), payload).
+		Return(nil)
 	mockService.EXPECT().
 		ProcessWebhook(gomock.Any(), "ws123", "int123", payload).
 		Return(errors.New("processing error"))
@@ -147,6 +152,27 @@ func TestInboundWebhookEventHandler_handleIncomingWebhook_ProcessError(t *testin
 	assert.Equal(t, "Failed to process webhook", response["error"])
 }
 
+func TestInboundWebhookEventHandler_handleIncomingWebhook_QueueFull(t *testing.T) {
+	handler, mockService, _ := setupInboundWebhookEventHandlerTest(t)
+
+	payload := []byte(`{"event": "test"}`)
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/email?provider=ses&workspace_id=ws123&integration_id=int123", bytes.NewReader(payload))
+	w := httptest.NewRecorder()
+
+	mockService.EXPECT().
+		VerifyWebhook(gomock.Any(), "ws123", "int123", gomock.Any(), payload).
+		Return(nil)
+	mockService.EXPECT().
+		ProcessWebhook(gomock.Any(), "ws123", "int123", payload).
+		Return(domain.ErrIngestionQueueFull)
+
+	handler.handleIncomingWebhook(w, req)
+
+	// The provider is asked to retry later
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	assert.Equal(t, "5", w.Header().Get("Retry-After"))
+}
+
 func TestInboundWebhookEventHandler_handleIncomingWebhook_Success(t *testing.T) {
 	handler, mockService, _ := setupInboundWebhookEventHandlerTest(t)
 
@@ -156,6 +182,9 @@ func TestInboundWebhookEventHandler_handleIncomingWebhook_Success(t *testing.T)
 	w := httptest.NewRecorder()
 
 	// Mock service to return success
+	mockService.EXPECT().
+		VerifyWebhook(gomock.Any(), "ws123", "int123", gomock.Any(), payload).
+		Return(nil)
 	mockService.EXPECT().
 		ProcessWebhook(gomock.Any(), "ws123", "int123", payload).
 		Return(nil)
@@ -172,6 +201,44 @@ func TestInboundWebhookEventHandler_handleIncomingWebhook_Success(t *testing.T)
 	assert.Equal(t, true, response["success"])
 }
 
+func TestInboundWebhookEventHandler_handleIncomingWebhook_InvalidSignature(t *testing.T) {
+	handler, mockService, _ := setupInboundWebhookEventHandlerTest(t)
+
+	payload := []byte(`{"signature": {"timestamp": "1", "token": "t", "signature": "tampered"}}`)
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/email?provider=mailgun&workspace_id=ws123&integration_id=int123", bytes.NewReader(payload))
+	w := httptest.NewRecorder()
+
+	// The event never reaches ProcessWebhook
+	mockService.EXPECT().
+		VerifyWebhook(gomock.Any(), "ws123", "int123", gomock.Any(), payload).
+		Return(fmt.Errorf("%w: Mailgun signature mismatch", domain.ErrInvalidWebhookSignature))
+
+	handler.handleIncomingWebhook(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	var response map[string]string
+	err := json.NewDecoder(w.Body).Decode(&response)
+	require.NoError(t, err)
+	assert.Equal(t, "Invalid webhook signature", response["error"])
+}
+
+func TestInboundWebhookEventHandler_handleIncomingWebhook_VerifyError(t *testing.T) {
+	handler, mockService, _ := setupInboundWebhookEventHandlerTest(t)
+
+	payload := []byte(`{"event": "test"}`)
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/email?provider=ses&workspace_id=ws123&integration_id=int123", bytes.NewReader(payload))
+	w := httptest.NewRecorder()
+
+	mockService.EXPECT().
+		VerifyWebhook(gomock.Any(), "ws123", "int123", gomock.Any(), payload).
+		Return(errors.New("failed to get workspace: not found"))
+
+	handler.handleIncomingWebhook(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
 // Tests for handleList
 
 func TestInboundWebhookEventHandler_handleList_MethodNotAllowed(t *testing.T) {
@@ -321,6 +388,98 @@ func TestInboundWebhookEventHandler_RegisterRoutes(t *testing.T) {
 	mux.ServeHTTP(w, listReq)
 }
 
+func TestInboundWebhookEventHandler_handleReprocess(t *testing.T) {
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(6 * time.Hour)
+	validBody := func() *bytes.Buffer {
+		body, _ := json.Marshal(domain.ReprocessInboundWebhooksRequest{WorkspaceID: "ws123", From: from, To: to})
+		return bytes.NewBuffer(body)
+	}
+
+	t.Run("method not allowed", func(t *testing.T) {
+		handler, _, _ := setupInboundWebhookEventHandlerTest(t)
+		w := httptest.NewRecorder()
+		handler.handleReprocess(w, httptest.NewRequest(http.MethodGet, "/api/inboundWebhookEvents.reprocess", nil))
+		assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	})
+
+	t.Run("invalid time range", func(t *testing.T) {
+		handler, _, _ := setupInboundWebhookEventHandlerTest(t)
+		body, _ := json.Marshal(domain.ReprocessInboundWebhooksRequest{WorkspaceID: "ws123", From: to, To: from})
+		w := httptest.NewRecorder()
+		handler.handleReprocess(w, httptest.NewRequest(http.MethodPost, "/api/inboundWebhookEvents.reprocess", bytes.NewBuffer(body)))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("non-owner is forbidden", func(t *testing.T) {
+		handler, mockService, _ := setupInboundWebhookEventHandlerTest(t)
+		mockService.EXPECT().ReprocessPayloads(gomock.Any(), gomock.Any()).
+			Return(nil, &domain.ErrUnauthorized{Message: "only workspace owners can reprocess inbound webhooks"})
+
+		w := httptest.NewRecorder()
+		handler.handleReprocess(w, httptest.NewRequest(http.MethodPost, "/api/inboundWebhookEvents.reprocess", validBody()))
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		handler, mockService, _ := setupInboundWebhookEventHandlerTest(t)
+		mockService.EXPECT().ReprocessPayloads(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, req domain.ReprocessInboundWebhooksRequest) (*domain.ReprocessInboundWebhooksResult, error) {
+				assert.Equal(t, "ws123", req.WorkspaceID)
+				assert.True(t, from.Equal(req.From))
+				assert.True(t, to.Equal(req.To))
+				return &domain.ReprocessInboundWebhooksResult{Payloads: 3, Events: 4, Failed: 1}, nil
+			})
+
+		w := httptest.NewRecorder()
+		handler.handleReprocess(w, httptest.NewRequest(http.MethodPost, "/api/inboundWebhookEvents.reprocess", validBody()))
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var result domain.ReprocessInboundWebhooksResult
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&result))
+		assert.Equal(t, domain.ReprocessInboundWebhooksResult{Payloads: 3, Events: 4, Failed: 1}, result)
+	})
+}
+
+func TestInboundWebhookEventHandler_handleHealth(t *testing.T) {
+	t.Run("missing workspace_id", func(t *testing.T) {
+		handler, _, _ := setupInboundWebhookEventHandlerTest(t)
+		w := httptest.NewRecorder()
+		handler.handleHealth(w, httptest.NewRequest(http.MethodGet, "/api/inboundWebhookEvents.health", nil))
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		handler, mockService, _ := setupInboundWebhookEventHandlerTest(t)
+		mockService.EXPECT().GetWebhookHealth(gomock.Any(), "ws123").Return(nil, errors.New("db error"))
+
+		w := httptest.NewRecorder()
+		handler.handleHealth(w, httptest.NewRequest(http.MethodGet, "/api/inboundWebhookEvents.health?workspace_id=ws123", nil))
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+
+	t.Run("success", func(t *testing.T) {
+		handler, mockService, _ := setupInboundWebhookEventHandlerTest(t)
+		lastEventAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+		mockService.EXPECT().GetWebhookHealth(gomock.Any(), "ws123").Return([]*domain.ProviderWebhookHealth{
+			{IntegrationID: "integration-1", LastEventAt: &lastEventAt, Stale: true},
+		}, nil)
+
+		w := httptest.NewRecorder()
+		handler.handleHealth(w, httptest.NewRequest(http.MethodGet, "/api/inboundWebhookEvents.health?workspace_id=ws123", nil))
+		require.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			Integrations []*domain.ProviderWebhookHealth `json:"integrations"`
+		}
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+		require.Len(t, response.Integrations, 1)
+		assert.Equal(t, "integration-1", response.Integrations[0].IntegrationID)
+		assert.True(t, lastEventAt.Equal(*response.Integrations[0].LastEventAt))
+		assert.True(t, response.Integrations[0].Stale)
+	})
+}
+
 // Custom error reader for testing read errors
 type errorReader struct{}
 
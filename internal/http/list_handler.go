@@ -2,6 +2,7 @@ package http
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 
 	"github.com/Notifuse/notifuse/internal/domain"
@@ -36,6 +37,7 @@ func (h *ListHandler) RegisterRoutes(mux *http.ServeMux) {
 	mux.Handle("/api/lists.update", requireAuth(http.HandlerFunc(h.handleUpdate)))
 	mux.Handle("/api/lists.delete", requireAuth(http.HandlerFunc(h.handleDelete)))
 	mux.Handle("/api/lists.stats", requireAuth(http.HandlerFunc(h.handleStats)))
+	mux.Handle("/api/lists.growth", requireAuth(http.HandlerFunc(h.handleGrowth)))
 	mux.Handle("/api/lists.subscribe", requireAuth(http.HandlerFunc(h.handleSubscribe)))
 }
 
@@ -220,6 +222,37 @@ func (h *ListHandler) handleStats(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+func (h *ListHandler) handleGrowth(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req domain.GetListGrowthRequest
+	if err := req.FromURLParams(r.URL.Query()); err != nil {
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	days, err := h.service.GetListGrowthStats(r.Context(), req.WorkspaceID, req.ListID, req.Timezone, req.From, req.To)
+	if err != nil {
+		var notFound *domain.ErrListNotFound
+		if errors.As(err, &notFound) {
+			WriteJSONError(w, "List not found", http.StatusNotFound)
+			return
+		}
+		h.logger.WithField("error", err.Error()).Error("Failed to get list growth")
+		WriteJSONError(w, "Failed to get list growth", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"list_id":  req.ListID,
+		"timezone": req.Timezone,
+		"days":     days,
+	})
+}
+
 func (h *ListHandler) handleSubscribe(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
 		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
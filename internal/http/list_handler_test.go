@@ -4,10 +4,12 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/Notifuse/notifuse/internal/domain"
 
@@ -54,6 +56,7 @@ func TestListHandler_RegisterRoutes(t *testing.T) {
 		"/api/lists.create",
 		"/api/lists.update",
 		"/api/lists.delete",
+		"/api/lists.growth",
 	}
 
 	for _, endpoint := range endpoints {
@@ -812,6 +815,110 @@ func TestListHandler_HandleStats(t *testing.T) {
 	}
 }
 
+func TestListHandler_HandleGrowth(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	assert.NoError(t, err)
+
+	t.Run("subscribe and unsubscribe on the same day net to zero", func(t *testing.T) {
+		mockService, _, handler := setupListHandlerTest(t)
+		from := time.Date(2026, 3, 1, 0, 0, 0, 0, paris)
+		to := time.Date(2026, 3, 2, 0, 0, 0, 0, paris)
+		mockService.EXPECT().GetListGrowthStats(gomock.Any(), "workspace123", "list1", "Europe/Paris", from, to).Return([]*domain.ListGrowthDay{
+			{Date: "2026-03-01", Subscribes: 1, Unsubscribes: 1, Net: 0},
+			{Date: "2026-03-02", Subscribes: 3, Unsubscribes: 0, Net: 3},
+		}, nil)
+
+		query := url.Values{
+			"workspace_id": []string{"workspace123"},
+			"list_id":      []string{"list1"},
+			"timezone":     []string{"Europe/Paris"},
+			"from":         []string{"2026-03-01"},
+			"to":           []string{"2026-03-02"},
+		}
+		req := httptest.NewRequest(http.MethodGet, "/api/lists.growth?"+query.Encode(), nil)
+		rr := httptest.NewRecorder()
+		handler.handleGrowth(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var response struct {
+			ListID   string                  `json:"list_id"`
+			Timezone string                  `json:"timezone"`
+			Days     []*domain.ListGrowthDay `json:"days"`
+		}
+		assert.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+		assert.Equal(t, "list1", response.ListID)
+		assert.Equal(t, "Europe/Paris", response.Timezone)
+		assert.Equal(t, &domain.ListGrowthDay{Date: "2026-03-01", Subscribes: 1, Unsubscribes: 1, Net: 0}, response.Days[0])
+		assert.Len(t, response.Days, 2)
+	})
+
+	t.Run("timezone defaults to UTC", func(t *testing.T) {
+		mockService, _, handler := setupListHandlerTest(t)
+		mockService.EXPECT().GetListGrowthStats(gomock.Any(), "workspace123", "list1", "UTC",
+			time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)).
+			Return([]*domain.ListGrowthDay{{Date: "2026-03-01"}}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/lists.growth?workspace_id=workspace123&list_id=list1&from=2026-03-01&to=2026-03-01", nil)
+		rr := httptest.NewRecorder()
+		handler.handleGrowth(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+
+	t.Run("list not found", func(t *testing.T) {
+		mockService, _, handler := setupListHandlerTest(t)
+		mockService.EXPECT().GetListGrowthStats(gomock.Any(), "workspace123", "list1", "UTC", gomock.Any(), gomock.Any()).
+			Return(nil, fmt.Errorf("failed to get list: %w", &domain.ErrListNotFound{Message: "list not found"}))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/lists.growth?workspace_id=workspace123&list_id=list1&from=2026-03-01&to=2026-03-07", nil)
+		rr := httptest.NewRecorder()
+		handler.handleGrowth(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		mockService, _, handler := setupListHandlerTest(t)
+		mockService.EXPECT().GetListGrowthStats(gomock.Any(), "workspace123", "list1", "UTC", gomock.Any(), gomock.Any()).
+			Return(nil, errors.New("service error"))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/lists.growth?workspace_id=workspace123&list_id=list1&from=2026-03-01&to=2026-03-07", nil)
+		rr := httptest.NewRecorder()
+		handler.handleGrowth(rr, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+
+	invalidQueries := map[string]string{
+		"missing workspace":   "list_id=list1&from=2026-03-01&to=2026-03-07",
+		"missing list":        "workspace_id=workspace123&from=2026-03-01&to=2026-03-07",
+		"invalid timezone":    "workspace_id=workspace123&list_id=list1&timezone=Mars/Olympus&from=2026-03-01&to=2026-03-07",
+		"invalid from":        "workspace_id=workspace123&list_id=list1&from=03/01/2026&to=2026-03-07",
+		"missing to":          "workspace_id=workspace123&list_id=list1&from=2026-03-01",
+		"to before from":      "workspace_id=workspace123&list_id=list1&from=2026-03-07&to=2026-03-01",
+		"range over 366 days": "workspace_id=workspace123&list_id=list1&from=2025-01-01&to=2026-03-01",
+	}
+	for name, query := range invalidQueries {
+		t.Run(name, func(t *testing.T) {
+			_, _, handler := setupListHandlerTest(t)
+			req := httptest.NewRequest(http.MethodGet, "/api/lists.growth?"+query, nil)
+			rr := httptest.NewRecorder()
+			handler.handleGrowth(rr, req)
+
+			assert.Equal(t, http.StatusBadRequest, rr.Code)
+		})
+	}
+
+	t.Run("method not allowed", func(t *testing.T) {
+		_, _, handler := setupListHandlerTest(t)
+		req := httptest.NewRequest(http.MethodPost, "/api/lists.growth", nil)
+		rr := httptest.NewRecorder()
+		handler.handleGrowth(rr, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+	})
+}
+
 func TestListHandler_HandleSubscribe(t *testing.T) {
 	mockService, _, handler := setupListHandlerTest(t)
 
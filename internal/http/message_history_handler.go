@@ -1,10 +1,12 @@
 package http
 
 import (
+	"io"
 	"net/http"
 
 	"github.com/Notifuse/notifuse/internal/domain"
 	"github.com/Notifuse/notifuse/internal/http/middleware"
+	"github.com/Notifuse/notifuse/pkg/export"
 	"github.com/Notifuse/notifuse/pkg/logger"
 	"github.com/Notifuse/notifuse/pkg/tracing"
 )
@@ -59,7 +61,9 @@ func (h *MessageHistoryHandler) RegisterRoutes(mux *http.ServeMux) {
 
 	// Register RPC-style endpoints with dot notation
 	mux.Handle("/api/messages.list", requireAuth(http.HandlerFunc(h.handleList)))
+	mux.Handle("/api/messages.export", requireAuth(http.HandlerFunc(h.handleExport)))
 	mux.Handle("/api/messages.broadcastStats", requireAuth(http.HandlerFunc(h.handleBroadcastStats)))
+	mux.Handle("/api/messages.broadcastStatsByDay", requireAuth(http.HandlerFunc(h.handleBroadcastStatsByDay)))
 }
 
 // handleList handles requests to list message history with pagination and filtering
@@ -124,6 +128,73 @@ func (h *MessageHistoryHandler) handleList(w http.ResponseWriter, r *http.Reques
 	writeJSON(w, http.StatusOK, result)
 }
 
+// handleExport streams messages matching the list filters as CSV, optionally gzip-compressed
+// (compress=gzip) and split into N-row parts bundled with a manifest (part_size=N)
+func (h *MessageHistoryHandler) handleExport(w http.ResponseWriter, r *http.Request) {
+	// codecov:ignore:start
+	ctx, span := h.tracer.StartSpan(r.Context(), "MessageHistoryHandler.handleExport")
+	defer func() {
+		if span != nil {
+			h.tracer.EndSpan(span, nil)
+		}
+	}()
+	// codecov:ignore:end
+
+	if r.Method != http.MethodGet {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	query := r.URL.Query()
+	workspaceID := query.Get("workspace_id")
+	if workspaceID == "" {
+		WriteJSONError(w, "Missing workspace ID", http.StatusBadRequest)
+		return
+	}
+
+	var err error
+	ctx, _, _, err = h.authService.AuthenticateUserForWorkspace(ctx, workspaceID)
+	if err != nil {
+		h.logger.Error(err.Error())
+		WriteJSONError(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	var params domain.MessageListParams
+	if err := params.FromQuery(query); err != nil {
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	opts, err := export.OptionsFromQuery(query)
+	if err != nil {
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Single file exports are streamed straight from the database with a keyset cursor
+	if opts.PartSize == 0 {
+		streamWriterExport(w, h.logger, "messages", opts, func(out io.Writer) error {
+			return h.service.ExportMessages(ctx, workspaceID, params, out)
+		})
+		return
+	}
+
+	params.Limit = exportPageSize
+	streamExport(w, r, h.logger, "messages", domain.MessageExportColumns, opts, func(cursor string) ([][]string, string, error) {
+		params.Cursor = cursor
+		result, err := h.service.ListMessages(ctx, workspaceID, params)
+		if err != nil {
+			return nil, "", err
+		}
+		rows := make([][]string, 0, len(result.Messages))
+		for _, message := range result.Messages {
+			rows = append(rows, message.ExportRow())
+		}
+		return rows, result.NextCursor, nil
+	})
+}
+
 func (h *MessageHistoryHandler) handleBroadcastStats(w http.ResponseWriter, r *http.Request) {
 	// codecov:ignore:start
 	ctx, span := h.tracer.StartSpan(r.Context(), "MessageHistoryHandler.handleBroadcastStats")
@@ -163,3 +234,50 @@ func (h *MessageHistoryHandler) handleBroadcastStats(w http.ResponseWriter, r *h
 		"stats":        stats,
 	})
 }
+
+func (h *MessageHistoryHandler) handleBroadcastStatsByDay(w http.ResponseWriter, r *http.Request) {
+	// codecov:ignore:start
+	ctx, span := h.tracer.StartSpan(r.Context(), "MessageHistoryHandler.handleBroadcastStatsByDay")
+	defer func() {
+		if span != nil {
+			h.tracer.EndSpan(span, nil)
+		}
+	}()
+	// codecov:ignore:end
+
+	if r.Method != http.MethodGet {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	broadcastID := r.URL.Query().Get("broadcast_id")
+	if broadcastID == "" {
+		WriteJSONError(w, "broadcast_id is required", http.StatusBadRequest)
+		return
+	}
+
+	workspaceID := r.URL.Query().Get("workspace_id")
+	if workspaceID == "" {
+		WriteJSONError(w, "workspace_id is required", http.StatusBadRequest)
+		return
+	}
+
+	// Defaults to the workspace timezone
+	timezone := r.URL.Query().Get("timezone")
+	if timezone != "" && !domain.IsValidTimezone(timezone) {
+		WriteJSONError(w, "invalid timezone", http.StatusBadRequest)
+		return
+	}
+
+	days, err := h.service.GetBroadcastStatsByDay(ctx, workspaceID, broadcastID, timezone)
+	if err != nil {
+		h.logger.WithField("error", err.Error()).Error("Failed to get stats by day")
+		WriteJSONError(w, "Failed to get stats by day", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"broadcast_id": broadcastID,
+		"days":         days,
+	})
+}
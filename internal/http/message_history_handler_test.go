@@ -1,10 +1,15 @@
 package http
 
 import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
 	"context"
+	"encoding/csv"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -800,3 +805,194 @@ func TestMessageHistoryHandler_handleBroadcastStats_Success(t *testing.T) {
 	assert.Equal(t, float64(30), statsMap["total_clicked"])
 	assert.Equal(t, float64(2), statsMap["total_unsubscribed"])
 }
+
+func TestMessageHistoryHandler_handleBroadcastStatsByDay(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		handler, mockService, _, mockTracer, _ := setupMessageHistoryHandlerTest(t)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/messages.broadcastStatsByDay?workspace_id=ws123&broadcast_id=bc123&timezone=Europe/Paris", nil)
+		w := httptest.NewRecorder()
+
+		mockSpan := &trace.Span{}
+		mockTracer.EXPECT().
+			StartSpan(gomock.Any(), "MessageHistoryHandler.handleBroadcastStatsByDay").
+			Return(context.Background(), mockSpan)
+		mockTracer.EXPECT().EndSpan(mockSpan, nil)
+
+		mockService.EXPECT().
+			GetBroadcastStatsByDay(gomock.Any(), "ws123", "bc123", "Europe/Paris").
+			Return([]*domain.MessageHistoryDailyStatusSum{
+				{Date: "2026-03-01", MessageHistoryStatusSum: domain.MessageHistoryStatusSum{TotalSent: 100, TotalOpened: 40}},
+				{Date: "2026-03-02", MessageHistoryStatusSum: domain.MessageHistoryStatusSum{TotalOpened: 15, TotalClicked: 4}},
+			}, nil)
+
+		handler.handleBroadcastStatsByDay(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response struct {
+			BroadcastID string                   `json:"broadcast_id"`
+			Days        []map[string]interface{} `json:"days"`
+		}
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+		assert.Equal(t, "bc123", response.BroadcastID)
+		require.Len(t, response.Days, 2)
+		// Status counts are inlined next to the date
+		assert.Equal(t, "2026-03-01", response.Days[0]["date"])
+		assert.Equal(t, float64(100), response.Days[0]["total_sent"])
+		assert.Equal(t, float64(40), response.Days[0]["total_opened"])
+		assert.Equal(t, "2026-03-02", response.Days[1]["date"])
+		assert.Equal(t, float64(4), response.Days[1]["total_clicked"])
+	})
+
+	t.Run("invalid timezone", func(t *testing.T) {
+		handler, _, _, mockTracer, _ := setupMessageHistoryHandlerTest(t)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/messages.broadcastStatsByDay?workspace_id=ws123&broadcast_id=bc123&timezone=Mars/Olympus", nil)
+		w := httptest.NewRecorder()
+
+		mockSpan := &trace.Span{}
+		mockTracer.EXPECT().
+			StartSpan(gomock.Any(), "MessageHistoryHandler.handleBroadcastStatsByDay").
+			Return(context.Background(), mockSpan)
+		mockTracer.EXPECT().EndSpan(mockSpan, nil)
+
+		handler.handleBroadcastStatsByDay(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		var response map[string]string
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+		assert.Equal(t, "invalid timezone", response["error"])
+	})
+
+	t.Run("missing broadcast_id", func(t *testing.T) {
+		handler, _, _, mockTracer, _ := setupMessageHistoryHandlerTest(t)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/messages.broadcastStatsByDay?workspace_id=ws123", nil)
+		w := httptest.NewRecorder()
+
+		mockSpan := &trace.Span{}
+		mockTracer.EXPECT().
+			StartSpan(gomock.Any(), "MessageHistoryHandler.handleBroadcastStatsByDay").
+			Return(context.Background(), mockSpan)
+		mockTracer.EXPECT().EndSpan(mockSpan, nil)
+
+		handler.handleBroadcastStatsByDay(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestMessageHistoryHandler_handleExport(t *testing.T) {
+	sentAt := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	broadcastID := "broadcast1"
+
+	setup := func(t *testing.T) (*MessageHistoryHandler, *mocks.MockMessageHistoryService) {
+		handler, mockService, mockAuthService, mockTracer, _ := setupMessageHistoryHandlerTest(t)
+
+		mockSpan := &trace.Span{}
+		mockTracer.EXPECT().
+			StartSpan(gomock.Any(), "MessageHistoryHandler.handleExport").
+			Return(context.Background(), mockSpan)
+		mockTracer.EXPECT().EndSpan(mockSpan, nil)
+
+		mockAuthService.EXPECT().
+			AuthenticateUserForWorkspace(gomock.Any(), "ws123").
+			Return(context.Background(), &domain.User{ID: "user123"}, nil, nil)
+
+		return handler, mockService
+	}
+
+	t.Run("gzip output is streamed from the service export", func(t *testing.T) {
+		handler, mockService := setup(t)
+
+		mockService.EXPECT().
+			ExportMessages(gomock.Any(), "ws123", gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, params domain.MessageListParams, w io.Writer) error {
+				assert.Equal(t, "email", params.Channel)
+				csvWriter := csv.NewWriter(w)
+				_ = csvWriter.Write(domain.MessageExportColumns)
+				message := &domain.MessageHistory{ID: "msg1", ContactEmail: "a@example.com", BroadcastID: &broadcastID, Channel: "email", SentAt: sentAt}
+				_ = csvWriter.Write(message.ExportRow())
+				csvWriter.Flush()
+				return csvWriter.Error()
+			})
+
+		req := httptest.NewRequest(http.MethodGet, "/api/messages.export?workspace_id=ws123&channel=email&compress=gzip", nil)
+		w := httptest.NewRecorder()
+		handler.handleExport(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/gzip", w.Header().Get("Content-Type"))
+		assert.Contains(t, w.Header().Get("Content-Disposition"), "attachment; filename=\"messages-")
+		assert.Contains(t, w.Header().Get("Content-Disposition"), ".csv.gz")
+
+		gz, err := gzip.NewReader(w.Body)
+		require.NoError(t, err)
+		records, err := csv.NewReader(gz).ReadAll()
+		require.NoError(t, err)
+
+		require.Len(t, records, 2)
+		assert.Equal(t, domain.MessageExportColumns, records[0])
+		assert.Equal(t, []string{"msg1", "", "a@example.com", "broadcast1"}, records[1][:4])
+		assert.Equal(t, "2024-05-01T10:00:00Z", records[1][10])
+	})
+
+	t.Run("failure before any output returns JSON error", func(t *testing.T) {
+		handler, mockService := setup(t)
+
+		mockService.EXPECT().
+			ExportMessages(gomock.Any(), "ws123", gomock.Any(), gomock.Any()).
+			Return(errors.New("db error"))
+
+		req := httptest.NewRequest(http.MethodGet, "/api/messages.export?workspace_id=ws123", nil)
+		w := httptest.NewRecorder()
+		handler.handleExport(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+		assert.Empty(t, w.Header().Get("Content-Disposition"))
+	})
+
+	t.Run("part_size pages through the message list", func(t *testing.T) {
+		handler, mockService := setup(t)
+
+		gomock.InOrder(
+			mockService.EXPECT().
+				ListMessages(gomock.Any(), "ws123", gomock.Any()).
+				DoAndReturn(func(_ context.Context, _ string, params domain.MessageListParams) (*domain.MessageListResult, error) {
+					assert.Equal(t, "", params.Cursor)
+					assert.Equal(t, exportPageSize, params.Limit)
+					assert.Equal(t, "email", params.Channel)
+					return &domain.MessageListResult{
+						Messages:   []*domain.MessageHistory{{ID: "msg1", ContactEmail: "a@example.com", BroadcastID: &broadcastID, Channel: "email", SentAt: sentAt}},
+						NextCursor: "next",
+						HasMore:    true,
+					}, nil
+				}),
+			mockService.EXPECT().
+				ListMessages(gomock.Any(), "ws123", gomock.Any()).
+				DoAndReturn(func(_ context.Context, _ string, params domain.MessageListParams) (*domain.MessageListResult, error) {
+					assert.Equal(t, "next", params.Cursor)
+					return &domain.MessageListResult{
+						Messages: []*domain.MessageHistory{{ID: "msg2", ContactEmail: "b@example.com", Channel: "email", SentAt: sentAt}},
+					}, nil
+				}),
+		)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/messages.export?workspace_id=ws123&channel=email&part_size=1", nil)
+		w := httptest.NewRecorder()
+		handler.handleExport(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "application/zip", w.Header().Get("Content-Type"))
+
+		archive, err := zip.NewReader(bytes.NewReader(w.Body.Bytes()), int64(w.Body.Len()))
+		require.NoError(t, err)
+		var names []string
+		for _, f := range archive.File {
+			names = append(names, f.Name)
+		}
+		assert.Equal(t, []string{"messages-part-0001.csv", "messages-part-0002.csv", "manifest.json"}, names)
+	})
+}
@@ -3,6 +3,7 @@ package middleware
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
@@ -21,21 +22,98 @@ func writeJSONError(w http.ResponseWriter, message string, statusCode int) {
 	})
 }
 
+// TokenRevocationChecker reports whether a token ID was revoked before the token expired
+type TokenRevocationChecker func(ctx context.Context, tokenID string) (bool, error)
+
+// defaultTokenRevocationChecker is the revocation check of the auth middlewares created by NewAuthMiddleware
+var defaultTokenRevocationChecker TokenRevocationChecker
+
+// SetTokenRevocationChecker sets the revocation check of the auth middlewares created afterwards.
+// Handlers create their own middleware when registering their routes, so it is set once at startup.
+func SetTokenRevocationChecker(checker TokenRevocationChecker) {
+	defaultTokenRevocationChecker = checker
+}
+
+// APIKeyAuthenticator returns the active scoped API key matching a key sent in the Authorization header
+type APIKeyAuthenticator func(ctx context.Context, key string) (*domain.APIKey, error)
+
+// defaultAPIKeyAuthenticator is the API key authentication of the auth middlewares created by NewAuthMiddleware
+var defaultAPIKeyAuthenticator APIKeyAuthenticator
+
+// SetAPIKeyAuthenticator sets the API key authentication of the auth middlewares created afterwards,
+// like SetTokenRevocationChecker it is set once at startup
+func SetAPIKeyAuthenticator(authenticator APIKeyAuthenticator) {
+	defaultAPIKeyAuthenticator = authenticator
+}
+
+type contextKey string
+
+// apiKeyWorkspaceIDKey holds the workspace of the scoped API key that authenticated a request
+const apiKeyWorkspaceIDKey contextKey = "api_key_workspace_id"
+
 // AuthConfig holds the configuration for the auth middleware
 type AuthConfig struct {
 	GetJWTSecret func() ([]byte, error)
+	// IsTokenRevoked, when set, denies the tokens with an ID on the revocation list
+	IsTokenRevoked TokenRevocationChecker
+	// AuthenticateAPIKey, when set, accepts scoped API keys in place of JWT tokens
+	AuthenticateAPIKey APIKeyAuthenticator
+	// RateLimiter, when set, limits the authenticated requests of each workspace
+	RateLimiter *WorkspaceRateLimiter
 }
 
 // NewAuthMiddleware creates a new auth middleware with the given JWT secret provider
 func NewAuthMiddleware(getJWTSecret func() ([]byte, error)) *AuthConfig {
 	return &AuthConfig{
-		GetJWTSecret: getJWTSecret,
+		GetJWTSecret:       getJWTSecret,
+		IsTokenRevoked:     defaultTokenRevocationChecker,
+		AuthenticateAPIKey: defaultAPIKeyAuthenticator,
+		RateLimiter:        defaultWorkspaceRateLimiter,
 	}
 }
 
+// serveAPIKey authenticates a scoped API key and checks its scopes allow the route
+func (ac *AuthConfig) serveAPIKey(w http.ResponseWriter, r *http.Request, next http.Handler, key string) {
+	if ac.AuthenticateAPIKey == nil {
+		writeJSONError(w, "Invalid API key", http.StatusUnauthorized)
+		return
+	}
+
+	apiKey, err := ac.AuthenticateAPIKey(r.Context(), key)
+	if errors.Is(err, service.ErrInvalidAPIKey) {
+		writeJSONError(w, "Invalid API key", http.StatusUnauthorized)
+		return
+	}
+	if err != nil {
+		writeJSONError(w, "Authentication unavailable", http.StatusServiceUnavailable)
+		return
+	}
+
+	scope, ok := domain.APIKeyScopeForRoute(r.Method, r.URL.Path)
+	if !ok {
+		writeJSONError(w, "This route can't be called with an API key", http.StatusForbidden)
+		return
+	}
+	if !apiKey.Scopes.Allows(scope) {
+		writeJSONError(w, fmt.Sprintf("API key is missing the %s scope", scope), http.StatusForbidden)
+		return
+	}
+
+	ctx := context.WithValue(r.Context(), domain.UserIDKey, apiKey.UserID)
+	ctx = context.WithValue(ctx, domain.UserTypeKey, string(domain.UserTypeAPIKey))
+	ctx = context.WithValue(ctx, apiKeyWorkspaceIDKey, apiKey.WorkspaceID)
+
+	next.ServeHTTP(w, r.WithContext(ctx))
+}
+
 // RequireAuth creates a middleware that verifies the JWT token and user session
 func (ac *AuthConfig) RequireAuth() func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
+		// Requests are rate limited once authenticated, so that unauthenticated ones can't drain a workspace bucket
+		if ac.RateLimiter != nil {
+			next = ac.RateLimiter.Middleware(next)
+		}
+
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			authHeader := r.Header.Get("Authorization")
 			if authHeader == "" {
@@ -51,6 +129,12 @@ func (ac *AuthConfig) RequireAuth() func(http.Handler) http.Handler {
 
 			tokenString := parts[1]
 
+			// Scoped API keys are opaque, they are looked up instead of parsed
+			if strings.HasPrefix(tokenString, domain.APIKeyPrefix) {
+				ac.serveAPIKey(w, r, next, tokenString)
+				return
+			}
+
 			// Get JWT secret
 			secret, err := ac.GetJWTSecret()
 			if err != nil {
@@ -92,11 +176,30 @@ func (ac *AuthConfig) RequireAuth() func(http.Handler) http.Handler {
 				return
 			}
 
+			// Deny the tokens revoked before their expiration (logout, compromised sessions)
+			if claims.ID != "" && ac.IsTokenRevoked != nil {
+				revoked, err := ac.IsTokenRevoked(r.Context(), claims.ID)
+				if err != nil {
+					writeJSONError(w, "Authentication unavailable", http.StatusServiceUnavailable)
+					return
+				}
+				if revoked {
+					writeJSONError(w, "Token has been revoked", http.StatusUnauthorized)
+					return
+				}
+			}
+
 			// Set context values
 			ctx := context.WithValue(r.Context(), domain.UserIDKey, claims.UserID)
 			ctx = context.WithValue(ctx, domain.UserTypeKey, claims.Type)
 			if claims.Type == string(domain.UserTypeUser) {
 				ctx = context.WithValue(ctx, domain.SessionIDKey, claims.SessionID)
+				if claims.ID != "" {
+					ctx = context.WithValue(ctx, domain.TokenIDKey, claims.ID)
+				}
+				if claims.ExpiresAt != nil {
+					ctx = context.WithValue(ctx, domain.TokenExpiresAtKey, claims.ExpiresAt.Time)
+				}
 			}
 
 			next.ServeHTTP(w, r.WithContext(ctx))
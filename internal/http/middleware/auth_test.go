@@ -1,12 +1,15 @@
 package middleware
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
 	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/service"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/stretchr/testify/assert"
 )
@@ -342,6 +345,144 @@ func TestRequireAuth(t *testing.T) {
 	})
 }
 
+func TestRequireAuth_RevokedToken(t *testing.T) {
+	getJWTSecret := func() ([]byte, error) {
+		return testJWTSecret, nil
+	}
+
+	revokedIDs := map[string]bool{"revoked-token-id": true}
+	SetTokenRevocationChecker(func(_ context.Context, tokenID string) (bool, error) {
+		if tokenID == "failing-token-id" {
+			return false, errors.New("database error")
+		}
+		return revokedIDs[tokenID], nil
+	})
+	defer SetTokenRevocationChecker(nil)
+
+	authConfig := NewAuthMiddleware(getJWTSecret)
+	expiresAt := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	createToken := func(tokenID string) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+			"jti":        tokenID,
+			"user_id":    "test-user",
+			"type":       string(domain.UserTypeUser),
+			"session_id": "test-session",
+			"exp":        expiresAt.Unix(),
+			"iat":        time.Now().Unix(),
+			"nbf":        time.Now().Unix(),
+		})
+		signedToken, _ := token.SignedString(testJWTSecret)
+		return signedToken
+	}
+
+	serve := func(tokenID string) (*httptest.ResponseRecorder, context.Context) {
+		var ctx context.Context
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx = r.Context()
+			w.WriteHeader(http.StatusOK)
+		})
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set("Authorization", "Bearer "+createToken(tokenID))
+		w := httptest.NewRecorder()
+		authConfig.RequireAuth()(next).ServeHTTP(w, req)
+		return w, ctx
+	}
+
+	t.Run("revoked token is denied", func(t *testing.T) {
+		w, _ := serve("revoked-token-id")
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Contains(t, w.Body.String(), "Token has been revoked")
+	})
+
+	t.Run("valid token carries its ID and expiration", func(t *testing.T) {
+		w, ctx := serve("valid-token-id")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "valid-token-id", ctx.Value(domain.TokenIDKey))
+		assert.Equal(t, expiresAt.Unix(), ctx.Value(domain.TokenExpiresAtKey).(time.Time).Unix())
+	})
+
+	t.Run("revocation check failure", func(t *testing.T) {
+		w, _ := serve("failing-token-id")
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	})
+}
+
+func TestRequireAuth_ScopedAPIKey(t *testing.T) {
+	getJWTSecret := func() ([]byte, error) {
+		return testJWTSecret, nil
+	}
+
+	keys := map[string]*domain.APIKey{
+		"nfk_send":     {ID: "key1", UserID: "api-user-1", Scopes: domain.APIKeyScopes{domain.APIKeyScopeTransactionalSend}},
+		"nfk_contacts": {ID: "key2", UserID: "api-user-2", Scopes: domain.APIKeyScopes{"contacts:write"}},
+	}
+	SetAPIKeyAuthenticator(func(_ context.Context, key string) (*domain.APIKey, error) {
+		if key == "nfk_failing" {
+			return nil, errors.New("database error")
+		}
+		if apiKey, ok := keys[key]; ok {
+			return apiKey, nil
+		}
+		return nil, service.ErrInvalidAPIKey
+	})
+	defer SetAPIKeyAuthenticator(nil)
+
+	authConfig := NewAuthMiddleware(getJWTSecret)
+
+	serve := func(method, path, key string) (*httptest.ResponseRecorder, context.Context) {
+		var ctx context.Context
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx = r.Context()
+			w.WriteHeader(http.StatusOK)
+		})
+		req := httptest.NewRequest(method, path, nil)
+		req.Header.Set("Authorization", "Bearer "+key)
+		w := httptest.NewRecorder()
+		authConfig.RequireAuth()(next).ServeHTTP(w, req)
+		return w, ctx
+	}
+
+	t.Run("transactional:send key can send", func(t *testing.T) {
+		w, ctx := serve(http.MethodPost, "/api/transactional.send", "nfk_send")
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "api-user-1", ctx.Value(domain.UserIDKey))
+		assert.Equal(t, string(domain.UserTypeAPIKey), ctx.Value(domain.UserTypeKey))
+	})
+
+	t.Run("transactional:send key is rejected on a contacts write route", func(t *testing.T) {
+		w, _ := serve(http.MethodPost, "/api/contacts.upsert", "nfk_send")
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		assert.Contains(t, w.Body.String(), "API key is missing the contacts:write scope")
+	})
+
+	t.Run("transactional:send key can't manage notifications", func(t *testing.T) {
+		w, _ := serve(http.MethodPost, "/api/transactional.create", "nfk_send")
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("write scope grants the read routes", func(t *testing.T) {
+		w, _ := serve(http.MethodGet, "/api/contacts.list", "nfk_contacts")
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("routes outside the scopes are rejected", func(t *testing.T) {
+		w, _ := serve(http.MethodPost, "/api/apiKeys.create", "nfk_contacts")
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		w, _ := serve(http.MethodPost, "/api/transactional.send", "nfk_unknown")
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Contains(t, w.Body.String(), "Invalid API key")
+	})
+
+	t.Run("authentication failure", func(t *testing.T) {
+		w, _ := serve(http.MethodPost, "/api/transactional.send", "nfk_failing")
+		assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	})
+}
+
 func TestRestrictedInDemo(t *testing.T) {
 	t.Run("allows request when not in demo mode", func(t *testing.T) {
 		// Create config with demo mode disabled
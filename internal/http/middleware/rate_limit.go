@@ -0,0 +1,291 @@
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/pkg/logger"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// rateLimitSettingsTTL is how long the rate limit overrides of a workspace are used before being resolved again
+	rateLimitSettingsTTL = time.Minute
+	// rateLimitMaxPeekedBody is the largest JSON body read to find the workspace ID of a request
+	rateLimitMaxPeekedBody = 1 << 20
+)
+
+// WorkspaceRateLimitResolver returns the rate limit overrides of a workspace, nil when it has none
+type WorkspaceRateLimitResolver func(ctx context.Context, workspaceID string) (*domain.WorkspaceRateLimitSettings, error)
+
+// WorkspaceMembershipChecker reports whether a user is a member of a workspace
+type WorkspaceMembershipChecker func(ctx context.Context, userID, workspaceID string) bool
+
+// workspaceBucket is the token bucket of a workspace, or of a user for the requests charged to them
+type workspaceBucket struct {
+	limiter    *rate.Limiter
+	resolvedAt time.Time
+}
+
+// workspaceMembership is a cached membership check of a user in a workspace
+type workspaceMembership struct {
+	member    bool
+	checkedAt time.Time
+}
+
+// WorkspaceRateLimiter limits the authenticated API requests of each workspace with a token bucket,
+// so that a single noisy workspace can't degrade the API for the others.
+// The bucket refills at requestsPerSecond up to burst, both overridable in the workspace settings.
+// Requests of users that are not members of the workspace they target, or that target none, are
+// charged to a bucket of the user with the server defaults, so that no one can drain the bucket of
+// another workspace nor escape the limit.
+type WorkspaceRateLimiter struct {
+	requestsPerSecond float64
+	burst             int
+	resolve           WorkspaceRateLimitResolver
+	isMember          WorkspaceMembershipChecker
+	logger            logger.Logger
+	now               func() time.Time
+
+	mu          sync.Mutex
+	buckets     map[string]*workspaceBucket
+	memberships map[string]workspaceMembership
+	lastSweep   time.Time
+}
+
+// NewWorkspaceRateLimiter creates a rate limiter with the server defaults, requestsPerSecond 0 only
+// limits the workspaces with overrides. resolve may be nil when overrides aren't used, isMember may be
+// nil to charge the requests of users to their own bucket only.
+func NewWorkspaceRateLimiter(requestsPerSecond float64, burst int, resolve WorkspaceRateLimitResolver, isMember WorkspaceMembershipChecker, logger logger.Logger) *WorkspaceRateLimiter {
+	return &WorkspaceRateLimiter{
+		requestsPerSecond: requestsPerSecond,
+		burst:             burst,
+		resolve:           resolve,
+		isMember:          isMember,
+		logger:            logger,
+		now:               time.Now,
+		buckets:           make(map[string]*workspaceBucket),
+		memberships:       make(map[string]workspaceMembership),
+	}
+}
+
+// defaultWorkspaceRateLimiter is the rate limiter of the auth middlewares created by NewAuthMiddleware
+var defaultWorkspaceRateLimiter *WorkspaceRateLimiter
+
+// SetWorkspaceRateLimiter sets the rate limiter of the auth middlewares created afterwards,
+// like SetTokenRevocationChecker it is set once at startup so that every handler shares the buckets
+func SetWorkspaceRateLimiter(limiter *WorkspaceRateLimiter) {
+	defaultWorkspaceRateLimiter = limiter
+}
+
+// limits returns the rate and burst of a workspace from the server defaults and its overrides
+func (l *WorkspaceRateLimiter) limits(overrides *domain.WorkspaceRateLimitSettings) (float64, int) {
+	requestsPerSecond, burst := l.requestsPerSecond, l.burst
+	if overrides != nil {
+		if overrides.RequestsPerSecond > 0 {
+			requestsPerSecond = overrides.RequestsPerSecond
+		}
+		if overrides.Burst > 0 {
+			burst = overrides.Burst
+		}
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return requestsPerSecond, burst
+}
+
+// bucket returns the bucket of a key, resolving the overrides of its workspace when they are unknown
+// or stale. User buckets have no workspace and use the server defaults.
+func (l *WorkspaceRateLimiter) bucket(ctx context.Context, key, workspaceID string, now time.Time) *workspaceBucket {
+	l.mu.Lock()
+	b, ok := l.buckets[key]
+	stale := !ok || now.Sub(b.resolvedAt) >= rateLimitSettingsTTL
+	l.mu.Unlock()
+	if !stale {
+		return b
+	}
+
+	var overrides *domain.WorkspaceRateLimitSettings
+	if l.resolve != nil && workspaceID != "" {
+		var err error
+		overrides, err = l.resolve(ctx, workspaceID)
+		if err != nil && l.logger != nil {
+			// Keep limiting with the server defaults rather than failing the request
+			l.logger.WithField("workspace_id", workspaceID).WithField("error", err.Error()).Warn("Failed to resolve workspace rate limit")
+		}
+	}
+	requestsPerSecond, burst := l.limits(overrides)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.sweep(now)
+	b, ok = l.buckets[key]
+	if !ok {
+		b = &workspaceBucket{limiter: rate.NewLimiter(rate.Limit(requestsPerSecond), burst)}
+		// A new bucket starts full
+		b.limiter.AllowN(now, 0)
+		l.buckets[key] = b
+	} else {
+		b.limiter.SetLimitAt(now, rate.Limit(requestsPerSecond))
+		b.limiter.SetBurstAt(now, burst)
+	}
+	b.resolvedAt = now
+	return b
+}
+
+// sweep drops the buckets and memberships that weren't resolved for a while, so that they don't pile up.
+// A dropped bucket went unused for at least a minute and is recreated full. Must be called with mu held.
+func (l *WorkspaceRateLimiter) sweep(now time.Time) {
+	if now.Sub(l.lastSweep) < rateLimitSettingsTTL {
+		return
+	}
+	for key, b := range l.buckets {
+		if now.Sub(b.resolvedAt) >= 2*rateLimitSettingsTTL {
+			delete(l.buckets, key)
+		}
+	}
+	for key, m := range l.memberships {
+		if now.Sub(m.checkedAt) >= rateLimitSettingsTTL {
+			delete(l.memberships, key)
+		}
+	}
+	l.lastSweep = now
+}
+
+// member reports whether a user is a member of a workspace, the check is cached like the overrides
+func (l *WorkspaceRateLimiter) member(ctx context.Context, userID, workspaceID string, now time.Time) bool {
+	if l.isMember == nil {
+		return false
+	}
+
+	key := userID + "/" + workspaceID
+	l.mu.Lock()
+	m, ok := l.memberships[key]
+	l.mu.Unlock()
+	if ok && now.Sub(m.checkedAt) < rateLimitSettingsTTL {
+		return m.member
+	}
+
+	member := l.isMember(ctx, userID, workspaceID)
+
+	l.mu.Lock()
+	l.memberships[key] = workspaceMembership{member: member, checkedAt: now}
+	l.mu.Unlock()
+	return member
+}
+
+// Allow takes a token from the bucket of a workspace. When the bucket is empty it returns false
+// with the delay until the next token.
+func (l *WorkspaceRateLimiter) Allow(ctx context.Context, workspaceID string) (bool, time.Duration) {
+	return l.allow(ctx, workspaceBucketKey(workspaceID), workspaceID)
+}
+
+// allow takes a token from the bucket of a key, workspaceID is empty for user buckets
+func (l *WorkspaceRateLimiter) allow(ctx context.Context, key, workspaceID string) (bool, time.Duration) {
+	now := l.now()
+	b := l.bucket(ctx, key, workspaceID, now)
+	if b.limiter.Limit() <= 0 {
+		// No limit for this workspace
+		return true, 0
+	}
+
+	reservation := b.limiter.ReserveN(now, 1)
+	if !reservation.OK() {
+		return false, time.Second
+	}
+	delay := reservation.DelayFrom(now)
+	if delay > 0 {
+		// Give the token back, the request is rejected rather than delayed
+		reservation.CancelAt(now)
+		return false, delay
+	}
+	return true, 0
+}
+
+// workspaceBucketKey returns the key of the bucket of a workspace
+func workspaceBucketKey(workspaceID string) string {
+	return "workspace:" + workspaceID
+}
+
+// userBucketKey returns the key of the bucket of a user, it can't collide with a workspace key
+func userBucketKey(userID string) string {
+	return "user:" + userID
+}
+
+// Middleware limits the requests of the workspace they are authenticated for, see requestBucket.
+// Unauthenticated requests aren't limited, the middleware is applied once the request is authenticated.
+func (l *WorkspaceRateLimiter) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key, workspaceID := l.requestBucket(r)
+		if key == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		allowed, retryAfter := l.allow(r.Context(), key, workspaceID)
+		if !allowed {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+			writeJSONError(w, "Rate limit exceeded, retry later", http.StatusTooManyRequests)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// requestBucket returns the bucket a request is charged to: the workspace of the API key that
+// authenticated it, else the workspace the request targets once the user is confirmed to be a member,
+// else the bucket of the user. The workspace ID sent by the client alone never selects a bucket.
+func (l *WorkspaceRateLimiter) requestBucket(r *http.Request) (key string, workspaceID string) {
+	if workspaceID, ok := r.Context().Value(apiKeyWorkspaceIDKey).(string); ok && workspaceID != "" {
+		return workspaceBucketKey(workspaceID), workspaceID
+	}
+
+	userID, _ := r.Context().Value(domain.UserIDKey).(string)
+	if userID == "" {
+		return "", ""
+	}
+
+	if workspaceID := requestedWorkspaceID(r); workspaceID != "" && l.member(r.Context(), userID, workspaceID, l.now()) {
+		return workspaceBucketKey(workspaceID), workspaceID
+	}
+	return userBucketKey(userID), ""
+}
+
+// requestedWorkspaceID returns the workspace a request targets: the workspace_id query parameter,
+// else the workspace_id of a JSON body. Bodies are read whatever their Content-Type, like the handlers do.
+func requestedWorkspaceID(r *http.Request) string {
+	if workspaceID := r.URL.Query().Get("workspace_id"); workspaceID != "" {
+		return workspaceID
+	}
+	if r.Body == nil || r.Body == http.NoBody || r.Method == http.MethodGet {
+		return ""
+	}
+
+	// Peek at the body and put it back for the handler
+	peeked, err := io.ReadAll(io.LimitReader(r.Body, rateLimitMaxPeekedBody+1))
+	r.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(peeked), r.Body), r.Body}
+	if err != nil || len(peeked) > rateLimitMaxPeekedBody {
+		return ""
+	}
+
+	var body struct {
+		WorkspaceID string `json:"workspace_id"`
+	}
+	if err := json.Unmarshal(peeked, &body); err != nil {
+		return ""
+	}
+	return body.WorkspaceID
+}
@@ -0,0 +1,293 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/pkg/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestRateLimiter returns a rate limiter on a clock advanced by the returned function,
+// where every user is a member of every workspace
+func newTestRateLimiter(requestsPerSecond float64, burst int, resolve WorkspaceRateLimitResolver) (*WorkspaceRateLimiter, func(time.Duration)) {
+	isMember := func(context.Context, string, string) bool { return true }
+	limiter := NewWorkspaceRateLimiter(requestsPerSecond, burst, resolve, isMember, logger.NewLoggerWithLevel("disabled"))
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	limiter.now = func() time.Time { return now }
+	return limiter, func(d time.Duration) { now = now.Add(d) }
+}
+
+// withUser authenticates a request as a user, like RequireAuth does for JWT tokens
+func withUser(req *http.Request, userID string) *http.Request {
+	return req.WithContext(context.WithValue(req.Context(), domain.UserIDKey, userID))
+}
+
+func serveRateLimited(handler http.Handler, workspaceID string) *httptest.ResponseRecorder {
+	return serveRateLimitedAs(handler, "user1", workspaceID)
+}
+
+func serveRateLimitedAs(handler http.Handler, userID, workspaceID string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, withUser(httptest.NewRequest(http.MethodGet, "/api/contacts.list?workspace_id="+workspaceID, nil), userID))
+	return rec
+}
+
+func TestWorkspaceRateLimiter_Middleware(t *testing.T) {
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	t.Run("burst beyond the bucket returns 429", func(t *testing.T) {
+		limiter, _ := newTestRateLimiter(1, 3, nil)
+		handler := limiter.Middleware(ok)
+
+		for i := 0; i < 3; i++ {
+			assert.Equal(t, http.StatusOK, serveRateLimited(handler, "ws1").Code)
+		}
+
+		rec := serveRateLimited(handler, "ws1")
+		assert.Equal(t, http.StatusTooManyRequests, rec.Code)
+		assert.Equal(t, "1", rec.Header().Get("Retry-After"))
+		assert.Contains(t, rec.Body.String(), "Rate limit exceeded")
+	})
+
+	t.Run("bucket refills over time", func(t *testing.T) {
+		limiter, advance := newTestRateLimiter(2, 2, nil)
+		handler := limiter.Middleware(ok)
+
+		assert.Equal(t, http.StatusOK, serveRateLimited(handler, "ws1").Code)
+		assert.Equal(t, http.StatusOK, serveRateLimited(handler, "ws1").Code)
+		assert.Equal(t, http.StatusTooManyRequests, serveRateLimited(handler, "ws1").Code)
+
+		// One token is back after half a second
+		advance(500 * time.Millisecond)
+		assert.Equal(t, http.StatusOK, serveRateLimited(handler, "ws1").Code)
+		assert.Equal(t, http.StatusTooManyRequests, serveRateLimited(handler, "ws1").Code)
+
+		// The bucket refills up to the burst only
+		advance(10 * time.Second)
+		assert.Equal(t, http.StatusOK, serveRateLimited(handler, "ws1").Code)
+		assert.Equal(t, http.StatusOK, serveRateLimited(handler, "ws1").Code)
+		assert.Equal(t, http.StatusTooManyRequests, serveRateLimited(handler, "ws1").Code)
+	})
+
+	t.Run("workspaces have their own bucket", func(t *testing.T) {
+		limiter, _ := newTestRateLimiter(1, 1, nil)
+		handler := limiter.Middleware(ok)
+
+		assert.Equal(t, http.StatusOK, serveRateLimited(handler, "ws1").Code)
+		assert.Equal(t, http.StatusTooManyRequests, serveRateLimited(handler, "ws1").Code)
+		assert.Equal(t, http.StatusOK, serveRateLimited(handler, "ws2").Code)
+	})
+
+	t.Run("requests without a workspace are charged to the user", func(t *testing.T) {
+		limiter, _ := newTestRateLimiter(1, 1, nil)
+		handler := limiter.Middleware(ok)
+
+		send := func(userID string) int {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, withUser(httptest.NewRequest(http.MethodGet, "/api/user.me", nil), userID))
+			return rec.Code
+		}
+
+		assert.Equal(t, http.StatusOK, send("user1"))
+		assert.Equal(t, http.StatusTooManyRequests, send("user1"))
+		assert.Equal(t, http.StatusOK, send("user2"))
+		// The workspace bucket is left untouched
+		assert.Equal(t, http.StatusOK, serveRateLimited(handler, "ws1").Code)
+	})
+
+	t.Run("unauthenticated requests are not limited", func(t *testing.T) {
+		limiter, _ := newTestRateLimiter(1, 1, nil)
+		handler := limiter.Middleware(ok)
+
+		for i := 0; i < 3; i++ {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/user.me", nil))
+			assert.Equal(t, http.StatusOK, rec.Code)
+		}
+	})
+
+	t.Run("requests for another workspace are charged to the user", func(t *testing.T) {
+		limiter, _ := newTestRateLimiter(1, 2, nil)
+		checks := 0
+		limiter.isMember = func(_ context.Context, userID, workspaceID string) bool {
+			checks++
+			return userID == "member" && workspaceID == "ws1"
+		}
+		handler := limiter.Middleware(ok)
+
+		// An outsider naming ws1 drains their own bucket, not the one of ws1
+		for i := 0; i < 2; i++ {
+			assert.Equal(t, http.StatusOK, serveRateLimitedAs(handler, "outsider", "ws1").Code)
+		}
+		assert.Equal(t, http.StatusTooManyRequests, serveRateLimitedAs(handler, "outsider", "ws1").Code)
+		assert.Equal(t, http.StatusTooManyRequests, serveRateLimitedAs(handler, "outsider", "ws2").Code)
+
+		assert.Equal(t, http.StatusOK, serveRateLimitedAs(handler, "member", "ws1").Code)
+		assert.Equal(t, http.StatusOK, serveRateLimitedAs(handler, "member", "ws1").Code)
+		assert.Equal(t, http.StatusTooManyRequests, serveRateLimitedAs(handler, "member", "ws1").Code)
+
+		// Memberships are checked once per user and workspace
+		assert.Equal(t, 3, checks)
+	})
+
+	t.Run("workspace overrides", func(t *testing.T) {
+		resolve := func(_ context.Context, workspaceID string) (*domain.WorkspaceRateLimitSettings, error) {
+			if workspaceID == "ws1" {
+				return &domain.WorkspaceRateLimitSettings{Burst: 5}, nil
+			}
+			return nil, nil
+		}
+		limiter, _ := newTestRateLimiter(1, 1, resolve)
+		handler := limiter.Middleware(ok)
+
+		for i := 0; i < 5; i++ {
+			assert.Equal(t, http.StatusOK, serveRateLimited(handler, "ws1").Code)
+		}
+		assert.Equal(t, http.StatusTooManyRequests, serveRateLimited(handler, "ws1").Code)
+
+		assert.Equal(t, http.StatusOK, serveRateLimited(handler, "ws2").Code)
+		assert.Equal(t, http.StatusTooManyRequests, serveRateLimited(handler, "ws2").Code)
+	})
+
+	t.Run("overrides limit workspaces when the default is unlimited", func(t *testing.T) {
+		resolve := func(_ context.Context, workspaceID string) (*domain.WorkspaceRateLimitSettings, error) {
+			if workspaceID == "ws1" {
+				return &domain.WorkspaceRateLimitSettings{RequestsPerSecond: 1, Burst: 1}, nil
+			}
+			return nil, errors.New("database error")
+		}
+		limiter, _ := newTestRateLimiter(0, 1, resolve)
+		handler := limiter.Middleware(ok)
+
+		assert.Equal(t, http.StatusOK, serveRateLimited(handler, "ws1").Code)
+		assert.Equal(t, http.StatusTooManyRequests, serveRateLimited(handler, "ws1").Code)
+
+		for i := 0; i < 3; i++ {
+			assert.Equal(t, http.StatusOK, serveRateLimited(handler, "ws2").Code)
+		}
+	})
+
+	t.Run("overrides are resolved again once stale", func(t *testing.T) {
+		burst := 1
+		resolve := func(_ context.Context, _ string) (*domain.WorkspaceRateLimitSettings, error) {
+			return &domain.WorkspaceRateLimitSettings{Burst: burst}, nil
+		}
+		limiter, advance := newTestRateLimiter(1, 1, resolve)
+		handler := limiter.Middleware(ok)
+
+		assert.Equal(t, http.StatusOK, serveRateLimited(handler, "ws1").Code)
+		assert.Equal(t, http.StatusTooManyRequests, serveRateLimited(handler, "ws1").Code)
+
+		burst = 3
+		advance(rateLimitSettingsTTL)
+		assert.Equal(t, http.StatusOK, serveRateLimited(handler, "ws1").Code)
+
+		// The larger bucket fills up at the workspace rate
+		advance(3 * time.Second)
+		for i := 0; i < 3; i++ {
+			assert.Equal(t, http.StatusOK, serveRateLimited(handler, "ws1").Code)
+		}
+		assert.Equal(t, http.StatusTooManyRequests, serveRateLimited(handler, "ws1").Code)
+	})
+
+	t.Run("workspace from a JSON body", func(t *testing.T) {
+		limiter, _ := newTestRateLimiter(1, 1, nil)
+		var bodies []string
+		handler := limiter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			bodies = append(bodies, string(body))
+			w.WriteHeader(http.StatusOK)
+		}))
+
+		body := `{"workspace_id":"ws1","email":"john@example.com"}`
+		send := func() int {
+			req := httptest.NewRequest(http.MethodPost, "/api/contacts.upsert", strings.NewReader(body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, withUser(req, "user1"))
+			return rec.Code
+		}
+
+		assert.Equal(t, http.StatusOK, send())
+		assert.Equal(t, http.StatusTooManyRequests, send())
+		// The handler still reads the whole body
+		assert.Equal(t, []string{body}, bodies)
+	})
+
+	t.Run("body without Content-Type or too large", func(t *testing.T) {
+		limiter, _ := newTestRateLimiter(1, 1, nil)
+		var sizes []int
+		handler := limiter.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, err := io.ReadAll(r.Body)
+			require.NoError(t, err)
+			sizes = append(sizes, len(body))
+			w.WriteHeader(http.StatusOK)
+		}))
+
+		send := func(userID, body string) int {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, withUser(httptest.NewRequest(http.MethodPost, "/api/contacts.upsert", strings.NewReader(body)), userID))
+			return rec.Code
+		}
+
+		// Without Content-Type the body still names the workspace
+		small := `{"workspace_id":"ws1"}`
+		assert.Equal(t, http.StatusOK, send("user1", small))
+		assert.Equal(t, http.StatusTooManyRequests, send("user1", small))
+
+		// A body too large to peek at is charged to the user, it does not escape the limit
+		large := `{"workspace_id":"ws2","padding":"` + strings.Repeat("x", rateLimitMaxPeekedBody) + `"}`
+		assert.Equal(t, http.StatusOK, send("user2", large))
+		assert.Equal(t, http.StatusTooManyRequests, send("user2", large))
+		assert.Equal(t, http.StatusOK, serveRateLimitedAs(handler, "user3", "ws2").Code)
+
+		// The handler still reads the whole bodies
+		assert.Equal(t, []int{len(small), len(large)}, sizes)
+	})
+
+	t.Run("workspace of the API key", func(t *testing.T) {
+		limiter, _ := newTestRateLimiter(1, 1, nil)
+		handler := limiter.Middleware(ok)
+
+		send := func() int {
+			req := httptest.NewRequest(http.MethodGet, "/api/contacts.list?workspace_id=other", nil)
+			req = req.WithContext(context.WithValue(req.Context(), apiKeyWorkspaceIDKey, "ws1"))
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+			return rec.Code
+		}
+
+		assert.Equal(t, http.StatusOK, send())
+		assert.Equal(t, http.StatusTooManyRequests, send())
+		assert.Equal(t, http.StatusOK, serveRateLimited(handler, "other").Code)
+	})
+}
+
+func TestRequireAuth_RateLimit(t *testing.T) {
+	limiter, _ := newTestRateLimiter(1, 1, nil)
+	authConfig := NewAuthMiddleware(func() ([]byte, error) { return testJWTSecret, nil })
+	authConfig.RateLimiter = limiter
+	handler := authConfig.RequireAuth()(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	// Unauthenticated requests don't take tokens
+	for i := 0; i < 3; i++ {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/contacts.list?workspace_id=ws1", nil))
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	}
+	assert.Empty(t, limiter.buckets)
+}
@@ -0,0 +1,119 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/http/middleware"
+	"github.com/Notifuse/notifuse/pkg/logger"
+)
+
+// SenderIdentityHandler handles HTTP requests for the verified sender identities of a workspace
+type SenderIdentityHandler struct {
+	service      domain.SenderIdentityService
+	logger       logger.Logger
+	getJWTSecret func() ([]byte, error)
+}
+
+// NewSenderIdentityHandler creates a new sender identity handler
+func NewSenderIdentityHandler(
+	service domain.SenderIdentityService,
+	getJWTSecret func() ([]byte, error),
+	logger logger.Logger,
+) *SenderIdentityHandler {
+	return &SenderIdentityHandler{
+		service:      service,
+		logger:       logger,
+		getJWTSecret: getJWTSecret,
+	}
+}
+
+// RegisterRoutes registers the sender identity HTTP endpoints
+func (h *SenderIdentityHandler) RegisterRoutes(mux *http.ServeMux) {
+	// Create auth middleware
+	authMiddleware := middleware.NewAuthMiddleware(h.getJWTSecret)
+	requireAuth := authMiddleware.RequireAuth()
+
+	mux.Handle("/api/senderIdentities.list", requireAuth(http.HandlerFunc(h.handleList)))
+	mux.Handle("/api/senderIdentities.verify", requireAuth(http.HandlerFunc(h.handleVerify)))
+}
+
+// handleList handles requests to list the sender identities of a workspace
+func (h *SenderIdentityHandler) handleList(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	workspaceID := r.URL.Query().Get("workspace_id")
+	if workspaceID == "" {
+		WriteJSONError(w, "workspace_id is required", http.StatusBadRequest)
+		return
+	}
+
+	identities, err := h.service.ListSenderIdentities(r.Context(), workspaceID)
+	if err != nil {
+		var workspaceNotFoundErr *domain.ErrWorkspaceNotFound
+		if errors.As(err, &workspaceNotFoundErr) {
+			WriteJSONError(w, "Workspace not found", http.StatusNotFound)
+			return
+		}
+		h.logger.WithField("error", err.Error()).
+			WithField("workspace_id", workspaceID).
+			Error("Failed to list sender identities")
+		WriteJSONError(w, "Failed to list sender identities", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"sender_identities": identities,
+	})
+}
+
+// handleVerify handles requests to start or check the verification of a sender identity
+func (h *SenderIdentityHandler) handleVerify(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req domain.VerifySenderIdentityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteJSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	identity, err := h.service.VerifySenderIdentity(r.Context(), req)
+	if err != nil {
+		var validationErr domain.ValidationError
+		if errors.As(err, &validationErr) {
+			WriteJSONError(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if _, ok := err.(*domain.ErrUnauthorized); ok {
+			WriteJSONError(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		var workspaceNotFoundErr *domain.ErrWorkspaceNotFound
+		if errors.As(err, &workspaceNotFoundErr) {
+			WriteJSONError(w, "Workspace not found", http.StatusNotFound)
+			return
+		}
+		h.logger.WithField("error", err.Error()).
+			WithField("workspace_id", req.WorkspaceID).
+			Error("Failed to verify sender identity")
+		WriteJSONError(w, "Failed to verify sender identity", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"sender_identity": identity,
+	})
+}
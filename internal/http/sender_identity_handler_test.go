@@ -0,0 +1,137 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/domain/mocks"
+	pkgmocks "github.com/Notifuse/notifuse/pkg/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupSenderIdentityHandlerTest(t *testing.T) (*mocks.MockSenderIdentityService, *SenderIdentityHandler) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockService := mocks.NewMockSenderIdentityService(ctrl)
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+	mockLogger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
+
+	handler := NewSenderIdentityHandler(mockService, func() ([]byte, error) { return []byte("test-jwt-secret-key-for-testing-32bytes"), nil }, mockLogger)
+	return mockService, handler
+}
+
+func TestSenderIdentityHandler_HandleList(t *testing.T) {
+	t.Run("lists the identities of the workspace", func(t *testing.T) {
+		mockService, handler := setupSenderIdentityHandlerTest(t)
+		mockService.EXPECT().ListSenderIdentities(gomock.Any(), "workspace123").Return([]domain.SenderIdentity{
+			{Email: "news@example.com", Status: domain.SenderIdentityStatusVerified},
+		}, nil)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/senderIdentities.list?workspace_id=workspace123", nil)
+		rr := httptest.NewRecorder()
+		handler.handleList(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var response struct {
+			SenderIdentities []domain.SenderIdentity `json:"sender_identities"`
+		}
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+		require.Len(t, response.SenderIdentities, 1)
+		assert.Equal(t, domain.SenderIdentityStatusVerified, response.SenderIdentities[0].Status)
+	})
+
+	t.Run("missing workspace_id", func(t *testing.T) {
+		_, handler := setupSenderIdentityHandlerTest(t)
+
+		rr := httptest.NewRecorder()
+		handler.handleList(rr, httptest.NewRequest(http.MethodGet, "/api/senderIdentities.list", nil))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("method not allowed", func(t *testing.T) {
+		_, handler := setupSenderIdentityHandlerTest(t)
+
+		rr := httptest.NewRecorder()
+		handler.handleList(rr, httptest.NewRequest(http.MethodPost, "/api/senderIdentities.list?workspace_id=workspace123", nil))
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rr.Code)
+	})
+}
+
+func TestSenderIdentityHandler_HandleVerify(t *testing.T) {
+	verify := func(handler *SenderIdentityHandler, body interface{}) *httptest.ResponseRecorder {
+		payload, _ := json.Marshal(body)
+		rr := httptest.NewRecorder()
+		handler.handleVerify(rr, httptest.NewRequest(http.MethodPost, "/api/senderIdentities.verify", bytes.NewBuffer(payload)))
+		return rr
+	}
+	request := domain.VerifySenderIdentityRequest{WorkspaceID: "workspace123", Email: "news@example.com"}
+
+	t.Run("returns the pending identity and its DNS record", func(t *testing.T) {
+		mockService, handler := setupSenderIdentityHandlerTest(t)
+		mockService.EXPECT().VerifySenderIdentity(gomock.Any(), request).Return(&domain.SenderIdentity{
+			Email:  "news@example.com",
+			Status: domain.SenderIdentityStatusPending,
+			DomainAuth: domain.SenderDomainAuth{
+				Domain:         "example.com",
+				TXTRecordName:  "_notifuse.example.com",
+				TXTRecordValue: "notifuse-verification=token",
+			},
+		}, nil)
+
+		rr := verify(handler, request)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var response struct {
+			SenderIdentity domain.SenderIdentity `json:"sender_identity"`
+		}
+		require.NoError(t, json.NewDecoder(rr.Body).Decode(&response))
+		assert.Equal(t, domain.SenderIdentityStatusPending, response.SenderIdentity.Status)
+		assert.Equal(t, "_notifuse.example.com", response.SenderIdentity.DomainAuth.TXTRecordName)
+	})
+
+	t.Run("missing email", func(t *testing.T) {
+		_, handler := setupSenderIdentityHandlerTest(t)
+
+		rr := verify(handler, domain.VerifySenderIdentityRequest{WorkspaceID: "workspace123"})
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("email that is not a sender", func(t *testing.T) {
+		mockService, handler := setupSenderIdentityHandlerTest(t)
+		mockService.EXPECT().VerifySenderIdentity(gomock.Any(), request).Return(nil, domain.NewValidationError("news@example.com is not a sender of an email integration of the workspace"))
+
+		rr := verify(handler, request)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+
+	t.Run("not an owner", func(t *testing.T) {
+		mockService, handler := setupSenderIdentityHandlerTest(t)
+		mockService.EXPECT().VerifySenderIdentity(gomock.Any(), request).Return(nil, &domain.ErrUnauthorized{Message: "user is not an owner of the workspace"})
+
+		rr := verify(handler, request)
+
+		assert.Equal(t, http.StatusForbidden, rr.Code)
+	})
+
+	t.Run("service error", func(t *testing.T) {
+		mockService, handler := setupSenderIdentityHandlerTest(t)
+		mockService.EXPECT().VerifySenderIdentity(gomock.Any(), request).Return(nil, errors.New("database error"))
+
+		rr := verify(handler, request)
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+	})
+}
@@ -0,0 +1,73 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/pkg/logger"
+)
+
+// ShortLinkHandler serves the short links of click-tracked URLs
+type ShortLinkHandler struct {
+	linkShortener domain.LinkShortenerService
+	emailService  domain.EmailServiceInterface
+	logger        logger.Logger
+}
+
+// NewShortLinkHandler creates a new short link handler
+func NewShortLinkHandler(
+	linkShortener domain.LinkShortenerService,
+	emailService domain.EmailServiceInterface,
+	logger logger.Logger,
+) *ShortLinkHandler {
+	return &ShortLinkHandler{
+		linkShortener: linkShortener,
+		emailService:  emailService,
+		logger:        logger,
+	}
+}
+
+// RegisterRoutes registers the short link redirect route
+func (h *ShortLinkHandler) RegisterRoutes(mux *http.ServeMux) {
+	mux.Handle(domain.ShortLinkPathPrefix, http.HandlerFunc(h.handleShortLink))
+}
+
+// handleShortLink records the click of a short link and redirects to its destination,
+// keeping the query parameters of the request
+func (h *ShortLinkHandler) handleShortLink(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	workspaceID, code, err := domain.ParseShortLinkPath(r.URL.Path)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+
+	link, err := h.linkShortener.ExpandShortLink(r.Context(), workspaceID, code)
+	if err != nil {
+		if errors.Is(err, domain.ErrShortLinkNotFound) {
+			http.NotFound(w, r)
+			return
+		}
+		h.logger.WithFields(map[string]interface{}{
+			"workspace_id": workspaceID,
+			"code":         code,
+			"error":        err.Error(),
+		}).Error("Failed to expand short link")
+		http.Error(w, "Failed to expand link", http.StatusInternalServerError)
+		return
+	}
+
+	// Record click only for GET requests that pass bot detection
+	if r.Method == http.MethodGet && isHumanClick(r, strconv.FormatInt(link.SentAt.Unix(), 10), link.MessageID, h.logger) {
+		_ = h.emailService.VisitLink(r.Context(), link.MessageID, workspaceID)
+	}
+
+	// Always redirect regardless of whether we recorded
+	http.Redirect(w, r, link.ExpandURL(r.URL.Query()), http.StatusSeeOther)
+}
@@ -0,0 +1,188 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/domain/mocks"
+	"github.com/Notifuse/notifuse/internal/service"
+	pkgmocks "github.com/Notifuse/notifuse/pkg/mocks"
+	"github.com/Notifuse/notifuse/pkg/notifuse_mjml"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const browserUserAgent = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) Chrome/120.0.0.0"
+
+func newShortLinkTestLogger(ctrl *gomock.Controller) *pkgmocks.MockLogger {
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+	mockLogger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Warn(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
+	return mockLogger
+}
+
+func TestShortLinkHandler_RedirectsAndRecordsClick(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockShortLinkRepository(ctrl)
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	mockEmailService := mocks.NewMockEmailServiceInterface(ctrl)
+	mockLogger := newShortLinkTestLogger(ctrl)
+
+	linkShortener := service.NewLinkShortenerService(mockRepo, mockWorkspaceRepo, "https://api.example.com", mockLogger)
+	handler := NewShortLinkHandler(linkShortener, mockEmailService, mockLogger)
+
+	workspace := &domain.Workspace{
+		ID: "ws1",
+		Settings: domain.WorkspaceSettings{
+			LinkShortening: &domain.LinkShorteningSettings{Enabled: true, Domain: "https://go.example.com"},
+		},
+	}
+	mockWorkspaceRepo.EXPECT().GetByID(gomock.Any(), "ws1").Return(workspace, nil)
+
+	// Keep the stored link to serve it back on expansion
+	var stored *domain.ShortLink
+	mockRepo.EXPECT().Create(gomock.Any(), "ws1", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, link *domain.ShortLink) (bool, error) {
+			stored = link
+			return true, nil
+		})
+
+	// Compile a tracked link sent 10 seconds ago
+	trackingSettings := notifuse_mjml.TrackingSettings{
+		EnableTracking: true,
+		Endpoint:       "https://api.example.com",
+		UTMSource:      "newsletter",
+		UTMMedium:      "email",
+		WorkspaceID:    "ws1",
+		MessageID:      "msg1",
+		LinkShortener:  linkShortener.ShortenerForWorkspace(context.Background(), "ws1"),
+	}
+	html, err := notifuse_mjml.TrackLinks(`<a href="https://shop.example.com/offer?id=42">Offer</a>`, trackingSettings)
+	require.NoError(t, err)
+	require.NotNil(t, stored)
+	stored.SentAt = time.Now().Add(-10 * time.Second)
+
+	shortURL := regexp.MustCompile(`href="([^"]+)"`).FindStringSubmatch(html)[1]
+	assert.Regexp(t, `^https://go\.example\.com/s/ws1/[a-z0-9]{8}$`, shortURL)
+
+	mockRepo.EXPECT().GetByCode(gomock.Any(), "ws1", stored.Code).Return(stored, nil)
+	mockEmailService.EXPECT().VisitLink(gomock.Any(), "msg1", "ws1").Return(nil)
+
+	// The short domain points to the API, so only the path and query reach the handler
+	req := httptest.NewRequest(http.MethodGet, shortURL+"?ref=partner", nil)
+	req.Header.Set("User-Agent", browserUserAgent)
+	w := httptest.NewRecorder()
+	handler.handleShortLink(w, req)
+
+	assert.Equal(t, http.StatusSeeOther, w.Code)
+	assert.Equal(t, "https://shop.example.com/offer?id=42&ref=partner&utm_medium=email&utm_source=newsletter", w.Header().Get("Location"))
+}
+
+func TestShortLinkHandler_HandleShortLink(t *testing.T) {
+	sentAt := time.Now().Add(-time.Minute)
+
+	tests := []struct {
+		name               string
+		method             string
+		path               string
+		userAgent          string
+		setupExpectations  func(*mocks.MockLinkShortenerService, *mocks.MockEmailServiceInterface)
+		expectedStatusCode int
+		expectedRedirectTo string
+	}{
+		{
+			name:      "bot click redirects without recording",
+			method:    http.MethodGet,
+			path:      "/s/ws1/abc12345",
+			userAgent: "Googlebot/2.1 (+http://www.google.com/bot.html)",
+			setupExpectations: func(ls *mocks.MockLinkShortenerService, _ *mocks.MockEmailServiceInterface) {
+				ls.EXPECT().ExpandShortLink(gomock.Any(), "ws1", "abc12345").
+					Return(&domain.ShortLink{Code: "abc12345", MessageID: "msg1", URL: "https://example.com", SentAt: sentAt}, nil)
+			},
+			expectedStatusCode: http.StatusSeeOther,
+			expectedRedirectTo: "https://example.com",
+		},
+		{
+			name:      "head request redirects without recording",
+			method:    http.MethodHead,
+			path:      "/s/ws1/abc12345",
+			userAgent: browserUserAgent,
+			setupExpectations: func(ls *mocks.MockLinkShortenerService, _ *mocks.MockEmailServiceInterface) {
+				ls.EXPECT().ExpandShortLink(gomock.Any(), "ws1", "abc12345").
+					Return(&domain.ShortLink{Code: "abc12345", MessageID: "msg1", URL: "https://example.com", SentAt: sentAt}, nil)
+			},
+			expectedStatusCode: http.StatusSeeOther,
+			expectedRedirectTo: "https://example.com",
+		},
+		{
+			name:               "malformed path",
+			method:             http.MethodGet,
+			path:               "/s/ws1",
+			userAgent:          browserUserAgent,
+			setupExpectations:  func(*mocks.MockLinkShortenerService, *mocks.MockEmailServiceInterface) {},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name:      "unknown code",
+			method:    http.MethodGet,
+			path:      "/s/ws1/missing1",
+			userAgent: browserUserAgent,
+			setupExpectations: func(ls *mocks.MockLinkShortenerService, _ *mocks.MockEmailServiceInterface) {
+				ls.EXPECT().ExpandShortLink(gomock.Any(), "ws1", "missing1").Return(nil, domain.ErrShortLinkNotFound)
+			},
+			expectedStatusCode: http.StatusNotFound,
+		},
+		{
+			name:      "repository error",
+			method:    http.MethodGet,
+			path:      "/s/ws1/abc12345",
+			userAgent: browserUserAgent,
+			setupExpectations: func(ls *mocks.MockLinkShortenerService, _ *mocks.MockEmailServiceInterface) {
+				ls.EXPECT().ExpandShortLink(gomock.Any(), "ws1", "abc12345").Return(nil, errors.New("db down"))
+			},
+			expectedStatusCode: http.StatusInternalServerError,
+		},
+		{
+			name:               "method not allowed",
+			method:             http.MethodPost,
+			path:               "/s/ws1/abc12345",
+			userAgent:          browserUserAgent,
+			setupExpectations:  func(*mocks.MockLinkShortenerService, *mocks.MockEmailServiceInterface) {},
+			expectedStatusCode: http.StatusMethodNotAllowed,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockLinkShortener := mocks.NewMockLinkShortenerService(ctrl)
+			mockEmailService := mocks.NewMockEmailServiceInterface(ctrl)
+			handler := NewShortLinkHandler(mockLinkShortener, mockEmailService, newShortLinkTestLogger(ctrl))
+			tt.setupExpectations(mockLinkShortener, mockEmailService)
+
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			req.Header.Set("User-Agent", tt.userAgent)
+			w := httptest.NewRecorder()
+			handler.handleShortLink(w, req)
+
+			assert.Equal(t, tt.expectedStatusCode, w.Code)
+			if tt.expectedRedirectTo != "" {
+				assert.Equal(t, tt.expectedRedirectTo, w.Header().Get("Location"))
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package http
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strings"
@@ -38,6 +39,7 @@ func (h *TemplateHandler) RegisterRoutes(mux *http.ServeMux) {
 	mux.Handle("/api/templates.update", requireAuth(http.HandlerFunc(h.handleUpdate)))
 	mux.Handle("/api/templates.delete", requireAuth(http.HandlerFunc(h.handleDelete)))
 	mux.Handle("/api/templates.compile", requireAuth(http.HandlerFunc(h.handleCompile)))
+	mux.Handle("/api/templates.preview", requireAuth(http.HandlerFunc(h.handlePreview)))
 }
 
 func (h *TemplateHandler) handleList(w http.ResponseWriter, r *http.Request) {
@@ -223,3 +225,43 @@ func (h *TemplateHandler) handleCompile(w http.ResponseWriter, r *http.Request)
 
 	writeJSON(w, http.StatusOK, resp)
 }
+
+func (h *TemplateHandler) handlePreview(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req domain.PreviewTemplateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		h.logger.WithField("error", err.Error()).Error("Failed to decode preview request body")
+		WriteJSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	resp, err := h.service.PreviewTemplate(r.Context(), req)
+	if err != nil {
+		var notFoundErr *domain.ErrTemplateNotFound
+		var validationErr domain.ValidationError
+		var permissionErr *domain.PermissionError
+		switch {
+		case errors.As(err, &notFoundErr):
+			WriteJSONError(w, "Template not found", http.StatusNotFound)
+		case errors.As(err, &validationErr):
+			WriteJSONError(w, validationErr.Message, http.StatusBadRequest)
+		case errors.As(err, &permissionErr):
+			WriteJSONError(w, permissionErr.Message, http.StatusForbidden)
+		default:
+			h.logger.WithField("error", err.Error()).Error("Failed to preview template")
+			WriteJSONError(w, "Failed to preview template", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	writeJSON(w, http.StatusOK, resp)
+}
@@ -3,6 +3,7 @@ package http
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strings"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/Notifuse/notifuse/config"
 	"github.com/Notifuse/notifuse/internal/domain"
 	"github.com/Notifuse/notifuse/internal/http/middleware"
+	"github.com/Notifuse/notifuse/internal/service"
 	"github.com/Notifuse/notifuse/pkg/logger"
 	"github.com/Notifuse/notifuse/pkg/tracing"
 )
@@ -26,6 +28,7 @@ type UserServiceInterface interface {
 	VerifyUserSession(ctx context.Context, userID string, sessionID string) (*domain.User, error)
 	GetUserByID(ctx context.Context, userID string) (*domain.User, error)
 	Logout(ctx context.Context, userID string) error
+	RefreshToken(ctx context.Context) (*domain.AuthResponse, error)
 }
 
 type UserHandler struct {
@@ -313,6 +316,37 @@ func (h *UserHandler) Logout(w http.ResponseWriter, r *http.Request) {
 	})
 }
 
+// RefreshToken issues a new token for the session of a near-expiry user token and revokes the previous one
+func (h *UserHandler) RefreshToken(w http.ResponseWriter, r *http.Request) {
+	ctx, span := h.tracer.StartSpan(r.Context(), "UserHandler.RefreshToken")
+	defer span.End()
+
+	if r.Method != http.MethodPost {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	h.tracer.AddAttribute(ctx, "operation", "RefreshToken")
+	response, err := h.userService.RefreshToken(ctx)
+	if err != nil {
+		h.tracer.MarkSpanError(ctx, err)
+		switch {
+		case errors.Is(err, service.ErrTokenNotRefreshable):
+			WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		case errors.Is(err, service.ErrSessionExpired):
+			WriteJSONError(w, err.Error(), http.StatusUnauthorized)
+		default:
+			WriteJSONError(w, "Failed to refresh token", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	span.AddAttributes(trace.StringAttribute("user.id", response.User.ID))
+
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
 func (h *UserHandler) RegisterRoutes(mux *http.ServeMux) {
 	// Public routes (no auth required)
 	mux.HandleFunc("/api/user.signin", h.SignIn)
@@ -326,4 +360,5 @@ func (h *UserHandler) RegisterRoutes(mux *http.ServeMux) {
 	// Register protected routes
 	mux.Handle("/api/user.me", requireAuth(http.HandlerFunc(h.GetCurrentUser)))
 	mux.Handle("/api/user.logout", requireAuth(http.HandlerFunc(h.Logout)))
+	mux.Handle("/api/user.refresh", requireAuth(http.HandlerFunc(h.RefreshToken)))
 }
@@ -515,6 +515,113 @@ func TestUserHandler_Logout(t *testing.T) {
 	})
 }
 
+func TestUserHandler_RefreshToken(t *testing.T) {
+	handler, mockUserSvc, _, jwtSecret := setupUserHandlerTest(t)
+
+	t.Run("successful refresh", func(t *testing.T) {
+		expectedResponse := &domain.AuthResponse{
+			Token:     "new-token",
+			User:      domain.User{ID: "user1", Email: "user@example.com"},
+			ExpiresAt: time.Now().Add(30 * 24 * time.Hour),
+		}
+		mockUserSvc.EXPECT().
+			RefreshToken(gomock.Any()).
+			Return(expectedResponse, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/user.refresh", nil)
+		rec := httptest.NewRecorder()
+
+		handler.RefreshToken(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var response domain.AuthResponse
+		err := json.NewDecoder(rec.Body).Decode(&response)
+		require.NoError(t, err)
+		assert.Equal(t, "new-token", response.Token)
+		assert.Equal(t, "user1", response.User.ID)
+	})
+
+	t.Run("method not allowed", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/user.refresh", nil)
+		rec := httptest.NewRecorder()
+
+		handler.RefreshToken(rec, req)
+
+		assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+	})
+
+	t.Run("token not near expiry", func(t *testing.T) {
+		mockUserSvc.EXPECT().
+			RefreshToken(gomock.Any()).
+			Return(nil, service.ErrTokenNotRefreshable)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/user.refresh", nil)
+		rec := httptest.NewRecorder()
+
+		handler.RefreshToken(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("session expired", func(t *testing.T) {
+		mockUserSvc.EXPECT().
+			RefreshToken(gomock.Any()).
+			Return(nil, service.ErrSessionExpired)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/user.refresh", nil)
+		rec := httptest.NewRecorder()
+
+		handler.RefreshToken(rec, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+
+	t.Run("internal error", func(t *testing.T) {
+		mockUserSvc.EXPECT().
+			RefreshToken(gomock.Any()).
+			Return(nil, fmt.Errorf("database error"))
+
+		req := httptest.NewRequest(http.MethodPost, "/api/user.refresh", nil)
+		rec := httptest.NewRecorder()
+
+		handler.RefreshToken(rec, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rec.Code)
+
+		var response map[string]string
+		err := json.NewDecoder(rec.Body).Decode(&response)
+		require.NoError(t, err)
+		assert.Equal(t, "Failed to refresh token", response["error"])
+	})
+
+	t.Run("expired token is rejected by the middleware", func(t *testing.T) {
+		mux := http.NewServeMux()
+		handler.RegisterRoutes(mux)
+
+		claims := &service.UserClaims{
+			UserID:    "user1",
+			SessionID: "session1",
+			Type:      string(domain.UserTypeUser),
+			RegisteredClaims: jwt.RegisteredClaims{
+				ID:        uuid.NewString(),
+				ExpiresAt: jwt.NewNumericDate(time.Now().Add(-time.Minute)),
+				IssuedAt:  jwt.NewNumericDate(time.Now().Add(-30 * 24 * time.Hour)),
+			},
+		}
+		signedToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(jwtSecret)
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/user.refresh", nil)
+		req.Header.Set("Authorization", "Bearer "+signedToken)
+		rec := httptest.NewRecorder()
+
+		mux.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	})
+}
+
 func TestUserHandler_RootSignIn(t *testing.T) {
 	handler, mockUserSvc, _, _ := setupUserHandlerTest(t)
 
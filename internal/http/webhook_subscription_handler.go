@@ -49,6 +49,7 @@ func (h *WebhookSubscriptionHandler) RegisterRoutes(mux *http.ServeMux) {
 	mux.Handle("/api/webhookSubscriptions.deliveries", requireAuth(http.HandlerFunc(h.handleGetDeliveries)))
 	mux.Handle("/api/webhookSubscriptions.test", requireAuth(http.HandlerFunc(h.handleTest)))
 	mux.Handle("/api/webhookSubscriptions.eventTypes", requireAuth(http.HandlerFunc(h.handleGetEventTypes)))
+	mux.Handle("/api/webhookSubscriptions.replayContactActivity", requireAuth(http.HandlerFunc(h.handleReplayContactActivity)))
 }
 
 // handleCreate handles POST /api/webhookSubscriptions.create
@@ -301,6 +302,49 @@ func (h *WebhookSubscriptionHandler) handleRegenerateSecret(w http.ResponseWrite
 	})
 }
 
+// handleReplayContactActivity handles POST /api/webhookSubscriptions.replayContactActivity
+func (h *WebhookSubscriptionHandler) handleReplayContactActivity(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req struct {
+		WorkspaceID string `json:"workspace_id"`
+		ID          string `json:"id"`
+		Checkpoint  string `json:"checkpoint"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteJSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.WorkspaceID == "" {
+		WriteJSONError(w, "workspace_id is required", http.StatusBadRequest)
+		return
+	}
+	if req.ID == "" {
+		WriteJSONError(w, "id is required", http.StatusBadRequest)
+		return
+	}
+	if _, _, err := domain.ParseContactActivityCheckpoint(req.Checkpoint); err != nil {
+		WriteJSONError(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	sub, err := h.service.ReplayContactActivity(r.Context(), req.WorkspaceID, req.ID, req.Checkpoint)
+	if err != nil {
+		h.logger.WithField("error", err.Error()).Error("Failed to replay contact activity")
+		WriteJSONError(w, "Failed to replay contact activity", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"subscription": sub,
+	})
+}
+
 // handleGetDeliveries handles GET /api/webhookSubscriptions.deliveries
 func (h *WebhookSubscriptionHandler) handleGetDeliveries(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
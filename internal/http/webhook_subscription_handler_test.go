@@ -719,3 +719,78 @@ func TestWebhookSubscriptionHandler_HandleGetEventTypes_MethodNotAllowed(t *test
 	json.NewDecoder(rr.Body).Decode(&response)
 	assert.Equal(t, "Method not allowed", response["error"])
 }
+
+func TestWebhookSubscriptionHandler_HandleReplayContactActivity_ValidationErrors(t *testing.T) {
+	testCases := []struct {
+		name           string
+		method         string
+		reqBody        interface{}
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name:           "Method Not Allowed",
+			method:         http.MethodGet,
+			reqBody:        map[string]interface{}{"workspace_id": "ws123", "id": "sub123"},
+			expectedStatus: http.StatusMethodNotAllowed,
+			expectedError:  "Method not allowed",
+		},
+		{
+			name:           "Invalid JSON",
+			method:         http.MethodPost,
+			reqBody:        "invalid json",
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "Invalid request body",
+		},
+		{
+			name:           "Missing Workspace ID",
+			method:         http.MethodPost,
+			reqBody:        map[string]interface{}{"id": "sub123", "checkpoint": "00000000000000000001-"},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "workspace_id is required",
+		},
+		{
+			name:           "Missing ID",
+			method:         http.MethodPost,
+			reqBody:        map[string]interface{}{"workspace_id": "ws123", "checkpoint": "00000000000000000001-"},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "id is required",
+		},
+		{
+			name:           "Invalid Checkpoint",
+			method:         http.MethodPost,
+			reqBody:        map[string]interface{}{"workspace_id": "ws123", "id": "sub123", "checkpoint": "yesterday"},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  "invalid checkpoint: yesterday",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := &WebhookSubscriptionHandler{
+				service:      nil,
+				worker:       nil,
+				logger:       &mockLogger{},
+				getJWTSecret: func() ([]byte, error) { return []byte("test"), nil },
+			}
+
+			var reqBody bytes.Buffer
+			if str, ok := tc.reqBody.(string); ok {
+				reqBody = *bytes.NewBufferString(str)
+			} else {
+				json.NewEncoder(&reqBody).Encode(tc.reqBody)
+			}
+
+			req := httptest.NewRequest(tc.method, "/api/webhookSubscriptions.replayContactActivity", &reqBody)
+			rr := httptest.NewRecorder()
+
+			handler.handleReplayContactActivity(rr, req)
+
+			assert.Equal(t, tc.expectedStatus, rr.Code)
+
+			var response map[string]string
+			json.NewDecoder(rr.Body).Decode(&response)
+			assert.Equal(t, tc.expectedError, response["error"])
+		})
+	}
+}
@@ -57,6 +57,7 @@ func (h *WorkspaceHandler) RegisterRoutes(mux *http.ServeMux) {
 	mux.Handle("/api/workspaces.removeMember", requireAuth(http.HandlerFunc(h.handleRemoveMember)))
 	mux.Handle("/api/workspaces.deleteInvitation", requireAuth(http.HandlerFunc(h.handleDeleteInvitation)))
 	mux.Handle("/api/workspaces.setUserPermissions", requireAuth(http.HandlerFunc(h.handleSetUserPermissions)))
+	mux.Handle("/api/workspaces.clearSendingBlock", requireAuth(http.HandlerFunc(h.handleClearSendingBlock)))
 
 	// Public invitation routes (no authentication required)
 	mux.Handle("/api/workspaces.verifyInvitationToken", http.HandlerFunc(h.handleVerifyInvitationToken))
@@ -464,6 +465,50 @@ func (h *WorkspaceHandler) handleRemoveMember(w http.ResponseWriter, r *http.Req
 	})
 }
 
+// ClearSendingBlockRequest defines the request structure for clearing a workspace sending block
+type ClearSendingBlockRequest struct {
+	WorkspaceID string `json:"workspace_id"`
+}
+
+// handleClearSendingBlock lifts the sending block set after a complaint spike
+func (h *WorkspaceHandler) handleClearSendingBlock(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		WriteJSONError(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req ClearSendingBlockRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		WriteJSONError(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if req.WorkspaceID == "" {
+		WriteJSONError(w, "Missing workspace_id", http.StatusBadRequest)
+		return
+	}
+
+	workspace, err := h.workspaceService.ClearSendingBlock(r.Context(), req.WorkspaceID)
+	if err != nil {
+		if _, ok := err.(*domain.ErrUnauthorized); ok {
+			WriteJSONError(w, err.Error(), http.StatusForbidden)
+			return
+		}
+		var workspaceNotFoundErr *domain.ErrWorkspaceNotFound
+		if errors.As(err, &workspaceNotFoundErr) {
+			WriteJSONError(w, "Workspace not found", http.StatusNotFound)
+			return
+		}
+		h.logger.WithField("workspace_id", req.WorkspaceID).WithField("error", err.Error()).Error("Failed to clear workspace sending block")
+		WriteJSONError(w, "Failed to clear workspace sending block", http.StatusInternalServerError)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"workspace": workspace,
+	})
+}
+
 // handleCreateIntegration handles the request to create a new integration
 func (h *WorkspaceHandler) handleCreateIntegration(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
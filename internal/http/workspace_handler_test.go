@@ -1356,6 +1356,60 @@ func TestWorkspaceHandler_HandleRemoveMember(t *testing.T) {
 	assert.Equal(t, "Member removed successfully", response["message"])
 }
 
+func TestWorkspaceHandler_HandleClearSendingBlock(t *testing.T) {
+	t.Run("clears the block", func(t *testing.T) {
+		_, workspaceSvc, mux, secretKey, _ := setupTest(t)
+
+		workspaceSvc.EXPECT().
+			ClearSendingBlock(gomock.Any(), "workspace-123").
+			Return(&domain.Workspace{ID: "workspace-123", Name: "Workspace"}, nil)
+
+		body, err := json.Marshal(ClearSendingBlockRequest{WorkspaceID: "workspace-123"})
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/workspaces.clearSendingBlock", bytes.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+createTestToken(t, secretKey, "test-user"))
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response map[string]domain.Workspace
+		require.NoError(t, json.NewDecoder(w.Body).Decode(&response))
+		assert.Equal(t, "workspace-123", response["workspace"].ID)
+		assert.Nil(t, response["workspace"].Settings.SendingBlock)
+	})
+
+	t.Run("not an owner", func(t *testing.T) {
+		_, workspaceSvc, mux, secretKey, _ := setupTest(t)
+
+		workspaceSvc.EXPECT().
+			ClearSendingBlock(gomock.Any(), "workspace-123").
+			Return(nil, &domain.ErrUnauthorized{Message: "user is not an owner of the workspace"})
+
+		body, err := json.Marshal(ClearSendingBlockRequest{WorkspaceID: "workspace-123"})
+		require.NoError(t, err)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/workspaces.clearSendingBlock", bytes.NewReader(body))
+		req.Header.Set("Authorization", "Bearer "+createTestToken(t, secretKey, "test-user"))
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("missing workspace_id", func(t *testing.T) {
+		_, _, mux, secretKey, _ := setupTest(t)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/workspaces.clearSendingBlock", bytes.NewReader([]byte(`{}`)))
+		req.Header.Set("Authorization", "Bearer "+createTestToken(t, secretKey, "test-user"))
+		w := httptest.NewRecorder()
+		mux.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
 func TestWorkspaceHandler_HandleRemoveMember_MethodNotAllowed(t *testing.T) {
 	handler, _, _, secretKey, _ := setupTest(t)
 
@@ -5,8 +5,10 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/Notifuse/notifuse/config"
 	"github.com/Notifuse/notifuse/internal/domain"
@@ -223,55 +225,116 @@ func (m *Manager) executeMigration(ctx context.Context, cfg *config.Config, db *
 			return fmt.Errorf("failed to get workspaces: %w", err)
 		}
 
-		for _, workspace := range workspaces {
-			m.logger.WithField("workspace", workspace.ID).
-				WithField("version", fmt.Sprintf("%.0f", version)).
-				Debug("Executing workspace migration")
+		if err := m.migrateWorkspaces(ctx, cfg, migration, workspaces); err != nil {
+			return err
+		}
+	}
 
-			// Connect to the specific workspace database
-			workspaceDB, err := m.connector.connectToWorkspace(&cfg.Database, workspace.ID)
-			if err != nil {
-				return fmt.Errorf("failed to connect to workspace database %s: %w", workspace.ID, err)
-			}
+	// Commit transaction
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit migration transaction: %w", err)
+	}
 
-			// Start transaction for the workspace database
-			workspaceTx, err := workspaceDB.BeginTx(ctx, nil)
-			if err != nil {
-				_ = workspaceDB.Close()
-				return fmt.Errorf("failed to start workspace transaction for %s: %w", workspace.ID, err)
-			}
+	m.logger.WithField("version", fmt.Sprintf("%.0f", version)).Info("Migration completed successfully")
+	return nil
+}
 
-			// Execute the workspace migration
-			migrationErr := migration.UpdateWorkspace(ctx, cfg, &workspace, workspaceTx)
+// migrateWorkspaces runs the workspace part of a migration on every workspace database, with at most
+// cfg.Database.MigrationConcurrency workspaces in parallel. A failing workspace does not stop the
+// others; the failures are reported together once every workspace has been attempted so the
+// migration is retried on next startup.
+func (m *Manager) migrateWorkspaces(ctx context.Context, cfg *config.Config, migration MajorMigrationInterface, workspaces []domain.Workspace) error {
+	version := fmt.Sprintf("%.0f", migration.GetMajorVersion())
 
-			if migrationErr != nil {
-				// Rollback workspace transaction and close connection
-				_ = workspaceTx.Rollback()
-				_ = workspaceDB.Close()
-				return fmt.Errorf("workspace migration failed for workspace %s: %w", workspace.ID, migrationErr)
-			}
+	concurrency := cfg.Database.MigrationConcurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
 
-			// Commit workspace transaction
-			if err := workspaceTx.Commit(); err != nil {
-				_ = workspaceDB.Close()
-				return fmt.Errorf("failed to commit workspace migration for %s: %w", workspace.ID, err)
-			}
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		failed   []string
+		errs     []error
+		migrated int
+	)
+	slots := make(chan struct{}, concurrency)
+
+	for i := range workspaces {
+		workspace := workspaces[i]
+
+		wg.Add(1)
+		slots <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-slots }()
 
-			// Close workspace database connection
-			_ = workspaceDB.Close()
+			err := m.migrateWorkspace(ctx, cfg, migration, &workspace)
 
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				m.logger.WithField("workspace", workspace.ID).
+					WithField("version", version).
+					WithField("error", err.Error()).
+					Error("Workspace migration failed")
+				failed = append(failed, workspace.ID)
+				errs = append(errs, err)
+				return
+			}
+			migrated++
 			m.logger.WithField("workspace", workspace.ID).
-				WithField("version", fmt.Sprintf("%.0f", version)).
+				WithField("version", version).
 				Debug("Workspace migration completed successfully")
-		}
+		}()
 	}
+	wg.Wait()
 
-	// Commit transaction
-	if err := tx.Commit(); err != nil {
-		return fmt.Errorf("failed to commit migration transaction: %w", err)
+	sort.Strings(failed)
+	m.logger.WithField("version", version).
+		WithField("migrated", migrated).
+		WithField("failed", failed).
+		Info("Workspace migrations finished")
+
+	if len(failed) > 0 {
+		return fmt.Errorf("workspace migration failed for %d of %d workspaces (%s): %w",
+			len(failed), len(workspaces), strings.Join(failed, ", "), errors.Join(errs...))
+	}
+	return nil
+}
+
+// migrateWorkspace runs the workspace part of a migration in a transaction on one workspace database
+func (m *Manager) migrateWorkspace(ctx context.Context, cfg *config.Config, migration MajorMigrationInterface, workspace *domain.Workspace) error {
+	m.logger.WithField("workspace", workspace.ID).
+		WithField("version", fmt.Sprintf("%.0f", migration.GetMajorVersion())).
+		Debug("Executing workspace migration")
+
+	// Connect to the specific workspace database
+	workspaceDB, err := m.connector.connectToWorkspace(&cfg.Database, workspace.ID)
+	if err != nil {
+		return fmt.Errorf("failed to connect to workspace database %s: %w", workspace.ID, err)
+	}
+	defer func() {
+		_ = workspaceDB.Close()
+	}()
+
+	// Start transaction for the workspace database
+	workspaceTx, err := workspaceDB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to start workspace transaction for %s: %w", workspace.ID, err)
+	}
+
+	// Execute the workspace migration
+	if err := migration.UpdateWorkspace(ctx, cfg, workspace, workspaceTx); err != nil {
+		_ = workspaceTx.Rollback()
+		return fmt.Errorf("workspace migration failed for workspace %s: %w", workspace.ID, err)
+	}
+
+	// Commit workspace transaction
+	if err := workspaceTx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit workspace migration for %s: %w", workspace.ID, err)
 	}
 
-	m.logger.WithField("version", fmt.Sprintf("%.0f", version)).Info("Migration completed successfully")
 	return nil
 }
 
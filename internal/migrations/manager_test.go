@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -698,3 +699,132 @@ func TestManager_ExecuteMigration_AdditionalCoverage(t *testing.T) {
 		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 }
+
+// workspaceDBConnector returns a dedicated database per workspace
+type workspaceDBConnector struct {
+	dbs map[string]*sql.DB
+}
+
+func (c *workspaceDBConnector) connectToWorkspace(cfg *config.DatabaseConfig, workspaceID string) (*sql.DB, error) {
+	db, ok := c.dbs[workspaceID]
+	if !ok {
+		return nil, errors.New("workspace database not found")
+	}
+	return db, nil
+}
+
+// concurrentWorkspaceMigration records how many workspaces it migrates at the same time
+// and fails for the configured workspaces
+type concurrentWorkspaceMigration struct {
+	*mockMigration
+	limit     int32
+	failFor   map[string]bool
+	active    int32
+	maxActive int32
+}
+
+func (m *concurrentWorkspaceMigration) UpdateWorkspace(ctx context.Context, config *config.Config, workspace *domain.Workspace, db DBExecutor) error {
+	active := atomic.AddInt32(&m.active, 1)
+	defer atomic.AddInt32(&m.active, -1)
+	for {
+		current := atomic.LoadInt32(&m.maxActive)
+		if active <= current || atomic.CompareAndSwapInt32(&m.maxActive, current, active) {
+			break
+		}
+	}
+
+	// Hold the slot until the concurrency limit is reached so parallelism is observable
+	deadline := time.Now().Add(time.Second)
+	for atomic.LoadInt32(&m.maxActive) < m.limit && time.Now().Before(deadline) {
+		time.Sleep(time.Millisecond)
+	}
+
+	if m.failFor[workspace.ID] {
+		return errors.New("workspace update error")
+	}
+	return nil
+}
+
+func TestManager_executeMigration_ParallelWorkspaces(t *testing.T) {
+	setup := func(t *testing.T, workspaceIDs []string, failFor map[string]bool) (*sql.DB, sqlmock.Sqlmock, map[string]sqlmock.Sqlmock, *workspaceDBConnector) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		t.Cleanup(func() { _ = db.Close() })
+
+		now := time.Now()
+		rows := sqlmock.NewRows([]string{"id", "name", "settings", "integrations", "created_at", "updated_at"})
+		connector := &workspaceDBConnector{dbs: map[string]*sql.DB{}}
+		workspaceMocks := map[string]sqlmock.Sqlmock{}
+		for _, id := range workspaceIDs {
+			rows.AddRow(id, id, []byte("{}"), []byte("[]"), now, now)
+
+			workspaceDB, workspaceMock, err := sqlmock.New()
+			require.NoError(t, err)
+			workspaceMock.ExpectBegin()
+			if failFor[id] {
+				workspaceMock.ExpectRollback()
+			} else {
+				workspaceMock.ExpectCommit()
+			}
+			workspaceMock.ExpectClose()
+			connector.dbs[id] = workspaceDB
+			workspaceMocks[id] = workspaceMock
+		}
+
+		mock.ExpectBegin()
+		mock.ExpectQuery("SELECT id, name, settings, integrations, created_at, updated_at FROM workspaces").
+			WillReturnRows(rows)
+		return db, mock, workspaceMocks, connector
+	}
+
+	workspaceIDs := []string{"ws1", "ws2", "ws3", "ws4", "ws5", "ws6"}
+
+	t.Run("migrates workspaces concurrently within the limit", func(t *testing.T) {
+		db, mock, workspaceMocks, connector := setup(t, workspaceIDs, nil)
+		mock.ExpectCommit()
+
+		manager := NewManager(&mockLogger{})
+		manager.connector = connector
+		cfg := &config.Config{Database: config.DatabaseConfig{MigrationConcurrency: 3}}
+		migration := &concurrentWorkspaceMigration{
+			mockMigration: &mockMigration{version: 3.0, hasWorkspaceUpdate: true},
+			limit:         3,
+		}
+
+		err := manager.executeMigration(context.Background(), cfg, db, migration)
+
+		require.NoError(t, err)
+		assert.Equal(t, int32(3), atomic.LoadInt32(&migration.maxActive))
+		assert.NoError(t, mock.ExpectationsWereMet())
+		for id, workspaceMock := range workspaceMocks {
+			assert.NoError(t, workspaceMock.ExpectationsWereMet(), id)
+		}
+	})
+
+	t.Run("a failing workspace does not block the others", func(t *testing.T) {
+		failFor := map[string]bool{"ws2": true}
+		db, mock, workspaceMocks, connector := setup(t, workspaceIDs, failFor)
+		// The system transaction is rolled back so the version is not bumped and the migration is retried
+		mock.ExpectRollback()
+
+		manager := NewManager(&mockLogger{})
+		manager.connector = connector
+		cfg := &config.Config{Database: config.DatabaseConfig{MigrationConcurrency: 2}}
+		migration := &concurrentWorkspaceMigration{
+			mockMigration: &mockMigration{version: 3.0, hasWorkspaceUpdate: true},
+			limit:         2,
+			failFor:       failFor,
+		}
+
+		err := manager.executeMigration(context.Background(), cfg, db, migration)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "workspace migration failed for 1 of 6 workspaces (ws2)")
+		assert.Contains(t, err.Error(), "workspace update error")
+		assert.NoError(t, mock.ExpectationsWereMet())
+		// Every other workspace was still migrated and committed
+		for id, workspaceMock := range workspaceMocks {
+			assert.NoError(t, workspaceMock.ExpectationsWereMet(), id)
+		}
+	})
+}
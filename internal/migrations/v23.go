@@ -0,0 +1,319 @@
+package migrations
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Notifuse/notifuse/config"
+	"github.com/Notifuse/notifuse/internal/domain"
+)
+
+// V23Migration adds the inbound_webhook_payloads table storing raw webhook bodies for reprocessing,
+// the contact_segment_evaluations table deduplicating segment membership transitions,
+// the broadcasts skipped_count column for recipients skipped at the send cutoff,
+// the short_links table mapping short codes to click-tracked URLs,
+// the contact_timeline insertion order index read by contact activity webhooks,
+// the broadcasts tags column with its index for filtering broadcasts by tag,
+// the broadcasts dry_run column for broadcasts recorded without being delivered,
+// the provider_webhook_health table tracking the webhooks received per integration,
+// the broadcasts plain_text_only column for broadcasts sent without an HTML part,
+// the broadcast_audience_recipients table holding the uploaded CSV audiences of broadcasts,
+// the templates sms column holding the body of SMS templates,
+// the broadcasts channel_type column for broadcasts sent by SMS,
+// the message_history idempotency_key column with its unique index deduplicating transactional sends,
+// the message_history bounce_category column holding the provider independent class of bounces,
+// the email_suppressions table excluding hard bounced and complaining emails from broadcasts,
+// the message_status_retries table queueing the webhook status updates that failed to apply,
+// the broadcasts seed_test column holding the seed addresses of broadcasts and their outcome,
+// the system revoked_tokens table denying user tokens revoked before their expiration,
+// and the system api_keys table holding the hashed scoped API keys of workspaces
+type V23Migration struct{}
+
+func (m *V23Migration) GetMajorVersion() float64 {
+	return 23.0
+}
+
+func (m *V23Migration) HasSystemUpdate() bool {
+	return true
+}
+
+func (m *V23Migration) HasWorkspaceUpdate() bool {
+	return true
+}
+
+func (m *V23Migration) ShouldRestartServer() bool {
+	return false
+}
+
+func (m *V23Migration) UpdateSystem(ctx context.Context, cfg *config.Config, db DBExecutor) error {
+	_, err := db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS revoked_tokens (
+			token_id VARCHAR(64) PRIMARY KEY,
+			expires_at TIMESTAMPTZ NOT NULL,
+			revoked_at TIMESTAMPTZ NOT NULL
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create revoked_tokens table: %w", err)
+	}
+
+	// Index for purging the entries of expired tokens
+	_, err = db.ExecContext(ctx, `
+		CREATE INDEX IF NOT EXISTS idx_revoked_tokens_expires_at
+		ON revoked_tokens(expires_at)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create idx_revoked_tokens_expires_at index: %w", err)
+	}
+
+	_, err = db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS api_keys (
+			id UUID PRIMARY KEY,
+			workspace_id VARCHAR(20) NOT NULL,
+			name VARCHAR(255) NOT NULL,
+			key_prefix VARCHAR(16) NOT NULL,
+			key_hash VARCHAR(64) NOT NULL UNIQUE,
+			scopes JSONB NOT NULL DEFAULT '[]'::jsonb,
+			user_id UUID NOT NULL,
+			created_by UUID NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL,
+			revoked_at TIMESTAMPTZ
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create api_keys table: %w", err)
+	}
+
+	_, err = db.ExecContext(ctx, `
+		CREATE INDEX IF NOT EXISTS idx_api_keys_workspace_id
+		ON api_keys(workspace_id)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create idx_api_keys_workspace_id index: %w", err)
+	}
+
+	return nil
+}
+
+func (m *V23Migration) UpdateWorkspace(ctx context.Context, cfg *config.Config, workspace *domain.Workspace, db DBExecutor) error {
+	_, err := db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS inbound_webhook_payloads (
+			id UUID PRIMARY KEY,
+			integration_id VARCHAR(255) NOT NULL,
+			payload TEXT NOT NULL,
+			received_at TIMESTAMPTZ NOT NULL,
+			processed_at TIMESTAMPTZ,
+			error TEXT
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create inbound_webhook_payloads table: %w", err)
+	}
+
+	// Index for reprocessing a time range and for retention cleanup
+	_, err = db.ExecContext(ctx, `
+		CREATE INDEX IF NOT EXISTS idx_inbound_webhook_payloads_received_at
+		ON inbound_webhook_payloads(received_at, id)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create idx_inbound_webhook_payloads_received_at index: %w", err)
+	}
+
+	// Latest membership evaluation per segment and contact, so that concurrent
+	// recompute and delta updates apply (and emit events for) each transition once
+	_, err = db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS contact_segment_evaluations (
+			segment_id VARCHAR(32) NOT NULL,
+			email VARCHAR(255) NOT NULL,
+			version INTEGER NOT NULL,
+			is_member BOOLEAN NOT NULL,
+			evaluated_at TIMESTAMPTZ NOT NULL,
+			PRIMARY KEY (segment_id, email)
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create contact_segment_evaluations table: %w", err)
+	}
+
+	_, err = db.ExecContext(ctx, `
+		ALTER TABLE broadcasts
+		ADD COLUMN IF NOT EXISTS skipped_count INTEGER DEFAULT 0
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to add broadcast skipped_count column: %w", err)
+	}
+
+	_, err = db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS short_links (
+			code VARCHAR(32) PRIMARY KEY,
+			message_id VARCHAR(255) NOT NULL,
+			url TEXT NOT NULL,
+			sent_at TIMESTAMPTZ NOT NULL,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create short_links table: %w", err)
+	}
+
+	_, err = db.ExecContext(ctx, `
+		CREATE INDEX IF NOT EXISTS idx_contact_timeline_db_created_at
+		ON contact_timeline(db_created_at, id)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create idx_contact_timeline_db_created_at index: %w", err)
+	}
+
+	_, err = db.ExecContext(ctx, `
+		ALTER TABLE broadcasts
+		ADD COLUMN IF NOT EXISTS tags TEXT[]
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to add broadcast tags column: %w", err)
+	}
+
+	_, err = db.ExecContext(ctx, `
+		CREATE INDEX IF NOT EXISTS idx_broadcasts_tags
+		ON broadcasts USING GIN (tags)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create idx_broadcasts_tags index: %w", err)
+	}
+
+	_, err = db.ExecContext(ctx, `
+		ALTER TABLE broadcasts
+		ADD COLUMN IF NOT EXISTS dry_run BOOLEAN NOT NULL DEFAULT FALSE
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to add broadcast dry_run column: %w", err)
+	}
+
+	_, err = db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS provider_webhook_health (
+			integration_id VARCHAR(255) PRIMARY KEY,
+			last_sent_at TIMESTAMPTZ,
+			last_event_at TIMESTAMPTZ,
+			pending_since TIMESTAMPTZ,
+			alerted_at TIMESTAMPTZ,
+			updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create provider_webhook_health table: %w", err)
+	}
+
+	_, err = db.ExecContext(ctx, `
+		ALTER TABLE broadcasts
+		ADD COLUMN IF NOT EXISTS plain_text_only BOOLEAN NOT NULL DEFAULT FALSE
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to add broadcast plain_text_only column: %w", err)
+	}
+
+	_, err = db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS broadcast_audience_recipients (
+			broadcast_id VARCHAR(255) NOT NULL,
+			email VARCHAR(255) NOT NULL,
+			data JSONB,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+			PRIMARY KEY (broadcast_id, email)
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create broadcast_audience_recipients table: %w", err)
+	}
+
+	_, err = db.ExecContext(ctx, `
+		ALTER TABLE templates
+		ADD COLUMN IF NOT EXISTS sms JSONB
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to add templates sms column: %w", err)
+	}
+
+	_, err = db.ExecContext(ctx, `
+		ALTER TABLE broadcasts
+		ADD COLUMN IF NOT EXISTS channel_type VARCHAR(20) NOT NULL DEFAULT 'email'
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to add broadcast channel_type column: %w", err)
+	}
+
+	_, err = db.ExecContext(ctx, `
+		ALTER TABLE message_history
+		ADD COLUMN IF NOT EXISTS idempotency_key VARCHAR(255)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to add message_history idempotency_key column: %w", err)
+	}
+
+	// Unique so that concurrent sends with the same key insert a single message
+	_, err = db.ExecContext(ctx, `
+		CREATE UNIQUE INDEX IF NOT EXISTS idx_message_history_idempotency_key
+		ON message_history(idempotency_key) WHERE idempotency_key IS NOT NULL
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create idx_message_history_idempotency_key index: %w", err)
+	}
+
+	_, err = db.ExecContext(ctx, `
+		ALTER TABLE message_history
+		ADD COLUMN IF NOT EXISTS bounce_category VARCHAR(20)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to add message_history bounce_category column: %w", err)
+	}
+
+	_, err = db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS email_suppressions (
+			email VARCHAR(255) PRIMARY KEY,
+			reason VARCHAR(20) NOT NULL,
+			message_id VARCHAR(255),
+			created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create email_suppressions table: %w", err)
+	}
+
+	// Unique per message, event and timestamp so that a webhook delivered twice is retried once
+	_, err = db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS message_status_retries (
+			external_id VARCHAR(255) NOT NULL,
+			event VARCHAR(20) NOT NULL,
+			event_timestamp TIMESTAMPTZ NOT NULL,
+			status_info VARCHAR(255),
+			bounce_category VARCHAR(20),
+			attempts INTEGER NOT NULL DEFAULT 0,
+			next_attempt_at TIMESTAMPTZ NOT NULL,
+			last_error TEXT,
+			created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+			PRIMARY KEY (external_id, event, event_timestamp)
+		)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create message_status_retries table: %w", err)
+	}
+
+	_, err = db.ExecContext(ctx, `
+		CREATE INDEX IF NOT EXISTS idx_message_status_retries_next_attempt_at
+		ON message_status_retries(next_attempt_at)
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to create idx_message_status_retries_next_attempt_at index: %w", err)
+	}
+
+	_, err = db.ExecContext(ctx, `
+		ALTER TABLE broadcasts
+		ADD COLUMN IF NOT EXISTS seed_test JSONB
+	`)
+	if err != nil {
+		return fmt.Errorf("failed to add broadcast seed_test column: %w", err)
+	}
+
+	return nil
+}
+
+func init() {
+	Register(&V23Migration{})
+}
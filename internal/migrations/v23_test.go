@@ -0,0 +1,658 @@
+package migrations
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Notifuse/notifuse/config"
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestV23Migration_Metadata(t *testing.T) {
+	migration := &V23Migration{}
+	assert.Equal(t, 23.0, migration.GetMajorVersion())
+	assert.True(t, migration.HasSystemUpdate())
+	assert.True(t, migration.HasWorkspaceUpdate())
+	assert.False(t, migration.ShouldRestartServer())
+}
+
+func TestV23Migration_UpdateSystem(t *testing.T) {
+	migration := &V23Migration{}
+	ctx := context.Background()
+	cfg := &config.Config{}
+
+	t.Run("Success - Creates revoked_tokens table", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS revoked_tokens").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE INDEX IF NOT EXISTS idx_revoked_tokens_expires_at").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS api_keys").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE INDEX IF NOT EXISTS idx_api_keys_workspace_id").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err = migration.UpdateSystem(ctx, cfg, db)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Error - revoked_tokens table creation fails", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS revoked_tokens").
+			WillReturnError(errors.New("db error"))
+
+		err = migration.UpdateSystem(ctx, cfg, db)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to create revoked_tokens table")
+	})
+
+	t.Run("Error - api_keys table creation fails", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS revoked_tokens").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE INDEX IF NOT EXISTS idx_revoked_tokens_expires_at").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS api_keys").
+			WillReturnError(errors.New("db error"))
+
+		err = migration.UpdateSystem(ctx, cfg, db)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to create api_keys table")
+	})
+}
+
+func TestV23Migration_UpdateWorkspace(t *testing.T) {
+	migration := &V23Migration{}
+	ctx := context.Background()
+	cfg := &config.Config{}
+	workspace := &domain.Workspace{ID: "test-workspace"}
+
+	t.Run("Success - Creates tables and adds broadcast skipped_count", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS inbound_webhook_payloads").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE INDEX IF NOT EXISTS idx_inbound_webhook_payloads_received_at").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS contact_segment_evaluations").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE broadcasts").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS short_links").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE INDEX IF NOT EXISTS idx_contact_timeline_db_created_at").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE broadcasts\\s+ADD COLUMN IF NOT EXISTS tags").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE INDEX IF NOT EXISTS idx_broadcasts_tags").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE broadcasts\\s+ADD COLUMN IF NOT EXISTS dry_run").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS provider_webhook_health").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE broadcasts\\s+ADD COLUMN IF NOT EXISTS plain_text_only").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS broadcast_audience_recipients").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE templates\\s+ADD COLUMN IF NOT EXISTS sms").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE broadcasts\\s+ADD COLUMN IF NOT EXISTS channel_type").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE message_history\\s+ADD COLUMN IF NOT EXISTS idempotency_key").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE UNIQUE INDEX IF NOT EXISTS idx_message_history_idempotency_key").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE message_history\\s+ADD COLUMN IF NOT EXISTS bounce_category").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS email_suppressions").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS message_status_retries").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE INDEX IF NOT EXISTS idx_message_status_retries_next_attempt_at").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE broadcasts\\s+ADD COLUMN IF NOT EXISTS seed_test").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err = migration.UpdateWorkspace(ctx, cfg, workspace, db)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Error - Table creation fails", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS inbound_webhook_payloads").
+			WillReturnError(errors.New("table creation failed"))
+
+		err = migration.UpdateWorkspace(ctx, cfg, workspace, db)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to create inbound_webhook_payloads table")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Error - Evaluations table creation fails", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS inbound_webhook_payloads").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE INDEX IF NOT EXISTS idx_inbound_webhook_payloads_received_at").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS contact_segment_evaluations").
+			WillReturnError(errors.New("table creation failed"))
+
+		err = migration.UpdateWorkspace(ctx, cfg, workspace, db)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to create contact_segment_evaluations table")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Error - Adding skipped_count column fails", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS inbound_webhook_payloads").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE INDEX IF NOT EXISTS idx_inbound_webhook_payloads_received_at").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS contact_segment_evaluations").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE broadcasts").
+			WillReturnError(errors.New("alter failed"))
+
+		err = migration.UpdateWorkspace(ctx, cfg, workspace, db)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to add broadcast skipped_count column")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Error - Short links table creation fails", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS inbound_webhook_payloads").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE INDEX IF NOT EXISTS idx_inbound_webhook_payloads_received_at").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS contact_segment_evaluations").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE broadcasts").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS short_links").
+			WillReturnError(errors.New("table creation failed"))
+
+		err = migration.UpdateWorkspace(ctx, cfg, workspace, db)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to create short_links table")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Error - Contact timeline index creation fails", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS inbound_webhook_payloads").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE INDEX IF NOT EXISTS idx_inbound_webhook_payloads_received_at").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS contact_segment_evaluations").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE broadcasts").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS short_links").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE INDEX IF NOT EXISTS idx_contact_timeline_db_created_at").
+			WillReturnError(errors.New("index creation failed"))
+
+		err = migration.UpdateWorkspace(ctx, cfg, workspace, db)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to create idx_contact_timeline_db_created_at index")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Error - Broadcast tags column fails", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS inbound_webhook_payloads").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE INDEX IF NOT EXISTS idx_inbound_webhook_payloads_received_at").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS contact_segment_evaluations").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE broadcasts").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS short_links").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE INDEX IF NOT EXISTS idx_contact_timeline_db_created_at").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE broadcasts\\s+ADD COLUMN IF NOT EXISTS tags").
+			WillReturnError(errors.New("alter failed"))
+
+		err = migration.UpdateWorkspace(ctx, cfg, workspace, db)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to add broadcast tags column")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Error - Broadcast dry_run column fails", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS inbound_webhook_payloads").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE INDEX IF NOT EXISTS idx_inbound_webhook_payloads_received_at").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS contact_segment_evaluations").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE broadcasts").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS short_links").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE INDEX IF NOT EXISTS idx_contact_timeline_db_created_at").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE broadcasts\\s+ADD COLUMN IF NOT EXISTS tags").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE INDEX IF NOT EXISTS idx_broadcasts_tags").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE broadcasts\\s+ADD COLUMN IF NOT EXISTS dry_run").
+			WillReturnError(errors.New("alter failed"))
+
+		err = migration.UpdateWorkspace(ctx, cfg, workspace, db)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to add broadcast dry_run column")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Error - Provider webhook health table creation fails", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS inbound_webhook_payloads").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE INDEX IF NOT EXISTS idx_inbound_webhook_payloads_received_at").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS contact_segment_evaluations").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE broadcasts").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS short_links").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE INDEX IF NOT EXISTS idx_contact_timeline_db_created_at").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE broadcasts\\s+ADD COLUMN IF NOT EXISTS tags").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE INDEX IF NOT EXISTS idx_broadcasts_tags").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE broadcasts\\s+ADD COLUMN IF NOT EXISTS dry_run").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS provider_webhook_health").
+			WillReturnError(errors.New("create failed"))
+
+		err = migration.UpdateWorkspace(ctx, cfg, workspace, db)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to create provider_webhook_health table")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Error - Broadcast plain_text_only column fails", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS inbound_webhook_payloads").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE INDEX IF NOT EXISTS idx_inbound_webhook_payloads_received_at").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS contact_segment_evaluations").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE broadcasts").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS short_links").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE INDEX IF NOT EXISTS idx_contact_timeline_db_created_at").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE broadcasts\\s+ADD COLUMN IF NOT EXISTS tags").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE INDEX IF NOT EXISTS idx_broadcasts_tags").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE broadcasts\\s+ADD COLUMN IF NOT EXISTS dry_run").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS provider_webhook_health").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE broadcasts\\s+ADD COLUMN IF NOT EXISTS plain_text_only").
+			WillReturnError(errors.New("alter failed"))
+
+		err = migration.UpdateWorkspace(ctx, cfg, workspace, db)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to add broadcast plain_text_only column")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Error - Broadcast audience recipients table fails", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS inbound_webhook_payloads").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE INDEX IF NOT EXISTS idx_inbound_webhook_payloads_received_at").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS contact_segment_evaluations").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE broadcasts").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS short_links").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE INDEX IF NOT EXISTS idx_contact_timeline_db_created_at").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE broadcasts\\s+ADD COLUMN IF NOT EXISTS tags").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE INDEX IF NOT EXISTS idx_broadcasts_tags").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE broadcasts\\s+ADD COLUMN IF NOT EXISTS dry_run").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS provider_webhook_health").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE broadcasts\\s+ADD COLUMN IF NOT EXISTS plain_text_only").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS broadcast_audience_recipients").
+			WillReturnError(errors.New("table creation failed"))
+
+		err = migration.UpdateWorkspace(ctx, cfg, workspace, db)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to create broadcast_audience_recipients table")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Error - Templates sms column fails", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS inbound_webhook_payloads").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE INDEX IF NOT EXISTS idx_inbound_webhook_payloads_received_at").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS contact_segment_evaluations").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE broadcasts").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS short_links").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE INDEX IF NOT EXISTS idx_contact_timeline_db_created_at").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE broadcasts\\s+ADD COLUMN IF NOT EXISTS tags").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE INDEX IF NOT EXISTS idx_broadcasts_tags").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE broadcasts\\s+ADD COLUMN IF NOT EXISTS dry_run").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS provider_webhook_health").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE broadcasts\\s+ADD COLUMN IF NOT EXISTS plain_text_only").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS broadcast_audience_recipients").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE templates\\s+ADD COLUMN IF NOT EXISTS sms").
+			WillReturnError(errors.New("alter failed"))
+
+		err = migration.UpdateWorkspace(ctx, cfg, workspace, db)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to add templates sms column")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Error - Broadcast channel_type column fails", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS inbound_webhook_payloads").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE INDEX IF NOT EXISTS idx_inbound_webhook_payloads_received_at").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS contact_segment_evaluations").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE broadcasts").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS short_links").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE INDEX IF NOT EXISTS idx_contact_timeline_db_created_at").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE broadcasts\\s+ADD COLUMN IF NOT EXISTS tags").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE INDEX IF NOT EXISTS idx_broadcasts_tags").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE broadcasts\\s+ADD COLUMN IF NOT EXISTS dry_run").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS provider_webhook_health").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE broadcasts\\s+ADD COLUMN IF NOT EXISTS plain_text_only").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS broadcast_audience_recipients").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE templates\\s+ADD COLUMN IF NOT EXISTS sms").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE broadcasts\\s+ADD COLUMN IF NOT EXISTS channel_type").
+			WillReturnError(errors.New("alter failed"))
+
+		err = migration.UpdateWorkspace(ctx, cfg, workspace, db)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to add broadcast channel_type column")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Error - Message history idempotency_key index fails", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS inbound_webhook_payloads").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE INDEX IF NOT EXISTS idx_inbound_webhook_payloads_received_at").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS contact_segment_evaluations").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE broadcasts").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS short_links").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE INDEX IF NOT EXISTS idx_contact_timeline_db_created_at").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE broadcasts\\s+ADD COLUMN IF NOT EXISTS tags").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE INDEX IF NOT EXISTS idx_broadcasts_tags").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE broadcasts\\s+ADD COLUMN IF NOT EXISTS dry_run").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS provider_webhook_health").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE broadcasts\\s+ADD COLUMN IF NOT EXISTS plain_text_only").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS broadcast_audience_recipients").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE templates\\s+ADD COLUMN IF NOT EXISTS sms").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE broadcasts\\s+ADD COLUMN IF NOT EXISTS channel_type").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE message_history\\s+ADD COLUMN IF NOT EXISTS idempotency_key").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE UNIQUE INDEX IF NOT EXISTS idx_message_history_idempotency_key").
+			WillReturnError(errors.New("index failed"))
+
+		err = migration.UpdateWorkspace(ctx, cfg, workspace, db)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to create idx_message_history_idempotency_key index")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Error - Message history bounce_category column fails", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS inbound_webhook_payloads").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE INDEX IF NOT EXISTS idx_inbound_webhook_payloads_received_at").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS contact_segment_evaluations").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE broadcasts").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS short_links").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE INDEX IF NOT EXISTS idx_contact_timeline_db_created_at").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE broadcasts\\s+ADD COLUMN IF NOT EXISTS tags").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE INDEX IF NOT EXISTS idx_broadcasts_tags").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE broadcasts\\s+ADD COLUMN IF NOT EXISTS dry_run").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS provider_webhook_health").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE broadcasts\\s+ADD COLUMN IF NOT EXISTS plain_text_only").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS broadcast_audience_recipients").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE templates\\s+ADD COLUMN IF NOT EXISTS sms").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE broadcasts\\s+ADD COLUMN IF NOT EXISTS channel_type").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE message_history\\s+ADD COLUMN IF NOT EXISTS idempotency_key").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE UNIQUE INDEX IF NOT EXISTS idx_message_history_idempotency_key").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE message_history\\s+ADD COLUMN IF NOT EXISTS bounce_category").
+			WillReturnError(errors.New("alter failed"))
+
+		err = migration.UpdateWorkspace(ctx, cfg, workspace, db)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to add message_history bounce_category column")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Error - Email suppressions table fails", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS inbound_webhook_payloads").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE INDEX IF NOT EXISTS idx_inbound_webhook_payloads_received_at").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS contact_segment_evaluations").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE broadcasts").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS short_links").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE INDEX IF NOT EXISTS idx_contact_timeline_db_created_at").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE broadcasts\\s+ADD COLUMN IF NOT EXISTS tags").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE INDEX IF NOT EXISTS idx_broadcasts_tags").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE broadcasts\\s+ADD COLUMN IF NOT EXISTS dry_run").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS provider_webhook_health").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE broadcasts\\s+ADD COLUMN IF NOT EXISTS plain_text_only").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS broadcast_audience_recipients").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE templates\\s+ADD COLUMN IF NOT EXISTS sms").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE broadcasts\\s+ADD COLUMN IF NOT EXISTS channel_type").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE message_history\\s+ADD COLUMN IF NOT EXISTS idempotency_key").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE UNIQUE INDEX IF NOT EXISTS idx_message_history_idempotency_key").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE message_history\\s+ADD COLUMN IF NOT EXISTS bounce_category").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS email_suppressions").
+			WillReturnError(errors.New("table failed"))
+
+		err = migration.UpdateWorkspace(ctx, cfg, workspace, db)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to create email_suppressions table")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Error - Message status retries table fails", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS inbound_webhook_payloads").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE INDEX IF NOT EXISTS idx_inbound_webhook_payloads_received_at").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS contact_segment_evaluations").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE broadcasts").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS short_links").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE INDEX IF NOT EXISTS idx_contact_timeline_db_created_at").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE broadcasts\\s+ADD COLUMN IF NOT EXISTS tags").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE INDEX IF NOT EXISTS idx_broadcasts_tags").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE broadcasts\\s+ADD COLUMN IF NOT EXISTS dry_run").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS provider_webhook_health").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE broadcasts\\s+ADD COLUMN IF NOT EXISTS plain_text_only").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS broadcast_audience_recipients").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE templates\\s+ADD COLUMN IF NOT EXISTS sms").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE broadcasts\\s+ADD COLUMN IF NOT EXISTS channel_type").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE message_history\\s+ADD COLUMN IF NOT EXISTS idempotency_key").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE UNIQUE INDEX IF NOT EXISTS idx_message_history_idempotency_key").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("ALTER TABLE message_history\\s+ADD COLUMN IF NOT EXISTS bounce_category").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS email_suppressions").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec("CREATE TABLE IF NOT EXISTS message_status_retries").
+			WillReturnError(errors.New("table failed"))
+
+		err = migration.UpdateWorkspace(ctx, cfg, workspace, db)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to create message_status_retries table")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+)
+
+type apiKeyRepository struct {
+	systemDB *sql.DB
+}
+
+// NewAPIKeyRepository creates a new PostgreSQL API key repository
+func NewAPIKeyRepository(db *sql.DB) domain.APIKeyRepository {
+	return &apiKeyRepository{systemDB: db}
+}
+
+const apiKeyColumns = `id, workspace_id, name, key_prefix, key_hash, scopes, user_id, created_by, created_at, revoked_at`
+
+// scanAPIKey scans a row into an APIKey struct
+func scanAPIKey(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*domain.APIKey, error) {
+	var key domain.APIKey
+	var revokedAt sql.NullTime
+	err := scanner.Scan(
+		&key.ID,
+		&key.WorkspaceID,
+		&key.Name,
+		&key.KeyPrefix,
+		&key.KeyHash,
+		&key.Scopes,
+		&key.UserID,
+		&key.CreatedBy,
+		&key.CreatedAt,
+		&revokedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if revokedAt.Valid {
+		key.RevokedAt = &revokedAt.Time
+	}
+	return &key, nil
+}
+
+func (r *apiKeyRepository) Create(ctx context.Context, key *domain.APIKey) error {
+	query := `
+		INSERT INTO api_keys (` + apiKeyColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+	_, err := r.systemDB.ExecContext(ctx, query,
+		key.ID,
+		key.WorkspaceID,
+		key.Name,
+		key.KeyPrefix,
+		key.KeyHash,
+		key.Scopes,
+		key.UserID,
+		key.CreatedBy,
+		key.CreatedAt,
+		key.RevokedAt,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create API key: %w", err)
+	}
+	return nil
+}
+
+func (r *apiKeyRepository) GetByHash(ctx context.Context, keyHash string) (*domain.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE key_hash = $1`
+	key, err := scanAPIKey(r.systemDB.QueryRowContext(ctx, query, keyHash))
+	if err == sql.ErrNoRows {
+		return nil, &domain.ErrAPIKeyNotFound{Message: "API key not found"}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get API key: %w", err)
+	}
+	return key, nil
+}
+
+func (r *apiKeyRepository) GetByID(ctx context.Context, workspaceID, id string) (*domain.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE id = $1 AND workspace_id = $2`
+	key, err := scanAPIKey(r.systemDB.QueryRowContext(ctx, query, id, workspaceID))
+	if err == sql.ErrNoRows {
+		return nil, &domain.ErrAPIKeyNotFound{Message: "API key not found"}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get API key: %w", err)
+	}
+	return key, nil
+}
+
+func (r *apiKeyRepository) List(ctx context.Context, workspaceID string) ([]*domain.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM api_keys WHERE workspace_id = $1 ORDER BY created_at DESC`
+	rows, err := r.systemDB.QueryContext(ctx, query, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list API keys: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	keys := []*domain.APIKey{}
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan API key: %w", err)
+		}
+		keys = append(keys, key)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate API keys: %w", err)
+	}
+	return keys, nil
+}
+
+func (r *apiKeyRepository) Revoke(ctx context.Context, workspaceID, id string, revokedAt time.Time) error {
+	result, err := r.systemDB.ExecContext(ctx,
+		`UPDATE api_keys SET revoked_at = $1 WHERE id = $2 AND workspace_id = $3 AND revoked_at IS NULL`,
+		revokedAt.UTC(), id, workspaceID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to revoke API key: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return &domain.ErrAPIKeyNotFound{Message: "API key not found"}
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/repository/testutil"
+)
+
+var apiKeyTestColumns = []string{"id", "workspace_id", "name", "key_prefix", "key_hash", "scopes", "user_id", "created_by", "created_at", "revoked_at"}
+
+func TestAPIKeyRepository_Create(t *testing.T) {
+	db, mock, cleanup := testutil.SetupMockDB(t)
+	defer cleanup()
+
+	repo := NewAPIKeyRepository(db)
+	key := &domain.APIKey{
+		ID:          "key1",
+		WorkspaceID: "ws1",
+		Name:        "Transactional sender",
+		KeyPrefix:   "nfk_a1b2c3d4",
+		KeyHash:     "hash",
+		Scopes:      domain.APIKeyScopes{domain.APIKeyScopeTransactionalSend},
+		UserID:      "user1",
+		CreatedBy:   "owner1",
+		CreatedAt:   time.Now().UTC(),
+	}
+
+	mock.ExpectExec(`INSERT INTO api_keys`).
+		WithArgs(key.ID, key.WorkspaceID, key.Name, key.KeyPrefix, key.KeyHash, []byte(`["transactional:send"]`), key.UserID, key.CreatedBy, key.CreatedAt, nil).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	require.NoError(t, repo.Create(context.Background(), key))
+
+	mock.ExpectExec(`INSERT INTO api_keys`).
+		WillReturnError(errors.New("database error"))
+
+	err := repo.Create(context.Background(), key)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to create API key")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAPIKeyRepository_GetByHash(t *testing.T) {
+	db, mock, cleanup := testutil.SetupMockDB(t)
+	defer cleanup()
+
+	repo := NewAPIKeyRepository(db)
+	createdAt := time.Now().UTC()
+	revokedAt := createdAt.Add(time.Hour)
+
+	t.Run("active key", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT (.+) FROM api_keys WHERE key_hash = \$1`).
+			WithArgs("hash").
+			WillReturnRows(sqlmock.NewRows(apiKeyTestColumns).
+				AddRow("key1", "ws1", "Sender", "nfk_a1b2c3d4", "hash", []byte(`["transactional:send","contacts:read"]`), "user1", "owner1", createdAt, nil))
+
+		key, err := repo.GetByHash(context.Background(), "hash")
+		require.NoError(t, err)
+		assert.Equal(t, "key1", key.ID)
+		assert.Equal(t, domain.APIKeyScopes{domain.APIKeyScopeTransactionalSend, "contacts:read"}, key.Scopes)
+		assert.False(t, key.IsRevoked())
+	})
+
+	t.Run("revoked key", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT (.+) FROM api_keys WHERE key_hash = \$1`).
+			WithArgs("hash").
+			WillReturnRows(sqlmock.NewRows(apiKeyTestColumns).
+				AddRow("key1", "ws1", "Sender", "nfk_a1b2c3d4", "hash", []byte(`["contacts:read"]`), "user1", "owner1", createdAt, revokedAt))
+
+		key, err := repo.GetByHash(context.Background(), "hash")
+		require.NoError(t, err)
+		assert.True(t, key.IsRevoked())
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT (.+) FROM api_keys WHERE key_hash = \$1`).
+			WithArgs("unknown").
+			WillReturnError(sql.ErrNoRows)
+
+		_, err := repo.GetByHash(context.Background(), "unknown")
+		var notFound *domain.ErrAPIKeyNotFound
+		assert.ErrorAs(t, err, &notFound)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAPIKeyRepository_List(t *testing.T) {
+	db, mock, cleanup := testutil.SetupMockDB(t)
+	defer cleanup()
+
+	repo := NewAPIKeyRepository(db)
+	createdAt := time.Now().UTC()
+
+	mock.ExpectQuery(`SELECT (.+) FROM api_keys WHERE workspace_id = \$1 ORDER BY created_at DESC`).
+		WithArgs("ws1").
+		WillReturnRows(sqlmock.NewRows(apiKeyTestColumns).
+			AddRow("key2", "ws1", "Reader", "nfk_e5f6a7b8", "hash2", []byte(`["broadcasts:read"]`), "user2", "owner1", createdAt, nil).
+			AddRow("key1", "ws1", "Sender", "nfk_a1b2c3d4", "hash1", []byte(`["transactional:send"]`), "user1", "owner1", createdAt, createdAt))
+
+	keys, err := repo.List(context.Background(), "ws1")
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+	assert.Equal(t, "key2", keys[0].ID)
+	assert.True(t, keys[1].IsRevoked())
+
+	mock.ExpectQuery(`SELECT (.+) FROM api_keys WHERE workspace_id = \$1`).
+		WithArgs("ws1").
+		WillReturnError(errors.New("database error"))
+
+	_, err = repo.List(context.Background(), "ws1")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to list API keys")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAPIKeyRepository_Revoke(t *testing.T) {
+	db, mock, cleanup := testutil.SetupMockDB(t)
+	defer cleanup()
+
+	repo := NewAPIKeyRepository(db)
+	revokedAt := time.Now().UTC()
+
+	mock.ExpectExec(`UPDATE api_keys SET revoked_at = \$1 WHERE id = \$2 AND workspace_id = \$3 AND revoked_at IS NULL`).
+		WithArgs(revokedAt, "key1", "ws1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	require.NoError(t, repo.Revoke(context.Background(), "ws1", "key1", revokedAt))
+
+	// Unknown, already revoked or from another workspace
+	mock.ExpectExec(`UPDATE api_keys SET revoked_at`).
+		WithArgs(revokedAt, "key1", "ws2").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := repo.Revoke(context.Background(), "ws2", "key1", revokedAt)
+	var notFound *domain.ErrAPIKeyNotFound
+	assert.ErrorAs(t, err, &notFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
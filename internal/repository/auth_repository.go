@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"time"
 
 	"github.com/Notifuse/notifuse/internal/domain"
@@ -49,3 +50,36 @@ func (r *SQLAuthRepository) GetUserByID(ctx context.Context, userID string) (*do
 
 	return &user, nil
 }
+
+// RevokeToken adds a token ID to the revocation list until the token expires.
+// Expired entries are purged on the way, the tokens they revoked no longer authenticate anyway.
+func (r *SQLAuthRepository) RevokeToken(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	_, err := r.systemDB.ExecContext(ctx,
+		`INSERT INTO revoked_tokens (token_id, expires_at, revoked_at) VALUES ($1, $2, $3)
+		ON CONFLICT (token_id) DO NOTHING`,
+		tokenID, expiresAt.UTC(), time.Now().UTC(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to revoke token: %w", err)
+	}
+
+	if _, err := r.systemDB.ExecContext(ctx, "DELETE FROM revoked_tokens WHERE expires_at <= $1", time.Now().UTC()); err != nil {
+		return fmt.Errorf("failed to purge expired revoked tokens: %w", err)
+	}
+
+	return nil
+}
+
+// IsTokenRevoked reports whether an unexpired token ID is on the revocation list
+func (r *SQLAuthRepository) IsTokenRevoked(ctx context.Context, tokenID string) (bool, error) {
+	var revoked bool
+	err := r.systemDB.QueryRowContext(ctx,
+		"SELECT EXISTS(SELECT 1 FROM revoked_tokens WHERE token_id = $1 AND expires_at > $2)",
+		tokenID, time.Now().UTC(),
+	).Scan(&revoked)
+	if err != nil {
+		return false, fmt.Errorf("failed to check token revocation: %w", err)
+	}
+
+	return revoked, nil
+}
@@ -100,3 +100,64 @@ func TestAuthRepository_GetUserByID(t *testing.T) {
 	assert.Nil(t, user)
 	assert.Contains(t, err.Error(), "database error")
 }
+
+func TestAuthRepository_RevokeToken(t *testing.T) {
+	db, mock, cleanup := testutil.SetupMockDB(t)
+	defer cleanup()
+
+	repo := NewSQLAuthRepository(db)
+	expiresAt := time.Now().Add(24 * time.Hour).UTC()
+
+	// Test case 1: Token revoked and expired entries purged
+	mock.ExpectExec(`INSERT INTO revoked_tokens \(token_id, expires_at, revoked_at\) VALUES \(\$1, \$2, \$3\) ON CONFLICT \(token_id\) DO NOTHING`).
+		WithArgs("token-id-1", expiresAt, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`DELETE FROM revoked_tokens WHERE expires_at <= \$1`).
+		WithArgs(sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	require.NoError(t, repo.RevokeToken(context.Background(), "token-id-1", expiresAt))
+
+	// Test case 2: Database error
+	mock.ExpectExec(`INSERT INTO revoked_tokens`).
+		WillReturnError(errors.New("database error"))
+
+	err := repo.RevokeToken(context.Background(), "token-id-2", expiresAt)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to revoke token")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAuthRepository_IsTokenRevoked(t *testing.T) {
+	db, mock, cleanup := testutil.SetupMockDB(t)
+	defer cleanup()
+
+	repo := NewSQLAuthRepository(db)
+
+	// Test case 1: Revoked token
+	mock.ExpectQuery(`SELECT EXISTS\(SELECT 1 FROM revoked_tokens WHERE token_id = \$1 AND expires_at > \$2\)`).
+		WithArgs("token-id-1", sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+	revoked, err := repo.IsTokenRevoked(context.Background(), "token-id-1")
+	require.NoError(t, err)
+	assert.True(t, revoked)
+
+	// Test case 2: Token not revoked
+	mock.ExpectQuery(`SELECT EXISTS\(SELECT 1 FROM revoked_tokens`).
+		WithArgs("token-id-2", sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(false))
+
+	revoked, err = repo.IsTokenRevoked(context.Background(), "token-id-2")
+	require.NoError(t, err)
+	assert.False(t, revoked)
+
+	// Test case 3: Database error
+	mock.ExpectQuery(`SELECT EXISTS\(SELECT 1 FROM revoked_tokens`).
+		WithArgs("token-id-3", sqlmock.AnyArg()).
+		WillReturnError(errors.New("database error"))
+
+	_, err = repo.IsTokenRevoked(context.Background(), "token-id-3")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to check token revocation")
+}
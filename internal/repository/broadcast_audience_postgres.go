@@ -0,0 +1,208 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/Notifuse/notifuse/internal/domain"
+)
+
+// broadcastAudienceInsertBatchSize is the number of recipients inserted per statement
+const broadcastAudienceInsertBatchSize = 1000
+
+// BroadcastAudienceRepository implements domain.BroadcastAudienceRepository
+type BroadcastAudienceRepository struct {
+	workspaceRepo domain.WorkspaceRepository
+}
+
+// NewBroadcastAudienceRepository creates a new broadcast audience repository
+func NewBroadcastAudienceRepository(workspaceRepo domain.WorkspaceRepository) *BroadcastAudienceRepository {
+	return &BroadcastAudienceRepository{
+		workspaceRepo: workspaceRepo,
+	}
+}
+
+// ReplaceRecipients replaces the uploaded recipients of a broadcast in a single transaction
+func (r *BroadcastAudienceRepository) ReplaceRecipients(ctx context.Context, workspaceID, broadcastID string, recipients []*domain.BroadcastAudienceRecipient) error {
+	// Get the workspace database connection
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	tx, err := workspaceDB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM broadcast_audience_recipients WHERE broadcast_id = $1`, broadcastID); err != nil {
+		return fmt.Errorf("failed to delete broadcast audience recipients: %w", err)
+	}
+
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	now := time.Now().UTC()
+
+	for start := 0; start < len(recipients); start += broadcastAudienceInsertBatchSize {
+		end := start + broadcastAudienceInsertBatchSize
+		if end > len(recipients) {
+			end = len(recipients)
+		}
+
+		query := psql.Insert("broadcast_audience_recipients").
+			Columns("broadcast_id", "email", "data", "created_at").
+			Suffix("ON CONFLICT (broadcast_id, email) DO NOTHING")
+		for _, recipient := range recipients[start:end] {
+			var data interface{}
+			if len(recipient.Data) > 0 {
+				data = recipient.Data
+			}
+			query = query.Values(broadcastID, recipient.Email, data, now)
+		}
+
+		sqlQuery, args, err := query.ToSql()
+		if err != nil {
+			return fmt.Errorf("failed to build insert query: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, sqlQuery, args...); err != nil {
+			return fmt.Errorf("failed to insert broadcast audience recipients: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// CountRecipients counts the recipients of a broadcast, applying the audience unsubscribe exclusion
+func (r *BroadcastAudienceRepository) CountRecipients(ctx context.Context, workspaceID, broadcastID string, audience domain.AudienceSettings) (int, error) {
+	// Get the workspace database connection
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	query := excludeUnsubscribedRecipients(psql.Select("COUNT(*)").
+		From("broadcast_audience_recipients r").
+		Where(sq.Eq{"r.broadcast_id": broadcastID}), audience)
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build count query: %w", err)
+	}
+
+	var count int
+	if err := workspaceDB.QueryRowContext(ctx, sqlQuery, args...).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count broadcast audience recipients: %w", err)
+	}
+
+	return count, nil
+}
+
+// GetContactsForBroadcast retrieves a batch of recipients as contacts of the audience list.
+// Uses cursor-based pagination on the email like contactRepository.GetContactsForBroadcast.
+func (r *BroadcastAudienceRepository) GetContactsForBroadcast(
+	ctx context.Context,
+	workspaceID, broadcastID string,
+	audience domain.AudienceSettings,
+	limit int,
+	afterEmail string,
+) ([]*domain.ContactWithList, error) {
+	// Get the workspace database connection
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	query := psql.Select("r.email", "r.data", "l.name").
+		From("broadcast_audience_recipients r").
+		LeftJoin("lists l ON l.id = ?", audience.PrimaryList()).
+		Where(sq.Eq{"r.broadcast_id": broadcastID}).
+		OrderBy("r.email ASC").
+		Limit(uint64(limit))
+
+	// Cursor-based pagination: fetch recipients with email > afterEmail
+	if afterEmail != "" {
+		query = query.Where(sq.Gt{"r.email": afterEmail})
+	}
+	query = excludeUnsubscribedRecipients(query, audience)
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := workspaceDB.QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get broadcast audience recipients: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	contactsWithList := []*domain.ContactWithList{}
+	for rows.Next() {
+		recipient := &domain.BroadcastAudienceRecipient{BroadcastID: broadcastID}
+		var listName sql.NullString
+		if err := rows.Scan(&recipient.Email, &recipient.Data, &listName); err != nil {
+			return nil, fmt.Errorf("failed to scan broadcast audience recipient: %w", err)
+		}
+
+		contact, err := recipient.ToContact()
+		if err != nil {
+			return nil, fmt.Errorf("invalid broadcast audience recipient %s: %w", recipient.Email, err)
+		}
+
+		contactsWithList = append(contactsWithList, &domain.ContactWithList{
+			Contact:  contact,
+			ListID:   audience.PrimaryList(),
+			ListName: listName.String,
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate broadcast audience recipients: %w", err)
+	}
+
+	return contactsWithList, nil
+}
+
+// DeleteRecipients deletes the uploaded recipients of a broadcast
+func (r *BroadcastAudienceRepository) DeleteRecipients(ctx context.Context, workspaceID, broadcastID string) error {
+	// Get the workspace database connection
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	if _, err := workspaceDB.ExecContext(ctx, `DELETE FROM broadcast_audience_recipients WHERE broadcast_id = $1`, broadcastID); err != nil {
+		return fmt.Errorf("failed to delete broadcast audience recipients: %w", err)
+	}
+
+	return nil
+}
+
+// excludeUnsubscribedRecipients leaves out the recipients that unsubscribed from, bounced or complained
+// on the audience list when the audience excludes unsubscribed contacts. Recipients that are not
+// contacts of the list are kept.
+func excludeUnsubscribedRecipients(query sq.SelectBuilder, audience domain.AudienceSettings) sq.SelectBuilder {
+	if !audience.ExcludeUnsubscribed || audience.PrimaryList() == "" {
+		return query
+	}
+
+	return query.
+		LeftJoin("contact_lists cl ON cl.email = r.email AND cl.list_id = ?", audience.PrimaryList()).
+		Where(sq.Or{
+			sq.Eq{"cl.status": nil},
+			sq.NotEq{"cl.status": []string{
+				string(domain.ContactListStatusUnsubscribed),
+				string(domain.ContactListStatusBounced),
+				string(domain.ContactListStatusComplained),
+			}},
+		})
+}
@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/domain/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBroadcastAudienceRepository_ReplaceRecipients(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	repo := NewBroadcastAudienceRepository(workspaceRepo)
+
+	recipients := []*domain.BroadcastAudienceRecipient{
+		{Email: "alice@example.com", Data: domain.MapOfAny{"first_name": "Alice"}},
+		{Email: "bob@example.com"},
+	}
+
+	t.Run("replaces the previous upload", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		workspaceRepo.EXPECT().GetConnection(ctx, "ws1").Return(db, nil)
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM broadcast_audience_recipients WHERE broadcast_id = \$1`).
+			WithArgs("broadcast1").
+			WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectExec(`INSERT INTO broadcast_audience_recipients \(broadcast_id,email,data,created_at\) VALUES \(\$1,\$2,\$3,\$4\),\(\$5,\$6,\$7,\$8\) ON CONFLICT`).
+			WithArgs("broadcast1", "alice@example.com", sqlmock.AnyArg(), sqlmock.AnyArg(), "broadcast1", "bob@example.com", nil, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		require.NoError(t, repo.ReplaceRecipients(ctx, "ws1", "broadcast1", recipients))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("insert error rolls back", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		workspaceRepo.EXPECT().GetConnection(ctx, "ws1").Return(db, nil)
+		mock.ExpectBegin()
+		mock.ExpectExec(`DELETE FROM broadcast_audience_recipients`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(`INSERT INTO broadcast_audience_recipients`).
+			WillReturnError(errors.New("db error"))
+		mock.ExpectRollback()
+
+		err = repo.ReplaceRecipients(ctx, "ws1", "broadcast1", recipients)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to insert broadcast audience recipients")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("connection error", func(t *testing.T) {
+		workspaceRepo.EXPECT().GetConnection(ctx, "ws1").Return(nil, errors.New("connection error"))
+
+		err := repo.ReplaceRecipients(ctx, "ws1", "broadcast1", recipients)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to get workspace connection")
+	})
+}
+
+func TestBroadcastAudienceRepository_CountRecipients(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	repo := NewBroadcastAudienceRepository(workspaceRepo)
+
+	t.Run("all recipients", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		workspaceRepo.EXPECT().GetConnection(ctx, "ws1").Return(db, nil)
+		mock.ExpectQuery(`SELECT COUNT\(\*\) FROM broadcast_audience_recipients r WHERE r.broadcast_id = \$1$`).
+			WithArgs("broadcast1").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+		count, err := repo.CountRecipients(ctx, "ws1", "broadcast1", domain.AudienceSettings{List: "list1", CSV: true})
+		require.NoError(t, err)
+		assert.Equal(t, 3, count)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("excludes the unsubscribed contacts of the list", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		workspaceRepo.EXPECT().GetConnection(ctx, "ws1").Return(db, nil)
+		mock.ExpectQuery(`LEFT JOIN contact_lists cl ON cl.email = r.email AND cl.list_id = \$1 WHERE r.broadcast_id = \$2 AND \(cl.status IS NULL OR cl.status NOT IN \(\$3,\$4,\$5\)\)`).
+			WithArgs("list1", "broadcast1", "unsubscribed", "bounced", "complained").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+		count, err := repo.CountRecipients(ctx, "ws1", "broadcast1", domain.AudienceSettings{List: "list1", CSV: true, ExcludeUnsubscribed: true})
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestBroadcastAudienceRepository_GetContactsForBroadcast(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	repo := NewBroadcastAudienceRepository(workspaceRepo)
+
+	t.Run("maps recipients to contacts of the list", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		workspaceRepo.EXPECT().GetConnection(ctx, "ws1").Return(db, nil)
+		mock.ExpectQuery(`SELECT r.email, r.data, l.name FROM broadcast_audience_recipients r LEFT JOIN lists l ON l.id = \$1 WHERE r.broadcast_id = \$2 AND r.email > \$3 ORDER BY r.email ASC LIMIT 2`).
+			WithArgs("list1", "broadcast1", "alice@example.com").
+			WillReturnRows(sqlmock.NewRows([]string{"email", "data", "name"}).
+				AddRow("bob@example.com", []byte(`{"first_name":"Bob","custom_number_1":42}`), "Newsletter").
+				AddRow("carol@example.com", nil, "Newsletter"))
+
+		contacts, err := repo.GetContactsForBroadcast(ctx, "ws1", "broadcast1", domain.AudienceSettings{List: "list1", CSV: true}, 2, "alice@example.com")
+		require.NoError(t, err)
+		require.Len(t, contacts, 2)
+
+		assert.Equal(t, "bob@example.com", contacts[0].Contact.Email)
+		assert.Equal(t, "Bob", contacts[0].Contact.FirstName.String)
+		assert.Equal(t, float64(42), contacts[0].Contact.CustomNumber1.Float64)
+		assert.Equal(t, "list1", contacts[0].ListID)
+		assert.Equal(t, "Newsletter", contacts[0].ListName)
+
+		assert.Equal(t, "carol@example.com", contacts[1].Contact.Email)
+		assert.Nil(t, contacts[1].Contact.FirstName)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		workspaceRepo.EXPECT().GetConnection(ctx, "ws1").Return(db, nil)
+		mock.ExpectQuery(`SELECT r.email, r.data, l.name FROM broadcast_audience_recipients r`).
+			WillReturnError(errors.New("db error"))
+
+		_, err = repo.GetContactsForBroadcast(ctx, "ws1", "broadcast1", domain.AudienceSettings{List: "list1", CSV: true}, 100, "")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to get broadcast audience recipients")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestBroadcastAudienceRepository_DeleteRecipients(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	repo := NewBroadcastAudienceRepository(workspaceRepo)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	workspaceRepo.EXPECT().GetConnection(ctx, "ws1").Return(db, nil)
+	mock.ExpectExec(`DELETE FROM broadcast_audience_recipients WHERE broadcast_id = \$1`).
+		WithArgs("broadcast1").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	require.NoError(t, repo.DeleteRecipients(ctx, "ws1", "broadcast1"))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/lib/pq"
 )
 
 // broadcastRepository implements domain.BroadcastRepository for PostgreSQL
@@ -81,6 +83,12 @@ func (r *broadcastRepository) CreateBroadcastTx(ctx context.Context, tx *sql.Tx,
 			test_sent_at,
 			winner_sent_at,
 			enqueued_count,
+			skipped_count,
+			tags,
+			dry_run,
+			plain_text_only,
+			channel_type,
+			seed_test,
 			created_at,
 			updated_at,
 			started_at,
@@ -89,7 +97,7 @@ func (r *broadcastRepository) CreateBroadcastTx(ctx context.Context, tx *sql.Tx,
 			paused_at,
 			pause_reason
 		) VALUES (
-			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20
+			$1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24, $25, $26
 		)
 	`
 
@@ -107,6 +115,12 @@ func (r *broadcastRepository) CreateBroadcastTx(ctx context.Context, tx *sql.Tx,
 		broadcast.TestSentAt,
 		broadcast.WinnerSentAt,
 		broadcast.EnqueuedCount,
+		broadcast.SkippedCount,
+		pq.Array(broadcast.Tags),
+		broadcast.DryRun,
+		broadcast.PlainTextOnly,
+		broadcast.ChannelType,
+		broadcast.SeedTest,
 		broadcast.CreatedAt,
 		broadcast.UpdatedAt,
 		broadcast.StartedAt,
@@ -146,6 +160,12 @@ func (r *broadcastRepository) GetBroadcast(ctx context.Context, workspaceID, id
 			test_sent_at,
 			winner_sent_at,
 			enqueued_count,
+			skipped_count,
+			tags,
+			dry_run,
+			plain_text_only,
+			channel_type,
+			seed_test,
 			created_at,
 			updated_at,
 			started_at,
@@ -187,6 +207,12 @@ func (r *broadcastRepository) GetBroadcastTx(ctx context.Context, tx *sql.Tx, wo
 			test_sent_at,
 			winner_sent_at,
 			enqueued_count,
+			skipped_count,
+			tags,
+			dry_run,
+			plain_text_only,
+			channel_type,
+			seed_test,
 			created_at,
 			updated_at,
 			started_at,
@@ -241,7 +267,13 @@ func (r *broadcastRepository) UpdateBroadcastTx(ctx context.Context, tx *sql.Tx,
 			cancelled_at = $16,
 			paused_at = $17,
 			pause_reason = $18,
-			enqueued_count = $19
+			enqueued_count = $19,
+			skipped_count = $20,
+			tags = $21,
+			dry_run = $22,
+			plain_text_only = $23,
+			channel_type = $24,
+			seed_test = $25
 		WHERE id = $1 AND workspace_id = $2
 			AND status != 'cancelled'
 			AND status != 'processed'
@@ -267,6 +299,12 @@ func (r *broadcastRepository) UpdateBroadcastTx(ctx context.Context, tx *sql.Tx,
 		broadcast.PausedAt,
 		broadcast.PauseReason,
 		broadcast.EnqueuedCount,
+		broadcast.SkippedCount,
+		pq.Array(broadcast.Tags),
+		broadcast.DryRun,
+		broadcast.PlainTextOnly,
+		broadcast.ChannelType,
+		broadcast.SeedTest,
 	)
 
 	if err != nil {
@@ -287,96 +325,103 @@ func (r *broadcastRepository) UpdateBroadcastTx(ctx context.Context, tx *sql.Tx,
 
 // ListBroadcastsTx retrieves a list of broadcasts within a transaction
 func (r *broadcastRepository) ListBroadcastsTx(ctx context.Context, tx *sql.Tx, params domain.ListBroadcastsParams) (*domain.BroadcastListResponse, error) {
-	// First count total records that match the criteria
-	var countQuery string
-	var countArgs []interface{}
+	// Build the filters shared by the count and data queries
+	conditions := []string{"workspace_id = $1"}
+	args := []interface{}{params.WorkspaceID}
+	addCondition := func(clause string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(clause, len(args)))
+	}
 
 	if params.Status != "" {
-		countQuery = `
-			SELECT COUNT(*)
-			FROM broadcasts
-			WHERE workspace_id = $1 AND status = $2
-		`
-		countArgs = []interface{}{params.WorkspaceID, params.Status}
-	} else {
-		countQuery = `
-			SELECT COUNT(*)
-			FROM broadcasts
-			WHERE workspace_id = $1
-		`
-		countArgs = []interface{}{params.WorkspaceID}
+		addCondition("status = $%d", params.Status)
+	}
+	if params.Tag != "" {
+		addCondition("$%d = ANY(tags)", params.Tag)
+	}
+	if params.CreatedAfter != nil {
+		addCondition("created_at >= $%d", *params.CreatedAfter)
+	}
+	if params.CreatedBefore != nil {
+		addCondition("created_at < $%d", *params.CreatedBefore)
+	}
+	if params.CompletedAfter != nil {
+		addCondition("completed_at >= $%d", *params.CompletedAfter)
 	}
+	if params.CompletedBefore != nil {
+		addCondition("completed_at < $%d", *params.CompletedBefore)
+	}
+	if params.Search != "" {
+		addCondition("name ILIKE $%d", "%"+params.Search+"%")
+	}
+	whereClause := strings.Join(conditions, " AND ")
+
+	// First count total records that match the criteria
+	countQuery := `
+		SELECT COUNT(*)
+		FROM broadcasts
+		WHERE ` + whereClause
 
 	var totalCount int
-	err := tx.QueryRowContext(ctx, countQuery, countArgs...).Scan(&totalCount)
+	err := tx.QueryRowContext(ctx, countQuery, args...).Scan(&totalCount)
 	if err != nil {
 		return nil, fmt.Errorf("failed to count broadcasts: %w", err)
 	}
 
-	// Then query paginated data
-	var dataQuery string
-	var dataArgs []interface{}
+	// Then query paginated data, after the cursor when given. The cursor only narrows the page,
+	// the total count covers every broadcast matching the filters
+	dataWhereClause := whereClause
+	dataArgs := append([]interface{}{}, args...)
+	pagination := fmt.Sprintf("LIMIT $%d OFFSET $%d", len(dataArgs)+1, len(dataArgs)+2)
+	paginationArgs := []interface{}{params.Limit, params.Offset}
+	if params.Cursor != "" {
+		cursorTime, cursorID, err := domain.DecodeBroadcastCursor(params.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		dataArgs = append(dataArgs, cursorTime, cursorID)
+		dataWhereClause += fmt.Sprintf(" AND (created_at < $%d OR (created_at = $%d AND id < $%d))", len(dataArgs)-1, len(dataArgs)-1, len(dataArgs))
 
-	if params.Status != "" {
-		dataQuery = `
-			SELECT
-				id,
-				workspace_id,
-				name,
-				status,
-				audience,
-				schedule,
-				test_settings,
-				utm_parameters,
-				metadata,
-				winning_template,
-				test_sent_at,
-				winner_sent_at,
-				enqueued_count,
-				created_at,
-				updated_at,
-				started_at,
-				completed_at,
-				cancelled_at,
-				paused_at,
-				pause_reason
-			FROM broadcasts
-			WHERE workspace_id = $1 AND status = $2
-			ORDER BY created_at DESC
-			LIMIT $3 OFFSET $4
-		`
-		dataArgs = []interface{}{params.WorkspaceID, params.Status, params.Limit, params.Offset}
-	} else {
-		dataQuery = `
-			SELECT
-				id,
-				workspace_id,
-				name,
-				status,
-				audience,
-				schedule,
-				test_settings,
-				utm_parameters,
-				metadata,
-				winning_template,
-				test_sent_at,
-				winner_sent_at,
-				enqueued_count,
-				created_at,
-				updated_at,
-				started_at,
-				completed_at,
-				cancelled_at,
-				paused_at,
-				pause_reason
-			FROM broadcasts
-			WHERE workspace_id = $1
-			ORDER BY created_at DESC
-			LIMIT $2 OFFSET $3
-		`
-		dataArgs = []interface{}{params.WorkspaceID, params.Limit, params.Offset}
+		// Fetch one extra to determine if there are more results
+		pagination = fmt.Sprintf("LIMIT $%d", len(dataArgs)+1)
+		paginationArgs = []interface{}{params.Limit + 1}
 	}
 
+	dataQuery := fmt.Sprintf(`
+		SELECT
+			id,
+			workspace_id,
+			name,
+			status,
+			audience,
+			schedule,
+			test_settings,
+			utm_parameters,
+			metadata,
+			winning_template,
+			test_sent_at,
+			winner_sent_at,
+			enqueued_count,
+			skipped_count,
+			tags,
+			dry_run,
+			plain_text_only,
+			channel_type,
+			seed_test,
+			created_at,
+			updated_at,
+			started_at,
+			completed_at,
+			cancelled_at,
+			paused_at,
+			pause_reason
+		FROM broadcasts
+		WHERE %s
+		ORDER BY created_at DESC, id DESC
+		%s
+	`, dataWhereClause, pagination)
+	dataArgs = append(dataArgs, paginationArgs...)
+
 	rows, err := tx.QueryContext(ctx, dataQuery, dataArgs...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list broadcasts: %w", err)
@@ -396,10 +441,25 @@ func (r *broadcastRepository) ListBroadcastsTx(ctx context.Context, tx *sql.Tx,
 		return nil, fmt.Errorf("error iterating broadcast rows: %w", err)
 	}
 
-	return &domain.BroadcastListResponse{
+	// Determine if we have more results and generate cursor
+	var hasMore bool
+	if params.Cursor != "" {
+		hasMore = len(broadcasts) > params.Limit
+		if hasMore {
+			broadcasts = broadcasts[:params.Limit]
+		}
+	} else {
+		hasMore = params.Offset+len(broadcasts) < totalCount
+	}
+
+	response := &domain.BroadcastListResponse{
 		Broadcasts: broadcasts,
 		TotalCount: totalCount,
-	}, nil
+	}
+	if hasMore && len(broadcasts) > 0 {
+		response.NextCursor = domain.EncodeBroadcastCursor(broadcasts[len(broadcasts)-1])
+	}
+	return response, nil
 }
 
 // ListBroadcasts retrieves a list of broadcasts
@@ -431,6 +491,99 @@ func (r *broadcastRepository) ListBroadcasts(ctx context.Context, params domain.
 	return result, nil
 }
 
+// SetBroadcastTags replaces the tags of a broadcast, whatever its status
+func (r *broadcastRepository) SetBroadcastTags(ctx context.Context, workspaceID, broadcastID string, tags []string) error {
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	query := `
+		UPDATE broadcasts
+		SET tags = $3, updated_at = $4
+		WHERE id = $1 AND workspace_id = $2
+	`
+
+	result, err := workspaceDB.ExecContext(ctx, query, broadcastID, workspaceID, pq.Array(tags), time.Now().UTC())
+	if err != nil {
+		return fmt.Errorf("failed to set broadcast tags: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return &domain.ErrBroadcastNotFound{ID: broadcastID}
+	}
+
+	return nil
+}
+
+// ListBroadcastTags returns the tags used by the broadcasts of a workspace with their broadcast count
+func (r *broadcastRepository) ListBroadcastTags(ctx context.Context, workspaceID string) ([]*domain.BroadcastTagCount, error) {
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	query := `
+		SELECT tag, COUNT(*)
+		FROM broadcasts, unnest(tags) AS tag
+		WHERE workspace_id = $1
+		GROUP BY tag
+		ORDER BY tag
+	`
+
+	rows, err := workspaceDB.QueryContext(ctx, query, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list broadcast tags: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	tags := []*domain.BroadcastTagCount{}
+	for rows.Next() {
+		tag := &domain.BroadcastTagCount{}
+		if err := rows.Scan(&tag.Tag, &tag.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan broadcast tag: %w", err)
+		}
+		tags = append(tags, tag)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating broadcast tag rows: %w", err)
+	}
+
+	return tags, nil
+}
+
+// DeleteBroadcastTag removes a tag from every broadcast of a workspace and returns the number of broadcasts updated
+func (r *broadcastRepository) DeleteBroadcastTag(ctx context.Context, workspaceID, tag string) (int64, error) {
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	query := `
+		UPDATE broadcasts
+		SET tags = array_remove(tags, $2), updated_at = $3
+		WHERE workspace_id = $1 AND $2 = ANY(tags)
+	`
+
+	result, err := workspaceDB.ExecContext(ctx, query, workspaceID, tag, time.Now().UTC())
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete broadcast tag: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected, nil
+}
+
 // DeleteBroadcast deletes a broadcast from the database
 func (r *broadcastRepository) DeleteBroadcast(ctx context.Context, workspaceID, id string) error {
 	return r.WithTransaction(ctx, workspaceID, func(tx *sql.Tx) error {
@@ -484,6 +637,12 @@ func scanBroadcast(scanner interface {
 		&broadcast.TestSentAt,
 		&broadcast.WinnerSentAt,
 		&broadcast.EnqueuedCount,
+		&broadcast.SkippedCount,
+		pq.Array(&broadcast.Tags),
+		&broadcast.DryRun,
+		&broadcast.PlainTextOnly,
+		&broadcast.ChannelType,
+		&broadcast.SeedTest,
 		&broadcast.CreatedAt,
 		&broadcast.UpdatedAt,
 		&broadcast.StartedAt,
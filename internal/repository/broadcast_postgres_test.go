@@ -425,6 +425,12 @@ func TestBroadcastRepository_CreateBroadcast_Success(t *testing.T) {
 			sqlmock.AnyArg(), // test_sent_at
 			sqlmock.AnyArg(), // winner_sent_at
 			sqlmock.AnyArg(), // enqueued_count
+			sqlmock.AnyArg(), // skipped_count
+			sqlmock.AnyArg(), // tags
+			sqlmock.AnyArg(), // dry_run
+			sqlmock.AnyArg(), // plain_text_only
+			sqlmock.AnyArg(), // channel_type
+			sqlmock.AnyArg(), // seed_test
 			sqlmock.AnyArg(), // created_at - timestamp will be added
 			sqlmock.AnyArg(), // updated_at - timestamp will be added
 			sqlmock.AnyArg(), // started_at
@@ -514,7 +520,7 @@ func TestBroadcastRepository_GetBroadcast_Success(t *testing.T) {
 		"id", "workspace_id", "name", "status", "audience", "schedule",
 		"test_settings", "utm_parameters", "metadata",
 		"winning_template",
-		"test_sent_at", "winner_sent_at", "enqueued_count",
+		"test_sent_at", "winner_sent_at", "enqueued_count", "skipped_count", "tags", "dry_run", "plain_text_only", "channel_type", "seed_test",
 		"created_at", "updated_at",
 		"started_at", "completed_at", "cancelled_at", "paused_at", "pause_reason",
 	}).
@@ -522,7 +528,7 @@ func TestBroadcastRepository_GetBroadcast_Success(t *testing.T) {
 			broadcastID, workspaceID, "Test Broadcast", domain.BroadcastStatusDraft,
 			[]byte("{}"), []byte("{}"), []byte("{}"), []byte("{}"), []byte("{}"),
 			"", // Use empty string instead of nil for winning_template
-			nil, nil, 0, // enqueued_count
+			nil, nil, 0, 0, nil, false, false, "email", nil, // enqueued_count, skipped_count, tags, dry_run, plain_text_only, channel_type, seed_test
 			time.Now(), time.Now(),
 			nil, nil, nil, nil, nil,
 		)
@@ -569,7 +575,7 @@ func TestBroadcastRepository_GetBroadcast_NullPauseReason(t *testing.T) {
 		"id", "workspace_id", "name", "status", "audience", "schedule",
 		"test_settings", "utm_parameters", "metadata",
 		"winning_template",
-		"test_sent_at", "winner_sent_at", "enqueued_count",
+		"test_sent_at", "winner_sent_at", "enqueued_count", "skipped_count", "tags", "dry_run", "plain_text_only", "channel_type", "seed_test",
 		"created_at", "updated_at",
 		"started_at", "completed_at", "cancelled_at", "paused_at", "pause_reason",
 	}).
@@ -577,7 +583,7 @@ func TestBroadcastRepository_GetBroadcast_NullPauseReason(t *testing.T) {
 			broadcastID, workspaceID, "Test Broadcast", domain.BroadcastStatusDraft,
 			[]byte("{}"), []byte("{}"), []byte("{}"), []byte("{}"), []byte("{}"),
 			"", // Use empty string instead of nil for winning_template
-			nil, nil, 0, // enqueued_count
+			nil, nil, 0, 0, nil, false, false, "email", nil, // enqueued_count, skipped_count, tags, dry_run, plain_text_only, channel_type, seed_test
 			time.Now(), time.Now(),
 			nil, nil, nil, nil, nil, // NULL pause_reason
 		)
@@ -623,7 +629,7 @@ func TestBroadcastRepository_GetBroadcast_WithPauseReason(t *testing.T) {
 		"id", "workspace_id", "name", "status", "audience", "schedule",
 		"test_settings", "utm_parameters", "metadata",
 		"winning_template",
-		"test_sent_at", "winner_sent_at", "enqueued_count",
+		"test_sent_at", "winner_sent_at", "enqueued_count", "skipped_count", "tags", "dry_run", "plain_text_only", "channel_type", "seed_test",
 		"created_at", "updated_at",
 		"started_at", "completed_at", "cancelled_at", "paused_at", "pause_reason",
 	}).
@@ -631,7 +637,7 @@ func TestBroadcastRepository_GetBroadcast_WithPauseReason(t *testing.T) {
 			broadcastID, workspaceID, "Test Broadcast", domain.BroadcastStatusPaused,
 			[]byte("{}"), []byte("{}"), []byte("{}"), []byte("{}"), []byte("{}"),
 			"",
-			nil, nil, 0, // enqueued_count
+			nil, nil, 0, 0, nil, false, false, "email", nil, // enqueued_count, skipped_count, tags, dry_run, plain_text_only, channel_type, seed_test
 			time.Now(), time.Now(),
 			nil, nil, nil, time.Now(), expectedReason, // Non-NULL pause_reason
 		)
@@ -784,6 +790,12 @@ func TestBroadcastRepository_UpdateBroadcast_Success(t *testing.T) {
 			sqlmock.AnyArg(), // paused_at
 			sqlmock.AnyArg(), // pause_reason
 			sqlmock.AnyArg(), // enqueued_count
+			sqlmock.AnyArg(), // skipped_count
+			sqlmock.AnyArg(), // tags
+			sqlmock.AnyArg(), // dry_run
+			sqlmock.AnyArg(), // plain_text_only
+			sqlmock.AnyArg(), // channel_type
+			sqlmock.AnyArg(), // seed_test
 		).
 		WillReturnResult(sqlmock.NewResult(0, 1))
 
@@ -1171,17 +1183,17 @@ func TestBroadcastRepository_ListBroadcasts_WithStatus(t *testing.T) {
 		"id", "workspace_id", "name", "status", "audience", "schedule",
 		"test_settings", "utm_parameters", "metadata",
 		"winning_template",
-		"test_sent_at", "winner_sent_at", "enqueued_count",
+		"test_sent_at", "winner_sent_at", "enqueued_count", "skipped_count", "tags", "dry_run", "plain_text_only", "channel_type", "seed_test",
 		"created_at", "updated_at",
 		"started_at", "completed_at", "cancelled_at", "paused_at", "pause_reason",
 	}).
 		AddRow(
 			"bc123", workspaceID, "Broadcast 1", status, []byte("{}"), []byte("{}"), []byte("{}"), []byte("{}"), []byte("{}"),
-			"", nil, nil, 0, time.Now(), time.Now(), nil, nil, nil, nil, nil,
+			"", nil, nil, 0, 0, nil, false, false, "email", nil, time.Now(), time.Now(), nil, nil, nil, nil, nil,
 		).
 		AddRow(
 			"bc456", workspaceID, "Broadcast 2", status, []byte("{}"), []byte("{}"), []byte("{}"), []byte("{}"), []byte("{}"),
-			"", nil, nil, 0, time.Now(), time.Now(), nil, nil, nil, nil, nil,
+			"", nil, nil, 0, 0, nil, false, false, "email", nil, time.Now(), time.Now(), nil, nil, nil, nil, nil,
 		)
 
 	// Expect query with limit/offset
@@ -1210,6 +1222,321 @@ func TestBroadcastRepository_ListBroadcasts_WithStatus(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestBroadcastRepository_ListBroadcasts_WithTagAndDates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	repo := NewBroadcastRepository(mockWorkspaceRepo)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+	workspaceID := "ws123"
+	createdAfter := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	createdBefore := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	mockWorkspaceRepo.EXPECT().
+		GetConnection(gomock.Any(), workspaceID).
+		Return(db, nil)
+
+	mock.ExpectBegin()
+
+	// Only the tagged broadcast matches the filters
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM broadcasts WHERE workspace_id = \$1 AND \$2 = ANY\(tags\) AND created_at >= \$3 AND created_at < \$4`).
+		WithArgs(workspaceID, "newsletter", createdAfter, createdBefore).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	rows := sqlmock.NewRows([]string{
+		"id", "workspace_id", "name", "status", "audience", "schedule",
+		"test_settings", "utm_parameters", "metadata",
+		"winning_template",
+		"test_sent_at", "winner_sent_at", "enqueued_count", "skipped_count", "tags", "dry_run", "plain_text_only", "channel_type", "seed_test",
+		"created_at", "updated_at",
+		"started_at", "completed_at", "cancelled_at", "paused_at", "pause_reason",
+	}).
+		AddRow(
+			"bc123", workspaceID, "Tagged Broadcast", domain.BroadcastStatusDraft, []byte("{}"), []byte("{}"), []byte("{}"), []byte("{}"), []byte("{}"),
+			"", nil, nil, 0, 0, []byte("{newsletter,promo}"), false, false, "email", nil, createdAfter.Add(time.Hour), createdAfter.Add(time.Hour), nil, nil, nil, nil, nil,
+		)
+
+	mock.ExpectQuery(`SELECT(.+)FROM broadcasts WHERE workspace_id = \$1 AND \$2 = ANY\(tags\)(.+)LIMIT \$5 OFFSET \$6`).
+		WithArgs(workspaceID, "newsletter", createdAfter, createdBefore, 10, 0).
+		WillReturnRows(rows)
+
+	mock.ExpectCommit()
+
+	result, err := repo.ListBroadcasts(ctx, domain.ListBroadcastsParams{
+		WorkspaceID:   workspaceID,
+		Tag:           "newsletter",
+		CreatedAfter:  &createdAfter,
+		CreatedBefore: &createdBefore,
+		Limit:         10,
+		Offset:        0,
+	})
+
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	assert.Equal(t, 1, result.TotalCount)
+	require.Len(t, result.Broadcasts, 1)
+	assert.Equal(t, "bc123", result.Broadcasts[0].ID)
+	assert.Equal(t, []string{"newsletter", "promo"}, result.Broadcasts[0].Tags)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+// newBroadcastListRows returns the rows of the broadcast list query, one per id, created an hour apart
+func newBroadcastListRows(workspaceID string, newest time.Time, ids ...string) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{
+		"id", "workspace_id", "name", "status", "audience", "schedule",
+		"test_settings", "utm_parameters", "metadata",
+		"winning_template",
+		"test_sent_at", "winner_sent_at", "enqueued_count", "skipped_count", "tags", "dry_run", "plain_text_only", "channel_type", "seed_test",
+		"created_at", "updated_at",
+		"started_at", "completed_at", "cancelled_at", "paused_at", "pause_reason",
+	})
+	for i, id := range ids {
+		createdAt := newest.Add(-time.Duration(i) * time.Hour)
+		rows.AddRow(
+			id, workspaceID, "Broadcast "+id, domain.BroadcastStatusProcessed, []byte("{}"), []byte("{}"), []byte("{}"), []byte("{}"), []byte("{}"),
+			"", nil, nil, 0, 0, nil, false, false, "email", nil, createdAt, createdAt, nil, createdAt, nil, nil, nil,
+		)
+	}
+	return rows
+}
+
+func TestBroadcastRepository_ListBroadcasts_WithSearchAndCompletedDates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	repo := NewBroadcastRepository(mockWorkspaceRepo)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+	workspaceID := "ws123"
+	status := domain.BroadcastStatusProcessed
+	completedAfter := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	completedBefore := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	mockWorkspaceRepo.EXPECT().
+		GetConnection(gomock.Any(), workspaceID).
+		Return(db, nil)
+
+	mock.ExpectBegin()
+
+	mock.ExpectQuery(`SELECT COUNT\(\*\) FROM broadcasts WHERE workspace_id = \$1 AND status = \$2 AND completed_at >= \$3 AND completed_at < \$4 AND name ILIKE \$5`).
+		WithArgs(workspaceID, status, completedAfter, completedBefore, "%spring%").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	mock.ExpectQuery(`SELECT(.+)FROM broadcasts WHERE workspace_id = \$1(.+)name ILIKE \$5(.+)LIMIT \$6 OFFSET \$7`).
+		WithArgs(workspaceID, status, completedAfter, completedBefore, "%spring%", 10, 0).
+		WillReturnRows(newBroadcastListRows(workspaceID, completedAfter.Add(24*time.Hour), "bc123"))
+
+	mock.ExpectCommit()
+
+	result, err := repo.ListBroadcasts(ctx, domain.ListBroadcastsParams{
+		WorkspaceID:     workspaceID,
+		Status:          status,
+		CompletedAfter:  &completedAfter,
+		CompletedBefore: &completedBefore,
+		Search:          "spring",
+		Limit:           10,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.TotalCount)
+	require.Len(t, result.Broadcasts, 1)
+	assert.Equal(t, "bc123", result.Broadcasts[0].ID)
+	assert.Empty(t, result.NextCursor)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBroadcastRepository_ListBroadcasts_CursorPagination(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	repo := NewBroadcastRepository(mockWorkspaceRepo)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+	workspaceID := "ws123"
+	newest := time.Date(2026, 5, 1, 12, 0, 0, 123456000, time.UTC)
+
+	mockWorkspaceRepo.EXPECT().
+		GetConnection(gomock.Any(), workspaceID).
+		Return(db, nil).
+		Times(3)
+
+	// First page: 2 of the 5 broadcasts
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT COUNT").
+		WithArgs(workspaceID).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(5))
+	mock.ExpectQuery(`SELECT(.+)FROM broadcasts(.+)ORDER BY created_at DESC, id DESC\s+LIMIT \$2 OFFSET \$3`).
+		WithArgs(workspaceID, 2, 0).
+		WillReturnRows(newBroadcastListRows(workspaceID, newest, "bc5", "bc4"))
+	mock.ExpectCommit()
+
+	firstPage, err := repo.ListBroadcasts(ctx, domain.ListBroadcastsParams{WorkspaceID: workspaceID, Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, firstPage.Broadcasts, 2)
+	require.NotEmpty(t, firstPage.NextCursor)
+
+	// The cursor continues after the last broadcast of the first page, to the nanosecond
+	cursorTime, cursorID, err := domain.DecodeBroadcastCursor(firstPage.NextCursor)
+	require.NoError(t, err)
+	assert.Equal(t, "bc4", cursorID)
+	assert.True(t, cursorTime.Equal(newest.Add(-time.Hour)))
+
+	// Second page: one extra row tells there are more
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT COUNT").
+		WithArgs(workspaceID).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(5))
+	mock.ExpectQuery(`SELECT(.+)FROM broadcasts WHERE workspace_id = \$1 AND \(created_at < \$2 OR \(created_at = \$2 AND id < \$3\)\)(.+)LIMIT \$4`).
+		WithArgs(workspaceID, cursorTime, "bc4", 3).
+		WillReturnRows(newBroadcastListRows(workspaceID, newest.Add(-2*time.Hour), "bc3", "bc2", "bc1"))
+	mock.ExpectCommit()
+
+	secondPage, err := repo.ListBroadcasts(ctx, domain.ListBroadcastsParams{WorkspaceID: workspaceID, Limit: 2, Cursor: firstPage.NextCursor})
+	require.NoError(t, err)
+	require.Len(t, secondPage.Broadcasts, 2)
+	assert.Equal(t, "bc3", secondPage.Broadcasts[0].ID)
+	assert.Equal(t, "bc2", secondPage.Broadcasts[1].ID)
+	assert.Equal(t, 5, secondPage.TotalCount)
+	require.NotEmpty(t, secondPage.NextCursor)
+
+	// Last page: no cursor
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT COUNT").
+		WithArgs(workspaceID).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(5))
+	mock.ExpectQuery(`SELECT(.+)FROM broadcasts(.+)LIMIT \$4`).
+		WithArgs(workspaceID, sqlmock.AnyArg(), "bc2", 3).
+		WillReturnRows(newBroadcastListRows(workspaceID, newest.Add(-4*time.Hour), "bc1"))
+	mock.ExpectCommit()
+
+	lastPage, err := repo.ListBroadcasts(ctx, domain.ListBroadcastsParams{WorkspaceID: workspaceID, Limit: 2, Cursor: secondPage.NextCursor})
+	require.NoError(t, err)
+	require.Len(t, lastPage.Broadcasts, 1)
+	assert.Equal(t, "bc1", lastPage.Broadcasts[0].ID)
+	assert.Empty(t, lastPage.NextCursor)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBroadcastRepository_SetBroadcastTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	repo := NewBroadcastRepository(mockWorkspaceRepo)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+	workspaceID := "ws123"
+
+	mockWorkspaceRepo.EXPECT().
+		GetConnection(gomock.Any(), workspaceID).
+		Return(db, nil).
+		Times(2)
+
+	t.Run("Success", func(t *testing.T) {
+		mock.ExpectExec("UPDATE broadcasts SET tags").
+			WithArgs("bc123", workspaceID, sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.SetBroadcastTags(ctx, workspaceID, "bc123", []string{"newsletter"})
+		require.NoError(t, err)
+	})
+
+	t.Run("Not found", func(t *testing.T) {
+		mock.ExpectExec("UPDATE broadcasts SET tags").
+			WithArgs("missing", workspaceID, sqlmock.AnyArg(), sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := repo.SetBroadcastTags(ctx, workspaceID, "missing", []string{"newsletter"})
+		require.Error(t, err)
+		var notFound *domain.ErrBroadcastNotFound
+		assert.ErrorAs(t, err, &notFound)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBroadcastRepository_ListBroadcastTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	repo := NewBroadcastRepository(mockWorkspaceRepo)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+	workspaceID := "ws123"
+
+	mockWorkspaceRepo.EXPECT().
+		GetConnection(gomock.Any(), workspaceID).
+		Return(db, nil)
+
+	mock.ExpectQuery(`SELECT tag, COUNT\(\*\) FROM broadcasts, unnest\(tags\) AS tag`).
+		WithArgs(workspaceID).
+		WillReturnRows(sqlmock.NewRows([]string{"tag", "count"}).
+			AddRow("newsletter", 3).
+			AddRow("promo", 1))
+
+	tags, err := repo.ListBroadcastTags(ctx, workspaceID)
+	require.NoError(t, err)
+	assert.Equal(t, []*domain.BroadcastTagCount{
+		{Tag: "newsletter", Count: 3},
+		{Tag: "promo", Count: 1},
+	}, tags)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestBroadcastRepository_DeleteBroadcastTag(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	repo := NewBroadcastRepository(mockWorkspaceRepo)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+	workspaceID := "ws123"
+
+	mockWorkspaceRepo.EXPECT().
+		GetConnection(gomock.Any(), workspaceID).
+		Return(db, nil)
+
+	mock.ExpectExec(`UPDATE broadcasts SET tags = array_remove\(tags, \$2\)`).
+		WithArgs(workspaceID, "promo", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	updated, err := repo.DeleteBroadcastTag(ctx, workspaceID, "promo")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), updated)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestBroadcastRepository_GetBroadcastTx(t *testing.T) {
 	// Test broadcastRepository.GetBroadcastTx - this was at 0% coverage
 	ctrl := gomock.NewController(t)
@@ -1235,14 +1562,14 @@ func TestBroadcastRepository_GetBroadcastTx(t *testing.T) {
 				"id", "workspace_id", "name", "status", "audience", "schedule",
 				"test_settings", "utm_parameters", "metadata",
 				"winning_template",
-				"test_sent_at", "winner_sent_at", "enqueued_count",
+				"test_sent_at", "winner_sent_at", "enqueued_count", "skipped_count", "tags", "dry_run", "plain_text_only", "channel_type", "seed_test",
 				"created_at", "updated_at",
 				"started_at", "completed_at", "cancelled_at", "paused_at", "pause_reason",
 			}).
 				AddRow(
 					broadcastID, workspaceID, "Test Broadcast", "draft",
 					[]byte("{}"), []byte("{}"), []byte("{}"), []byte("{}"), []byte("{}"),
-					"", nil, nil, 0, time.Now(), time.Now(), nil, nil, nil, nil, nil,
+					"", nil, nil, 0, 0, nil, false, false, "email", nil, time.Now(), time.Now(), nil, nil, nil, nil, nil,
 				))
 		sqlMock.ExpectCommit()
 
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -13,6 +14,7 @@ import (
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/lib/pq"
 )
 
 type contactRepository struct {
@@ -173,6 +175,59 @@ func (r *contactRepository) fetchContact(ctx context.Context, workspaceID string
 	return contact, nil
 }
 
+func (r *contactRepository) GetContactsByEmails(ctx context.Context, workspaceID string, emails []string) ([]*domain.Contact, error) {
+	return r.fetchContacts(ctx, workspaceID, sq.Eq{"c.email": emails})
+}
+
+func (r *contactRepository) GetContactsByExternalIDs(ctx context.Context, workspaceID string, externalIDs []string) ([]*domain.Contact, error) {
+	return r.fetchContacts(ctx, workspaceID, sq.Eq{"c.external_id": externalIDs})
+}
+
+// fetchContacts fetches the contacts matching a filter with their lists and segments,
+// using one query per table whatever the number of contacts
+func (r *contactRepository) fetchContacts(ctx context.Context, workspaceID string, filter sq.Sqlizer) ([]*domain.Contact, error) {
+	db, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	query, args, err := psql.Select(contactColumnsWithPrefix("c")...).
+		From("contacts c").
+		Where(filter).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	contacts := []*domain.Contact{}
+	for rows.Next() {
+		contact, err := domain.ScanContact(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan contact: %w", err)
+		}
+		contacts = append(contacts, contact)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over rows: %w", err)
+	}
+
+	if err := attachContactLists(ctx, db, contacts); err != nil {
+		return nil, err
+	}
+	if err := attachContactSegments(ctx, db, contacts); err != nil {
+		return nil, err
+	}
+
+	return contacts, nil
+}
+
 func (r *contactRepository) GetContacts(ctx context.Context, req *domain.GetContactsRequest) (*domain.GetContactsResponse, error) {
 	db, err := r.workspaceRepo.GetConnection(ctx, req.WorkspaceID)
 	if err != nil {
@@ -336,124 +391,143 @@ func (r *contactRepository) GetContacts(ctx context.Context, req *domain.GetCont
 	}
 
 	// If WithContactLists is true, fetch contact lists in a separate query
-	if req.WithContactLists && len(contacts) > 0 {
-		// Build list of contact emails
-		emails := make([]string, len(contacts))
-		for i, contact := range contacts {
-			emails[i] = contact.Email
+	if req.WithContactLists {
+		if err := attachContactLists(ctx, db, contacts); err != nil {
+			return nil, err
 		}
+	}
 
-		// Query for ALL contact lists for these contacts, regardless of filter criteria
-		listQueryBuilder := psql.Select("cl.email, cl.list_id, cl.status, cl.created_at, cl.updated_at, l.name as list_name").
-			From("contact_lists cl").
-			Join("lists l ON cl.list_id = l.id").
-			Where(sq.Eq{"cl.email": emails}).   // squirrel handles IN clauses automatically
-			Where(sq.Eq{"cl.deleted_at": nil}). // Filter out deleted contact_list entries
-			Where(sq.Eq{"l.deleted_at": nil})   // Filter out deleted lists
+	// Fetch contact segments for all contacts (always included)
+	if err := attachContactSegments(ctx, db, contacts); err != nil {
+		return nil, err
+	}
 
-		// We no longer apply the ListID and ContactListStatus filters here
-		// This way, we show ALL lists for each contact, not just the ones that match the filter
+	return &domain.GetContactsResponse{
+		Contacts:   contacts,
+		NextCursor: nextCursor,
+	}, nil
+}
 
-		listQuery, listArgs, err := listQueryBuilder.ToSql()
-		if err != nil {
-			return nil, fmt.Errorf("failed to build contact list query: %w", err)
-		}
+// attachContactLists loads the lists of all contacts in a single query
+func attachContactLists(ctx context.Context, db *sql.DB, contacts []*domain.Contact) error {
+	if len(contacts) == 0 {
+		return nil
+	}
 
-		listRows, err := db.QueryContext(ctx, listQuery, listArgs...)
-		if err != nil {
-			return nil, fmt.Errorf("failed to query contact lists: %w", err)
-		}
-		defer func() {
-			_ = listRows.Close()
-		}()
+	// Build list of contact emails
+	emails := make([]string, len(contacts))
+	for i, contact := range contacts {
+		emails[i] = contact.Email
+	}
 
-		// Create a map of contacts by email for quick lookup
-		contactMap := make(map[string]*domain.Contact)
-		for _, contact := range contacts {
-			contact.ContactLists = []*domain.ContactList{}
-			contactMap[contact.Email] = contact
-		}
+	// Query for ALL contact lists for these contacts, regardless of filter criteria
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	listQuery, listArgs, err := psql.Select("cl.email, cl.list_id, cl.status, cl.created_at, cl.updated_at, l.name as list_name").
+		From("contact_lists cl").
+		Join("lists l ON cl.list_id = l.id").
+		Where(sq.Eq{"cl.email": emails}).   // squirrel handles IN clauses automatically
+		Where(sq.Eq{"cl.deleted_at": nil}). // Filter out deleted contact_list entries
+		Where(sq.Eq{"l.deleted_at": nil}).  // Filter out deleted lists
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build contact list query: %w", err)
+	}
 
-		// Process contact list results
-		for listRows.Next() {
-			var email string
-			var list domain.ContactList
-			var listName string
-			err := listRows.Scan(&email, &list.ListID, &list.Status, &list.CreatedAt, &list.UpdatedAt, &listName)
-			if err != nil {
-				return nil, fmt.Errorf("failed to scan contact list: %w", err)
-			}
+	listRows, err := db.QueryContext(ctx, listQuery, listArgs...)
+	if err != nil {
+		return fmt.Errorf("failed to query contact lists: %w", err)
+	}
+	defer func() {
+		_ = listRows.Close()
+	}()
 
-			list.ListName = listName
-			if contact, ok := contactMap[email]; ok {
-				contact.ContactLists = append(contact.ContactLists, &list)
-			}
+	// Create a map of contacts by email for quick lookup
+	contactMap := make(map[string]*domain.Contact)
+	for _, contact := range contacts {
+		contact.ContactLists = []*domain.ContactList{}
+		contactMap[contact.Email] = contact
+	}
+
+	// Process contact list results
+	for listRows.Next() {
+		var email string
+		var list domain.ContactList
+		var listName string
+		err := listRows.Scan(&email, &list.ListID, &list.Status, &list.CreatedAt, &list.UpdatedAt, &listName)
+		if err != nil {
+			return fmt.Errorf("failed to scan contact list: %w", err)
 		}
 
-		if err = listRows.Err(); err != nil {
-			return nil, fmt.Errorf("error iterating over contact list rows: %w", err)
+		list.ListName = listName
+		if contact, ok := contactMap[email]; ok {
+			contact.ContactLists = append(contact.ContactLists, &list)
 		}
 	}
 
-	// Fetch contact segments for all contacts (always included)
-	if len(contacts) > 0 {
-		// Build list of contact emails
-		emails := make([]string, len(contacts))
-		for i, contact := range contacts {
-			emails[i] = contact.Email
-		}
+	if err = listRows.Err(); err != nil {
+		return fmt.Errorf("error iterating over contact list rows: %w", err)
+	}
+	return nil
+}
 
-		// Query for ALL contact segments for these contacts
-		segmentQueryBuilder := psql.Select("cs.email", "cs.segment_id", "cs.version", "cs.matched_at", "cs.computed_at", "s.name as segment_name", "s.color as segment_color").
-			From("contact_segments cs").
-			Join("segments s ON cs.segment_id = s.id").
-			Where(sq.Eq{"cs.email": emails}) // squirrel handles IN clauses automatically
+// attachContactSegments loads the segments of all contacts in a single query
+func attachContactSegments(ctx context.Context, db *sql.DB, contacts []*domain.Contact) error {
+	if len(contacts) == 0 {
+		return nil
+	}
 
-		segmentQuery, segmentArgs, err := segmentQueryBuilder.ToSql()
-		if err != nil {
-			return nil, fmt.Errorf("failed to build contact segment query: %w", err)
-		}
+	// Build list of contact emails
+	emails := make([]string, len(contacts))
+	for i, contact := range contacts {
+		emails[i] = contact.Email
+	}
 
-		segmentRows, err := db.QueryContext(ctx, segmentQuery, segmentArgs...)
-		if err != nil {
-			return nil, fmt.Errorf("failed to query contact segments: %w", err)
-		}
-		defer func() {
-			_ = segmentRows.Close()
-		}()
+	// Query for ALL contact segments for these contacts
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	segmentQuery, segmentArgs, err := psql.Select("cs.email", "cs.segment_id", "cs.version", "cs.matched_at", "cs.computed_at", "s.name as segment_name", "s.color as segment_color").
+		From("contact_segments cs").
+		Join("segments s ON cs.segment_id = s.id").
+		Where(sq.Eq{"cs.email": emails}). // squirrel handles IN clauses automatically
+		ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build contact segment query: %w", err)
+	}
 
-		// Create/use the map of contacts by email for quick lookup
-		contactMap := make(map[string]*domain.Contact)
-		for _, contact := range contacts {
-			contact.ContactSegments = []*domain.ContactSegment{}
-			contactMap[contact.Email] = contact
-		}
+	segmentRows, err := db.QueryContext(ctx, segmentQuery, segmentArgs...)
+	if err != nil {
+		return fmt.Errorf("failed to query contact segments: %w", err)
+	}
+	defer func() {
+		_ = segmentRows.Close()
+	}()
 
-		// Process contact segment results
-		for segmentRows.Next() {
-			var email string
-			var segment domain.ContactSegment
-			var segmentName, segmentColor string
-			err := segmentRows.Scan(&email, &segment.SegmentID, &segment.Version, &segment.MatchedAt, &segment.ComputedAt, &segmentName, &segmentColor)
-			if err != nil {
-				return nil, fmt.Errorf("failed to scan contact segment: %w", err)
-			}
+	// Create/use the map of contacts by email for quick lookup
+	contactMap := make(map[string]*domain.Contact)
+	for _, contact := range contacts {
+		contact.ContactSegments = []*domain.ContactSegment{}
+		contactMap[contact.Email] = contact
+	}
 
-			segment.Email = email
-			if contact, ok := contactMap[email]; ok {
-				contact.ContactSegments = append(contact.ContactSegments, &segment)
-			}
+	// Process contact segment results
+	for segmentRows.Next() {
+		var email string
+		var segment domain.ContactSegment
+		var segmentName, segmentColor string
+		err := segmentRows.Scan(&email, &segment.SegmentID, &segment.Version, &segment.MatchedAt, &segment.ComputedAt, &segmentName, &segmentColor)
+		if err != nil {
+			return fmt.Errorf("failed to scan contact segment: %w", err)
 		}
 
-		if err = segmentRows.Err(); err != nil {
-			return nil, fmt.Errorf("error iterating over contact segment rows: %w", err)
+		segment.Email = email
+		if contact, ok := contactMap[email]; ok {
+			contact.ContactSegments = append(contact.ContactSegments, &segment)
 		}
 	}
 
-	return &domain.GetContactsResponse{
-		Contacts:   contacts,
-		NextCursor: nextCursor,
-	}, nil
+	if err = segmentRows.Err(); err != nil {
+		return fmt.Errorf("error iterating over contact segment rows: %w", err)
+	}
+	return nil
 }
 
 func (r *contactRepository) DeleteContact(ctx context.Context, workspaceID string, email string) error {
@@ -488,6 +562,228 @@ func (r *contactRepository) DeleteContact(ctx context.Context, workspaceID strin
 	return nil
 }
 
+// contactRedactedColumns are the personal data columns cleared by RedactContact
+var contactRedactedColumns = []string{
+	"first_name", "last_name", "full_name", "phone",
+	"address_line_1", "address_line_2", "postcode",
+	"custom_string_1", "custom_string_2", "custom_string_3", "custom_string_4", "custom_string_5",
+}
+
+// RedactContact clears the personal data columns of a contact and deletes its list and segment
+// memberships in a single transaction, for erasure requests. The contact row and its email are
+// kept so that message history and timeline references stay consistent.
+func (r *contactRepository) RedactContact(ctx context.Context, workspaceID string, email string) (*domain.ContactRedactionResult, error) {
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	tx, err := workspaceDB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }() // No-op once committed
+
+	result, err := redactContactTx(ctx, tx, email)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return result, nil
+}
+
+// redactContactTx clears the personal data columns of a contact and deletes its list and segment
+// memberships within the given transaction
+func redactContactTx(ctx context.Context, tx *sql.Tx, email string) (*domain.ContactRedactionResult, error) {
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+
+	// Only rows that still hold personal data are updated, so a repeated redaction affects nothing
+	update := psql.Update("contacts")
+	notRedacted := sq.Or{}
+	for _, column := range contactRedactedColumns {
+		update = update.Set(column, nil)
+		notRedacted = append(notRedacted, sq.NotEq{column: nil})
+	}
+	updateQuery, updateArgs, err := update.
+		Set("db_updated_at", time.Now().UTC()).
+		Where(sq.Eq{"email": email}).
+		Where(notRedacted).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build redact query: %w", err)
+	}
+
+	result := &domain.ContactRedactionResult{}
+
+	res, err := tx.ExecContext(ctx, updateQuery, updateArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to redact contact: %w", err)
+	}
+	if result.Contacts, err = res.RowsAffected(); err != nil {
+		return nil, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	res, err = tx.ExecContext(ctx, `DELETE FROM contact_lists WHERE email = $1`, email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete contact list memberships: %w", err)
+	}
+	if result.ContactLists, err = res.RowsAffected(); err != nil {
+		return nil, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	res, err = tx.ExecContext(ctx, `DELETE FROM contact_segments WHERE email = $1`, email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to delete contact segment memberships: %w", err)
+	}
+	if result.ContactSegments, err = res.RowsAffected(); err != nil {
+		return nil, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	return result, nil
+}
+
+// FindDuplicatesByExternalID returns the contacts sharing an external ID with at least one other
+// contact, grouped by external ID. Groups are ordered by external ID and their contacts by creation
+// date, so the first contact of a group is the oldest.
+func (r *contactRepository) FindDuplicatesByExternalID(ctx context.Context, workspaceID string) ([]*domain.ContactDuplicateGroup, error) {
+	contacts, err := r.fetchContacts(ctx, workspaceID, sq.Expr(
+		`c.external_id IN (SELECT external_id FROM contacts WHERE external_id IS NOT NULL AND external_id <> '' GROUP BY external_id HAVING COUNT(*) > 1)`,
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(contacts, func(i, j int) bool {
+		if !contacts[i].CreatedAt.Equal(contacts[j].CreatedAt) {
+			return contacts[i].CreatedAt.Before(contacts[j].CreatedAt)
+		}
+		return contacts[i].Email < contacts[j].Email
+	})
+
+	groups := []*domain.ContactDuplicateGroup{}
+	groupsByExternalID := make(map[string]*domain.ContactDuplicateGroup)
+	for _, contact := range contacts {
+		if contact.ExternalID == nil || contact.ExternalID.IsNull {
+			continue
+		}
+		group, ok := groupsByExternalID[contact.ExternalID.String]
+		if !ok {
+			group = &domain.ContactDuplicateGroup{ExternalID: contact.ExternalID.String}
+			groupsByExternalID[group.ExternalID] = group
+			groups = append(groups, group)
+		}
+		group.Contacts = append(group.Contacts, contact)
+	}
+
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].ExternalID < groups[j].ExternalID
+	})
+
+	return groups, nil
+}
+
+// MergeContacts moves the list and segment memberships and the message history of duplicate contacts
+// to a primary contact, then clears the external ID of the duplicates and redacts them, in a single
+// transaction. Memberships are union-ed: the primary contact keeps its own status on the lists and
+// segments it already belongs to.
+func (r *contactRepository) MergeContacts(ctx context.Context, workspaceID string, primaryEmail string, duplicateEmails []string) (*domain.ContactMergeResult, error) {
+	if len(duplicateEmails) == 0 {
+		return nil, fmt.Errorf("no duplicate contacts to merge")
+	}
+	for _, email := range duplicateEmails {
+		if email == primaryEmail {
+			return nil, fmt.Errorf("primary contact %s can't be one of its duplicates", primaryEmail)
+		}
+	}
+
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	tx, err := workspaceDB.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }() // No-op once committed
+
+	var exists int
+	err = tx.QueryRowContext(ctx, `SELECT 1 FROM contacts WHERE email = $1 FOR UPDATE`, primaryEmail).Scan(&exists)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, domain.ErrContactNotFound
+		}
+		return nil, fmt.Errorf("failed to lock primary contact: %w", err)
+	}
+
+	result := &domain.ContactMergeResult{}
+
+	// A list shared by several duplicates takes the status of the most recently updated membership
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO contact_lists (email, list_id, status, created_at, updated_at)
+		SELECT DISTINCT ON (list_id) $1, list_id, status, created_at, $2
+		FROM contact_lists
+		WHERE email = ANY($3) AND deleted_at IS NULL
+		ORDER BY list_id, updated_at DESC
+		ON CONFLICT (email, list_id) DO NOTHING`,
+		primaryEmail, time.Now().UTC(), pq.Array(duplicateEmails))
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge contact list memberships: %w", err)
+	}
+	if result.ContactLists, err = res.RowsAffected(); err != nil {
+		return nil, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	res, err = tx.ExecContext(ctx, `
+		INSERT INTO contact_segments (email, segment_id, version, matched_at, computed_at)
+		SELECT DISTINCT ON (segment_id) $1, segment_id, version, matched_at, computed_at
+		FROM contact_segments
+		WHERE email = ANY($2)
+		ORDER BY segment_id, version DESC
+		ON CONFLICT (email, segment_id) DO NOTHING`,
+		primaryEmail, pq.Array(duplicateEmails))
+	if err != nil {
+		return nil, fmt.Errorf("failed to merge contact segment memberships: %w", err)
+	}
+	if result.ContactSegments, err = res.RowsAffected(); err != nil {
+		return nil, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	res, err = tx.ExecContext(ctx, `UPDATE message_history SET contact_email = $1 WHERE contact_email = ANY($2)`,
+		primaryEmail, pq.Array(duplicateEmails))
+	if err != nil {
+		return nil, fmt.Errorf("failed to reassign message history: %w", err)
+	}
+	if result.MessageHistory, err = res.RowsAffected(); err != nil {
+		return nil, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	// The duplicates lose their external ID so that they are not reported as duplicates again
+	_, err = tx.ExecContext(ctx, `UPDATE contacts SET external_id = NULL, db_updated_at = $1 WHERE email = ANY($2)`,
+		time.Now().UTC(), pq.Array(duplicateEmails))
+	if err != nil {
+		return nil, fmt.Errorf("failed to clear duplicate external IDs: %w", err)
+	}
+
+	for _, email := range duplicateEmails {
+		redacted, err := redactContactTx(ctx, tx, email)
+		if err != nil {
+			return nil, err
+		}
+		result.Redacted += redacted.Contacts
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return result, nil
+}
+
 func (r *contactRepository) UpsertContact(ctx context.Context, workspaceID string, contact *domain.Contact) (isNew bool, err error) {
 	// Get the workspace database connection
 	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
@@ -1137,6 +1433,17 @@ func (r *contactRepository) UpsertContact(ctx context.Context, workspaceID strin
 // It uses PostgreSQL's INSERT ... ON CONFLICT to efficiently handle both inserts and updates
 // Returns per-contact results indicating whether each was inserted (IsNew=true) or updated (IsNew=false)
 func (r *contactRepository) BulkUpsertContacts(ctx context.Context, workspaceID string, contacts []*domain.Contact) ([]domain.BulkUpsertResult, error) {
+	return r.UpsertContacts(ctx, workspaceID, contacts, domain.MergeStrategyOverwriteAll)
+}
+
+// UpsertContacts creates or updates multiple contacts with a single INSERT ... ON CONFLICT statement.
+// The merge strategy decides how the incoming values are merged into an existing row:
+// see upsertContactsConflictClause.
+func (r *contactRepository) UpsertContacts(ctx context.Context, workspaceID string, contacts []*domain.Contact, strategy domain.MergeStrategy) ([]domain.BulkUpsertResult, error) {
+	if err := strategy.Validate(); err != nil {
+		return nil, err
+	}
+
 	if len(contacts) == 0 {
 		return []domain.BulkUpsertResult{}, nil
 	}
@@ -1272,47 +1579,9 @@ func (r *contactRepository) BulkUpsertContacts(ctx context.Context, workspaceID
 		)
 	}
 
-	// Add ON CONFLICT clause with merge semantics
-	// For updates, we only update fields that were provided (non-null in the import)
-	// This preserves the merge behavior from the single upsert
+	// Add ON CONFLICT clause with the merge semantics of the strategy
+	queryBuilder.WriteString(upsertContactsConflictClause(strategy))
 	queryBuilder.WriteString(`
-	ON CONFLICT (email) DO UPDATE SET
-		external_id = CASE WHEN EXCLUDED.external_id IS NOT NULL THEN EXCLUDED.external_id ELSE contacts.external_id END,
-		timezone = CASE WHEN EXCLUDED.timezone IS NOT NULL THEN EXCLUDED.timezone ELSE contacts.timezone END,
-		language = CASE WHEN EXCLUDED.language IS NOT NULL THEN EXCLUDED.language ELSE contacts.language END,
-		first_name = CASE WHEN EXCLUDED.first_name IS NOT NULL THEN EXCLUDED.first_name ELSE contacts.first_name END,
-		last_name = CASE WHEN EXCLUDED.last_name IS NOT NULL THEN EXCLUDED.last_name ELSE contacts.last_name END,
-		full_name = CASE WHEN EXCLUDED.full_name IS NOT NULL THEN EXCLUDED.full_name ELSE contacts.full_name END,
-		phone = CASE WHEN EXCLUDED.phone IS NOT NULL THEN EXCLUDED.phone ELSE contacts.phone END,
-		address_line_1 = CASE WHEN EXCLUDED.address_line_1 IS NOT NULL THEN EXCLUDED.address_line_1 ELSE contacts.address_line_1 END,
-		address_line_2 = CASE WHEN EXCLUDED.address_line_2 IS NOT NULL THEN EXCLUDED.address_line_2 ELSE contacts.address_line_2 END,
-		country = CASE WHEN EXCLUDED.country IS NOT NULL THEN EXCLUDED.country ELSE contacts.country END,
-		postcode = CASE WHEN EXCLUDED.postcode IS NOT NULL THEN EXCLUDED.postcode ELSE contacts.postcode END,
-		state = CASE WHEN EXCLUDED.state IS NOT NULL THEN EXCLUDED.state ELSE contacts.state END,
-		job_title = CASE WHEN EXCLUDED.job_title IS NOT NULL THEN EXCLUDED.job_title ELSE contacts.job_title END,
-		custom_string_1 = CASE WHEN EXCLUDED.custom_string_1 IS NOT NULL THEN EXCLUDED.custom_string_1 ELSE contacts.custom_string_1 END,
-		custom_string_2 = CASE WHEN EXCLUDED.custom_string_2 IS NOT NULL THEN EXCLUDED.custom_string_2 ELSE contacts.custom_string_2 END,
-		custom_string_3 = CASE WHEN EXCLUDED.custom_string_3 IS NOT NULL THEN EXCLUDED.custom_string_3 ELSE contacts.custom_string_3 END,
-		custom_string_4 = CASE WHEN EXCLUDED.custom_string_4 IS NOT NULL THEN EXCLUDED.custom_string_4 ELSE contacts.custom_string_4 END,
-		custom_string_5 = CASE WHEN EXCLUDED.custom_string_5 IS NOT NULL THEN EXCLUDED.custom_string_5 ELSE contacts.custom_string_5 END,
-		custom_number_1 = CASE WHEN EXCLUDED.custom_number_1 IS NOT NULL THEN EXCLUDED.custom_number_1 ELSE contacts.custom_number_1 END,
-		custom_number_2 = CASE WHEN EXCLUDED.custom_number_2 IS NOT NULL THEN EXCLUDED.custom_number_2 ELSE contacts.custom_number_2 END,
-		custom_number_3 = CASE WHEN EXCLUDED.custom_number_3 IS NOT NULL THEN EXCLUDED.custom_number_3 ELSE contacts.custom_number_3 END,
-		custom_number_4 = CASE WHEN EXCLUDED.custom_number_4 IS NOT NULL THEN EXCLUDED.custom_number_4 ELSE contacts.custom_number_4 END,
-		custom_number_5 = CASE WHEN EXCLUDED.custom_number_5 IS NOT NULL THEN EXCLUDED.custom_number_5 ELSE contacts.custom_number_5 END,
-		custom_datetime_1 = CASE WHEN EXCLUDED.custom_datetime_1 IS NOT NULL THEN EXCLUDED.custom_datetime_1 ELSE contacts.custom_datetime_1 END,
-		custom_datetime_2 = CASE WHEN EXCLUDED.custom_datetime_2 IS NOT NULL THEN EXCLUDED.custom_datetime_2 ELSE contacts.custom_datetime_2 END,
-		custom_datetime_3 = CASE WHEN EXCLUDED.custom_datetime_3 IS NOT NULL THEN EXCLUDED.custom_datetime_3 ELSE contacts.custom_datetime_3 END,
-		custom_datetime_4 = CASE WHEN EXCLUDED.custom_datetime_4 IS NOT NULL THEN EXCLUDED.custom_datetime_4 ELSE contacts.custom_datetime_4 END,
-		custom_datetime_5 = CASE WHEN EXCLUDED.custom_datetime_5 IS NOT NULL THEN EXCLUDED.custom_datetime_5 ELSE contacts.custom_datetime_5 END,
-		custom_json_1 = CASE WHEN EXCLUDED.custom_json_1 IS NOT NULL THEN EXCLUDED.custom_json_1 ELSE contacts.custom_json_1 END,
-		custom_json_2 = CASE WHEN EXCLUDED.custom_json_2 IS NOT NULL THEN EXCLUDED.custom_json_2 ELSE contacts.custom_json_2 END,
-		custom_json_3 = CASE WHEN EXCLUDED.custom_json_3 IS NOT NULL THEN EXCLUDED.custom_json_3 ELSE contacts.custom_json_3 END,
-		custom_json_4 = CASE WHEN EXCLUDED.custom_json_4 IS NOT NULL THEN EXCLUDED.custom_json_4 ELSE contacts.custom_json_4 END,
-		custom_json_5 = CASE WHEN EXCLUDED.custom_json_5 IS NOT NULL THEN EXCLUDED.custom_json_5 ELSE contacts.custom_json_5 END,
-		created_at = EXCLUDED.created_at,
-		updated_at = EXCLUDED.updated_at,
-		db_updated_at = NOW()
 	RETURNING email, (xmax = 0) AS is_new`)
 
 	query := queryBuilder.String()
@@ -1346,6 +1615,66 @@ func (r *contactRepository) BulkUpsertContacts(ctx context.Context, workspaceID
 	return results, nil
 }
 
+// upsertContactsStringColumns are the text columns merged by UpsertContacts, where an empty string counts as empty
+var upsertContactsStringColumns = []string{
+	"external_id", "timezone", "language",
+	"first_name", "last_name", "full_name", "phone", "address_line_1", "address_line_2",
+	"country", "postcode", "state", "job_title",
+	"custom_string_1", "custom_string_2", "custom_string_3", "custom_string_4", "custom_string_5",
+}
+
+// upsertContactsValueColumns are the number, datetime and JSON columns merged by UpsertContacts
+var upsertContactsValueColumns = []string{
+	"custom_number_1", "custom_number_2", "custom_number_3", "custom_number_4", "custom_number_5",
+	"custom_datetime_1", "custom_datetime_2", "custom_datetime_3", "custom_datetime_4", "custom_datetime_5",
+	"custom_json_1", "custom_json_2", "custom_json_3", "custom_json_4", "custom_json_5",
+}
+
+// upsertContactsConflictClause builds the ON CONFLICT clause of UpsertContacts for a merge strategy:
+//   - OverwriteAll: every value provided by the incoming contact replaces the existing one
+//   - FillEmptyOnly: the incoming value is only used where the existing column is null (or an empty string),
+//     so fields that are already set, custom fields included, are preserved
+//   - IgnoreExisting: existing rows are not updated
+func upsertContactsConflictClause(strategy domain.MergeStrategy) string {
+	if strategy == domain.MergeStrategyIgnoreExisting {
+		return `
+	ON CONFLICT (email) DO NOTHING`
+	}
+
+	var sb strings.Builder
+	sb.WriteString(`
+	ON CONFLICT (email) DO UPDATE SET`)
+
+	for _, column := range upsertContactsStringColumns {
+		if strategy == domain.MergeStrategyFillEmptyOnly {
+			fmt.Fprintf(&sb, "\n\t\t%s = COALESCE(NULLIF(contacts.%s, ''), EXCLUDED.%s),", column, column, column)
+		} else {
+			fmt.Fprintf(&sb, "\n\t\t%s = COALESCE(EXCLUDED.%s, contacts.%s),", column, column, column)
+		}
+	}
+	for _, column := range upsertContactsValueColumns {
+		if strategy == domain.MergeStrategyFillEmptyOnly {
+			fmt.Fprintf(&sb, "\n\t\t%s = COALESCE(contacts.%s, EXCLUDED.%s),", column, column, column)
+		} else {
+			fmt.Fprintf(&sb, "\n\t\t%s = COALESCE(EXCLUDED.%s, contacts.%s),", column, column, column)
+		}
+	}
+
+	// Filling empty fields does not make the contact newer than its original creation
+	if strategy == domain.MergeStrategyFillEmptyOnly {
+		sb.WriteString(`
+		created_at = contacts.created_at,`)
+	} else {
+		sb.WriteString(`
+		created_at = EXCLUDED.created_at,`)
+	}
+	sb.WriteString(`
+		updated_at = EXCLUDED.updated_at,
+		db_updated_at = NOW()`)
+
+	return sb.String()
+}
+
 // GetContactsForBroadcast retrieves contacts based on broadcast audience settings
 // It supports filtering by lists, handling unsubscribed contacts, and deduplication
 // Uses cursor-based pagination with afterEmail for deterministic ordering (fixes Issue #157)
@@ -1368,7 +1697,8 @@ func (r *contactRepository) GetContactsForBroadcast(
 	var includeListID bool
 
 	// If we're filtering by list, include list_id in the result
-	if audience.List != "" {
+	lists := audience.TargetLists()
+	if len(lists) > 0 {
 		includeListID = true
 		// Build column list: all contact columns plus list_id and list_name
 		selectCols := append(contactColumnsWithPrefix("c"), "cl.list_id", "l.name as list_name")
@@ -1376,11 +1706,17 @@ func (r *contactRepository) GetContactsForBroadcast(
 			From("contacts c").
 			Join("contact_lists cl ON c.email = cl.email").
 			Join("lists l ON cl.list_id = l.id"). // Join with lists table to get the name
-			Where(sq.Eq{"cl.list_id": audience.List}).
+			Where(inTargetLists(lists)).
 			Where(sq.Eq{"l.deleted_at": nil}). // Filter out deleted lists
+			Where(notPendingCondition).        // Members waiting for their double opt-in confirmation are never sent to
 			Limit(uint64(limit)).
 			OrderBy("c.email ASC") // Sort by email only (unique, deterministic)
 
+		// A contact member of several of the lists is returned once, as a member of the first of them
+		if len(lists) > 1 {
+			query = query.Options("DISTINCT ON (c.email)").OrderByClause(listPriority(lists))
+		}
+
 		// Cursor-based pagination: fetch contacts with email > afterEmail
 		if afterEmail != "" {
 			query = query.Where(sq.Gt{"c.email": afterEmail})
@@ -1410,18 +1746,13 @@ func (r *contactRepository) GetContactsForBroadcast(
 	if len(audience.Segments) > 0 {
 		// If we already have list filtering, we need to add segments as an additional filter
 		// This means contacts must be in BOTH the specified list AND segments
-		if audience.List != "" {
-			// Join with contact_segments table in addition to the existing list joins
-			query = query.Join("contact_segments cs ON c.email = cs.email")
-			query = query.Where(sq.Eq{"cs.segment_id": audience.Segments})
+		if len(lists) > 0 {
+			// Filter on segment membership in addition to the existing list joins
+			query = inAnySegment(query, audience.Segments)
 		} else {
 			// No list filtering, so we're filtering by segments only
-			// We need to select from contacts and join with contact_segments
 			includeListID = false
-			query = psql.Select(contactColumnsWithPrefix("c")...).
-				From("contacts c").
-				Join("contact_segments cs ON c.email = cs.email").
-				Where(sq.Eq{"cs.segment_id": audience.Segments}).
+			query = inAnySegment(psql.Select(contactColumnsWithPrefix("c")...).From("contacts c"), audience.Segments).
 				Limit(uint64(limit)).
 				OrderBy("c.email ASC") // Sort by email only (unique, deterministic)
 
@@ -1432,6 +1763,10 @@ func (r *contactRepository) GetContactsForBroadcast(
 		}
 	}
 
+	// Exclude suppressed emails and contacts messaged within the audience window
+	query = excludeSuppressed(query)
+	query = excludeRecentlyMessaged(query, audience)
+
 	// Build the final query
 	sqlQuery, args, err := query.ToSql()
 	if err != nil {
@@ -1643,23 +1978,89 @@ func (r *contactRepository) CountContactsForBroadcast(
 		return 0, fmt.Errorf("failed to get workspace connection: %w", err)
 	}
 
+	// Build and execute the query
+	sqlQuery, args, err := broadcastAudienceCountQuery(audience, audienceCount(audience)).ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build count query: %w", err)
+	}
+
+	var count int
+	err = db.QueryRowContext(ctx, sqlQuery, args...).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to execute count query: %w", err)
+	}
+
+	return count, nil
+}
+
+// CountDeliverableContactsForBroadcast counts the raw broadcast audience, including unsubscribed
+// contacts, and the part of it that can receive email. A contact is not deliverable when it
+// unsubscribed from, bounced or complained on the broadcast lists, or bounced or complained on any list.
+// Suppressed emails are part of neither count, as they are never sent to.
+func (r *contactRepository) CountDeliverableContactsForBroadcast(
+	ctx context.Context,
+	workspaceID string,
+	audience domain.AudienceSettings,
+) (int, int, error) {
+	db, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	deliverable := fmt.Sprintf(`NOT EXISTS (
+		SELECT 1 FROM contact_lists sup
+		WHERE sup.email = c.email AND sup.deleted_at IS NULL AND sup.status IN ('%s', '%s')
+	)`, domain.ContactListStatusBounced, domain.ContactListStatusComplained)
+	if len(audience.TargetLists()) > 0 {
+		deliverable = fmt.Sprintf("cl.status NOT IN ('%s', '%s', '%s') AND %s",
+			domain.ContactListStatusUnsubscribed, domain.ContactListStatusBounced, domain.ContactListStatusComplained, deliverable)
+	}
+
+	// The raw audience ignores the unsubscribed exclusion so the two counts can be compared
+	raw := audience
+	raw.ExcludeUnsubscribed = false
+
+	count := audienceCount(audience)
+	sqlQuery, args, err := broadcastAudienceCountQuery(raw, count, count+" FILTER (WHERE "+deliverable+")").ToSql()
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to build deliverable count query: %w", err)
+	}
+
+	var rawCount, deliverableCount int
+	err = db.QueryRowContext(ctx, sqlQuery, args...).Scan(&rawCount, &deliverableCount)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to execute deliverable count query: %w", err)
+	}
+
+	return rawCount, deliverableCount, nil
+}
+
+// notPendingCondition excludes the list members who have not confirmed their double opt-in subscription yet
+var notPendingCondition = sq.NotEq{"cl.status": domain.ContactListStatusPending}
+
+// broadcastAudienceCountQuery builds the recipient selection of a broadcast audience
+// with the given aggregate columns, matching GetContactsForBroadcast filters
+func broadcastAudienceCountQuery(audience domain.AudienceSettings, columns ...string) sq.SelectBuilder {
 	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
 
 	// Start building the count query
-	query := psql.Select("COUNT(*)").
+	query := psql.Select(columns...).
 		From("contacts c")
 
 	// Handle list filtering
-	if audience.List != "" {
+	lists := audience.TargetLists()
+	if len(lists) > 0 {
 		// Join with contact_lists table to filter by list membership and status
 		query = query.Join("contact_lists cl ON c.email = cl.email")
 		// Join with lists table to filter by list deletion status (matches GetContactsForBroadcast)
 		query = query.Join("lists l ON cl.list_id = l.id")
 
-		// Filter by the specified list
-		query = query.Where(sq.Eq{"cl.list_id": audience.List})
+		// Filter by the specified lists
+		query = query.Where(inTargetLists(lists))
 		// Filter out soft-deleted lists (matches GetContactsForBroadcast)
 		query = query.Where(sq.Eq{"l.deleted_at": nil})
+		// Exclude members waiting for their double opt-in confirmation (matches GetContactsForBroadcast)
+		query = query.Where(notPendingCondition)
 
 		// Exclude unsubscribed contacts if required
 		if audience.ExcludeUnsubscribed {
@@ -1673,32 +2074,82 @@ func (r *contactRepository) CountContactsForBroadcast(
 	if len(audience.Segments) > 0 {
 		// If we already have list filtering, we need to add segments as an additional filter
 		// This means contacts must be in BOTH the specified list AND segments
-		if audience.List != "" {
-			// Join with contact_segments table in addition to the existing list joins
-			query = query.Join("contact_segments cs ON c.email = cs.email")
-			query = query.Where(sq.Eq{"cs.segment_id": audience.Segments})
+		if len(lists) > 0 {
+			// Filter on segment membership in addition to the existing list joins
+			query = inAnySegment(query, audience.Segments)
 		} else {
 			// No list filtering, so we're filtering by segments only
-			query = psql.Select("COUNT(*)").
-				From("contacts c").
-				Join("contact_segments cs ON c.email = cs.email").
-				Where(sq.Eq{"cs.segment_id": audience.Segments})
+			query = inAnySegment(psql.Select(columns...).From("contacts c"), audience.Segments)
 		}
 	}
 
-	// Build and execute the query
-	sqlQuery, args, err := query.ToSql()
-	if err != nil {
-		return 0, fmt.Errorf("failed to build count query: %w", err)
+	return excludeRecentlyMessaged(excludeSuppressed(query), audience)
+}
+
+// inTargetLists keeps the memberships of the lists of a broadcast audience. A contact member of several
+// of the lists has a row per list.
+func inTargetLists(lists []string) sq.Eq {
+	if len(lists) == 1 {
+		return sq.Eq{"cl.list_id": lists[0]}
 	}
+	return sq.Eq{"cl.list_id": lists}
+}
 
-	var count int
-	err = db.QueryRowContext(ctx, sqlQuery, args...).Scan(&count)
-	if err != nil {
-		return 0, fmt.Errorf("failed to execute count query: %w", err)
+// listPriority orders the memberships of a contact by the position of their list in the audience lists
+func listPriority(lists []string) sq.Sqlizer {
+	var sb strings.Builder
+	args := make([]interface{}, len(lists))
+	sb.WriteString("CASE cl.list_id")
+	for i, listID := range lists {
+		fmt.Fprintf(&sb, " WHEN ? THEN %d", i)
+		args[i] = listID
+	}
+	sb.WriteString(" END")
+	return sq.Expr(sb.String(), args...)
+}
+
+// audienceCount returns the count column of a broadcast audience, a contact member of several of
+// its lists is counted once
+func audienceCount(audience domain.AudienceSettings) string {
+	if len(audience.TargetLists()) > 1 {
+		return "COUNT(DISTINCT c.email)"
 	}
+	return "COUNT(*)"
+}
 
-	return count, nil
+// inAnySegment keeps the contacts that are members of at least one of the segments. It is an EXISTS
+// condition rather than a join, so a contact in several of the segments is a single recipient and is
+// counted once, and the cursor pagination on the email never returns it twice.
+func inAnySegment(query sq.SelectBuilder, segments []string) sq.SelectBuilder {
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(segments)), ",")
+	args := make([]interface{}, len(segments))
+	for i, segmentID := range segments {
+		args[i] = segmentID
+	}
+
+	return query.Where(sq.Expr(
+		fmt.Sprintf("EXISTS (SELECT 1 FROM contact_segments cs WHERE cs.email = c.email AND cs.segment_id IN (%s))", placeholders),
+		args...,
+	))
+}
+
+// excludeSuppressed filters out the contacts whose email is on the workspace suppression list
+func excludeSuppressed(query sq.SelectBuilder) sq.SelectBuilder {
+	return query.Where(`NOT EXISTS (SELECT 1 FROM email_suppressions es WHERE es.email = c.email)`)
+}
+
+// excludeRecentlyMessaged filters out the contacts that received a message within the last
+// MinHoursSinceLastMessage hours. It is a NOT EXISTS condition on the contact email, so the
+// audience keeps one row per contact and the cursor pagination is unaffected.
+func excludeRecentlyMessaged(query sq.SelectBuilder, audience domain.AudienceSettings) sq.SelectBuilder {
+	if audience.MinHoursSinceLastMessage <= 0 {
+		return query
+	}
+
+	return query.Where(sq.Expr(`NOT EXISTS (
+		SELECT 1 FROM message_history mh
+		WHERE mh.contact_email = c.email AND mh.sent_at > NOW() - make_interval(hours => ?)
+	)`, audience.MinHoursSinceLastMessage))
 }
 
 // Count returns the total number of contacts in a workspace
@@ -1726,6 +2177,77 @@ func (r *contactRepository) Count(ctx context.Context, workspaceID string) (int,
 	return count, nil
 }
 
+// GetAudienceCounts counts the active subscribers of each list and the members of each segment
+// Lists and segments that were deleted are excluded, empty ones are returned with a zero count
+func (r *contactRepository) GetAudienceCounts(ctx context.Context, workspaceID string) (*domain.AudienceCounts, error) {
+	db, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	counts := &domain.AudienceCounts{
+		Lists:    []domain.ListContactCount{},
+		Segments: []domain.SegmentContactCount{},
+	}
+
+	listQuery := `
+		SELECT l.id, l.name, COUNT(cl.email) AS active_count
+		FROM lists l
+		LEFT JOIN contact_lists cl ON cl.list_id = l.id
+			AND cl.status = 'active'
+			AND cl.deleted_at IS NULL
+		WHERE l.deleted_at IS NULL
+		GROUP BY l.id, l.name
+		ORDER BY l.name ASC
+	`
+
+	listRows, err := db.QueryContext(ctx, listQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count list contacts: %w", err)
+	}
+	defer func() { _ = listRows.Close() }()
+
+	for listRows.Next() {
+		var count domain.ListContactCount
+		if err := listRows.Scan(&count.ListID, &count.Name, &count.ActiveCount); err != nil {
+			return nil, fmt.Errorf("failed to scan list count: %w", err)
+		}
+		counts.Lists = append(counts.Lists, count)
+	}
+	if err := listRows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating list counts: %w", err)
+	}
+
+	// Only memberships of the current segment version are counted, as in GetSegments
+	segmentQuery := `
+		SELECT s.id, s.name, COUNT(cs.email) AS contact_count
+		FROM segments s
+		LEFT JOIN contact_segments cs ON cs.segment_id = s.id AND cs.version = s.version
+		WHERE s.status != 'deleted'
+		GROUP BY s.id, s.name
+		ORDER BY s.name ASC
+	`
+
+	segmentRows, err := db.QueryContext(ctx, segmentQuery)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count segment contacts: %w", err)
+	}
+	defer func() { _ = segmentRows.Close() }()
+
+	for segmentRows.Next() {
+		var count domain.SegmentContactCount
+		if err := segmentRows.Scan(&count.SegmentID, &count.Name, &count.ContactCount); err != nil {
+			return nil, fmt.Errorf("failed to scan segment count: %w", err)
+		}
+		counts.Segments = append(counts.Segments, count)
+	}
+	if err := segmentRows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating segment counts: %w", err)
+	}
+
+	return counts, nil
+}
+
 // GetBatchForSegment retrieves a batch of email addresses for segment processing
 // Optimized to only fetch emails instead of full contact objects
 func (r *contactRepository) GetBatchForSegment(ctx context.Context, workspaceID string, offset int64, limit int) ([]string, error) {
@@ -1762,3 +2284,148 @@ func (r *contactRepository) GetBatchForSegment(ctx context.Context, workspaceID
 
 	return emails, nil
 }
+
+// GetOptimalSendHours returns the UTC hour of day each contact most frequently opened messages at,
+// ties going to the earliest hour. Contacts without any opened message are left out of the map.
+func (r *contactRepository) GetOptimalSendHours(ctx context.Context, workspaceID string, emails []string) (map[string]int, error) {
+	hours := make(map[string]int)
+	if len(emails) == 0 {
+		return hours, nil
+	}
+
+	db, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	query := `
+		SELECT DISTINCT ON (contact_email) contact_email, EXTRACT(HOUR FROM opened_at AT TIME ZONE 'UTC')::int AS open_hour
+		FROM message_history
+		WHERE contact_email = ANY($1) AND opened_at IS NOT NULL
+		GROUP BY contact_email, open_hour
+		ORDER BY contact_email, COUNT(*) DESC, open_hour ASC
+	`
+
+	rows, err := db.QueryContext(ctx, query, pq.Array(emails))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query open hours: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var email string
+		var hour int
+		if err := rows.Scan(&email, &hour); err != nil {
+			return nil, fmt.Errorf("failed to scan open hour: %w", err)
+		}
+		hours[email] = hour
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating open hours: %w", err)
+	}
+
+	return hours, nil
+}
+
+// AddSuppressions adds emails to the workspace suppression list. An email already suppressed keeps
+// its first reason, so that a later complaint does not hide the bounce that suppressed it.
+func (r *contactRepository) AddSuppressions(ctx context.Context, workspaceID string, suppressions []*domain.EmailSuppression) error {
+	if len(suppressions) == 0 {
+		return nil
+	}
+
+	db, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	insert := psql.Insert("email_suppressions").
+		Columns("email", "reason", "message_id", "created_at").
+		Suffix("ON CONFLICT (email) DO NOTHING")
+	for _, suppression := range suppressions {
+		insert = insert.Values(suppression.Email, string(suppression.Reason), suppression.MessageID, suppression.CreatedAt)
+	}
+
+	query, args, err := insert.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build insert query: %w", err)
+	}
+
+	if _, err := db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to add suppressions: %w", err)
+	}
+
+	return nil
+}
+
+// ListSuppressions lists the suppressed emails ordered by email, paginated with the last email of the previous page
+func (r *contactRepository) ListSuppressions(ctx context.Context, workspaceID string, limit int, afterEmail string) ([]*domain.EmailSuppression, error) {
+	db, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	query := psql.Select("email", "reason", "message_id", "created_at").
+		From("email_suppressions").
+		OrderBy("email ASC").
+		Limit(uint64(limit))
+	if afterEmail != "" {
+		query = query.Where(sq.Gt{"email": afterEmail})
+	}
+
+	sqlQuery, args, err := query.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := db.QueryContext(ctx, sqlQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list suppressions: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	suppressions := []*domain.EmailSuppression{}
+	for rows.Next() {
+		var suppression domain.EmailSuppression
+		var messageID sql.NullString
+		if err := rows.Scan(&suppression.Email, &suppression.Reason, &messageID, &suppression.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan suppression: %w", err)
+		}
+		if messageID.Valid {
+			suppression.MessageID = &messageID.String
+		}
+		suppressions = append(suppressions, &suppression)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating suppressions: %w", err)
+	}
+
+	return suppressions, nil
+}
+
+// RemoveSuppression removes an email from the workspace suppression list
+func (r *contactRepository) RemoveSuppression(ctx context.Context, workspaceID string, email string) error {
+	db, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	result, err := db.ExecContext(ctx, `DELETE FROM email_suppressions WHERE email = $1`, email)
+	if err != nil {
+		return fmt.Errorf("failed to remove suppression: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if rows == 0 {
+		return domain.ErrSuppressionNotFound
+	}
+
+	return nil
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	sq "github.com/Masterminds/squirrel"
 	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -25,6 +26,9 @@ import (
 // This matches the contactColumnsWithPrefix("c") output in contact_postgres.go.
 const contactColumnsPattern = `c\.email, c\.external_id, c\.timezone, c\.language, c\.first_name, c\.last_name, c\.full_name, c\.phone, c\.address_line_1, c\.address_line_2, c\.country, c\.postcode, c\.state, c\.job_title, c\.custom_string_1, c\.custom_string_2, c\.custom_string_3, c\.custom_string_4, c\.custom_string_5, c\.custom_number_1, c\.custom_number_2, c\.custom_number_3, c\.custom_number_4, c\.custom_number_5, c\.custom_datetime_1, c\.custom_datetime_2, c\.custom_datetime_3, c\.custom_datetime_4, c\.custom_datetime_5, c\.custom_json_1, c\.custom_json_2, c\.custom_json_3, c\.custom_json_4, c\.custom_json_5, c\.created_at, c\.updated_at, c\.db_created_at, c\.db_updated_at`
 
+// notSuppressedPattern matches the exclusion of suppressed emails from broadcast audiences
+const notSuppressedPattern = `NOT EXISTS \(SELECT 1 FROM email_suppressions es WHERE es\.email = c\.email\)`
+
 // setupMockDB creates a mock database and sqlmock for testing
 func setupMockDB(t *testing.T) (*sql.DB, sqlmock.Sqlmock, func()) {
 	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherRegexp))
@@ -381,6 +385,131 @@ func TestFetchContact(t *testing.T) {
 	})
 }
 
+func TestGetContactsByEmailsAndExternalIDs(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Microsecond)
+
+	newContactRows := func() *sqlmock.Rows {
+		rows := sqlmock.NewRows([]string{
+			"email", "external_id", "timezone", "language", "first_name", "last_name", "full_name",
+			"phone", "address_line_1", "address_line_2", "country", "postcode", "state",
+			"job_title", "custom_string_1", "custom_string_2", "custom_string_3", "custom_string_4",
+			"custom_string_5", "custom_number_1", "custom_number_2", "custom_number_3",
+			"custom_number_4", "custom_number_5", "custom_datetime_1", "custom_datetime_2",
+			"custom_datetime_3", "custom_datetime_4", "custom_datetime_5",
+			"custom_json_1", "custom_json_2", "custom_json_3", "custom_json_4",
+			"custom_json_5", "created_at", "updated_at", "db_created_at", "db_updated_at",
+		})
+		for i, email := range []string{"a@example.com", "b@example.com"} {
+			rows.AddRow(
+				email, fmt.Sprintf("ext%d", i+1), nil, nil, nil, nil, nil,
+				nil, nil, nil, nil, nil, nil,
+				nil, nil, nil, nil, nil,
+				nil, nil, nil, nil,
+				nil, nil, nil, nil,
+				nil, nil, nil,
+				nil, nil, nil, nil,
+				nil, now, now, now, now,
+			)
+		}
+		return rows
+	}
+
+	expectListsAndSegments := func(mock sqlmock.Sqlmock) {
+		mock.ExpectQuery(`SELECT cl\.email, cl\.list_id, cl\.status, cl\.created_at, cl\.updated_at, l\.name as list_name FROM contact_lists cl JOIN lists l ON cl\.list_id = l\.id WHERE cl\.email IN \(\$1,\$2\) AND cl\.deleted_at IS NULL AND l\.deleted_at IS NULL`).
+			WithArgs("a@example.com", "b@example.com").
+			WillReturnRows(sqlmock.NewRows([]string{"email", "list_id", "status", "created_at", "updated_at", "list_name"}).
+				AddRow("a@example.com", "list1", "active", now, now, "Marketing List").
+				AddRow("b@example.com", "list1", "unsubscribed", now, now, "Marketing List"))
+
+		mock.ExpectQuery(`SELECT cs\.email, cs\.segment_id, cs\.version, cs\.matched_at, cs\.computed_at, s\.name as segment_name, s\.color as segment_color FROM contact_segments cs JOIN segments s ON cs\.segment_id = s\.id WHERE cs\.email IN \(\$1,\$2\)`).
+			WithArgs("a@example.com", "b@example.com").
+			WillReturnRows(sqlmock.NewRows([]string{"email", "segment_id", "version", "matched_at", "computed_at", "segment_name", "segment_color"}).
+				AddRow("b@example.com", "segment1", int64(1), now, now, "Active Users", "#FF5733"))
+	}
+
+	assertContacts := func(t *testing.T, contacts []*domain.Contact) {
+		require.Len(t, contacts, 2)
+		assert.Equal(t, "a@example.com", contacts[0].Email)
+		require.Len(t, contacts[0].ContactLists, 1)
+		assert.Equal(t, domain.ContactListStatusActive, contacts[0].ContactLists[0].Status)
+		assert.Empty(t, contacts[0].ContactSegments)
+		assert.Equal(t, "b@example.com", contacts[1].Email)
+		require.Len(t, contacts[1].ContactLists, 1)
+		assert.Equal(t, domain.ContactListStatusUnsubscribed, contacts[1].ContactLists[0].Status)
+		require.Len(t, contacts[1].ContactSegments, 1)
+		assert.Equal(t, "segment1", contacts[1].ContactSegments[0].SegmentID)
+	}
+
+	setup := func(t *testing.T) (domain.ContactRepository, sqlmock.Sqlmock, func()) {
+		db, mock, cleanup := setupMockDB(t)
+		ctrl := gomock.NewController(t)
+		workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+		workspaceRepo.EXPECT().GetConnection(gomock.Any(), "workspace123").Return(db, nil).AnyTimes()
+		return NewContactRepository(workspaceRepo), mock, func() {
+			ctrl.Finish()
+			cleanup()
+		}
+	}
+
+	t.Run("by emails", func(t *testing.T) {
+		repo, mock, cleanup := setup(t)
+		defer cleanup()
+
+		mock.ExpectQuery(`SELECT ` + contactColumnsPattern + ` FROM contacts c WHERE c\.email IN \(\$1,\$2,\$3\)`).
+			WithArgs("a@example.com", "b@example.com", "missing@example.com").
+			WillReturnRows(newContactRows())
+		expectListsAndSegments(mock)
+
+		contacts, err := repo.GetContactsByEmails(context.Background(), "workspace123", []string{"a@example.com", "b@example.com", "missing@example.com"})
+		require.NoError(t, err)
+		assertContacts(t, contacts)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("by external IDs", func(t *testing.T) {
+		repo, mock, cleanup := setup(t)
+		defer cleanup()
+
+		mock.ExpectQuery(`SELECT ` + contactColumnsPattern + ` FROM contacts c WHERE c\.external_id IN \(\$1,\$2\)`).
+			WithArgs("ext1", "ext2").
+			WillReturnRows(newContactRows())
+		expectListsAndSegments(mock)
+
+		contacts, err := repo.GetContactsByExternalIDs(context.Background(), "workspace123", []string{"ext1", "ext2"})
+		require.NoError(t, err)
+		assertContacts(t, contacts)
+		assert.Equal(t, "ext1", contacts[0].ExternalID.String)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("no match skips list and segment queries", func(t *testing.T) {
+		repo, mock, cleanup := setup(t)
+		defer cleanup()
+
+		mock.ExpectQuery(`SELECT ` + contactColumnsPattern + ` FROM contacts c WHERE c\.email IN \(\$1\)`).
+			WithArgs("missing@example.com").
+			WillReturnRows(sqlmock.NewRows(contactColumns))
+
+		contacts, err := repo.GetContactsByEmails(context.Background(), "workspace123", []string{"missing@example.com"})
+		require.NoError(t, err)
+		assert.Empty(t, contacts)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		repo, mock, cleanup := setup(t)
+		defer cleanup()
+
+		mock.ExpectQuery(`SELECT ` + contactColumnsPattern + ` FROM contacts c WHERE c\.external_id IN \(\$1\)`).
+			WithArgs("ext1").
+			WillReturnError(errors.New("connection reset"))
+
+		_, err := repo.GetContactsByExternalIDs(context.Background(), "workspace123", []string{"ext1"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "connection reset")
+	})
+}
+
 func TestGetContacts(t *testing.T) {
 	t.Run("should get contacts with pagination", func(t *testing.T) {
 		// Create a mock workspace database
@@ -1370,8 +1499,9 @@ func TestGetContactsForBroadcast(t *testing.T) {
 			)
 
 			// Expect query with JOINS for list filtering and excludeUnsubscribed (cursor-based pagination)
-		mock.ExpectQuery(`SELECT ` + contactColumnsPattern + `, cl\.list_id, l\.name as list_name FROM contacts c JOIN contact_lists cl ON c\.email = cl\.email JOIN lists l ON cl\.list_id = l\.id WHERE cl\.list_id = \$1 AND l\.deleted_at IS NULL AND cl\.status <> \$2 AND cl\.status <> \$3 AND cl\.status <> \$4 ORDER BY c\.email ASC LIMIT 10`).
+		mock.ExpectQuery(`SELECT ` + contactColumnsPattern + `, cl\.list_id, l\.name as list_name FROM contacts c JOIN contact_lists cl ON c\.email = cl\.email JOIN lists l ON cl\.list_id = l\.id WHERE cl\.list_id = \$1 AND l\.deleted_at IS NULL AND cl\.status <> \$2 AND cl\.status <> \$3 AND cl\.status <> \$4 AND cl\.status <> \$5 AND ` + notSuppressedPattern + ` ORDER BY c\.email ASC LIMIT 10`).
 			WithArgs("list1",
+				domain.ContactListStatusPending,
 				domain.ContactListStatusUnsubscribed,
 				domain.ContactListStatusBounced,
 				domain.ContactListStatusComplained).
@@ -1449,7 +1579,7 @@ func TestGetContactsForBroadcast(t *testing.T) {
 			)
 
 		// Expect query without JOINS for all contacts (cursor-based pagination)
-		mock.ExpectQuery(`SELECT ` + contactColumnsPattern + ` FROM contacts c ORDER BY c\.email ASC LIMIT 10`).
+		mock.ExpectQuery(`SELECT ` + contactColumnsPattern + ` FROM contacts c WHERE ` + notSuppressedPattern + ` ORDER BY c\.email ASC LIMIT 10`).
 			WillReturnRows(rows)
 
 		// Call the method being tested (empty string for first batch cursor)
@@ -1517,8 +1647,9 @@ func TestGetContactsForBroadcast(t *testing.T) {
 		}
 
 		// Expect query with error (cursor-based pagination)
-		mock.ExpectQuery(`SELECT ` + contactColumnsPattern + `, cl\.list_id, l\.name as list_name FROM contacts c JOIN contact_lists cl ON c\.email = cl\.email JOIN lists l ON cl\.list_id = l\.id WHERE cl\.list_id = \$1 AND l\.deleted_at IS NULL AND cl\.status <> \$2 AND cl\.status <> \$3 AND cl\.status <> \$4 ORDER BY c\.email ASC LIMIT 10`).
+		mock.ExpectQuery(`SELECT ` + contactColumnsPattern + `, cl\.list_id, l\.name as list_name FROM contacts c JOIN contact_lists cl ON c\.email = cl\.email JOIN lists l ON cl\.list_id = l\.id WHERE cl\.list_id = \$1 AND l\.deleted_at IS NULL AND cl\.status <> \$2 AND cl\.status <> \$3 AND cl\.status <> \$4 AND cl\.status <> \$5 AND ` + notSuppressedPattern + ` ORDER BY c\.email ASC LIMIT 10`).
 			WithArgs("list1",
+				domain.ContactListStatusPending,
 				domain.ContactListStatusUnsubscribed,
 				domain.ContactListStatusBounced,
 				domain.ContactListStatusComplained).
@@ -1554,7 +1685,7 @@ func TestGetContactsForBroadcast(t *testing.T) {
 		}
 
 		// Set up expectations for the query
-		// When selecting from contacts with segment filtering, we should see a JOIN with contact_segments
+		// When selecting from contacts with segment filtering, we should see an EXISTS on contact_segments
 		createdAt1 := time.Now().UTC().Add(-24 * time.Hour)
 		createdAt2 := time.Now().UTC()
 		rows := sqlmock.NewRows([]string{
@@ -1573,8 +1704,8 @@ func TestGetContactsForBroadcast(t *testing.T) {
 				nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
 				nil, nil, nil, nil, nil, createdAt2, createdAt2, createdAt2, createdAt2)
 
-		// Expect the query to join contacts with contact_segments (cursor-based pagination)
-		mock.ExpectQuery(`SELECT ` + contactColumnsPattern + ` FROM contacts c JOIN contact_segments cs ON c\.email = cs\.email WHERE cs\.segment_id IN \(\$1\) ORDER BY c\.email ASC LIMIT 10`).
+		// Expect the query to filter contacts on contact_segments membership (cursor-based pagination)
+		mock.ExpectQuery(`SELECT ` + contactColumnsPattern + ` FROM contacts c WHERE EXISTS \(SELECT 1 FROM contact_segments cs WHERE cs\.email = c\.email AND cs\.segment_id IN \(\$1\)\) AND ` + notSuppressedPattern + ` ORDER BY c\.email ASC LIMIT 10`).
 			WithArgs("segment1").
 			WillReturnRows(rows)
 
@@ -1592,6 +1723,137 @@ func TestGetContactsForBroadcast(t *testing.T) {
 	})
 }
 
+func TestGetContactsForBroadcast_KeysetPagination(t *testing.T) {
+	// Batches are paged by the unique email rather than an offset or the created_at of contacts, so that
+	// contacts created at the same instant are neither skipped nor duplicated across batch boundaries
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherFunc(func(expectedSQL, actualSQL string) error {
+		if strings.Contains(strings.ToUpper(actualSQL), "OFFSET") {
+			return fmt.Errorf("broadcast audience query must not use OFFSET: %s", actualSQL)
+		}
+		return sqlmock.QueryMatcherRegexp.Match(expectedSQL, actualSQL)
+	})))
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	workspaceRepo.EXPECT().GetConnection(gomock.Any(), "workspace123").Return(db, nil).AnyTimes()
+	repo := NewContactRepository(workspaceRepo)
+
+	audience := domain.AudienceSettings{Segments: []string{"segment1"}}
+	createdAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	columns := []string{
+		"email", "external_id", "timezone", "language", "first_name", "last_name", "full_name", "phone",
+		"address_line_1", "address_line_2", "country", "postcode", "state", "job_title",
+		"custom_string_1", "custom_string_2", "custom_string_3", "custom_string_4", "custom_string_5",
+		"custom_number_1", "custom_number_2", "custom_number_3", "custom_number_4", "custom_number_5",
+		"custom_datetime_1", "custom_datetime_2", "custom_datetime_3", "custom_datetime_4", "custom_datetime_5",
+		"custom_json_1", "custom_json_2", "custom_json_3", "custom_json_4", "custom_json_5",
+		"created_at", "updated_at", "db_created_at", "db_updated_at",
+	}
+	// All the contacts of the segment were imported at the same instant
+	contactRows := func(emails ...string) *sqlmock.Rows {
+		rows := sqlmock.NewRows(columns)
+		for _, email := range emails {
+			rows.AddRow(email, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+				nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+				nil, nil, nil, nil, nil, createdAt, createdAt, createdAt, createdAt)
+		}
+		return rows
+	}
+
+	firstBatch := `SELECT ` + contactColumnsPattern + ` FROM contacts c WHERE EXISTS \(SELECT 1 FROM contact_segments cs WHERE cs\.email = c\.email AND cs\.segment_id IN \(\$1\)\) AND ` + notSuppressedPattern + ` ORDER BY c\.email ASC LIMIT 2`
+	nextBatch := `SELECT ` + contactColumnsPattern + ` FROM contacts c WHERE EXISTS \(SELECT 1 FROM contact_segments cs WHERE cs\.email = c\.email AND cs\.segment_id IN \(\$1\)\) AND c\.email > \$2 AND ` + notSuppressedPattern + ` ORDER BY c\.email ASC LIMIT 2`
+
+	mock.ExpectQuery(firstBatch).WithArgs("segment1").
+		WillReturnRows(contactRows("a@example.com", "b@example.com"))
+	mock.ExpectQuery(nextBatch).WithArgs("segment1", "b@example.com").
+		WillReturnRows(contactRows("c@example.com", "d@example.com"))
+	mock.ExpectQuery(nextBatch).WithArgs("segment1", "d@example.com").
+		WillReturnRows(contactRows("e@example.com"))
+
+	var received []string
+	afterEmail := ""
+	for {
+		batch, err := repo.GetContactsForBroadcast(context.Background(), "workspace123", audience, 2, afterEmail)
+		require.NoError(t, err)
+		for _, contact := range batch {
+			received = append(received, contact.Contact.Email)
+		}
+		if len(batch) < 2 {
+			break
+		}
+		afterEmail = batch[len(batch)-1].Contact.Email
+	}
+
+	assert.Equal(t, []string{"a@example.com", "b@example.com", "c@example.com", "d@example.com", "e@example.com"}, received)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestContactsForBroadcast_OverlappingSegments(t *testing.T) {
+	// both@example.com is a member of the two targeted segments. Joining contact_segments would return
+	// and count it once per segment, so segment membership must be a condition on the contact.
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherFunc(func(expectedSQL, actualSQL string) error {
+		if strings.Contains(actualSQL, "JOIN contact_segments") {
+			return fmt.Errorf("broadcast audience query must not join contact_segments: %s", actualSQL)
+		}
+		return sqlmock.QueryMatcherRegexp.Match(expectedSQL, actualSQL)
+	})))
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	workspaceRepo.EXPECT().GetConnection(gomock.Any(), "workspace123").Return(db, nil).AnyTimes()
+	repo := NewContactRepository(workspaceRepo)
+
+	audience := domain.AudienceSettings{Segments: []string{"segment1", "segment2"}}
+	inSegments := `EXISTS \(SELECT 1 FROM contact_segments cs WHERE cs\.email = c\.email AND cs\.segment_id IN \(\$1,\$2\)\)`
+
+	t.Run("fetch returns each contact once", func(t *testing.T) {
+		createdAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+		rows := sqlmock.NewRows([]string{
+			"email", "external_id", "timezone", "language", "first_name", "last_name", "full_name", "phone",
+			"address_line_1", "address_line_2", "country", "postcode", "state", "job_title",
+			"custom_string_1", "custom_string_2", "custom_string_3", "custom_string_4", "custom_string_5",
+			"custom_number_1", "custom_number_2", "custom_number_3", "custom_number_4", "custom_number_5",
+			"custom_datetime_1", "custom_datetime_2", "custom_datetime_3", "custom_datetime_4", "custom_datetime_5",
+			"custom_json_1", "custom_json_2", "custom_json_3", "custom_json_4", "custom_json_5",
+			"created_at", "updated_at", "db_created_at", "db_updated_at",
+		})
+		for _, email := range []string{"both@example.com", "first@example.com", "second@example.com"} {
+			rows.AddRow(email, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+				nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+				nil, nil, nil, nil, nil, createdAt, createdAt, createdAt, createdAt)
+		}
+
+		mock.ExpectQuery(`SELECT `+contactColumnsPattern+` FROM contacts c WHERE `+inSegments+` AND `+notSuppressedPattern+` ORDER BY c\.email ASC LIMIT 10`).
+			WithArgs("segment1", "segment2").
+			WillReturnRows(rows)
+
+		contacts, err := repo.GetContactsForBroadcast(context.Background(), "workspace123", audience, 10, "")
+		require.NoError(t, err)
+		require.Len(t, contacts, 3)
+		assert.Equal(t, "both@example.com", contacts[0].Contact.Email)
+	})
+
+	t.Run("count matches the distinct recipients", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT COUNT\(\*\) FROM contacts c WHERE `+inSegments+` AND `+notSuppressedPattern+`$`).
+			WithArgs("segment1", "segment2").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+		count, err := repo.CountContactsForBroadcast(context.Background(), "workspace123", audience)
+		require.NoError(t, err)
+		assert.Equal(t, 3, count)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestCountContactsForBroadcast(t *testing.T) {
 	t.Run("should count contacts for broadcast with list filtering", func(t *testing.T) {
 		// Create a mock workspace database
@@ -1618,8 +1880,9 @@ func TestCountContactsForBroadcast(t *testing.T) {
 
 		// Expect query with JOINS for list filtering, soft-deleted lists filtering, and excludeUnsubscribed
 		// Note: SkipDuplicateEmails is false, so we expect COUNT(*) not COUNT(DISTINCT)
-		mock.ExpectQuery(`SELECT COUNT\(\*\) FROM contacts c JOIN contact_lists cl ON c\.email = cl\.email JOIN lists l ON cl\.list_id = l\.id WHERE cl\.list_id = \$1 AND l\.deleted_at IS NULL AND cl\.status <> \$2 AND cl\.status <> \$3 AND cl\.status <> \$4`).
+		mock.ExpectQuery(`SELECT COUNT\(\*\) FROM contacts c JOIN contact_lists cl ON c\.email = cl\.email JOIN lists l ON cl\.list_id = l\.id WHERE cl\.list_id = \$1 AND l\.deleted_at IS NULL AND cl\.status <> \$2 AND cl\.status <> \$3 AND cl\.status <> \$4 AND cl\.status <> \$5`).
 			WithArgs("list1",
+				domain.ContactListStatusPending,
 				domain.ContactListStatusUnsubscribed,
 				domain.ContactListStatusBounced,
 				domain.ContactListStatusComplained).
@@ -1755,7 +2018,7 @@ func TestCountContactsForBroadcast(t *testing.T) {
 		rows := sqlmock.NewRows([]string{"count"}).AddRow(42)
 
 		// Expect query with JOIN for segment filtering
-		mock.ExpectQuery(`SELECT COUNT\(\*\) FROM contacts c JOIN contact_segments cs ON c\.email = cs\.email WHERE cs\.segment_id IN \(\$1,\$2\)`).
+		mock.ExpectQuery(`SELECT COUNT\(\*\) FROM contacts c WHERE EXISTS \(SELECT 1 FROM contact_segments cs WHERE cs\.email = c\.email AND cs\.segment_id IN \(\$1,\$2\)\)`).
 			WithArgs("segment1", "segment2").
 			WillReturnRows(rows)
 
@@ -1791,10 +2054,10 @@ func TestCountContactsForBroadcast(t *testing.T) {
 		// Set up expectations for the count query
 		rows := sqlmock.NewRows([]string{"count"}).AddRow(15)
 
-		// Expect query with JOINs for both list, lists table (for soft-delete filter), and segment filtering
-		// The query should join contact_lists, lists, then also join contact_segments
-		mock.ExpectQuery(`SELECT COUNT\(\*\) FROM contacts c JOIN contact_lists cl ON c\.email = cl\.email JOIN lists l ON cl\.list_id = l\.id JOIN contact_segments cs ON c\.email = cs\.email WHERE cl\.list_id = \$1 AND l\.deleted_at IS NULL AND cl\.status <> \$2 AND cl\.status <> \$3 AND cl\.status <> \$4 AND cs\.segment_id IN \(\$5\)`).
+		// Expect query with JOINs for both list and lists table (for soft-delete filter), and a segment membership condition
+		mock.ExpectQuery(`SELECT COUNT\(\*\) FROM contacts c JOIN contact_lists cl ON c\.email = cl\.email JOIN lists l ON cl\.list_id = l\.id WHERE cl\.list_id = \$1 AND l\.deleted_at IS NULL AND cl\.status <> \$2 AND cl\.status <> \$3 AND cl\.status <> \$4 AND cl\.status <> \$5 AND EXISTS \(SELECT 1 FROM contact_segments cs WHERE cs\.email = c\.email AND cs\.segment_id IN \(\$6\)\)`).
 			WithArgs("list1",
+				domain.ContactListStatusPending,
 				domain.ContactListStatusUnsubscribed,
 				domain.ContactListStatusBounced,
 				domain.ContactListStatusComplained,
@@ -1810,102 +2073,617 @@ func TestCountContactsForBroadcast(t *testing.T) {
 	})
 }
 
-func TestDeleteContact(t *testing.T) {
-	t.Run("should successfully delete existing contact", func(t *testing.T) {
-		// Create a mock workspace database
-		mockDB, mock, cleanup := setupMockDB(t)
-		defer cleanup()
+func TestContactsForBroadcast_MinHoursSinceLastMessage(t *testing.T) {
+	// The contact messaged an hour ago (recent@example.com) only matches while the NOT EXISTS
+	// condition on message_history is absent, so the mocked results model its exclusion
+	recentlyMessaged := `AND NOT EXISTS \( SELECT 1 FROM message_history mh WHERE mh\.contact_email = c\.email AND mh\.sent_at > NOW\(\) - make_interval\(hours => \$6\) \)`
+	listQuery := `SELECT ` + contactColumnsPattern + `, cl\.list_id, l\.name as list_name FROM contacts c JOIN contact_lists cl ON c\.email = cl\.email JOIN lists l ON cl\.list_id = l\.id WHERE cl\.list_id = \$1 AND l\.deleted_at IS NULL AND cl\.status <> \$2 AND cl\.status <> \$3 AND cl\.status <> \$4 AND cl\.status <> \$5 AND ` + notSuppressedPattern + ``
+	countQuery := `SELECT COUNT\(\*\) FROM contacts c JOIN contact_lists cl ON c\.email = cl\.email JOIN lists l ON cl\.list_id = l\.id WHERE cl\.list_id = \$1 AND l\.deleted_at IS NULL AND cl\.status <> \$2 AND cl\.status <> \$3 AND cl\.status <> \$4 AND cl\.status <> \$5 AND ` + notSuppressedPattern + ``
 
-		// Create a new repository with the mock DB
+	// contactRows returns list contacts with only their email and timestamps set
+	contactRows := func(emails ...string) *sqlmock.Rows {
+		rows := sqlmock.NewRows([]string{
+			"email", "external_id", "timezone", "language",
+			"first_name", "last_name", "full_name", "phone", "address_line_1", "address_line_2",
+			"country", "postcode", "state", "job_title",
+			"custom_string_1", "custom_string_2", "custom_string_3", "custom_string_4", "custom_string_5",
+			"custom_number_1", "custom_number_2", "custom_number_3", "custom_number_4", "custom_number_5",
+			"custom_datetime_1", "custom_datetime_2", "custom_datetime_3", "custom_datetime_4", "custom_datetime_5",
+			"custom_json_1", "custom_json_2", "custom_json_3", "custom_json_4",
+			"custom_json_5", "created_at", "updated_at", "db_created_at", "db_updated_at",
+			"list_id", "list_name",
+		})
+		now := time.Now().UTC().Truncate(time.Microsecond)
+		for _, email := range emails {
+			values := make([]driver.Value, 40)
+			values[0] = email
+			values[34], values[35], values[36], values[37] = now, now, now, now
+			values[38], values[39] = "list1", "Marketing List"
+			rows.AddRow(values...)
+		}
+		return rows
+	}
+
+	setup := func(t *testing.T) (domain.ContactRepository, sqlmock.Sqlmock) {
+		mockDB, mock, cleanup := setupMockDB(t)
+		t.Cleanup(cleanup)
 		ctrl := gomock.NewController(t)
-		defer ctrl.Finish()
+		t.Cleanup(ctrl.Finish)
 
 		workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
 		workspaceRepo.EXPECT().GetConnection(gomock.Any(), "workspace123").Return(mockDB, nil)
+		return NewContactRepository(workspaceRepo), mock
+	}
 
-		repo := NewContactRepository(workspaceRepo)
-
-		email := "test@example.com"
-
-		// Set up expectations for the delete query
-		mock.ExpectExec(`DELETE FROM contacts WHERE email = \$1`).
-			WithArgs(email).
-			WillReturnResult(sqlmock.NewResult(0, 1)) // 1 row affected
+	t.Run("contact messaged an hour ago is excluded with a 24h window", func(t *testing.T) {
+		repo, mock := setup(t)
+		audience := domain.AudienceSettings{List: "list1", ExcludeUnsubscribed: true, MinHoursSinceLastMessage: 24}
 
-		// Call the method being tested
-		err := repo.DeleteContact(context.Background(), "workspace123", email)
+		mock.ExpectQuery(listQuery+` `+recentlyMessaged+` ORDER BY c\.email ASC LIMIT 10`).
+			WithArgs("list1", domain.ContactListStatusPending, domain.ContactListStatusUnsubscribed, domain.ContactListStatusBounced, domain.ContactListStatusComplained, 24).
+			WillReturnRows(contactRows("quiet@example.com"))
 
-		// Assertions
+		contacts, err := repo.GetContactsForBroadcast(context.Background(), "workspace123", audience, 10, "")
 		require.NoError(t, err)
+		require.Len(t, contacts, 1)
+		assert.Equal(t, "quiet@example.com", contacts[0].Contact.Email)
+		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 
-	t.Run("should return error when contact not found", func(t *testing.T) {
-		// Create a mock workspace database
-		mockDB, mock, cleanup := setupMockDB(t)
-		defer cleanup()
+	t.Run("contact messaged an hour ago is included without a window", func(t *testing.T) {
+		repo, mock := setup(t)
+		audience := domain.AudienceSettings{List: "list1", ExcludeUnsubscribed: true, MinHoursSinceLastMessage: 0}
 
-		// Create a new repository with the mock DB
-		ctrl := gomock.NewController(t)
-		defer ctrl.Finish()
+		mock.ExpectQuery(listQuery+` ORDER BY c\.email ASC LIMIT 10`).
+			WithArgs("list1", domain.ContactListStatusPending, domain.ContactListStatusUnsubscribed, domain.ContactListStatusBounced, domain.ContactListStatusComplained).
+			WillReturnRows(contactRows("quiet@example.com", "recent@example.com"))
 
-		workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
-		workspaceRepo.EXPECT().GetConnection(gomock.Any(), "workspace123").Return(mockDB, nil)
+		contacts, err := repo.GetContactsForBroadcast(context.Background(), "workspace123", audience, 10, "")
+		require.NoError(t, err)
+		require.Len(t, contacts, 2)
+		assert.Equal(t, "recent@example.com", contacts[1].Contact.Email)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
 
-		repo := NewContactRepository(workspaceRepo)
+	t.Run("count excludes the contact with a 24h window", func(t *testing.T) {
+		repo, mock := setup(t)
+		audience := domain.AudienceSettings{List: "list1", ExcludeUnsubscribed: true, MinHoursSinceLastMessage: 24}
 
-		email := "nonexistent@example.com"
+		mock.ExpectQuery(countQuery+` `+recentlyMessaged+`$`).
+			WithArgs("list1", domain.ContactListStatusPending, domain.ContactListStatusUnsubscribed, domain.ContactListStatusBounced, domain.ContactListStatusComplained, 24).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
 
-		// Set up expectations for the delete query with 0 rows affected
-		mock.ExpectExec(`DELETE FROM contacts WHERE email = \$1`).
-			WithArgs(email).
-			WillReturnResult(sqlmock.NewResult(0, 0)) // 0 rows affected
+		count, err := repo.CountContactsForBroadcast(context.Background(), "workspace123", audience)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
 
-		// Call the method being tested
-		err := repo.DeleteContact(context.Background(), "workspace123", email)
+	t.Run("count includes the contact without a window", func(t *testing.T) {
+		repo, mock := setup(t)
+		audience := domain.AudienceSettings{List: "list1", ExcludeUnsubscribed: true}
 
-		// Assertions
-		require.Error(t, err)
-		assert.Contains(t, err.Error(), "contact not found")
+		mock.ExpectQuery(countQuery+`$`).
+			WithArgs("list1", domain.ContactListStatusPending, domain.ContactListStatusUnsubscribed, domain.ContactListStatusBounced, domain.ContactListStatusComplained).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+		count, err := repo.CountContactsForBroadcast(context.Background(), "workspace123", audience)
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+		assert.NoError(t, mock.ExpectationsWereMet())
 	})
+}
 
-	t.Run("should handle database connection error", func(t *testing.T) {
-		// Create a new repository with the mock DB
+func TestContactsForBroadcast_PendingMembers(t *testing.T) {
+	// The member waiting for the double opt-in confirmation (pending@example.com) only matches while the
+	// status condition is absent, so the mocked results model its exclusion
+	audience := domain.AudienceSettings{List: "list1"}
+	notPending := `cl\.list_id = \$1 AND l\.deleted_at IS NULL AND cl\.status <> \$2 AND ` + notSuppressedPattern
+
+	setup := func(t *testing.T) (domain.ContactRepository, sqlmock.Sqlmock) {
+		mockDB, mock, cleanup := setupMockDB(t)
+		t.Cleanup(cleanup)
 		ctrl := gomock.NewController(t)
-		defer ctrl.Finish()
+		t.Cleanup(ctrl.Finish)
 
 		workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
-		workspaceRepo.EXPECT().GetConnection(gomock.Any(), "workspace123").
-			Return(nil, fmt.Errorf("connection error"))
+		workspaceRepo.EXPECT().GetConnection(gomock.Any(), "workspace123").Return(mockDB, nil)
+		return NewContactRepository(workspaceRepo), mock
+	}
 
-		repo := NewContactRepository(workspaceRepo)
+	t.Run("pending member is not a recipient", func(t *testing.T) {
+		repo, mock := setup(t)
 
-		email := "test@example.com"
+		rows := sqlmock.NewRows([]string{
+			"email", "external_id", "timezone", "language",
+			"first_name", "last_name", "full_name", "phone", "address_line_1", "address_line_2",
+			"country", "postcode", "state", "job_title",
+			"custom_string_1", "custom_string_2", "custom_string_3", "custom_string_4", "custom_string_5",
+			"custom_number_1", "custom_number_2", "custom_number_3", "custom_number_4", "custom_number_5",
+			"custom_datetime_1", "custom_datetime_2", "custom_datetime_3", "custom_datetime_4", "custom_datetime_5",
+			"custom_json_1", "custom_json_2", "custom_json_3", "custom_json_4",
+			"custom_json_5", "created_at", "updated_at", "db_created_at", "db_updated_at",
+			"list_id", "list_name",
+		})
+		values := make([]driver.Value, 40)
+		now := time.Now().UTC().Truncate(time.Microsecond)
+		values[0] = "active@example.com"
+		values[34], values[35], values[36], values[37] = now, now, now, now
+		values[38], values[39] = "list1", "Marketing List"
+		rows.AddRow(values...)
 
-		// Call the method being tested
-		err := repo.DeleteContact(context.Background(), "workspace123", email)
+		mock.ExpectQuery(`SELECT ` + contactColumnsPattern + `, cl\.list_id, l\.name as list_name FROM contacts c JOIN contact_lists cl ON c\.email = cl\.email JOIN lists l ON cl\.list_id = l\.id WHERE ` + notPending + ` ORDER BY c\.email ASC LIMIT 10`).
+			WithArgs("list1", domain.ContactListStatusPending).
+			WillReturnRows(rows)
 
-		// Assertions
-		require.Error(t, err)
-		assert.Contains(t, err.Error(), "failed to get workspace connection")
+		contacts, err := repo.GetContactsForBroadcast(context.Background(), "workspace123", audience, 10, "")
+		require.NoError(t, err)
+		require.Len(t, contacts, 1)
+		assert.Equal(t, "active@example.com", contacts[0].Contact.Email)
+		assert.NoError(t, mock.ExpectationsWereMet())
 	})
 
-	t.Run("should handle database execution error", func(t *testing.T) {
-		// Create a mock workspace database
-		mockDB, mock, cleanup := setupMockDB(t)
-		defer cleanup()
+	t.Run("pending member is not counted", func(t *testing.T) {
+		repo, mock := setup(t)
 
-		// Create a new repository with the mock DB
+		mock.ExpectQuery(`SELECT COUNT\(\*\) FROM contacts c JOIN contact_lists cl ON c\.email = cl\.email JOIN lists l ON cl\.list_id = l\.id WHERE ` + notPending + `$`).
+			WithArgs("list1", domain.ContactListStatusPending).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+		count, err := repo.CountContactsForBroadcast(context.Background(), "workspace123", audience)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestContactsForBroadcast_MultipleLists(t *testing.T) {
+	// shared@example.com is a member of both lists: DISTINCT ON keeps its membership of the first list
+	// in priority order, so the mocked results hold a single row for it
+	audience := domain.AudienceSettings{Lists: []string{"list-vip", "list-all"}}
+	inLists := `FROM contacts c JOIN contact_lists cl ON c\.email = cl\.email JOIN lists l ON cl\.list_id = l\.id WHERE cl\.list_id IN \(\$1,\$2\) AND l\.deleted_at IS NULL AND cl\.status <> \$3 AND ` + notSuppressedPattern
+
+	setup := func(t *testing.T) (domain.ContactRepository, sqlmock.Sqlmock) {
+		mockDB, mock, cleanup := setupMockDB(t)
+		t.Cleanup(cleanup)
 		ctrl := gomock.NewController(t)
-		defer ctrl.Finish()
+		t.Cleanup(ctrl.Finish)
 
 		workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
 		workspaceRepo.EXPECT().GetConnection(gomock.Any(), "workspace123").Return(mockDB, nil)
+		return NewContactRepository(workspaceRepo), mock
+	}
 
-		repo := NewContactRepository(workspaceRepo)
+	t.Run("contact in two lists is a single recipient of the first list", func(t *testing.T) {
+		repo, mock := setup(t)
 
-		email := "test@example.com"
+		rows := sqlmock.NewRows([]string{
+			"email", "external_id", "timezone", "language",
+			"first_name", "last_name", "full_name", "phone", "address_line_1", "address_line_2",
+			"country", "postcode", "state", "job_title",
+			"custom_string_1", "custom_string_2", "custom_string_3", "custom_string_4", "custom_string_5",
+			"custom_number_1", "custom_number_2", "custom_number_3", "custom_number_4", "custom_number_5",
+			"custom_datetime_1", "custom_datetime_2", "custom_datetime_3", "custom_datetime_4", "custom_datetime_5",
+			"custom_json_1", "custom_json_2", "custom_json_3", "custom_json_4",
+			"custom_json_5", "created_at", "updated_at", "db_created_at", "db_updated_at",
+			"list_id", "list_name",
+		})
+		now := time.Now().UTC().Truncate(time.Microsecond)
+		for _, member := range [][3]string{{"other@example.com", "list-all", "All"}, {"shared@example.com", "list-vip", "VIP"}} {
+			values := make([]driver.Value, 40)
+			values[0] = member[0]
+			values[34], values[35], values[36], values[37] = now, now, now, now
+			values[38], values[39] = member[1], member[2]
+			rows.AddRow(values...)
+		}
 
-		// Set up expectations for the delete query with database error
+		mock.ExpectQuery(`SELECT DISTINCT ON \(c\.email\) ` + contactColumnsPattern + `, cl\.list_id, l\.name as list_name ` + inLists + ` ORDER BY c\.email ASC, CASE cl\.list_id WHEN \$4 THEN 0 WHEN \$5 THEN 1 END LIMIT 10`).
+			WithArgs("list-vip", "list-all", domain.ContactListStatusPending, "list-vip", "list-all").
+			WillReturnRows(rows)
+
+		contacts, err := repo.GetContactsForBroadcast(context.Background(), "workspace123", audience, 10, "")
+		require.NoError(t, err)
+		require.Len(t, contacts, 2)
+		assert.Equal(t, "shared@example.com", contacts[1].Contact.Email)
+		assert.Equal(t, "list-vip", contacts[1].ListID)
+		assert.Equal(t, "VIP", contacts[1].ListName)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("contact in two lists is counted once", func(t *testing.T) {
+		repo, mock := setup(t)
+
+		mock.ExpectQuery(`SELECT COUNT\(DISTINCT c\.email\) ` + inLists + `$`).
+			WithArgs("list-vip", "list-all", domain.ContactListStatusPending).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
+
+		count, err := repo.CountContactsForBroadcast(context.Background(), "workspace123", audience)
+		require.NoError(t, err)
+		assert.Equal(t, 2, count)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestContactRepository_RedactContact(t *testing.T) {
+	email := "test@example.com"
+	redactQuery := `UPDATE contacts SET first_name = \$1, last_name = \$2, full_name = \$3, phone = \$4, address_line_1 = \$5, address_line_2 = \$6, postcode = \$7, custom_string_1 = \$8, custom_string_2 = \$9, custom_string_3 = \$10, custom_string_4 = \$11, custom_string_5 = \$12, db_updated_at = \$13 WHERE email = \$14 AND \(first_name IS NOT NULL OR .* OR custom_string_5 IS NOT NULL\)`
+	deleteListsQuery := `DELETE FROM contact_lists WHERE email = \$1`
+	deleteSegmentsQuery := `DELETE FROM contact_segments WHERE email = \$1`
+
+	setup := func(t *testing.T) (domain.ContactRepository, sqlmock.Sqlmock) {
+		mockDB, mock, cleanup := setupMockDB(t)
+		t.Cleanup(cleanup)
+
+		ctrl := gomock.NewController(t)
+		t.Cleanup(ctrl.Finish)
+
+		workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+		workspaceRepo.EXPECT().GetConnection(gomock.Any(), "workspace123").Return(mockDB, nil)
+
+		return NewContactRepository(workspaceRepo), mock
+	}
+
+	t.Run("should redact contact and delete memberships", func(t *testing.T) {
+		repo, mock := setup(t)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(redactQuery).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(deleteListsQuery).WithArgs(email).WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectExec(deleteSegmentsQuery).WithArgs(email).WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		result, err := repo.RedactContact(context.Background(), "workspace123", email)
+		require.NoError(t, err)
+		assert.Equal(t, &domain.ContactRedactionResult{Contacts: 1, ContactLists: 3, ContactSegments: 2}, result)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should succeed on an already redacted contact", func(t *testing.T) {
+		repo, mock := setup(t)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(redactQuery).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(deleteListsQuery).WithArgs(email).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(deleteSegmentsQuery).WithArgs(email).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit()
+
+		result, err := repo.RedactContact(context.Background(), "workspace123", email)
+		require.NoError(t, err)
+		assert.Equal(t, &domain.ContactRedactionResult{}, result)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should roll back when deleting list memberships fails", func(t *testing.T) {
+		repo, mock := setup(t)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(redactQuery).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(deleteListsQuery).WithArgs(email).WillReturnError(errors.New("lock timeout"))
+		mock.ExpectRollback()
+
+		result, err := repo.RedactContact(context.Background(), "workspace123", email)
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "failed to delete contact list memberships")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should roll back when deleting segment memberships fails", func(t *testing.T) {
+		repo, mock := setup(t)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(redactQuery).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(deleteListsQuery).WithArgs(email).WillReturnResult(sqlmock.NewResult(0, 3))
+		mock.ExpectExec(deleteSegmentsQuery).WithArgs(email).WillReturnError(errors.New("connection reset"))
+		mock.ExpectRollback()
+
+		result, err := repo.RedactContact(context.Background(), "workspace123", email)
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "failed to delete contact segment memberships")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should roll back when redacting the contact fails", func(t *testing.T) {
+		repo, mock := setup(t)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(redactQuery).WillReturnError(errors.New("serialization failure"))
+		mock.ExpectRollback()
+
+		result, err := repo.RedactContact(context.Background(), "workspace123", email)
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "failed to redact contact")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should return error when commit fails", func(t *testing.T) {
+		repo, mock := setup(t)
+
+		mock.ExpectBegin()
+		mock.ExpectExec(redactQuery).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(deleteListsQuery).WithArgs(email).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(deleteSegmentsQuery).WithArgs(email).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectCommit().WillReturnError(errors.New("commit failed"))
+
+		result, err := repo.RedactContact(context.Background(), "workspace123", email)
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "failed to commit transaction")
+	})
+}
+
+func TestContactRepository_FindDuplicatesByExternalID(t *testing.T) {
+	older := time.Date(2026, 1, 10, 9, 0, 0, 0, time.UTC)
+	newer := older.Add(24 * time.Hour)
+	duplicatesQuery := `SELECT ` + contactColumnsPattern + ` FROM contacts c WHERE c\.external_id IN \(SELECT external_id FROM contacts WHERE external_id IS NOT NULL AND external_id <> '' GROUP BY external_id HAVING COUNT\(\*\) > 1\)`
+
+	setup := func(t *testing.T) (domain.ContactRepository, sqlmock.Sqlmock) {
+		mockDB, mock, cleanup := setupMockDB(t)
+		t.Cleanup(cleanup)
+
+		ctrl := gomock.NewController(t)
+		t.Cleanup(ctrl.Finish)
+
+		workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+		workspaceRepo.EXPECT().GetConnection(gomock.Any(), "workspace123").Return(mockDB, nil)
+
+		return NewContactRepository(workspaceRepo), mock
+	}
+
+	addContact := func(rows *sqlmock.Rows, email, externalID string, createdAt time.Time) {
+		values := make([]driver.Value, len(contactColumns))
+		values[0] = email
+		values[1] = externalID
+		for i := len(values) - 4; i < len(values); i++ {
+			values[i] = createdAt
+		}
+		rows.AddRow(values...)
+	}
+
+	t.Run("groups contacts by external ID, oldest first", func(t *testing.T) {
+		repo, mock := setup(t)
+
+		rows := sqlmock.NewRows(contactColumns)
+		addContact(rows, "new@example.com", "crm-1", newer)
+		addContact(rows, "bob@example.com", "crm-2", older)
+		addContact(rows, "old@example.com", "crm-1", older)
+		addContact(rows, "robert@example.com", "crm-2", newer)
+		mock.ExpectQuery(duplicatesQuery).WillReturnRows(rows)
+		mock.ExpectQuery(`SELECT cl\.email, cl\.list_id, cl\.status, cl\.created_at, cl\.updated_at, l\.name as list_name FROM contact_lists cl`).
+			WillReturnRows(sqlmock.NewRows([]string{"email", "list_id", "status", "created_at", "updated_at", "list_name"}).
+				AddRow("old@example.com", "newsletter", "active", older, older, "Newsletter"))
+		mock.ExpectQuery(`SELECT cs\.email, cs\.segment_id, cs\.version, cs\.matched_at, cs\.computed_at, s\.name as segment_name, s\.color as segment_color FROM contact_segments cs`).
+			WillReturnRows(sqlmock.NewRows([]string{"email", "segment_id", "version", "matched_at", "computed_at", "segment_name", "segment_color"}))
+
+		groups, err := repo.FindDuplicatesByExternalID(context.Background(), "workspace123")
+		require.NoError(t, err)
+		require.Len(t, groups, 2)
+
+		assert.Equal(t, "crm-1", groups[0].ExternalID)
+		require.Len(t, groups[0].Contacts, 2)
+		assert.Equal(t, "old@example.com", groups[0].Contacts[0].Email)
+		assert.Len(t, groups[0].Contacts[0].ContactLists, 1)
+		assert.Equal(t, "new@example.com", groups[0].Contacts[1].Email)
+
+		assert.Equal(t, "crm-2", groups[1].ExternalID)
+		require.Len(t, groups[1].Contacts, 2)
+		assert.Equal(t, "bob@example.com", groups[1].Contacts[0].Email)
+		assert.Equal(t, "robert@example.com", groups[1].Contacts[1].Email)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("no duplicates", func(t *testing.T) {
+		repo, mock := setup(t)
+
+		mock.ExpectQuery(duplicatesQuery).WillReturnRows(sqlmock.NewRows(contactColumns))
+
+		groups, err := repo.FindDuplicatesByExternalID(context.Background(), "workspace123")
+		require.NoError(t, err)
+		assert.Empty(t, groups)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		repo, mock := setup(t)
+
+		mock.ExpectQuery(duplicatesQuery).WillReturnError(errors.New("connection reset"))
+
+		groups, err := repo.FindDuplicatesByExternalID(context.Background(), "workspace123")
+		require.Error(t, err)
+		assert.Nil(t, groups)
+		assert.Contains(t, err.Error(), "connection reset")
+	})
+}
+
+func TestContactRepository_MergeContacts(t *testing.T) {
+	primary := "ada@example.com"
+	duplicate := "ada.lovelace@example.com"
+	lockQuery := `SELECT 1 FROM contacts WHERE email = \$1 FOR UPDATE`
+	mergeListsQuery := `INSERT INTO contact_lists \(email, list_id, status, created_at, updated_at\)\s+SELECT DISTINCT ON \(list_id\) \$1, list_id, status, created_at, \$2\s+FROM contact_lists\s+WHERE email = ANY\(\$3\) AND deleted_at IS NULL\s+ORDER BY list_id, updated_at DESC\s+ON CONFLICT \(email, list_id\) DO NOTHING`
+	mergeSegmentsQuery := `INSERT INTO contact_segments \(email, segment_id, version, matched_at, computed_at\)\s+SELECT DISTINCT ON \(segment_id\) \$1, segment_id, version, matched_at, computed_at\s+FROM contact_segments\s+WHERE email = ANY\(\$2\)\s+ORDER BY segment_id, version DESC\s+ON CONFLICT \(email, segment_id\) DO NOTHING`
+	messagesQuery := `UPDATE message_history SET contact_email = \$1 WHERE contact_email = ANY\(\$2\)`
+	clearExternalIDQuery := `UPDATE contacts SET external_id = NULL, db_updated_at = \$1 WHERE email = ANY\(\$2\)`
+	redactQuery := `UPDATE contacts SET first_name = \$1, .* WHERE email = \$14 AND \(first_name IS NOT NULL OR .*\)`
+
+	setup := func(t *testing.T) (domain.ContactRepository, sqlmock.Sqlmock) {
+		mockDB, mock, cleanup := setupMockDB(t)
+		t.Cleanup(cleanup)
+
+		ctrl := gomock.NewController(t)
+		t.Cleanup(ctrl.Finish)
+
+		workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+		workspaceRepo.EXPECT().GetConnection(gomock.Any(), "workspace123").Return(mockDB, nil).AnyTimes()
+
+		return NewContactRepository(workspaceRepo), mock
+	}
+
+	t.Run("two contacts sharing an external ID merge with list memberships union-ed", func(t *testing.T) {
+		repo, mock := setup(t)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockQuery).WithArgs(primary).WillReturnRows(sqlmock.NewRows([]string{"?column?"}).AddRow(1))
+		// The duplicate is on newsletter and product-updates, the primary already on newsletter:
+		// only product-updates is added to the primary
+		mock.ExpectExec(mergeListsQuery).WithArgs(primary, sqlmock.AnyArg(), pq.Array([]string{duplicate})).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(mergeSegmentsQuery).WithArgs(primary, pq.Array([]string{duplicate})).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec(messagesQuery).WithArgs(primary, pq.Array([]string{duplicate})).
+			WillReturnResult(sqlmock.NewResult(0, 7))
+		mock.ExpectExec(clearExternalIDQuery).WithArgs(sqlmock.AnyArg(), pq.Array([]string{duplicate})).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(redactQuery).WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectExec(`DELETE FROM contact_lists WHERE email = \$1`).WithArgs(duplicate).WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectExec(`DELETE FROM contact_segments WHERE email = \$1`).WithArgs(duplicate).WillReturnResult(sqlmock.NewResult(0, 2))
+		mock.ExpectCommit()
+
+		result, err := repo.MergeContacts(context.Background(), "workspace123", primary, []string{duplicate})
+		require.NoError(t, err)
+		assert.Equal(t, &domain.ContactMergeResult{ContactLists: 1, ContactSegments: 2, MessageHistory: 7, Redacted: 1}, result)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("missing primary contact", func(t *testing.T) {
+		repo, mock := setup(t)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockQuery).WithArgs(primary).WillReturnError(sql.ErrNoRows)
+		mock.ExpectRollback()
+
+		result, err := repo.MergeContacts(context.Background(), "workspace123", primary, []string{duplicate})
+		assert.ErrorIs(t, err, domain.ErrContactNotFound)
+		assert.Nil(t, result)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("rolls back when reassigning message history fails", func(t *testing.T) {
+		repo, mock := setup(t)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(lockQuery).WithArgs(primary).WillReturnRows(sqlmock.NewRows([]string{"?column?"}).AddRow(1))
+		mock.ExpectExec(mergeListsQuery).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(mergeSegmentsQuery).WillReturnResult(sqlmock.NewResult(0, 0))
+		mock.ExpectExec(messagesQuery).WillReturnError(errors.New("lock timeout"))
+		mock.ExpectRollback()
+
+		result, err := repo.MergeContacts(context.Background(), "workspace123", primary, []string{duplicate})
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "failed to reassign message history")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("invalid duplicates", func(t *testing.T) {
+		repo, _ := setup(t)
+
+		_, err := repo.MergeContacts(context.Background(), "workspace123", primary, nil)
+		assert.EqualError(t, err, "no duplicate contacts to merge")
+
+		_, err = repo.MergeContacts(context.Background(), "workspace123", primary, []string{duplicate, primary})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "can't be one of its duplicates")
+	})
+}
+
+func TestDeleteContact(t *testing.T) {
+	t.Run("should successfully delete existing contact", func(t *testing.T) {
+		// Create a mock workspace database
+		mockDB, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+
+		// Create a new repository with the mock DB
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+		workspaceRepo.EXPECT().GetConnection(gomock.Any(), "workspace123").Return(mockDB, nil)
+
+		repo := NewContactRepository(workspaceRepo)
+
+		email := "test@example.com"
+
+		// Set up expectations for the delete query
+		mock.ExpectExec(`DELETE FROM contacts WHERE email = \$1`).
+			WithArgs(email).
+			WillReturnResult(sqlmock.NewResult(0, 1)) // 1 row affected
+
+		// Call the method being tested
+		err := repo.DeleteContact(context.Background(), "workspace123", email)
+
+		// Assertions
+		require.NoError(t, err)
+	})
+
+	t.Run("should return error when contact not found", func(t *testing.T) {
+		// Create a mock workspace database
+		mockDB, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+
+		// Create a new repository with the mock DB
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+		workspaceRepo.EXPECT().GetConnection(gomock.Any(), "workspace123").Return(mockDB, nil)
+
+		repo := NewContactRepository(workspaceRepo)
+
+		email := "nonexistent@example.com"
+
+		// Set up expectations for the delete query with 0 rows affected
+		mock.ExpectExec(`DELETE FROM contacts WHERE email = \$1`).
+			WithArgs(email).
+			WillReturnResult(sqlmock.NewResult(0, 0)) // 0 rows affected
+
+		// Call the method being tested
+		err := repo.DeleteContact(context.Background(), "workspace123", email)
+
+		// Assertions
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "contact not found")
+	})
+
+	t.Run("should handle database connection error", func(t *testing.T) {
+		// Create a new repository with the mock DB
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+		workspaceRepo.EXPECT().GetConnection(gomock.Any(), "workspace123").
+			Return(nil, fmt.Errorf("connection error"))
+
+		repo := NewContactRepository(workspaceRepo)
+
+		email := "test@example.com"
+
+		// Call the method being tested
+		err := repo.DeleteContact(context.Background(), "workspace123", email)
+
+		// Assertions
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to get workspace connection")
+	})
+
+	t.Run("should handle database execution error", func(t *testing.T) {
+		// Create a mock workspace database
+		mockDB, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+
+		// Create a new repository with the mock DB
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+		workspaceRepo.EXPECT().GetConnection(gomock.Any(), "workspace123").Return(mockDB, nil)
+
+		repo := NewContactRepository(workspaceRepo)
+
+		email := "test@example.com"
+
+		// Set up expectations for the delete query with database error
 		mock.ExpectExec(`DELETE FROM contacts WHERE email = \$1`).
 			WithArgs(email).
 			WillReturnError(fmt.Errorf("database execution error"))
@@ -2188,22 +2966,125 @@ func TestContactRepository_BulkUpsertContacts(t *testing.T) {
 	})
 }
 
-func TestContactRepository_Count(t *testing.T) {
-	// Test contactRepository.Count - this was at 0% coverage
+func TestContactRepository_UpsertContacts(t *testing.T) {
+	db, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
-	repo := NewContactRepository(mockWorkspaceRepo)
-
-	db, mock, cleanup := setupMockDB(t)
-	defer cleanup()
+	workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	workspaceID := "workspace123"
+	workspaceRepo.EXPECT().GetConnection(gomock.Any(), workspaceID).Return(db, nil).AnyTimes()
 
+	repo := NewContactRepository(workspaceRepo)
 	ctx := context.Background()
-	workspaceID := "workspace123"
+	now := time.Now()
 
-	t.Run("Success - Returns count", func(t *testing.T) {
-		mockWorkspaceRepo.EXPECT().
+	// The existing row of existing@example.com has a first name and custom_string_1 but no last name
+	// or custom_number_1: the import provides all four.
+	contacts := []*domain.Contact{
+		{
+			Email:         "existing@example.com",
+			FirstName:     &domain.NullableString{String: "Jane", IsNull: false},
+			LastName:      &domain.NullableString{String: "Doe", IsNull: false},
+			CustomString1: &domain.NullableString{String: "gold", IsNull: false},
+			CustomNumber1: &domain.NullableFloat64{Float64: 42, IsNull: false},
+			CreatedAt:     now,
+			UpdatedAt:     now,
+		},
+		{Email: "new@example.com", CreatedAt: now, UpdatedAt: now},
+	}
+
+	t.Run("overwrite all replaces the provided fields", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`ON CONFLICT \(email\) DO UPDATE SET\s+external_id = COALESCE\(EXCLUDED.external_id, contacts.external_id\),` +
+			`[\s\S]+custom_string_1 = COALESCE\(EXCLUDED.custom_string_1, contacts.custom_string_1\),` +
+			`[\s\S]+custom_number_1 = COALESCE\(EXCLUDED.custom_number_1, contacts.custom_number_1\),` +
+			`[\s\S]+created_at = EXCLUDED.created_at,\s+updated_at = EXCLUDED.updated_at`).
+			WillReturnRows(
+				sqlmock.NewRows([]string{"email", "is_new"}).
+					AddRow("existing@example.com", false).
+					AddRow("new@example.com", true),
+			)
+		mock.ExpectCommit()
+
+		results, err := repo.UpsertContacts(ctx, workspaceID, contacts, domain.MergeStrategyOverwriteAll)
+
+		require.NoError(t, err)
+		assert.Equal(t, []domain.BulkUpsertResult{
+			{Email: "existing@example.com", IsNew: false},
+			{Email: "new@example.com", IsNew: true},
+		}, results)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("fill empty only preserves the fields already set", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`ON CONFLICT \(email\) DO UPDATE SET\s+external_id = COALESCE\(NULLIF\(contacts.external_id, ''\), EXCLUDED.external_id\),` +
+			`[\s\S]+first_name = COALESCE\(NULLIF\(contacts.first_name, ''\), EXCLUDED.first_name\),` +
+			`[\s\S]+custom_string_1 = COALESCE\(NULLIF\(contacts.custom_string_1, ''\), EXCLUDED.custom_string_1\),` +
+			`[\s\S]+custom_number_1 = COALESCE\(contacts.custom_number_1, EXCLUDED.custom_number_1\),` +
+			`[\s\S]+custom_json_5 = COALESCE\(contacts.custom_json_5, EXCLUDED.custom_json_5\),` +
+			`\s+created_at = contacts.created_at,\s+updated_at = EXCLUDED.updated_at`).
+			WillReturnRows(
+				sqlmock.NewRows([]string{"email", "is_new"}).
+					AddRow("existing@example.com", false).
+					AddRow("new@example.com", true),
+			)
+		mock.ExpectCommit()
+
+		results, err := repo.UpsertContacts(ctx, workspaceID, contacts, domain.MergeStrategyFillEmptyOnly)
+
+		require.NoError(t, err)
+		assert.Len(t, results, 2)
+		assert.False(t, results[0].IsNew)
+		assert.True(t, results[1].IsNew)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("ignore existing only inserts new contacts", func(t *testing.T) {
+		mock.ExpectBegin()
+		mock.ExpectQuery(`ON CONFLICT \(email\) DO NOTHING\s+RETURNING email, \(xmax = 0\) AS is_new$`).
+			WillReturnRows(
+				sqlmock.NewRows([]string{"email", "is_new"}).
+					AddRow("new@example.com", true),
+			)
+		mock.ExpectCommit()
+
+		results, err := repo.UpsertContacts(ctx, workspaceID, contacts, domain.MergeStrategyIgnoreExisting)
+
+		require.NoError(t, err)
+		assert.Equal(t, []domain.BulkUpsertResult{{Email: "new@example.com", IsNew: true}}, results)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("invalid strategy", func(t *testing.T) {
+		results, err := repo.UpsertContacts(ctx, workspaceID, contacts, domain.MergeStrategy("merge_somehow"))
+
+		require.Error(t, err)
+		assert.Nil(t, results)
+		assert.Contains(t, err.Error(), "invalid merge strategy")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestContactRepository_Count(t *testing.T) {
+	// Test contactRepository.Count - this was at 0% coverage
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	repo := NewContactRepository(mockWorkspaceRepo)
+
+	db, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	workspaceID := "workspace123"
+
+	t.Run("Success - Returns count", func(t *testing.T) {
+		mockWorkspaceRepo.EXPECT().
 			GetConnection(ctx, workspaceID).
 			Return(db, nil)
 
@@ -2242,6 +3123,97 @@ func TestContactRepository_Count(t *testing.T) {
 	})
 }
 
+func TestContactRepository_GetAudienceCounts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	repo := NewContactRepository(mockWorkspaceRepo)
+
+	db, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	workspaceID := "workspace123"
+
+	// Only active, non deleted subscriptions of non deleted lists are counted
+	listQuery := `SELECT l.id, l.name, COUNT\(cl.email\) AS active_count FROM lists l LEFT JOIN contact_lists cl ON cl.list_id = l.id AND cl.status = 'active' AND cl.deleted_at IS NULL WHERE l.deleted_at IS NULL GROUP BY l.id, l.name`
+	// Only memberships of the current version of non deleted segments are counted
+	segmentQuery := `SELECT s.id, s.name, COUNT\(cs.email\) AS contact_count FROM segments s LEFT JOIN contact_segments cs ON cs.segment_id = s.id AND cs.version = s.version WHERE s.status != 'deleted' GROUP BY s.id, s.name`
+
+	t.Run("Success - Returns list and segment counts", func(t *testing.T) {
+		mockWorkspaceRepo.EXPECT().
+			GetConnection(ctx, workspaceID).
+			Return(db, nil)
+
+		mock.ExpectQuery(listQuery).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "active_count"}).
+				AddRow("newsletter", "Newsletter", 12).
+				AddRow("product", "Product updates", 0))
+		mock.ExpectQuery(segmentQuery).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "contact_count"}).
+				AddRow("vip", "VIP", 3))
+
+		counts, err := repo.GetAudienceCounts(ctx, workspaceID)
+		require.NoError(t, err)
+		assert.Equal(t, []domain.ListContactCount{
+			{ListID: "newsletter", Name: "Newsletter", ActiveCount: 12},
+			{ListID: "product", Name: "Product updates", ActiveCount: 0},
+		}, counts.Lists)
+		assert.Equal(t, []domain.SegmentContactCount{
+			{SegmentID: "vip", Name: "VIP", ContactCount: 3},
+		}, counts.Segments)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Success - Empty workspace", func(t *testing.T) {
+		mockWorkspaceRepo.EXPECT().
+			GetConnection(ctx, workspaceID).
+			Return(db, nil)
+
+		mock.ExpectQuery(listQuery).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "active_count"}))
+		mock.ExpectQuery(segmentQuery).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "contact_count"}))
+
+		counts, err := repo.GetAudienceCounts(ctx, workspaceID)
+		require.NoError(t, err)
+		assert.Empty(t, counts.Lists)
+		assert.NotNil(t, counts.Lists)
+		assert.Empty(t, counts.Segments)
+		assert.NotNil(t, counts.Segments)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Error - Connection error", func(t *testing.T) {
+		mockWorkspaceRepo.EXPECT().
+			GetConnection(ctx, workspaceID).
+			Return(nil, errors.New("connection error"))
+
+		counts, err := repo.GetAudienceCounts(ctx, workspaceID)
+		assert.Error(t, err)
+		assert.Nil(t, counts)
+		assert.Contains(t, err.Error(), "failed to get workspace connection")
+	})
+
+	t.Run("Error - Segment query error", func(t *testing.T) {
+		mockWorkspaceRepo.EXPECT().
+			GetConnection(ctx, workspaceID).
+			Return(db, nil)
+
+		mock.ExpectQuery(listQuery).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "name", "active_count"}))
+		mock.ExpectQuery(segmentQuery).
+			WillReturnError(errors.New("query error"))
+
+		counts, err := repo.GetAudienceCounts(ctx, workspaceID)
+		assert.Error(t, err)
+		assert.Nil(t, counts)
+		assert.Contains(t, err.Error(), "failed to count segment contacts")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
 func TestContactRepository_GetBatchForSegment(t *testing.T) {
 	// Test contactRepository.GetBatchForSegment - this was at 0% coverage
 	ctrl := gomock.NewController(t)
@@ -2316,3 +3288,303 @@ func TestContactRepository_GetBatchForSegment(t *testing.T) {
 		assert.Contains(t, err.Error(), "failed to query emails")
 	})
 }
+
+func TestContactRepository_GetOptimalSendHours(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	repo := NewContactRepository(mockWorkspaceRepo)
+
+	db, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	workspaceID := "workspace123"
+	emails := []string{"morning@example.com", "evening@example.com", "never@example.com"}
+
+	// The most frequent UTC open hour of each contact, the earliest one on a tie
+	hoursQuery := `SELECT DISTINCT ON \(contact_email\) contact_email, EXTRACT\(HOUR FROM opened_at AT TIME ZONE 'UTC'\)::int AS open_hour FROM message_history WHERE contact_email = ANY\(\$1\) AND opened_at IS NOT NULL GROUP BY contact_email, open_hour ORDER BY contact_email, COUNT\(\*\) DESC, open_hour ASC`
+
+	t.Run("Success - Returns the hour of contacts with opens", func(t *testing.T) {
+		mockWorkspaceRepo.EXPECT().
+			GetConnection(ctx, workspaceID).
+			Return(db, nil)
+
+		mock.ExpectQuery(hoursQuery).
+			WithArgs(pq.Array(emails)).
+			WillReturnRows(sqlmock.NewRows([]string{"contact_email", "open_hour"}).
+				AddRow("evening@example.com", 19).
+				AddRow("morning@example.com", 9))
+
+		hours, err := repo.GetOptimalSendHours(ctx, workspaceID, emails)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int{"morning@example.com": 9, "evening@example.com": 19}, hours)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("Success - Empty emails does not query", func(t *testing.T) {
+		hours, err := repo.GetOptimalSendHours(ctx, workspaceID, []string{})
+		require.NoError(t, err)
+		assert.Empty(t, hours)
+		assert.NotNil(t, hours)
+	})
+
+	t.Run("Error - Connection error", func(t *testing.T) {
+		mockWorkspaceRepo.EXPECT().
+			GetConnection(ctx, workspaceID).
+			Return(nil, errors.New("connection error"))
+
+		hours, err := repo.GetOptimalSendHours(ctx, workspaceID, emails)
+		assert.Error(t, err)
+		assert.Nil(t, hours)
+		assert.Contains(t, err.Error(), "failed to get workspace connection")
+	})
+
+	t.Run("Error - Query error", func(t *testing.T) {
+		mockWorkspaceRepo.EXPECT().
+			GetConnection(ctx, workspaceID).
+			Return(db, nil)
+
+		mock.ExpectQuery(hoursQuery).
+			WithArgs(pq.Array(emails)).
+			WillReturnError(errors.New("query error"))
+
+		hours, err := repo.GetOptimalSendHours(ctx, workspaceID, emails)
+		assert.Error(t, err)
+		assert.Nil(t, hours)
+		assert.Contains(t, err.Error(), "failed to query open hours")
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestCountDeliverableContactsForBroadcast(t *testing.T) {
+	t.Run("should count raw and deliverable contacts of a list audience", func(t *testing.T) {
+		mockDB, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+		workspaceRepo.EXPECT().GetConnection(gomock.Any(), "workspace123").Return(mockDB, nil)
+
+		repo := NewContactRepository(workspaceRepo)
+
+		// ExcludeUnsubscribed is ignored for the raw count
+		audience := domain.AudienceSettings{
+			List:                "list1",
+			ExcludeUnsubscribed: true,
+		}
+
+		rows := sqlmock.NewRows([]string{"count", "count"}).AddRow(1000, 120)
+
+		mock.ExpectQuery(`SELECT COUNT\(\*\), COUNT\(\*\) FILTER \(WHERE cl\.status NOT IN \('unsubscribed', 'bounced', 'complained'\) AND NOT EXISTS \( SELECT 1 FROM contact_lists sup WHERE sup\.email = c\.email AND sup\.deleted_at IS NULL AND sup\.status IN \('bounced', 'complained'\) \)\) FROM contacts c JOIN contact_lists cl ON c\.email = cl\.email JOIN lists l ON cl\.list_id = l\.id WHERE cl\.list_id = \$1 AND l\.deleted_at IS NULL AND cl\.status <> \$2 AND ` + notSuppressedPattern + `$`).
+			WithArgs("list1", domain.ContactListStatusPending).
+			WillReturnRows(rows)
+
+		rawCount, deliverableCount, err := repo.CountDeliverableContactsForBroadcast(context.Background(), "workspace123", audience)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1000, rawCount)
+		assert.Equal(t, 120, deliverableCount)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should only exclude bounced and complained contacts for a segment audience", func(t *testing.T) {
+		mockDB, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+		workspaceRepo.EXPECT().GetConnection(gomock.Any(), "workspace123").Return(mockDB, nil)
+
+		repo := NewContactRepository(workspaceRepo)
+
+		audience := domain.AudienceSettings{Segments: []string{"seg1"}}
+
+		rows := sqlmock.NewRows([]string{"count", "count"}).AddRow(50, 48)
+
+		mock.ExpectQuery(`SELECT COUNT\(\*\), COUNT\(\*\) FILTER \(WHERE NOT EXISTS \( SELECT 1 FROM contact_lists sup WHERE sup\.email = c\.email AND sup\.deleted_at IS NULL AND sup\.status IN \('bounced', 'complained'\) \)\) FROM contacts c WHERE EXISTS \(SELECT 1 FROM contact_segments cs WHERE cs\.email = c\.email AND cs\.segment_id IN \(\$1\)\)`).
+			WithArgs("seg1").
+			WillReturnRows(rows)
+
+		rawCount, deliverableCount, err := repo.CountDeliverableContactsForBroadcast(context.Background(), "workspace123", audience)
+
+		require.NoError(t, err)
+		assert.Equal(t, 50, rawCount)
+		assert.Equal(t, 48, deliverableCount)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("should handle database query error", func(t *testing.T) {
+		mockDB, mock, cleanup := setupMockDB(t)
+		defer cleanup()
+
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+		workspaceRepo.EXPECT().GetConnection(gomock.Any(), "workspace123").Return(mockDB, nil)
+
+		repo := NewContactRepository(workspaceRepo)
+
+		mock.ExpectQuery(`SELECT COUNT\(\*\), COUNT\(\*\) FILTER`).
+			WillReturnError(fmt.Errorf("query error"))
+
+		_, _, err := repo.CountDeliverableContactsForBroadcast(context.Background(), "workspace123", domain.AudienceSettings{List: "list1"})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to execute deliverable count query")
+	})
+}
+
+func TestContactRepository_Suppressions(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	repo := NewContactRepository(mockWorkspaceRepo)
+
+	db, mock, cleanup := setupMockDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	workspaceID := "workspace123"
+	bouncedAt := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	messageID := "msg-1"
+
+	t.Run("AddSuppressions keeps the first reason of suppressed emails", func(t *testing.T) {
+		mockWorkspaceRepo.EXPECT().GetConnection(ctx, workspaceID).Return(db, nil)
+
+		mock.ExpectExec(`INSERT INTO email_suppressions \(email,reason,message_id,created_at\) VALUES \(\$1,\$2,\$3,\$4\),\(\$5,\$6,\$7,\$8\) ON CONFLICT \(email\) DO NOTHING`).
+			WithArgs("bounced@example.com", "hard_bounce", "msg-1", bouncedAt, "spam@example.com", "spam_complaint", nil, bouncedAt).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		err := repo.AddSuppressions(ctx, workspaceID, []*domain.EmailSuppression{
+			{Email: "bounced@example.com", Reason: domain.BounceCategoryHardBounce, MessageID: &messageID, CreatedAt: bouncedAt},
+			{Email: "spam@example.com", Reason: domain.BounceCategorySpamComplaint, CreatedAt: bouncedAt},
+		})
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("AddSuppressions without suppressions does not query", func(t *testing.T) {
+		require.NoError(t, repo.AddSuppressions(ctx, workspaceID, nil))
+	})
+
+	t.Run("ListSuppressions pages by email", func(t *testing.T) {
+		mockWorkspaceRepo.EXPECT().GetConnection(ctx, workspaceID).Return(db, nil)
+
+		mock.ExpectQuery(`SELECT email, reason, message_id, created_at FROM email_suppressions WHERE email > \$1 ORDER BY email ASC LIMIT 2`).
+			WithArgs("a@example.com").
+			WillReturnRows(sqlmock.NewRows([]string{"email", "reason", "message_id", "created_at"}).
+				AddRow("bounced@example.com", "hard_bounce", "msg-1", bouncedAt).
+				AddRow("spam@example.com", "spam_complaint", nil, bouncedAt))
+
+		suppressions, err := repo.ListSuppressions(ctx, workspaceID, 2, "a@example.com")
+		require.NoError(t, err)
+		assert.Equal(t, []*domain.EmailSuppression{
+			{Email: "bounced@example.com", Reason: domain.BounceCategoryHardBounce, MessageID: &messageID, CreatedAt: bouncedAt},
+			{Email: "spam@example.com", Reason: domain.BounceCategorySpamComplaint, CreatedAt: bouncedAt},
+		}, suppressions)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("RemoveSuppression deletes the email", func(t *testing.T) {
+		mockWorkspaceRepo.EXPECT().GetConnection(ctx, workspaceID).Return(db, nil)
+
+		mock.ExpectExec(`DELETE FROM email_suppressions WHERE email = \$1`).
+			WithArgs("bounced@example.com").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		require.NoError(t, repo.RemoveSuppression(ctx, workspaceID, "bounced@example.com"))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("RemoveSuppression of an email that is not suppressed", func(t *testing.T) {
+		mockWorkspaceRepo.EXPECT().GetConnection(ctx, workspaceID).Return(db, nil)
+
+		mock.ExpectExec(`DELETE FROM email_suppressions WHERE email = \$1`).
+			WithArgs("unknown@example.com").
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := repo.RemoveSuppression(ctx, workspaceID, "unknown@example.com")
+		assert.ErrorIs(t, err, domain.ErrSuppressionNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
+func TestContactsForBroadcast_SuppressedContact(t *testing.T) {
+	// bounced@example.com hard bounced on a previous broadcast. It only matches while the NOT EXISTS
+	// condition on email_suppressions is absent, so the mocked results model its exclusion.
+	segmentQuery := `SELECT ` + contactColumnsPattern + ` FROM contacts c WHERE EXISTS \(SELECT 1 FROM contact_segments cs WHERE cs\.email = c\.email AND cs\.segment_id IN \(\$1\)\) AND ` + notSuppressedPattern + ` ORDER BY c\.email ASC LIMIT 10$`
+	countQuery := `SELECT COUNT\(\*\) FROM contacts c WHERE EXISTS \(SELECT 1 FROM contact_segments cs WHERE cs\.email = c\.email AND cs\.segment_id IN \(\$1\)\) AND ` + notSuppressedPattern + `$`
+	audience := domain.AudienceSettings{Segments: []string{"segment1"}}
+
+	setup := func(t *testing.T) (domain.ContactRepository, sqlmock.Sqlmock) {
+		mockDB, mock, cleanup := setupMockDB(t)
+		t.Cleanup(cleanup)
+		ctrl := gomock.NewController(t)
+		t.Cleanup(ctrl.Finish)
+
+		workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+		workspaceRepo.EXPECT().GetConnection(gomock.Any(), "workspace123").Return(mockDB, nil).AnyTimes()
+		return NewContactRepository(workspaceRepo), mock
+	}
+
+	t.Run("suppressed contact is excluded from the fetched audience", func(t *testing.T) {
+		repo, mock := setup(t)
+
+		mock.ExpectExec(`INSERT INTO email_suppressions`).
+			WithArgs("bounced@example.com", "hard_bounce", nil, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(segmentQuery).
+			WithArgs("segment1").
+			WillReturnRows(sqlmock.NewRows([]string{
+				"email", "external_id", "timezone", "language", "first_name", "last_name", "full_name", "phone",
+				"address_line_1", "address_line_2", "country", "postcode", "state", "job_title",
+				"custom_string_1", "custom_string_2", "custom_string_3", "custom_string_4", "custom_string_5",
+				"custom_number_1", "custom_number_2", "custom_number_3", "custom_number_4", "custom_number_5",
+				"custom_datetime_1", "custom_datetime_2", "custom_datetime_3", "custom_datetime_4", "custom_datetime_5",
+				"custom_json_1", "custom_json_2", "custom_json_3", "custom_json_4", "custom_json_5",
+				"created_at", "updated_at", "db_created_at", "db_updated_at",
+			}).AddRow("active@example.com", nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+				nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+				nil, nil, nil, nil, nil, time.Now(), time.Now(), time.Now(), time.Now()))
+
+		err := repo.AddSuppressions(context.Background(), "workspace123", []*domain.EmailSuppression{
+			{Email: "bounced@example.com", Reason: domain.BounceCategoryHardBounce, CreatedAt: time.Now()},
+		})
+		require.NoError(t, err)
+
+		contacts, err := repo.GetContactsForBroadcast(context.Background(), "workspace123", audience, 10, "")
+		require.NoError(t, err)
+		require.Len(t, contacts, 1)
+		assert.Equal(t, "active@example.com", contacts[0].Contact.Email)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("suppressed contact is excluded from the audience count", func(t *testing.T) {
+		repo, mock := setup(t)
+
+		mock.ExpectExec(`INSERT INTO email_suppressions`).
+			WithArgs("bounced@example.com", "hard_bounce", nil, sqlmock.AnyArg()).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectQuery(countQuery).
+			WithArgs("segment1").
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+		err := repo.AddSuppressions(context.Background(), "workspace123", []*domain.EmailSuppression{
+			{Email: "bounced@example.com", Reason: domain.BounceCategoryHardBounce, CreatedAt: time.Now()},
+		})
+		require.NoError(t, err)
+
+		count, err := repo.CountContactsForBroadcast(context.Background(), "workspace123", audience)
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
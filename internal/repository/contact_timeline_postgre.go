@@ -283,3 +283,78 @@ func (r *ContactTimelineRepository) DeleteForEmail(ctx context.Context, workspac
 
 	return nil
 }
+
+// ListSince retrieves entries of all contacts inserted after a contact activity checkpoint and no
+// later than until, ordered by insertion time and ID
+func (r *ContactTimelineRepository) ListSince(ctx context.Context, workspaceID string, checkpoint string, until time.Time, limit int) ([]*domain.ContactTimelineEntry, error) {
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	after, afterID, err := domain.ParseContactActivityCheckpoint(checkpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
+		Select("id", "email", "operation", "entity_type", "kind", "changes", "entity_id", "created_at", "db_created_at").
+		From("contact_timeline").
+		Where(sq.LtOrEq{"db_created_at": until}).
+		OrderBy("db_created_at ASC", "id ASC").
+		Limit(uint64(limit))
+
+	// A checkpoint without entry ID points before every entry inserted at that time
+	if afterID == "" {
+		builder = builder.Where(sq.GtOrEq{"db_created_at": after})
+	} else {
+		builder = builder.Where(sq.Expr("(db_created_at, id) > (?, ?::uuid)", after, afterID))
+	}
+
+	query, args, err := builder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := workspaceDB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query timeline: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var entries []*domain.ContactTimelineEntry
+	for rows.Next() {
+		entry := &domain.ContactTimelineEntry{}
+		var changesJSON []byte
+
+		if err := rows.Scan(
+			&entry.ID,
+			&entry.Email,
+			&entry.Operation,
+			&entry.EntityType,
+			&entry.Kind,
+			&changesJSON,
+			&entry.EntityID,
+			&entry.CreatedAt,
+			&entry.DBCreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan timeline entry: %w", err)
+		}
+
+		if changesJSON != nil {
+			changes := make(map[string]interface{})
+			if err := parseJSON(changesJSON, &changes); err != nil {
+				return nil, fmt.Errorf("failed to parse changes JSON: %w", err)
+			}
+			entry.Changes = changes
+		}
+
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating timeline rows: %w", err)
+	}
+
+	return entries, nil
+}
@@ -86,7 +86,7 @@ func (r *EmailQueueRepository) EnqueueTx(ctx context.Context, tx *sql.Tx, entrie
 			"id", "status", "priority", "source_type", "source_id",
 			"integration_id", "provider_kind", "contact_email", "message_id",
 			"template_id", "payload", "attempts", "max_attempts",
-			"created_at", "updated_at",
+			"next_retry_at", "created_at", "updated_at",
 		)
 
 	for _, entry := range entries {
@@ -118,7 +118,7 @@ func (r *EmailQueueRepository) EnqueueTx(ctx context.Context, tx *sql.Tx, entrie
 			entry.ID, entry.Status, entry.Priority, entry.SourceType, entry.SourceID,
 			entry.IntegrationID, entry.ProviderKind, entry.ContactEmail, entry.MessageID,
 			entry.TemplateID, payloadJSON, entry.Attempts, entry.MaxAttempts,
-			entry.CreatedAt, entry.UpdatedAt,
+			entry.NextRetryAt, entry.CreatedAt, entry.UpdatedAt,
 		)
 	}
 
@@ -346,3 +346,114 @@ func (r *inboundWebhookEventRepository) DeleteForEmail(ctx context.Context, work
 
 	return nil
 }
+
+// StorePayload stores a raw inbound webhook payload for later reprocessing
+func (r *inboundWebhookEventRepository) StorePayload(ctx context.Context, workspaceID string, payload *domain.InboundWebhookPayload) error {
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	query := `
+		INSERT INTO inbound_webhook_payloads (id, integration_id, payload, received_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (id) DO NOTHING`
+
+	if _, err := workspaceDB.ExecContext(ctx, query, payload.ID, payload.IntegrationID, payload.Payload, payload.ReceivedAt); err != nil {
+		return fmt.Errorf("failed to store inbound webhook payload: %w", err)
+	}
+
+	return nil
+}
+
+// MarkPayloadProcessed records the outcome of the last ingestion of a raw payload
+func (r *inboundWebhookEventRepository) MarkPayloadProcessed(ctx context.Context, workspaceID, id string, processedAt time.Time, processingError *string) error {
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	query := `UPDATE inbound_webhook_payloads SET processed_at = $1, error = $2 WHERE id = $3`
+
+	if _, err := workspaceDB.ExecContext(ctx, query, processedAt, processingError, id); err != nil {
+		return fmt.Errorf("failed to mark inbound webhook payload as processed: %w", err)
+	}
+
+	return nil
+}
+
+// ListPayloads retrieves raw payloads received within a time range, oldest first
+func (r *inboundWebhookEventRepository) ListPayloads(ctx context.Context, workspaceID string, params domain.InboundWebhookPayloadListParams) ([]*domain.InboundWebhookPayload, error) {
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
+	queryBuilder := psql.Select("id", "integration_id", "payload", "received_at", "processed_at", "error").
+		From("inbound_webhook_payloads").
+		Where(sq.GtOrEq{"received_at": params.ReceivedAfter}).
+		Where(sq.Lt{"received_at": params.ReceivedBefore}).
+		OrderBy("received_at ASC", "id ASC")
+
+	if params.IntegrationID != "" {
+		queryBuilder = queryBuilder.Where(sq.Eq{"integration_id": params.IntegrationID})
+	}
+
+	if params.AfterReceivedAt != nil {
+		queryBuilder = queryBuilder.Where(sq.Expr("(received_at, id) > (?, ?)", *params.AfterReceivedAt, params.AfterID))
+	}
+
+	if params.Limit > 0 {
+		queryBuilder = queryBuilder.Limit(uint64(params.Limit))
+	}
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := workspaceDB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list inbound webhook payloads: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	payloads := []*domain.InboundWebhookPayload{}
+	for rows.Next() {
+		payload := &domain.InboundWebhookPayload{}
+		var processedAt sql.NullTime
+		var processingError sql.NullString
+		if err := rows.Scan(&payload.ID, &payload.IntegrationID, &payload.Payload, &payload.ReceivedAt, &processedAt, &processingError); err != nil {
+			return nil, fmt.Errorf("failed to scan inbound webhook payload: %w", err)
+		}
+		if processedAt.Valid {
+			payload.ProcessedAt = &processedAt.Time
+		}
+		if processingError.Valid {
+			payload.Error = &processingError.String
+		}
+		payloads = append(payloads, payload)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating inbound webhook payloads: %w", err)
+	}
+
+	return payloads, nil
+}
+
+// DeletePayloadsBefore deletes raw payloads received before the given time
+func (r *inboundWebhookEventRepository) DeletePayloadsBefore(ctx context.Context, workspaceID string, before time.Time) (int64, error) {
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	result, err := workspaceDB.ExecContext(ctx, `DELETE FROM inbound_webhook_payloads WHERE received_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete inbound webhook payloads: %w", err)
+	}
+
+	return result.RowsAffected()
+}
@@ -595,3 +595,199 @@ func TestInboundWebhookEventRepository_DeleteForEmail(t *testing.T) {
 		assert.Contains(t, err.Error(), "failed to get affected rows")
 	})
 }
+
+func TestInboundWebhookEventRepository_StorePayload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	repo := NewInboundWebhookEventRepository(mockWorkspaceRepo)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+	workspaceID := "ws-123"
+	payload := &domain.InboundWebhookPayload{
+		ID:            "payload-1",
+		IntegrationID: "integration-1",
+		Payload:       `{"RecordType":"Delivery"}`,
+		ReceivedAt:    time.Now().UTC(),
+	}
+
+	t.Run("success", func(t *testing.T) {
+		mockWorkspaceRepo.EXPECT().GetConnection(gomock.Any(), workspaceID).Return(db, nil)
+
+		mock.ExpectExec(`INSERT INTO inbound_webhook_payloads .* ON CONFLICT \(id\) DO NOTHING`).
+			WithArgs(payload.ID, payload.IntegrationID, payload.Payload, payload.ReceivedAt).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.StorePayload(ctx, workspaceID, payload)
+		assert.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("execution error", func(t *testing.T) {
+		mockWorkspaceRepo.EXPECT().GetConnection(gomock.Any(), workspaceID).Return(db, nil)
+
+		mock.ExpectExec(`INSERT INTO inbound_webhook_payloads`).
+			WillReturnError(errors.New("db error"))
+
+		err := repo.StorePayload(ctx, workspaceID, payload)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to store inbound webhook payload")
+	})
+
+	t.Run("workspace connection error", func(t *testing.T) {
+		mockWorkspaceRepo.EXPECT().GetConnection(gomock.Any(), workspaceID).Return(nil, errors.New("connection error"))
+
+		err := repo.StorePayload(ctx, workspaceID, payload)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to get workspace connection")
+	})
+}
+
+func TestInboundWebhookEventRepository_MarkPayloadProcessed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	repo := NewInboundWebhookEventRepository(mockWorkspaceRepo)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+	workspaceID := "ws-123"
+	processedAt := time.Now().UTC()
+	processingError := "failed to process webhook"
+
+	mockWorkspaceRepo.EXPECT().GetConnection(gomock.Any(), workspaceID).Return(db, nil)
+
+	mock.ExpectExec(`UPDATE inbound_webhook_payloads SET processed_at = \$1, error = \$2 WHERE id = \$3`).
+		WithArgs(processedAt, &processingError, "payload-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repo.MarkPayloadProcessed(ctx, workspaceID, "payload-1", processedAt, &processingError)
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestInboundWebhookEventRepository_ListPayloads(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	repo := NewInboundWebhookEventRepository(mockWorkspaceRepo)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+	workspaceID := "ws-123"
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+	processedAt := from.Add(2 * time.Hour)
+
+	t.Run("first page", func(t *testing.T) {
+		mockWorkspaceRepo.EXPECT().GetConnection(gomock.Any(), workspaceID).Return(db, nil)
+
+		rows := sqlmock.NewRows([]string{"id", "integration_id", "payload", "received_at", "processed_at", "error"}).
+			AddRow("payload-1", "integration-1", `{"a":1}`, from.Add(time.Hour), processedAt, nil).
+			AddRow("payload-2", "integration-1", `{"a":2}`, from.Add(2*time.Hour), nil, "boom")
+
+		mock.ExpectQuery(`SELECT id, integration_id, payload, received_at, processed_at, error FROM inbound_webhook_payloads WHERE received_at >= \$1 AND received_at < \$2 AND integration_id = \$3 ORDER BY received_at ASC, id ASC LIMIT 100`).
+			WithArgs(from, to, "integration-1").
+			WillReturnRows(rows)
+
+		payloads, err := repo.ListPayloads(ctx, workspaceID, domain.InboundWebhookPayloadListParams{
+			IntegrationID:  "integration-1",
+			ReceivedAfter:  from,
+			ReceivedBefore: to,
+			Limit:          100,
+		})
+		require.NoError(t, err)
+		require.Len(t, payloads, 2)
+		assert.Equal(t, "payload-1", payloads[0].ID)
+		require.NotNil(t, payloads[0].ProcessedAt)
+		assert.Nil(t, payloads[0].Error)
+		assert.Nil(t, payloads[1].ProcessedAt)
+		require.NotNil(t, payloads[1].Error)
+		assert.Equal(t, "boom", *payloads[1].Error)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("with cursor", func(t *testing.T) {
+		mockWorkspaceRepo.EXPECT().GetConnection(gomock.Any(), workspaceID).Return(db, nil)
+
+		after := from.Add(2 * time.Hour)
+		mock.ExpectQuery(`SELECT .* FROM inbound_webhook_payloads WHERE received_at >= \$1 AND received_at < \$2 AND \(received_at, id\) > \(\$3, \$4\) ORDER BY received_at ASC, id ASC LIMIT 100`).
+			WithArgs(from, to, after, "payload-2").
+			WillReturnRows(sqlmock.NewRows([]string{"id", "integration_id", "payload", "received_at", "processed_at", "error"}))
+
+		payloads, err := repo.ListPayloads(ctx, workspaceID, domain.InboundWebhookPayloadListParams{
+			ReceivedAfter:   from,
+			ReceivedBefore:  to,
+			AfterReceivedAt: &after,
+			AfterID:         "payload-2",
+			Limit:           100,
+		})
+		require.NoError(t, err)
+		assert.Empty(t, payloads)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		mockWorkspaceRepo.EXPECT().GetConnection(gomock.Any(), workspaceID).Return(db, nil)
+
+		mock.ExpectQuery(`SELECT .* FROM inbound_webhook_payloads`).
+			WillReturnError(errors.New("db error"))
+
+		_, err := repo.ListPayloads(ctx, workspaceID, domain.InboundWebhookPayloadListParams{ReceivedAfter: from, ReceivedBefore: to})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to list inbound webhook payloads")
+	})
+}
+
+func TestInboundWebhookEventRepository_DeletePayloadsBefore(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	repo := NewInboundWebhookEventRepository(mockWorkspaceRepo)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	ctx := context.Background()
+	workspaceID := "ws-123"
+	before := time.Now().UTC().Add(-72 * time.Hour)
+
+	t.Run("success", func(t *testing.T) {
+		mockWorkspaceRepo.EXPECT().GetConnection(gomock.Any(), workspaceID).Return(db, nil)
+
+		mock.ExpectExec(`DELETE FROM inbound_webhook_payloads WHERE received_at < \$1`).
+			WithArgs(before).
+			WillReturnResult(sqlmock.NewResult(0, 5))
+
+		deleted, err := repo.DeletePayloadsBefore(ctx, workspaceID, before)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(5), deleted)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("execution error", func(t *testing.T) {
+		mockWorkspaceRepo.EXPECT().GetConnection(gomock.Any(), workspaceID).Return(db, nil)
+
+		mock.ExpectExec(`DELETE FROM inbound_webhook_payloads`).
+			WillReturnError(errors.New("db error"))
+
+		_, err := repo.DeletePayloadsBefore(ctx, workspaceID, before)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to delete inbound webhook payloads")
+	})
+}
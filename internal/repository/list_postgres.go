@@ -249,3 +249,64 @@ func (r *listRepository) GetListStats(ctx context.Context, workspaceID string, i
 
 	return stats, nil
 }
+
+// GetGrowthStats retrieves the subscribes and unsubscribes of a list for each day from from to to, both included,
+// with days cut in the timezone tz. from and to are read as dates, their time is ignored.
+// Subscribes count the contacts added to the list on the day (created_at). Unsubscribes count the contacts
+// removed from the list (deleted_at) or that unsubscribed (unsubscribed status, dated by updated_at).
+func (r *listRepository) GetGrowthStats(ctx context.Context, workspaceID string, listID string, tz string, from time.Time, to time.Time) ([]*domain.ListGrowthDay, error) {
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	// Days without any change are filled by the series
+	query := `
+		WITH days AS (
+			SELECT generate_series($2::date::timestamp, $3::date::timestamp, INTERVAL '1 day') AS day
+		),
+		subscribes AS (
+			SELECT date_trunc('day', created_at AT TIME ZONE $4) AS day, COUNT(*) AS count
+			FROM contact_lists
+			WHERE list_id = $1
+			AND created_at >= $2::date::timestamp AT TIME ZONE $4
+			AND created_at < ($3::date + 1)::timestamp AT TIME ZONE $4
+			GROUP BY 1
+		),
+		unsubscribes AS (
+			SELECT date_trunc('day', COALESCE(deleted_at, updated_at) AT TIME ZONE $4) AS day, COUNT(*) AS count
+			FROM contact_lists
+			WHERE list_id = $1
+			AND (deleted_at IS NOT NULL OR status = 'unsubscribed')
+			AND COALESCE(deleted_at, updated_at) >= $2::date::timestamp AT TIME ZONE $4
+			AND COALESCE(deleted_at, updated_at) < ($3::date + 1)::timestamp AT TIME ZONE $4
+			GROUP BY 1
+		)
+		SELECT to_char(d.day, 'YYYY-MM-DD'), COALESCE(s.count, 0), COALESCE(u.count, 0)
+		FROM days d
+		LEFT JOIN subscribes s ON s.day = d.day
+		LEFT JOIN unsubscribes u ON u.day = d.day
+		ORDER BY d.day
+	`
+
+	rows, err := workspaceDB.QueryContext(ctx, query, listID, from.Format("2006-01-02"), to.Format("2006-01-02"), tz)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get list growth stats: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	days := make([]*domain.ListGrowthDay, 0)
+	for rows.Next() {
+		day := &domain.ListGrowthDay{}
+		if err := rows.Scan(&day.Date, &day.Subscribes, &day.Unsubscribes); err != nil {
+			return nil, fmt.Errorf("failed to scan list growth stats: %w", err)
+		}
+		day.Net = day.Subscribes - day.Unsubscribes
+		days = append(days, day)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to get list growth stats: %w", err)
+	}
+
+	return days, nil
+}
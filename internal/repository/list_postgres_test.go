@@ -407,6 +407,42 @@ func TestListRepository(t *testing.T) {
 			assert.Contains(t, err.Error(), "failed to get list stats")
 		})
 	})
+
+	t.Run("GetGrowthStats", func(t *testing.T) {
+		from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC)
+
+		t.Run("successful retrieval", func(t *testing.T) {
+			// A contact subscribing and unsubscribing on the same day counts in both the gross figures
+			rows := sqlmock.NewRows([]string{"day", "subscribes", "unsubscribes"}).
+				AddRow("2026-03-01", 1, 1).
+				AddRow("2026-03-02", 0, 0).
+				AddRow("2026-03-03", 4, 1)
+
+			sqlMock.ExpectQuery(`(?s)WITH days AS .+generate_series.+FROM contact_lists.+ORDER BY d.day`).
+				WithArgs(testList.ID, "2026-03-01", "2026-03-03", "Europe/Paris").
+				WillReturnRows(rows)
+
+			days, err := repo.GetGrowthStats(context.Background(), "workspace123", testList.ID, "Europe/Paris", from, to)
+			require.NoError(t, err)
+			assert.Equal(t, []*domain.ListGrowthDay{
+				{Date: "2026-03-01", Subscribes: 1, Unsubscribes: 1, Net: 0},
+				{Date: "2026-03-02", Subscribes: 0, Unsubscribes: 0, Net: 0},
+				{Date: "2026-03-03", Subscribes: 4, Unsubscribes: 1, Net: 3},
+			}, days)
+		})
+
+		t.Run("database error", func(t *testing.T) {
+			sqlMock.ExpectQuery(`(?s)WITH days AS .+generate_series`).
+				WithArgs(testList.ID, "2026-03-01", "2026-03-03", "UTC").
+				WillReturnError(errors.New("database error"))
+
+			days, err := repo.GetGrowthStats(context.Background(), "workspace123", testList.ID, "UTC", from, to)
+			require.Error(t, err)
+			assert.Nil(t, days)
+			assert.Contains(t, err.Error(), "failed to get list growth stats")
+		})
+	})
 }
 
 func setupListRepositoryTest(t *testing.T) (*listRepository, *mocks.MockWorkspaceRepository) {
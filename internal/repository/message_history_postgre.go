@@ -4,8 +4,10 @@ import (
 	"context"
 	"database/sql"
 	"encoding/base64"
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
+	"io"
 	"strings"
 	"time"
 
@@ -13,23 +15,31 @@ import (
 	"github.com/Notifuse/notifuse/internal/domain"
 	"github.com/Notifuse/notifuse/pkg/crypto"
 	"github.com/Notifuse/notifuse/pkg/tracing"
+	"github.com/lib/pq"
 )
 
 // MessageHistoryRepository implements domain.MessageHistoryRepository
 type MessageHistoryRepository struct {
 	workspaceRepo domain.WorkspaceRepository
+	deriveKeys    bool
 }
 
-// NewMessageHistoryRepository creates a new message history repository
-func NewMessageHistoryRepository(workspaceRepo domain.WorkspaceRepository) *MessageHistoryRepository {
+// NewMessageHistoryRepository creates a new message history repository.
+// When deriveKeys is set, message data is encrypted with a key derived from the workspace secret key.
+func NewMessageHistoryRepository(workspaceRepo domain.WorkspaceRepository, deriveKeys bool) *MessageHistoryRepository {
 	return &MessageHistoryRepository{
 		workspaceRepo: workspaceRepo,
+		deriveKeys:    deriveKeys,
 	}
 }
 
+// messageDataDerivedKey is the "_key" marker of message data encrypted with the derived key,
+// data without the marker was encrypted with the raw workspace secret key
+const messageDataDerivedKey = "hkdf_v1"
+
 // encryptMessageData encrypts the Data field in MessageData
-// Stores encrypted data as {"_encrypted": "hex_string"}
-func encryptMessageData(data domain.MessageData, secretKey string) (domain.MessageData, error) {
+// Stores encrypted data as {"_encrypted": "hex_string"}, plus {"_key": "hkdf_v1"} when the key is derived
+func encryptMessageData(data domain.MessageData, secretKey string, deriveKey bool) (domain.MessageData, error) {
 	// If Data is empty or nil, return as-is
 	if len(data.Data) == 0 {
 		return data, nil
@@ -41,15 +51,26 @@ func encryptMessageData(data domain.MessageData, secretKey string) (domain.Messa
 		return data, fmt.Errorf("failed to marshal data for encryption: %w", err)
 	}
 
+	encryptedMap := map[string]interface{}{}
+	key := secretKey
+	if deriveKey {
+		key, err = crypto.DeriveKey(secretKey, crypto.KeyPurposeMessageData)
+		if err != nil {
+			return data, fmt.Errorf("failed to derive message data key: %w", err)
+		}
+		encryptedMap["_key"] = messageDataDerivedKey
+	}
+
 	// Encrypt the JSON string
-	encrypted, err := crypto.EncryptString(string(jsonBytes), secretKey)
+	encrypted, err := crypto.EncryptString(string(jsonBytes), key)
 	if err != nil {
 		return data, fmt.Errorf("failed to encrypt data: %w", err)
 	}
+	encryptedMap["_encrypted"] = encrypted
 
 	// Create new MessageData with encrypted content
 	encryptedData := domain.MessageData{
-		Data:     map[string]interface{}{"_encrypted": encrypted},
+		Data:     encryptedMap,
 		Metadata: data.Metadata, // Metadata is not encrypted
 	}
 
@@ -72,8 +93,18 @@ func decryptMessageData(data domain.MessageData, secretKey string) (domain.Messa
 		return data, fmt.Errorf("encrypted data is not a string")
 	}
 
+	// Data encrypted before key derivation uses the raw secret key
+	key := secretKey
+	if data.Data["_key"] == messageDataDerivedKey {
+		derivedKey, err := crypto.DeriveKey(secretKey, crypto.KeyPurposeMessageData)
+		if err != nil {
+			return data, fmt.Errorf("failed to derive message data key: %w", err)
+		}
+		key = derivedKey
+	}
+
 	// Decrypt the hex string
-	decrypted, err := crypto.DecryptFromHexString(encryptedHex, secretKey)
+	decrypted, err := crypto.DecryptFromHexString(encryptedHex, key)
 	if err != nil {
 		// If decryption fails, return error (don't expose encrypted data)
 		return data, fmt.Errorf("failed to decrypt data: %w", err)
@@ -154,7 +185,7 @@ func (r *MessageHistoryRepository) Create(ctx context.Context, workspaceID strin
 	}
 
 	// Encrypt message data before storage
-	encryptedMessageData, err := encryptMessageData(message.MessageData, secretKey)
+	encryptedMessageData, err := encryptMessageData(message.MessageData, secretKey, r.deriveKeys)
 	if err != nil {
 		return fmt.Errorf("failed to encrypt message data: %w", err)
 	}
@@ -214,6 +245,86 @@ func (r *MessageHistoryRepository) Create(ctx context.Context, workspaceID strin
 	return nil
 }
 
+// CreateWithIdempotencyKey adds a new message history record holding the idempotency key.
+// The insert is skipped when another message holds the key, so concurrent sends with the same key
+// record (and send) a single message.
+func (r *MessageHistoryRepository) CreateWithIdempotencyKey(ctx context.Context, workspaceID string, secretKey string, message *domain.MessageHistory, idempotencyKey string) error {
+	// Get the workspace database connection
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	// Encrypt message data before storage
+	encryptedMessageData, err := encryptMessageData(message.MessageData, secretKey, r.deriveKeys)
+	if err != nil {
+		return fmt.Errorf("failed to encrypt message data: %w", err)
+	}
+
+	// Serialize attachments to JSON for storage
+	var attachmentsJSON interface{}
+	if len(message.Attachments) > 0 {
+		attachmentsJSON = message.Attachments
+	}
+
+	query := `
+		INSERT INTO message_history (
+			id, external_id, contact_email, broadcast_id, automation_id, list_id, template_id, template_version,
+			channel, status_info, message_data, channel_options, attachments, sent_at, delivered_at,
+			failed_at, opened_at, clicked_at, bounced_at, complained_at,
+			unsubscribed_at, created_at, updated_at, idempotency_key
+		) VALUES (
+			$1, $2, $3, $4, $5, $6, $7, $8,
+			$9, LEFT($10, 255), $11, $12, $13, $14, $15,
+			$16, $17, $18, $19, $20,
+			$21, $22, $23, $24
+		)
+		ON CONFLICT (idempotency_key) WHERE idempotency_key IS NOT NULL DO NOTHING
+	`
+
+	result, err := workspaceDB.ExecContext(
+		ctx,
+		query,
+		message.ID,
+		message.ExternalID,
+		message.ContactEmail,
+		message.BroadcastID,
+		message.AutomationID,
+		message.ListID,
+		message.TemplateID,
+		message.TemplateVersion,
+		message.Channel,
+		message.StatusInfo,
+		encryptedMessageData,
+		message.ChannelOptions,
+		attachmentsJSON,
+		message.SentAt,
+		message.DeliveredAt,
+		message.FailedAt,
+		message.OpenedAt,
+		message.ClickedAt,
+		message.BouncedAt,
+		message.ComplainedAt,
+		message.UnsubscribedAt,
+		message.CreatedAt,
+		message.UpdatedAt,
+		idempotencyKey,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create message history: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return domain.ErrIdempotencyKeyUsed
+	}
+
+	return nil
+}
+
 // Upsert creates or updates a message history record (for retry handling)
 // On conflict, updates failed_at, status_info, and updated_at fields
 func (r *MessageHistoryRepository) Upsert(ctx context.Context, workspaceID string, secretKey string, message *domain.MessageHistory) error {
@@ -224,7 +335,7 @@ func (r *MessageHistoryRepository) Upsert(ctx context.Context, workspaceID strin
 	}
 
 	// Encrypt message data before storage
-	encryptedMessageData, err := encryptMessageData(message.MessageData, secretKey)
+	encryptedMessageData, err := encryptMessageData(message.MessageData, secretKey, r.deriveKeys)
 	if err != nil {
 		return fmt.Errorf("failed to encrypt message data: %w", err)
 	}
@@ -250,6 +361,7 @@ func (r *MessageHistoryRepository) Upsert(ctx context.Context, workspaceID strin
 		ON CONFLICT (id) DO UPDATE SET
 			failed_at = EXCLUDED.failed_at,
 			status_info = EXCLUDED.status_info,
+			channel_options = COALESCE(EXCLUDED.channel_options, message_history.channel_options),
 			updated_at = EXCLUDED.updated_at
 	`
 
@@ -422,6 +534,102 @@ func (r *MessageHistoryRepository) GetByExternalID(ctx context.Context, workspac
 	return &message, nil
 }
 
+// GetByExternalIDs retrieves the message histories of several external IDs in a single query, keyed by
+// external ID. External IDs without a message are left out of the map.
+func (r *MessageHistoryRepository) GetByExternalIDs(ctx context.Context, workspaceID string, secretKey string, externalIDs []string) (map[string]*domain.MessageHistory, error) {
+	messages := make(map[string]*domain.MessageHistory, len(externalIDs))
+	if len(externalIDs) == 0 {
+		return messages, nil
+	}
+
+	// Get the workspace database connection
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	query := fmt.Sprintf(`SELECT %s FROM message_history WHERE external_id = ANY($1)`, messageHistorySelectFields())
+
+	rows, err := workspaceDB.QueryContext(ctx, query, pq.Array(externalIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get message histories by external_id: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var message domain.MessageHistory
+		if err := scanMessage(rows, &message); err != nil {
+			return nil, fmt.Errorf("failed to scan message history: %w", err)
+		}
+
+		// Decrypt message data after reading from database
+		decryptedMessageData, err := decryptMessageData(message.MessageData, secretKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt message data: %w", err)
+		}
+		message.MessageData = decryptedMessageData
+
+		if message.ExternalID != nil {
+			messages[*message.ExternalID] = &message
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating message histories: %w", err)
+	}
+
+	return messages, nil
+}
+
+// GetByIdempotencyKey retrieves the message history holding an idempotency key
+func (r *MessageHistoryRepository) GetByIdempotencyKey(ctx context.Context, workspaceID string, secretKey string, idempotencyKey string) (*domain.MessageHistory, error) {
+	// Get the workspace database connection
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	query := fmt.Sprintf(`SELECT %s FROM message_history WHERE idempotency_key = $1`, messageHistorySelectFields())
+
+	var message domain.MessageHistory
+	err = scanMessage(workspaceDB.QueryRowContext(ctx, query, idempotencyKey), &message)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("message history with idempotency_key %s not found", idempotencyKey)
+		}
+		return nil, fmt.Errorf("failed to get message history by idempotency_key: %w", err)
+	}
+
+	// Decrypt message data after reading from database
+	decryptedMessageData, err := decryptMessageData(message.MessageData, secretKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt message data: %w", err)
+	}
+	message.MessageData = decryptedMessageData
+
+	return &message, nil
+}
+
+// ReleaseIdempotencyKey clears an idempotency key held by a message created before the given time
+// so that a new send can claim it
+func (r *MessageHistoryRepository) ReleaseIdempotencyKey(ctx context.Context, workspaceID string, idempotencyKey string, createdBefore time.Time) error {
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	_, err = workspaceDB.ExecContext(ctx,
+		`UPDATE message_history SET idempotency_key = NULL WHERE idempotency_key = $1 AND created_at < $2`,
+		idempotencyKey, createdBefore,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+
+	return nil
+}
+
 // GetByContact retrieves message history for a specific contact
 func (r *MessageHistoryRepository) GetByContact(ctx context.Context, workspaceID string, secretKey string, contactEmail string, limit, offset int) ([]*domain.MessageHistory, int, error) {
 	// Get the workspace database connection
@@ -580,36 +788,26 @@ func (r *MessageHistoryRepository) SetStatusesIfNotSet(ctx context.Context, work
 	// Process each status group with a single query
 	for messageEvent, groupUpdates := range messageEventGroups {
 		// Determine which field to check and update based on status
-		var field string
-		switch messageEvent {
-		case domain.MessageEventDelivered:
-			field = "delivered_at"
-		case domain.MessageEventFailed:
-			field = "failed_at"
-		case domain.MessageEventOpened:
-			field = "opened_at"
-		case domain.MessageEventClicked:
-			field = "clicked_at"
-		case domain.MessageEventBounced:
-			field = "bounced_at"
-		case domain.MessageEventComplained:
-			field = "complained_at"
-		case domain.MessageEventUnsubscribed:
-			field = "unsubscribed_at"
-		default:
+		field, err := messageEventField(messageEvent)
+		if err != nil {
 			// codecov:ignore:start
-			tracing.MarkSpanError(ctx, fmt.Errorf("invalid status: %s", messageEvent))
+			tracing.MarkSpanError(ctx, err)
 			// codecov:ignore:end
-			return fmt.Errorf("invalid status: %s", messageEvent)
+			return err
 		}
 
-		// Build VALUES clause for batch update with explicit timestamp casting and status_info
+		// Build VALUES clause for batch update with explicit timestamp casting, status_info and bounce_category
 		valuesParts := make([]string, len(groupUpdates))
 		args := []interface{}{now}
 
 		for i, update := range groupUpdates {
-			valuesParts[i] = fmt.Sprintf("($%d, $%d::TIMESTAMP WITH TIME ZONE, $%d)", len(args)+1, len(args)+2, len(args)+3)
-			args = append(args, update.ID, update.Timestamp, update.StatusInfo)
+			valuesParts[i] = fmt.Sprintf("($%d, $%d::TIMESTAMP WITH TIME ZONE, $%d, $%d)", len(args)+1, len(args)+2, len(args)+3, len(args)+4)
+			var bounceCategory *string
+			if update.BounceCategory != nil {
+				category := string(*update.BounceCategory)
+				bounceCategory = &category
+			}
+			args = append(args, update.ID, update.Timestamp, update.StatusInfo, bounceCategory)
 		}
 
 		valuesClause := strings.Join(valuesParts, ", ")
@@ -618,8 +816,9 @@ func (r *MessageHistoryRepository) SetStatusesIfNotSet(ctx context.Context, work
 			UPDATE message_history 
 			SET %s = updates.timestamp, 
 				status_info = COALESCE(LEFT(updates.status_info, 255), message_history.status_info), 
+				bounce_category = COALESCE(updates.bounce_category, message_history.bounce_category),
 				updated_at = $1::TIMESTAMP WITH TIME ZONE
-			FROM (VALUES %s) AS updates(id, timestamp, status_info)
+			FROM (VALUES %s) AS updates(id, timestamp, status_info, bounce_category)
 			WHERE message_history.id = updates.id AND %s IS NULL
 		`, field, valuesClause, field)
 		_, err = workspaceDB.ExecContext(ctx, query, args...)
@@ -634,6 +833,152 @@ func (r *MessageHistoryRepository) SetStatusesIfNotSet(ctx context.Context, work
 	return nil
 }
 
+// messageEventField returns the timestamp column recording a message event
+func messageEventField(event domain.MessageEvent) (string, error) {
+	switch event {
+	case domain.MessageEventDelivered:
+		return "delivered_at", nil
+	case domain.MessageEventFailed:
+		return "failed_at", nil
+	case domain.MessageEventOpened:
+		return "opened_at", nil
+	case domain.MessageEventClicked:
+		return "clicked_at", nil
+	case domain.MessageEventBounced:
+		return "bounced_at", nil
+	case domain.MessageEventComplained:
+		return "complained_at", nil
+	case domain.MessageEventUnsubscribed:
+		return "unsubscribed_at", nil
+	default:
+		return "", fmt.Errorf("invalid status: %s", event)
+	}
+}
+
+// ApplyStatusesLocked applies message status updates in a single transaction. The messages are
+// locked with SELECT ... FOR UPDATE first, so concurrent appliers of the same messages run one
+// after the other and each status keeps the timestamp of the first update applied. When several
+// updates of the batch set the same status of a message, the earliest one wins.
+func (r *MessageHistoryRepository) ApplyStatusesLocked(ctx context.Context, workspaceID string, updates []domain.MessageEventUpdate) error {
+	// codecov:ignore:start
+	ctx, span := tracing.StartServiceSpan(ctx, "MessageHistoryRepository", "ApplyStatusesLocked")
+	defer tracing.EndSpan(span, nil)
+	tracing.AddAttribute(ctx, "workspaceID", workspaceID)
+	tracing.AddAttribute(ctx, "updateCount", len(updates))
+	// codecov:ignore:end
+
+	if len(updates) == 0 {
+		return nil
+	}
+
+	// Keep the earliest update of each status of each message
+	type statusKey struct{ id, field string }
+	first := make(map[statusKey]domain.MessageEventUpdate, len(updates))
+	var keys []statusKey
+	ids := make([]string, 0, len(updates))
+	seenIDs := make(map[string]bool, len(updates))
+	for _, update := range updates {
+		field, err := messageEventField(update.Event)
+		if err != nil {
+			return err
+		}
+		key := statusKey{id: update.ID, field: field}
+		current, ok := first[key]
+		if !ok {
+			keys = append(keys, key)
+		}
+		if !ok || update.Timestamp.Before(current.Timestamp) {
+			first[key] = update
+		}
+		if !seenIDs[update.ID] {
+			seenIDs[update.ID] = true
+			ids = append(ids, update.ID)
+		}
+	}
+
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	tx, err := workspaceDB.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// Locking in id order keeps concurrent transactions over overlapping messages from deadlocking
+	rows, err := tx.QueryContext(ctx, `
+		SELECT id, delivered_at, failed_at, opened_at, clicked_at, bounced_at, complained_at, unsubscribed_at
+		FROM message_history
+		WHERE id = ANY($1)
+		ORDER BY id
+		FOR UPDATE
+	`, pq.Array(ids))
+	if err != nil {
+		return fmt.Errorf("failed to lock messages: %w", err)
+	}
+
+	alreadySet := make(map[statusKey]bool)
+	for rows.Next() {
+		var id string
+		var deliveredAt, failedAt, openedAt, clickedAt, bouncedAt, complainedAt, unsubscribedAt sql.NullTime
+		if err := rows.Scan(&id, &deliveredAt, &failedAt, &openedAt, &clickedAt, &bouncedAt, &complainedAt, &unsubscribedAt); err != nil {
+			_ = rows.Close()
+			return fmt.Errorf("failed to scan message statuses: %w", err)
+		}
+		for field, value := range map[string]sql.NullTime{
+			"delivered_at":    deliveredAt,
+			"failed_at":       failedAt,
+			"opened_at":       openedAt,
+			"clicked_at":      clickedAt,
+			"bounced_at":      bouncedAt,
+			"complained_at":   complainedAt,
+			"unsubscribed_at": unsubscribedAt,
+		} {
+			alreadySet[statusKey{id: id, field: field}] = value.Valid
+		}
+	}
+	if err := rows.Err(); err != nil {
+		_ = rows.Close()
+		return fmt.Errorf("error iterating message statuses: %w", err)
+	}
+	_ = rows.Close()
+
+	now := time.Now().UTC()
+	for _, key := range keys {
+		set, found := alreadySet[key]
+		if !found || set {
+			// Unknown message, or a status set by an earlier event
+			continue
+		}
+
+		update := first[key]
+		var bounceCategory *string
+		if update.BounceCategory != nil {
+			category := string(*update.BounceCategory)
+			bounceCategory = &category
+		}
+		query := fmt.Sprintf(`
+			UPDATE message_history
+			SET %s = $1,
+				status_info = COALESCE(LEFT($2, 255), status_info),
+				bounce_category = COALESCE($3, bounce_category),
+				updated_at = $4
+			WHERE id = $5
+		`, key.field)
+		if _, err := tx.ExecContext(ctx, query, update.Timestamp, update.StatusInfo, bounceCategory, now, key.id); err != nil {
+			return fmt.Errorf("failed to apply message status %s: %w", update.Event, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
 func (r *MessageHistoryRepository) SetClicked(ctx context.Context, workspaceID, id string, timestamp time.Time) error {
 	// Get the workspace database connection
 	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
@@ -728,147 +1073,38 @@ func (r *MessageHistoryRepository) ListMessages(ctx context.Context, workspaceID
 		"unsubscribed_at", "created_at", "updated_at",
 	).From("message_history")
 
-	// Apply filters using squirrel
-	if params.ID != "" {
-		queryBuilder = queryBuilder.Where(sq.Eq{"id": params.ID})
-	}
-
-	if params.ExternalID != "" {
-		queryBuilder = queryBuilder.Where(sq.Eq{"external_id": params.ExternalID})
-	}
-
-	if params.ListID != "" {
-		// Check if the list_id matches the specified list ID
-		queryBuilder = queryBuilder.Where(sq.Eq{"list_id": params.ListID})
-	}
+	queryBuilder = applyMessageListFilters(queryBuilder, params)
 
-	if params.Channel != "" {
-		queryBuilder = queryBuilder.Where(sq.Eq{"channel": params.Channel})
-	}
+	// Handle cursor-based pagination
+	if params.Cursor != "" {
+		// Decode the base64 cursor
+		decodedCursor, err := base64.StdEncoding.DecodeString(params.Cursor)
+		if err != nil {
+			// codecov:ignore:start
+			tracing.MarkSpanError(ctx, err)
+			// codecov:ignore:end
+			return nil, "", fmt.Errorf("invalid cursor encoding: %w", err)
+		}
 
-	if params.ContactEmail != "" {
-		queryBuilder = queryBuilder.Where(sq.Eq{"contact_email": params.ContactEmail})
-	}
+		// Parse the compound cursor (timestamp~id)
+		cursorStr := string(decodedCursor)
+		cursorParts := strings.Split(cursorStr, "~")
+		if len(cursorParts) != 2 {
+			// codecov:ignore:start
+			tracing.MarkSpanError(ctx, fmt.Errorf("invalid cursor format"))
+			// codecov:ignore:end
+			return nil, "", fmt.Errorf("invalid cursor format: expected timestamp~id")
+		}
 
-	if params.BroadcastID != "" {
-		queryBuilder = queryBuilder.Where(sq.Eq{"broadcast_id": params.BroadcastID})
-	}
+		cursorTime, err := time.Parse(time.RFC3339, cursorParts[0])
+		if err != nil {
+			// codecov:ignore:start
+			tracing.MarkSpanError(ctx, err)
+			// codecov:ignore:end
+			return nil, "", fmt.Errorf("invalid cursor timestamp format: %w", err)
+		}
 
-	if params.TemplateID != "" {
-		queryBuilder = queryBuilder.Where(sq.Eq{"template_id": params.TemplateID})
-	}
-
-	if params.IsSent != nil {
-		if *params.IsSent {
-			queryBuilder = queryBuilder.Where(sq.NotEq{"sent_at": nil})
-		} else {
-			queryBuilder = queryBuilder.Where(sq.Eq{"sent_at": nil})
-		}
-	}
-
-	if params.IsDelivered != nil {
-		if *params.IsDelivered {
-			queryBuilder = queryBuilder.Where(sq.NotEq{"delivered_at": nil})
-		} else {
-			queryBuilder = queryBuilder.Where(sq.Eq{"delivered_at": nil})
-		}
-	}
-
-	if params.IsFailed != nil {
-		if *params.IsFailed {
-			queryBuilder = queryBuilder.Where(sq.NotEq{"failed_at": nil})
-		} else {
-			queryBuilder = queryBuilder.Where(sq.Eq{"failed_at": nil})
-		}
-	}
-
-	if params.IsOpened != nil {
-		if *params.IsOpened {
-			queryBuilder = queryBuilder.Where(sq.NotEq{"opened_at": nil})
-		} else {
-			queryBuilder = queryBuilder.Where(sq.Eq{"opened_at": nil})
-		}
-	}
-
-	if params.IsClicked != nil {
-		if *params.IsClicked {
-			queryBuilder = queryBuilder.Where(sq.NotEq{"clicked_at": nil})
-		} else {
-			queryBuilder = queryBuilder.Where(sq.Eq{"clicked_at": nil})
-		}
-	}
-
-	if params.IsBounced != nil {
-		if *params.IsBounced {
-			queryBuilder = queryBuilder.Where(sq.NotEq{"bounced_at": nil})
-		} else {
-			queryBuilder = queryBuilder.Where(sq.Eq{"bounced_at": nil})
-		}
-	}
-
-	if params.IsComplained != nil {
-		if *params.IsComplained {
-			queryBuilder = queryBuilder.Where(sq.NotEq{"complained_at": nil})
-		} else {
-			queryBuilder = queryBuilder.Where(sq.Eq{"complained_at": nil})
-		}
-	}
-
-	if params.IsUnsubscribed != nil {
-		if *params.IsUnsubscribed {
-			queryBuilder = queryBuilder.Where(sq.NotEq{"unsubscribed_at": nil})
-		} else {
-			queryBuilder = queryBuilder.Where(sq.Eq{"unsubscribed_at": nil})
-		}
-	}
-
-	// Time range filters
-	if params.SentAfter != nil {
-		queryBuilder = queryBuilder.Where(sq.GtOrEq{"sent_at": params.SentAfter})
-	}
-
-	if params.SentBefore != nil {
-		queryBuilder = queryBuilder.Where(sq.LtOrEq{"sent_at": params.SentBefore})
-	}
-
-	if params.UpdatedAfter != nil {
-		queryBuilder = queryBuilder.Where(sq.GtOrEq{"updated_at": params.UpdatedAfter})
-	}
-
-	if params.UpdatedBefore != nil {
-		queryBuilder = queryBuilder.Where(sq.LtOrEq{"updated_at": params.UpdatedBefore})
-	}
-
-	// Handle cursor-based pagination
-	if params.Cursor != "" {
-		// Decode the base64 cursor
-		decodedCursor, err := base64.StdEncoding.DecodeString(params.Cursor)
-		if err != nil {
-			// codecov:ignore:start
-			tracing.MarkSpanError(ctx, err)
-			// codecov:ignore:end
-			return nil, "", fmt.Errorf("invalid cursor encoding: %w", err)
-		}
-
-		// Parse the compound cursor (timestamp~id)
-		cursorStr := string(decodedCursor)
-		cursorParts := strings.Split(cursorStr, "~")
-		if len(cursorParts) != 2 {
-			// codecov:ignore:start
-			tracing.MarkSpanError(ctx, fmt.Errorf("invalid cursor format"))
-			// codecov:ignore:end
-			return nil, "", fmt.Errorf("invalid cursor format: expected timestamp~id")
-		}
-
-		cursorTime, err := time.Parse(time.RFC3339, cursorParts[0])
-		if err != nil {
-			// codecov:ignore:start
-			tracing.MarkSpanError(ctx, err)
-			// codecov:ignore:end
-			return nil, "", fmt.Errorf("invalid cursor timestamp format: %w", err)
-		}
-
-		cursorID := cursorParts[1]
+		cursorID := cursorParts[1]
 
 		// Query for messages before the cursor (newer messages first)
 		// Either created_at is less than cursor time
@@ -1029,103 +1265,474 @@ func (r *MessageHistoryRepository) ListMessages(ctx context.Context, workspaceID
 	return messages, nextCursor, nil
 }
 
-func (r *MessageHistoryRepository) GetBroadcastStats(ctx context.Context, workspaceID string, id string) (*domain.MessageHistoryStatusSum, error) {
+// exportMessagesPageSize is the number of rows ExportMessages reads per query
+const exportMessagesPageSize = 1000
+
+// ExportMessages streams the messages matching the list filters as CSV, paging with a keyset
+// cursor on (created_at, id) so the whole result is never held in memory.
+// The message data is not exported, secretKey is accepted for parity with ListMessages.
+func (r *MessageHistoryRepository) ExportMessages(ctx context.Context, workspaceID string, secretKey string, params domain.MessageListParams, w io.Writer) error {
 	// codecov:ignore:start
-	ctx, span := tracing.StartServiceSpan(ctx, "MessageHistoryRepository", "GetBroadcastStats")
+	ctx, span := tracing.StartServiceSpan(ctx, "MessageHistoryRepository", "ExportMessages")
 	defer tracing.EndSpan(span, nil)
 	tracing.AddAttribute(ctx, "workspaceID", workspaceID)
-	tracing.AddAttribute(ctx, "broadcastID", id)
 	// codecov:ignore:end
 
-	// Get the workspace database connection
 	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
 	if err != nil {
 		// codecov:ignore:start
 		tracing.MarkSpanError(ctx, err)
 		// codecov:ignore:end
-		return nil, fmt.Errorf("failed to get workspace connection: %w", err)
+		return fmt.Errorf("failed to get workspace connection: %w", err)
 	}
 
-	// MessageEventSent         MessageEvent = "sent"
-	// MessageEventDelivered    MessageEvent = "delivered"
-	// MessageEventFailed       MessageEvent = "failed"
-	// MessageEventOpened       MessageEvent = "opened"
-	// MessageEventClicked      MessageEvent = "clicked"
-	// MessageEventBounced      MessageEvent = "bounced"
-	// MessageEventComplained   MessageEvent = "complained"
-	// MessageEventUnsubscribed MessageEvent = "unsubscribed"
+	csvWriter := csv.NewWriter(w)
+	if err := csvWriter.Write(domain.MessageExportColumns); err != nil {
+		return fmt.Errorf("failed to write export header: %w", err)
+	}
 
-	query := `
-		SELECT 
-			SUM(CASE WHEN sent_at IS NOT NULL THEN 1 ELSE 0 END) as total_sent,
-			SUM(CASE WHEN delivered_at IS NOT NULL THEN 1 ELSE 0 END) as total_delivered,
-			SUM(CASE WHEN failed_at IS NOT NULL THEN 1 ELSE 0 END) as total_failed,
-			SUM(CASE WHEN opened_at IS NOT NULL THEN 1 ELSE 0 END) as total_opened,
-			SUM(CASE WHEN clicked_at IS NOT NULL THEN 1 ELSE 0 END) as total_clicked,
-			SUM(CASE WHEN bounced_at IS NOT NULL THEN 1 ELSE 0 END) as total_bounced,
-			SUM(CASE WHEN complained_at IS NOT NULL THEN 1 ELSE 0 END) as total_complained,
-			SUM(CASE WHEN unsubscribed_at IS NOT NULL THEN 1 ELSE 0 END) as total_unsubscribed
-		FROM message_history
-		WHERE broadcast_id = $1
-	`
+	psql := sq.StatementBuilder.PlaceholderFormat(sq.Dollar)
 
-	row := workspaceDB.QueryRowContext(ctx, query, id)
-	stats := &domain.MessageHistoryStatusSum{}
+	var lastCreatedAt time.Time
+	var lastID string
+
+	for {
+		queryBuilder := psql.Select(
+			"id", "external_id", "contact_email", "broadcast_id", "automation_id", "list_id", "template_id", "template_version",
+			"channel", "status_info", "sent_at", "delivered_at", "failed_at", "opened_at", "clicked_at", "bounced_at",
+			"complained_at", "unsubscribed_at", "created_at", "updated_at",
+		).From("message_history")
+
+		queryBuilder = applyMessageListFilters(queryBuilder, params)
+
+		// Unlike the ListMessages cursor, the keyset keeps the full timestamp precision
+		// so messages created within the same second are neither skipped nor repeated
+		if lastID != "" {
+			queryBuilder = queryBuilder.Where(
+				sq.Or{
+					sq.Lt{"created_at": lastCreatedAt},
+					sq.And{
+						sq.Eq{"created_at": lastCreatedAt},
+						sq.Lt{"id": lastID},
+					},
+				},
+			)
+		}
 
-	// Use NullInt64 to handle NULL values from database
-	var totalSent, totalDelivered, totalFailed, totalOpened sql.NullInt64
-	var totalClicked, totalBounced, totalComplained, totalUnsubscribed sql.NullInt64
+		query, args, err := queryBuilder.
+			OrderBy("created_at DESC", "id DESC").
+			Limit(exportMessagesPageSize).
+			ToSql()
+		if err != nil {
+			// codecov:ignore:start
+			tracing.MarkSpanError(ctx, err)
+			// codecov:ignore:end
+			return fmt.Errorf("failed to build query: %w", err)
+		}
 
-	err = row.Scan(
-		&totalSent,
-		&totalDelivered,
-		&totalFailed,
-		&totalOpened,
-		&totalClicked,
-		&totalBounced,
-		&totalComplained,
-		&totalUnsubscribed,
-	)
+		count, err := r.exportMessagesPage(ctx, workspaceDB, query, args, csvWriter, &lastCreatedAt, &lastID)
+		if err != nil {
+			// codecov:ignore:start
+			tracing.MarkSpanError(ctx, err)
+			// codecov:ignore:end
+			return err
+		}
 
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return stats, nil // Return empty stats (all zeros)
+		csvWriter.Flush()
+		if err := csvWriter.Error(); err != nil {
+			return fmt.Errorf("failed to write export rows: %w", err)
 		}
-		// codecov:ignore:start
-		tracing.MarkSpanError(ctx, err)
-		// codecov:ignore:end
-		return nil, fmt.Errorf("failed to get broadcast stats: %w", err)
-	}
 
-	// Convert nullable values to integers (use 0 for NULL values)
-	if totalSent.Valid {
-		stats.TotalSent = int(totalSent.Int64)
+		if count < exportMessagesPageSize {
+			return nil
+		}
 	}
-	if totalDelivered.Valid {
-		stats.TotalDelivered = int(totalDelivered.Int64)
+}
+
+// exportMessagesPage writes the rows of one export page and moves the keyset cursor to its last row
+func (r *MessageHistoryRepository) exportMessagesPage(ctx context.Context, db *sql.DB, query string, args []interface{}, csvWriter *csv.Writer, lastCreatedAt *time.Time, lastID *string) (int, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query message history: %w", err)
 	}
-	if totalFailed.Valid {
-		stats.TotalFailed = int(totalFailed.Int64)
+	defer func() { _ = rows.Close() }()
+
+	count := 0
+	for rows.Next() {
+		message := &domain.MessageHistory{}
+		var externalID, broadcastID, automationID, statusInfo sql.NullString
+		var deliveredAt, failedAt, openedAt, clickedAt, bouncedAt, complainedAt, unsubscribedAt sql.NullTime
+
+		if err := rows.Scan(
+			&message.ID, &externalID, &message.ContactEmail, &broadcastID, &automationID, &message.ListID, &message.TemplateID, &message.TemplateVersion,
+			&message.Channel, &statusInfo, &message.SentAt, &deliveredAt, &failedAt, &openedAt, &clickedAt, &bouncedAt,
+			&complainedAt, &unsubscribedAt, &message.CreatedAt, &message.UpdatedAt,
+		); err != nil {
+			return count, fmt.Errorf("failed to scan message history row: %w", err)
+		}
+
+		// Convert nullable fields
+		if externalID.Valid {
+			message.ExternalID = &externalID.String
+		}
+		if broadcastID.Valid {
+			message.BroadcastID = &broadcastID.String
+		}
+		if automationID.Valid {
+			message.AutomationID = &automationID.String
+		}
+		if statusInfo.Valid {
+			message.StatusInfo = &statusInfo.String
+		}
+		if deliveredAt.Valid {
+			message.DeliveredAt = &deliveredAt.Time
+		}
+		if failedAt.Valid {
+			message.FailedAt = &failedAt.Time
+		}
+		if openedAt.Valid {
+			message.OpenedAt = &openedAt.Time
+		}
+		if clickedAt.Valid {
+			message.ClickedAt = &clickedAt.Time
+		}
+		if bouncedAt.Valid {
+			message.BouncedAt = &bouncedAt.Time
+		}
+		if complainedAt.Valid {
+			message.ComplainedAt = &complainedAt.Time
+		}
+		if unsubscribedAt.Valid {
+			message.UnsubscribedAt = &unsubscribedAt.Time
+		}
+
+		if err := csvWriter.Write(message.ExportRow()); err != nil {
+			return count, fmt.Errorf("failed to write export row: %w", err)
+		}
+
+		*lastCreatedAt = message.CreatedAt
+		*lastID = message.ID
+		count++
 	}
-	if totalOpened.Valid {
-		stats.TotalOpened = int(totalOpened.Int64)
+
+	if err := rows.Err(); err != nil {
+		return count, fmt.Errorf("error iterating message history rows: %w", err)
 	}
-	if totalClicked.Valid {
-		stats.TotalClicked = int(totalClicked.Int64)
+
+	return count, nil
+}
+
+// applyMessageListFilters adds the filters of the list params to a message history query,
+// shared by ListMessages and ExportMessages so both select the same messages
+func applyMessageListFilters(queryBuilder sq.SelectBuilder, params domain.MessageListParams) sq.SelectBuilder {
+	if params.ID != "" {
+		queryBuilder = queryBuilder.Where(sq.Eq{"id": params.ID})
 	}
-	if totalBounced.Valid {
-		stats.TotalBounced = int(totalBounced.Int64)
+
+	if params.ExternalID != "" {
+		queryBuilder = queryBuilder.Where(sq.Eq{"external_id": params.ExternalID})
 	}
-	if totalComplained.Valid {
-		stats.TotalComplained = int(totalComplained.Int64)
+
+	if params.ListID != "" {
+		// Check if the list_id matches the specified list ID
+		queryBuilder = queryBuilder.Where(sq.Eq{"list_id": params.ListID})
 	}
-	if totalUnsubscribed.Valid {
-		stats.TotalUnsubscribed = int(totalUnsubscribed.Int64)
+
+	if params.Channel != "" {
+		queryBuilder = queryBuilder.Where(sq.Eq{"channel": params.Channel})
+	}
+
+	if params.ContactEmail != "" {
+		queryBuilder = queryBuilder.Where(sq.Eq{"contact_email": params.ContactEmail})
+	}
+
+	if params.BroadcastID != "" {
+		queryBuilder = queryBuilder.Where(sq.Eq{"broadcast_id": params.BroadcastID})
+	}
+
+	if params.TemplateID != "" {
+		queryBuilder = queryBuilder.Where(sq.Eq{"template_id": params.TemplateID})
+	}
+
+	if params.IsSent != nil {
+		if *params.IsSent {
+			queryBuilder = queryBuilder.Where(sq.NotEq{"sent_at": nil})
+		} else {
+			queryBuilder = queryBuilder.Where(sq.Eq{"sent_at": nil})
+		}
+	}
+
+	if params.IsDelivered != nil {
+		if *params.IsDelivered {
+			queryBuilder = queryBuilder.Where(sq.NotEq{"delivered_at": nil})
+		} else {
+			queryBuilder = queryBuilder.Where(sq.Eq{"delivered_at": nil})
+		}
+	}
+
+	if params.IsFailed != nil {
+		if *params.IsFailed {
+			queryBuilder = queryBuilder.Where(sq.NotEq{"failed_at": nil})
+		} else {
+			queryBuilder = queryBuilder.Where(sq.Eq{"failed_at": nil})
+		}
+	}
+
+	if params.IsOpened != nil {
+		if *params.IsOpened {
+			queryBuilder = queryBuilder.Where(sq.NotEq{"opened_at": nil})
+		} else {
+			queryBuilder = queryBuilder.Where(sq.Eq{"opened_at": nil})
+		}
+	}
+
+	if params.IsClicked != nil {
+		if *params.IsClicked {
+			queryBuilder = queryBuilder.Where(sq.NotEq{"clicked_at": nil})
+		} else {
+			queryBuilder = queryBuilder.Where(sq.Eq{"clicked_at": nil})
+		}
+	}
+
+	if params.IsBounced != nil {
+		if *params.IsBounced {
+			queryBuilder = queryBuilder.Where(sq.NotEq{"bounced_at": nil})
+		} else {
+			queryBuilder = queryBuilder.Where(sq.Eq{"bounced_at": nil})
+		}
+	}
+
+	if params.IsComplained != nil {
+		if *params.IsComplained {
+			queryBuilder = queryBuilder.Where(sq.NotEq{"complained_at": nil})
+		} else {
+			queryBuilder = queryBuilder.Where(sq.Eq{"complained_at": nil})
+		}
+	}
+
+	if params.IsUnsubscribed != nil {
+		if *params.IsUnsubscribed {
+			queryBuilder = queryBuilder.Where(sq.NotEq{"unsubscribed_at": nil})
+		} else {
+			queryBuilder = queryBuilder.Where(sq.Eq{"unsubscribed_at": nil})
+		}
+	}
+
+	// Time range filters
+	if params.SentAfter != nil {
+		queryBuilder = queryBuilder.Where(sq.GtOrEq{"sent_at": params.SentAfter})
+	}
+
+	if params.SentBefore != nil {
+		queryBuilder = queryBuilder.Where(sq.LtOrEq{"sent_at": params.SentBefore})
+	}
+
+	if params.UpdatedAfter != nil {
+		queryBuilder = queryBuilder.Where(sq.GtOrEq{"updated_at": params.UpdatedAfter})
+	}
+
+	if params.UpdatedBefore != nil {
+		queryBuilder = queryBuilder.Where(sq.LtOrEq{"updated_at": params.UpdatedBefore})
+	}
+
+	return queryBuilder
+}
+
+func (r *MessageHistoryRepository) GetBroadcastStats(ctx context.Context, workspaceID string, id string) (*domain.MessageHistoryStatusSum, error) {
+	// codecov:ignore:start
+	ctx, span := tracing.StartServiceSpan(ctx, "MessageHistoryRepository", "GetBroadcastStats")
+	defer tracing.EndSpan(span, nil)
+	tracing.AddAttribute(ctx, "workspaceID", workspaceID)
+	tracing.AddAttribute(ctx, "broadcastID", id)
+	// codecov:ignore:end
+
+	// Get the workspace database connection
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		// codecov:ignore:start
+		tracing.MarkSpanError(ctx, err)
+		// codecov:ignore:end
+		return nil, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	// MessageEventSent         MessageEvent = "sent"
+	// MessageEventDelivered    MessageEvent = "delivered"
+	// MessageEventFailed       MessageEvent = "failed"
+	// MessageEventOpened       MessageEvent = "opened"
+	// MessageEventClicked      MessageEvent = "clicked"
+	// MessageEventBounced      MessageEvent = "bounced"
+	// MessageEventComplained   MessageEvent = "complained"
+	// MessageEventUnsubscribed MessageEvent = "unsubscribed"
+
+	query := `
+		SELECT 
+			SUM(CASE WHEN sent_at IS NOT NULL THEN 1 ELSE 0 END) as total_sent,
+			SUM(CASE WHEN delivered_at IS NOT NULL THEN 1 ELSE 0 END) as total_delivered,
+			SUM(CASE WHEN failed_at IS NOT NULL THEN 1 ELSE 0 END) as total_failed,
+			SUM(CASE WHEN opened_at IS NOT NULL THEN 1 ELSE 0 END) as total_opened,
+			SUM(CASE WHEN clicked_at IS NOT NULL THEN 1 ELSE 0 END) as total_clicked,
+			SUM(CASE WHEN bounced_at IS NOT NULL THEN 1 ELSE 0 END) as total_bounced,
+			SUM(CASE WHEN complained_at IS NOT NULL THEN 1 ELSE 0 END) as total_complained,
+			SUM(CASE WHEN unsubscribed_at IS NOT NULL THEN 1 ELSE 0 END) as total_unsubscribed
+		FROM message_history
+		WHERE broadcast_id = $1
+	`
+
+	row := workspaceDB.QueryRowContext(ctx, query, id)
+	stats := &domain.MessageHistoryStatusSum{}
+
+	// Use NullInt64 to handle NULL values from database
+	var totalSent, totalDelivered, totalFailed, totalOpened sql.NullInt64
+	var totalClicked, totalBounced, totalComplained, totalUnsubscribed sql.NullInt64
+
+	err = row.Scan(
+		&totalSent,
+		&totalDelivered,
+		&totalFailed,
+		&totalOpened,
+		&totalClicked,
+		&totalBounced,
+		&totalComplained,
+		&totalUnsubscribed,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return stats, nil // Return empty stats (all zeros)
+		}
+		// codecov:ignore:start
+		tracing.MarkSpanError(ctx, err)
+		// codecov:ignore:end
+		return nil, fmt.Errorf("failed to get broadcast stats: %w", err)
+	}
+
+	// Convert nullable values to integers (use 0 for NULL values)
+	if totalSent.Valid {
+		stats.TotalSent = int(totalSent.Int64)
+	}
+	if totalDelivered.Valid {
+		stats.TotalDelivered = int(totalDelivered.Int64)
+	}
+	if totalFailed.Valid {
+		stats.TotalFailed = int(totalFailed.Int64)
+	}
+	if totalOpened.Valid {
+		stats.TotalOpened = int(totalOpened.Int64)
+	}
+	if totalClicked.Valid {
+		stats.TotalClicked = int(totalClicked.Int64)
+	}
+	if totalBounced.Valid {
+		stats.TotalBounced = int(totalBounced.Int64)
+	}
+	if totalComplained.Valid {
+		stats.TotalComplained = int(totalComplained.Int64)
+	}
+	if totalUnsubscribed.Valid {
+		stats.TotalUnsubscribed = int(totalUnsubscribed.Int64)
 	}
 
 	return stats, nil
 }
 
+// GetBroadcastStatsByDay retrieves statistics for a broadcast per day. Each status is counted on the
+// day of its own timestamp (opened_at, clicked_at, ...) in the given timezone, and every day between
+// the first and the last activity is returned, with zero counts for the days without activity.
+func (r *MessageHistoryRepository) GetBroadcastStatsByDay(ctx context.Context, workspaceID, broadcastID, tz string) ([]*domain.MessageHistoryDailyStatusSum, error) {
+	// codecov:ignore:start
+	ctx, span := tracing.StartServiceSpan(ctx, "MessageHistoryRepository", "GetBroadcastStatsByDay")
+	defer tracing.EndSpan(span, nil)
+	tracing.AddAttribute(ctx, "workspaceID", workspaceID)
+	tracing.AddAttribute(ctx, "broadcastID", broadcastID)
+	tracing.AddAttribute(ctx, "timezone", tz)
+	// codecov:ignore:end
+
+	if !domain.IsValidTimezone(tz) {
+		return nil, fmt.Errorf("invalid timezone: %s", tz)
+	}
+
+	// Get the workspace database connection
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		// codecov:ignore:start
+		tracing.MarkSpanError(ctx, err)
+		// codecov:ignore:end
+		return nil, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	// Timestamps are converted to local time in the timezone before truncating,
+	// so that the day boundaries fall on the local midnight
+	query := `
+		WITH events AS (
+			SELECT date_trunc('day', e.at AT TIME ZONE $2) AS day, e.status
+			FROM message_history m
+			CROSS JOIN LATERAL (VALUES
+				('sent', m.sent_at),
+				('delivered', m.delivered_at),
+				('failed', m.failed_at),
+				('opened', m.opened_at),
+				('clicked', m.clicked_at),
+				('bounced', m.bounced_at),
+				('complained', m.complained_at),
+				('unsubscribed', m.unsubscribed_at)
+			) AS e(status, at)
+			WHERE m.broadcast_id = $1 AND e.at IS NOT NULL
+		),
+		days AS (
+			SELECT generate_series(bounds.first_day, bounds.last_day, interval '1 day') AS day
+			FROM (SELECT MIN(day) AS first_day, MAX(day) AS last_day FROM events) bounds
+		)
+		SELECT 
+			to_char(d.day, 'YYYY-MM-DD') AS date,
+			COUNT(e.status) FILTER (WHERE e.status = 'sent') AS total_sent,
+			COUNT(e.status) FILTER (WHERE e.status = 'delivered') AS total_delivered,
+			COUNT(e.status) FILTER (WHERE e.status = 'failed') AS total_failed,
+			COUNT(e.status) FILTER (WHERE e.status = 'opened') AS total_opened,
+			COUNT(e.status) FILTER (WHERE e.status = 'clicked') AS total_clicked,
+			COUNT(e.status) FILTER (WHERE e.status = 'bounced') AS total_bounced,
+			COUNT(e.status) FILTER (WHERE e.status = 'complained') AS total_complained,
+			COUNT(e.status) FILTER (WHERE e.status = 'unsubscribed') AS total_unsubscribed
+		FROM days d
+		LEFT JOIN events e ON e.day = d.day
+		GROUP BY d.day
+		ORDER BY d.day
+	`
+
+	rows, err := workspaceDB.QueryContext(ctx, query, broadcastID, tz)
+	if err != nil {
+		// codecov:ignore:start
+		tracing.MarkSpanError(ctx, err)
+		// codecov:ignore:end
+		return nil, fmt.Errorf("failed to get broadcast stats by day: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	days := []*domain.MessageHistoryDailyStatusSum{}
+	for rows.Next() {
+		day := &domain.MessageHistoryDailyStatusSum{}
+		if err := rows.Scan(
+			&day.Date,
+			&day.TotalSent,
+			&day.TotalDelivered,
+			&day.TotalFailed,
+			&day.TotalOpened,
+			&day.TotalClicked,
+			&day.TotalBounced,
+			&day.TotalComplained,
+			&day.TotalUnsubscribed,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan broadcast stats by day: %w", err)
+		}
+		days = append(days, day)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate broadcast stats by day: %w", err)
+	}
+
+	return days, nil
+}
+
 // GetBroadcastVariationStats retrieves statistics for a specific variation of a broadcast
 func (r *MessageHistoryRepository) GetBroadcastVariationStats(ctx context.Context, workspaceID string, broadcastID, templateID string) (*domain.MessageHistoryStatusSum, error) {
 	// codecov:ignore:start
@@ -1216,6 +1823,455 @@ func (r *MessageHistoryRepository) GetBroadcastVariationStats(ctx context.Contex
 	return stats, nil
 }
 
+// GetBroadcastVariationStatsAll retrieves the statistics of the given variations of a broadcast with a
+// single query grouped by template, variations without messages get zeroed statistics
+func (r *MessageHistoryRepository) GetBroadcastVariationStatsAll(ctx context.Context, workspaceID, broadcastID string, templateIDs []string) (map[string]*domain.MessageHistoryStatusSum, error) {
+	// codecov:ignore:start
+	ctx, span := tracing.StartServiceSpan(ctx, "MessageHistoryRepository", "GetBroadcastVariationStatsAll")
+	defer tracing.EndSpan(span, nil)
+	tracing.AddAttribute(ctx, "workspaceID", workspaceID)
+	tracing.AddAttribute(ctx, "broadcastID", broadcastID)
+	tracing.AddAttribute(ctx, "templateCount", len(templateIDs))
+	// codecov:ignore:end
+
+	statsByTemplate := make(map[string]*domain.MessageHistoryStatusSum, len(templateIDs))
+	for _, templateID := range templateIDs {
+		statsByTemplate[templateID] = &domain.MessageHistoryStatusSum{}
+	}
+	if len(templateIDs) == 0 {
+		return statsByTemplate, nil
+	}
+
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		// codecov:ignore:start
+		tracing.MarkSpanError(ctx, err)
+		// codecov:ignore:end
+		return nil, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	query, args, err := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
+		Select(
+			"template_id",
+			"COUNT(sent_at) AS total_sent",
+			"COUNT(delivered_at) AS total_delivered",
+			"COUNT(failed_at) AS total_failed",
+			"COUNT(opened_at) AS total_opened",
+			"COUNT(clicked_at) AS total_clicked",
+			"COUNT(bounced_at) AS total_bounced",
+			"COUNT(complained_at) AS total_complained",
+			"COUNT(unsubscribed_at) AS total_unsubscribed",
+		).
+		From("message_history").
+		Where(sq.Eq{"broadcast_id": broadcastID}).
+		Where(sq.Eq{"template_id": templateIDs}).
+		GroupBy("template_id").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := workspaceDB.QueryContext(ctx, query, args...)
+	if err != nil {
+		// codecov:ignore:start
+		tracing.MarkSpanError(ctx, err)
+		// codecov:ignore:end
+		return nil, fmt.Errorf("failed to get broadcast variation stats: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var templateID string
+		stats := &domain.MessageHistoryStatusSum{}
+		if err := rows.Scan(
+			&templateID,
+			&stats.TotalSent,
+			&stats.TotalDelivered,
+			&stats.TotalFailed,
+			&stats.TotalOpened,
+			&stats.TotalClicked,
+			&stats.TotalBounced,
+			&stats.TotalComplained,
+			&stats.TotalUnsubscribed,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan broadcast variation stats: %w", err)
+		}
+		statsByTemplate[templateID] = stats
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate broadcast variation stats: %w", err)
+	}
+
+	return statsByTemplate, nil
+}
+
+// GetSentEmailsForBroadcast returns the emails among the given ones that already have a message for the broadcast
+func (r *MessageHistoryRepository) GetSentEmailsForBroadcast(ctx context.Context, workspaceID, broadcastID string, emails []string) ([]string, error) {
+	// codecov:ignore:start
+	ctx, span := tracing.StartServiceSpan(ctx, "MessageHistoryRepository", "GetSentEmailsForBroadcast")
+	defer tracing.EndSpan(span, nil)
+	tracing.AddAttribute(ctx, "workspaceID", workspaceID)
+	tracing.AddAttribute(ctx, "broadcastID", broadcastID)
+	tracing.AddAttribute(ctx, "emailCount", len(emails))
+	// codecov:ignore:end
+
+	if len(emails) == 0 {
+		return []string{}, nil
+	}
+
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		// codecov:ignore:start
+		tracing.MarkSpanError(ctx, err)
+		// codecov:ignore:end
+		return nil, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	query, args, err := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
+		Select("DISTINCT contact_email").
+		From("message_history").
+		Where(sq.Eq{"broadcast_id": broadcastID}).
+		Where(sq.Eq{"contact_email": emails}).
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := workspaceDB.QueryContext(ctx, query, args...)
+	if err != nil {
+		// codecov:ignore:start
+		tracing.MarkSpanError(ctx, err)
+		// codecov:ignore:end
+		return nil, fmt.Errorf("failed to query sent emails for broadcast: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	sentEmails := []string{}
+	for rows.Next() {
+		var email string
+		if err := rows.Scan(&email); err != nil {
+			return nil, fmt.Errorf("failed to scan sent email: %w", err)
+		}
+		sentEmails = append(sentEmails, email)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating sent emails: %w", err)
+	}
+
+	return sentEmails, nil
+}
+
+// GetEngagementScores computes the engagement score of the given contacts with a single query grouped by email,
+// only the opens and clicks of the trailing window count and each one is decayed by its age
+func (r *MessageHistoryRepository) GetEngagementScores(ctx context.Context, workspaceID string, emails []string) (map[string]float64, error) {
+	// codecov:ignore:start
+	ctx, span := tracing.StartServiceSpan(ctx, "MessageHistoryRepository", "GetEngagementScores")
+	defer tracing.EndSpan(span, nil)
+	tracing.AddAttribute(ctx, "workspaceID", workspaceID)
+	tracing.AddAttribute(ctx, "emailCount", len(emails))
+	// codecov:ignore:end
+
+	scores := make(map[string]float64, len(emails))
+	for _, email := range emails {
+		scores[email] = 0
+	}
+	if len(emails) == 0 {
+		return scores, nil
+	}
+
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		// codecov:ignore:start
+		tracing.MarkSpanError(ctx, err)
+		// codecov:ignore:end
+		return nil, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	windowStart := fmt.Sprintf("NOW() - INTERVAL '%d days'", domain.EngagementScoreWindowDays)
+	query, args, err := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
+		Select(
+			"contact_email",
+			fmt.Sprintf("SUM(%s + %s) AS engagement_score",
+				engagementScoreTerm("opened_at", domain.EngagementScoreOpenWeight),
+				engagementScoreTerm("clicked_at", domain.EngagementScoreClickWeight),
+			),
+		).
+		From("message_history").
+		Where(sq.Eq{"contact_email": emails}).
+		Where(fmt.Sprintf("(opened_at > %s OR clicked_at > %s)", windowStart, windowStart)).
+		GroupBy("contact_email").
+		ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build query: %w", err)
+	}
+
+	rows, err := workspaceDB.QueryContext(ctx, query, args...)
+	if err != nil {
+		// codecov:ignore:start
+		tracing.MarkSpanError(ctx, err)
+		// codecov:ignore:end
+		return nil, fmt.Errorf("failed to get engagement scores: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	for rows.Next() {
+		var email string
+		var score float64
+		if err := rows.Scan(&email, &score); err != nil {
+			return nil, fmt.Errorf("failed to scan engagement score: %w", err)
+		}
+		scores[email] = score
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate engagement scores: %w", err)
+	}
+
+	return scores, nil
+}
+
+// engagementScoreTerm returns the SQL contribution of an open or click timestamp column to the
+// engagement score: the weight halved every half-life, or zero outside the window
+func engagementScoreTerm(column string, weight int) string {
+	return fmt.Sprintf(
+		"CASE WHEN %[1]s > NOW() - INTERVAL '%[2]d days' THEN %[3]d * POWER(0.5, EXTRACT(EPOCH FROM NOW() - %[1]s) / %[4]d) ELSE 0 END",
+		column,
+		domain.EngagementScoreWindowDays,
+		weight,
+		domain.EngagementScoreHalfLifeDays*24*60*60,
+	)
+}
+
+// CountSentAndComplaintsSince counts the messages of the workspace sent since the given time and the
+// complaints received since then, whatever the message they are about
+func (r *MessageHistoryRepository) CountSentAndComplaintsSince(ctx context.Context, workspaceID string, since time.Time) (int, int, error) {
+	// codecov:ignore:start
+	ctx, span := tracing.StartServiceSpan(ctx, "MessageHistoryRepository", "CountSentAndComplaintsSince")
+	defer tracing.EndSpan(span, nil)
+	tracing.AddAttribute(ctx, "workspaceID", workspaceID)
+	// codecov:ignore:end
+
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		// codecov:ignore:start
+		tracing.MarkSpanError(ctx, err)
+		// codecov:ignore:end
+		return 0, 0, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	query := `
+		SELECT
+			COUNT(*) FILTER (WHERE sent_at >= $1) AS sent,
+			COUNT(*) FILTER (WHERE complained_at >= $1) AS complaints
+		FROM message_history
+		WHERE sent_at >= $1 OR complained_at >= $1
+	`
+
+	var sent, complaints int
+	if err := workspaceDB.QueryRowContext(ctx, query, since.UTC()).Scan(&sent, &complaints); err != nil {
+		// codecov:ignore:start
+		tracing.MarkSpanError(ctx, err)
+		// codecov:ignore:end
+		return 0, 0, fmt.Errorf("failed to count sent messages and complaints: %w", err)
+	}
+
+	return sent, complaints, nil
+}
+
+// GetContactTimeline merges the message events and list subscription changes of a contact
+// into a single timeline, most recent first. Each source is fetched with the cursor applied
+// and limit+1 rows, which is enough to fill a page once both are merged.
+// The secretKey is accepted like the other read methods but timeline payloads carry no message data.
+func (r *MessageHistoryRepository) GetContactTimeline(ctx context.Context, workspaceID string, secretKey string, email string, limit int, cursor string) ([]*domain.TimelineEntry, string, error) {
+	// codecov:ignore:start
+	ctx, span := tracing.StartServiceSpan(ctx, "MessageHistoryRepository", "GetContactTimeline")
+	defer tracing.EndSpan(span, nil)
+	tracing.AddAttribute(ctx, "workspaceID", workspaceID)
+	tracing.AddAttribute(ctx, "limit", limit)
+	// codecov:ignore:end
+
+	if limit <= 0 {
+		limit = 20
+	}
+	if limit > 100 {
+		limit = 100
+	}
+
+	var cursorTime *time.Time
+	var cursorID string
+	if cursor != "" {
+		decodedCursor, err := base64.StdEncoding.DecodeString(cursor)
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid cursor encoding: %w", err)
+		}
+
+		cursorParts := strings.SplitN(string(decodedCursor), "~", 2)
+		if len(cursorParts) != 2 {
+			return nil, "", fmt.Errorf("invalid cursor format: expected timestamp~id")
+		}
+
+		parsedTime, err := time.Parse(time.RFC3339Nano, cursorParts[0])
+		if err != nil {
+			return nil, "", fmt.Errorf("invalid cursor timestamp format: %w", err)
+		}
+		cursorTime = &parsedTime
+		cursorID = cursorParts[1]
+	}
+
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		// codecov:ignore:start
+		tracing.MarkSpanError(ctx, err)
+		// codecov:ignore:end
+		return nil, "", fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	// One row per message event, the entry id combines the message id and the event
+	messageEvents := `SELECT 'message:' || mh.id || ':' || e.event AS entry_id, 'message.' || e.event AS kind, e.occurred_at,
+			mh.id AS message_id, mh.template_id, mh.channel, mh.broadcast_id, mh.list_id
+		FROM message_history mh
+		CROSS JOIN LATERAL (VALUES
+			('sent', mh.sent_at), ('delivered', mh.delivered_at), ('failed', mh.failed_at),
+			('opened', mh.opened_at), ('clicked', mh.clicked_at), ('bounced', mh.bounced_at),
+			('complained', mh.complained_at), ('unsubscribed', mh.unsubscribed_at)
+		) AS e(event, occurred_at)
+		WHERE mh.contact_email = $1 AND e.occurred_at IS NOT NULL`
+
+	// One row per list membership, at its last change
+	listEvents := `SELECT 'list:' || list_id AS entry_id,
+			CASE WHEN deleted_at IS NOT NULL THEN 'list.removed' ELSE 'list.' || status END AS kind,
+			COALESCE(deleted_at, updated_at) AS occurred_at, list_id, status
+		FROM contact_lists
+		WHERE email = $1`
+
+	messageEntries, err := r.queryTimelineEntries(ctx, workspaceDB, messageEvents, email, cursorTime, cursorID, limit+1, scanMessageTimelineEntry)
+	if err != nil {
+		// codecov:ignore:start
+		tracing.MarkSpanError(ctx, err)
+		// codecov:ignore:end
+		return nil, "", fmt.Errorf("failed to query message events: %w", err)
+	}
+
+	listEntries, err := r.queryTimelineEntries(ctx, workspaceDB, listEvents, email, cursorTime, cursorID, limit+1, scanListTimelineEntry)
+	if err != nil {
+		// codecov:ignore:start
+		tracing.MarkSpanError(ctx, err)
+		// codecov:ignore:end
+		return nil, "", fmt.Errorf("failed to query list events: %w", err)
+	}
+
+	entries := mergeTimelineEntries(messageEntries, listEntries)
+
+	var nextCursor string
+	if len(entries) > limit {
+		entries = entries[:limit]
+		last := entries[len(entries)-1]
+		cursorStr := fmt.Sprintf("%s~%s", last.Timestamp.UTC().Format(time.RFC3339Nano), last.ID)
+		nextCursor = base64.StdEncoding.EncodeToString([]byte(cursorStr))
+	}
+
+	return entries, nextCursor, nil
+}
+
+// queryTimelineEntries wraps a timeline source query with the cursor condition, ordering and limit
+func (r *MessageHistoryRepository) queryTimelineEntries(ctx context.Context, db *sql.DB, source string, email string, cursorTime *time.Time, cursorID string, limit int, scan func(*sql.Rows) (*domain.TimelineEntry, error)) ([]*domain.TimelineEntry, error) {
+	query := "SELECT * FROM (" + source + ") AS timeline"
+	args := []interface{}{email}
+
+	if cursorTime != nil {
+		query += " WHERE occurred_at < $2 OR (occurred_at = $2 AND entry_id < $3)"
+		args = append(args, *cursorTime, cursorID)
+	}
+	query += fmt.Sprintf(" ORDER BY occurred_at DESC, entry_id DESC LIMIT %d", limit)
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	entries := []*domain.TimelineEntry{}
+	for rows.Next() {
+		entry, err := scan(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan timeline row: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating timeline rows: %w", err)
+	}
+
+	return entries, nil
+}
+
+func scanMessageTimelineEntry(rows *sql.Rows) (*domain.TimelineEntry, error) {
+	entry := &domain.TimelineEntry{}
+	var messageID, templateID, channel string
+	var broadcastID, listID sql.NullString
+
+	if err := rows.Scan(&entry.ID, &entry.Kind, &entry.Timestamp, &messageID, &templateID, &channel, &broadcastID, &listID); err != nil {
+		return nil, err
+	}
+
+	entry.Payload = map[string]interface{}{
+		"message_id":  messageID,
+		"template_id": templateID,
+		"channel":     channel,
+	}
+	if broadcastID.Valid {
+		entry.Payload["broadcast_id"] = broadcastID.String
+	}
+	if listID.Valid {
+		entry.Payload["list_id"] = listID.String
+	}
+
+	return entry, nil
+}
+
+func scanListTimelineEntry(rows *sql.Rows) (*domain.TimelineEntry, error) {
+	entry := &domain.TimelineEntry{}
+	var listID, status string
+
+	if err := rows.Scan(&entry.ID, &entry.Kind, &entry.Timestamp, &listID, &status); err != nil {
+		return nil, err
+	}
+
+	entry.Payload = map[string]interface{}{
+		"list_id": listID,
+		"status":  status,
+	}
+
+	return entry, nil
+}
+
+// mergeTimelineEntries merges entries already sorted by timestamp and id descending,
+// ties on the timestamp are broken by id so the order matches the cursor condition
+func mergeTimelineEntries(a, b []*domain.TimelineEntry) []*domain.TimelineEntry {
+	merged := make([]*domain.TimelineEntry, 0, len(a)+len(b))
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		if timelineEntryBefore(a[i], b[j]) {
+			merged = append(merged, a[i])
+			i++
+		} else {
+			merged = append(merged, b[j])
+			j++
+		}
+	}
+	merged = append(merged, a[i:]...)
+	merged = append(merged, b[j:]...)
+	return merged
+}
+
+// timelineEntryBefore reports whether a comes first in a most recent first timeline
+func timelineEntryBefore(a, b *domain.TimelineEntry) bool {
+	if !a.Timestamp.Equal(b.Timestamp) {
+		return a.Timestamp.After(b.Timestamp)
+	}
+	return a.ID > b.ID
+}
+
 // DeleteForEmail redacts the email address in all message history records for a specific email
 func (r *MessageHistoryRepository) DeleteForEmail(ctx context.Context, workspaceID, email string) error {
 	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
@@ -1242,3 +2298,56 @@ func (r *MessageHistoryRepository) DeleteForEmail(ctx context.Context, workspace
 
 	return nil
 }
+
+// PruneOlderThan deletes at most limit messages created before cutoff, oldest first, so that each call
+// holds its locks briefly. Messages that reached any of keepStatuses are kept.
+func (r *MessageHistoryRepository) PruneOlderThan(ctx context.Context, workspaceID string, cutoff time.Time, keepStatuses []domain.MessageEvent, limit int) (int64, error) {
+	// codecov:ignore:start
+	ctx, span := tracing.StartServiceSpan(ctx, "MessageHistoryRepository", "PruneOlderThan")
+	defer tracing.EndSpan(span, nil)
+	tracing.AddAttribute(ctx, "workspaceID", workspaceID)
+	tracing.AddAttribute(ctx, "limit", limit)
+	// codecov:ignore:end
+
+	conditions := []string{"created_at < $1"}
+	for _, status := range keepStatuses {
+		field, err := messageEventField(status)
+		if err != nil {
+			return 0, fmt.Errorf("invalid keep status: %w", err)
+		}
+		conditions = append(conditions, field+" IS NULL")
+	}
+
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		// codecov:ignore:start
+		tracing.MarkSpanError(ctx, err)
+		// codecov:ignore:end
+		return 0, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	query := fmt.Sprintf(`
+		DELETE FROM message_history
+		WHERE id IN (
+			SELECT id FROM message_history
+			WHERE %s
+			ORDER BY created_at
+			LIMIT $2
+		)
+	`, strings.Join(conditions, " AND "))
+
+	result, err := workspaceDB.ExecContext(ctx, query, cutoff.UTC(), limit)
+	if err != nil {
+		// codecov:ignore:start
+		tracing.MarkSpanError(ctx, err)
+		// codecov:ignore:end
+		return 0, fmt.Errorf("failed to prune message history: %w", err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	return deleted, nil
+}
@@ -1,12 +1,17 @@
 package repository
 
 import (
+	"bytes"
 	"context"
 	"database/sql"
 	"database/sql/driver"
 	"encoding/base64"
+	"encoding/csv"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"math"
+	"regexp"
 	"strings"
 	"testing"
 	"time"
@@ -14,6 +19,7 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/Notifuse/notifuse/internal/domain"
 	"github.com/Notifuse/notifuse/internal/domain/mocks"
+	"github.com/Notifuse/notifuse/pkg/crypto"
 	"github.com/golang/mock/gomock"
 	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
@@ -49,7 +55,7 @@ func setupMessageHistoryTest(t *testing.T) (*mocks.MockWorkspaceRepository, doma
 	db, mock, err := sqlmock.New(sqlmock.ValueConverterOption(StringArrayConverter{}))
 	require.NoError(t, err)
 
-	repo := NewMessageHistoryRepository(mockWorkspaceRepo)
+	repo := NewMessageHistoryRepository(mockWorkspaceRepo, true)
 
 	// Set up cleanup function
 	cleanup := func() {
@@ -96,6 +102,47 @@ func createSampleMessageHistory() *domain.MessageHistory {
 	}
 }
 
+func TestMessageDataEncryption_KeyDerivation(t *testing.T) {
+	data := domain.MessageData{
+		Data:     map[string]interface{}{"subject": "Test Subject"},
+		Metadata: map[string]interface{}{"source": "api"},
+	}
+
+	t.Run("derived key round trip", func(t *testing.T) {
+		encrypted, err := encryptMessageData(data, testSecretKey, true)
+		require.NoError(t, err)
+		assert.Equal(t, messageDataDerivedKey, encrypted.Data["_key"])
+		assert.Equal(t, data.Metadata, encrypted.Metadata)
+
+		// The raw secret key cannot decrypt it
+		_, err = crypto.DecryptFromHexString(encrypted.Data["_encrypted"].(string), testSecretKey)
+		require.Error(t, err)
+
+		decrypted, err := decryptMessageData(encrypted, testSecretKey)
+		require.NoError(t, err)
+		assert.Equal(t, data, decrypted)
+	})
+
+	t.Run("legacy data encrypted with the raw key", func(t *testing.T) {
+		encrypted, err := encryptMessageData(data, testSecretKey, false)
+		require.NoError(t, err)
+		assert.NotContains(t, encrypted.Data, "_key")
+
+		decrypted, err := decryptMessageData(encrypted, testSecretKey)
+		require.NoError(t, err)
+		assert.Equal(t, data, decrypted)
+	})
+
+	t.Run("wrong secret key", func(t *testing.T) {
+		encrypted, err := encryptMessageData(data, testSecretKey, true)
+		require.NoError(t, err)
+
+		_, err = decryptMessageData(encrypted, "another-secret-key")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to decrypt data")
+	})
+}
+
 func TestMessageHistoryRepository_Create(t *testing.T) {
 	mockWorkspaceRepo, repo, mock, db, cleanup := setupMessageHistoryTest(t)
 	defer cleanup()
@@ -190,6 +237,94 @@ func TestMessageHistoryRepository_Create(t *testing.T) {
 	})
 }
 
+func TestMessageHistoryRepository_CreateWithIdempotencyKey(t *testing.T) {
+	mockWorkspaceRepo, repo, mock, db, cleanup := setupMessageHistoryTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	workspaceID := "workspace-123"
+	message := createSampleMessageHistory()
+	insertArgs := []driver.Value{
+		message.ID, message.ExternalID, message.ContactEmail, message.BroadcastID, message.AutomationID,
+		message.ListID, message.TemplateID, message.TemplateVersion, message.Channel, message.StatusInfo,
+		sqlmock.AnyArg(), sqlmock.AnyArg(), sqlmock.AnyArg(), // message_data, channel_options, attachments
+		message.SentAt, message.DeliveredAt, message.FailedAt, message.OpenedAt, message.ClickedAt,
+		message.BouncedAt, message.ComplainedAt, message.UnsubscribedAt, message.CreatedAt, message.UpdatedAt,
+		"order-12345",
+	}
+
+	t.Run("claims the key", func(t *testing.T) {
+		mockWorkspaceRepo.EXPECT().GetConnection(gomock.Any(), workspaceID).Return(db, nil)
+
+		mock.ExpectExec(`INSERT INTO message_history .+ ON CONFLICT \(idempotency_key\) WHERE idempotency_key IS NOT NULL DO NOTHING`).
+			WithArgs(insertArgs...).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		err := repo.CreateWithIdempotencyKey(ctx, workspaceID, testSecretKey, message, "order-12345")
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("key held by another message", func(t *testing.T) {
+		mockWorkspaceRepo.EXPECT().GetConnection(gomock.Any(), workspaceID).Return(db, nil)
+
+		mock.ExpectExec(`INSERT INTO message_history`).
+			WithArgs(insertArgs...).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		err := repo.CreateWithIdempotencyKey(ctx, workspaceID, testSecretKey, message, "order-12345")
+		assert.ErrorIs(t, err, domain.ErrIdempotencyKeyUsed)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("execution error", func(t *testing.T) {
+		mockWorkspaceRepo.EXPECT().GetConnection(gomock.Any(), workspaceID).Return(db, nil)
+
+		mock.ExpectExec(`INSERT INTO message_history`).
+			WithArgs(insertArgs...).
+			WillReturnError(errors.New("execution error"))
+
+		err := repo.CreateWithIdempotencyKey(ctx, workspaceID, testSecretKey, message, "order-12345")
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, domain.ErrIdempotencyKeyUsed)
+		assert.Contains(t, err.Error(), "failed to create message history")
+	})
+}
+
+func TestMessageHistoryRepository_IdempotencyKeyLookup(t *testing.T) {
+	mockWorkspaceRepo, repo, mock, db, cleanup := setupMessageHistoryTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	workspaceID := "workspace-123"
+
+	t.Run("not found", func(t *testing.T) {
+		mockWorkspaceRepo.EXPECT().GetConnection(gomock.Any(), workspaceID).Return(db, nil)
+
+		mock.ExpectQuery(`SELECT .+ FROM message_history WHERE idempotency_key = \$1`).
+			WithArgs("order-12345").
+			WillReturnError(sql.ErrNoRows)
+
+		message, err := repo.GetByIdempotencyKey(ctx, workspaceID, testSecretKey, "order-12345")
+		require.Error(t, err)
+		assert.Nil(t, message)
+		assert.Contains(t, err.Error(), "not found")
+	})
+
+	t.Run("release clears keys created before the cutoff", func(t *testing.T) {
+		cutoff := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+		mockWorkspaceRepo.EXPECT().GetConnection(gomock.Any(), workspaceID).Return(db, nil)
+
+		mock.ExpectExec(`UPDATE message_history SET idempotency_key = NULL WHERE idempotency_key = \$1 AND created_at < \$2`).
+			WithArgs("order-12345", cutoff).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		err := repo.ReleaseIdempotencyKey(ctx, workspaceID, "order-12345", cutoff)
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
 func TestMessageHistoryRepository_Update(t *testing.T) {
 	mockWorkspaceRepo, repo, mock, db, cleanup := setupMessageHistoryTest(t)
 	defer cleanup()
@@ -512,6 +647,93 @@ func TestMessageHistoryRepository_GetByExternalID(t *testing.T) {
 	})
 }
 
+func TestMessageHistoryRepository_GetByExternalIDs(t *testing.T) {
+	mockWorkspaceRepo, repo, mock, db, cleanup := setupMessageHistoryTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	workspaceID := "workspace-123"
+	columns := []string{
+		"id", "external_id", "contact_email", "broadcast_id", "automation_id", "list_id", "template_id", "template_version",
+		"channel", "status_info", "message_data", "channel_options", "attachments", "sent_at", "delivered_at",
+		"failed_at", "opened_at", "clicked_at", "bounced_at", "complained_at",
+		"unsubscribed_at", "created_at", "updated_at",
+	}
+	addMessageRow := func(rows *sqlmock.Rows, id, externalID string) *sqlmock.Rows {
+		message := createSampleMessageHistory()
+		messageDataJSON, _ := json.Marshal(message.MessageData)
+		return rows.AddRow(
+			id, externalID, message.ContactEmail, message.BroadcastID, message.AutomationID, nil,
+			message.TemplateID, message.TemplateVersion, message.Channel, message.StatusInfo,
+			messageDataJSON, nil, []byte("[]"), message.SentAt, message.DeliveredAt,
+			message.FailedAt, message.OpenedAt, message.ClickedAt, message.BouncedAt, message.ComplainedAt,
+			message.UnsubscribedAt, message.CreatedAt, message.UpdatedAt,
+		)
+	}
+
+	t.Run("partial match leaves unknown IDs out", func(t *testing.T) {
+		externalIDs := []string{"ext-1", "ext-missing", "ext-2"}
+		mockWorkspaceRepo.EXPECT().GetConnection(gomock.Any(), workspaceID).Return(db, nil)
+
+		rows := sqlmock.NewRows(columns)
+		addMessageRow(rows, "msg-1", "ext-1")
+		addMessageRow(rows, "msg-2", "ext-2")
+		mock.ExpectQuery(`SELECT .* FROM message_history WHERE external_id = ANY\(\$1\)`).
+			WithArgs(pq.Array(externalIDs)).
+			WillReturnRows(rows)
+
+		result, err := repo.GetByExternalIDs(ctx, workspaceID, testSecretKey, externalIDs)
+		require.NoError(t, err)
+		require.Len(t, result, 2)
+		assert.Equal(t, "msg-1", result["ext-1"].ID)
+		assert.Equal(t, "msg-2", result["ext-2"].ID)
+		assert.Equal(t, "Test Subject", result["ext-2"].MessageData.Data["subject"])
+		_, found := result["ext-missing"]
+		assert.False(t, found)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("no match returns an empty map", func(t *testing.T) {
+		mockWorkspaceRepo.EXPECT().GetConnection(gomock.Any(), workspaceID).Return(db, nil)
+		mock.ExpectQuery(`SELECT .* FROM message_history WHERE external_id = ANY\(\$1\)`).
+			WithArgs(pq.Array([]string{"ext-missing"})).
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		result, err := repo.GetByExternalIDs(ctx, workspaceID, testSecretKey, []string{"ext-missing"})
+		require.NoError(t, err)
+		assert.Empty(t, result)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("empty slice does not query", func(t *testing.T) {
+		result, err := repo.GetByExternalIDs(ctx, workspaceID, testSecretKey, nil)
+		require.NoError(t, err)
+		assert.NotNil(t, result)
+		assert.Empty(t, result)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		mockWorkspaceRepo.EXPECT().GetConnection(gomock.Any(), workspaceID).Return(db, nil)
+		mock.ExpectQuery(`SELECT .* FROM message_history WHERE external_id = ANY\(\$1\)`).
+			WillReturnError(errors.New("db error"))
+
+		result, err := repo.GetByExternalIDs(ctx, workspaceID, testSecretKey, []string{"ext-1"})
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "failed to get message histories by external_id")
+	})
+
+	t.Run("workspace connection error", func(t *testing.T) {
+		mockWorkspaceRepo.EXPECT().GetConnection(gomock.Any(), workspaceID).Return(nil, errors.New("connection error"))
+
+		result, err := repo.GetByExternalIDs(ctx, workspaceID, testSecretKey, []string{"ext-1"})
+		require.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "failed to get workspace connection")
+	})
+}
+
 func TestMessageHistoryRepository_GetByContact(t *testing.T) {
 	mockWorkspaceRepo, repo, mock, db, cleanup := setupMessageHistoryTest(t)
 	defer cleanup()
@@ -1152,6 +1374,125 @@ func TestMessageHistoryRepository_GetBroadcastStats(t *testing.T) {
 	})
 }
 
+func TestMessageHistoryRepository_GetBroadcastStatsByDay(t *testing.T) {
+	mockWorkspaceRepo, repo, mock, db, cleanup := setupMessageHistoryTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	workspaceID := "workspace-123"
+	broadcastID := "broadcast-123"
+	columns := []string{
+		"date", "total_sent", "total_delivered", "total_failed", "total_opened",
+		"total_clicked", "total_bounced", "total_complained", "total_unsubscribed",
+	}
+
+	t.Run("multi-day broadcast includes days without activity", func(t *testing.T) {
+		mockWorkspaceRepo.EXPECT().
+			GetConnection(gomock.Any(), workspaceID).
+			Return(db, nil)
+
+		rows := sqlmock.NewRows(columns).
+			AddRow("2026-03-01", 100, 95, 5, 40, 12, 2, 0, 1).
+			AddRow("2026-03-02", 0, 0, 0, 15, 4, 0, 1, 0).
+			AddRow("2026-03-03", 0, 0, 0, 0, 0, 0, 0, 0).
+			AddRow("2026-03-04", 0, 0, 0, 3, 1, 0, 0, 0)
+
+		mock.ExpectQuery(`date_trunc\('day', e.at AT TIME ZONE \$2\).* WHERE m.broadcast_id = \$1 .* generate_series\(bounds.first_day, bounds.last_day, interval '1 day'\)`).
+			WithArgs(broadcastID, "America/New_York").
+			WillReturnRows(rows)
+
+		days, err := repo.GetBroadcastStatsByDay(ctx, workspaceID, broadcastID, "America/New_York")
+		require.NoError(t, err)
+		require.Len(t, days, 4)
+
+		assert.Equal(t, "2026-03-01", days[0].Date)
+		assert.Equal(t, 100, days[0].TotalSent)
+		assert.Equal(t, 95, days[0].TotalDelivered)
+		assert.Equal(t, 5, days[0].TotalFailed)
+		assert.Equal(t, 40, days[0].TotalOpened)
+		assert.Equal(t, 12, days[0].TotalClicked)
+		assert.Equal(t, 2, days[0].TotalBounced)
+		assert.Equal(t, 1, days[0].TotalUnsubscribed)
+
+		assert.Equal(t, "2026-03-02", days[1].Date)
+		assert.Equal(t, 15, days[1].TotalOpened)
+		assert.Equal(t, 1, days[1].TotalComplained)
+
+		assert.Equal(t, "2026-03-03", days[2].Date)
+		assert.Equal(t, domain.MessageHistoryStatusSum{}, days[2].MessageHistoryStatusSum)
+
+		assert.Equal(t, "2026-03-04", days[3].Date)
+		assert.Equal(t, 3, days[3].TotalOpened)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("timestamps are shifted to the timezone before truncating to the day", func(t *testing.T) {
+		mockWorkspaceRepo.EXPECT().
+			GetConnection(gomock.Any(), workspaceID).
+			Return(db, nil)
+
+		// A message opened at 2026-03-01T23:30:00Z falls on 2026-03-02 in Tokyo
+		mock.ExpectQuery(`date_trunc\('day', e.at AT TIME ZONE \$2\)`).
+			WithArgs(broadcastID, "Asia/Tokyo").
+			WillReturnRows(sqlmock.NewRows(columns).AddRow("2026-03-02", 0, 0, 0, 1, 0, 0, 0, 0))
+
+		days, err := repo.GetBroadcastStatsByDay(ctx, workspaceID, broadcastID, "Asia/Tokyo")
+		require.NoError(t, err)
+		require.Len(t, days, 1)
+		assert.Equal(t, "2026-03-02", days[0].Date)
+		assert.Equal(t, 1, days[0].TotalOpened)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("no activity", func(t *testing.T) {
+		mockWorkspaceRepo.EXPECT().
+			GetConnection(gomock.Any(), workspaceID).
+			Return(db, nil)
+
+		mock.ExpectQuery(`FROM message_history m`).
+			WithArgs(broadcastID, "UTC").
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		days, err := repo.GetBroadcastStatsByDay(ctx, workspaceID, broadcastID, "UTC")
+		require.NoError(t, err)
+		assert.NotNil(t, days)
+		assert.Empty(t, days)
+	})
+
+	t.Run("invalid timezone", func(t *testing.T) {
+		days, err := repo.GetBroadcastStatsByDay(ctx, workspaceID, broadcastID, "Mars/Olympus")
+		require.Error(t, err)
+		assert.Nil(t, days)
+		assert.Contains(t, err.Error(), "invalid timezone")
+	})
+
+	t.Run("workspace connection error", func(t *testing.T) {
+		mockWorkspaceRepo.EXPECT().
+			GetConnection(gomock.Any(), workspaceID).
+			Return(nil, errors.New("connection error"))
+
+		days, err := repo.GetBroadcastStatsByDay(ctx, workspaceID, broadcastID, "UTC")
+		require.Error(t, err)
+		assert.Nil(t, days)
+		assert.Contains(t, err.Error(), "failed to get workspace connection")
+	})
+
+	t.Run("sql error", func(t *testing.T) {
+		mockWorkspaceRepo.EXPECT().
+			GetConnection(gomock.Any(), workspaceID).
+			Return(db, nil)
+
+		mock.ExpectQuery(`FROM message_history m`).
+			WithArgs(broadcastID, "UTC").
+			WillReturnError(errors.New("sql error"))
+
+		days, err := repo.GetBroadcastStatsByDay(ctx, workspaceID, broadcastID, "UTC")
+		require.Error(t, err)
+		assert.Nil(t, days)
+		assert.Contains(t, err.Error(), "failed to get broadcast stats by day")
+	})
+}
+
 func TestMessageHistoryRepository_GetBroadcastVariationStats(t *testing.T) {
 	mockWorkspaceRepo, repo, mock, db, cleanup := setupMessageHistoryTest(t)
 	defer cleanup()
@@ -1266,6 +1607,88 @@ func TestMessageHistoryRepository_GetBroadcastVariationStats(t *testing.T) {
 	})
 }
 
+func TestMessageHistoryRepository_GetBroadcastVariationStatsAll(t *testing.T) {
+	mockWorkspaceRepo, repo, mock, db, cleanup := setupMessageHistoryTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	workspaceID := "workspace-123"
+	broadcastID := "broadcast-123"
+	groupedColumns := []string{
+		"template_id", "total_sent", "total_delivered", "total_failed", "total_opened",
+		"total_clicked", "total_bounced", "total_complained", "total_unsubscribed",
+	}
+	groupedQuery := `SELECT template_id, COUNT\(sent_at\) AS total_sent, .* FROM message_history WHERE broadcast_id = \$1 AND template_id IN \(\$2,\$3,\$4\) GROUP BY template_id`
+
+	t.Run("matches the per-variation stats and zeroes variations without sends", func(t *testing.T) {
+		variations := map[string][]driver.Value{
+			"tplA": {10, 8, 2, 5, 3, 1, 0, 1},
+			"tplB": {20, 19, 1, 12, 6, 0, 1, 2},
+		}
+
+		// Per-variation stats
+		expected := map[string]*domain.MessageHistoryStatusSum{}
+		for _, templateID := range []string{"tplA", "tplB", "tplC"} {
+			mockWorkspaceRepo.EXPECT().GetConnection(gomock.Any(), workspaceID).Return(db, nil)
+			rows := sqlmock.NewRows(groupedColumns[1:])
+			if values, ok := variations[templateID]; ok {
+				rows.AddRow(values...)
+			} else {
+				rows.AddRow(nil, nil, nil, nil, nil, nil, nil, nil)
+			}
+			mock.ExpectQuery(`SELECT .* FROM message_history WHERE broadcast_id = \$1 AND template_id = \$2`).
+				WithArgs(broadcastID, templateID).
+				WillReturnRows(rows)
+
+			stats, err := repo.GetBroadcastVariationStats(ctx, workspaceID, broadcastID, templateID)
+			require.NoError(t, err)
+			expected[templateID] = stats
+		}
+
+		// The grouped query has no row for tplC, which has no message
+		mockWorkspaceRepo.EXPECT().GetConnection(gomock.Any(), workspaceID).Return(db, nil)
+		rows := sqlmock.NewRows(groupedColumns).
+			AddRow(append([]driver.Value{"tplA"}, variations["tplA"]...)...).
+			AddRow(append([]driver.Value{"tplB"}, variations["tplB"]...)...)
+		mock.ExpectQuery(groupedQuery).
+			WithArgs(broadcastID, "tplA", "tplB", "tplC").
+			WillReturnRows(rows)
+
+		allStats, err := repo.GetBroadcastVariationStatsAll(ctx, workspaceID, broadcastID, []string{"tplA", "tplB", "tplC"})
+		require.NoError(t, err)
+		assert.Equal(t, expected, allStats)
+		assert.Equal(t, &domain.MessageHistoryStatusSum{}, allStats["tplC"])
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("no variation", func(t *testing.T) {
+		allStats, err := repo.GetBroadcastVariationStatsAll(ctx, workspaceID, broadcastID, nil)
+		require.NoError(t, err)
+		assert.Empty(t, allStats)
+	})
+
+	t.Run("workspace connection error", func(t *testing.T) {
+		mockWorkspaceRepo.EXPECT().GetConnection(gomock.Any(), workspaceID).Return(nil, errors.New("connection error"))
+
+		allStats, err := repo.GetBroadcastVariationStatsAll(ctx, workspaceID, broadcastID, []string{"tplA"})
+		require.Error(t, err)
+		assert.Nil(t, allStats)
+		assert.Contains(t, err.Error(), "failed to get workspace connection")
+	})
+
+	t.Run("sql error", func(t *testing.T) {
+		mockWorkspaceRepo.EXPECT().GetConnection(gomock.Any(), workspaceID).Return(db, nil)
+		mock.ExpectQuery(groupedQuery).
+			WithArgs(broadcastID, "tplA", "tplB", "tplC").
+			WillReturnError(errors.New("sql error"))
+
+		allStats, err := repo.GetBroadcastVariationStatsAll(ctx, workspaceID, broadcastID, []string{"tplA", "tplB", "tplC"})
+		require.Error(t, err)
+		assert.Nil(t, allStats)
+		assert.Contains(t, err.Error(), "failed to get broadcast variation stats")
+	})
+}
+
 func TestMessageHistoryRepository_SetStatusesIfNotSet(t *testing.T) {
 	mockWorkspaceRepo, repo, mock, db, cleanup := setupMessageHistoryTest(t)
 	defer cleanup()
@@ -1314,15 +1737,17 @@ func TestMessageHistoryRepository_SetStatusesIfNotSet(t *testing.T) {
 			Return(db, nil)
 
 		// Expect batch query for delivered status updates (2 messages)
-		mock.ExpectExec(`UPDATE message_history SET delivered_at = updates\.timestamp, status_info = COALESCE\(LEFT\(updates\.status_info, 255\), message_history\.status_info\), updated_at = \$1::TIMESTAMP WITH TIME ZONE FROM \(VALUES \(\$2, \$3::TIMESTAMP WITH TIME ZONE, \$4\), \(\$5, \$6::TIMESTAMP WITH TIME ZONE, \$7\)\) AS updates\(id, timestamp, status_info\) WHERE message_history\.id = updates\.id AND delivered_at IS NULL`).
+		mock.ExpectExec(`UPDATE message_history SET delivered_at = updates\.timestamp, status_info = COALESCE\(LEFT\(updates\.status_info, 255\), message_history\.status_info\), bounce_category = COALESCE\(updates\.bounce_category, message_history\.bounce_category\), updated_at = \$1::TIMESTAMP WITH TIME ZONE FROM \(VALUES \(\$2, \$3::TIMESTAMP WITH TIME ZONE, \$4, \$5\), \(\$6, \$7::TIMESTAMP WITH TIME ZONE, \$8, \$9\)\) AS updates\(id, timestamp, status_info, bounce_category\) WHERE message_history\.id = updates\.id AND delivered_at IS NULL`).
 			WithArgs(
 				sqlmock.AnyArg(), // updated_at timestamp
 				"msg-123",
 				now,
 				nil, // status_info for msg-123
+				nil, // bounce_category for msg-123
 				"msg-456",
 				now,
 				nil, // status_info for msg-456
+				nil, // bounce_category for msg-456
 			).
 			WillReturnResult(sqlmock.NewResult(0, 2))
 
@@ -1345,12 +1770,13 @@ func TestMessageHistoryRepository_SetStatusesIfNotSet(t *testing.T) {
 			Return(db, nil)
 
 		// Expect batch query for bounced status updates (1 message)
-		mock.ExpectExec(`UPDATE message_history SET bounced_at = updates\.timestamp, status_info = COALESCE\(LEFT\(updates\.status_info, 255\), message_history\.status_info\), updated_at = \$1::TIMESTAMP WITH TIME ZONE FROM \(VALUES \(\$2, \$3::TIMESTAMP WITH TIME ZONE, \$4\)\) AS updates\(id, timestamp, status_info\) WHERE message_history\.id = updates\.id AND bounced_at IS NULL`).
+		mock.ExpectExec(`UPDATE message_history SET bounced_at = updates\.timestamp, status_info = COALESCE\(LEFT\(updates\.status_info, 255\), message_history\.status_info\), bounce_category = COALESCE\(updates\.bounce_category, message_history\.bounce_category\), updated_at = \$1::TIMESTAMP WITH TIME ZONE FROM \(VALUES \(\$2, \$3::TIMESTAMP WITH TIME ZONE, \$4, \$5\)\) AS updates\(id, timestamp, status_info, bounce_category\) WHERE message_history\.id = updates\.id AND bounced_at IS NULL`).
 			WithArgs(
 				sqlmock.AnyArg(), // updated_at timestamp
 				"msg-789",
 				now,
 				nil, // status_info for msg-789
+				nil, // bounce_category for msg-789
 			).
 			WillReturnResult(sqlmock.NewResult(0, 1))
 
@@ -1378,15 +1804,17 @@ func TestMessageHistoryRepository_SetStatusesIfNotSet(t *testing.T) {
 			Return(db, nil)
 
 		// Expect single batch query for opened status updates (2 messages)
-		mock.ExpectExec(`UPDATE message_history SET opened_at = updates\.timestamp, status_info = COALESCE\(LEFT\(updates\.status_info, 255\), message_history\.status_info\), updated_at = \$1::TIMESTAMP WITH TIME ZONE FROM \(VALUES \(\$2, \$3::TIMESTAMP WITH TIME ZONE, \$4\), \(\$5, \$6::TIMESTAMP WITH TIME ZONE, \$7\)\) AS updates\(id, timestamp, status_info\) WHERE message_history\.id = updates\.id AND opened_at IS NULL`).
+		mock.ExpectExec(`UPDATE message_history SET opened_at = updates\.timestamp, status_info = COALESCE\(LEFT\(updates\.status_info, 255\), message_history\.status_info\), bounce_category = COALESCE\(updates\.bounce_category, message_history\.bounce_category\), updated_at = \$1::TIMESTAMP WITH TIME ZONE FROM \(VALUES \(\$2, \$3::TIMESTAMP WITH TIME ZONE, \$4, \$5\), \(\$6, \$7::TIMESTAMP WITH TIME ZONE, \$8, \$9\)\) AS updates\(id, timestamp, status_info, bounce_category\) WHERE message_history\.id = updates\.id AND opened_at IS NULL`).
 			WithArgs(
 				sqlmock.AnyArg(), // updated_at timestamp
 				"msg-123",
 				now,
 				nil, // status_info for msg-123
+				nil, // bounce_category for msg-123
 				"msg-456",
 				now.Add(1*time.Second),
 				nil, // status_info for msg-456
+				nil, // bounce_category for msg-456
 			).
 			WillReturnResult(sqlmock.NewResult(0, 2))
 
@@ -1433,15 +1861,17 @@ func TestMessageHistoryRepository_SetStatusesIfNotSet(t *testing.T) {
 			GetConnection(gomock.Any(), workspaceID).
 			Return(db, nil)
 
-		mock.ExpectExec(`UPDATE message_history SET delivered_at = updates\.timestamp, status_info = COALESCE\(LEFT\(updates\.status_info, 255\), message_history\.status_info\), updated_at = \$1::TIMESTAMP WITH TIME ZONE FROM \(VALUES \(\$2, \$3::TIMESTAMP WITH TIME ZONE, \$4\), \(\$5, \$6::TIMESTAMP WITH TIME ZONE, \$7\)\) AS updates\(id, timestamp, status_info\) WHERE message_history\.id = updates\.id AND delivered_at IS NULL`).
+		mock.ExpectExec(`UPDATE message_history SET delivered_at = updates\.timestamp, status_info = COALESCE\(LEFT\(updates\.status_info, 255\), message_history\.status_info\), bounce_category = COALESCE\(updates\.bounce_category, message_history\.bounce_category\), updated_at = \$1::TIMESTAMP WITH TIME ZONE FROM \(VALUES \(\$2, \$3::TIMESTAMP WITH TIME ZONE, \$4, \$5\), \(\$6, \$7::TIMESTAMP WITH TIME ZONE, \$8, \$9\)\) AS updates\(id, timestamp, status_info, bounce_category\) WHERE message_history\.id = updates\.id AND delivered_at IS NULL`).
 			WithArgs(
 				sqlmock.AnyArg(),
 				"msg-123",
 				now,
 				nil, // status_info for msg-123
+				nil, // bounce_category for msg-123
 				"msg-456",
 				now,
 				nil, // status_info for msg-456
+				nil, // bounce_category for msg-456
 			).
 			WillReturnError(errors.New("database error"))
 
@@ -1470,12 +1900,13 @@ func TestMessageHistoryRepository_SetStatusesIfNotSet(t *testing.T) {
 			Return(db, nil)
 
 		// Expect the batch version to be called with a single update
-		mock.ExpectExec(`UPDATE message_history SET delivered_at = updates\.timestamp, status_info = COALESCE\(LEFT\(updates\.status_info, 255\), message_history\.status_info\), updated_at = \$1::TIMESTAMP WITH TIME ZONE FROM \(VALUES \(\$2, \$3::TIMESTAMP WITH TIME ZONE, \$4\)\) AS updates\(id, timestamp, status_info\) WHERE message_history\.id = updates\.id AND delivered_at IS NULL`).
+		mock.ExpectExec(`UPDATE message_history SET delivered_at = updates\.timestamp, status_info = COALESCE\(LEFT\(updates\.status_info, 255\), message_history\.status_info\), bounce_category = COALESCE\(updates\.bounce_category, message_history\.bounce_category\), updated_at = \$1::TIMESTAMP WITH TIME ZONE FROM \(VALUES \(\$2, \$3::TIMESTAMP WITH TIME ZONE, \$4, \$5\)\) AS updates\(id, timestamp, status_info, bounce_category\) WHERE message_history\.id = updates\.id AND delivered_at IS NULL`).
 			WithArgs(
 				sqlmock.AnyArg(),
 				"msg-123",
 				now,
 				nil, // status_info for msg-123
+				nil, // bounce_category for msg-123
 			).
 			WillReturnResult(sqlmock.NewResult(0, 1))
 
@@ -1491,15 +1922,17 @@ func TestMessageHistoryRepository_SetStatusesIfNotSet(t *testing.T) {
 	})
 
 	t.Run("successful batch update with status_info", func(t *testing.T) {
-		// Test updates with status_info provided - use only one event type to avoid order issues
+		// Test updates with status_info and bounce_category provided - use only one event type to avoid order issues
 		statusInfo1 := "Hard bounce: mailbox does not exist"
+		bounceCategory1 := domain.BounceCategoryHardBounce
 
 		updatesWithStatusInfo := []domain.MessageEventUpdate{
 			{
-				ID:         "msg-123",
-				Event:      domain.MessageEventBounced,
-				Timestamp:  now,
-				StatusInfo: &statusInfo1,
+				ID:             "msg-123",
+				Event:          domain.MessageEventBounced,
+				Timestamp:      now,
+				StatusInfo:     &statusInfo1,
+				BounceCategory: &bounceCategory1,
 			},
 		}
 
@@ -1507,13 +1940,14 @@ func TestMessageHistoryRepository_SetStatusesIfNotSet(t *testing.T) {
 			GetConnection(gomock.Any(), workspaceID).
 			Return(db, nil)
 
-		// Expect batch query for bounced status updates (1 message with status_info)
-		mock.ExpectExec(`UPDATE message_history SET bounced_at = updates\.timestamp, status_info = COALESCE\(LEFT\(updates\.status_info, 255\), message_history\.status_info\), updated_at = \$1::TIMESTAMP WITH TIME ZONE FROM \(VALUES \(\$2, \$3::TIMESTAMP WITH TIME ZONE, \$4\)\) AS updates\(id, timestamp, status_info\) WHERE message_history\.id = updates\.id AND bounced_at IS NULL`).
+		// Expect batch query for bounced status updates (1 message with status_info and bounce_category)
+		mock.ExpectExec(`UPDATE message_history SET bounced_at = updates\.timestamp, status_info = COALESCE\(LEFT\(updates\.status_info, 255\), message_history\.status_info\), bounce_category = COALESCE\(updates\.bounce_category, message_history\.bounce_category\), updated_at = \$1::TIMESTAMP WITH TIME ZONE FROM \(VALUES \(\$2, \$3::TIMESTAMP WITH TIME ZONE, \$4, \$5\)\) AS updates\(id, timestamp, status_info, bounce_category\) WHERE message_history\.id = updates\.id AND bounced_at IS NULL`).
 			WithArgs(
 				sqlmock.AnyArg(), // updated_at timestamp
 				"msg-123",
 				now,
-				&statusInfo1, // status_info for msg-123
+				&statusInfo1,  // status_info for msg-123
+				"hard_bounce", // bounce_category for msg-123
 			).
 			WillReturnResult(sqlmock.NewResult(0, 1))
 
@@ -1522,6 +1956,74 @@ func TestMessageHistoryRepository_SetStatusesIfNotSet(t *testing.T) {
 	})
 }
 
+func TestMessageHistoryRepository_ApplyStatusesLocked(t *testing.T) {
+	mockWorkspaceRepo, repo, mock, db, cleanup := setupMessageHistoryTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	workspaceID := "workspace-123"
+	earlier := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	later := earlier.Add(time.Minute)
+	statusColumns := []string{"id", "delivered_at", "failed_at", "opened_at", "clicked_at", "bounced_at", "complained_at", "unsubscribed_at"}
+
+	t.Run("first event wins", func(t *testing.T) {
+		mockWorkspaceRepo.EXPECT().GetConnection(gomock.Any(), workspaceID).Return(db, nil)
+
+		mock.ExpectBegin()
+		// msg-1 was already opened by a concurrent event, msg-2 is not opened yet
+		mock.ExpectQuery(`SELECT id, delivered_at, failed_at, opened_at, clicked_at, bounced_at, complained_at, unsubscribed_at FROM message_history WHERE id = ANY\(\$1\) ORDER BY id FOR UPDATE`).
+			WithArgs(sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows(statusColumns).
+				AddRow("msg-1", nil, nil, earlier, nil, nil, nil, nil).
+				AddRow("msg-2", nil, nil, nil, nil, nil, nil, nil))
+		// The duplicate opens of msg-2 are applied once, with the earliest timestamp
+		mock.ExpectExec(`UPDATE message_history SET opened_at = \$1`).
+			WithArgs(earlier, nil, nil, sqlmock.AnyArg(), "msg-2").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+		mock.ExpectCommit()
+
+		err := repo.ApplyStatusesLocked(ctx, workspaceID, []domain.MessageEventUpdate{
+			{ID: "msg-1", Event: domain.MessageEventOpened, Timestamp: later},
+			{ID: "msg-2", Event: domain.MessageEventOpened, Timestamp: later},
+			{ID: "msg-2", Event: domain.MessageEventOpened, Timestamp: earlier},
+			{ID: "msg-unknown", Event: domain.MessageEventOpened, Timestamp: later},
+		})
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("empty updates", func(t *testing.T) {
+		require.NoError(t, repo.ApplyStatusesLocked(ctx, workspaceID, nil))
+	})
+
+	t.Run("invalid status", func(t *testing.T) {
+		err := repo.ApplyStatusesLocked(ctx, workspaceID, []domain.MessageEventUpdate{
+			{ID: "msg-1", Event: domain.MessageEvent("invalid"), Timestamp: later},
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "invalid status")
+	})
+
+	t.Run("update error rolls back", func(t *testing.T) {
+		mockWorkspaceRepo.EXPECT().GetConnection(gomock.Any(), workspaceID).Return(db, nil)
+
+		mock.ExpectBegin()
+		mock.ExpectQuery(`FOR UPDATE`).
+			WithArgs(sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows(statusColumns).AddRow("msg-1", nil, nil, nil, nil, nil, nil, nil))
+		mock.ExpectExec(`UPDATE message_history SET clicked_at = \$1`).
+			WillReturnError(errors.New("database error"))
+		mock.ExpectRollback()
+
+		err := repo.ApplyStatusesLocked(ctx, workspaceID, []domain.MessageEventUpdate{
+			{ID: "msg-1", Event: domain.MessageEventClicked, Timestamp: later},
+		})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to apply message status clicked")
+		require.NoError(t, mock.ExpectationsWereMet())
+	})
+}
+
 func TestMessageHistoryRepository_ListMessages(t *testing.T) {
 	mockWorkspaceRepo, repo, mock, db, cleanup := setupMessageHistoryTest(t)
 	defer cleanup()
@@ -2520,6 +3022,147 @@ func TestMessageHistoryRepository_ListMessages(t *testing.T) {
 	})
 }
 
+func TestMessageHistoryRepository_ExportMessages(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := "workspace-123"
+	createdAt := time.Date(2023, 1, 1, 12, 0, 0, 123456789, time.UTC)
+
+	exportColumns := []string{
+		"id", "external_id", "contact_email", "broadcast_id", "automation_id", "list_id", "template_id", "template_version",
+		"channel", "status_info", "sent_at", "delivered_at", "failed_at", "opened_at", "clicked_at", "bounced_at",
+		"complained_at", "unsubscribed_at", "created_at", "updated_at",
+	}
+	exportSelect := "SELECT id, external_id, contact_email, broadcast_id, automation_id, list_id, template_id, template_version, channel, status_info, sent_at, delivered_at, failed_at, opened_at, clicked_at, bounced_at, complained_at, unsubscribed_at, created_at, updated_at FROM message_history"
+
+	t.Run("applies the same filters as ListMessages", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+
+		// Record the executed queries instead of matching them
+		var queries []string
+		db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(sqlmock.QueryMatcherFunc(func(_, actualSQL string) error {
+			queries = append(queries, actualSQL)
+			return nil
+		})))
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		repo := NewMessageHistoryRepository(mockWorkspaceRepo, true)
+		mockWorkspaceRepo.EXPECT().GetConnection(gomock.Any(), workspaceID).Return(db, nil).Times(2)
+
+		sentAfter := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+		isOpened := true
+		params := domain.MessageListParams{
+			Channel:      "email",
+			ContactEmail: "user@example.com",
+			BroadcastID:  "broadcast-1",
+			IsOpened:     &isOpened,
+			SentAfter:    &sentAfter,
+		}
+
+		mock.ExpectQuery("list").
+			WithArgs("email", "user@example.com", "broadcast-1", sentAfter).
+			WillReturnRows(sqlmock.NewRows([]string{"id"}))
+		mock.ExpectQuery("export").
+			WithArgs("email", "user@example.com", "broadcast-1", sentAfter).
+			WillReturnRows(sqlmock.NewRows(exportColumns))
+
+		_, _, err = repo.ListMessages(ctx, workspaceID, testSecretKey, params)
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		err = repo.ExportMessages(ctx, workspaceID, testSecretKey, params, &buf)
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+
+		whereClause := func(query string) string {
+			start := strings.Index(query, " WHERE ")
+			end := strings.Index(query, " ORDER BY ")
+			require.True(t, start >= 0 && end > start, query)
+			return query[start:end]
+		}
+		require.Len(t, queries, 2)
+		assert.Equal(t, " WHERE channel = $1 AND contact_email = $2 AND broadcast_id = $3 AND opened_at IS NOT NULL AND sent_at >= $4", whereClause(queries[0]))
+		assert.Equal(t, whereClause(queries[0]), whereClause(queries[1]))
+		assert.Equal(t, strings.Join(domain.MessageExportColumns, ",")+"\n", buf.String())
+	})
+
+	t.Run("escapes emails containing commas", func(t *testing.T) {
+		mockWorkspaceRepo, repo, mock, db, cleanup := setupMessageHistoryTest(t)
+		defer cleanup()
+
+		mockWorkspaceRepo.EXPECT().GetConnection(gomock.Any(), workspaceID).Return(db, nil)
+
+		mock.ExpectQuery(regexp.QuoteMeta(exportSelect + " ORDER BY created_at DESC, id DESC LIMIT 1000")).
+			WillReturnRows(sqlmock.NewRows(exportColumns).AddRow(
+				"msg-1", nil, `"Doe, John"@example.com`, "broadcast-1", nil, "list-1", "template-1", 3,
+				"email", nil, createdAt, nil, nil, createdAt, nil, nil,
+				nil, nil, createdAt, createdAt,
+			))
+
+		var buf bytes.Buffer
+		err := repo.ExportMessages(ctx, workspaceID, testSecretKey, domain.MessageListParams{}, &buf)
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+
+		lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+		require.Len(t, lines, 2)
+		assert.Equal(t, `msg-1,,"""Doe, John""@example.com",broadcast-1,,list-1,template-1,3,email,,2023-01-01T12:00:00Z,,,2023-01-01T12:00:00Z,,,,,2023-01-01T12:00:00Z,2023-01-01T12:00:00Z`, lines[1])
+
+		records, err := csv.NewReader(&buf).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 2)
+		assert.Equal(t, `"Doe, John"@example.com`, records[1][2])
+	})
+
+	t.Run("pages with a keyset cursor on the exact creation time", func(t *testing.T) {
+		mockWorkspaceRepo, repo, mock, db, cleanup := setupMessageHistoryTest(t)
+		defer cleanup()
+
+		mockWorkspaceRepo.EXPECT().GetConnection(gomock.Any(), workspaceID).Return(db, nil)
+
+		firstPage := sqlmock.NewRows(exportColumns)
+		for i := 0; i < 1000; i++ {
+			firstPage.AddRow(
+				fmt.Sprintf("msg-%04d", 1000-i), nil, "user@example.com", nil, nil, nil, "template-1", 1,
+				"email", nil, createdAt, nil, nil, nil, nil, nil,
+				nil, nil, createdAt, createdAt,
+			)
+		}
+		mock.ExpectQuery(regexp.QuoteMeta(exportSelect + " ORDER BY created_at DESC, id DESC LIMIT 1000")).
+			WillReturnRows(firstPage)
+		mock.ExpectQuery(regexp.QuoteMeta(exportSelect+" WHERE (created_at < $1 OR (created_at = $2 AND id < $3)) ORDER BY created_at DESC, id DESC LIMIT 1000")).
+			WithArgs(createdAt, createdAt, "msg-0001").
+			WillReturnRows(sqlmock.NewRows(exportColumns).AddRow(
+				"msg-0000", nil, "user@example.com", nil, nil, nil, "template-1", 1,
+				"email", nil, createdAt, nil, nil, nil, nil, nil,
+				nil, nil, createdAt, createdAt,
+			))
+
+		var buf bytes.Buffer
+		err := repo.ExportMessages(ctx, workspaceID, testSecretKey, domain.MessageListParams{}, &buf)
+		require.NoError(t, err)
+		require.NoError(t, mock.ExpectationsWereMet())
+
+		records, err := csv.NewReader(&buf).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, records, 1002)
+		assert.Equal(t, "msg-0000", records[1001][0])
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		mockWorkspaceRepo, repo, mock, db, cleanup := setupMessageHistoryTest(t)
+		defer cleanup()
+
+		mockWorkspaceRepo.EXPECT().GetConnection(gomock.Any(), workspaceID).Return(db, nil)
+		mock.ExpectQuery("SELECT").WillReturnError(errors.New("db error"))
+
+		err := repo.ExportMessages(ctx, workspaceID, testSecretKey, domain.MessageListParams{}, &bytes.Buffer{})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to query message history")
+	})
+}
+
 func TestMessageHistoryRepository_DeleteForEmail(t *testing.T) {
 	mockWorkspaceRepo, repo, mock, db, cleanup := setupMessageHistoryTest(t)
 	defer cleanup()
@@ -2595,6 +3238,290 @@ func TestMessageHistoryRepository_DeleteForEmail(t *testing.T) {
 	})
 }
 
+func TestMessageHistoryRepository_CountSentAndComplaintsSince(t *testing.T) {
+	mockWorkspaceRepo, repo, mock, db, cleanup := setupMessageHistoryTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	workspaceID := "workspace-123"
+	since := time.Date(2026, 3, 1, 11, 0, 0, 0, time.UTC)
+
+	t.Run("counts sends and complaints in the window", func(t *testing.T) {
+		mockWorkspaceRepo.EXPECT().
+			GetConnection(gomock.Any(), workspaceID).
+			Return(db, nil)
+
+		mock.ExpectQuery(`COUNT\(\*\) FILTER \(WHERE sent_at >= \$1\) AS sent, COUNT\(\*\) FILTER \(WHERE complained_at >= \$1\) AS complaints FROM message_history WHERE sent_at >= \$1 OR complained_at >= \$1`).
+			WithArgs(since).
+			WillReturnRows(sqlmock.NewRows([]string{"sent", "complaints"}).AddRow(1200, 7))
+
+		sent, complaints, err := repo.CountSentAndComplaintsSince(ctx, workspaceID, since)
+		require.NoError(t, err)
+		assert.Equal(t, 1200, sent)
+		assert.Equal(t, 7, complaints)
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		mockWorkspaceRepo.EXPECT().
+			GetConnection(gomock.Any(), workspaceID).
+			Return(db, nil)
+
+		mock.ExpectQuery(`FROM message_history`).
+			WillReturnError(errors.New("db error"))
+
+		_, _, err := repo.CountSentAndComplaintsSince(ctx, workspaceID, since)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to count sent messages and complaints")
+	})
+
+	t.Run("workspace connection error", func(t *testing.T) {
+		mockWorkspaceRepo.EXPECT().
+			GetConnection(gomock.Any(), workspaceID).
+			Return(nil, errors.New("connection error"))
+
+		_, _, err := repo.CountSentAndComplaintsSince(ctx, workspaceID, since)
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to get workspace connection")
+	})
+}
+
+func TestMessageHistoryRepository_GetEngagementScores(t *testing.T) {
+	mockWorkspaceRepo, repo, mock, db, cleanup := setupMessageHistoryTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	workspaceID := "workspace-123"
+
+	// decayed mirrors the SQL term of one open or click of the given age
+	decayed := func(weight int, ageDays float64) float64 {
+		return float64(weight) * math.Pow(0.5, ageDays/domain.EngagementScoreHalfLifeDays)
+	}
+
+	t.Run("recent click scores higher than an old open", func(t *testing.T) {
+		mockWorkspaceRepo.EXPECT().
+			GetConnection(gomock.Any(), workspaceID).
+			Return(db, nil)
+
+		// A click sets opened_at too, the old opener only opened 3 months ago
+		recentClicker := decayed(domain.EngagementScoreOpenWeight, 2) + decayed(domain.EngagementScoreClickWeight, 2)
+		oldOpener := decayed(domain.EngagementScoreOpenWeight, 90)
+
+		mock.ExpectQuery(`SELECT contact_email, SUM\(CASE WHEN opened_at > NOW\(\) - INTERVAL '180 days' THEN 1 \* POWER\(0\.5, EXTRACT\(EPOCH FROM NOW\(\) - opened_at\) / 2592000\) ELSE 0 END \+ CASE WHEN clicked_at > NOW\(\) - INTERVAL '180 days' THEN 3 \* .* FROM message_history WHERE contact_email IN \(\$1,\$2,\$3\) AND \(opened_at > .* OR clicked_at > .*\) GROUP BY contact_email`).
+			WithArgs("clicker@example.com", "opener@example.com", "idle@example.com").
+			WillReturnRows(sqlmock.NewRows([]string{"contact_email", "engagement_score"}).
+				AddRow("clicker@example.com", recentClicker).
+				AddRow("opener@example.com", oldOpener))
+
+		scores, err := repo.GetEngagementScores(ctx, workspaceID, []string{"clicker@example.com", "opener@example.com", "idle@example.com"})
+		require.NoError(t, err)
+		require.Len(t, scores, 3)
+		assert.InDelta(t, recentClicker, scores["clicker@example.com"], 0.0001)
+		assert.InDelta(t, oldOpener, scores["opener@example.com"], 0.0001)
+		assert.Greater(t, scores["clicker@example.com"], scores["opener@example.com"])
+		assert.Zero(t, scores["idle@example.com"])
+	})
+
+	t.Run("no emails does not query", func(t *testing.T) {
+		scores, err := repo.GetEngagementScores(ctx, workspaceID, nil)
+		require.NoError(t, err)
+		assert.Empty(t, scores)
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		mockWorkspaceRepo.EXPECT().
+			GetConnection(gomock.Any(), workspaceID).
+			Return(db, nil)
+
+		mock.ExpectQuery(`FROM message_history`).
+			WillReturnError(errors.New("db error"))
+
+		_, err := repo.GetEngagementScores(ctx, workspaceID, []string{"clicker@example.com"})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to get engagement scores")
+	})
+
+	t.Run("workspace connection error", func(t *testing.T) {
+		mockWorkspaceRepo.EXPECT().
+			GetConnection(gomock.Any(), workspaceID).
+			Return(nil, errors.New("connection error"))
+
+		_, err := repo.GetEngagementScores(ctx, workspaceID, []string{"clicker@example.com"})
+		require.Error(t, err)
+		require.Contains(t, err.Error(), "failed to get workspace connection")
+	})
+}
+
+func TestMessageHistoryRepository_GetContactTimeline(t *testing.T) {
+	mockWorkspaceRepo, repo, mock, db, cleanup := setupMessageHistoryTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	workspaceID := "workspace-123"
+	email := "test@example.com"
+
+	messageColumns := []string{"entry_id", "kind", "occurred_at", "message_id", "template_id", "channel", "broadcast_id", "list_id"}
+	listColumns := []string{"entry_id", "kind", "occurred_at", "list_id", "status"}
+
+	t10 := time.Date(2023, 1, 1, 10, 0, 0, 0, time.UTC)
+	t11 := time.Date(2023, 1, 1, 11, 0, 0, 0, time.UTC)
+	t12 := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("merges message and list events", func(t *testing.T) {
+		mockWorkspaceRepo.EXPECT().GetConnection(gomock.Any(), workspaceID).Return(db, nil)
+
+		mock.ExpectQuery(`FROM message_history mh CROSS JOIN LATERAL .* ORDER BY occurred_at DESC, entry_id DESC LIMIT 21`).
+			WithArgs(email).
+			WillReturnRows(sqlmock.NewRows(messageColumns).
+				AddRow("message:msg-1:opened", "message.opened", t12, "msg-1", "template-1", "email", "broadcast-1", "list-1").
+				AddRow("message:msg-1:sent", "message.sent", t10, "msg-1", "template-1", "email", "broadcast-1", "list-1"))
+		mock.ExpectQuery(`FROM contact_lists WHERE email = \$1\) AS timeline ORDER BY occurred_at DESC, entry_id DESC LIMIT 21`).
+			WithArgs(email).
+			WillReturnRows(sqlmock.NewRows(listColumns).
+				AddRow("list:list-1", "list.active", t11, "list-1", "active"))
+
+		entries, nextCursor, err := repo.GetContactTimeline(ctx, workspaceID, testSecretKey, email, 0, "")
+		require.NoError(t, err)
+		assert.Empty(t, nextCursor)
+		require.Len(t, entries, 3)
+		assert.Equal(t, "message.opened", entries[0].Kind)
+		assert.Equal(t, "list.active", entries[1].Kind)
+		assert.Equal(t, "message.sent", entries[2].Kind)
+		assert.Equal(t, map[string]interface{}{"list_id": "list-1", "status": "active"}, entries[1].Payload)
+		assert.Equal(t, "broadcast-1", entries[2].Payload["broadcast_id"])
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("same timestamp is ordered by id and paginated", func(t *testing.T) {
+		mockWorkspaceRepo.EXPECT().GetConnection(gomock.Any(), workspaceID).Return(db, nil)
+
+		mock.ExpectQuery(`FROM message_history mh`).
+			WithArgs(email).
+			WillReturnRows(sqlmock.NewRows(messageColumns).
+				AddRow("message:msg-1:unsubscribed", "message.unsubscribed", t12, "msg-1", "template-1", "email", nil, nil))
+		mock.ExpectQuery(`FROM contact_lists`).
+			WithArgs(email).
+			WillReturnRows(sqlmock.NewRows(listColumns).
+				AddRow("list:list-1", "list.unsubscribed", t12, "list-1", "unsubscribed"))
+
+		entries, nextCursor, err := repo.GetContactTimeline(ctx, workspaceID, testSecretKey, email, 1, "")
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, "message:msg-1:unsubscribed", entries[0].ID)
+		assert.NotContains(t, entries[0].Payload, "broadcast_id")
+
+		decoded, err := base64.StdEncoding.DecodeString(nextCursor)
+		require.NoError(t, err)
+		assert.Equal(t, "2023-01-01T12:00:00Z~message:msg-1:unsubscribed", string(decoded))
+
+		// The next page resumes after the message event, which returns the list event sharing its timestamp
+		mockWorkspaceRepo.EXPECT().GetConnection(gomock.Any(), workspaceID).Return(db, nil)
+
+		mock.ExpectQuery(`FROM message_history mh .* WHERE occurred_at < \$2 OR \(occurred_at = \$2 AND entry_id < \$3\)`).
+			WithArgs(email, t12, "message:msg-1:unsubscribed").
+			WillReturnRows(sqlmock.NewRows(messageColumns))
+		mock.ExpectQuery(`FROM contact_lists .* WHERE occurred_at < \$2 OR \(occurred_at = \$2 AND entry_id < \$3\)`).
+			WithArgs(email, t12, "message:msg-1:unsubscribed").
+			WillReturnRows(sqlmock.NewRows(listColumns).
+				AddRow("list:list-1", "list.unsubscribed", t12, "list-1", "unsubscribed"))
+
+		entries, nextCursor, err = repo.GetContactTimeline(ctx, workspaceID, testSecretKey, email, 1, nextCursor)
+		require.NoError(t, err)
+		assert.Empty(t, nextCursor)
+		require.Len(t, entries, 1)
+		assert.Equal(t, "list:list-1", entries[0].ID)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		_, _, err := repo.GetContactTimeline(ctx, workspaceID, testSecretKey, email, 10, "not-base64!")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid cursor encoding")
+
+		_, _, err = repo.GetContactTimeline(ctx, workspaceID, testSecretKey, email, 10, base64.StdEncoding.EncodeToString([]byte("no-separator")))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid cursor format")
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		mockWorkspaceRepo.EXPECT().GetConnection(gomock.Any(), workspaceID).Return(db, nil)
+
+		mock.ExpectQuery(`FROM message_history mh`).
+			WithArgs(email).
+			WillReturnError(errors.New("db error"))
+
+		_, _, err := repo.GetContactTimeline(ctx, workspaceID, testSecretKey, email, 10, "")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to query message events")
+	})
+
+	t.Run("workspace connection error", func(t *testing.T) {
+		mockWorkspaceRepo.EXPECT().GetConnection(gomock.Any(), workspaceID).Return(nil, errors.New("connection error"))
+
+		_, _, err := repo.GetContactTimeline(ctx, workspaceID, testSecretKey, email, 10, "")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to get workspace connection")
+	})
+}
+
+func TestMessageHistoryRepository_PruneOlderThan(t *testing.T) {
+	mockWorkspaceRepo, repo, mock, db, cleanup := setupMessageHistoryTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	workspaceID := "workspace-123"
+	cutoff := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("keeps messages with retained statuses", func(t *testing.T) {
+		mockWorkspaceRepo.EXPECT().GetConnection(gomock.Any(), workspaceID).Return(db, nil)
+
+		mock.ExpectExec(`(?s)DELETE FROM message_history\s+WHERE id IN \(\s*SELECT id FROM message_history\s+WHERE created_at < \$1 AND complained_at IS NULL AND unsubscribed_at IS NULL\s+ORDER BY created_at\s+LIMIT \$2`).
+			WithArgs(cutoff, 500).
+			WillReturnResult(sqlmock.NewResult(0, 500))
+
+		deleted, err := repo.PruneOlderThan(ctx, workspaceID, cutoff, []domain.MessageEvent{domain.MessageEventComplained, domain.MessageEventUnsubscribed}, 500)
+		require.NoError(t, err)
+		assert.Equal(t, int64(500), deleted)
+	})
+
+	t.Run("without retained statuses", func(t *testing.T) {
+		mockWorkspaceRepo.EXPECT().GetConnection(gomock.Any(), workspaceID).Return(db, nil)
+
+		mock.ExpectExec(`(?s)WHERE created_at < \$1\s+ORDER BY created_at`).
+			WithArgs(cutoff, 500).
+			WillReturnResult(sqlmock.NewResult(0, 12))
+
+		deleted, err := repo.PruneOlderThan(ctx, workspaceID, cutoff, nil, 500)
+		require.NoError(t, err)
+		assert.Equal(t, int64(12), deleted)
+	})
+
+	t.Run("invalid keep status", func(t *testing.T) {
+		_, err := repo.PruneOlderThan(ctx, workspaceID, cutoff, []domain.MessageEvent{domain.MessageEventSent}, 500)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid keep status")
+	})
+
+	t.Run("execution error", func(t *testing.T) {
+		mockWorkspaceRepo.EXPECT().GetConnection(gomock.Any(), workspaceID).Return(db, nil)
+
+		mock.ExpectExec(`DELETE FROM message_history`).
+			WithArgs(cutoff, 500).
+			WillReturnError(errors.New("lock timeout"))
+
+		_, err := repo.PruneOlderThan(ctx, workspaceID, cutoff, nil, 500)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to prune message history")
+	})
+
+	t.Run("workspace connection error", func(t *testing.T) {
+		mockWorkspaceRepo.EXPECT().GetConnection(gomock.Any(), workspaceID).Return(nil, errors.New("connection error"))
+
+		_, err := repo.PruneOlderThan(ctx, workspaceID, cutoff, nil, 500)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to get workspace connection")
+	})
+}
+
 // Helper function to create string pointers
 func stringPtr(s string) *string {
 	return &s
@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/Notifuse/notifuse/internal/domain"
+)
+
+// MessageStatusRetryRepository implements domain.MessageStatusRetryRepository
+type MessageStatusRetryRepository struct {
+	workspaceRepo domain.WorkspaceRepository
+}
+
+// NewMessageStatusRetryRepository creates a new message status retry repository
+func NewMessageStatusRetryRepository(workspaceRepo domain.WorkspaceRepository) *MessageStatusRetryRepository {
+	return &MessageStatusRetryRepository{
+		workspaceRepo: workspaceRepo,
+	}
+}
+
+// Enqueue stores failed updates for a first retry at nextAttemptAt. An update already queued for the
+// same message, event and timestamp keeps its schedule, so a webhook delivered twice is retried once.
+func (r *MessageStatusRetryRepository) Enqueue(ctx context.Context, workspaceID string, updates []domain.MessageEventUpdate, nextAttemptAt time.Time, lastError string) error {
+	if len(updates) == 0 {
+		return nil
+	}
+
+	// Get the workspace database connection
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	now := time.Now().UTC()
+	insert := sq.StatementBuilder.PlaceholderFormat(sq.Dollar).
+		Insert("message_status_retries").
+		Columns("external_id", "event", "event_timestamp", "status_info", "bounce_category", "attempts", "next_attempt_at", "last_error", "created_at")
+	for _, update := range updates {
+		var bounceCategory *string
+		if update.BounceCategory != nil {
+			category := string(*update.BounceCategory)
+			bounceCategory = &category
+		}
+		insert = insert.Values(update.ID, string(update.Event), update.Timestamp.UTC(), update.StatusInfo, bounceCategory, 0, nextAttemptAt.UTC(), lastError, now)
+	}
+
+	query, args, err := insert.Suffix("ON CONFLICT (external_id, event, event_timestamp) DO NOTHING").ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build query: %w", err)
+	}
+
+	if _, err := workspaceDB.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to enqueue message status retries: %w", err)
+	}
+
+	return nil
+}
+
+// ListDue retrieves at most limit retries whose next attempt is due, oldest first
+func (r *MessageStatusRetryRepository) ListDue(ctx context.Context, workspaceID string, now time.Time, limit int) ([]*domain.MessageStatusRetry, error) {
+	// Get the workspace database connection
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	query := `
+		SELECT external_id, event, event_timestamp, status_info, bounce_category, attempts, next_attempt_at, last_error, created_at
+		FROM message_status_retries
+		WHERE next_attempt_at <= $1
+		ORDER BY next_attempt_at, created_at
+		LIMIT $2
+	`
+
+	rows, err := workspaceDB.QueryContext(ctx, query, now.UTC(), limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list message status retries: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	retries := []*domain.MessageStatusRetry{}
+	for rows.Next() {
+		retry := &domain.MessageStatusRetry{}
+		var event string
+		var bounceCategory, lastError *string
+		if err := rows.Scan(
+			&retry.Update.ID,
+			&event,
+			&retry.Update.Timestamp,
+			&retry.Update.StatusInfo,
+			&bounceCategory,
+			&retry.Attempts,
+			&retry.NextAttemptAt,
+			&lastError,
+			&retry.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan message status retry: %w", err)
+		}
+		retry.Update.Event = domain.MessageEvent(event)
+		if bounceCategory != nil {
+			category := domain.BounceCategory(*bounceCategory)
+			retry.Update.BounceCategory = &category
+		}
+		if lastError != nil {
+			retry.LastError = *lastError
+		}
+		retries = append(retries, retry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate message status retries: %w", err)
+	}
+
+	return retries, nil
+}
+
+// Reschedule records a failed attempt of a retry with its attempts, next attempt and last error
+func (r *MessageStatusRetryRepository) Reschedule(ctx context.Context, workspaceID string, retry *domain.MessageStatusRetry) error {
+	// Get the workspace database connection
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	query := `
+		UPDATE message_status_retries
+		SET attempts = $4, next_attempt_at = $5, last_error = $6
+		WHERE external_id = $1 AND event = $2 AND event_timestamp = $3
+	`
+
+	_, err = workspaceDB.ExecContext(ctx, query,
+		retry.Update.ID, string(retry.Update.Event), retry.Update.Timestamp.UTC(),
+		retry.Attempts, retry.NextAttemptAt.UTC(), retry.LastError)
+	if err != nil {
+		return fmt.Errorf("failed to reschedule message status retry: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes retries that were applied or given up
+func (r *MessageStatusRetryRepository) Delete(ctx context.Context, workspaceID string, retries []*domain.MessageStatusRetry) error {
+	if len(retries) == 0 {
+		return nil
+	}
+
+	// Get the workspace database connection
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	keys := make([]string, len(retries))
+	args := make([]interface{}, 0, len(retries)*3)
+	for i, retry := range retries {
+		keys[i] = fmt.Sprintf("($%d, $%d, $%d::TIMESTAMP WITH TIME ZONE)", len(args)+1, len(args)+2, len(args)+3)
+		args = append(args, retry.Update.ID, string(retry.Update.Event), retry.Update.Timestamp.UTC())
+	}
+
+	query := fmt.Sprintf(`DELETE FROM message_status_retries WHERE (external_id, event, event_timestamp) IN (%s)`, strings.Join(keys, ", "))
+
+	if _, err := workspaceDB.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to delete message status retries: %w", err)
+	}
+
+	return nil
+}
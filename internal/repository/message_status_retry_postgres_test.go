@@ -0,0 +1,170 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/domain/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMessageStatusRetryRepository_Enqueue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	repo := NewMessageStatusRetryRepository(workspaceRepo)
+	eventAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	nextAttemptAt := eventAt.Add(30 * time.Second)
+	info := "hard bounce"
+	category := domain.BounceCategoryHardBounce
+
+	t.Run("duplicates are left to the existing retry", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		workspaceRepo.EXPECT().GetConnection(ctx, "ws1").Return(db, nil)
+		mock.ExpectExec(`INSERT INTO message_status_retries \(external_id,event,event_timestamp,status_info,bounce_category,attempts,next_attempt_at,last_error,created_at\) VALUES \(\$1,\$2,\$3,\$4,\$5,\$6,\$7,\$8,\$9\),\(\$10,.*\) ON CONFLICT \(external_id, event, event_timestamp\) DO NOTHING`).
+			WithArgs(
+				"msg-1", "delivered", eventAt, nil, nil, 0, nextAttemptAt, "connection reset", sqlmock.AnyArg(),
+				"msg-2", "bounced", eventAt, &info, "hard_bounce", 0, nextAttemptAt, "connection reset", sqlmock.AnyArg(),
+			).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		err = repo.Enqueue(ctx, "ws1", []domain.MessageEventUpdate{
+			{ID: "msg-1", Event: domain.MessageEventDelivered, Timestamp: eventAt},
+			{ID: "msg-2", Event: domain.MessageEventBounced, Timestamp: eventAt, StatusInfo: &info, BounceCategory: &category},
+		}, nextAttemptAt, "connection reset")
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("no updates", func(t *testing.T) {
+		require.NoError(t, repo.Enqueue(ctx, "ws1", nil, nextAttemptAt, "connection reset"))
+	})
+
+	t.Run("connection error", func(t *testing.T) {
+		workspaceRepo.EXPECT().GetConnection(ctx, "ws1").Return(nil, errors.New("connection error"))
+
+		err := repo.Enqueue(ctx, "ws1", []domain.MessageEventUpdate{{ID: "msg-1", Event: domain.MessageEventDelivered, Timestamp: eventAt}}, nextAttemptAt, "connection reset")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to get workspace connection")
+	})
+}
+
+func TestMessageStatusRetryRepository_ListDue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	repo := NewMessageStatusRetryRepository(workspaceRepo)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	eventAt := now.Add(-time.Minute)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	workspaceRepo.EXPECT().GetConnection(ctx, "ws1").Return(db, nil)
+	mock.ExpectQuery(`SELECT external_id, event, event_timestamp, status_info, bounce_category, attempts, next_attempt_at, last_error, created_at\s+FROM message_status_retries\s+WHERE next_attempt_at <= \$1\s+ORDER BY next_attempt_at, created_at\s+LIMIT \$2`).
+		WithArgs(now, 100).
+		WillReturnRows(sqlmock.NewRows([]string{"external_id", "event", "event_timestamp", "status_info", "bounce_category", "attempts", "next_attempt_at", "last_error", "created_at"}).
+			AddRow("msg-1", "delivered", eventAt, nil, nil, 0, now, "connection reset", eventAt).
+			AddRow("msg-2", "bounced", eventAt, "hard bounce", "hard_bounce", 2, now, nil, eventAt))
+
+	retries, err := repo.ListDue(ctx, "ws1", now, 100)
+	require.NoError(t, err)
+	require.Len(t, retries, 2)
+
+	assert.Equal(t, domain.MessageEventUpdate{ID: "msg-1", Event: domain.MessageEventDelivered, Timestamp: eventAt}, retries[0].Update)
+	assert.Equal(t, "connection reset", retries[0].LastError)
+
+	assert.Equal(t, domain.MessageEventBounced, retries[1].Update.Event)
+	require.NotNil(t, retries[1].Update.StatusInfo)
+	assert.Equal(t, "hard bounce", *retries[1].Update.StatusInfo)
+	require.NotNil(t, retries[1].Update.BounceCategory)
+	assert.Equal(t, domain.BounceCategoryHardBounce, *retries[1].Update.BounceCategory)
+	assert.Equal(t, 2, retries[1].Attempts)
+	assert.Empty(t, retries[1].LastError)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMessageStatusRetryRepository_Reschedule(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	repo := NewMessageStatusRetryRepository(workspaceRepo)
+	eventAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	workspaceRepo.EXPECT().GetConnection(ctx, "ws1").Return(db, nil)
+	mock.ExpectExec(`UPDATE message_status_retries\s+SET attempts = \$4, next_attempt_at = \$5, last_error = \$6\s+WHERE external_id = \$1 AND event = \$2 AND event_timestamp = \$3`).
+		WithArgs("msg-1", "delivered", eventAt, 2, eventAt.Add(time.Minute), "lock timeout").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repo.Reschedule(ctx, "ws1", &domain.MessageStatusRetry{
+		Update:        domain.MessageEventUpdate{ID: "msg-1", Event: domain.MessageEventDelivered, Timestamp: eventAt},
+		Attempts:      2,
+		NextAttemptAt: eventAt.Add(time.Minute),
+		LastError:     "lock timeout",
+	})
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMessageStatusRetryRepository_Delete(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	repo := NewMessageStatusRetryRepository(workspaceRepo)
+	eventAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("deletes by message, event and timestamp", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		workspaceRepo.EXPECT().GetConnection(ctx, "ws1").Return(db, nil)
+		mock.ExpectExec(`DELETE FROM message_status_retries WHERE \(external_id, event, event_timestamp\) IN \(\(\$1, \$2, \$3::TIMESTAMP WITH TIME ZONE\), \(\$4, \$5, \$6::TIMESTAMP WITH TIME ZONE\)\)`).
+			WithArgs("msg-1", "delivered", eventAt, "msg-2", "bounced", eventAt).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		err = repo.Delete(ctx, "ws1", []*domain.MessageStatusRetry{
+			{Update: domain.MessageEventUpdate{ID: "msg-1", Event: domain.MessageEventDelivered, Timestamp: eventAt}},
+			{Update: domain.MessageEventUpdate{ID: "msg-2", Event: domain.MessageEventBounced, Timestamp: eventAt}},
+		})
+		require.NoError(t, err)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		workspaceRepo.EXPECT().GetConnection(ctx, "ws1").Return(db, nil)
+		mock.ExpectExec(`DELETE FROM message_status_retries`).WillReturnError(errors.New("lock timeout"))
+
+		err = repo.Delete(ctx, "ws1", []*domain.MessageStatusRetry{
+			{Update: domain.MessageEventUpdate{ID: "msg-1", Event: domain.MessageEventDelivered, Timestamp: eventAt}},
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to delete message status retries")
+	})
+}
@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+)
+
+// ProviderWebhookHealthRepository implements domain.ProviderWebhookHealthRepository
+type ProviderWebhookHealthRepository struct {
+	workspaceRepo domain.WorkspaceRepository
+}
+
+// NewProviderWebhookHealthRepository creates a new provider webhook health repository
+func NewProviderWebhookHealthRepository(workspaceRepo domain.WorkspaceRepository) *ProviderWebhookHealthRepository {
+	return &ProviderWebhookHealthRepository{
+		workspaceRepo: workspaceRepo,
+	}
+}
+
+// RecordSent records an email sent through the integration. The period without events starts
+// with the first send after the last event, so later sends keep pending_since unchanged.
+func (r *ProviderWebhookHealthRepository) RecordSent(ctx context.Context, workspaceID, integrationID string, sentAt time.Time) error {
+	// Get the workspace database connection
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	query := `
+		INSERT INTO provider_webhook_health (integration_id, last_sent_at, pending_since, updated_at)
+		VALUES ($1, $2, $2, $2)
+		ON CONFLICT (integration_id) DO UPDATE SET
+			last_sent_at = GREATEST(provider_webhook_health.last_sent_at, EXCLUDED.last_sent_at),
+			pending_since = COALESCE(provider_webhook_health.pending_since, EXCLUDED.pending_since),
+			updated_at = EXCLUDED.updated_at
+	`
+
+	if _, err := workspaceDB.ExecContext(ctx, query, integrationID, sentAt.UTC()); err != nil {
+		return fmt.Errorf("failed to record provider send: %w", err)
+	}
+
+	return nil
+}
+
+// RecordEvent records a webhook event received for the integration and ends the period without events
+func (r *ProviderWebhookHealthRepository) RecordEvent(ctx context.Context, workspaceID, integrationID string, receivedAt time.Time) error {
+	// Get the workspace database connection
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	query := `
+		INSERT INTO provider_webhook_health (integration_id, last_event_at, updated_at)
+		VALUES ($1, $2, $2)
+		ON CONFLICT (integration_id) DO UPDATE SET
+			last_event_at = GREATEST(provider_webhook_health.last_event_at, EXCLUDED.last_event_at),
+			pending_since = NULL,
+			updated_at = EXCLUDED.updated_at
+	`
+
+	if _, err := workspaceDB.ExecContext(ctx, query, integrationID, receivedAt.UTC()); err != nil {
+		return fmt.Errorf("failed to record provider webhook event: %w", err)
+	}
+
+	return nil
+}
+
+// List retrieves the webhook health of the integrations of a workspace
+func (r *ProviderWebhookHealthRepository) List(ctx context.Context, workspaceID string) ([]*domain.ProviderWebhookHealth, error) {
+	// Get the workspace database connection
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	query := `
+		SELECT integration_id, last_sent_at, last_event_at, pending_since, alerted_at, updated_at
+		FROM provider_webhook_health
+		ORDER BY integration_id
+	`
+
+	rows, err := workspaceDB.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list provider webhook health: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	healths := []*domain.ProviderWebhookHealth{}
+	for rows.Next() {
+		health := &domain.ProviderWebhookHealth{}
+		if err := rows.Scan(
+			&health.IntegrationID,
+			&health.LastSentAt,
+			&health.LastEventAt,
+			&health.PendingSince,
+			&health.AlertedAt,
+			&health.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan provider webhook health: %w", err)
+		}
+		healths = append(healths, health)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate provider webhook health: %w", err)
+	}
+
+	return healths, nil
+}
+
+// MarkAlerted records that an alert was raised for the current period without events
+func (r *ProviderWebhookHealthRepository) MarkAlerted(ctx context.Context, workspaceID, integrationID string, alertedAt time.Time) error {
+	// Get the workspace database connection
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	query := `UPDATE provider_webhook_health SET alerted_at = $2 WHERE integration_id = $1`
+
+	if _, err := workspaceDB.ExecContext(ctx, query, integrationID, alertedAt.UTC()); err != nil {
+		return fmt.Errorf("failed to mark provider webhook alert: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Notifuse/notifuse/internal/domain/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProviderWebhookHealthRepository_RecordSent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	repo := NewProviderWebhookHealthRepository(workspaceRepo)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("keeps the start of the period without events", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		workspaceRepo.EXPECT().GetConnection(ctx, "ws1").Return(db, nil)
+		mock.ExpectExec(`INSERT INTO provider_webhook_health .* pending_since = COALESCE\(provider_webhook_health.pending_since, EXCLUDED.pending_since\)`).
+			WithArgs("integration-1", now).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		require.NoError(t, repo.RecordSent(ctx, "ws1", "integration-1", now))
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("connection error", func(t *testing.T) {
+		workspaceRepo.EXPECT().GetConnection(ctx, "ws1").Return(nil, errors.New("connection error"))
+
+		err := repo.RecordSent(ctx, "ws1", "integration-1", now)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to get workspace connection")
+	})
+}
+
+func TestProviderWebhookHealthRepository_RecordEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	repo := NewProviderWebhookHealthRepository(workspaceRepo)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	workspaceRepo.EXPECT().GetConnection(ctx, "ws1").Return(db, nil)
+	mock.ExpectExec(`INSERT INTO provider_webhook_health .* pending_since = NULL`).
+		WithArgs("integration-1", now).
+		WillReturnError(errors.New("db error"))
+
+	err = repo.RecordEvent(ctx, "ws1", "integration-1", now)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to record provider webhook event")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProviderWebhookHealthRepository_List(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	repo := NewProviderWebhookHealthRepository(workspaceRepo)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	workspaceRepo.EXPECT().GetConnection(ctx, "ws1").Return(db, nil)
+	mock.ExpectQuery(`SELECT integration_id, last_sent_at, last_event_at, pending_since, alerted_at, updated_at FROM provider_webhook_health`).
+		WillReturnRows(sqlmock.NewRows([]string{"integration_id", "last_sent_at", "last_event_at", "pending_since", "alerted_at", "updated_at"}).
+			AddRow("integration-1", now, now.Add(-time.Hour), now.Add(-30*time.Minute), nil, now).
+			AddRow("integration-2", nil, now, nil, nil, now))
+
+	healths, err := repo.List(ctx, "ws1")
+	require.NoError(t, err)
+	require.Len(t, healths, 2)
+
+	assert.Equal(t, "integration-1", healths[0].IntegrationID)
+	require.NotNil(t, healths[0].PendingSince)
+	assert.Equal(t, now.Add(-30*time.Minute), *healths[0].PendingSince)
+	assert.Nil(t, healths[0].AlertedAt)
+
+	assert.Equal(t, "integration-2", healths[1].IntegrationID)
+	assert.Nil(t, healths[1].LastSentAt)
+	require.NotNil(t, healths[1].LastEventAt)
+	assert.Equal(t, now, *healths[1].LastEventAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProviderWebhookHealthRepository_MarkAlerted(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	repo := NewProviderWebhookHealthRepository(workspaceRepo)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	workspaceRepo.EXPECT().GetConnection(ctx, "ws1").Return(db, nil)
+	mock.ExpectExec(`UPDATE provider_webhook_health SET alerted_at = \$2 WHERE integration_id = \$1`).
+		WithArgs("integration-1", now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	require.NoError(t, repo.MarkAlerted(ctx, "ws1", "integration-1", now))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/lib/pq"
 )
 
 // segmentRepository implements domain.SegmentRepository for PostgreSQL
@@ -290,11 +291,21 @@ func (r *segmentRepository) DeleteSegment(ctx context.Context, workspaceID strin
 		return fmt.Errorf("failed to delete contact_segments for segment: %w", err)
 	}
 
+	// Delete the membership evaluations of this segment
+	_, err = workspaceDB.ExecContext(ctx, `DELETE FROM contact_segment_evaluations WHERE segment_id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete contact_segment_evaluations for segment: %w", err)
+	}
+
 	return nil
 }
 
-// AddContactToSegment adds a contact to a segment
-func (r *segmentRepository) AddContactToSegment(ctx context.Context, workspaceID string, email string, segmentID string, version int64) error {
+// AddContactToSegment adds a contact to a segment.
+// Recompute and delta updates can evaluate the same contact concurrently: each evaluation is
+// recorded in contact_segment_evaluations and only applied when it is the newest for the
+// segment and contact (by version, then evaluation time). A stale evaluation is a no-op, so
+// the membership triggers emit a single segment.joined event per transition.
+func (r *segmentRepository) AddContactToSegment(ctx context.Context, workspaceID string, email string, segmentID string, version int64, evaluatedAt time.Time) error {
 	// Get the workspace database connection
 	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
 	if err != nil {
@@ -302,14 +313,21 @@ func (r *segmentRepository) AddContactToSegment(ctx context.Context, workspaceID
 	}
 
 	query := `
+		WITH evaluation AS (
+			INSERT INTO contact_segment_evaluations (segment_id, email, version, is_member, evaluated_at)
+			VALUES ($1, $2, $3, TRUE, $4)
+			ON CONFLICT (segment_id, email) DO UPDATE
+			SET version = EXCLUDED.version, is_member = EXCLUDED.is_member, evaluated_at = EXCLUDED.evaluated_at
+			WHERE (EXCLUDED.version, EXCLUDED.evaluated_at) >= (contact_segment_evaluations.version, contact_segment_evaluations.evaluated_at)
+			RETURNING segment_id
+		)
 		INSERT INTO contact_segments (email, segment_id, version, matched_at, computed_at)
-		VALUES ($1, $2, $3, $4, $5)
+		SELECT $2, $1, $3, $4, $4 FROM evaluation
 		ON CONFLICT (email, segment_id)
-		DO UPDATE SET version = $3, computed_at = $5
+		DO UPDATE SET version = GREATEST(contact_segments.version, EXCLUDED.version), computed_at = EXCLUDED.computed_at
 	`
 
-	now := time.Now().UTC()
-	_, err = workspaceDB.ExecContext(ctx, query, email, segmentID, version, now, now)
+	_, err = workspaceDB.ExecContext(ctx, query, segmentID, email, version, evaluatedAt.UTC())
 	if err != nil {
 		return fmt.Errorf("failed to add contact to segment: %w", err)
 	}
@@ -317,17 +335,33 @@ func (r *segmentRepository) AddContactToSegment(ctx context.Context, workspaceID
 	return nil
 }
 
-// RemoveContactFromSegment removes a contact from a segment
-func (r *segmentRepository) RemoveContactFromSegment(ctx context.Context, workspaceID string, email string, segmentID string) error {
+// RemoveContactFromSegment removes a contact from a segment, unless a newer evaluation
+// already decided the contact is a member (see AddContactToSegment)
+func (r *segmentRepository) RemoveContactFromSegment(ctx context.Context, workspaceID string, email string, segmentID string, version int64, evaluatedAt time.Time) error {
 	// Get the workspace database connection
 	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
 	if err != nil {
 		return fmt.Errorf("failed to get workspace connection: %w", err)
 	}
 
-	query := `DELETE FROM contact_segments WHERE email = $1 AND segment_id = $2`
+	// Evaluations are only recorded for contacts that are or were members,
+	// to avoid storing a row for every contact and segment pair
+	query := `
+		WITH evaluation AS (
+			INSERT INTO contact_segment_evaluations (segment_id, email, version, is_member, evaluated_at)
+			SELECT $1, $2, $3, FALSE, $4
+			WHERE EXISTS (SELECT 1 FROM contact_segments WHERE email = $2 AND segment_id = $1)
+				OR EXISTS (SELECT 1 FROM contact_segment_evaluations WHERE segment_id = $1 AND email = $2)
+			ON CONFLICT (segment_id, email) DO UPDATE
+			SET version = EXCLUDED.version, is_member = EXCLUDED.is_member, evaluated_at = EXCLUDED.evaluated_at
+			WHERE (EXCLUDED.version, EXCLUDED.evaluated_at) >= (contact_segment_evaluations.version, contact_segment_evaluations.evaluated_at)
+			RETURNING segment_id
+		)
+		DELETE FROM contact_segments
+		WHERE email = $2 AND segment_id = $1 AND EXISTS (SELECT 1 FROM evaluation)
+	`
 
-	_, err = workspaceDB.ExecContext(ctx, query, email, segmentID)
+	_, err = workspaceDB.ExecContext(ctx, query, segmentID, email, version, evaluatedAt.UTC())
 	if err != nil {
 		return fmt.Errorf("failed to remove contact from segment: %w", err)
 	}
@@ -353,6 +387,67 @@ func (r *segmentRepository) RemoveOldMemberships(ctx context.Context, workspaceI
 	return nil
 }
 
+// GetSegmentMembers returns which of the given emails are members of a segment
+func (r *segmentRepository) GetSegmentMembers(ctx context.Context, workspaceID string, segmentID string, emails []string) ([]string, error) {
+	// Get the workspace database connection
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	query := `SELECT email FROM contact_segments WHERE segment_id = $1 AND email = ANY($2)`
+
+	rows, err := workspaceDB.QueryContext(ctx, query, segmentID, pq.Array(emails))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get segment members: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	members := make([]string, 0, len(emails))
+	for rows.Next() {
+		var email string
+		if err := rows.Scan(&email); err != nil {
+			return nil, fmt.Errorf("failed to scan segment member: %w", err)
+		}
+		members = append(members, email)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating segment members: %w", err)
+	}
+
+	return members, nil
+}
+
+// TouchSegmentMemberships updates the version and computed_at of the memberships a recompute
+// confirmed. Updating the rows in place keeps the membership triggers, which fire on insert
+// and delete only, from emitting segment events for contacts whose membership did not change.
+func (r *segmentRepository) TouchSegmentMemberships(ctx context.Context, workspaceID string, segmentID string, emails []string, version int64, computedAt time.Time) error {
+	if len(emails) == 0 {
+		return nil
+	}
+
+	// Get the workspace database connection
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	// A concurrent delta update may already have recorded a newer evaluation
+	query := `
+		UPDATE contact_segments
+		SET version = GREATEST(version, $3), computed_at = GREATEST(computed_at, $4)
+		WHERE segment_id = $1 AND email = ANY($2)
+	`
+
+	_, err = workspaceDB.ExecContext(ctx, query, segmentID, pq.Array(emails), version, computedAt.UTC())
+	if err != nil {
+		return fmt.Errorf("failed to touch segment memberships: %w", err)
+	}
+
+	return nil
+}
+
 // GetContactSegments retrieves all segments a contact belongs to
 func (r *segmentRepository) GetContactSegments(ctx context.Context, workspaceID string, email string) ([]*domain.Segment, error) {
 	// Get the workspace database connection
@@ -466,6 +466,10 @@ func TestSegmentRepository_DeleteSegment(t *testing.T) {
 			WithArgs("seg123").
 			WillReturnResult(sqlmock.NewResult(0, 1))
 
+		sqlMock.ExpectExec(regexp.QuoteMeta(`DELETE FROM contact_segment_evaluations WHERE segment_id = $1`)).
+			WithArgs("seg123").
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
 		err := repo.DeleteSegment(context.Background(), "workspace123", "seg123")
 		require.NoError(t, err)
 	})
@@ -524,20 +528,20 @@ func TestSegmentRepository_AddContactToSegment(t *testing.T) {
 		AnyTimes()
 
 	t.Run("successful addition", func(t *testing.T) {
-		sqlMock.ExpectExec(regexp.QuoteMeta(`
-		INSERT INTO contact_segments (email, segment_id, version, matched_at, computed_at)
-		VALUES ($1, $2, $3, $4, $5)
-		ON CONFLICT (email, segment_id)
-		DO UPDATE SET version = $3, computed_at = $5
-	`)).WithArgs(
-			"test@example.com",
-			"seg123",
-			int64(1),
-			sqlmock.AnyArg(), // matched_at
-			sqlmock.AnyArg(), // computed_at
-		).WillReturnResult(sqlmock.NewResult(1, 1))
+		evaluatedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+		sqlMock.ExpectExec(`(?s)WITH evaluation AS \(\s*INSERT INTO contact_segment_evaluations .*RETURNING segment_id\s*\)\s*INSERT INTO contact_segments .*FROM evaluation`).
+			WithArgs("seg123", "test@example.com", int64(1), evaluatedAt).
+			WillReturnResult(sqlmock.NewResult(1, 1))
+
+		err := repo.AddContactToSegment(context.Background(), "workspace123", "test@example.com", "seg123", 1, evaluatedAt)
+		require.NoError(t, err)
+	})
+
+	t.Run("stale evaluation is guarded by version and evaluation time", func(t *testing.T) {
+		sqlMock.ExpectExec(regexp.QuoteMeta(`WHERE (EXCLUDED.version, EXCLUDED.evaluated_at) >= (contact_segment_evaluations.version, contact_segment_evaluations.evaluated_at)`)).
+			WillReturnResult(sqlmock.NewResult(0, 0))
 
-		err := repo.AddContactToSegment(context.Background(), "workspace123", "test@example.com", "seg123", 1)
+		err := repo.AddContactToSegment(context.Background(), "workspace123", "test@example.com", "seg123", 1, time.Now())
 		require.NoError(t, err)
 	})
 
@@ -546,7 +550,7 @@ func TestSegmentRepository_AddContactToSegment(t *testing.T) {
 		INSERT INTO contact_segments
 	`)).WillReturnError(errors.New("database error"))
 
-		err := repo.AddContactToSegment(context.Background(), "workspace123", "test@example.com", "seg123", 1)
+		err := repo.AddContactToSegment(context.Background(), "workspace123", "test@example.com", "seg123", 1, time.Now())
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to add contact to segment")
 	})
@@ -566,11 +570,12 @@ func TestSegmentRepository_RemoveContactFromSegment(t *testing.T) {
 		AnyTimes()
 
 	t.Run("successful removal", func(t *testing.T) {
-		sqlMock.ExpectExec(regexp.QuoteMeta(`DELETE FROM contact_segments WHERE email = $1 AND segment_id = $2`)).
-			WithArgs("test@example.com", "seg123").
+		evaluatedAt := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+		sqlMock.ExpectExec(`(?s)WITH evaluation AS \(\s*INSERT INTO contact_segment_evaluations .*FALSE.*RETURNING segment_id\s*\)\s*DELETE FROM contact_segments WHERE email = \$2 AND segment_id = \$1 AND EXISTS \(SELECT 1 FROM evaluation\)`).
+			WithArgs("seg123", "test@example.com", int64(2), evaluatedAt).
 			WillReturnResult(sqlmock.NewResult(0, 1))
 
-		err := repo.RemoveContactFromSegment(context.Background(), "workspace123", "test@example.com", "seg123")
+		err := repo.RemoveContactFromSegment(context.Background(), "workspace123", "test@example.com", "seg123", 2, evaluatedAt)
 		require.NoError(t, err)
 	})
 
@@ -578,7 +583,7 @@ func TestSegmentRepository_RemoveContactFromSegment(t *testing.T) {
 		sqlMock.ExpectExec(regexp.QuoteMeta(`DELETE FROM contact_segments`)).
 			WillReturnError(errors.New("database error"))
 
-		err := repo.RemoveContactFromSegment(context.Background(), "workspace123", "test@example.com", "seg123")
+		err := repo.RemoveContactFromSegment(context.Background(), "workspace123", "test@example.com", "seg123", 2, time.Now())
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to remove contact from segment")
 	})
@@ -616,6 +621,82 @@ func TestSegmentRepository_RemoveOldMemberships(t *testing.T) {
 	})
 }
 
+func TestSegmentRepository_GetSegmentMembers(t *testing.T) {
+	repo, _, mockWorkspaceRepo := setupSegmentRepositoryTest(t)
+
+	// Setup workspace connection mock
+	db, sqlMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	mockWorkspaceRepo.EXPECT().
+		GetConnection(gomock.Any(), "workspace123").
+		Return(db, nil).
+		AnyTimes()
+
+	query := regexp.QuoteMeta(`SELECT email FROM contact_segments WHERE segment_id = $1 AND email = ANY($2)`)
+
+	t.Run("members found", func(t *testing.T) {
+		sqlMock.ExpectQuery(query).
+			WithArgs("seg123", sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"email"}).AddRow("a@example.com"))
+
+		members, err := repo.GetSegmentMembers(context.Background(), "workspace123", "seg123", []string{"a@example.com", "b@example.com"})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a@example.com"}, members)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		sqlMock.ExpectQuery(query).
+			WillReturnError(errors.New("database error"))
+
+		_, err := repo.GetSegmentMembers(context.Background(), "workspace123", "seg123", []string{"a@example.com"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to get segment members")
+	})
+}
+
+func TestSegmentRepository_TouchSegmentMemberships(t *testing.T) {
+	repo, _, mockWorkspaceRepo := setupSegmentRepositoryTest(t)
+
+	// Setup workspace connection mock
+	db, sqlMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+
+	mockWorkspaceRepo.EXPECT().
+		GetConnection(gomock.Any(), "workspace123").
+		Return(db, nil).
+		AnyTimes()
+
+	computedAt := time.Date(2026, 3, 1, 5, 0, 0, 0, time.UTC)
+
+	t.Run("successful update", func(t *testing.T) {
+		sqlMock.ExpectExec(`UPDATE contact_segments\s+SET version = GREATEST\(version, \$3\), computed_at = GREATEST\(computed_at, \$4\)\s+WHERE segment_id = \$1 AND email = ANY\(\$2\)`).
+			WithArgs("seg123", sqlmock.AnyArg(), int64(3), computedAt).
+			WillReturnResult(sqlmock.NewResult(0, 2))
+
+		err := repo.TouchSegmentMemberships(context.Background(), "workspace123", "seg123", []string{"a@example.com", "b@example.com"}, 3, computedAt)
+		require.NoError(t, err)
+	})
+
+	t.Run("no emails", func(t *testing.T) {
+		err := repo.TouchSegmentMemberships(context.Background(), "workspace123", "seg123", nil, 3, computedAt)
+		require.NoError(t, err)
+	})
+
+	t.Run("database error", func(t *testing.T) {
+		sqlMock.ExpectExec(`UPDATE contact_segments`).
+			WillReturnError(errors.New("database error"))
+
+		err := repo.TouchSegmentMemberships(context.Background(), "workspace123", "seg123", []string{"a@example.com"}, 3, computedAt)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to touch segment memberships")
+	})
+
+	require.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
 func TestSegmentRepository_GetContactSegments(t *testing.T) {
 	repo, _, mockWorkspaceRepo := setupSegmentRepositoryTest(t)
 
@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+)
+
+// ShortLinkRepository implements domain.ShortLinkRepository
+type ShortLinkRepository struct {
+	workspaceRepo domain.WorkspaceRepository
+}
+
+// NewShortLinkRepository creates a new short link repository
+func NewShortLinkRepository(workspaceRepo domain.WorkspaceRepository) *ShortLinkRepository {
+	return &ShortLinkRepository{
+		workspaceRepo: workspaceRepo,
+	}
+}
+
+// Create stores a short link, returning false when the code is already taken
+func (r *ShortLinkRepository) Create(ctx context.Context, workspaceID string, link *domain.ShortLink) (bool, error) {
+	// Get the workspace database connection
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	query := `
+		INSERT INTO short_links (code, message_id, url, sent_at, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (code) DO NOTHING
+	`
+
+	result, err := workspaceDB.ExecContext(
+		ctx,
+		query,
+		link.Code,
+		link.MessageID,
+		link.URL,
+		link.SentAt,
+		link.CreatedAt,
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to create short link: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// GetByCode retrieves a short link by code
+func (r *ShortLinkRepository) GetByCode(ctx context.Context, workspaceID string, code string) (*domain.ShortLink, error) {
+	// Get the workspace database connection
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	query := `
+		SELECT code, message_id, url, sent_at, created_at
+		FROM short_links
+		WHERE code = $1
+	`
+
+	link := &domain.ShortLink{}
+	err = workspaceDB.QueryRowContext(ctx, query, code).Scan(
+		&link.Code,
+		&link.MessageID,
+		&link.URL,
+		&link.SentAt,
+		&link.CreatedAt,
+	)
+
+	if err == sql.ErrNoRows {
+		return nil, domain.ErrShortLinkNotFound
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to get short link: %w", err)
+	}
+
+	return link, nil
+}
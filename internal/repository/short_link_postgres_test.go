@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/domain/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShortLinkRepository_Create(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	repo := NewShortLinkRepository(workspaceRepo)
+
+	now := time.Now().UTC()
+	link := &domain.ShortLink{
+		Code:      "abc12345",
+		MessageID: "msg1",
+		URL:       "https://example.com?utm_source=newsletter",
+		SentAt:    now,
+		CreatedAt: now,
+	}
+
+	t.Run("created", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		workspaceRepo.EXPECT().GetConnection(ctx, "ws1").Return(db, nil)
+		mock.ExpectExec(`INSERT INTO short_links \(code, message_id, url, sent_at, created_at\)`).
+			WithArgs("abc12345", "msg1", link.URL, now, now).
+			WillReturnResult(sqlmock.NewResult(0, 1))
+
+		created, err := repo.Create(ctx, "ws1", link)
+		require.NoError(t, err)
+		assert.True(t, created)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("code already taken", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		workspaceRepo.EXPECT().GetConnection(ctx, "ws1").Return(db, nil)
+		mock.ExpectExec(`INSERT INTO short_links`).
+			WillReturnResult(sqlmock.NewResult(0, 0))
+
+		created, err := repo.Create(ctx, "ws1", link)
+		require.NoError(t, err)
+		assert.False(t, created)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("connection error", func(t *testing.T) {
+		workspaceRepo.EXPECT().GetConnection(ctx, "ws1").Return(nil, errors.New("connection failed"))
+
+		_, err := repo.Create(ctx, "ws1", link)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to get workspace connection")
+	})
+}
+
+func TestShortLinkRepository_GetByCode(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	repo := NewShortLinkRepository(workspaceRepo)
+
+	t.Run("found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		now := time.Now().UTC()
+		workspaceRepo.EXPECT().GetConnection(ctx, "ws1").Return(db, nil)
+		mock.ExpectQuery(`SELECT code, message_id, url, sent_at, created_at FROM short_links WHERE code = \$1`).
+			WithArgs("abc12345").
+			WillReturnRows(sqlmock.NewRows([]string{"code", "message_id", "url", "sent_at", "created_at"}).
+				AddRow("abc12345", "msg1", "https://example.com", now, now))
+
+		link, err := repo.GetByCode(ctx, "ws1", "abc12345")
+		require.NoError(t, err)
+		assert.Equal(t, "msg1", link.MessageID)
+		assert.Equal(t, "https://example.com", link.URL)
+		assert.Equal(t, now, link.SentAt)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		db, mock, err := sqlmock.New()
+		require.NoError(t, err)
+		defer func() { _ = db.Close() }()
+
+		workspaceRepo.EXPECT().GetConnection(ctx, "ws1").Return(db, nil)
+		mock.ExpectQuery(`SELECT code, message_id, url, sent_at, created_at FROM short_links`).
+			WithArgs("missing1").
+			WillReturnError(sql.ErrNoRows)
+
+		_, err = repo.GetByCode(ctx, "ws1", "missing1")
+		assert.ErrorIs(t, err, domain.ErrShortLinkNotFound)
+		assert.NoError(t, mock.ExpectationsWereMet())
+	})
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/pkg/notifuse_mjml"
 )
 
 type telemetryRepository struct {
@@ -81,6 +82,19 @@ func (r *telemetryRepository) GetWorkspaceMetrics(ctx context.Context, workspace
 		metrics.LastMessageAt = lastMessageAt
 	}
 
+	// Get send performance over the last 24 hours
+	if sendMetrics, err := r.GetSendMetrics(ctx, db); err == nil {
+		metrics.MessagesSentLast24h = sendMetrics.MessagesSent
+		metrics.AvgSendLatencyMs = sendMetrics.AvgLatencyMs
+		metrics.BounceRate = sendMetrics.BounceRate()
+		metrics.ComplaintRate = sendMetrics.ComplaintRate()
+	}
+
+	// Count template blocks by component type
+	if blockUsage, err := r.CountTemplateBlocks(ctx, db); err == nil {
+		metrics.BlockUsage = blockUsage
+	}
+
 	return metrics, nil
 }
 
@@ -197,6 +211,65 @@ func (r *telemetryRepository) GetLastMessageAt(ctx context.Context, db *sql.DB)
 	return lastMessageAt.Time.Format(time.RFC3339), nil
 }
 
+// GetSendMetrics aggregates the send performance of the workspace over the last 24 hours.
+// Dry runs and failed sends are not counted, and the latency only covers messages whose delivery was reported.
+func (r *telemetryRepository) GetSendMetrics(ctx context.Context, db *sql.DB) (*domain.TelemetrySendMetrics, error) {
+	query := `SELECT COUNT(*),
+			  COALESCE(AVG(EXTRACT(EPOCH FROM (delivered_at - sent_at)) * 1000) FILTER (WHERE delivered_at >= sent_at), 0),
+			  COUNT(*) FILTER (WHERE bounced_at IS NOT NULL),
+			  COUNT(*) FILTER (WHERE complained_at IS NOT NULL)
+			  FROM message_history
+			  WHERE sent_at >= NOW() - INTERVAL '24 hours'
+			  AND failed_at IS NULL
+			  AND status_info IS DISTINCT FROM 'dry_run'`
+
+	metrics := &domain.TelemetrySendMetrics{}
+	err := db.QueryRowContext(ctx, query).Scan(&metrics.MessagesSent, &metrics.AvgLatencyMs, &metrics.BouncedCount, &metrics.ComplainedCount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get send metrics: %w", err)
+	}
+	return metrics, nil
+}
+
+// CountTemplateBlocks counts the blocks of each component type across the latest version of the visual editor
+// templates of a workspace. Only the component types are kept, the content of the templates is never returned.
+func (r *telemetryRepository) CountTemplateBlocks(ctx context.Context, db *sql.DB) (map[string]int, error) {
+	query := `WITH latest_versions AS (
+				SELECT id, MAX(version) AS max_version
+				FROM templates
+				GROUP BY id
+			  )
+			  SELECT t.email->'visual_editor_tree' FROM templates t
+			  JOIN latest_versions lv ON t.id = lv.id AND t.version = lv.max_version
+			  WHERE t.deleted_at IS NULL
+			  AND t.email->'visual_editor_tree' IS NOT NULL
+			  AND jsonb_typeof(t.email->'visual_editor_tree') = 'object'`
+
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count template blocks: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	counts := map[string]int{}
+	for rows.Next() {
+		var treeJSON []byte
+		if err := rows.Scan(&treeJSON); err != nil {
+			return nil, fmt.Errorf("failed to scan template blocks: %w", err)
+		}
+		// A malformed tree is skipped rather than failing the counts of the other templates
+		tree, err := notifuse_mjml.UnmarshalEmailBlock(treeJSON)
+		if err != nil {
+			continue
+		}
+		notifuse_mjml.CountBlockTypes(tree, counts)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to count template blocks: %w", err)
+	}
+	return counts, nil
+}
+
 // getSystemConnection is a helper method to get the system database connection
 func (r *telemetryRepository) getSystemConnection(ctx context.Context) (*sql.DB, error) {
 	return r.workspaceRepo.GetSystemConnection(ctx)
@@ -82,6 +82,13 @@ func TestGetWorkspaceMetrics_Success(t *testing.T) {
 	workspaceMock.ExpectQuery(`SELECT created_at FROM message_history\s+WHERE created_at IS NOT NULL\s+ORDER BY created_at DESC, id DESC\s+LIMIT 1`).
 		WillReturnRows(sqlmock.NewRows([]string{"created_at"}).AddRow(lastMessageTime))
 
+	workspaceMock.ExpectQuery(sendMetricsQuery).
+		WillReturnRows(sqlmock.NewRows([]string{"count", "avg", "bounced", "complained"}).AddRow(400, 1250.5, 8, 1))
+
+	workspaceMock.ExpectQuery(templateBlocksQuery).
+		WillReturnRows(sqlmock.NewRows([]string{"visual_editor_tree"}).
+			AddRow([]byte(`{"id":"root","type":"mjml","children":[{"id":"body","type":"mj-body"}]}`)))
+
 	ctx := context.Background()
 	metrics, err := repo.GetWorkspaceMetrics(ctx, "workspace123")
 
@@ -95,6 +102,11 @@ func TestGetWorkspaceMetrics_Success(t *testing.T) {
 	assert.Equal(t, 8, metrics.SegmentsCount)
 	assert.Equal(t, 3, metrics.UsersCount)
 	assert.Equal(t, lastMessageTime.Format(time.RFC3339), metrics.LastMessageAt)
+	assert.Equal(t, 400, metrics.MessagesSentLast24h)
+	assert.Equal(t, 1250.5, metrics.AvgSendLatencyMs)
+	assert.Equal(t, 0.02, metrics.BounceRate)
+	assert.Equal(t, 0.0025, metrics.ComplaintRate)
+	assert.Equal(t, map[string]int{"mjml": 1, "mj-body": 1}, metrics.BlockUsage)
 
 	// Verify all expectations were met
 	require.NoError(t, workspaceMock.ExpectationsWereMet())
@@ -591,6 +603,176 @@ func TestGetLastMessageAt_DatabaseError(t *testing.T) {
 	assert.Contains(t, err.Error(), "failed to get last message timestamp")
 }
 
+// sendMetricsQuery matches the aggregation of the send performance over the last 24 hours
+const sendMetricsQuery = `SELECT COUNT\(\*\),\s+` +
+	`COALESCE\(AVG\(EXTRACT\(EPOCH FROM \(delivered_at - sent_at\)\) \* 1000\) FILTER \(WHERE delivered_at >= sent_at\), 0\),\s+` +
+	`COUNT\(\*\) FILTER \(WHERE bounced_at IS NOT NULL\),\s+` +
+	`COUNT\(\*\) FILTER \(WHERE complained_at IS NOT NULL\)\s+` +
+	`FROM message_history\s+` +
+	`WHERE sent_at >= NOW\(\) - INTERVAL '24 hours'\s+` +
+	`AND failed_at IS NULL\s+` +
+	`AND status_info IS DISTINCT FROM 'dry_run'`
+
+func TestGetSendMetrics_Success(t *testing.T) {
+	db, mock, cleanup := setupTelemetryMockDB(t)
+	defer cleanup()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	repo := NewTelemetryRepository(workspaceRepo)
+
+	mock.ExpectQuery(sendMetricsQuery).
+		WillReturnRows(sqlmock.NewRows([]string{"count", "avg", "bounced", "complained"}).AddRow(200, 830.25, 5, 2))
+
+	ctx := context.Background()
+	sendMetrics, err := repo.GetSendMetrics(ctx, db)
+
+	require.NoError(t, err)
+	assert.Equal(t, 200, sendMetrics.MessagesSent)
+	assert.Equal(t, 830.25, sendMetrics.AvgLatencyMs)
+	assert.Equal(t, 5, sendMetrics.BouncedCount)
+	assert.Equal(t, 2, sendMetrics.ComplainedCount)
+	assert.Equal(t, 0.025, sendMetrics.BounceRate())
+	assert.Equal(t, 0.01, sendMetrics.ComplaintRate())
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetSendMetrics_NoMessages(t *testing.T) {
+	db, mock, cleanup := setupTelemetryMockDB(t)
+	defer cleanup()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	repo := NewTelemetryRepository(workspaceRepo)
+
+	// Without messages the average falls back to 0 and the rates are not divided by zero
+	mock.ExpectQuery(sendMetricsQuery).
+		WillReturnRows(sqlmock.NewRows([]string{"count", "avg", "bounced", "complained"}).AddRow(0, 0, 0, 0))
+
+	ctx := context.Background()
+	sendMetrics, err := repo.GetSendMetrics(ctx, db)
+
+	require.NoError(t, err)
+	assert.Equal(t, 0, sendMetrics.MessagesSent)
+	assert.Equal(t, float64(0), sendMetrics.AvgLatencyMs)
+	assert.Equal(t, float64(0), sendMetrics.BounceRate())
+	assert.Equal(t, float64(0), sendMetrics.ComplaintRate())
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestGetSendMetrics_DatabaseError(t *testing.T) {
+	db, mock, cleanup := setupTelemetryMockDB(t)
+	defer cleanup()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	repo := NewTelemetryRepository(workspaceRepo)
+
+	mock.ExpectQuery(sendMetricsQuery).
+		WillReturnError(errors.New("database error"))
+
+	ctx := context.Background()
+	sendMetrics, err := repo.GetSendMetrics(ctx, db)
+
+	assert.Error(t, err)
+	assert.Nil(t, sendMetrics)
+	assert.Contains(t, err.Error(), "failed to get send metrics")
+}
+
+// templateBlocksQuery matches the selection of the visual editor tree of the latest version of each template
+const templateBlocksQuery = `(?s)WITH latest_versions AS \(.+\)\s+` +
+	`SELECT t.email->'visual_editor_tree' FROM templates t\s+` +
+	`JOIN latest_versions lv ON t.id = lv.id AND t.version = lv.max_version\s+` +
+	`WHERE t.deleted_at IS NULL`
+
+func TestCountTemplateBlocks_Success(t *testing.T) {
+	db, mock, cleanup := setupTelemetryMockDB(t)
+	defer cleanup()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	repo := NewTelemetryRepository(workspaceRepo)
+
+	newsletter := `{"id":"root","type":"mjml","children":[{"id":"body","type":"mj-body","children":[
+		{"id":"s","type":"mj-section","children":[{"id":"c","type":"mj-column","children":[
+			{"id":"t","type":"mj-text","content":"Hi {{ contact.email }}"},
+			{"id":"b","type":"mj-button","content":"Read more"}]}]}]}]}`
+	promo := `{"id":"root","type":"mjml","children":[{"id":"body","type":"mj-body","children":[
+		{"id":"g","type":"product-grid","attributes":{"items":"products"}}]}]}`
+
+	mock.ExpectQuery(templateBlocksQuery).
+		WillReturnRows(sqlmock.NewRows([]string{"visual_editor_tree"}).
+			AddRow([]byte(newsletter)).
+			AddRow([]byte(promo)).
+			AddRow([]byte(`not a tree`)))
+
+	ctx := context.Background()
+	counts, err := repo.CountTemplateBlocks(ctx, db)
+
+	// The malformed tree is skipped and only the component types are returned
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{
+		"mjml":         2,
+		"mj-body":      2,
+		"mj-section":   1,
+		"mj-column":    1,
+		"mj-text":      1,
+		"mj-button":    1,
+		"product-grid": 1,
+	}, counts)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCountTemplateBlocks_NoTemplates(t *testing.T) {
+	db, mock, cleanup := setupTelemetryMockDB(t)
+	defer cleanup()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	repo := NewTelemetryRepository(workspaceRepo)
+
+	mock.ExpectQuery(templateBlocksQuery).
+		WillReturnRows(sqlmock.NewRows([]string{"visual_editor_tree"}))
+
+	ctx := context.Background()
+	counts, err := repo.CountTemplateBlocks(ctx, db)
+
+	require.NoError(t, err)
+	assert.Empty(t, counts)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCountTemplateBlocks_DatabaseError(t *testing.T) {
+	db, mock, cleanup := setupTelemetryMockDB(t)
+	defer cleanup()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	repo := NewTelemetryRepository(workspaceRepo)
+
+	mock.ExpectQuery(templateBlocksQuery).
+		WillReturnError(errors.New("database error"))
+
+	ctx := context.Background()
+	counts, err := repo.CountTemplateBlocks(ctx, db)
+
+	assert.Error(t, err)
+	assert.Nil(t, counts)
+	assert.Contains(t, err.Error(), "failed to count template blocks")
+}
+
 func TestGetSystemConnection(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
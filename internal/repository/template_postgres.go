@@ -45,6 +45,7 @@ func (r *templateRepository) CreateTemplate(ctx context.Context, workspaceID str
 			channel, 
 			email,
 			web, 
+			sms,
 			category, 
 			template_macro_id, 
 			integration_id,
@@ -53,7 +54,7 @@ func (r *templateRepository) CreateTemplate(ctx context.Context, workspaceID str
 			created_at, 
 			updated_at
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
 	`
 	_, err = workspaceDB.ExecContext(ctx, query,
 		template.ID,
@@ -62,6 +63,7 @@ func (r *templateRepository) CreateTemplate(ctx context.Context, workspaceID str
 		template.Channel,
 		template.Email,
 		template.Web,
+		template.SMS,
 		template.Category,
 		template.TemplateMacroID,
 		template.IntegrationID,
@@ -97,6 +99,7 @@ func (r *templateRepository) GetTemplateByID(ctx context.Context, workspaceID st
 				channel, 
 				email,
 				web, 
+				sms,
 				category, 
 				template_macro_id, 
 				integration_id,
@@ -118,6 +121,7 @@ func (r *templateRepository) GetTemplateByID(ctx context.Context, workspaceID st
 				channel, 
 				email,
 				web, 
+				sms,
 				category, 
 				template_macro_id, 
 				integration_id,
@@ -196,6 +200,7 @@ func (r *templateRepository) GetTemplates(ctx context.Context, workspaceID strin
 		"t.channel",
 		"t.email",
 		"t.web",
+		"t.sms",
 		"t.category",
 		"t.template_macro_id",
 		"t.integration_id",
@@ -270,6 +275,7 @@ func (r *templateRepository) UpdateTemplate(ctx context.Context, workspaceID str
 			channel, 
 			email,
 			web, 
+			sms,
 			category, 
 			template_macro_id, 
 			integration_id,
@@ -278,7 +284,7 @@ func (r *templateRepository) UpdateTemplate(ctx context.Context, workspaceID str
 			created_at, 
 			updated_at
 		)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
 	`
 	_, err = workspaceDB.ExecContext(ctx, query,
 		template.ID,
@@ -287,6 +293,7 @@ func (r *templateRepository) UpdateTemplate(ctx context.Context, workspaceID str
 		template.Channel,
 		template.Email,
 		template.Web,
+		template.SMS,
 		template.Category,
 		template.TemplateMacroID,
 		template.IntegrationID,
@@ -346,6 +353,7 @@ func scanTemplate(scanner interface {
 		&template.Channel,
 		&template.Email,
 		&template.Web,
+		&template.SMS,
 		&template.Category,
 		&templateMacroID,
 		&integrationID,
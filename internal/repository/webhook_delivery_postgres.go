@@ -41,7 +41,7 @@ func (r *webhookDeliveryRepository) GetPendingForWorkspace(ctx context.Context,
 		WHERE status IN ('pending', 'failed')
 			AND attempts < max_attempts
 			AND next_attempt_at <= NOW()
-		ORDER BY next_attempt_at ASC
+		ORDER BY next_attempt_at ASC, created_at ASC
 		LIMIT $1
 	`
 
@@ -316,6 +316,34 @@ func (r *webhookDeliveryRepository) CleanupOldDeliveries(ctx context.Context, wo
 	return rowsAffected, nil
 }
 
+// HasPendingContactActivityBefore reports whether a contact activity of the same contact with an
+// earlier checkpoint is still waiting to be delivered to the subscription. Checkpoints sort as bytes.
+func (r *webhookDeliveryRepository) HasPendingContactActivityBefore(ctx context.Context, workspaceID, subscriptionID, email, checkpoint string) (bool, error) {
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM webhook_deliveries
+			WHERE subscription_id = $1
+				AND event_type = 'contact.activity'
+				AND status IN ('pending', 'delivering', 'failed')
+				AND attempts < max_attempts
+				AND payload->'activity'->>'email' = $2
+				AND (payload->'activity'->>'checkpoint') COLLATE "C" < $3
+		)
+	`
+
+	var exists bool
+	if err := workspaceDB.QueryRowContext(ctx, query, subscriptionID, email, checkpoint).Scan(&exists); err != nil {
+		return false, fmt.Errorf("failed to check pending contact activities: %w", err)
+	}
+
+	return exists, nil
+}
+
 // scanWebhookDeliveryFromRows scans a row from sql.Rows into a WebhookDelivery
 func scanWebhookDeliveryFromRows(rows *sql.Rows) (*WebhookDelivery, error) {
 	var delivery WebhookDelivery
@@ -215,6 +215,27 @@ func (r *webhookSubscriptionRepository) UpdateLastDeliveryAt(ctx context.Context
 	return nil
 }
 
+// UpdateContactActivityCheckpoint sets the contact activity checkpoint without touching other settings
+func (r *webhookSubscriptionRepository) UpdateContactActivityCheckpoint(ctx context.Context, workspaceID, id, checkpoint string) error {
+	workspaceDB, err := r.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get workspace connection: %w", err)
+	}
+
+	query := `
+		UPDATE webhook_subscriptions
+		SET settings = jsonb_set(COALESCE(settings, '{}'::jsonb), '{contact_activity_checkpoint}', to_jsonb($2::text))
+		WHERE id = $1
+	`
+
+	_, err = workspaceDB.ExecContext(ctx, query, id, checkpoint)
+	if err != nil {
+		return fmt.Errorf("failed to update contact activity checkpoint: %w", err)
+	}
+
+	return nil
+}
+
 // WebhookSubscription alias for domain type
 type WebhookSubscription = domain.WebhookSubscription
 
@@ -0,0 +1,204 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/pkg/logger"
+	"github.com/google/uuid"
+)
+
+// ErrInvalidAPIKey is returned when an API key is unknown or revoked
+var ErrInvalidAPIKey = errors.New("invalid API key")
+
+// apiKeyDisplayLength is the number of characters of a key kept to recognize it in the console
+const apiKeyDisplayLength = 12
+
+// APIKeyService manages the scoped API keys of workspaces and authenticates them.
+// Each key authenticates as its own API user, a workspace member whose permissions mirror the scopes,
+// so the services keep enforcing the permissions of the routes the middleware allowed.
+type APIKeyService struct {
+	repo          domain.APIKeyRepository
+	userRepo      domain.UserRepository
+	workspaceRepo domain.WorkspaceRepository
+	authService   domain.AuthService
+	logger        logger.Logger
+	apiEndpoint   string
+}
+
+// NewAPIKeyService creates a new API key service
+func NewAPIKeyService(
+	repo domain.APIKeyRepository,
+	userRepo domain.UserRepository,
+	workspaceRepo domain.WorkspaceRepository,
+	authService domain.AuthService,
+	logger logger.Logger,
+	apiEndpoint string,
+) *APIKeyService {
+	return &APIKeyService{
+		repo:          repo,
+		userRepo:      userRepo,
+		workspaceRepo: workspaceRepo,
+		authService:   authService,
+		logger:        logger,
+		apiEndpoint:   apiEndpoint,
+	}
+}
+
+// generateAPIKey returns a new random key
+func generateAPIKey() (string, error) {
+	bytes := make([]byte, 32) // 256 bits
+	if _, err := rand.Read(bytes); err != nil {
+		return "", fmt.Errorf("failed to generate random bytes: %w", err)
+	}
+	return domain.APIKeyPrefix + hex.EncodeToString(bytes), nil
+}
+
+// hashAPIKey returns the hash stored for a key, keys are random enough for a plain SHA-256
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// authenticateOwner checks the user calling is an owner of the workspace
+func (s *APIKeyService) authenticateOwner(ctx context.Context, workspaceID string) (context.Context, *domain.User, error) {
+	ctx, user, userWorkspace, err := s.authService.AuthenticateUserForWorkspace(ctx, workspaceID)
+	if err != nil {
+		return ctx, nil, fmt.Errorf("failed to authenticate user: %w", err)
+	}
+	if userWorkspace.Role != "owner" {
+		return ctx, nil, &domain.ErrUnauthorized{Message: "user is not an owner of the workspace"}
+	}
+	return ctx, user, nil
+}
+
+// Create creates a scoped API key, the key is only returned in the response
+func (s *APIKeyService) Create(ctx context.Context, req *domain.CreateScopedAPIKeyRequest) (*domain.CreateScopedAPIKeyResponse, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	ctx, owner, err := s.authenticateOwner(ctx, req.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := generateAPIKey()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	apiKey := &domain.APIKey{
+		ID:          uuid.New().String(),
+		WorkspaceID: req.WorkspaceID,
+		Name:        req.Name,
+		KeyPrefix:   key[:apiKeyDisplayLength],
+		KeyHash:     hashAPIKey(key),
+		Scopes:      domain.APIKeyScopes(req.Scopes),
+		UserID:      uuid.New().String(),
+		CreatedBy:   owner.ID,
+		CreatedAt:   now,
+	}
+
+	apiUser := &domain.User{
+		ID:    apiKey.UserID,
+		Email: "api-key-" + apiKey.ID + "@" + apiEmailDomain(s.apiEndpoint),
+		Name:  req.Name,
+		Type:  domain.UserTypeAPIKey,
+	}
+	if err := s.userRepo.CreateUser(ctx, apiUser); err != nil {
+		s.logger.WithField("workspace_id", req.WorkspaceID).WithField("error", err.Error()).Error("Failed to create API key user")
+		return nil, err
+	}
+
+	err = s.workspaceRepo.AddUserToWorkspace(ctx, &domain.UserWorkspace{
+		UserID:      apiUser.ID,
+		WorkspaceID: req.WorkspaceID,
+		Role:        "member",
+		Permissions: apiKey.Scopes.Permissions(),
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	})
+	if err != nil {
+		s.logger.WithField("workspace_id", req.WorkspaceID).WithField("user_id", apiUser.ID).WithField("error", err.Error()).Error("Failed to add API key user to workspace")
+		return nil, err
+	}
+
+	if err := s.repo.Create(ctx, apiKey); err != nil {
+		s.logger.WithField("workspace_id", req.WorkspaceID).WithField("error", err.Error()).Error("Failed to create API key")
+		return nil, err
+	}
+
+	return &domain.CreateScopedAPIKeyResponse{APIKey: apiKey, Key: key}, nil
+}
+
+// List returns the API keys of a workspace, revoked ones included
+func (s *APIKeyService) List(ctx context.Context, workspaceID string) ([]*domain.APIKey, error) {
+	if workspaceID == "" {
+		return nil, fmt.Errorf("workspace_id is required")
+	}
+
+	ctx, _, err := s.authenticateOwner(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.repo.List(ctx, workspaceID)
+}
+
+// Revoke revokes an API key and removes its API user from the workspace
+func (s *APIKeyService) Revoke(ctx context.Context, req *domain.RevokeAPIKeyRequest) error {
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	ctx, _, err := s.authenticateOwner(ctx, req.WorkspaceID)
+	if err != nil {
+		return err
+	}
+
+	apiKey, err := s.repo.GetByID(ctx, req.WorkspaceID, req.ID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.Revoke(ctx, req.WorkspaceID, req.ID, time.Now().UTC()); err != nil {
+		return err
+	}
+
+	if err := s.workspaceRepo.RemoveUserFromWorkspace(ctx, apiKey.UserID, req.WorkspaceID); err != nil {
+		s.logger.WithField("workspace_id", req.WorkspaceID).WithField("api_key_id", req.ID).WithField("error", err.Error()).Error("Failed to remove API key user from workspace")
+		return err
+	}
+
+	return nil
+}
+
+// Authenticate returns the active API key matching a key sent by a client
+func (s *APIKeyService) Authenticate(ctx context.Context, key string) (*domain.APIKey, error) {
+	if !strings.HasPrefix(key, domain.APIKeyPrefix) {
+		return nil, ErrInvalidAPIKey
+	}
+
+	apiKey, err := s.repo.GetByHash(ctx, hashAPIKey(key))
+	if err != nil {
+		var notFound *domain.ErrAPIKeyNotFound
+		if errors.As(err, &notFound) {
+			return nil, ErrInvalidAPIKey
+		}
+		return nil, err
+	}
+	if apiKey.IsRevoked() {
+		return nil, ErrInvalidAPIKey
+	}
+
+	return apiKey, nil
+}
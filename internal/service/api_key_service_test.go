@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/domain/mocks"
+	"github.com/Notifuse/notifuse/pkg/logger"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupAPIKeyServiceTest(t *testing.T) (*APIKeyService, *mocks.MockAPIKeyRepository, *mocks.MockUserRepository, *mocks.MockWorkspaceRepository, *mocks.MockAuthService) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockAPIKeyRepository(ctrl)
+	userRepo := mocks.NewMockUserRepository(ctrl)
+	workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	authService := mocks.NewMockAuthService(ctrl)
+
+	svc := NewAPIKeyService(repo, userRepo, workspaceRepo, authService, logger.NewLoggerWithLevel("disabled"), "https://api.example.com/v1")
+	return svc, repo, userRepo, workspaceRepo, authService
+}
+
+func TestAPIKeyService_Create(t *testing.T) {
+	ctx := context.Background()
+	owner := &domain.User{ID: "owner1"}
+
+	t.Run("creates a hashed key backed by an API user", func(t *testing.T) {
+		svc, repo, userRepo, workspaceRepo, authService := setupAPIKeyServiceTest(t)
+
+		authService.EXPECT().AuthenticateUserForWorkspace(ctx, "ws1").
+			Return(ctx, owner, &domain.UserWorkspace{Role: "owner"}, nil)
+
+		var apiUser *domain.User
+		userRepo.EXPECT().CreateUser(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, user *domain.User) error {
+			apiUser = user
+			return nil
+		})
+		workspaceRepo.EXPECT().AddUserToWorkspace(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, uw *domain.UserWorkspace) error {
+			assert.Equal(t, apiUser.ID, uw.UserID)
+			assert.Equal(t, "member", uw.Role)
+			assert.Equal(t, domain.UserPermissions{
+				domain.PermissionResourceTransactional: domain.ResourcePermissions{Write: true},
+			}, uw.Permissions)
+			return nil
+		})
+		var stored *domain.APIKey
+		repo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, key *domain.APIKey) error {
+			stored = key
+			return nil
+		})
+
+		response, err := svc.Create(ctx, &domain.CreateScopedAPIKeyRequest{
+			WorkspaceID: "ws1",
+			Name:        "Transactional sender",
+			Scopes:      []domain.APIKeyScope{domain.APIKeyScopeTransactionalSend},
+		})
+		require.NoError(t, err)
+
+		assert.True(t, strings.HasPrefix(response.Key, domain.APIKeyPrefix))
+		assert.Equal(t, hashAPIKey(response.Key), stored.KeyHash)
+		assert.NotContains(t, stored.KeyHash, response.Key)
+		assert.Equal(t, response.Key[:apiKeyDisplayLength], stored.KeyPrefix)
+		assert.Equal(t, "owner1", stored.CreatedBy)
+		assert.Equal(t, apiUser.ID, stored.UserID)
+		assert.Equal(t, domain.UserTypeAPIKey, apiUser.Type)
+		assert.Equal(t, "api-key-"+stored.ID+"@api.example.com", apiUser.Email)
+	})
+
+	t.Run("only owners create keys", func(t *testing.T) {
+		svc, _, _, _, authService := setupAPIKeyServiceTest(t)
+
+		authService.EXPECT().AuthenticateUserForWorkspace(ctx, "ws1").
+			Return(ctx, &domain.User{ID: "member1"}, &domain.UserWorkspace{Role: "member"}, nil)
+
+		_, err := svc.Create(ctx, &domain.CreateScopedAPIKeyRequest{
+			WorkspaceID: "ws1",
+			Name:        "Sender",
+			Scopes:      []domain.APIKeyScope{domain.APIKeyScopeTransactionalSend},
+		})
+		var unauthorized *domain.ErrUnauthorized
+		assert.ErrorAs(t, err, &unauthorized)
+	})
+
+	t.Run("invalid scope", func(t *testing.T) {
+		svc, _, _, _, _ := setupAPIKeyServiceTest(t)
+
+		_, err := svc.Create(ctx, &domain.CreateScopedAPIKeyRequest{
+			WorkspaceID: "ws1",
+			Name:        "Sender",
+			Scopes:      []domain.APIKeyScope{"contacts:delete"},
+		})
+		assert.Error(t, err)
+	})
+}
+
+func TestAPIKeyService_Revoke(t *testing.T) {
+	ctx := context.Background()
+	svc, repo, _, workspaceRepo, authService := setupAPIKeyServiceTest(t)
+
+	authService.EXPECT().AuthenticateUserForWorkspace(ctx, "ws1").
+		Return(ctx, &domain.User{ID: "owner1"}, &domain.UserWorkspace{Role: "owner"}, nil)
+	repo.EXPECT().GetByID(ctx, "ws1", "key1").Return(&domain.APIKey{ID: "key1", UserID: "api-user-1"}, nil)
+	repo.EXPECT().Revoke(ctx, "ws1", "key1", gomock.Any()).Return(nil)
+	workspaceRepo.EXPECT().RemoveUserFromWorkspace(ctx, "api-user-1", "ws1").Return(nil)
+
+	require.NoError(t, svc.Revoke(ctx, &domain.RevokeAPIKeyRequest{WorkspaceID: "ws1", ID: "key1"}))
+}
+
+func TestAPIKeyService_Authenticate(t *testing.T) {
+	ctx := context.Background()
+	key := domain.APIKeyPrefix + "0123456789abcdef"
+
+	t.Run("active key", func(t *testing.T) {
+		svc, repo, _, _, _ := setupAPIKeyServiceTest(t)
+		repo.EXPECT().GetByHash(ctx, hashAPIKey(key)).Return(&domain.APIKey{ID: "key1"}, nil)
+
+		apiKey, err := svc.Authenticate(ctx, key)
+		require.NoError(t, err)
+		assert.Equal(t, "key1", apiKey.ID)
+	})
+
+	t.Run("revoked key", func(t *testing.T) {
+		svc, repo, _, _, _ := setupAPIKeyServiceTest(t)
+		revokedAt := time.Now()
+		repo.EXPECT().GetByHash(ctx, hashAPIKey(key)).Return(&domain.APIKey{ID: "key1", RevokedAt: &revokedAt}, nil)
+
+		_, err := svc.Authenticate(ctx, key)
+		assert.ErrorIs(t, err, ErrInvalidAPIKey)
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		svc, repo, _, _, _ := setupAPIKeyServiceTest(t)
+		repo.EXPECT().GetByHash(ctx, hashAPIKey(key)).Return(nil, &domain.ErrAPIKeyNotFound{Message: "API key not found"})
+
+		_, err := svc.Authenticate(ctx, key)
+		assert.ErrorIs(t, err, ErrInvalidAPIKey)
+	})
+
+	t.Run("lookup failure", func(t *testing.T) {
+		svc, repo, _, _, _ := setupAPIKeyServiceTest(t)
+		repo.EXPECT().GetByHash(ctx, hashAPIKey(key)).Return(nil, errors.New("database error"))
+
+		_, err := svc.Authenticate(ctx, key)
+		assert.Error(t, err)
+		assert.NotErrorIs(t, err, ErrInvalidAPIKey)
+	})
+
+	t.Run("not an API key", func(t *testing.T) {
+		svc, _, _, _, _ := setupAPIKeyServiceTest(t)
+
+		_, err := svc.Authenticate(ctx, "eyJhbGciOiJIUzI1NiJ9")
+		assert.ErrorIs(t, err, ErrInvalidAPIKey)
+	})
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/Notifuse/notifuse/internal/domain"
 	"github.com/Notifuse/notifuse/pkg/logger"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
 var (
@@ -206,6 +207,8 @@ func (s *AuthService) GenerateUserAuthToken(user *domain.User, sessionID string,
 		SessionID: sessionID,
 		Email:     user.Email,
 		RegisteredClaims: jwt.RegisteredClaims{
+			// The token ID identifies the token on the revocation list
+			ID:        uuid.NewString(),
 			ExpiresAt: jwt.NewNumericDate(expiresAt),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			NotBefore: jwt.NewNumericDate(time.Now()),
@@ -323,3 +326,25 @@ func (s *AuthService) GetUserByID(ctx context.Context, userID string) (*domain.U
 	}
 	return user, nil
 }
+
+// RevokeToken denies a user token until its expiration, even while its session is still valid
+func (s *AuthService) RevokeToken(ctx context.Context, tokenID string, expiresAt time.Time) error {
+	if tokenID == "" {
+		return nil
+	}
+	if err := s.repo.RevokeToken(ctx, tokenID, expiresAt); err != nil {
+		if s.logger != nil {
+			s.logger.WithField("error", err.Error()).WithField("token_id", tokenID).Error("Failed to revoke token")
+		}
+		return err
+	}
+	return nil
+}
+
+// IsTokenRevoked reports whether a user token was revoked before its expiration
+func (s *AuthService) IsTokenRevoked(ctx context.Context, tokenID string) (bool, error) {
+	if tokenID == "" {
+		return false, nil
+	}
+	return s.repo.IsTokenRevoked(ctx, tokenID)
+}
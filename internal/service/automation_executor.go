@@ -67,6 +67,13 @@ func NewAutomationExecutor(
 	}
 }
 
+// SetLinkShortener sets the service shortening click-tracked links of automation emails
+func (e *AutomationExecutor) SetLinkShortener(linkShortener domain.LinkShortenerService) {
+	if emailExecutor, ok := e.nodeExecutors[domain.NodeTypeEmail].(*EmailNodeExecutor); ok {
+		emailExecutor.linkShortener = linkShortener
+	}
+}
+
 // Execute processes a contact through their automation nodes until a delay or completion.
 // It loops through multiple nodes in a single tick for efficiency, persisting state after each node.
 func (e *AutomationExecutor) Execute(ctx context.Context, workspaceID string, contactAutomation *domain.ContactAutomation) error {
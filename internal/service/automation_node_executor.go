@@ -125,6 +125,7 @@ type EmailNodeExecutor struct {
 	workspaceRepo  domain.WorkspaceRepository
 	apiEndpoint    string
 	logger         logger.Logger
+	linkShortener  domain.LinkShortenerService
 }
 
 // NewEmailNodeExecutor creates a new email node executor
@@ -185,6 +186,7 @@ func (e *EmailNodeExecutor) Execute(ctx context.Context, params NodeExecutionPar
 	if err != nil {
 		return nil, fmt.Errorf("failed to get template: %w", err)
 	}
+	template = template.ForContact(params.ContactData)
 
 	// 5. Build template data from contact + automation
 	templateData := buildAutomationTemplateData(params.ContactData, params.Automation)
@@ -199,15 +201,17 @@ func (e *EmailNodeExecutor) Execute(ctx context.Context, params NodeExecutionPar
 	}
 
 	trackingSettings := notifuse_mjml.TrackingSettings{
-		Endpoint:       endpoint,
-		EnableTracking: workspace.Settings.EmailTrackingEnabled,
-		UTMSource:      "automation",
-		UTMMedium:      "email",
-		UTMCampaign:    params.Automation.Name,
-		UTMContent:     config.TemplateID,
-		WorkspaceID:    params.WorkspaceID,
-		MessageID:      messageID,
-	}
+		Endpoint:    endpoint,
+		UTMSource:   "automation",
+		UTMMedium:   "email",
+		UTMCampaign: params.Automation.Name,
+		UTMContent:  config.TemplateID,
+		WorkspaceID: params.WorkspaceID,
+		MessageID:   messageID,
+	}
+	trackingSettings.LinkShortener = domain.WorkspaceLinkShortener(ctx, e.linkShortener, params.WorkspaceID)
+	workspace.Settings.ApplyTrackingDefaults(&trackingSettings)
+	template.Email.ApplyTrackingOverrides(&trackingSettings)
 
 	// 8. Compile template
 	compiledTemplate, err := notifuse_mjml.CompileTemplate(
@@ -217,6 +221,7 @@ func (e *EmailNodeExecutor) Execute(ctx context.Context, params NodeExecutionPar
 			VisualEditorTree: template.Email.VisualEditorTree,
 			TemplateData:     templateData,
 			TrackingSettings: trackingSettings,
+			RawMergeFields:   template.Email.RawMergeFields,
 		},
 	)
 	if err != nil {
@@ -271,6 +276,7 @@ func (e *EmailNodeExecutor) Execute(ctx context.Context, params NodeExecutionPar
 		CreatedAt:   time.Now().UTC(),
 		UpdatedAt:   time.Now().UTC(),
 	}
+	template.Email.ApplyProviderTags(&entry.Payload.EmailOptions)
 
 	// 12. Enqueue the email
 	if err := e.emailQueueRepo.Enqueue(ctx, params.WorkspaceID, []*domain.EmailQueueEntry{entry}); err != nil {
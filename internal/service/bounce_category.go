@@ -0,0 +1,129 @@
+package service
+
+import (
+	"strings"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+)
+
+// mailboxFullDiagnostics are the SMTP codes and texts reporting a full mailbox, which providers
+// classify as hard or soft bounces depending on how long the mailbox has been full
+var mailboxFullDiagnostics = []string{"5.2.2", "4.2.2", "mailbox full", "mailbox is full", "quota"}
+
+// normalizeBounceCategory maps the provider specific fields of a bounce or complaint event to its
+// bounce category. It returns an empty category for events that are not classified, such as the
+// unsubscribes some providers report as complaints.
+func normalizeBounceCategory(event *domain.InboundWebhookEvent) domain.BounceCategory {
+	if event.Type == domain.EmailEventComplaint {
+		if strings.EqualFold(event.ComplaintFeedbackType, "unsubscribe") {
+			return ""
+		}
+		return domain.BounceCategorySpamComplaint
+	}
+	if event.Type != domain.EmailEventBounce {
+		return ""
+	}
+
+	var category domain.BounceCategory
+	switch event.Source {
+	case domain.WebhookSourceSES:
+		category = sesBounceCategory(event.BounceType, event.BounceCategory)
+	case domain.WebhookSourcePostmark:
+		category = postmarkBounceCategory(event.BounceType)
+	case domain.WebhookSourceMailgun:
+		category = mailgunBounceCategory(event.BounceCategory, event.BounceDiagnostic)
+	case domain.WebhookSourceSparkPost:
+		category = sparkPostBounceCategory(event.BounceCategory)
+	default:
+		// Mailjet, SendGrid and SMTP report readable bounce types and categories
+		category = genericBounceCategory(event.BounceType, event.BounceCategory)
+	}
+
+	if category == domain.BounceCategoryHardBounce || category == domain.BounceCategorySoftBounce {
+		diagnostic := strings.ToLower(event.BounceDiagnostic)
+		for _, pattern := range mailboxFullDiagnostics {
+			if strings.Contains(diagnostic, pattern) {
+				return domain.BounceCategoryMailboxFull
+			}
+		}
+	}
+	return category
+}
+
+// sesBounceCategory classifies an Amazon SES bounce from its type and sub-type
+// Reference: https://docs.aws.amazon.com/ses/latest/dg/notification-contents.html#bounce-types
+func sesBounceCategory(bounceType, bounceSubType string) domain.BounceCategory {
+	switch strings.ToLower(bounceSubType) {
+	case "mailboxfull":
+		return domain.BounceCategoryMailboxFull
+	case "suppressed", "onaccountsuppressionlist":
+		return domain.BounceCategoryBlockList
+	}
+
+	if strings.EqualFold(bounceType, "permanent") {
+		return domain.BounceCategoryHardBounce
+	}
+	return domain.BounceCategorySoftBounce
+}
+
+// postmarkBounceCategory classifies a Postmark bounce from its type
+// Reference: https://postmarkapp.com/developer/api/bounce-api#bounce-types
+func postmarkBounceCategory(bounceType string) domain.BounceCategory {
+	switch strings.ToLower(bounceType) {
+	case "hardbounce", "bademailaddress":
+		return domain.BounceCategoryHardBounce
+	case "blocked", "manuallydeactivated", "dmarcpolicy":
+		return domain.BounceCategoryBlockList
+	case "spamnotification", "spamcomplaint":
+		return domain.BounceCategorySpamComplaint
+	default:
+		return domain.BounceCategorySoftBounce
+	}
+}
+
+// mailgunBounceCategory classifies a Mailgun failure from its severity and reason, Mailgun
+// fails the sends to suppressed addresses with a suppress-* reason
+func mailgunBounceCategory(severity, reason string) domain.BounceCategory {
+	reason = strings.ToLower(reason)
+	if strings.HasPrefix(reason, "suppress-") || reason == "espblock" {
+		return domain.BounceCategoryBlockList
+	}
+
+	if strings.EqualFold(severity, "hardbounce") {
+		return domain.BounceCategoryHardBounce
+	}
+	return domain.BounceCategorySoftBounce
+}
+
+// sparkPostBounceCategory classifies a SparkPost bounce from its bounce class
+// Reference: https://support.sparkpost.com/docs/deliverability/bounce-classification-codes
+func sparkPostBounceCategory(bounceClass string) domain.BounceCategory {
+	switch bounceClass {
+	case "10", "30":
+		return domain.BounceCategoryHardBounce
+	case "22":
+		return domain.BounceCategoryMailboxFull
+	case "50", "51", "52", "53", "54", "90":
+		return domain.BounceCategoryBlockList
+	default:
+		return domain.BounceCategorySoftBounce
+	}
+}
+
+// genericBounceCategory classifies a bounce from the words of its type and category
+func genericBounceCategory(bounceType, bounceCategory string) domain.BounceCategory {
+	raw := strings.ToLower(bounceType + " " + bounceCategory)
+
+	switch {
+	case strings.Contains(raw, "block") || strings.Contains(raw, "dropped"):
+		return domain.BounceCategoryBlockList
+	case strings.Contains(raw, "spam") || strings.Contains(raw, "complaint"):
+		return domain.BounceCategorySpamComplaint
+	case strings.Contains(raw, "full") || strings.Contains(raw, "quota"):
+		return domain.BounceCategoryMailboxFull
+	case strings.Contains(raw, "hard") || strings.Contains(raw, "permanent"):
+		return domain.BounceCategoryHardBounce
+	default:
+		return domain.BounceCategorySoftBounce
+	}
+}
@@ -0,0 +1,207 @@
+package service
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	pkgmocks "github.com/Notifuse/notifuse/pkg/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeBounceCategory_ProviderPayloads(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	log := pkgmocks.NewMockLogger(ctrl)
+	log.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(log).AnyTimes()
+	log.EXPECT().WithFields(gomock.Any()).Return(log).AnyTimes()
+	log.EXPECT().Warn(gomock.Any()).AnyTimes()
+	service := &InboundWebhookEventService{logger: log}
+
+	sesPayload := func(message string) []byte {
+		raw, err := json.Marshal(domain.SESWebhookPayload{Message: message})
+		require.NoError(t, err)
+		return raw
+	}
+	mailgunPayload := func(event, severity, reason string) []byte {
+		raw, err := json.Marshal(domain.MailgunWebhookPayload{
+			EventData: domain.MailgunEventData{
+				Event:     event,
+				Recipient: "test@example.com",
+				Timestamp: 1672567200,
+				Severity:  severity,
+				Reason:    reason,
+				Message:   domain.MailgunMessage{Headers: domain.MailgunHeaders{MessageID: "message1"}},
+			},
+		})
+		require.NoError(t, err)
+		return raw
+	}
+	postmarkPayload := func(recordType, bounceType, details string) []byte {
+		raw, err := json.Marshal(map[string]interface{}{
+			"RecordType": recordType,
+			"MessageID":  "message1",
+			"Email":      "test@example.com",
+			"Type":       bounceType,
+			"Details":    details,
+			"BouncedAt":  "2023-01-01T12:00:00Z",
+		})
+		require.NoError(t, err)
+		return raw
+	}
+
+	tests := []struct {
+		name     string
+		process  func(integrationID string, rawPayload []byte) ([]*domain.InboundWebhookEvent, error)
+		payload  []byte
+		expected domain.BounceCategory
+	}{
+		{
+			name:     "SES permanent general bounce",
+			process:  service.processSESWebhook,
+			payload:  sesPayload(`{"eventType":"Bounce","bounce":{"bounceType":"Permanent","bounceSubType":"General","bouncedRecipients":[{"emailAddress":"test@example.com","diagnosticCode":"smtp; 550 5.1.1 user unknown"}],"timestamp":"2023-01-01T12:00:00Z"},"mail":{"messageId":"message1"}}`),
+			expected: domain.BounceCategoryHardBounce,
+		},
+		{
+			name:     "SES transient general bounce",
+			process:  service.processSESWebhook,
+			payload:  sesPayload(`{"eventType":"Bounce","bounce":{"bounceType":"Transient","bounceSubType":"General","bouncedRecipients":[{"emailAddress":"test@example.com","diagnosticCode":"smtp; 451 4.4.1 try again later"}],"timestamp":"2023-01-01T12:00:00Z"},"mail":{"messageId":"message1"}}`),
+			expected: domain.BounceCategorySoftBounce,
+		},
+		{
+			name:     "SES mailbox full",
+			process:  service.processSESWebhook,
+			payload:  sesPayload(`{"eventType":"Bounce","bounce":{"bounceType":"Transient","bounceSubType":"MailboxFull","bouncedRecipients":[{"emailAddress":"test@example.com","diagnosticCode":"smtp; 452 4.2.2 over quota"}],"timestamp":"2023-01-01T12:00:00Z"},"mail":{"messageId":"message1"}}`),
+			expected: domain.BounceCategoryMailboxFull,
+		},
+		{
+			name:     "SES suppression list",
+			process:  service.processSESWebhook,
+			payload:  sesPayload(`{"eventType":"Bounce","bounce":{"bounceType":"Permanent","bounceSubType":"OnAccountSuppressionList","bouncedRecipients":[{"emailAddress":"test@example.com","diagnosticCode":"Amazon SES did not send the message to this address because it is on the suppression list for your account."}],"timestamp":"2023-01-01T12:00:00Z"},"mail":{"messageId":"message1"}}`),
+			expected: domain.BounceCategoryBlockList,
+		},
+		{
+			name:     "SES complaint",
+			process:  service.processSESWebhook,
+			payload:  sesPayload(`{"eventType":"Complaint","complaint":{"complainedRecipients":[{"emailAddress":"test@example.com"}],"complaintFeedbackType":"abuse","timestamp":"2023-01-01T12:00:00Z"},"mail":{"messageId":"message1"}}`),
+			expected: domain.BounceCategorySpamComplaint,
+		},
+		{
+			name:     "Mailgun permanent failure",
+			process:  service.processMailgunWebhook,
+			payload:  mailgunPayload("failed", "permanent", "bounce"),
+			expected: domain.BounceCategoryHardBounce,
+		},
+		{
+			name:     "Mailgun temporary failure",
+			process:  service.processMailgunWebhook,
+			payload:  mailgunPayload("failed", "temporary", "generic"),
+			expected: domain.BounceCategorySoftBounce,
+		},
+		{
+			name:     "Mailgun suppressed address",
+			process:  service.processMailgunWebhook,
+			payload:  mailgunPayload("failed", "permanent", "suppress-bounce"),
+			expected: domain.BounceCategoryBlockList,
+		},
+		{
+			name:     "Mailgun mailbox full",
+			process:  service.processMailgunWebhook,
+			payload:  mailgunPayload("failed", "temporary", "552 5.2.2 Mailbox full"),
+			expected: domain.BounceCategoryMailboxFull,
+		},
+		{
+			name:     "Mailgun complaint",
+			process:  service.processMailgunWebhook,
+			payload:  mailgunPayload("complained", "", ""),
+			expected: domain.BounceCategorySpamComplaint,
+		},
+		{
+			name:     "Postmark hard bounce",
+			process:  service.processPostmarkWebhook,
+			payload:  postmarkPayload("Bounce", "HardBounce", "smtp;550 5.1.1 The email account that you tried to reach does not exist."),
+			expected: domain.BounceCategoryHardBounce,
+		},
+		{
+			name:     "Postmark soft bounce",
+			process:  service.processPostmarkWebhook,
+			payload:  postmarkPayload("Bounce", "SoftBounce", "smtp;421 4.7.0 Try again later"),
+			expected: domain.BounceCategorySoftBounce,
+		},
+		{
+			name:     "Postmark soft bounce on a full mailbox",
+			process:  service.processPostmarkWebhook,
+			payload:  postmarkPayload("Bounce", "SoftBounce", "smtp;452 4.2.2 The email account that you tried to reach is over quota."),
+			expected: domain.BounceCategoryMailboxFull,
+		},
+		{
+			name:     "Postmark blocked",
+			process:  service.processPostmarkWebhook,
+			payload:  postmarkPayload("Bounce", "Blocked", "smtp;554 5.7.1 Service unavailable; client host blocked using Spamhaus"),
+			expected: domain.BounceCategoryBlockList,
+		},
+		{
+			name:     "Postmark spam notification",
+			process:  service.processPostmarkWebhook,
+			payload:  postmarkPayload("Bounce", "SpamNotification", "The message was delivered, but was either blocked by the user, or classified as spam."),
+			expected: domain.BounceCategorySpamComplaint,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, err := tt.process("integration1", tt.payload)
+			require.NoError(t, err)
+			require.Len(t, events, 1)
+
+			assert.Equal(t, tt.expected, normalizeBounceCategory(events[0]))
+		})
+	}
+}
+
+func TestNormalizeBounceCategory(t *testing.T) {
+	t.Run("SparkPost bounce classes", func(t *testing.T) {
+		for class, expected := range map[string]domain.BounceCategory{
+			"10": domain.BounceCategoryHardBounce,
+			"21": domain.BounceCategorySoftBounce,
+			"22": domain.BounceCategoryMailboxFull,
+			"51": domain.BounceCategoryBlockList,
+			"90": domain.BounceCategoryBlockList,
+		} {
+			event := &domain.InboundWebhookEvent{Type: domain.EmailEventBounce, Source: domain.WebhookSourceSparkPost, BounceType: "Bounce", BounceCategory: class}
+			assert.Equal(t, expected, normalizeBounceCategory(event), "class %s", class)
+		}
+	})
+
+	t.Run("readable bounce types", func(t *testing.T) {
+		tests := []struct {
+			source         domain.WebhookSource
+			bounceType     string
+			bounceCategory string
+			expected       domain.BounceCategory
+		}{
+			{domain.WebhookSourceMailjet, "HardBounce", "Permanent", domain.BounceCategoryHardBounce},
+			{domain.WebhookSourceMailjet, "Blocked", "Blocked", domain.BounceCategoryBlockList},
+			{domain.WebhookSourceSendGrid, "SoftBounce", "Temporary", domain.BounceCategorySoftBounce},
+			{domain.WebhookSourceSendGrid, "HardBounce", "Dropped", domain.BounceCategoryBlockList},
+			{domain.WebhookSourceSMTP, "Bounce", "mailbox-full", domain.BounceCategoryMailboxFull},
+		}
+		for _, tt := range tests {
+			event := &domain.InboundWebhookEvent{Type: domain.EmailEventBounce, Source: tt.source, BounceType: tt.bounceType, BounceCategory: tt.bounceCategory}
+			assert.Equal(t, tt.expected, normalizeBounceCategory(event), "%s %s %s", tt.source, tt.bounceType, tt.bounceCategory)
+		}
+	})
+
+	t.Run("unsubscribes reported as complaints are not classified", func(t *testing.T) {
+		event := &domain.InboundWebhookEvent{Type: domain.EmailEventComplaint, Source: domain.WebhookSourceSendGrid, ComplaintFeedbackType: "unsubscribe"}
+		assert.Equal(t, domain.BounceCategory(""), normalizeBounceCategory(event))
+	})
+
+	t.Run("deliveries are not classified", func(t *testing.T) {
+		event := &domain.InboundWebhookEvent{Type: domain.EmailEventDelivered, Source: domain.WebhookSourceSES}
+		assert.Equal(t, domain.BounceCategory(""), normalizeBounceCategory(event))
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"math"
 	"time"
 
 	"github.com/Notifuse/notifuse/internal/domain"
@@ -28,87 +29,259 @@ func NewABTestEvaluator(
 	}
 }
 
-func (e *ABTestEvaluator) EvaluateAndSelectWinner(ctx context.Context, workspaceID, broadcastID string) (string, error) {
+// WinnerEvaluation is the result of an automatic A/B winner evaluation
+type WinnerEvaluation struct {
+	// WinnerTemplateID is empty when no significant winner exists, the broadcast then stays test_completed
+	WinnerTemplateID   string
+	LeaderTemplateID   string
+	RunnerUpTemplateID string
+	// PValue is the two-sided p-value of the two-proportion z-test between the leader and the runner-up,
+	// nil when no minimum confidence is set or no runner-up was ranked
+	PValue *float64
+}
+
+func (e *ABTestEvaluator) EvaluateAndSelectWinner(ctx context.Context, workspaceID, broadcastID string) (*WinnerEvaluation, error) {
 	// Get broadcast
 	broadcast, err := e.broadcastRepo.GetBroadcast(ctx, workspaceID, broadcastID)
 	if err != nil {
-		return "", fmt.Errorf("failed to get broadcast: %w", err)
+		return nil, fmt.Errorf("failed to get broadcast: %w", err)
 	}
 
 	// Validate broadcast state
 	if broadcast.Status != domain.BroadcastStatusTestCompleted {
-		return "", fmt.Errorf("broadcast is not in test completed state")
+		return nil, fmt.Errorf("broadcast is not in test completed state")
 	}
 
 	if !broadcast.TestSettings.AutoSendWinner {
-		return "", fmt.Errorf("auto winner selection not enabled for broadcast")
+		return nil, fmt.Errorf("auto winner selection not enabled for broadcast")
 	}
 
 	// Evaluate variations and select winner
-	winnerTemplateID, err := e.selectBestVariation(ctx, workspaceID, broadcast)
+	evaluation, err := e.selectBestVariation(ctx, workspaceID, broadcast)
 	if err != nil {
-		return "", fmt.Errorf("failed to select winner: %w", err)
+		return nil, fmt.Errorf("failed to select winner: %w", err)
+	}
+
+	if evaluation.WinnerTemplateID == "" {
+		return evaluation, nil
 	}
 
 	// Update broadcast with winner
-	err = e.updateBroadcastWithWinner(ctx, workspaceID, broadcast, winnerTemplateID)
+	err = e.updateBroadcastWithWinner(ctx, workspaceID, broadcast, evaluation.WinnerTemplateID)
 	if err != nil {
-		return "", fmt.Errorf("failed to update broadcast with winner: %w", err)
+		return nil, fmt.Errorf("failed to update broadcast with winner: %w", err)
 	}
 
-	return winnerTemplateID, nil
+	return evaluation, nil
 }
 
-func (e *ABTestEvaluator) selectBestVariation(ctx context.Context, workspaceID string, broadcast *domain.Broadcast) (string, error) {
-	bestTemplateID := ""
-	bestScore := -1.0
+// minDeliveredForUnsubscribeRate is the number of delivered emails a variation needs to be ranked on
+// its unsubscribe rate, so that a tiny sample without any unsubscribe cannot win
+const minDeliveredForUnsubscribeRate = 100
+
+// rankedVariation is a variation with the counts of the winner metric
+type rankedVariation struct {
+	templateID string
+	successes  int
+	trials     int
+	score      float64
+}
+
+func (e *ABTestEvaluator) selectBestVariation(ctx context.Context, workspaceID string, broadcast *domain.Broadcast) (*WinnerEvaluation, error) {
+	metric := broadcast.TestSettings.AutoSendWinnerMetric
+	var best, runnerUp *rankedVariation
+
+	allStats, err := e.messageHistoryRepo.GetBroadcastVariationStatsAll(ctx, workspaceID, broadcast.ID, broadcast.TestSettings.TemplateIDs())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get variation stats: %w", err)
+	}
 
 	for _, variation := range broadcast.TestSettings.Variations {
-		stats, err := e.messageHistoryRepo.GetBroadcastVariationStats(ctx, workspaceID, broadcast.ID, variation.TemplateID)
+		stats := variationStats(allStats, variation.TemplateID)
+
+		successes, trials, ranked, err := variationCounts(metric, stats)
 		if err != nil {
+			return nil, err
+		}
+		if !ranked {
 			e.logger.WithFields(map[string]interface{}{
 				"template_id": variation.TemplateID,
-				"error":       err.Error(),
-			}).Warn("Failed to get variation stats")
+				"metric":      metric,
+				"delivered":   stats.TotalDelivered,
+			}).Info("Variation not ranked, too few delivered emails")
 			continue
 		}
 
-		var score float64
-		if stats.TotalDelivered > 0 {
-			switch broadcast.TestSettings.AutoSendWinnerMetric {
-			case domain.TestWinnerMetricOpenRate:
-				score = float64(stats.TotalOpened) / float64(stats.TotalDelivered)
-			case domain.TestWinnerMetricClickRate:
-				score = float64(stats.TotalClicked) / float64(stats.TotalDelivered)
-			default:
-				return "", fmt.Errorf("invalid winner metric: %s", broadcast.TestSettings.AutoSendWinnerMetric)
-			}
+		current := &rankedVariation{
+			templateID: variation.TemplateID,
+			successes:  successes,
+			trials:     trials,
+			score:      ratio(successes, trials),
 		}
-
-		if score > bestScore {
-			bestScore = score
-			bestTemplateID = variation.TemplateID
+		if best == nil || isBetterScore(metric, current.score, best.score) {
+			best, runnerUp = current, best
+		} else if runnerUp == nil || isBetterScore(metric, current.score, runnerUp.score) {
+			runnerUp = current
 		}
 
 		e.logger.WithFields(map[string]interface{}{
 			"template_id": variation.TemplateID,
-			"metric":      broadcast.TestSettings.AutoSendWinnerMetric,
-			"score":       score,
-			"is_best":     score == bestScore,
+			"metric":      metric,
+			"score":       current.score,
+			"is_best":     best == current,
 		}).Info("Variation evaluation result")
 	}
 
-	if bestTemplateID == "" {
-		return "", fmt.Errorf("no winner could be determined")
+	if best == nil {
+		return nil, fmt.Errorf("no winner could be determined")
+	}
+
+	evaluation := &WinnerEvaluation{
+		WinnerTemplateID: best.templateID,
+		LeaderTemplateID: best.templateID,
+	}
+
+	// With a minimum confidence, the leader must beat the runner-up beyond noise
+	if minConfidence := broadcast.TestSettings.MinConfidence; minConfidence > 0 {
+		if runnerUp == nil {
+			evaluation.WinnerTemplateID = ""
+			e.logger.WithFields(map[string]interface{}{
+				"broadcast_id":   broadcast.ID,
+				"leader":         best.templateID,
+				"min_confidence": minConfidence,
+			}).Info("No significant winner, a single variation was ranked")
+			return evaluation, nil
+		}
+
+		pValue := twoProportionPValue(best.successes, best.trials, runnerUp.successes, runnerUp.trials)
+		evaluation.RunnerUpTemplateID = runnerUp.templateID
+		evaluation.PValue = &pValue
+
+		if 1-pValue < minConfidence {
+			evaluation.WinnerTemplateID = ""
+			e.logger.WithFields(map[string]interface{}{
+				"broadcast_id":   broadcast.ID,
+				"leader":         best.templateID,
+				"runner_up":      runnerUp.templateID,
+				"p_value":        pValue,
+				"min_confidence": minConfidence,
+			}).Info("No significant winner, waiting for more results or a manual selection")
+			return evaluation, nil
+		}
 	}
 
 	e.logger.WithFields(map[string]interface{}{
 		"broadcast_id":    broadcast.ID,
-		"winner_template": bestTemplateID,
-		"winning_score":   bestScore,
+		"winner_template": best.templateID,
+		"winning_score":   best.score,
 	}).Info("Auto winner selected")
 
-	return bestTemplateID, nil
+	return evaluation, nil
+}
+
+// twoProportionPValue returns the two-sided p-value of the pooled two-proportion z-test
+// comparing successes1/trials1 with successes2/trials2
+func twoProportionPValue(successes1, trials1, successes2, trials2 int) float64 {
+	if trials1 == 0 || trials2 == 0 {
+		return 1
+	}
+
+	p1 := float64(successes1) / float64(trials1)
+	p2 := float64(successes2) / float64(trials2)
+	pooled := float64(successes1+successes2) / float64(trials1+trials2)
+
+	standardError := math.Sqrt(pooled * (1 - pooled) * (1/float64(trials1) + 1/float64(trials2)))
+	if standardError == 0 {
+		// Both rates are 0% or 100%, the variations cannot be told apart
+		return 1
+	}
+
+	z := (p1 - p2) / standardError
+	return math.Erfc(math.Abs(z) / math.Sqrt2)
+}
+
+// variationCounts returns the successes and trials of a variation for the winner metric, its rate
+// being successes/trials. ranked is false when the variation has too few delivered emails to be
+// compared on its unsubscribe rate.
+func variationCounts(metric domain.TestWinnerMetric, stats *domain.MessageHistoryStatusSum) (successes, trials int, ranked bool, err error) {
+	switch metric {
+	case domain.TestWinnerMetricOpenRate:
+		return stats.TotalOpened, stats.TotalDelivered, true, nil
+	case domain.TestWinnerMetricClickRate:
+		return stats.TotalClicked, stats.TotalDelivered, true, nil
+	case domain.TestWinnerMetricClickToOpenRate:
+		return stats.TotalClicked, stats.TotalOpened, true, nil
+	case domain.TestWinnerMetricLowestUnsubscribeRate:
+		if stats.TotalDelivered < minDeliveredForUnsubscribeRate {
+			return 0, 0, false, nil
+		}
+		return stats.TotalUnsubscribed, stats.TotalDelivered, true, nil
+	default:
+		return 0, 0, false, fmt.Errorf("invalid winner metric: %s", metric)
+	}
+}
+
+// isBetterScore reports whether score beats best, the unsubscribe rate is better when lower
+func isBetterScore(metric domain.TestWinnerMetric, score, best float64) bool {
+	if metric == domain.TestWinnerMetricLowestUnsubscribeRate {
+		return score < best
+	}
+	return score > best
+}
+
+// ratio returns part/total, 0 when total is 0
+func ratio(part, total int) float64 {
+	if total == 0 {
+		return 0
+	}
+	return float64(part) / float64(total)
+}
+
+// variationStats returns the stats of a variation, zeroed when the variation has none
+func variationStats(allStats map[string]*domain.MessageHistoryStatusSum, templateID string) *domain.MessageHistoryStatusSum {
+	if stats, ok := allStats[templateID]; ok && stats != nil {
+		return stats
+	}
+	return &domain.MessageHistoryStatusSum{}
+}
+
+// TestOutcome collects the stats of each variation of an A/B broadcast along with the winner and
+// the metric that decided it. Variations are left out when their stats cannot be read.
+func (e *ABTestEvaluator) TestOutcome(ctx context.Context, workspaceID string, broadcast *domain.Broadcast) *domain.BroadcastTestOutcome {
+	outcome := &domain.BroadcastTestOutcome{
+		Variations:   make([]domain.BroadcastVariationOutcome, 0, len(broadcast.TestSettings.Variations)),
+		WinnerMetric: domain.TestWinnerMetricManual,
+	}
+	if broadcast.TestSettings.AutoSendWinner {
+		outcome.WinnerMetric = broadcast.TestSettings.AutoSendWinnerMetric
+	}
+	if broadcast.WinningTemplate != nil {
+		outcome.WinningTemplate = *broadcast.WinningTemplate
+	}
+
+	allStats, err := e.messageHistoryRepo.GetBroadcastVariationStatsAll(ctx, workspaceID, broadcast.ID, broadcast.TestSettings.TemplateIDs())
+	if err != nil {
+		e.logger.WithFields(map[string]interface{}{
+			"broadcast_id": broadcast.ID,
+			"error":        err.Error(),
+		}).Warn("Failed to get variation stats")
+		return outcome
+	}
+
+	for _, variation := range broadcast.TestSettings.Variations {
+		stats := variationStats(allStats, variation.TemplateID)
+
+		outcome.Variations = append(outcome.Variations, domain.BroadcastVariationOutcome{
+			VariationName: variation.VariationName,
+			TemplateID:    variation.TemplateID,
+			Sent:          stats.TotalSent,
+			Delivered:     stats.TotalDelivered,
+			Opens:         stats.TotalOpened,
+			Clicks:        stats.TotalClicked,
+		})
+	}
+
+	return outcome
 }
 
 func (e *ABTestEvaluator) updateBroadcastWithWinner(ctx context.Context, workspaceID string, broadcast *domain.Broadcast, winnerTemplateID string) error {
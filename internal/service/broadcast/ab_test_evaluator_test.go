@@ -68,8 +68,7 @@ func TestABTestEvaluator_EvaluateAndSelectWinner_OpenRate_Success(t *testing.T)
 	bcRepo.EXPECT().GetBroadcast(ctx, workspaceID, broadcastID).Return(b, nil)
 
 	// Stats: A wins on open rate (0.40 vs 0.30)
-	msgRepo.EXPECT().GetBroadcastVariationStats(ctx, workspaceID, broadcastID, "tplA").Return(&domain.MessageHistoryStatusSum{TotalDelivered: 100, TotalOpened: 40}, nil)
-	msgRepo.EXPECT().GetBroadcastVariationStats(ctx, workspaceID, broadcastID, "tplB").Return(&domain.MessageHistoryStatusSum{TotalDelivered: 100, TotalOpened: 30}, nil)
+	msgRepo.EXPECT().GetBroadcastVariationStatsAll(ctx, workspaceID, broadcastID, []string{"tplA", "tplB"}).Return(map[string]*domain.MessageHistoryStatusSum{"tplA": {TotalDelivered: 100, TotalOpened: 40}, "tplB": {TotalDelivered: 100, TotalOpened: 30}}, nil)
 
 	// Transaction update/assert broadcast updated fields
 	bcRepo.EXPECT().WithTransaction(ctx, workspaceID, gomock.Any()).DoAndReturn(
@@ -89,7 +88,7 @@ func TestABTestEvaluator_EvaluateAndSelectWinner_OpenRate_Success(t *testing.T)
 
 	winner, err := evaluator.EvaluateAndSelectWinner(ctx, workspaceID, broadcastID)
 	require.NoError(t, err)
-	assert.Equal(t, "tplA", winner)
+	assert.Equal(t, "tplA", winner.WinnerTemplateID)
 }
 
 func TestABTestEvaluator_EvaluateAndSelectWinner_ClickRate_Success(t *testing.T) {
@@ -106,8 +105,7 @@ func TestABTestEvaluator_EvaluateAndSelectWinner_ClickRate_Success(t *testing.T)
 	bcRepo.EXPECT().GetBroadcast(ctx, workspaceID, broadcastID).Return(b, nil)
 
 	// Stats: B wins on click rate (10/100 vs 5/100)
-	msgRepo.EXPECT().GetBroadcastVariationStats(ctx, workspaceID, broadcastID, "tplA").Return(&domain.MessageHistoryStatusSum{TotalDelivered: 100, TotalClicked: 5}, nil)
-	msgRepo.EXPECT().GetBroadcastVariationStats(ctx, workspaceID, broadcastID, "tplB").Return(&domain.MessageHistoryStatusSum{TotalDelivered: 100, TotalClicked: 10}, nil)
+	msgRepo.EXPECT().GetBroadcastVariationStatsAll(ctx, workspaceID, broadcastID, []string{"tplA", "tplB"}).Return(map[string]*domain.MessageHistoryStatusSum{"tplA": {TotalDelivered: 100, TotalClicked: 5}, "tplB": {TotalDelivered: 100, TotalClicked: 10}}, nil)
 
 	bcRepo.EXPECT().WithTransaction(ctx, workspaceID, gomock.Any()).DoAndReturn(
 		func(_ context.Context, _ string, fn func(*sql.Tx) error) error { return fn(nil) },
@@ -123,7 +121,7 @@ func TestABTestEvaluator_EvaluateAndSelectWinner_ClickRate_Success(t *testing.T)
 
 	winner, err := evaluator.EvaluateAndSelectWinner(ctx, workspaceID, broadcastID)
 	require.NoError(t, err)
-	assert.Equal(t, "tplB", winner)
+	assert.Equal(t, "tplB", winner.WinnerTemplateID)
 }
 
 func TestABTestEvaluator_EvaluateAndSelectWinner_GetBroadcastError(t *testing.T) {
@@ -192,14 +190,14 @@ func TestABTestEvaluator_EvaluateAndSelectWinner_InvalidMetric(t *testing.T) {
 	bcRepo.EXPECT().GetBroadcast(ctx, workspaceID, broadcastID).Return(b, nil)
 
 	// Provide stats so selection reaches metric switch; evaluator will error on first variation
-	msgRepo.EXPECT().GetBroadcastVariationStats(ctx, workspaceID, broadcastID, "tplA").Return(&domain.MessageHistoryStatusSum{TotalDelivered: 100, TotalOpened: 40, TotalClicked: 5}, nil)
+	msgRepo.EXPECT().GetBroadcastVariationStatsAll(ctx, workspaceID, broadcastID, []string{"tplA", "tplB"}).Return(map[string]*domain.MessageHistoryStatusSum{"tplA": {TotalDelivered: 100, TotalOpened: 40, TotalClicked: 5}}, nil)
 
 	_, err := evaluator.EvaluateAndSelectWinner(ctx, workspaceID, broadcastID)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "invalid winner metric")
 }
 
-func TestABTestEvaluator_EvaluateAndSelectWinner_NoWinnerWhenAllStatsFail(t *testing.T) {
+func TestABTestEvaluator_EvaluateAndSelectWinner_StatsError(t *testing.T) {
 	ctrl, msgRepo, bcRepo, _, evaluator := setupEvaluator(t)
 	defer ctrl.Finish()
 
@@ -211,15 +209,14 @@ func TestABTestEvaluator_EvaluateAndSelectWinner_NoWinnerWhenAllStatsFail(t *tes
 
 	bcRepo.EXPECT().GetBroadcast(ctx, workspaceID, broadcastID).Return(b, nil)
 
-	msgRepo.EXPECT().GetBroadcastVariationStats(ctx, workspaceID, broadcastID, "tplA").Return(nil, errors.New("boom"))
-	msgRepo.EXPECT().GetBroadcastVariationStats(ctx, workspaceID, broadcastID, "tplB").Return(nil, errors.New("boom"))
+	msgRepo.EXPECT().GetBroadcastVariationStatsAll(ctx, workspaceID, broadcastID, []string{"tplA", "tplB"}).Return(nil, errors.New("boom"))
 
 	_, err := evaluator.EvaluateAndSelectWinner(ctx, workspaceID, broadcastID)
 	require.Error(t, err)
-	assert.Contains(t, err.Error(), "no winner could be determined")
+	assert.Contains(t, err.Error(), "failed to get variation stats")
 }
 
-func TestABTestEvaluator_EvaluateAndSelectWinner_StatsErrorContinue(t *testing.T) {
+func TestABTestEvaluator_EvaluateAndSelectWinner_VariationWithoutSends(t *testing.T) {
 	ctrl, msgRepo, bcRepo, _, evaluator := setupEvaluator(t)
 	defer ctrl.Finish()
 
@@ -232,9 +229,8 @@ func TestABTestEvaluator_EvaluateAndSelectWinner_StatsErrorContinue(t *testing.T
 
 	bcRepo.EXPECT().GetBroadcast(ctx, workspaceID, broadcastID).Return(b, nil)
 
-	// A fails, B succeeds; should still pick B
-	msgRepo.EXPECT().GetBroadcastVariationStats(ctx, workspaceID, broadcastID, "tplA").Return(nil, errors.New("stats error"))
-	msgRepo.EXPECT().GetBroadcastVariationStats(ctx, workspaceID, broadcastID, "tplB").Return(&domain.MessageHistoryStatusSum{TotalDelivered: 100, TotalOpened: 50}, nil)
+	// A has no sends, B wins
+	msgRepo.EXPECT().GetBroadcastVariationStatsAll(ctx, workspaceID, broadcastID, []string{"tplA", "tplB"}).Return(map[string]*domain.MessageHistoryStatusSum{"tplA": {}, "tplB": {TotalDelivered: 100, TotalOpened: 50}}, nil)
 
 	bcRepo.EXPECT().WithTransaction(ctx, workspaceID, gomock.Any()).DoAndReturn(
 		func(_ context.Context, _ string, fn func(*sql.Tx) error) error { return fn(nil) },
@@ -249,7 +245,7 @@ func TestABTestEvaluator_EvaluateAndSelectWinner_StatsErrorContinue(t *testing.T
 
 	winner, err := evaluator.EvaluateAndSelectWinner(ctx, workspaceID, broadcastID)
 	require.NoError(t, err)
-	assert.Equal(t, "tplB", winner)
+	assert.Equal(t, "tplB", winner.WinnerTemplateID)
 }
 
 func TestABTestEvaluator_EvaluateAndSelectWinner_WithTransactionError(t *testing.T) {
@@ -264,8 +260,7 @@ func TestABTestEvaluator_EvaluateAndSelectWinner_WithTransactionError(t *testing
 
 	bcRepo.EXPECT().GetBroadcast(ctx, workspaceID, broadcastID).Return(b, nil)
 
-	msgRepo.EXPECT().GetBroadcastVariationStats(ctx, workspaceID, broadcastID, "tplA").Return(&domain.MessageHistoryStatusSum{TotalDelivered: 100, TotalOpened: 60}, nil)
-	msgRepo.EXPECT().GetBroadcastVariationStats(ctx, workspaceID, broadcastID, "tplB").Return(&domain.MessageHistoryStatusSum{TotalDelivered: 100, TotalOpened: 50}, nil)
+	msgRepo.EXPECT().GetBroadcastVariationStatsAll(ctx, workspaceID, broadcastID, []string{"tplA", "tplB"}).Return(map[string]*domain.MessageHistoryStatusSum{"tplA": {TotalDelivered: 100, TotalOpened: 60}, "tplB": {TotalDelivered: 100, TotalOpened: 50}}, nil)
 
 	bcRepo.EXPECT().WithTransaction(ctx, workspaceID, gomock.Any()).Return(errors.New("tx failed"))
 
@@ -286,8 +281,7 @@ func TestABTestEvaluator_EvaluateAndSelectWinner_UpdateBroadcastError(t *testing
 
 	bcRepo.EXPECT().GetBroadcast(ctx, workspaceID, broadcastID).Return(b, nil)
 
-	msgRepo.EXPECT().GetBroadcastVariationStats(ctx, workspaceID, broadcastID, "tplA").Return(&domain.MessageHistoryStatusSum{TotalDelivered: 100, TotalOpened: 60}, nil)
-	msgRepo.EXPECT().GetBroadcastVariationStats(ctx, workspaceID, broadcastID, "tplB").Return(&domain.MessageHistoryStatusSum{TotalDelivered: 100, TotalOpened: 50}, nil)
+	msgRepo.EXPECT().GetBroadcastVariationStatsAll(ctx, workspaceID, broadcastID, []string{"tplA", "tplB"}).Return(map[string]*domain.MessageHistoryStatusSum{"tplA": {TotalDelivered: 100, TotalOpened: 60}, "tplB": {TotalDelivered: 100, TotalOpened: 50}}, nil)
 
 	bcRepo.EXPECT().WithTransaction(ctx, workspaceID, gomock.Any()).DoAndReturn(
 		func(_ context.Context, _ string, fn func(*sql.Tx) error) error { return fn(nil) },
@@ -298,3 +292,158 @@ func TestABTestEvaluator_EvaluateAndSelectWinner_UpdateBroadcastError(t *testing
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "failed to update broadcast with winner")
 }
+
+// expectWinner expects the broadcast to be saved with the given winning template
+func expectWinner(t *testing.T, ctx context.Context, bcRepo *domainmocks.MockBroadcastRepository, workspaceID, templateID string) {
+	bcRepo.EXPECT().WithTransaction(ctx, workspaceID, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, fn func(*sql.Tx) error) error { return fn(nil) },
+	)
+	bcRepo.EXPECT().UpdateBroadcastTx(ctx, gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ *sql.Tx, updated *domain.Broadcast) error {
+			require.NotNil(t, updated.WinningTemplate)
+			assert.Equal(t, templateID, *updated.WinningTemplate)
+			return nil
+		},
+	)
+}
+
+func TestABTestEvaluator_EvaluateAndSelectWinner_ClickToOpenRate(t *testing.T) {
+	ctrl, msgRepo, bcRepo, _, evaluator := setupEvaluator(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	b := newTestBroadcast("w1", "b1")
+	b.TestSettings.AutoSendWinnerMetric = domain.TestWinnerMetricClickToOpenRate
+	bcRepo.EXPECT().GetBroadcast(ctx, "w1", "b1").Return(b, nil)
+
+	// Both variations tie on opens, B converts more of its opens to clicks (12/40 vs 8/40)
+	msgRepo.EXPECT().GetBroadcastVariationStatsAll(ctx, "w1", "b1", []string{"tplA", "tplB"}).Return(map[string]*domain.MessageHistoryStatusSum{"tplA": {TotalDelivered: 100, TotalOpened: 40, TotalClicked: 8}, "tplB": {TotalDelivered: 100, TotalOpened: 40, TotalClicked: 12}}, nil)
+	expectWinner(t, ctx, bcRepo, "w1", "tplB")
+
+	winner, err := evaluator.EvaluateAndSelectWinner(ctx, "w1", "b1")
+	require.NoError(t, err)
+	assert.Equal(t, "tplB", winner.WinnerTemplateID)
+}
+
+func TestABTestEvaluator_EvaluateAndSelectWinner_LowestUnsubscribeRate(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("lowest rate wins", func(t *testing.T) {
+		ctrl, msgRepo, bcRepo, _, evaluator := setupEvaluator(t)
+		defer ctrl.Finish()
+
+		b := newTestBroadcast("w1", "b1")
+		b.TestSettings.AutoSendWinnerMetric = domain.TestWinnerMetricLowestUnsubscribeRate
+		bcRepo.EXPECT().GetBroadcast(ctx, "w1", "b1").Return(b, nil)
+
+		// A has the best opens but loses on unsubscribes (4/200 vs 1/200)
+		msgRepo.EXPECT().GetBroadcastVariationStatsAll(ctx, "w1", "b1", []string{"tplA", "tplB"}).Return(map[string]*domain.MessageHistoryStatusSum{"tplA": {TotalDelivered: 200, TotalOpened: 90, TotalUnsubscribed: 4}, "tplB": {TotalDelivered: 200, TotalOpened: 60, TotalUnsubscribed: 1}}, nil)
+		expectWinner(t, ctx, bcRepo, "w1", "tplB")
+
+		winner, err := evaluator.EvaluateAndSelectWinner(ctx, "w1", "b1")
+		require.NoError(t, err)
+		assert.Equal(t, "tplB", winner.WinnerTemplateID)
+	})
+
+	t.Run("variation under the delivered threshold is not ranked", func(t *testing.T) {
+		ctrl, msgRepo, bcRepo, _, evaluator := setupEvaluator(t)
+		defer ctrl.Finish()
+
+		b := newTestBroadcast("w1", "b1")
+		b.TestSettings.AutoSendWinnerMetric = domain.TestWinnerMetricLowestUnsubscribeRate
+		bcRepo.EXPECT().GetBroadcast(ctx, "w1", "b1").Return(b, nil)
+
+		// A has no unsubscribe on a tiny sample
+		msgRepo.EXPECT().GetBroadcastVariationStatsAll(ctx, "w1", "b1", []string{"tplA", "tplB"}).Return(map[string]*domain.MessageHistoryStatusSum{"tplA": {TotalDelivered: 20}, "tplB": {TotalDelivered: 200, TotalUnsubscribed: 2}}, nil)
+		expectWinner(t, ctx, bcRepo, "w1", "tplB")
+
+		winner, err := evaluator.EvaluateAndSelectWinner(ctx, "w1", "b1")
+		require.NoError(t, err)
+		assert.Equal(t, "tplB", winner.WinnerTemplateID)
+	})
+
+	t.Run("no variation over the delivered threshold", func(t *testing.T) {
+		ctrl, msgRepo, bcRepo, _, evaluator := setupEvaluator(t)
+		defer ctrl.Finish()
+
+		b := newTestBroadcast("w1", "b1")
+		b.TestSettings.AutoSendWinnerMetric = domain.TestWinnerMetricLowestUnsubscribeRate
+		bcRepo.EXPECT().GetBroadcast(ctx, "w1", "b1").Return(b, nil)
+
+		msgRepo.EXPECT().GetBroadcastVariationStatsAll(ctx, "w1", "b1", []string{"tplA", "tplB"}).Return(map[string]*domain.MessageHistoryStatusSum{"tplA": {TotalDelivered: 20}, "tplB": {TotalDelivered: 30, TotalUnsubscribed: 1}}, nil)
+
+		_, err := evaluator.EvaluateAndSelectWinner(ctx, "w1", "b1")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "no winner could be determined")
+	})
+}
+
+func TestABTestEvaluator_EvaluateAndSelectWinner_MinConfidence(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("significant difference selects the leader", func(t *testing.T) {
+		ctrl, msgRepo, bcRepo, _, evaluator := setupEvaluator(t)
+		defer ctrl.Finish()
+
+		b := newTestBroadcast("w1", "b1")
+		b.TestSettings.MinConfidence = 0.95
+		bcRepo.EXPECT().GetBroadcast(ctx, "w1", "b1").Return(b, nil)
+
+		// 40% vs 30% open rate on 1000 delivered emails each, z ≈ 4.69
+		msgRepo.EXPECT().GetBroadcastVariationStatsAll(ctx, "w1", "b1", []string{"tplA", "tplB"}).Return(map[string]*domain.MessageHistoryStatusSum{"tplA": {TotalDelivered: 1000, TotalOpened: 400}, "tplB": {TotalDelivered: 1000, TotalOpened: 300}}, nil)
+		expectWinner(t, ctx, bcRepo, "w1", "tplA")
+
+		evaluation, err := evaluator.EvaluateAndSelectWinner(ctx, "w1", "b1")
+		require.NoError(t, err)
+		assert.Equal(t, "tplA", evaluation.WinnerTemplateID)
+		assert.Equal(t, "tplB", evaluation.RunnerUpTemplateID)
+		require.NotNil(t, evaluation.PValue)
+		assert.Less(t, *evaluation.PValue, 0.001)
+	})
+
+	t.Run("inconclusive difference keeps the test completed", func(t *testing.T) {
+		ctrl, msgRepo, bcRepo, _, evaluator := setupEvaluator(t)
+		defer ctrl.Finish()
+
+		b := newTestBroadcast("w1", "b1")
+		b.TestSettings.MinConfidence = 0.95
+		bcRepo.EXPECT().GetBroadcast(ctx, "w1", "b1").Return(b, nil)
+
+		// The same rates on 100 delivered emails each, z ≈ 1.48
+		msgRepo.EXPECT().GetBroadcastVariationStatsAll(ctx, "w1", "b1", []string{"tplA", "tplB"}).Return(map[string]*domain.MessageHistoryStatusSum{"tplA": {TotalDelivered: 100, TotalOpened: 40}, "tplB": {TotalDelivered: 100, TotalOpened: 30}}, nil)
+
+		// No transaction is expected, the broadcast is not updated
+		evaluation, err := evaluator.EvaluateAndSelectWinner(ctx, "w1", "b1")
+		require.NoError(t, err)
+		assert.Empty(t, evaluation.WinnerTemplateID)
+		assert.Equal(t, "tplA", evaluation.LeaderTemplateID)
+		assert.Equal(t, "tplB", evaluation.RunnerUpTemplateID)
+		require.NotNil(t, evaluation.PValue)
+		assert.InDelta(t, 0.138, *evaluation.PValue, 0.001)
+		assert.Equal(t, domain.BroadcastStatusTestCompleted, b.Status)
+	})
+
+	t.Run("without min confidence no p-value is computed", func(t *testing.T) {
+		ctrl, msgRepo, bcRepo, _, evaluator := setupEvaluator(t)
+		defer ctrl.Finish()
+
+		b := newTestBroadcast("w1", "b1")
+		bcRepo.EXPECT().GetBroadcast(ctx, "w1", "b1").Return(b, nil)
+
+		msgRepo.EXPECT().GetBroadcastVariationStatsAll(ctx, "w1", "b1", []string{"tplA", "tplB"}).Return(map[string]*domain.MessageHistoryStatusSum{"tplA": {TotalDelivered: 100, TotalOpened: 40}, "tplB": {TotalDelivered: 100, TotalOpened: 30}}, nil)
+		expectWinner(t, ctx, bcRepo, "w1", "tplA")
+
+		evaluation, err := evaluator.EvaluateAndSelectWinner(ctx, "w1", "b1")
+		require.NoError(t, err)
+		assert.Equal(t, "tplA", evaluation.WinnerTemplateID)
+		assert.Nil(t, evaluation.PValue)
+	})
+}
+
+func TestTwoProportionPValue(t *testing.T) {
+	// z = 1.96 is the usual 95% two-sided threshold
+	assert.InDelta(t, 0.05, twoProportionPValue(1062, 2000, 1000, 2000), 0.005)
+	assert.Equal(t, 1.0, twoProportionPValue(30, 100, 30, 100))
+	assert.Equal(t, 1.0, twoProportionPValue(0, 100, 0, 100))
+	assert.Equal(t, 1.0, twoProportionPValue(0, 0, 10, 100))
+}
@@ -0,0 +1,87 @@
+package broadcast
+
+import (
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+)
+
+const (
+	// adaptiveBatchTargetShare is the share of MaxProcessTime a batch should take at most,
+	// so that several batches fit in a task execution
+	adaptiveBatchTargetShare = 5
+	// adaptiveBatchMaxFailureRate is the share of failed recipients above which a batch is shrunk
+	adaptiveBatchMaxFailureRate = 0.1
+)
+
+// adaptiveBatchTarget returns the time a batch should stay under
+func (c *Config) adaptiveBatchTarget() time.Duration {
+	return c.MaxProcessTime / adaptiveBatchTargetShare
+}
+
+// clampAdaptiveBatchSize keeps a batch size within the adaptive bounds
+func (c *Config) clampAdaptiveBatchSize(size int) int {
+	if c.AdaptiveBatchMaxSize > 0 && size > c.AdaptiveBatchMaxSize {
+		size = c.AdaptiveBatchMaxSize
+	}
+	if size < c.AdaptiveBatchMinSize {
+		size = c.AdaptiveBatchMinSize
+	}
+	if size < 1 {
+		size = 1
+	}
+	return size
+}
+
+// nextAdaptiveBatchSize returns the batch size to use after a batch of the current size sent
+// attempted recipients in latency, failed of them failing. A slow batch or one with many failures
+// halves the size, a batch well under the target grows it by half.
+func (c *Config) nextAdaptiveBatchSize(current, attempted, failed int, latency time.Duration) int {
+	if attempted <= 0 {
+		return current
+	}
+
+	// A partial batch (end of the audience, throttling, quota) is projected to the full size
+	projected := latency * time.Duration(current) / time.Duration(attempted)
+	target := c.adaptiveBatchTarget()
+
+	next := current
+	switch {
+	case float64(failed)/float64(attempted) > adaptiveBatchMaxFailureRate || projected > target:
+		next = current / 2
+	case projected < target/2:
+		next = current + max(1, current/2)
+	}
+	return c.clampAdaptiveBatchSize(next)
+}
+
+// fetchBatchSize returns the number of recipients to fetch for the next batch of a broadcast
+func (o *BroadcastOrchestrator) fetchBatchSize(state *domain.SendBroadcastState) int {
+	if !o.config.AdaptiveBatchSize {
+		return o.config.FetchBatchSize
+	}
+	if state.AdaptiveBatchSize == 0 {
+		state.AdaptiveBatchSize = o.config.clampAdaptiveBatchSize(o.config.FetchBatchSize)
+	}
+	return state.AdaptiveBatchSize
+}
+
+// tuneBatchSize adjusts the adaptive batch size of a broadcast from the batch just sent,
+// the tuned size is saved with the broadcast state and carries across resumes
+func (o *BroadcastOrchestrator) tuneBatchSize(state *domain.SendBroadcastState, attempted, failed int, latency time.Duration) {
+	if state.AdaptiveBatchSize == 0 {
+		return
+	}
+
+	next := o.config.nextAdaptiveBatchSize(state.AdaptiveBatchSize, attempted, failed, latency)
+	if next != state.AdaptiveBatchSize {
+		o.logger.WithFields(map[string]interface{}{
+			"broadcast_id":    state.BroadcastID,
+			"batch_size":      state.AdaptiveBatchSize,
+			"next_batch_size": next,
+			"latency":         latency.String(),
+			"failed":          failed,
+		}).Debug("Adjusted adaptive broadcast batch size")
+	}
+	state.AdaptiveBatchSize = next
+}
@@ -0,0 +1,97 @@
+package broadcast
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	pkgmocks "github.com/Notifuse/notifuse/pkg/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func adaptiveBatchTestConfig() *Config {
+	return &Config{
+		FetchBatchSize:       100,
+		MaxProcessTime:       50 * time.Second, // batches target 10s
+		AdaptiveBatchSize:    true,
+		AdaptiveBatchMinSize: 10,
+		AdaptiveBatchMaxSize: 500,
+	}
+}
+
+// converge runs batches of a provider answering in perRecipient per message
+func converge(config *Config, size int, perRecipient time.Duration, batches int) int {
+	for i := 0; i < batches; i++ {
+		size = config.nextAdaptiveBatchSize(size, size, 0, time.Duration(size)*perRecipient)
+	}
+	return size
+}
+
+func TestNextAdaptiveBatchSize_Converges(t *testing.T) {
+	config := adaptiveBatchTestConfig()
+
+	t.Run("fast provider grows up to the max", func(t *testing.T) {
+		assert.Equal(t, 500, converge(config, 100, 5*time.Millisecond, 10))
+	})
+
+	t.Run("slow provider shrinks under the target", func(t *testing.T) {
+		// 200ms per message fits 50 messages in 10s
+		size := converge(config, 100, 200*time.Millisecond, 10)
+		assert.LessOrEqual(t, size, 50)
+		assert.GreaterOrEqual(t, size, 25)
+	})
+
+	t.Run("very slow provider stops at the min", func(t *testing.T) {
+		assert.Equal(t, 10, converge(config, 100, 5*time.Second, 10))
+	})
+
+	t.Run("steady within the target band", func(t *testing.T) {
+		assert.Equal(t, 100, config.nextAdaptiveBatchSize(100, 100, 0, 7*time.Second))
+	})
+}
+
+func TestNextAdaptiveBatchSize_Failures(t *testing.T) {
+	config := adaptiveBatchTestConfig()
+
+	// A fast batch with many failures is still shrunk
+	assert.Equal(t, 50, config.nextAdaptiveBatchSize(100, 100, 20, time.Second))
+	// A few failures don't prevent growing
+	assert.Equal(t, 150, config.nextAdaptiveBatchSize(100, 100, 5, time.Second))
+}
+
+func TestNextAdaptiveBatchSize_PartialBatch(t *testing.T) {
+	config := adaptiveBatchTestConfig()
+
+	// 10 recipients in 2s projects to 20s for the full batch of 100
+	assert.Equal(t, 50, config.nextAdaptiveBatchSize(100, 10, 0, 2*time.Second))
+	// Nothing attempted keeps the size
+	assert.Equal(t, 100, config.nextAdaptiveBatchSize(100, 0, 0, 0))
+}
+
+func TestBroadcastOrchestrator_AdaptiveBatchSizeState(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
+
+	o := &BroadcastOrchestrator{config: adaptiveBatchTestConfig(), logger: mockLogger}
+
+	// The first batch starts from the fetch batch size and the tuned size is kept in the state
+	state := &domain.SendBroadcastState{BroadcastID: "b1"}
+	assert.Equal(t, 100, o.fetchBatchSize(state))
+	o.tuneBatchSize(state, 100, 0, time.Second)
+	assert.Equal(t, 150, state.AdaptiveBatchSize)
+	assert.Equal(t, 150, o.fetchBatchSize(state))
+
+	// A resumed task continues from its saved size
+	resumed := &domain.SendBroadcastState{BroadcastID: "b1", AdaptiveBatchSize: 320}
+	assert.Equal(t, 320, o.fetchBatchSize(resumed))
+
+	// Disabled, the fetch batch size is used and the state is left untouched
+	o.config.AdaptiveBatchSize = false
+	assert.Equal(t, 100, o.fetchBatchSize(resumed))
+	assert.Equal(t, 100, o.fetchBatchSize(&domain.SendBroadcastState{}))
+}
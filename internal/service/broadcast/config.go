@@ -22,26 +22,61 @@ type Config struct {
 
 	// Rate limiting
 	DefaultRateLimit int `json:"default_rate_limit"` // Emails per minute (fallback when broadcast doesn't specify rate limit)
+	// MaxSendsPerSecond caps how many recipients the orchestrator hands to SendBatch over any one second,
+	// 0 disables throttling. Broadcasts can override it with Schedule.MaxSendsPerSecond.
+	MaxSendsPerSecond int `json:"max_sends_per_second"`
 
 	// Retry settings
 	MaxRetries    int           `json:"max_retries"`
 	RetryInterval time.Duration `json:"retry_interval"`
+
+	// Retries of the final broadcast status write, so that a transient database error
+	// does not fail a broadcast whose messages were all enqueued
+	StatusUpdateRetries      int           `json:"status_update_retries"`
+	StatusUpdateRetryBackoff time.Duration `json:"status_update_retry_backoff"` // Doubled on each retry
+
+	// Retries of the recipients a batch left unsent because of a transient provider error (5xx, throttling).
+	// The backoff doubles on each retry up to BatchRetryMaxBackoff, and BatchRetryJitter is the fraction
+	// of it that is randomized so that concurrent broadcasts do not retry in lockstep.
+	BatchRetries         int           `json:"batch_retries"`
+	BatchRetryBackoff    time.Duration `json:"batch_retry_backoff"`
+	BatchRetryMaxBackoff time.Duration `json:"batch_retry_max_backoff"`
+	BatchRetryJitter     float64       `json:"batch_retry_jitter"`
+
+	// RenderTimeout bounds the time spent rendering a single recipient's message. A render exceeding it
+	// fails that recipient instead of blocking the batch, 0 disables the limit.
+	RenderTimeout time.Duration `json:"render_timeout"`
+
+	// AdaptiveBatchSize replaces FetchBatchSize with a batch size tuned between AdaptiveBatchMinSize
+	// and AdaptiveBatchMaxSize from the latency and the failures of the batches sent
+	AdaptiveBatchSize    bool `json:"adaptive_batch_size"`
+	AdaptiveBatchMinSize int  `json:"adaptive_batch_min_size"`
+	AdaptiveBatchMaxSize int  `json:"adaptive_batch_max_size"`
 }
 
 // DefaultConfig returns a configuration with sensible defaults
 func DefaultConfig() *Config {
 	return &Config{
-		MaxParallelism:          10,
-		MaxProcessTime:          50 * time.Second,
-		FetchBatchSize:          50,
-		ProcessBatchSize:        25,
-		ProgressLogInterval:     5 * time.Second,
-		EnableCircuitBreaker:    true,
-		CircuitBreakerThreshold: 5,
-		CircuitBreakerCooldown:  1 * time.Minute,
-		DefaultRateLimit:        25, // 25 per minute
-		MaxRetries:              3,
-		RetryInterval:           30 * time.Second,
+		MaxParallelism:           10,
+		MaxProcessTime:           50 * time.Second,
+		FetchBatchSize:           50,
+		ProcessBatchSize:         25,
+		ProgressLogInterval:      5 * time.Second,
+		EnableCircuitBreaker:     true,
+		CircuitBreakerThreshold:  5,
+		CircuitBreakerCooldown:   1 * time.Minute,
+		DefaultRateLimit:         25, // 25 per minute
+		MaxRetries:               3,
+		RetryInterval:            30 * time.Second,
+		StatusUpdateRetries:      3,
+		StatusUpdateRetryBackoff: 500 * time.Millisecond,
+		BatchRetries:             3,
+		BatchRetryBackoff:        time.Second,
+		BatchRetryMaxBackoff:     10 * time.Second,
+		BatchRetryJitter:         0.5,
+		RenderTimeout:            10 * time.Second,
+		AdaptiveBatchMinSize:     10,
+		AdaptiveBatchMaxSize:     500,
 	}
 }
 
@@ -49,16 +84,46 @@ func DefaultConfig() *Config {
 // with rate limiting disabled for speed
 func TestConfig() *Config {
 	return &Config{
-		MaxParallelism:          10,
-		MaxProcessTime:          50 * time.Second,
-		FetchBatchSize:          50,
-		ProcessBatchSize:        25,
-		ProgressLogInterval:     5 * time.Second,
-		EnableCircuitBreaker:    true,
-		CircuitBreakerThreshold: 5,
-		CircuitBreakerCooldown:  1 * time.Minute,
-		DefaultRateLimit:        6000, // 6000 per minute = 100/sec (effectively no rate limiting for tests)
-		MaxRetries:              3,
-		RetryInterval:           30 * time.Second,
+		MaxParallelism:           10,
+		MaxProcessTime:           50 * time.Second,
+		FetchBatchSize:           50,
+		ProcessBatchSize:         25,
+		ProgressLogInterval:      5 * time.Second,
+		EnableCircuitBreaker:     true,
+		CircuitBreakerThreshold:  5,
+		CircuitBreakerCooldown:   1 * time.Minute,
+		DefaultRateLimit:         6000, // 6000 per minute = 100/sec (effectively no rate limiting for tests)
+		MaxRetries:               3,
+		RetryInterval:            30 * time.Second,
+		StatusUpdateRetries:      3,
+		StatusUpdateRetryBackoff: time.Millisecond,
+		BatchRetries:             3,
+		BatchRetryBackoff:        time.Millisecond,
+		BatchRetryMaxBackoff:     5 * time.Millisecond,
+		BatchRetryJitter:         0.5,
+		RenderTimeout:            10 * time.Second,
+		AdaptiveBatchMinSize:     10,
+		AdaptiveBatchMaxSize:     500,
+	}
+}
+
+// BatchRetryDelay returns the delay before the given batch retry (0 for the first one).
+// random is a value in [0, 1) used to randomize the jitter part of the backoff.
+func (c *Config) BatchRetryDelay(attempt int, random float64) time.Duration {
+	delay := c.BatchRetryBackoff
+	for i := 0; i < attempt && (c.BatchRetryMaxBackoff <= 0 || delay < c.BatchRetryMaxBackoff); i++ {
+		delay *= 2
+	}
+	if c.BatchRetryMaxBackoff > 0 && delay > c.BatchRetryMaxBackoff {
+		delay = c.BatchRetryMaxBackoff
+	}
+
+	jitter := c.BatchRetryJitter
+	if jitter < 0 {
+		jitter = 0
+	} else if jitter > 1 {
+		jitter = 1
 	}
+	// Keep the fixed part of the backoff and randomize the rest
+	return delay - time.Duration(float64(delay)*jitter*random)
 }
@@ -26,3 +26,25 @@ func TestDefaultConfig(t *testing.T) {
 	assert.Equal(t, 3, config.MaxRetries)
 	assert.Equal(t, 30*time.Second, config.RetryInterval)
 }
+
+func TestConfig_BatchRetryDelay(t *testing.T) {
+	config := &broadcast.Config{
+		BatchRetryBackoff:    time.Second,
+		BatchRetryMaxBackoff: 5 * time.Second,
+		BatchRetryJitter:     0.5,
+	}
+
+	// Without randomness the full backoff is used, doubling up to the maximum
+	assert.Equal(t, time.Second, config.BatchRetryDelay(0, 0))
+	assert.Equal(t, 2*time.Second, config.BatchRetryDelay(1, 0))
+	assert.Equal(t, 4*time.Second, config.BatchRetryDelay(2, 0))
+	assert.Equal(t, 5*time.Second, config.BatchRetryDelay(3, 0))
+	assert.Equal(t, 5*time.Second, config.BatchRetryDelay(10, 0))
+
+	// Jitter randomizes at most its fraction of the backoff
+	assert.Equal(t, 1500*time.Millisecond, config.BatchRetryDelay(1, 0.5))
+	assert.Greater(t, config.BatchRetryDelay(1, 0.999), time.Second)
+
+	config.BatchRetryJitter = 0
+	assert.Equal(t, 2*time.Second, config.BatchRetryDelay(1, 0.9))
+}
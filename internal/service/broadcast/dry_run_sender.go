@@ -0,0 +1,127 @@
+package broadcast
+
+import (
+	"context"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/pkg/logger"
+	"github.com/Notifuse/notifuse/pkg/notifuse_mjml"
+)
+
+// dryRunMessageSender implements the MessageSender interface for dry-run broadcasts.
+// Messages are rendered exactly like the queue sender does, then recorded in message history
+// with the dry_run status info instead of being enqueued, so nothing reaches the email provider.
+type dryRunMessageSender struct {
+	*queueMessageSender
+}
+
+// NewDryRunMessageSender creates a new message sender that records messages without delivering them
+func NewDryRunMessageSender(
+	broadcastRepo domain.BroadcastRepository,
+	messageHistoryRepo domain.MessageHistoryRepository,
+	templateRepo domain.TemplateRepository,
+	logger logger.Logger,
+	config *Config,
+	apiEndpoint string,
+) MessageSender {
+	if config == nil {
+		config = DefaultConfig()
+	}
+
+	return &dryRunMessageSender{
+		queueMessageSender: &queueMessageSender{
+			broadcastRepo:      broadcastRepo,
+			messageHistoryRepo: messageHistoryRepo,
+			templateRepo:       templateRepo,
+			logger:             logger,
+			config:             config,
+			apiEndpoint:        apiEndpoint,
+			compileTemplate:    notifuse_mjml.CompileTemplate,
+			renderCache:        newRenderCache(),
+		},
+	}
+}
+
+// SendToRecipient is not supported in dry run, the message history record needs the workspace
+// secret key that only SendBatch receives
+func (s *dryRunMessageSender) SendToRecipient(
+	ctx context.Context,
+	workspaceID string,
+	integrationID string,
+	trackingEnabled bool,
+	broadcast *domain.Broadcast,
+	messageID string,
+	email string,
+	template *domain.Template,
+	data map[string]interface{},
+	emailProvider *domain.EmailProvider,
+	timeoutAt time.Time,
+) error {
+	return NewBroadcastError(ErrCodeSendFailed, "single recipient sends are not supported in dry run", false, nil)
+}
+
+// SendBatch renders the messages of a batch of recipients and records them in message history
+func (s *dryRunMessageSender) SendBatch(
+	ctx context.Context,
+	workspaceID string,
+	integrationID string,
+	workspaceSecretKey string,
+	endpoint string,
+	trackingEnabled bool,
+	broadcastID string,
+	recipients []*domain.ContactWithList,
+	templates map[string]*domain.Template,
+	emailProvider *domain.EmailProvider,
+	timeoutAt time.Time,
+) (result domain.BatchSendResult, err error) {
+	if len(recipients) == 0 {
+		return result, nil
+	}
+
+	broadcast, entries, buildFailures, err := s.buildBatch(ctx, workspaceID, integrationID, workspaceSecretKey, endpoint, trackingEnabled, broadcastID, recipients, templates, emailProvider, timeoutAt)
+	if err != nil {
+		return failAll(recipients), err
+	}
+	result.FailedEmails = buildFailures
+
+	statusInfo := domain.MessageStatusInfoDryRun
+	for _, entry := range entries {
+		message := &domain.MessageHistory{
+			ID:              entry.MessageID,
+			ContactEmail:    entry.ContactEmail,
+			BroadcastID:     &broadcast.ID,
+			TemplateID:      entry.TemplateID,
+			TemplateVersion: int64(entry.Payload.TemplateVersion),
+			Channel:         "email",
+			StatusInfo:      &statusInfo,
+			MessageData:     domain.MessageData{Data: entry.Payload.TemplateData},
+			SentAt:          entry.CreatedAt,
+			CreatedAt:       entry.CreatedAt,
+			UpdatedAt:       entry.UpdatedAt,
+		}
+		if entry.Payload.ListID != "" {
+			message.ListID = &entry.Payload.ListID
+		}
+
+		if err := s.messageHistoryRepo.Create(ctx, workspaceID, workspaceSecretKey, message); err != nil {
+			s.logger.WithFields(map[string]interface{}{
+				"broadcast_id": broadcastID,
+				"workspace_id": workspaceID,
+				"recorded":     result.Sent(),
+				"error":        err.Error(),
+			}).Error("Failed to record dry run message")
+			return result, NewBroadcastError(ErrCodeSendFailed, "failed to record dry run message", true, err)
+		}
+		result.SentEmails = append(result.SentEmails, entry.ContactEmail)
+	}
+
+	s.logger.WithFields(map[string]interface{}{
+		"broadcast_id": broadcastID,
+		"workspace_id": workspaceID,
+		"recorded":     result.Sent(),
+		"build_errors": len(buildFailures),
+	}).Debug("Dry run batch recorded")
+
+	return result, nil
+}
@@ -0,0 +1,162 @@
+package broadcast
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/domain/mocks"
+	pkgmocks "github.com/Notifuse/notifuse/pkg/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupDryRunTest(t *testing.T) (*gomock.Controller, *mocks.MockBroadcastRepository, *mocks.MockMessageHistoryRepository, MessageSender) {
+	ctrl := gomock.NewController(t)
+
+	mockBroadcastRepo := mocks.NewMockBroadcastRepository(ctrl)
+	mockMessageHistoryRepo := mocks.NewMockMessageHistoryRepository(ctrl)
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
+
+	sender := NewDryRunMessageSender(mockBroadcastRepo, mockMessageHistoryRepo, nil, mockLogger, TestConfig(), "https://api.example.com")
+
+	return ctrl, mockBroadcastRepo, mockMessageHistoryRepo, sender
+}
+
+func dryRunTestFixtures() ([]*domain.ContactWithList, map[string]*domain.Template, *domain.EmailProvider) {
+	emailSender := domain.NewEmailSender("sender@example.com", "Test Sender")
+	emailProvider := &domain.EmailProvider{
+		Kind:    domain.EmailProviderKindSMTP,
+		Senders: []domain.EmailSender{emailSender},
+	}
+
+	templates := map[string]*domain.Template{
+		"template-1": {
+			ID:      "template-1",
+			Version: 3,
+			Email: &domain.EmailTemplate{
+				SenderID:         emailSender.ID,
+				Subject:          "Hello",
+				VisualEditorTree: createQueueValidTestTree(createQueueTestTextBlock("txt1", "Hello")),
+			},
+		},
+	}
+
+	recipients := []*domain.ContactWithList{
+		{Contact: &domain.Contact{Email: "one@example.com"}, ListID: "list-1"},
+		{Contact: &domain.Contact{Email: "two@example.com"}, ListID: "list-1"},
+		{Contact: &domain.Contact{Email: "three@example.com"}, ListID: "list-1"},
+	}
+
+	return recipients, templates, emailProvider
+}
+
+func TestDryRunMessageSender_SendBatch(t *testing.T) {
+	t.Run("Records every recipient in message history", func(t *testing.T) {
+		ctrl, mockBroadcastRepo, mockMessageHistoryRepo, sender := setupDryRunTest(t)
+		defer ctrl.Finish()
+
+		recipients, templates, emailProvider := dryRunTestFixtures()
+
+		mockBroadcastRepo.EXPECT().GetBroadcast(gomock.Any(), "workspace-1", "broadcast-1").
+			Return(&domain.Broadcast{ID: "broadcast-1", WorkspaceID: "workspace-1", DryRun: true}, nil)
+
+		var recorded []*domain.MessageHistory
+		mockMessageHistoryRepo.EXPECT().Create(gomock.Any(), "workspace-1", "secret-key", gomock.Any()).
+			DoAndReturn(func(_ context.Context, _, _ string, message *domain.MessageHistory) error {
+				recorded = append(recorded, message)
+				return nil
+			}).Times(3)
+
+		result, err := sender.SendBatch(
+			context.Background(),
+			"workspace-1",
+			"integration-1",
+			"secret-key",
+			"https://api.example.com",
+			false,
+			"broadcast-1",
+			recipients,
+			templates,
+			emailProvider,
+			time.Now().Add(5*time.Minute),
+		)
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, result.Sent())
+		assert.Equal(t, 0, result.Failed())
+		require.Len(t, recorded, 3)
+		for i, message := range recorded {
+			assert.Equal(t, recipients[i].Contact.Email, message.ContactEmail)
+			require.NotNil(t, message.StatusInfo)
+			assert.Equal(t, domain.MessageStatusInfoDryRun, *message.StatusInfo)
+			require.NotNil(t, message.BroadcastID)
+			assert.Equal(t, "broadcast-1", *message.BroadcastID)
+			require.NotNil(t, message.ListID)
+			assert.Equal(t, "list-1", *message.ListID)
+			assert.Equal(t, "template-1", message.TemplateID)
+			assert.NotEmpty(t, message.ID)
+		}
+	})
+
+	t.Run("Empty batch does nothing", func(t *testing.T) {
+		ctrl, _, _, sender := setupDryRunTest(t)
+		defer ctrl.Finish()
+
+		result, err := sender.SendBatch(context.Background(), "workspace-1", "integration-1", "secret-key", "https://api.example.com", false, "broadcast-1", nil, nil, nil, time.Now().Add(time.Minute))
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, result.Sent())
+		assert.Equal(t, 0, result.Failed())
+	})
+
+	t.Run("Recording failure leaves the remaining recipients for a retry", func(t *testing.T) {
+		ctrl, mockBroadcastRepo, mockMessageHistoryRepo, sender := setupDryRunTest(t)
+		defer ctrl.Finish()
+
+		recipients, templates, emailProvider := dryRunTestFixtures()
+
+		mockBroadcastRepo.EXPECT().GetBroadcast(gomock.Any(), "workspace-1", "broadcast-1").
+			Return(&domain.Broadcast{ID: "broadcast-1", WorkspaceID: "workspace-1", DryRun: true}, nil)
+		gomock.InOrder(
+			mockMessageHistoryRepo.EXPECT().Create(gomock.Any(), "workspace-1", "secret-key", gomock.Any()).Return(nil),
+			mockMessageHistoryRepo.EXPECT().Create(gomock.Any(), "workspace-1", "secret-key", gomock.Any()).Return(errors.New("db error")),
+		)
+
+		result, err := sender.SendBatch(
+			context.Background(),
+			"workspace-1",
+			"integration-1",
+			"secret-key",
+			"https://api.example.com",
+			false,
+			"broadcast-1",
+			recipients,
+			templates,
+			emailProvider,
+			time.Now().Add(5*time.Minute),
+		)
+
+		require.Error(t, err)
+		assert.Equal(t, []string{"one@example.com"}, result.SentEmails)
+		assert.Empty(t, result.FailedEmails)
+		var broadcastErr *BroadcastError
+		require.ErrorAs(t, err, &broadcastErr)
+		assert.True(t, broadcastErr.Retryable)
+	})
+}
+
+func TestDryRunMessageSender_SendToRecipient(t *testing.T) {
+	ctrl, _, _, sender := setupDryRunTest(t)
+	defer ctrl.Finish()
+
+	err := sender.SendToRecipient(context.Background(), "workspace-1", "integration-1", false, &domain.Broadcast{ID: "broadcast-1"}, "message-1", "one@example.com", nil, nil, nil, time.Now().Add(time.Minute))
+
+	require.Error(t, err)
+}
@@ -0,0 +1,43 @@
+package broadcast
+
+import (
+	"github.com/Notifuse/notifuse/internal/domain"
+)
+
+// skipDuplicateRecipients leaves out the recipients already sent earlier in the task execution, e.g. a contact
+// that reappears in a later batch after changes to the audience, and the repeats of an email within the batch.
+// The recipients sent by earlier executions are left out against the message history when the task resumes.
+// It returns the recipients to send and the number of duplicates left out.
+func skipDuplicateRecipients(recipients []*domain.ContactWithList, sentInRun map[string]bool) ([]*domain.ContactWithList, int) {
+	inBatch := make(map[string]bool, len(recipients))
+	duplicates := make(map[*domain.ContactWithList]bool)
+	for _, recipient := range recipients {
+		if recipient.Contact == nil {
+			continue
+		}
+		email := recipient.Contact.Email
+		if inBatch[email] || sentInRun[email] {
+			duplicates[recipient] = true
+		}
+		inBatch[email] = true
+	}
+
+	if len(duplicates) == 0 {
+		return recipients, 0
+	}
+
+	toSend := make([]*domain.ContactWithList, 0, len(recipients)-len(duplicates))
+	for _, recipient := range recipients {
+		if !duplicates[recipient] {
+			toSend = append(toSend, recipient)
+		}
+	}
+	return toSend, len(duplicates)
+}
+
+// recordSentEmails adds the emails sent by a batch to the emails sent by this execution
+func recordSentEmails(sentInRun map[string]bool, result domain.BatchSendResult) {
+	for _, email := range result.SentEmails {
+		sentInRun[email] = true
+	}
+}
@@ -1,16 +1,22 @@
 package broadcast
 
-import "fmt"
+import (
+	"fmt"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/pkg/emailerror"
+)
 
 // ErrorCode represents specific error conditions in the broadcast system
 type ErrorCode string
 
 const (
 	// Template related errors
-	ErrCodeTemplateMissing ErrorCode = "TEMPLATE_MISSING"
-	ErrCodeTemplateInvalid ErrorCode = "TEMPLATE_INVALID"
-	ErrCodeTemplateCompile ErrorCode = "TEMPLATE_COMPILE_FAILED"
-	ErrCodeSenderNotFound  ErrorCode = "SENDER_NOT_FOUND"
+	ErrCodeTemplateMissing  ErrorCode = "TEMPLATE_MISSING"
+	ErrCodeTemplateInvalid  ErrorCode = "TEMPLATE_INVALID"
+	ErrCodeTemplateCompile  ErrorCode = "TEMPLATE_COMPILE_FAILED"
+	ErrCodeSenderNotFound   ErrorCode = "SENDER_NOT_FOUND"
+	ErrCodeSenderUnverified ErrorCode = "SENDER_UNVERIFIED"
 
 	// Recipient related errors
 	ErrCodeRecipientFetch ErrorCode = "RECIPIENT_FETCH_FAILED"
@@ -19,16 +25,19 @@ const (
 	// Broadcast related errors
 	ErrCodeBroadcastNotFound ErrorCode = "BROADCAST_NOT_FOUND"
 	ErrCodeBroadcastInvalid  ErrorCode = "BROADCAST_INVALID"
+	ErrCodeBroadcastNotDue   ErrorCode = "BROADCAST_NOT_DUE"
 
 	// Sending related errors
 	ErrCodeSendFailed        ErrorCode = "SEND_FAILED"
 	ErrCodeRateLimitExceeded ErrorCode = "RATE_LIMIT_EXCEEDED"
 	ErrCodeCircuitOpen       ErrorCode = "CIRCUIT_OPEN"
+	ErrCodeQuotaExceeded     ErrorCode = "QUOTA_EXCEEDED"
 
 	// Task related errors
 	ErrCodeTaskStateInvalid   ErrorCode = "TASK_STATE_INVALID"
 	ErrCodeTaskTimeout        ErrorCode = "TASK_TIMEOUT"
 	ErrCodeBroadcastCancelled ErrorCode = "BROADCAST_CANCELLED"
+	ErrCodeBroadcastPaused    ErrorCode = "BROADCAST_PAUSED"
 )
 
 // BroadcastError represents an error in the broadcast system with context
@@ -91,3 +100,30 @@ func IsRetryable(err error) bool {
 	}
 	return false
 }
+
+// sendErrorClassifier classifies the errors returned by the email providers
+var sendErrorClassifier = emailerror.NewClassifier()
+
+// RetryableError reports whether an error returned by an email provider is transient, such as
+// throttling or a 5xx response, so that sending again after a backoff may succeed. Recipient errors
+// (invalid or inactive address) and unclassified errors are permanent.
+func RetryableError(err error, provider domain.EmailProviderKind) bool {
+	if err == nil {
+		return false
+	}
+	classified := sendErrorClassifier.Classify(err, provider)
+	return classified.Retryable && classified.Type == emailerror.ErrorTypeProvider
+}
+
+// isSenderError reports whether a broadcast failed validation because a template sender doesn't resolve to
+// a verified sender identity, which no retry fixes until the sender is verified
+func isSenderError(err error) bool {
+	broadcastErr, ok := err.(*BroadcastError)
+	return ok && !broadcastErr.Retryable && (broadcastErr.Code == ErrCodeSenderUnverified || broadcastErr.Code == ErrCodeSenderNotFound)
+}
+
+// isTransientSendError reports whether a send error left recipients that can be sent again after a backoff
+func isTransientSendError(err error) bool {
+	broadcastErr, ok := err.(*BroadcastError)
+	return ok && broadcastErr.Code == ErrCodeSendFailed && broadcastErr.Retryable
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/Notifuse/notifuse/internal/domain"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -123,6 +124,16 @@ func TestIsRetryable(t *testing.T) {
 			},
 			expected: false,
 		},
+		{
+			name: "Broadcast paused error",
+			err: &BroadcastError{
+				Code:      ErrCodeBroadcastPaused,
+				Message:   "Broadcast paused",
+				Retryable: false,
+				Err:       nil,
+			},
+			expected: false,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -132,3 +143,17 @@ func TestIsRetryable(t *testing.T) {
 		})
 	}
 }
+
+func TestRetryableError(t *testing.T) {
+	// Throttling and server errors are transient
+	assert.True(t, RetryableError(errors.New("ThrottlingException: Maximum sending rate exceeded"), domain.EmailProviderKindSES))
+	assert.True(t, RetryableError(errors.New("request failed with status code: 503"), domain.EmailProviderKindMailgun))
+	assert.True(t, RetryableError(errors.New("API error (429): too many requests"), domain.EmailProviderKindPostmark))
+
+	// Recipient errors are permanent
+	assert.False(t, RetryableError(errors.New("MessageRejected: invalid recipient"), domain.EmailProviderKindSES))
+
+	// Unclassified errors are not retried
+	assert.False(t, RetryableError(errors.New("something went wrong"), domain.EmailProviderKindSES))
+	assert.False(t, RetryableError(nil, domain.EmailProviderKindSES))
+}
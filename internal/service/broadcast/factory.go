@@ -20,6 +20,10 @@ type Factory struct {
 	apiEndpoint        string
 	eventBus           domain.EventBus
 	useQueueSender     bool
+	linkShortener      domain.LinkShortenerService
+	audienceRepo       domain.BroadcastAudienceRepository
+	smsProviders       map[domain.SMSProviderKind]domain.SMSProviderService
+	shutdownNotice     *ShutdownNotice
 }
 
 // NewFactory creates a new factory for broadcast components
@@ -59,12 +63,32 @@ func NewFactory(
 	}
 }
 
+// SetLinkShortener sets the service shortening click-tracked links of queued broadcast emails
+func (f *Factory) SetLinkShortener(linkShortener domain.LinkShortenerService) {
+	f.linkShortener = linkShortener
+}
+
+// SetAudienceRepository sets the repository of the uploaded recipients of broadcasts with a CSV audience
+func (f *Factory) SetAudienceRepository(audienceRepo domain.BroadcastAudienceRepository) {
+	f.audienceRepo = audienceRepo
+}
+
+// SetSMSProviders sets the services sending the messages of broadcasts on the sms channel
+func (f *Factory) SetSMSProviders(smsProviders map[domain.SMSProviderKind]domain.SMSProviderService) {
+	f.smsProviders = smsProviders
+}
+
+// SetShutdownNotice sets the notice signaling the server shutdown to running broadcasts
+func (f *Factory) SetShutdownNotice(shutdownNotice *ShutdownNotice) {
+	f.shutdownNotice = shutdownNotice
+}
+
 // CreateMessageSender creates a new message sender
 // If useQueueSender is true, it creates a queue-based sender that enqueues emails
 // for processing by the queue worker. Otherwise, it creates a direct sender.
 func (f *Factory) CreateMessageSender() MessageSender {
 	if f.useQueueSender && f.emailQueueRepo != nil {
-		return NewQueueMessageSender(
+		sender := NewQueueMessageSender(
 			f.emailQueueRepo,
 			f.broadcastRepo,
 			f.messageHistoryRepo,
@@ -72,7 +96,10 @@ func (f *Factory) CreateMessageSender() MessageSender {
 			f.logger,
 			f.config,
 			f.apiEndpoint,
-		)
+		).(*queueMessageSender)
+		sender.linkShortener = f.linkShortener
+		sender.workspaceRepo = f.workspaceRepo
+		return sender
 	}
 
 	return NewMessageSender(
@@ -98,7 +125,7 @@ func (f *Factory) CreateOrchestrator() BroadcastOrchestratorInterface {
 		f.logger,
 	)
 
-	return NewBroadcastOrchestrator(
+	orchestrator := NewBroadcastOrchestrator(
 		messageSender,
 		f.broadcastRepo,
 		f.templateRepo,
@@ -111,7 +138,30 @@ func (f *Factory) CreateOrchestrator() BroadcastOrchestratorInterface {
 		timeProvider,
 		f.apiEndpoint,
 		f.eventBus,
+	).(*BroadcastOrchestrator)
+	orchestrator.messageHistoryRepo = f.messageHistoryRepo
+	orchestrator.audienceRepo = f.audienceRepo
+	orchestrator.shutdown = f.shutdownNotice
+	// Links of dry-run messages are not shortened, no short link is created for them
+	orchestrator.dryRunSender = NewDryRunMessageSender(
+		f.broadcastRepo,
+		f.messageHistoryRepo,
+		f.templateRepo,
+		f.logger,
+		f.config,
+		f.apiEndpoint,
 	)
+	if len(f.smsProviders) > 0 {
+		orchestrator.smsSender = NewSMSMessageSender(
+			f.broadcastRepo,
+			f.messageHistoryRepo,
+			f.workspaceRepo,
+			f.smsProviders,
+			f.logger,
+			f.config,
+		)
+	}
+	return orchestrator
 }
 
 // RegisterWithTaskService registers the orchestrator with the task service
@@ -24,9 +24,27 @@ type MessageSender interface {
 	SendToRecipient(ctx context.Context, workspaceID string, integrationID string, trackingEnabled bool, broadcast *domain.Broadcast, messageID string, email string,
 		template *domain.Template, data map[string]interface{}, emailProvider *domain.EmailProvider, timeoutAt time.Time) error
 
-	// SendBatch sends messages to a batch of recipients
+	// SendBatch sends messages to a batch of recipients. The result lists the recipients that were
+	// processed, including when an error stops the batch midway, so that only the others are sent again.
 	SendBatch(ctx context.Context, workspaceID string, integrationID string, workspaceSecretKey string, endpoint string, trackingEnabled bool, broadcastID string, recipients []*domain.ContactWithList,
-		templates map[string]*domain.Template, emailProvider *domain.EmailProvider, timeoutAt time.Time) (sent int, failed int, err error)
+		templates map[string]*domain.Template, emailProvider *domain.EmailProvider, timeoutAt time.Time) (result domain.BatchSendResult, err error)
+}
+
+// failAll returns the result of a batch whose recipients all failed
+func failAll(recipients []*domain.ContactWithList) domain.BatchSendResult {
+	result := domain.BatchSendResult{FailedEmails: make([]string, 0, len(recipients))}
+	for _, recipient := range recipients {
+		result.FailedEmails = append(result.FailedEmails, recipientEmail(recipient))
+	}
+	return result
+}
+
+// recipientEmail returns the email of a recipient, empty when it has no contact
+func recipientEmail(recipient *domain.ContactWithList) string {
+	if recipient == nil || recipient.Contact == nil {
+		return ""
+	}
+	return recipient.Contact.Email
 }
 
 // CircuitBreaker provides circuit breaking functionality
@@ -248,42 +266,63 @@ func (s *messageSender) SendToRecipient(ctx context.Context, workspaceID string,
 		WorkspaceID:    workspaceID,
 		MessageID:      messageID,
 	}
+	// The template may enable or disable open and click tracking on its own
+	template.Email.ApplyTrackingOverrides(&trackingSettings)
 
-	// Compile template with the provided data
-	compiledTemplate, err := notifuse_mjml.CompileTemplate(
-		notifuse_mjml.CompileTemplateRequest{
-			WorkspaceID:      workspaceID,
-			MessageID:        messageID,
-			VisualEditorTree: template.Email.VisualEditorTree,
-			TemplateData:     data,
-			TrackingSettings: trackingSettings,
-		},
-	)
+	var content string
+	if broadcast.PlainTextOnly {
+		// Plain text only broadcasts are sent without HTML part, hence without tracking pixel
+		text, err := renderPlainText(template, data, trackingSettings, notifuse_mjml.CompileTemplate)
+		if err != nil {
+			s.logger.WithFields(map[string]interface{}{
+				"broadcast_id": broadcast.ID,
+				"workspace_id": workspaceID,
+				"recipient":    email,
+				"template_id":  template.ID,
+				"error":        err.Error(),
+			}).Error("Failed to render plain text from template")
+			return NewBroadcastError(ErrCodeTemplateCompile, "failed to render plain text", true, err)
+		}
+		content = text
+	} else {
+		// Compile template with the provided data
+		compiledTemplate, err := notifuse_mjml.CompileTemplate(
+			notifuse_mjml.CompileTemplateRequest{
+				WorkspaceID:      workspaceID,
+				MessageID:        messageID,
+				VisualEditorTree: template.Email.VisualEditorTree,
+				TemplateData:     data,
+				TrackingSettings: trackingSettings,
+				RawMergeFields:   template.Email.RawMergeFields,
+			},
+		)
 
-	if err != nil {
-		s.logger.WithFields(map[string]interface{}{
-			"broadcast_id": broadcast.ID,
-			"workspace_id": workspaceID,
-			"recipient":    email,
-			"template_id":  template.ID,
-			"error":        err.Error(),
-		}).Error("Failed to compile template")
-		return NewBroadcastError(ErrCodeTemplateCompile, "failed to compile template", true, err)
-	}
+		if err != nil {
+			s.logger.WithFields(map[string]interface{}{
+				"broadcast_id": broadcast.ID,
+				"workspace_id": workspaceID,
+				"recipient":    email,
+				"template_id":  template.ID,
+				"error":        err.Error(),
+			}).Error("Failed to compile template")
+			return NewBroadcastError(ErrCodeTemplateCompile, "failed to compile template", true, err)
+		}
 
-	if !compiledTemplate.Success || compiledTemplate.HTML == nil {
-		errMsg := "Template compilation failed"
-		if compiledTemplate.Error != nil {
-			errMsg = compiledTemplate.Error.Message
+		if !compiledTemplate.Success || compiledTemplate.HTML == nil {
+			errMsg := "Template compilation failed"
+			if compiledTemplate.Error != nil {
+				errMsg = compiledTemplate.Error.Message
+			}
+			s.logger.WithFields(map[string]interface{}{
+				"broadcast_id": broadcast.ID,
+				"workspace_id": workspaceID,
+				"recipient":    email,
+				"template_id":  template.ID,
+				"error":        errMsg,
+			}).Error("Failed to generate HTML from template")
+			return NewBroadcastError(ErrCodeTemplateCompile, errMsg, true, nil)
 		}
-		s.logger.WithFields(map[string]interface{}{
-			"broadcast_id": broadcast.ID,
-			"workspace_id": workspaceID,
-			"recipient":    email,
-			"template_id":  template.ID,
-			"error":        errMsg,
-		}).Error("Failed to generate HTML from template")
-		return NewBroadcastError(ErrCodeTemplateCompile, errMsg, true, nil)
+		content = *compiledTemplate.HTML
 	}
 
 	emailSender := emailProvider.GetSender(template.Email.SenderID)
@@ -324,12 +363,14 @@ func (s *messageSender) SendToRecipient(ctx context.Context, workspaceID string,
 		FromName:      emailSender.Name,
 		To:            email,
 		Subject:       processedSubject,
-		Content:       *compiledTemplate.HTML,
+		Content:       content,
 		Provider:      emailProvider,
 		EmailOptions: domain.EmailOptions{
 			ReplyTo: template.Email.ReplyTo,
 		},
+		PlainTextOnly: broadcast.PlainTextOnly,
 	}
+	template.Email.ApplyProviderTags(&emailRequest.EmailOptions)
 
 	// Extract List-Unsubscribe URL from template data for RFC-8058 compliance (broadcast emails only)
 	if unsubscribeURL, ok := data["oneclick_unsubscribe_url"].(string); ok && unsubscribeURL != "" {
@@ -354,7 +395,7 @@ func (s *messageSender) SendToRecipient(ctx context.Context, workspaceID string,
 			"recipient":    email,
 			"error":        err.Error(),
 		}).Error("Failed to send message")
-		return NewBroadcastError(ErrCodeSendFailed, "failed to send message", true, err)
+		return NewBroadcastError(ErrCodeSendFailed, "failed to send message", RetryableError(err, emailProvider.Kind), err)
 	}
 
 	// Record success in circuit breaker
@@ -373,7 +414,7 @@ func (s *messageSender) SendToRecipient(ctx context.Context, workspaceID string,
 
 // SendBatch sends messages to a batch of recipients
 func (s *messageSender) SendBatch(ctx context.Context, workspaceID string, integrationID string, workspaceSecretKey string, endpoint string, trackingEnabled bool, broadcastID string, recipients []*domain.ContactWithList,
-	templates map[string]*domain.Template, emailProvider *domain.EmailProvider, timeoutAt time.Time) (sent int, failed int, err error) {
+	templates map[string]*domain.Template, emailProvider *domain.EmailProvider, timeoutAt time.Time) (result domain.BatchSendResult, err error) {
 
 	// Track specific error types for better reporting
 	errorCounts := map[string]int{
@@ -390,14 +431,14 @@ func (s *messageSender) SendBatch(ctx context.Context, workspaceID string, integ
 			"broadcast_id": broadcastID,
 			"workspace_id": workspaceID,
 			"total":        len(recipients),
-			"sent":         sent,
-			"failed":       failed,
+			"sent":         result.Sent(),
+			"failed":       result.Failed(),
 		}).Info("Batch send completed")
 	}()
 
 	// Check if we have any recipients
 	if len(recipients) == 0 {
-		return 0, 0, nil
+		return result, nil
 	}
 
 	// Check circuit breaker
@@ -412,7 +453,7 @@ func (s *messageSender) SendBatch(ctx context.Context, workspaceID string, integ
 			logFields["last_error"] = lastError.Error()
 		}
 		s.logger.WithFields(logFields).Warn("Circuit breaker open, skipping batch")
-		return 0, 0, NewBroadcastError(ErrCodeCircuitOpen, "circuit breaker is open", true, lastError)
+		return result, NewBroadcastError(ErrCodeCircuitOpen, "circuit breaker is open", true, lastError)
 	}
 
 	// Get the broadcast to determine variations and templates
@@ -423,7 +464,7 @@ func (s *messageSender) SendBatch(ctx context.Context, workspaceID string, integ
 			"workspace_id": workspaceID,
 			"error":        err.Error(),
 		}).Error("Failed to get broadcast for sending")
-		return 0, 0, NewBroadcastError(ErrCodeBroadcastNotFound, "broadcast not found", false, err)
+		return result, NewBroadcastError(ErrCodeBroadcastNotFound, "broadcast not found", false, err)
 	}
 	if broadcast == nil {
 		// Defensive: repository returned nil without error
@@ -431,7 +472,7 @@ func (s *messageSender) SendBatch(ctx context.Context, workspaceID string, integ
 			"broadcast_id": broadcastID,
 			"workspace_id": workspaceID,
 		}).Error("Nil broadcast returned from repository")
-		return 0, 0, NewBroadcastError(ErrCodeBroadcastNotFound, "broadcast not found", false, fmt.Errorf("nil broadcast"))
+		return result, NewBroadcastError(ErrCodeBroadcastNotFound, "broadcast not found", false, fmt.Errorf("nil broadcast"))
 	}
 
 	// Ensure UTM parameters is non-nil for downstream usage
@@ -459,7 +500,7 @@ func (s *messageSender) SendBatch(ctx context.Context, workspaceID string, integ
 
 		// Skip empty emails (shouldn't happen, but just in case)
 		if contact == nil || contact.Email == "" {
-			failed++
+			result.FailedEmails = append(result.FailedEmails, recipientEmail(contactWithList))
 			errorCounts["empty_email"]++
 			if firstError == nil {
 				firstError = fmt.Errorf("contact has empty email")
@@ -471,7 +512,7 @@ func (s *messageSender) SendBatch(ctx context.Context, workspaceID string, integ
 		if time.Now().After(timeoutAt) {
 			// Note: This is NOT an error - just time limit reached
 			s.logger.WithField("broadcast_id", broadcastID).Info("Time limit reached in batch processing")
-			return sent, failed, nil // Return current progress, no error
+			return result, nil // Return current progress, no error
 		}
 
 		// Determine which variation to use for this contact
@@ -492,8 +533,8 @@ func (s *messageSender) SendBatch(ctx context.Context, workspaceID string, integ
 				templateID = broadcast.TestSettings.Variations[idx].TemplateID
 			}
 		} else if len(broadcast.TestSettings.Variations) > 0 {
-			// Not A/B testing: use the first variation
-			templateID = broadcast.TestSettings.Variations[0].TemplateID
+			// Not A/B testing: use the first variation, or the template of the list of the contact
+			templateID = broadcast.Audience.TemplateForList(contactWithList.ListID, broadcast.TestSettings.Variations[0].TemplateID)
 		}
 
 		// Skip if no template ID was found or template is missing
@@ -503,7 +544,7 @@ func (s *messageSender) SendBatch(ctx context.Context, workspaceID string, integ
 				"workspace_id": workspaceID,
 				"recipient":    contact.Email,
 			}).Error("No template found for recipient")
-			failed++
+			result.FailedEmails = append(result.FailedEmails, contact.Email)
 			errorCounts["template_not_found"]++
 			if firstError == nil {
 				firstError = fmt.Errorf("template not found for template_id: %s", templateID)
@@ -546,7 +587,7 @@ func (s *messageSender) SendBatch(ctx context.Context, workspaceID string, integ
 				"recipient":    contact.Email,
 				"error":        err.Error(),
 			}).Error("Failed to build template data")
-			failed++
+			result.FailedEmails = append(result.FailedEmails, contact.Email)
 			errorCounts["template_data_failed"]++
 			if firstError == nil {
 				firstError = fmt.Errorf("template data build failed: %w", err)
@@ -555,20 +596,28 @@ func (s *messageSender) SendBatch(ctx context.Context, workspaceID string, integ
 		}
 
 		// Send to the recipient
-		err = s.SendToRecipient(ctx, workspaceID, integrationID, trackingEnabled, broadcast, messageID, contact.Email, templates[templateID], recipientData, emailProvider, timeoutAt)
+		err = s.SendToRecipient(ctx, workspaceID, integrationID, trackingEnabled, broadcast, messageID, contact.Email, templates[templateID].ForContact(contact), recipientData, emailProvider, timeoutAt)
+		if err != nil && isTransientSendError(err) {
+			// Transient provider error: stop before this recipient so that the orchestrator
+			// sends it again with the rest of the batch after a backoff
+			return result, err
+		}
 		if err != nil {
 			// SendToRecipient already logs errors
-			failed++
+			result.FailedEmails = append(result.FailedEmails, contact.Email)
 			errorCounts["send_failed"]++
 			if firstError == nil {
 				firstError = fmt.Errorf("send failed: %w", err)
 			}
 		} else {
-			sent++
+			result.SentEmails = append(result.SentEmails, contact.Email)
 		}
 
 		now := time.Now().UTC()
-		listID := broadcast.Audience.List
+		listID := contactWithList.ListID
+		if listID == "" {
+			listID = broadcast.Audience.PrimaryList()
+		}
 		message := &domain.MessageHistory{
 			ID:              messageID,
 			ContactEmail:    contact.Email,
@@ -613,6 +662,7 @@ func (s *messageSender) SendBatch(ctx context.Context, workspaceID string, integ
 
 	// Record success/failure in circuit breaker based on overall success rate
 	if s.circuitBreaker != nil {
+		sent, failed := result.Sent(), result.Failed()
 		if failed > sent {
 			// Create detailed error message with breakdown
 			var errorDetails []string
@@ -637,7 +687,7 @@ func (s *messageSender) SendBatch(ctx context.Context, workspaceID string, integ
 		}
 	}
 
-	return sent, failed, nil
+	return result, nil
 }
 
 // generateMessageID creates a unique message ID for tracking
@@ -314,15 +314,15 @@ func TestWithMockMessageSender(t *testing.T) {
 	timeoutAt = time.Now().Add(30 * time.Second)
 	mockSender.EXPECT().
 		SendBatch(ctx, workspaceID, "test-integration-id", workspaceSecretKey, "https://api.example.com", trackingEnabled, broadcast.ID, mockContacts, mockTemplates, nil, timeoutAt).
-		Return(1, 0, nil)
+		Return(domain.BatchSendResult{SentEmails: []string{recipientEmail}}, nil)
 
 	// Use the mock
-	sent, failed, err := mockSender.SendBatch(ctx, workspaceID, "test-integration-id", workspaceSecretKey, "https://api.example.com", trackingEnabled, broadcast.ID, mockContacts, mockTemplates, nil, timeoutAt)
+	result, err := mockSender.SendBatch(ctx, workspaceID, "test-integration-id", workspaceSecretKey, "https://api.example.com", trackingEnabled, broadcast.ID, mockContacts, mockTemplates, nil, timeoutAt)
 
 	// Verify results
 	assert.NoError(t, err)
-	assert.Equal(t, 1, sent)
-	assert.Equal(t, 0, failed)
+	assert.Equal(t, 1, result.Sent())
+	assert.Equal(t, 0, result.Failed())
 }
 
 // TestErrorHandlingWithMock demonstrates error handling with mocks
@@ -389,13 +389,13 @@ func TestErrorHandlingWithMock(t *testing.T) {
 
 	mockSender.EXPECT().
 		SendBatch(ctx, workspaceID, "test-integration-id", workspaceSecretKey, "https://api.example.com", trackingEnabled, broadcast.ID, mockContacts, mockTemplates, nil, timeoutAt).
-		Return(0, 0, batchError)
+		Return(domain.BatchSendResult{}, batchError)
 
-	sent, failed, err := mockSender.SendBatch(ctx, workspaceID, "test-integration-id", workspaceSecretKey, "https://api.example.com", trackingEnabled, broadcast.ID, mockContacts, mockTemplates, nil, timeoutAt)
+	result, err := mockSender.SendBatch(ctx, workspaceID, "test-integration-id", workspaceSecretKey, "https://api.example.com", trackingEnabled, broadcast.ID, mockContacts, mockTemplates, nil, timeoutAt)
 	assert.Error(t, err)
 	assert.Equal(t, batchError, err)
-	assert.Equal(t, 0, sent)
-	assert.Equal(t, 0, failed)
+	assert.Equal(t, 0, result.Sent())
+	assert.Equal(t, 0, result.Failed())
 }
 
 // TestSendBatch tests the SendBatch method
@@ -517,10 +517,10 @@ func TestSendBatch(t *testing.T) {
 		},
 	}
 	templates := map[string]*domain.Template{"template-123": template}
-	sent, failed, err := sender.SendBatch(ctx, workspaceID, "test-integration-id", "secret-key-123", "https://api.example.com", tracking, broadcastID, recipients, templates, emailProvider, timeoutAt)
+	result, err := sender.SendBatch(ctx, workspaceID, "test-integration-id", "secret-key-123", "https://api.example.com", tracking, broadcastID, recipients, templates, emailProvider, timeoutAt)
 	assert.NoError(t, err)
-	assert.Equal(t, 2, sent)
-	assert.Equal(t, 0, failed)
+	assert.Equal(t, 2, result.Sent())
+	assert.Equal(t, 0, result.Failed())
 }
 
 // TestSendBatch_EmptyRecipients tests SendBatch with no recipients
@@ -566,13 +566,13 @@ func TestSendBatch_EmptyRecipients(t *testing.T) {
 	)
 
 	// Call the method being tested with empty recipients
-	sent, failed, err := sender.SendBatch(ctx, workspaceID, "test-integration-id", workspaceSecretKey, "https://api.example.com", trackingEnabled, broadcastID, []*domain.ContactWithList{},
+	result, err := sender.SendBatch(ctx, workspaceID, "test-integration-id", workspaceSecretKey, "https://api.example.com", trackingEnabled, broadcastID, []*domain.ContactWithList{},
 		map[string]*domain.Template{}, emailProvider, timeoutAt)
 
 	// Verify results
 	assert.NoError(t, err)
-	assert.Equal(t, 0, sent)
-	assert.Equal(t, 0, failed)
+	assert.Equal(t, 0, result.Sent())
+	assert.Equal(t, 0, result.Failed())
 }
 
 // TestSendBatch_CircuitBreakerOpen tests SendBatch when circuit breaker is open
@@ -633,13 +633,13 @@ func TestSendBatch_CircuitBreakerOpen(t *testing.T) {
 	messageSenderImpl.circuitBreaker.RecordFailure(fmt.Errorf("test error"))
 
 	// Call the method being tested
-	sent, failed, err := sender.SendBatch(ctx, workspaceID, "test-integration-id", workspaceSecretKey, "https://api.example.com", trackingEnabled, broadcastID, recipients,
+	result, err := sender.SendBatch(ctx, workspaceID, "test-integration-id", workspaceSecretKey, "https://api.example.com", trackingEnabled, broadcastID, recipients,
 		map[string]*domain.Template{}, emailProvider, timeoutAt)
 
 	// Verify results
 	assert.Error(t, err)
-	assert.Equal(t, 0, sent)
-	assert.Equal(t, 0, failed)
+	assert.Equal(t, 0, result.Sent())
+	assert.Equal(t, 0, result.Failed())
 
 	// Check that we got the right error
 	broadcastErr, ok := err.(*BroadcastError)
@@ -779,10 +779,10 @@ func TestSendBatch_WithFailure(t *testing.T) {
 		},
 	}
 	templates := map[string]*domain.Template{"template-123": template}
-	sent, failed, err := sender.SendBatch(ctx, workspaceID, "test-integration-id", "secret-key-123", "https://api.example.com", tracking, broadcastID, recipients, templates, emailProvider, timeoutAt)
+	result, err := sender.SendBatch(ctx, workspaceID, "test-integration-id", "secret-key-123", "https://api.example.com", tracking, broadcastID, recipients, templates, emailProvider, timeoutAt)
 	assert.NoError(t, err)
-	assert.Equal(t, 0, sent)
-	assert.Equal(t, 1, failed)
+	assert.Equal(t, 0, result.Sent())
+	assert.Equal(t, 1, result.Failed())
 }
 
 // TestSendBatch_RecordMessageFails tests that SendBatch continues even if recording message history fails
@@ -897,10 +897,10 @@ func TestSendBatch_RecordMessageFails(t *testing.T) {
 		},
 	}
 	templates := map[string]*domain.Template{"template-123": template}
-	sent, failed, err := sender.SendBatch(ctx, workspaceID, "test-integration-id", "secret-key-123", "https://api.example.com", tracking, broadcastID, recipients, templates, emailProvider, timeoutAt)
+	result, err := sender.SendBatch(ctx, workspaceID, "test-integration-id", "secret-key-123", "https://api.example.com", tracking, broadcastID, recipients, templates, emailProvider, timeoutAt)
 	assert.NoError(t, err)
-	assert.Equal(t, 1, sent)
-	assert.Equal(t, 0, failed)
+	assert.Equal(t, 1, result.Sent())
+	assert.Equal(t, 0, result.Failed())
 }
 
 // TestSendToRecipientWithLiquidSubject tests sending email with Liquid templating in subject
@@ -1457,11 +1457,11 @@ func TestSendBatch_AdvancedScenarios(t *testing.T) {
 			GetBroadcast(ctx, workspaceID, broadcastID).
 			Return(nil, nil)
 
-		sent, failed, err := sender.SendBatch(ctx, workspaceID, "test-integration-id", "secret-key", "https://api.example.com", true, broadcastID, recipients, map[string]*domain.Template{}, nil, timeoutAt)
+		result, err := sender.SendBatch(ctx, workspaceID, "test-integration-id", "secret-key", "https://api.example.com", true, broadcastID, recipients, map[string]*domain.Template{}, nil, timeoutAt)
 
 		assert.Error(t, err)
-		assert.Equal(t, 0, sent)
-		assert.Equal(t, 0, failed)
+		assert.Equal(t, 0, result.Sent())
+		assert.Equal(t, 0, result.Failed())
 		broadcastErr, ok := err.(*BroadcastError)
 		assert.True(t, ok)
 		assert.Equal(t, ErrCodeBroadcastNotFound, broadcastErr.Code)
@@ -1523,12 +1523,12 @@ func TestSendBatch_AdvancedScenarios(t *testing.T) {
 		// Use a timeout that's already passed
 		pastTimeout := time.Now().Add(-1 * time.Second)
 
-		sent, failed, err := sender.SendBatch(ctx, workspaceID, "test-integration-id", "secret-key", "https://api.example.com", true, broadcastID, recipients, templates, emailProvider, pastTimeout)
+		result, err := sender.SendBatch(ctx, workspaceID, "test-integration-id", "secret-key", "https://api.example.com", true, broadcastID, recipients, templates, emailProvider, pastTimeout)
 
 		// Should return immediately without processing any recipients
 		assert.NoError(t, err)
-		assert.Equal(t, 0, sent)
-		assert.Equal(t, 0, failed)
+		assert.Equal(t, 0, result.Sent())
+		assert.Equal(t, 0, result.Failed())
 	})
 
 	t.Run("ABTestRandomVariationSelection", func(t *testing.T) {
@@ -1613,11 +1613,11 @@ func TestSendBatch_AdvancedScenarios(t *testing.T) {
 			}).
 			Return(nil).Times(2)
 
-		sent, failed, err := sender.SendBatch(ctx, workspaceID, "test-integration-id", "secret-key", "https://api.example.com", true, broadcastID, recipients, templates, emailProvider, timeoutAt)
+		result, err := sender.SendBatch(ctx, workspaceID, "test-integration-id", "secret-key", "https://api.example.com", true, broadcastID, recipients, templates, emailProvider, timeoutAt)
 
 		assert.NoError(t, err)
-		assert.Equal(t, 2, sent)
-		assert.Equal(t, 0, failed)
+		assert.Equal(t, 2, result.Sent())
+		assert.Equal(t, 0, result.Failed())
 	})
 
 	t.Run("WinnerTemplateSelected", func(t *testing.T) {
@@ -1693,11 +1693,11 @@ func TestSendBatch_AdvancedScenarios(t *testing.T) {
 			}).
 			Return(nil)
 
-		sent, failed, err := sender.SendBatch(ctx, workspaceID, "test-integration-id", "secret-key", "https://api.example.com", true, broadcastID, recipients, templates, emailProvider, timeoutAt)
+		result, err := sender.SendBatch(ctx, workspaceID, "test-integration-id", "secret-key", "https://api.example.com", true, broadcastID, recipients, templates, emailProvider, timeoutAt)
 
 		assert.NoError(t, err)
-		assert.Equal(t, 1, sent)
-		assert.Equal(t, 0, failed)
+		assert.Equal(t, 1, result.Sent())
+		assert.Equal(t, 0, result.Failed())
 	})
 
 	t.Run("CircuitBreakerRecordsFailureOnHighFailureRate", func(t *testing.T) {
@@ -1761,10 +1761,10 @@ func TestSendBatch_AdvancedScenarios(t *testing.T) {
 			GetBroadcast(ctx, workspaceID, broadcastID).
 			Return(broadcast, nil)
 
-		// All email sends fail
+		// All email sends fail with a permanent error
 		mockEmailService.EXPECT().
 			SendEmail(gomock.Any(), gomock.Any(), gomock.Any()).
-			Return(fmt.Errorf("email service unavailable")).Times(3)
+			Return(fmt.Errorf("email rejected by content filter")).Times(3)
 
 		// Message history should still be recorded for failed messages
 		mockMessageHistoryRepo.EXPECT().
@@ -1776,16 +1776,88 @@ func TestSendBatch_AdvancedScenarios(t *testing.T) {
 			}).
 			Return(nil).Times(3)
 
-		sent, failed, err := sender.SendBatch(ctx, workspaceID, "test-integration-id", "secret-key", "https://api.example.com", true, broadcastID, recipients, templates, emailProvider, timeoutAt)
+		result, err := sender.SendBatch(ctx, workspaceID, "test-integration-id", "secret-key", "https://api.example.com", true, broadcastID, recipients, templates, emailProvider, timeoutAt)
 
 		assert.NoError(t, err) // SendBatch itself doesn't return error, just counts
-		assert.Equal(t, 0, sent)
-		assert.Equal(t, 3, failed)
+		assert.Equal(t, 0, result.Sent())
+		assert.Equal(t, 3, result.Failed())
 
 		// Circuit breaker should have recorded the failure
 		messageSenderImpl := sender.(*messageSender)
 		assert.NotNil(t, messageSenderImpl.circuitBreaker.GetLastError())
 	})
+
+	t.Run("TransientProviderErrorStopsBatch", func(t *testing.T) {
+		config := TestConfig()
+		config.EnableCircuitBreaker = false
+
+		sender := NewMessageSender(
+			mockBroadcastRepository,
+			mockMessageHistoryRepo,
+			mockTemplateRepo,
+			mockEmailService,
+			mockLogger,
+			config,
+			"",
+		)
+
+		broadcast := &domain.Broadcast{
+			ID:          broadcastID,
+			WorkspaceID: workspaceID,
+			Audience:    domain.AudienceSettings{List: "test-list-1"},
+			UTMParameters: &domain.UTMParameters{
+				Source: "test",
+			},
+			TestSettings: domain.BroadcastTestSettings{
+				Variations: []domain.BroadcastVariation{
+					{VariationName: "variation-1", TemplateID: "template-123"},
+				},
+			},
+		}
+
+		recipients := []*domain.ContactWithList{
+			{Contact: &domain.Contact{Email: "test1@example.com"}},
+			{Contact: &domain.Contact{Email: "test2@example.com"}},
+			{Contact: &domain.Contact{Email: "test3@example.com"}},
+		}
+
+		emailSender := domain.NewEmailSender("sender@example.com", "Sender")
+		templates := map[string]*domain.Template{"template-123": {
+			ID: "template-123",
+			Email: &domain.EmailTemplate{
+				SenderID:         emailSender.ID,
+				Subject:          "Test Subject",
+				VisualEditorTree: createValidTestTree(createTestTextBlock("txt1", "Test content")),
+			},
+		}}
+
+		emailProvider := &domain.EmailProvider{
+			Kind:    domain.EmailProviderKindSMTP,
+			Senders: []domain.EmailSender{emailSender},
+			SMTP:    &domain.SMTPSettings{Host: "smtp.example.com", Port: 587, Username: "user", Password: "pass", UseTLS: true},
+		}
+
+		mockBroadcastRepository.EXPECT().
+			GetBroadcast(ctx, workspaceID, broadcastID).
+			Return(broadcast, nil)
+
+		gomock.InOrder(
+			mockEmailService.EXPECT().SendEmail(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil),
+			mockEmailService.EXPECT().SendEmail(gomock.Any(), gomock.Any(), gomock.Any()).Return(fmt.Errorf("421 service unavailable, try again later")),
+		)
+
+		// Only the sent message is recorded, the throttled recipient is left for a retry
+		mockMessageHistoryRepo.EXPECT().
+			Create(ctx, workspaceID, gomock.Any(), gomock.Any()).
+			Return(nil).Times(1)
+
+		result, err := sender.SendBatch(ctx, workspaceID, "test-integration-id", "secret-key", "https://api.example.com", true, broadcastID, recipients, templates, emailProvider, timeoutAt)
+
+		assert.Error(t, err)
+		assert.True(t, isTransientSendError(err))
+		assert.Equal(t, 1, result.Sent())
+		assert.Equal(t, 0, result.Failed())
+	})
 }
 
 // TestNewMessageSender_EdgeCases tests NewMessageSender with different configurations
@@ -2003,12 +2075,12 @@ func TestSendBatch_TemplateDataBuildFailure(t *testing.T) {
 		}).
 		Return(nil).AnyTimes()
 
-	sent, failed, err := sender.SendBatch(ctx, workspaceID, "test-integration-id", "secret-key", "https://api.example.com", true, broadcastID, recipients, templates, emailProvider, timeoutAt)
+	result, err := sender.SendBatch(ctx, workspaceID, "test-integration-id", "secret-key", "https://api.example.com", true, broadcastID, recipients, templates, emailProvider, timeoutAt)
 
 	// Should handle the case gracefully
 	assert.NoError(t, err)
 	// Results depend on whether template data building succeeds or fails
-	assert.Equal(t, len(recipients), sent+failed)
+	assert.Equal(t, len(recipients), result.Processed())
 }
 
 // TestSendBatch_EmptyEmailContact tests SendBatch with contacts having empty emails
@@ -2106,11 +2178,11 @@ func TestSendBatch_EmptyEmailContact(t *testing.T) {
 		}).
 		Return(nil).Times(1)
 
-	sent, failed, err := sender.SendBatch(ctx, workspaceID, "test-integration-id", "secret-key", "https://api.example.com", true, broadcastID, recipients, templates, emailProvider, timeoutAt)
+	result, err := sender.SendBatch(ctx, workspaceID, "test-integration-id", "secret-key", "https://api.example.com", true, broadcastID, recipients, templates, emailProvider, timeoutAt)
 
 	assert.NoError(t, err)
-	assert.Equal(t, 1, sent)
-	assert.Equal(t, 2, failed) // Two invalid contacts
+	assert.Equal(t, 1, result.Sent())
+	assert.Equal(t, 2, result.Failed()) // Two invalid contacts
 }
 
 // TestSendBatch_NoVariations tests SendBatch when broadcast has no variations
@@ -2179,11 +2251,11 @@ func TestSendBatch_NoVariations(t *testing.T) {
 		GetBroadcast(ctx, workspaceID, broadcastID).
 		Return(broadcast, nil)
 
-	sent, failed, err := sender.SendBatch(ctx, workspaceID, "test-integration-id", "secret-key", "https://api.example.com", true, broadcastID, recipients, templates, emailProvider, timeoutAt)
+	result, err := sender.SendBatch(ctx, workspaceID, "test-integration-id", "secret-key", "https://api.example.com", true, broadcastID, recipients, templates, emailProvider, timeoutAt)
 
 	assert.NoError(t, err)
-	assert.Equal(t, 0, sent)
-	assert.Equal(t, 1, failed) // Should fail due to no template
+	assert.Equal(t, 0, result.Sent())
+	assert.Equal(t, 1, result.Failed()) // Should fail due to no template
 }
 
 // TestSendToRecipient_CompilationFailsWithNilHTML tests when compilation succeeds but HTML is nil
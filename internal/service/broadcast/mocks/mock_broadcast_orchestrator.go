@@ -126,15 +126,15 @@ func (mr *MockBroadcastOrchestratorInterfaceMockRecorder) SaveProgressState(arg0
 }
 
 // ValidateTemplates mocks base method.
-func (m *MockBroadcastOrchestratorInterface) ValidateTemplates(arg0 map[string]*domain.Template) error {
+func (m *MockBroadcastOrchestratorInterface) ValidateTemplates(arg0 map[string]*domain.Template, arg1 *domain.EmailProvider, arg2 *domain.WorkspaceSettings) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ValidateTemplates", arg0)
+	ret := m.ctrl.Call(m, "ValidateTemplates", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // ValidateTemplates indicates an expected call of ValidateTemplates.
-func (mr *MockBroadcastOrchestratorInterfaceMockRecorder) ValidateTemplates(arg0 interface{}) *gomock.Call {
+func (mr *MockBroadcastOrchestratorInterfaceMockRecorder) ValidateTemplates(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateTemplates", reflect.TypeOf((*MockBroadcastOrchestratorInterface)(nil).ValidateTemplates), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ValidateTemplates", reflect.TypeOf((*MockBroadcastOrchestratorInterface)(nil).ValidateTemplates), arg0, arg1, arg2)
 }
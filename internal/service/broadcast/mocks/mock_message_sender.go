@@ -37,13 +37,12 @@ func (m *MockMessageSender) EXPECT() *MockMessageSenderMockRecorder {
 }
 
 // SendBatch mocks base method.
-func (m *MockMessageSender) SendBatch(arg0 context.Context, arg1, arg2, arg3, arg4 string, arg5 bool, arg6 string, arg7 []*domain.ContactWithList, arg8 map[string]*domain.Template, arg9 *domain.EmailProvider, arg10 time.Time) (int, int, error) {
+func (m *MockMessageSender) SendBatch(arg0 context.Context, arg1, arg2, arg3, arg4 string, arg5 bool, arg6 string, arg7 []*domain.ContactWithList, arg8 map[string]*domain.Template, arg9 *domain.EmailProvider, arg10 time.Time) (domain.BatchSendResult, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "SendBatch", arg0, arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8, arg9, arg10)
-	ret0, _ := ret[0].(int)
-	ret1, _ := ret[1].(int)
-	ret2, _ := ret[2].(error)
-	return ret0, ret1, ret2
+	ret0, _ := ret[0].(domain.BatchSendResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
 }
 
 // SendBatch indicates an expected call of SendBatch.
@@ -2,7 +2,11 @@ package broadcast
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math/rand"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/Notifuse/notifuse/internal/domain"
@@ -22,8 +26,9 @@ type BroadcastOrchestratorInterface interface {
 	// LoadTemplates loads all templates for a broadcast's variations
 	LoadTemplates(ctx context.Context, workspaceID string, templateIDs []string) (map[string]*domain.Template, error)
 
-	// ValidateTemplates validates that the required templates are loaded and valid
-	ValidateTemplates(templates map[string]*domain.Template) error
+	// ValidateTemplates validates that the required templates are loaded and valid, and that their senders
+	// resolve to verified sender identities when the workspace requires it
+	ValidateTemplates(templates map[string]*domain.Template, emailProvider *domain.EmailProvider, workspaceSettings *domain.WorkspaceSettings) error
 
 	// GetTotalRecipientCount gets the total number of recipients for a broadcast
 	GetTotalRecipientCount(ctx context.Context, workspaceID, broadcastID string) (int, error)
@@ -50,6 +55,24 @@ type BroadcastOrchestrator struct {
 	timeProvider    TimeProvider
 	apiEndpoint     string
 	eventBus        domain.EventBus
+
+	// messageHistoryRepo is used to skip recipients already sent by earlier executions of a resumed task
+	messageHistoryRepo domain.MessageHistoryRepository
+
+	// dryRunSender replaces messageSender for dry-run broadcasts
+	dryRunSender MessageSender
+
+	// smsSender replaces messageSender for broadcasts on the sms channel
+	smsSender MessageSender
+
+	// audienceRepo reads the uploaded recipients of broadcasts with a CSV audience
+	audienceRepo domain.BroadcastAudienceRepository
+
+	// sleep waits between the batches of throttled broadcasts, replaced in tests to advance a fake clock
+	sleep func(ctx context.Context, d time.Duration) error
+
+	// shutdown stops running broadcasts at a batch boundary when the server shuts down
+	shutdown *ShutdownNotice
 }
 
 // NewBroadcastOrchestrator creates a new broadcast orchestrator
@@ -88,6 +111,7 @@ func NewBroadcastOrchestrator(
 		timeProvider:    timeProvider,
 		apiEndpoint:     apiEndpoint,
 		eventBus:        eventBus,
+		sleep:           sleepContext,
 	}
 }
 
@@ -129,8 +153,10 @@ func (o *BroadcastOrchestrator) LoadTemplates(ctx context.Context, workspaceID s
 	return templates, nil
 }
 
-// ValidateTemplates validates that the required templates are loaded and valid
-func (o *BroadcastOrchestrator) ValidateTemplates(templates map[string]*domain.Template) error {
+// ValidateTemplates validates that the required templates are loaded and valid.
+// When the workspace requires verified senders, the sender of each email template must resolve on the
+// email provider to a sender with a verified sender identity, so the broadcast fails before any send.
+func (o *BroadcastOrchestrator) ValidateTemplates(templates map[string]*domain.Template, emailProvider *domain.EmailProvider, workspaceSettings *domain.WorkspaceSettings) error {
 	if len(templates) == 0 {
 		return NewBroadcastError(ErrCodeTemplateMissing, "no templates provided for validation", false, nil)
 	}
@@ -141,6 +167,17 @@ func (o *BroadcastOrchestrator) ValidateTemplates(templates map[string]*domain.T
 			return NewBroadcastError(ErrCodeTemplateInvalid, "template is nil", false, nil)
 		}
 
+		// SMS templates only need a body to render
+		if template.Channel == domain.ChannelSMS {
+			if template.SMS == nil || strings.TrimSpace(template.SMS.Body) == "" {
+				// codecov:ignore:start
+				o.logger.WithField("template_id", id).Error("Template missing sms body")
+				// codecov:ignore:end
+				return NewBroadcastError(ErrCodeTemplateInvalid, "template missing sms body", false, nil)
+			}
+			continue
+		}
+
 		// Ensure the template has the required fields for sending emails
 		if template.Email == nil {
 			// codecov:ignore:start
@@ -162,6 +199,64 @@ func (o *BroadcastOrchestrator) ValidateTemplates(templates map[string]*domain.T
 			// codecov:ignore:end
 			return NewBroadcastError(ErrCodeTemplateInvalid, "template missing content", false, nil)
 		}
+
+		// Merge tags on unknown contact fields would render blank for every recipient
+		if unknownTags := domain.UnknownContactMergeTags(template.Email.Subject, template.Email.VisualEditorTree); len(unknownTags) > 0 {
+			// codecov:ignore:start
+			o.logger.WithFields(map[string]interface{}{
+				"template_id":  id,
+				"unknown_tags": unknownTags,
+			}).Error("Template references unknown contact fields")
+			// codecov:ignore:end
+			return NewBroadcastError(ErrCodeTemplateInvalid, fmt.Sprintf("template %s references unknown contact fields: %s", id, strings.Join(unknownTags, ", ")), false, nil)
+		}
+
+		// Malformed conditions would fail the rendering of every recipient
+		if invalidConditions := domain.InvalidConditions(template.Email.VisualEditorTree); len(invalidConditions) > 0 {
+			// codecov:ignore:start
+			o.logger.WithFields(map[string]interface{}{
+				"template_id":        id,
+				"invalid_conditions": invalidConditions,
+			}).Error("Template has malformed conditions")
+			// codecov:ignore:end
+			return NewBroadcastError(ErrCodeTemplateInvalid, fmt.Sprintf("template %s has malformed conditions: %s", id, strings.Join(invalidConditions, "; ")), false, nil)
+		}
+
+		// Product grids iterate an array of the contact, other sources would never render
+		if invalidSources := domain.InvalidProductGridSources(template.Email.VisualEditorTree); len(invalidSources) > 0 {
+			// codecov:ignore:start
+			o.logger.WithFields(map[string]interface{}{
+				"template_id":     id,
+				"invalid_sources": invalidSources,
+			}).Error("Template has product grids without a contact array source")
+			// codecov:ignore:end
+			return NewBroadcastError(ErrCodeTemplateInvalid, fmt.Sprintf("template %s has product grids that don't reference a JSON contact field: %q", id, invalidSources), false, nil)
+		}
+
+		if emailProvider != nil && workspaceSettings != nil && workspaceSettings.RequireVerifiedSenders {
+			if _, senderErr := workspaceSettings.ResolveVerifiedSender(emailProvider, template.Email.SenderID); senderErr != nil {
+				// codecov:ignore:start
+				o.logger.WithFields(map[string]interface{}{
+					"template_id": id,
+					"sender_id":   template.Email.SenderID,
+					"error":       senderErr.Error(),
+				}).Error("Template sender is not a verified sender identity")
+				// codecov:ignore:end
+				code := ErrCodeSenderUnverified
+				var notFoundErr *domain.ErrSenderNotFound
+				if errors.As(senderErr, &notFoundErr) {
+					code = ErrCodeSenderNotFound
+				}
+				return NewBroadcastError(code, fmt.Sprintf("template %s cannot be sent from its sender", id), false, senderErr)
+			}
+		}
+
+		// Not an error, workspaces requiring an unsubscribe link get a footer added at send time
+		if !template.Email.HasUnsubscribeLink() {
+			// codecov:ignore:start
+			o.logger.WithField("template_id", id).Warn("Template has no unsubscribe link")
+			// codecov:ignore:end
+		}
 	}
 
 	return nil
@@ -193,8 +288,18 @@ func (o *BroadcastOrchestrator) GetTotalRecipientCount(ctx context.Context, work
 		return 0, NewBroadcastError(ErrCodeBroadcastNotFound, "broadcast not found", false, err)
 	}
 
-	// Use the contact repository to count recipients
-	count, err := o.contactRepo.CountContactsForBroadcast(ctx, workspaceID, broadcast.Audience)
+	// A scheduled broadcast does not start, nor count its audience, before its scheduled time
+	if _, notDueErr := o.scheduledStart(broadcast); notDueErr != nil {
+		return 0, notDueErr
+	}
+
+	// Use the contact repository to count recipients, or the uploaded recipients of a CSV audience
+	var count int
+	if broadcast.Audience.CSV {
+		count, err = o.countCSVRecipients(ctx, workspaceID, broadcast)
+	} else {
+		count, err = o.contactRepo.CountContactsForBroadcast(ctx, workspaceID, broadcast.Audience)
+	}
 	if err != nil {
 		// codecov:ignore:start
 		o.logger.WithFields(map[string]interface{}{
@@ -211,7 +316,7 @@ func (o *BroadcastOrchestrator) GetTotalRecipientCount(ctx context.Context, work
 		"broadcast_id":      broadcastID,
 		"workspace_id":      workspaceID,
 		"recipient_count":   count,
-		"audience_list":     broadcast.Audience.List,
+		"audience_lists":    broadcast.Audience.TargetLists(),
 		"audience_segments": len(broadcast.Audience.Segments),
 	}).Info("Got recipient count for broadcast")
 	// codecov:ignore:end
@@ -253,13 +358,23 @@ func (o *BroadcastOrchestrator) FetchBatch(ctx context.Context, workspaceID, bro
 		return nil, NewBroadcastError(ErrCodeBroadcastCancelled, "broadcast has been cancelled", false, nil)
 	}
 
+	// Paused broadcasts are not retried but resume from the saved cursor once resumed
+	if broadcast.Status == domain.BroadcastStatusPaused {
+		return nil, NewBroadcastError(ErrCodeBroadcastPaused, "broadcast has been paused", false, nil)
+	}
+
 	// Apply the actual batch limit from config if not specified
 	if limit <= 0 {
 		limit = o.config.FetchBatchSize
 	}
 
 	// Fetch contacts based on broadcast audience using cursor-based pagination
-	contactsWithList, err := o.contactRepo.GetContactsForBroadcast(ctx, workspaceID, broadcast.Audience, limit, afterEmail)
+	var contactsWithList []*domain.ContactWithList
+	if broadcast.Audience.CSV {
+		contactsWithList, err = o.fetchCSVRecipients(ctx, workspaceID, broadcast, limit, afterEmail)
+	} else {
+		contactsWithList, err = o.contactRepo.GetContactsForBroadcast(ctx, workspaceID, broadcast.Audience, limit, afterEmail)
+	}
 	if err != nil {
 		// codecov:ignore:start
 		o.logger.WithFields(map[string]interface{}{
@@ -287,6 +402,22 @@ func (o *BroadcastOrchestrator) FetchBatch(ctx context.Context, workspaceID, bro
 	return contactsWithList, nil
 }
 
+// countCSVRecipients counts the uploaded recipients of a broadcast with a CSV audience
+func (o *BroadcastOrchestrator) countCSVRecipients(ctx context.Context, workspaceID string, broadcast *domain.Broadcast) (int, error) {
+	if o.audienceRepo == nil {
+		return 0, fmt.Errorf("CSV audiences are not available")
+	}
+	return o.audienceRepo.CountRecipients(ctx, workspaceID, broadcast.ID, broadcast.Audience)
+}
+
+// fetchCSVRecipients retrieves a batch of the uploaded recipients of a broadcast with a CSV audience
+func (o *BroadcastOrchestrator) fetchCSVRecipients(ctx context.Context, workspaceID string, broadcast *domain.Broadcast, limit int, afterEmail string) ([]*domain.ContactWithList, error) {
+	if o.audienceRepo == nil {
+		return nil, fmt.Errorf("CSV audiences are not available")
+	}
+	return o.audienceRepo.GetContactsForBroadcast(ctx, workspaceID, broadcast.ID, broadcast.Audience, limit, afterEmail)
+}
+
 // FormatDuration formats a duration in a human-readable form
 func FormatDuration(d time.Duration) string {
 	if d < time.Minute {
@@ -315,18 +446,30 @@ func CalculateProgress(processed, total int) float64 {
 	return progress
 }
 
+// EstimateRemaining extrapolates the time left to process the remaining recipients from the
+// elapsed time, it returns zero until enough recipients were processed for a useful estimate
+func EstimateRemaining(processed, total int, elapsed time.Duration) time.Duration {
+	progress := CalculateProgress(processed, total)
+	if progress <= 5.0 || processed <= 0 || processed >= total {
+		return 0
+	}
+
+	estimatedTotalSeconds := elapsed.Seconds() * float64(total) / float64(processed)
+	remainingSeconds := estimatedTotalSeconds - elapsed.Seconds()
+	if remainingSeconds <= 0 {
+		return 0
+	}
+	return time.Duration(remainingSeconds * float64(time.Second))
+}
+
 // FormatProgressMessage creates a human-readable progress message
 func FormatProgressMessage(processed, total int, elapsed time.Duration) string {
 	progress := CalculateProgress(processed, total)
 
 	// Calculate remaining time when in progress (not completed) and we have enough data
 	var eta string
-	if progress > 5.0 && processed > 0 && processed < total {
-		estimatedTotalSeconds := elapsed.Seconds() * float64(total) / float64(processed)
-		remainingSeconds := estimatedTotalSeconds - elapsed.Seconds()
-		if remainingSeconds > 0 {
-			eta = fmt.Sprintf(", ETA: %s", FormatDuration(time.Duration(remainingSeconds)*time.Second))
-		}
+	if remaining := EstimateRemaining(processed, total, elapsed); remaining > 0 {
+		eta = fmt.Sprintf(", ETA: %s", FormatDuration(remaining.Truncate(time.Second)))
 	}
 
 	return fmt.Sprintf("Processed %d/%d recipients (%.1f%%)%s",
@@ -384,6 +527,24 @@ func (o *BroadcastOrchestrator) SaveProgressState(
 		"failed":       failedCount,
 	}).Debug("Saved progress state")
 
+	// Stream the progress to the clients following the broadcast
+	if o.eventBus != nil {
+		o.eventBus.Publish(context.Background(), domain.EventPayload{
+			Type:        domain.EventBroadcastProgress,
+			WorkspaceID: workspaceID,
+			EntityID:    broadcastState.BroadcastID,
+			Data: map[string]interface{}{
+				"processed":   processedCount,
+				"total":       broadcastState.TotalRecipients,
+				"percent":     progress,
+				"sent":        sentCount,
+				"failed":      failedCount,
+				"eta_seconds": int(EstimateRemaining(processedCount, broadcastState.TotalRecipients, elapsedSinceStart).Seconds()),
+				"message":     message,
+			},
+		})
+	}
+
 	return currentTime, nil
 }
 
@@ -399,9 +560,10 @@ func (o *BroadcastOrchestrator) Process(ctx context.Context, task *domain.Task,
 	var err error
 	var allDone bool
 
-	// Defer function to mark broadcast as failed if we're returning an error on the last retry
+	// Defer function to mark broadcast as failed if we're returning an error on the last retry,
+	// or right away when its sender is not verified
 	defer func() {
-		if err != nil && isLastRetry && broadcastID != "" {
+		if err != nil && (isLastRetry || isSenderError(err)) && broadcastID != "" {
 			// Check if the error is a circuit breaker error - don't mark as failed in that case
 			if broadcastErr, ok := err.(*BroadcastError); ok && broadcastErr.Code == ErrCodeCircuitOpen {
 				o.logger.WithFields(map[string]interface{}{
@@ -420,7 +582,7 @@ func (o *BroadcastOrchestrator) Process(ctx context.Context, task *domain.Task,
 				"retry_count":  task.RetryCount,
 				"max_retries":  task.MaxRetries,
 				"error":        err.Error(),
-			}).Info("Task failed on last retry attempt, marking broadcast as failed")
+			}).Info("Task failed on last retry attempt or on an unverified sender, marking broadcast as failed")
 
 			// Get the broadcast
 			broadcast, getBroadcastErr := o.broadcastRepo.GetBroadcast(ctx, task.WorkspaceID, broadcastID)
@@ -450,6 +612,7 @@ func (o *BroadcastOrchestrator) Process(ctx context.Context, task *domain.Task,
 					"task_id":      task.ID,
 					"broadcast_id": broadcastID,
 				}).Info("Broadcast marked as failed due to max retries reached")
+				o.publishPhaseChange(task.State.SendBroadcast, broadcast)
 			}
 
 		}
@@ -521,9 +684,22 @@ func (o *BroadcastOrchestrator) Process(ctx context.Context, task *domain.Task,
 	// Track if broadcast was cancelled during processing
 	broadcastCancelledDuringProcessing := false
 
+	// Track if the broadcast send cutoff was reached during processing
+	sendCutoffReached := false
+
+	// Track if the workspace daily send quota was used up during processing
+	quotaExceeded := false
+
+	// Track if processing stopped because the server is shutting down
+	stoppedForShutdown := false
+
 	// Phase 1: Get recipient count if not already set
 	if broadcastState.TotalRecipients == 0 {
 		count, countErr := o.GetTotalRecipientCount(ctx, task.WorkspaceID, broadcastState.BroadcastID)
+		if broadcastErr, ok := countErr.(*BroadcastError); ok && broadcastErr.Code == ErrCodeBroadcastNotDue {
+			err = o.deferUntilScheduled(ctx, task, broadcastState.BroadcastID)
+			return false, err
+		}
 		if countErr != nil {
 			// codecov:ignore:start
 			o.logger.WithFields(map[string]interface{}{
@@ -537,7 +713,6 @@ func (o *BroadcastOrchestrator) Process(ctx context.Context, task *domain.Task,
 		}
 
 		broadcastState.TotalRecipients = count
-		broadcastState.ChannelType = "email" // Hardcoded for now
 
 		task.State.Message = "Preparing to send broadcast"
 		task.Progress = 0
@@ -547,7 +722,6 @@ func (o *BroadcastOrchestrator) Process(ctx context.Context, task *domain.Task,
 			"task_id":          task.ID,
 			"broadcast_id":     broadcastState.BroadcastID,
 			"total_recipients": broadcastState.TotalRecipients,
-			"channel_type":     broadcastState.ChannelType,
 		}).Info("Broadcast sending initialized")
 		// codecov:ignore:end
 
@@ -586,7 +760,7 @@ func (o *BroadcastOrchestrator) Process(ctx context.Context, task *domain.Task,
 			broadcast.CompletedAt = &completedAt
 
 			// Save the updated broadcast (includes enqueued_count atomically)
-			if updateErr := o.broadcastRepo.UpdateBroadcast(context.Background(), broadcast); updateErr != nil {
+			if updateErr := o.updateFinalStatus(context.Background(), broadcast); updateErr != nil {
 				o.logger.WithFields(map[string]interface{}{
 					"task_id":      task.ID,
 					"broadcast_id": broadcastState.BroadcastID,
@@ -600,6 +774,7 @@ func (o *BroadcastOrchestrator) Process(ctx context.Context, task *domain.Task,
 				"task_id":      task.ID,
 				"broadcast_id": broadcastState.BroadcastID,
 			}).Info("Broadcast marked as processed successfully (no recipients)")
+			o.publishPhaseChange(broadcastState, broadcast)
 
 			allDone = true
 			return allDone, err
@@ -620,24 +795,105 @@ func (o *BroadcastOrchestrator) Process(ctx context.Context, task *domain.Task,
 		return false, err
 	}
 
-	// Get the email provider and integration ID using the workspace's GetEmailProviderWithIntegrationID method
-	emailProvider, integrationID, providerErr := workspace.GetEmailProviderWithIntegrationID(true)
-	if providerErr != nil {
-		err = providerErr
+	// Get the broadcast to access its channel and template variations
+	broadcast, err := o.broadcastRepo.GetBroadcast(ctx, task.WorkspaceID, broadcastState.BroadcastID)
+	if err != nil {
 		return false, err
 	}
 
-	// Validate that the provider is configured
-	if emailProvider == nil || emailProvider.Kind == "" {
-		err = fmt.Errorf("no email provider configured for marketing emails")
-		return false, err
+	channelType := broadcast.ChannelType
+	if channelType == "" {
+		channelType = domain.ChannelEmail
 	}
+	broadcastState.ChannelType = channelType
 
-	// Get the broadcast to access its template variations
-	broadcast, err := o.broadcastRepo.GetBroadcast(ctx, task.WorkspaceID, broadcastState.BroadcastID)
-	if err != nil {
+	var emailProvider *domain.EmailProvider
+	var integrationID string
+	messageSender := o.messageSender
+
+	if channelType == domain.ChannelSMS {
+		// SMS broadcasts are sent through the workspace SMS provider
+		if o.smsSender == nil {
+			err = NewBroadcastErrorWithTask(ErrCodeTaskStateInvalid, "sms broadcasts are not available", task.ID, false, nil)
+			return false, err
+		}
+
+		smsProvider, smsIntegrationID, providerErr := workspace.GetSMSProviderWithIntegrationID()
+		if providerErr != nil {
+			err = providerErr
+			return false, err
+		}
+		if smsProvider == nil || smsProvider.Kind == "" {
+			err = fmt.Errorf("no sms provider configured for broadcasts")
+			return false, err
+		}
+
+		integrationID = smsIntegrationID
+		messageSender = o.smsSender
+	} else {
+		// Get the email provider and integration ID using the workspace's GetEmailProviderWithIntegrationID method
+		var providerErr error
+		emailProvider, integrationID, providerErr = workspace.GetEmailProviderWithIntegrationID(true)
+		if providerErr != nil {
+			err = providerErr
+			return false, err
+		}
+
+		// Validate that the provider is configured
+		if emailProvider == nil || emailProvider.Kind == "" {
+			err = fmt.Errorf("no email provider configured for marketing emails")
+			return false, err
+		}
+	}
+
+	// The dry-run flag is captured once, a broadcast never switches to real sends halfway
+	if broadcast.DryRun && !broadcastState.DryRun {
+		broadcastState.DryRun = true
+	}
+	if broadcastState.DryRun {
+		// The dry-run sender renders emails, sms broadcasts have no email content to render
+		if o.dryRunSender == nil || channelType == domain.ChannelSMS {
+			err = NewBroadcastErrorWithTask(ErrCodeTaskStateInvalid, "dry run is not available", task.ID, false, nil)
+			return false, err
+		}
+		messageSender = o.dryRunSender
+	}
+
+	// A scheduled broadcast, or one sent during the workspace cool-off, starts sending when its task first runs
+	// at or after its scheduled time
+	if _, notDueErr := o.scheduledStart(broadcast); notDueErr != nil {
+		err = o.deferUntilScheduled(ctx, task, broadcastState.BroadcastID)
 		return false, err
 	}
+	if broadcast.Status == domain.BroadcastStatusScheduled || broadcast.Status == domain.BroadcastStatusStartingSoon {
+		now := o.timeProvider.Now().UTC()
+		broadcast.Status = domain.BroadcastStatusProcessing
+		broadcast.StartedAt = &now
+		broadcast.UpdatedAt = now
+		if err = o.broadcastRepo.UpdateBroadcast(ctx, broadcast); err != nil {
+			err = fmt.Errorf("failed to update broadcast status to processing: %w", err)
+			return false, err
+		}
+	}
+
+	// A broadcast deferred by the daily send quota resumes sending on the next UTC day
+	if broadcast.Status == domain.BroadcastStatusQuotaExceeded {
+		broadcast.Status = resumedStatus(broadcastState)
+		broadcast.UpdatedAt = o.timeProvider.Now().UTC()
+		if err = o.broadcastRepo.UpdateBroadcast(ctx, broadcast); err != nil {
+			err = fmt.Errorf("failed to update broadcast status to %s: %w", broadcast.Status, err)
+			return false, err
+		}
+	}
+
+	// Publish transitions made since the last execution, e.g. a manual winner selection
+	o.publishPhaseChange(broadcastState, broadcast)
+
+	// The main send is held until the seed test is approved
+	if broadcast.Status == domain.BroadcastStatusSeedPending {
+		task.State.Message = seedTestMessage
+		return false, nil
+	}
 
 	// Check if we should perform auto winner evaluation
 	if broadcastState.Phase == "test" && broadcast.Status == domain.BroadcastStatusTestCompleted {
@@ -655,6 +911,7 @@ func (o *BroadcastOrchestrator) Process(ctx context.Context, task *domain.Task,
 			if err != nil {
 				return false, err
 			}
+			o.publishPhaseChange(broadcastState, broadcast)
 		}
 	}
 
@@ -683,6 +940,7 @@ func (o *BroadcastOrchestrator) Process(ctx context.Context, task *domain.Task,
 							return false, fmt.Errorf("failed to update broadcast status to testing: %w", err)
 						}
 						o.logger.WithField("broadcast_id", broadcast.ID).Info("A/B test phase started - broadcast status updated to testing")
+						o.publishPhaseChange(broadcastState, broadcast)
 					}
 				}
 			case domain.BroadcastStatusWinnerSelected:
@@ -735,6 +993,12 @@ func (o *BroadcastOrchestrator) Process(ctx context.Context, task *domain.Task,
 		for i, variation := range broadcast.TestSettings.Variations {
 			templateIDs[i] = variation.TemplateID
 		}
+		// The members of the lists with their own template receive it instead
+		for _, templateID := range broadcast.Audience.ListTemplates {
+			if !slices.Contains(templateIDs, templateID) {
+				templateIDs = append(templateIDs, templateID)
+			}
+		}
 	}
 
 	// Phase 2: Load templates
@@ -752,8 +1016,16 @@ func (o *BroadcastOrchestrator) Process(ctx context.Context, task *domain.Task,
 		return false, err
 	}
 
+	// Every template must be written for the channel of the broadcast
+	for id, template := range templates {
+		if template != nil && (template.Channel == domain.ChannelSMS) != (channelType == domain.ChannelSMS) {
+			err = NewBroadcastError(ErrCodeTemplateInvalid, fmt.Sprintf("template %s does not match the %s channel of the broadcast", id, channelType), false, nil)
+			return false, err
+		}
+	}
+
 	// Validate templates
-	if validateErr := o.ValidateTemplates(templates); validateErr != nil {
+	if validateErr := o.ValidateTemplates(templates, emailProvider, &workspace.Settings); validateErr != nil {
 		// codecov:ignore:start
 		o.logger.WithFields(map[string]interface{}{
 			"task_id":      task.ID,
@@ -765,6 +1037,56 @@ func (o *BroadcastOrchestrator) Process(ctx context.Context, task *domain.Task,
 		return false, err
 	}
 
+	// Pin the template versions sent first so a resume can detect templates edited meanwhile
+	if broadcast.PinTemplateVersions(templates) {
+		broadcast.UpdatedAt = time.Now().UTC()
+		if updateErr := o.broadcastRepo.UpdateBroadcast(ctx, broadcast); updateErr != nil {
+			o.logger.WithFields(map[string]interface{}{
+				"broadcast_id": broadcast.ID,
+				"error":        updateErr.Error(),
+			}).Error("Failed to pin broadcast template versions")
+			err = fmt.Errorf("failed to pin broadcast template versions: %w", updateErr)
+			return false, err
+		}
+	}
+
+	// Templates inherit the workspace open/click tracking defaults unless they override them
+	for id, template := range templates {
+		templates[id] = template.WithTrackingDefaults(&workspace.Settings)
+	}
+
+	// Use workspace CustomEndpointURL if provided, otherwise use default API endpoint
+	endpoint := o.apiEndpoint
+	if workspace.Settings.CustomEndpointURL != nil && *workspace.Settings.CustomEndpointURL != "" {
+		endpoint = *workspace.Settings.CustomEndpointURL
+	}
+
+	// The seed addresses are sent to before any recipient, the main send may then wait for the approval
+	if broadcast.SeedTest.Enabled && broadcast.SeedTest.SentAt == nil {
+		held, seedErr := o.sendSeedTest(ctx, task, broadcast, broadcastState, func(seeds []*domain.ContactWithList) (domain.BatchSendResult, error) {
+			return messageSender.SendBatch(
+				ctx,
+				task.WorkspaceID,
+				integrationID,
+				workspace.Settings.SecretKey,
+				endpoint,
+				workspace.Settings.EmailTrackingEnabled,
+				broadcastState.BroadcastID,
+				seeds,
+				templates,
+				emailProvider,
+				o.shutdown.capToShutdown(timeoutAt),
+			)
+		})
+		if seedErr != nil {
+			err = seedErr
+			return false, err
+		}
+		if held {
+			return false, nil
+		}
+	}
+
 	// Phase 3: Process recipients in batches with a timeout
 	// Use the timeoutAt parameter passed from task service
 	processTimeoutAt := timeoutAt
@@ -781,6 +1103,23 @@ func (o *BroadcastOrchestrator) Process(ctx context.Context, task *domain.Task,
 	// Cursor for keyset pagination - tracks the last processed email
 	cursor := broadcastState.LastProcessedEmail
 
+	// The emails sent by earlier executions of a resumed task are only known to the message history: a
+	// contact sent before a pause may reappear in a later batch, and the checkpoint may be older than what
+	// was actually sent (e.g. after a crash). Each batch is then cross-checked to never send a recipient twice.
+	skipSentRecipients := o.messageHistoryRepo != nil &&
+		(broadcastState.RecipientOffset > 0 || broadcastState.LastProcessedEmail != "")
+
+	// Emails sent by this execution, so that recipients reappearing in a later batch are sent once
+	sentInRun := make(map[string]bool)
+
+	// Waits of throttled broadcasts must end before the task deadline and the max process time
+	throttleDeadline := processTimeoutAt
+	if o.config.MaxProcessTime > 0 {
+		if maxProcessAt := startTime.Add(o.config.MaxProcessTime); maxProcessAt.Before(throttleDeadline) {
+			throttleDeadline = maxProcessAt
+		}
+	}
+
 	// If a winner has already been selected manually while test is running, transition immediately
 	if broadcastState.Phase == "test" {
 		if broadcast.WinningTemplate != nil || broadcast.Status == domain.BroadcastStatusWinnerSelected {
@@ -802,9 +1141,22 @@ func (o *BroadcastOrchestrator) Process(ctx context.Context, task *domain.Task,
 
 	// Process until timeout or completion
 	for {
+		// Stop at the batch boundary when the server shuts down, the progress is checkpointed after the loop
+		if _, shuttingDown := o.shutdown.Deadline(); shuttingDown || ctx.Err() != nil {
+			o.logger.WithFields(map[string]interface{}{
+				"broadcast_id": broadcastState.BroadcastID,
+				"task_id":      task.ID,
+				"offset":       broadcastState.RecipientOffset,
+			}).Info("Server shutting down - checkpointing broadcast")
+			stoppedForShutdown = true
+			allDone = false
+			break
+		}
+
 		// Refresh broadcast each iteration to observe external changes (e.g., manual winner selection, cancellation)
 		if refreshed, refreshErr := o.broadcastRepo.GetBroadcast(ctx, task.WorkspaceID, broadcastState.BroadcastID); refreshErr == nil && refreshed != nil {
 			broadcast = refreshed
+			o.publishPhaseChange(broadcastState, broadcast)
 
 			// Check if broadcast has been cancelled
 			if broadcast.Status == domain.BroadcastStatusCancelled {
@@ -839,6 +1191,18 @@ func (o *BroadcastOrchestrator) Process(ctx context.Context, task *domain.Task,
 				}).Info("Winner selected during test phase - transitioning to winner phase")
 			}
 		}
+		// Stop at the send cutoff: remaining recipients are skipped rather than sent stale content
+		if broadcast.Schedule.CutoffReached(o.timeProvider.Now()) {
+			o.logger.WithFields(map[string]interface{}{
+				"broadcast_id":   broadcast.ID,
+				"task_id":        task.ID,
+				"send_cutoff_at": broadcast.Schedule.SendCutoffAt,
+			}).Info("Broadcast send cutoff reached - stopping task")
+			sendCutoffReached = true
+			allDone = true
+			break
+		}
+
 		// Check time-based timeout
 		if time.Now().After(processTimeoutAt) {
 			o.logger.WithField("task_id", task.ID).Info("Processing time limit reached - pausing task")
@@ -884,7 +1248,7 @@ func (o *BroadcastOrchestrator) Process(ctx context.Context, task *domain.Task,
 
 		// Calculate remaining recipients for this phase
 		remainingInPhase := recipientLimit - currentOffset
-		batchSize := o.config.FetchBatchSize
+		batchSize := o.fetchBatchSize(broadcastState)
 		if remainingInPhase < batchSize {
 			batchSize = remainingInPhase
 		}
@@ -921,6 +1285,57 @@ func (o *BroadcastOrchestrator) Process(ctx context.Context, task *domain.Task,
 			break
 		}
 
+		// Throttled broadcasts only fetch what the send rate allows, and wait when the last second is used up
+		maxSendsPerSecond := o.sendRateLimit(broadcast)
+		if maxSendsPerSecond > 0 {
+			now := o.timeProvider.Now()
+			broadcastState.RecentSends = pruneRecentSends(broadcastState.RecentSends, now)
+			allowance, wait := sendAllowance(broadcastState.RecentSends, maxSendsPerSecond, now)
+			if allowance <= 0 {
+				if now.Add(wait).After(throttleDeadline) {
+					o.logger.WithFields(map[string]interface{}{
+						"task_id":              task.ID,
+						"broadcast_id":         broadcastState.BroadcastID,
+						"max_sends_per_second": maxSendsPerSecond,
+					}).Info("Send rate limit reached close to the processing time limit - pausing task")
+					allDone = false
+					break
+				}
+				if sleepErr := o.sleep(ctx, wait); sleepErr != nil {
+					stoppedForShutdown = ctx.Err() != nil
+					allDone = false
+					break
+				}
+				// Re-check the broadcast status and the deadlines before the next batch
+				continue
+			}
+			if batchSize > allowance {
+				batchSize = allowance
+			}
+		}
+
+		// The workspace daily send quota clamps the batch, the rest of the broadcast waits for the next day
+		if !broadcastState.DryRun {
+			quotaAllowance, quotaErr := o.dailySendAllowance(ctx, workspace)
+			if quotaErr != nil {
+				if broadcastErr, ok := quotaErr.(*BroadcastError); ok && broadcastErr.Code == ErrCodeQuotaExceeded {
+					o.logger.WithFields(map[string]interface{}{
+						"task_id":          task.ID,
+						"broadcast_id":     broadcastState.BroadcastID,
+						"daily_send_quota": workspace.Settings.DailySendQuota,
+					}).Info("Daily send quota reached - deferring broadcast")
+					quotaExceeded = true
+					allDone = false
+					break
+				}
+				err = quotaErr
+				return false, err
+			}
+			if quotaAllowance >= 0 && batchSize > quotaAllowance {
+				batchSize = quotaAllowance
+			}
+		}
+
 		// Fetch the next batch of recipients using cursor-based pagination
 		recipients, batchErr := o.FetchBatch(
 			ctx,
@@ -941,6 +1356,22 @@ func (o *BroadcastOrchestrator) Process(ctx context.Context, task *domain.Task,
 				err = nil
 				return allDone, err
 			}
+			// Paused between the status refresh and the fetch: keep the saved progress for the resume
+			if broadcastErr, ok := batchErr.(*BroadcastError); ok && broadcastErr.Code == ErrCodeBroadcastPaused {
+				o.logger.WithFields(map[string]interface{}{
+					"task_id":      task.ID,
+					"broadcast_id": broadcastState.BroadcastID,
+					"offset":       broadcastState.RecipientOffset,
+				}).Info("Broadcast paused - stopping task execution")
+				allDone = false
+				break
+			}
+			// The fetch was cancelled by the server shutdown, the task is resumed rather than failed
+			if ctx.Err() != nil {
+				stoppedForShutdown = true
+				allDone = false
+				break
+			}
 
 			err = batchErr
 			return false, err
@@ -986,26 +1417,82 @@ func (o *BroadcastOrchestrator) Process(ctx context.Context, task *domain.Task,
 			break
 		}
 
-		// Use workspace CustomEndpointURL if provided, otherwise use default API endpoint
-		endpoint := o.apiEndpoint
-		if workspace.Settings.CustomEndpointURL != nil && *workspace.Settings.CustomEndpointURL != "" {
-			endpoint = *workspace.Settings.CustomEndpointURL
+		// Leave out the recipients that already have a message for this broadcast
+		toSend := recipients
+		if skipSentRecipients {
+			var filterErr error
+			toSend, filterErr = o.filterSentRecipients(ctx, task.WorkspaceID, broadcastState.BroadcastID, recipients)
+			if filterErr != nil {
+				err = filterErr
+				return false, err
+			}
+			broadcastState.DuplicateCount += len(recipients) - len(toSend)
+		}
+		// Leave out the recipients processed past the cursor by a batch that stopped midway
+		if len(broadcastState.ResumeSentEmails) > 0 {
+			toSend = skipRecipients(toSend, broadcastState.ResumeSentEmails)
+		}
+		// Leave out the recipients already sent earlier in the run
+		toSend, duplicates := skipDuplicateRecipients(toSend, sentInRun)
+		if duplicates > 0 {
+			o.logger.WithFields(map[string]interface{}{
+				"broadcast_id": broadcastState.BroadcastID,
+				"workspace_id": task.WorkspaceID,
+				"duplicates":   duplicates,
+			}).Warn("Skipped recipients already sent in this broadcast run")
+			broadcastState.DuplicateCount += duplicates
 		}
 
-		// Process this batch of recipients
-		sent, failed, sendErr := o.messageSender.SendBatch(
-			ctx,
-			task.WorkspaceID,
-			integrationID,
-			workspace.Settings.SecretKey,
-			endpoint,
-			workspace.Settings.EmailTrackingEnabled,
-			broadcastState.BroadcastID,
-			recipients,
-			templates,
-			emailProvider,
-			processTimeoutAt,
-		)
+		// Defer each email to the hour its contact usually opens messages at. Test phase messages are sent
+		// immediately so that the variations are evaluated over the same time frame.
+		if broadcast.Audience.OptimizeSendTime && channelType == domain.ChannelEmail && !broadcastState.DryRun && broadcastState.Phase != "test" && len(toSend) > 0 {
+			o.optimizeSendTimes(ctx, task.WorkspaceID, broadcastState.BroadcastID, toSend)
+		}
+
+		// Process this batch of recipients, the batch must be done by the shutdown deadline if one has begun
+		var result domain.BatchSendResult
+		var sendErr error
+		batchTimeoutAt := o.shutdown.capToShutdown(processTimeoutAt)
+		if len(toSend) > 0 {
+			var sendStart time.Time
+			if o.config.AdaptiveBatchSize {
+				sendStart = o.timeProvider.Now()
+			}
+			result, sendErr = o.sendBatchWithRetry(ctx, broadcastState.BroadcastID, toSend, batchTimeoutAt, func(batch []*domain.ContactWithList) (domain.BatchSendResult, error) {
+				return messageSender.SendBatch(
+					ctx,
+					task.WorkspaceID,
+					integrationID,
+					workspace.Settings.SecretKey,
+					endpoint,
+					workspace.Settings.EmailTrackingEnabled,
+					broadcastState.BroadcastID,
+					batch,
+					templates,
+					emailProvider,
+					batchTimeoutAt,
+				)
+			})
+
+			if o.config.AdaptiveBatchSize {
+				// A batch interrupted by an error counts as failed as a whole
+				batchFailed := result.Failed()
+				if sendErr != nil {
+					batchFailed = len(toSend)
+				}
+				o.tuneBatchSize(broadcastState, len(toSend), batchFailed, o.timeProvider.Since(sendStart))
+			}
+		}
+		sent, failed := result.Sent(), result.Failed()
+		recordSentEmails(sentInRun, result)
+
+		// Record the batch once sent, all of its messages are then within the throttle window
+		if maxSendsPerSecond > 0 && sent+failed > 0 {
+			broadcastState.RecentSends = append(broadcastState.RecentSends, domain.ThrottledSend{
+				At:    o.timeProvider.Now(),
+				Count: sent + failed,
+			})
+		}
 
 		// Handle errors during sending
 		if sendErr != nil {
@@ -1036,6 +1523,7 @@ func (o *BroadcastOrchestrator) Process(ctx context.Context, task *domain.Task,
 							"broadcast_id": broadcastState.BroadcastID,
 							"pause_reason": currentBroadcast.PauseReason,
 						}).Info("Broadcast paused due to circuit breaker")
+						o.publishPhaseChange(broadcastState, currentBroadcast)
 
 						// Publish circuit breaker event for notification handling
 						if o.eventBus != nil {
@@ -1114,22 +1602,22 @@ func (o *BroadcastOrchestrator) Process(ctx context.Context, task *domain.Task,
 		sentCount += sent
 		failedCount += failed
 
+		// Update cursor for next batch - only past the recipients confirmed as processed by the result,
+		// not past everything fetched: if SendBatch stops mid-batch, the unsent recipients are fetched
+		// and sent again. Those processed after the first unsent one are kept in ResumeSentEmails so
+		// that the retry does not send them twice. Recipients skipped as already sent count as processed.
+		doneInBatch, resumeSentEmails := batchProgress(recipients, toSend, result)
+		if doneInBatch > 0 {
+			cursor = recipientEmail(recipients[doneInBatch-1])
+			broadcastState.LastProcessedEmail = cursor
+		}
+		// If nothing was processed, don't update cursor (will retry same batch on next run)
+		broadcastState.ResumeSentEmails = resumeSentEmails
+
 		// Update recipient offset - used across all phases for continuity (progress tracking)
-		broadcastState.RecipientOffset += int64(sent + failed)
+		broadcastState.RecipientOffset += int64(doneInBatch)
 		currentOffset = int(broadcastState.RecipientOffset)
 
-		// Update cursor for next batch - use the email of the last PROCESSED contact
-		// This is critical: we must use sent+failed (what was actually processed), not len(recipients) (what was fetched)
-		// If SendBatch times out mid-batch, only part of the fetched batch may be processed
-		if len(recipients) > 0 {
-			processedInBatch := sent + failed
-			if processedInBatch > 0 && processedInBatch <= len(recipients) {
-				cursor = recipients[processedInBatch-1].Contact.Email
-				broadcastState.LastProcessedEmail = cursor
-			}
-			// If nothing was processed, don't update cursor (will retry same batch on next run)
-		}
-
 		// Use sent + failed as the number of recipients processed/attempted
 		processedCount = sentCount + failedCount
 
@@ -1195,6 +1683,36 @@ func (o *BroadcastOrchestrator) Process(ctx context.Context, task *domain.Task,
 	task.State.Message = message
 	task.Progress = progress
 
+	// Checkpoint the progress before giving the task back, it resumes from there on the next boot
+	if stoppedForShutdown {
+		if _, saveErr := o.SaveProgressState(
+			context.Background(),
+			task.WorkspaceID,
+			task.ID,
+			broadcastState,
+			sentCount,
+			failedCount,
+			processedCount,
+			lastSaveTime,
+			startTime,
+		); saveErr != nil {
+			// codecov:ignore:start
+			o.logger.WithFields(map[string]interface{}{
+				"task_id":      task.ID,
+				"broadcast_id": broadcastState.BroadcastID,
+				"error":        saveErr.Error(),
+			}).Error("Failed to checkpoint broadcast on shutdown")
+			// codecov:ignore:end
+		}
+		return false, nil
+	}
+
+	// The rest of the broadcast is sent once the daily send quota resets
+	if quotaExceeded {
+		err = o.deferToNextQuotaDay(ctx, task, broadcast, broadcastState)
+		return false, err
+	}
+
 	// If the task is complete, update the broadcast status appropriately
 	if allDone {
 		// Don't update status if broadcast was cancelled during processing
@@ -1215,6 +1733,11 @@ func (o *BroadcastOrchestrator) Process(ctx context.Context, task *domain.Task,
 			return false, fmt.Errorf("circuit breaker triggered during processing")
 		}
 
+		if sendCutoffReached {
+			err = o.completeAtSendCutoff(task, broadcast, broadcastState)
+			return err == nil, err
+		}
+
 		var statusMessage string
 
 		switch broadcastState.Phase {
@@ -1244,7 +1767,7 @@ func (o *BroadcastOrchestrator) Process(ctx context.Context, task *domain.Task,
 		broadcast.EnqueuedCount = broadcastState.EnqueuedCount
 
 		// Save the updated broadcast (includes enqueued_count atomically)
-		updateErr := o.broadcastRepo.UpdateBroadcast(context.Background(), broadcast)
+		updateErr := o.updateFinalStatus(context.Background(), broadcast)
 		if updateErr != nil {
 			// codecov:ignore:start
 			o.logger.WithFields(map[string]interface{}{
@@ -1266,6 +1789,7 @@ func (o *BroadcastOrchestrator) Process(ctx context.Context, task *domain.Task,
 			"phase":          broadcastState.Phase,
 		}).Info("Broadcast marked as " + statusMessage + " successfully")
 		// codecov:ignore:end
+		o.publishPhaseChange(broadcastState, broadcast)
 	}
 
 	// codecov:ignore:start
@@ -1282,6 +1806,285 @@ func (o *BroadcastOrchestrator) Process(ctx context.Context, task *domain.Task,
 	return allDone, err
 }
 
+// publishPhaseChange publishes a broadcast.phase_changed event when the broadcast status differs
+// from the last status published for the task. The published status is kept in the task state,
+// so a transition is published once even when the task is saved and resumed.
+func (o *BroadcastOrchestrator) publishPhaseChange(state *domain.SendBroadcastState, broadcast *domain.Broadcast) {
+	if state == nil || broadcast == nil || state.PublishedStatus == broadcast.Status {
+		return
+	}
+
+	from := state.PublishedStatus
+	if from == "" {
+		// Tasks are created when the broadcast is scheduled
+		from = domain.BroadcastStatusScheduled
+	}
+	state.PublishedStatus = broadcast.Status
+	if from == broadcast.Status || o.eventBus == nil {
+		return
+	}
+
+	event := domain.NewBroadcastPhaseChangedEvent(broadcast, from, o.timeProvider.Now())
+	// The completion of an A/B broadcast carries the stats of each variation and the winner
+	if broadcast.Status == domain.BroadcastStatusProcessed && broadcast.TestSettings.Enabled && o.abTestEvaluator != nil {
+		event.Data["ab_test"] = o.abTestEvaluator.TestOutcome(context.Background(), broadcast.WorkspaceID, broadcast)
+	}
+
+	o.eventBus.Publish(context.Background(), event)
+}
+
+// updateFinalStatus saves a broadcast that reached its final status. The write is retried with
+// backoff so that a transient database error does not fail a broadcast whose messages were all
+// enqueued; the error is returned once the retries are exhausted.
+func (o *BroadcastOrchestrator) updateFinalStatus(ctx context.Context, broadcast *domain.Broadcast) error {
+	backoff := o.config.StatusUpdateRetryBackoff
+	for attempt := 0; ; attempt++ {
+		err := o.broadcastRepo.UpdateBroadcast(ctx, broadcast)
+		if err == nil || attempt >= o.config.StatusUpdateRetries {
+			return err
+		}
+
+		o.logger.WithFields(map[string]interface{}{
+			"broadcast_id": broadcast.ID,
+			"status":       broadcast.Status,
+			"attempt":      attempt + 1,
+			"retry_in":     backoff.String(),
+			"error":        err.Error(),
+		}).Warn("Failed to update broadcast final status, retrying")
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return err
+		}
+		backoff *= 2
+	}
+}
+
+// sendBatchWithRetry sends a batch of recipients. When a transient provider error interrupts the
+// batch, the recipients it left unsent are sent again after an exponential backoff with jitter.
+// Once the retries are exhausted they are counted as failed so that the broadcast keeps progressing;
+// they are left unprocessed when the context or the process time runs out before the next retry.
+func (o *BroadcastOrchestrator) sendBatchWithRetry(
+	ctx context.Context,
+	broadcastID string,
+	recipients []*domain.ContactWithList,
+	timeoutAt time.Time,
+	send func(batch []*domain.ContactWithList) (domain.BatchSendResult, error),
+) (result domain.BatchSendResult, err error) {
+	remaining := recipients
+	for attempt := 0; ; attempt++ {
+		batchResult, sendErr := send(remaining)
+		result.SentEmails = append(result.SentEmails, batchResult.SentEmails...)
+		result.FailedEmails = append(result.FailedEmails, batchResult.FailedEmails...)
+		remaining = unprocessedRecipients(remaining, batchResult)
+
+		if sendErr == nil || len(remaining) == 0 || !isTransientSendError(sendErr) {
+			return result, sendErr
+		}
+		if attempt >= o.config.BatchRetries {
+			result.FailedEmails = append(result.FailedEmails, failAll(remaining).FailedEmails...)
+			return result, sendErr
+		}
+
+		delay := o.config.BatchRetryDelay(attempt, rand.Float64())
+		if time.Now().Add(delay).After(timeoutAt) {
+			return result, sendErr
+		}
+
+		o.logger.WithFields(map[string]interface{}{
+			"broadcast_id": broadcastID,
+			"attempt":      attempt + 1,
+			"remaining":    len(remaining),
+			"retry_in":     delay.String(),
+			"error":        sendErr.Error(),
+		}).Warn("Transient provider error sending batch, retrying")
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			return result, sendErr
+		}
+	}
+}
+
+// filterSentRecipients returns the recipients that have no message for the broadcast yet
+func (o *BroadcastOrchestrator) filterSentRecipients(ctx context.Context, workspaceID, broadcastID string, recipients []*domain.ContactWithList) ([]*domain.ContactWithList, error) {
+	emails := make([]string, 0, len(recipients))
+	for _, recipient := range recipients {
+		if recipient.Contact != nil {
+			emails = append(emails, recipient.Contact.Email)
+		}
+	}
+
+	sentEmails, err := o.messageHistoryRepo.GetSentEmailsForBroadcast(ctx, workspaceID, broadcastID, emails)
+	if err != nil {
+		o.logger.WithFields(map[string]interface{}{
+			"broadcast_id": broadcastID,
+			"workspace_id": workspaceID,
+			"error":        err.Error(),
+		}).Error("Failed to check already sent recipients")
+		return nil, NewBroadcastError(ErrCodeRecipientFetch, "failed to check already sent recipients", true, err)
+	}
+
+	toSend := skipRecipients(recipients, sentEmails)
+
+	if skipped := len(recipients) - len(toSend); skipped > 0 {
+		o.logger.WithFields(map[string]interface{}{
+			"broadcast_id": broadcastID,
+			"workspace_id": workspaceID,
+			"skipped":      skipped,
+		}).Info("Skipped recipients already sent before resume")
+	}
+
+	return toSend, nil
+}
+
+// skipRecipients returns the recipients whose email is not in the given list
+func skipRecipients(recipients []*domain.ContactWithList, emails []string) []*domain.ContactWithList {
+	skip := make(map[string]bool, len(emails))
+	for _, email := range emails {
+		skip[email] = true
+	}
+
+	kept := make([]*domain.ContactWithList, 0, len(recipients))
+	for _, recipient := range recipients {
+		if recipient.Contact != nil && skip[recipient.Contact.Email] {
+			continue
+		}
+		kept = append(kept, recipient)
+	}
+	return kept
+}
+
+// unprocessedRecipients returns the recipients of a batch that are not listed in its result
+func unprocessedRecipients(recipients []*domain.ContactWithList, result domain.BatchSendResult) []*domain.ContactWithList {
+	if result.Processed() == 0 {
+		return recipients
+	}
+
+	// Counted rather than flagged, recipients without a contact share the empty email
+	processed := make(map[string]int, result.Processed())
+	for _, email := range result.SentEmails {
+		processed[email]++
+	}
+	for _, email := range result.FailedEmails {
+		processed[email]++
+	}
+
+	var remaining []*domain.ContactWithList
+	for _, recipient := range recipients {
+		if email := recipientEmail(recipient); processed[email] > 0 {
+			processed[email]--
+			continue
+		}
+		remaining = append(remaining, recipient)
+	}
+	return remaining
+}
+
+// batchProgress returns how many recipients at the start of a fetched batch are done, whether
+// processed by the result or left out of toSend as already sent, and the emails of the done
+// recipients after the first one that is not
+func batchProgress(recipients, toSend []*domain.ContactWithList, result domain.BatchSendResult) (done int, resumeSentEmails []string) {
+	pending := make(map[*domain.ContactWithList]bool, len(toSend))
+	for _, recipient := range unprocessedRecipients(toSend, result) {
+		pending[recipient] = true
+	}
+
+	for done < len(recipients) && !pending[recipients[done]] {
+		done++
+	}
+	for _, recipient := range recipients[done:] {
+		if !pending[recipient] {
+			resumeSentEmails = append(resumeSentEmails, recipientEmail(recipient))
+		}
+	}
+	return done, resumeSentEmails
+}
+
+// scheduledStart returns the scheduled time of a broadcast still waiting to be sent, and a retryable
+// ErrCodeBroadcastNotDue error when that time, read in the schedule timezone, is not reached yet
+func (o *BroadcastOrchestrator) scheduledStart(broadcast *domain.Broadcast) (time.Time, error) {
+	if broadcast.Status != domain.BroadcastStatusScheduled || !broadcast.Schedule.IsScheduled {
+		return time.Time{}, nil
+	}
+
+	scheduledAt, err := broadcast.Schedule.ParseScheduledDateTime()
+	if err != nil || scheduledAt.IsZero() {
+		return time.Time{}, nil
+	}
+
+	if o.timeProvider.Now().Before(scheduledAt) {
+		return scheduledAt, NewBroadcastError(ErrCodeBroadcastNotDue, fmt.Sprintf("broadcast is scheduled for %s", scheduledAt.Format(time.RFC3339)), true, nil)
+	}
+	return scheduledAt, nil
+}
+
+// deferUntilScheduled moves the task of a broadcast run before its scheduled time to that time,
+// e.g. when the task was created or retried too early
+func (o *BroadcastOrchestrator) deferUntilScheduled(ctx context.Context, task *domain.Task, broadcastID string) error {
+	broadcast, err := o.broadcastRepo.GetBroadcast(ctx, task.WorkspaceID, broadcastID)
+	if err != nil {
+		return fmt.Errorf("failed to get broadcast: %w", err)
+	}
+
+	scheduledAt, notDueErr := o.scheduledStart(broadcast)
+	if notDueErr == nil {
+		// The scheduled time was reached meanwhile, the next run starts the broadcast
+		return nil
+	}
+
+	resumeAt := scheduledAt.UTC()
+	task.NextRunAfter = &resumeAt
+	task.State.Message = fmt.Sprintf("Broadcast scheduled for %s", scheduledAt.Format(time.RFC3339))
+
+	o.logger.WithFields(map[string]interface{}{
+		"task_id":      task.ID,
+		"broadcast_id": broadcastID,
+		"scheduled_at": scheduledAt.Format(time.RFC3339),
+		"timezone":     broadcast.Schedule.Timezone,
+	}).Info("Broadcast run before its scheduled time - deferring task")
+	return nil
+}
+
+// completeAtSendCutoff marks a broadcast stopped by its send cutoff as processed,
+// recording the recipients that were never enqueued as skipped
+func (o *BroadcastOrchestrator) completeAtSendCutoff(task *domain.Task, broadcast *domain.Broadcast, broadcastState *domain.SendBroadcastState) error {
+	skipped := broadcastState.TotalRecipients - int(broadcastState.RecipientOffset)
+	if skipped < 0 {
+		skipped = 0
+	}
+
+	completedAt := time.Now().UTC()
+	broadcast.Status = domain.BroadcastStatusProcessed
+	broadcast.CompletedAt = &completedAt
+	broadcast.UpdatedAt = completedAt
+	broadcast.EnqueuedCount = broadcastState.EnqueuedCount
+	broadcast.SkippedCount = skipped
+
+	if err := o.updateFinalStatus(context.Background(), broadcast); err != nil {
+		o.logger.WithFields(map[string]interface{}{
+			"task_id":      task.ID,
+			"broadcast_id": broadcast.ID,
+			"error":        err.Error(),
+		}).Error("Failed to update broadcast status after send cutoff")
+		return fmt.Errorf("failed to update broadcast status after send cutoff: %w", err)
+	}
+
+	task.State.Message = fmt.Sprintf("Send cutoff reached: %d recipients skipped", skipped)
+	o.publishPhaseChange(broadcastState, broadcast)
+
+	o.logger.WithFields(map[string]interface{}{
+		"task_id":        task.ID,
+		"broadcast_id":   broadcast.ID,
+		"enqueued_count": broadcast.EnqueuedCount,
+		"skipped_count":  skipped,
+	}).Info("Broadcast marked as processed at send cutoff")
+
+	return nil
+}
+
 // handleTestPhaseCompletion handles the transition from test phase to test_completed status
 func (o *BroadcastOrchestrator) handleTestPhaseCompletion(ctx context.Context, broadcast *domain.Broadcast, broadcastState *domain.SendBroadcastState) bool {
 	// Re-fetch latest broadcast state to avoid race with concurrent winner selection
@@ -1324,6 +2127,7 @@ func (o *BroadcastOrchestrator) handleTestPhaseCompletion(ctx context.Context, b
 		}).Error("Failed to update broadcast status to test_completed")
 		return false // Continue processing despite error
 	}
+	o.publishPhaseChange(broadcastState, broadcast)
 
 	o.logger.WithFields(map[string]interface{}{
 		"broadcast_id":    broadcast.ID,
@@ -1380,17 +2184,27 @@ func (o *BroadcastOrchestrator) evaluateWinner(ctx context.Context, broadcast *d
 	o.logger.WithField("broadcast_id", broadcast.ID).Info("Performing automatic winner evaluation")
 
 	// Perform the evaluation using the ABTestEvaluator
-	winnerTemplateID, err := o.abTestEvaluator.EvaluateAndSelectWinner(ctx, broadcast.WorkspaceID, broadcast.ID)
+	evaluation, err := o.abTestEvaluator.EvaluateAndSelectWinner(ctx, broadcast.WorkspaceID, broadcast.ID)
 	if err != nil {
 		return fmt.Errorf("auto winner evaluation failed: %w", err)
 	}
 
+	// Without a significant winner the broadcast stays test_completed, evaluated again on the next run
+	if evaluation.WinnerTemplateID == "" {
+		o.logger.WithFields(map[string]interface{}{
+			"broadcast_id": broadcast.ID,
+			"leader":       evaluation.LeaderTemplateID,
+			"runner_up":    evaluation.RunnerUpTemplateID,
+		}).Info("No significant A/B test winner yet")
+		return nil
+	}
+
 	// Update task state to proceed to winner phase
 	broadcastState.Phase = "winner"
 
 	o.logger.WithFields(map[string]interface{}{
 		"broadcast_id":    broadcast.ID,
-		"winner_template": winnerTemplateID,
+		"winner_template": evaluation.WinnerTemplateID,
 	}).Info("Auto winner evaluation completed successfully")
 
 	return nil
@@ -0,0 +1,179 @@
+package broadcast
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupBatchRetryTest(t *testing.T) *BroadcastOrchestrator {
+	f := newOrchestratorFixture(t)
+	f.config = TestConfig()
+	return f.build()
+}
+
+func batchRetryRecipients(n int) []*domain.ContactWithList {
+	recipients := make([]*domain.ContactWithList, n)
+	for i := range recipients {
+		recipients[i] = &domain.ContactWithList{Contact: &domain.Contact{Email: string(rune('a'+i)) + "@example.com"}}
+	}
+	return recipients
+}
+
+// sentResult returns the result of a batch that sent the given recipients
+func sentResult(recipients []*domain.ContactWithList) domain.BatchSendResult {
+	var result domain.BatchSendResult
+	for _, recipient := range recipients {
+		result.SentEmails = append(result.SentEmails, recipient.Contact.Email)
+	}
+	return result
+}
+
+// sendAll is a SendBatch stub that sends every recipient of the batch
+func sendAll(_ context.Context, _, _, _, _ string, _ bool, _ string, batch []*domain.ContactWithList, _ map[string]*domain.Template, _ *domain.EmailProvider, _ time.Time) (domain.BatchSendResult, error) {
+	return sentResult(batch), nil
+}
+
+// sendFirst returns a SendBatch stub that sends the first n recipients of the batch and then stops with err
+func sendFirst(n int, err error) func(context.Context, string, string, string, string, bool, string, []*domain.ContactWithList, map[string]*domain.Template, *domain.EmailProvider, time.Time) (domain.BatchSendResult, error) {
+	return func(_ context.Context, _, _, _, _ string, _ bool, _ string, batch []*domain.ContactWithList, _ map[string]*domain.Template, _ *domain.EmailProvider, _ time.Time) (domain.BatchSendResult, error) {
+		if n < len(batch) {
+			batch = batch[:n]
+		}
+		return sentResult(batch), err
+	}
+}
+
+func transientSendError() error {
+	return NewBroadcastError(ErrCodeSendFailed, "failed to send message", true, errors.New("ThrottlingException: Maximum sending rate exceeded"))
+}
+
+func TestBroadcastOrchestrator_SendBatchWithRetry(t *testing.T) {
+	timeoutAt := time.Now().Add(time.Minute)
+
+	t.Run("two transient failures followed by success send every recipient", func(t *testing.T) {
+		orchestrator := setupBatchRetryTest(t)
+		recipients := batchRetryRecipients(5)
+
+		var attempts [][]*domain.ContactWithList
+		result, err := orchestrator.sendBatchWithRetry(context.Background(), "broadcast-1", recipients, timeoutAt, func(batch []*domain.ContactWithList) (domain.BatchSendResult, error) {
+			attempts = append(attempts, batch)
+			switch len(attempts) {
+			case 1:
+				// Two recipients sent, one permanent failure, then the provider throttles
+				result := sentResult(batch[:2])
+				result.FailedEmails = []string{batch[2].Contact.Email}
+				return result, transientSendError()
+			case 2:
+				return domain.BatchSendResult{}, transientSendError()
+			default:
+				return sentResult(batch), nil
+			}
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, 4, result.Sent())
+		assert.Equal(t, 1, result.Failed())
+		require.Len(t, attempts, 3)
+		// Retries only resend the recipients left unsent
+		assert.Equal(t, recipients[3:], attempts[1])
+		assert.Equal(t, recipients[3:], attempts[2])
+	})
+
+	t.Run("exhausted retries count the remaining recipients as failed", func(t *testing.T) {
+		orchestrator := setupBatchRetryTest(t)
+		orchestrator.config.BatchRetries = 2
+
+		calls := 0
+		result, err := orchestrator.sendBatchWithRetry(context.Background(), "broadcast-1", batchRetryRecipients(3), timeoutAt, func(batch []*domain.ContactWithList) (domain.BatchSendResult, error) {
+			calls++
+			return domain.BatchSendResult{}, transientSendError()
+		})
+
+		require.Error(t, err)
+		assert.Equal(t, 3, calls)
+		assert.Equal(t, 0, result.Sent())
+		assert.Equal(t, []string{"a@example.com", "b@example.com", "c@example.com"}, result.FailedEmails)
+	})
+
+	t.Run("permanent errors are not retried", func(t *testing.T) {
+		orchestrator := setupBatchRetryTest(t)
+
+		calls := 0
+		result, err := orchestrator.sendBatchWithRetry(context.Background(), "broadcast-1", batchRetryRecipients(3), timeoutAt, func(batch []*domain.ContactWithList) (domain.BatchSendResult, error) {
+			calls++
+			return domain.BatchSendResult{}, NewBroadcastError(ErrCodeBroadcastNotFound, "broadcast not found", false, nil)
+		})
+
+		require.Error(t, err)
+		assert.Equal(t, 1, calls)
+		assert.Equal(t, 0, result.Processed())
+	})
+
+	t.Run("open circuit is not retried", func(t *testing.T) {
+		orchestrator := setupBatchRetryTest(t)
+
+		calls := 0
+		_, err := orchestrator.sendBatchWithRetry(context.Background(), "broadcast-1", batchRetryRecipients(3), timeoutAt, func(batch []*domain.ContactWithList) (domain.BatchSendResult, error) {
+			calls++
+			return domain.BatchSendResult{}, NewBroadcastError(ErrCodeCircuitOpen, "circuit breaker is open", true, nil)
+		})
+
+		require.Error(t, err)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("retry past the process timeout leaves the recipients unprocessed", func(t *testing.T) {
+		orchestrator := setupBatchRetryTest(t)
+		orchestrator.config.BatchRetryBackoff = 2 * time.Minute
+		orchestrator.config.BatchRetryMaxBackoff = 2 * time.Minute
+		orchestrator.config.BatchRetryJitter = 0
+
+		calls := 0
+		result, err := orchestrator.sendBatchWithRetry(context.Background(), "broadcast-1", batchRetryRecipients(3), timeoutAt, func(batch []*domain.ContactWithList) (domain.BatchSendResult, error) {
+			calls++
+			return sentResult(batch[:1]), transientSendError()
+		})
+
+		require.Error(t, err)
+		assert.Equal(t, 1, calls)
+		assert.Equal(t, []string{"a@example.com"}, result.SentEmails)
+		assert.Equal(t, 0, result.Failed())
+	})
+}
+
+func TestBroadcastOrchestrator_Process_BatchRetry(t *testing.T) {
+	orchestrator, task, messageSender, _ := setupDryRunOrchestratorTest(t, false)
+	orchestrator.config.BatchRetries = 3
+	orchestrator.config.BatchRetryBackoff = time.Millisecond
+	orchestrator.config.BatchRetryMaxBackoff = 5 * time.Millisecond
+	orchestrator.config.BatchRetryJitter = 0.5
+
+	gomock.InOrder(
+		messageSender.EXPECT().
+			SendBatch(gomock.Any(), "workspace-123", "marketing-provider-id", "secret-key", gomock.Any(), true, "broadcast-123", gomock.Len(3), gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(sendFirst(1, transientSendError())),
+		messageSender.EXPECT().
+			SendBatch(gomock.Any(), "workspace-123", "marketing-provider-id", "secret-key", gomock.Any(), true, "broadcast-123", gomock.Len(2), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(domain.BatchSendResult{}, transientSendError()),
+		messageSender.EXPECT().
+			SendBatch(gomock.Any(), "workspace-123", "marketing-provider-id", "secret-key", gomock.Any(), true, "broadcast-123", gomock.Len(2), gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(sendAll),
+	)
+
+	done, err := orchestrator.Process(context.Background(), task, time.Now().Add(30*time.Second))
+	require.NoError(t, err)
+	assert.True(t, done)
+
+	state := task.State.SendBroadcast
+	assert.Equal(t, 3, state.EnqueuedCount)
+	assert.Equal(t, 0, state.FailedCount)
+	assert.Equal(t, int64(3), state.RecipientOffset)
+	assert.Equal(t, "user3@example.com", state.LastProcessedEmail)
+}
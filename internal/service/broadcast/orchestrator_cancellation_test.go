@@ -30,6 +30,7 @@ func TestBroadcastOrchestrator_Process_CancelledBroadcast(t *testing.T) {
 	mockLogger := pkgmocks.NewMockLogger(ctrl)
 	mockTimeProvider := mocks.NewMockTimeProvider(ctrl)
 	mockEventBus := domainmocks.NewMockEventBus(ctrl)
+	mockEventBus.EXPECT().Publish(gomock.Any(), gomock.Any()).AnyTimes()
 
 	// Setup logger expectations
 	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
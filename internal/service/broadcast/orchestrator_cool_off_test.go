@@ -0,0 +1,77 @@
+package broadcast
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type coolOffTestSetup struct {
+	*orchestratorFixture
+	orchestrator *BroadcastOrchestrator
+	statuses     *[]domain.BroadcastStatus
+	now          time.Time
+}
+
+// setupCoolOffTest prepares the task of a broadcast of 2 recipients that was sent during the workspace
+// cool-off and has the given status when the task first runs at the end of the cool-off
+func setupCoolOffTest(t *testing.T, status domain.BroadcastStatus) *coolOffTestSetup {
+	f := newOrchestratorFixture(t)
+	now := time.Date(2026, 3, 1, 12, 5, 0, 0, time.UTC)
+	f.clock.now = now
+	f.workspace.Settings.SendCoolOff = &domain.SendCoolOffSettings{Enabled: true, Minutes: 5}
+	coolOffUntil := now
+	f.broadcast.Status = status
+	f.broadcast.Schedule = domain.ScheduleSettings{CoolOffUntil: &coolOffUntil}
+	f.task.State.SendBroadcast.TotalRecipients = 2
+
+	statuses := []domain.BroadcastStatus{}
+	f.broadcastRepo.EXPECT().UpdateBroadcast(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, b *domain.Broadcast) error {
+		statuses = append(statuses, b.Status)
+		return nil
+	}).AnyTimes()
+
+	return &coolOffTestSetup{orchestratorFixture: f, orchestrator: f.build(), statuses: &statuses, now: now}
+}
+
+func TestBroadcastOrchestrator_Process_SendCoolOff(t *testing.T) {
+	t.Run("cancelled during the cool-off sends nothing", func(t *testing.T) {
+		setup := setupCoolOffTest(t, domain.BroadcastStatusCancelled)
+
+		// No recipient is fetched and the message sender has no expectation
+		done, err := setup.orchestrator.Process(context.Background(), setup.task, time.Now().Add(30*time.Second))
+		require.NoError(t, err)
+		assert.True(t, done)
+		assert.NotContains(t, *setup.statuses, domain.BroadcastStatusProcessing)
+		assert.Equal(t, 0, setup.task.State.SendBroadcast.EnqueuedCount)
+	})
+
+	t.Run("starting soon broadcast sends after the cool-off", func(t *testing.T) {
+		setup := setupCoolOffTest(t, domain.BroadcastStatusStartingSoon)
+
+		recipients := []*domain.ContactWithList{
+			{Contact: &domain.Contact{Email: "user1@example.com"}, ListID: "list-1"},
+			{Contact: &domain.Contact{Email: "user2@example.com"}, ListID: "list-1"},
+		}
+		setup.contactRepo.EXPECT().GetContactsForBroadcast(gomock.Any(), "workspace-123", setup.broadcast.Audience, 2, "").Return(recipients, nil)
+		setup.messageSender.EXPECT().
+			SendBatch(gomock.Any(), "workspace-123", "marketing-provider-id", "secret-key", gomock.Any(), true, "broadcast-123", recipients, gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(sendAll)
+
+		done, err := setup.orchestrator.Process(context.Background(), setup.task, time.Now().Add(30*time.Second))
+		require.NoError(t, err)
+		assert.True(t, done)
+
+		// The broadcast starts processing when the task runs, then completes
+		require.NotEmpty(t, *setup.statuses)
+		assert.Equal(t, domain.BroadcastStatusProcessing, (*setup.statuses)[0])
+		require.NotNil(t, setup.broadcast.StartedAt)
+		assert.Equal(t, setup.now, *setup.broadcast.StartedAt)
+		assert.Equal(t, 2, setup.task.State.SendBroadcast.EnqueuedCount)
+	})
+}
@@ -0,0 +1,89 @@
+package broadcast
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	domainmocks "github.com/Notifuse/notifuse/internal/domain/mocks"
+	"github.com/Notifuse/notifuse/internal/service/broadcast/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupCSVAudienceOrchestratorTest prepares a broadcast whose audience is an uploaded CSV. The contact
+// repository has no expectations, so reading the list members instead of the upload fails the test.
+func setupCSVAudienceOrchestratorTest(t *testing.T) (*BroadcastOrchestrator, *domain.Broadcast, *mocks.MockMessageSender, *domainmocks.MockBroadcastAudienceRepository) {
+	f := newOrchestratorFixture(t)
+	f.broadcast.Audience = domain.AudienceSettings{List: "list-1", CSV: true, ExcludeUnsubscribed: true}
+
+	mockAudienceRepo := domainmocks.NewMockBroadcastAudienceRepository(f.ctrl)
+	orchestrator := f.build()
+	orchestrator.audienceRepo = mockAudienceRepo
+
+	return orchestrator, f.broadcast, f.messageSender, mockAudienceRepo
+}
+
+func TestBroadcastOrchestrator_Process_CSVAudience(t *testing.T) {
+	t.Run("sends to exactly the uploaded addresses", func(t *testing.T) {
+		orchestrator, bcast, messageSender, audienceRepo := setupCSVAudienceOrchestratorTest(t)
+
+		uploaded := []*domain.BroadcastAudienceRecipient{
+			{BroadcastID: bcast.ID, Email: "alice@example.com", Data: domain.MapOfAny{"first_name": "Alice"}},
+			{BroadcastID: bcast.ID, Email: "bob@example.com"},
+			{BroadcastID: bcast.ID, Email: "carol@example.com", Data: domain.MapOfAny{"custom_string_1": "VIP"}},
+		}
+		recipients := make([]*domain.ContactWithList, 0, len(uploaded))
+		for _, recipient := range uploaded {
+			contact, err := recipient.ToContact()
+			require.NoError(t, err)
+			recipients = append(recipients, &domain.ContactWithList{Contact: contact, ListID: "list-1", ListName: "Newsletter"})
+		}
+
+		audienceRepo.EXPECT().CountRecipients(gomock.Any(), "workspace-123", "broadcast-123", bcast.Audience).Return(len(uploaded), nil)
+		audienceRepo.EXPECT().GetContactsForBroadcast(gomock.Any(), "workspace-123", "broadcast-123", bcast.Audience, gomock.Any(), "").Return(recipients, nil)
+
+		var sentTo []string
+		messageSender.EXPECT().
+			SendBatch(gomock.Any(), "workspace-123", "marketing-provider-id", "secret-key", gomock.Any(), true, "broadcast-123", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _, _, _, _ string, _ bool, _ string, batch []*domain.ContactWithList, _ map[string]*domain.Template, _ *domain.EmailProvider, _ time.Time) (domain.BatchSendResult, error) {
+				for _, recipient := range batch {
+					sentTo = append(sentTo, recipient.Contact.Email)
+				}
+				return sentResult(batch), nil
+			})
+
+		broadcastID := "broadcast-123"
+		task := &domain.Task{
+			ID:          "task-123",
+			WorkspaceID: "workspace-123",
+			Type:        "send_broadcast",
+			BroadcastID: &broadcastID,
+			State:       &domain.TaskState{SendBroadcast: &domain.SendBroadcastState{BroadcastID: broadcastID}},
+			MaxRetries:  3,
+		}
+
+		done, err := orchestrator.Process(context.Background(), task, time.Now().Add(30*time.Second))
+		require.NoError(t, err)
+		assert.True(t, done)
+
+		assert.Equal(t, []string{"alice@example.com", "bob@example.com", "carol@example.com"}, sentTo)
+		state := task.State.SendBroadcast
+		assert.Equal(t, 3, state.TotalRecipients)
+		assert.Equal(t, 3, state.EnqueuedCount)
+		assert.Equal(t, "carol@example.com", state.LastProcessedEmail)
+	})
+
+	t.Run("without an audience repository", func(t *testing.T) {
+		orchestrator, _, _, _ := setupCSVAudienceOrchestratorTest(t)
+		orchestrator.audienceRepo = nil
+
+		_, err := orchestrator.GetTotalRecipientCount(context.Background(), "workspace-123", "broadcast-123")
+		require.Error(t, err)
+
+		_, err = orchestrator.FetchBatch(context.Background(), "workspace-123", "broadcast-123", "", 10)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,100 @@
+package broadcast
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestBroadcastOrchestrator_Process_SendCutoff tests that the orchestrator stops enqueueing
+// once the broadcast send cutoff is reached, and marks the broadcast processed with the
+// remaining recipients recorded as skipped.
+func TestBroadcastOrchestrator_Process_SendCutoff(t *testing.T) {
+	cutoffAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	// setup prepares a broadcast of 30 recipients fetched in batches of 10, with the clock at now
+	setup := func(t *testing.T, now time.Time) *orchestratorFixture {
+		f := newOrchestratorFixture(t)
+		f.clock.now = now
+		f.config.FetchBatchSize = 10
+		f.broadcast.Schedule = domain.ScheduleSettings{SendCutoffAt: &cutoffAt}
+		state := f.task.State.SendBroadcast
+		state.TotalRecipients = 30
+		state.Phase = "single"
+		state.ChannelType = "email"
+		return f
+	}
+
+	t.Run("reaching the cutoff stops further sends and records skipped recipients", func(t *testing.T) {
+		f := setup(t, cutoffAt.Add(-10*time.Second))
+
+		f.contactRepo.EXPECT().
+			GetContactsForBroadcast(gomock.Any(), "workspace-123", gomock.Any(), 10, "").
+			Return(numberedRecipients(10), nil).
+			Times(1)
+
+		// The cutoff passes while the first batch is being enqueued
+		f.messageSender.EXPECT().
+			SendBatch(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _, _, _, _ string, _ bool, _ string, recipients []*domain.ContactWithList, _ map[string]*domain.Template, _ *domain.EmailProvider, _ time.Time) (domain.BatchSendResult, error) {
+				f.clock.now = cutoffAt
+				return sentResult(recipients), nil
+			}).
+			Times(1)
+
+		var updated *domain.Broadcast
+		f.broadcastRepo.EXPECT().
+			UpdateBroadcast(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, b *domain.Broadcast) error {
+				copied := *b
+				updated = &copied
+				return nil
+			}).
+			Times(1)
+
+		allDone, err := f.build().Process(context.Background(), f.task, time.Now().Add(5*time.Minute))
+
+		require.NoError(t, err)
+		assert.True(t, allDone)
+		require.NotNil(t, updated)
+		assert.Equal(t, domain.BroadcastStatusProcessed, updated.Status)
+		assert.NotNil(t, updated.CompletedAt)
+		assert.Equal(t, 10, updated.EnqueuedCount)
+		assert.Equal(t, 20, updated.SkippedCount)
+		assert.Contains(t, f.task.State.Message, "20 recipients skipped")
+	})
+
+	t.Run("cutoff already passed skips every remaining recipient", func(t *testing.T) {
+		f := setup(t, cutoffAt.Add(time.Hour))
+		f.task.State.SendBroadcast.RecipientOffset = 5
+		f.task.State.SendBroadcast.EnqueuedCount = 5
+
+		// Nothing is fetched or enqueued
+		f.contactRepo.EXPECT().GetContactsForBroadcast(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+		f.messageSender.EXPECT().SendBatch(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		var updated *domain.Broadcast
+		f.broadcastRepo.EXPECT().
+			UpdateBroadcast(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, b *domain.Broadcast) error {
+				copied := *b
+				updated = &copied
+				return nil
+			}).
+			Times(1)
+
+		allDone, err := f.build().Process(context.Background(), f.task, time.Now().Add(5*time.Minute))
+
+		require.NoError(t, err)
+		assert.True(t, allDone)
+		require.NotNil(t, updated)
+		assert.Equal(t, domain.BroadcastStatusProcessed, updated.Status)
+		assert.Equal(t, 5, updated.EnqueuedCount)
+		assert.Equal(t, 25, updated.SkippedCount)
+	})
+}
@@ -0,0 +1,83 @@
+package broadcast
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/service/broadcast/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupDryRunOrchestratorTest prepares a single-template broadcast of 3 recipients whose batch is sent
+// through the sender the orchestrator picks, returning the real and dry-run senders to set expectations on
+func setupDryRunOrchestratorTest(t *testing.T, dryRun bool) (*BroadcastOrchestrator, *domain.Task, *mocks.MockMessageSender, *mocks.MockMessageSender) {
+	f := newOrchestratorFixture(t)
+	f.broadcast.DryRun = dryRun
+	f.task.State.SendBroadcast.TotalRecipients = 3
+
+	// A dry run refused before loading templates never reaches the templates and contacts
+	recipients := []*domain.ContactWithList{
+		{Contact: &domain.Contact{Email: "user1@example.com"}, ListID: "list-1"},
+		{Contact: &domain.Contact{Email: "user2@example.com"}, ListID: "list-1"},
+		{Contact: &domain.Contact{Email: "user3@example.com"}, ListID: "list-1"},
+	}
+	f.contactRepo.EXPECT().GetContactsForBroadcast(gomock.Any(), "workspace-123", f.broadcast.Audience, 3, "").Return(recipients, nil).MaxTimes(1)
+
+	mockDryRunSender := mocks.NewMockMessageSender(f.ctrl)
+	orchestrator := f.build()
+	orchestrator.dryRunSender = mockDryRunSender
+
+	return orchestrator, f.task, f.messageSender, mockDryRunSender
+}
+
+func TestBroadcastOrchestrator_Process_DryRun(t *testing.T) {
+	t.Run("dry run never reaches the real sender", func(t *testing.T) {
+		orchestrator, task, _, dryRunSender := setupDryRunOrchestratorTest(t, true)
+
+		// The real sender has no expectations, any call to it fails the test
+		dryRunSender.EXPECT().
+			SendBatch(gomock.Any(), "workspace-123", "marketing-provider-id", "secret-key", gomock.Any(), true, "broadcast-123", gomock.Len(3), gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(sendAll)
+
+		done, err := orchestrator.Process(context.Background(), task, time.Now().Add(30*time.Second))
+		require.NoError(t, err)
+		assert.True(t, done)
+
+		state := task.State.SendBroadcast
+		assert.True(t, state.DryRun)
+		assert.Equal(t, 3, state.EnqueuedCount)
+		assert.Equal(t, int64(3), state.RecipientOffset)
+		assert.Equal(t, "user3@example.com", state.LastProcessedEmail)
+	})
+
+	t.Run("normal run counts match the dry run", func(t *testing.T) {
+		orchestrator, task, messageSender, _ := setupDryRunOrchestratorTest(t, false)
+
+		messageSender.EXPECT().
+			SendBatch(gomock.Any(), "workspace-123", "marketing-provider-id", "secret-key", gomock.Any(), true, "broadcast-123", gomock.Len(3), gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(sendAll)
+
+		done, err := orchestrator.Process(context.Background(), task, time.Now().Add(30*time.Second))
+		require.NoError(t, err)
+		assert.True(t, done)
+
+		state := task.State.SendBroadcast
+		assert.False(t, state.DryRun)
+		assert.Equal(t, 3, state.EnqueuedCount)
+		assert.Equal(t, int64(3), state.RecipientOffset)
+	})
+
+	t.Run("dry run without a dry-run sender fails", func(t *testing.T) {
+		orchestrator, task, _, _ := setupDryRunOrchestratorTest(t, true)
+		orchestrator.dryRunSender = nil
+
+		done, err := orchestrator.Process(context.Background(), task, time.Now().Add(30*time.Second))
+		require.Error(t, err)
+		assert.False(t, done)
+		assert.Contains(t, err.Error(), "dry run is not available")
+	})
+}
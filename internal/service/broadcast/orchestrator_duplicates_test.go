@@ -0,0 +1,169 @@
+package broadcast
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type duplicatesTestSetup struct {
+	*orchestratorFixture
+	orchestrator *BroadcastOrchestrator
+	audience     domain.AudienceSettings
+}
+
+// setupDuplicatesTest prepares a single-template broadcast of 4 recipients fetched in batches of 2
+func setupDuplicatesTest(t *testing.T) *duplicatesTestSetup {
+	f := newOrchestratorFixture(t)
+	f.config.FetchBatchSize = 2
+	f.task.State.SendBroadcast.TotalRecipients = 4
+
+	orchestrator := f.build()
+	orchestrator.messageHistoryRepo = f.messageHistoryRepo
+
+	return &duplicatesTestSetup{orchestratorFixture: f, orchestrator: orchestrator, audience: f.broadcast.Audience}
+}
+
+func contactsWithList(emails ...string) []*domain.ContactWithList {
+	recipients := make([]*domain.ContactWithList, 0, len(emails))
+	for _, email := range emails {
+		recipients = append(recipients, &domain.ContactWithList{Contact: &domain.Contact{Email: email}, ListID: "list-1"})
+	}
+	return recipients
+}
+
+func TestBroadcastOrchestrator_Process_SkipsDuplicateRecipients(t *testing.T) {
+	t.Run("contact reappearing in the next batch is sent once", func(t *testing.T) {
+		setup := setupDuplicatesTest(t)
+
+		// user2 comes back in the second batch, e.g. after its contact changed during the run
+		gomock.InOrder(
+			setup.contactRepo.EXPECT().GetContactsForBroadcast(gomock.Any(), "workspace-123", setup.audience, 2, "").
+				Return(contactsWithList("user1@example.com", "user2@example.com"), nil),
+			setup.contactRepo.EXPECT().GetContactsForBroadcast(gomock.Any(), "workspace-123", setup.audience, 2, "user2@example.com").
+				Return(contactsWithList("user2@example.com", "user3@example.com"), nil),
+		)
+
+		var sent [][]string
+		setup.messageSender.EXPECT().
+			SendBatch(gomock.Any(), "workspace-123", "marketing-provider-id", "secret-key", gomock.Any(), true, "broadcast-123", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(ctx context.Context, workspaceID, integrationID, secretKey, endpoint string, tracking bool, broadcastID string, batch []*domain.ContactWithList, templates map[string]*domain.Template, provider *domain.EmailProvider, timeoutAt time.Time) (domain.BatchSendResult, error) {
+				sent = append(sent, sentEmails(batch))
+				return sendAll(ctx, workspaceID, integrationID, secretKey, endpoint, tracking, broadcastID, batch, templates, provider, timeoutAt)
+			}).Times(2)
+
+		done, err := setup.orchestrator.Process(context.Background(), setup.task, time.Now().Add(30*time.Second))
+		require.NoError(t, err)
+		assert.True(t, done)
+
+		assert.Equal(t, [][]string{{"user1@example.com", "user2@example.com"}, {"user3@example.com"}}, sent)
+		state := setup.task.State.SendBroadcast
+		assert.Equal(t, 3, state.EnqueuedCount)
+		assert.Equal(t, 1, state.DuplicateCount)
+		assert.Equal(t, int64(4), state.RecipientOffset)
+		assert.Equal(t, "user3@example.com", state.LastProcessedEmail)
+	})
+
+	t.Run("email repeated within a batch is sent once", func(t *testing.T) {
+		setup := setupDuplicatesTest(t)
+		setup.task.State.SendBroadcast.TotalRecipients = 2
+
+		setup.contactRepo.EXPECT().GetContactsForBroadcast(gomock.Any(), "workspace-123", setup.audience, 2, "").
+			Return(contactsWithList("user1@example.com", "user1@example.com"), nil)
+		setup.messageSender.EXPECT().
+			SendBatch(gomock.Any(), "workspace-123", "marketing-provider-id", "secret-key", gomock.Any(), true, "broadcast-123", gomock.Len(1), gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(sendAll)
+
+		done, err := setup.orchestrator.Process(context.Background(), setup.task, time.Now().Add(30*time.Second))
+		require.NoError(t, err)
+		assert.True(t, done)
+		assert.Equal(t, 1, setup.task.State.SendBroadcast.EnqueuedCount)
+		assert.Equal(t, 1, setup.task.State.SendBroadcast.DuplicateCount)
+	})
+
+	t.Run("emails sent by an earlier execution are skipped against the message history", func(t *testing.T) {
+		setup := setupDuplicatesTest(t)
+
+		// The task resumes after user1 and user2 were sent, user2 comes back in the next batch
+		state := setup.task.State.SendBroadcast
+		state.EnqueuedCount = 2
+		state.RecipientOffset = 2
+		state.LastProcessedEmail = "user2@example.com"
+
+		setup.contactRepo.EXPECT().GetContactsForBroadcast(gomock.Any(), "workspace-123", setup.audience, 2, "user2@example.com").
+			Return(contactsWithList("user2@example.com", "user3@example.com"), nil)
+		setup.messageHistoryRepo.EXPECT().
+			GetSentEmailsForBroadcast(gomock.Any(), "workspace-123", "broadcast-123", []string{"user2@example.com", "user3@example.com"}).
+			Return([]string{"user2@example.com"}, nil)
+		setup.messageSender.EXPECT().
+			SendBatch(gomock.Any(), "workspace-123", "marketing-provider-id", "secret-key", gomock.Any(), true, "broadcast-123", contactsWithList("user3@example.com"), gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(sendAll)
+
+		done, err := setup.orchestrator.Process(context.Background(), setup.task, time.Now().Add(30*time.Second))
+		require.NoError(t, err)
+		assert.True(t, done)
+		assert.Equal(t, 3, state.EnqueuedCount)
+		assert.Equal(t, 1, state.DuplicateCount)
+	})
+
+	t.Run("contact sent before a pause is skipped when it reappears after the resume", func(t *testing.T) {
+		f := newOrchestratorFixture(t)
+		f.config.FetchBatchSize = 2
+		f.task.State.SendBroadcast.TotalRecipients = 4
+
+		// The broadcast status is switched by the test to simulate the pause and resume actions
+		status := domain.BroadcastStatusProcessing
+		audience := f.broadcast.Audience
+		f.broadcastRepo.EXPECT().GetBroadcast(gomock.Any(), "workspace-123", "broadcast-123").DoAndReturn(func(_ context.Context, _, _ string) (*domain.Broadcast, error) {
+			broadcast := *f.broadcast
+			broadcast.Status = status
+			return &broadcast, nil
+		}).AnyTimes()
+
+		// user2 comes back in the batch fetched after the resume, e.g. after its contact changed while paused
+		gomock.InOrder(
+			f.contactRepo.EXPECT().GetContactsForBroadcast(gomock.Any(), "workspace-123", audience, 2, "").
+				Return(contactsWithList("user1@example.com", "user2@example.com"), nil),
+			f.contactRepo.EXPECT().GetContactsForBroadcast(gomock.Any(), "workspace-123", audience, 2, "user2@example.com").
+				Return(contactsWithList("user2@example.com", "user3@example.com"), nil),
+		)
+		f.messageHistoryRepo.EXPECT().
+			GetSentEmailsForBroadcast(gomock.Any(), "workspace-123", "broadcast-123", []string{"user2@example.com", "user3@example.com"}).
+			Return([]string{"user2@example.com"}, nil)
+
+		var sent [][]string
+		f.messageSender.EXPECT().
+			SendBatch(gomock.Any(), "workspace-123", "marketing-provider-id", "secret-key", gomock.Any(), true, "broadcast-123", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _, _, _, _ string, _ bool, _ string, batch []*domain.ContactWithList, _ map[string]*domain.Template, _ *domain.EmailProvider, _ time.Time) (domain.BatchSendResult, error) {
+				sent = append(sent, sentEmails(batch))
+				// The broadcast is paused while its first batch is being sent
+				if batch[0].Contact.Email == "user1@example.com" {
+					status = domain.BroadcastStatusPaused
+				}
+				return sentResult(batch), nil
+			}).Times(2)
+
+		orchestrator := f.build()
+		orchestrator.messageHistoryRepo = f.messageHistoryRepo
+
+		done, err := orchestrator.Process(context.Background(), f.task, time.Now().Add(30*time.Second))
+		require.NoError(t, err)
+		assert.False(t, done)
+
+		// The resumed execution starts without the emails sent in memory by the first one
+		status = domain.BroadcastStatusProcessing
+		done, err = orchestrator.Process(context.Background(), f.task, time.Now().Add(30*time.Second))
+		require.NoError(t, err)
+		assert.True(t, done)
+
+		assert.Equal(t, [][]string{{"user1@example.com", "user2@example.com"}, {"user3@example.com"}}, sent)
+		state := f.task.State.SendBroadcast
+		assert.Equal(t, 3, state.EnqueuedCount)
+		assert.Equal(t, 1, state.DuplicateCount)
+	})
+}
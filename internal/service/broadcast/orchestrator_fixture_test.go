@@ -0,0 +1,147 @@
+package broadcast
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	domainmocks "github.com/Notifuse/notifuse/internal/domain/mocks"
+	"github.com/Notifuse/notifuse/internal/service/broadcast/mocks"
+	pkgmocks "github.com/Notifuse/notifuse/pkg/mocks"
+	"github.com/Notifuse/notifuse/pkg/notifuse_mjml"
+	"github.com/golang/mock/gomock"
+)
+
+// orchestratorFixture holds the mocks and the data of an orchestrator test: the email broadcast broadcast-123
+// of workspace-123, sending template-1 through the marketing-provider-id SES integration on a fake clock.
+// Tests adjust the data and set their own expectations, then call build. Contacts and sends are left to the test.
+type orchestratorFixture struct {
+	ctrl               *gomock.Controller
+	messageSender      *mocks.MockMessageSender
+	broadcastRepo      *domainmocks.MockBroadcastRepository
+	templateRepo       *domainmocks.MockTemplateRepository
+	contactRepo        *domainmocks.MockContactRepository
+	taskRepo           *domainmocks.MockTaskRepository
+	workspaceRepo      *domainmocks.MockWorkspaceRepository
+	messageHistoryRepo *domainmocks.MockMessageHistoryRepository
+	eventBus           *domainmocks.MockEventBus
+	logger             *pkgmocks.MockLogger
+
+	workspace *domain.Workspace
+	broadcast *domain.Broadcast
+	templates []*domain.Template
+	task      *domain.Task
+	config    *Config
+	clock     *fakeTimeProvider
+}
+
+// newOrchestratorFixture creates the mocks of an orchestrator test and its default data
+func newOrchestratorFixture(t *testing.T) *orchestratorFixture {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	workspaceID := "workspace-123"
+	broadcastID := "broadcast-123"
+
+	f := &orchestratorFixture{
+		ctrl:               ctrl,
+		messageSender:      mocks.NewMockMessageSender(ctrl),
+		broadcastRepo:      domainmocks.NewMockBroadcastRepository(ctrl),
+		templateRepo:       domainmocks.NewMockTemplateRepository(ctrl),
+		contactRepo:        domainmocks.NewMockContactRepository(ctrl),
+		taskRepo:           domainmocks.NewMockTaskRepository(ctrl),
+		workspaceRepo:      domainmocks.NewMockWorkspaceRepository(ctrl),
+		messageHistoryRepo: domainmocks.NewMockMessageHistoryRepository(ctrl),
+		eventBus:           domainmocks.NewMockEventBus(ctrl),
+		logger:             pkgmocks.NewMockLogger(ctrl),
+		workspace: &domain.Workspace{
+			ID: workspaceID,
+			Settings: domain.WorkspaceSettings{
+				SecretKey:                "secret-key",
+				EmailTrackingEnabled:     true,
+				MarketingEmailProviderID: "marketing-provider-id",
+			},
+			Integrations: []domain.Integration{
+				{ID: "marketing-provider-id", Type: domain.IntegrationTypeEmail, EmailProvider: domain.EmailProvider{Kind: domain.EmailProviderKindSES, SES: &domain.AmazonSESSettings{AccessKey: "ak", SecretKey: "sk", Region: "us-east-1"}}},
+			},
+		},
+		broadcast: &domain.Broadcast{
+			ID:           broadcastID,
+			WorkspaceID:  workspaceID,
+			Audience:     domain.AudienceSettings{List: "list-1"},
+			Status:       domain.BroadcastStatusProcessing,
+			TestSettings: domain.BroadcastTestSettings{Variations: []domain.BroadcastVariation{{TemplateID: "template-1"}}},
+		},
+		templates: []*domain.Template{testEmailTemplate("template-1")},
+		task: &domain.Task{
+			ID:          "task-123",
+			WorkspaceID: workspaceID,
+			Type:        "send_broadcast",
+			BroadcastID: &broadcastID,
+			State:       &domain.TaskState{SendBroadcast: &domain.SendBroadcastState{BroadcastID: broadcastID}},
+			MaxRetries:  3,
+		},
+		config: &Config{
+			FetchBatchSize:           50,
+			MaxProcessTime:           30 * time.Second,
+			ProgressLogInterval:      5 * time.Second,
+			StatusUpdateRetryBackoff: time.Millisecond,
+		},
+		clock: &fakeTimeProvider{now: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)},
+	}
+
+	f.logger.EXPECT().WithFields(gomock.Any()).Return(f.logger).AnyTimes()
+	f.logger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(f.logger).AnyTimes()
+	f.logger.EXPECT().Info(gomock.Any()).AnyTimes()
+	f.logger.EXPECT().Debug(gomock.Any()).AnyTimes()
+	f.logger.EXPECT().Warn(gomock.Any()).AnyTimes()
+	f.logger.EXPECT().Error(gomock.Any()).AnyTimes()
+
+	return f
+}
+
+// build returns the orchestrator of the fixture. The workspace, broadcast, templates, event bus and task
+// state writes get default expectations, which gomock only uses when the test set none matching the call.
+func (f *orchestratorFixture) build() *BroadcastOrchestrator {
+	f.eventBus.EXPECT().Publish(gomock.Any(), gomock.Any()).AnyTimes()
+	f.workspaceRepo.EXPECT().GetByID(gomock.Any(), f.workspace.ID).Return(f.workspace, nil).AnyTimes()
+	f.broadcastRepo.EXPECT().GetBroadcast(gomock.Any(), f.workspace.ID, f.broadcast.ID).Return(f.broadcast, nil).AnyTimes()
+	f.broadcastRepo.EXPECT().UpdateBroadcast(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	for _, tpl := range f.templates {
+		f.templateRepo.EXPECT().GetTemplateByID(gomock.Any(), f.workspace.ID, tpl.ID, int64(0)).Return(tpl, nil).AnyTimes()
+	}
+	f.taskRepo.EXPECT().SaveState(gomock.Any(), f.workspace.ID, f.task.ID, gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+
+	return NewBroadcastOrchestrator(f.messageSender, f.broadcastRepo, f.templateRepo, f.contactRepo, f.taskRepo, f.workspaceRepo, nil, f.logger, f.config, f.clock, "https://api.example.com", f.eventBus).(*BroadcastOrchestrator)
+}
+
+// testEmailTemplate returns a minimal email template that passes the orchestrator validation
+func testEmailTemplate(id string) *domain.Template {
+	return &domain.Template{ID: id, Email: &domain.EmailTemplate{Subject: "S", SenderID: "s", VisualEditorTree: &notifuse_mjml.MJMLBlock{BaseBlock: notifuse_mjml.NewBaseBlock("root", notifuse_mjml.MJMLComponentMjml)}}}
+}
+
+// serveRecipients makes the contact repository return the recipients after the cursor in email order,
+// as the keyset pagination does
+func (f *orchestratorFixture) serveRecipients(recipients []*domain.ContactWithList) {
+	f.contactRepo.EXPECT().GetContactsForBroadcast(gomock.Any(), f.workspace.ID, gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, _ domain.AudienceSettings, limit int, afterEmail string) ([]*domain.ContactWithList, error) {
+			start := sort.Search(len(recipients), func(i int) bool { return recipients[i].Contact.Email > afterEmail })
+			end := start + limit
+			if end > len(recipients) {
+				end = len(recipients)
+			}
+			return recipients[start:end], nil
+		}).AnyTimes()
+}
+
+// numberedRecipients returns n recipients user000@example.com, user001@example.com... in email order
+func numberedRecipients(n int) []*domain.ContactWithList {
+	recipients := make([]*domain.ContactWithList, n)
+	for i := range recipients {
+		recipients[i] = &domain.ContactWithList{Contact: &domain.Contact{Email: fmt.Sprintf("user%03d@example.com", i)}, ListID: "list-1"}
+	}
+	return recipients
+}
@@ -122,8 +122,7 @@ func TestEvaluateWinner_Success(t *testing.T) {
 	bcRepo.EXPECT().GetBroadcast(ctx, workspaceID, broadcastID).Return(b, nil)
 
 	// Stats favor tplB
-	msgRepo.EXPECT().GetBroadcastVariationStats(ctx, workspaceID, broadcastID, "tplA").Return(&domain.MessageHistoryStatusSum{TotalDelivered: 100, TotalOpened: 30}, nil)
-	msgRepo.EXPECT().GetBroadcastVariationStats(ctx, workspaceID, broadcastID, "tplB").Return(&domain.MessageHistoryStatusSum{TotalDelivered: 100, TotalOpened: 60}, nil)
+	msgRepo.EXPECT().GetBroadcastVariationStatsAll(ctx, workspaceID, broadcastID, []string{"tplA", "tplB"}).Return(map[string]*domain.MessageHistoryStatusSum{"tplA": {TotalDelivered: 100, TotalOpened: 30}, "tplB": {TotalDelivered: 100, TotalOpened: 60}}, nil)
 
 	// Transactional update by evaluator
 	bcRepo.EXPECT().WithTransaction(ctx, workspaceID, gomock.Any()).DoAndReturn(
@@ -290,3 +289,64 @@ func TestHandleTestPhaseCompletion_AutoWinnerLogsEvaluationTime(t *testing.T) {
 	// The key assertion is that the test passed without errors, meaning the auto-winner
 	// evaluation time logging path (lines 1069-1075) was executed successfully
 }
+
+func TestPublishPhaseChange_ABTestCompletion(t *testing.T) {
+	ctrl, msgRepo, bcRepo, _, evaluator := setupEvaluator(t)
+	defer ctrl.Finish()
+	eventBus := domainmocks.NewMockEventBus(ctrl)
+	o := minimalOrchestrator(ctrl, bcRepo, nil, nil, evaluator)
+	o.eventBus = eventBus
+
+	winner := "tplB"
+	b := newTestBroadcast("w1", "b1")
+	b.Status = domain.BroadcastStatusProcessed
+	b.WinningTemplate = &winner
+
+	msgRepo.EXPECT().GetBroadcastVariationStatsAll(gomock.Any(), "w1", "b1", []string{"tplA", "tplB"}).Return(map[string]*domain.MessageHistoryStatusSum{"tplA": {TotalSent: 100, TotalDelivered: 98, TotalOpened: 20, TotalClicked: 4}, "tplB": {TotalSent: 100, TotalDelivered: 97, TotalOpened: 35, TotalClicked: 9}}, nil)
+
+	var published domain.EventPayload
+	eventBus.EXPECT().Publish(gomock.Any(), gomock.Any()).Do(func(_ context.Context, event domain.EventPayload) {
+		published = event
+	})
+
+	o.publishPhaseChange(&domain.SendBroadcastState{PublishedStatus: domain.BroadcastStatusWinnerSelected}, b)
+
+	assert.Equal(t, "sent", published.Data["phase"])
+	outcome, ok := published.Data["ab_test"].(*domain.BroadcastTestOutcome)
+	require.True(t, ok)
+	assert.Equal(t, "tplB", outcome.WinningTemplate)
+	assert.Equal(t, domain.TestWinnerMetricOpenRate, outcome.WinnerMetric)
+	assert.Equal(t, []domain.BroadcastVariationOutcome{
+		{VariationName: "A", TemplateID: "tplA", Sent: 100, Delivered: 98, Opens: 20, Clicks: 4},
+		{VariationName: "B", TemplateID: "tplB", Sent: 100, Delivered: 97, Opens: 35, Clicks: 9},
+	}, outcome.Variations)
+}
+
+func TestPublishPhaseChange_ABTestManualWinnerAndOtherPhases(t *testing.T) {
+	ctrl, msgRepo, bcRepo, _, evaluator := setupEvaluator(t)
+	defer ctrl.Finish()
+	eventBus := domainmocks.NewMockEventBus(ctrl)
+	o := minimalOrchestrator(ctrl, bcRepo, nil, nil, evaluator)
+	o.eventBus = eventBus
+
+	b := newTestBroadcast("w1", "b1")
+	b.TestSettings.AutoSendWinner = false
+	b.Status = domain.BroadcastStatusWinnerSelected
+
+	// Only the completion carries the A/B test outcome
+	eventBus.EXPECT().Publish(gomock.Any(), gomock.Any()).Do(func(_ context.Context, event domain.EventPayload) {
+		assert.NotContains(t, event.Data, "ab_test")
+	})
+	state := &domain.SendBroadcastState{PublishedStatus: domain.BroadcastStatusTestCompleted}
+	o.publishPhaseChange(state, b)
+
+	// Stats that cannot be read leave the variations out
+	msgRepo.EXPECT().GetBroadcastVariationStatsAll(gomock.Any(), "w1", "b1", []string{"tplA", "tplB"}).Return(nil, errors.New("db down"))
+	eventBus.EXPECT().Publish(gomock.Any(), gomock.Any()).Do(func(_ context.Context, event domain.EventPayload) {
+		outcome := event.Data["ab_test"].(*domain.BroadcastTestOutcome)
+		assert.Equal(t, domain.TestWinnerMetricManual, outcome.WinnerMetric)
+		assert.Empty(t, outcome.Variations)
+	})
+	b.Status = domain.BroadcastStatusProcessed
+	o.publishPhaseChange(state, b)
+}
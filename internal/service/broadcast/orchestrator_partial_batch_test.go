@@ -0,0 +1,147 @@
+package broadcast
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type partialBatchTestSetup struct {
+	*orchestratorFixture
+	orchestrator *BroadcastOrchestrator
+	audience     domain.AudienceSettings
+	recipients   []*domain.ContactWithList
+}
+
+// setupPartialBatchTest builds an orchestrator for a broadcast of 5 recipients, user1 to user5,
+// whose contact fetches and sends are left to the test
+func setupPartialBatchTest(t *testing.T) *partialBatchTestSetup {
+	f := newOrchestratorFixture(t)
+
+	recipients := make([]*domain.ContactWithList, 5)
+	for i := range recipients {
+		recipients[i] = &domain.ContactWithList{Contact: &domain.Contact{Email: fmt.Sprintf("user%d@example.com", i+1)}, ListID: "list-1"}
+	}
+	f.task.State.SendBroadcast.TotalRecipients = len(recipients)
+
+	return &partialBatchTestSetup{orchestratorFixture: f, orchestrator: f.build(), audience: f.broadcast.Audience, recipients: recipients}
+}
+
+func (s *partialBatchTestSetup) expectSend(batch []*domain.ContactWithList) *gomock.Call {
+	return s.messageSender.EXPECT().
+		SendBatch(gomock.Any(), "workspace-123", "marketing-provider-id", "secret-key", gomock.Any(), true, "broadcast-123", batch, gomock.Any(), gomock.Any(), gomock.Any())
+}
+
+func TestBroadcastOrchestrator_Process_PartialBatch(t *testing.T) {
+	t.Run("failure after the third of five recipients resumes from the fourth", func(t *testing.T) {
+		setup := setupPartialBatchTest(t)
+
+		gomock.InOrder(
+			setup.contactRepo.EXPECT().GetContactsForBroadcast(gomock.Any(), "workspace-123", setup.audience, 5, "").Return(setup.recipients, nil),
+			setup.expectSend(setup.recipients).
+				Return(sentResult(setup.recipients[:3]), NewBroadcastError(ErrCodeSendFailed, "failed to enqueue batch", false, errors.New("database error"))),
+			// The cursor stops at user3, the unsent recipients are fetched and sent again
+			setup.contactRepo.EXPECT().GetContactsForBroadcast(gomock.Any(), "workspace-123", setup.audience, 2, "user3@example.com").Return(setup.recipients[3:], nil),
+			setup.expectSend(setup.recipients[3:]).DoAndReturn(sendAll),
+		)
+
+		done, err := setup.orchestrator.Process(context.Background(), setup.task, time.Now().Add(30*time.Second))
+		require.NoError(t, err)
+		assert.True(t, done)
+
+		state := setup.task.State.SendBroadcast
+		assert.Equal(t, 5, state.EnqueuedCount)
+		assert.Equal(t, 0, state.FailedCount)
+		assert.Equal(t, int64(5), state.RecipientOffset)
+		assert.Equal(t, "user5@example.com", state.LastProcessedEmail)
+		assert.Empty(t, state.ResumeSentEmails)
+	})
+
+	t.Run("recipients processed after the first unsent one are not sent again", func(t *testing.T) {
+		setup := setupPartialBatchTest(t)
+
+		gomock.InOrder(
+			setup.contactRepo.EXPECT().GetContactsForBroadcast(gomock.Any(), "workspace-123", setup.audience, 5, "").Return(setup.recipients, nil),
+			// user3 was throttled but user4 went through
+			setup.expectSend(setup.recipients).
+				Return(domain.BatchSendResult{
+					SentEmails:   []string{"user1@example.com", "user2@example.com", "user4@example.com"},
+					FailedEmails: []string{"user5@example.com"},
+				}, nil),
+			setup.contactRepo.EXPECT().GetContactsForBroadcast(gomock.Any(), "workspace-123", setup.audience, 3, "user2@example.com").Return(setup.recipients[2:], nil),
+			setup.expectSend(setup.recipients[2:3]).DoAndReturn(sendAll),
+		)
+
+		done, err := setup.orchestrator.Process(context.Background(), setup.task, time.Now().Add(30*time.Second))
+		require.NoError(t, err)
+		assert.True(t, done)
+
+		state := setup.task.State.SendBroadcast
+		assert.Equal(t, 4, state.EnqueuedCount)
+		assert.Equal(t, 1, state.FailedCount)
+		assert.Equal(t, int64(5), state.RecipientOffset)
+		assert.Equal(t, "user5@example.com", state.LastProcessedEmail)
+		assert.Empty(t, state.ResumeSentEmails)
+	})
+
+	t.Run("a new run skips the recipients saved in the checkpoint", func(t *testing.T) {
+		setup := setupPartialBatchTest(t)
+		state := setup.task.State.SendBroadcast
+		state.RecipientOffset = 2
+		state.LastProcessedEmail = "user2@example.com"
+		state.ResumeSentEmails = []string{"user4@example.com"}
+		state.EnqueuedCount = 3
+
+		setup.contactRepo.EXPECT().GetContactsForBroadcast(gomock.Any(), "workspace-123", setup.audience, 3, "user2@example.com").Return(setup.recipients[2:], nil)
+		setup.expectSend([]*domain.ContactWithList{setup.recipients[2], setup.recipients[4]}).DoAndReturn(sendAll)
+
+		done, err := setup.orchestrator.Process(context.Background(), setup.task, time.Now().Add(30*time.Second))
+		require.NoError(t, err)
+		assert.True(t, done)
+
+		assert.Equal(t, 5, state.EnqueuedCount)
+		assert.Equal(t, int64(5), state.RecipientOffset)
+		assert.Equal(t, "user5@example.com", state.LastProcessedEmail)
+		assert.Empty(t, state.ResumeSentEmails)
+	})
+}
+
+func TestBatchProgress(t *testing.T) {
+	recipients := batchRetryRecipients(5)
+
+	t.Run("every recipient processed", func(t *testing.T) {
+		done, resume := batchProgress(recipients, recipients, sentResult(recipients))
+		assert.Equal(t, 5, done)
+		assert.Empty(t, resume)
+	})
+
+	t.Run("stops at the first unprocessed recipient", func(t *testing.T) {
+		result := sentResult([]*domain.ContactWithList{recipients[0], recipients[3]})
+		result.FailedEmails = []string{recipients[1].Contact.Email}
+
+		done, resume := batchProgress(recipients, recipients, result)
+		assert.Equal(t, 2, done)
+		assert.Equal(t, []string{"d@example.com"}, resume)
+	})
+
+	t.Run("recipients left out as already sent are done", func(t *testing.T) {
+		toSend := []*domain.ContactWithList{recipients[2], recipients[3], recipients[4]}
+
+		done, resume := batchProgress(recipients, toSend, sentResult(toSend[:1]))
+		assert.Equal(t, 3, done)
+		assert.Empty(t, resume)
+	})
+
+	t.Run("nothing processed", func(t *testing.T) {
+		done, resume := batchProgress(recipients, recipients, domain.BatchSendResult{})
+		assert.Equal(t, 0, done)
+		assert.Empty(t, resume)
+	})
+}
@@ -32,6 +32,7 @@ func TestBroadcastOrchestrator_Process_PausedBroadcast(t *testing.T) {
 	mockLogger := pkgmocks.NewMockLogger(ctrl)
 	mockTimeProvider := mocks.NewMockTimeProvider(ctrl)
 	mockEventBus := domainmocks.NewMockEventBus(ctrl)
+	mockEventBus.EXPECT().Publish(gomock.Any(), gomock.Any()).AnyTimes()
 
 	// Setup logger expectations
 	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
@@ -187,6 +188,7 @@ func TestBroadcastOrchestrator_Process_PausedDuringProcessing(t *testing.T) {
 	mockLogger := pkgmocks.NewMockLogger(ctrl)
 	mockTimeProvider := mocks.NewMockTimeProvider(ctrl)
 	mockEventBus := domainmocks.NewMockEventBus(ctrl)
+	mockEventBus.EXPECT().Publish(gomock.Any(), gomock.Any()).AnyTimes()
 
 	// Setup logger expectations
 	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
@@ -355,7 +357,7 @@ func TestBroadcastOrchestrator_Process_PausedDuringProcessing(t *testing.T) {
 	// Mock message sender - may or may not be called before pause is detected
 	mockMessageSender.EXPECT().
 		SendBatch(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-		Return(len(mockContacts), 0, nil).
+		Return(sentResult(mockContacts), nil).
 		MaxTimes(1)
 
 	// Execute
@@ -408,6 +410,7 @@ func TestBroadcastOrchestrator_Process_PausedVsCancelled(t *testing.T) {
 			mockLogger := pkgmocks.NewMockLogger(ctrl)
 			mockTimeProvider := mocks.NewMockTimeProvider(ctrl)
 			mockEventBus := domainmocks.NewMockEventBus(ctrl)
+			mockEventBus.EXPECT().Publish(gomock.Any(), gomock.Any()).AnyTimes()
 
 			// Setup logger expectations
 			mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
@@ -0,0 +1,96 @@
+package broadcast
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestBroadcastOrchestrator_Process_PhaseEvents runs a full A/B broadcast over several task
+// executions, saving and restoring the task state between them, and checks that each phase
+// transition is published once and in order.
+func TestBroadcastOrchestrator_Process_PhaseEvents(t *testing.T) {
+	f := newOrchestratorFixture(t)
+	workspaceID := "workspace-123"
+	broadcastID := "broadcast-123"
+
+	// The stored broadcast: reads return a copy, updates replace it
+	record := f.broadcast
+	record.Name = "Spring sale"
+	record.TestSettings = domain.BroadcastTestSettings{
+		Enabled:          true,
+		SamplePercentage: 50,
+		Variations:       []domain.BroadcastVariation{{TemplateID: "template-A"}, {TemplateID: "template-B"}},
+	}
+	f.broadcastRepo.EXPECT().GetBroadcast(gomock.Any(), workspaceID, broadcastID).DoAndReturn(func(_ context.Context, _, _ string) (*domain.Broadcast, error) {
+		copied := *record
+		return &copied, nil
+	}).AnyTimes()
+	f.broadcastRepo.EXPECT().UpdateBroadcast(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, b *domain.Broadcast) error {
+		*record = *b
+		return nil
+	}).AnyTimes()
+	f.templates = []*domain.Template{testEmailTemplate("template-A"), testEmailTemplate("template-B")}
+
+	// Two recipients: the first one receives the test, the second one the winner
+	first := &domain.ContactWithList{Contact: &domain.Contact{Email: "first@example.com"}, ListID: "list-1"}
+	second := &domain.ContactWithList{Contact: &domain.Contact{Email: "second@example.com"}, ListID: "list-1"}
+	f.contactRepo.EXPECT().GetContactsForBroadcast(gomock.Any(), workspaceID, record.Audience, 1, "").Return([]*domain.ContactWithList{first}, nil)
+	f.contactRepo.EXPECT().GetContactsForBroadcast(gomock.Any(), workspaceID, record.Audience, 1, "first@example.com").Return([]*domain.ContactWithList{second}, nil)
+	f.messageSender.EXPECT().SendBatch(gomock.Any(), workspaceID, "marketing-provider-id", "secret-key", gomock.Any(), true, broadcastID, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(sendAll).Times(2)
+
+	var phases []string
+	f.eventBus.EXPECT().Publish(gomock.Any(), gomock.Any()).Do(func(_ context.Context, event domain.EventPayload) {
+		if event.Type == domain.EventBroadcastProgress {
+			return
+		}
+		require.Equal(t, domain.EventBroadcastPhaseChanged, event.Type)
+		assert.Equal(t, broadcastID, event.EntityID)
+		assert.Equal(t, "Spring sale", event.Data["broadcast_name"])
+		phases = append(phases, event.Data["old_phase"].(string)+" -> "+event.Data["phase"].(string))
+	}).AnyTimes()
+
+	f.task.State.SendBroadcast.TotalRecipients = 2
+	orchestrator := f.build()
+	task := f.task
+
+	// run executes the task and restores its state from JSON, as a saved and resumed task would
+	run := func() bool {
+		done, err := orchestrator.Process(context.Background(), task, time.Now().Add(30*time.Second))
+		require.NoError(t, err)
+
+		data, err := json.Marshal(task.State)
+		require.NoError(t, err)
+		task.State = &domain.TaskState{}
+		require.NoError(t, json.Unmarshal(data, task.State))
+		return done
+	}
+
+	// Test phase: the task pauses once the test recipients are enqueued
+	assert.False(t, run())
+	assert.Equal(t, domain.BroadcastStatusTestCompleted, record.Status)
+
+	// Resuming before a winner is selected publishes nothing new
+	assert.False(t, run())
+
+	// Manual winner selection, then the winner phase completes the broadcast
+	winner := "template-B"
+	record.WinningTemplate = &winner
+	record.Status = domain.BroadcastStatusWinnerSelected
+	assert.True(t, run())
+	assert.Equal(t, domain.BroadcastStatusProcessed, record.Status)
+
+	assert.Equal(t, []string{
+		"scheduled -> sending",
+		"sending -> testing",
+		"testing -> test_completed",
+		"test_completed -> winner_selected",
+		"winner_selected -> sent",
+	}, phases)
+}
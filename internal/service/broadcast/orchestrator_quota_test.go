@@ -0,0 +1,167 @@
+package broadcast
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	domainmocks "github.com/Notifuse/notifuse/internal/domain/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type quotaTestSetup struct {
+	*orchestratorFixture
+	orchestrator *BroadcastOrchestrator
+	sends        []throttledSend
+}
+
+// sentSince returns the number of messages sent at or after since, as the message history counts them
+func (s *quotaTestSetup) sentSince(since time.Time) int {
+	total := 0
+	for _, send := range s.sends {
+		if !send.at.Before(since) {
+			total += send.count
+		}
+	}
+	return total
+}
+
+// setupQuotaTest prepares a single-template broadcast of totalRecipients recipients in a workspace with
+// the given daily send quota, every SendBatch call is recorded and counted by the message history
+func setupQuotaTest(t *testing.T, totalRecipients, dailySendQuota int) *quotaTestSetup {
+	f := newOrchestratorFixture(t)
+	f.workspace.Settings.DailySendQuota = dailySendQuota
+	f.clock.now = time.Date(2026, 10, 16, 15, 30, 0, 0, time.UTC)
+	f.config.FetchBatchSize = 5
+	f.config.MaxProcessTime = time.Minute
+	f.task.State.SendBroadcast.TotalRecipients = totalRecipients
+	f.task.MaxRetries = 1 // Every run is the last retry, a deferred broadcast must not be marked as failed
+	setup := &quotaTestSetup{orchestratorFixture: f}
+
+	f.serveRecipients(numberedRecipients(totalRecipients))
+	f.messageSender.EXPECT().
+		SendBatch(gomock.Any(), "workspace-123", "marketing-provider-id", "secret-key", gomock.Any(), true, "broadcast-123", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _, _, _ string, _ bool, _ string, batch []*domain.ContactWithList, _ map[string]*domain.Template, _ *domain.EmailProvider, _ time.Time) (domain.BatchSendResult, error) {
+			setup.sends = append(setup.sends, throttledSend{at: f.clock.Now(), count: len(batch)})
+			return sentResult(batch), nil
+		}).AnyTimes()
+	f.messageHistoryRepo.EXPECT().CountSentAndComplaintsSince(gomock.Any(), "workspace-123", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, since time.Time) (int, int, error) {
+			return setup.sentSince(since), 0, nil
+		}).AnyTimes()
+
+	setup.orchestrator = f.build()
+	setup.orchestrator.messageHistoryRepo = f.messageHistoryRepo
+
+	return setup
+}
+
+func TestBroadcastOrchestrator_Process_DailySendQuota(t *testing.T) {
+	t.Run("batch is clamped to the quota and the rest is deferred to the next day", func(t *testing.T) {
+		setup := setupQuotaTest(t, 5, 2)
+
+		done, err := setup.orchestrator.Process(context.Background(), setup.task, time.Now().Add(30*time.Second))
+		require.NoError(t, err)
+		assert.False(t, done)
+
+		require.Len(t, setup.sends, 1)
+		assert.Equal(t, 2, setup.sends[0].count)
+		assert.Equal(t, int64(2), setup.task.State.SendBroadcast.RecipientOffset)
+		assert.Equal(t, "user001@example.com", setup.task.State.SendBroadcast.LastProcessedEmail)
+
+		assert.Equal(t, domain.BroadcastStatusQuotaExceeded, setup.broadcast.Status)
+		require.NotNil(t, setup.task.NextRunAfter)
+		assert.Equal(t, time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC), *setup.task.NextRunAfter)
+		assert.Contains(t, setup.task.State.Message, "Daily send quota reached")
+	})
+
+	t.Run("deferred broadcast resumes the next UTC day", func(t *testing.T) {
+		setup := setupQuotaTest(t, 5, 2)
+
+		done, err := setup.orchestrator.Process(context.Background(), setup.task, time.Now().Add(30*time.Second))
+		require.NoError(t, err)
+		require.False(t, done)
+
+		// Running again the same day sends nothing more
+		done, err = setup.orchestrator.Process(context.Background(), setup.task, time.Now().Add(30*time.Second))
+		require.NoError(t, err)
+		assert.False(t, done)
+		assert.Len(t, setup.sends, 1)
+
+		setup.clock.now = *setup.task.NextRunAfter
+		done, err = setup.orchestrator.Process(context.Background(), setup.task, time.Now().Add(30*time.Second))
+		require.NoError(t, err)
+		assert.False(t, done)
+		require.Len(t, setup.sends, 2)
+		assert.Equal(t, 2, setup.sends[1].count)
+		assert.Equal(t, domain.BroadcastStatusQuotaExceeded, setup.broadcast.Status)
+
+		setup.clock.now = *setup.task.NextRunAfter
+		done, err = setup.orchestrator.Process(context.Background(), setup.task, time.Now().Add(30*time.Second))
+		require.NoError(t, err)
+		assert.True(t, done)
+		require.Len(t, setup.sends, 3)
+		assert.Equal(t, 1, setup.sends[2].count)
+		assert.Equal(t, domain.BroadcastStatusProcessed, setup.broadcast.Status)
+		assert.Equal(t, int64(5), setup.task.State.SendBroadcast.RecipientOffset)
+	})
+
+	t.Run("no quota sends everything", func(t *testing.T) {
+		setup := setupQuotaTest(t, 5, 0)
+
+		done, err := setup.orchestrator.Process(context.Background(), setup.task, time.Now().Add(30*time.Second))
+		require.NoError(t, err)
+		assert.True(t, done)
+		require.Len(t, setup.sends, 1)
+		assert.Equal(t, 5, setup.sends[0].count)
+		assert.Nil(t, setup.task.NextRunAfter)
+	})
+}
+
+func TestBroadcastOrchestrator_dailySendAllowance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockMessageHistoryRepo := domainmocks.NewMockMessageHistoryRepository(ctrl)
+	clock := &fakeTimeProvider{now: time.Date(2026, 10, 16, 15, 30, 0, 0, time.FixedZone("UTC+2", 2*60*60))}
+	o := &BroadcastOrchestrator{timeProvider: clock, messageHistoryRepo: mockMessageHistoryRepo}
+	workspace := &domain.Workspace{ID: "workspace-123", Settings: domain.WorkspaceSettings{DailySendQuota: 2}}
+	dayStart := time.Date(2026, 10, 16, 0, 0, 0, 0, time.UTC)
+
+	t.Run("remaining quota", func(t *testing.T) {
+		mockMessageHistoryRepo.EXPECT().CountSentAndComplaintsSince(gomock.Any(), "workspace-123", dayStart).Return(1, 0, nil)
+
+		allowance, err := o.dailySendAllowance(context.Background(), workspace)
+		require.NoError(t, err)
+		assert.Equal(t, 1, allowance)
+	})
+
+	t.Run("quota used up", func(t *testing.T) {
+		mockMessageHistoryRepo.EXPECT().CountSentAndComplaintsSince(gomock.Any(), "workspace-123", dayStart).Return(2, 0, nil)
+
+		_, err := o.dailySendAllowance(context.Background(), workspace)
+		require.Error(t, err)
+		var broadcastErr *BroadcastError
+		require.True(t, errors.As(err, &broadcastErr))
+		assert.Equal(t, ErrCodeQuotaExceeded, broadcastErr.Code)
+		assert.True(t, broadcastErr.Retryable)
+	})
+
+	t.Run("count failure", func(t *testing.T) {
+		mockMessageHistoryRepo.EXPECT().CountSentAndComplaintsSince(gomock.Any(), "workspace-123", dayStart).Return(0, 0, errors.New("db down"))
+
+		_, err := o.dailySendAllowance(context.Background(), workspace)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to count messages sent today")
+	})
+
+	t.Run("no quota", func(t *testing.T) {
+		allowance, err := o.dailySendAllowance(context.Background(), &domain.Workspace{ID: "workspace-123"})
+		require.NoError(t, err)
+		assert.Equal(t, -1, allowance)
+	})
+}
@@ -0,0 +1,184 @@
+package broadcast
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type resumeTestSetup struct {
+	*orchestratorFixture
+	orchestrator *BroadcastOrchestrator
+	recipients   []*domain.ContactWithList
+}
+
+// setupResumeTest prepares a single-template broadcast of 4 recipients resumed after user1@example.com,
+// whose next batch (user2 to user4) is returned by the contact repository
+func setupResumeTest(t *testing.T) *resumeTestSetup {
+	f := newOrchestratorFixture(t)
+	state := f.task.State.SendBroadcast
+	state.TotalRecipients = 4
+	state.EnqueuedCount = 1
+	state.RecipientOffset = 1
+	state.LastProcessedEmail = "user1@example.com"
+
+	recipients := []*domain.ContactWithList{
+		{Contact: &domain.Contact{Email: "user2@example.com"}, ListID: "list-1"},
+		{Contact: &domain.Contact{Email: "user3@example.com"}, ListID: "list-1"},
+		{Contact: &domain.Contact{Email: "user4@example.com"}, ListID: "list-1"},
+	}
+	f.contactRepo.EXPECT().GetContactsForBroadcast(gomock.Any(), "workspace-123", f.broadcast.Audience, 3, "user1@example.com").Return(recipients, nil)
+
+	orchestrator := f.build()
+	orchestrator.messageHistoryRepo = f.messageHistoryRepo
+
+	return &resumeTestSetup{orchestratorFixture: f, orchestrator: orchestrator, recipients: recipients}
+}
+
+func TestBroadcastOrchestrator_Process_SkipSentOnResume(t *testing.T) {
+	t.Run("skips recipients already sent", func(t *testing.T) {
+		setup := setupResumeTest(t)
+
+		// user2 and user3 were sent before the crash but the checkpoint only covered user1
+		setup.messageHistoryRepo.EXPECT().
+			GetSentEmailsForBroadcast(gomock.Any(), "workspace-123", "broadcast-123", []string{"user2@example.com", "user3@example.com", "user4@example.com"}).
+			Return([]string{"user2@example.com", "user3@example.com"}, nil)
+		setup.messageSender.EXPECT().
+			SendBatch(gomock.Any(), "workspace-123", "marketing-provider-id", "secret-key", gomock.Any(), true, "broadcast-123", setup.recipients[2:], gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(sendAll)
+
+		done, err := setup.orchestrator.Process(context.Background(), setup.task, time.Now().Add(30*time.Second))
+		require.NoError(t, err)
+		assert.True(t, done)
+
+		state := setup.task.State.SendBroadcast
+		assert.Equal(t, int64(4), state.RecipientOffset)
+		assert.Equal(t, "user4@example.com", state.LastProcessedEmail)
+		assert.Equal(t, 2, state.EnqueuedCount)
+		assert.Equal(t, 2, state.DuplicateCount)
+	})
+
+	t.Run("whole batch already sent", func(t *testing.T) {
+		setup := setupResumeTest(t)
+
+		setup.messageHistoryRepo.EXPECT().
+			GetSentEmailsForBroadcast(gomock.Any(), "workspace-123", "broadcast-123", gomock.Any()).
+			Return([]string{"user2@example.com", "user3@example.com", "user4@example.com"}, nil)
+
+		done, err := setup.orchestrator.Process(context.Background(), setup.task, time.Now().Add(30*time.Second))
+		require.NoError(t, err)
+		assert.True(t, done)
+
+		state := setup.task.State.SendBroadcast
+		assert.Equal(t, int64(4), state.RecipientOffset)
+		assert.Equal(t, "user4@example.com", state.LastProcessedEmail)
+		assert.Equal(t, 1, state.EnqueuedCount)
+	})
+
+	t.Run("lookup failure stops the batch", func(t *testing.T) {
+		setup := setupResumeTest(t)
+
+		setup.messageHistoryRepo.EXPECT().
+			GetSentEmailsForBroadcast(gomock.Any(), "workspace-123", "broadcast-123", gomock.Any()).
+			Return(nil, errors.New("database is down"))
+
+		done, err := setup.orchestrator.Process(context.Background(), setup.task, time.Now().Add(30*time.Second))
+		require.Error(t, err)
+		assert.False(t, done)
+		assert.Contains(t, err.Error(), "failed to check already sent recipients")
+		assert.Equal(t, "user1@example.com", setup.task.State.SendBroadcast.LastProcessedEmail)
+	})
+
+	t.Run("without message history the whole batch is sent", func(t *testing.T) {
+		setup := setupResumeTest(t)
+		setup.orchestrator.messageHistoryRepo = nil
+
+		setup.messageSender.EXPECT().
+			SendBatch(gomock.Any(), "workspace-123", "marketing-provider-id", "secret-key", gomock.Any(), true, "broadcast-123", setup.recipients, gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(sendAll)
+
+		done, err := setup.orchestrator.Process(context.Background(), setup.task, time.Now().Add(30*time.Second))
+		require.NoError(t, err)
+		assert.True(t, done)
+		assert.Equal(t, int64(4), setup.task.State.SendBroadcast.RecipientOffset)
+	})
+}
+
+func TestBroadcastOrchestrator_PauseResumeContinuity(t *testing.T) {
+	f := newOrchestratorFixture(t)
+	workspaceID := "workspace-123"
+	broadcastID := "broadcast-123"
+
+	// The broadcast status is switched by the test to simulate the pause and resume actions
+	status := domain.BroadcastStatusProcessing
+	audience := f.broadcast.Audience
+	f.broadcastRepo.EXPECT().GetBroadcast(gomock.Any(), workspaceID, broadcastID).DoAndReturn(func(_ context.Context, _, _ string) (*domain.Broadcast, error) {
+		return &domain.Broadcast{
+			ID:           broadcastID,
+			WorkspaceID:  workspaceID,
+			Audience:     audience,
+			Status:       status,
+			TestSettings: domain.BroadcastTestSettings{Variations: []domain.BroadcastVariation{{TemplateID: "template-1"}}},
+		}, nil
+	}).AnyTimes()
+
+	f.contactRepo.EXPECT().GetContactsForBroadcast(gomock.Any(), workspaceID, audience, 2, "").Return([]*domain.ContactWithList{
+		{Contact: &domain.Contact{Email: "user1@example.com"}, ListID: "list-1"},
+		{Contact: &domain.Contact{Email: "user2@example.com"}, ListID: "list-1"},
+	}, nil)
+	f.contactRepo.EXPECT().GetContactsForBroadcast(gomock.Any(), workspaceID, audience, 2, "user2@example.com").Return([]*domain.ContactWithList{
+		{Contact: &domain.Contact{Email: "user3@example.com"}, ListID: "list-1"},
+		{Contact: &domain.Contact{Email: "user4@example.com"}, ListID: "list-1"},
+	}, nil)
+
+	var sentTo []string
+	f.messageSender.EXPECT().
+		SendBatch(gomock.Any(), workspaceID, "marketing-provider-id", "secret-key", gomock.Any(), true, broadcastID, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _, _, _ string, _ bool, _ string, batch []*domain.ContactWithList, _ map[string]*domain.Template, _ *domain.EmailProvider, _ time.Time) (domain.BatchSendResult, error) {
+			for _, recipient := range batch {
+				sentTo = append(sentTo, recipient.Contact.Email)
+			}
+			// The broadcast is paused while its first batch is being sent
+			if batch[0].Contact.Email == "user1@example.com" {
+				status = domain.BroadcastStatusPaused
+			}
+			return sentResult(batch), nil
+		}).Times(2)
+
+	f.config.FetchBatchSize = 2
+	f.task.State.SendBroadcast.TotalRecipients = 4
+	f.task.State.SendBroadcast.Phase = "single"
+	orchestrator := f.build()
+	task := f.task
+
+	// Paused after the first batch: the task stops with its progress saved
+	done, err := orchestrator.Process(context.Background(), task, time.Now().Add(30*time.Second))
+	require.NoError(t, err)
+	assert.False(t, done)
+	state := task.State.SendBroadcast
+	assert.Equal(t, int64(2), state.RecipientOffset)
+	assert.Equal(t, "user2@example.com", state.LastProcessedEmail)
+
+	// While paused, fetching the next batch is refused without a retry
+	_, err = orchestrator.FetchBatch(context.Background(), workspaceID, broadcastID, state.LastProcessedEmail, 2)
+	var broadcastErr *BroadcastError
+	require.ErrorAs(t, err, &broadcastErr)
+	assert.Equal(t, ErrCodeBroadcastPaused, broadcastErr.Code)
+	assert.False(t, IsRetryable(err))
+
+	// Resumed: the task continues from the saved offset without sending twice
+	status = domain.BroadcastStatusProcessing
+	done, err = orchestrator.Process(context.Background(), task, time.Now().Add(30*time.Second))
+	require.NoError(t, err)
+	assert.True(t, done)
+
+	assert.Equal(t, []string{"user1@example.com", "user2@example.com", "user3@example.com", "user4@example.com"}, sentTo)
+	assert.Equal(t, int64(4), task.State.SendBroadcast.RecipientOffset)
+	assert.Equal(t, 4, task.State.SendBroadcast.EnqueuedCount)
+}
@@ -0,0 +1,82 @@
+package broadcast
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type scheduleTestSetup struct {
+	*orchestratorFixture
+	orchestrator *BroadcastOrchestrator
+}
+
+// setupScheduleTest prepares the first run of the task of a broadcast scheduled at 09:00 in New York on
+// the day DST starts, which is 13:00 UTC
+func setupScheduleTest(t *testing.T) *scheduleTestSetup {
+	f := newOrchestratorFixture(t)
+	f.broadcast.Status = domain.BroadcastStatusScheduled
+	f.broadcast.Schedule = domain.ScheduleSettings{
+		IsScheduled:   true,
+		ScheduledDate: "2026-03-08",
+		ScheduledTime: "09:00",
+		Timezone:      "America/New_York",
+	}
+	f.task.State = nil
+	f.config = nil
+
+	return &scheduleTestSetup{orchestratorFixture: f, orchestrator: f.build()}
+}
+
+func TestBroadcastOrchestrator_Process_ScheduledTime(t *testing.T) {
+	dispatchAt := time.Date(2026, 3, 8, 13, 0, 0, 0, time.UTC)
+
+	t.Run("run before the scheduled time is deferred to it", func(t *testing.T) {
+		setup := setupScheduleTest(t)
+		// 08:30 in New York
+		setup.clock.now = time.Date(2026, 3, 8, 12, 30, 0, 0, time.UTC)
+
+		// The audience is not counted, the contact repository has no expectation
+		done, err := setup.orchestrator.Process(context.Background(), setup.task, time.Now().Add(30*time.Second))
+		require.NoError(t, err)
+		assert.False(t, done)
+
+		require.NotNil(t, setup.task.NextRunAfter)
+		assert.Equal(t, dispatchAt, *setup.task.NextRunAfter)
+		assert.Equal(t, 0, setup.task.State.SendBroadcast.TotalRecipients)
+		assert.Equal(t, domain.BroadcastStatusScheduled, setup.broadcast.Status)
+	})
+
+	t.Run("run at the scheduled time starts the broadcast", func(t *testing.T) {
+		setup := setupScheduleTest(t)
+		// 09:00 EDT, an hour before 09:00 EST
+		setup.clock.now = dispatchAt
+
+		setup.contactRepo.EXPECT().CountContactsForBroadcast(gomock.Any(), "workspace-123", setup.broadcast.Audience).Return(5, nil)
+
+		done, err := setup.orchestrator.Process(context.Background(), setup.task, time.Now().Add(30*time.Second))
+		require.NoError(t, err)
+		assert.False(t, done)
+
+		assert.Nil(t, setup.task.NextRunAfter)
+		assert.Equal(t, 5, setup.task.State.SendBroadcast.TotalRecipients)
+	})
+
+	t.Run("scheduledStart reports a retryable not due error", func(t *testing.T) {
+		setup := setupScheduleTest(t)
+		setup.clock.now = dispatchAt.Add(-time.Minute)
+
+		scheduledAt, err := setup.orchestrator.scheduledStart(setup.broadcast)
+		require.Error(t, err)
+		broadcastErr, ok := err.(*BroadcastError)
+		require.True(t, ok)
+		assert.Equal(t, ErrCodeBroadcastNotDue, broadcastErr.Code)
+		assert.True(t, broadcastErr.Retryable)
+		assert.True(t, dispatchAt.Equal(scheduledAt))
+	})
+}
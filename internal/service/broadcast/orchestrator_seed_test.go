@@ -0,0 +1,112 @@
+package broadcast
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	domainmocks "github.com/Notifuse/notifuse/internal/domain/mocks"
+	"github.com/Notifuse/notifuse/internal/service/broadcast/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupSeedTestOrchestratorTest prepares a broadcast of 3 recipients with a seed test of 2 seed addresses,
+// returning the broadcast read by every GetBroadcast call so that tests can change its status meanwhile
+func setupSeedTestOrchestratorTest(t *testing.T, requireApproval bool) (*BroadcastOrchestrator, *domain.Task, *mocks.MockMessageSender, *domainmocks.MockContactRepository, *domain.Broadcast) {
+	f := newOrchestratorFixture(t)
+	f.broadcast.SeedTest = domain.BroadcastSeedTest{
+		Enabled:         true,
+		Emails:          []string{"seed@gmail.com", "seed@outlook.com"},
+		RequireApproval: requireApproval,
+	}
+	f.task.State.SendBroadcast.TotalRecipients = 3
+
+	return f.build(), f.task, f.messageSender, f.contactRepo, f.broadcast
+}
+
+func seedTestRecipients() []*domain.ContactWithList {
+	return []*domain.ContactWithList{
+		{Contact: &domain.Contact{Email: "user1@example.com"}, ListID: "list-1"},
+		{Contact: &domain.Contact{Email: "user2@example.com"}, ListID: "list-1"},
+		{Contact: &domain.Contact{Email: "user3@example.com"}, ListID: "list-1"},
+	}
+}
+
+// sentEmails returns the recipients of a SendBatch call
+func sentEmails(batch []*domain.ContactWithList) []string {
+	emails := make([]string, 0, len(batch))
+	for _, recipient := range batch {
+		emails = append(emails, recipient.Contact.Email)
+	}
+	return emails
+}
+
+func TestBroadcastOrchestrator_Process_SeedTest(t *testing.T) {
+	t.Run("main send is held in seed_pending until approval", func(t *testing.T) {
+		orchestrator, task, messageSender, contactRepo, bcast := setupSeedTestOrchestratorTest(t, true)
+
+		// First run: only the seed addresses are sent to, before any recipient is fetched
+		var sent [][]string
+		messageSender.EXPECT().
+			SendBatch(gomock.Any(), "workspace-123", "marketing-provider-id", "secret-key", gomock.Any(), true, "broadcast-123", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _, _, _, _ string, _ bool, _ string, batch []*domain.ContactWithList, _ map[string]*domain.Template, _ *domain.EmailProvider, _ time.Time) (domain.BatchSendResult, error) {
+				sent = append(sent, sentEmails(batch))
+				return domain.BatchSendResult{SentEmails: []string{"seed@gmail.com"}, FailedEmails: []string{"seed@outlook.com"}}, nil
+			})
+
+		done, err := orchestrator.Process(context.Background(), task, time.Now().Add(30*time.Second))
+		require.NoError(t, err)
+		assert.False(t, done)
+		assert.Equal(t, [][]string{{"seed@gmail.com", "seed@outlook.com"}}, sent)
+		assert.Equal(t, domain.BroadcastStatusSeedPending, bcast.Status)
+		require.NotNil(t, bcast.SeedTest.SentAt)
+		assert.Equal(t, []domain.SeedTestResult{
+			{Email: "seed@gmail.com", MailboxProvider: "gmail.com", Status: domain.SeedTestStatusSent},
+			{Email: "seed@outlook.com", MailboxProvider: "outlook.com", Status: domain.SeedTestStatusFailed},
+		}, bcast.SeedTest.Results)
+		assert.Equal(t, int64(0), task.State.SendBroadcast.RecipientOffset)
+
+		// Second run before approval: nothing is sent
+		done, err = orchestrator.Process(context.Background(), task, time.Now().Add(30*time.Second))
+		require.NoError(t, err)
+		assert.False(t, done)
+		assert.Len(t, sent, 1)
+
+		// Once approved, the recipients are sent to and the seeds are not sent again
+		bcast.Status = domain.BroadcastStatusProcessing
+		contactRepo.EXPECT().GetContactsForBroadcast(gomock.Any(), "workspace-123", bcast.Audience, 3, "").Return(seedTestRecipients(), nil)
+		messageSender.EXPECT().
+			SendBatch(gomock.Any(), "workspace-123", "marketing-provider-id", "secret-key", gomock.Any(), true, "broadcast-123", gomock.Len(3), gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(sendAll)
+
+		done, err = orchestrator.Process(context.Background(), task, time.Now().Add(30*time.Second))
+		require.NoError(t, err)
+		assert.True(t, done)
+		assert.Equal(t, 3, task.State.SendBroadcast.EnqueuedCount)
+		assert.Equal(t, domain.BroadcastStatusProcessed, bcast.Status)
+	})
+
+	t.Run("without approval the main send follows the seeds", func(t *testing.T) {
+		orchestrator, task, messageSender, contactRepo, bcast := setupSeedTestOrchestratorTest(t, false)
+
+		contactRepo.EXPECT().GetContactsForBroadcast(gomock.Any(), "workspace-123", bcast.Audience, 3, "").Return(seedTestRecipients(), nil)
+		gomock.InOrder(
+			messageSender.EXPECT().
+				SendBatch(gomock.Any(), "workspace-123", "marketing-provider-id", "secret-key", gomock.Any(), true, "broadcast-123", gomock.Len(2), gomock.Any(), gomock.Any(), gomock.Any()).
+				DoAndReturn(sendAll),
+			messageSender.EXPECT().
+				SendBatch(gomock.Any(), "workspace-123", "marketing-provider-id", "secret-key", gomock.Any(), true, "broadcast-123", gomock.Len(3), gomock.Any(), gomock.Any(), gomock.Any()).
+				DoAndReturn(sendAll),
+		)
+
+		done, err := orchestrator.Process(context.Background(), task, time.Now().Add(30*time.Second))
+		require.NoError(t, err)
+		assert.True(t, done)
+		assert.Len(t, bcast.SeedTest.Results, 2)
+		// Seed messages are not counted as recipients of the broadcast
+		assert.Equal(t, 3, task.State.SendBroadcast.EnqueuedCount)
+	})
+}
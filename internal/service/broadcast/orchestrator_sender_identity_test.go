@@ -0,0 +1,144 @@
+package broadcast
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	domainmocks "github.com/Notifuse/notifuse/internal/domain/mocks"
+	"github.com/Notifuse/notifuse/internal/service/broadcast/mocks"
+	pkgmocks "github.com/Notifuse/notifuse/pkg/mocks"
+	"github.com/Notifuse/notifuse/pkg/notifuse_mjml"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupSenderIdentityTest prepares a broadcast of 3 recipients in a workspace requiring verified senders,
+// whose template is sent from senderID and whose marketing provider has a single sender news@example.com
+func setupSenderIdentityTest(t *testing.T, senderID string, identities []domain.SenderIdentity) (*BroadcastOrchestrator, *domain.Task, *mocks.MockMessageSender, *domainmocks.MockContactRepository, *domain.Broadcast) {
+	f := newOrchestratorFixture(t)
+	f.workspace.Settings.RequireVerifiedSenders = true
+	f.workspace.Settings.SenderIdentities = identities
+	f.workspace.Integrations[0].EmailProvider.Senders = []domain.EmailSender{{ID: "sender-news", Email: "news@example.com", Name: "News"}}
+	f.templates[0].Email.SenderID = senderID
+	f.task.State.SendBroadcast.TotalRecipients = 3
+
+	return f.build(), f.task, f.messageSender, f.contactRepo, f.broadcast
+}
+
+func TestBroadcastOrchestrator_Process_SenderIdentity(t *testing.T) {
+	verifiedAt := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("verified sender is sent from", func(t *testing.T) {
+		orchestrator, task, messageSender, contactRepo, bcast := setupSenderIdentityTest(t, "sender-news", []domain.SenderIdentity{
+			{Email: "news@example.com", Status: domain.SenderIdentityStatusVerified, VerifiedAt: &verifiedAt},
+		})
+
+		contactRepo.EXPECT().GetContactsForBroadcast(gomock.Any(), "workspace-123", bcast.Audience, 3, "").Return(seedTestRecipients(), nil)
+		messageSender.EXPECT().
+			SendBatch(gomock.Any(), "workspace-123", "marketing-provider-id", "secret-key", gomock.Any(), true, "broadcast-123", gomock.Len(3), gomock.Any(), gomock.Any(), gomock.Any()).
+			DoAndReturn(sendAll)
+
+		done, err := orchestrator.Process(context.Background(), task, time.Now().Add(30*time.Second))
+		require.NoError(t, err)
+		assert.True(t, done)
+		assert.Equal(t, 3, task.State.SendBroadcast.EnqueuedCount)
+		assert.Equal(t, domain.BroadcastStatusProcessed, bcast.Status)
+	})
+
+	// No recipient is fetched nor sent to, the mocks fail on any call
+	failures := []struct {
+		name       string
+		senderID   string
+		identities []domain.SenderIdentity
+		code       ErrorCode
+		message    string
+	}{
+		{
+			name:     "sender pending verification",
+			senderID: "sender-news",
+			identities: []domain.SenderIdentity{
+				{Email: "news@example.com", Status: domain.SenderIdentityStatusPending},
+			},
+			code:    ErrCodeSenderUnverified,
+			message: "sender news@example.com is not verified (status: pending)",
+		},
+		{
+			name:     "sender without identity",
+			senderID: "sender-news",
+			identities: []domain.SenderIdentity{
+				{Email: "other@example.com", Status: domain.SenderIdentityStatusVerified, VerifiedAt: &verifiedAt},
+			},
+			code:    ErrCodeSenderUnverified,
+			message: "sender news@example.com has no sender identity",
+		},
+		{
+			name:     "unknown sender without default",
+			senderID: "sender-deleted",
+			identities: []domain.SenderIdentity{
+				{Email: "news@example.com", Status: domain.SenderIdentityStatusVerified, VerifiedAt: &verifiedAt},
+			},
+			code:    ErrCodeSenderNotFound,
+			message: "sender sender-deleted is not configured for the email provider",
+		},
+	}
+
+	for _, tc := range failures {
+		t.Run(tc.name+" fails the broadcast before sending", func(t *testing.T) {
+			orchestrator, task, _, _, bcast := setupSenderIdentityTest(t, tc.senderID, tc.identities)
+
+			done, err := orchestrator.Process(context.Background(), task, time.Now().Add(30*time.Second))
+			require.Error(t, err)
+			assert.False(t, done)
+			assert.Contains(t, err.Error(), tc.message)
+
+			var broadcastErr *BroadcastError
+			require.True(t, errors.As(err, &broadcastErr))
+			assert.Equal(t, tc.code, broadcastErr.Code)
+			assert.False(t, broadcastErr.Retryable)
+
+			// Failed on the first attempt, retries can't fix the sender
+			assert.Equal(t, domain.BroadcastStatusFailed, bcast.Status)
+			assert.Equal(t, 0, task.State.SendBroadcast.EnqueuedCount)
+		})
+	}
+}
+
+func TestBroadcastOrchestrator_ValidateTemplates_SenderIdentity(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Warn(gomock.Any()).AnyTimes()
+
+	orchestrator := &BroadcastOrchestrator{logger: mockLogger}
+
+	templates := map[string]*domain.Template{
+		"template-1": {ID: "template-1", Email: &domain.EmailTemplate{Subject: "S", VisualEditorTree: &notifuse_mjml.MJMLBlock{BaseBlock: notifuse_mjml.NewBaseBlock("root", notifuse_mjml.MJMLComponentMjml)}}},
+	}
+	provider := &domain.EmailProvider{
+		Kind:    domain.EmailProviderKindSES,
+		Senders: []domain.EmailSender{{ID: "sender-news", Email: "news@example.com", IsDefault: true}},
+	}
+	settings := &domain.WorkspaceSettings{
+		SenderIdentities: []domain.SenderIdentity{{Email: "News@Example.com", Status: domain.SenderIdentityStatusPending}},
+	}
+
+	// Senders are not checked unless the workspace requires verified senders
+	require.NoError(t, orchestrator.ValidateTemplates(templates, provider, settings))
+
+	// The template without sender ID is sent from the default sender
+	settings.RequireVerifiedSenders = true
+	err := orchestrator.ValidateTemplates(templates, provider, settings)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "[SENDER_UNVERIFIED] template template-1 cannot be sent from its sender: sender news@example.com is not verified (status: pending)")
+
+	settings.SenderIdentities[0].Status = domain.SenderIdentityStatusVerified
+	require.NoError(t, orchestrator.ValidateTemplates(templates, provider, settings))
+}
@@ -0,0 +1,119 @@
+package broadcast
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/service/broadcast/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupShutdownOrchestratorTest prepares a single-template broadcast of 4 recipients sent in batches of 2,
+// returning the sender to set expectations on and the states saved to the task in order
+func setupShutdownOrchestratorTest(t *testing.T, onFetch func()) (*BroadcastOrchestrator, *domain.Task, *mocks.MockMessageSender, *[]*domain.TaskState) {
+	f := newOrchestratorFixture(t)
+	f.config.FetchBatchSize = 2
+	f.task.State.SendBroadcast.TotalRecipients = 4
+
+	// Only the first batch is fetched, the shutdown stops the broadcast before the second one
+	recipients := []*domain.ContactWithList{
+		{Contact: &domain.Contact{Email: "user1@example.com"}, ListID: "list-1"},
+		{Contact: &domain.Contact{Email: "user2@example.com"}, ListID: "list-1"},
+	}
+	f.contactRepo.EXPECT().GetContactsForBroadcast(gomock.Any(), "workspace-123", f.broadcast.Audience, 2, "").
+		DoAndReturn(func(_ context.Context, _ string, _ domain.AudienceSettings, _ int, _ string) ([]*domain.ContactWithList, error) {
+			if onFetch != nil {
+				onFetch()
+			}
+			return recipients, nil
+		})
+
+	var saved []*domain.TaskState
+	f.taskRepo.EXPECT().SaveState(gomock.Any(), "workspace-123", "task-123", gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _ string, _ float64, state *domain.TaskState) error {
+			saved = append(saved, state)
+			return nil
+		}).AnyTimes()
+
+	return f.build(), f.task, f.messageSender, &saved
+}
+
+func TestBroadcastOrchestrator_Process_CancelledMidBroadcast(t *testing.T) {
+	orchestrator, task, messageSender, saved := setupShutdownOrchestratorTest(t, nil)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The server shuts down while the first batch is being sent
+	messageSender.EXPECT().
+		SendBatch(gomock.Any(), "workspace-123", "marketing-provider-id", "secret-key", gomock.Any(), true, "broadcast-123", gomock.Len(2), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _, _, _ string, _ bool, _ string, batch []*domain.ContactWithList, _ map[string]*domain.Template, _ *domain.EmailProvider, _ time.Time) (domain.BatchSendResult, error) {
+			cancel()
+			return sentResult(batch), nil
+		})
+
+	done, err := orchestrator.Process(ctx, task, time.Now().Add(30*time.Second))
+	require.NoError(t, err, "a shutdown must not fail the task")
+	assert.False(t, done, "the task is resumed on the next boot")
+
+	// The batch progress is saved, then checkpointed once more before the task is given back
+	require.Len(t, *saved, 2)
+	checkpoint := (*saved)[len(*saved)-1].SendBroadcast
+	assert.Equal(t, 2, checkpoint.EnqueuedCount)
+	assert.Equal(t, 0, checkpoint.FailedCount)
+	assert.Equal(t, int64(2), checkpoint.RecipientOffset)
+	assert.Equal(t, "user2@example.com", checkpoint.LastProcessedEmail)
+}
+
+func TestBroadcastOrchestrator_Process_ShutdownNotice(t *testing.T) {
+	shutdown := NewShutdownNotice()
+	deadline := time.Now().Add(10 * time.Second)
+
+	// The shutdown begins after the batch boundary, while the first batch is fetched
+	orchestrator, task, messageSender, saved := setupShutdownOrchestratorTest(t, func() {
+		shutdown.Begin(deadline)
+	})
+	orchestrator.shutdown = shutdown
+
+	// The batch in flight must be done by the shutdown deadline rather than the task timeout
+	messageSender.EXPECT().
+		SendBatch(gomock.Any(), "workspace-123", "marketing-provider-id", "secret-key", gomock.Any(), true, "broadcast-123", gomock.Len(2), gomock.Any(), gomock.Any(), deadline).
+		DoAndReturn(sendAll)
+
+	done, err := orchestrator.Process(context.Background(), task, time.Now().Add(30*time.Second))
+	require.NoError(t, err)
+	assert.False(t, done)
+
+	require.Len(t, *saved, 2)
+	checkpoint := (*saved)[len(*saved)-1].SendBroadcast
+	assert.Equal(t, 2, checkpoint.EnqueuedCount)
+	assert.Equal(t, int64(2), checkpoint.RecipientOffset)
+	assert.Equal(t, "user2@example.com", checkpoint.LastProcessedEmail)
+}
+
+func TestShutdownNotice(t *testing.T) {
+	var nilNotice *ShutdownNotice
+	nilNotice.Begin(time.Now())
+	_, ok := nilNotice.Deadline()
+	assert.False(t, ok, "a nil notice is never signaled")
+
+	notice := NewShutdownNotice()
+	_, ok = notice.Deadline()
+	assert.False(t, ok)
+
+	later := time.Now().Add(time.Hour)
+	assert.Equal(t, later, notice.capToShutdown(later))
+
+	deadline := time.Now().Add(time.Minute)
+	notice.Begin(deadline)
+	got, ok := notice.Deadline()
+	require.True(t, ok)
+	assert.Equal(t, deadline, got)
+	assert.Equal(t, deadline, notice.capToShutdown(later))
+
+	earlier := time.Now()
+	assert.Equal(t, earlier, notice.capToShutdown(earlier))
+}
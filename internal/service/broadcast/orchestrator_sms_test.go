@@ -0,0 +1,79 @@
+package broadcast
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/service/broadcast/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupSMSOrchestratorTest prepares an sms broadcast of 2 recipients in a workspace with both an email
+// and an sms provider, returning the email and sms senders to set expectations on
+func setupSMSOrchestratorTest(t *testing.T, tpl *domain.Template) (*BroadcastOrchestrator, *domain.Task, *mocks.MockMessageSender, *mocks.MockMessageSender) {
+	f := newOrchestratorFixture(t)
+	f.workspace.Settings.SMSProviderID = "sms-provider-id"
+	f.workspace.Integrations = append(f.workspace.Integrations, domain.Integration{
+		ID: "sms-provider-id", Type: domain.IntegrationTypeSMS, SMSProvider: &domain.SMSProvider{Kind: domain.SMSProviderKindTwilio, Twilio: &domain.TwilioSettings{AccountSID: "AC123", AuthToken: "token", FromNumber: "+15550000000"}},
+	})
+	f.broadcast.ChannelType = domain.ChannelSMS
+	f.templates = []*domain.Template{tpl}
+	f.task.State.SendBroadcast.TotalRecipients = 2
+
+	recipients := []*domain.ContactWithList{
+		{Contact: &domain.Contact{Email: "user1@example.com", Phone: &domain.NullableString{String: "+33600000001"}}, ListID: "list-1"},
+		{Contact: &domain.Contact{Email: "user2@example.com", Phone: &domain.NullableString{String: "+33600000002"}}, ListID: "list-1"},
+	}
+	f.contactRepo.EXPECT().GetContactsForBroadcast(gomock.Any(), "workspace-123", f.broadcast.Audience, 2, "").Return(recipients, nil).MaxTimes(1)
+
+	mockSMSSender := mocks.NewMockMessageSender(f.ctrl)
+	orchestrator := f.build()
+	orchestrator.smsSender = mockSMSSender
+
+	return orchestrator, f.task, f.messageSender, mockSMSSender
+}
+
+func TestBroadcastOrchestrator_Process_SMS(t *testing.T) {
+	smsTemplate := &domain.Template{ID: "template-1", Channel: domain.ChannelSMS, SMS: &domain.SMSTemplate{Body: "Hello {{ contact.first_name }}"}}
+
+	t.Run("sms broadcast is sent through the sms provider", func(t *testing.T) {
+		orchestrator, task, _, smsSender := setupSMSOrchestratorTest(t, smsTemplate)
+
+		// The email sender has no expectations, any call to it fails the test
+		smsSender.EXPECT().
+			SendBatch(gomock.Any(), "workspace-123", "sms-provider-id", "secret-key", gomock.Any(), true, "broadcast-123", gomock.Len(2), gomock.Any(), gomock.Nil(), gomock.Any()).
+			DoAndReturn(sendAll)
+
+		done, err := orchestrator.Process(context.Background(), task, time.Now().Add(30*time.Second))
+		require.NoError(t, err)
+		assert.True(t, done)
+
+		state := task.State.SendBroadcast
+		assert.Equal(t, domain.ChannelSMS, state.ChannelType)
+		assert.Equal(t, 2, state.EnqueuedCount)
+		assert.Equal(t, int64(2), state.RecipientOffset)
+	})
+
+	t.Run("email template is rejected on an sms broadcast", func(t *testing.T) {
+		emailTemplate := &domain.Template{ID: "template-1", Channel: domain.ChannelEmail, SMS: &domain.SMSTemplate{Body: "Hello"}}
+		orchestrator, task, _, _ := setupSMSOrchestratorTest(t, emailTemplate)
+
+		done, err := orchestrator.Process(context.Background(), task, time.Now().Add(30*time.Second))
+		require.Error(t, err)
+		assert.False(t, done)
+		assert.Contains(t, err.Error(), "does not match the sms channel of the broadcast")
+	})
+
+	t.Run("sms template without body is rejected", func(t *testing.T) {
+		orchestrator, task, _, _ := setupSMSOrchestratorTest(t, &domain.Template{ID: "template-1", Channel: domain.ChannelSMS})
+
+		done, err := orchestrator.Process(context.Background(), task, time.Now().Add(30*time.Second))
+		require.Error(t, err)
+		assert.False(t, done)
+		assert.Contains(t, err.Error(), "template missing sms body")
+	})
+}
@@ -0,0 +1,63 @@
+package broadcast
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupFinalStatusRetryTest prepares a single-template broadcast with one recipient, so that a
+// single Process call sends it and writes the sent status. updateBroadcast handles the status writes.
+func setupFinalStatusRetryTest(t *testing.T, updateBroadcast func(b *domain.Broadcast) error) (*BroadcastOrchestrator, *domain.Task) {
+	f := newOrchestratorFixture(t)
+	f.config.StatusUpdateRetries = 2
+	f.task.State.SendBroadcast.TotalRecipients = 1
+
+	f.broadcastRepo.EXPECT().UpdateBroadcast(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, b *domain.Broadcast) error {
+		return updateBroadcast(b)
+	}).AnyTimes()
+
+	recipients := []*domain.ContactWithList{{Contact: &domain.Contact{Email: "user1@example.com"}, ListID: "list-1"}}
+	f.contactRepo.EXPECT().GetContactsForBroadcast(gomock.Any(), "workspace-123", f.broadcast.Audience, 1, "").Return(recipients, nil)
+	f.messageSender.EXPECT().SendBatch(gomock.Any(), "workspace-123", "marketing-provider-id", "secret-key", gomock.Any(), true, "broadcast-123", recipients, gomock.Any(), gomock.Any(), gomock.Any()).Return(sentResult(recipients), nil)
+
+	return f.build(), f.task
+}
+
+func TestBroadcastOrchestrator_Process_FinalStatusRetry(t *testing.T) {
+	t.Run("transient failure still completes the broadcast", func(t *testing.T) {
+		var attempts []domain.BroadcastStatus
+		orchestrator, task := setupFinalStatusRetryTest(t, func(b *domain.Broadcast) error {
+			attempts = append(attempts, b.Status)
+			if len(attempts) == 1 {
+				return errors.New("connection reset by peer")
+			}
+			return nil
+		})
+
+		done, err := orchestrator.Process(context.Background(), task, time.Now().Add(30*time.Second))
+		require.NoError(t, err)
+		assert.True(t, done)
+		assert.Equal(t, []domain.BroadcastStatus{domain.BroadcastStatusProcessed, domain.BroadcastStatusProcessed}, attempts)
+	})
+
+	t.Run("fails once the retries are exhausted", func(t *testing.T) {
+		attempts := 0
+		orchestrator, task := setupFinalStatusRetryTest(t, func(b *domain.Broadcast) error {
+			attempts++
+			return errors.New("database is down")
+		})
+
+		done, err := orchestrator.Process(context.Background(), task, time.Now().Add(30*time.Second))
+		require.Error(t, err)
+		assert.False(t, done)
+		assert.Contains(t, err.Error(), "failed to update broadcast status to processed")
+		assert.Equal(t, 3, attempts)
+	})
+}
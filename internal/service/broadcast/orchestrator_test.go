@@ -254,7 +254,7 @@ func TestBroadcastOrchestrator_ValidateTemplates(t *testing.T) {
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			err := orchestrator.ValidateTemplates(tc.templates)
+			err := orchestrator.ValidateTemplates(tc.templates, nil, nil)
 			if tc.expectError {
 				assert.Error(t, err)
 			} else {
@@ -262,6 +262,98 @@ func TestBroadcastOrchestrator_ValidateTemplates(t *testing.T) {
 			}
 		})
 	}
+
+	t.Run("Unknown contact merge tags", func(t *testing.T) {
+		tree := createMinimalValidMJMLBlock("root1")
+		textBase := notifuse_mjml.NewBaseBlock("text1", notifuse_mjml.MJMLComponentMjText)
+		content := "Hi {{ contact.first_name }}, your tier is {{ contact.tier }}"
+		textBase.Content = &content
+		tree.Children[0].SetChildren([]notifuse_mjml.EmailBlock{&notifuse_mjml.MJTextBlock{BaseBlock: textBase}})
+
+		err := orchestrator.ValidateTemplates(map[string]*domain.Template{
+			"template-1": {
+				ID: "template-1",
+				Email: &domain.EmailTemplate{
+					Subject:          "Hello {{ contact.nickname }}",
+					SenderID:         "sender-123",
+					VisualEditorTree: tree,
+				},
+			},
+		}, nil, nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "template template-1 references unknown contact fields: contact.nickname, contact.tier")
+	})
+
+	t.Run("Malformed conditions", func(t *testing.T) {
+		newTemplate := func(condition string) *domain.Template {
+			tree := createMinimalValidMJMLBlock("root1")
+			conditionalBase := notifuse_mjml.NewBaseBlock("conditional1", notifuse_mjml.MJMLComponentConditional)
+			conditionalBase.Attributes["condition"] = condition
+			tree.Children[0].SetChildren([]notifuse_mjml.EmailBlock{&notifuse_mjml.MJConditionalBlock{BaseBlock: conditionalBase}})
+			return &domain.Template{
+				ID: "template-1",
+				Email: &domain.EmailTemplate{
+					Subject:          "Your offer",
+					SenderID:         "sender-123",
+					VisualEditorTree: tree,
+				},
+			}
+		}
+
+		require.NoError(t, orchestrator.ValidateTemplates(map[string]*domain.Template{
+			"template-1": newTemplate("contact.custom_number_1 > 100"),
+		}, nil, nil))
+
+		err := orchestrator.ValidateTemplates(map[string]*domain.Template{
+			"template-1": newTemplate("contact.custom_number_1 > > 100"),
+		}, nil, nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "template template-1 has malformed conditions")
+	})
+
+	t.Run("Product grid sources", func(t *testing.T) {
+		newTemplate := func(source string) *domain.Template {
+			tree := createMinimalValidMJMLBlock("root1")
+			gridBase := notifuse_mjml.NewBaseBlock("grid1", notifuse_mjml.MJMLComponentProductGrid)
+			gridBase.Attributes["source"] = source
+			tree.Children[0].SetChildren([]notifuse_mjml.EmailBlock{&notifuse_mjml.MJProductGridBlock{BaseBlock: gridBase}})
+			return &domain.Template{
+				ID: "template-1",
+				Email: &domain.EmailTemplate{
+					Subject:          "Picked for you",
+					SenderID:         "sender-123",
+					VisualEditorTree: tree,
+				},
+			}
+		}
+
+		require.NoError(t, orchestrator.ValidateTemplates(map[string]*domain.Template{
+			"template-1": newTemplate("contact.custom_json_1.recommended_products"),
+		}, nil, nil))
+
+		err := orchestrator.ValidateTemplates(map[string]*domain.Template{
+			"template-1": newTemplate("contact.recommended_products"),
+		}, nil, nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `template template-1 has product grids that don't reference a JSON contact field: ["contact.recommended_products"]`)
+	})
+
+	t.Run("Tracking opt-outs are left unchanged", func(t *testing.T) {
+		template := &domain.Template{
+			ID: "template-1",
+			Email: &domain.EmailTemplate{
+				Subject:              "Reset your password",
+				SenderID:             "sender-123",
+				VisualEditorTree:     createMinimalValidMJMLBlock("root1"),
+				DisableOpenTracking:  true,
+				DisableClickTracking: true,
+			},
+		}
+
+		require.NoError(t, orchestrator.ValidateTemplates(map[string]*domain.Template{"template-1": template}, nil, nil))
+		assert.True(t, template.Email.DisableOpenTracking)
+		assert.True(t, template.Email.DisableClickTracking)
+	})
 }
 
 func TestBroadcastOrchestrator_GetTotalRecipientCount(t *testing.T) {
@@ -607,6 +699,11 @@ func TestSaveProgressState(t *testing.T) {
 		SaveState(ctx, workspaceID, taskID, gomock.Any(), gomock.Any()).
 		Return(nil)
 
+	var progressEvent domain.EventPayload
+	mockEventBus.EXPECT().Publish(gomock.Any(), gomock.Any()).Do(func(_ context.Context, event domain.EventPayload) {
+		progressEvent = event
+	})
+
 	// Create broadcast state
 	broadcastState := &domain.SendBroadcastState{
 		BroadcastID:               broadcastID,
@@ -631,6 +728,17 @@ func TestSaveProgressState(t *testing.T) {
 	// Verify
 	require.NoError(t, err)
 	assert.Equal(t, currentTime, newSaveTime)
+
+	// The saved progress is published for the clients following the broadcast
+	assert.Equal(t, domain.EventBroadcastProgress, progressEvent.Type)
+	assert.Equal(t, workspaceID, progressEvent.WorkspaceID)
+	assert.Equal(t, broadcastID, progressEvent.EntityID)
+	assert.Equal(t, processedCount, progressEvent.Data["processed"])
+	assert.Equal(t, totalRecipients, progressEvent.Data["total"])
+	assert.InDelta(t, 30.0, progressEvent.Data["percent"], 0.001)
+	assert.Equal(t, sentCount, progressEvent.Data["sent"])
+	assert.Equal(t, failedCount, progressEvent.Data["failed"])
+	assert.Equal(t, 140, progressEvent.Data["eta_seconds"])
 }
 
 // TestBroadcastOrchestrator_Process tests the main Process method covering lines 594-795
@@ -659,6 +767,7 @@ func TestBroadcastOrchestrator_Process(t *testing.T) {
 				mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
 				mockLogger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(mockLogger).AnyTimes()
 				mockLogger.EXPECT().Info(gomock.Any()).AnyTimes()
+				mockLogger.EXPECT().Warn(gomock.Any()).AnyTimes()
 				mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
 				mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
 
@@ -751,7 +860,7 @@ func TestBroadcastOrchestrator_Process(t *testing.T) {
 					gomock.Any(),
 					gomock.Any(),
 					gomock.Any(),
-				).Return(2, 0, nil)
+				).Return(sentResult(recipients), nil)
 
 				// Mock task state saving
 				mockTaskRepo.EXPECT().SaveState(gomock.Any(), "workspace-123", "task-123", gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
@@ -796,6 +905,7 @@ func TestBroadcastOrchestrator_Process(t *testing.T) {
 				mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
 				mockLogger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(mockLogger).AnyTimes()
 				mockLogger.EXPECT().Info(gomock.Any()).AnyTimes()
+				mockLogger.EXPECT().Warn(gomock.Any()).AnyTimes()
 				mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
 				mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
 
@@ -881,7 +991,7 @@ func TestBroadcastOrchestrator_Process(t *testing.T) {
 					gomock.Any(),
 					gomock.Any(),
 					gomock.Any(),
-				).Return(1, 0, nil)
+				).Return(sentResult(recipients), nil)
 
 				// Mock task state saving
 				mockTaskRepo.EXPECT().SaveState(gomock.Any(), "workspace-123", "task-123", gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
@@ -927,6 +1037,7 @@ func TestBroadcastOrchestrator_Process(t *testing.T) {
 				mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
 				mockLogger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(mockLogger).AnyTimes()
 				mockLogger.EXPECT().Info(gomock.Any()).AnyTimes()
+				mockLogger.EXPECT().Warn(gomock.Any()).AnyTimes()
 				mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
 				mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
 
@@ -978,6 +1089,7 @@ func TestBroadcastOrchestrator_Process(t *testing.T) {
 				mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
 				mockLogger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(mockLogger).AnyTimes()
 				mockLogger.EXPECT().Info(gomock.Any()).AnyTimes()
+				mockLogger.EXPECT().Warn(gomock.Any()).AnyTimes()
 				mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
 				mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
 
@@ -996,6 +1108,12 @@ func TestBroadcastOrchestrator_Process(t *testing.T) {
 				}
 				mockWorkspaceRepo.EXPECT().GetByID(gomock.Any(), "workspace-123").Return(workspace, nil)
 
+				// The broadcast is read first to pick the provider of its channel
+				mockBroadcastRepo.EXPECT().GetBroadcast(gomock.Any(), "workspace-123", "broadcast-123").Return(&domain.Broadcast{
+					ID:       "broadcast-123",
+					Audience: domain.AudienceSettings{List: "list-1"},
+				}, nil)
+
 				return mockMessageSender, mockBroadcastRepo, mockTemplateRepo, mockContactRepo, mockTaskRepo, mockWorkspaceRepo, mockLogger, mockTimeProvider
 			},
 			task: &domain.Task{
@@ -1037,6 +1155,7 @@ func TestBroadcastOrchestrator_Process(t *testing.T) {
 				mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
 				mockLogger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(mockLogger).AnyTimes()
 				mockLogger.EXPECT().Info(gomock.Any()).AnyTimes()
+				mockLogger.EXPECT().Warn(gomock.Any()).AnyTimes()
 				mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
 				mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
 
@@ -1128,6 +1247,7 @@ func TestBroadcastOrchestrator_Process(t *testing.T) {
 				mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
 				mockLogger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(mockLogger).AnyTimes()
 				mockLogger.EXPECT().Info(gomock.Any()).AnyTimes()
+				mockLogger.EXPECT().Warn(gomock.Any()).AnyTimes()
 				mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
 				mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
 
@@ -1232,6 +1352,7 @@ func TestBroadcastOrchestrator_Process(t *testing.T) {
 				mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
 				mockLogger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(mockLogger).AnyTimes()
 				mockLogger.EXPECT().Info(gomock.Any()).AnyTimes()
+				mockLogger.EXPECT().Warn(gomock.Any()).AnyTimes()
 				mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
 				mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
 
@@ -1322,7 +1443,7 @@ func TestBroadcastOrchestrator_Process(t *testing.T) {
 					gomock.Any(),
 					gomock.Any(),
 					gomock.Any(),
-				).Return(1, 0, nil)
+				).Return(sentResult(recipients), nil)
 
 				// Mock task state saving
 				mockTaskRepo.EXPECT().SaveState(gomock.Any(), "workspace-123", "task-123", gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
@@ -1361,6 +1482,7 @@ func TestBroadcastOrchestrator_Process(t *testing.T) {
 
 			mockMessageSender, mockBroadcastRepo, mockTemplateRepo, mockContactRepo, mockTaskRepo, mockWorkspaceRepo, mockLogger, mockTimeProvider := tc.setupMocks(ctrl)
 			mockEventBus := domainmocks.NewMockEventBus(ctrl)
+			mockEventBus.EXPECT().Publish(gomock.Any(), gomock.Any()).AnyTimes()
 
 			config := &broadcast.Config{
 				FetchBatchSize:      100,
@@ -1416,11 +1538,13 @@ func TestBroadcastOrchestrator_Process_ABTestStartSetsTestingAndCompletesTestPha
 	mockLogger := pkgmocks.NewMockLogger(ctrl)
 	mockTimeProvider := mocks.NewMockTimeProvider(ctrl)
 	mockEventBus := domainmocks.NewMockEventBus(ctrl)
+	mockEventBus.EXPECT().Publish(gomock.Any(), gomock.Any()).AnyTimes()
 
 	// Logger expectations
 	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
 	mockLogger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(mockLogger).AnyTimes()
 	mockLogger.EXPECT().Info(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Warn(gomock.Any()).AnyTimes()
 	mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
 	mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
 
@@ -1476,7 +1600,7 @@ func TestBroadcastOrchestrator_Process_ABTestStartSetsTestingAndCompletesTestPha
 	mockContactRepo.EXPECT().GetContactsForBroadcast(gomock.Any(), "workspace-123", bcast.Audience, 1, "").Return(recipients, nil)
 
 	// Send batch
-	mockMessageSender.EXPECT().SendBatch(gomock.Any(), "workspace-123", "marketing-provider-id", "secret-key", gomock.Any(), true, "broadcast-123", recipients, gomock.Any(), gomock.Any(), gomock.Any()).Return(1, 0, nil)
+	mockMessageSender.EXPECT().SendBatch(gomock.Any(), "workspace-123", "marketing-provider-id", "secret-key", gomock.Any(), true, "broadcast-123", recipients, gomock.Any(), gomock.Any(), gomock.Any()).Return(sentResult(recipients), nil)
 
 	// Save state
 	mockTaskRepo.EXPECT().SaveState(gomock.Any(), "workspace-123", "task-123", gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
@@ -1515,10 +1639,12 @@ func TestBroadcastOrchestrator_Process_WinnerPhaseMissingTemplate_Error(t *testi
 	mockLogger := pkgmocks.NewMockLogger(ctrl)
 	mockTimeProvider := mocks.NewMockTimeProvider(ctrl)
 	mockEventBus := domainmocks.NewMockEventBus(ctrl)
+	mockEventBus.EXPECT().Publish(gomock.Any(), gomock.Any()).AnyTimes()
 
 	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
 	mockLogger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(mockLogger).AnyTimes()
 	mockLogger.EXPECT().Info(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Warn(gomock.Any()).AnyTimes()
 	mockTimeProvider.EXPECT().Now().Return(time.Now()).AnyTimes()
 
 	workspace := &domain.Workspace{
@@ -1566,10 +1692,12 @@ func TestBroadcastOrchestrator_Process_ValidateTemplatesFailure(t *testing.T) {
 	mockLogger := pkgmocks.NewMockLogger(ctrl)
 	mockTimeProvider := mocks.NewMockTimeProvider(ctrl)
 	mockEventBus := domainmocks.NewMockEventBus(ctrl)
+	mockEventBus.EXPECT().Publish(gomock.Any(), gomock.Any()).AnyTimes()
 
 	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
 	mockLogger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(mockLogger).AnyTimes()
 	mockLogger.EXPECT().Info(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Warn(gomock.Any()).AnyTimes()
 	mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
 	mockTimeProvider.EXPECT().Now().Return(time.Now()).AnyTimes()
 
@@ -1621,11 +1749,13 @@ func TestBroadcastOrchestrator_Process_BatchSizeZeroTriggersPhaseCompletion(t *t
 	mockLogger := pkgmocks.NewMockLogger(ctrl)
 	mockTimeProvider := mocks.NewMockTimeProvider(ctrl)
 	mockEventBus := domainmocks.NewMockEventBus(ctrl)
+	mockEventBus.EXPECT().Publish(gomock.Any(), gomock.Any()).AnyTimes()
 
 	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
 	mockLogger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(mockLogger).AnyTimes()
 	mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
 	mockLogger.EXPECT().Info(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Warn(gomock.Any()).AnyTimes()
 	mockTimeProvider.EXPECT().Now().Return(time.Now()).AnyTimes()
 
 	workspace := &domain.Workspace{ID: "w", Settings: domain.WorkspaceSettings{SecretKey: "k", MarketingEmailProviderID: "pid"}, Integrations: []domain.Integration{{ID: "pid", Type: domain.IntegrationTypeEmail, EmailProvider: domain.EmailProvider{Kind: domain.EmailProviderKindSES, SES: &domain.AmazonSESSettings{AccessKey: "a", SecretKey: "b", Region: "us-east-1"}}}}}
@@ -1669,10 +1799,12 @@ func TestBroadcastOrchestrator_Process_EmptyRecipientsTriggersTestCompletion(t *
 	mockLogger := pkgmocks.NewMockLogger(ctrl)
 	mockTimeProvider := mocks.NewMockTimeProvider(ctrl)
 	mockEventBus := domainmocks.NewMockEventBus(ctrl)
+	mockEventBus.EXPECT().Publish(gomock.Any(), gomock.Any()).AnyTimes()
 
 	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
 	mockLogger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(mockLogger).AnyTimes()
 	mockLogger.EXPECT().Info(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Warn(gomock.Any()).AnyTimes()
 	mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
 	mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
 	mockTimeProvider.EXPECT().Now().Return(time.Now()).AnyTimes()
@@ -1719,6 +1851,7 @@ func TestBroadcastOrchestrator_Process_AutoWinnerEvaluationPath(t *testing.T) {
 	mockLogger := pkgmocks.NewMockLogger(ctrl)
 	mockTimeProvider := mocks.NewMockTimeProvider(ctrl)
 	mockEventBus := domainmocks.NewMockEventBus(ctrl)
+	mockEventBus.EXPECT().Publish(gomock.Any(), gomock.Any()).AnyTimes()
 	msgRepo := domainmocks.NewMockMessageHistoryRepository(ctrl)
 
 	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
@@ -1757,8 +1890,7 @@ func TestBroadcastOrchestrator_Process_AutoWinnerEvaluationPath(t *testing.T) {
 	abEval := broadcast.NewABTestEvaluator(msgRepo, mockBroadcastRepo, mockLogger)
 
 	// Stats prefer tplB
-	msgRepo.EXPECT().GetBroadcastVariationStats(gomock.Any(), "w", "b", "tplA").Return(&domain.MessageHistoryStatusSum{TotalDelivered: 100, TotalOpened: 10}, nil)
-	msgRepo.EXPECT().GetBroadcastVariationStats(gomock.Any(), "w", "b", "tplB").Return(&domain.MessageHistoryStatusSum{TotalDelivered: 100, TotalOpened: 60}, nil)
+	msgRepo.EXPECT().GetBroadcastVariationStatsAll(gomock.Any(), "w", "b", []string{"tplA", "tplB"}).Return(map[string]*domain.MessageHistoryStatusSum{"tplA": {TotalDelivered: 100, TotalOpened: 10}, "tplB": {TotalDelivered: 100, TotalOpened: 60}}, nil)
 
 	// Transaction and update during evaluation
 	mockBroadcastRepo.EXPECT().WithTransaction(gomock.Any(), "w", gomock.Any()).DoAndReturn(func(_ context.Context, _ string, fn func(*sql.Tx) error) error { return fn(nil) })
@@ -1789,7 +1921,7 @@ func TestBroadcastOrchestrator_Process_AutoWinnerEvaluationPath(t *testing.T) {
 	mockContactRepo.EXPECT().GetContactsForBroadcast(gomock.Any(), "w", bcast.Audience, 1, "").Return([]*domain.ContactWithList{{Contact: &domain.Contact{Email: "w@x.com"}}}, nil)
 
 	// Send
-	mockMessageSender.EXPECT().SendBatch(gomock.Any(), "w", "pid", "k", gomock.Any(), true, "b", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(1, 0, nil)
+	mockMessageSender.EXPECT().SendBatch(gomock.Any(), "w", "pid", "k", gomock.Any(), true, "b", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(sendAll)
 
 	// Save state
 	mockTaskRepo.EXPECT().SaveState(gomock.Any(), "w", "t", gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
@@ -1826,6 +1958,7 @@ func TestBroadcastOrchestrator_Process_ABTestWinnerPhaseProcessesRemainingRecipi
 	mockLogger := pkgmocks.NewMockLogger(ctrl)
 	mockTimeProvider := mocks.NewMockTimeProvider(ctrl)
 	mockEventBus := domainmocks.NewMockEventBus(ctrl)
+	mockEventBus.EXPECT().Publish(gomock.Any(), gomock.Any()).AnyTimes()
 
 	// Mock time provider
 	base := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
@@ -1931,7 +2064,7 @@ func TestBroadcastOrchestrator_Process_ABTestWinnerPhaseProcessesRemainingRecipi
 		gomock.Any(), // templates
 		gomock.Any(), // email provider
 		gomock.Any(), // timeout
-	).Return(1, 0, nil) // 1 sent, 0 failed
+	).Return(sentResult([]*domain.ContactWithList{recipient}), nil)
 
 	// Mock task state saving
 	mockTaskRepo.EXPECT().SaveState(gomock.Any(), "workspace-123", "task-123", gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
@@ -1946,6 +2079,7 @@ func TestBroadcastOrchestrator_Process_ABTestWinnerPhaseProcessesRemainingRecipi
 	mockLogger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(mockLogger).AnyTimes()
 	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
 	mockLogger.EXPECT().Info(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Warn(gomock.Any()).AnyTimes()
 	mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
 	mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
 
@@ -2024,11 +2158,13 @@ func TestBroadcastOrchestrator_Process_NoRecipientsUpdatesBroadcastStatus(t *tes
 	mockLogger := pkgmocks.NewMockLogger(ctrl)
 	mockTimeProvider := mocks.NewMockTimeProvider(ctrl)
 	mockEventBus := domainmocks.NewMockEventBus(ctrl)
+	mockEventBus.EXPECT().Publish(gomock.Any(), gomock.Any()).AnyTimes()
 
 	// Setup logger expectations
 	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
 	mockLogger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(mockLogger).AnyTimes()
 	mockLogger.EXPECT().Info(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Warn(gomock.Any()).AnyTimes()
 	mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
 	mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
 
@@ -2125,6 +2261,20 @@ func stringPtr(s string) *string {
 	return &s
 }
 
+// sentResult returns the result of a batch that sent the given recipients
+func sentResult(recipients []*domain.ContactWithList) domain.BatchSendResult {
+	var result domain.BatchSendResult
+	for _, recipient := range recipients {
+		result.SentEmails = append(result.SentEmails, recipient.Contact.Email)
+	}
+	return result
+}
+
+// sendAll is a SendBatch stub that sends every recipient of the batch
+func sendAll(_ context.Context, _, _, _, _ string, _ bool, _ string, batch []*domain.ContactWithList, _ map[string]*domain.Template, _ *domain.EmailProvider, _ time.Time) (domain.BatchSendResult, error) {
+	return sentResult(batch), nil
+}
+
 // TestNewBroadcastOrchestrator_DefaultConfig tests that NewBroadcastOrchestrator properly handles nil config
 func TestNewBroadcastOrchestrator_DefaultConfig(t *testing.T) {
 	ctrl := gomock.NewController(t)
@@ -2235,7 +2385,7 @@ func TestBroadcastOrchestrator_ValidateTemplates_EmptyTemplates(t *testing.T) {
 	)
 
 	// Test with empty templates map
-	err := orchestrator.ValidateTemplates(map[string]*domain.Template{})
+	err := orchestrator.ValidateTemplates(map[string]*domain.Template{}, nil, nil)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "no templates provided for validation")
 }
@@ -2280,7 +2430,7 @@ func TestBroadcastOrchestrator_ValidateTemplates_NilTemplate(t *testing.T) {
 	templates := map[string]*domain.Template{
 		"template-1": nil,
 	}
-	err := orchestrator.ValidateTemplates(templates)
+	err := orchestrator.ValidateTemplates(templates, nil, nil)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "template is nil")
 }
@@ -2332,7 +2482,7 @@ func TestBroadcastOrchestrator_ValidateTemplates_MissingEmailConfig(t *testing.T
 			Email: nil, // Missing email config
 		},
 	}
-	err := orchestrator.ValidateTemplates(templates)
+	err := orchestrator.ValidateTemplates(templates, nil, nil)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "template missing email configuration")
 }
@@ -2389,7 +2539,7 @@ func TestBroadcastOrchestrator_ValidateTemplates_MissingContent(t *testing.T) {
 			},
 		},
 	}
-	err := orchestrator.ValidateTemplates(templates)
+	err := orchestrator.ValidateTemplates(templates, nil, nil)
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "template missing content")
 }
@@ -2653,6 +2803,7 @@ func TestBroadcastOrchestrator_Process_PartialBatchCursorUpdate(t *testing.T) {
 	mockLogger := pkgmocks.NewMockLogger(ctrl)
 	mockTimeProvider := mocks.NewMockTimeProvider(ctrl)
 	mockEventBus := domainmocks.NewMockEventBus(ctrl)
+	mockEventBus.EXPECT().Publish(gomock.Any(), gomock.Any()).AnyTimes()
 
 	// Setup logger expectations
 	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
@@ -2763,9 +2914,9 @@ func TestBroadcastOrchestrator_Process_PartialBatchCursorUpdate(t *testing.T) {
 		gomock.Any(),
 		gomock.Any(),
 		gomock.Any(),
-	).DoAndReturn(func(_ context.Context, _, _, _, _ interface{}, _ bool, _ string, _ []*domain.ContactWithList, _, _, _ interface{}) (int, int, error) {
+	).DoAndReturn(func(_ context.Context, _, _, _, _ interface{}, _ bool, _ string, _ []*domain.ContactWithList, _, _, _ interface{}) (domain.BatchSendResult, error) {
 		sendBatchCalled = true
-		return 3, 0, nil // Only 3 sent due to internal timeout
+		return sentResult(recipients1[:3]), nil // Only 3 sent due to internal timeout
 	})
 	// Second call: sends remaining 2 contacts
 	mockMessageSender.EXPECT().SendBatch(
@@ -2780,7 +2931,7 @@ func TestBroadcastOrchestrator_Process_PartialBatchCursorUpdate(t *testing.T) {
 		gomock.Any(),
 		gomock.Any(),
 		gomock.Any(),
-	).Return(2, 0, nil)
+	).Return(sentResult(recipients2), nil)
 
 	// Capture the saved state to verify cursor
 	var savedState *domain.TaskState
@@ -0,0 +1,178 @@
+package broadcast
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type throttledSend struct {
+	at    time.Time
+	count int
+}
+
+type throttleTestSetup struct {
+	*orchestratorFixture
+	orchestrator *BroadcastOrchestrator
+	sends        []throttledSend
+}
+
+// setupThrottleTest prepares a single-template broadcast of totalRecipients recipients on a fake clock,
+// sleeping advances the clock and every SendBatch call is recorded with the time it happened
+func setupThrottleTest(t *testing.T, totalRecipients int, config *Config) *throttleTestSetup {
+	f := newOrchestratorFixture(t)
+	f.config = config
+	f.clock.now = time.Now()
+	f.task.State.SendBroadcast.TotalRecipients = totalRecipients
+	setup := &throttleTestSetup{orchestratorFixture: f}
+
+	f.serveRecipients(numberedRecipients(totalRecipients))
+	f.messageSender.EXPECT().
+		SendBatch(gomock.Any(), "workspace-123", "marketing-provider-id", "secret-key", gomock.Any(), true, "broadcast-123", gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _, _, _ string, _ bool, _ string, batch []*domain.ContactWithList, _ map[string]*domain.Template, _ *domain.EmailProvider, _ time.Time) (domain.BatchSendResult, error) {
+			setup.sends = append(setup.sends, throttledSend{at: f.clock.Now(), count: len(batch)})
+			return sentResult(batch), nil
+		}).AnyTimes()
+
+	setup.orchestrator = f.build()
+	setup.orchestrator.sleep = func(_ context.Context, d time.Duration) error {
+		f.clock.now = f.clock.now.Add(d)
+		return nil
+	}
+
+	return setup
+}
+
+// maxSendsInAnySecond returns the highest number of recipients sent within a one second window
+func maxSendsInAnySecond(sends []throttledSend) int {
+	highest := 0
+	for _, last := range sends {
+		inWindow := 0
+		for _, send := range sends {
+			if !send.at.After(last.at) && last.at.Sub(send.at) < time.Second {
+				inWindow += send.count
+			}
+		}
+		if inWindow > highest {
+			highest = inWindow
+		}
+	}
+	return highest
+}
+
+func throttleTestConfig(maxSendsPerSecond int) *Config {
+	return &Config{
+		FetchBatchSize:           4,
+		MaxProcessTime:           time.Minute,
+		ProgressLogInterval:      5 * time.Second,
+		StatusUpdateRetryBackoff: time.Millisecond,
+		MaxSendsPerSecond:        maxSendsPerSecond,
+	}
+}
+
+func TestBroadcastOrchestrator_Process_Throttling(t *testing.T) {
+	t.Run("sends stay under the configured cap", func(t *testing.T) {
+		setup := setupThrottleTest(t, 25, throttleTestConfig(10))
+		start := setup.clock.Now()
+
+		done, err := setup.orchestrator.Process(context.Background(), setup.task, time.Now().Add(30*time.Second))
+		require.NoError(t, err)
+		assert.True(t, done)
+
+		total := 0
+		for _, send := range setup.sends {
+			assert.LessOrEqual(t, send.count, 4, "batches never exceed the fetch batch size")
+			total += send.count
+		}
+		assert.Equal(t, 25, total)
+		assert.LessOrEqual(t, maxSendsInAnySecond(setup.sends), 10)
+		// 25 recipients at 10 per second need 3 windows
+		assert.GreaterOrEqual(t, setup.clock.Now().Sub(start), 2*time.Second)
+		assert.Equal(t, int64(25), setup.task.State.SendBroadcast.RecipientOffset)
+	})
+
+	t.Run("broadcast override takes precedence", func(t *testing.T) {
+		setup := setupThrottleTest(t, 12, throttleTestConfig(10))
+		setup.broadcast.Schedule.MaxSendsPerSecond = 3
+
+		done, err := setup.orchestrator.Process(context.Background(), setup.task, time.Now().Add(30*time.Second))
+		require.NoError(t, err)
+		assert.True(t, done)
+
+		assert.LessOrEqual(t, maxSendsInAnySecond(setup.sends), 3)
+		assert.Len(t, setup.sends, 4)
+	})
+
+	t.Run("resumed broadcast does not burst", func(t *testing.T) {
+		setup := setupThrottleTest(t, 10, throttleTestConfig(10))
+		start := setup.clock.Now()
+		// A previous run sent a full second worth of messages just before restarting
+		setup.task.State.SendBroadcast.RecentSends = []domain.ThrottledSend{
+			{At: start.Add(-400 * time.Millisecond), Count: 10},
+		}
+
+		done, err := setup.orchestrator.Process(context.Background(), setup.task, time.Now().Add(30*time.Second))
+		require.NoError(t, err)
+		assert.True(t, done)
+
+		require.NotEmpty(t, setup.sends)
+		assert.False(t, setup.sends[0].at.Before(start.Add(600*time.Millisecond)), "first send waits for the previous window")
+	})
+
+	t.Run("stops when the wait exceeds the max process time", func(t *testing.T) {
+		config := throttleTestConfig(4)
+		config.MaxProcessTime = 1500 * time.Millisecond
+		setup := setupThrottleTest(t, 20, config)
+
+		done, err := setup.orchestrator.Process(context.Background(), setup.task, time.Now().Add(30*time.Second))
+		require.NoError(t, err)
+		assert.False(t, done)
+
+		// Sends at 0s and 1s, the next window opens after the max process time
+		assert.Len(t, setup.sends, 2)
+		state := setup.task.State.SendBroadcast
+		assert.Equal(t, int64(8), state.RecipientOffset)
+		assert.Equal(t, "user007@example.com", state.LastProcessedEmail)
+		assert.NotEmpty(t, state.RecentSends, "the throttle window is persisted for the next run")
+	})
+
+	t.Run("disabled throttling never sleeps", func(t *testing.T) {
+		setup := setupThrottleTest(t, 25, throttleTestConfig(0))
+		start := setup.clock.Now()
+
+		done, err := setup.orchestrator.Process(context.Background(), setup.task, time.Now().Add(30*time.Second))
+		require.NoError(t, err)
+		assert.True(t, done)
+		assert.Equal(t, start, setup.clock.Now())
+		assert.Nil(t, setup.task.State.SendBroadcast.RecentSends)
+	})
+}
+
+func TestSendAllowance(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	allowance, wait := sendAllowance(nil, 10, now)
+	assert.Equal(t, 10, allowance)
+	assert.Zero(t, wait)
+
+	recent := []domain.ThrottledSend{
+		{At: now.Add(-1500 * time.Millisecond), Count: 10}, // out of the window
+		{At: now.Add(-800 * time.Millisecond), Count: 4},
+		{At: now.Add(-200 * time.Millisecond), Count: 4},
+	}
+	allowance, wait = sendAllowance(recent, 10, now)
+	assert.Equal(t, 2, allowance)
+	assert.Zero(t, wait)
+
+	allowance, wait = sendAllowance(recent, 8, now)
+	assert.Equal(t, 0, allowance)
+	assert.Equal(t, 200*time.Millisecond, wait)
+
+	assert.Len(t, pruneRecentSends(recent, now), 2)
+	assert.Nil(t, pruneRecentSends(recent, now.Add(time.Second)))
+}
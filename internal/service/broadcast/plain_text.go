@@ -0,0 +1,166 @@
+package broadcast
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/pkg/notifuse_mjml"
+	"golang.org/x/net/html"
+)
+
+var (
+	plainTextWhitespace = regexp.MustCompile(`[\s\x{00a0}]+`)
+	plainTextSpaces     = regexp.MustCompile(`[ \t]+`)
+	plainTextNewlines   = regexp.MustCompile(`\n{3,}`)
+)
+
+// renderPlainText renders the body of a plain text only broadcast: the template text when it has one,
+// otherwise the text of the template HTML compiled without open or click tracking.
+// The unsubscribe link is appended when the text does not already include it.
+func renderPlainText(
+	template *domain.Template,
+	data map[string]interface{},
+	trackingSettings notifuse_mjml.TrackingSettings,
+	compile func(notifuse_mjml.CompileTemplateRequest) (*notifuse_mjml.CompileTemplateResponse, error),
+) (string, error) {
+	var text string
+	if template.Email.Text != nil && strings.TrimSpace(*template.Email.Text) != "" {
+		rendered, err := notifuse_mjml.ProcessLiquidTemplate(*template.Email.Text, data, "email_text")
+		if err != nil {
+			return "", fmt.Errorf("failed to process text: %w", err)
+		}
+		text = strings.TrimSpace(rendered)
+	} else {
+		// Links are kept as they are, the text part has no tracking
+		trackingSettings.SetTracking(false, false)
+		trackingSettings.LinkShortener = nil
+
+		compiledTemplate, err := compile(notifuse_mjml.CompileTemplateRequest{
+			WorkspaceID:      trackingSettings.WorkspaceID,
+			MessageID:        trackingSettings.MessageID,
+			VisualEditorTree: template.Email.VisualEditorTree,
+			TemplateData:     data,
+			TrackingSettings: trackingSettings,
+			RawMergeFields:   template.Email.RawMergeFields,
+		})
+		if err != nil {
+			return "", fmt.Errorf("failed to compile template: %w", err)
+		}
+		if !compiledTemplate.Success || compiledTemplate.HTML == nil {
+			errMsg := "template compilation failed"
+			if compiledTemplate.Error != nil {
+				errMsg = compiledTemplate.Error.Message
+			}
+			return "", fmt.Errorf("%s", errMsg)
+		}
+
+		text, err = htmlToPlainText(*compiledTemplate.HTML)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	if unsubscribeURL, ok := data["unsubscribe_url"].(string); ok && unsubscribeURL != "" && !strings.Contains(text, unsubscribeURL) {
+		text = strings.TrimSpace(text + "\n\nUnsubscribe: " + unsubscribeURL)
+	}
+
+	return text, nil
+}
+
+// htmlToPlainText extracts the readable text of an email HTML body. Block elements start new lines,
+// links are followed by their URL and images are replaced by their alt text.
+func htmlToPlainText(content string) (string, error) {
+	doc, err := html.Parse(strings.NewReader(content))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse HTML: %w", err)
+	}
+
+	var sb strings.Builder
+	writePlainText(&sb, doc)
+
+	lines := strings.Split(sb.String(), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(plainTextSpaces.ReplaceAllString(line, " "))
+	}
+	text := plainTextNewlines.ReplaceAllString(strings.Join(lines, "\n"), "\n\n")
+
+	return strings.TrimSpace(text), nil
+}
+
+// writePlainText writes the text of a node and its children
+func writePlainText(sb *strings.Builder, node *html.Node) {
+	switch node.Type {
+	case html.TextNode:
+		// Line breaks in the source are layout, only block elements start new lines
+		sb.WriteString(plainTextWhitespace.ReplaceAllString(node.Data, " "))
+		return
+	case html.ElementNode:
+		// Hidden elements such as the preheader are not part of the message
+		if strings.Contains(strings.ReplaceAll(plainTextAttr(node, "style"), " ", ""), "display:none") {
+			return
+		}
+
+		switch node.Data {
+		case "head", "style", "script", "title":
+			return
+		case "br":
+			sb.WriteString("\n")
+			return
+		case "hr":
+			sb.WriteString("\n----------\n")
+			return
+		case "img":
+			if alt := strings.TrimSpace(plainTextAttr(node, "alt")); alt != "" {
+				sb.WriteString(alt + " ")
+			}
+			return
+		case "a":
+			var link strings.Builder
+			for child := node.FirstChild; child != nil; child = child.NextSibling {
+				writePlainText(&link, child)
+			}
+			label := strings.TrimSpace(link.String())
+			href := strings.TrimSpace(plainTextAttr(node, "href"))
+			sb.WriteString(label)
+			if href != "" && !strings.HasPrefix(href, "#") && href != label && strings.TrimPrefix(href, "mailto:") != label {
+				if label != "" {
+					sb.WriteString(" ")
+				}
+				sb.WriteString("(" + href + ")")
+			}
+			sb.WriteString(" ")
+			return
+		case "li":
+			sb.WriteString("\n- ")
+		case "p", "h1", "h2", "h3", "h4", "h5", "h6", "table", "ul", "ol", "blockquote":
+			sb.WriteString("\n\n")
+		case "div", "tr", "td", "section":
+			sb.WriteString("\n")
+		}
+	}
+
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		writePlainText(sb, child)
+	}
+
+	if node.Type == html.ElementNode {
+		switch node.Data {
+		case "p", "h1", "h2", "h3", "h4", "h5", "h6", "table", "ul", "ol", "blockquote":
+			sb.WriteString("\n\n")
+		case "div", "tr", "td", "section":
+			sb.WriteString("\n")
+		}
+	}
+}
+
+// plainTextAttr returns the value of an attribute of an element
+func plainTextAttr(node *html.Node, name string) string {
+	for _, attr := range node.Attr {
+		if attr.Key == name {
+			return attr.Val
+		}
+	}
+	return ""
+}
@@ -0,0 +1,91 @@
+package broadcast
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/pkg/notifuse_mjml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHtmlToPlainText(t *testing.T) {
+	content := `<!doctype html><html><head><title>Newsletter</title><style>p { color: red; }</style></head>
+<body>
+	<div style="display: none;">Preheader text</div>
+	<h1>Hello   John</h1>
+	<p>Read our
+		<a href="https://example.com/blog">latest post</a> today.</p>
+	<ul><li>First</li><li>Second</li></ul>
+	<img src="https://example.com/logo.png" alt="Logo">
+	<p>Line one<br>Line two</p>
+	<a href="https://example.com">https://example.com</a>
+</body></html>`
+
+	text, err := htmlToPlainText(content)
+	require.NoError(t, err)
+
+	expected := "Hello John\n\n" +
+		"Read our latest post (https://example.com/blog) today.\n\n" +
+		"- First\n" +
+		"- Second\n\n" +
+		"Logo\n\n" +
+		"Line one\n" +
+		"Line two\n\n" +
+		"https://example.com"
+	assert.Equal(t, expected, text)
+	assert.NotContains(t, text, "Preheader")
+	assert.NotContains(t, text, "Newsletter")
+}
+
+func TestRenderPlainText(t *testing.T) {
+	data := map[string]interface{}{
+		"contact":         map[string]interface{}{"first_name": "John"},
+		"unsubscribe_url": "https://example.com/unsubscribe",
+	}
+
+	t.Run("renders the template text", func(t *testing.T) {
+		text := "Hi {{ contact.first_name }}"
+		template := &domain.Template{Email: &domain.EmailTemplate{Text: &text}}
+
+		result, err := renderPlainText(template, data, notifuse_mjml.TrackingSettings{}, func(notifuse_mjml.CompileTemplateRequest) (*notifuse_mjml.CompileTemplateResponse, error) {
+			t.Fatal("the template should not be compiled")
+			return nil, nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "Hi John\n\nUnsubscribe: https://example.com/unsubscribe", result)
+	})
+
+	t.Run("does not repeat the unsubscribe link", func(t *testing.T) {
+		text := "Hi {{ contact.first_name }}, unsubscribe at {{ unsubscribe_url }}"
+		template := &domain.Template{Email: &domain.EmailTemplate{Text: &text}}
+
+		result, err := renderPlainText(template, data, notifuse_mjml.TrackingSettings{}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "Hi John, unsubscribe at https://example.com/unsubscribe", result)
+	})
+
+	t.Run("converts the HTML compiled without tracking", func(t *testing.T) {
+		template := &domain.Template{Email: &domain.EmailTemplate{}}
+		trackingSettings := notifuse_mjml.TrackingSettings{EnableTracking: true, Endpoint: "https://track.example.com"}
+
+		html := `<html><body><p>Hello <a href="https://example.com">there</a></p></body></html>`
+		result, err := renderPlainText(template, data, trackingSettings, func(req notifuse_mjml.CompileTemplateRequest) (*notifuse_mjml.CompileTemplateResponse, error) {
+			assert.False(t, req.TrackingSettings.EnableTracking)
+			return &notifuse_mjml.CompileTemplateResponse{Success: true, HTML: &html}, nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "Hello there (https://example.com)\n\nUnsubscribe: https://example.com/unsubscribe", result)
+	})
+
+	t.Run("compilation error", func(t *testing.T) {
+		template := &domain.Template{Email: &domain.EmailTemplate{}}
+
+		_, err := renderPlainText(template, data, notifuse_mjml.TrackingSettings{}, func(notifuse_mjml.CompileTemplateRequest) (*notifuse_mjml.CompileTemplateResponse, error) {
+			return nil, errors.New("compile error")
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to compile template")
+	})
+}
@@ -3,6 +3,7 @@ package broadcast
 import (
 	"context"
 	crand "crypto/rand"
+	"errors"
 	"fmt"
 	"math/big"
 	"time"
@@ -24,8 +25,18 @@ type queueMessageSender struct {
 	logger             logger.Logger
 	config             *Config
 	apiEndpoint        string
+	linkShortener      domain.LinkShortenerService
+	// workspaceRepo loads the unsubscribe link requirement and tracking domain of the workspace, nil uses the defaults
+	workspaceRepo domain.WorkspaceRepository
+	// compileTemplate renders the email body, swapped in tests to simulate slow renders
+	compileTemplate func(notifuse_mjml.CompileTemplateRequest) (*notifuse_mjml.CompileTemplateResponse, error)
+	// renderCache keeps the compiled HTML of each template version, nil compiles every recipient
+	renderCache *renderCache
 }
 
+// errRenderTimeout is returned when rendering a recipient's message exceeds Config.RenderTimeout
+var errRenderTimeout = errors.New("template render timed out")
+
 // NewQueueMessageSender creates a new message sender that enqueues to the email queue
 func NewQueueMessageSender(
 	queueRepo domain.EmailQueueRepository,
@@ -48,6 +59,8 @@ func NewQueueMessageSender(
 		logger:             logger,
 		config:             config,
 		apiEndpoint:        apiEndpoint,
+		compileTemplate:    notifuse_mjml.CompileTemplate,
+		renderCache:        newRenderCache(),
 	}
 }
 
@@ -65,11 +78,21 @@ func (s *queueMessageSender) SendToRecipient(
 	emailProvider *domain.EmailProvider,
 	timeoutAt time.Time,
 ) error {
+	settings, err := s.workspaceSettings(ctx, workspaceID)
+	if err != nil {
+		return err
+	}
+	trackingEndpoint := settings.TrackingEndpoint()
+
 	// Build the email payload
-	entry, err := s.buildQueueEntry(ctx, workspaceID, integrationID, trackingEnabled, broadcast, messageID, email, template, data, emailProvider)
+	linkShortener := domain.WorkspaceLinkShortener(ctx, s.linkShortener, workspaceID)
+	entry, err := s.buildQueueEntryWithTimeout(ctx, workspaceID, integrationID, trackingEnabled, broadcast, messageID, email, template, data, emailProvider, linkShortener, trackingEndpoint)
 	if err != nil {
 		return err
 	}
+	if settings.RequireUnsubscribeLink {
+		s.ensureUnsubscribeLink(workspaceID, broadcast.ID, entry, template, data)
+	}
 
 	// Enqueue the email
 	if err := s.queueRepo.Enqueue(ctx, workspaceID, []*domain.EmailQueueEntry{entry}); err != nil {
@@ -98,20 +121,78 @@ func (s *queueMessageSender) SendBatch(
 	templates map[string]*domain.Template,
 	emailProvider *domain.EmailProvider,
 	timeoutAt time.Time,
-) (sent int, failed int, err error) {
+) (result domain.BatchSendResult, err error) {
 	if len(recipients) == 0 {
-		return 0, 0, nil
+		return result, nil
 	}
 
+	_, entries, buildFailures, err := s.buildBatch(ctx, workspaceID, integrationID, workspaceSecretKey, endpoint, trackingEnabled, broadcastID, recipients, templates, emailProvider, timeoutAt)
+	if err != nil {
+		return failAll(recipients), err
+	}
+	result.FailedEmails = buildFailures
+
+	if len(entries) == 0 {
+		return result, nil
+	}
+
+	// Enqueue all entries in batch
+	if err := s.queueRepo.Enqueue(ctx, workspaceID, entries); err != nil {
+		s.logger.WithFields(map[string]interface{}{
+			"broadcast_id": broadcastID,
+			"workspace_id": workspaceID,
+			"batch_size":   len(entries),
+			"error":        err.Error(),
+		}).Error("Failed to enqueue batch")
+		// Nothing was enqueued, the recipients are left out of the result to be enqueued again
+		return result, NewBroadcastError(ErrCodeSendFailed, "failed to enqueue batch", true, err)
+	}
+	for _, entry := range entries {
+		result.SentEmails = append(result.SentEmails, entry.ContactEmail)
+	}
+
+	renderStats := s.GetRenderCacheStats()
+	s.logger.WithFields(map[string]interface{}{
+		"broadcast_id":        broadcastID,
+		"workspace_id":        workspaceID,
+		"enqueued":            result.Sent(),
+		"build_errors":        len(buildFailures),
+		"render_cache_hits":   renderStats.Hits,
+		"render_cache_misses": renderStats.Misses,
+	}).Debug("Batch enqueued successfully")
+
+	// Report enqueued as "sent" since from the orchestrator's perspective, the job is done
+	return result, nil
+}
+
+// buildBatch renders the messages of a batch of recipients into queue entries.
+// Recipients whose message cannot be built are listed in buildFailures and left out of entries.
+func (s *queueMessageSender) buildBatch(
+	ctx context.Context,
+	workspaceID string,
+	integrationID string,
+	workspaceSecretKey string,
+	endpoint string,
+	trackingEnabled bool,
+	broadcastID string,
+	recipients []*domain.ContactWithList,
+	templates map[string]*domain.Template,
+	emailProvider *domain.EmailProvider,
+	timeoutAt time.Time,
+) (broadcast *domain.Broadcast, entries []*domain.EmailQueueEntry, buildFailures []string, err error) {
 	// Get broadcast for context
-	broadcast, err := s.broadcastRepo.GetBroadcast(ctx, workspaceID, broadcastID)
+	broadcast, err = s.broadcastRepo.GetBroadcast(ctx, workspaceID, broadcastID)
 	if err != nil {
-		return 0, len(recipients), fmt.Errorf("failed to get broadcast: %w", err)
+		return nil, nil, nil, fmt.Errorf("failed to get broadcast: %w", err)
 	}
 
-	// Build queue entries
-	var entries []*domain.EmailQueueEntry
-	var buildErrors int
+	// Shared by the whole batch so the workspace settings are loaded once
+	linkShortener := domain.WorkspaceLinkShortener(ctx, s.linkShortener, workspaceID)
+	settings, err := s.workspaceSettings(ctx, workspaceID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	trackingEndpoint := settings.TrackingEndpoint()
 
 	for _, recipient := range recipients {
 		// Check timeout
@@ -124,35 +205,29 @@ func (s *queueMessageSender) SendBatch(
 		}
 
 		// Select template (for A/B testing, use first template or random selection)
-		template := s.selectTemplate(templates, broadcast)
+		template := s.selectTemplate(templates, broadcast, recipient.ListID)
 		if template == nil {
-			buildErrors++
+			buildFailures = append(buildFailures, recipient.Contact.Email)
 			continue
 		}
+		template = template.ForContact(recipient.Contact)
 
 		// Generate message ID
 		messageID := fmt.Sprintf("%s_%s", workspaceID, uuid.New().String())
 
-		// Ensure UTM parameters object is present
-		if broadcast.UTMParameters == nil {
-			broadcast.UTMParameters = &domain.UTMParameters{}
-		}
-
-		if broadcast.UTMParameters.Content == "" {
-			broadcast.UTMParameters.Content = template.ID
-		}
-
 		// Build tracking settings for BuildTemplateData
+		utm := utmParameters(broadcast, template)
 		trackingSettings := notifuse_mjml.TrackingSettings{
-			Endpoint:       endpoint,
-			EnableTracking: trackingEnabled,
-			UTMSource:      broadcast.UTMParameters.Source,
-			UTMMedium:      broadcast.UTMParameters.Medium,
-			UTMCampaign:    broadcast.UTMParameters.Campaign,
-			UTMContent:     broadcast.UTMParameters.Content,
-			UTMTerm:        broadcast.UTMParameters.Term,
-			WorkspaceID:    workspaceID,
-			MessageID:      messageID,
+			Endpoint:         endpoint,
+			TrackingEndpoint: trackingEndpoint,
+			EnableTracking:   trackingEnabled,
+			UTMSource:        utm.Source,
+			UTMMedium:        utm.Medium,
+			UTMCampaign:      utm.Campaign,
+			UTMContent:       utm.Content,
+			UTMTerm:          utm.Term,
+			WorkspaceID:      workspaceID,
+			MessageID:        messageID,
 		}
 
 		// Build template data with all system variables (unsubscribe_url, notification_center_url, etc.)
@@ -172,12 +247,35 @@ func (s *queueMessageSender) SendBatch(
 				"recipient":    recipient.Contact.Email,
 				"error":        err.Error(),
 			}).Warn("Failed to build template data")
-			buildErrors++
+			buildFailures = append(buildFailures, recipient.Contact.Email)
 			continue
 		}
 
 		// Build queue entry
-		entry, err := s.buildQueueEntry(ctx, workspaceID, integrationID, trackingEnabled, broadcast, messageID, recipient.Contact.Email, template, data, emailProvider)
+		entry, err := s.buildQueueEntryWithTimeout(ctx, workspaceID, integrationID, trackingEnabled, broadcast, messageID, recipient.Contact.Email, template, data, emailProvider, linkShortener, trackingEndpoint)
+		if errors.Is(err, errRenderTimeout) {
+			s.logger.WithFields(map[string]interface{}{
+				"broadcast_id":   broadcastID,
+				"workspace_id":   workspaceID,
+				"recipient":      recipient.Contact.Email,
+				"template_id":    template.ID,
+				"render_timeout": s.config.RenderTimeout.String(),
+			}).Warn("Template render timed out, skipping recipient")
+			buildFailures = append(buildFailures, recipient.Contact.Email)
+			continue
+		}
+		var personalizationErr *domain.ErrRequiredMergeFieldsEmpty
+		if errors.As(err, &personalizationErr) {
+			s.logger.WithFields(map[string]interface{}{
+				"broadcast_id": broadcastID,
+				"workspace_id": workspaceID,
+				"recipient":    recipient.Contact.Email,
+				"template_id":  template.ID,
+				"fields":       personalizationErr.Fields,
+			}).Info("Skipping recipient with empty required merge fields")
+			buildFailures = append(buildFailures, recipient.Contact.Email)
+			continue
+		}
 		if err != nil {
 			s.logger.WithFields(map[string]interface{}{
 				"broadcast_id": broadcastID,
@@ -185,41 +283,62 @@ func (s *queueMessageSender) SendBatch(
 				"recipient":    recipient.Contact.Email,
 				"error":        err.Error(),
 			}).Warn("Failed to build queue entry")
-			buildErrors++
+			buildFailures = append(buildFailures, recipient.Contact.Email)
 			continue
 		}
 
+		if settings.RequireUnsubscribeLink {
+			s.ensureUnsubscribeLink(workspaceID, broadcastID, entry, template, data)
+		}
+
+		// The members of a multi-list audience belong to the list they are sent as a member of
+		if recipient.ListID != "" {
+			entry.Payload.ListID = recipient.ListID
+		}
+		// Quiet hours are evaluated in the recipient's local time at delivery
+		if recipient.Contact.Timezone != nil && !recipient.Contact.Timezone.IsNull {
+			entry.Payload.RecipientTimezone = recipient.Contact.Timezone.String
+		}
+		// Deliveries deferred to the optimal send time wait in the queue until then
+		entry.NextRetryAt = recipient.SendAt
+
 		entries = append(entries, entry)
 	}
 
-	if len(entries) == 0 {
-		return 0, buildErrors, nil
-	}
+	return broadcast, entries, buildFailures, nil
+}
 
-	// Enqueue all entries in batch
-	if err := s.queueRepo.Enqueue(ctx, workspaceID, entries); err != nil {
-		s.logger.WithFields(map[string]interface{}{
-			"broadcast_id": broadcastID,
-			"workspace_id": workspaceID,
-			"batch_size":   len(entries),
-			"error":        err.Error(),
-		}).Error("Failed to enqueue batch")
-		return 0, len(recipients), NewBroadcastError(ErrCodeSendFailed, "failed to enqueue batch", true, err)
+// workspaceSettings loads the settings of the workspace that apply to its broadcast emails,
+// the defaults when the sender has no workspace repository
+func (s *queueMessageSender) workspaceSettings(ctx context.Context, workspaceID string) (*domain.WorkspaceSettings, error) {
+	if s.workspaceRepo == nil {
+		return &domain.WorkspaceSettings{}, nil
 	}
+	workspace, err := s.workspaceRepo.GetByID(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace: %w", err)
+	}
+	return &workspace.Settings, nil
+}
 
-	s.logger.WithFields(map[string]interface{}{
-		"broadcast_id": broadcastID,
-		"workspace_id": workspaceID,
-		"enqueued":     len(entries),
-		"build_errors": buildErrors,
-	}).Debug("Batch enqueued successfully")
-
-	// Return enqueued as "sent" since from the orchestrator's perspective, the job is done
-	return len(entries), buildErrors, nil
+// utmParameters returns the UTM parameters of a message, whose content defaults to the template ID.
+// The broadcast is left untouched: it is shared with the renders still running after their timeout.
+func utmParameters(broadcast *domain.Broadcast, template *domain.Template) domain.UTMParameters {
+	var utm domain.UTMParameters
+	if broadcast.UTMParameters != nil {
+		utm = *broadcast.UTMParameters
+	}
+	if utm.Content == "" {
+		utm.Content = template.ID
+	}
+	return utm
 }
 
-// buildQueueEntry creates an EmailQueueEntry for a recipient
-func (s *queueMessageSender) buildQueueEntry(
+// buildQueueEntryWithTimeout runs buildQueueEntry bounded by Config.RenderTimeout so that a pathological
+// template fails its recipient instead of blocking the batch. A render that times out keeps running in
+// the background until it returns, its result is discarded. buildQueueEntry must therefore not write
+// to the broadcast or the template, which the next recipients read.
+func (s *queueMessageSender) buildQueueEntryWithTimeout(
 	ctx context.Context,
 	workspaceID string,
 	integrationID string,
@@ -230,28 +349,69 @@ func (s *queueMessageSender) buildQueueEntry(
 	template *domain.Template,
 	data map[string]interface{},
 	emailProvider *domain.EmailProvider,
+	linkShortener notifuse_mjml.LinkShortener,
+	trackingEndpoint string,
 ) (*domain.EmailQueueEntry, error) {
-	// Ensure UTM parameters object is present
-	if broadcast.UTMParameters == nil {
-		broadcast.UTMParameters = &domain.UTMParameters{}
+	if s.config.RenderTimeout <= 0 {
+		return s.buildQueueEntry(ctx, workspaceID, integrationID, trackingEnabled, broadcast, messageID, email, template, data, emailProvider, linkShortener, trackingEndpoint)
 	}
 
-	if broadcast.UTMParameters.Content == "" {
-		broadcast.UTMParameters.Content = template.ID
+	type buildResult struct {
+		entry *domain.EmailQueueEntry
+		err   error
 	}
+	// Buffered so the render goroutine never blocks once nobody is waiting for it
+	done := make(chan buildResult, 1)
+	go func() {
+		entry, err := s.buildQueueEntry(ctx, workspaceID, integrationID, trackingEnabled, broadcast, messageID, email, template, data, emailProvider, linkShortener, trackingEndpoint)
+		done <- buildResult{entry: entry, err: err}
+	}()
+
+	timer := time.NewTimer(s.config.RenderTimeout)
+	defer timer.Stop()
+
+	select {
+	case result := <-done:
+		return result.entry, result.err
+	case <-timer.C:
+		return nil, fmt.Errorf("%w after %s", errRenderTimeout, s.config.RenderTimeout)
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
 
+// buildQueueEntry creates an EmailQueueEntry for a recipient
+func (s *queueMessageSender) buildQueueEntry(
+	ctx context.Context,
+	workspaceID string,
+	integrationID string,
+	trackingEnabled bool,
+	broadcast *domain.Broadcast,
+	messageID string,
+	email string,
+	template *domain.Template,
+	data map[string]interface{},
+	emailProvider *domain.EmailProvider,
+	linkShortener notifuse_mjml.LinkShortener,
+	trackingEndpoint string,
+) (*domain.EmailQueueEntry, error) {
 	// Build tracking settings
+	utm := utmParameters(broadcast, template)
 	trackingSettings := notifuse_mjml.TrackingSettings{
-		Endpoint:       s.apiEndpoint,
-		EnableTracking: trackingEnabled,
-		UTMSource:      broadcast.UTMParameters.Source,
-		UTMMedium:      broadcast.UTMParameters.Medium,
-		UTMCampaign:    broadcast.UTMParameters.Campaign,
-		UTMContent:     broadcast.UTMParameters.Content,
-		UTMTerm:        broadcast.UTMParameters.Term,
-		WorkspaceID:    workspaceID,
-		MessageID:      messageID,
+		Endpoint:         s.apiEndpoint,
+		TrackingEndpoint: trackingEndpoint,
+		EnableTracking:   trackingEnabled,
+		UTMSource:        utm.Source,
+		UTMMedium:        utm.Medium,
+		UTMCampaign:      utm.Campaign,
+		UTMContent:       utm.Content,
+		UTMTerm:          utm.Term,
+		WorkspaceID:      workspaceID,
+		MessageID:        messageID,
+		LinkShortener:    linkShortener,
 	}
+	// The template may enable or disable open and click tracking on its own
+	template.Email.ApplyTrackingOverrides(&trackingSettings)
 
 	// Get sender (use template's sender ID if specified, otherwise default)
 	sender := emailProvider.GetSender(template.Email.SenderID)
@@ -259,27 +419,38 @@ func (s *queueMessageSender) buildQueueEntry(
 		return nil, fmt.Errorf("no sender configured for email provider")
 	}
 
-	// Compile template with the provided data
-	compiledTemplate, err := notifuse_mjml.CompileTemplate(
-		notifuse_mjml.CompileTemplateRequest{
-			WorkspaceID:      workspaceID,
-			MessageID:        messageID,
-			VisualEditorTree: template.Email.VisualEditorTree,
-			TemplateData:     data,
-			TrackingSettings: trackingSettings,
-		},
-	)
-	if err != nil {
-		return nil, fmt.Errorf("failed to compile template: %w", err)
+	// Required merge fields either skip the recipient or get their fallback when empty
+	if template.Email.Personalization != nil {
+		fallbacks, err := template.Email.Personalization.Apply(data)
+		if err != nil {
+			return nil, err
+		}
+		if len(fallbacks) > 0 {
+			s.logger.WithFields(map[string]interface{}{
+				"broadcast_id": broadcast.ID,
+				"workspace_id": workspaceID,
+				"recipient":    email,
+				"fields":       fallbacks,
+			}).Debug("Applied personalization fallbacks")
+		}
 	}
-	if !compiledTemplate.Success || compiledTemplate.HTML == nil {
-		errMsg := "template compilation failed"
-		if compiledTemplate.Error != nil {
-			errMsg = compiledTemplate.Error.Message
+
+	// Plain text only broadcasts are sent without HTML part, hence without tracking pixel
+	var htmlContent, textContent string
+	if broadcast.PlainTextOnly {
+		text, err := renderPlainText(template, data, trackingSettings, s.compileTemplate)
+		if err != nil {
+			return nil, err
 		}
-		return nil, fmt.Errorf("%s", errMsg)
+		textContent = text
+	} else {
+		// Compile template with the provided data
+		html, err := s.renderHTML(workspaceID, messageID, template, data, trackingSettings)
+		if err != nil {
+			return nil, err
+		}
+		htmlContent = html
 	}
-	htmlContent := *compiledTemplate.HTML
 
 	// Process subject line through Liquid templating
 	subject, err := notifuse_mjml.ProcessLiquidTemplate(
@@ -308,16 +479,20 @@ func (s *queueMessageSender) buildQueueEntry(
 			FromName:           sender.Name,
 			Subject:            subject,
 			HTMLContent:        htmlContent,
+			TextContent:        textContent,
 			RateLimitPerMinute: emailProvider.RateLimitPerMinute,
 			EmailOptions:       domain.EmailOptions{},
 			TemplateVersion:    int(template.Version),
 			ListID:             broadcast.Audience.List,
 			TemplateData:       data, // Store template data for message history
+			IgnoreQuietHours:   broadcast.Schedule.IgnoreQuietHours,
+			SendCutoffAt:       broadcast.Schedule.SendCutoffAt,
 		},
 		MaxAttempts: 3,
 		CreatedAt:   time.Now().UTC(),
 		UpdatedAt:   time.Now().UTC(),
 	}
+	template.Email.ApplyProviderTags(&entry.Payload.EmailOptions)
 
 	// Extract List-Unsubscribe URL from template data for RFC-8058 compliance (broadcast emails only)
 	if unsubscribeURL, ok := data["oneclick_unsubscribe_url"].(string); ok && unsubscribeURL != "" {
@@ -328,12 +503,18 @@ func (s *queueMessageSender) buildQueueEntry(
 }
 
 // selectTemplate selects a template for sending
-// For A/B testing, this uses random selection; for normal sends, uses the first template
-func (s *queueMessageSender) selectTemplate(templates map[string]*domain.Template, broadcast *domain.Broadcast) *domain.Template {
+// For A/B testing, this uses random selection; for normal sends, uses the first template,
+// or the template of the list of the recipient when the audience overrides it
+func (s *queueMessageSender) selectTemplate(templates map[string]*domain.Template, broadcast *domain.Broadcast, listID string) *domain.Template {
 	if len(templates) == 0 {
 		return nil
 	}
 
+	// List templates are not combined with A/B testing, the broadcast template is the first variation
+	if len(broadcast.Audience.ListTemplates) > 0 && len(broadcast.TestSettings.Variations) > 0 {
+		return templates[broadcast.Audience.TemplateForList(listID, broadcast.TestSettings.Variations[0].TemplateID)]
+	}
+
 	// If only one template, use it
 	if len(templates) == 1 {
 		for _, t := range templates {
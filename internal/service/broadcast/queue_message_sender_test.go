@@ -3,6 +3,7 @@ package broadcast
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -195,6 +196,78 @@ func TestQueueMessageSender_SendToRecipient(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("applies template open and click tracking", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockQueueRepo := mocks.NewMockEmailQueueRepository(ctrl)
+		mockLogger := pkgmocks.NewMockLogger(ctrl)
+		mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+		mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
+
+		emailSender := domain.NewEmailSender("sender@example.com", "Test Sender")
+		emailProvider := &domain.EmailProvider{
+			Kind:    domain.EmailProviderKindSMTP,
+			Senders: []domain.EmailSender{emailSender},
+		}
+
+		broadcast := &domain.Broadcast{
+			ID:            "broadcast-1",
+			WorkspaceID:   "workspace-1",
+			UTMParameters: &domain.UTMParameters{},
+		}
+
+		// Workspace defaults: open tracking off, click tracking on
+		openTracking, clickTracking := false, true
+		template := (&domain.Template{
+			ID: "template-1",
+			Email: &domain.EmailTemplate{
+				SenderID:         emailSender.ID,
+				Subject:          "Test Subject",
+				VisualEditorTree: createQueueValidTestTree(createQueueTestTextBlock("txt1", `<a href="https://example.com/offer">Offer</a>`)),
+			},
+		}).WithTrackingDefaults(&domain.WorkspaceSettings{
+			OpenTrackingDefault:  &openTracking,
+			ClickTrackingDefault: &clickTracking,
+		})
+
+		var enqueued []*domain.EmailQueueEntry
+		mockQueueRepo.EXPECT().Enqueue(gomock.Any(), "workspace-1", gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, entries []*domain.EmailQueueEntry) error {
+				enqueued = entries
+				return nil
+			})
+
+		sender := NewQueueMessageSender(
+			mockQueueRepo,
+			mocks.NewMockBroadcastRepository(ctrl),
+			mocks.NewMockMessageHistoryRepository(ctrl),
+			mocks.NewMockTemplateRepository(ctrl),
+			mockLogger,
+			nil,
+			"https://api.example.com",
+		)
+
+		err := sender.SendToRecipient(
+			context.Background(),
+			"workspace-1",
+			"integration-1",
+			false,
+			broadcast,
+			"msg-1",
+			"recipient@example.com",
+			template,
+			map[string]interface{}{},
+			emailProvider,
+			time.Now().Add(5*time.Minute),
+		)
+
+		require.NoError(t, err)
+		require.Len(t, enqueued, 1)
+		assert.Contains(t, enqueued[0].Payload.HTMLContent, "https://api.example.com/visit?mid=msg-1")
+		assert.NotContains(t, enqueued[0].Payload.HTMLContent, "/opens?")
+	})
+
 	t.Run("returns error on enqueue failure", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
 		defer ctrl.Finish()
@@ -384,7 +457,7 @@ func TestQueueMessageSender_SendBatch(t *testing.T) {
 			"https://api.example.com",
 		)
 
-		sent, failed, err := sender.SendBatch(
+		result, err := sender.SendBatch(
 			context.Background(),
 			"workspace-1",
 			"integration-1",
@@ -399,8 +472,73 @@ func TestQueueMessageSender_SendBatch(t *testing.T) {
 		)
 
 		assert.NoError(t, err)
-		assert.Equal(t, 2, sent)
-		assert.Equal(t, 0, failed)
+		assert.Equal(t, 2, result.Sent())
+		assert.Equal(t, 0, result.Failed())
+	})
+
+	t.Run("defers entries of recipients with a send time", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockQueueRepo := mocks.NewMockEmailQueueRepository(ctrl)
+		mockBroadcastRepo := mocks.NewMockBroadcastRepository(ctrl)
+		mockLogger := pkgmocks.NewMockLogger(ctrl)
+
+		mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+		mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
+
+		emailSender := domain.NewEmailSender("sender@example.com", "Test Sender")
+		emailProvider := &domain.EmailProvider{
+			Kind:    domain.EmailProviderKindSMTP,
+			Senders: []domain.EmailSender{emailSender},
+		}
+
+		template := &domain.Template{
+			ID: "template-1",
+			Email: &domain.EmailTemplate{
+				SenderID:         emailSender.ID,
+				Subject:          "Test Subject",
+				VisualEditorTree: createQueueValidTestTree(createQueueTestTextBlock("txt1", "Hello")),
+			},
+		}
+
+		sendAt := time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+		recipients := []*domain.ContactWithList{
+			{Contact: &domain.Contact{Email: "morning@example.com"}, ListID: "list-1", SendAt: &sendAt},
+			{Contact: &domain.Contact{Email: "unknown@example.com"}, ListID: "list-1"},
+		}
+
+		mockBroadcastRepo.EXPECT().GetBroadcast(gomock.Any(), "workspace-1", "broadcast-1").
+			Return(&domain.Broadcast{ID: "broadcast-1", WorkspaceID: "workspace-1"}, nil)
+
+		mockQueueRepo.EXPECT().Enqueue(gomock.Any(), "workspace-1", gomock.Any()).
+			DoAndReturn(func(ctx context.Context, workspaceID string, entries []*domain.EmailQueueEntry) error {
+				require.Len(t, entries, 2)
+				require.NotNil(t, entries[0].NextRetryAt)
+				assert.Equal(t, sendAt, *entries[0].NextRetryAt)
+				assert.Nil(t, entries[1].NextRetryAt)
+				return nil
+			})
+
+		sender := NewQueueMessageSender(mockQueueRepo, mockBroadcastRepo, mocks.NewMockMessageHistoryRepository(ctrl), mocks.NewMockTemplateRepository(ctrl), mockLogger, nil, "https://api.example.com")
+
+		result, err := sender.SendBatch(
+			context.Background(),
+			"workspace-1",
+			"integration-1",
+			"secret-key",
+			"https://api.example.com",
+			true,
+			"broadcast-1",
+			recipients,
+			map[string]*domain.Template{"template-1": template},
+			emailProvider,
+			time.Now().Add(5*time.Minute),
+		)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, result.Sent())
+		assert.Equal(t, 0, result.Failed())
 	})
 
 	t.Run("handles empty recipients", func(t *testing.T) {
@@ -423,7 +561,7 @@ func TestQueueMessageSender_SendBatch(t *testing.T) {
 			"https://api.example.com",
 		)
 
-		sent, failed, err := sender.SendBatch(
+		result, err := sender.SendBatch(
 			context.Background(),
 			"workspace-1",
 			"integration-1",
@@ -438,8 +576,8 @@ func TestQueueMessageSender_SendBatch(t *testing.T) {
 		)
 
 		assert.NoError(t, err)
-		assert.Equal(t, 0, sent)
-		assert.Equal(t, 0, failed)
+		assert.Equal(t, 0, result.Sent())
+		assert.Equal(t, 0, result.Failed())
 	})
 
 	t.Run("handles enqueue failure", func(t *testing.T) {
@@ -497,7 +635,7 @@ func TestQueueMessageSender_SendBatch(t *testing.T) {
 			"https://api.example.com",
 		)
 
-		sent, failed, err := sender.SendBatch(
+		result, err := sender.SendBatch(
 			context.Background(),
 			"workspace-1",
 			"integration-1",
@@ -512,8 +650,8 @@ func TestQueueMessageSender_SendBatch(t *testing.T) {
 		)
 
 		assert.Error(t, err)
-		assert.Equal(t, 0, sent)
-		assert.Equal(t, 1, failed)
+		assert.Equal(t, 0, result.Sent())
+		assert.Equal(t, 1, result.Failed())
 	})
 
 	t.Run("populates system variables via BuildTemplateData", func(t *testing.T) {
@@ -603,7 +741,7 @@ func TestQueueMessageSender_SendBatch(t *testing.T) {
 			"https://api.example.com",
 		)
 
-		sent, failed, err := sender.SendBatch(
+		result, err := sender.SendBatch(
 			context.Background(),
 			"workspace-1",
 			"integration-1",
@@ -618,8 +756,8 @@ func TestQueueMessageSender_SendBatch(t *testing.T) {
 		)
 
 		assert.NoError(t, err)
-		assert.Equal(t, 1, sent)
-		assert.Equal(t, 0, failed)
+		assert.Equal(t, 1, result.Sent())
+		assert.Equal(t, 0, result.Failed())
 	})
 
 	t.Run("renders system variables in subject line", func(t *testing.T) {
@@ -695,7 +833,7 @@ func TestQueueMessageSender_SendBatch(t *testing.T) {
 			"https://api.example.com",
 		)
 
-		sent, failed, err := sender.SendBatch(
+		result, err := sender.SendBatch(
 			context.Background(),
 			"workspace-1",
 			"integration-1",
@@ -710,8 +848,8 @@ func TestQueueMessageSender_SendBatch(t *testing.T) {
 		)
 
 		assert.NoError(t, err)
-		assert.Equal(t, 1, sent)
-		assert.Equal(t, 0, failed)
+		assert.Equal(t, 1, result.Sent())
+		assert.Equal(t, 0, result.Failed())
 	})
 }
 
@@ -739,7 +877,7 @@ func TestQueueMessageSender_SelectTemplate(t *testing.T) {
 	broadcast := &domain.Broadcast{ID: "broadcast-1"}
 
 	t.Run("returns nil for empty templates", func(t *testing.T) {
-		result := qms.selectTemplate(map[string]*domain.Template{}, broadcast)
+		result := qms.selectTemplate(map[string]*domain.Template{}, broadcast, "")
 		assert.Nil(t, result)
 	})
 
@@ -749,7 +887,7 @@ func TestQueueMessageSender_SelectTemplate(t *testing.T) {
 			"template-1": template,
 		}
 
-		result := qms.selectTemplate(templates, broadcast)
+		result := qms.selectTemplate(templates, broadcast, "")
 		assert.NotNil(t, result)
 		assert.Equal(t, "template-1", result.ID)
 	})
@@ -765,7 +903,7 @@ func TestQueueMessageSender_SelectTemplate(t *testing.T) {
 		// Run multiple times to verify randomness
 		selections := make(map[string]int)
 		for i := 0; i < 20; i++ {
-			result := qms.selectTemplate(templates, broadcast)
+			result := qms.selectTemplate(templates, broadcast, "")
 			require.NotNil(t, result)
 			selections[result.ID]++
 		}
@@ -773,6 +911,23 @@ func TestQueueMessageSender_SelectTemplate(t *testing.T) {
 		// Both templates should be selected at least once
 		assert.Greater(t, selections["template-1"]+selections["template-2"], 0)
 	})
+
+	t.Run("selects the template of the list of the recipient", func(t *testing.T) {
+		templates := map[string]*domain.Template{
+			"template-1":   {ID: "template-1"},
+			"template-vip": {ID: "template-vip"},
+		}
+		withListTemplates := &domain.Broadcast{
+			ID:           "broadcast-1",
+			Audience:     domain.AudienceSettings{Lists: []string{"list-vip", "list-all"}, ListTemplates: map[string]string{"list-vip": "template-vip"}},
+			TestSettings: domain.BroadcastTestSettings{Variations: []domain.BroadcastVariation{{TemplateID: "template-1"}}},
+		}
+
+		for i := 0; i < 20; i++ {
+			assert.Equal(t, "template-vip", qms.selectTemplate(templates, withListTemplates, "list-vip").ID)
+			assert.Equal(t, "template-1", qms.selectTemplate(templates, withListTemplates, "list-all").ID)
+		}
+	})
 }
 
 func TestQueueMessageSender_BuildQueueEntry(t *testing.T) {
@@ -842,6 +997,8 @@ func TestQueueMessageSender_BuildQueueEntry(t *testing.T) {
 			template,
 			data,
 			emailProvider,
+			nil,
+			"",
 		)
 
 		require.NoError(t, err)
@@ -863,6 +1020,9 @@ func TestQueueMessageSender_BuildQueueEntry(t *testing.T) {
 		assert.NotEmpty(t, entry.Payload.HTMLContent)
 		assert.Equal(t, 100, entry.Payload.RateLimitPerMinute)
 		assert.Equal(t, 3, entry.MaxAttempts)
+
+		// The broadcast is shared with the renders still running after their timeout, it is left untouched
+		assert.Equal(t, &domain.UTMParameters{Source: "newsletter", Medium: "email", Campaign: "weekly"}, broadcast.UTMParameters)
 	})
 
 	t.Run("extracts List-Unsubscribe URL from data", func(t *testing.T) {
@@ -901,6 +1061,8 @@ func TestQueueMessageSender_BuildQueueEntry(t *testing.T) {
 			template,
 			data,
 			emailProvider,
+			nil,
+			"",
 		)
 
 		require.NoError(t, err)
@@ -937,9 +1099,438 @@ func TestQueueMessageSender_BuildQueueEntry(t *testing.T) {
 			template,
 			nil,
 			emailProvider,
+			nil,
+			"",
 		)
 
 		assert.Error(t, err)
 		assert.Contains(t, err.Error(), "no sender configured")
 	})
+
+	t.Run("template opted out of tracking is sent untracked", func(t *testing.T) {
+		emailSender := domain.NewEmailSender("sender@example.com", "Test Sender")
+		emailProvider := &domain.EmailProvider{
+			Kind:    domain.EmailProviderKindSMTP,
+			Senders: []domain.EmailSender{emailSender},
+		}
+
+		broadcast := &domain.Broadcast{
+			ID:            "broadcast-1",
+			UTMParameters: &domain.UTMParameters{},
+		}
+
+		build := func(t *testing.T, disableTracking bool) string {
+			template := &domain.Template{
+				ID: "template-1",
+				Email: &domain.EmailTemplate{
+					SenderID:             emailSender.ID,
+					Subject:              "Reset your password",
+					VisualEditorTree:     createQueueValidTestTree(createQueueTestTextBlock("txt1", `<a href="https://app.example.com/reset">Reset</a>`)),
+					DisableOpenTracking:  disableTracking,
+					DisableClickTracking: disableTracking,
+				},
+			}
+
+			entry, err := qms.buildQueueEntry(
+				context.Background(),
+				"workspace-1",
+				"integration-1",
+				true,
+				broadcast,
+				"msg-123",
+				"test@example.com",
+				template,
+				map[string]interface{}{},
+				emailProvider,
+				nil,
+				"",
+			)
+			require.NoError(t, err)
+			return entry.Payload.HTMLContent
+		}
+
+		tracked := build(t, false)
+		assert.Contains(t, tracked, "https://api.example.com/opens?")
+		assert.Contains(t, tracked, "https://api.example.com/visit?")
+
+		untracked := build(t, true)
+		assert.NotContains(t, untracked, "/opens?")
+		assert.NotContains(t, untracked, "/visit?")
+		assert.Contains(t, untracked, `href="https://app.example.com/reset"`)
+	})
+}
+
+func TestQueueMessageSender_SendBatch_SingleEnqueue(t *testing.T) {
+	makeRecipients := func(n int) []*domain.ContactWithList {
+		recipients := make([]*domain.ContactWithList, n)
+		for i := range recipients {
+			recipients[i] = &domain.ContactWithList{
+				Contact: &domain.Contact{Email: fmt.Sprintf("user%d@example.com", i)},
+				ListID:  "list-1",
+			}
+		}
+		return recipients
+	}
+
+	setup := func(t *testing.T) (*mocks.MockEmailQueueRepository, MessageSender, *domain.EmailProvider, *domain.Template) {
+		ctrl := gomock.NewController(t)
+		t.Cleanup(ctrl.Finish)
+
+		mockQueueRepo := mocks.NewMockEmailQueueRepository(ctrl)
+		mockBroadcastRepo := mocks.NewMockBroadcastRepository(ctrl)
+		mockMessageHistoryRepo := mocks.NewMockMessageHistoryRepository(ctrl)
+		mockTemplateRepo := mocks.NewMockTemplateRepository(ctrl)
+		mockLogger := pkgmocks.NewMockLogger(ctrl)
+
+		mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+		mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
+		mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
+
+		emailSender := domain.NewEmailSender("sender@example.com", "Test Sender")
+		emailProvider := &domain.EmailProvider{
+			Kind:    domain.EmailProviderKindSES,
+			Senders: []domain.EmailSender{emailSender},
+		}
+
+		template := &domain.Template{
+			ID: "template-1",
+			Email: &domain.EmailTemplate{
+				SenderID:         emailSender.ID,
+				Subject:          "Test Subject",
+				VisualEditorTree: createQueueValidTestTree(createQueueTestTextBlock("txt1", "Hello")),
+			},
+		}
+
+		mockBroadcastRepo.EXPECT().GetBroadcast(gomock.Any(), "workspace-1", "broadcast-1").
+			Return(&domain.Broadcast{ID: "broadcast-1", WorkspaceID: "workspace-1"}, nil)
+
+		config := TestConfig()
+		config.FetchBatchSize = 100
+
+		sender := NewQueueMessageSender(
+			mockQueueRepo,
+			mockBroadcastRepo,
+			mockMessageHistoryRepo,
+			mockTemplateRepo,
+			mockLogger,
+			config,
+			"https://api.example.com",
+		)
+		return mockQueueRepo, sender, emailProvider, template
+	}
+
+	t.Run("enqueues the whole batch at once", func(t *testing.T) {
+		mockQueueRepo, sender, emailProvider, template := setup(t)
+
+		mockQueueRepo.EXPECT().Enqueue(gomock.Any(), "workspace-1", gomock.Len(100)).Return(nil).Times(1)
+
+		result, err := sender.SendBatch(
+			context.Background(),
+			"workspace-1",
+			"integration-1",
+			"secret-key",
+			"https://api.example.com",
+			true,
+			"broadcast-1",
+			makeRecipients(100),
+			map[string]*domain.Template{"template-1": template},
+			emailProvider,
+			time.Now().Add(5*time.Minute),
+		)
+
+		require.NoError(t, err)
+		assert.Equal(t, 100, result.Sent())
+		assert.Equal(t, 0, result.Failed())
+	})
+
+	t.Run("enqueues nothing when the batch fails", func(t *testing.T) {
+		mockQueueRepo, sender, emailProvider, template := setup(t)
+
+		mockQueueRepo.EXPECT().Enqueue(gomock.Any(), "workspace-1", gomock.Len(100)).Return(errors.New("database error"))
+
+		result, err := sender.SendBatch(
+			context.Background(),
+			"workspace-1",
+			"integration-1",
+			"secret-key",
+			"https://api.example.com",
+			true,
+			"broadcast-1",
+			makeRecipients(100),
+			map[string]*domain.Template{"template-1": template},
+			emailProvider,
+			time.Now().Add(5*time.Minute),
+		)
+
+		// The recipients are neither sent nor failed, so they are enqueued again
+		assert.Error(t, err)
+		assert.Equal(t, 0, result.Sent())
+		assert.Equal(t, 0, result.Failed())
+	})
+}
+
+func TestQueueMessageSender_SendBatch_Personalization(t *testing.T) {
+	tests := []struct {
+		name             string
+		policy           domain.PersonalizationPolicy
+		expectedSubjects []string
+		expectedFailed   int
+	}{
+		{
+			name:             "skip policy skips recipient with empty first name",
+			policy:           domain.PersonalizationPolicySkip,
+			expectedSubjects: []string{"Hi Ada"},
+			expectedFailed:   1,
+		},
+		{
+			name:             "fallback policy renders the fallback",
+			policy:           domain.PersonalizationPolicyFallback,
+			expectedSubjects: []string{"Hi Ada", "Hi there"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockQueueRepo := mocks.NewMockEmailQueueRepository(ctrl)
+			mockBroadcastRepo := mocks.NewMockBroadcastRepository(ctrl)
+			mockLogger := pkgmocks.NewMockLogger(ctrl)
+			mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+			mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
+			mockLogger.EXPECT().Info(gomock.Any()).AnyTimes()
+
+			emailSender := domain.NewEmailSender("sender@example.com", "Test Sender")
+			emailProvider := &domain.EmailProvider{
+				Kind:    domain.EmailProviderKindSMTP,
+				Senders: []domain.EmailSender{emailSender},
+			}
+
+			template := &domain.Template{
+				ID: "template-1",
+				Email: &domain.EmailTemplate{
+					SenderID:         emailSender.ID,
+					Subject:          "Hi {{ contact.first_name }}",
+					VisualEditorTree: createQueueValidTestTree(createQueueTestTextBlock("txt1", "Hello")),
+					Personalization: &domain.PersonalizationRules{
+						Policy:         tt.policy,
+						RequiredFields: []domain.RequiredMergeField{{Path: "contact.first_name", Fallback: "there"}},
+					},
+				},
+			}
+
+			recipients := []*domain.ContactWithList{
+				{
+					Contact: &domain.Contact{Email: "ada@example.com", FirstName: &domain.NullableString{String: "Ada"}},
+					ListID:  "list-1",
+				},
+				{
+					Contact: &domain.Contact{Email: "anonymous@example.com"},
+					ListID:  "list-1",
+				},
+			}
+
+			mockBroadcastRepo.EXPECT().GetBroadcast(gomock.Any(), "workspace-1", "broadcast-1").
+				Return(&domain.Broadcast{ID: "broadcast-1", WorkspaceID: "workspace-1"}, nil)
+
+			var subjects []string
+			mockQueueRepo.EXPECT().Enqueue(gomock.Any(), "workspace-1", gomock.Any()).
+				DoAndReturn(func(_ context.Context, _ string, entries []*domain.EmailQueueEntry) error {
+					for _, entry := range entries {
+						subjects = append(subjects, entry.Payload.Subject)
+					}
+					return nil
+				})
+
+			sender := NewQueueMessageSender(mockQueueRepo, mockBroadcastRepo, nil, nil, mockLogger, nil, "https://api.example.com")
+
+			result, err := sender.SendBatch(
+				context.Background(),
+				"workspace-1",
+				"integration-1",
+				"secret-key",
+				"https://api.example.com",
+				false,
+				"broadcast-1",
+				recipients,
+				map[string]*domain.Template{"template-1": template},
+				emailProvider,
+				time.Now().Add(5*time.Minute),
+			)
+
+			require.NoError(t, err)
+			assert.Equal(t, len(tt.expectedSubjects), result.Sent())
+			assert.Equal(t, tt.expectedFailed, result.Failed())
+			assert.Equal(t, tt.expectedSubjects, subjects)
+		})
+	}
+}
+
+func TestQueueMessageSender_SendBatch_RenderTimeout(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockQueueRepo := mocks.NewMockEmailQueueRepository(ctrl)
+	mockBroadcastRepo := mocks.NewMockBroadcastRepository(ctrl)
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Warn("Template render timed out, skipping recipient").Times(1)
+
+	emailSender := domain.NewEmailSender("sender@example.com", "Test Sender")
+	emailProvider := &domain.EmailProvider{
+		Kind:    domain.EmailProviderKindSMTP,
+		Senders: []domain.EmailSender{emailSender},
+	}
+
+	template := &domain.Template{
+		ID: "template-1",
+		Email: &domain.EmailTemplate{
+			SenderID:         emailSender.ID,
+			Subject:          "Hello",
+			VisualEditorTree: createQueueValidTestTree(createQueueTestTextBlock("txt1", "Hello")),
+		},
+	}
+
+	recipients := []*domain.ContactWithList{
+		{Contact: &domain.Contact{Email: "fast1@example.com"}, ListID: "list-1"},
+		{Contact: &domain.Contact{Email: "slow@example.com"}, ListID: "list-1"},
+		{Contact: &domain.Contact{Email: "fast2@example.com"}, ListID: "list-1"},
+	}
+
+	mockBroadcastRepo.EXPECT().GetBroadcast(gomock.Any(), "workspace-1", "broadcast-1").
+		Return(&domain.Broadcast{ID: "broadcast-1", WorkspaceID: "workspace-1"}, nil)
+
+	var enqueued []string
+	mockQueueRepo.EXPECT().Enqueue(gomock.Any(), "workspace-1", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, entries []*domain.EmailQueueEntry) error {
+			for _, entry := range entries {
+				enqueued = append(enqueued, entry.ContactEmail)
+			}
+			return nil
+		})
+
+	config := TestConfig()
+	config.RenderTimeout = 50 * time.Millisecond
+	sender := NewQueueMessageSender(mockQueueRepo, mockBroadcastRepo, nil, nil, mockLogger, config, "https://api.example.com").(*queueMessageSender)
+	// Every recipient is compiled, a cached template would not render the slow one through compileTemplate
+	sender.renderCache = nil
+
+	// The slow recipient's render blocks well past the timeout, until the test ends
+	release := make(chan struct{})
+	defer close(release)
+	sender.compileTemplate = func(req notifuse_mjml.CompileTemplateRequest) (*notifuse_mjml.CompileTemplateResponse, error) {
+		if contact, ok := req.TemplateData["contact"].(domain.MapOfAny); ok && contact["email"] == "slow@example.com" {
+			<-release
+		}
+		return notifuse_mjml.CompileTemplate(req)
+	}
+
+	start := time.Now()
+	result, err := sender.SendBatch(
+		context.Background(),
+		"workspace-1",
+		"integration-1",
+		"secret-key",
+		"https://api.example.com",
+		false,
+		"broadcast-1",
+		recipients,
+		map[string]*domain.Template{"template-1": template},
+		emailProvider,
+		time.Now().Add(5*time.Minute),
+	)
+
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Sent())
+	assert.Equal(t, 1, result.Failed())
+	assert.Equal(t, []string{"fast1@example.com", "fast2@example.com"}, enqueued)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
+
+func TestQueueMessageSender_SendBatch_TrackingDomain(t *testing.T) {
+	sendBatch := func(t *testing.T, trackingDomain string) *domain.EmailQueueEntry {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockQueueRepo := mocks.NewMockEmailQueueRepository(ctrl)
+		mockBroadcastRepo := mocks.NewMockBroadcastRepository(ctrl)
+		mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+		mockLogger := pkgmocks.NewMockLogger(ctrl)
+		mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+		mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
+
+		emailSender := domain.NewEmailSender("sender@example.com", "Test Sender")
+		emailProvider := &domain.EmailProvider{
+			Kind:    domain.EmailProviderKindSMTP,
+			Senders: []domain.EmailSender{emailSender},
+		}
+		template := &domain.Template{
+			ID: "template-1",
+			Email: &domain.EmailTemplate{
+				SenderID:         emailSender.ID,
+				Subject:          "Spring news",
+				VisualEditorTree: createQueueValidTestTree(createQueueTestTextBlock("txt1", `See the <a href="https://shop.example.com/spring">offer</a> or <a href="{{ unsubscribe_url }}">leave</a>`)),
+			},
+		}
+
+		mockWorkspaceRepo.EXPECT().GetByID(gomock.Any(), "workspace-1").Return(&domain.Workspace{
+			ID:       "workspace-1",
+			Settings: domain.WorkspaceSettings{TrackingDomain: trackingDomain},
+		}, nil)
+		mockBroadcastRepo.EXPECT().GetBroadcast(gomock.Any(), "workspace-1", "broadcast-1").
+			Return(&domain.Broadcast{ID: "broadcast-1", WorkspaceID: "workspace-1"}, nil)
+
+		var enqueued []*domain.EmailQueueEntry
+		mockQueueRepo.EXPECT().Enqueue(gomock.Any(), "workspace-1", gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, entries []*domain.EmailQueueEntry) error {
+				enqueued = append(enqueued, entries...)
+				return nil
+			})
+
+		sender := NewQueueMessageSender(mockQueueRepo, mockBroadcastRepo, nil, nil, mockLogger, nil, "https://api.example.com").(*queueMessageSender)
+		sender.workspaceRepo = mockWorkspaceRepo
+
+		recipients := []*domain.ContactWithList{
+			{Contact: &domain.Contact{Email: "ada@example.com"}, ListID: "newsletter", ListName: "Newsletter"},
+		}
+		result, err := sender.SendBatch(
+			context.Background(),
+			"workspace-1",
+			"integration-1",
+			"secret-key",
+			"https://api.example.com",
+			true,
+			"broadcast-1",
+			recipients,
+			map[string]*domain.Template{"template-1": template},
+			emailProvider,
+			time.Now().Add(5*time.Minute),
+		)
+
+		require.NoError(t, err)
+		require.Equal(t, 1, result.Sent())
+		require.Len(t, enqueued, 1)
+		return enqueued[0]
+	}
+
+	t.Run("tracking URLs use the workspace tracking domain", func(t *testing.T) {
+		html := sendBatch(t, "track.brand.com").Payload.HTMLContent
+
+		assert.Contains(t, html, `href="https://track.brand.com/visit?mid=`)
+		assert.Contains(t, html, `<img src="https://track.brand.com/opens?mid=`)
+		assert.NotContains(t, html, "https://api.example.com/visit")
+		assert.NotContains(t, html, "https://api.example.com/opens")
+		// Only the tracking URLs move to the tracking domain
+		assert.Contains(t, html, "https%3A%2F%2Fapi.example.com%2Fnotification-center")
+	})
+
+	t.Run("tracking URLs fall back to the API endpoint", func(t *testing.T) {
+		html := sendBatch(t, "").Payload.HTMLContent
+
+		assert.Contains(t, html, `href="https://api.example.com/visit?mid=`)
+		assert.Contains(t, html, `<img src="https://api.example.com/opens?mid=`)
+		assert.NotContains(t, html, "track.brand.com")
+	})
 }
@@ -0,0 +1,75 @@
+package broadcast
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+)
+
+// quotaDayStart returns the start of the UTC day the workspace daily send quota is counted over
+func quotaDayStart(now time.Time) time.Time {
+	return now.UTC().Truncate(24 * time.Hour)
+}
+
+// dailySendAllowance returns how many messages the workspace may still send today under its daily
+// send quota, -1 when it has no quota. Once the quota is used up it returns an ErrCodeQuotaExceeded
+// error, the broadcast then waits for the next UTC day instead of failing.
+func (o *BroadcastOrchestrator) dailySendAllowance(ctx context.Context, workspace *domain.Workspace) (int, error) {
+	quota := workspace.Settings.DailySendQuota
+	if quota <= 0 || o.messageHistoryRepo == nil {
+		return -1, nil
+	}
+
+	sentToday, _, err := o.messageHistoryRepo.CountSentAndComplaintsSince(ctx, workspace.ID, quotaDayStart(o.timeProvider.Now()))
+	if err != nil {
+		return 0, fmt.Errorf("failed to count messages sent today: %w", err)
+	}
+
+	if sentToday >= quota {
+		return 0, NewBroadcastError(ErrCodeQuotaExceeded, fmt.Sprintf("daily send quota of %d messages reached", quota), true, nil)
+	}
+	return quota - sentToday, nil
+}
+
+// deferToNextQuotaDay moves a broadcast that used up the workspace daily send quota to the
+// quota_exceeded status and schedules its task for the start of the next UTC day
+func (o *BroadcastOrchestrator) deferToNextQuotaDay(ctx context.Context, task *domain.Task, broadcast *domain.Broadcast, broadcastState *domain.SendBroadcastState) error {
+	now := o.timeProvider.Now().UTC()
+	resumeAt := quotaDayStart(now).Add(24 * time.Hour)
+
+	broadcast.Status = domain.BroadcastStatusQuotaExceeded
+	broadcast.UpdatedAt = now
+	if err := o.broadcastRepo.UpdateBroadcast(ctx, broadcast); err != nil {
+		o.logger.WithFields(map[string]interface{}{
+			"task_id":      task.ID,
+			"broadcast_id": broadcast.ID,
+			"error":        err.Error(),
+		}).Error("Failed to update broadcast status to quota exceeded")
+		return fmt.Errorf("failed to update broadcast status to quota exceeded: %w", err)
+	}
+	o.publishPhaseChange(broadcastState, broadcast)
+
+	task.NextRunAfter = &resumeAt
+	task.State.Message = fmt.Sprintf("Daily send quota reached: resuming at %s", resumeAt.Format(time.RFC3339))
+
+	o.logger.WithFields(map[string]interface{}{
+		"task_id":      task.ID,
+		"broadcast_id": broadcast.ID,
+		"resume_at":    resumeAt,
+	}).Info("Broadcast deferred to the next day by the daily send quota")
+	return nil
+}
+
+// resumedStatus returns the status a broadcast waiting for the daily send quota goes back to
+func resumedStatus(broadcastState *domain.SendBroadcastState) domain.BroadcastStatus {
+	switch broadcastState.Phase {
+	case "test":
+		return domain.BroadcastStatusTesting
+	case "winner":
+		return domain.BroadcastStatusWinnerSelected
+	default:
+		return domain.BroadcastStatusProcessing
+	}
+}
@@ -0,0 +1,174 @@
+package broadcast
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/pkg/notifuse_mjml"
+)
+
+// maxRenderCacheEntries bounds the number of template versions a sender keeps compiled
+const maxRenderCacheEntries = 256
+
+// RenderCacheStats reports the lookups of the compiled template cache of a message sender
+type RenderCacheStats struct {
+	Hits    int64 `json:"hits"`
+	Misses  int64 `json:"misses"`
+	Entries int   `json:"entries"`
+}
+
+// renderCacheKey identifies a template version, template IDs are only unique within a workspace.
+// The translations of a template share its ID and version and are told apart by their language.
+type renderCacheKey struct {
+	workspaceID string
+	templateID  string
+	version     int64
+	language    string
+}
+
+// renderCacheEntry is the compiled HTML skeleton of a template version, with its Liquid markup left to
+// render per recipient. A template version whose skeleton does not render like CompileTemplate is
+// recorded as not cacheable and compiled per recipient.
+type renderCacheEntry struct {
+	skeleton  string
+	cacheable bool
+}
+
+// renderCache keeps the compiled skeleton of the template versions a sender renders, so that the
+// MJML to HTML compilation runs once per template version instead of once per recipient
+type renderCache struct {
+	mu      sync.Mutex
+	entries map[renderCacheKey]*renderCacheEntry
+	hits    atomic.Int64
+	misses  atomic.Int64
+}
+
+func newRenderCache() *renderCache {
+	return &renderCache{entries: make(map[renderCacheKey]*renderCacheEntry)}
+}
+
+// get returns the entry of a template version and counts the lookup
+func (c *renderCache) get(key renderCacheKey) (*renderCacheEntry, bool) {
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+
+	if ok {
+		c.hits.Add(1)
+	} else {
+		c.misses.Add(1)
+	}
+	return entry, ok
+}
+
+// put stores the entry of a template version, dropping the other versions of the template
+func (c *renderCache) put(key renderCacheKey, entry *renderCacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for existing := range c.entries {
+		if existing.workspaceID == key.workspaceID && existing.templateID == key.templateID && existing.version != key.version {
+			delete(c.entries, existing)
+		}
+	}
+
+	if _, exists := c.entries[key]; !exists && len(c.entries) >= maxRenderCacheEntries {
+		// Evict an arbitrary entry, it is compiled again on its next use
+		for existing := range c.entries {
+			delete(c.entries, existing)
+			break
+		}
+	}
+	c.entries[key] = entry
+}
+
+// stats returns the lookup counters and the number of cached template versions
+func (c *renderCache) stats() RenderCacheStats {
+	c.mu.Lock()
+	entries := len(c.entries)
+	c.mu.Unlock()
+
+	return RenderCacheStats{
+		Hits:    c.hits.Load(),
+		Misses:  c.misses.Load(),
+		Entries: entries,
+	}
+}
+
+// GetRenderCacheStats returns the counters of the compiled template cache of the sender
+func (s *queueMessageSender) GetRenderCacheStats() RenderCacheStats {
+	if s.renderCache == nil {
+		return RenderCacheStats{}
+	}
+	return s.renderCache.stats()
+}
+
+// renderHTML compiles the email body of a recipient. The first recipient of a template version is
+// compiled in full and checked against the template skeleton, the next ones only render the cached
+// skeleton with their data when both matched.
+func (s *queueMessageSender) renderHTML(workspaceID, messageID string, template *domain.Template, data map[string]interface{}, trackingSettings notifuse_mjml.TrackingSettings) (string, error) {
+	if s.renderCache == nil {
+		return s.compileHTML(workspaceID, messageID, template, data, trackingSettings)
+	}
+
+	key := renderCacheKey{workspaceID: workspaceID, templateID: template.ID, version: template.Version, language: template.Email.Language}
+	if entry, ok := s.renderCache.get(key); ok {
+		if entry.cacheable {
+			html, err := notifuse_mjml.RenderSkeleton(entry.skeleton, data, template.Email.RawMergeFields, trackingSettings)
+			if err == nil {
+				return html, nil
+			}
+			// Compiled in full to report the error of the failing block
+		}
+		return s.compileHTML(workspaceID, messageID, template, data, trackingSettings)
+	}
+
+	// Compiled without tracking to compare it with the skeleton, the tracking is applied after
+	html, err := s.compileHTML(workspaceID, messageID, template, data, notifuse_mjml.TrackingSettings{})
+	if err != nil {
+		return "", err
+	}
+
+	entry := &renderCacheEntry{}
+	// Trusted merge fields may hold markup the MJML compiler rewrites, their templates are not cached
+	if len(template.Email.RawMergeFields) == 0 {
+		skeleton, ok, skeletonErr := notifuse_mjml.CompileSkeleton(template.Email.VisualEditorTree)
+		if skeletonErr == nil && ok {
+			rendered, renderErr := notifuse_mjml.RenderSkeleton(skeleton, data, nil, notifuse_mjml.TrackingSettings{})
+			if renderErr == nil && rendered == html {
+				entry.skeleton = skeleton
+				entry.cacheable = true
+			}
+		}
+	}
+	s.renderCache.put(key, entry)
+
+	return notifuse_mjml.TrackLinks(html, trackingSettings)
+}
+
+// compileHTML compiles the email body of a recipient from the visual editor tree
+func (s *queueMessageSender) compileHTML(workspaceID, messageID string, template *domain.Template, data map[string]interface{}, trackingSettings notifuse_mjml.TrackingSettings) (string, error) {
+	compiledTemplate, err := s.compileTemplate(
+		notifuse_mjml.CompileTemplateRequest{
+			WorkspaceID:      workspaceID,
+			MessageID:        messageID,
+			VisualEditorTree: template.Email.VisualEditorTree,
+			TemplateData:     data,
+			TrackingSettings: trackingSettings,
+			RawMergeFields:   template.Email.RawMergeFields,
+		},
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to compile template: %w", err)
+	}
+	if !compiledTemplate.Success || compiledTemplate.HTML == nil {
+		errMsg := "template compilation failed"
+		if compiledTemplate.Error != nil {
+			errMsg = compiledTemplate.Error.Message
+		}
+		return "", fmt.Errorf("%s", errMsg)
+	}
+	return *compiledTemplate.HTML, nil
+}
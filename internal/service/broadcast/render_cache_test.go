@@ -0,0 +1,322 @@
+package broadcast
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/domain/mocks"
+	pkgmocks "github.com/Notifuse/notifuse/pkg/mocks"
+	"github.com/Notifuse/notifuse/pkg/notifuse_mjml"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newRenderTestSender returns a sender counting the full template compilations
+func newRenderTestSender(compilations *int) *queueMessageSender {
+	sender := NewQueueMessageSender(nil, nil, nil, nil, nil, nil, "https://api.example.com").(*queueMessageSender)
+	sender.compileTemplate = func(req notifuse_mjml.CompileTemplateRequest) (*notifuse_mjml.CompileTemplateResponse, error) {
+		*compilations++
+		return notifuse_mjml.CompileTemplate(req)
+	}
+	return sender
+}
+
+func newRenderTestTemplate(version int64) *domain.Template {
+	return &domain.Template{
+		ID:      "template-1",
+		Version: version,
+		Email: &domain.EmailTemplate{
+			Subject:          "Hello",
+			VisualEditorTree: createQueueValidTestTree(createQueueTestTextBlock("txt1", `<p>Hello {{ contact.first_name }}</p><a href="https://example.com/offer">Offer</a>`)),
+		},
+	}
+}
+
+func renderTestData(i int) map[string]interface{} {
+	return map[string]interface{}{
+		"contact": map[string]interface{}{
+			"email":      fmt.Sprintf("user%d@example.com", i),
+			"first_name": fmt.Sprintf("Name%d", i),
+		},
+	}
+}
+
+func TestQueueMessageSender_RenderHTML(t *testing.T) {
+	tracking := notifuse_mjml.TrackingSettings{
+		Endpoint:       "https://api.example.com",
+		EnableTracking: true,
+		WorkspaceID:    "workspace-1",
+		MessageID:      "message-1",
+	}
+
+	t.Run("renders recipients from the cached skeleton", func(t *testing.T) {
+		compilations := 0
+		sender := newRenderTestSender(&compilations)
+		template := newRenderTestTemplate(1)
+
+		for i := 0; i < 3; i++ {
+			html, err := sender.renderHTML("workspace-1", "message-1", template, renderTestData(i), tracking)
+			require.NoError(t, err)
+
+			// Each recipient gets its own data and tracking
+			assert.Contains(t, html, fmt.Sprintf("Hello Name%d", i))
+			assert.Contains(t, html, "https://api.example.com/visit?mid=message-1")
+			assert.Contains(t, html, "https://api.example.com/opens?mid=message-1")
+
+			// Same output as the full compilation
+			expected, err := notifuse_mjml.CompileTemplate(notifuse_mjml.CompileTemplateRequest{
+				WorkspaceID:      "workspace-1",
+				MessageID:        "message-1",
+				VisualEditorTree: template.Email.VisualEditorTree,
+				TemplateData:     renderTestData(i),
+			})
+			require.NoError(t, err)
+			untracked, err := sender.renderHTML("workspace-1", "message-1", template, renderTestData(i), notifuse_mjml.TrackingSettings{})
+			require.NoError(t, err)
+			assert.Equal(t, *expected.HTML, untracked)
+		}
+
+		// Only the first recipient is compiled in full
+		assert.Equal(t, 1, compilations)
+		assert.Equal(t, RenderCacheStats{Hits: 5, Misses: 1, Entries: 1}, sender.GetRenderCacheStats())
+	})
+
+	t.Run("a version bump busts the cache", func(t *testing.T) {
+		compilations := 0
+		sender := newRenderTestSender(&compilations)
+
+		_, err := sender.renderHTML("workspace-1", "message-1", newRenderTestTemplate(1), renderTestData(1), tracking)
+		require.NoError(t, err)
+		_, err = sender.renderHTML("workspace-1", "message-2", newRenderTestTemplate(1), renderTestData(2), tracking)
+		require.NoError(t, err)
+		assert.Equal(t, 1, compilations)
+
+		updated := newRenderTestTemplate(2)
+		updated.Email.VisualEditorTree = createQueueValidTestTree(createQueueTestTextBlock("txt1", "<p>Welcome back {{ contact.first_name }}</p>"))
+
+		html, err := sender.renderHTML("workspace-1", "message-3", updated, renderTestData(3), tracking)
+		require.NoError(t, err)
+		assert.Contains(t, html, "Welcome back Name3")
+		assert.Equal(t, 2, compilations)
+
+		html, err = sender.renderHTML("workspace-1", "message-4", updated, renderTestData(4), tracking)
+		require.NoError(t, err)
+		assert.Contains(t, html, "Welcome back Name4")
+		assert.Equal(t, 2, compilations)
+
+		// The previous version is dropped
+		assert.Equal(t, RenderCacheStats{Hits: 2, Misses: 2, Entries: 1}, sender.GetRenderCacheStats())
+	})
+
+	t.Run("templates are cached per workspace", func(t *testing.T) {
+		compilations := 0
+		sender := newRenderTestSender(&compilations)
+
+		_, err := sender.renderHTML("workspace-1", "message-1", newRenderTestTemplate(1), renderTestData(1), tracking)
+		require.NoError(t, err)
+		_, err = sender.renderHTML("workspace-2", "message-2", newRenderTestTemplate(1), renderTestData(2), tracking)
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, compilations)
+		assert.Equal(t, 2, sender.GetRenderCacheStats().Entries)
+	})
+
+	t.Run("templates with trusted merge fields are compiled per recipient", func(t *testing.T) {
+		compilations := 0
+		sender := newRenderTestSender(&compilations)
+		template := newRenderTestTemplate(1)
+		template.Email.RawMergeFields = []string{"custom_string_1"}
+
+		for i := 0; i < 3; i++ {
+			html, err := sender.renderHTML("workspace-1", "message-1", template, renderTestData(i), tracking)
+			require.NoError(t, err)
+			assert.Contains(t, html, fmt.Sprintf("Hello Name%d", i))
+		}
+
+		assert.Equal(t, 3, compilations)
+	})
+
+	t.Run("disabled cache compiles every recipient", func(t *testing.T) {
+		compilations := 0
+		sender := newRenderTestSender(&compilations)
+		sender.renderCache = nil
+
+		for i := 0; i < 2; i++ {
+			_, err := sender.renderHTML("workspace-1", "message-1", newRenderTestTemplate(1), renderTestData(i), tracking)
+			require.NoError(t, err)
+		}
+
+		assert.Equal(t, 2, compilations)
+		assert.Equal(t, RenderCacheStats{}, sender.GetRenderCacheStats())
+	})
+}
+
+// sendRenderTestBatch sends a batch of recipients through a caching sender and returns the HTML
+// body enqueued for each recipient, by email
+func sendRenderTestBatch(t *testing.T, template *domain.Template, recipients []*domain.ContactWithList) map[string]string {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockQueueRepo := mocks.NewMockEmailQueueRepository(ctrl)
+	mockBroadcastRepo := mocks.NewMockBroadcastRepository(ctrl)
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
+
+	emailSender := domain.NewEmailSender("sender@example.com", "Test Sender")
+	emailProvider := &domain.EmailProvider{
+		Kind:    domain.EmailProviderKindSMTP,
+		Senders: []domain.EmailSender{emailSender},
+	}
+	template.Email.SenderID = emailSender.ID
+
+	mockBroadcastRepo.EXPECT().GetBroadcast(gomock.Any(), "workspace-1", "broadcast-1").
+		Return(&domain.Broadcast{ID: "broadcast-1", WorkspaceID: "workspace-1"}, nil)
+
+	bodies := make(map[string]string)
+	mockQueueRepo.EXPECT().Enqueue(gomock.Any(), "workspace-1", gomock.Len(len(recipients))).
+		DoAndReturn(func(_ context.Context, _ string, entries []*domain.EmailQueueEntry) error {
+			for _, entry := range entries {
+				bodies[entry.ContactEmail] = entry.Payload.HTMLContent
+			}
+			return nil
+		})
+
+	sender := NewQueueMessageSender(mockQueueRepo, mockBroadcastRepo, nil, nil, mockLogger, nil, "https://api.example.com")
+
+	result, err := sender.SendBatch(
+		context.Background(),
+		"workspace-1",
+		"integration-1",
+		"secret-key",
+		"https://api.example.com",
+		false,
+		"broadcast-1",
+		recipients,
+		map[string]*domain.Template{template.ID: template},
+		emailProvider,
+		time.Now().Add(5*time.Minute),
+	)
+	require.NoError(t, err)
+	require.Equal(t, len(recipients), result.Sent())
+
+	return bodies
+}
+
+func TestQueueMessageSender_SendBatch_RenderCache(t *testing.T) {
+	t.Run("each recipient gets the translation of their language", func(t *testing.T) {
+		template := newRenderTestTemplate(1)
+		template.Email.Translations = map[string]*domain.EmailTemplate{
+			"fr": {
+				Subject:          "Bonjour",
+				VisualEditorTree: createQueueValidTestTree(createQueueTestTextBlock("txt1", `<p>Bonjour {{ contact.first_name }}</p>`)),
+			},
+		}
+
+		recipients := []*domain.ContactWithList{
+			{Contact: &domain.Contact{Email: "ada@example.com", FirstName: &domain.NullableString{String: "Ada"}, Language: &domain.NullableString{String: "fr"}}},
+			{Contact: &domain.Contact{Email: "bob@example.com", FirstName: &domain.NullableString{String: "Bob"}, Language: &domain.NullableString{String: "en"}}},
+			{Contact: &domain.Contact{Email: "chloe@example.com", FirstName: &domain.NullableString{String: "Chloe"}, Language: &domain.NullableString{String: "fr-FR"}}},
+			{Contact: &domain.Contact{Email: "dan@example.com", FirstName: &domain.NullableString{String: "Dan"}}},
+		}
+
+		bodies := sendRenderTestBatch(t, template, recipients)
+
+		assert.Contains(t, bodies["ada@example.com"], "Bonjour Ada")
+		assert.Contains(t, bodies["bob@example.com"], "Hello Bob")
+		assert.NotContains(t, bodies["bob@example.com"], "Bonjour")
+		assert.Contains(t, bodies["chloe@example.com"], "Bonjour Chloe")
+		assert.Contains(t, bodies["dan@example.com"], "Hello Dan")
+	})
+
+	t.Run("conditional blocks are evaluated for each recipient", func(t *testing.T) {
+		conditionalBase := notifuse_mjml.NewBaseBlock("vip", notifuse_mjml.MJMLComponentConditional)
+		conditionalBase.Attributes = map[string]interface{}{"condition": "contact.custom_number_1 > 100"}
+		conditionalBase.Children = []notifuse_mjml.EmailBlock{createQueueTestTextBlock("txt2", "<p>Your VIP offer</p>")}
+
+		template := newRenderTestTemplate(1)
+		template.Email.VisualEditorTree = createQueueValidTestTree(createQueueTestTextBlock("txt1", "<p>Hello {{ contact.first_name }}</p>"))
+		column := template.Email.VisualEditorTree.GetChildren()[0].GetChildren()[0].GetChildren()[0]
+		column.SetChildren(append(column.GetChildren(), &notifuse_mjml.MJConditionalBlock{BaseBlock: conditionalBase}))
+
+		recipients := []*domain.ContactWithList{
+			{Contact: &domain.Contact{Email: "bob@example.com", FirstName: &domain.NullableString{String: "Bob"}, CustomNumber1: &domain.NullableFloat64{Float64: 20}}},
+			{Contact: &domain.Contact{Email: "ada@example.com", FirstName: &domain.NullableString{String: "Ada"}, CustomNumber1: &domain.NullableFloat64{Float64: 250}}},
+		}
+
+		bodies := sendRenderTestBatch(t, template, recipients)
+
+		assert.Contains(t, bodies["bob@example.com"], "Hello Bob")
+		assert.NotContains(t, bodies["bob@example.com"], "Your VIP offer")
+		assert.Contains(t, bodies["ada@example.com"], "Hello Ada")
+		assert.Contains(t, bodies["ada@example.com"], "Your VIP offer")
+	})
+
+	t.Run("product grids are expanded for each recipient", func(t *testing.T) {
+		// The default card template keeps the grid free of Liquid attributes
+		gridBase := notifuse_mjml.NewBaseBlock("grid", notifuse_mjml.MJMLComponentProductGrid)
+		gridBase.Attributes = map[string]interface{}{"source": "contact.custom_json_1.recommended_products"}
+
+		template := newRenderTestTemplate(1)
+		body := template.Email.VisualEditorTree.GetChildren()[0]
+		body.SetChildren(append(body.GetChildren(), &notifuse_mjml.MJProductGridBlock{BaseBlock: gridBase}))
+
+		products := map[string]interface{}{
+			"recommended_products": []interface{}{map[string]interface{}{"name": "Lamp"}, map[string]interface{}{"name": "Desk"}},
+		}
+		recipients := []*domain.ContactWithList{
+			{Contact: &domain.Contact{Email: "bob@example.com", FirstName: &domain.NullableString{String: "Bob"}}},
+			{Contact: &domain.Contact{Email: "ada@example.com", FirstName: &domain.NullableString{String: "Ada"}, CustomJSON1: &domain.NullableJSON{Data: products}}},
+		}
+
+		bodies := sendRenderTestBatch(t, template, recipients)
+
+		assert.Contains(t, bodies["bob@example.com"], "Hello Bob")
+		assert.NotContains(t, bodies["bob@example.com"], "Lamp")
+		assert.Contains(t, bodies["ada@example.com"], "Hello Ada")
+		assert.Contains(t, bodies["ada@example.com"], "Lamp")
+		assert.Contains(t, bodies["ada@example.com"], "Desk")
+	})
+}
+
+func TestRenderCache_Eviction(t *testing.T) {
+	cache := newRenderCache()
+	for i := 0; i < maxRenderCacheEntries+10; i++ {
+		cache.put(renderCacheKey{workspaceID: "workspace-1", templateID: fmt.Sprintf("template-%d", i), version: 1}, &renderCacheEntry{})
+	}
+
+	assert.Equal(t, maxRenderCacheEntries, cache.stats().Entries)
+}
+
+// BenchmarkQueueMessageSender_RenderBatch renders a batch of recipients with and without the render cache
+func BenchmarkQueueMessageSender_RenderBatch(b *testing.B) {
+	const batchSize = 50
+	template := newRenderTestTemplate(1)
+	tracking := notifuse_mjml.TrackingSettings{Endpoint: "https://api.example.com", EnableTracking: true, WorkspaceID: "workspace-1", MessageID: "message-1"}
+
+	for _, cached := range []bool{false, true} {
+		name := "uncached"
+		if cached {
+			name = "cached"
+		}
+
+		b.Run(name, func(b *testing.B) {
+			for n := 0; n < b.N; n++ {
+				sender := NewQueueMessageSender(nil, nil, nil, nil, nil, nil, "https://api.example.com").(*queueMessageSender)
+				if !cached {
+					sender.renderCache = nil
+				}
+
+				for i := 0; i < batchSize; i++ {
+					if _, err := sender.renderHTML("workspace-1", "message-1", template, renderTestData(i), tracking); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		})
+	}
+}
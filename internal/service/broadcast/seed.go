@@ -0,0 +1,70 @@
+package broadcast
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+)
+
+// seedTestMessage is the task message of a broadcast held after its seed test
+const seedTestMessage = "Seed test sent: awaiting approval"
+
+// sendSeedTest sends a broadcast to the seed addresses of its seed test, before any recipient, and records
+// the outcome of each address on the broadcast. When the seed test requires approval, the broadcast moves
+// to the seed_pending status and true is returned: the main send is held until the seed test is approved.
+func (o *BroadcastOrchestrator) sendSeedTest(ctx context.Context, task *domain.Task, broadcast *domain.Broadcast, broadcastState *domain.SendBroadcastState,
+	send func(recipients []*domain.ContactWithList) (domain.BatchSendResult, error)) (bool, error) {
+
+	seeds := make([]*domain.ContactWithList, 0, len(broadcast.SeedTest.Emails))
+	for _, email := range broadcast.SeedTest.Emails {
+		seeds = append(seeds, &domain.ContactWithList{
+			Contact: &domain.Contact{Email: email},
+			ListID:  broadcast.Audience.PrimaryList(),
+		})
+	}
+
+	// A batch stopped midway fails its remaining seed addresses, they are not sent again
+	result, sendErr := send(seeds)
+	if sendErr != nil {
+		if result.Processed() == 0 {
+			return false, fmt.Errorf("failed to send seed test: %w", sendErr)
+		}
+		o.logger.WithFields(map[string]interface{}{
+			"task_id":      task.ID,
+			"broadcast_id": broadcast.ID,
+			"error":        sendErr.Error(),
+		}).Warn("Seed test stopped before all the seed addresses were sent")
+	}
+
+	now := o.timeProvider.Now().UTC()
+	broadcast.SeedTest.SentAt = &now
+	broadcast.SeedTest.Results = domain.NewSeedTestResults(broadcast.SeedTest.Emails, result)
+	if broadcast.SeedTest.RequireApproval {
+		broadcast.Status = domain.BroadcastStatusSeedPending
+	}
+	broadcast.UpdatedAt = now
+	if err := o.broadcastRepo.UpdateBroadcast(ctx, broadcast); err != nil {
+		o.logger.WithFields(map[string]interface{}{
+			"task_id":      task.ID,
+			"broadcast_id": broadcast.ID,
+			"error":        err.Error(),
+		}).Error("Failed to record seed test results")
+		return false, fmt.Errorf("failed to record seed test results: %w", err)
+	}
+	o.publishPhaseChange(broadcastState, broadcast)
+
+	o.logger.WithFields(map[string]interface{}{
+		"task_id":          task.ID,
+		"broadcast_id":     broadcast.ID,
+		"seed_sent":        result.Sent(),
+		"seed_count":       len(seeds),
+		"require_approval": broadcast.SeedTest.RequireApproval,
+	}).Info("Seed test sent")
+
+	if broadcast.SeedTest.RequireApproval {
+		task.State.Message = seedTestMessage
+		return true, nil
+	}
+	return false, nil
+}
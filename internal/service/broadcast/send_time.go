@@ -0,0 +1,118 @@
+package broadcast
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+)
+
+// sendTimeBucket groups the recipients of a batch delivered at the same time, a nil SendAt is sent immediately
+type sendTimeBucket struct {
+	SendAt     *time.Time
+	Recipients []*domain.ContactWithList
+}
+
+// nextSendTime returns the next start of the given UTC hour within 24 hours of now, nil when now is
+// already within that hour
+func nextSendTime(now time.Time, hour int) *time.Time {
+	now = now.UTC()
+	if now.Hour() == hour {
+		return nil
+	}
+
+	sendAt := time.Date(now.Year(), now.Month(), now.Day(), hour, 0, 0, 0, time.UTC)
+	if sendAt.Before(now) {
+		sendAt = sendAt.Add(24 * time.Hour)
+	}
+	return &sendAt
+}
+
+// bucketBySendTime sets the SendAt of each recipient to the next occurrence of its optimal hour and
+// groups the recipients by it, the immediate bucket first then the later ones in order. Recipients
+// without an optimal hour, having never opened a message, are sent immediately.
+func bucketBySendTime(recipients []*domain.ContactWithList, optimalHours map[string]int, now time.Time) []sendTimeBucket {
+	immediate := sendTimeBucket{}
+	deferred := make(map[time.Time]*sendTimeBucket)
+
+	for _, recipient := range recipients {
+		recipient.SendAt = nil
+		if recipient.Contact == nil {
+			immediate.Recipients = append(immediate.Recipients, recipient)
+			continue
+		}
+
+		hour, ok := optimalHours[recipient.Contact.Email]
+		if !ok {
+			immediate.Recipients = append(immediate.Recipients, recipient)
+			continue
+		}
+
+		sendAt := nextSendTime(now, hour)
+		if sendAt == nil {
+			immediate.Recipients = append(immediate.Recipients, recipient)
+			continue
+		}
+
+		recipient.SendAt = sendAt
+		bucket, exists := deferred[*sendAt]
+		if !exists {
+			bucket = &sendTimeBucket{SendAt: sendAt}
+			deferred[*sendAt] = bucket
+		}
+		bucket.Recipients = append(bucket.Recipients, recipient)
+	}
+
+	buckets := make([]sendTimeBucket, 0, len(deferred)+1)
+	if len(immediate.Recipients) > 0 {
+		buckets = append(buckets, immediate)
+	}
+
+	later := make([]sendTimeBucket, 0, len(deferred))
+	for _, bucket := range deferred {
+		later = append(later, *bucket)
+	}
+	sort.Slice(later, func(i, j int) bool {
+		return later[i].SendAt.Before(*later[j].SendAt)
+	})
+
+	return append(buckets, later...)
+}
+
+// optimizeSendTimes defers the delivery of each recipient to the hour of day it most frequently opened
+// past messages at. The open history is an optimization: when it can't be read the batch is sent immediately.
+func (o *BroadcastOrchestrator) optimizeSendTimes(ctx context.Context, workspaceID, broadcastID string, recipients []*domain.ContactWithList) {
+	emails := make([]string, 0, len(recipients))
+	for _, recipient := range recipients {
+		if recipient.Contact != nil {
+			emails = append(emails, recipient.Contact.Email)
+		}
+	}
+
+	optimalHours, err := o.contactRepo.GetOptimalSendHours(ctx, workspaceID, emails)
+	if err != nil {
+		o.logger.WithFields(map[string]interface{}{
+			"broadcast_id": broadcastID,
+			"workspace_id": workspaceID,
+			"error":        err.Error(),
+		}).Warn("Failed to get optimal send hours, sending the batch immediately")
+		for _, recipient := range recipients {
+			recipient.SendAt = nil
+		}
+		return
+	}
+
+	buckets := bucketBySendTime(recipients, optimalHours, o.timeProvider.Now())
+	for _, bucket := range buckets {
+		fields := map[string]interface{}{
+			"broadcast_id": broadcastID,
+			"workspace_id": workspaceID,
+			"recipients":   len(bucket.Recipients),
+		}
+		if bucket.SendAt != nil {
+			fields["send_at"] = *bucket.SendAt
+		}
+		o.logger.WithFields(fields).Debug("Scheduled broadcast sub-batch at its optimal send time")
+	}
+}
@@ -0,0 +1,131 @@
+package broadcast
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	domainmocks "github.com/Notifuse/notifuse/internal/domain/mocks"
+	pkgmocks "github.com/Notifuse/notifuse/pkg/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextSendTime(t *testing.T) {
+	now := time.Date(2026, 10, 16, 14, 30, 0, 0, time.UTC)
+
+	t.Run("later hour today", func(t *testing.T) {
+		sendAt := nextSendTime(now, 18)
+		require.NotNil(t, sendAt)
+		assert.Equal(t, time.Date(2026, 10, 16, 18, 0, 0, 0, time.UTC), *sendAt)
+	})
+
+	t.Run("earlier hour is tomorrow", func(t *testing.T) {
+		sendAt := nextSendTime(now, 9)
+		require.NotNil(t, sendAt)
+		assert.Equal(t, time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC), *sendAt)
+	})
+
+	t.Run("current hour is immediate", func(t *testing.T) {
+		assert.Nil(t, nextSendTime(now, 14))
+	})
+
+	t.Run("read in UTC", func(t *testing.T) {
+		paris, err := time.LoadLocation("Europe/Paris")
+		require.NoError(t, err)
+
+		// 16:30 in Paris is 14:30 UTC
+		assert.Nil(t, nextSendTime(now.In(paris), 14))
+	})
+}
+
+func TestBucketBySendTime(t *testing.T) {
+	// 14:30 UTC, the 9am opener is next reached tomorrow
+	now := time.Date(2026, 10, 16, 14, 30, 0, 0, time.UTC)
+	tomorrowAt9 := time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+	todayAt20 := time.Date(2026, 10, 16, 20, 0, 0, 0, time.UTC)
+
+	morning := &domain.ContactWithList{Contact: &domain.Contact{Email: "morning@example.com"}}
+	evening := &domain.ContactWithList{Contact: &domain.Contact{Email: "evening@example.com"}}
+	afternoon := &domain.ContactWithList{Contact: &domain.Contact{Email: "afternoon@example.com"}}
+	unknown := &domain.ContactWithList{Contact: &domain.Contact{Email: "unknown@example.com"}}
+	morning2 := &domain.ContactWithList{Contact: &domain.Contact{Email: "morning2@example.com"}}
+
+	optimalHours := map[string]int{
+		"morning@example.com":   9,
+		"morning2@example.com":  9,
+		"evening@example.com":   20,
+		"afternoon@example.com": 14,
+	}
+
+	buckets := bucketBySendTime([]*domain.ContactWithList{morning, evening, afternoon, unknown, morning2}, optimalHours, now)
+	require.Len(t, buckets, 3)
+
+	// Contacts without open history, or whose hour is the current one, are sent immediately
+	assert.Nil(t, buckets[0].SendAt)
+	assert.Equal(t, []*domain.ContactWithList{afternoon, unknown}, buckets[0].Recipients)
+	assert.Nil(t, afternoon.SendAt)
+	assert.Nil(t, unknown.SendAt)
+
+	require.NotNil(t, buckets[1].SendAt)
+	assert.Equal(t, todayAt20, *buckets[1].SendAt)
+	assert.Equal(t, []*domain.ContactWithList{evening}, buckets[1].Recipients)
+
+	// Opens clustered at 9am defer the contacts to 9am the next day
+	require.NotNil(t, buckets[2].SendAt)
+	assert.Equal(t, tomorrowAt9, *buckets[2].SendAt)
+	assert.Equal(t, []*domain.ContactWithList{morning, morning2}, buckets[2].Recipients)
+	require.NotNil(t, morning.SendAt)
+	assert.Equal(t, tomorrowAt9, *morning.SendAt)
+	assert.True(t, morning.SendAt.Sub(now) < 24*time.Hour)
+}
+
+func TestBroadcastOrchestrator_optimizeSendTimes(t *testing.T) {
+	setup := func(t *testing.T) (*BroadcastOrchestrator, *domainmocks.MockContactRepository) {
+		ctrl := gomock.NewController(t)
+		t.Cleanup(ctrl.Finish)
+
+		mockContactRepo := domainmocks.NewMockContactRepository(ctrl)
+		mockLogger := pkgmocks.NewMockLogger(ctrl)
+		mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+		mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
+		mockLogger.EXPECT().Warn(gomock.Any()).AnyTimes()
+
+		clock := &fakeTimeProvider{now: time.Date(2026, 10, 16, 14, 30, 0, 0, time.UTC)}
+		orchestrator := &BroadcastOrchestrator{contactRepo: mockContactRepo, logger: mockLogger, timeProvider: clock}
+		return orchestrator, mockContactRepo
+	}
+
+	t.Run("defers contacts to their optimal hour", func(t *testing.T) {
+		orchestrator, mockContactRepo := setup(t)
+		morning := &domain.ContactWithList{Contact: &domain.Contact{Email: "morning@example.com"}}
+		unknown := &domain.ContactWithList{Contact: &domain.Contact{Email: "unknown@example.com"}}
+
+		mockContactRepo.EXPECT().
+			GetOptimalSendHours(gomock.Any(), "workspace-123", []string{"morning@example.com", "unknown@example.com"}).
+			Return(map[string]int{"morning@example.com": 9}, nil)
+
+		orchestrator.optimizeSendTimes(context.Background(), "workspace-123", "broadcast-123", []*domain.ContactWithList{morning, unknown})
+
+		require.NotNil(t, morning.SendAt)
+		assert.Equal(t, time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC), *morning.SendAt)
+		assert.Nil(t, unknown.SendAt)
+	})
+
+	t.Run("sends immediately when the open history fails", func(t *testing.T) {
+		orchestrator, mockContactRepo := setup(t)
+		stale := time.Date(2026, 10, 17, 9, 0, 0, 0, time.UTC)
+		recipient := &domain.ContactWithList{Contact: &domain.Contact{Email: "morning@example.com"}, SendAt: &stale}
+
+		mockContactRepo.EXPECT().
+			GetOptimalSendHours(gomock.Any(), "workspace-123", []string{"morning@example.com"}).
+			Return(nil, errors.New("db error"))
+
+		orchestrator.optimizeSendTimes(context.Background(), "workspace-123", "broadcast-123", []*domain.ContactWithList{recipient})
+
+		assert.Nil(t, recipient.SendAt)
+	})
+}
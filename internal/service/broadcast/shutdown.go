@@ -0,0 +1,46 @@
+package broadcast
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// ShutdownNotice tells running broadcasts that the server is shutting down and by when their
+// in-flight batch must be done. A nil notice is never signaled.
+type ShutdownNotice struct {
+	deadline atomic.Pointer[time.Time]
+}
+
+// NewShutdownNotice creates a notice that is not signaled yet
+func NewShutdownNotice() *ShutdownNotice {
+	return &ShutdownNotice{}
+}
+
+// Begin signals the shutdown, broadcasts then stop at their next batch boundary and must have
+// checkpointed their progress by the deadline
+func (n *ShutdownNotice) Begin(deadline time.Time) {
+	if n == nil {
+		return
+	}
+	n.deadline.Store(&deadline)
+}
+
+// Deadline returns the shutdown deadline, false when no shutdown has begun
+func (n *ShutdownNotice) Deadline() (time.Time, bool) {
+	if n == nil {
+		return time.Time{}, false
+	}
+	deadline := n.deadline.Load()
+	if deadline == nil {
+		return time.Time{}, false
+	}
+	return *deadline, true
+}
+
+// capToShutdown returns the earliest of t and the shutdown deadline
+func (n *ShutdownNotice) capToShutdown(t time.Time) time.Time {
+	if deadline, ok := n.Deadline(); ok && deadline.Before(t) {
+		return deadline
+	}
+	return t
+}
@@ -0,0 +1,239 @@
+package broadcast
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/pkg/logger"
+	"github.com/Notifuse/notifuse/pkg/notifuse_mjml"
+	"github.com/google/uuid"
+)
+
+// smsMessageSender implements the MessageSender interface for SMS broadcasts.
+// The body of the SMS template is rendered with the same template data as emails, sent through
+// the workspace SMS provider and recorded in message history with the sms channel.
+// Recipients without a phone number are counted as failed.
+type smsMessageSender struct {
+	*queueMessageSender
+	workspaceRepo domain.WorkspaceRepository
+	providers     map[domain.SMSProviderKind]domain.SMSProviderService
+}
+
+// NewSMSMessageSender creates a new message sender that delivers SMS broadcasts through the SMS providers
+func NewSMSMessageSender(
+	broadcastRepo domain.BroadcastRepository,
+	messageHistoryRepo domain.MessageHistoryRepository,
+	workspaceRepo domain.WorkspaceRepository,
+	providers map[domain.SMSProviderKind]domain.SMSProviderService,
+	logger logger.Logger,
+	config *Config,
+) MessageSender {
+	if config == nil {
+		config = DefaultConfig()
+	}
+
+	return &smsMessageSender{
+		queueMessageSender: &queueMessageSender{
+			broadcastRepo:      broadcastRepo,
+			messageHistoryRepo: messageHistoryRepo,
+			logger:             logger,
+			config:             config,
+		},
+		workspaceRepo: workspaceRepo,
+		providers:     providers,
+	}
+}
+
+// SendToRecipient is not supported for SMS, the message history record needs the workspace
+// secret key that only SendBatch receives
+func (s *smsMessageSender) SendToRecipient(
+	ctx context.Context,
+	workspaceID string,
+	integrationID string,
+	trackingEnabled bool,
+	broadcast *domain.Broadcast,
+	messageID string,
+	email string,
+	template *domain.Template,
+	data map[string]interface{},
+	emailProvider *domain.EmailProvider,
+	timeoutAt time.Time,
+) error {
+	return NewBroadcastError(ErrCodeSendFailed, "single recipient sends are not supported for sms", false, nil)
+}
+
+// SendBatch sends the SMS of a batch of recipients through the SMS provider of the integration
+func (s *smsMessageSender) SendBatch(
+	ctx context.Context,
+	workspaceID string,
+	integrationID string,
+	workspaceSecretKey string,
+	endpoint string,
+	trackingEnabled bool,
+	broadcastID string,
+	recipients []*domain.ContactWithList,
+	templates map[string]*domain.Template,
+	emailProvider *domain.EmailProvider,
+	timeoutAt time.Time,
+) (result domain.BatchSendResult, err error) {
+	if len(recipients) == 0 {
+		return result, nil
+	}
+
+	broadcast, err := s.broadcastRepo.GetBroadcast(ctx, workspaceID, broadcastID)
+	if err != nil {
+		return failAll(recipients), fmt.Errorf("failed to get broadcast: %w", err)
+	}
+
+	smsProvider, providerService, err := s.provider(ctx, workspaceID, integrationID)
+	if err != nil {
+		return failAll(recipients), err
+	}
+
+	liquid := notifuse_mjml.NewSecureLiquidEngine()
+
+	for i, recipient := range recipients {
+		if time.Now().After(timeoutAt) {
+			s.logger.WithFields(map[string]interface{}{
+				"broadcast_id": broadcastID,
+				"workspace_id": workspaceID,
+			}).Debug("Timeout reached during sms batch")
+			return result, nil
+		}
+
+		phone := ""
+		if recipient.Contact.Phone != nil && !recipient.Contact.Phone.IsNull {
+			phone = strings.TrimSpace(recipient.Contact.Phone.String)
+		}
+		if phone == "" {
+			result.FailedEmails = append(result.FailedEmails, recipient.Contact.Email)
+			continue
+		}
+
+		template := s.selectTemplate(templates, broadcast, recipient.ListID)
+		if template == nil || template.SMS == nil {
+			result.FailedEmails = append(result.FailedEmails, recipient.Contact.Email)
+			continue
+		}
+
+		messageID := fmt.Sprintf("%s_%s", workspaceID, uuid.New().String())
+
+		data, err := domain.BuildTemplateData(domain.TemplateDataRequest{
+			WorkspaceID:        workspaceID,
+			WorkspaceSecretKey: workspaceSecretKey,
+			ContactWithList:    *recipient,
+			MessageID:          messageID,
+			TrackingSettings: notifuse_mjml.TrackingSettings{
+				Endpoint:    endpoint,
+				WorkspaceID: workspaceID,
+				MessageID:   messageID,
+			},
+			Broadcast: broadcast,
+		})
+		if err != nil {
+			s.logger.WithFields(map[string]interface{}{
+				"broadcast_id": broadcastID,
+				"workspace_id": workspaceID,
+				"recipient":    recipient.Contact.Email,
+				"error":        err.Error(),
+			}).Warn("Failed to build template data")
+			result.FailedEmails = append(result.FailedEmails, recipient.Contact.Email)
+			continue
+		}
+
+		body, err := liquid.Render(template.SMS.Body, data)
+		if err != nil || strings.TrimSpace(body) == "" {
+			s.logger.WithFields(map[string]interface{}{
+				"broadcast_id": broadcastID,
+				"workspace_id": workspaceID,
+				"recipient":    recipient.Contact.Email,
+				"template_id":  template.ID,
+			}).Warn("Failed to render sms body")
+			result.FailedEmails = append(result.FailedEmails, recipient.Contact.Email)
+			continue
+		}
+
+		if err := providerService.SendSMS(ctx, domain.SendSMSRequest{
+			WorkspaceID:   workspaceID,
+			IntegrationID: integrationID,
+			MessageID:     messageID,
+			To:            phone,
+			Body:          body,
+			Provider:      smsProvider,
+		}); err != nil {
+			s.logger.WithFields(map[string]interface{}{
+				"broadcast_id": broadcastID,
+				"workspace_id": workspaceID,
+				"recipient":    recipient.Contact.Email,
+				"error":        err.Error(),
+			}).Error("Failed to send sms")
+			result.FailedEmails = append(result.FailedEmails, recipient.Contact.Email)
+			continue
+		}
+
+		now := time.Now().UTC()
+		message := &domain.MessageHistory{
+			ID:              messageID,
+			ContactEmail:    recipient.Contact.Email,
+			BroadcastID:     &broadcast.ID,
+			TemplateID:      template.ID,
+			TemplateVersion: template.Version,
+			Channel:         domain.ChannelSMS,
+			MessageData:     domain.MessageData{Data: data},
+			SentAt:          now,
+			CreatedAt:       now,
+			UpdatedAt:       now,
+		}
+		if recipient.ListID != "" {
+			listID := recipient.ListID
+			message.ListID = &listID
+		}
+
+		// The SMS is already delivered, a retry of the batch would send it twice
+		if err := s.messageHistoryRepo.Create(ctx, workspaceID, workspaceSecretKey, message); err != nil {
+			s.logger.WithFields(map[string]interface{}{
+				"broadcast_id": broadcastID,
+				"workspace_id": workspaceID,
+				"sent":         result.Sent(),
+				"error":        err.Error(),
+			}).Error("Failed to record sms message")
+			result.SentEmails = append(result.SentEmails, recipient.Contact.Email)
+			result.FailedEmails = append(result.FailedEmails, failAll(recipients[i+1:]).FailedEmails...)
+			return result, NewBroadcastError(ErrCodeSendFailed, "failed to record sms message", false, err)
+		}
+		result.SentEmails = append(result.SentEmails, recipient.Contact.Email)
+	}
+
+	s.logger.WithFields(map[string]interface{}{
+		"broadcast_id":  broadcastID,
+		"workspace_id":  workspaceID,
+		"sent":          result.Sent(),
+		"failed":        result.Failed(),
+		"provider_kind": smsProvider.Kind,
+	}).Debug("SMS batch sent")
+
+	return result, nil
+}
+
+// provider returns the SMS provider settings of the integration and the service sending through it
+func (s *smsMessageSender) provider(ctx context.Context, workspaceID, integrationID string) (*domain.SMSProvider, domain.SMSProviderService, error) {
+	workspace, err := s.workspaceRepo.GetByID(ctx, workspaceID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get workspace: %w", err)
+	}
+
+	integration := workspace.GetIntegrationByID(integrationID)
+	if integration == nil || integration.SMSProvider == nil {
+		return nil, nil, NewBroadcastError(ErrCodeSendFailed, fmt.Sprintf("sms integration %s not found", integrationID), false, nil)
+	}
+
+	providerService, ok := s.providers[integration.SMSProvider.Kind]
+	if !ok {
+		return nil, nil, NewBroadcastError(ErrCodeSendFailed, fmt.Sprintf("unsupported sms provider: %s", integration.SMSProvider.Kind), false, nil)
+	}
+
+	return integration.SMSProvider, providerService, nil
+}
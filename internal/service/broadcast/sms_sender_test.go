@@ -0,0 +1,151 @@
+package broadcast
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/domain/mocks"
+	pkgmocks "github.com/Notifuse/notifuse/pkg/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupSMSSenderTest(t *testing.T) (*mocks.MockBroadcastRepository, *mocks.MockMessageHistoryRepository, *mocks.MockSMSProviderService, MessageSender) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockBroadcastRepo := mocks.NewMockBroadcastRepository(ctrl)
+	mockMessageHistoryRepo := mocks.NewMockMessageHistoryRepository(ctrl)
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	mockSMSProvider := mocks.NewMockSMSProviderService(ctrl)
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Warn(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
+
+	mockBroadcastRepo.EXPECT().GetBroadcast(gomock.Any(), "workspace-1", "broadcast-1").
+		Return(&domain.Broadcast{ID: "broadcast-1", WorkspaceID: "workspace-1", ChannelType: domain.ChannelSMS}, nil).AnyTimes()
+	mockWorkspaceRepo.EXPECT().GetByID(gomock.Any(), "workspace-1").Return(&domain.Workspace{
+		ID: "workspace-1",
+		Integrations: []domain.Integration{
+			{ID: "sms-1", Type: domain.IntegrationTypeSMS, SMSProvider: &domain.SMSProvider{Kind: domain.SMSProviderKindTwilio, Twilio: &domain.TwilioSettings{AccountSID: "AC123", AuthToken: "token", FromNumber: "+15550000000"}}},
+		},
+	}, nil).AnyTimes()
+
+	sender := NewSMSMessageSender(
+		mockBroadcastRepo,
+		mockMessageHistoryRepo,
+		mockWorkspaceRepo,
+		map[domain.SMSProviderKind]domain.SMSProviderService{domain.SMSProviderKindTwilio: mockSMSProvider},
+		mockLogger,
+		TestConfig(),
+	)
+
+	return mockBroadcastRepo, mockMessageHistoryRepo, mockSMSProvider, sender
+}
+
+func smsSenderTestFixtures() ([]*domain.ContactWithList, map[string]*domain.Template) {
+	templates := map[string]*domain.Template{
+		"template-1": {
+			ID:      "template-1",
+			Version: 2,
+			Channel: domain.ChannelSMS,
+			SMS:     &domain.SMSTemplate{Body: "Hello {{ contact.first_name }}"},
+		},
+	}
+
+	recipients := []*domain.ContactWithList{
+		{Contact: &domain.Contact{Email: "one@example.com", FirstName: &domain.NullableString{String: "Ann"}, Phone: &domain.NullableString{String: "+33600000001"}}, ListID: "list-1"},
+		{Contact: &domain.Contact{Email: "two@example.com", FirstName: &domain.NullableString{String: "Bob"}, Phone: &domain.NullableString{String: "+33600000002"}}, ListID: "list-1"},
+	}
+
+	return recipients, templates
+}
+
+func TestSMSMessageSender_SendBatch(t *testing.T) {
+	t.Run("Sends through the sms provider and records sms message history", func(t *testing.T) {
+		_, mockMessageHistoryRepo, mockSMSProvider, sender := setupSMSSenderTest(t)
+		recipients, templates := smsSenderTestFixtures()
+
+		var requests []domain.SendSMSRequest
+		mockSMSProvider.EXPECT().SendSMS(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, request domain.SendSMSRequest) error {
+				requests = append(requests, request)
+				return nil
+			}).Times(2)
+
+		var recorded []*domain.MessageHistory
+		mockMessageHistoryRepo.EXPECT().Create(gomock.Any(), "workspace-1", "secret-key", gomock.Any()).
+			DoAndReturn(func(_ context.Context, _, _ string, message *domain.MessageHistory) error {
+				recorded = append(recorded, message)
+				return nil
+			}).Times(2)
+
+		result, err := sender.SendBatch(context.Background(), "workspace-1", "sms-1", "secret-key", "https://api.example.com", true, "broadcast-1", recipients, templates, nil, time.Now().Add(time.Minute))
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.Sent())
+		assert.Equal(t, 0, result.Failed())
+
+		require.Len(t, requests, 2)
+		assert.Equal(t, "+33600000001", requests[0].To)
+		assert.Equal(t, "Hello Ann", requests[0].Body)
+		assert.Equal(t, "sms-1", requests[0].IntegrationID)
+		assert.Equal(t, domain.SMSProviderKindTwilio, requests[0].Provider.Kind)
+
+		require.Len(t, recorded, 2)
+		for i, message := range recorded {
+			assert.Equal(t, "sms", message.Channel)
+			assert.Equal(t, requests[i].MessageID, message.ID)
+			assert.Equal(t, "template-1", message.TemplateID)
+			assert.Equal(t, int64(2), message.TemplateVersion)
+			require.NotNil(t, message.BroadcastID)
+			assert.Equal(t, "broadcast-1", *message.BroadcastID)
+			require.NotNil(t, message.ListID)
+			assert.Equal(t, "list-1", *message.ListID)
+		}
+		assert.Equal(t, "one@example.com", recorded[0].ContactEmail)
+	})
+
+	t.Run("Counts contacts without phone as failed", func(t *testing.T) {
+		_, mockMessageHistoryRepo, mockSMSProvider, sender := setupSMSSenderTest(t)
+		recipients, templates := smsSenderTestFixtures()
+		recipients[0].Contact.Phone = nil
+
+		mockSMSProvider.EXPECT().SendSMS(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+		mockMessageHistoryRepo.EXPECT().Create(gomock.Any(), "workspace-1", "secret-key", gomock.Any()).Return(nil).Times(1)
+
+		result, err := sender.SendBatch(context.Background(), "workspace-1", "sms-1", "secret-key", "https://api.example.com", true, "broadcast-1", recipients, templates, nil, time.Now().Add(time.Minute))
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Sent())
+		assert.Equal(t, 1, result.Failed())
+	})
+
+	t.Run("Provider errors are counted as failed and not recorded", func(t *testing.T) {
+		_, _, mockSMSProvider, sender := setupSMSSenderTest(t)
+		recipients, templates := smsSenderTestFixtures()
+
+		// The message history repository has no expectations, any record fails the test
+		mockSMSProvider.EXPECT().SendSMS(gomock.Any(), gomock.Any()).Return(errors.New("twilio API error (400)")).Times(2)
+
+		result, err := sender.SendBatch(context.Background(), "workspace-1", "sms-1", "secret-key", "https://api.example.com", true, "broadcast-1", recipients, templates, nil, time.Now().Add(time.Minute))
+		require.NoError(t, err)
+		assert.Equal(t, 0, result.Sent())
+		assert.Equal(t, 2, result.Failed())
+	})
+
+	t.Run("Unknown integration fails the whole batch", func(t *testing.T) {
+		_, _, _, sender := setupSMSSenderTest(t)
+		recipients, templates := smsSenderTestFixtures()
+
+		result, err := sender.SendBatch(context.Background(), "workspace-1", "missing", "secret-key", "https://api.example.com", true, "broadcast-1", recipients, templates, nil, time.Now().Add(time.Minute))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "sms integration missing not found")
+		assert.Equal(t, 0, result.Sent())
+		assert.Equal(t, 2, result.Failed())
+	})
+}
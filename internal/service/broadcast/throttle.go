@@ -0,0 +1,71 @@
+package broadcast
+
+import (
+	"context"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+)
+
+// throttleWindow is the period over which the send rate of a throttled broadcast is measured
+const throttleWindow = time.Second
+
+// sendRateLimit returns the maximum sends per second of a broadcast, 0 when it is not throttled
+func (o *BroadcastOrchestrator) sendRateLimit(broadcast *domain.Broadcast) int {
+	if broadcast != nil && broadcast.Schedule.MaxSendsPerSecond > 0 {
+		return broadcast.Schedule.MaxSendsPerSecond
+	}
+	if o.config.MaxSendsPerSecond > 0 {
+		return o.config.MaxSendsPerSecond
+	}
+	return 0
+}
+
+// pruneRecentSends drops the sends that are out of the throttle window ending at now
+func pruneRecentSends(recent []domain.ThrottledSend, now time.Time) []domain.ThrottledSend {
+	kept := recent[:0]
+	for _, send := range recent {
+		if now.Sub(send.At) < throttleWindow {
+			kept = append(kept, send)
+		}
+	}
+	if len(kept) == 0 {
+		return nil
+	}
+	return kept
+}
+
+// sendAllowance returns how many recipients may be sent at now without exceeding maxPerSecond
+// within the throttle window. When none may be sent, it also returns how long to wait for the
+// oldest send to leave the window.
+func sendAllowance(recent []domain.ThrottledSend, maxPerSecond int, now time.Time) (int, time.Duration) {
+	sentInWindow := 0
+	var oldest time.Time
+	for _, send := range recent {
+		if now.Sub(send.At) >= throttleWindow {
+			continue
+		}
+		sentInWindow += send.Count
+		if oldest.IsZero() || send.At.Before(oldest) {
+			oldest = send.At
+		}
+	}
+
+	if allowance := maxPerSecond - sentInWindow; allowance > 0 {
+		return allowance, 0
+	}
+	return 0, oldest.Add(throttleWindow).Sub(now)
+}
+
+// sleepContext waits for d or until ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
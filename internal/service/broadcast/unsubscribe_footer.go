@@ -0,0 +1,61 @@
+package broadcast
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+)
+
+// unsubscribeFooterHTML is added at the end of the HTML body of emails whose template has no unsubscribe link
+const unsubscribeFooterHTML = `<div style="padding:16px 0;text-align:center;font-family:Arial,sans-serif;font-size:12px;color:#8c8c8c;">` +
+	`<a href="%s" style="color:#8c8c8c;text-decoration:underline;">Unsubscribe</a></div>`
+
+// injectUnsubscribeLink adds an unsubscribe footer to the content of a queued email and sets its
+// List-Unsubscribe headers. It returns false when the template data holds no unsubscribe URL,
+// which is the case for recipients without list.
+func injectUnsubscribeLink(payload *domain.EmailQueuePayload, data map[string]interface{}) bool {
+	oneClickURL, _ := data["oneclick_unsubscribe_url"].(string)
+	unsubscribeURL, _ := data["unsubscribe_url"].(string)
+	if unsubscribeURL == "" {
+		unsubscribeURL = oneClickURL
+	}
+	if unsubscribeURL == "" {
+		return false
+	}
+
+	if payload.HTMLContent != "" {
+		footer := fmt.Sprintf(unsubscribeFooterHTML, html.EscapeString(unsubscribeURL))
+		// The footer goes inside the body when there is one
+		if index := strings.LastIndex(strings.ToLower(payload.HTMLContent), "</body>"); index >= 0 {
+			payload.HTMLContent = payload.HTMLContent[:index] + footer + payload.HTMLContent[index:]
+		} else {
+			payload.HTMLContent += footer
+		}
+	}
+	if payload.TextContent != "" {
+		payload.TextContent += "\n\nUnsubscribe: " + unsubscribeURL
+	}
+
+	if oneClickURL != "" {
+		payload.EmailOptions.ListUnsubscribeURL = oneClickURL
+	}
+	return true
+}
+
+// ensureUnsubscribeLink adds the unsubscribe footer and headers to a queued email whose template
+// has no unsubscribe link
+func (s *queueMessageSender) ensureUnsubscribeLink(workspaceID, broadcastID string, entry *domain.EmailQueueEntry, template *domain.Template, data map[string]interface{}) {
+	if template.Email.HasUnsubscribeLink() {
+		return
+	}
+	if !injectUnsubscribeLink(&entry.Payload, data) {
+		s.logger.WithFields(map[string]interface{}{
+			"broadcast_id": broadcastID,
+			"workspace_id": workspaceID,
+			"recipient":    entry.ContactEmail,
+			"template_id":  template.ID,
+		}).Warn("No unsubscribe URL to add to an email without unsubscribe link")
+	}
+}
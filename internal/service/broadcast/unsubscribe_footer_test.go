@@ -0,0 +1,142 @@
+package broadcast
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/domain/mocks"
+	pkgmocks "github.com/Notifuse/notifuse/pkg/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueueMessageSender_SendBatch_UnsubscribeLink(t *testing.T) {
+	sendBatch := func(t *testing.T, requireUnsubscribeLink bool, content string) *domain.EmailQueueEntry {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockQueueRepo := mocks.NewMockEmailQueueRepository(ctrl)
+		mockBroadcastRepo := mocks.NewMockBroadcastRepository(ctrl)
+		mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+		mockLogger := pkgmocks.NewMockLogger(ctrl)
+		mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+		mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
+
+		emailSender := domain.NewEmailSender("sender@example.com", "Test Sender")
+		emailProvider := &domain.EmailProvider{
+			Kind:    domain.EmailProviderKindSMTP,
+			Senders: []domain.EmailSender{emailSender},
+		}
+		template := &domain.Template{
+			ID: "template-1",
+			Email: &domain.EmailTemplate{
+				SenderID:         emailSender.ID,
+				Subject:          "Spring news",
+				VisualEditorTree: createQueueValidTestTree(createQueueTestTextBlock("txt1", content)),
+			},
+		}
+
+		mockWorkspaceRepo.EXPECT().GetByID(gomock.Any(), "workspace-1").Return(&domain.Workspace{
+			ID:       "workspace-1",
+			Settings: domain.WorkspaceSettings{RequireUnsubscribeLink: requireUnsubscribeLink},
+		}, nil)
+		mockBroadcastRepo.EXPECT().GetBroadcast(gomock.Any(), "workspace-1", "broadcast-1").
+			Return(&domain.Broadcast{ID: "broadcast-1", WorkspaceID: "workspace-1"}, nil)
+
+		var enqueued []*domain.EmailQueueEntry
+		mockQueueRepo.EXPECT().Enqueue(gomock.Any(), "workspace-1", gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, entries []*domain.EmailQueueEntry) error {
+				enqueued = append(enqueued, entries...)
+				return nil
+			})
+
+		sender := NewQueueMessageSender(mockQueueRepo, mockBroadcastRepo, nil, nil, mockLogger, nil, "https://api.example.com").(*queueMessageSender)
+		sender.workspaceRepo = mockWorkspaceRepo
+
+		recipients := []*domain.ContactWithList{
+			{Contact: &domain.Contact{Email: "ada@example.com"}, ListID: "newsletter", ListName: "Newsletter"},
+		}
+		result, err := sender.SendBatch(
+			context.Background(),
+			"workspace-1",
+			"integration-1",
+			"secret-key",
+			"https://api.example.com",
+			false,
+			"broadcast-1",
+			recipients,
+			map[string]*domain.Template{"template-1": template},
+			emailProvider,
+			time.Now().Add(5*time.Minute),
+		)
+
+		require.NoError(t, err)
+		require.Equal(t, 1, result.Sent())
+		require.Len(t, enqueued, 1)
+		return enqueued[0]
+	}
+
+	t.Run("template without unsubscribe link gets the footer and headers", func(t *testing.T) {
+		entry := sendBatch(t, true, "Hello")
+
+		html := entry.Payload.HTMLContent
+		footerAt := strings.Index(html, ">Unsubscribe</a></div>")
+		require.NotEqual(t, -1, footerAt, "footer missing from %s", html)
+		assert.Contains(t, html, `<a href="https://api.example.com/notification-center?action=unsubscribe`)
+		assert.Less(t, footerAt, strings.LastIndex(strings.ToLower(html), "</body>"))
+
+		assert.True(t, strings.HasPrefix(entry.Payload.EmailOptions.ListUnsubscribeURL, "https://api.example.com/unsubscribe-oneclick?"))
+		assert.Contains(t, entry.Payload.EmailOptions.ListUnsubscribeURL, "lids=newsletter")
+	})
+
+	t.Run("template with an unsubscribe link is left as is", func(t *testing.T) {
+		entry := sendBatch(t, true, `Hello <a href="{{ unsubscribe_url }}">Leave</a>`)
+
+		assert.NotContains(t, entry.Payload.HTMLContent, ">Unsubscribe</a></div>")
+		assert.Contains(t, entry.Payload.HTMLContent, ">Leave</a>")
+		assert.NotEmpty(t, entry.Payload.EmailOptions.ListUnsubscribeURL)
+	})
+
+	t.Run("workspace not requiring the link gets no footer", func(t *testing.T) {
+		entry := sendBatch(t, false, "Hello")
+
+		assert.NotContains(t, entry.Payload.HTMLContent, ">Unsubscribe</a></div>")
+	})
+}
+
+func TestInjectUnsubscribeLink(t *testing.T) {
+	data := map[string]interface{}{
+		"unsubscribe_url":          "https://api.example.com/notification-center?action=unsubscribe&lid=newsletter",
+		"oneclick_unsubscribe_url": "https://api.example.com/unsubscribe-oneclick?lids=newsletter",
+	}
+
+	t.Run("html body", func(t *testing.T) {
+		payload := &domain.EmailQueuePayload{HTMLContent: "<html><BODY><p>Hello</p></BODY></html>"}
+
+		require.True(t, injectUnsubscribeLink(payload, data))
+		assert.Equal(t, `<html><BODY><p>Hello</p><div style="padding:16px 0;text-align:center;font-family:Arial,sans-serif;font-size:12px;color:#8c8c8c;">`+
+			`<a href="https://api.example.com/notification-center?action=unsubscribe&amp;lid=newsletter" style="color:#8c8c8c;text-decoration:underline;">Unsubscribe</a></div></BODY></html>`,
+			payload.HTMLContent)
+		assert.Equal(t, "https://api.example.com/unsubscribe-oneclick?lids=newsletter", payload.EmailOptions.ListUnsubscribeURL)
+	})
+
+	t.Run("plain text only", func(t *testing.T) {
+		payload := &domain.EmailQueuePayload{TextContent: "Hello"}
+
+		require.True(t, injectUnsubscribeLink(payload, data))
+		assert.Equal(t, "Hello\n\nUnsubscribe: https://api.example.com/notification-center?action=unsubscribe&lid=newsletter", payload.TextContent)
+		assert.Empty(t, payload.HTMLContent)
+	})
+
+	t.Run("no unsubscribe URL without list", func(t *testing.T) {
+		payload := &domain.EmailQueuePayload{HTMLContent: "<html><body>Hello</body></html>"}
+
+		assert.False(t, injectUnsubscribeLink(payload, map[string]interface{}{}))
+		assert.Equal(t, "<html><body>Hello</body></html>", payload.HTMLContent)
+		assert.Empty(t, payload.EmailOptions.ListUnsubscribeURL)
+	})
+}
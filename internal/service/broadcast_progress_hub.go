@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"sync"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/pkg/logger"
+)
+
+// progressSubscriberBuffer is the number of progress updates buffered for a subscriber, updates
+// are dropped for subscribers that fall further behind so that a slow client never blocks the bus
+const progressSubscriberBuffer = 16
+
+// BroadcastProgressHub fans out the progress events published by the broadcast orchestrator to
+// the clients following a broadcast. It subscribes to the event bus once and keeps its own
+// subscriber lists, so that clients can come and go without touching the bus.
+type BroadcastProgressHub struct {
+	logger      logger.Logger
+	mu          sync.RWMutex
+	subscribers map[string]map[chan domain.BroadcastProgress]struct{}
+}
+
+// NewBroadcastProgressHub creates a new broadcast progress hub
+func NewBroadcastProgressHub(logger logger.Logger) *BroadcastProgressHub {
+	return &BroadcastProgressHub{
+		logger:      logger,
+		subscribers: make(map[string]map[chan domain.BroadcastProgress]struct{}),
+	}
+}
+
+// RegisterWithEventBus subscribes the hub to the broadcast progress events
+func (h *BroadcastProgressHub) RegisterWithEventBus(eventBus domain.EventBus) {
+	eventBus.Subscribe(domain.EventBroadcastProgress, h.HandleProgressEvent)
+}
+
+// SubscribeProgress returns a channel receiving the progress updates of a broadcast and a
+// function that ends the subscription and closes the channel
+func (h *BroadcastProgressHub) SubscribeProgress(workspaceID, broadcastID string) (<-chan domain.BroadcastProgress, func()) {
+	key := progressKey(workspaceID, broadcastID)
+	ch := make(chan domain.BroadcastProgress, progressSubscriberBuffer)
+
+	h.mu.Lock()
+	if h.subscribers[key] == nil {
+		h.subscribers[key] = make(map[chan domain.BroadcastProgress]struct{})
+	}
+	h.subscribers[key][ch] = struct{}{}
+	h.mu.Unlock()
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			h.mu.Lock()
+			defer h.mu.Unlock()
+
+			delete(h.subscribers[key], ch)
+			if len(h.subscribers[key]) == 0 {
+				delete(h.subscribers, key)
+			}
+			close(ch)
+		})
+	}
+
+	return ch, unsubscribe
+}
+
+// HandleProgressEvent forwards a broadcast progress event to the subscribers of the broadcast
+func (h *BroadcastProgressHub) HandleProgressEvent(ctx context.Context, payload domain.EventPayload) {
+	progress := domain.BroadcastProgress{
+		BroadcastID: payload.EntityID,
+		Processed:   intFromEventData(payload.Data, "processed"),
+		Total:       intFromEventData(payload.Data, "total"),
+		Sent:        intFromEventData(payload.Data, "sent"),
+		Failed:      intFromEventData(payload.Data, "failed"),
+		ETASeconds:  intFromEventData(payload.Data, "eta_seconds"),
+	}
+	if percent, ok := payload.Data["percent"].(float64); ok {
+		progress.Percent = percent
+	}
+	if message, ok := payload.Data["message"].(string); ok {
+		progress.Message = message
+	}
+
+	// The lock is held while sending so that an unsubscribe can't close a channel mid-send,
+	// the sends never block
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	for ch := range h.subscribers[progressKey(payload.WorkspaceID, payload.EntityID)] {
+		select {
+		case ch <- progress:
+		default:
+			h.logger.WithFields(map[string]interface{}{
+				"workspace_id": payload.WorkspaceID,
+				"broadcast_id": payload.EntityID,
+			}).Debug("Dropped broadcast progress update for a slow subscriber")
+		}
+	}
+}
+
+// progressKey identifies the subscribers of a broadcast, broadcast IDs are only unique within a workspace
+func progressKey(workspaceID, broadcastID string) string {
+	return workspaceID + "/" + broadcastID
+}
+
+// intFromEventData reads an integer from event data, which holds float64 numbers once it went
+// through JSON
+func intFromEventData(data map[string]interface{}, key string) int {
+	switch value := data[key].(type) {
+	case int:
+		return value
+	case int64:
+		return int(value)
+	case float64:
+		return int(value)
+	default:
+		return 0
+	}
+}
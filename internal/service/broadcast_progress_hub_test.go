@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	pkgmocks "github.com/Notifuse/notifuse/pkg/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func progressEvent(workspaceID, broadcastID string, processed int) domain.EventPayload {
+	return domain.EventPayload{
+		Type:        domain.EventBroadcastProgress,
+		WorkspaceID: workspaceID,
+		EntityID:    broadcastID,
+		Data: map[string]interface{}{
+			"processed":   processed,
+			"total":       100,
+			"percent":     float64(processed),
+			"sent":        processed - 1,
+			"failed":      1,
+			"eta_seconds": 90,
+			"message":     "Processed recipients",
+		},
+	}
+}
+
+func receiveProgress(t *testing.T, ch <-chan domain.BroadcastProgress) domain.BroadcastProgress {
+	t.Helper()
+	select {
+	case progress, ok := <-ch:
+		require.True(t, ok, "channel closed")
+		return progress
+	case <-time.After(2 * time.Second):
+		t.Fatal("no progress update received")
+		return domain.BroadcastProgress{}
+	}
+}
+
+func TestBroadcastProgressHub(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
+
+	t.Run("published progress reaches the subscribers through the event bus", func(t *testing.T) {
+		eventBus := domain.NewInMemoryEventBus()
+		hub := NewBroadcastProgressHub(mockLogger)
+		hub.RegisterWithEventBus(eventBus)
+
+		first, unsubscribeFirst := hub.SubscribeProgress("workspace1", "broadcast1")
+		defer unsubscribeFirst()
+		second, unsubscribeSecond := hub.SubscribeProgress("workspace1", "broadcast1")
+		defer unsubscribeSecond()
+
+		eventBus.Publish(context.Background(), progressEvent("workspace1", "broadcast1", 40))
+
+		for _, ch := range []<-chan domain.BroadcastProgress{first, second} {
+			progress := receiveProgress(t, ch)
+			assert.Equal(t, domain.BroadcastProgress{
+				BroadcastID: "broadcast1",
+				Processed:   40,
+				Total:       100,
+				Percent:     40,
+				Sent:        39,
+				Failed:      1,
+				ETASeconds:  90,
+				Message:     "Processed recipients",
+			}, progress)
+		}
+	})
+
+	t.Run("only the subscribers of the broadcast receive its progress", func(t *testing.T) {
+		hub := NewBroadcastProgressHub(mockLogger)
+		other, unsubscribeOther := hub.SubscribeProgress("workspace2", "broadcast1")
+		defer unsubscribeOther()
+		ch, unsubscribe := hub.SubscribeProgress("workspace1", "broadcast1")
+		defer unsubscribe()
+
+		hub.HandleProgressEvent(context.Background(), progressEvent("workspace1", "broadcast1", 10))
+
+		assert.Equal(t, 10, receiveProgress(t, ch).Processed)
+		assert.Empty(t, other)
+	})
+
+	t.Run("unsubscribe closes the channel and stops the updates", func(t *testing.T) {
+		hub := NewBroadcastProgressHub(mockLogger)
+		ch, unsubscribe := hub.SubscribeProgress("workspace1", "broadcast1")
+
+		unsubscribe()
+		unsubscribe() // a second call is a no-op
+
+		_, ok := <-ch
+		assert.False(t, ok)
+		assert.NotPanics(t, func() {
+			hub.HandleProgressEvent(context.Background(), progressEvent("workspace1", "broadcast1", 10))
+		})
+		assert.Empty(t, hub.subscribers)
+	})
+
+	t.Run("updates are dropped for a subscriber that falls behind", func(t *testing.T) {
+		hub := NewBroadcastProgressHub(mockLogger)
+		ch, unsubscribe := hub.SubscribeProgress("workspace1", "broadcast1")
+		defer unsubscribe()
+
+		for i := 0; i < progressSubscriberBuffer+5; i++ {
+			hub.HandleProgressEvent(context.Background(), progressEvent("workspace1", "broadcast1", i+1))
+		}
+
+		assert.Len(t, ch, progressSubscriberBuffer)
+		assert.Equal(t, 1, receiveProgress(t, ch).Processed)
+	})
+}
@@ -13,6 +13,9 @@ import (
 	"github.com/google/uuid"
 )
 
+// broadcastAudienceUpsertBatchSize is the number of uploaded recipients upserted as contacts per statement
+const broadcastAudienceUpsertBatchSize = 500
+
 // BroadcastService handles all broadcast-related operations
 type BroadcastService struct {
 	logger             logger.Logger
@@ -27,7 +30,9 @@ type BroadcastService struct {
 	eventBus           domain.EventBus
 	messageHistoryRepo domain.MessageHistoryRepository
 	listService        domain.ListService
+	audienceRepo       domain.BroadcastAudienceRepository
 	apiEndpoint        string
+	statsCache         *BroadcastStatsCache
 }
 
 // NewBroadcastService creates a new broadcast service
@@ -68,6 +73,16 @@ func (s *BroadcastService) SetTaskService(taskService domain.TaskService) {
 	s.taskService = taskService
 }
 
+// SetAudienceRepository sets the repository of the uploaded recipients of broadcasts with a CSV audience
+func (s *BroadcastService) SetAudienceRepository(audienceRepo domain.BroadcastAudienceRepository) {
+	s.audienceRepo = audienceRepo
+}
+
+// SetStatsCache sets the cache of the broadcast stats, shared with the webhook ingestion that invalidates it
+func (s *BroadcastService) SetStatsCache(cache *BroadcastStatsCache) {
+	s.statsCache = cache
+}
+
 // CreateBroadcast creates a new broadcast
 func (s *BroadcastService) CreateBroadcast(ctx context.Context, request *domain.CreateBroadcastRequest) (*domain.Broadcast, error) {
 	// Authenticate user for workspace
@@ -243,6 +258,191 @@ func (s *BroadcastService) ListBroadcasts(ctx context.Context, params domain.Lis
 	return response, nil
 }
 
+// SetBroadcastTags replaces the tags of a broadcast. Unlike UpdateBroadcast it is allowed whatever
+// the broadcast status, so that sent broadcasts can be organized too.
+func (s *BroadcastService) SetBroadcastTags(ctx context.Context, request *domain.SetBroadcastTagsRequest) (*domain.Broadcast, error) {
+	ctx, _, userWorkspace, err := s.authService.AuthenticateUserForWorkspace(ctx, request.WorkspaceID)
+	if err != nil {
+		s.logger.WithField("broadcast_id", request.ID).Error("Failed to authenticate user for workspace")
+		return nil, fmt.Errorf("failed to authenticate user: %w", err)
+	}
+
+	if !userWorkspace.HasPermission(domain.PermissionResourceBroadcasts, domain.PermissionTypeWrite) {
+		return nil, domain.NewPermissionError(
+			domain.PermissionResourceBroadcasts,
+			domain.PermissionTypeWrite,
+			"Insufficient permissions: write access to broadcasts required",
+		)
+	}
+
+	if err := request.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.SetBroadcastTags(ctx, request.WorkspaceID, request.ID, request.Tags); err != nil {
+		s.logger.WithField("broadcast_id", request.ID).Error("Failed to set broadcast tags")
+		return nil, err
+	}
+
+	return s.repo.GetBroadcast(ctx, request.WorkspaceID, request.ID)
+}
+
+// ListBroadcastTags lists the tags used by the broadcasts of a workspace
+func (s *BroadcastService) ListBroadcastTags(ctx context.Context, workspaceID string) ([]*domain.BroadcastTagCount, error) {
+	ctx, _, userWorkspace, err := s.authService.AuthenticateUserForWorkspace(ctx, workspaceID)
+	if err != nil {
+		s.logger.Error("Failed to authenticate user for workspace")
+		return nil, fmt.Errorf("failed to authenticate user: %w", err)
+	}
+
+	if !userWorkspace.HasPermission(domain.PermissionResourceBroadcasts, domain.PermissionTypeRead) {
+		return nil, domain.NewPermissionError(
+			domain.PermissionResourceBroadcasts,
+			domain.PermissionTypeRead,
+			"Insufficient permissions: read access to broadcasts required",
+		)
+	}
+
+	return s.repo.ListBroadcastTags(ctx, workspaceID)
+}
+
+// DeleteBroadcastTag removes a tag from every broadcast of a workspace
+func (s *BroadcastService) DeleteBroadcastTag(ctx context.Context, request *domain.DeleteBroadcastTagRequest) error {
+	ctx, _, userWorkspace, err := s.authService.AuthenticateUserForWorkspace(ctx, request.WorkspaceID)
+	if err != nil {
+		s.logger.Error("Failed to authenticate user for workspace")
+		return fmt.Errorf("failed to authenticate user: %w", err)
+	}
+
+	if !userWorkspace.HasPermission(domain.PermissionResourceBroadcasts, domain.PermissionTypeWrite) {
+		return domain.NewPermissionError(
+			domain.PermissionResourceBroadcasts,
+			domain.PermissionTypeWrite,
+			"Insufficient permissions: write access to broadcasts required",
+		)
+	}
+
+	if err := request.Validate(); err != nil {
+		return err
+	}
+
+	updated, err := s.repo.DeleteBroadcastTag(ctx, request.WorkspaceID, request.Tag)
+	if err != nil {
+		s.logger.WithField("tag", request.Tag).Error("Failed to delete broadcast tag")
+		return err
+	}
+
+	s.logger.WithFields(map[string]interface{}{
+		"workspace_id": request.WorkspaceID,
+		"tag":          request.Tag,
+		"broadcasts":   updated,
+	}).Info("Broadcast tag deleted")
+
+	return nil
+}
+
+// UploadBroadcastAudience replaces the uploaded recipients of a broadcast with a CSV audience.
+// The recipients are not added to the contacts unless the request asks to upsert them.
+func (s *BroadcastService) UploadBroadcastAudience(ctx context.Context, request *domain.UploadBroadcastAudienceRequest) (*domain.UploadBroadcastAudienceResponse, error) {
+	ctx, _, userWorkspace, err := s.authService.AuthenticateUserForWorkspace(ctx, request.WorkspaceID)
+	if err != nil {
+		s.logger.WithField("broadcast_id", request.BroadcastID).Error("Failed to authenticate user for workspace")
+		return nil, fmt.Errorf("failed to authenticate user: %w", err)
+	}
+
+	if !userWorkspace.HasPermission(domain.PermissionResourceBroadcasts, domain.PermissionTypeWrite) {
+		return nil, domain.NewPermissionError(
+			domain.PermissionResourceBroadcasts,
+			domain.PermissionTypeWrite,
+			"Insufficient permissions: write access to broadcasts required",
+		)
+	}
+
+	if request.UpsertContacts && !userWorkspace.HasPermission(domain.PermissionResourceContacts, domain.PermissionTypeWrite) {
+		return nil, domain.NewPermissionError(
+			domain.PermissionResourceContacts,
+			domain.PermissionTypeWrite,
+			"Insufficient permissions: write access to contacts required",
+		)
+	}
+
+	recipients, err := request.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	if s.audienceRepo == nil {
+		return nil, fmt.Errorf("CSV audiences are not available")
+	}
+
+	broadcast, err := s.repo.GetBroadcast(ctx, request.WorkspaceID, request.BroadcastID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !broadcast.Audience.CSV {
+		return nil, fmt.Errorf("%w: broadcast audience is not a CSV audience", domain.ErrBroadcastAudienceUploadRejected)
+	}
+
+	// The audience is read while sending, it cannot change once the broadcast started
+	if broadcast.Status != domain.BroadcastStatusDraft && broadcast.Status != domain.BroadcastStatusScheduled {
+		return nil, fmt.Errorf("%w: broadcast status is %s", domain.ErrBroadcastAudienceUploadRejected, broadcast.Status)
+	}
+
+	if err := s.audienceRepo.ReplaceRecipients(ctx, request.WorkspaceID, request.BroadcastID, recipients); err != nil {
+		s.logger.WithField("broadcast_id", request.BroadcastID).Error("Failed to store broadcast audience")
+		return nil, err
+	}
+
+	response := &domain.UploadBroadcastAudienceResponse{Recipients: len(recipients)}
+
+	if request.UpsertContacts {
+		upserted, err := s.upsertAudienceContacts(ctx, request.WorkspaceID, recipients)
+		if err != nil {
+			s.logger.WithField("broadcast_id", request.BroadcastID).Error("Failed to upsert broadcast audience contacts")
+			return nil, err
+		}
+		response.UpsertedContacts = upserted
+	}
+
+	s.logger.WithFields(map[string]interface{}{
+		"workspace_id":      request.WorkspaceID,
+		"broadcast_id":      request.BroadcastID,
+		"recipients":        response.Recipients,
+		"upserted_contacts": response.UpsertedContacts,
+	}).Info("Broadcast audience uploaded")
+
+	return response, nil
+}
+
+// upsertAudienceContacts creates or updates the contacts of uploaded recipients in batches
+func (s *BroadcastService) upsertAudienceContacts(ctx context.Context, workspaceID string, recipients []*domain.BroadcastAudienceRecipient) (int, error) {
+	upserted := 0
+	for start := 0; start < len(recipients); start += broadcastAudienceUpsertBatchSize {
+		end := start + broadcastAudienceUpsertBatchSize
+		if end > len(recipients) {
+			end = len(recipients)
+		}
+
+		contacts := make([]*domain.Contact, 0, end-start)
+		for _, recipient := range recipients[start:end] {
+			contact, err := recipient.ToContact()
+			if err != nil {
+				return upserted, fmt.Errorf("invalid recipient %s: %w", recipient.Email, err)
+			}
+			contacts = append(contacts, contact)
+		}
+
+		results, err := s.contactRepo.BulkUpsertContacts(ctx, workspaceID, contacts)
+		if err != nil {
+			return upserted, fmt.Errorf("failed to upsert contacts: %w", err)
+		}
+		upserted += len(results)
+	}
+
+	return upserted, nil
+}
+
 // ScheduleBroadcast schedules a broadcast for sending
 func (s *BroadcastService) ScheduleBroadcast(ctx context.Context, request *domain.ScheduleBroadcastRequest) error {
 	// Authenticate user for workspace
@@ -278,6 +478,20 @@ func (s *BroadcastService) ScheduleBroadcast(ctx context.Context, request *domai
 		return fmt.Errorf("no marketing email provider configured for this workspace")
 	}
 
+	// Refuse to send while a complaint spike blocks the workspace
+	if err := workspace.Settings.CheckSendingAllowed(); err != nil {
+		s.logger.WithField("broadcast_id", request.ID).Warn("Broadcast schedule rejected while workspace sending is blocked")
+		return err
+	}
+
+	// Require a recent preflight when the workspace enforces send confirmation
+	if settings := workspace.Settings.SendConfirmation; settings != nil && settings.Enabled {
+		if err := domain.VerifySendConfirmationToken(workspace.Settings.SecretKey, request.ConfirmationToken, request.WorkspaceID, request.ID, time.Now()); err != nil {
+			s.logger.WithField("broadcast_id", request.ID).Warn("Broadcast schedule rejected without a valid confirmation token")
+			return err
+		}
+	}
+
 	// Using a channel to wait for the event callback
 	done := make(chan error, 1)
 
@@ -297,11 +511,49 @@ func (s *BroadcastService) ScheduleBroadcast(ctx context.Context, request *domai
 			return err
 		}
 
+		// The marketing provider would reject every message of a template with tags beyond its limits
+		if err := s.validateProviderTags(ctx, broadcast, emailProvider.Kind); err != nil {
+			s.logger.WithField("broadcast_id", request.ID).Warn("Cannot schedule broadcast with invalid provider tags")
+			return err
+		}
+
+		// Check that enough of the audience is deliverable when the workspace requires it
+		if settings := workspace.Settings.DeliverableAudience; settings != nil && settings.Enabled {
+			preflight, err := s.audiencePreflight(ctx, workspace, broadcast)
+			if err != nil {
+				s.logger.Error("Failed to run deliverable audience preflight")
+				return err
+			}
+			if preflight.Blocking {
+				s.logger.WithField("broadcast_id", request.ID).Warn("Broadcast blocked by deliverable audience preflight")
+				return &domain.ErrDeliverableAudienceTooLow{Preflight: preflight}
+			}
+			if preflight.BelowThreshold {
+				s.logger.WithFields(map[string]interface{}{
+					"broadcast_id":      request.ID,
+					"raw_count":         preflight.RawCount,
+					"deliverable_count": preflight.DeliverableCount,
+				}).Warn("Broadcast audience is mostly undeliverable")
+			}
+		}
+
 		// Update broadcast status and scheduling info
 		broadcast.Status = domain.BroadcastStatusScheduled
 		broadcast.UpdatedAt = time.Now().UTC()
-
-		if request.SendNow {
+		broadcast.Schedule.IgnoreQuietHours = request.IgnoreQuietHours
+		broadcast.Schedule.SendCutoffAt = request.SendCutoffAt
+		broadcast.Schedule.MaxSendsPerSecond = request.MaxSendsPerSecond
+
+		// The workspace cool-off holds a sent broadcast so that it can still be cancelled,
+		// the orchestrator only starts it once its task runs at the end of the cool-off
+		coolOff := workspace.Settings.SendCoolOff.Duration()
+		broadcast.Schedule.CoolOffUntil = nil
+
+		if request.SendNow && coolOff > 0 {
+			broadcast.Status = domain.BroadcastStatusStartingSoon
+			coolOffUntil := time.Now().UTC().Add(coolOff)
+			broadcast.Schedule.CoolOffUntil = &coolOffUntil
+		} else if request.SendNow {
 			// If sending immediately, set status to sending
 			broadcast.Status = domain.BroadcastStatusProcessing
 			now := time.Now().UTC()
@@ -333,9 +585,16 @@ func (s *BroadcastService) ScheduleBroadcast(ctx context.Context, request *domai
 		if !request.SendNow && broadcast.Schedule.IsScheduled {
 			scheduledTime, parseErr := broadcast.Schedule.ParseScheduledDateTime()
 			if parseErr == nil && !scheduledTime.IsZero() {
+				// A broadcast scheduled within the cool-off starts at the end of the cool-off
+				if earliest := time.Now().UTC().Add(coolOff); coolOff > 0 && scheduledTime.Before(earliest) {
+					scheduledTime = earliest
+				}
 				payloadData["scheduled_time"] = scheduledTime.Format(time.RFC3339)
 			}
 		}
+		if broadcast.Schedule.CoolOffUntil != nil {
+			payloadData["scheduled_time"] = broadcast.Schedule.CoolOffUntil.Format(time.RFC3339)
+		}
 
 		eventPayload := domain.EventPayload{
 			Type:        domain.EventBroadcastScheduled,
@@ -496,6 +755,17 @@ func (s *BroadcastService) ResumeBroadcast(ctx context.Context, request *domain.
 		return err
 	}
 
+	// Refuse to resume while a complaint spike blocks the workspace
+	workspace, err := s.workspaceRepo.GetByID(ctx, request.WorkspaceID)
+	if err != nil {
+		s.logger.Error("Failed to get workspace for resuming broadcast")
+		return fmt.Errorf("failed to get workspace: %w", err)
+	}
+	if err := workspace.Settings.CheckSendingAllowed(); err != nil {
+		s.logger.WithField("broadcast_id", request.ID).Warn("Broadcast resume rejected while workspace sending is blocked")
+		return err
+	}
+
 	// Using a channel to wait for the event callback
 	done := make(chan error, 1)
 
@@ -515,6 +785,27 @@ func (s *BroadcastService) ResumeBroadcast(ctx context.Context, request *domain.
 			return err
 		}
 
+		// Templates edited while paused would send different content to the rest of the audience
+		changes, err := s.templateChanges(ctx, broadcast)
+		if err != nil {
+			s.logger.WithField("broadcast_id", request.ID).Error("Failed to check broadcast template versions")
+			return err
+		}
+		if len(changes) > 0 {
+			if !request.AcknowledgeTemplateChanges {
+				return &domain.ErrBroadcastTemplateChanged{Changes: changes}
+			}
+			// Pin the acknowledged versions so later resumes compare against them
+			for i, variation := range broadcast.TestSettings.Variations {
+				for _, change := range changes {
+					if variation.TemplateID == change.TemplateID {
+						broadcast.TestSettings.Variations[i].TemplateVersion = change.CurrentVersion
+					}
+				}
+			}
+			s.logger.WithField("broadcast_id", request.ID).Warn("Resuming broadcast with acknowledged template changes")
+		}
+
 		// Update broadcast status
 		now := time.Now().UTC()
 		broadcast.UpdatedAt = now
@@ -611,6 +902,49 @@ func (s *BroadcastService) ResumeBroadcast(ctx context.Context, request *domain.
 	return err
 }
 
+// validateProviderTags checks the provider tags of the templates of a broadcast against the limits of its email provider
+func (s *BroadcastService) validateProviderTags(ctx context.Context, broadcast *domain.Broadcast, kind domain.EmailProviderKind) error {
+	// SMTP servers don't receive provider tags
+	if kind == domain.EmailProviderKindSMTP {
+		return nil
+	}
+	for _, variation := range broadcast.TestSettings.Variations {
+		template, err := s.templateSvc.GetTemplateByID(ctx, broadcast.WorkspaceID, variation.TemplateID, variation.TemplateVersion)
+		if err != nil {
+			return fmt.Errorf("failed to get template %s: %w", variation.TemplateID, err)
+		}
+		if template.Email == nil {
+			continue
+		}
+		if err := template.Email.ValidateProviderTagsFor(kind); err != nil {
+			return domain.NewValidationError(fmt.Sprintf("template %s: %v", variation.TemplateID, err))
+		}
+	}
+	return nil
+}
+
+// templateChanges lists the pinned templates of a broadcast whose latest version differs from the pinned one
+func (s *BroadcastService) templateChanges(ctx context.Context, broadcast *domain.Broadcast) ([]domain.BroadcastTemplateChange, error) {
+	var changes []domain.BroadcastTemplateChange
+	for _, variation := range broadcast.TestSettings.Variations {
+		if variation.TemplateVersion == 0 {
+			continue
+		}
+		template, err := s.templateSvc.GetTemplateByID(ctx, broadcast.WorkspaceID, variation.TemplateID, 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get template %s: %w", variation.TemplateID, err)
+		}
+		if template.Version != variation.TemplateVersion {
+			changes = append(changes, domain.BroadcastTemplateChange{
+				TemplateID:     variation.TemplateID,
+				PinnedVersion:  variation.TemplateVersion,
+				CurrentVersion: template.Version,
+			})
+		}
+	}
+	return changes, nil
+}
+
 // CancelBroadcast cancels a scheduled broadcast
 func (s *BroadcastService) CancelBroadcast(ctx context.Context, request *domain.CancelBroadcastRequest) error {
 	// Authenticate user for workspace
@@ -639,9 +973,14 @@ func (s *BroadcastService) CancelBroadcast(ctx context.Context, request *domain.
 			return err
 		}
 
-		// Only scheduled or paused broadcasts can be cancelled
-		if broadcast.Status != domain.BroadcastStatusScheduled && broadcast.Status != domain.BroadcastStatusPaused {
-			err := fmt.Errorf("only broadcasts with scheduled or paused status can be cancelled, current status: %s", broadcast.Status)
+		// Only scheduled, starting soon, paused, quota exceeded or seed pending broadcasts can be cancelled,
+		// cancelling a seed pending broadcast rejects its seed test
+		if broadcast.Status != domain.BroadcastStatusScheduled &&
+			broadcast.Status != domain.BroadcastStatusStartingSoon &&
+			broadcast.Status != domain.BroadcastStatusPaused &&
+			broadcast.Status != domain.BroadcastStatusQuotaExceeded &&
+			broadcast.Status != domain.BroadcastStatusSeedPending {
+			err := fmt.Errorf("only broadcasts with scheduled, starting_soon, paused, quota_exceeded or seed_pending status can be cancelled, current status: %s", broadcast.Status)
 			s.logger.Error("Cannot cancel broadcast with invalid status")
 			return err
 		}
@@ -746,6 +1085,13 @@ func (s *BroadcastService) DeleteBroadcast(ctx context.Context, request *domain.
 		return err
 	}
 
+	// The uploaded recipients of a CSV audience are only kept for the broadcast
+	if broadcast.Audience.CSV && s.audienceRepo != nil {
+		if err := s.audienceRepo.DeleteRecipients(ctx, request.WorkspaceID, request.ID); err != nil {
+			s.logger.WithField("broadcast_id", request.ID).Warn("Failed to delete broadcast audience recipients")
+		}
+	}
+
 	s.logger.Info("Broadcast deleted successfully")
 
 	return nil
@@ -850,11 +1196,12 @@ func (s *BroadcastService) SendToIndividual(ctx context.Context, request *domain
 	}
 
 	trackingSettings := notifuse_mjml.TrackingSettings{
-		Endpoint:       endpoint,
-		EnableTracking: workspace.Settings.EmailTrackingEnabled,
-		WorkspaceID:    request.WorkspaceID,
-		MessageID:      messageID,
+		Endpoint:    endpoint,
+		WorkspaceID: request.WorkspaceID,
+		MessageID:   messageID,
 	}
+	workspace.Settings.ApplyTrackingDefaults(&trackingSettings)
+	template.Email.ApplyTrackingOverrides(&trackingSettings)
 
 	// Add UTM parameters if available
 	if broadcast.UTMParameters != nil {
@@ -870,7 +1217,7 @@ func (s *BroadcastService) SendToIndividual(ctx context.Context, request *domain
 		WorkspaceSecretKey: workspace.Settings.SecretKey,
 		ContactWithList: domain.ContactWithList{
 			Contact:  contact,
-			ListID:   broadcast.Audience.List, // Use list from broadcast audience for unsubscribe URL
+			ListID:   broadcast.Audience.PrimaryList(), // Use list from broadcast audience for unsubscribe URL
 			ListName: "",
 		},
 		MessageID:        messageID,
@@ -898,6 +1245,7 @@ func (s *BroadcastService) SendToIndividual(ctx context.Context, request *domain
 		VisualEditorTree: template.Email.VisualEditorTree,
 		TemplateData:     notifuse_mjml.MapOfAny(templateData),
 		TrackingSettings: trackingSettings,
+		RawMergeFields:   template.Email.RawMergeFields,
 	})
 	if err != nil {
 		s.logger.Error("Failed to compile template for broadcast")
@@ -928,6 +1276,7 @@ func (s *BroadcastService) SendToIndividual(ctx context.Context, request *domain
 			ReplyTo: template.Email.ReplyTo,
 		},
 	}
+	template.Email.ApplyProviderTags(&emailRequest.EmailOptions)
 
 	// Extract List-Unsubscribe URL from template data for RFC-8058 compliance
 	if unsubscribeURL, ok := templateData["oneclick_unsubscribe_url"].(string); ok && unsubscribeURL != "" {
@@ -942,7 +1291,7 @@ func (s *BroadcastService) SendToIndividual(ctx context.Context, request *domain
 	}
 
 	now := time.Now().UTC()
-	listID := broadcast.Audience.List
+	listID := broadcast.Audience.PrimaryList()
 	message := &domain.MessageHistory{
 		ID:              messageID,
 		ContactEmail:    request.RecipientEmail,
@@ -991,15 +1340,19 @@ func (s *BroadcastService) GetTestResults(ctx context.Context, workspaceID, broa
 	var recommendedWinner string
 	bestScore := -1.0
 
+	// The stats of all the variations are aggregated by a single query
+	allStats, err := s.messageHistoryRepo.GetBroadcastVariationStatsAll(ctx, workspaceID, broadcastID, broadcast.TestSettings.TemplateIDs())
+	if err != nil {
+		s.logger.WithFields(map[string]interface{}{
+			"broadcast_id": broadcastID,
+			"error":        err.Error(),
+		}).Warn("Failed to get variation stats")
+	}
+
 	for _, variation := range broadcast.TestSettings.Variations {
-		// Use existing message history repository method with TemplateID
-		stats, err := s.messageHistoryRepo.GetBroadcastVariationStats(ctx, workspaceID, broadcastID, variation.TemplateID)
-		if err != nil {
-			s.logger.WithFields(map[string]interface{}{
-				"template_id": variation.TemplateID,
-				"error":       err.Error(),
-			}).Warn("Failed to get variation stats")
-			continue // Skip failed variations
+		stats, ok := allStats[variation.TemplateID]
+		if !ok || stats == nil {
+			continue // Skip the variations whose stats could not be read
 		}
 
 		// Calculate rates (avoid division by zero)
@@ -1049,6 +1402,118 @@ func (s *BroadcastService) GetTestResults(ctx context.Context, workspaceID, broa
 	}, nil
 }
 
+// PreflightBroadcast compares the deliverable audience of a broadcast with its raw size
+// using the workspace deliverable audience settings
+func (s *BroadcastService) PreflightBroadcast(ctx context.Context, workspaceID, broadcastID string) (*domain.AudiencePreflight, error) {
+	// Authenticate user
+	ctx, _, _, err := s.authService.AuthenticateUserForWorkspace(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	workspace, err := s.workspaceRepo.GetByID(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace: %w", err)
+	}
+
+	broadcast, err := s.repo.GetBroadcast(ctx, workspaceID, broadcastID)
+	if err != nil {
+		return nil, err
+	}
+
+	preflight, err := s.audiencePreflight(ctx, workspace, broadcast)
+	if err != nil {
+		return nil, err
+	}
+
+	// Issue the token the schedule call has to confirm with
+	if settings := workspace.Settings.SendConfirmation; settings != nil && settings.Enabled {
+		expiresAt := time.Now().UTC().Add(settings.TTL())
+		token, err := domain.NewSendConfirmationToken(workspace.Settings.SecretKey, workspaceID, broadcastID, expiresAt)
+		if err != nil {
+			return nil, err
+		}
+		preflight.ConfirmationToken = token
+		preflight.ConfirmationExpiresAt = &expiresAt
+	}
+
+	return preflight, nil
+}
+
+// GetBroadcastStats retrieves the message statuses of a broadcast with their rates.
+// The statuses are cached for a short time, rapid polling of the same broadcast reads the cache.
+func (s *BroadcastService) GetBroadcastStats(ctx context.Context, workspaceID, broadcastID string) (*domain.BroadcastStats, error) {
+	ctx, _, userWorkspace, err := s.authService.AuthenticateUserForWorkspace(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate user: %w", err)
+	}
+
+	// Check permission for reading broadcasts
+	if !userWorkspace.HasPermission(domain.PermissionResourceBroadcasts, domain.PermissionTypeRead) {
+		return nil, domain.NewPermissionError(
+			domain.PermissionResourceBroadcasts,
+			domain.PermissionTypeRead,
+			"Insufficient permissions: read access to broadcasts required",
+		)
+	}
+
+	if stats, ok := s.statsCache.Get(workspaceID, broadcastID); ok {
+		return domain.NewBroadcastStats(broadcastID, stats), nil
+	}
+
+	if _, err := s.repo.GetBroadcast(ctx, workspaceID, broadcastID); err != nil {
+		return nil, err
+	}
+
+	stats, err := s.messageHistoryRepo.GetBroadcastStats(ctx, workspaceID, broadcastID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get broadcast stats: %w", err)
+	}
+	s.statsCache.Set(workspaceID, broadcastID, stats)
+
+	return domain.NewBroadcastStats(broadcastID, stats), nil
+}
+
+// audiencePreflight counts the raw and deliverable audience of a broadcast and evaluates them
+func (s *BroadcastService) audiencePreflight(ctx context.Context, workspace *domain.Workspace, broadcast *domain.Broadcast) (*domain.AudiencePreflight, error) {
+	var rawCount, deliverableCount int
+	var err error
+	if broadcast.Audience.CSV {
+		rawCount, deliverableCount, err = s.countCSVAudience(ctx, workspace.ID, broadcast)
+	} else {
+		rawCount, deliverableCount, err = s.contactRepo.CountDeliverableContactsForBroadcast(ctx, workspace.ID, broadcast.Audience)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to count deliverable audience: %w", err)
+	}
+
+	return domain.NewAudiencePreflight(rawCount, deliverableCount, workspace.Settings.DeliverableAudience), nil
+}
+
+// countCSVAudience counts the uploaded recipients of a CSV audience, the deliverable ones
+// leave out the unsubscribed contacts of the list
+func (s *BroadcastService) countCSVAudience(ctx context.Context, workspaceID string, broadcast *domain.Broadcast) (int, int, error) {
+	if s.audienceRepo == nil {
+		return 0, 0, fmt.Errorf("CSV audiences are not available")
+	}
+
+	all := broadcast.Audience
+	all.ExcludeUnsubscribed = false
+	rawCount, err := s.audienceRepo.CountRecipients(ctx, workspaceID, broadcast.ID, all)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	deliverable := broadcast.Audience
+	deliverable.ExcludeUnsubscribed = true
+	deliverableCount, err := s.audienceRepo.CountRecipients(ctx, workspaceID, broadcast.ID, deliverable)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return rawCount, deliverableCount, nil
+}
+
 // SelectWinner manually selects the winning variation for an A/B test
 func (s *BroadcastService) SelectWinner(ctx context.Context, workspaceID, broadcastID, templateID string) error {
 	// Authenticate user
@@ -1139,6 +1604,76 @@ func (s *BroadcastService) SelectWinner(ctx context.Context, workspaceID, broadc
 	})
 }
 
+// ApproveSeedTest releases the main send of a broadcast held in the seed_pending status after its seed test
+func (s *BroadcastService) ApproveSeedTest(ctx context.Context, workspaceID, broadcastID string) error {
+	// Authenticate user
+	ctx, _, userWorkspace, err := s.authService.AuthenticateUserForWorkspace(ctx, workspaceID)
+	if err != nil {
+		return err
+	}
+
+	// Check permission for writing broadcasts
+	if !userWorkspace.HasPermission(domain.PermissionResourceBroadcasts, domain.PermissionTypeWrite) {
+		return domain.NewPermissionError(
+			domain.PermissionResourceBroadcasts,
+			domain.PermissionTypeWrite,
+			"Insufficient permissions: write access to broadcasts required",
+		)
+	}
+
+	return s.repo.WithTransaction(ctx, workspaceID, func(tx *sql.Tx) error {
+		broadcast, err := s.repo.GetBroadcastTx(ctx, tx, workspaceID, broadcastID)
+		if err != nil {
+			return err
+		}
+
+		if broadcast.Status != domain.BroadcastStatusSeedPending {
+			return fmt.Errorf("broadcast is not awaiting seed test approval, current status: %s", broadcast.Status)
+		}
+
+		now := time.Now().UTC()
+		broadcast.SeedTest.ApprovedAt = &now
+		broadcast.Status = domain.BroadcastStatusProcessing
+		broadcast.UpdatedAt = now
+
+		if err := s.repo.UpdateBroadcastTx(ctx, tx, broadcast); err != nil {
+			return err
+		}
+
+		// Resume the associated task by finding it and updating its status
+		task, err := s.taskRepo.GetTaskByBroadcastID(ctx, workspaceID, broadcastID)
+		if err != nil {
+			s.logger.WithField("broadcast_id", broadcastID).Debug("No task found for broadcast")
+			return nil // Not an error if no task exists
+		}
+
+		nextRunAfter := now
+		task.NextRunAfter = &nextRunAfter
+		task.Status = domain.TaskStatusPending
+
+		if updateErr := s.taskRepo.Update(ctx, workspaceID, task); updateErr != nil {
+			return updateErr
+		}
+
+		// Immediately trigger task execution so that the main send starts without waiting for the next run
+		if s.taskService.IsAutoExecuteEnabled() {
+			go func() {
+				// Small delay to ensure transaction is committed
+				time.Sleep(100 * time.Millisecond)
+				if execErr := s.taskService.ExecutePendingTasks(context.Background(), 1); execErr != nil {
+					s.logger.WithFields(map[string]interface{}{
+						"broadcast_id": broadcastID,
+						"task_id":      task.ID,
+						"error":        execErr.Error(),
+					}).Error("Failed to trigger immediate task execution after seed test approval")
+				}
+			}()
+		}
+
+		return nil
+	})
+}
+
 // ValidateSlug checks if slug is valid format (no nanoid - clean slugs)
 func ValidateSlug(slug string) error {
 	if slug == "" {
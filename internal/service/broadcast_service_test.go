@@ -222,6 +222,7 @@ func TestBroadcastService_ResumeBroadcast_ToScheduled_Success(t *testing.T) {
 	ctx := context.Background()
 	req := &domain.ResumeBroadcastRequest{WorkspaceID: "w1", ID: "b1"}
 	authOK(d.authService, ctx, req.WorkspaceID)
+	d.workspaceRepo.EXPECT().GetByID(gomock.Any(), req.WorkspaceID).Return(&domain.Workspace{ID: req.WorkspaceID}, nil)
 
 	d.repo.EXPECT().WithTransaction(ctx, req.WorkspaceID, gomock.Any()).DoAndReturn(
 		func(_ context.Context, _ string, fn func(*sql.Tx) error) error { return fn(nil) },
@@ -325,8 +326,7 @@ func TestBroadcastService_GetTestResults_ComputesRecommendation(t *testing.T) {
 	d.repo.EXPECT().GetBroadcast(ctx, workspaceID, broadcastID).Return(b, nil)
 
 	// stats for A and B
-	d.messageHistoryRepo.EXPECT().GetBroadcastVariationStats(ctx, workspaceID, broadcastID, "tplA").Return(&domain.MessageHistoryStatusSum{TotalSent: 100, TotalDelivered: 100, TotalOpened: 30, TotalClicked: 5}, nil)
-	d.messageHistoryRepo.EXPECT().GetBroadcastVariationStats(ctx, workspaceID, broadcastID, "tplB").Return(&domain.MessageHistoryStatusSum{TotalSent: 100, TotalDelivered: 100, TotalOpened: 25, TotalClicked: 10}, nil)
+	d.messageHistoryRepo.EXPECT().GetBroadcastVariationStatsAll(ctx, workspaceID, broadcastID, []string{"tplA", "tplB"}).Return(map[string]*domain.MessageHistoryStatusSum{"tplA": {TotalSent: 100, TotalDelivered: 100, TotalOpened: 30, TotalClicked: 5}, "tplB": {TotalSent: 100, TotalDelivered: 100, TotalOpened: 25, TotalClicked: 10}}, nil)
 
 	res, err := d.svc.GetTestResults(ctx, workspaceID, broadcastID)
 	require.NoError(t, err)
@@ -376,6 +376,60 @@ func TestBroadcastService_SelectWinner_SetsWinnerAndResumesTask(t *testing.T) {
 	time.Sleep(200 * time.Millisecond)
 }
 
+func TestBroadcastService_ApproveSeedTest(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := "w1"
+	broadcastID := "b1"
+
+	t.Run("releases the main send and resumes the task", func(t *testing.T) {
+		d := setupBroadcastSvc(t)
+		defer d.ctrl.Finish()
+		authOK(d.authService, ctx, workspaceID)
+
+		d.repo.EXPECT().WithTransaction(ctx, workspaceID, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ string, fn func(*sql.Tx) error) error { return fn(nil) },
+		)
+
+		b := testBroadcast(workspaceID, broadcastID)
+		b.Status = domain.BroadcastStatusSeedPending
+		b.SeedTest = domain.BroadcastSeedTest{Enabled: true, Emails: []string{"seed@gmail.com"}, RequireApproval: true}
+		d.repo.EXPECT().GetBroadcastTx(ctx, gomock.Any(), workspaceID, broadcastID).Return(b, nil)
+		d.repo.EXPECT().UpdateBroadcastTx(ctx, gomock.Any(), gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ *sql.Tx, updated *domain.Broadcast) error {
+				assert.Equal(t, domain.BroadcastStatusProcessing, updated.Status)
+				assert.NotNil(t, updated.SeedTest.ApprovedAt)
+				return nil
+			},
+		)
+
+		task := &domain.Task{ID: "task1", WorkspaceID: workspaceID, Status: domain.TaskStatusPending}
+		d.taskRepo.EXPECT().GetTaskByBroadcastID(ctx, workspaceID, broadcastID).Return(task, nil)
+		d.taskRepo.EXPECT().Update(ctx, workspaceID, gomock.Any()).Return(nil)
+		d.taskService.EXPECT().IsAutoExecuteEnabled().Return(false)
+
+		err := d.svc.ApproveSeedTest(ctx, workspaceID, broadcastID)
+		require.NoError(t, err)
+		assert.NotNil(t, task.NextRunAfter)
+	})
+
+	t.Run("rejects broadcasts not awaiting approval", func(t *testing.T) {
+		d := setupBroadcastSvc(t)
+		defer d.ctrl.Finish()
+		authOK(d.authService, ctx, workspaceID)
+
+		d.repo.EXPECT().WithTransaction(ctx, workspaceID, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ string, fn func(*sql.Tx) error) error { return fn(nil) },
+		)
+		b := testBroadcast(workspaceID, broadcastID)
+		b.Status = domain.BroadcastStatusProcessing
+		d.repo.EXPECT().GetBroadcastTx(ctx, gomock.Any(), workspaceID, broadcastID).Return(b, nil)
+
+		err := d.svc.ApproveSeedTest(ctx, workspaceID, broadcastID)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "broadcast is not awaiting seed test approval")
+	})
+}
+
 func TestBroadcastService_SetTaskService_SetsField(t *testing.T) {
 	d := setupBroadcastSvc(t)
 	defer d.ctrl.Finish()
@@ -758,6 +812,200 @@ func TestBroadcastService_ListBroadcasts_MaxLimit(t *testing.T) {
 	require.NotNil(t, out)
 }
 
+func TestBroadcastService_SetBroadcastTags(t *testing.T) {
+	t.Run("normalizes and stores the tags", func(t *testing.T) {
+		d := setupBroadcastSvc(t)
+		defer d.ctrl.Finish()
+
+		ctx := context.Background()
+		authOK(d.authService, ctx, "w1")
+
+		broadcast := testBroadcast("w1", "b1")
+		broadcast.Tags = []string{"newsletter", "promo"}
+		d.repo.EXPECT().SetBroadcastTags(ctx, "w1", "b1", []string{"newsletter", "promo"}).Return(nil)
+		d.repo.EXPECT().GetBroadcast(ctx, "w1", "b1").Return(broadcast, nil)
+
+		out, err := d.svc.SetBroadcastTags(ctx, &domain.SetBroadcastTagsRequest{
+			WorkspaceID: "w1",
+			ID:          "b1",
+			Tags:        []string{" newsletter ", "promo", "Newsletter", ""},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, []string{"newsletter", "promo"}, out.Tags)
+	})
+
+	t.Run("requires write permission", func(t *testing.T) {
+		d := setupBroadcastSvc(t)
+		defer d.ctrl.Finish()
+
+		ctx := context.Background()
+		userWorkspace := &domain.UserWorkspace{
+			UserID:      "user1",
+			WorkspaceID: "w1",
+			Permissions: domain.UserPermissions{
+				domain.PermissionResourceBroadcasts: {Read: true, Write: false},
+			},
+		}
+		d.authService.EXPECT().AuthenticateUserForWorkspace(ctx, "w1").Return(ctx, &domain.User{ID: "user1"}, userWorkspace, nil)
+
+		_, err := d.svc.SetBroadcastTags(ctx, &domain.SetBroadcastTagsRequest{WorkspaceID: "w1", ID: "b1", Tags: []string{"promo"}})
+		require.Error(t, err)
+		assert.IsType(t, &domain.PermissionError{}, err)
+	})
+}
+
+func TestBroadcastService_ListBroadcastTags(t *testing.T) {
+	d := setupBroadcastSvc(t)
+	defer d.ctrl.Finish()
+
+	ctx := context.Background()
+	authOK(d.authService, ctx, "w1")
+
+	expected := []*domain.BroadcastTagCount{{Tag: "newsletter", Count: 2}}
+	d.repo.EXPECT().ListBroadcastTags(ctx, "w1").Return(expected, nil)
+
+	tags, err := d.svc.ListBroadcastTags(ctx, "w1")
+	require.NoError(t, err)
+	assert.Equal(t, expected, tags)
+}
+
+func TestBroadcastService_DeleteBroadcastTag(t *testing.T) {
+	t.Run("removes the tag from the workspace broadcasts", func(t *testing.T) {
+		d := setupBroadcastSvc(t)
+		defer d.ctrl.Finish()
+
+		ctx := context.Background()
+		authOK(d.authService, ctx, "w1")
+		d.repo.EXPECT().DeleteBroadcastTag(ctx, "w1", "promo").Return(int64(3), nil)
+
+		err := d.svc.DeleteBroadcastTag(ctx, &domain.DeleteBroadcastTagRequest{WorkspaceID: "w1", Tag: " promo "})
+		require.NoError(t, err)
+	})
+
+	t.Run("requires a tag", func(t *testing.T) {
+		d := setupBroadcastSvc(t)
+		defer d.ctrl.Finish()
+
+		ctx := context.Background()
+		authOK(d.authService, ctx, "w1")
+
+		err := d.svc.DeleteBroadcastTag(ctx, &domain.DeleteBroadcastTagRequest{WorkspaceID: "w1", Tag: " "})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "tag is required")
+	})
+}
+
+func TestBroadcastService_UploadBroadcastAudience(t *testing.T) {
+	csvBroadcast := func() *domain.Broadcast {
+		broadcast := testBroadcast("w1", "b1")
+		broadcast.Audience = domain.AudienceSettings{List: "list1", CSV: true}
+		return broadcast
+	}
+	request := func(upsert bool) *domain.UploadBroadcastAudienceRequest {
+		return &domain.UploadBroadcastAudienceRequest{
+			WorkspaceID:    "w1",
+			BroadcastID:    "b1",
+			CSV:            "email,first_name\nalice@example.com,Alice\nbob@example.com,\n",
+			UpsertContacts: upsert,
+		}
+	}
+
+	t.Run("stores the recipients without adding contacts", func(t *testing.T) {
+		d := setupBroadcastSvc(t)
+		defer d.ctrl.Finish()
+		audienceRepo := domainmocks.NewMockBroadcastAudienceRepository(d.ctrl)
+		d.svc.SetAudienceRepository(audienceRepo)
+
+		ctx := context.Background()
+		authOK(d.authService, ctx, "w1")
+		d.repo.EXPECT().GetBroadcast(ctx, "w1", "b1").Return(csvBroadcast(), nil)
+		audienceRepo.EXPECT().ReplaceRecipients(ctx, "w1", "b1", gomock.Any()).
+			DoAndReturn(func(_ context.Context, _, _ string, recipients []*domain.BroadcastAudienceRecipient) error {
+				require.Len(t, recipients, 2)
+				assert.Equal(t, "alice@example.com", recipients[0].Email)
+				assert.Equal(t, domain.MapOfAny{"first_name": "Alice"}, recipients[0].Data)
+				assert.Equal(t, "bob@example.com", recipients[1].Email)
+				return nil
+			})
+
+		// The contact repository has no expectations, the contacts are left untouched
+		out, err := d.svc.UploadBroadcastAudience(ctx, request(false))
+		require.NoError(t, err)
+		assert.Equal(t, &domain.UploadBroadcastAudienceResponse{Recipients: 2}, out)
+	})
+
+	t.Run("optionally upserts the recipients as contacts", func(t *testing.T) {
+		d := setupBroadcastSvc(t)
+		defer d.ctrl.Finish()
+		audienceRepo := domainmocks.NewMockBroadcastAudienceRepository(d.ctrl)
+		d.svc.SetAudienceRepository(audienceRepo)
+
+		ctx := context.Background()
+		userWorkspace := &domain.UserWorkspace{
+			UserID:      "user1",
+			WorkspaceID: "w1",
+			Permissions: domain.UserPermissions{
+				domain.PermissionResourceBroadcasts: {Read: true, Write: true},
+				domain.PermissionResourceContacts:   {Read: true, Write: true},
+			},
+		}
+		d.authService.EXPECT().AuthenticateUserForWorkspace(ctx, "w1").Return(ctx, &domain.User{ID: "user1"}, userWorkspace, nil)
+		d.repo.EXPECT().GetBroadcast(ctx, "w1", "b1").Return(csvBroadcast(), nil)
+		audienceRepo.EXPECT().ReplaceRecipients(ctx, "w1", "b1", gomock.Len(2)).Return(nil)
+		d.contactRepo.EXPECT().BulkUpsertContacts(ctx, "w1", gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, contacts []*domain.Contact) ([]domain.BulkUpsertResult, error) {
+				require.Len(t, contacts, 2)
+				assert.Equal(t, "Alice", contacts[0].FirstName.String)
+				return []domain.BulkUpsertResult{{Email: "alice@example.com", IsNew: true}, {Email: "bob@example.com"}}, nil
+			})
+
+		out, err := d.svc.UploadBroadcastAudience(ctx, request(true))
+		require.NoError(t, err)
+		assert.Equal(t, &domain.UploadBroadcastAudienceResponse{Recipients: 2, UpsertedContacts: 2}, out)
+	})
+
+	t.Run("upserting requires write access to contacts", func(t *testing.T) {
+		d := setupBroadcastSvc(t)
+		defer d.ctrl.Finish()
+		d.svc.SetAudienceRepository(domainmocks.NewMockBroadcastAudienceRepository(d.ctrl))
+
+		ctx := context.Background()
+		authOK(d.authService, ctx, "w1")
+
+		_, err := d.svc.UploadBroadcastAudience(ctx, request(true))
+		require.Error(t, err)
+		assert.IsType(t, &domain.PermissionError{}, err)
+	})
+
+	t.Run("rejects broadcasts without a CSV audience", func(t *testing.T) {
+		d := setupBroadcastSvc(t)
+		defer d.ctrl.Finish()
+		d.svc.SetAudienceRepository(domainmocks.NewMockBroadcastAudienceRepository(d.ctrl))
+
+		ctx := context.Background()
+		authOK(d.authService, ctx, "w1")
+		d.repo.EXPECT().GetBroadcast(ctx, "w1", "b1").Return(testBroadcast("w1", "b1"), nil)
+
+		_, err := d.svc.UploadBroadcastAudience(ctx, request(false))
+		assert.ErrorIs(t, err, domain.ErrBroadcastAudienceUploadRejected)
+	})
+
+	t.Run("rejects broadcasts that started sending", func(t *testing.T) {
+		d := setupBroadcastSvc(t)
+		defer d.ctrl.Finish()
+		d.svc.SetAudienceRepository(domainmocks.NewMockBroadcastAudienceRepository(d.ctrl))
+
+		ctx := context.Background()
+		authOK(d.authService, ctx, "w1")
+		broadcast := csvBroadcast()
+		broadcast.Status = domain.BroadcastStatusProcessing
+		d.repo.EXPECT().GetBroadcast(ctx, "w1", "b1").Return(broadcast, nil)
+
+		_, err := d.svc.UploadBroadcastAudience(ctx, request(false))
+		assert.ErrorIs(t, err, domain.ErrBroadcastAudienceUploadRejected)
+	})
+}
+
 func TestBroadcastService_ListBroadcasts_WithTemplates_TemplateError(t *testing.T) {
 	d := setupBroadcastSvc(t)
 	defer d.ctrl.Finish()
@@ -999,6 +1247,7 @@ func TestBroadcastService_ResumeBroadcast_InvalidStatus(t *testing.T) {
 	ctx := context.Background()
 	req := &domain.ResumeBroadcastRequest{WorkspaceID: "w1", ID: "b1"}
 	authOK(d.authService, ctx, req.WorkspaceID)
+	d.workspaceRepo.EXPECT().GetByID(gomock.Any(), req.WorkspaceID).Return(&domain.Workspace{ID: req.WorkspaceID}, nil)
 
 	d.repo.EXPECT().WithTransaction(ctx, req.WorkspaceID, gomock.Any()).DoAndReturn(
 		func(_ context.Context, _ string, fn func(*sql.Tx) error) error {
@@ -1022,6 +1271,7 @@ func TestBroadcastService_ResumeBroadcast_ToSending_Success(t *testing.T) {
 	ctx := context.Background()
 	req := &domain.ResumeBroadcastRequest{WorkspaceID: "w1", ID: "b1"}
 	authOK(d.authService, ctx, req.WorkspaceID)
+	d.workspaceRepo.EXPECT().GetByID(gomock.Any(), req.WorkspaceID).Return(&domain.Workspace{ID: req.WorkspaceID}, nil)
 
 	d.repo.EXPECT().WithTransaction(ctx, req.WorkspaceID, gomock.Any()).DoAndReturn(
 		func(_ context.Context, _ string, fn func(*sql.Tx) error) error { return fn(nil) },
@@ -1040,6 +1290,63 @@ func TestBroadcastService_ResumeBroadcast_ToSending_Success(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestBroadcastService_ResumeBroadcast_TemplateVersions(t *testing.T) {
+	tests := []struct {
+		name           string
+		acknowledge    bool
+		currentVersion int64
+		expectResume   bool
+	}{
+		{name: "unchanged template resumes", currentVersion: 3, expectResume: true},
+		{name: "changed template is flagged", currentVersion: 4},
+		{name: "acknowledged change resumes and re-pins", acknowledge: true, currentVersion: 4, expectResume: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := setupBroadcastSvc(t)
+			defer d.ctrl.Finish()
+
+			ctx := context.Background()
+			req := &domain.ResumeBroadcastRequest{WorkspaceID: "w1", ID: "b1", AcknowledgeTemplateChanges: tt.acknowledge}
+			authOK(d.authService, ctx, req.WorkspaceID)
+			d.workspaceRepo.EXPECT().GetByID(gomock.Any(), req.WorkspaceID).Return(&domain.Workspace{ID: req.WorkspaceID}, nil)
+
+			d.repo.EXPECT().WithTransaction(ctx, req.WorkspaceID, gomock.Any()).DoAndReturn(
+				func(_ context.Context, _ string, fn func(*sql.Tx) error) error { return fn(nil) },
+			)
+
+			paused := testBroadcast(req.WorkspaceID, req.ID)
+			paused.Status = domain.BroadcastStatusPaused
+			paused.Schedule.IsScheduled = false
+			paused.TestSettings.Variations = []domain.BroadcastVariation{{VariationName: "A", TemplateID: "tplA", TemplateVersion: 3}}
+
+			d.repo.EXPECT().GetBroadcastTx(gomock.Any(), gomock.Any(), req.WorkspaceID, req.ID).Return(paused, nil)
+			d.templateSvc.EXPECT().GetTemplateByID(ctx, req.WorkspaceID, "tplA", int64(0)).
+				Return(&domain.Template{ID: "tplA", Version: tt.currentVersion}, nil)
+
+			if tt.expectResume {
+				d.repo.EXPECT().UpdateBroadcastTx(gomock.Any(), gomock.Any(), gomock.Any()).
+					Do(func(_ context.Context, _ *sql.Tx, b *domain.Broadcast) {
+						assert.Equal(t, domain.BroadcastStatusProcessing, b.Status)
+						assert.Equal(t, tt.currentVersion, b.TestSettings.Variations[0].TemplateVersion)
+					}).Return(nil)
+				d.eventBus.EXPECT().PublishWithAck(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ context.Context, _ domain.EventPayload, ack domain.EventAckCallback) { ack(nil) })
+			}
+
+			err := d.svc.ResumeBroadcast(ctx, req)
+			if tt.expectResume {
+				require.NoError(t, err)
+				return
+			}
+
+			var templateErr *domain.ErrBroadcastTemplateChanged
+			require.ErrorAs(t, err, &templateErr)
+			assert.Equal(t, []domain.BroadcastTemplateChange{{TemplateID: "tplA", PinnedVersion: 3, CurrentVersion: 4}}, templateErr.Changes)
+		})
+	}
+}
+
 func TestBroadcastService_CancelBroadcast_AuthFailure(t *testing.T) {
 	d := setupBroadcastSvc(t)
 	defer d.ctrl.Finish()
@@ -1074,7 +1381,7 @@ func TestBroadcastService_CancelBroadcast_InvalidStatus(t *testing.T) {
 
 	err := d.svc.CancelBroadcast(ctx, req)
 	require.Error(t, err)
-	assert.Contains(t, err.Error(), "only broadcasts with scheduled or paused status can be cancelled")
+	assert.Contains(t, err.Error(), "only broadcasts with scheduled, starting_soon, paused, quota_exceeded or seed_pending status can be cancelled")
 }
 
 func TestBroadcastService_DeleteBroadcast_AuthFailure(t *testing.T) {
@@ -1617,8 +1924,8 @@ func TestBroadcastService_GetTestResults_StatsFailure(t *testing.T) {
 	}
 	d.repo.EXPECT().GetBroadcast(ctx, workspaceID, broadcastID).Return(b, nil)
 
-	// Stats fetch fails - should continue without that variation
-	d.messageHistoryRepo.EXPECT().GetBroadcastVariationStats(ctx, workspaceID, broadcastID, "tplA").Return(nil, errors.New("stats failed"))
+	// Stats fetch fails - should continue without the variations
+	d.messageHistoryRepo.EXPECT().GetBroadcastVariationStatsAll(ctx, workspaceID, broadcastID, []string{"tplA"}).Return(nil, errors.New("stats failed"))
 
 	res, err := d.svc.GetTestResults(ctx, workspaceID, broadcastID)
 	require.NoError(t, err)
@@ -2034,8 +2341,7 @@ func TestBroadcastService_GetTestResults_WithAutoSendWinner(t *testing.T) {
 	d.repo.EXPECT().GetBroadcast(ctx, workspaceID, broadcastID).Return(b, nil)
 
 	// stats for A and B
-	d.messageHistoryRepo.EXPECT().GetBroadcastVariationStats(ctx, workspaceID, broadcastID, "tplA").Return(&domain.MessageHistoryStatusSum{TotalSent: 100, TotalDelivered: 100, TotalOpened: 30, TotalClicked: 5}, nil)
-	d.messageHistoryRepo.EXPECT().GetBroadcastVariationStats(ctx, workspaceID, broadcastID, "tplB").Return(&domain.MessageHistoryStatusSum{TotalSent: 100, TotalDelivered: 100, TotalOpened: 25, TotalClicked: 10}, nil)
+	d.messageHistoryRepo.EXPECT().GetBroadcastVariationStatsAll(ctx, workspaceID, broadcastID, []string{"tplA", "tplB"}).Return(map[string]*domain.MessageHistoryStatusSum{"tplA": {TotalSent: 100, TotalDelivered: 100, TotalOpened: 30, TotalClicked: 5}, "tplB": {TotalSent: 100, TotalDelivered: 100, TotalOpened: 25, TotalClicked: 10}}, nil)
 
 	res, err := d.svc.GetTestResults(ctx, workspaceID, broadcastID)
 	require.NoError(t, err)
@@ -2067,8 +2373,7 @@ func TestBroadcastService_GetTestResults_WithWinnerAlreadySelected(t *testing.T)
 	d.repo.EXPECT().GetBroadcast(ctx, workspaceID, broadcastID).Return(b, nil)
 
 	// stats for A and B
-	d.messageHistoryRepo.EXPECT().GetBroadcastVariationStats(ctx, workspaceID, broadcastID, "tplA").Return(&domain.MessageHistoryStatusSum{TotalSent: 100, TotalDelivered: 100, TotalOpened: 30, TotalClicked: 5}, nil)
-	d.messageHistoryRepo.EXPECT().GetBroadcastVariationStats(ctx, workspaceID, broadcastID, "tplB").Return(&domain.MessageHistoryStatusSum{TotalSent: 100, TotalDelivered: 100, TotalOpened: 25, TotalClicked: 10}, nil)
+	d.messageHistoryRepo.EXPECT().GetBroadcastVariationStatsAll(ctx, workspaceID, broadcastID, []string{"tplA", "tplB"}).Return(map[string]*domain.MessageHistoryStatusSum{"tplA": {TotalSent: 100, TotalDelivered: 100, TotalOpened: 30, TotalClicked: 5}, "tplB": {TotalSent: 100, TotalDelivered: 100, TotalOpened: 25, TotalClicked: 10}}, nil)
 
 	res, err := d.svc.GetTestResults(ctx, workspaceID, broadcastID)
 	require.NoError(t, err)
@@ -2097,7 +2402,7 @@ func TestBroadcastService_GetTestResults_ZeroSentMessages(t *testing.T) {
 	d.repo.EXPECT().GetBroadcast(ctx, workspaceID, broadcastID).Return(b, nil)
 
 	// Zero sent messages - should handle division by zero
-	d.messageHistoryRepo.EXPECT().GetBroadcastVariationStats(ctx, workspaceID, broadcastID, "tplA").Return(&domain.MessageHistoryStatusSum{TotalSent: 0, TotalDelivered: 0, TotalOpened: 0, TotalClicked: 0}, nil)
+	d.messageHistoryRepo.EXPECT().GetBroadcastVariationStatsAll(ctx, workspaceID, broadcastID, []string{"tplA"}).Return(map[string]*domain.MessageHistoryStatusSum{"tplA": {TotalSent: 0, TotalDelivered: 0, TotalOpened: 0, TotalClicked: 0}}, nil)
 
 	res, err := d.svc.GetTestResults(ctx, workspaceID, broadcastID)
 	require.NoError(t, err)
@@ -2247,6 +2552,56 @@ func TestBroadcastService_ScheduleBroadcast_ScheduledTimeInPayload(t *testing.T)
 	require.NoError(t, err)
 }
 
+func TestBroadcastService_ScheduleBroadcast_ScheduledTimeInTimezone(t *testing.T) {
+	d := setupBroadcastSvc(t)
+	defer d.ctrl.Finish()
+
+	ctx := context.Background()
+	// 09:00 in New York on the day DST starts is 13:00 UTC, not 14:00
+	req := &domain.ScheduleBroadcastRequest{
+		WorkspaceID:   "w1",
+		ID:            "b1",
+		SendNow:       false,
+		ScheduledDate: "2099-03-08",
+		ScheduledTime: "09:00",
+		Timezone:      "America/New_York",
+	}
+	authOK(d.authService, ctx, req.WorkspaceID)
+
+	workspace := &domain.Workspace{
+		ID:       "w1",
+		Settings: domain.WorkspaceSettings{MarketingEmailProviderID: "mkt"},
+		Integrations: domain.Integrations{
+			{ID: "mkt", Type: domain.IntegrationTypeEmail, EmailProvider: domain.EmailProvider{Kind: domain.EmailProviderKindSMTP}},
+		},
+	}
+	d.workspaceRepo.EXPECT().GetByID(ctx, req.WorkspaceID).Return(workspace, nil)
+
+	var scheduledTime string
+	d.repo.EXPECT().WithTransaction(ctx, req.WorkspaceID, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, fn func(*sql.Tx) error) error {
+			draft := testBroadcast(req.WorkspaceID, req.ID)
+			d.repo.EXPECT().GetBroadcastTx(gomock.Any(), gomock.Any(), req.WorkspaceID, req.ID).Return(draft, nil)
+			d.repo.EXPECT().UpdateBroadcastTx(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+			d.eventBus.EXPECT().PublishWithAck(gomock.Any(), gomock.Any(), gomock.Any()).Do(
+				func(_ context.Context, payload domain.EventPayload, ack domain.EventAckCallback) {
+					scheduledTime, _ = payload.Data["scheduled_time"].(string)
+					ack(nil)
+				},
+			)
+			return fn(nil)
+		},
+	)
+
+	err := d.svc.ScheduleBroadcast(ctx, req)
+	require.NoError(t, err)
+
+	// The task service dispatches the broadcast at this instant
+	dispatchAt, err := time.Parse(time.RFC3339, scheduledTime)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2099, 3, 8, 13, 0, 0, 0, time.UTC), dispatchAt.UTC())
+}
+
 func TestBroadcastService_ResumeBroadcast_ScheduleParseError(t *testing.T) {
 	d := setupBroadcastSvc(t)
 	defer d.ctrl.Finish()
@@ -2254,6 +2609,7 @@ func TestBroadcastService_ResumeBroadcast_ScheduleParseError(t *testing.T) {
 	ctx := context.Background()
 	req := &domain.ResumeBroadcastRequest{WorkspaceID: "w1", ID: "b1"}
 	authOK(d.authService, ctx, req.WorkspaceID)
+	d.workspaceRepo.EXPECT().GetByID(gomock.Any(), req.WorkspaceID).Return(&domain.Workspace{ID: req.WorkspaceID}, nil)
 
 	d.repo.EXPECT().WithTransaction(ctx, req.WorkspaceID, gomock.Any()).DoAndReturn(
 		func(_ context.Context, _ string, fn func(*sql.Tx) error) error {
@@ -2388,3 +2744,369 @@ func TestValidateSlug(t *testing.T) {
 		}
 	})
 }
+
+func TestBroadcastService_PreflightBroadcast(t *testing.T) {
+	newWorkspace := func(settings *domain.DeliverableAudienceSettings) *domain.Workspace {
+		return &domain.Workspace{
+			ID:       "w1",
+			Settings: domain.WorkspaceSettings{DeliverableAudience: settings},
+		}
+	}
+
+	testCases := []struct {
+		name             string
+		settings         *domain.DeliverableAudienceSettings
+		rawCount         int
+		deliverableCount int
+		belowThreshold   bool
+		blocking         bool
+	}{
+		{
+			name:             "heavily suppressed audience triggers the warning",
+			settings:         &domain.DeliverableAudienceSettings{Enabled: true, MinRatio: 0.5},
+			rawCount:         1000,
+			deliverableCount: 120,
+			belowThreshold:   true,
+		},
+		{
+			name:             "heavily suppressed audience blocks when configured",
+			settings:         &domain.DeliverableAudienceSettings{Enabled: true, MinRatio: 0.5, Block: true},
+			rawCount:         1000,
+			deliverableCount: 120,
+			belowThreshold:   true,
+			blocking:         true,
+		},
+		{
+			name:             "healthy audience passes",
+			settings:         &domain.DeliverableAudienceSettings{Enabled: true, MinRatio: 0.5, Block: true},
+			rawCount:         1000,
+			deliverableCount: 950,
+		},
+		{
+			name:             "disabled check never warns",
+			rawCount:         1000,
+			deliverableCount: 10,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			d := setupBroadcastSvc(t)
+			defer d.ctrl.Finish()
+
+			ctx := context.Background()
+			authOK(d.authService, ctx, "w1")
+			broadcast := testBroadcast("w1", "b1")
+			d.workspaceRepo.EXPECT().GetByID(ctx, "w1").Return(newWorkspace(tc.settings), nil)
+			d.repo.EXPECT().GetBroadcast(ctx, "w1", "b1").Return(broadcast, nil)
+			d.contactRepo.EXPECT().CountDeliverableContactsForBroadcast(ctx, "w1", broadcast.Audience).Return(tc.rawCount, tc.deliverableCount, nil)
+
+			preflight, err := d.svc.PreflightBroadcast(ctx, "w1", "b1")
+			require.NoError(t, err)
+			assert.Equal(t, tc.rawCount, preflight.RawCount)
+			assert.Equal(t, tc.deliverableCount, preflight.DeliverableCount)
+			assert.Equal(t, tc.belowThreshold, preflight.BelowThreshold)
+			assert.Equal(t, tc.blocking, preflight.Blocking)
+		})
+	}
+}
+
+func TestBroadcastService_ScheduleBroadcast_DeliverableAudience(t *testing.T) {
+	newWorkspace := func(block bool) *domain.Workspace {
+		return &domain.Workspace{
+			ID: "w1",
+			Settings: domain.WorkspaceSettings{
+				MarketingEmailProviderID: "mkt",
+				DeliverableAudience:      &domain.DeliverableAudienceSettings{Enabled: true, MinRatio: 0.5, Block: block},
+			},
+			Integrations: domain.Integrations{
+				{ID: "mkt", Type: domain.IntegrationTypeEmail, EmailProvider: domain.EmailProvider{Kind: domain.EmailProviderKindSMTP, Senders: []domain.EmailSender{domain.NewEmailSender("from@example.com", "From")}}},
+			},
+		}
+	}
+
+	t.Run("blocking threshold refuses to schedule a heavily suppressed audience", func(t *testing.T) {
+		d := setupBroadcastSvc(t)
+		defer d.ctrl.Finish()
+
+		ctx := context.Background()
+		req := &domain.ScheduleBroadcastRequest{WorkspaceID: "w1", ID: "b1", SendNow: true}
+		authOK(d.authService, ctx, req.WorkspaceID)
+		d.workspaceRepo.EXPECT().GetByID(ctx, req.WorkspaceID).Return(newWorkspace(true), nil)
+		d.repo.EXPECT().WithTransaction(ctx, req.WorkspaceID, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ string, fn func(*sql.Tx) error) error { return fn(nil) },
+		)
+		d.repo.EXPECT().GetBroadcastTx(gomock.Any(), gomock.Any(), req.WorkspaceID, req.ID).Return(testBroadcast(req.WorkspaceID, req.ID), nil)
+		d.contactRepo.EXPECT().CountDeliverableContactsForBroadcast(gomock.Any(), req.WorkspaceID, gomock.Any()).Return(1000, 120, nil)
+		d.repo.EXPECT().UpdateBroadcastTx(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		err := d.svc.ScheduleBroadcast(ctx, req)
+		require.Error(t, err)
+		var audienceErr *domain.ErrDeliverableAudienceTooLow
+		require.ErrorAs(t, err, &audienceErr)
+		assert.Equal(t, 120, audienceErr.Preflight.DeliverableCount)
+	})
+
+	t.Run("warning threshold still schedules", func(t *testing.T) {
+		d := setupBroadcastSvc(t)
+		defer d.ctrl.Finish()
+
+		ctx := context.Background()
+		req := &domain.ScheduleBroadcastRequest{WorkspaceID: "w1", ID: "b1", SendNow: true}
+		authOK(d.authService, ctx, req.WorkspaceID)
+		d.workspaceRepo.EXPECT().GetByID(ctx, req.WorkspaceID).Return(newWorkspace(false), nil)
+		d.repo.EXPECT().WithTransaction(ctx, req.WorkspaceID, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ string, fn func(*sql.Tx) error) error { return fn(nil) },
+		)
+		d.repo.EXPECT().GetBroadcastTx(gomock.Any(), gomock.Any(), req.WorkspaceID, req.ID).Return(testBroadcast(req.WorkspaceID, req.ID), nil)
+		d.contactRepo.EXPECT().CountDeliverableContactsForBroadcast(gomock.Any(), req.WorkspaceID, gomock.Any()).Return(1000, 120, nil)
+		d.repo.EXPECT().UpdateBroadcastTx(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		d.eventBus.EXPECT().PublishWithAck(gomock.Any(), gomock.Any(), gomock.Any()).Do(
+			func(_ context.Context, _ domain.EventPayload, ack domain.EventAckCallback) { ack(nil) },
+		)
+
+		err := d.svc.ScheduleBroadcast(ctx, req)
+		require.NoError(t, err)
+	})
+}
+
+func TestBroadcastService_SendConfirmation(t *testing.T) {
+	const secretKey = "workspace-secret"
+	newWorkspace := func() *domain.Workspace {
+		return &domain.Workspace{
+			ID: "w1",
+			Settings: domain.WorkspaceSettings{
+				MarketingEmailProviderID: "mkt",
+				SendConfirmation:         &domain.SendConfirmationSettings{Enabled: true, TTLSeconds: 60},
+				SecretKey:                secretKey,
+			},
+			Integrations: domain.Integrations{
+				{ID: "mkt", Type: domain.IntegrationTypeEmail, EmailProvider: domain.EmailProvider{Kind: domain.EmailProviderKindSMTP, Senders: []domain.EmailSender{domain.NewEmailSender("from@example.com", "From")}}},
+			},
+		}
+	}
+	newToken := func(broadcastID string, expiresAt time.Time) string {
+		token, err := domain.NewSendConfirmationToken(secretKey, "w1", broadcastID, expiresAt)
+		require.NoError(t, err)
+		return token
+	}
+
+	t.Run("preflight issues a confirmation token", func(t *testing.T) {
+		d := setupBroadcastSvc(t)
+		defer d.ctrl.Finish()
+
+		ctx := context.Background()
+		authOK(d.authService, ctx, "w1")
+		broadcast := testBroadcast("w1", "b1")
+		d.workspaceRepo.EXPECT().GetByID(ctx, "w1").Return(newWorkspace(), nil)
+		d.repo.EXPECT().GetBroadcast(ctx, "w1", "b1").Return(broadcast, nil)
+		d.contactRepo.EXPECT().CountDeliverableContactsForBroadcast(ctx, "w1", broadcast.Audience).Return(100, 100, nil)
+
+		preflight, err := d.svc.PreflightBroadcast(ctx, "w1", "b1")
+		require.NoError(t, err)
+		require.NotEmpty(t, preflight.ConfirmationToken)
+		require.NotNil(t, preflight.ConfirmationExpiresAt)
+		assert.WithinDuration(t, time.Now().Add(time.Minute), *preflight.ConfirmationExpiresAt, 5*time.Second)
+		assert.NoError(t, domain.VerifySendConfirmationToken(secretKey, preflight.ConfirmationToken, "w1", "b1", time.Now()))
+	})
+
+	rejected := []struct {
+		name  string
+		token string
+	}{
+		{name: "missing token is rejected", token: ""},
+		{name: "expired token is rejected", token: newToken("b1", time.Now().Add(-time.Minute))},
+		{name: "token of another broadcast is rejected", token: newToken("b2", time.Now().Add(time.Minute))},
+	}
+	for _, tc := range rejected {
+		t.Run(tc.name, func(t *testing.T) {
+			d := setupBroadcastSvc(t)
+			defer d.ctrl.Finish()
+
+			ctx := context.Background()
+			req := &domain.ScheduleBroadcastRequest{WorkspaceID: "w1", ID: "b1", SendNow: true, ConfirmationToken: tc.token}
+			authOK(d.authService, ctx, req.WorkspaceID)
+			d.workspaceRepo.EXPECT().GetByID(ctx, req.WorkspaceID).Return(newWorkspace(), nil)
+			d.repo.EXPECT().WithTransaction(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+			err := d.svc.ScheduleBroadcast(ctx, req)
+			var confirmationErr *domain.ErrSendConfirmationRequired
+			require.ErrorAs(t, err, &confirmationErr)
+		})
+	}
+
+	t.Run("valid token schedules", func(t *testing.T) {
+		d := setupBroadcastSvc(t)
+		defer d.ctrl.Finish()
+
+		ctx := context.Background()
+		token := newToken("b1", time.Now().Add(time.Minute))
+		req := &domain.ScheduleBroadcastRequest{WorkspaceID: "w1", ID: "b1", SendNow: true, ConfirmationToken: token}
+		authOK(d.authService, ctx, req.WorkspaceID)
+		d.workspaceRepo.EXPECT().GetByID(ctx, req.WorkspaceID).Return(newWorkspace(), nil)
+		d.repo.EXPECT().WithTransaction(ctx, req.WorkspaceID, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ string, fn func(*sql.Tx) error) error { return fn(nil) },
+		)
+		d.repo.EXPECT().GetBroadcastTx(gomock.Any(), gomock.Any(), req.WorkspaceID, req.ID).Return(testBroadcast(req.WorkspaceID, req.ID), nil)
+		d.repo.EXPECT().UpdateBroadcastTx(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+		d.eventBus.EXPECT().PublishWithAck(gomock.Any(), gomock.Any(), gomock.Any()).Do(
+			func(_ context.Context, _ domain.EventPayload, ack domain.EventAckCallback) { ack(nil) },
+		)
+
+		err := d.svc.ScheduleBroadcast(ctx, req)
+		require.NoError(t, err)
+	})
+}
+
+func TestBroadcastService_ScheduleBroadcast_SendingBlock(t *testing.T) {
+	d := setupBroadcastSvc(t)
+	defer d.ctrl.Finish()
+
+	ctx := context.Background()
+	req := &domain.ScheduleBroadcastRequest{WorkspaceID: "w1", ID: "b1", SendNow: true}
+	authOK(d.authService, ctx, req.WorkspaceID)
+
+	workspace := &domain.Workspace{
+		ID: "w1",
+		Settings: domain.WorkspaceSettings{
+			MarketingEmailProviderID: "mkt",
+			SendingBlock:             &domain.SendingBlock{Reason: "Complaint rate 0.80% over the last 1h0m0s exceeds 0.30%", BlockedAt: time.Now().UTC()},
+		},
+		Integrations: domain.Integrations{
+			{ID: "mkt", Type: domain.IntegrationTypeEmail, EmailProvider: domain.EmailProvider{Kind: domain.EmailProviderKindSMTP, Senders: []domain.EmailSender{domain.NewEmailSender("from@example.com", "From")}}},
+		},
+	}
+	d.workspaceRepo.EXPECT().GetByID(ctx, req.WorkspaceID).Return(workspace, nil).Times(2)
+
+	// Blocked workspace refuses to send
+	err := d.svc.ScheduleBroadcast(ctx, req)
+	var blockedErr *domain.ErrWorkspaceSendingBlocked
+	require.ErrorAs(t, err, &blockedErr)
+
+	// Clearing the block re-enables sending
+	workspace.Settings.SendingBlock = nil
+	d.repo.EXPECT().WithTransaction(ctx, req.WorkspaceID, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, fn func(*sql.Tx) error) error { return fn(nil) },
+	)
+	d.repo.EXPECT().GetBroadcastTx(gomock.Any(), gomock.Any(), req.WorkspaceID, req.ID).Return(testBroadcast(req.WorkspaceID, req.ID), nil)
+	d.repo.EXPECT().UpdateBroadcastTx(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	d.eventBus.EXPECT().PublishWithAck(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ context.Context, _ domain.EventPayload, ack domain.EventAckCallback) { ack(nil) })
+
+	err = d.svc.ScheduleBroadcast(ctx, req)
+	require.NoError(t, err)
+}
+
+func TestBroadcastService_ScheduleBroadcast_ProviderTags(t *testing.T) {
+	d := setupBroadcastSvc(t)
+	defer d.ctrl.Finish()
+
+	ctx := context.Background()
+	req := &domain.ScheduleBroadcastRequest{WorkspaceID: "w1", ID: "b1", SendNow: true}
+	authOK(d.authService, ctx, req.WorkspaceID)
+
+	workspace := &domain.Workspace{
+		ID:       "w1",
+		Settings: domain.WorkspaceSettings{MarketingEmailProviderID: "mkt"},
+		Integrations: domain.Integrations{
+			{ID: "mkt", Type: domain.IntegrationTypeEmail, EmailProvider: domain.EmailProvider{Kind: domain.EmailProviderKindPostmark, Senders: []domain.EmailSender{domain.NewEmailSender("from@example.com", "From")}}},
+		},
+	}
+	d.workspaceRepo.EXPECT().GetByID(ctx, req.WorkspaceID).Return(workspace, nil).Times(2)
+	d.repo.EXPECT().WithTransaction(ctx, req.WorkspaceID, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, fn func(*sql.Tx) error) error { return fn(nil) },
+	).Times(2)
+	d.repo.EXPECT().GetBroadcastTx(gomock.Any(), gomock.Any(), req.WorkspaceID, req.ID).DoAndReturn(
+		func(context.Context, *sql.Tx, string, string) (*domain.Broadcast, error) {
+			return testBroadcast(req.WorkspaceID, req.ID), nil
+		},
+	).Times(2)
+
+	// Postmark metadata keys are limited to 20 characters
+	tags := map[string]string{"campaign_identifier_long": "spring"}
+	d.templateSvc.EXPECT().GetTemplateByID(gomock.Any(), req.WorkspaceID, "tplA", int64(0)).DoAndReturn(
+		func(context.Context, string, string, int64) (*domain.Template, error) {
+			return &domain.Template{ID: "tplA", Email: &domain.EmailTemplate{Subject: "S", ProviderTags: tags}}, nil
+		},
+	).Times(2)
+
+	err := d.svc.ScheduleBroadcast(ctx, req)
+	var validationErr domain.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Contains(t, err.Error(), "template tplA: provider tags: Postmark metadata key")
+
+	// Tags within the limits of the provider are scheduled
+	tags = map[string]string{"campaign": "spring"}
+	authOK(d.authService, ctx, req.WorkspaceID)
+	d.repo.EXPECT().UpdateBroadcastTx(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	d.eventBus.EXPECT().PublishWithAck(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ context.Context, _ domain.EventPayload, ack domain.EventAckCallback) { ack(nil) })
+
+	require.NoError(t, d.svc.ScheduleBroadcast(ctx, req))
+}
+
+func TestBroadcastService_ScheduleBroadcast_SendCoolOff(t *testing.T) {
+	d := setupBroadcastSvc(t)
+	defer d.ctrl.Finish()
+
+	ctx := context.Background()
+	req := &domain.ScheduleBroadcastRequest{WorkspaceID: "w1", ID: "b1", SendNow: true}
+	authOK(d.authService, ctx, req.WorkspaceID)
+
+	workspace := &domain.Workspace{
+		ID: "w1",
+		Settings: domain.WorkspaceSettings{
+			MarketingEmailProviderID: "mkt",
+			SendCoolOff:              &domain.SendCoolOffSettings{Enabled: true, Minutes: 5},
+		},
+		Integrations: domain.Integrations{
+			{ID: "mkt", Type: domain.IntegrationTypeEmail, EmailProvider: domain.EmailProvider{Kind: domain.EmailProviderKindSMTP, Senders: []domain.EmailSender{domain.NewEmailSender("from@example.com", "From")}}},
+		},
+	}
+	d.workspaceRepo.EXPECT().GetByID(ctx, req.WorkspaceID).Return(workspace, nil)
+
+	var saved *domain.Broadcast
+	var event domain.EventPayload
+	d.repo.EXPECT().WithTransaction(ctx, req.WorkspaceID, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, fn func(*sql.Tx) error) error { return fn(nil) },
+	)
+	d.repo.EXPECT().GetBroadcastTx(gomock.Any(), gomock.Any(), req.WorkspaceID, req.ID).Return(testBroadcast(req.WorkspaceID, req.ID), nil)
+	d.repo.EXPECT().UpdateBroadcastTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, _ *sql.Tx, b *domain.Broadcast) error {
+		saved = b
+		return nil
+	})
+	d.eventBus.EXPECT().PublishWithAck(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ context.Context, payload domain.EventPayload, ack domain.EventAckCallback) {
+		event = payload
+		ack(nil)
+	})
+
+	before := time.Now().UTC()
+	require.NoError(t, d.svc.ScheduleBroadcast(ctx, req))
+
+	// The broadcast waits in starting_soon and is not started yet
+	require.NotNil(t, saved)
+	assert.Equal(t, domain.BroadcastStatusStartingSoon, saved.Status)
+	assert.Nil(t, saved.StartedAt)
+	require.NotNil(t, saved.Schedule.CoolOffUntil)
+	assert.WithinDuration(t, before.Add(5*time.Minute), *saved.Schedule.CoolOffUntil, 5*time.Second)
+
+	// The task is scheduled for the end of the cool-off
+	assert.Equal(t, string(domain.BroadcastStatusStartingSoon), event.Data["status"])
+	assert.Equal(t, saved.Schedule.CoolOffUntil.Format(time.RFC3339), event.Data["scheduled_time"])
+
+	// Cancelling during the cool-off is allowed
+	cancelReq := &domain.CancelBroadcastRequest{WorkspaceID: "w1", ID: "b1"}
+	authOK(d.authService, ctx, cancelReq.WorkspaceID)
+	d.repo.EXPECT().WithTransaction(ctx, cancelReq.WorkspaceID, gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, fn func(*sql.Tx) error) error { return fn(nil) },
+	)
+	d.repo.EXPECT().GetBroadcastTx(gomock.Any(), gomock.Any(), cancelReq.WorkspaceID, cancelReq.ID).Return(saved, nil)
+	d.repo.EXPECT().UpdateBroadcastTx(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, _ *sql.Tx, b *domain.Broadcast) error {
+		assert.Equal(t, domain.BroadcastStatusCancelled, b.Status)
+		return nil
+	})
+	d.eventBus.EXPECT().PublishWithAck(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ context.Context, payload domain.EventPayload, ack domain.EventAckCallback) {
+		assert.Equal(t, domain.EventBroadcastCancelled, payload.Type)
+		ack(nil)
+	})
+
+	require.NoError(t, d.svc.CancelBroadcast(ctx, cancelReq))
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+)
+
+// getBroadcastStatsCacheTTL returns how long the stats of a broadcast are served from memory.
+// Can be overridden via BROADCAST_STATS_CACHE_TTL environment variable.
+// Default is 10 seconds, dashboards polling faster than that share one query.
+func getBroadcastStatsCacheTTL() time.Duration {
+	if ttl := os.Getenv("BROADCAST_STATS_CACHE_TTL"); ttl != "" {
+		if d, err := time.ParseDuration(ttl); err == nil {
+			return d
+		}
+	}
+	return 10 * time.Second
+}
+
+type broadcastStatsCacheEntry struct {
+	stats     *domain.MessageHistoryStatusSum
+	expiresAt time.Time
+}
+
+// BroadcastStatsCache keeps the message statuses of broadcasts in memory for a short time,
+// so that polling the stats of a broadcast doesn't aggregate message_history on every call
+type BroadcastStatsCache struct {
+	mu      sync.Mutex
+	entries map[string]map[string]broadcastStatsCacheEntry // workspace ID -> broadcast ID -> stats
+	ttl     time.Duration
+	now     func() time.Time
+}
+
+// NewBroadcastStatsCache creates an empty broadcast stats cache
+func NewBroadcastStatsCache() *BroadcastStatsCache {
+	return &BroadcastStatsCache{
+		entries: make(map[string]map[string]broadcastStatsCacheEntry),
+		ttl:     getBroadcastStatsCacheTTL(),
+		now:     time.Now,
+	}
+}
+
+// Get returns the cached stats of a broadcast, false when missing or expired
+func (c *BroadcastStatsCache) Get(workspaceID, broadcastID string) (*domain.MessageHistoryStatusSum, bool) {
+	if c == nil {
+		return nil, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[workspaceID][broadcastID]
+	if !ok {
+		return nil, false
+	}
+	if c.now().After(entry.expiresAt) {
+		delete(c.entries[workspaceID], broadcastID)
+		return nil, false
+	}
+	return entry.stats, true
+}
+
+// Set caches the stats of a broadcast for the cache TTL
+func (c *BroadcastStatsCache) Set(workspaceID, broadcastID string, stats *domain.MessageHistoryStatusSum) {
+	if c == nil || c.ttl <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	broadcasts, ok := c.entries[workspaceID]
+	if !ok {
+		broadcasts = make(map[string]broadcastStatsCacheEntry)
+		c.entries[workspaceID] = broadcasts
+	}
+	broadcasts[broadcastID] = broadcastStatsCacheEntry{stats: stats, expiresAt: c.now().Add(c.ttl)}
+}
+
+// InvalidateWorkspace drops the cached stats of the broadcasts of a workspace. Webhooks identify
+// the messages they update by ID only, so their status updates bust every broadcast of the workspace
+// rather than resolving the broadcast of each message.
+func (c *BroadcastStatsCache) InvalidateWorkspace(workspaceID string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, workspaceID)
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/domain/mocks"
+	"github.com/Notifuse/notifuse/pkg/logger"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBroadcastStatsCache(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	cache := NewBroadcastStatsCache()
+	cache.ttl = 10 * time.Second
+	cache.now = func() time.Time { return now }
+
+	stats := &domain.MessageHistoryStatusSum{TotalSent: 10}
+	cache.Set("w1", "b1", stats)
+	cache.Set("w2", "b1", stats)
+
+	got, ok := cache.Get("w1", "b1")
+	require.True(t, ok)
+	assert.Equal(t, stats, got)
+
+	_, ok = cache.Get("w1", "b2")
+	assert.False(t, ok)
+
+	// Invalidation is scoped to the workspace
+	cache.InvalidateWorkspace("w1")
+	_, ok = cache.Get("w1", "b1")
+	assert.False(t, ok)
+	_, ok = cache.Get("w2", "b1")
+	assert.True(t, ok)
+
+	now = now.Add(11 * time.Second)
+	_, ok = cache.Get("w2", "b1")
+	assert.False(t, ok, "expired entries are not served")
+
+	var nilCache *BroadcastStatsCache
+	nilCache.Set("w1", "b1", stats)
+	nilCache.InvalidateWorkspace("w1")
+	_, ok = nilCache.Get("w1", "b1")
+	assert.False(t, ok)
+}
+
+func TestBroadcastService_GetBroadcastStats_Cached(t *testing.T) {
+	d := setupBroadcastSvc(t)
+	defer d.ctrl.Finish()
+
+	ctx := context.Background()
+	workspaceID := "w1"
+	broadcastID := "b1"
+
+	cache := NewBroadcastStatsCache()
+	cache.ttl = time.Minute
+	d.svc.SetStatsCache(cache)
+
+	// Two rapid calls aggregate message_history once
+	authOK(d.authService, ctx, workspaceID)
+	authOK(d.authService, ctx, workspaceID)
+	d.repo.EXPECT().GetBroadcast(ctx, workspaceID, broadcastID).Return(testBroadcast(workspaceID, broadcastID), nil)
+	d.messageHistoryRepo.EXPECT().GetBroadcastStats(ctx, workspaceID, broadcastID).
+		Return(&domain.MessageHistoryStatusSum{TotalSent: 100, TotalDelivered: 90, TotalOpened: 40, TotalClicked: 10, TotalBounced: 5}, nil)
+
+	first, err := d.svc.GetBroadcastStats(ctx, workspaceID, broadcastID)
+	require.NoError(t, err)
+	second, err := d.svc.GetBroadcastStats(ctx, workspaceID, broadcastID)
+	require.NoError(t, err)
+	assert.Equal(t, first, second)
+	assert.InDelta(t, 0.9, first.Rates.DeliveryRate, 0.0001)
+	assert.InDelta(t, 0.4, first.Rates.OpenRate, 0.0001)
+	assert.InDelta(t, 0.1, first.Rates.ClickRate, 0.0001)
+	assert.InDelta(t, 0.05, first.Rates.BounceRate, 0.0001)
+
+	// A webhook status update of the workspace busts the cached stats
+	integrationID := "integration1"
+	webhookRepo := mocks.NewMockInboundWebhookEventRepository(d.ctrl)
+	webhookRepo.EXPECT().StoreEvents(gomock.Any(), workspaceID, gomock.Any()).Return(nil)
+	d.workspaceRepo.EXPECT().GetByID(gomock.Any(), workspaceID).Return(&domain.Workspace{
+		ID: workspaceID,
+		Integrations: []domain.Integration{{
+			ID:            integrationID,
+			EmailProvider: domain.EmailProvider{Kind: domain.EmailProviderKindSES, SES: &domain.AmazonSESSettings{Region: "us-east-1"}},
+		}},
+	}, nil)
+	d.messageHistoryRepo.EXPECT().SetStatusesIfNotSet(gomock.Any(), workspaceID, gomock.Any()).Return(nil)
+
+	webhookService := NewInboundWebhookEventService(webhookRepo, d.authService, logger.NewLoggerWithLevel("disabled"), d.workspaceRepo, d.messageHistoryRepo, 0)
+	webhookService.SetBroadcastStatsCache(cache)
+	rawPayload, err := json.Marshal(domain.SESWebhookPayload{
+		Message: `{"eventType":"Delivery","delivery":{"timestamp":"2026-03-01T12:00:00Z","recipients":["test@example.com"]},"mail":{"messageId":"message1"}}`,
+	})
+	require.NoError(t, err)
+	require.NoError(t, webhookService.ProcessWebhook(ctx, workspaceID, integrationID, rawPayload))
+
+	authOK(d.authService, ctx, workspaceID)
+	d.repo.EXPECT().GetBroadcast(ctx, workspaceID, broadcastID).Return(testBroadcast(workspaceID, broadcastID), nil)
+	d.messageHistoryRepo.EXPECT().GetBroadcastStats(ctx, workspaceID, broadcastID).
+		Return(&domain.MessageHistoryStatusSum{TotalSent: 100, TotalDelivered: 95}, nil)
+
+	third, err := d.svc.GetBroadcastStats(ctx, workspaceID, broadcastID)
+	require.NoError(t, err)
+	assert.Equal(t, 95, third.Stats.TotalDelivered)
+}
+
+func TestNewBroadcastStats_NoMessagesSent(t *testing.T) {
+	stats := domain.NewBroadcastStats("b1", &domain.MessageHistoryStatusSum{})
+	assert.Equal(t, domain.BroadcastStatsRates{}, stats.Rates)
+}
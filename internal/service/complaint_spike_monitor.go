@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/pkg/logger"
+)
+
+// complaintSpikePauseLimit bounds the number of broadcasts of each active status paused per check
+const complaintSpikePauseLimit = 1000
+
+// complaintSpikeActiveStatuses are the statuses of the broadcasts still enqueueing emails
+var complaintSpikeActiveStatuses = []domain.BroadcastStatus{
+	domain.BroadcastStatusProcessing,
+	domain.BroadcastStatusTesting,
+	domain.BroadcastStatusWinnerSelected,
+}
+
+// ComplaintSpikeMonitor watches the complaint rate of every workspace over the rolling window of its
+// complaint_spike settings. When the rate exceeds the threshold, the workspace gets a sending block:
+// its active broadcasts are paused, a workspace.sending_blocked event notifies the owners, and no
+// broadcast can be sent until an owner clears the block.
+type ComplaintSpikeMonitor struct {
+	workspaceRepo      domain.WorkspaceRepository
+	messageHistoryRepo domain.MessageHistoryRepository
+	broadcastRepo      domain.BroadcastRepository
+	eventBus           domain.EventBus
+	logger             logger.Logger
+	checkInterval      time.Duration
+	now                func() time.Time
+}
+
+// NewComplaintSpikeMonitor creates a new workspace complaint spike monitor
+func NewComplaintSpikeMonitor(
+	workspaceRepo domain.WorkspaceRepository,
+	messageHistoryRepo domain.MessageHistoryRepository,
+	broadcastRepo domain.BroadcastRepository,
+	eventBus domain.EventBus,
+	logger logger.Logger,
+) *ComplaintSpikeMonitor {
+	return &ComplaintSpikeMonitor{
+		workspaceRepo:      workspaceRepo,
+		messageHistoryRepo: messageHistoryRepo,
+		broadcastRepo:      broadcastRepo,
+		eventBus:           eventBus,
+		logger:             logger,
+		checkInterval:      time.Minute,
+		now:                time.Now,
+	}
+}
+
+// Start starts checking the complaint rate of every workspace periodically
+func (m *ComplaintSpikeMonitor) Start(ctx context.Context) {
+	m.logger.Info("Complaint spike monitor started")
+
+	ticker := time.NewTicker(m.checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			m.logger.Info("Complaint spike monitor stopping...")
+			return
+		case <-ticker.C:
+			m.checkWorkspaces(ctx)
+		}
+	}
+}
+
+// checkWorkspaces checks the complaint rate of every workspace
+func (m *ComplaintSpikeMonitor) checkWorkspaces(ctx context.Context) {
+	workspaces, err := m.workspaceRepo.List(ctx)
+	if err != nil {
+		m.logger.WithField("error", err.Error()).Error("Failed to list workspaces for complaint spike check")
+		return
+	}
+
+	for _, workspace := range workspaces {
+		if err := m.CheckWorkspace(ctx, workspace); err != nil {
+			m.logger.WithFields(map[string]interface{}{
+				"workspace_id": workspace.ID,
+				"error":        err.Error(),
+			}).Error("Failed to check complaint rate for workspace")
+		}
+	}
+}
+
+// CheckWorkspace blocks the sending of the workspace when its complaint rate spikes. While the
+// workspace is blocked, broadcasts that started since, such as a scheduled one, are paused as well.
+func (m *ComplaintSpikeMonitor) CheckWorkspace(ctx context.Context, workspace *domain.Workspace) error {
+	if block := workspace.Settings.SendingBlock; block != nil {
+		_, err := m.pauseActiveBroadcasts(ctx, workspace.ID, block.Reason)
+		return err
+	}
+
+	settings := workspace.Settings.ComplaintSpike
+	if settings == nil || !settings.Enabled {
+		return nil
+	}
+
+	now := m.now().UTC()
+	sent, complaints, err := m.messageHistoryRepo.CountSentAndComplaintsSince(ctx, workspace.ID, now.Add(-settings.Window()))
+	if err != nil {
+		return fmt.Errorf("failed to count complaints: %w", err)
+	}
+	if !settings.IsSpike(sent, complaints) {
+		return nil
+	}
+
+	rate := float64(complaints) / float64(sent)
+	block := &domain.SendingBlock{
+		Reason:        fmt.Sprintf("Complaint rate %.2f%% over the last %s exceeds %.2f%%", rate*100, settings.Window(), settings.MaxRate*100),
+		ComplaintRate: rate,
+		Sent:          sent,
+		Complaints:    complaints,
+		BlockedAt:     now,
+	}
+
+	// Reload the workspace so that the block does not overwrite concurrent settings changes
+	current, err := m.workspaceRepo.GetByID(ctx, workspace.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get workspace: %w", err)
+	}
+	current.Settings.SendingBlock = block
+	if err := m.workspaceRepo.Update(ctx, current); err != nil {
+		return fmt.Errorf("failed to save sending block: %w", err)
+	}
+
+	m.logger.WithFields(map[string]interface{}{
+		"workspace_id":   workspace.ID,
+		"sent":           sent,
+		"complaints":     complaints,
+		"complaint_rate": rate,
+	}).Warn("Complaint spike detected, blocking workspace sending")
+
+	paused, pauseErr := m.pauseActiveBroadcasts(ctx, workspace.ID, block.Reason)
+
+	m.eventBus.Publish(ctx, domain.NewWorkspaceSendingBlockedEvent(workspace.ID, block, paused))
+
+	return pauseErr
+}
+
+// pauseActiveBroadcasts pauses the broadcasts of the workspace that are enqueueing emails and
+// returns their IDs
+func (m *ComplaintSpikeMonitor) pauseActiveBroadcasts(ctx context.Context, workspaceID, reason string) ([]string, error) {
+	paused := []string{}
+
+	for _, status := range complaintSpikeActiveStatuses {
+		result, err := m.broadcastRepo.ListBroadcasts(ctx, domain.ListBroadcastsParams{
+			WorkspaceID: workspaceID,
+			Status:      status,
+			Limit:       complaintSpikePauseLimit,
+		})
+		if err != nil {
+			return paused, fmt.Errorf("failed to list %s broadcasts: %w", status, err)
+		}
+
+		for _, broadcast := range result.Broadcasts {
+			now := m.now().UTC()
+			pauseReason := reason
+			broadcast.Status = domain.BroadcastStatusPaused
+			broadcast.PausedAt = &now
+			broadcast.PauseReason = &pauseReason
+			broadcast.UpdatedAt = now
+
+			if err := m.broadcastRepo.UpdateBroadcast(ctx, broadcast); err != nil {
+				return paused, fmt.Errorf("failed to pause broadcast %s: %w", broadcast.ID, err)
+			}
+			paused = append(paused, broadcast.ID)
+
+			m.logger.WithFields(map[string]interface{}{
+				"workspace_id": workspaceID,
+				"broadcast_id": broadcast.ID,
+			}).Info("Broadcast paused due to workspace complaint spike")
+
+			// Pauses the task of the broadcast
+			m.eventBus.Publish(ctx, domain.EventPayload{
+				Type:        domain.EventBroadcastPaused,
+				WorkspaceID: workspaceID,
+				EntityID:    broadcast.ID,
+				Data: map[string]interface{}{
+					"broadcast_id": broadcast.ID,
+					"reason":       "complaint_spike",
+				},
+			})
+		}
+	}
+
+	return paused, nil
+}
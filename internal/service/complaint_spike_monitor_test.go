@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/domain/mocks"
+	pkgmocks "github.com/Notifuse/notifuse/pkg/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type complaintSpikeMonitorDeps struct {
+	workspaceRepo      *mocks.MockWorkspaceRepository
+	messageHistoryRepo *mocks.MockMessageHistoryRepository
+	broadcastRepo      *mocks.MockBroadcastRepository
+	eventBus           *mocks.MockEventBus
+	monitor            *ComplaintSpikeMonitor
+	now                time.Time
+}
+
+func setupComplaintSpikeMonitorTest(t *testing.T) *complaintSpikeMonitorDeps {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	log := pkgmocks.NewMockLogger(ctrl)
+	log.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(log).AnyTimes()
+	log.EXPECT().WithFields(gomock.Any()).Return(log).AnyTimes()
+	log.EXPECT().Info(gomock.Any()).AnyTimes()
+	log.EXPECT().Warn(gomock.Any()).AnyTimes()
+
+	d := &complaintSpikeMonitorDeps{
+		workspaceRepo:      mocks.NewMockWorkspaceRepository(ctrl),
+		messageHistoryRepo: mocks.NewMockMessageHistoryRepository(ctrl),
+		broadcastRepo:      mocks.NewMockBroadcastRepository(ctrl),
+		eventBus:           mocks.NewMockEventBus(ctrl),
+		now:                time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+	}
+	d.monitor = NewComplaintSpikeMonitor(d.workspaceRepo, d.messageHistoryRepo, d.broadcastRepo, d.eventBus, log)
+	d.monitor.now = func() time.Time { return d.now }
+
+	return d
+}
+
+func complaintSpikeWorkspace() *domain.Workspace {
+	return &domain.Workspace{
+		ID:   "workspace-1",
+		Name: "Test Workspace",
+		Settings: domain.WorkspaceSettings{
+			Timezone:       "UTC",
+			ComplaintSpike: &domain.ComplaintSpikeSettings{Enabled: true, MaxRate: 0.003, WindowMinutes: 60, MinSent: 500},
+		},
+	}
+}
+
+// expectActiveBroadcasts lists the given broadcasts as processing and no testing or winner_selected ones
+func (d *complaintSpikeMonitorDeps) expectActiveBroadcasts(broadcasts ...*domain.Broadcast) {
+	for _, status := range complaintSpikeActiveStatuses {
+		result := &domain.BroadcastListResponse{Broadcasts: []*domain.Broadcast{}}
+		if status == domain.BroadcastStatusProcessing {
+			result.Broadcasts = broadcasts
+			result.TotalCount = len(broadcasts)
+		}
+		d.broadcastRepo.EXPECT().ListBroadcasts(gomock.Any(), domain.ListBroadcastsParams{
+			WorkspaceID: "workspace-1",
+			Status:      status,
+			Limit:       complaintSpikePauseLimit,
+		}).Return(result, nil)
+	}
+}
+
+func TestComplaintSpikeMonitor_CheckWorkspace(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("spike blocks sending and pauses active broadcasts", func(t *testing.T) {
+		d := setupComplaintSpikeMonitorTest(t)
+		workspace := complaintSpikeWorkspace()
+
+		d.messageHistoryRepo.EXPECT().CountSentAndComplaintsSince(gomock.Any(), "workspace-1", d.now.Add(-time.Hour)).Return(1000, 8, nil)
+
+		var saved *domain.Workspace
+		d.workspaceRepo.EXPECT().GetByID(gomock.Any(), "workspace-1").Return(complaintSpikeWorkspace(), nil)
+		d.workspaceRepo.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, ws *domain.Workspace) error {
+			saved = ws
+			return nil
+		})
+
+		d.expectActiveBroadcasts(&domain.Broadcast{ID: "broadcast-1", WorkspaceID: "workspace-1", Status: domain.BroadcastStatusProcessing})
+		d.broadcastRepo.EXPECT().UpdateBroadcast(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, b *domain.Broadcast) error {
+			assert.Equal(t, domain.BroadcastStatusPaused, b.Status)
+			require.NotNil(t, b.PauseReason)
+			assert.Contains(t, *b.PauseReason, "Complaint rate 0.80%")
+			return nil
+		})
+
+		var published []domain.EventPayload
+		d.eventBus.EXPECT().Publish(gomock.Any(), gomock.Any()).Do(func(_ context.Context, event domain.EventPayload) {
+			published = append(published, event)
+		}).Times(2)
+
+		require.NoError(t, d.monitor.CheckWorkspace(ctx, workspace))
+
+		require.NotNil(t, saved)
+		block := saved.Settings.SendingBlock
+		require.NotNil(t, block)
+		assert.Equal(t, 1000, block.Sent)
+		assert.Equal(t, 8, block.Complaints)
+		assert.InDelta(t, 0.008, block.ComplaintRate, 0.0001)
+		assert.Equal(t, d.now, block.BlockedAt)
+		assert.Error(t, saved.Settings.CheckSendingAllowed())
+
+		require.Len(t, published, 2)
+		assert.Equal(t, domain.EventBroadcastPaused, published[0].Type)
+		assert.Equal(t, "broadcast-1", published[0].EntityID)
+		assert.Equal(t, domain.EventWorkspaceSendingBlocked, published[1].Type)
+		assert.Equal(t, []string{"broadcast-1"}, published[1].Data["paused_broadcast_ids"])
+	})
+
+	t.Run("rate under the threshold has no effect", func(t *testing.T) {
+		d := setupComplaintSpikeMonitorTest(t)
+
+		d.messageHistoryRepo.EXPECT().CountSentAndComplaintsSince(gomock.Any(), "workspace-1", gomock.Any()).Return(1000, 2, nil)
+
+		require.NoError(t, d.monitor.CheckWorkspace(ctx, complaintSpikeWorkspace()))
+	})
+
+	t.Run("volume under min_sent has no effect", func(t *testing.T) {
+		d := setupComplaintSpikeMonitorTest(t)
+
+		d.messageHistoryRepo.EXPECT().CountSentAndComplaintsSince(gomock.Any(), "workspace-1", gomock.Any()).Return(100, 10, nil)
+
+		require.NoError(t, d.monitor.CheckWorkspace(ctx, complaintSpikeWorkspace()))
+	})
+
+	t.Run("disabled settings are skipped", func(t *testing.T) {
+		d := setupComplaintSpikeMonitorTest(t)
+		workspace := complaintSpikeWorkspace()
+		workspace.Settings.ComplaintSpike.Enabled = false
+
+		require.NoError(t, d.monitor.CheckWorkspace(ctx, workspace))
+	})
+
+	t.Run("blocked workspace pauses broadcasts started since", func(t *testing.T) {
+		d := setupComplaintSpikeMonitorTest(t)
+		workspace := complaintSpikeWorkspace()
+		workspace.Settings.SendingBlock = &domain.SendingBlock{Reason: "complaint spike", BlockedAt: d.now.Add(-time.Hour)}
+
+		d.expectActiveBroadcasts(&domain.Broadcast{ID: "broadcast-2", WorkspaceID: "workspace-1", Status: domain.BroadcastStatusProcessing})
+		d.broadcastRepo.EXPECT().UpdateBroadcast(gomock.Any(), gomock.Any()).Return(nil)
+		d.eventBus.EXPECT().Publish(gomock.Any(), gomock.Any()).Do(func(_ context.Context, event domain.EventPayload) {
+			assert.Equal(t, domain.EventBroadcastPaused, event.Type)
+		})
+
+		require.NoError(t, d.monitor.CheckWorkspace(ctx, workspace))
+	})
+}
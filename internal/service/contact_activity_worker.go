@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/pkg/logger"
+)
+
+// contactActivityVisibilityLag leaves time for in-flight transactions to commit before their
+// timeline entries are read, so that entries are not skipped past the checkpoint
+const contactActivityVisibilityLag = 5 * time.Second
+
+// contactActivityMaxBatchesPerPoll bounds the work done for a workspace in a single poll
+const contactActivityMaxBatchesPerPoll = 10
+
+// ContactActivityWorker reads the contact timeline of workspaces with contact.activity webhook
+// subscriptions and publishes the activities on the event bus, in timeline order.
+// It reads from the checkpoint of the slowest subscription; the subscribers advance the
+// checkpoints once the activities are queued for delivery.
+type ContactActivityWorker struct {
+	workspaceRepo    domain.WorkspaceRepository
+	subscriptionRepo domain.WebhookSubscriptionRepository
+	timelineRepo     domain.ContactTimelineRepository
+	eventBus         domain.EventBus
+	logger           logger.Logger
+	pollInterval     time.Duration
+	batchSize        int
+}
+
+// NewContactActivityWorker creates a new contact activity worker
+func NewContactActivityWorker(
+	workspaceRepo domain.WorkspaceRepository,
+	subscriptionRepo domain.WebhookSubscriptionRepository,
+	timelineRepo domain.ContactTimelineRepository,
+	eventBus domain.EventBus,
+	logger logger.Logger,
+) *ContactActivityWorker {
+	return &ContactActivityWorker{
+		workspaceRepo:    workspaceRepo,
+		subscriptionRepo: subscriptionRepo,
+		timelineRepo:     timelineRepo,
+		eventBus:         eventBus,
+		logger:           logger,
+		pollInterval:     5 * time.Second,
+		batchSize:        500,
+	}
+}
+
+// Start starts the contact activity worker
+func (w *ContactActivityWorker) Start(ctx context.Context) {
+	w.logger.Info("Contact activity worker started")
+
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("Contact activity worker stopping...")
+			return
+		case <-ticker.C:
+			w.processWorkspaces(ctx)
+		}
+	}
+}
+
+// processWorkspaces publishes the new activities of every workspace
+func (w *ContactActivityWorker) processWorkspaces(ctx context.Context) {
+	workspaces, err := w.workspaceRepo.List(ctx)
+	if err != nil {
+		w.logger.WithField("error", err.Error()).Error("Failed to list workspaces for contact activity processing")
+		return
+	}
+
+	for _, workspace := range workspaces {
+		if err := w.ProcessWorkspace(ctx, workspace.ID); err != nil {
+			w.logger.WithFields(map[string]interface{}{
+				"workspace_id": workspace.ID,
+				"error":        err.Error(),
+			}).Error("Failed to process contact activities for workspace")
+		}
+	}
+}
+
+// ProcessWorkspace publishes the activities a workspace's contact.activity subscriptions have not received yet
+func (w *ContactActivityWorker) ProcessWorkspace(ctx context.Context, workspaceID string) error {
+	until := time.Now().UTC().Add(-contactActivityVisibilityLag)
+	previous := ""
+
+	for i := 0; i < contactActivityMaxBatchesPerPoll; i++ {
+		checkpoint, err := w.slowestCheckpoint(ctx, workspaceID, until)
+		if err != nil || checkpoint == "" {
+			return err
+		}
+		// A subscriber failed to queue the last batch, retry on the next poll
+		if checkpoint == previous {
+			return nil
+		}
+		previous = checkpoint
+
+		entries, err := w.timelineRepo.ListSince(ctx, workspaceID, checkpoint, until, w.batchSize)
+		if err != nil {
+			return fmt.Errorf("failed to list contact timeline: %w", err)
+		}
+		if len(entries) == 0 {
+			return nil
+		}
+
+		activities := make([]*domain.ContactActivity, 0, len(entries))
+		for _, entry := range entries {
+			if activity, ok := domain.NewContactActivity(entry); ok {
+				activities = append(activities, activity)
+			}
+		}
+		last := entries[len(entries)-1]
+
+		// The batch checkpoint also covers entries that are not activities, so they are not read again
+		if err := w.publish(ctx, workspaceID, activities, domain.NewContactActivityCheckpoint(last.DBCreatedAt, last.ID)); err != nil {
+			return err
+		}
+
+		if len(entries) < w.batchSize {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// slowestCheckpoint returns the lowest checkpoint of the enabled contact.activity subscriptions, or an
+// empty string when there are none. Subscriptions without checkpoint start at until.
+func (w *ContactActivityWorker) slowestCheckpoint(ctx context.Context, workspaceID string, until time.Time) (string, error) {
+	subs, err := w.subscriptionRepo.List(ctx, workspaceID)
+	if err != nil {
+		return "", fmt.Errorf("failed to list webhook subscriptions: %w", err)
+	}
+
+	slowest := ""
+	for _, sub := range subs {
+		if !sub.Enabled || !slices.Contains(sub.Settings.EventTypes, string(domain.EventContactActivity)) {
+			continue
+		}
+
+		checkpoint := sub.Settings.ContactActivityCheckpoint
+		if checkpoint == "" {
+			checkpoint = domain.NewContactActivityCheckpoint(until, "")
+			if err := w.subscriptionRepo.UpdateContactActivityCheckpoint(ctx, workspaceID, sub.ID, checkpoint); err != nil {
+				return "", fmt.Errorf("failed to initialize contact activity checkpoint: %w", err)
+			}
+		}
+
+		if slowest == "" || checkpoint < slowest {
+			slowest = checkpoint
+		}
+	}
+
+	return slowest, nil
+}
+
+// publish publishes a batch of activities and waits for the subscribers to handle it
+func (w *ContactActivityWorker) publish(ctx context.Context, workspaceID string, activities []*domain.ContactActivity, checkpoint string) error {
+	done := make(chan error, 1)
+	w.eventBus.PublishWithAck(ctx, domain.EventPayload{
+		Type:        domain.EventContactActivity,
+		WorkspaceID: workspaceID,
+		EntityID:    checkpoint,
+		Data: map[string]interface{}{
+			"activities": activities,
+			"checkpoint": checkpoint,
+		},
+	}, func(err error) {
+		done <- err
+	})
+
+	select {
+	case err := <-done:
+		if err != nil {
+			return fmt.Errorf("failed to handle contact activities: %w", err)
+		}
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
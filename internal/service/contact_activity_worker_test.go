@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/domain/mocks"
+	pkgmocks "github.com/Notifuse/notifuse/pkg/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupContactActivityWorkerTest(t *testing.T) (*ContactActivityWorker, *mocks.MockWebhookSubscriptionRepository, *mocks.MockContactTimelineRepository, *mocks.MockEventBus) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	subscriptionRepo := mocks.NewMockWebhookSubscriptionRepository(ctrl)
+	timelineRepo := mocks.NewMockContactTimelineRepository(ctrl)
+	eventBus := mocks.NewMockEventBus(ctrl)
+	log := pkgmocks.NewMockLogger(ctrl)
+	log.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(log).AnyTimes()
+	log.EXPECT().WithFields(gomock.Any()).Return(log).AnyTimes()
+	log.EXPECT().Info(gomock.Any()).AnyTimes()
+	log.EXPECT().Error(gomock.Any()).AnyTimes()
+
+	worker := NewContactActivityWorker(mocks.NewMockWorkspaceRepository(ctrl), subscriptionRepo, timelineRepo, eventBus, log)
+	return worker, subscriptionRepo, timelineRepo, eventBus
+}
+
+func contactActivitySubscription(checkpoint string) *domain.WebhookSubscription {
+	return &domain.WebhookSubscription{
+		ID:      "sub1",
+		Enabled: true,
+		Settings: domain.WebhookSubscriptionSettings{
+			EventTypes:                []string{"contact.activity"},
+			ContactActivityCheckpoint: checkpoint,
+		},
+	}
+}
+
+func TestContactActivityWorker_ProcessWorkspace(t *testing.T) {
+	ctx := context.Background()
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	start := domain.NewContactActivityCheckpoint(at, "")
+	listID := "newsletter"
+
+	entries := []*domain.ContactTimelineEntry{
+		{
+			ID: "00000000-0000-0000-0000-000000000001", Email: "john@example.com", Operation: "update", EntityType: "contact",
+			Kind: "update_contact", Changes: map[string]interface{}{"first_name": map[string]interface{}{"old": "Jon", "new": "John"}},
+			CreatedAt: at, DBCreatedAt: at,
+		},
+		{
+			// Not a contact activity, only moves the checkpoint
+			ID: "00000000-0000-0000-0000-000000000002", Email: "john@example.com", Operation: "update", EntityType: "message_history",
+			Kind: "update_message_history", CreatedAt: at, DBCreatedAt: at.Add(time.Millisecond),
+		},
+		{
+			ID: "00000000-0000-0000-0000-000000000003", Email: "john@example.com", Operation: "update", EntityType: "contact_list",
+			Kind: "update_contact_list", EntityID: &listID, Changes: map[string]interface{}{"status": map[string]interface{}{"old": "active", "new": "unsubscribed"}},
+			CreatedAt: at, DBCreatedAt: at.Add(2 * time.Millisecond),
+		},
+	}
+	end := domain.NewContactActivityCheckpoint(entries[2].DBCreatedAt, entries[2].ID)
+
+	t.Run("publishes a field update and a list change as typed activities", func(t *testing.T) {
+		worker, subscriptionRepo, timelineRepo, eventBus := setupContactActivityWorkerTest(t)
+
+		subscriptionRepo.EXPECT().List(ctx, "ws1").Return([]*domain.WebhookSubscription{contactActivitySubscription(start)}, nil)
+		timelineRepo.EXPECT().ListSince(ctx, "ws1", start, gomock.Any(), worker.batchSize).Return(entries, nil)
+		eventBus.EXPECT().PublishWithAck(ctx, gomock.Any(), gomock.Any()).Do(func(_ context.Context, event domain.EventPayload, ack domain.EventAckCallback) {
+			assert.Equal(t, domain.EventContactActivity, event.Type)
+			assert.Equal(t, "ws1", event.WorkspaceID)
+			assert.Equal(t, end, event.Data["checkpoint"])
+
+			activities := event.Data["activities"].([]*domain.ContactActivity)
+			require.Len(t, activities, 2)
+
+			assert.Equal(t, domain.ContactActivityFieldUpdate, activities[0].Type)
+			assert.Equal(t, "john@example.com", activities[0].Email)
+			assert.Contains(t, activities[0].Changes, "first_name")
+
+			assert.Equal(t, domain.ContactActivityListChange, activities[1].Type)
+			assert.Equal(t, listID, *activities[1].EntityID)
+			assert.Equal(t, end, activities[1].Checkpoint)
+			assert.Less(t, activities[0].Checkpoint, activities[1].Checkpoint)
+
+			ack(nil)
+		})
+
+		require.NoError(t, worker.ProcessWorkspace(ctx, "ws1"))
+	})
+
+	t.Run("starts new subscriptions at the current position", func(t *testing.T) {
+		worker, subscriptionRepo, timelineRepo, _ := setupContactActivityWorkerTest(t)
+
+		var initialized string
+		subscriptionRepo.EXPECT().List(ctx, "ws1").Return([]*domain.WebhookSubscription{contactActivitySubscription("")}, nil)
+		subscriptionRepo.EXPECT().UpdateContactActivityCheckpoint(ctx, "ws1", "sub1", gomock.Any()).DoAndReturn(func(_ context.Context, _, _, checkpoint string) error {
+			initialized = checkpoint
+			return nil
+		})
+		timelineRepo.EXPECT().ListSince(ctx, "ws1", gomock.Any(), gomock.Any(), worker.batchSize).DoAndReturn(func(_ context.Context, _, checkpoint string, until time.Time, _ int) ([]*domain.ContactTimelineEntry, error) {
+			assert.Equal(t, initialized, checkpoint)
+			assert.Equal(t, domain.NewContactActivityCheckpoint(until, ""), checkpoint)
+			return nil, nil
+		})
+
+		require.NoError(t, worker.ProcessWorkspace(ctx, "ws1"))
+	})
+
+	t.Run("skips workspaces without contact.activity subscriptions", func(t *testing.T) {
+		worker, subscriptionRepo, _, _ := setupContactActivityWorkerTest(t)
+
+		subscriptionRepo.EXPECT().List(ctx, "ws1").Return([]*domain.WebhookSubscription{
+			{ID: "sub1", Enabled: true, Settings: domain.WebhookSubscriptionSettings{EventTypes: []string{"contact.updated"}}},
+		}, nil)
+
+		require.NoError(t, worker.ProcessWorkspace(ctx, "ws1"))
+	})
+
+	t.Run("stops when the subscribers do not advance", func(t *testing.T) {
+		worker, subscriptionRepo, timelineRepo, eventBus := setupContactActivityWorkerTest(t)
+		worker.batchSize = len(entries)
+
+		subscriptionRepo.EXPECT().List(ctx, "ws1").Return([]*domain.WebhookSubscription{contactActivitySubscription(start)}, nil).Times(2)
+		timelineRepo.EXPECT().ListSince(ctx, "ws1", start, gomock.Any(), len(entries)).Return(entries, nil)
+		eventBus.EXPECT().PublishWithAck(ctx, gomock.Any(), gomock.Any()).Do(func(_ context.Context, _ domain.EventPayload, ack domain.EventAckCallback) {
+			ack(nil)
+		})
+
+		require.NoError(t, worker.ProcessWorkspace(ctx, "ws1"))
+	})
+
+	t.Run("returns subscriber errors", func(t *testing.T) {
+		worker, subscriptionRepo, timelineRepo, eventBus := setupContactActivityWorkerTest(t)
+
+		subscriptionRepo.EXPECT().List(ctx, "ws1").Return([]*domain.WebhookSubscription{contactActivitySubscription(start)}, nil)
+		timelineRepo.EXPECT().ListSince(ctx, "ws1", start, gomock.Any(), worker.batchSize).Return(entries, nil)
+		eventBus.EXPECT().PublishWithAck(ctx, gomock.Any(), gomock.Any()).Do(func(_ context.Context, _ domain.EventPayload, ack domain.EventAckCallback) {
+			ack(errors.New("event handler timed out"))
+		})
+
+		err := worker.ProcessWorkspace(ctx, "ws1")
+		assert.Error(t, err)
+	})
+}
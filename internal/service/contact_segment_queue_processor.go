@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/Notifuse/notifuse/internal/domain"
 	"github.com/Notifuse/notifuse/pkg/logger"
@@ -259,6 +260,9 @@ func (p *ContactSegmentQueueProcessor) processContact(ctx context.Context, works
 		fullQuery += " UNION ALL (" + queryParts[i] + ")"
 	}
 
+	// Evaluation time orders this result against concurrent recomputes of the same segments
+	evaluatedAt := time.Now().UTC()
+
 	// Execute the combined query to get all matching segment IDs
 	rows, err := workspaceDB.QueryContext(ctx, fullQuery, allArgs...)
 	if err != nil {
@@ -285,7 +289,7 @@ func (p *ContactSegmentQueueProcessor) processContact(ctx context.Context, works
 	for _, segment := range segments {
 		if matchingSegments[segment.ID] {
 			// Contact matches - add to segment
-			if err := p.segmentRepo.AddContactToSegment(ctx, workspaceID, email, segment.ID, segment.Version); err != nil {
+			if err := p.segmentRepo.AddContactToSegment(ctx, workspaceID, email, segment.ID, segment.Version, evaluatedAt); err != nil {
 				p.logger.WithFields(map[string]interface{}{
 					"segment_id": segment.ID,
 					"email":      email,
@@ -294,7 +298,7 @@ func (p *ContactSegmentQueueProcessor) processContact(ctx context.Context, works
 			}
 		} else {
 			// Contact doesn't match - remove from segment if exists
-			if err := p.segmentRepo.RemoveContactFromSegment(ctx, workspaceID, email, segment.ID); err != nil {
+			if err := p.segmentRepo.RemoveContactFromSegment(ctx, workspaceID, email, segment.ID, segment.Version, evaluatedAt); err != nil {
 				// It's OK if the contact wasn't in the segment, ignore the error
 				p.logger.WithFields(map[string]interface{}{
 					"segment_id": segment.ID,
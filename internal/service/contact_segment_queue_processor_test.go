@@ -320,7 +320,7 @@ func TestContactSegmentQueueProcessor_ProcessQueue_Success(t *testing.T) {
 		Return([]*domain.Segment{segment}, nil)
 
 	mockSegmentRepo.EXPECT().
-		AddContactToSegment(ctx, "workspace1", "test@test.com", "segment1", int64(1)).
+		AddContactToSegment(ctx, "workspace1", "test@test.com", "segment1", int64(1), gomock.Any()).
 		Return(nil)
 
 	count, err = processor.ProcessQueue(ctx, "workspace1")
@@ -393,7 +393,7 @@ func TestContactSegmentQueueProcessor_ProcessQueue_RemoveFromSegment(t *testing.
 		Return([]*domain.Segment{segment}, nil)
 
 	mockSegmentRepo.EXPECT().
-		RemoveContactFromSegment(ctx, "workspace1", "test@test.com", "segment1").
+		RemoveContactFromSegment(ctx, "workspace1", "test@test.com", "segment1", int64(1), gomock.Any()).
 		Return(nil)
 
 	count, err = processor.ProcessQueue(ctx, "workspace1")
@@ -464,7 +464,7 @@ func TestContactSegmentQueueProcessor_ProcessQueue_RemoveBatchError(t *testing.T
 		Return([]*domain.Segment{segment}, nil)
 
 	mockSegmentRepo.EXPECT().
-		AddContactToSegment(ctx, "workspace1", "test@test.com", "segment1", int64(1)).
+		AddContactToSegment(ctx, "workspace1", "test@test.com", "segment1", int64(1), gomock.Any()).
 		Return(nil)
 
 	count, err = processor.ProcessQueue(ctx, "workspace1")
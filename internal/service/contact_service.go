@@ -1,13 +1,20 @@
 package service
 
 import (
+	"bufio"
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"log"
 	"strings"
+	"time"
 
 	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/pkg/cache"
 	"github.com/Notifuse/notifuse/pkg/logger"
+	"github.com/tidwall/gjson"
 )
 
 type ContactService struct {
@@ -19,6 +26,10 @@ type ContactService struct {
 	contactListRepo     domain.ContactListRepository
 	contactTimelineRepo domain.ContactTimelineRepository
 	logger              logger.Logger
+	bulkGetMax          int
+	// audienceCountsCache is nil when audience counts are computed on every request
+	audienceCountsCache    cache.Cache
+	audienceCountsCacheTTL time.Duration
 }
 
 func NewContactService(
@@ -40,9 +51,27 @@ func NewContactService(
 		contactListRepo:     contactListRepo,
 		contactTimelineRepo: contactTimelineRepo,
 		logger:              logger,
+		bulkGetMax:          domain.DefaultBulkGetContactsMax,
 	}
 }
 
+// SetBulkGetMax sets the maximum number of emails or external IDs accepted by BulkGetContacts
+func (s *ContactService) SetBulkGetMax(max int) {
+	if max > 0 {
+		s.bulkGetMax = max
+	}
+}
+
+// SetAudienceCountsCache caches the audience counts of each workspace for ttl, a zero ttl disables caching
+func (s *ContactService) SetAudienceCountsCache(c cache.Cache, ttl time.Duration) {
+	if c == nil || ttl <= 0 {
+		s.audienceCountsCache = nil
+		return
+	}
+	s.audienceCountsCache = c
+	s.audienceCountsCacheTTL = ttl
+}
+
 func (s *ContactService) GetContactByEmail(ctx context.Context, workspaceID string, email string) (*domain.Contact, error) {
 	// Check if this is a system call (e.g., from Supabase webhook)
 	isSystemCall := ctx.Value(domain.SystemCallKey) != nil
@@ -106,6 +135,40 @@ func (s *ContactService) GetContactByExternalID(ctx context.Context, workspaceID
 	return contact, nil
 }
 
+func (s *ContactService) BulkGetContacts(ctx context.Context, req *domain.BulkGetContactsRequest) (*domain.BulkGetContactsResponse, error) {
+	if err := req.Validate(s.bulkGetMax); err != nil {
+		return nil, domain.ValidationError{Message: err.Error()}
+	}
+
+	var err error
+	ctx, _, userWorkspace, err := s.authService.AuthenticateUserForWorkspace(ctx, req.WorkspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate user: %w", err)
+	}
+
+	// Check permission for reading contacts
+	if !userWorkspace.HasPermission(domain.PermissionResourceContacts, domain.PermissionTypeRead) {
+		return nil, domain.NewPermissionError(
+			domain.PermissionResourceContacts,
+			domain.PermissionTypeRead,
+			"Insufficient permissions: read access to contacts required",
+		)
+	}
+
+	var contacts []*domain.Contact
+	if len(req.Emails) > 0 {
+		contacts, err = s.repo.GetContactsByEmails(ctx, req.WorkspaceID, req.Emails)
+	} else {
+		contacts, err = s.repo.GetContactsByExternalIDs(ctx, req.WorkspaceID, req.ExternalIDs)
+	}
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("Failed to bulk get contacts: %v", err))
+		return nil, fmt.Errorf("failed to bulk get contacts: %w", err)
+	}
+
+	return domain.NewBulkGetContactsResponse(req, contacts), nil
+}
+
 func (s *ContactService) GetContacts(ctx context.Context, req *domain.GetContactsRequest) (*domain.GetContactsResponse, error) {
 	var err error
 	ctx, _, userWorkspace, err := s.authService.AuthenticateUserForWorkspace(ctx, req.WorkspaceID)
@@ -178,32 +241,191 @@ func (s *ContactService) DeleteContact(ctx context.Context, workspaceID string,
 	return nil
 }
 
-func (s *ContactService) BatchImportContacts(ctx context.Context, workspaceID string, contacts []*domain.Contact, listIDs []string) *domain.BatchImportContactsResponse {
+func (s *ContactService) BatchImportContacts(ctx context.Context, workspaceID string, contacts []*domain.Contact, listIDs []string, onConflict domain.ImportConflictPolicy) *domain.BatchImportContactsResponse {
 	response := &domain.BatchImportContactsResponse{
 		Operations: make([]*domain.UpsertContactOperation, 0, len(contacts)),
 	}
 
-	var err error
-	ctx, _, userWorkspace, err := s.authService.AuthenticateUserForWorkspace(ctx, workspaceID)
+	ctx, errMsg := s.authorizeImport(ctx, workspaceID, listIDs)
+	if errMsg != "" {
+		response.Error = errMsg
+		return response
+	}
+
+	validContacts, validContactIndices := s.validateImportContacts(contacts, response)
+
+	// Rows matching existing contacts are resolved with the conflict policy before the upsert
+	if len(validContacts) > 0 && onConflict != "" && onConflict != domain.ImportConflictOverwrite {
+		var err error
+		validContacts, validContactIndices, err = s.applyImportConflictPolicy(ctx, workspaceID, validContacts, validContactIndices, onConflict, response)
+		if err != nil {
+			s.logger.Error(fmt.Sprintf("Failed to load existing contacts for import: %v", err))
+			for i, contact := range validContacts {
+				response.Operations = append(response.Operations, &domain.UpsertContactOperation{
+					Email:  contact.Email,
+					Action: domain.UpsertContactOperationError,
+					Error:  fmt.Sprintf("failed to upsert contact at index %d: %v", validContactIndices[i], err),
+				})
+			}
+			return response
+		}
+	}
+
+	// If there are valid contacts, perform bulk upsert
+	if len(validContacts) > 0 {
+		bulkResults, err := s.repo.BulkUpsertContacts(ctx, workspaceID, validContacts)
+		if err != nil {
+			// Bulk operation failed - mark all valid contacts as errors
+			s.logger.Error(fmt.Sprintf("Bulk upsert failed: %v", err))
+			for i, contact := range validContacts {
+				operation := &domain.UpsertContactOperation{
+					Email:  contact.Email,
+					Action: domain.UpsertContactOperationError,
+					Error:  fmt.Sprintf("failed to upsert contact at index %d: %v", validContactIndices[i], err),
+				}
+				response.Operations = append(response.Operations, operation)
+			}
+		} else {
+			// Map bulk results to individual operations
+			for _, result := range bulkResults {
+				action := domain.UpsertContactOperationCreate
+				if !result.IsNew {
+					action = domain.UpsertContactOperationUpdate
+				}
+
+				operation := &domain.UpsertContactOperation{
+					Email:  result.Email,
+					Action: action,
+				}
+				response.Operations = append(response.Operations, operation)
+			}
+
+			// If listIDs were provided, bulk subscribe contacts to lists
+			if len(listIDs) > 0 {
+				emails := make([]string, len(validContacts))
+				for i, contact := range validContacts {
+					emails[i] = contact.Email
+				}
+
+				// Bulk add all valid contacts to all specified lists
+				err := s.contactListRepo.BulkAddContactsToLists(ctx, workspaceID, emails, listIDs, domain.ContactListStatusActive)
+				if err != nil {
+					s.logger.Error(fmt.Sprintf("Failed to bulk add contacts to lists: %v", err))
+					// Note: We don't fail the entire operation if list subscription fails
+					// The contacts were successfully created/updated
+				}
+			}
+		}
+	}
+
+	return response
+}
+
+// applyImportConflictPolicy resolves the import rows whose email matches an existing contact.
+// With the skip policy those rows are reported as skipped and left out of the upsert, with the
+// fill_empty policy they are merged into the existing contact so only its empty fields are set.
+func (s *ContactService) applyImportConflictPolicy(ctx context.Context, workspaceID string, contacts []*domain.Contact, indices []int, onConflict domain.ImportConflictPolicy, response *domain.BatchImportContactsResponse) ([]*domain.Contact, []int, error) {
+	emails := make([]string, len(contacts))
+	for i, contact := range contacts {
+		emails[i] = contact.Email
+	}
+
+	existingContacts, err := s.repo.GetContactsByEmails(ctx, workspaceID, emails)
 	if err != nil {
-		response.Error = fmt.Sprintf("failed to authenticate user: %v", err)
+		return contacts, indices, err
+	}
+	if len(existingContacts) == 0 {
+		return contacts, indices, nil
+	}
+
+	existingByEmail := make(map[string]*domain.Contact, len(existingContacts))
+	for _, existing := range existingContacts {
+		existingByEmail[existing.Email] = existing
+	}
+
+	resolvedContacts := make([]*domain.Contact, 0, len(contacts))
+	resolvedIndices := make([]int, 0, len(indices))
+	for i, contact := range contacts {
+		existing, ok := existingByEmail[contact.Email]
+		if !ok {
+			resolvedContacts = append(resolvedContacts, contact)
+			resolvedIndices = append(resolvedIndices, indices[i])
+			continue
+		}
+
+		if onConflict == domain.ImportConflictSkip {
+			response.Operations = append(response.Operations, &domain.UpsertContactOperation{
+				Email:  contact.Email,
+				Action: domain.UpsertContactOperationSkip,
+			})
+			continue
+		}
+
+		// Start from the existing values so the upsert writes them back unchanged
+		merged := &domain.Contact{Email: existing.Email}
+		merged.Merge(existing)
+		merged.FillEmpty(contact)
+		merged.UpdatedAt = contact.UpdatedAt
+
+		resolvedContacts = append(resolvedContacts, merged)
+		resolvedIndices = append(resolvedIndices, indices[i])
+	}
+
+	return resolvedContacts, resolvedIndices, nil
+}
+
+// ValidateImportContacts runs the same checks as BatchImportContacts and reports
+// the per-row outcome without writing contacts or list subscriptions
+func (s *ContactService) ValidateImportContacts(ctx context.Context, workspaceID string, contacts []*domain.Contact, listIDs []string) *domain.BatchImportContactsResponse {
+	response := &domain.BatchImportContactsResponse{
+		Operations:   make([]*domain.UpsertContactOperation, 0, len(contacts)),
+		ValidateOnly: true,
+	}
+
+	_, errMsg := s.authorizeImport(ctx, workspaceID, listIDs)
+	if errMsg != "" {
+		response.Error = errMsg
 		return response
 	}
 
+	validContacts, _ := s.validateImportContacts(contacts, response)
+	for _, contact := range validContacts {
+		response.Operations = append(response.Operations, &domain.UpsertContactOperation{
+			Email:  contact.Email,
+			Action: domain.UpsertContactOperationValid,
+		})
+	}
+
+	return response
+}
+
+// authorizeImport checks the permissions required to import contacts, and to
+// subscribe them to lists when listIDs are provided
+func (s *ContactService) authorizeImport(ctx context.Context, workspaceID string, listIDs []string) (context.Context, string) {
+	ctx, _, userWorkspace, err := s.authService.AuthenticateUserForWorkspace(ctx, workspaceID)
+	if err != nil {
+		return ctx, fmt.Sprintf("failed to authenticate user: %v", err)
+	}
+
 	// Check permission for writing contacts
 	if !userWorkspace.HasPermission(domain.PermissionResourceContacts, domain.PermissionTypeWrite) {
-		response.Error = "Insufficient permissions: write access to contacts required"
-		return response
+		return ctx, "Insufficient permissions: write access to contacts required"
 	}
 
 	// If listIDs are provided, also check permission for writing lists
 	if len(listIDs) > 0 {
 		if !userWorkspace.HasPermission(domain.PermissionResourceLists, domain.PermissionTypeWrite) {
-			response.Error = "Insufficient permissions: write access to lists required"
-			return response
+			return ctx, "Insufficient permissions: write access to lists required"
 		}
 	}
 
+	return ctx, ""
+}
+
+// validateImportContacts validates every contact of an import, appends an error
+// operation to the response for each invalid one, and returns the valid contacts
+// deduplicated by email along with their original indices
+func (s *ContactService) validateImportContacts(contacts []*domain.Contact, response *domain.BatchImportContactsResponse) ([]*domain.Contact, []int) {
 	// Pre-validate all contacts and separate valid from invalid
 	// This allows us to provide immediate feedback on validation errors
 	// while still processing valid contacts in bulk
@@ -251,54 +473,154 @@ func (s *ContactService) BatchImportContacts(ctx context.Context, workspaceID st
 		validContactIndices = deduplicatedIndices
 	}
 
-	// If there are valid contacts, perform bulk upsert
-	if len(validContacts) > 0 {
-		bulkResults, err := s.repo.BulkUpsertContacts(ctx, workspaceID, validContacts)
+	return validContacts, validContactIndices
+}
+
+const (
+	// importLinesBatchSize is the number of contacts upserted per statement by a JSON Lines import
+	importLinesBatchSize = 500
+	// importLinesMaxErrors caps the line errors reported by a JSON Lines import
+	importLinesMaxErrors = 1000
+	// importLinesMaxLineSize is the largest line accepted by a JSON Lines import
+	importLinesMaxLineSize = 1024 * 1024
+)
+
+// ImportContactLines reads the body line by line and upserts the valid contacts in batches of
+// importLinesBatchSize with the merge strategy. A line that is not a JSON object or not a valid
+// contact is reported with its line number and the import goes on with the next one.
+func (s *ContactService) ImportContactLines(ctx context.Context, workspaceID string, body io.Reader, strategy domain.MergeStrategy, dryRun bool) (*domain.ImportContactLinesResponse, error) {
+	if strategy == "" {
+		strategy = domain.MergeStrategyOverwriteAll
+	}
+	if err := strategy.Validate(); err != nil {
+		return nil, domain.ValidationError{Message: err.Error()}
+	}
+
+	var err error
+	ctx, _, userWorkspace, err := s.authService.AuthenticateUserForWorkspace(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate user: %w", err)
+	}
+
+	// Check permission for writing contacts
+	if !userWorkspace.HasPermission(domain.PermissionResourceContacts, domain.PermissionTypeWrite) {
+		return nil, domain.NewPermissionError(
+			domain.PermissionResourceContacts,
+			domain.PermissionTypeWrite,
+			"Insufficient permissions: write access to contacts required",
+		)
+	}
+
+	response := &domain.ImportContactLinesResponse{
+		Errors: []domain.ContactImportLineError{},
+		DryRun: dryRun,
+	}
+
+	addError := func(line int, message string) {
+		response.Failed++
+		if len(response.Errors) >= importLinesMaxErrors {
+			response.ErrorsTruncated = true
+			return
+		}
+		response.Errors = append(response.Errors, domain.ContactImportLineError{Line: line, Error: message})
+	}
+
+	batch := make([]*domain.Contact, 0, importLinesBatchSize)
+	batchLines := make([]int, 0, importLinesBatchSize)
+	batchEmails := make(map[string]bool, importLinesBatchSize)
+
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		defer func() {
+			batch = batch[:0]
+			batchLines = batchLines[:0]
+			batchEmails = make(map[string]bool, importLinesBatchSize)
+		}()
+
+		if dryRun {
+			response.Valid += len(batch)
+			return
+		}
+
+		results, err := s.repo.UpsertContacts(ctx, workspaceID, batch, strategy)
 		if err != nil {
-			// Bulk operation failed - mark all valid contacts as errors
-			s.logger.Error(fmt.Sprintf("Bulk upsert failed: %v", err))
-			for i, contact := range validContacts {
-				operation := &domain.UpsertContactOperation{
-					Email:  contact.Email,
-					Action: domain.UpsertContactOperationError,
-					Error:  fmt.Sprintf("failed to upsert contact at index %d: %v", validContactIndices[i], err),
-				}
-				response.Operations = append(response.Operations, operation)
+			// The batch failed as a whole - report all its lines and go on with the next batch
+			s.logger.Error(fmt.Sprintf("Failed to upsert imported contacts: %v", err))
+			for _, line := range batchLines {
+				addError(line, fmt.Sprintf("failed to upsert contact: %v", err))
 			}
-		} else {
-			// Map bulk results to individual operations
-			for _, result := range bulkResults {
-				action := domain.UpsertContactOperationCreate
-				if !result.IsNew {
-					action = domain.UpsertContactOperationUpdate
-				}
+			return
+		}
 
-				operation := &domain.UpsertContactOperation{
-					Email:  result.Email,
-					Action: action,
-				}
-				response.Operations = append(response.Operations, operation)
+		for _, result := range results {
+			if result.IsNew {
+				response.Created++
+			} else {
+				response.Updated++
 			}
+		}
+		// Contacts left as is by the ignore_existing strategy are not part of the results
+		response.Skipped += len(batch) - len(results)
+	}
 
-			// If listIDs were provided, bulk subscribe contacts to lists
-			if len(listIDs) > 0 {
-				emails := make([]string, len(validContacts))
-				for i, contact := range validContacts {
-					emails[i] = contact.Email
-				}
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), importLinesMaxLineSize)
 
-				// Bulk add all valid contacts to all specified lists
-				err := s.contactListRepo.BulkAddContactsToLists(ctx, workspaceID, emails, listIDs, domain.ContactListStatusActive)
-				if err != nil {
-					s.logger.Error(fmt.Sprintf("Failed to bulk add contacts to lists: %v", err))
-					// Note: We don't fail the entire operation if list subscription fails
-					// The contacts were successfully created/updated
-				}
-			}
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		response.Lines++
+
+		if !gjson.ValidBytes(line) {
+			addError(lineNumber, "invalid JSON")
+			continue
+		}
+		parsed := gjson.ParseBytes(line)
+		if !parsed.IsObject() {
+			addError(lineNumber, "line must be a JSON object")
+			continue
+		}
+
+		contact, err := domain.FromJSON(parsed)
+		if err != nil {
+			addError(lineNumber, fmt.Sprintf("invalid contact: %v", err))
+			continue
+		}
+		if err := contact.Validate(); err != nil {
+			addError(lineNumber, fmt.Sprintf("invalid contact: %v", err))
+			continue
+		}
+
+		// An upsert statement cannot affect the same row twice, so a repeated email starts a new batch
+		if batchEmails[contact.Email] {
+			flush()
+		}
+		batch = append(batch, contact)
+		batchLines = append(batchLines, lineNumber)
+		batchEmails[contact.Email] = true
+
+		if len(batch) >= importLinesBatchSize {
+			flush()
 		}
 	}
+	flush()
 
-	return response
+	if err := scanner.Err(); err != nil {
+		if !errors.Is(err, bufio.ErrTooLong) {
+			return nil, fmt.Errorf("failed to read import body: %w", err)
+		}
+		// The scanner cannot go past a line that is too long, the lines after it are not read
+		response.Lines++
+		addError(lineNumber+1, fmt.Sprintf("line exceeds the maximum size of %d bytes, the remaining lines were not imported", importLinesMaxLineSize))
+	}
+
+	return response, nil
 }
 
 func (s *ContactService) UpsertContact(ctx context.Context, workspaceID string, contact *domain.Contact) domain.UpsertContactOperation {
@@ -380,3 +702,38 @@ func (s *ContactService) CountContacts(ctx context.Context, workspaceID string)
 
 	return count, nil
 }
+
+// GetAudienceCounts returns the active contact counts per list and per segment of a workspace
+func (s *ContactService) GetAudienceCounts(ctx context.Context, workspaceID string) (*domain.AudienceCounts, error) {
+	var err error
+	ctx, _, userWorkspace, err := s.authService.AuthenticateUserForWorkspace(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate user: %w", err)
+	}
+
+	// Check permission for reading contacts
+	if !userWorkspace.HasPermission(domain.PermissionResourceContacts, domain.PermissionTypeRead) {
+		return nil, domain.NewPermissionError(
+			domain.PermissionResourceContacts,
+			domain.PermissionTypeRead,
+			"Insufficient permissions: read access to contacts required",
+		)
+	}
+
+	compute := func() (interface{}, error) {
+		return s.repo.GetAudienceCounts(ctx, workspaceID)
+	}
+
+	var value interface{}
+	if s.audienceCountsCache != nil {
+		value, err = s.audienceCountsCache.GetOrSet("audience_counts:"+workspaceID, s.audienceCountsCacheTTL, compute)
+	} else {
+		value, err = compute()
+	}
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("Failed to get audience counts: %v", err))
+		return nil, fmt.Errorf("failed to get audience counts: %w", err)
+	}
+
+	return value.(*domain.AudienceCounts), nil
+}
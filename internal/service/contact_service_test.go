@@ -4,13 +4,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/Notifuse/notifuse/internal/domain"
 	"github.com/Notifuse/notifuse/internal/domain/mocks"
+	"github.com/Notifuse/notifuse/pkg/cache"
 	pkgmocks "github.com/Notifuse/notifuse/pkg/mocks"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // createContactServiceWithMocks creates a ContactService with all required mocks
@@ -243,6 +247,93 @@ func TestContactService_GetContactByExternalID(t *testing.T) {
 	})
 }
 
+func TestContactService_BulkGetContacts(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := "workspace123"
+	userWorkspace := &domain.UserWorkspace{
+		UserID:      "user123",
+		WorkspaceID: workspaceID,
+		Role:        "member",
+		Permissions: domain.UserPermissions{
+			domain.PermissionResourceContacts: {Read: true},
+		},
+	}
+
+	t.Run("by emails preserves request order", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		service, mockRepo, _, mockAuthService, _, _, _, _, _ := createContactServiceWithMocks(ctrl)
+
+		req := &domain.BulkGetContactsRequest{WorkspaceID: workspaceID, Emails: []string{"b@example.com", "missing@example.com", "a@example.com"}}
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, &domain.User{}, userWorkspace, nil)
+		mockRepo.EXPECT().GetContactsByEmails(ctx, workspaceID, req.Emails).
+			Return([]*domain.Contact{{Email: "a@example.com"}, {Email: "b@example.com"}}, nil)
+
+		result, err := service.BulkGetContacts(ctx, req)
+		require.NoError(t, err)
+		require.Len(t, result.Contacts, 2)
+		assert.Equal(t, "b@example.com", result.Contacts[0].Email)
+		assert.Equal(t, "a@example.com", result.Contacts[1].Email)
+		assert.Equal(t, []string{"missing@example.com"}, result.NotFound)
+	})
+
+	t.Run("by external IDs", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		service, mockRepo, _, mockAuthService, _, _, _, _, _ := createContactServiceWithMocks(ctrl)
+
+		req := &domain.BulkGetContactsRequest{WorkspaceID: workspaceID, ExternalIDs: []string{"ext1", "ext2"}}
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, &domain.User{}, userWorkspace, nil)
+		mockRepo.EXPECT().GetContactsByExternalIDs(ctx, workspaceID, req.ExternalIDs).
+			Return([]*domain.Contact{{Email: "b@example.com", ExternalID: &domain.NullableString{String: "ext2"}}}, nil)
+
+		result, err := service.BulkGetContacts(ctx, req)
+		require.NoError(t, err)
+		require.Len(t, result.Contacts, 1)
+		assert.Equal(t, "b@example.com", result.Contacts[0].Email)
+		assert.Equal(t, []string{"ext1"}, result.NotFound)
+	})
+
+	t.Run("rejects more keys than the configured max", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		service, _, _, _, _, _, _, _, _ := createContactServiceWithMocks(ctrl)
+		service.SetBulkGetMax(2)
+
+		_, err := service.BulkGetContacts(ctx, &domain.BulkGetContactsRequest{WorkspaceID: workspaceID, ExternalIDs: []string{"ext1", "ext2", "ext3"}})
+		var validationErr domain.ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Contains(t, validationErr.Message, "at most 2")
+	})
+
+	t.Run("requires read permission", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		service, _, _, mockAuthService, _, _, _, _, _ := createContactServiceWithMocks(ctrl)
+
+		noAccess := &domain.UserWorkspace{UserID: "user123", WorkspaceID: workspaceID, Role: "member", Permissions: domain.UserPermissions{}}
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, &domain.User{}, noAccess, nil)
+
+		_, err := service.BulkGetContacts(ctx, &domain.BulkGetContactsRequest{WorkspaceID: workspaceID, Emails: []string{"a@example.com"}})
+		var permissionErr *domain.PermissionError
+		assert.ErrorAs(t, err, &permissionErr)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		service, mockRepo, _, mockAuthService, _, _, _, _, mockLogger := createContactServiceWithMocks(ctrl)
+
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, &domain.User{}, userWorkspace, nil)
+		mockRepo.EXPECT().GetContactsByEmails(ctx, workspaceID, []string{"a@example.com"}).Return(nil, errors.New("db down"))
+		mockLogger.EXPECT().Error(gomock.Any())
+
+		_, err := service.BulkGetContacts(ctx, &domain.BulkGetContactsRequest{WorkspaceID: workspaceID, Emails: []string{"a@example.com"}})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to bulk get contacts")
+	})
+}
+
 func TestContactService_GetContacts(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -586,7 +677,7 @@ func TestContactService_BatchImportContacts(t *testing.T) {
 
 		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, nil, nil, errors.New("auth error"))
 
-		response := service.BatchImportContacts(ctx, workspaceID, contacts, nil)
+		response := service.BatchImportContacts(ctx, workspaceID, contacts, nil, domain.ImportConflictOverwrite)
 		assert.NotNil(t, response)
 		assert.Contains(t, response.Error, "failed to authenticate user")
 	})
@@ -598,7 +689,7 @@ func TestContactService_BatchImportContacts(t *testing.T) {
 
 		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, &domain.User{}, userWorkspace, nil)
 
-		response := service.BatchImportContacts(ctx, workspaceID, contacts, nil)
+		response := service.BatchImportContacts(ctx, workspaceID, contacts, nil, domain.ImportConflictOverwrite)
 		assert.NotNil(t, response)
 
 		// Find the error operation in the response
@@ -623,7 +714,7 @@ func TestContactService_BatchImportContacts(t *testing.T) {
 		mockRepo.EXPECT().BulkUpsertContacts(ctx, workspaceID, gomock.Any()).Return(nil, errors.New("repo error"))
 		mockLogger.EXPECT().Error(gomock.Any())
 
-		response := service.BatchImportContacts(ctx, workspaceID, contacts, nil)
+		response := service.BatchImportContacts(ctx, workspaceID, contacts, nil, domain.ImportConflictOverwrite)
 		assert.NotNil(t, response)
 
 		// Find the error operation in the response
@@ -653,7 +744,7 @@ func TestContactService_BatchImportContacts(t *testing.T) {
 			{Email: "existing@example.com", IsNew: false},
 		}, nil)
 
-		response := service.BatchImportContacts(ctx, workspaceID, contacts, nil)
+		response := service.BatchImportContacts(ctx, workspaceID, contacts, nil, domain.ImportConflictOverwrite)
 		assert.NotNil(t, response)
 		assert.Empty(t, response.Error)
 
@@ -678,6 +769,71 @@ func TestContactService_BatchImportContacts(t *testing.T) {
 	})
 }
 
+func TestContactService_ValidateImportContacts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// No repository expectations are set: any write would fail the test
+	service, _, _, mockAuthService, _, _, _, _, _ := createContactServiceWithMocks(ctrl)
+
+	ctx := context.Background()
+	workspaceID := "workspace123"
+
+	userWorkspace := &domain.UserWorkspace{
+		UserID:      "user123",
+		WorkspaceID: workspaceID,
+		Role:        "member",
+		Permissions: domain.UserPermissions{
+			domain.PermissionResourceContacts: {Read: true, Write: true},
+			domain.PermissionResourceLists:    {Read: true, Write: true},
+		},
+	}
+
+	t.Run("reports errors and writes nothing", func(t *testing.T) {
+		contacts := []*domain.Contact{
+			{Email: "valid@example.com"},
+			{Email: "not-an-email"},
+			{Email: "typed@example.com", ImportError: "invalid type for custom_number_1: expected number, got String"},
+			{Email: "valid@example.com"},
+		}
+
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, &domain.User{}, userWorkspace, nil)
+
+		response := service.ValidateImportContacts(ctx, workspaceID, contacts, []string{"newsletter"})
+		require.NotNil(t, response)
+		assert.Empty(t, response.Error)
+		assert.True(t, response.ValidateOnly)
+		require.Len(t, response.Operations, 3)
+
+		assert.Equal(t, domain.UpsertContactOperationError, response.Operations[0].Action)
+		assert.Contains(t, response.Operations[0].Error, "invalid contact at index 1: invalid email format")
+		assert.Equal(t, domain.UpsertContactOperationError, response.Operations[1].Action)
+		assert.Equal(t, "typed@example.com", response.Operations[1].Email)
+		assert.Contains(t, response.Operations[1].Error, "invalid contact at index 2: invalid type for custom_number_1")
+
+		// Duplicates collapse the same way as a real import
+		assert.Equal(t, domain.UpsertContactOperationValid, response.Operations[2].Action)
+		assert.Equal(t, "valid@example.com", response.Operations[2].Email)
+	})
+
+	t.Run("requires list write permission when subscribing", func(t *testing.T) {
+		readOnlyLists := &domain.UserWorkspace{
+			UserID:      "user123",
+			WorkspaceID: workspaceID,
+			Role:        "member",
+			Permissions: domain.UserPermissions{
+				domain.PermissionResourceContacts: {Read: true, Write: true},
+				domain.PermissionResourceLists:    {Read: true, Write: false},
+			},
+		}
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, &domain.User{}, readOnlyLists, nil)
+
+		response := service.ValidateImportContacts(ctx, workspaceID, []*domain.Contact{{Email: "valid@example.com"}}, []string{"newsletter"})
+		assert.Equal(t, "Insufficient permissions: write access to lists required", response.Error)
+		assert.Empty(t, response.Operations)
+	})
+}
+
 func TestContactService_BatchImportContacts_WithBulkOperations(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -713,7 +869,7 @@ func TestContactService_BatchImportContacts_WithBulkOperations(t *testing.T) {
 			{Email: "test3@example.com", IsNew: false},
 		}, nil)
 
-		response := service.BatchImportContacts(ctx, workspaceID, contacts, nil)
+		response := service.BatchImportContacts(ctx, workspaceID, contacts, nil, domain.ImportConflictOverwrite)
 
 		assert.NotNil(t, response)
 		assert.Empty(t, response.Error)
@@ -746,7 +902,7 @@ func TestContactService_BatchImportContacts_WithBulkOperations(t *testing.T) {
 			domain.ContactListStatusActive,
 		).Return(nil)
 
-		response := service.BatchImportContacts(ctx, workspaceID, contacts, listIDs)
+		response := service.BatchImportContacts(ctx, workspaceID, contacts, listIDs, domain.ImportConflictOverwrite)
 
 		assert.NotNil(t, response)
 		assert.Empty(t, response.Error)
@@ -773,7 +929,7 @@ func TestContactService_BatchImportContacts_WithBulkOperations(t *testing.T) {
 			{Email: "another@example.com", IsNew: true},
 		}, nil)
 
-		response := service.BatchImportContacts(ctx, workspaceID, contacts, nil)
+		response := service.BatchImportContacts(ctx, workspaceID, contacts, nil, domain.ImportConflictOverwrite)
 
 		assert.NotNil(t, response)
 		assert.Empty(t, response.Error)
@@ -800,7 +956,7 @@ func TestContactService_BatchImportContacts_WithBulkOperations(t *testing.T) {
 		mockRepo.EXPECT().BulkUpsertContacts(ctx, workspaceID, contacts).Return(nil, errors.New("database error"))
 		mockLogger.EXPECT().Error(gomock.Any())
 
-		response := service.BatchImportContacts(ctx, workspaceID, contacts, nil)
+		response := service.BatchImportContacts(ctx, workspaceID, contacts, nil, domain.ImportConflictOverwrite)
 
 		assert.NotNil(t, response)
 		assert.Empty(t, response.Error)
@@ -833,7 +989,7 @@ func TestContactService_BatchImportContacts_WithBulkOperations(t *testing.T) {
 		).Return(errors.New("list error"))
 		mockLogger.EXPECT().Error(gomock.Any())
 
-		response := service.BatchImportContacts(ctx, workspaceID, contacts, listIDs)
+		response := service.BatchImportContacts(ctx, workspaceID, contacts, listIDs, domain.ImportConflictOverwrite)
 
 		// Contact should still be created successfully
 		assert.NotNil(t, response)
@@ -860,7 +1016,7 @@ func TestContactService_BatchImportContacts_WithBulkOperations(t *testing.T) {
 
 		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, &domain.User{}, userWorkspaceNoListPerms, nil)
 
-		response := service.BatchImportContacts(ctx, workspaceID, contacts, listIDs)
+		response := service.BatchImportContacts(ctx, workspaceID, contacts, listIDs, domain.ImportConflictOverwrite)
 
 		assert.NotNil(t, response)
 		assert.NotEmpty(t, response.Error)
@@ -915,7 +1071,7 @@ func TestContactService_BatchImportContacts_DuplicateEmails(t *testing.T) {
 				}, nil
 			})
 
-		response := service.BatchImportContacts(ctx, workspaceID, contacts, nil)
+		response := service.BatchImportContacts(ctx, workspaceID, contacts, nil, domain.ImportConflictOverwrite)
 
 		assert.NotNil(t, response)
 		assert.Empty(t, response.Error)
@@ -941,7 +1097,7 @@ func TestContactService_BatchImportContacts_DuplicateEmails(t *testing.T) {
 				}, nil
 			})
 
-		response := service.BatchImportContacts(ctx, workspaceID, contacts, nil)
+		response := service.BatchImportContacts(ctx, workspaceID, contacts, nil, domain.ImportConflictOverwrite)
 
 		assert.NotNil(t, response)
 		assert.Empty(t, response.Error)
@@ -963,7 +1119,7 @@ func TestContactService_BatchImportContacts_DuplicateEmails(t *testing.T) {
 			{Email: "c@example.com", IsNew: true},
 		}, nil)
 
-		response := service.BatchImportContacts(ctx, workspaceID, contacts, nil)
+		response := service.BatchImportContacts(ctx, workspaceID, contacts, nil, domain.ImportConflictOverwrite)
 
 		assert.NotNil(t, response)
 		assert.Empty(t, response.Error)
@@ -971,6 +1127,302 @@ func TestContactService_BatchImportContacts_DuplicateEmails(t *testing.T) {
 	})
 }
 
+func TestContactService_BatchImportContacts_ConflictPolicy(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := "workspace123"
+
+	userWorkspace := &domain.UserWorkspace{
+		UserID:      "user123",
+		WorkspaceID: workspaceID,
+		Role:        "member",
+		Permissions: domain.UserPermissions{
+			domain.PermissionResourceContacts: {Read: true, Write: true},
+		},
+	}
+
+	existing := &domain.Contact{
+		Email:     "existing@example.com",
+		FirstName: &domain.NullableString{String: "Jane", IsNull: false},
+		CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	importedContacts := func() []*domain.Contact {
+		return []*domain.Contact{
+			{Email: "new@example.com", FirstName: &domain.NullableString{String: "New", IsNull: false}},
+			{
+				Email:     "existing@example.com",
+				FirstName: &domain.NullableString{String: "Imported", IsNull: false},
+				LastName:  &domain.NullableString{String: "Doe", IsNull: false},
+			},
+		}
+	}
+
+	t.Run("overwrite replaces existing fields", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service, mockRepo, _, mockAuthService, _, _, _, _, _ := createContactServiceWithMocks(ctrl)
+		contacts := importedContacts()
+
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, &domain.User{}, userWorkspace, nil)
+		// No lookup of the existing contacts, the upsert replaces the provided fields
+		mockRepo.EXPECT().BulkUpsertContacts(ctx, workspaceID, contacts).Return([]domain.BulkUpsertResult{
+			{Email: "new@example.com", IsNew: true},
+			{Email: "existing@example.com", IsNew: false},
+		}, nil)
+
+		response := service.BatchImportContacts(ctx, workspaceID, contacts, nil, domain.ImportConflictOverwrite)
+
+		assert.Empty(t, response.Error)
+		require.Len(t, response.Operations, 2)
+		assert.Equal(t, domain.UpsertContactOperationCreate, response.Operations[0].Action)
+		assert.Equal(t, domain.UpsertContactOperationUpdate, response.Operations[1].Action)
+	})
+
+	t.Run("fill_empty preserves existing non-empty fields", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service, mockRepo, _, mockAuthService, _, _, _, _, _ := createContactServiceWithMocks(ctrl)
+		contacts := importedContacts()
+
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, &domain.User{}, userWorkspace, nil)
+		mockRepo.EXPECT().GetContactsByEmails(ctx, workspaceID, []string{"new@example.com", "existing@example.com"}).
+			Return([]*domain.Contact{existing}, nil)
+		mockRepo.EXPECT().BulkUpsertContacts(ctx, workspaceID, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, upserted []*domain.Contact) ([]domain.BulkUpsertResult, error) {
+				require.Len(t, upserted, 2)
+				assert.Same(t, contacts[0], upserted[0])
+
+				merged := upserted[1]
+				assert.Equal(t, "existing@example.com", merged.Email)
+				assert.Equal(t, "Jane", merged.FirstName.String)
+				assert.Equal(t, "Doe", merged.LastName.String)
+				assert.Equal(t, existing.CreatedAt, merged.CreatedAt)
+				return []domain.BulkUpsertResult{
+					{Email: "new@example.com", IsNew: true},
+					{Email: "existing@example.com", IsNew: false},
+				}, nil
+			})
+
+		response := service.BatchImportContacts(ctx, workspaceID, contacts, nil, domain.ImportConflictFillEmpty)
+
+		assert.Empty(t, response.Error)
+		require.Len(t, response.Operations, 2)
+		assert.Equal(t, domain.UpsertContactOperationUpdate, response.Operations[1].Action)
+		// The existing contact itself is untouched
+		assert.Nil(t, existing.LastName)
+	})
+
+	t.Run("skip leaves the existing contact unchanged", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service, mockRepo, _, mockAuthService, _, _, mockContactListRepo, _, _ := createContactServiceWithMocks(ctrl)
+		contacts := importedContacts()
+
+		listsUserWorkspace := *userWorkspace
+		listsUserWorkspace.Permissions = domain.UserPermissions{
+			domain.PermissionResourceContacts: {Read: true, Write: true},
+			domain.PermissionResourceLists:    {Read: true, Write: true},
+		}
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, &domain.User{}, &listsUserWorkspace, nil)
+		mockRepo.EXPECT().GetContactsByEmails(ctx, workspaceID, []string{"new@example.com", "existing@example.com"}).
+			Return([]*domain.Contact{existing}, nil)
+		mockRepo.EXPECT().BulkUpsertContacts(ctx, workspaceID, []*domain.Contact{contacts[0]}).
+			Return([]domain.BulkUpsertResult{{Email: "new@example.com", IsNew: true}}, nil)
+		// Skipped contacts are not subscribed to the lists either
+		mockContactListRepo.EXPECT().BulkAddContactsToLists(ctx, workspaceID, []string{"new@example.com"}, []string{"list1"}, domain.ContactListStatusActive).Return(nil)
+
+		response := service.BatchImportContacts(ctx, workspaceID, contacts, []string{"list1"}, domain.ImportConflictSkip)
+
+		assert.Empty(t, response.Error)
+		require.Len(t, response.Operations, 2)
+		assert.Equal(t, "existing@example.com", response.Operations[0].Email)
+		assert.Equal(t, domain.UpsertContactOperationSkip, response.Operations[0].Action)
+		assert.Equal(t, "new@example.com", response.Operations[1].Email)
+		assert.Equal(t, domain.UpsertContactOperationCreate, response.Operations[1].Action)
+	})
+
+	t.Run("existing contacts lookup error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service, mockRepo, _, mockAuthService, _, _, _, _, mockLogger := createContactServiceWithMocks(ctrl)
+		contacts := importedContacts()
+
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, &domain.User{}, userWorkspace, nil)
+		mockRepo.EXPECT().GetContactsByEmails(ctx, workspaceID, gomock.Any()).Return(nil, errors.New("db error"))
+		mockLogger.EXPECT().Error(gomock.Any())
+
+		response := service.BatchImportContacts(ctx, workspaceID, contacts, nil, domain.ImportConflictSkip)
+
+		require.Len(t, response.Operations, 2)
+		for _, op := range response.Operations {
+			assert.Equal(t, domain.UpsertContactOperationError, op.Action)
+		}
+	})
+}
+
+func TestContactService_ImportContactLines(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := "workspace123"
+
+	userWorkspace := &domain.UserWorkspace{
+		UserID:      "user123",
+		WorkspaceID: workspaceID,
+		Role:        "member",
+		Permissions: domain.UserPermissions{
+			domain.PermissionResourceContacts: {Read: true, Write: true},
+		},
+	}
+
+	body := strings.Join([]string{
+		`{"email": "first@example.com", "first_name": "First"}`,
+		`{"email": "broken@example.com"`,
+		``,
+		`{"email": "not-an-email"}`,
+		`["second@example.com"]`,
+		`{"email": "second@example.com"}`,
+		`{"first_name": "No Email"}`,
+		`{"email": "first@example.com", "last_name": "Again"}`,
+	}, "\n")
+
+	upsertedEmails := func(contacts []*domain.Contact) []string {
+		emails := make([]string, len(contacts))
+		for i, contact := range contacts {
+			emails[i] = contact.Email
+		}
+		return emails
+	}
+
+	t.Run("imports valid lines and reports the others", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		service, mockRepo, _, mockAuthService, _, _, _, _, _ := createContactServiceWithMocks(ctrl)
+
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, &domain.User{}, userWorkspace, nil)
+
+		// The repeated email starts a second batch
+		var batches [][]string
+		mockRepo.EXPECT().UpsertContacts(ctx, workspaceID, gomock.Any(), domain.MergeStrategyFillEmptyOnly).
+			DoAndReturn(func(_ context.Context, _ string, contacts []*domain.Contact, _ domain.MergeStrategy) ([]domain.BulkUpsertResult, error) {
+				batches = append(batches, upsertedEmails(contacts))
+				results := make([]domain.BulkUpsertResult, len(contacts))
+				for i, contact := range contacts {
+					results[i] = domain.BulkUpsertResult{Email: contact.Email, IsNew: len(batches) == 1}
+				}
+				return results, nil
+			}).Times(2)
+
+		response, err := service.ImportContactLines(ctx, workspaceID, strings.NewReader(body), domain.MergeStrategyFillEmptyOnly, false)
+		require.NoError(t, err)
+
+		assert.Equal(t, [][]string{{"first@example.com", "second@example.com"}, {"first@example.com"}}, batches)
+		assert.Equal(t, 7, response.Lines)
+		assert.Equal(t, 2, response.Created)
+		assert.Equal(t, 1, response.Updated)
+		assert.Equal(t, 4, response.Failed)
+		assert.False(t, response.DryRun)
+
+		require.Len(t, response.Errors, 4)
+		assert.Equal(t, domain.ContactImportLineError{Line: 2, Error: "invalid JSON"}, response.Errors[0])
+		assert.Equal(t, domain.ContactImportLineError{Line: 4, Error: "invalid contact: invalid email format"}, response.Errors[1])
+		assert.Equal(t, domain.ContactImportLineError{Line: 5, Error: "line must be a JSON object"}, response.Errors[2])
+		assert.Equal(t, domain.ContactImportLineError{Line: 7, Error: "invalid contact: email is required"}, response.Errors[3])
+	})
+
+	t.Run("dry run validates without writing", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		// No repository expectations are set: any write would fail the test
+		service, _, _, mockAuthService, _, _, _, _, _ := createContactServiceWithMocks(ctrl)
+
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, &domain.User{}, userWorkspace, nil)
+
+		response, err := service.ImportContactLines(ctx, workspaceID, strings.NewReader(body), "", true)
+		require.NoError(t, err)
+		assert.True(t, response.DryRun)
+		assert.Equal(t, 7, response.Lines)
+		assert.Equal(t, 3, response.Valid)
+		assert.Equal(t, 4, response.Failed)
+		assert.Zero(t, response.Created+response.Updated+response.Skipped)
+		assert.Len(t, response.Errors, 4)
+	})
+
+	t.Run("failed batch is reported per line and the import goes on", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		service, mockRepo, _, mockAuthService, _, _, _, _, mockLogger := createContactServiceWithMocks(ctrl)
+
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, &domain.User{}, userWorkspace, nil)
+		mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
+		gomock.InOrder(
+			mockRepo.EXPECT().UpsertContacts(ctx, workspaceID, gomock.Any(), domain.MergeStrategyIgnoreExisting).
+				Return(nil, errors.New("deadlock detected")),
+			// Existing contacts skipped by ignore_existing are not part of the results
+			mockRepo.EXPECT().UpsertContacts(ctx, workspaceID, gomock.Any(), domain.MergeStrategyIgnoreExisting).
+				Return([]domain.BulkUpsertResult{}, nil),
+		)
+
+		response, err := service.ImportContactLines(ctx, workspaceID, strings.NewReader(body), domain.MergeStrategyIgnoreExisting, false)
+		require.NoError(t, err)
+		assert.Equal(t, 6, response.Failed)
+		assert.Equal(t, 1, response.Skipped)
+		require.Len(t, response.Errors, 6)
+		// The lines of the batch are reported when the batch is flushed, after the invalid lines
+		assert.Equal(t, domain.ContactImportLineError{Line: 1, Error: "failed to upsert contact: deadlock detected"}, response.Errors[4])
+		assert.Equal(t, domain.ContactImportLineError{Line: 6, Error: "failed to upsert contact: deadlock detected"}, response.Errors[5])
+	})
+
+	t.Run("invalid merge strategy", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		service, _, _, _, _, _, _, _, _ := createContactServiceWithMocks(ctrl)
+
+		_, err := service.ImportContactLines(ctx, workspaceID, strings.NewReader(body), "replace", false)
+		var validationErr domain.ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, "invalid merge strategy: replace", validationErr.Message)
+	})
+
+	t.Run("requires contact write permission", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		service, _, _, mockAuthService, _, _, _, _, _ := createContactServiceWithMocks(ctrl)
+
+		readOnly := &domain.UserWorkspace{
+			UserID:      "user123",
+			WorkspaceID: workspaceID,
+			Role:        "member",
+			Permissions: domain.UserPermissions{
+				domain.PermissionResourceContacts: {Read: true, Write: false},
+			},
+		}
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, &domain.User{}, readOnly, nil)
+
+		_, err := service.ImportContactLines(ctx, workspaceID, strings.NewReader(body), "", false)
+		var permissionErr *domain.PermissionError
+		require.ErrorAs(t, err, &permissionErr)
+	})
+
+	t.Run("line too long", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		service, _, _, mockAuthService, _, _, _, _, _ := createContactServiceWithMocks(ctrl)
+
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, &domain.User{}, userWorkspace, nil)
+
+		long := `{"email": "long@example.com", "first_name": "` + strings.Repeat("a", importLinesMaxLineSize) + `"}`
+		response, err := service.ImportContactLines(ctx, workspaceID, strings.NewReader(`{"email": "first@example.com"}`+"\n"+long), "", true)
+		require.NoError(t, err)
+		assert.Equal(t, 1, response.Valid)
+		assert.Equal(t, 1, response.Failed)
+		require.Len(t, response.Errors, 1)
+		assert.Equal(t, 2, response.Errors[0].Line)
+	})
+}
+
 func TestContactService_CountContacts(t *testing.T) {
 	// Test ContactService.CountContacts - this was at 0% coverage
 	ctrl := gomock.NewController(t)
@@ -1038,3 +1490,83 @@ func TestContactService_CountContacts(t *testing.T) {
 		assert.Contains(t, err.Error(), "failed to count contacts")
 	})
 }
+
+func TestContactService_GetAudienceCounts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service, mockRepo, _, mockAuthService, _, _, _, _, mockLogger := createContactServiceWithMocks(ctrl)
+
+	ctx := context.Background()
+	workspaceID := "workspace123"
+
+	userWorkspace := &domain.UserWorkspace{
+		UserID:      "user123",
+		WorkspaceID: workspaceID,
+		Role:        "member",
+		Permissions: domain.UserPermissions{
+			domain.PermissionResourceContacts: {Read: true, Write: true},
+		},
+	}
+
+	counts := &domain.AudienceCounts{
+		Lists:    []domain.ListContactCount{{ListID: "newsletter", Name: "Newsletter", ActiveCount: 12}},
+		Segments: []domain.SegmentContactCount{{SegmentID: "vip", Name: "VIP", ContactCount: 3}},
+	}
+
+	t.Run("Success - Returns counts", func(t *testing.T) {
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, &domain.User{}, userWorkspace, nil)
+		mockRepo.EXPECT().GetAudienceCounts(ctx, workspaceID).Return(counts, nil)
+
+		result, err := service.GetAudienceCounts(ctx, workspaceID)
+		require.NoError(t, err)
+		assert.Equal(t, counts, result)
+	})
+
+	t.Run("Error - Insufficient permissions", func(t *testing.T) {
+		userWorkspaceNoPerms := &domain.UserWorkspace{
+			UserID:      "user123",
+			WorkspaceID: workspaceID,
+			Role:        "member",
+			Permissions: domain.UserPermissions{
+				domain.PermissionResourceContacts: {Read: false, Write: false},
+			},
+		}
+
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, &domain.User{}, userWorkspaceNoPerms, nil)
+
+		result, err := service.GetAudienceCounts(ctx, workspaceID)
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		var permErr *domain.PermissionError
+		assert.True(t, errors.As(err, &permErr))
+	})
+
+	t.Run("Error - Repository error", func(t *testing.T) {
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, &domain.User{}, userWorkspace, nil)
+		mockRepo.EXPECT().GetAudienceCounts(ctx, workspaceID).Return(nil, errors.New("repository error"))
+		mockLogger.EXPECT().Error(gomock.Any())
+
+		result, err := service.GetAudienceCounts(ctx, workspaceID)
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Contains(t, err.Error(), "failed to get audience counts")
+	})
+
+	t.Run("Success - Cached counts are reused", func(t *testing.T) {
+		audienceCache := cache.NewInMemoryCache(time.Minute)
+		defer audienceCache.Stop()
+		service.SetAudienceCountsCache(audienceCache, time.Minute)
+		defer service.SetAudienceCountsCache(nil, 0)
+
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, &domain.User{}, userWorkspace, nil).Times(2)
+		mockRepo.EXPECT().GetAudienceCounts(ctx, workspaceID).Return(counts, nil).Times(1)
+
+		first, err := service.GetAudienceCounts(ctx, workspaceID)
+		require.NoError(t, err)
+		second, err := service.GetAudienceCounts(ctx, workspaceID)
+		require.NoError(t, err)
+		assert.Equal(t, counts, first)
+		assert.Equal(t, counts, second)
+	})
+}
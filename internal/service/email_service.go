@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -35,6 +36,9 @@ type EmailService struct {
 	postmarkService  domain.EmailProviderService
 	mailgunService   domain.EmailProviderService
 	mailjetService   domain.EmailProviderService
+	sendGridService  domain.EmailProviderService
+	linkShortener    domain.LinkShortenerService
+	statusApplier    *IdempotentStatusApplier
 }
 
 // NewEmailService creates a new EmailService instance
@@ -58,6 +62,7 @@ func NewEmailService(
 	postmarkService := NewPostmarkService(httpClient, authService, logger)
 	mailgunService := NewMailgunService(httpClient, authService, logger, webhookEndpoint)
 	mailjetService := NewMailjetService(httpClient, authService, logger)
+	sendGridService := NewSendGridService(httpClient, authService, logger)
 
 	return &EmailService{
 		logger:           logger,
@@ -77,6 +82,7 @@ func NewEmailService(
 		postmarkService:  postmarkService,
 		mailgunService:   mailgunService,
 		mailjetService:   mailjetService,
+		sendGridService:  sendGridService,
 	}
 }
 
@@ -89,6 +95,17 @@ func CreateSESClient(region, accessKey, secretKey string) domain.SESClient {
 	return ses.New(sess)
 }
 
+// SetStatusApplier makes opens and clicks apply through the applier, so that a tracking pixel or a
+// link hit several times at once records a single open or click
+func (s *EmailService) SetStatusApplier(applier *IdempotentStatusApplier) {
+	s.statusApplier = applier
+}
+
+// SetLinkShortener sets the service shortening click-tracked links of compiled templates
+func (s *EmailService) SetLinkShortener(linkShortener domain.LinkShortenerService) {
+	s.linkShortener = linkShortener
+}
+
 // TestEmailProvider sends a test email to verify the provider configuration works
 func (s *EmailService) TestEmailProvider(ctx context.Context, workspaceID string, provider domain.EmailProvider, to string) error {
 	ctx, span := tracing.StartServiceSpan(ctx, "EmailService", "TestEmailProvider")
@@ -196,14 +213,26 @@ func (s *EmailService) getProviderService(providerKind domain.EmailProviderKind)
 		return s.mailgunService, nil
 	case domain.EmailProviderKindMailjet:
 		return s.mailjetService, nil
+	case domain.EmailProviderKindSendGrid:
+		return s.sendGridService, nil
 	default:
 		return nil, fmt.Errorf("unsupported provider kind: %s", providerKind)
 	}
 }
 
 func (s *EmailService) VisitLink(ctx context.Context, messageID string, workspaceID string) error {
-	// find the message by id
-	err := s.messageRepo.SetClicked(ctx, workspaceID, messageID, time.Now())
+	var err error
+	if s.statusApplier != nil {
+		// A click means the message was opened
+		now := time.Now()
+		err = s.statusApplier.Apply(ctx, workspaceID, []domain.MessageEventUpdate{
+			{ID: messageID, Event: domain.MessageEventClicked, Timestamp: now},
+			{ID: messageID, Event: domain.MessageEventOpened, Timestamp: now},
+		})
+	} else {
+		// find the message by id
+		err = s.messageRepo.SetClicked(ctx, workspaceID, messageID, time.Now())
+	}
 	if err != nil {
 		s.logger.Error(err.Error())
 		return fmt.Errorf("failed to set clicked: %w", err)
@@ -213,8 +242,15 @@ func (s *EmailService) VisitLink(ctx context.Context, messageID string, workspac
 }
 
 func (s *EmailService) OpenEmail(ctx context.Context, messageID string, workspaceID string) error {
-	// find the message by id
-	err := s.messageRepo.SetOpened(ctx, workspaceID, messageID, time.Now())
+	var err error
+	if s.statusApplier != nil {
+		err = s.statusApplier.Apply(ctx, workspaceID, []domain.MessageEventUpdate{
+			{ID: messageID, Event: domain.MessageEventOpened, Timestamp: time.Now()},
+		})
+	} else {
+		// find the message by id
+		err = s.messageRepo.SetOpened(ctx, workspaceID, messageID, time.Now())
+	}
 	if err != nil {
 		return fmt.Errorf("failed to update message opened: %w", err)
 	}
@@ -258,6 +294,9 @@ func (s *EmailService) SendEmailForTemplate(ctx context.Context, request domain.
 		return fmt.Errorf("failed to get template: %w", err)
 	}
 
+	// Use the variant matching the contact language (exact, then base language, then default)
+	template = template.ForContact(request.Contact)
+
 	// Find the emailSender
 	emailSender := request.EmailProvider.GetSender(template.Email.SenderID)
 
@@ -288,16 +327,21 @@ func (s *EmailService) SendEmailForTemplate(ctx context.Context, request domain.
 	}
 
 	trackingSettings := notifuse_mjml.TrackingSettings{
-		Endpoint:       endpoint,
-		EnableTracking: request.TrackingSettings.EnableTracking,
-		UTMSource:      request.TrackingSettings.UTMSource,
-		UTMMedium:      request.TrackingSettings.UTMMedium,
-		UTMCampaign:    request.TrackingSettings.UTMCampaign,
-		UTMContent:     request.TrackingSettings.UTMContent,
-		UTMTerm:        request.TrackingSettings.UTMTerm,
-		WorkspaceID:    request.WorkspaceID,
-		MessageID:      request.MessageID,
+		Endpoint:             endpoint,
+		TrackingEndpoint:     workspace.Settings.TrackingEndpoint(),
+		EnableTracking:       request.TrackingSettings.EnableTracking,
+		DisableOpenTracking:  request.TrackingSettings.DisableOpenTracking,
+		DisableClickTracking: request.TrackingSettings.DisableClickTracking,
+		UTMSource:            request.TrackingSettings.UTMSource,
+		UTMMedium:            request.TrackingSettings.UTMMedium,
+		UTMCampaign:          request.TrackingSettings.UTMCampaign,
+		UTMContent:           request.TrackingSettings.UTMContent,
+		UTMTerm:              request.TrackingSettings.UTMTerm,
+		WorkspaceID:          request.WorkspaceID,
+		MessageID:            request.MessageID,
+		LinkShortener:        domain.WorkspaceLinkShortener(ctx, s.linkShortener, request.WorkspaceID),
 	}
+	template.Email.ApplyTrackingOverrides(&trackingSettings)
 
 	compileTemplateRequest := domain.CompileTemplateRequest{
 		WorkspaceID:      request.WorkspaceID,
@@ -305,6 +349,7 @@ func (s *EmailService) SendEmailForTemplate(ctx context.Context, request domain.
 		VisualEditorTree: template.Email.VisualEditorTree,
 		TemplateData:     request.MessageData.Data,
 		TrackingSettings: trackingSettings,
+		RawMergeFields:   template.Email.RawMergeFields,
 	}
 
 	// Compile the template with the message data (use system context to bypass authentication)
@@ -370,6 +415,13 @@ func (s *EmailService) SendEmailForTemplate(ctx context.Context, request domain.
 	// Convert email options to channel options for storage
 	channelOptions := request.EmailOptions.ToChannelOptions()
 
+	// Only the attachment metadata is recorded, the content is passed to the provider
+	attachments, err := domain.AttachmentsMetadata(request.EmailOptions.Attachments)
+	if err != nil {
+		tracing.MarkSpanError(ctx, err)
+		return fmt.Errorf("invalid attachments: %w", err)
+	}
+
 	// Create message history record
 	messageHistory := &domain.MessageHistory{
 		ID:             request.MessageID,
@@ -380,13 +432,30 @@ func (s *EmailService) SendEmailForTemplate(ctx context.Context, request domain.
 		Channel:        "email",
 		MessageData:    request.MessageData,
 		ChannelOptions: channelOptions,
+		Attachments:    attachments,
 		SentAt:         now,
 		CreatedAt:      now,
 		UpdatedAt:      now,
 	}
 
-	// Save to message history
-	if err := s.messageRepo.Create(ctx, request.WorkspaceID, workspace.Settings.SecretKey, messageHistory); err != nil {
+	// Sandbox workspaces only deliver to allowlisted recipients; the drop is flagged on the message
+	sandboxDropped := !workspace.Settings.IsRecipientAllowed(request.Contact.Email)
+	if sandboxDropped {
+		statusInfo := domain.ErrSandboxRecipientNotAllowed.Error()
+		messageHistory.FailedAt = &now
+		messageHistory.StatusInfo = &statusInfo
+	}
+
+	// Save to message history, claiming the idempotency key before anything is sent
+	if request.IdempotencyKey != nil && *request.IdempotencyKey != "" {
+		err = s.messageRepo.CreateWithIdempotencyKey(ctx, request.WorkspaceID, workspace.Settings.SecretKey, messageHistory, *request.IdempotencyKey)
+	} else {
+		err = s.messageRepo.Create(ctx, request.WorkspaceID, workspace.Settings.SecretKey, messageHistory)
+	}
+	if errors.Is(err, domain.ErrIdempotencyKeyUsed) {
+		return err
+	}
+	if err != nil {
 		s.logger.WithFields(map[string]interface{}{
 			"error":      err.Error(),
 			"message_id": request.MessageID,
@@ -398,6 +467,17 @@ func (s *EmailService) SendEmailForTemplate(ctx context.Context, request domain.
 
 	tracing.AddAttribute(ctx, "message_history.created", true)
 
+	if sandboxDropped {
+		s.logger.WithFields(map[string]interface{}{
+			"workspace":  request.WorkspaceID,
+			"message_id": request.MessageID,
+			"to":         request.Contact.Email,
+		}).Warn("Sandbox mode: dropping email to recipient outside allowlist")
+
+		tracing.AddAttribute(ctx, "email.sandbox_dropped", true)
+		return nil
+	}
+
 	// Send the email using the email service
 	s.logger.WithFields(map[string]interface{}{
 		"to":         request.Contact.Email,
@@ -426,6 +506,7 @@ func (s *EmailService) SendEmailForTemplate(ctx context.Context, request domain.
 		Provider:      request.EmailProvider,
 		EmailOptions:  request.EmailOptions,
 	}
+	template.Email.ApplyProviderTags(&providerRequest.EmailOptions)
 
 	err = s.SendEmail(ctx, providerRequest, false)
 
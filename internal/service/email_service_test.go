@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"encoding/base64"
 	"testing"
 	"time"
 
@@ -717,6 +718,22 @@ func TestEmailService_VisitLink(t *testing.T) {
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to set clicked")
 	})
+
+	t.Run("Applies the click and the open through the status applier", func(t *testing.T) {
+		mockMessageRepo.EXPECT().
+			ApplyStatusesLocked(gomock.Any(), workspaceID, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, updates []domain.MessageEventUpdate) error {
+				require.Len(t, updates, 2)
+				events := []domain.MessageEvent{updates[0].Event, updates[1].Event}
+				assert.ElementsMatch(t, []domain.MessageEvent{domain.MessageEventClicked, domain.MessageEventOpened}, events)
+				return nil
+			})
+
+		applierService := emailService
+		applierService.SetStatusApplier(NewIdempotentStatusApplier(mockMessageRepo, mockLogger, 10*time.Millisecond))
+
+		require.NoError(t, applierService.VisitLink(ctx, messageID, workspaceID))
+	})
 }
 
 func TestEmailService_OpenEmail(t *testing.T) {
@@ -978,6 +995,157 @@ func TestEmailService_SendEmailForTemplate(t *testing.T) {
 		require.NoError(t, err)
 	})
 
+	t.Run("Sandbox mode sends to allowlisted recipient", func(t *testing.T) {
+		workspace := &domain.Workspace{
+			ID: workspaceID,
+			Settings: domain.WorkspaceSettings{
+				SandboxMode:      true,
+				SandboxAllowlist: []string{"qa@example.org", "@example.com"},
+			},
+		}
+		mockWorkspaceRepo.EXPECT().GetByID(gomock.Any(), workspaceID).Return(workspace, nil)
+		mockTemplateService.EXPECT().
+			GetTemplateByID(gomock.Any(), workspaceID, templateConfig.TemplateID, int64(0)).
+			Return(emailTemplate, nil)
+		mockTemplateService.EXPECT().CompileTemplate(gomock.Any(), gomock.Any()).Return(compileResult, nil)
+		mockMessageRepo.EXPECT().
+			Create(gomock.Any(), workspaceID, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, _ string, msgHistory *domain.MessageHistory) error {
+				assert.Nil(t, msgHistory.FailedAt)
+				assert.Nil(t, msgHistory.StatusInfo)
+				return nil
+			})
+		mockSESService.EXPECT().SendEmail(gomock.Any(), gomock.Any()).Return(nil)
+
+		request := domain.SendEmailRequest{
+			WorkspaceID:      workspaceID,
+			IntegrationID:    "test-integration-id",
+			MessageID:        messageID,
+			Contact:          contact,
+			TemplateConfig:   templateConfig,
+			MessageData:      messageData,
+			TrackingSettings: trackingSettings,
+			EmailProvider:    emailProvider,
+			EmailOptions:     options,
+		}
+		err := emailService.SendEmailForTemplate(ctx, request)
+		require.NoError(t, err)
+	})
+
+	t.Run("Records attachment metadata without content", func(t *testing.T) {
+		workspace := &domain.Workspace{ID: workspaceID}
+		mockWorkspaceRepo.EXPECT().GetByID(gomock.Any(), workspaceID).Return(workspace, nil)
+		mockTemplateService.EXPECT().
+			GetTemplateByID(gomock.Any(), workspaceID, templateConfig.TemplateID, int64(0)).
+			Return(emailTemplate, nil)
+		mockTemplateService.EXPECT().CompileTemplate(gomock.Any(), gomock.Any()).Return(compileResult, nil)
+
+		pdfContent := base64.StdEncoding.EncodeToString([]byte("%PDF-1.4\n%%EOF"))
+		attachmentOptions := options
+		attachmentOptions.Attachments = []domain.Attachment{{Filename: "invoice.pdf", Content: pdfContent}}
+
+		mockMessageRepo.EXPECT().
+			Create(gomock.Any(), workspaceID, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, _ string, msgHistory *domain.MessageHistory) error {
+				require.Len(t, msgHistory.Attachments, 1)
+				assert.Equal(t, "invoice.pdf", msgHistory.Attachments[0].Filename)
+				assert.Equal(t, "application/pdf", msgHistory.Attachments[0].ContentType)
+				assert.Equal(t, "attachment", msgHistory.Attachments[0].Disposition)
+				assert.NotEmpty(t, msgHistory.Attachments[0].Checksum)
+				return nil
+			})
+		mockSESService.EXPECT().
+			SendEmail(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, providerRequest domain.SendEmailProviderRequest) error {
+				// The provider still receives the content
+				require.Len(t, providerRequest.EmailOptions.Attachments, 1)
+				assert.Equal(t, pdfContent, providerRequest.EmailOptions.Attachments[0].Content)
+				return nil
+			})
+
+		request := domain.SendEmailRequest{
+			WorkspaceID:      workspaceID,
+			IntegrationID:    "test-integration-id",
+			MessageID:        messageID,
+			Contact:          contact,
+			TemplateConfig:   templateConfig,
+			MessageData:      messageData,
+			TrackingSettings: trackingSettings,
+			EmailProvider:    emailProvider,
+			EmailOptions:     attachmentOptions,
+		}
+		err := emailService.SendEmailForTemplate(ctx, request)
+		require.NoError(t, err)
+	})
+
+	t.Run("Sandbox mode drops recipient outside allowlist", func(t *testing.T) {
+		workspace := &domain.Workspace{
+			ID: workspaceID,
+			Settings: domain.WorkspaceSettings{
+				SandboxMode:      true,
+				SandboxAllowlist: []string{"qa@example.org"},
+			},
+		}
+		mockWorkspaceRepo.EXPECT().GetByID(gomock.Any(), workspaceID).Return(workspace, nil)
+		mockTemplateService.EXPECT().
+			GetTemplateByID(gomock.Any(), workspaceID, templateConfig.TemplateID, int64(0)).
+			Return(emailTemplate, nil)
+		mockTemplateService.EXPECT().CompileTemplate(gomock.Any(), gomock.Any()).Return(compileResult, nil)
+		mockMessageRepo.EXPECT().
+			Create(gomock.Any(), workspaceID, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, _ string, msgHistory *domain.MessageHistory) error {
+				require.NotNil(t, msgHistory.FailedAt)
+				require.NotNil(t, msgHistory.StatusInfo)
+				assert.Equal(t, domain.ErrSandboxRecipientNotAllowed.Error(), *msgHistory.StatusInfo)
+				return nil
+			})
+		mockLogger.EXPECT().Warn(gomock.Any()).Times(1)
+		// No provider SendEmail expectation: the message must not be delivered
+
+		request := domain.SendEmailRequest{
+			WorkspaceID:      workspaceID,
+			IntegrationID:    "test-integration-id",
+			MessageID:        messageID,
+			Contact:          contact,
+			TemplateConfig:   templateConfig,
+			MessageData:      messageData,
+			TrackingSettings: trackingSettings,
+			EmailProvider:    emailProvider,
+			EmailOptions:     options,
+		}
+		err := emailService.SendEmailForTemplate(ctx, request)
+		require.NoError(t, err)
+	})
+
+	t.Run("Idempotency key already held aborts the send", func(t *testing.T) {
+		idempotencyKey := "order-12345"
+		workspace := &domain.Workspace{ID: workspaceID, Settings: domain.WorkspaceSettings{SecretKey: "secret"}}
+		mockWorkspaceRepo.EXPECT().GetByID(gomock.Any(), workspaceID).Return(workspace, nil)
+		mockTemplateService.EXPECT().
+			GetTemplateByID(gomock.Any(), workspaceID, templateConfig.TemplateID, int64(0)).
+			Return(emailTemplate, nil)
+		mockTemplateService.EXPECT().CompileTemplate(gomock.Any(), gomock.Any()).Return(compileResult, nil)
+		mockMessageRepo.EXPECT().
+			CreateWithIdempotencyKey(gomock.Any(), workspaceID, "secret", gomock.Any(), idempotencyKey).
+			Return(domain.ErrIdempotencyKeyUsed)
+		// No provider SendEmail expectation: the message must not be delivered twice
+
+		request := domain.SendEmailRequest{
+			WorkspaceID:      workspaceID,
+			IntegrationID:    "test-integration-id",
+			MessageID:        messageID,
+			IdempotencyKey:   &idempotencyKey,
+			Contact:          contact,
+			TemplateConfig:   templateConfig,
+			MessageData:      messageData,
+			TrackingSettings: trackingSettings,
+			EmailProvider:    emailProvider,
+			EmailOptions:     options,
+		}
+		err := emailService.SendEmailForTemplate(ctx, request)
+		assert.ErrorIs(t, err, domain.ErrIdempotencyKeyUsed)
+	})
+
 	t.Run("Error getting template", func(t *testing.T) {
 		// Setup template service mock to return an error
 		mockTemplateService.EXPECT().
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/pkg/logger"
+)
+
+// defaultFileStorageRegion is used when the file manager has no region,
+// S3-compatible stores such as MinIO accept any region
+const defaultFileStorageRegion = "us-east-1"
+
+// FileStorageService checks the S3-compatible storage configured in the workspace file manager
+type FileStorageService struct {
+	logger     logger.Logger
+	httpClient *http.Client
+}
+
+// NewFileStorageService creates a new file storage service
+func NewFileStorageService(logger logger.Logger) *FileStorageService {
+	return &FileStorageService{
+		logger:     logger,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// NewFileManagerS3Client creates an S3 client for the file manager settings.
+// The endpoint is used as-is so that non-AWS stores (MinIO, R2, Spaces...) can be reached,
+// and path-style addressing puts the bucket in the path instead of the hostname.
+func NewFileManagerS3Client(settings domain.FileManagerSettings, httpClient *http.Client) (*s3.S3, error) {
+	region := defaultFileStorageRegion
+	if settings.Region != nil && *settings.Region != "" {
+		region = *settings.Region
+	}
+
+	sess, err := session.NewSession(&aws.Config{
+		Endpoint:         aws.String(settings.Endpoint),
+		Region:           aws.String(region),
+		Credentials:      credentials.NewStaticCredentials(settings.AccessKey, settings.SecretKey, ""),
+		S3ForcePathStyle: aws.Bool(settings.ForcePathStyle),
+		HTTPClient:       httpClient,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create S3 session: %w", err)
+	}
+
+	return s3.New(sess), nil
+}
+
+// VerifyConnection checks that the bucket of the file manager can be listed with its credentials
+func (s *FileStorageService) VerifyConnection(ctx context.Context, settings domain.FileManagerSettings) error {
+	client, err := NewFileManagerS3Client(settings, s.httpClient)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.ListObjectsV2WithContext(ctx, &s3.ListObjectsV2Input{
+		Bucket:  aws.String(settings.Bucket),
+		MaxKeys: aws.Int64(1),
+	})
+	if err != nil {
+		s.logger.WithFields(map[string]interface{}{
+			"endpoint":         settings.Endpoint,
+			"bucket":           settings.Bucket,
+			"force_path_style": settings.ForcePathStyle,
+			"error":            err.Error(),
+		}).Warn("File storage connection check failed")
+		return fmt.Errorf("failed to access bucket %s: %w", settings.Bucket, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	pkgmocks "github.com/Notifuse/notifuse/pkg/mocks"
+)
+
+func TestNewFileManagerS3Client(t *testing.T) {
+	region := "eu-central-1"
+
+	t.Run("custom endpoint with path-style addressing", func(t *testing.T) {
+		client, err := NewFileManagerS3Client(domain.FileManagerSettings{
+			Endpoint:       "https://minio.example.com:9000",
+			Bucket:         "assets",
+			AccessKey:      "minio",
+			SecretKey:      "minio-secret",
+			Region:         &region,
+			ForcePathStyle: true,
+		}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, region, aws.StringValue(client.Config.Region))
+
+		req, _ := client.ListObjectsV2Request(&s3.ListObjectsV2Input{Bucket: aws.String("assets")})
+		require.NoError(t, req.Build())
+		assert.Equal(t, "minio.example.com:9000", req.HTTPRequest.URL.Host)
+		assert.Equal(t, "/assets", req.HTTPRequest.URL.Path)
+	})
+
+	t.Run("virtual-hosted addressing and default region", func(t *testing.T) {
+		client, err := NewFileManagerS3Client(domain.FileManagerSettings{
+			Endpoint:  "https://storage.example.com",
+			Bucket:    "assets",
+			AccessKey: "key",
+			SecretKey: "secret",
+		}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "us-east-1", aws.StringValue(client.Config.Region))
+
+		req, _ := client.ListObjectsV2Request(&s3.ListObjectsV2Input{Bucket: aws.String("assets")})
+		require.NoError(t, req.Build())
+		assert.Equal(t, "assets.storage.example.com", req.HTTPRequest.URL.Host)
+	})
+}
+
+func TestFileStorageService_VerifyConnection(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().Warn(gomock.Any()).AnyTimes()
+
+	t.Run("lists the bucket on the custom endpoint", func(t *testing.T) {
+		var path string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			path = r.URL.Path
+			w.Header().Set("Content-Type", "application/xml")
+			_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><ListBucketResult><Name>assets</Name><KeyCount>0</KeyCount></ListBucketResult>`))
+		}))
+		defer server.Close()
+
+		service := NewFileStorageService(mockLogger)
+		err := service.VerifyConnection(context.Background(), domain.FileManagerSettings{
+			Endpoint:       server.URL,
+			Bucket:         "assets",
+			AccessKey:      "minio",
+			SecretKey:      "minio-secret",
+			ForcePathStyle: true,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "/assets", path)
+	})
+
+	t.Run("returns access errors", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/xml")
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><Error><Code>AccessDenied</Code><Message>Access Denied</Message></Error>`))
+		}))
+		defer server.Close()
+
+		service := NewFileStorageService(mockLogger)
+		err := service.VerifyConnection(context.Background(), domain.FileManagerSettings{
+			Endpoint:       server.URL,
+			Bucket:         "assets",
+			AccessKey:      "minio",
+			SecretKey:      "wrong",
+			ForcePathStyle: true,
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "AccessDenied")
+	})
+}
+
+func TestFileManagerConnectionChanged(t *testing.T) {
+	region := "us-west-2"
+	base := domain.FileManagerSettings{Endpoint: "https://s3.example.com", Bucket: "assets", AccessKey: "key", SecretKey: "secret"}
+
+	assert.False(t, fileManagerConnectionChanged(base, base))
+
+	cdn := "https://cdn.example.com"
+	withCDN := base
+	withCDN.CDNEndpoint = &cdn
+	assert.False(t, fileManagerConnectionChanged(base, withCDN))
+
+	pathStyle := base
+	pathStyle.ForcePathStyle = true
+	assert.True(t, fileManagerConnectionChanged(base, pathStyle))
+
+	withRegion := base
+	withRegion.Region = &region
+	assert.True(t, fileManagerConnectionChanged(base, withRegion))
+
+	endpoint := base
+	endpoint.Endpoint = "https://minio.example.com"
+	assert.True(t, fileManagerConnectionChanged(base, endpoint))
+}
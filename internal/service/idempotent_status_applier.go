@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/pkg/logger"
+)
+
+// statusApplierKey identifies one status of one message
+type statusApplierKey struct {
+	messageID string
+	event     domain.MessageEvent
+}
+
+// statusApplierWindow collects the updates of a workspace until they are applied
+type statusApplierWindow struct {
+	updates map[statusApplierKey]domain.MessageEventUpdate
+	done    chan struct{}
+	err     error
+}
+
+// IdempotentStatusApplier applies message status updates so that concurrent deliveries of the same
+// event, such as a tracking pixel loaded twice or a webhook retried by the provider, are counted once.
+// Updates of a workspace are collected for a short window and de-duplicated per message and status,
+// keeping the earliest timestamp, then applied with ApplyStatusesLocked which locks the messages and
+// never overwrites a status already set.
+type IdempotentStatusApplier struct {
+	repo   domain.MessageHistoryRepository
+	logger logger.Logger
+	window time.Duration
+
+	mu      sync.Mutex
+	pending map[string]*statusApplierWindow // workspace ID -> updates waiting to be applied
+}
+
+// NewIdempotentStatusApplier creates a new IdempotentStatusApplier collecting updates for window
+func NewIdempotentStatusApplier(repo domain.MessageHistoryRepository, logger logger.Logger, window time.Duration) *IdempotentStatusApplier {
+	if window <= 0 {
+		window = 50 * time.Millisecond
+	}
+	return &IdempotentStatusApplier{
+		repo:    repo,
+		logger:  logger,
+		window:  window,
+		pending: make(map[string]*statusApplierWindow),
+	}
+}
+
+// Apply queues the updates of a workspace and waits until the window they joined is applied.
+// It returns the error of the write, or the context error when ctx is done first.
+func (a *IdempotentStatusApplier) Apply(ctx context.Context, workspaceID string, updates []domain.MessageEventUpdate) error {
+	if len(updates) == 0 {
+		return nil
+	}
+
+	a.mu.Lock()
+	window, ok := a.pending[workspaceID]
+	if !ok {
+		window = &statusApplierWindow{
+			updates: make(map[statusApplierKey]domain.MessageEventUpdate),
+			done:    make(chan struct{}),
+		}
+		a.pending[workspaceID] = window
+		time.AfterFunc(a.window, func() { a.flush(workspaceID, window) })
+	}
+	for _, update := range updates {
+		key := statusApplierKey{messageID: update.ID, event: update.Event}
+		if current, exists := window.updates[key]; !exists || update.Timestamp.Before(current.Timestamp) {
+			window.updates[key] = update
+		}
+	}
+	a.mu.Unlock()
+
+	select {
+	case <-window.done:
+		return window.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// flush applies the updates of a window in a single transaction and releases its callers
+func (a *IdempotentStatusApplier) flush(workspaceID string, window *statusApplierWindow) {
+	a.mu.Lock()
+	if a.pending[workspaceID] == window {
+		delete(a.pending, workspaceID)
+	}
+	updates := make([]domain.MessageEventUpdate, 0, len(window.updates))
+	for _, update := range window.updates {
+		updates = append(updates, update)
+	}
+	a.mu.Unlock()
+
+	window.err = a.repo.ApplyStatusesLocked(context.Background(), workspaceID, updates)
+	if window.err != nil {
+		a.logger.WithField("workspace_id", workspaceID).
+			WithField("updates", len(updates)).
+			WithField("error", window.err.Error()).
+			Error("Failed to apply message status updates")
+	}
+	close(window.done)
+}
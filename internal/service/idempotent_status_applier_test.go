@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/domain/mocks"
+	"github.com/Notifuse/notifuse/pkg/logger"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// firstWinsStatuses mimics ApplyStatusesLocked: a status keeps the first timestamp applied
+type firstWinsStatuses struct {
+	mu     sync.Mutex
+	calls  int
+	writes int
+	set    map[string]time.Time // message ID + event -> timestamp
+}
+
+func (f *firstWinsStatuses) apply(_ context.Context, _ string, updates []domain.MessageEventUpdate) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls++
+	for _, update := range updates {
+		key := update.ID + "/" + string(update.Event)
+		if _, ok := f.set[key]; !ok {
+			f.set[key] = update.Timestamp
+			f.writes++
+		}
+	}
+	return nil
+}
+
+func TestIdempotentStatusApplier_ConcurrentOpens(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockMessageHistoryRepository(ctrl)
+	statuses := &firstWinsStatuses{set: make(map[string]time.Time)}
+	repo.EXPECT().ApplyStatusesLocked(gomock.Any(), "w1", gomock.Any()).DoAndReturn(statuses.apply).AnyTimes()
+
+	applier := NewIdempotentStatusApplier(repo, logger.NewLoggerWithLevel("disabled"), 20*time.Millisecond)
+
+	// The same open event is delivered by many goroutines at once
+	openedAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			err := applier.Apply(context.Background(), "w1", []domain.MessageEventUpdate{
+				{ID: "message1", Event: domain.MessageEventOpened, Timestamp: openedAt.Add(time.Duration(i) * time.Millisecond)},
+			})
+			assert.NoError(t, err)
+		}(i)
+	}
+	wg.Wait()
+
+	statuses.mu.Lock()
+	defer statuses.mu.Unlock()
+	assert.Equal(t, 1, statuses.writes, "a single opened_at is recorded")
+	assert.Len(t, statuses.set, 1)
+	assert.Less(t, statuses.calls, 50, "updates are batched per window")
+}
+
+func TestIdempotentStatusApplier_DeduplicatesWithinWindow(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	earliest := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	repo := mocks.NewMockMessageHistoryRepository(ctrl)
+	repo.EXPECT().ApplyStatusesLocked(gomock.Any(), "w1", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, updates []domain.MessageEventUpdate) error {
+			byKey := make(map[statusApplierKey]time.Time)
+			for _, update := range updates {
+				byKey[statusApplierKey{messageID: update.ID, event: update.Event}] = update.Timestamp
+			}
+			assert.Len(t, updates, 3)
+			assert.Equal(t, earliest, byKey[statusApplierKey{messageID: "message1", event: domain.MessageEventOpened}])
+			assert.Equal(t, earliest, byKey[statusApplierKey{messageID: "message1", event: domain.MessageEventClicked}])
+			return nil
+		})
+
+	applier := NewIdempotentStatusApplier(repo, logger.NewLoggerWithLevel("disabled"), 50*time.Millisecond)
+
+	updates := [][]domain.MessageEventUpdate{
+		{{ID: "message1", Event: domain.MessageEventOpened, Timestamp: earliest.Add(time.Second)}},
+		{{ID: "message1", Event: domain.MessageEventOpened, Timestamp: earliest}},
+		{{ID: "message1", Event: domain.MessageEventClicked, Timestamp: earliest}, {ID: "message1", Event: domain.MessageEventOpened, Timestamp: earliest.Add(time.Minute)}},
+		{{ID: "message2", Event: domain.MessageEventDelivered, Timestamp: earliest}},
+	}
+	var wg sync.WaitGroup
+	for _, batch := range updates {
+		wg.Add(1)
+		go func(batch []domain.MessageEventUpdate) {
+			defer wg.Done()
+			assert.NoError(t, applier.Apply(context.Background(), "w1", batch))
+		}(batch)
+	}
+	wg.Wait()
+}
+
+func TestIdempotentStatusApplier_Errors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockMessageHistoryRepository(ctrl)
+	applier := NewIdempotentStatusApplier(repo, logger.NewLoggerWithLevel("disabled"), 10*time.Millisecond)
+	update := []domain.MessageEventUpdate{{ID: "message1", Event: domain.MessageEventOpened, Timestamp: time.Now()}}
+
+	// Nothing to apply
+	require.NoError(t, applier.Apply(context.Background(), "w1", nil))
+
+	// The write error is returned to the callers of the window
+	repo.EXPECT().ApplyStatusesLocked(gomock.Any(), "w1", gomock.Any()).Return(errors.New("db down"))
+	err := applier.Apply(context.Background(), "w1", update)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "db down")
+
+	// A caller giving up still gets its update applied with the window
+	applied := make(chan struct{})
+	repo.EXPECT().ApplyStatusesLocked(gomock.Any(), "w1", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, _ []domain.MessageEventUpdate) error {
+			close(applied)
+			return nil
+		})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, applier.Apply(ctx, "w1", update), context.Canceled)
+	<-applied
+}
@@ -2,12 +2,14 @@ package service
 
 import (
 	"context"
+	"crypto/x509"
 	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/Notifuse/notifuse/internal/domain"
@@ -16,6 +18,12 @@ import (
 	"github.com/google/uuid"
 )
 
+// payloadCleanupInterval is the minimum delay between two raw payload retention cleanups of a workspace
+const payloadCleanupInterval = time.Hour
+
+// reprocessPageSize is the number of raw payloads loaded per page while reprocessing
+const reprocessPageSize = 100
+
 // InboundWebhookEventService implements the domain.InboundWebhookEventServiceInterface
 type InboundWebhookEventService struct {
 	repo               domain.InboundWebhookEventRepository
@@ -23,6 +31,41 @@ type InboundWebhookEventService struct {
 	logger             logger.Logger
 	workspaceRepo      domain.WorkspaceRepository
 	messageHistoryRepo domain.MessageHistoryRepository
+
+	// payloadRetention is how long raw payloads are kept for reprocessing, 0 disables storage
+	payloadRetention   time.Duration
+	cleanupMu          sync.Mutex
+	lastPayloadCleanup map[string]time.Time
+
+	// statusBatcher, when set, applies message status updates of incoming webhooks asynchronously
+	statusBatcher *MessageStatusBatcher
+
+	// statusApplier, when set, applies the message status updates written synchronously so that
+	// concurrent deliveries of the same event are counted once
+	statusApplier *IdempotentStatusApplier
+
+	// webhookHealth, when set, records the events received for provider webhook health monitoring
+	webhookHealth *WebhookHealthMonitor
+
+	// contactRepo, when set, records the recipients of hard bounces and spam complaints on the
+	// workspace suppression list
+	contactRepo domain.ContactRepository
+
+	// statusRetryRepo, when set, queues the message status updates that failed to apply for a retry
+	statusRetryRepo domain.MessageStatusRetryRepository
+
+	// statsCache, when set, holds the broadcast stats that message status updates invalidate
+	statsCache *BroadcastStatsCache
+
+	// snsCertificates caches the SNS signing certificates by URL
+	snsCertMu           sync.Mutex
+	snsCertificates     map[string]*x509.Certificate
+	fetchSNSCertificate func(ctx context.Context, certURL string) (*x509.Certificate, error)
+
+	// awsAccountIDs caches the AWS account of the SES integrations by access key
+	awsAccountMu       sync.Mutex
+	awsAccountIDs      map[string]string
+	lookupAWSAccountID func(ctx context.Context, config domain.AmazonSESSettings) (string, error)
 }
 
 // NewInboundWebhookEventService creates a new InboundWebhookEventService
@@ -32,16 +75,54 @@ func NewInboundWebhookEventService(
 	logger logger.Logger,
 	workspaceRepo domain.WorkspaceRepository,
 	messageHistoryRepo domain.MessageHistoryRepository,
+	payloadRetention time.Duration,
 ) *InboundWebhookEventService {
 	return &InboundWebhookEventService{
-		repo:               repo,
-		authService:        authService,
-		logger:             logger,
-		workspaceRepo:      workspaceRepo,
-		messageHistoryRepo: messageHistoryRepo,
+		repo:                repo,
+		authService:         authService,
+		logger:              logger,
+		workspaceRepo:       workspaceRepo,
+		messageHistoryRepo:  messageHistoryRepo,
+		payloadRetention:    payloadRetention,
+		lastPayloadCleanup:  make(map[string]time.Time),
+		snsCertificates:     make(map[string]*x509.Certificate),
+		fetchSNSCertificate: downloadSNSCertificate,
+		awsAccountIDs:       make(map[string]string),
+		lookupAWSAccountID:  lookupAWSAccountID,
 	}
 }
 
+// SetStatusBatcher makes incoming webhooks apply their message status updates through the batcher
+func (s *InboundWebhookEventService) SetStatusBatcher(batcher *MessageStatusBatcher) {
+	s.statusBatcher = batcher
+}
+
+// SetStatusApplier makes incoming webhooks write their synchronous message status updates through the applier
+func (s *InboundWebhookEventService) SetStatusApplier(applier *IdempotentStatusApplier) {
+	s.statusApplier = applier
+}
+
+// SetWebhookHealthMonitor makes incoming webhooks feed the provider webhook health monitor
+func (s *InboundWebhookEventService) SetWebhookHealthMonitor(monitor *WebhookHealthMonitor) {
+	s.webhookHealth = monitor
+}
+
+// SetSuppressionRepository makes incoming webhooks suppress the recipients of hard bounces and spam complaints
+func (s *InboundWebhookEventService) SetSuppressionRepository(contactRepo domain.ContactRepository) {
+	s.contactRepo = contactRepo
+}
+
+// SetStatusRetryRepository makes incoming webhooks queue the message status updates that failed to
+// apply for the retry worker, instead of failing and getting the webhook retried by the provider
+func (s *InboundWebhookEventService) SetStatusRetryRepository(retryRepo domain.MessageStatusRetryRepository) {
+	s.statusRetryRepo = retryRepo
+}
+
+// SetBroadcastStatsCache makes the message status updates of incoming webhooks invalidate the cached broadcast stats
+func (s *InboundWebhookEventService) SetBroadcastStatsCache(cache *BroadcastStatsCache) {
+	s.statsCache = cache
+}
+
 // ProcessWebhook processes a webhook event from an email provider
 func (s *InboundWebhookEventService) ProcessWebhook(ctx context.Context, workspaceID string, integrationID string, rawPayload []byte) error {
 	// codecov:ignore:start
@@ -51,6 +132,14 @@ func (s *InboundWebhookEventService) ProcessWebhook(ctx context.Context, workspa
 	tracing.AddAttribute(ctx, "integrationID", integrationID)
 	// codecov:ignore:end
 
+	// Reject before storing anything so the provider retry does not duplicate events
+	if s.statusBatcher != nil && s.statusBatcher.Full() {
+		// codecov:ignore:start
+		tracing.MarkSpanError(ctx, domain.ErrIngestionQueueFull)
+		// codecov:ignore:end
+		return domain.ErrIngestionQueueFull
+	}
+
 	// get workspace and integration
 	workspace, err := s.workspaceRepo.GetByID(ctx, workspaceID)
 	if err != nil {
@@ -59,6 +148,54 @@ func (s *InboundWebhookEventService) ProcessWebhook(ctx context.Context, workspa
 		// codecov:ignore:end
 		return fmt.Errorf("failed to get workspace: %w", err)
 	}
+
+	// Keep the raw payload so it can be reprocessed if ingestion turns out to be wrong.
+	// Storage is best effort: the provider must not retry because of it.
+	payloadID := uuid.New().String()
+	payloadStored := false
+	if s.payloadRetention > 0 {
+		payload := &domain.InboundWebhookPayload{
+			ID:            payloadID,
+			IntegrationID: integrationID,
+			Payload:       string(rawPayload),
+			ReceivedAt:    time.Now().UTC(),
+		}
+		if err := s.repo.StorePayload(ctx, workspaceID, payload); err != nil {
+			s.logger.WithField("workspace_id", workspaceID).
+				WithField("integration_id", integrationID).
+				WithField("error", err.Error()).
+				Warn("Failed to store raw inbound webhook payload")
+		} else {
+			payloadStored = true
+		}
+		s.cleanupExpiredPayloads(ctx, workspaceID)
+	}
+
+	events, err := s.ingest(ctx, workspace, integrationID, payloadID, rawPayload, true)
+	if payloadStored {
+		s.markPayloadProcessed(ctx, workspaceID, payloadID, err)
+	}
+	if err != nil {
+		// codecov:ignore:start
+		tracing.MarkSpanError(ctx, err)
+		// codecov:ignore:end
+		return err
+	}
+
+	if s.webhookHealth != nil && events > 0 {
+		s.webhookHealth.RecordEvent(ctx, workspaceID, integrationID)
+	}
+
+	return nil
+}
+
+// ingest extracts the events of a raw payload, stores them and applies them to the message history.
+// Event IDs derive from payloadID, so ingesting the same payload twice is a no-op.
+// When batched is true and a status batcher is set, the message history is updated asynchronously.
+func (s *InboundWebhookEventService) ingest(ctx context.Context, workspace *domain.Workspace, integrationID string, payloadID string, rawPayload []byte, batched bool) (int, error) {
+	workspaceID := workspace.ID
+	var err error
+
 	var integration domain.Integration
 	for _, i := range workspace.Integrations {
 		if i.ID == integrationID {
@@ -79,31 +216,30 @@ func (s *InboundWebhookEventService) ProcessWebhook(ctx context.Context, workspa
 		events, err = s.processSparkPostWebhook(integration.ID, rawPayload)
 	case domain.EmailProviderKindMailjet:
 		events, err = s.processMailjetWebhook(integration.ID, rawPayload)
+	case domain.EmailProviderKindSendGrid:
+		events, err = s.processSendGridWebhook(integration.ID, rawPayload)
 	case domain.EmailProviderKindSMTP:
 		events, err = s.processSMTPWebhook(integration.ID, rawPayload)
 	default:
-		// codecov:ignore:start
-		tracing.MarkSpanError(ctx, fmt.Errorf("unsupported email provider kind: %s", integration.EmailProvider.Kind))
-		// codecov:ignore:end
-		return fmt.Errorf("unsupported email provider kind: %s", integration.EmailProvider.Kind)
+		return 0, fmt.Errorf("unsupported email provider kind: %s", integration.EmailProvider.Kind)
 	}
 
 	if err != nil {
-		// codecov:ignore:start
-		tracing.MarkSpanError(ctx, err)
-		// codecov:ignore:end
-		return fmt.Errorf("failed to process webhook: %w", err)
+		return 0, fmt.Errorf("failed to process webhook: %w", err)
+	}
+
+	for i, event := range events {
+		event.ID = domain.InboundWebhookEventID(payloadID, i)
 	}
 
 	// Store the event
 	// No authentication needed for webhook events as they come from external providers
 	if err := s.repo.StoreEvents(ctx, workspaceID, events); err != nil {
-		// codecov:ignore:start
-		tracing.MarkSpanError(ctx, err)
-		// codecov:ignore:end
-		return fmt.Errorf("failed to store inbound webhook events: %w", err)
+		return 0, fmt.Errorf("failed to store inbound webhook events: %w", err)
 	}
 
+	s.suppressRecipients(ctx, workspaceID, events)
+
 	updates := []domain.MessageEventUpdate{}
 
 	for _, event := range events {
@@ -142,26 +278,123 @@ func (s *InboundWebhookEventService) ProcessWebhook(ctx context.Context, workspa
 				statusInfo = &reason
 			default:
 				// Skip other event types
-				return nil
+				return len(events), nil
 			}
 
-			updates = append(updates, domain.MessageEventUpdate{
+			update := domain.MessageEventUpdate{
 				ID:         *event.MessageID,
 				Event:      messageEvent,
 				Timestamp:  event.Timestamp,
 				StatusInfo: statusInfo,
-			})
+			}
+			if category := normalizeBounceCategory(event); category != "" {
+				update.BounceCategory = &category
+			}
+			updates = append(updates, update)
 		}
 	}
 
-	if err := s.messageHistoryRepo.SetStatusesIfNotSet(ctx, workspaceID, updates); err != nil {
-		// codecov:ignore:start
-		tracing.MarkSpanError(ctx, err)
-		// codecov:ignore:end
-		return fmt.Errorf("failed to update message status: %w", err)
+	// Fall back to a synchronous write if the queue filled up since ProcessWebhook checked it
+	if batched && s.statusBatcher != nil {
+		if err := s.statusBatcher.Enqueue(workspaceID, updates); err == nil {
+			return len(events), nil
+		}
 	}
 
-	return nil
+	if s.statusApplier != nil {
+		err = s.statusApplier.Apply(ctx, workspaceID, updates)
+	} else {
+		err = s.messageHistoryRepo.SetStatusesIfNotSet(ctx, workspaceID, updates)
+	}
+	if err != nil {
+		// The events are stored, the updates are applied later rather than failing the webhook
+		if queueStatusRetry(ctx, s.statusRetryRepo, s.logger, workspaceID, updates, err) {
+			return len(events), nil
+		}
+		return 0, fmt.Errorf("failed to update message status: %w", err)
+	}
+	s.statsCache.InvalidateWorkspace(workspaceID)
+
+	return len(events), nil
+}
+
+// suppressRecipients adds the recipients of hard bounces and spam complaints to the workspace
+// suppression list, so that later broadcasts skip them. Soft bounces, blocks and full mailboxes
+// may be delivered on a later attempt and are not suppressed.
+func (s *InboundWebhookEventService) suppressRecipients(ctx context.Context, workspaceID string, events []*domain.InboundWebhookEvent) {
+	if s.contactRepo == nil {
+		return
+	}
+
+	suppressions := []*domain.EmailSuppression{}
+	for _, event := range events {
+		if event.RecipientEmail == "" {
+			continue
+		}
+		category := normalizeBounceCategory(event)
+		if category != domain.BounceCategoryHardBounce && category != domain.BounceCategorySpamComplaint {
+			continue
+		}
+		suppressions = append(suppressions, &domain.EmailSuppression{
+			Email:     event.RecipientEmail,
+			Reason:    category,
+			MessageID: event.MessageID,
+			CreatedAt: event.Timestamp,
+		})
+	}
+	if len(suppressions) == 0 {
+		return
+	}
+
+	// The events are stored, a failure is logged rather than failing the webhook and getting it retried
+	if err := s.contactRepo.AddSuppressions(ctx, workspaceID, suppressions); err != nil {
+		s.logger.WithField("workspace_id", workspaceID).
+			WithField("error", err.Error()).
+			Error("Failed to add bounced and complaining recipients to the suppression list")
+	}
+}
+
+// markPayloadProcessed records the outcome of an ingestion on the stored raw payload
+func (s *InboundWebhookEventService) markPayloadProcessed(ctx context.Context, workspaceID, payloadID string, ingestErr error) {
+	var processingError *string
+	if ingestErr != nil {
+		msg := ingestErr.Error()
+		processingError = &msg
+	}
+	if err := s.repo.MarkPayloadProcessed(ctx, workspaceID, payloadID, time.Now().UTC(), processingError); err != nil {
+		s.logger.WithField("workspace_id", workspaceID).
+			WithField("payload_id", payloadID).
+			WithField("error", err.Error()).
+			Warn("Failed to mark raw inbound webhook payload as processed")
+	}
+}
+
+// cleanupExpiredPayloads deletes raw payloads older than the retention period, at most once per
+// payloadCleanupInterval for each workspace
+func (s *InboundWebhookEventService) cleanupExpiredPayloads(ctx context.Context, workspaceID string) {
+	s.cleanupMu.Lock()
+	if s.lastPayloadCleanup == nil {
+		s.lastPayloadCleanup = make(map[string]time.Time)
+	}
+	if time.Since(s.lastPayloadCleanup[workspaceID]) < payloadCleanupInterval {
+		s.cleanupMu.Unlock()
+		return
+	}
+	s.lastPayloadCleanup[workspaceID] = time.Now()
+	s.cleanupMu.Unlock()
+
+	deleted, err := s.repo.DeletePayloadsBefore(ctx, workspaceID, time.Now().UTC().Add(-s.payloadRetention))
+	if err != nil {
+		s.logger.WithField("workspace_id", workspaceID).
+			WithField("error", err.Error()).
+			Warn("Failed to delete expired raw inbound webhook payloads")
+		return
+	}
+	if deleted > 0 {
+		s.logger.WithField("workspace_id", workspaceID).
+			WithField("deleted", deleted).
+			Info("Deleted expired raw inbound webhook payloads")
+	}
 }
 
 // isHardBounce determines if a bounce is a hard/permanent bounce based on bounce type and category
@@ -871,6 +1104,109 @@ func (s *InboundWebhookEventService) processSingleMailjetEvent(integrationID str
 	return event, nil
 }
 
+// processSendGridWebhook processes a batch of events from the SendGrid Event Webhook
+// Events that don't affect the message status (processed, open, click...) are ignored
+func (s *InboundWebhookEventService) processSendGridWebhook(integrationID string, rawPayload []byte) (events []*domain.InboundWebhookEvent, err error) {
+	var payloads []domain.SendGridWebhookEvent
+	if err := json.Unmarshal(rawPayload, &payloads); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal SendGrid webhook payload: %w", err)
+	}
+
+	events = []*domain.InboundWebhookEvent{}
+	for _, payload := range payloads {
+		var eventType domain.EmailEventType
+		var bounceType, bounceCategory, bounceDiagnostic, complaintFeedbackType string
+
+		// Map SendGrid event types to our event types
+		// According to https://www.twilio.com/docs/sendgrid/for-developers/tracking-events/event
+		switch payload.Event {
+		case "delivered":
+			eventType = domain.EmailEventDelivered
+		case "bounce":
+			eventType = domain.EmailEventBounce
+			// "blocked" bounces are temporary rejections by the receiving server
+			if payload.Type == "blocked" {
+				bounceType = "SoftBounce"
+				bounceCategory = "Blocked"
+			} else {
+				bounceType = "HardBounce"
+				bounceCategory = "Permanent"
+			}
+			bounceDiagnostic = sendGridDiagnostic(payload)
+		case "dropped":
+			// Dropped messages target a suppressed or invalid address and are never delivered
+			eventType = domain.EmailEventBounce
+			bounceType = "HardBounce"
+			bounceCategory = "Dropped"
+			bounceDiagnostic = sendGridDiagnostic(payload)
+		case "deferred":
+			eventType = domain.EmailEventBounce
+			bounceType = "SoftBounce"
+			bounceCategory = "Temporary"
+			bounceDiagnostic = sendGridDiagnostic(payload)
+		case "spamreport":
+			eventType = domain.EmailEventComplaint
+			complaintFeedbackType = "spam"
+		case "unsubscribe", "group_unsubscribe":
+			// Unsubscribe events can be treated as complaints for tracking purposes
+			eventType = domain.EmailEventComplaint
+			complaintFeedbackType = "unsubscribe"
+		default:
+			s.logger.WithField("integration_id", integrationID).
+				WithField("event", payload.Event).
+				Debug("Ignoring SendGrid webhook event")
+			continue
+		}
+
+		// Use notifuse_message_id from the custom args if available, otherwise fallback to SendGrid's ID
+		messageID := payload.SGMessageID
+		if payload.NotifuseMessageID != "" {
+			messageID = payload.NotifuseMessageID
+		}
+
+		event := domain.NewInboundWebhookEvent(
+			uuid.New().String(),
+			eventType,
+			domain.WebhookSourceSendGrid,
+			integrationID,
+			payload.Email,
+			&messageID,
+			time.Unix(payload.Timestamp, 0),
+			string(rawPayload),
+		)
+
+		// Set event-specific information
+		switch eventType {
+		case domain.EmailEventBounce:
+			event.BounceType = bounceType
+			event.BounceCategory = bounceCategory
+			event.BounceDiagnostic = bounceDiagnostic
+		case domain.EmailEventComplaint:
+			event.ComplaintFeedbackType = complaintFeedbackType
+		}
+
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+// sendGridDiagnostic joins the SMTP status and reason of a SendGrid bounce, dropped or deferred event
+func sendGridDiagnostic(payload domain.SendGridWebhookEvent) string {
+	diagnostic := payload.Status
+	reason := payload.Reason
+	if reason == "" {
+		reason = payload.Response
+	}
+	if reason != "" {
+		if diagnostic != "" {
+			diagnostic += ": "
+		}
+		diagnostic += reason
+	}
+	return diagnostic
+}
+
 // processSMTPWebhook processes a webhook event from a generic SMTP provider
 func (s *InboundWebhookEventService) processSMTPWebhook(integrationID string, rawPayload []byte) (events []*domain.InboundWebhookEvent, err error) {
 
@@ -965,3 +1301,123 @@ func (s *InboundWebhookEventService) ListEvents(ctx context.Context, workspaceID
 
 	return result, nil
 }
+
+// GetWebhookHealth retrieves the last send and webhook event times of the integrations of a workspace
+func (s *InboundWebhookEventService) GetWebhookHealth(ctx context.Context, workspaceID string) ([]*domain.ProviderWebhookHealth, error) {
+	// codecov:ignore:start
+	ctx, span := tracing.StartServiceSpan(ctx, "InboundWebhookEventService", "GetWebhookHealth")
+	defer tracing.EndSpan(span, nil)
+	tracing.AddAttribute(ctx, "workspaceID", workspaceID)
+	// codecov:ignore:end
+
+	ctx, _, _, err := s.authService.AuthenticateUserForWorkspace(ctx, workspaceID)
+	if err != nil {
+		// codecov:ignore:start
+		tracing.MarkSpanError(ctx, err)
+		// codecov:ignore:end
+		return nil, fmt.Errorf("failed to authenticate user: %w", err)
+	}
+
+	// Monitoring is disabled
+	if s.webhookHealth == nil {
+		return []*domain.ProviderWebhookHealth{}, nil
+	}
+
+	healths, err := s.webhookHealth.List(ctx, workspaceID)
+	if err != nil {
+		// codecov:ignore:start
+		tracing.MarkSpanError(ctx, err)
+		// codecov:ignore:end
+		return nil, err
+	}
+
+	return healths, nil
+}
+
+// ReprocessPayloads runs the stored raw payloads of a time range through the current ingest logic.
+// Reprocessing is idempotent: events already stored are skipped and message statuses are only set once.
+func (s *InboundWebhookEventService) ReprocessPayloads(ctx context.Context, request domain.ReprocessInboundWebhooksRequest) (*domain.ReprocessInboundWebhooksResult, error) {
+	// codecov:ignore:start
+	ctx, span := tracing.StartServiceSpan(ctx, "InboundWebhookEventService", "ReprocessPayloads")
+	defer tracing.EndSpan(span, nil)
+	tracing.AddAttribute(ctx, "workspaceID", request.WorkspaceID)
+	// codecov:ignore:end
+
+	ctx, user, userWorkspace, err := s.authService.AuthenticateUserForWorkspace(ctx, request.WorkspaceID)
+	if err != nil {
+		// codecov:ignore:start
+		tracing.MarkSpanError(ctx, err)
+		// codecov:ignore:end
+		return nil, fmt.Errorf("failed to authenticate user: %w", err)
+	}
+
+	if userWorkspace.Role != "owner" {
+		s.logger.WithField("workspace_id", request.WorkspaceID).WithField("user_id", user.ID).Warn("Non-owner attempted to reprocess inbound webhooks")
+		return nil, &domain.ErrUnauthorized{Message: "only workspace owners can reprocess inbound webhooks"}
+	}
+
+	if err := request.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid request: %w", err)
+	}
+
+	workspace, err := s.workspaceRepo.GetByID(ctx, request.WorkspaceID)
+	if err != nil {
+		// codecov:ignore:start
+		tracing.MarkSpanError(ctx, err)
+		// codecov:ignore:end
+		return nil, fmt.Errorf("failed to get workspace: %w", err)
+	}
+
+	result := &domain.ReprocessInboundWebhooksResult{}
+	params := domain.InboundWebhookPayloadListParams{
+		IntegrationID:  request.IntegrationID,
+		ReceivedAfter:  request.From,
+		ReceivedBefore: request.To,
+		Limit:          reprocessPageSize,
+	}
+
+	for {
+		payloads, err := s.repo.ListPayloads(ctx, request.WorkspaceID, params)
+		if err != nil {
+			// codecov:ignore:start
+			tracing.MarkSpanError(ctx, err)
+			// codecov:ignore:end
+			return result, fmt.Errorf("failed to list inbound webhook payloads: %w", err)
+		}
+
+		for _, payload := range payloads {
+			if err := ctx.Err(); err != nil {
+				return result, err
+			}
+
+			result.Payloads++
+			events, ingestErr := s.ingest(ctx, workspace, payload.IntegrationID, payload.ID, []byte(payload.Payload), false)
+			if ingestErr != nil {
+				result.Failed++
+				s.logger.WithField("workspace_id", request.WorkspaceID).
+					WithField("payload_id", payload.ID).
+					WithField("error", ingestErr.Error()).
+					Warn("Failed to reprocess inbound webhook payload")
+			} else {
+				result.Events += events
+			}
+			s.markPayloadProcessed(ctx, request.WorkspaceID, payload.ID, ingestErr)
+		}
+
+		if len(payloads) < reprocessPageSize {
+			break
+		}
+		last := payloads[len(payloads)-1]
+		params.AfterReceivedAt = &last.ReceivedAt
+		params.AfterID = last.ID
+	}
+
+	s.logger.WithField("workspace_id", request.WorkspaceID).
+		WithField("user_id", user.ID).
+		WithField("payloads", result.Payloads).
+		WithField("events", result.Events).
+		WithField("failed", result.Failed).
+		Info("Reprocessed inbound webhook payloads")
+
+	return result, nil
+}
@@ -97,6 +97,8 @@ func TestProcessWebhook_Success(t *testing.T) {
 			assert.Equal(t, 1, len(updates), "Should have 1 message status update")
 			assert.Equal(t, "message1", updates[0].ID)
 			assert.Equal(t, domain.MessageEventBounced, updates[0].Event)
+			require.NotNil(t, updates[0].BounceCategory)
+			assert.Equal(t, domain.BounceCategoryHardBounce, *updates[0].BounceCategory)
 			return nil
 		})
 
@@ -318,7 +320,7 @@ func TestNewInboundWebhookEventService(t *testing.T) {
 	workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
 	messageHistoryRepo := mocks.NewMockMessageHistoryRepository(ctrl)
 
-	service := NewInboundWebhookEventService(repo, authService, log, workspaceRepo, messageHistoryRepo)
+	service := NewInboundWebhookEventService(repo, authService, log, workspaceRepo, messageHistoryRepo, 72*time.Hour)
 
 	assert.NotNil(t, service)
 	assert.Equal(t, repo, service.repo)
@@ -326,6 +328,7 @@ func TestNewInboundWebhookEventService(t *testing.T) {
 	assert.NotNil(t, service.logger)
 	assert.Equal(t, workspaceRepo, service.workspaceRepo)
 	assert.Equal(t, messageHistoryRepo, service.messageHistoryRepo)
+	assert.Equal(t, 72*time.Hour, service.payloadRetention)
 }
 
 func TestProcessSESWebhook(t *testing.T) {
@@ -1710,6 +1713,80 @@ func TestProcessMailjetWebhook(t *testing.T) {
 	})
 }
 
+func TestProcessSendGridWebhook(t *testing.T) {
+	// Setup
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	log := pkgmocks.NewMockLogger(ctrl)
+	log.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(log).AnyTimes()
+	log.EXPECT().Debug(gomock.Any()).AnyTimes()
+
+	service := &InboundWebhookEventService{
+		repo:               mocks.NewMockInboundWebhookEventRepository(ctrl),
+		authService:        mocks.NewMockAuthService(ctrl),
+		logger:             log,
+		workspaceRepo:      mocks.NewMockWorkspaceRepository(ctrl),
+		messageHistoryRepo: mocks.NewMockMessageHistoryRepository(ctrl),
+	}
+
+	integrationID := "integration1"
+
+	t.Run("Batch of events", func(t *testing.T) {
+		payload := []domain.SendGridWebhookEvent{
+			{Email: "a@example.com", Timestamp: 1672574400, Event: "processed", SGMessageID: "sg1.filter", NotifuseMessageID: "msg1"},
+			{Email: "a@example.com", Timestamp: 1672574400, Event: "delivered", SGMessageID: "sg1.filter", NotifuseMessageID: "msg1"},
+			{Email: "b@example.com", Timestamp: 1672574400, Event: "bounce", Type: "bounce", Status: "5.1.1", Reason: "550 5.1.1 User unknown", SGMessageID: "sg2.filter", NotifuseMessageID: "msg2"},
+			{Email: "c@example.com", Timestamp: 1672574400, Event: "bounce", Type: "blocked", Status: "4.0.0", Reason: "Blocked by receiver", NotifuseMessageID: "msg3"},
+			{Email: "d@example.com", Timestamp: 1672574400, Event: "dropped", Reason: "Bounced Address", NotifuseMessageID: "msg4"},
+			{Email: "e@example.com", Timestamp: 1672574400, Event: "spamreport", NotifuseMessageID: "msg5"},
+			{Email: "f@example.com", Timestamp: 1672574400, Event: "group_unsubscribe", SGMessageID: "sg6.filter"},
+		}
+		rawPayload, err := json.Marshal(payload)
+		require.NoError(t, err)
+
+		events, err := service.processSendGridWebhook(integrationID, rawPayload)
+		require.NoError(t, err)
+
+		// The processed event is ignored
+		require.Len(t, events, 6)
+		for _, event := range events {
+			assert.Equal(t, domain.WebhookSourceSendGrid, event.Source)
+			assert.Equal(t, integrationID, event.IntegrationID)
+		}
+
+		assert.Equal(t, domain.EmailEventDelivered, events[0].Type)
+		assert.Equal(t, "msg1", *events[0].MessageID)
+
+		assert.Equal(t, domain.EmailEventBounce, events[1].Type)
+		assert.Equal(t, "HardBounce", events[1].BounceType)
+		assert.Equal(t, "Permanent", events[1].BounceCategory)
+		assert.Equal(t, "5.1.1: 550 5.1.1 User unknown", events[1].BounceDiagnostic)
+		assert.True(t, isHardBounce(events[1].BounceType, events[1].BounceCategory))
+
+		assert.Equal(t, "SoftBounce", events[2].BounceType)
+		assert.Equal(t, "Blocked", events[2].BounceCategory)
+		assert.False(t, isHardBounce(events[2].BounceType, events[2].BounceCategory))
+
+		assert.Equal(t, "Dropped", events[3].BounceCategory)
+		assert.Equal(t, "Bounced Address", events[3].BounceDiagnostic)
+		assert.True(t, isHardBounce(events[3].BounceType, events[3].BounceCategory))
+
+		assert.Equal(t, domain.EmailEventComplaint, events[4].Type)
+		assert.Equal(t, "spam", events[4].ComplaintFeedbackType)
+
+		// Falls back to the SendGrid message ID without custom args
+		assert.Equal(t, "unsubscribe", events[5].ComplaintFeedbackType)
+		assert.Equal(t, "sg6.filter", *events[5].MessageID)
+	})
+
+	t.Run("Invalid payload", func(t *testing.T) {
+		_, err := service.processSendGridWebhook(integrationID, []byte(`{"event":"delivered"}`))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to unmarshal SendGrid webhook payload")
+	})
+}
+
 func TestProcessSMTPWebhook(t *testing.T) {
 	// Setup
 	ctrl := gomock.NewController(t)
@@ -2584,3 +2661,421 @@ func TestListEvents(t *testing.T) {
 		assert.False(t, result.HasMore)
 	})
 }
+
+func TestProcessWebhook_StoresRawPayload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockInboundWebhookEventRepository(ctrl)
+	log := pkgmocks.NewMockLogger(ctrl)
+	log.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(log).AnyTimes()
+	log.EXPECT().Info(gomock.Any()).AnyTimes()
+	workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	messageHistoryRepo := mocks.NewMockMessageHistoryRepository(ctrl)
+
+	service := NewInboundWebhookEventService(repo, mocks.NewMockAuthService(ctrl), log, workspaceRepo, messageHistoryRepo, 24*time.Hour)
+
+	workspace := &domain.Workspace{
+		ID: "workspace1",
+		Integrations: []domain.Integration{
+			{ID: "integration1", EmailProvider: domain.EmailProvider{Kind: domain.EmailProviderKindPostmark}},
+		},
+	}
+	rawPayload := []byte(`{"RecordType":"Delivery","MessageID":"message123","Recipient":"test@example.com","DeliveredAt":"2024-05-01T10:00:00Z"}`)
+
+	var storedPayload *domain.InboundWebhookPayload
+	workspaceRepo.EXPECT().GetByID(gomock.Any(), "workspace1").Return(workspace, nil)
+	repo.EXPECT().StorePayload(gomock.Any(), "workspace1", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, payload *domain.InboundWebhookPayload) error {
+			assert.Equal(t, "integration1", payload.IntegrationID)
+			assert.Equal(t, string(rawPayload), payload.Payload)
+			storedPayload = payload
+			return nil
+		})
+	repo.EXPECT().DeletePayloadsBefore(gomock.Any(), "workspace1", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, before time.Time) (int64, error) {
+			assert.WithinDuration(t, time.Now().Add(-24*time.Hour), before, time.Minute)
+			return 0, nil
+		})
+	repo.EXPECT().StoreEvents(gomock.Any(), "workspace1", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, events []*domain.InboundWebhookEvent) error {
+			require.Len(t, events, 1)
+			// Event IDs are derived from the stored payload so reprocessing can't duplicate them
+			assert.Equal(t, domain.InboundWebhookEventID(storedPayload.ID, 0), events[0].ID)
+			return nil
+		})
+	messageHistoryRepo.EXPECT().SetStatusesIfNotSet(gomock.Any(), "workspace1", gomock.Any()).Return(nil)
+	repo.EXPECT().MarkPayloadProcessed(gomock.Any(), "workspace1", gomock.Any(), gomock.Any(), nil).
+		DoAndReturn(func(_ context.Context, _ string, id string, _ time.Time, _ *string) error {
+			assert.Equal(t, storedPayload.ID, id)
+			return nil
+		})
+
+	err := service.ProcessWebhook(context.Background(), "workspace1", "integration1", rawPayload)
+	assert.NoError(t, err)
+}
+
+func TestReprocessPayloads(t *testing.T) {
+	workspaceID := "workspace1"
+	integrationID := "integration1"
+	from := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+
+	workspace := &domain.Workspace{
+		ID: workspaceID,
+		Integrations: []domain.Integration{
+			{ID: integrationID, EmailProvider: domain.EmailProvider{Kind: domain.EmailProviderKindPostmark}},
+		},
+	}
+	storedPayload := &domain.InboundWebhookPayload{
+		ID:            "6f1c1c1e-8f0a-4c8e-9d55-3f5b8a3c2b11",
+		IntegrationID: integrationID,
+		Payload:       `{"RecordType":"Bounce","MessageID":"message123","Email":"bounce@example.com","Type":"HardBounce","Details":"mailbox unavailable","BouncedAt":"2024-05-01T10:00:00Z"}`,
+		ReceivedAt:    from.Add(time.Hour),
+	}
+
+	setup := func(t *testing.T, role string) (*InboundWebhookEventService, *mocks.MockInboundWebhookEventRepository, *mocks.MockWorkspaceRepository, *mocks.MockMessageHistoryRepository, *mocks.MockAuthService) {
+		ctrl := gomock.NewController(t)
+		t.Cleanup(ctrl.Finish)
+
+		repo := mocks.NewMockInboundWebhookEventRepository(ctrl)
+		authService := mocks.NewMockAuthService(ctrl)
+		log := pkgmocks.NewMockLogger(ctrl)
+		log.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(log).AnyTimes()
+		log.EXPECT().Info(gomock.Any()).AnyTimes()
+		log.EXPECT().Warn(gomock.Any()).AnyTimes()
+		log.EXPECT().Debug(gomock.Any()).AnyTimes()
+		workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+		messageHistoryRepo := mocks.NewMockMessageHistoryRepository(ctrl)
+
+		authService.EXPECT().AuthenticateUserForWorkspace(gomock.Any(), workspaceID).
+			Return(context.Background(), &domain.User{ID: "user1"}, &domain.UserWorkspace{Role: role}, nil).AnyTimes()
+
+		service := NewInboundWebhookEventService(repo, authService, log, workspaceRepo, messageHistoryRepo, 72*time.Hour)
+		return service, repo, workspaceRepo, messageHistoryRepo, authService
+	}
+
+	request := domain.ReprocessInboundWebhooksRequest{WorkspaceID: workspaceID, From: from, To: to}
+
+	t.Run("reprocessing applies the event and reprocessing again is a no-op", func(t *testing.T) {
+		service, repo, workspaceRepo, messageHistoryRepo, _ := setup(t, "owner")
+
+		// In-memory stores reproducing the repositories' idempotent semantics:
+		// events are inserted with ON CONFLICT (id) DO NOTHING, statuses are only set once
+		storedEvents := map[string]*domain.InboundWebhookEvent{}
+		insertedEvents := 0
+		statuses := map[string]map[domain.MessageEvent]time.Time{}
+		statusChanges := 0
+
+		workspaceRepo.EXPECT().GetByID(gomock.Any(), workspaceID).Return(workspace, nil).Times(2)
+		repo.EXPECT().ListPayloads(gomock.Any(), workspaceID, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, params domain.InboundWebhookPayloadListParams) ([]*domain.InboundWebhookPayload, error) {
+				assert.True(t, from.Equal(params.ReceivedAfter))
+				assert.True(t, to.Equal(params.ReceivedBefore))
+				return []*domain.InboundWebhookPayload{storedPayload}, nil
+			}).Times(2)
+		repo.EXPECT().StoreEvents(gomock.Any(), workspaceID, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, events []*domain.InboundWebhookEvent) error {
+				for _, event := range events {
+					if _, exists := storedEvents[event.ID]; !exists {
+						storedEvents[event.ID] = event
+						insertedEvents++
+					}
+				}
+				return nil
+			}).Times(2)
+		messageHistoryRepo.EXPECT().SetStatusesIfNotSet(gomock.Any(), workspaceID, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, updates []domain.MessageEventUpdate) error {
+				for _, update := range updates {
+					if statuses[update.ID] == nil {
+						statuses[update.ID] = map[domain.MessageEvent]time.Time{}
+					}
+					if _, set := statuses[update.ID][update.Event]; !set {
+						statuses[update.ID][update.Event] = update.Timestamp
+						statusChanges++
+					}
+				}
+				return nil
+			}).Times(2)
+		repo.EXPECT().MarkPayloadProcessed(gomock.Any(), workspaceID, storedPayload.ID, gomock.Any(), nil).Return(nil).Times(2)
+
+		// First run applies the bounce
+		result, err := service.ReprocessPayloads(context.Background(), request)
+		require.NoError(t, err)
+		assert.Equal(t, &domain.ReprocessInboundWebhooksResult{Payloads: 1, Events: 1, Failed: 0}, result)
+		assert.Equal(t, 1, insertedEvents)
+		assert.Equal(t, 1, statusChanges)
+		_, bounced := statuses["message123"][domain.MessageEventBounced]
+		assert.True(t, bounced)
+
+		// Second run produces the same event IDs and changes nothing
+		result, err = service.ReprocessPayloads(context.Background(), request)
+		require.NoError(t, err)
+		assert.Equal(t, 1, result.Events)
+		assert.Equal(t, 1, insertedEvents)
+		assert.Equal(t, 1, statusChanges)
+		assert.Len(t, storedEvents, 1)
+	})
+
+	t.Run("failing payload is counted and recorded", func(t *testing.T) {
+		service, repo, workspaceRepo, _, _ := setup(t, "owner")
+
+		broken := &domain.InboundWebhookPayload{ID: "broken", IntegrationID: integrationID, Payload: "not json", ReceivedAt: from}
+		workspaceRepo.EXPECT().GetByID(gomock.Any(), workspaceID).Return(workspace, nil)
+		repo.EXPECT().ListPayloads(gomock.Any(), workspaceID, gomock.Any()).Return([]*domain.InboundWebhookPayload{broken}, nil)
+		repo.EXPECT().MarkPayloadProcessed(gomock.Any(), workspaceID, "broken", gomock.Any(), gomock.Not(gomock.Nil())).Return(nil)
+
+		result, err := service.ReprocessPayloads(context.Background(), request)
+		require.NoError(t, err)
+		assert.Equal(t, &domain.ReprocessInboundWebhooksResult{Payloads: 1, Events: 0, Failed: 1}, result)
+	})
+
+	t.Run("non-owner is rejected", func(t *testing.T) {
+		service, _, _, _, _ := setup(t, "member")
+
+		_, err := service.ReprocessPayloads(context.Background(), request)
+		require.Error(t, err)
+		var unauthorized *domain.ErrUnauthorized
+		assert.ErrorAs(t, err, &unauthorized)
+	})
+
+	t.Run("invalid time range", func(t *testing.T) {
+		service, _, _, _, _ := setup(t, "owner")
+
+		_, err := service.ReprocessPayloads(context.Background(), domain.ReprocessInboundWebhooksRequest{WorkspaceID: workspaceID, From: to, To: from})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "from must be before to")
+	})
+}
+
+func TestProcessWebhook_StatusBatcher(t *testing.T) {
+	workspaceID := "workspace1"
+	integrationID := "integration1"
+	workspace := &domain.Workspace{
+		ID: workspaceID,
+		Integrations: []domain.Integration{
+			{ID: integrationID, EmailProvider: domain.EmailProvider{Kind: domain.EmailProviderKindPostmark}},
+		},
+	}
+	deliveryPayload := func(messageID string) []byte {
+		payload, err := json.Marshal(map[string]interface{}{
+			"RecordType":  "Delivery",
+			"MessageID":   messageID,
+			"Recipient":   "test@example.com",
+			"DeliveredAt": time.Now().Format(time.RFC3339),
+		})
+		require.NoError(t, err)
+		return payload
+	}
+
+	setup := func(t *testing.T) (*InboundWebhookEventService, *mocks.MockInboundWebhookEventRepository, *mocks.MockWorkspaceRepository, *mocks.MockMessageHistoryRepository, *pkgmocks.MockLogger) {
+		ctrl := gomock.NewController(t)
+		t.Cleanup(ctrl.Finish)
+
+		repo := mocks.NewMockInboundWebhookEventRepository(ctrl)
+		log := pkgmocks.NewMockLogger(ctrl)
+		log.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(log).AnyTimes()
+		log.EXPECT().Info(gomock.Any()).AnyTimes()
+		log.EXPECT().Error(gomock.Any()).AnyTimes()
+		workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+		messageHistoryRepo := mocks.NewMockMessageHistoryRepository(ctrl)
+
+		service := NewInboundWebhookEventService(repo, mocks.NewMockAuthService(ctrl), log, workspaceRepo, messageHistoryRepo, 0)
+		return service, repo, workspaceRepo, messageHistoryRepo, log
+	}
+
+	t.Run("burst is written in a single call", func(t *testing.T) {
+		service, repo, workspaceRepo, messageHistoryRepo, log := setup(t)
+
+		workspaceRepo.EXPECT().GetByID(gomock.Any(), workspaceID).Return(workspace, nil).Times(5)
+		repo.EXPECT().StoreEvents(gomock.Any(), workspaceID, gomock.Any()).Return(nil).Times(5)
+		messageHistoryRepo.EXPECT().SetStatusesIfNotSet(gomock.Any(), workspaceID, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ string, updates []domain.MessageEventUpdate) error {
+				assert.Len(t, updates, 5)
+				return nil
+			}).Times(1)
+
+		batcher := NewMessageStatusBatcher(messageHistoryRepo, log, 1, 100, 500, time.Hour)
+		batcher.Start()
+		service.SetStatusBatcher(batcher)
+
+		for i := 0; i < 5; i++ {
+			err := service.ProcessWebhook(context.Background(), workspaceID, integrationID, deliveryPayload(fmt.Sprintf("message-%d", i)))
+			require.NoError(t, err)
+		}
+		batcher.Stop()
+	})
+
+	t.Run("saturated queue rejects the webhook before storing it", func(t *testing.T) {
+		service, _, _, messageHistoryRepo, log := setup(t)
+
+		// Workers are not started so the single slot stays taken
+		batcher := NewMessageStatusBatcher(messageHistoryRepo, log, 1, 1, 500, time.Hour)
+		require.NoError(t, batcher.Enqueue(workspaceID, []domain.MessageEventUpdate{{ID: "queued", Event: domain.MessageEventOpened}}))
+		service.SetStatusBatcher(batcher)
+
+		err := service.ProcessWebhook(context.Background(), workspaceID, integrationID, deliveryPayload("message-1"))
+		assert.ErrorIs(t, err, domain.ErrIngestionQueueFull)
+	})
+
+	t.Run("stopped batcher falls back to a synchronous write", func(t *testing.T) {
+		service, repo, workspaceRepo, messageHistoryRepo, log := setup(t)
+
+		workspaceRepo.EXPECT().GetByID(gomock.Any(), workspaceID).Return(workspace, nil)
+		repo.EXPECT().StoreEvents(gomock.Any(), workspaceID, gomock.Any()).Return(nil)
+		messageHistoryRepo.EXPECT().SetStatusesIfNotSet(gomock.Any(), workspaceID, gomock.Any()).Return(nil)
+
+		batcher := NewMessageStatusBatcher(messageHistoryRepo, log, 1, 10, 500, time.Hour)
+		batcher.Start()
+		batcher.Stop()
+		service.SetStatusBatcher(batcher)
+
+		err := service.ProcessWebhook(context.Background(), workspaceID, integrationID, deliveryPayload("message-1"))
+		require.NoError(t, err)
+	})
+}
+
+func TestProcessWebhook_WebhookHealth(t *testing.T) {
+	workspaceID := "workspace1"
+	integrationID := "integration1"
+	workspace := &domain.Workspace{
+		ID: workspaceID,
+		Integrations: []domain.Integration{
+			{ID: integrationID, EmailProvider: domain.EmailProvider{Kind: domain.EmailProviderKindPostmark}},
+		},
+	}
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	repo := mocks.NewMockInboundWebhookEventRepository(ctrl)
+	healthRepo := mocks.NewMockProviderWebhookHealthRepository(ctrl)
+	authService := mocks.NewMockAuthService(ctrl)
+	log := pkgmocks.NewMockLogger(ctrl)
+	log.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(log).AnyTimes()
+	log.EXPECT().Info(gomock.Any()).AnyTimes()
+	log.EXPECT().Error(gomock.Any()).AnyTimes()
+	workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	messageHistoryRepo := mocks.NewMockMessageHistoryRepository(ctrl)
+
+	service := NewInboundWebhookEventService(repo, authService, log, workspaceRepo, messageHistoryRepo, 0)
+	service.SetWebhookHealthMonitor(NewWebhookHealthMonitor(healthRepo, workspaceRepo, mocks.NewMockEventBus(ctrl), log, 6*time.Hour))
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"RecordType":  "Delivery",
+		"MessageID":   "message-1",
+		"Recipient":   "test@example.com",
+		"DeliveredAt": time.Now().Format(time.RFC3339),
+	})
+	require.NoError(t, err)
+
+	workspaceRepo.EXPECT().GetByID(gomock.Any(), workspaceID).Return(workspace, nil)
+	repo.EXPECT().StoreEvents(gomock.Any(), workspaceID, gomock.Any()).Return(nil)
+	messageHistoryRepo.EXPECT().SetStatusesIfNotSet(gomock.Any(), workspaceID, gomock.Any()).Return(nil)
+	healthRepo.EXPECT().RecordEvent(gomock.Any(), workspaceID, integrationID, gomock.Any()).Return(nil)
+
+	require.NoError(t, service.ProcessWebhook(context.Background(), workspaceID, integrationID, payload))
+
+	lastEventAt := time.Now().UTC()
+	authService.EXPECT().AuthenticateUserForWorkspace(gomock.Any(), workspaceID).
+		Return(context.Background(), &domain.User{ID: "user1"}, &domain.UserWorkspace{Role: "member"}, nil)
+	healthRepo.EXPECT().List(gomock.Any(), workspaceID).Return([]*domain.ProviderWebhookHealth{
+		{IntegrationID: integrationID, LastEventAt: &lastEventAt},
+	}, nil)
+
+	healths, err := service.GetWebhookHealth(context.Background(), workspaceID)
+	require.NoError(t, err)
+	require.Len(t, healths, 1)
+	assert.False(t, healths[0].Stale)
+}
+
+func TestProcessWebhook_SuppressesRecipients(t *testing.T) {
+	workspaceID := "workspace1"
+	integrationID := "integration1"
+	workspace := &domain.Workspace{
+		ID: workspaceID,
+		Integrations: []domain.Integration{
+			{ID: integrationID, EmailProvider: domain.EmailProvider{Kind: domain.EmailProviderKindPostmark}},
+		},
+	}
+	bouncedAt := time.Date(2026, 10, 16, 9, 0, 0, 0, time.UTC)
+	postmarkPayload := func(recordType, bounceType string) []byte {
+		payload, err := json.Marshal(map[string]interface{}{
+			"RecordType":   recordType,
+			"MessageID":    "message1",
+			"Email":        "test@example.com",
+			"Type":         bounceType,
+			"BouncedAt":    bouncedAt.Format(time.RFC3339),
+			"ComplainedAt": bouncedAt.Format(time.RFC3339),
+		})
+		require.NoError(t, err)
+		return payload
+	}
+
+	setup := func(t *testing.T) (*InboundWebhookEventService, *mocks.MockContactRepository) {
+		ctrl := gomock.NewController(t)
+		t.Cleanup(ctrl.Finish)
+
+		repo := mocks.NewMockInboundWebhookEventRepository(ctrl)
+		repo.EXPECT().StoreEvents(gomock.Any(), workspaceID, gomock.Any()).Return(nil)
+		log := pkgmocks.NewMockLogger(ctrl)
+		log.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(log).AnyTimes()
+		log.EXPECT().Debug(gomock.Any()).AnyTimes()
+		log.EXPECT().Info(gomock.Any()).AnyTimes()
+		log.EXPECT().Error(gomock.Any()).AnyTimes()
+		workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+		workspaceRepo.EXPECT().GetByID(gomock.Any(), workspaceID).Return(workspace, nil)
+		messageHistoryRepo := mocks.NewMockMessageHistoryRepository(ctrl)
+		messageHistoryRepo.EXPECT().SetStatusesIfNotSet(gomock.Any(), workspaceID, gomock.Any()).Return(nil)
+		contactRepo := mocks.NewMockContactRepository(ctrl)
+
+		service := NewInboundWebhookEventService(repo, mocks.NewMockAuthService(ctrl), log, workspaceRepo, messageHistoryRepo, 0)
+		service.SetSuppressionRepository(contactRepo)
+		return service, contactRepo
+	}
+
+	t.Run("hard bounce suppresses the recipient", func(t *testing.T) {
+		service, contactRepo := setup(t)
+		messageID := "message1"
+
+		contactRepo.EXPECT().AddSuppressions(gomock.Any(), workspaceID, []*domain.EmailSuppression{
+			{Email: "test@example.com", Reason: domain.BounceCategoryHardBounce, MessageID: &messageID, CreatedAt: bouncedAt},
+		}).Return(nil)
+
+		err := service.ProcessWebhook(context.Background(), workspaceID, integrationID, postmarkPayload("Bounce", "HardBounce"))
+		require.NoError(t, err)
+	})
+
+	t.Run("spam complaint suppresses the recipient", func(t *testing.T) {
+		service, contactRepo := setup(t)
+
+		contactRepo.EXPECT().AddSuppressions(gomock.Any(), workspaceID, gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ string, suppressions []*domain.EmailSuppression) error {
+				require.Len(t, suppressions, 1)
+				assert.Equal(t, "test@example.com", suppressions[0].Email)
+				assert.Equal(t, domain.BounceCategorySpamComplaint, suppressions[0].Reason)
+				return nil
+			})
+
+		err := service.ProcessWebhook(context.Background(), workspaceID, integrationID, postmarkPayload("SpamComplaint", "SpamComplaint"))
+		require.NoError(t, err)
+	})
+
+	t.Run("soft bounce is not suppressed", func(t *testing.T) {
+		service, contactRepo := setup(t)
+
+		contactRepo.EXPECT().AddSuppressions(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+		err := service.ProcessWebhook(context.Background(), workspaceID, integrationID, postmarkPayload("Bounce", "SoftBounce"))
+		require.NoError(t, err)
+	})
+
+	t.Run("suppression failure does not fail the webhook", func(t *testing.T) {
+		service, contactRepo := setup(t)
+
+		contactRepo.EXPECT().AddSuppressions(gomock.Any(), workspaceID, gomock.Any()).Return(errors.New("db error"))
+
+		err := service.ProcessWebhook(context.Background(), workspaceID, integrationID, postmarkPayload("Bounce", "HardBounce"))
+		require.NoError(t, err)
+	})
+}
@@ -0,0 +1,209 @@
+package service
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/pkg/tracing"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/sts"
+)
+
+// snsCertificateClient downloads the SNS signing certificates
+var snsCertificateClient = &http.Client{Timeout: 10 * time.Second}
+
+// VerifyWebhook verifies the signature of a webhook event with the secret of its integration.
+// Mailgun events are checked against the webhook signing key, Postmark events against the basic auth
+// credentials and SES events against the SNS signing certificate and the SNS topic of the integration.
+// Integrations without a secret, or SES integrations without signature verification, accept every event.
+func (s *InboundWebhookEventService) VerifyWebhook(ctx context.Context, workspaceID, integrationID string, header http.Header, rawPayload []byte) error {
+	// codecov:ignore:start
+	ctx, span := tracing.StartServiceSpan(ctx, "InboundWebhookEventService", "VerifyWebhook")
+	defer tracing.EndSpan(span, nil)
+	tracing.AddAttribute(ctx, "workspaceID", workspaceID)
+	tracing.AddAttribute(ctx, "integrationID", integrationID)
+	// codecov:ignore:end
+
+	workspace, err := s.workspaceRepo.GetByID(ctx, workspaceID)
+	if err != nil {
+		// codecov:ignore:start
+		tracing.MarkSpanError(ctx, err)
+		// codecov:ignore:end
+		return fmt.Errorf("failed to get workspace: %w", err)
+	}
+
+	integration := workspace.GetIntegrationByID(integrationID)
+	if integration == nil {
+		return fmt.Errorf("integration not found: %s", integrationID)
+	}
+	provider := integration.EmailProvider
+
+	switch provider.Kind {
+	case domain.EmailProviderKindMailgun:
+		if provider.Mailgun == nil || provider.Mailgun.WebhookSigningKey == "" {
+			return nil
+		}
+		err = domain.VerifyMailgunWebhookSignature(rawPayload, provider.Mailgun.WebhookSigningKey)
+	case domain.EmailProviderKindPostmark:
+		if provider.Postmark == nil || provider.Postmark.WebhookHttpAuth() == nil {
+			return nil
+		}
+		username, password, ok := (&http.Request{Header: header}).BasicAuth()
+		err = domain.VerifyPostmarkWebhookAuth(username, password, ok, provider.Postmark)
+	case domain.EmailProviderKindSES:
+		if provider.SES == nil || !provider.SES.VerifyWebhookSignature {
+			return nil
+		}
+		err = s.verifySNSMessage(ctx, integrationID, *provider.SES, rawPayload)
+	default:
+		return nil
+	}
+
+	if err != nil {
+		// codecov:ignore:start
+		tracing.MarkSpanError(ctx, err)
+		// codecov:ignore:end
+		return err
+	}
+
+	return nil
+}
+
+// verifySNSMessage verifies the signature of an SNS message with the certificate it references, and
+// that it was published on the topic created for the integration. Anyone can get a message signed by
+// SNS from a topic of their own AWS account, subscription confirmations included, so a valid signature
+// alone does not prove the message comes from the integration.
+func (s *InboundWebhookEventService) verifySNSMessage(ctx context.Context, integrationID string, config domain.AmazonSESSettings, rawPayload []byte) error {
+	var message domain.SESWebhookPayload
+	if err := json.Unmarshal(rawPayload, &message); err != nil {
+		return fmt.Errorf("%w: failed to unmarshal SNS message: %v", domain.ErrInvalidWebhookSignature, err)
+	}
+
+	if err := domain.ValidateSNSSigningCertURL(message.SigningCertURL); err != nil {
+		return err
+	}
+
+	cert, err := s.snsCertificate(ctx, message.SigningCertURL)
+	if err != nil {
+		return err
+	}
+
+	if err := domain.VerifySNSMessageSignature(&message, cert); err != nil {
+		return err
+	}
+
+	accountID, err := s.awsAccountID(ctx, config)
+	if err != nil {
+		return err
+	}
+
+	expectedTopicARN := fmt.Sprintf("arn:aws:sns:%s:%s:%s", config.Region, accountID, sesWebhookTopicName(integrationID))
+	if message.TopicARN != expectedTopicARN {
+		return fmt.Errorf("%w: SNS message from topic %q, expected %q", domain.ErrInvalidWebhookSignature, message.TopicARN, expectedTopicARN)
+	}
+
+	return nil
+}
+
+// awsAccountID returns the AWS account of the credentials of an SES integration, looking it up on first use
+func (s *InboundWebhookEventService) awsAccountID(ctx context.Context, config domain.AmazonSESSettings) (string, error) {
+	s.awsAccountMu.Lock()
+	accountID, ok := s.awsAccountIDs[config.AccessKey]
+	s.awsAccountMu.Unlock()
+	if ok {
+		return accountID, nil
+	}
+
+	accountID, err := s.lookupAWSAccountID(ctx, config)
+	if err != nil {
+		return "", err
+	}
+
+	s.awsAccountMu.Lock()
+	s.awsAccountIDs[config.AccessKey] = accountID
+	s.awsAccountMu.Unlock()
+
+	return accountID, nil
+}
+
+// lookupAWSAccountID returns the AWS account of the credentials of an SES integration
+func lookupAWSAccountID(ctx context.Context, config domain.AmazonSESSettings) (string, error) {
+	if config.AccessKey == "" || config.SecretKey == "" {
+		return "", ErrInvalidAWSCredentials
+	}
+
+	sess, err := createSession(config)
+	if err != nil {
+		return "", fmt.Errorf("failed to create AWS session: %w", err)
+	}
+
+	identity, err := sts.New(sess).GetCallerIdentityWithContext(ctx, &sts.GetCallerIdentityInput{})
+	if err != nil {
+		return "", fmt.Errorf("failed to get the AWS account of the integration: %w", err)
+	}
+
+	return aws.StringValue(identity.Account), nil
+}
+
+// snsCertificate returns the signing certificate at certURL, downloading it on first use
+func (s *InboundWebhookEventService) snsCertificate(ctx context.Context, certURL string) (*x509.Certificate, error) {
+	s.snsCertMu.Lock()
+	cert, ok := s.snsCertificates[certURL]
+	s.snsCertMu.Unlock()
+	if ok {
+		return cert, nil
+	}
+
+	cert, err := s.fetchSNSCertificate(ctx, certURL)
+	if err != nil {
+		return nil, err
+	}
+
+	s.snsCertMu.Lock()
+	s.snsCertificates[certURL] = cert
+	s.snsCertMu.Unlock()
+
+	return cert, nil
+}
+
+// downloadSNSCertificate downloads and parses a PEM encoded SNS signing certificate
+func downloadSNSCertificate(ctx context.Context, certURL string) (*x509.Certificate, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, certURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create SNS certificate request: %w", err)
+	}
+
+	resp, err := snsCertificateClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download SNS certificate: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download SNS certificate: HTTP %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read SNS certificate: %w", err)
+	}
+
+	block, _ := pem.Decode(body)
+	if block == nil {
+		return nil, fmt.Errorf("failed to decode SNS certificate PEM")
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse SNS certificate: %w", err)
+	}
+
+	return cert, nil
+}
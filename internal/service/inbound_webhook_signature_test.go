@@ -0,0 +1,247 @@
+package service
+
+import (
+	"context"
+	"crypto"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/domain/mocks"
+	pkgmocks "github.com/Notifuse/notifuse/pkg/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupVerifyWebhookTest(t *testing.T, provider domain.EmailProvider) (*InboundWebhookEventService, *mocks.MockWorkspaceRepository) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	service := NewInboundWebhookEventService(
+		mocks.NewMockInboundWebhookEventRepository(ctrl),
+		mocks.NewMockAuthService(ctrl),
+		pkgmocks.NewMockLogger(ctrl),
+		workspaceRepo,
+		mocks.NewMockMessageHistoryRepository(ctrl),
+		0,
+	)
+
+	workspaceRepo.EXPECT().GetByID(gomock.Any(), "workspace1").Return(&domain.Workspace{
+		ID:           "workspace1",
+		Integrations: []domain.Integration{{ID: "integration1", EmailProvider: provider}},
+	}, nil).AnyTimes()
+
+	return service, workspaceRepo
+}
+
+func TestInboundWebhookEventService_VerifyWebhook_Mailgun(t *testing.T) {
+	provider := domain.EmailProvider{
+		Kind:    domain.EmailProviderKindMailgun,
+		Mailgun: &domain.MailgunSettings{Domain: "example.com", WebhookSigningKey: "signing-key"},
+	}
+	service, _ := setupVerifyWebhookTest(t, provider)
+
+	mac := hmac.New(sha256.New, []byte("signing-key"))
+	mac.Write([]byte("1700000000token123"))
+	signature := hex.EncodeToString(mac.Sum(nil))
+
+	valid := []byte(fmt.Sprintf(`{"signature":{"timestamp":"1700000000","token":"token123","signature":%q},"event-data":{"event":"delivered"}}`, signature))
+	assert.NoError(t, service.VerifyWebhook(context.Background(), "workspace1", "integration1", http.Header{}, valid))
+
+	tampered := []byte(fmt.Sprintf(`{"signature":{"timestamp":"1700000000","token":"token456","signature":%q},"event-data":{"event":"delivered"}}`, signature))
+	err := service.VerifyWebhook(context.Background(), "workspace1", "integration1", http.Header{}, tampered)
+	assert.ErrorIs(t, err, domain.ErrInvalidWebhookSignature)
+
+	unsigned := []byte(`{"event-data":{"event":"delivered"}}`)
+	err = service.VerifyWebhook(context.Background(), "workspace1", "integration1", http.Header{}, unsigned)
+	assert.ErrorIs(t, err, domain.ErrInvalidWebhookSignature)
+}
+
+func TestInboundWebhookEventService_VerifyWebhook_Postmark(t *testing.T) {
+	provider := domain.EmailProvider{
+		Kind:     domain.EmailProviderKindPostmark,
+		Postmark: &domain.PostmarkSettings{WebhookUsername: "notifuse", WebhookPassword: "s3cret"},
+	}
+	service, _ := setupVerifyWebhookTest(t, provider)
+	payload := []byte(`{"RecordType":"Delivery","MessageID":"message123"}`)
+
+	basicAuth := func(username, password string) http.Header {
+		req, _ := http.NewRequest(http.MethodPost, "/webhooks/email", nil)
+		req.SetBasicAuth(username, password)
+		return req.Header
+	}
+
+	assert.NoError(t, service.VerifyWebhook(context.Background(), "workspace1", "integration1", basicAuth("notifuse", "s3cret"), payload))
+
+	err := service.VerifyWebhook(context.Background(), "workspace1", "integration1", basicAuth("notifuse", "wrong"), payload)
+	assert.ErrorIs(t, err, domain.ErrInvalidWebhookSignature)
+
+	err = service.VerifyWebhook(context.Background(), "workspace1", "integration1", http.Header{}, payload)
+	assert.ErrorIs(t, err, domain.ErrInvalidWebhookSignature)
+}
+
+func TestInboundWebhookEventService_VerifyWebhook_SES(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "sns.amazonaws.com"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	certURL := "https://sns.us-east-1.amazonaws.com/SimpleNotificationService-abc.pem"
+	integrationTopicARN := "arn:aws:sns:us-east-1:123456789012:notifuse-ses-integration1"
+	signedMessage := func(messageType, topicARN, message string) []byte {
+		payload := &domain.SESWebhookPayload{
+			Type:             messageType,
+			MessageID:        "sns-message-1",
+			TopicARN:         topicARN,
+			Message:          `{"eventType":"Delivery"}`,
+			Timestamp:        "2026-01-01T00:00:00.000Z",
+			SignatureVersion: "2",
+			SigningCertURL:   certURL,
+		}
+		stringToSign, err := domain.SNSStringToSign(payload)
+		require.NoError(t, err)
+		sum := sha256.Sum256([]byte(stringToSign))
+		signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, sum[:])
+		require.NoError(t, err)
+		payload.Signature = base64.StdEncoding.EncodeToString(signature)
+
+		// The message is replaced after signing to tamper with it
+		payload.Message = message
+		raw, err := json.Marshal(payload)
+		require.NoError(t, err)
+		return raw
+	}
+	signedPayload := func(message string) []byte {
+		return signedMessage("Notification", integrationTopicARN, message)
+	}
+	stubAccount := func(service *InboundWebhookEventService) {
+		service.lookupAWSAccountID = func(_ context.Context, config domain.AmazonSESSettings) (string, error) {
+			assert.Equal(t, "AKIAEXAMPLE", config.AccessKey)
+			return "123456789012", nil
+		}
+	}
+
+	provider := domain.EmailProvider{
+		Kind: domain.EmailProviderKindSES,
+		SES:  &domain.AmazonSESSettings{Region: "us-east-1", AccessKey: "AKIAEXAMPLE", SecretKey: "secret", VerifyWebhookSignature: true},
+	}
+
+	t.Run("valid and tampered messages", func(t *testing.T) {
+		service, _ := setupVerifyWebhookTest(t, provider)
+		stubAccount(service)
+		downloads := 0
+		service.fetchSNSCertificate = func(_ context.Context, url string) (*x509.Certificate, error) {
+			downloads++
+			assert.Equal(t, certURL, url)
+			return cert, nil
+		}
+
+		assert.NoError(t, service.VerifyWebhook(context.Background(), "workspace1", "integration1", http.Header{}, signedPayload(`{"eventType":"Delivery"}`)))
+
+		err := service.VerifyWebhook(context.Background(), "workspace1", "integration1", http.Header{}, signedPayload(`{"eventType":"Bounce"}`))
+		assert.ErrorIs(t, err, domain.ErrInvalidWebhookSignature)
+
+		// The certificate is downloaded once
+		assert.Equal(t, 1, downloads)
+	})
+
+	t.Run("signed messages from a foreign topic", func(t *testing.T) {
+		service, _ := setupVerifyWebhookTest(t, provider)
+		lookups := 0
+		service.lookupAWSAccountID = func(context.Context, domain.AmazonSESSettings) (string, error) {
+			lookups++
+			return "123456789012", nil
+		}
+		service.fetchSNSCertificate = func(context.Context, string) (*x509.Certificate, error) {
+			return cert, nil
+		}
+
+		// Correctly signed by SNS, but published on a topic of another AWS account or integration
+		for _, topicARN := range []string{
+			"arn:aws:sns:us-east-1:999999999999:notifuse-ses-integration1",
+			"arn:aws:sns:us-east-1:123456789012:notifuse-ses-integration2",
+			"arn:aws:sns:eu-west-1:123456789012:notifuse-ses-integration1",
+		} {
+			for _, messageType := range []string{"Notification", "SubscriptionConfirmation"} {
+				err := service.VerifyWebhook(context.Background(), "workspace1", "integration1", http.Header{}, signedMessage(messageType, topicARN, `{"eventType":"Delivery"}`))
+				assert.ErrorIs(t, err, domain.ErrInvalidWebhookSignature, topicARN)
+			}
+		}
+
+		assert.NoError(t, service.VerifyWebhook(context.Background(), "workspace1", "integration1", http.Header{}, signedMessage("SubscriptionConfirmation", integrationTopicARN, `{"eventType":"Delivery"}`)))
+
+		// The account of the integration is looked up once
+		assert.Equal(t, 1, lookups)
+	})
+
+	t.Run("untrusted certificate URL", func(t *testing.T) {
+		service, _ := setupVerifyWebhookTest(t, provider)
+		service.fetchSNSCertificate = func(context.Context, string) (*x509.Certificate, error) {
+			t.Fatal("an untrusted certificate must not be downloaded")
+			return nil, nil
+		}
+
+		payload := []byte(`{"Type":"Notification","Message":"{}","Signature":"c2ln","SignatureVersion":"2","SigningCertURL":"https://evil.com/cert.pem"}`)
+		err := service.VerifyWebhook(context.Background(), "workspace1", "integration1", http.Header{}, payload)
+		assert.ErrorIs(t, err, domain.ErrInvalidWebhookSignature)
+	})
+
+	t.Run("verification disabled", func(t *testing.T) {
+		service, _ := setupVerifyWebhookTest(t, domain.EmailProvider{
+			Kind: domain.EmailProviderKindSES,
+			SES:  &domain.AmazonSESSettings{Region: "us-east-1"},
+		})
+
+		assert.NoError(t, service.VerifyWebhook(context.Background(), "workspace1", "integration1", http.Header{}, []byte(`{"Type":"Notification"}`)))
+	})
+}
+
+func TestInboundWebhookEventService_VerifyWebhook_NoSecret(t *testing.T) {
+	service, _ := setupVerifyWebhookTest(t, domain.EmailProvider{
+		Kind:    domain.EmailProviderKindMailgun,
+		Mailgun: &domain.MailgunSettings{Domain: "example.com"},
+	})
+
+	// Integrations without a signing key keep accepting unsigned events
+	assert.NoError(t, service.VerifyWebhook(context.Background(), "workspace1", "integration1", http.Header{}, []byte(`{"event-data":{}}`)))
+
+	err := service.VerifyWebhook(context.Background(), "workspace1", "unknown", http.Header{}, []byte(`{}`))
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, domain.ErrInvalidWebhookSignature)
+}
+
+func TestInboundWebhookEventService_VerifyWebhook_WorkspaceError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	service := NewInboundWebhookEventService(nil, nil, pkgmocks.NewMockLogger(ctrl), workspaceRepo, nil, 0)
+	workspaceRepo.EXPECT().GetByID(gomock.Any(), "workspace1").Return(nil, errors.New("not found"))
+
+	err := service.VerifyWebhook(context.Background(), "workspace1", "integration1", http.Header{}, []byte(`{}`))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to get workspace")
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/pkg/logger"
+	"github.com/Notifuse/notifuse/pkg/notifuse_mjml"
+)
+
+const (
+	shortLinkCodeLength  = 8
+	shortLinkMaxAttempts = 3
+)
+
+// errLinkShorteningDisabled makes the compiler keep the full tracking URL
+var errLinkShorteningDisabled = errors.New("link shortening is disabled")
+
+// LinkShortenerService implements domain.LinkShortenerService
+type LinkShortenerService struct {
+	repo          domain.ShortLinkRepository
+	workspaceRepo domain.WorkspaceRepository
+	apiEndpoint   string
+	logger        logger.Logger
+}
+
+// NewLinkShortenerService creates a new link shortener service
+func NewLinkShortenerService(repo domain.ShortLinkRepository, workspaceRepo domain.WorkspaceRepository, apiEndpoint string, logger logger.Logger) *LinkShortenerService {
+	return &LinkShortenerService{
+		repo:          repo,
+		workspaceRepo: workspaceRepo,
+		apiEndpoint:   apiEndpoint,
+		logger:        logger,
+	}
+}
+
+// ShortenerForWorkspace returns the shortener used when compiling messages of the workspace.
+// The workspace settings are only loaded once a link has to be shortened.
+func (s *LinkShortenerService) ShortenerForWorkspace(ctx context.Context, workspaceID string) notifuse_mjml.LinkShortener {
+	return &workspaceLinkShortener{
+		ctx:         ctx,
+		service:     s,
+		workspaceID: workspaceID,
+	}
+}
+
+// ExpandShortLink retrieves the short link stored for a code
+func (s *LinkShortenerService) ExpandShortLink(ctx context.Context, workspaceID string, code string) (*domain.ShortLink, error) {
+	return s.repo.GetByCode(ctx, workspaceID, code)
+}
+
+// baseURL returns the URL short links of the workspace are served from, or an empty
+// string when link shortening is disabled
+func (s *LinkShortenerService) baseURL(ctx context.Context, workspaceID string) (string, error) {
+	workspace, err := s.workspaceRepo.GetByID(ctx, workspaceID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get workspace: %w", err)
+	}
+
+	if !workspace.Settings.LinkShorteningEnabled() {
+		return "", nil
+	}
+
+	// Serve short links from the short domain, falling back to the tracking endpoint
+	if workspace.Settings.LinkShortening.Domain != "" {
+		return workspace.Settings.LinkShortening.Domain, nil
+	}
+	if workspace.Settings.CustomEndpointURL != nil && *workspace.Settings.CustomEndpointURL != "" {
+		return *workspace.Settings.CustomEndpointURL, nil
+	}
+	return s.apiEndpoint, nil
+}
+
+// shorten stores a short link for the destination under a new random code
+func (s *LinkShortenerService) shorten(ctx context.Context, workspaceID, messageID, destinationURL string, sentAt time.Time) (string, error) {
+	for attempt := 0; attempt < shortLinkMaxAttempts; attempt++ {
+		link := &domain.ShortLink{
+			Code:      notifuse_mjml.GenerateNanoID(shortLinkCodeLength),
+			MessageID: messageID,
+			URL:       destinationURL,
+			SentAt:    sentAt,
+			CreatedAt: time.Now().UTC(),
+		}
+
+		created, err := s.repo.Create(ctx, workspaceID, link)
+		if err != nil {
+			return "", err
+		}
+		if created {
+			return link.Code, nil
+		}
+	}
+
+	return "", fmt.Errorf("failed to generate a unique short link code after %d attempts", shortLinkMaxAttempts)
+}
+
+// workspaceLinkShortener binds the shortener to the workspace and request of a compilation
+type workspaceLinkShortener struct {
+	ctx         context.Context
+	service     *LinkShortenerService
+	workspaceID string
+
+	once    sync.Once
+	baseURL string
+	err     error
+}
+
+// ShortenLink implements notifuse_mjml.LinkShortener
+func (w *workspaceLinkShortener) ShortenLink(workspaceID, messageID, destinationURL string, sentTimestamp int64) (string, error) {
+	w.once.Do(func() {
+		w.baseURL, w.err = w.service.baseURL(w.ctx, w.workspaceID)
+	})
+	if w.err != nil {
+		return "", w.err
+	}
+	if w.baseURL == "" {
+		return "", errLinkShorteningDisabled
+	}
+
+	code, err := w.service.shorten(w.ctx, workspaceID, messageID, destinationURL, time.Unix(sentTimestamp, 0).UTC())
+	if err != nil {
+		w.service.logger.WithFields(map[string]interface{}{
+			"workspace_id": workspaceID,
+			"message_id":   messageID,
+			"error":        err.Error(),
+		}).Warn("Failed to create short link, keeping the full tracking URL")
+		return "", err
+	}
+	return domain.ShortLinkURL(w.baseURL, workspaceID, code), nil
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/domain/mocks"
+	pkgmocks "github.com/Notifuse/notifuse/pkg/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupLinkShortenerServiceTest(t *testing.T) (*LinkShortenerService, *mocks.MockShortLinkRepository, *mocks.MockWorkspaceRepository) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockRepo := mocks.NewMockShortLinkRepository(ctrl)
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().Warn(gomock.Any()).AnyTimes()
+
+	return NewLinkShortenerService(mockRepo, mockWorkspaceRepo, "https://api.example.com", mockLogger), mockRepo, mockWorkspaceRepo
+}
+
+func TestLinkShortenerService_ShortenLink(t *testing.T) {
+	ctx := context.Background()
+	sentAt := time.Now().Unix()
+
+	t.Run("stores the destination and loads the workspace once", func(t *testing.T) {
+		svc, mockRepo, mockWorkspaceRepo := setupLinkShortenerServiceTest(t)
+
+		customEndpoint := "https://track.example.com"
+		mockWorkspaceRepo.EXPECT().GetByID(ctx, "ws1").Return(&domain.Workspace{
+			ID: "ws1",
+			Settings: domain.WorkspaceSettings{
+				CustomEndpointURL: &customEndpoint,
+				LinkShortening:    &domain.LinkShorteningSettings{Enabled: true},
+			},
+		}, nil).Times(1)
+
+		var stored []*domain.ShortLink
+		mockRepo.EXPECT().Create(ctx, "ws1", gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, link *domain.ShortLink) (bool, error) {
+				stored = append(stored, link)
+				return true, nil
+			}).Times(2)
+
+		shortener := svc.ShortenerForWorkspace(ctx, "ws1")
+		first, err := shortener.ShortenLink("ws1", "msg1", "https://example.com/a?utm_source=newsletter", sentAt)
+		require.NoError(t, err)
+		_, err = shortener.ShortenLink("ws1", "msg1", "https://example.com/b", sentAt)
+		require.NoError(t, err)
+
+		require.Len(t, stored, 2)
+		// Without a short domain, links are served from the tracking endpoint
+		assert.Equal(t, "https://track.example.com/s/ws1/"+stored[0].Code, first)
+		assert.Len(t, stored[0].Code, shortLinkCodeLength)
+		assert.Equal(t, "msg1", stored[0].MessageID)
+		assert.Equal(t, "https://example.com/a?utm_source=newsletter", stored[0].URL)
+		assert.Equal(t, sentAt, stored[0].SentAt.Unix())
+	})
+
+	t.Run("retries when the code is taken", func(t *testing.T) {
+		svc, mockRepo, mockWorkspaceRepo := setupLinkShortenerServiceTest(t)
+
+		mockWorkspaceRepo.EXPECT().GetByID(ctx, "ws1").Return(&domain.Workspace{
+			ID: "ws1",
+			Settings: domain.WorkspaceSettings{
+				LinkShortening: &domain.LinkShorteningSettings{Enabled: true, Domain: "https://go.example.com"},
+			},
+		}, nil)
+		gomock.InOrder(
+			mockRepo.EXPECT().Create(ctx, "ws1", gomock.Any()).Return(false, nil),
+			mockRepo.EXPECT().Create(ctx, "ws1", gomock.Any()).Return(true, nil),
+		)
+
+		shortURL, err := svc.ShortenerForWorkspace(ctx, "ws1").ShortenLink("ws1", "msg1", "https://example.com", sentAt)
+		require.NoError(t, err)
+		assert.Regexp(t, `^https://go\.example\.com/s/ws1/[a-z0-9]{8}$`, shortURL)
+	})
+
+	t.Run("disabled workspace keeps the full URL", func(t *testing.T) {
+		svc, _, mockWorkspaceRepo := setupLinkShortenerServiceTest(t)
+
+		mockWorkspaceRepo.EXPECT().GetByID(ctx, "ws1").Return(&domain.Workspace{ID: "ws1"}, nil)
+
+		_, err := svc.ShortenerForWorkspace(ctx, "ws1").ShortenLink("ws1", "msg1", "https://example.com", sentAt)
+		assert.ErrorIs(t, err, errLinkShorteningDisabled)
+	})
+
+	t.Run("storage error", func(t *testing.T) {
+		svc, mockRepo, mockWorkspaceRepo := setupLinkShortenerServiceTest(t)
+
+		mockWorkspaceRepo.EXPECT().GetByID(ctx, "ws1").Return(&domain.Workspace{
+			ID: "ws1",
+			Settings: domain.WorkspaceSettings{
+				LinkShortening: &domain.LinkShorteningSettings{Enabled: true},
+			},
+		}, nil)
+		mockRepo.EXPECT().Create(ctx, "ws1", gomock.Any()).Return(false, errors.New("db down"))
+
+		_, err := svc.ShortenerForWorkspace(ctx, "ws1").ShortenLink("ws1", "msg1", "https://example.com", sentAt)
+		assert.Error(t, err)
+	})
+}
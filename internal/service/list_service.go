@@ -213,6 +213,36 @@ func (s *ListService) GetListStats(ctx context.Context, workspaceID string, id s
 	return stats, nil
 }
 
+// GetListGrowthStats retrieves the daily subscribes and unsubscribes of a list, for charting its health
+func (s *ListService) GetListGrowthStats(ctx context.Context, workspaceID string, listID string, tz string, from time.Time, to time.Time) ([]*domain.ListGrowthDay, error) {
+	var err error
+	ctx, _, userWorkspace, err := s.authService.AuthenticateUserForWorkspace(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate user: %w", err)
+	}
+
+	// Check permission for reading lists
+	if !userWorkspace.HasPermission(domain.PermissionResourceLists, domain.PermissionTypeRead) {
+		return nil, domain.NewPermissionError(
+			domain.PermissionResourceLists,
+			domain.PermissionTypeRead,
+			"Insufficient permissions: read access to lists required",
+		)
+	}
+
+	// The list must exist in the workspace
+	if _, err := s.repo.GetListByID(ctx, workspaceID, listID); err != nil {
+		return nil, fmt.Errorf("failed to get list: %w", err)
+	}
+
+	days, err := s.repo.GetGrowthStats(ctx, workspaceID, listID, tz, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get list growth stats: %w", err)
+	}
+
+	return days, nil
+}
+
 // this method is used to subscribe a contact to a list
 // request can come from 3 different sources:
 // 1. API
@@ -361,15 +391,15 @@ func (s *ListService) SubscribeToLists(ctx context.Context, payload *domain.Subs
 		}
 
 		trackingSettings := notifuse_mjml.TrackingSettings{
-			Endpoint:       endpoint,
-			EnableTracking: workspace.Settings.EmailTrackingEnabled,
-			UTMSource:      workspace.Settings.WebsiteURL,
-			UTMMedium:      "email",
-			UTMCampaign:    list.Name,
-			UTMContent:     messageID,
-			WorkspaceID:    workspace.ID,
-			MessageID:      messageID,
+			Endpoint:    endpoint,
+			UTMSource:   workspace.Settings.WebsiteURL,
+			UTMMedium:   "email",
+			UTMCampaign: list.Name,
+			UTMContent:  messageID,
+			WorkspaceID: workspace.ID,
+			MessageID:   messageID,
 		}
+		workspace.Settings.ApplyTrackingDefaults(&trackingSettings)
 
 		req := domain.TemplateDataRequest{
 			WorkspaceID:        workspace.ID,
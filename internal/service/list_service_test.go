@@ -565,6 +565,86 @@ func TestListService_GetListStats(t *testing.T) {
 	})
 }
 
+func TestListService_GetListGrowthStats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockListRepository(ctrl)
+	mockAuthService := mocks.NewMockAuthService(ctrl)
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	mockContactListRepo := mocks.NewMockContactListRepository(ctrl)
+	mockContactRepo := mocks.NewMockContactRepository(ctrl)
+	mockMessageHistoryRepo := mocks.NewMockMessageHistoryRepository(ctrl)
+	mockEmailService := mocks.NewMockEmailServiceInterface(ctrl)
+	mockCache := pkgmocks.NewMockCache(ctrl)
+	apiEndpoint := "https://api.example.com"
+
+	service := NewListService(mockRepo, mockWorkspaceRepo, mockContactListRepo, mockContactRepo, mockMessageHistoryRepo, mockAuthService, mockEmailService, mockLogger, apiEndpoint, mockCache)
+
+	ctx := context.Background()
+	workspaceID := "workspace123"
+	listID := "list123"
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 3, 7, 0, 0, 0, 0, time.UTC)
+	userWorkspace := &domain.UserWorkspace{
+		UserID:      "user123",
+		WorkspaceID: workspaceID,
+		Role:        "member",
+		Permissions: domain.UserPermissions{
+			domain.PermissionResourceLists: {Read: true, Write: false},
+		},
+	}
+
+	t.Run("successful retrieval", func(t *testing.T) {
+		expectedDays := []*domain.ListGrowthDay{{Date: "2026-03-01", Subscribes: 2, Unsubscribes: 1, Net: 1}}
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(gomock.Any(), workspaceID).Return(ctx, &domain.User{}, userWorkspace, nil)
+		mockRepo.EXPECT().GetListByID(ctx, workspaceID, listID).Return(&domain.List{ID: listID}, nil)
+		mockRepo.EXPECT().GetGrowthStats(ctx, workspaceID, listID, "UTC", from, to).Return(expectedDays, nil)
+
+		days, err := service.GetListGrowthStats(ctx, workspaceID, listID, "UTC", from, to)
+		assert.NoError(t, err)
+		assert.Equal(t, expectedDays, days)
+	})
+
+	t.Run("insufficient permissions", func(t *testing.T) {
+		noReadWorkspace := &domain.UserWorkspace{
+			UserID:      "user123",
+			WorkspaceID: workspaceID,
+			Role:        "member",
+			Permissions: domain.UserPermissions{},
+		}
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(gomock.Any(), workspaceID).Return(ctx, &domain.User{}, noReadWorkspace, nil)
+
+		days, err := service.GetListGrowthStats(ctx, workspaceID, listID, "UTC", from, to)
+		assert.Error(t, err)
+		assert.Nil(t, days)
+		var permErr *domain.PermissionError
+		assert.True(t, errors.As(err, &permErr))
+	})
+
+	t.Run("list not found", func(t *testing.T) {
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(gomock.Any(), workspaceID).Return(ctx, &domain.User{}, userWorkspace, nil)
+		mockRepo.EXPECT().GetListByID(ctx, workspaceID, listID).Return(nil, &domain.ErrListNotFound{Message: "list not found"})
+
+		days, err := service.GetListGrowthStats(ctx, workspaceID, listID, "UTC", from, to)
+		assert.Nil(t, days)
+		var notFound *domain.ErrListNotFound
+		assert.True(t, errors.As(err, &notFound))
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(gomock.Any(), workspaceID).Return(ctx, &domain.User{}, userWorkspace, nil)
+		mockRepo.EXPECT().GetListByID(ctx, workspaceID, listID).Return(&domain.List{ID: listID}, nil)
+		mockRepo.EXPECT().GetGrowthStats(ctx, workspaceID, listID, "UTC", from, to).Return(nil, errors.New("db error"))
+
+		days, err := service.GetListGrowthStats(ctx, workspaceID, listID, "UTC", from, to)
+		assert.Error(t, err)
+		assert.Nil(t, days)
+		assert.Contains(t, err.Error(), "failed to get list growth stats")
+	})
+}
+
 func TestListService_SubscribeToLists(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
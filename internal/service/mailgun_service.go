@@ -633,7 +633,11 @@ func (s *MailgunService) sendEmailSimple(ctx context.Context, apiURL string, req
 	form.Add("from", fmt.Sprintf("%s <%s>", request.FromName, request.FromAddress))
 	form.Add("to", request.To)
 	form.Add("subject", request.Subject)
-	form.Add("html", request.Content)
+	if request.PlainTextOnly {
+		form.Add("text", request.Content)
+	} else {
+		form.Add("html", request.Content)
+	}
 
 	// Add cc recipients if provided
 	for _, ccAddress := range request.EmailOptions.CC {
@@ -663,6 +667,11 @@ func (s *MailgunService) sendEmailSimple(ctx context.Context, apiURL string, req
 	// Add messageID as a custom variable for tracking
 	form.Add("v:notifuse_message_id", request.MessageID)
 
+	// Add custom provider tags as custom variables
+	for key, value := range request.EmailOptions.ProviderTags {
+		form.Add("v:"+key, value)
+	}
+
 	// Create the request
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, apiURL, strings.NewReader(form.Encode()))
 	if err != nil {
@@ -707,8 +716,12 @@ func (s *MailgunService) sendEmailWithAttachments(ctx context.Context, apiURL st
 	if err := writer.WriteField("subject", request.Subject); err != nil {
 		return fmt.Errorf("failed to write subject field: %w", err)
 	}
-	if err := writer.WriteField("html", request.Content); err != nil {
-		return fmt.Errorf("failed to write html field: %w", err)
+	bodyField := "html"
+	if request.PlainTextOnly {
+		bodyField = "text"
+	}
+	if err := writer.WriteField(bodyField, request.Content); err != nil {
+		return fmt.Errorf("failed to write %s field: %w", bodyField, err)
 	}
 
 	// Add cc recipients if provided
@@ -751,6 +764,13 @@ func (s *MailgunService) sendEmailWithAttachments(ctx context.Context, apiURL st
 		return fmt.Errorf("failed to write message id field: %w", err)
 	}
 
+	// Add custom provider tags as custom variables
+	for key, value := range request.EmailOptions.ProviderTags {
+		if err := writer.WriteField("v:"+key, value); err != nil {
+			return fmt.Errorf("failed to write provider tag field: %w", err)
+		}
+	}
+
 	// Add attachments
 	for i, att := range request.EmailOptions.Attachments {
 		content, err := att.DecodeContent()
@@ -1435,3 +1435,57 @@ func TestMailgunService_SendEmail(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+func TestMailgunService_SendEmail_ProviderTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHTTPClient := mocks.NewMockHTTPClient(ctrl)
+	mockAuthService := mocks.NewMockAuthService(ctrl)
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+	mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
+
+	service := NewMailgunService(mockHTTPClient, mockAuthService, mockLogger, "https://webhook.example.com")
+
+	mockHTTPClient.EXPECT().
+		Do(gomock.Any()).
+		DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			body, err := io.ReadAll(req.Body)
+			require.NoError(t, err)
+			form, err := url.ParseQuery(string(body))
+			require.NoError(t, err)
+
+			assert.Equal(t, "test-message-id", form.Get("v:notifuse_message_id"))
+			assert.Equal(t, "spring-sale", form.Get("v:campaign"))
+
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(`{"id":"<message-id>","message":"Queued. Thank you."}`)),
+			}, nil
+		})
+
+	request := domain.SendEmailProviderRequest{
+		WorkspaceID:   "workspace-123",
+		IntegrationID: "test-integration-id",
+		MessageID:     "test-message-id",
+		FromAddress:   "sender@example.com",
+		FromName:      "Sender",
+		To:            "recipient@example.com",
+		Subject:       "Subject",
+		Content:       "Content",
+		Provider: &domain.EmailProvider{
+			Kind: domain.EmailProviderKindMailgun,
+			Mailgun: &domain.MailgunSettings{
+				Domain: "example.com",
+				APIKey: "test-api-key",
+				Region: "US",
+			},
+		},
+		EmailOptions: domain.EmailOptions{
+			ProviderTags: map[string]string{"campaign": "spring-sale"},
+		},
+	}
+
+	err := service.SendEmail(context.Background(), request)
+	assert.NoError(t, err)
+}
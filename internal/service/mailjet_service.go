@@ -503,8 +503,9 @@ func (s *MailjetService) SendEmail(ctx context.Context, request domain.SendEmail
 		Cc                 []EmailRecipient           `json:"Cc,omitempty"`
 		Bcc                []EmailRecipient           `json:"Bcc,omitempty"`
 		Subject            string                     `json:"Subject"`
-		HTMLPart           string                     `json:"HTMLPart"`
+		HTMLPart           string                     `json:"HTMLPart,omitempty"`
 		CustomID           string                     `json:"CustomID,omitempty"`
+		EventPayload       string                     `json:"EventPayload,omitempty"`
 		TextPart           string                     `json:"TextPart,omitempty"`
 		TemplateID         int                        `json:"TemplateID,omitempty"`
 		TemplateLanguage   bool                       `json:"TemplateLanguage,omitempty"`
@@ -533,9 +534,22 @@ func (s *MailjetService) SendEmail(ctx context.Context, request domain.SendEmail
 			},
 		},
 		Subject:  request.Subject,
-		HTMLPart: request.Content,
 		CustomID: request.MessageID,
 	}
+	if request.PlainTextOnly {
+		message.TextPart = request.Content
+	} else {
+		message.HTMLPart = request.Content
+	}
+
+	// Add custom provider tags as the event payload, returned by Mailjet in webhook events
+	if len(request.EmailOptions.ProviderTags) > 0 {
+		payload, err := json.Marshal(request.EmailOptions.ProviderTags)
+		if err != nil {
+			return fmt.Errorf("failed to marshal provider tags: %w", err)
+		}
+		message.EventPayload = string(payload)
+	}
 
 	// Add CC recipients if specified
 	if len(request.EmailOptions.CC) > 0 {
@@ -1310,3 +1310,53 @@ func TestMailjetService_SendEmail(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+func TestMailjetService_SendEmail_ProviderTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHTTPClient := mocks.NewMockHTTPClient(ctrl)
+	mockAuthService := mocks.NewMockAuthService(ctrl)
+	service := NewMailjetService(mockHTTPClient, mockAuthService, logger.NewLogger())
+
+	mockHTTPClient.EXPECT().
+		Do(gomock.Any()).
+		DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			body, err := io.ReadAll(req.Body)
+			require.NoError(t, err)
+
+			var emailReq map[string]interface{}
+			require.NoError(t, json.Unmarshal(body, &emailReq))
+			message := emailReq["Messages"].([]interface{})[0].(map[string]interface{})
+			assert.Equal(t, "message-123", message["CustomID"])
+			assert.JSONEq(t, `{"campaign":"spring-sale"}`, message["EventPayload"].(string))
+
+			return mockHTTPResponse(t, http.StatusOK, map[string]interface{}{
+				"Messages": []map[string]interface{}{{"Status": "success", "CustomID": "message-123"}},
+			}), nil
+		})
+
+	request := domain.SendEmailProviderRequest{
+		WorkspaceID:   "workspace-123",
+		IntegrationID: "test-integration-id",
+		MessageID:     "message-123",
+		FromAddress:   "sender@example.com",
+		FromName:      "Sender",
+		To:            "recipient@example.com",
+		Subject:       "Subject",
+		Content:       "Content",
+		Provider: &domain.EmailProvider{
+			Kind: domain.EmailProviderKindMailjet,
+			Mailjet: &domain.MailjetSettings{
+				APIKey:    "test-api-key",
+				SecretKey: "test-secret-key",
+			},
+		},
+		EmailOptions: domain.EmailOptions{
+			ProviderTags: map[string]string{"campaign": "spring-sale"},
+		},
+	}
+
+	err := service.SendEmail(context.Background(), request)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/pkg/logger"
+)
+
+// MessageHistoryPruneProcessor handles the execution of message history pruning tasks.
+// Messages created before the cutoff of the task are deleted in bounded batches, so that
+// large workspaces never hold long locks on message_history, and the deleted count is saved
+// after each batch for the task to resume where it stopped.
+type MessageHistoryPruneProcessor struct {
+	messageHistoryRepo domain.MessageHistoryRepository
+	taskRepo           domain.TaskRepository
+	logger             logger.Logger
+	batchSize          int // Number of messages to delete per batch
+}
+
+// NewMessageHistoryPruneProcessor creates a new message history prune processor
+func NewMessageHistoryPruneProcessor(
+	messageHistoryRepo domain.MessageHistoryRepository,
+	taskRepo domain.TaskRepository,
+	logger logger.Logger,
+) *MessageHistoryPruneProcessor {
+	return &MessageHistoryPruneProcessor{
+		messageHistoryRepo: messageHistoryRepo,
+		taskRepo:           taskRepo,
+		logger:             logger,
+		batchSize:          1000,
+	}
+}
+
+// CanProcess returns whether this processor can handle the given task type
+func (p *MessageHistoryPruneProcessor) CanProcess(taskType string) bool {
+	return taskType == "prune_message_history"
+}
+
+// Process executes or continues a message history pruning task
+func (p *MessageHistoryPruneProcessor) Process(ctx context.Context, task *domain.Task, timeoutAt time.Time) (completed bool, err error) {
+	p.logger.WithFields(map[string]interface{}{
+		"task_id":      task.ID,
+		"workspace_id": task.WorkspaceID,
+		"type":         task.Type,
+	}).Info("Processing message history prune task")
+
+	if task.State == nil || task.State.PruneMessageHistory == nil {
+		return false, fmt.Errorf("task state missing PruneMessageHistory data - task may not have been properly initialized")
+	}
+	state := task.State.PruneMessageHistory
+
+	if err := state.Validate(); err != nil {
+		return false, fmt.Errorf("invalid prune state: %w", err)
+	}
+
+	if state.BatchSize == 0 {
+		state.BatchSize = p.batchSize
+	}
+
+	if state.StartedAt == "" {
+		state.StartedAt = time.Now().UTC().Format(time.RFC3339)
+	}
+
+	for {
+		// Check if we're approaching timeout
+		if time.Now().Add(5 * time.Second).After(timeoutAt) {
+			p.logger.Info("Approaching timeout, pausing message history prune")
+			if err := p.saveProgress(ctx, task, state); err != nil {
+				return false, fmt.Errorf("failed to save progress: %w", err)
+			}
+			return false, nil
+		}
+
+		deleted, err := p.messageHistoryRepo.PruneOlderThan(ctx, task.WorkspaceID, state.Cutoff, state.KeepStatuses, state.BatchSize)
+		if err != nil {
+			return false, fmt.Errorf("failed to prune message history: %w", err)
+		}
+
+		state.DeletedCount += deleted
+
+		if err := p.saveProgress(ctx, task, state); err != nil {
+			p.logger.WithField("error", err.Error()).Warn("Failed to save progress (non-fatal)")
+		}
+
+		// A batch that is not full leaves no message to prune
+		if deleted < int64(state.BatchSize) {
+			break
+		}
+	}
+
+	task.Progress = 1
+	p.logger.WithFields(map[string]interface{}{
+		"task_id":       task.ID,
+		"workspace_id":  task.WorkspaceID,
+		"cutoff":        state.Cutoff.Format(time.RFC3339),
+		"keep_statuses": state.KeepStatuses,
+		"deleted_count": state.DeletedCount,
+	}).Info("Message history prune completed")
+
+	return true, nil
+}
+
+// saveProgress saves the current progress of the message history prune
+func (p *MessageHistoryPruneProcessor) saveProgress(ctx context.Context, task *domain.Task, state *domain.PruneMessageHistoryState) error {
+	task.State.Message = fmt.Sprintf("Pruning message history: %d messages deleted", state.DeletedCount)
+
+	if err := p.taskRepo.SaveState(ctx, task.WorkspaceID, task.ID, task.Progress, task.State); err != nil {
+		return fmt.Errorf("failed to save task state: %w", err)
+	}
+
+	return nil
+}
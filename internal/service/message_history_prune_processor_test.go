@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/domain/mocks"
+	pkgmocks "github.com/Notifuse/notifuse/pkg/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupMessageHistoryPruneProcessorTest(t *testing.T) (*MessageHistoryPruneProcessor, *mocks.MockMessageHistoryRepository, *mocks.MockTaskRepository) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	mockMessageHistoryRepo := mocks.NewMockMessageHistoryRepository(ctrl)
+	mockTaskRepo := mocks.NewMockTaskRepository(ctrl)
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().Info(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Warn(gomock.Any()).AnyTimes()
+
+	processor := NewMessageHistoryPruneProcessor(mockMessageHistoryRepo, mockTaskRepo, mockLogger)
+	return processor, mockMessageHistoryRepo, mockTaskRepo
+}
+
+func pruneMessageHistoryTask(state *domain.PruneMessageHistoryState) *domain.Task {
+	return &domain.Task{
+		ID:          "task1",
+		WorkspaceID: "workspace1",
+		Type:        "prune_message_history",
+		State:       &domain.TaskState{PruneMessageHistory: state},
+	}
+}
+
+func TestMessageHistoryPruneProcessor_CanProcess(t *testing.T) {
+	processor, _, _ := setupMessageHistoryPruneProcessorTest(t)
+
+	assert.True(t, processor.CanProcess("prune_message_history"))
+	assert.False(t, processor.CanProcess("recompute_segment"))
+}
+
+func TestMessageHistoryPruneProcessor_Process_InvalidState(t *testing.T) {
+	processor, _, _ := setupMessageHistoryPruneProcessorTest(t)
+
+	completed, err := processor.Process(context.Background(), pruneMessageHistoryTask(nil), time.Now().Add(time.Minute))
+	assert.False(t, completed)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "missing PruneMessageHistory data")
+
+	completed, err = processor.Process(context.Background(), pruneMessageHistoryTask(&domain.PruneMessageHistoryState{}), time.Now().Add(time.Minute))
+	assert.False(t, completed)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cutoff is required")
+
+	completed, err = processor.Process(context.Background(), pruneMessageHistoryTask(&domain.PruneMessageHistoryState{
+		Cutoff:       time.Now().AddDate(0, -6, 0),
+		KeepStatuses: []domain.MessageEvent{domain.MessageEventSent},
+	}), time.Now().Add(time.Minute))
+	assert.False(t, completed)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid keep status: sent")
+}
+
+func TestMessageHistoryPruneProcessor_Process_DeletesInBatches(t *testing.T) {
+	processor, messageHistoryRepo, taskRepo := setupMessageHistoryPruneProcessorTest(t)
+	ctx := context.Background()
+
+	cutoff := time.Now().AddDate(0, -6, 0)
+	keepStatuses := []domain.MessageEvent{domain.MessageEventComplained}
+	task := pruneMessageHistoryTask(&domain.PruneMessageHistoryState{
+		Cutoff:       cutoff,
+		KeepStatuses: keepStatuses,
+		BatchSize:    2,
+	})
+
+	// Full batches are followed by another one until a batch is not full
+	gomock.InOrder(
+		messageHistoryRepo.EXPECT().PruneOlderThan(ctx, "workspace1", cutoff, keepStatuses, 2).Return(int64(2), nil),
+		messageHistoryRepo.EXPECT().PruneOlderThan(ctx, "workspace1", cutoff, keepStatuses, 2).Return(int64(2), nil),
+		messageHistoryRepo.EXPECT().PruneOlderThan(ctx, "workspace1", cutoff, keepStatuses, 2).Return(int64(1), nil),
+	)
+	var savedCounts []int64
+	taskRepo.EXPECT().SaveState(ctx, "workspace1", "task1", gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, _, _ string, _ float64, state *domain.TaskState) error {
+			savedCounts = append(savedCounts, state.PruneMessageHistory.DeletedCount)
+			return nil
+		}).Times(3)
+
+	completed, err := processor.Process(ctx, task, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.True(t, completed)
+	assert.Equal(t, []int64{2, 4, 5}, savedCounts)
+	assert.Equal(t, int64(5), task.State.PruneMessageHistory.DeletedCount)
+	assert.Equal(t, "Pruning message history: 5 messages deleted", task.State.Message)
+	assert.NotEmpty(t, task.State.PruneMessageHistory.StartedAt)
+}
+
+func TestMessageHistoryPruneProcessor_Process_DefaultBatchSize(t *testing.T) {
+	processor, messageHistoryRepo, taskRepo := setupMessageHistoryPruneProcessorTest(t)
+	ctx := context.Background()
+
+	task := pruneMessageHistoryTask(&domain.PruneMessageHistoryState{Cutoff: time.Now().AddDate(-1, 0, 0)})
+
+	messageHistoryRepo.EXPECT().PruneOlderThan(ctx, "workspace1", gomock.Any(), gomock.Nil(), 1000).Return(int64(0), nil)
+	taskRepo.EXPECT().SaveState(ctx, "workspace1", "task1", gomock.Any(), gomock.Any()).Return(nil)
+
+	completed, err := processor.Process(ctx, task, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.True(t, completed)
+	assert.Equal(t, 1000, task.State.PruneMessageHistory.BatchSize)
+}
+
+func TestMessageHistoryPruneProcessor_Process_ResumesAfterTimeout(t *testing.T) {
+	processor, messageHistoryRepo, taskRepo := setupMessageHistoryPruneProcessorTest(t)
+	ctx := context.Background()
+
+	task := pruneMessageHistoryTask(&domain.PruneMessageHistoryState{
+		Cutoff:       time.Now().AddDate(0, -1, 0),
+		BatchSize:    10,
+		DeletedCount: 30,
+	})
+
+	// Too close to the timeout to delete a batch: the progress is saved and the task paused
+	taskRepo.EXPECT().SaveState(ctx, "workspace1", "task1", gomock.Any(), gomock.Any()).Return(nil)
+
+	completed, err := processor.Process(ctx, task, time.Now().Add(time.Second))
+	require.NoError(t, err)
+	assert.False(t, completed)
+	assert.Equal(t, int64(30), task.State.PruneMessageHistory.DeletedCount)
+
+	// The next run adds to the count deleted by the previous ones
+	messageHistoryRepo.EXPECT().PruneOlderThan(ctx, "workspace1", gomock.Any(), gomock.Any(), 10).Return(int64(4), nil)
+	taskRepo.EXPECT().SaveState(ctx, "workspace1", "task1", gomock.Any(), gomock.Any()).Return(nil)
+
+	completed, err = processor.Process(ctx, task, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.True(t, completed)
+	assert.Equal(t, int64(34), task.State.PruneMessageHistory.DeletedCount)
+}
+
+func TestMessageHistoryPruneProcessor_Process_RepositoryError(t *testing.T) {
+	processor, messageHistoryRepo, _ := setupMessageHistoryPruneProcessorTest(t)
+	ctx := context.Background()
+
+	task := pruneMessageHistoryTask(&domain.PruneMessageHistoryState{Cutoff: time.Now().AddDate(0, -1, 0)})
+	messageHistoryRepo.EXPECT().PruneOlderThan(ctx, "workspace1", gomock.Any(), gomock.Any(), 1000).Return(int64(0), errors.New("lock timeout"))
+
+	completed, err := processor.Process(ctx, task, time.Now().Add(time.Minute))
+	assert.False(t, completed)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to prune message history")
+}
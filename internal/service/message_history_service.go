@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 
 	"github.com/Notifuse/notifuse/internal/domain"
 	"github.com/Notifuse/notifuse/pkg/logger"
@@ -76,6 +77,43 @@ func (s *MessageHistoryService) ListMessages(ctx context.Context, workspaceID st
 	}, nil
 }
 
+// ExportMessages streams the messages matching the filters to w as CSV
+func (s *MessageHistoryService) ExportMessages(ctx context.Context, workspaceID string, params domain.MessageListParams, w io.Writer) error {
+	// codecov:ignore:start
+	ctx, span := tracing.StartServiceSpan(ctx, "MessageHistoryService", "ExportMessages")
+	defer tracing.EndSpan(span, nil)
+	tracing.AddAttribute(ctx, "workspaceID", workspaceID)
+	// codecov:ignore:end
+
+	ctx, _, userWorkspace, err := s.authService.AuthenticateUserForWorkspace(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to authenticate user: %w", err)
+	}
+
+	if !userWorkspace.HasPermission(domain.PermissionResourceMessageHistory, domain.PermissionTypeRead) {
+		return domain.NewPermissionError(
+			domain.PermissionResourceMessageHistory,
+			domain.PermissionTypeRead,
+			"Insufficient permissions: read access to message history required",
+		)
+	}
+
+	workspace, err := s.workspaceRepo.GetByID(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get workspace: %w", err)
+	}
+
+	if err := s.repo.ExportMessages(ctx, workspaceID, workspace.Settings.SecretKey, params, w); err != nil {
+		// codecov:ignore:start
+		s.logger.Error(fmt.Sprintf("Failed to export messages: %v", err))
+		tracing.MarkSpanError(ctx, err)
+		// codecov:ignore:end
+		return err
+	}
+
+	return nil
+}
+
 func (s *MessageHistoryService) GetBroadcastStats(ctx context.Context, workspaceID string, id string) (*domain.MessageHistoryStatusSum, error) {
 	var err error
 	ctx, _, userWorkspace, err := s.authService.AuthenticateUserForWorkspace(ctx, workspaceID)
@@ -100,6 +138,40 @@ func (s *MessageHistoryService) GetBroadcastStats(ctx context.Context, workspace
 	return stats, nil
 }
 
+// GetBroadcastStatsByDay retrieves statistics for a broadcast per day, in the given timezone
+// or the workspace timezone when empty
+func (s *MessageHistoryService) GetBroadcastStatsByDay(ctx context.Context, workspaceID, broadcastID, tz string) ([]*domain.MessageHistoryDailyStatusSum, error) {
+	var err error
+	ctx, _, userWorkspace, err := s.authService.AuthenticateUserForWorkspace(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate user: %w", err)
+	}
+
+	// Check permission for reading message history
+	if !userWorkspace.HasPermission(domain.PermissionResourceMessageHistory, domain.PermissionTypeRead) {
+		return nil, domain.NewPermissionError(
+			domain.PermissionResourceMessageHistory,
+			domain.PermissionTypeRead,
+			"Insufficient permissions: read access to message history required",
+		)
+	}
+
+	if tz == "" {
+		workspace, err := s.workspaceRepo.GetByID(ctx, workspaceID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get workspace: %w", err)
+		}
+		tz = workspace.Settings.Timezone
+	}
+
+	days, err := s.repo.GetBroadcastStatsByDay(ctx, workspaceID, broadcastID, tz)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get broadcast stats by day: %w", err)
+	}
+
+	return days, nil
+}
+
 // GetBroadcastVariationStats retrieves statistics for a specific variation of a broadcast
 func (s *MessageHistoryService) GetBroadcastVariationStats(ctx context.Context, workspaceID, broadcastID, templateID string) (*domain.MessageHistoryStatusSum, error) {
 	var err error
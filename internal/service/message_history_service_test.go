@@ -1,8 +1,10 @@
 package service
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"io"
 	"testing"
 	"time"
 
@@ -514,6 +516,75 @@ func TestMessageHistoryService_ListMessages(t *testing.T) {
 	}
 }
 
+func TestMessageHistoryService_ExportMessages(t *testing.T) {
+	setup := func(t *testing.T, permissions domain.UserPermissions) (*MessageHistoryService, *mocks.MockMessageHistoryRepository, *mocks.MockWorkspaceRepository) {
+		ctrl := gomock.NewController(t)
+		mockRepo := mocks.NewMockMessageHistoryRepository(ctrl)
+		mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+		mockLogger := pkgmocks.NewMockLogger(ctrl)
+		mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
+		mockAuthService := mocks.NewMockAuthService(ctrl)
+		mockAuthService.EXPECT().
+			AuthenticateUserForWorkspace(gomock.Any(), "workspace-123").
+			Return(context.Background(), &domain.User{}, &domain.UserWorkspace{
+				UserID:      "user123",
+				WorkspaceID: "workspace-123",
+				Role:        "member",
+				Permissions: permissions,
+			}, nil)
+
+		return NewMessageHistoryService(mockRepo, mockWorkspaceRepo, mockLogger, mockAuthService), mockRepo, mockWorkspaceRepo
+	}
+
+	t.Run("Streams the export with the workspace secret key", func(t *testing.T) {
+		service, mockRepo, mockWorkspaceRepo := setup(t, domain.UserPermissions{
+			domain.PermissionResourceMessageHistory: {Read: true},
+		})
+		params := domain.MessageListParams{Channel: "email"}
+		var buf bytes.Buffer
+
+		mockWorkspaceRepo.EXPECT().
+			GetByID(gomock.Any(), "workspace-123").
+			Return(&domain.Workspace{ID: "workspace-123", Settings: domain.WorkspaceSettings{SecretKey: "test-secret"}}, nil)
+		mockRepo.EXPECT().
+			ExportMessages(gomock.Any(), "workspace-123", "test-secret", params, &buf).
+			DoAndReturn(func(_ context.Context, _, _ string, _ domain.MessageListParams, w io.Writer) error {
+				_, err := w.Write([]byte("id\n"))
+				return err
+			})
+
+		err := service.ExportMessages(context.Background(), "workspace-123", params, &buf)
+		assert.NoError(t, err)
+		assert.Equal(t, "id\n", buf.String())
+	})
+
+	t.Run("Requires read access to message history", func(t *testing.T) {
+		service, _, _ := setup(t, domain.UserPermissions{
+			domain.PermissionResourceMessageHistory: {Read: false},
+		})
+
+		err := service.ExportMessages(context.Background(), "workspace-123", domain.MessageListParams{}, &bytes.Buffer{})
+		var permissionErr *domain.PermissionError
+		assert.True(t, errors.As(err, &permissionErr))
+	})
+
+	t.Run("Repository errors are returned", func(t *testing.T) {
+		service, mockRepo, mockWorkspaceRepo := setup(t, domain.UserPermissions{
+			domain.PermissionResourceMessageHistory: {Read: true},
+		})
+
+		mockWorkspaceRepo.EXPECT().
+			GetByID(gomock.Any(), "workspace-123").
+			Return(&domain.Workspace{ID: "workspace-123", Settings: domain.WorkspaceSettings{SecretKey: "test-secret"}}, nil)
+		mockRepo.EXPECT().
+			ExportMessages(gomock.Any(), "workspace-123", "test-secret", gomock.Any(), gomock.Any()).
+			Return(errors.New("db error"))
+
+		err := service.ExportMessages(context.Background(), "workspace-123", domain.MessageListParams{}, &bytes.Buffer{})
+		assert.EqualError(t, err, "db error")
+	})
+}
+
 func TestMessageHistoryService_GetBroadcastStats(t *testing.T) {
 	testCases := []struct {
 		name          string
@@ -632,6 +703,96 @@ func TestMessageHistoryService_GetBroadcastStats(t *testing.T) {
 	}
 }
 
+func TestMessageHistoryService_GetBroadcastStatsByDay(t *testing.T) {
+	userWorkspace := &domain.UserWorkspace{
+		UserID:      "user123",
+		WorkspaceID: "workspace-123",
+		Role:        "member",
+		Permissions: domain.UserPermissions{
+			domain.PermissionResourceMessageHistory: {Read: true, Write: false},
+		},
+	}
+	days := []*domain.MessageHistoryDailyStatusSum{
+		{Date: "2026-03-01", MessageHistoryStatusSum: domain.MessageHistoryStatusSum{TotalSent: 100, TotalOpened: 40}},
+		{Date: "2026-03-02", MessageHistoryStatusSum: domain.MessageHistoryStatusSum{TotalOpened: 15}},
+	}
+
+	setup := func(t *testing.T) (*MessageHistoryService, *mocks.MockMessageHistoryRepository, *mocks.MockWorkspaceRepository, *mocks.MockAuthService) {
+		ctrl := gomock.NewController(t)
+		t.Cleanup(ctrl.Finish)
+
+		mockRepo := mocks.NewMockMessageHistoryRepository(ctrl)
+		mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+		mockAuthService := mocks.NewMockAuthService(ctrl)
+		service := NewMessageHistoryService(mockRepo, mockWorkspaceRepo, pkgmocks.NewMockLogger(ctrl), mockAuthService)
+		return service, mockRepo, mockWorkspaceRepo, mockAuthService
+	}
+
+	t.Run("uses the requested timezone", func(t *testing.T) {
+		service, mockRepo, _, mockAuthService := setup(t)
+		mockAuthService.EXPECT().
+			AuthenticateUserForWorkspace(gomock.Any(), "workspace-123").
+			Return(context.Background(), &domain.User{}, userWorkspace, nil)
+		mockRepo.EXPECT().
+			GetBroadcastStatsByDay(gomock.Any(), "workspace-123", "broadcast-123", "Asia/Tokyo").
+			Return(days, nil)
+
+		result, err := service.GetBroadcastStatsByDay(context.Background(), "workspace-123", "broadcast-123", "Asia/Tokyo")
+		assert.NoError(t, err)
+		assert.Equal(t, days, result)
+	})
+
+	t.Run("defaults to the workspace timezone", func(t *testing.T) {
+		service, mockRepo, mockWorkspaceRepo, mockAuthService := setup(t)
+		mockAuthService.EXPECT().
+			AuthenticateUserForWorkspace(gomock.Any(), "workspace-123").
+			Return(context.Background(), &domain.User{}, userWorkspace, nil)
+		mockWorkspaceRepo.EXPECT().
+			GetByID(gomock.Any(), "workspace-123").
+			Return(&domain.Workspace{ID: "workspace-123", Settings: domain.WorkspaceSettings{Timezone: "Europe/Paris"}}, nil)
+		mockRepo.EXPECT().
+			GetBroadcastStatsByDay(gomock.Any(), "workspace-123", "broadcast-123", "Europe/Paris").
+			Return(days, nil)
+
+		result, err := service.GetBroadcastStatsByDay(context.Background(), "workspace-123", "broadcast-123", "")
+		assert.NoError(t, err)
+		assert.Equal(t, days, result)
+	})
+
+	t.Run("permission denied", func(t *testing.T) {
+		service, _, _, mockAuthService := setup(t)
+		mockAuthService.EXPECT().
+			AuthenticateUserForWorkspace(gomock.Any(), "workspace-123").
+			Return(context.Background(), &domain.User{}, &domain.UserWorkspace{
+				UserID:      "user123",
+				WorkspaceID: "workspace-123",
+				Role:        "member",
+				Permissions: domain.UserPermissions{},
+			}, nil)
+
+		result, err := service.GetBroadcastStatsByDay(context.Background(), "workspace-123", "broadcast-123", "UTC")
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		var permErr *domain.PermissionError
+		assert.True(t, errors.As(err, &permErr))
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		service, mockRepo, _, mockAuthService := setup(t)
+		mockAuthService.EXPECT().
+			AuthenticateUserForWorkspace(gomock.Any(), "workspace-123").
+			Return(context.Background(), &domain.User{}, userWorkspace, nil)
+		mockRepo.EXPECT().
+			GetBroadcastStatsByDay(gomock.Any(), "workspace-123", "broadcast-123", "UTC").
+			Return(nil, errors.New("database error"))
+
+		result, err := service.GetBroadcastStatsByDay(context.Background(), "workspace-123", "broadcast-123", "UTC")
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		assert.Equal(t, "failed to get broadcast stats by day: database error", err.Error())
+	})
+}
+
 func TestMessageHistoryService_GetBroadcastVariationStats(t *testing.T) {
 	testCases := []struct {
 		name          string
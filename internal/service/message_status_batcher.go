@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/pkg/logger"
+)
+
+// messageStatusBatch is a set of message status updates queued for one workspace
+type messageStatusBatch struct {
+	workspaceID string
+	updates     []domain.MessageEventUpdate
+}
+
+// MessageStatusBatcher applies message status updates coming from provider webhooks through a
+// bounded in-memory queue. Workers merge the queued updates of a workspace and write them with a
+// single SetStatusesIfNotSet call, which groups them by event type.
+type MessageStatusBatcher struct {
+	repo          domain.MessageHistoryRepository
+	retryRepo     domain.MessageStatusRetryRepository
+	statsCache    *BroadcastStatsCache
+	logger        logger.Logger
+	queue         chan messageStatusBatch
+	workers       int
+	batchSize     int
+	flushInterval time.Duration
+
+	mu      sync.RWMutex
+	stopped bool
+	wg      sync.WaitGroup
+}
+
+// NewMessageStatusBatcher creates a new MessageStatusBatcher, Start must be called to run its workers
+func NewMessageStatusBatcher(
+	repo domain.MessageHistoryRepository,
+	logger logger.Logger,
+	workers int,
+	queueSize int,
+	batchSize int,
+	flushInterval time.Duration,
+) *MessageStatusBatcher {
+	if workers < 1 {
+		workers = 1
+	}
+	if queueSize < 1 {
+		queueSize = 1
+	}
+	if batchSize < 1 {
+		batchSize = 1
+	}
+	if flushInterval <= 0 {
+		flushInterval = 200 * time.Millisecond
+	}
+
+	return &MessageStatusBatcher{
+		repo:          repo,
+		logger:        logger,
+		queue:         make(chan messageStatusBatch, queueSize),
+		workers:       workers,
+		batchSize:     batchSize,
+		flushInterval: flushInterval,
+	}
+}
+
+// SetBroadcastStatsCache makes the workers invalidate the cached broadcast stats of the workspaces they update
+func (b *MessageStatusBatcher) SetBroadcastStatsCache(cache *BroadcastStatsCache) {
+	b.statsCache = cache
+}
+
+// SetRetryRepository makes the workers queue the updates that failed to apply for the retry worker
+func (b *MessageStatusBatcher) SetRetryRepository(retryRepo domain.MessageStatusRetryRepository) {
+	b.retryRepo = retryRepo
+}
+
+// Start starts the workers
+func (b *MessageStatusBatcher) Start() {
+	for i := 0; i < b.workers; i++ {
+		b.wg.Add(1)
+		go b.run()
+	}
+	b.logger.WithField("workers", b.workers).
+		WithField("queue_size", cap(b.queue)).
+		Info("Message status batcher started")
+}
+
+// Stop stops accepting updates and waits for the workers to write the queued ones
+func (b *MessageStatusBatcher) Stop() {
+	b.mu.Lock()
+	if b.stopped {
+		b.mu.Unlock()
+		return
+	}
+	b.stopped = true
+	close(b.queue)
+	b.mu.Unlock()
+
+	b.wg.Wait()
+	b.logger.Info("Message status batcher stopped")
+}
+
+// Full reports whether the queue has no room left
+func (b *MessageStatusBatcher) Full() bool {
+	return len(b.queue) >= cap(b.queue)
+}
+
+// Enqueue queues the updates of a workspace without blocking.
+// It returns domain.ErrIngestionQueueFull when the queue is full or the batcher is stopped.
+func (b *MessageStatusBatcher) Enqueue(workspaceID string, updates []domain.MessageEventUpdate) error {
+	if len(updates) == 0 {
+		return nil
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if b.stopped {
+		return domain.ErrIngestionQueueFull
+	}
+
+	select {
+	case b.queue <- messageStatusBatch{workspaceID: workspaceID, updates: updates}:
+		return nil
+	default:
+		return domain.ErrIngestionQueueFull
+	}
+}
+
+// run merges queued updates per workspace and flushes them when a workspace reaches the batch
+// size, when the flush interval elapses, and when the queue is closed
+func (b *MessageStatusBatcher) run() {
+	defer b.wg.Done()
+
+	ticker := time.NewTicker(b.flushInterval)
+	defer ticker.Stop()
+
+	pending := make(map[string][]domain.MessageEventUpdate)
+
+	for {
+		select {
+		case batch, ok := <-b.queue:
+			if !ok {
+				for workspaceID, updates := range pending {
+					b.flush(workspaceID, updates)
+				}
+				return
+			}
+			pending[batch.workspaceID] = append(pending[batch.workspaceID], batch.updates...)
+			if len(pending[batch.workspaceID]) >= b.batchSize {
+				b.flush(batch.workspaceID, pending[batch.workspaceID])
+				delete(pending, batch.workspaceID)
+			}
+		case <-ticker.C:
+			for workspaceID, updates := range pending {
+				b.flush(workspaceID, updates)
+				delete(pending, workspaceID)
+			}
+		}
+	}
+}
+
+// flush writes the updates of a workspace. Failed updates are queued for the retry worker when a
+// retry repository is set, and logged otherwise: the raw payloads can be reprocessed to apply them again.
+func (b *MessageStatusBatcher) flush(workspaceID string, updates []domain.MessageEventUpdate) {
+	ctx := context.Background()
+	if err := b.repo.SetStatusesIfNotSet(ctx, workspaceID, updates); err != nil {
+		if queueStatusRetry(ctx, b.retryRepo, b.logger, workspaceID, updates, err) {
+			return
+		}
+		b.logger.WithField("workspace_id", workspaceID).
+			WithField("updates", len(updates)).
+			WithField("error", err.Error()).
+			Error("Failed to apply batched message status updates")
+		return
+	}
+	b.statsCache.InvalidateWorkspace(workspaceID)
+}
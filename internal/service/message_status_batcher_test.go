@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/domain/mocks"
+	pkgmocks "github.com/Notifuse/notifuse/pkg/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupMessageStatusBatcherTest(t *testing.T) (*mocks.MockMessageHistoryRepository, *pkgmocks.MockLogger) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	repo := mocks.NewMockMessageHistoryRepository(ctrl)
+	log := pkgmocks.NewMockLogger(ctrl)
+	log.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(log).AnyTimes()
+	log.EXPECT().Info(gomock.Any()).AnyTimes()
+	log.EXPECT().Error(gomock.Any()).AnyTimes()
+
+	return repo, log
+}
+
+func statusUpdate(id string, event domain.MessageEvent) domain.MessageEventUpdate {
+	return domain.MessageEventUpdate{ID: id, Event: event, Timestamp: time.Now()}
+}
+
+func TestMessageStatusBatcher_BatchesBurst(t *testing.T) {
+	repo, log := setupMessageStatusBatcherTest(t)
+
+	var mu sync.Mutex
+	calls := map[string][]int{}
+	repo.EXPECT().SetStatusesIfNotSet(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, workspaceID string, updates []domain.MessageEventUpdate) error {
+			mu.Lock()
+			defer mu.Unlock()
+			calls[workspaceID] = append(calls[workspaceID], len(updates))
+			return nil
+		}).AnyTimes()
+
+	// A long flush interval leaves the batch size and Stop as the only flush triggers
+	batcher := NewMessageStatusBatcher(repo, log, 1, 100, 25, time.Hour)
+	batcher.Start()
+
+	// A burst of 60 webhooks with one update each, opens and clicks mixed
+	for i := 0; i < 60; i++ {
+		event := domain.MessageEventOpened
+		if i%2 == 0 {
+			event = domain.MessageEventClicked
+		}
+		require.NoError(t, batcher.Enqueue("workspace1", []domain.MessageEventUpdate{statusUpdate(fmt.Sprintf("msg-%d", i), event)}))
+	}
+	require.NoError(t, batcher.Enqueue("workspace2", []domain.MessageEventUpdate{statusUpdate("other", domain.MessageEventDelivered)}))
+
+	batcher.Stop()
+
+	assert.Equal(t, []int{25, 25, 10}, calls["workspace1"], "60 webhooks should be written in 3 calls")
+	assert.Equal(t, []int{1}, calls["workspace2"])
+}
+
+func TestMessageStatusBatcher_FlushesOnInterval(t *testing.T) {
+	repo, log := setupMessageStatusBatcherTest(t)
+
+	flushed := make(chan int, 1)
+	repo.EXPECT().SetStatusesIfNotSet(gomock.Any(), "workspace1", gomock.Any()).DoAndReturn(
+		func(_ context.Context, _ string, updates []domain.MessageEventUpdate) error {
+			flushed <- len(updates)
+			return nil
+		})
+
+	batcher := NewMessageStatusBatcher(repo, log, 1, 100, 500, 10*time.Millisecond)
+	batcher.Start()
+	defer batcher.Stop()
+
+	require.NoError(t, batcher.Enqueue("workspace1", []domain.MessageEventUpdate{
+		statusUpdate("msg-1", domain.MessageEventDelivered),
+		statusUpdate("msg-2", domain.MessageEventDelivered),
+	}))
+
+	select {
+	case n := <-flushed:
+		assert.Equal(t, 2, n)
+	case <-time.After(time.Second):
+		t.Fatal("updates were not flushed after the flush interval")
+	}
+}
+
+func TestMessageStatusBatcher_Overflow(t *testing.T) {
+	repo, log := setupMessageStatusBatcherTest(t)
+
+	// Workers are not started so the queue cannot drain
+	batcher := NewMessageStatusBatcher(repo, log, 1, 2, 500, time.Hour)
+	updates := []domain.MessageEventUpdate{statusUpdate("msg-1", domain.MessageEventOpened)}
+
+	require.NoError(t, batcher.Enqueue("workspace1", updates))
+	assert.False(t, batcher.Full())
+	require.NoError(t, batcher.Enqueue("workspace1", updates))
+	assert.True(t, batcher.Full())
+
+	err := batcher.Enqueue("workspace1", updates)
+	assert.True(t, errors.Is(err, domain.ErrIngestionQueueFull))
+
+	// Empty updates never take a slot
+	assert.NoError(t, batcher.Enqueue("workspace1", nil))
+}
+
+func TestMessageStatusBatcher_RejectsAfterStop(t *testing.T) {
+	repo, log := setupMessageStatusBatcherTest(t)
+
+	batcher := NewMessageStatusBatcher(repo, log, 2, 10, 500, time.Hour)
+	batcher.Start()
+	batcher.Stop()
+	batcher.Stop()
+
+	err := batcher.Enqueue("workspace1", []domain.MessageEventUpdate{statusUpdate("msg-1", domain.MessageEventOpened)})
+	assert.True(t, errors.Is(err, domain.ErrIngestionQueueFull))
+}
+
+func TestMessageStatusBatcher_LogsWriteFailures(t *testing.T) {
+	repo, log := setupMessageStatusBatcherTest(t)
+
+	repo.EXPECT().SetStatusesIfNotSet(gomock.Any(), "workspace1", gomock.Any()).Return(errors.New("db down"))
+
+	batcher := NewMessageStatusBatcher(repo, log, 1, 10, 500, time.Hour)
+	batcher.Start()
+	require.NoError(t, batcher.Enqueue("workspace1", []domain.MessageEventUpdate{statusUpdate("msg-1", domain.MessageEventOpened)}))
+	batcher.Stop()
+}
@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/pkg/logger"
+)
+
+// MessageStatusRetryWorker applies again the message status updates from provider webhooks that
+// failed to apply. Each poll handles at most batchSize due retries per workspace, so that a backlog
+// built during a database incident drains at a bounded rate once the database is back.
+type MessageStatusRetryWorker struct {
+	workspaceRepo      domain.WorkspaceRepository
+	retryRepo          domain.MessageStatusRetryRepository
+	messageHistoryRepo domain.MessageHistoryRepository
+	logger             logger.Logger
+	pollInterval       time.Duration
+	batchSize          int
+}
+
+// NewMessageStatusRetryWorker creates a new message status retry worker
+func NewMessageStatusRetryWorker(
+	workspaceRepo domain.WorkspaceRepository,
+	retryRepo domain.MessageStatusRetryRepository,
+	messageHistoryRepo domain.MessageHistoryRepository,
+	logger logger.Logger,
+) *MessageStatusRetryWorker {
+	return &MessageStatusRetryWorker{
+		workspaceRepo:      workspaceRepo,
+		retryRepo:          retryRepo,
+		messageHistoryRepo: messageHistoryRepo,
+		logger:             logger,
+		pollInterval:       15 * time.Second,
+		batchSize:          100,
+	}
+}
+
+// Start starts the message status retry worker
+func (w *MessageStatusRetryWorker) Start(ctx context.Context) {
+	w.logger.Info("Message status retry worker started")
+
+	ticker := time.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			w.logger.Info("Message status retry worker stopping...")
+			return
+		case <-ticker.C:
+			w.processWorkspaces(ctx)
+		}
+	}
+}
+
+// processWorkspaces applies the due retries of every workspace
+func (w *MessageStatusRetryWorker) processWorkspaces(ctx context.Context) {
+	workspaces, err := w.workspaceRepo.List(ctx)
+	if err != nil {
+		w.logger.WithField("error", err.Error()).Error("Failed to list workspaces for message status retries")
+		return
+	}
+
+	for _, workspace := range workspaces {
+		if err := w.ProcessWorkspace(ctx, workspace.ID); err != nil {
+			w.logger.WithFields(map[string]interface{}{
+				"workspace_id": workspace.ID,
+				"error":        err.Error(),
+			}).Error("Failed to process message status retries for workspace")
+		}
+	}
+}
+
+// ProcessWorkspace applies a batch of due retries of a workspace. Applied retries are deleted, failed
+// ones are rescheduled with backoff and dropped after domain.MessageStatusRetryMaxAttempts attempts.
+// SetStatusesIfNotSet never overwrites a status that is already set, so a retry applied again after
+// its deletion failed leaves the message history unchanged.
+func (w *MessageStatusRetryWorker) ProcessWorkspace(ctx context.Context, workspaceID string) error {
+	now := time.Now().UTC()
+
+	retries, err := w.retryRepo.ListDue(ctx, workspaceID, now, w.batchSize)
+	if err != nil {
+		return err
+	}
+	if len(retries) == 0 {
+		return nil
+	}
+
+	updates := make([]domain.MessageEventUpdate, len(retries))
+	for i, retry := range retries {
+		updates[i] = retry.Update
+	}
+
+	applyErr := w.messageHistoryRepo.SetStatusesIfNotSet(ctx, workspaceID, updates)
+	if applyErr == nil {
+		w.logger.WithFields(map[string]interface{}{
+			"workspace_id": workspaceID,
+			"updates":      len(retries),
+		}).Info("Applied retried message status updates")
+		return w.retryRepo.Delete(ctx, workspaceID, retries)
+	}
+
+	dropped := []*domain.MessageStatusRetry{}
+	for _, retry := range retries {
+		retry.Attempts++
+		retry.LastError = applyErr.Error()
+		if retry.Attempts >= domain.MessageStatusRetryMaxAttempts {
+			dropped = append(dropped, retry)
+			continue
+		}
+		retry.NextAttemptAt = now.Add(domain.MessageStatusRetryDelay(retry.Attempts))
+		if err := w.retryRepo.Reschedule(ctx, workspaceID, retry); err != nil {
+			return err
+		}
+	}
+
+	if len(dropped) > 0 {
+		w.logger.WithFields(map[string]interface{}{
+			"workspace_id": workspaceID,
+			"updates":      len(dropped),
+			"error":        applyErr.Error(),
+		}).Error("Dropped message status updates after too many failed attempts")
+		if err := w.retryRepo.Delete(ctx, workspaceID, dropped); err != nil {
+			return err
+		}
+	}
+
+	w.logger.WithFields(map[string]interface{}{
+		"workspace_id": workspaceID,
+		"updates":      len(retries),
+		"error":        applyErr.Error(),
+	}).Warn("Failed to apply retried message status updates")
+
+	return nil
+}
+
+// queueStatusRetry stores message status updates that failed to apply for the retry worker.
+// It returns false when there is no retry repository or the updates could not be stored.
+func queueStatusRetry(ctx context.Context, retryRepo domain.MessageStatusRetryRepository, log logger.Logger, workspaceID string, updates []domain.MessageEventUpdate, applyErr error) bool {
+	if retryRepo == nil {
+		return false
+	}
+
+	nextAttemptAt := time.Now().UTC().Add(domain.MessageStatusRetryDelay(1))
+	if err := retryRepo.Enqueue(ctx, workspaceID, updates, nextAttemptAt, applyErr.Error()); err != nil {
+		log.WithFields(map[string]interface{}{
+			"workspace_id": workspaceID,
+			"updates":      len(updates),
+			"error":        err.Error(),
+		}).Error("Failed to queue message status updates for retry")
+		return false
+	}
+
+	log.WithFields(map[string]interface{}{
+		"workspace_id": workspaceID,
+		"updates":      len(updates),
+		"error":        applyErr.Error(),
+	}).Warn("Queued message status updates for retry")
+	return true
+}
@@ -0,0 +1,233 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/domain/mocks"
+	pkgmocks "github.com/Notifuse/notifuse/pkg/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryStatusRetryRepository keeps the retries in memory, unique per message, event and timestamp
+type memoryStatusRetryRepository struct {
+	retries []*domain.MessageStatusRetry
+}
+
+func (r *memoryStatusRetryRepository) find(update domain.MessageEventUpdate) int {
+	for i, retry := range r.retries {
+		if retry.Update.ID == update.ID && retry.Update.Event == update.Event && retry.Update.Timestamp.Equal(update.Timestamp) {
+			return i
+		}
+	}
+	return -1
+}
+
+func (r *memoryStatusRetryRepository) Enqueue(_ context.Context, _ string, updates []domain.MessageEventUpdate, nextAttemptAt time.Time, lastError string) error {
+	for _, update := range updates {
+		if r.find(update) < 0 {
+			r.retries = append(r.retries, &domain.MessageStatusRetry{Update: update, NextAttemptAt: nextAttemptAt, LastError: lastError})
+		}
+	}
+	return nil
+}
+
+func (r *memoryStatusRetryRepository) ListDue(_ context.Context, _ string, now time.Time, limit int) ([]*domain.MessageStatusRetry, error) {
+	due := []*domain.MessageStatusRetry{}
+	for _, retry := range r.retries {
+		if !retry.NextAttemptAt.After(now) && len(due) < limit {
+			copied := *retry
+			due = append(due, &copied)
+		}
+	}
+	return due, nil
+}
+
+func (r *memoryStatusRetryRepository) Reschedule(_ context.Context, _ string, retry *domain.MessageStatusRetry) error {
+	if i := r.find(retry.Update); i >= 0 {
+		copied := *retry
+		r.retries[i] = &copied
+	}
+	return nil
+}
+
+func (r *memoryStatusRetryRepository) Delete(_ context.Context, _ string, retries []*domain.MessageStatusRetry) error {
+	for _, retry := range retries {
+		if i := r.find(retry.Update); i >= 0 {
+			r.retries = append(r.retries[:i], r.retries[i+1:]...)
+		}
+	}
+	return nil
+}
+
+func newRetryTestLogger(ctrl *gomock.Controller) *pkgmocks.MockLogger {
+	log := pkgmocks.NewMockLogger(ctrl)
+	log.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(log).AnyTimes()
+	log.EXPECT().WithFields(gomock.Any()).Return(log).AnyTimes()
+	log.EXPECT().Debug(gomock.Any()).AnyTimes()
+	log.EXPECT().Info(gomock.Any()).AnyTimes()
+	log.EXPECT().Warn(gomock.Any()).AnyTimes()
+	log.EXPECT().Error(gomock.Any()).AnyTimes()
+	return log
+}
+
+func TestMessageStatusRetry_TransientFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	workspaceID := "workspace1"
+	integrationID := "integration1"
+	log := newRetryTestLogger(ctrl)
+	repo := mocks.NewMockInboundWebhookEventRepository(ctrl)
+	workspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	messageHistoryRepo := mocks.NewMockMessageHistoryRepository(ctrl)
+	retryRepo := &memoryStatusRetryRepository{}
+
+	service := NewInboundWebhookEventService(repo, mocks.NewMockAuthService(ctrl), log, workspaceRepo, messageHistoryRepo, 0)
+	service.SetStatusRetryRepository(retryRepo)
+	worker := NewMessageStatusRetryWorker(workspaceRepo, retryRepo, messageHistoryRepo, log)
+
+	workspaceRepo.EXPECT().GetByID(gomock.Any(), workspaceID).Return(&domain.Workspace{
+		ID: workspaceID,
+		Integrations: []domain.Integration{
+			{ID: integrationID, EmailProvider: domain.EmailProvider{Kind: domain.EmailProviderKindPostmark}},
+		},
+	}, nil)
+	repo.EXPECT().StoreEvents(gomock.Any(), workspaceID, gomock.Any()).Return(nil)
+
+	var applied []domain.MessageEventUpdate
+	gomock.InOrder(
+		messageHistoryRepo.EXPECT().SetStatusesIfNotSet(gomock.Any(), workspaceID, gomock.Any()).
+			Return(errors.New("connection reset by peer")),
+		// The retry is applied exactly once
+		messageHistoryRepo.EXPECT().SetStatusesIfNotSet(gomock.Any(), workspaceID, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, updates []domain.MessageEventUpdate) error {
+				applied = append(applied, updates...)
+				return nil
+			}),
+	)
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"RecordType":  "Delivery",
+		"MessageID":   "message-1",
+		"Recipient":   "test@example.com",
+		"DeliveredAt": "2026-03-01T12:00:00Z",
+	})
+	require.NoError(t, err)
+
+	// The webhook succeeds so that the provider does not retry it
+	require.NoError(t, service.ProcessWebhook(context.Background(), workspaceID, integrationID, payload))
+	require.Len(t, retryRepo.retries, 1)
+	assert.Equal(t, "message-1", retryRepo.retries[0].Update.ID)
+	assert.Equal(t, domain.MessageEventDelivered, retryRepo.retries[0].Update.Event)
+	assert.Equal(t, "connection reset by peer", retryRepo.retries[0].LastError)
+
+	// Not due yet
+	require.NoError(t, worker.ProcessWorkspace(context.Background(), workspaceID))
+	assert.Empty(t, applied)
+
+	retryRepo.retries[0].NextAttemptAt = time.Now().Add(-time.Second)
+	require.NoError(t, worker.ProcessWorkspace(context.Background(), workspaceID))
+	require.Len(t, applied, 1)
+	assert.Equal(t, "message-1", applied[0].ID)
+	assert.Empty(t, retryRepo.retries)
+
+	// Nothing left to apply
+	require.NoError(t, worker.ProcessWorkspace(context.Background(), workspaceID))
+	assert.Len(t, applied, 1)
+}
+
+func TestMessageStatusRetryWorker_ProcessWorkspace(t *testing.T) {
+	workspaceID := "workspace1"
+	update := domain.MessageEventUpdate{ID: "message-1", Event: domain.MessageEventDelivered, Timestamp: time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)}
+
+	setup := func(t *testing.T) (*MessageStatusRetryWorker, *mocks.MockMessageStatusRetryRepository, *mocks.MockMessageHistoryRepository) {
+		ctrl := gomock.NewController(t)
+		t.Cleanup(ctrl.Finish)
+
+		retryRepo := mocks.NewMockMessageStatusRetryRepository(ctrl)
+		messageHistoryRepo := mocks.NewMockMessageHistoryRepository(ctrl)
+		worker := NewMessageStatusRetryWorker(mocks.NewMockWorkspaceRepository(ctrl), retryRepo, messageHistoryRepo, newRetryTestLogger(ctrl))
+		return worker, retryRepo, messageHistoryRepo
+	}
+
+	t.Run("failed retry is rescheduled with backoff", func(t *testing.T) {
+		worker, retryRepo, messageHistoryRepo := setup(t)
+
+		retry := &domain.MessageStatusRetry{Update: update, Attempts: 2}
+		retryRepo.EXPECT().ListDue(gomock.Any(), workspaceID, gomock.Any(), 100).Return([]*domain.MessageStatusRetry{retry}, nil)
+		messageHistoryRepo.EXPECT().SetStatusesIfNotSet(gomock.Any(), workspaceID, []domain.MessageEventUpdate{update}).Return(errors.New("lock timeout"))
+		retryRepo.EXPECT().Reschedule(gomock.Any(), workspaceID, retry).Return(nil)
+
+		before := time.Now()
+		require.NoError(t, worker.ProcessWorkspace(context.Background(), workspaceID))
+		assert.Equal(t, 3, retry.Attempts)
+		assert.Equal(t, "lock timeout", retry.LastError)
+		assert.WithinDuration(t, before.Add(2*time.Minute), retry.NextAttemptAt, 5*time.Second)
+	})
+
+	t.Run("retry is dropped after the last attempt", func(t *testing.T) {
+		worker, retryRepo, messageHistoryRepo := setup(t)
+
+		retry := &domain.MessageStatusRetry{Update: update, Attempts: domain.MessageStatusRetryMaxAttempts - 1}
+		retryRepo.EXPECT().ListDue(gomock.Any(), workspaceID, gomock.Any(), 100).Return([]*domain.MessageStatusRetry{retry}, nil)
+		messageHistoryRepo.EXPECT().SetStatusesIfNotSet(gomock.Any(), workspaceID, gomock.Any()).Return(errors.New("lock timeout"))
+		retryRepo.EXPECT().Delete(gomock.Any(), workspaceID, []*domain.MessageStatusRetry{retry}).Return(nil)
+
+		require.NoError(t, worker.ProcessWorkspace(context.Background(), workspaceID))
+	})
+
+	t.Run("list error", func(t *testing.T) {
+		worker, retryRepo, _ := setup(t)
+
+		retryRepo.EXPECT().ListDue(gomock.Any(), workspaceID, gomock.Any(), 100).Return(nil, errors.New("connection refused"))
+
+		err := worker.ProcessWorkspace(context.Background(), workspaceID)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "connection refused")
+	})
+}
+
+func TestQueueStatusRetry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	log := newRetryTestLogger(ctrl)
+	updates := []domain.MessageEventUpdate{{ID: "message-1", Event: domain.MessageEventBounced}}
+	applyErr := errors.New("connection reset")
+
+	t.Run("no retry repository", func(t *testing.T) {
+		assert.False(t, queueStatusRetry(context.Background(), nil, log, "workspace1", updates, applyErr))
+	})
+
+	t.Run("queue error", func(t *testing.T) {
+		retryRepo := mocks.NewMockMessageStatusRetryRepository(ctrl)
+		retryRepo.EXPECT().Enqueue(gomock.Any(), "workspace1", updates, gomock.Any(), "connection reset").Return(errors.New("connection reset"))
+
+		assert.False(t, queueStatusRetry(context.Background(), retryRepo, log, "workspace1", updates, applyErr))
+	})
+}
+
+func TestMessageStatusBatcher_QueuesFailedUpdates(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	messageHistoryRepo := mocks.NewMockMessageHistoryRepository(ctrl)
+	retryRepo := mocks.NewMockMessageStatusRetryRepository(ctrl)
+	updates := []domain.MessageEventUpdate{{ID: "message-1", Event: domain.MessageEventDelivered}}
+
+	messageHistoryRepo.EXPECT().SetStatusesIfNotSet(gomock.Any(), "workspace1", updates).Return(errors.New("connection reset"))
+	retryRepo.EXPECT().Enqueue(gomock.Any(), "workspace1", updates, gomock.Any(), "connection reset").Return(nil)
+
+	batcher := NewMessageStatusBatcher(messageHistoryRepo, newRetryTestLogger(ctrl), 1, 10, 500, time.Hour)
+	batcher.SetRetryRepository(retryRepo)
+	batcher.Start()
+	require.NoError(t, batcher.Enqueue("workspace1", updates))
+	batcher.Stop()
+}
@@ -353,11 +353,15 @@ func (s *PostmarkService) RegisterWebhooks(
 	webhookConfig := domain.PostmarkWebhookConfig{
 		URL:           webhookURL,
 		MessageStream: "outbound",
-		Triggers:      triggers,
+		// Postmark sends the credentials with each event so that incoming events can be verified
+		HttpAuth: providerConfig.Postmark.WebhookHttpAuth(),
+		Triggers: triggers,
 	}
 
-	// Debug log the webhook config
-	jsonData, _ := json.Marshal(webhookConfig)
+	// Debug log the webhook config, without the credentials
+	loggedConfig := webhookConfig
+	loggedConfig.HttpAuth = nil
+	jsonData, _ := json.Marshal(loggedConfig)
 	s.logger.Info(fmt.Sprintf("Registering Postmark webhook with config: %s", string(jsonData)))
 
 	webhookResponse, err := s.RegisterWebhook(ctx, *providerConfig.Postmark, webhookConfig)
@@ -544,15 +548,24 @@ func (s *PostmarkService) SendEmail(ctx context.Context, request domain.SendEmai
 	// Prepare the API endpoint
 	endpoint := "https://api.postmarkapp.com/email"
 
+	// Custom provider tags are sent as Postmark metadata alongside the message ID
+	metadata := map[string]string{}
+	for key, value := range request.EmailOptions.ProviderTags {
+		metadata[key] = value
+	}
+	metadata["notifuse_message_id"] = request.MessageID
+
 	// Prepare the request body
 	requestBody := map[string]interface{}{
 		"From":     fmt.Sprintf("%s <%s>", request.FromName, request.FromAddress),
 		"To":       request.To,
 		"Subject":  request.Subject,
-		"HtmlBody": request.Content,
-		"Metadata": map[string]string{
-			"notifuse_message_id": request.MessageID,
-		},
+		"Metadata": metadata,
+	}
+	if request.PlainTextOnly {
+		requestBody["TextBody"] = request.Content
+	} else {
+		requestBody["HtmlBody"] = request.Content
 	}
 
 	// Add CC if specified
@@ -2349,3 +2349,58 @@ type errorReader struct{}
 func (e *errorReader) Read(p []byte) (n int, err error) {
 	return 0, errors.New("read error")
 }
+
+func TestPostmarkService_SendEmail_ProviderTags(t *testing.T) {
+	providerConfig := &domain.EmailProvider{
+		Kind: domain.EmailProviderKindPostmark,
+		Postmark: &domain.PostmarkSettings{
+			ServerToken: "test-server-token",
+		},
+	}
+
+	newRequest := func(tags map[string]string) domain.SendEmailProviderRequest {
+		return domain.SendEmailProviderRequest{
+			WorkspaceID:   "workspace-123",
+			IntegrationID: "test-integration-id",
+			MessageID:     "test-message-id",
+			FromAddress:   "sender@example.com",
+			FromName:      "Sender",
+			To:            "recipient@example.com",
+			Subject:       "Subject",
+			Content:       "Content",
+			Provider:      providerConfig,
+			EmailOptions:  domain.EmailOptions{ProviderTags: tags},
+		}
+	}
+
+	t.Run("Tags are sent as metadata", func(t *testing.T) {
+		service, httpClient, _, _ := setupPostmarkTest(t)
+
+		httpClient.EXPECT().
+			Do(gomock.Any()).
+			DoAndReturn(func(req *http.Request) (*http.Response, error) {
+				body, _ := io.ReadAll(req.Body)
+				var requestBody map[string]interface{}
+				require.NoError(t, json.Unmarshal(body, &requestBody))
+
+				metadata, ok := requestBody["Metadata"].(map[string]interface{})
+				require.True(t, ok)
+				assert.Equal(t, "test-message-id", metadata["notifuse_message_id"])
+				assert.Equal(t, "spring-sale", metadata["campaign"])
+
+				return createMockResponse(http.StatusOK, `{"MessageID":"12345"}`), nil
+			})
+
+		err := service.SendEmail(context.Background(), newRequest(map[string]string{"campaign": "spring-sale"}))
+		assert.NoError(t, err)
+	})
+
+	t.Run("Oversize metadata value is rejected", func(t *testing.T) {
+		service, httpClient, _, _ := setupPostmarkTest(t)
+		httpClient.EXPECT().Do(gomock.Any()).Times(0)
+
+		err := service.SendEmail(context.Background(), newRequest(map[string]string{"campaign": strings.Repeat("a", 81)}))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "exceeds 80 characters")
+	})
+}
@@ -35,6 +35,7 @@ func NewQueryBuilder() *QueryBuilder {
 
 	// Initialize field whitelist for contacts table
 	qb.initializeContactFields()
+	qb.initializeComputedFields()
 
 	// Initialize operator whitelist
 	qb.initializeOperators()
@@ -94,6 +95,34 @@ func (qb *QueryBuilder) initializeContactFields() {
 	}
 }
 
+// initializeComputedFields sets up the contact fields computed from other tables with a correlated subquery
+func (qb *QueryBuilder) initializeComputedFields() {
+	// Opens and clicks of the trailing window, decayed by age, see domain.EngagementScoreWindowDays
+	windowStart := fmt.Sprintf("NOW() - INTERVAL '%d days'", domain.EngagementScoreWindowDays)
+	qb.allowedFields["engagement_score"] = fieldConfig{
+		dbColumn: fmt.Sprintf(
+			"(SELECT COALESCE(SUM(%s + %s), 0) FROM message_history mh WHERE mh.contact_email = contacts.email AND (mh.opened_at > %s OR mh.clicked_at > %s))",
+			engagementScoreTerm("mh.opened_at", domain.EngagementScoreOpenWeight),
+			engagementScoreTerm("mh.clicked_at", domain.EngagementScoreClickWeight),
+			windowStart,
+			windowStart,
+		),
+		fieldType: "number",
+	}
+}
+
+// engagementScoreTerm returns the SQL contribution of an open or click timestamp column to the
+// engagement score, like MessageHistoryRepository.GetEngagementScores computes it
+func engagementScoreTerm(column string, weight int) string {
+	return fmt.Sprintf(
+		"CASE WHEN %[1]s > NOW() - INTERVAL '%[2]d days' THEN %[3]d * POWER(0.5, EXTRACT(EPOCH FROM NOW() - %[1]s) / %[4]d) ELSE 0 END",
+		column,
+		domain.EngagementScoreWindowDays,
+		weight,
+		domain.EngagementScoreHalfLifeDays*24*60*60,
+	)
+}
+
 // initializeOperators sets up the whitelist of allowed operators
 func (qb *QueryBuilder) initializeOperators() {
 	qb.allowedOperators = map[string]sqlOperator{
@@ -241,8 +270,14 @@ func (qb *QueryBuilder) parseLeaf(leaf *domain.TreeNodeLeaf, argIndex int) (stri
 		}
 		return qb.parseCustomEventsGoalCondition(leaf.CustomEventsGoal, argIndex)
 
+	case "message_activity":
+		if leaf.MessageActivity == nil {
+			return "", nil, argIndex, fmt.Errorf("leaf with source 'message_activity' must have 'message_activity' field")
+		}
+		return qb.parseMessageActivityConditionWithEmailRef(leaf.MessageActivity, argIndex, "contacts.email")
+
 	default:
-		return "", nil, argIndex, fmt.Errorf("unsupported source: %s (supported: 'contacts', 'contact_lists', 'contact_timeline', 'custom_events_goals')", leaf.Source)
+		return "", nil, argIndex, fmt.Errorf("unsupported source: %s (supported: 'contacts', 'contact_lists', 'contact_timeline', 'custom_events_goals', 'message_activity')", leaf.Source)
 	}
 }
 
@@ -521,6 +556,44 @@ func (qb *QueryBuilder) parseContactTimelineConditions(timeline *domain.ContactT
 	return countCondition, args, argIndex, nil
 }
 
+// parseMessageActivityConditionWithEmailRef generates SQL for message_activity filtering
+// Uses an EXISTS subquery on the messages of the contact, NOT EXISTS for the "none" operator.
+// Dry runs are never counted, nor failed sends for the "sent" event.
+func (qb *QueryBuilder) parseMessageActivityConditionWithEmailRef(activity *domain.MessageActivityCondition, argIndex int, emailRef string) (string, []interface{}, int, error) {
+	if activity == nil {
+		return "", nil, argIndex, fmt.Errorf("message_activity condition cannot be nil")
+	}
+
+	// The column comes from the known events, never from the condition itself
+	column, ok := domain.MessageActivityEvents[activity.Event]
+	if !ok {
+		return "", nil, argIndex, fmt.Errorf("invalid message_activity event: %s", activity.Event)
+	}
+
+	conditions := []string{
+		fmt.Sprintf("mh.contact_email = %s", emailRef),
+		fmt.Sprintf("mh.%s IS NOT NULL", column),
+		fmt.Sprintf("mh.status_info IS DISTINCT FROM '%s'", domain.MessageStatusInfoDryRun),
+	}
+	if activity.Event == "sent" {
+		conditions = append(conditions, "mh.failed_at IS NULL")
+	}
+	if activity.Days > 0 {
+		// The value is an int so it's safe from SQL injection, like the in_the_last_days timeframes
+		conditions = append(conditions, fmt.Sprintf("mh.%s > NOW() - INTERVAL '%d days'", column, activity.Days))
+	}
+
+	existsClause := fmt.Sprintf("EXISTS (SELECT 1 FROM message_history mh WHERE %s)", strings.Join(conditions, " AND "))
+	switch activity.Operator {
+	case "any":
+		return existsClause, nil, argIndex, nil
+	case "none":
+		return "NOT " + existsClause, nil, argIndex, nil
+	default:
+		return "", nil, argIndex, fmt.Errorf("invalid message_activity operator: %s (must be 'any' or 'none')", activity.Operator)
+	}
+}
+
 // parseCustomEventsGoalCondition generates SQL for custom_events goal-based filtering
 // Uses EXISTS subquery with aggregation to check goal metrics (LTV, transaction count, etc.)
 func (qb *QueryBuilder) parseCustomEventsGoalCondition(goal *domain.CustomEventsGoalCondition, argIndex int) (string, []interface{}, int, error) {
@@ -1176,6 +1249,12 @@ func (qb *QueryBuilder) parseLeafWithEmailRef(leaf *domain.TreeNodeLeaf, argInde
 		}
 		return qb.parseCustomEventsGoalConditionWithEmailRef(leaf.CustomEventsGoal, argIndex, emailRef)
 
+	case "message_activity":
+		if leaf.MessageActivity == nil {
+			return "", nil, argIndex, fmt.Errorf("leaf with source 'message_activity' must have 'message_activity' field")
+		}
+		return qb.parseMessageActivityConditionWithEmailRef(leaf.MessageActivity, argIndex, emailRef)
+
 	default:
 		return "", nil, argIndex, fmt.Errorf("unsupported source: %s", leaf.Source)
 	}
@@ -964,6 +964,37 @@ func TestQueryBuilder_ContactLists(t *testing.T) {
 	})
 }
 
+func TestQueryBuilder_EngagementScore(t *testing.T) {
+	qb := NewQueryBuilder()
+
+	tree := &domain.TreeNode{
+		Kind: "leaf",
+		Leaf: &domain.TreeNodeLeaf{
+			Source: "contacts",
+			Contact: &domain.ContactCondition{
+				Filters: []*domain.DimensionFilter{
+					{
+						FieldName:    "engagement_score",
+						FieldType:    "number",
+						Operator:     "gt",
+						NumberValues: []float64{2.5},
+					},
+				},
+			},
+		},
+	}
+
+	sql, args, err := qb.BuildSQL(tree)
+	require.NoError(t, err)
+
+	assert.Contains(t, sql, "(SELECT COALESCE(SUM(")
+	assert.Contains(t, sql, "FROM message_history mh WHERE mh.contact_email = contacts.email")
+	assert.Contains(t, sql, "CASE WHEN mh.opened_at > NOW() - INTERVAL '180 days' THEN 1 * POWER(0.5, EXTRACT(EPOCH FROM NOW() - mh.opened_at) / 2592000) ELSE 0 END")
+	assert.Contains(t, sql, "CASE WHEN mh.clicked_at > NOW() - INTERVAL '180 days' THEN 3 * POWER(0.5, EXTRACT(EPOCH FROM NOW() - mh.clicked_at) / 2592000) ELSE 0 END")
+	assert.True(t, strings.HasSuffix(sql, ") > $1)"), sql)
+	assert.Equal(t, []interface{}{2.5}, args)
+}
+
 func TestQueryBuilder_ContactTimeline(t *testing.T) {
 	qb := NewQueryBuilder()
 
@@ -1298,6 +1329,146 @@ func TestQueryBuilder_ContactTimeline(t *testing.T) {
 	})
 }
 
+func TestQueryBuilder_MessageActivity(t *testing.T) {
+	qb := NewQueryBuilder()
+
+	t.Run("opened any message in the last 30 days", func(t *testing.T) {
+		tree := &domain.TreeNode{
+			Kind: "leaf",
+			Leaf: &domain.TreeNodeLeaf{
+				Source: "message_activity",
+				MessageActivity: &domain.MessageActivityCondition{
+					Event:    "opened",
+					Operator: "any",
+					Days:     30,
+				},
+			},
+		}
+
+		sql, args, err := qb.BuildSQL(tree)
+		require.NoError(t, err)
+
+		assert.Equal(t, "SELECT email FROM contacts WHERE EXISTS (SELECT 1 FROM message_history mh WHERE "+
+			"mh.contact_email = contacts.email AND mh.opened_at IS NOT NULL AND mh.status_info IS DISTINCT FROM 'dry_run' "+
+			"AND mh.opened_at > NOW() - INTERVAL '30 days')", sql)
+		assert.Empty(t, args)
+	})
+
+	t.Run("never clicked", func(t *testing.T) {
+		tree := &domain.TreeNode{
+			Kind: "leaf",
+			Leaf: &domain.TreeNodeLeaf{
+				Source: "message_activity",
+				MessageActivity: &domain.MessageActivityCondition{
+					Event:    "clicked",
+					Operator: "none",
+				},
+			},
+		}
+
+		sql, _, err := qb.BuildSQL(tree)
+		require.NoError(t, err)
+
+		assert.Contains(t, sql, "WHERE NOT EXISTS (SELECT 1 FROM message_history mh")
+		assert.Contains(t, sql, "mh.clicked_at IS NOT NULL")
+		assert.NotContains(t, sql, "INTERVAL")
+	})
+
+	t.Run("sent excludes failed sends", func(t *testing.T) {
+		tree := &domain.TreeNode{
+			Kind: "leaf",
+			Leaf: &domain.TreeNodeLeaf{
+				Source: "message_activity",
+				MessageActivity: &domain.MessageActivityCondition{
+					Event:    "sent",
+					Operator: "any",
+					Days:     7,
+				},
+			},
+		}
+
+		sql, _, err := qb.BuildSQL(tree)
+		require.NoError(t, err)
+
+		assert.Contains(t, sql, "mh.failed_at IS NULL")
+		assert.Contains(t, sql, "mh.sent_at > NOW() - INTERVAL '7 days'")
+	})
+
+	t.Run("combined with contact filters keeps arg numbering", func(t *testing.T) {
+		tree := &domain.TreeNode{
+			Kind: "branch",
+			Branch: &domain.TreeNodeBranch{
+				Operator: "and",
+				Leaves: []*domain.TreeNode{
+					{
+						Kind: "leaf",
+						Leaf: &domain.TreeNodeLeaf{
+							Source: "message_activity",
+							MessageActivity: &domain.MessageActivityCondition{
+								Event:    "opened",
+								Operator: "any",
+								Days:     30,
+							},
+						},
+					},
+					{
+						Kind: "leaf",
+						Leaf: &domain.TreeNodeLeaf{
+							Source: "contacts",
+							Contact: &domain.ContactCondition{
+								Filters: []*domain.DimensionFilter{
+									{FieldName: "country", FieldType: "string", Operator: "equals", StringValues: []string{"FR"}},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		sql, args, err := qb.BuildSQL(tree)
+		require.NoError(t, err)
+
+		assert.Contains(t, sql, "EXISTS (SELECT 1 FROM message_history mh")
+		assert.Contains(t, sql, "country = $1")
+		assert.Equal(t, []interface{}{"FR"}, args)
+	})
+
+	t.Run("trigger condition uses the email reference", func(t *testing.T) {
+		tree := &domain.TreeNode{
+			Kind: "leaf",
+			Leaf: &domain.TreeNodeLeaf{
+				Source: "message_activity",
+				MessageActivity: &domain.MessageActivityCondition{
+					Event:    "opened",
+					Operator: "none",
+				},
+			},
+		}
+
+		sql, _, err := qb.BuildTriggerCondition(tree, "NEW.email")
+		require.NoError(t, err)
+
+		assert.Contains(t, sql, "NOT EXISTS (SELECT 1 FROM message_history mh WHERE mh.contact_email = NEW.email")
+	})
+
+	t.Run("invalid event", func(t *testing.T) {
+		tree := &domain.TreeNode{
+			Kind: "leaf",
+			Leaf: &domain.TreeNodeLeaf{
+				Source: "message_activity",
+				MessageActivity: &domain.MessageActivityCondition{
+					Event:    "opened_at; DROP TABLE contacts",
+					Operator: "any",
+				},
+			},
+		}
+
+		_, _, err := qb.BuildSQL(tree)
+		assert.Error(t, err)
+	})
+}
+
 func TestQueryBuilder_BuildSQL_JSONFiltering(t *testing.T) {
 	qb := NewQueryBuilder()
 
@@ -11,6 +11,9 @@ import (
 	"github.com/Notifuse/notifuse/pkg/logger"
 )
 
+// sendingBlockRecheckInterval is how long broadcast emails are held while the workspace sending is blocked
+const sendingBlockRecheckInterval = 5 * time.Minute
+
 // EmailQueueWorkerConfig holds configuration for the worker pool
 type EmailQueueWorkerConfig struct {
 	WorkerCount  int           // Number of concurrent workers per workspace (default: 5)
@@ -63,6 +66,9 @@ type EmailQueueWorker struct {
 	// Callbacks for progress tracking
 	onEmailSent   EmailSentCallback
 	onEmailFailed EmailFailedCallback
+
+	// Optional recorder of the sends for provider webhook health monitoring
+	webhookHealth domain.ProviderWebhookHealthRecorder
 }
 
 // NewEmailQueueWorker creates a new EmailQueueWorker
@@ -109,6 +115,11 @@ func (w *EmailQueueWorker) SetCallbacks(onSent EmailSentCallback, onFailed Email
 	w.onEmailFailed = onFailed
 }
 
+// SetWebhookHealthRecorder sets the recorder notified of every email sent through a provider
+func (w *EmailQueueWorker) SetWebhookHealthRecorder(recorder domain.ProviderWebhookHealthRecorder) {
+	w.webhookHealth = recorder
+}
+
 // Start begins processing queued emails
 func (w *EmailQueueWorker) Start(ctx context.Context) error {
 	w.mu.Lock()
@@ -253,6 +264,55 @@ func (w *EmailQueueWorker) processWorkspace(workspace *domain.Workspace) {
 
 // processEntry processes a single queue entry
 func (w *EmailQueueWorker) processEntry(workspace *domain.Workspace, entry *domain.EmailQueueEntry) {
+	// Sandbox workspaces only deliver to allowlisted recipients; everything else is dropped
+	if !workspace.Settings.IsRecipientAllowed(entry.ContactEmail) {
+		w.dropEntry(workspace, entry, domain.ErrSandboxRecipientNotAllowed, "Sandbox mode: dropping email to recipient outside allowlist")
+		return
+	}
+
+	// Broadcast emails still queued once the send cutoff passed are stale and never sent
+	if entry.SourceType == domain.EmailQueueSourceBroadcast && entry.Payload.SendCutoffAt != nil && !time.Now().Before(*entry.Payload.SendCutoffAt) {
+		w.dropEntry(workspace, entry, domain.ErrBroadcastSendCutoffReached, "Broadcast send cutoff reached: dropping queued email")
+		return
+	}
+
+	// Broadcasts are held back while a complaint spike blocks the workspace sending
+	if entry.SourceType == domain.EmailQueueSourceBroadcast && workspace.Settings.SendingBlock != nil {
+		w.logger.WithFields(map[string]interface{}{
+			"entry_id":  entry.ID,
+			"source_id": entry.SourceID,
+		}).Debug("Workspace sending blocked, holding broadcast email")
+
+		// Reschedule WITHOUT incrementing attempts
+		if err := w.queueRepo.SetNextRetry(w.ctx, workspace.ID, entry.ID, time.Now().Add(sendingBlockRecheckInterval)); err != nil {
+			w.logger.WithFields(map[string]interface{}{
+				"entry_id": entry.ID,
+				"error":    err.Error(),
+			}).Warn("Failed to set next retry for sending block")
+		}
+		return
+	}
+
+	// Broadcasts are held back during the workspace quiet hours unless they opt out
+	if entry.SourceType == domain.EmailQueueSourceBroadcast && !entry.Payload.IgnoreQuietHours {
+		if until, deferred := workspace.Settings.QuietHoursDeferral(time.Now(), entry.Payload.RecipientTimezone); deferred {
+			w.logger.WithFields(map[string]interface{}{
+				"entry_id":    entry.ID,
+				"source_id":   entry.SourceID,
+				"defer_until": until,
+			}).Debug("Quiet hours active, deferring broadcast email")
+
+			// Reschedule WITHOUT incrementing attempts
+			if err := w.queueRepo.SetNextRetry(w.ctx, workspace.ID, entry.ID, until); err != nil {
+				w.logger.WithFields(map[string]interface{}{
+					"entry_id": entry.ID,
+					"error":    err.Error(),
+				}).Warn("Failed to set next retry for quiet hours")
+			}
+			return
+		}
+	}
+
 	// Get the integration to retrieve the email provider (needed for circuit breaker check)
 	integration := workspace.GetIntegrationByID(entry.IntegrationID)
 	if integration == nil {
@@ -268,8 +328,10 @@ func (w *EmailQueueWorker) processEntry(workspace *domain.Workspace, entry *doma
 		return
 	}
 
-	// Check circuit breaker BEFORE MarkAsProcessing to avoid incrementing attempts
-	if w.circuitBreaker.IsOpen(entry.IntegrationID) {
+	// Check circuit breaker BEFORE MarkAsProcessing to avoid incrementing attempts. While the circuit
+	// is open the email goes straight to the failover providers, it waits only when none is available.
+	primaryOpen := w.circuitBreaker.IsOpen(entry.IntegrationID)
+	if primaryOpen && !w.hasAvailableFailover(workspace, entry) {
 		w.logger.WithFields(map[string]interface{}{
 			"entry_id":       entry.ID,
 			"integration_id": entry.IntegrationID,
@@ -295,56 +357,42 @@ func (w *EmailQueueWorker) processEntry(workspace *domain.Workspace, entry *doma
 		return
 	}
 
-	// Wait for rate limiter - always use current integration rate limit (not stale payload value)
-	ratePerMinute := integration.EmailProvider.RateLimitPerMinute
-	if ratePerMinute <= 0 {
-		ratePerMinute = 60 // Default to 1 per second if not configured
-	}
+	var sentThrough *domain.Integration
+	var classifiedErr *emailerror.ClassifiedError
+	if primaryOpen {
+		// The provider is known to be down, the email is only sent through the failover providers
+		sentThrough, classifiedErr = w.sendThroughFailover(workspace, entry)
+		if sentThrough == nil && classifiedErr == nil {
+			// The failover providers opened their circuits meanwhile, or the rate limit wait was cancelled
+			w.handleError(workspace, entry, fmt.Errorf("circuit breaker open for integration %s and no failover provider available", entry.IntegrationID), nil)
+			return
+		}
+	} else {
+		// Wait for rate limiter - always use current integration rate limit (not stale payload value)
+		if err := w.rateLimiter.Wait(w.ctx, entry.IntegrationID, integrationRatePerMinute(integration)); err != nil {
+			// Context cancelled, don't mark as failed
+			w.logger.WithFields(map[string]interface{}{
+				"entry_id": entry.ID,
+				"error":    err.Error(),
+			}).Debug("Rate limit wait cancelled")
+			return
+		}
 
-	if err := w.rateLimiter.Wait(w.ctx, entry.IntegrationID, ratePerMinute); err != nil {
-		// Context cancelled, don't mark as failed
-		w.logger.WithFields(map[string]interface{}{
-			"entry_id": entry.ID,
-			"error":    err.Error(),
-		}).Debug("Rate limit wait cancelled")
-		return
+		// Send the email, failing over to the next providers of the workspace when the provider is unavailable
+		sentThrough = integration
+		classifiedErr = w.sendThrough(workspace, entry, integration)
+		if classifiedErr != nil && shouldFailover(classifiedErr) {
+			if failoverIntegration, _ := w.sendThroughFailover(workspace, entry); failoverIntegration != nil {
+				sentThrough = failoverIntegration
+				classifiedErr = nil
+			}
+		}
 	}
-
-	// Build the send request
-	request := entry.Payload.ToSendEmailProviderRequest(
-		workspace.ID,
-		entry.IntegrationID,
-		entry.MessageID,
-		entry.ContactEmail,
-		&integration.EmailProvider,
-	)
-
-	// Send the email
-	err := w.emailService.SendEmail(w.ctx, *request, true) // isMarketing = true
-	if err != nil {
-		// Classify the error
-		classifiedErr := w.errorClassifier.Classify(err, integration.EmailProvider.Kind)
-
-		// Log the classification for debugging
-		w.logger.WithFields(map[string]interface{}{
-			"entry_id":    entry.ID,
-			"error_type":  classifiedErr.Type,
-			"provider":    classifiedErr.Provider,
-			"http_status": classifiedErr.HTTPStatus,
-			"retryable":   classifiedErr.Retryable,
-			"original":    err.Error(),
-		}).Debug("Classified send error")
-
-		// Record failure to circuit breaker (only counts provider errors)
-		w.circuitBreaker.RecordFailure(entry.IntegrationID, classifiedErr)
-
-		w.handleError(workspace, entry, err, classifiedErr)
+	if classifiedErr != nil {
+		w.handleError(workspace, entry, classifiedErr.Original, classifiedErr)
 		return
 	}
 
-	// Record success to reset circuit breaker
-	w.circuitBreaker.RecordSuccess(entry.IntegrationID)
-
 	// Mark as sent
 	if err := w.queueRepo.MarkAsSent(w.ctx, workspace.ID, entry.ID); err != nil {
 		w.logger.WithFields(map[string]interface{}{
@@ -355,7 +403,11 @@ func (w *EmailQueueWorker) processEntry(workspace *domain.Workspace, entry *doma
 	}
 
 	// Upsert message history (success - clears any previous failure)
-	w.upsertMessageHistory(w.ctx, workspace.ID, workspace.Settings.SecretKey, entry, nil)
+	w.upsertMessageHistory(w.ctx, workspace.ID, workspace.Settings.SecretKey, entry, sentThrough.ID, nil)
+
+	if w.webhookHealth != nil {
+		w.webhookHealth.RecordSent(w.ctx, workspace.ID, sentThrough.ID, sentThrough.EmailProvider.Kind)
+	}
 
 	w.logger.WithFields(map[string]interface{}{
 		"entry_id":     entry.ID,
@@ -372,6 +424,101 @@ func (w *EmailQueueWorker) processEntry(workspace *domain.Workspace, entry *doma
 	}
 }
 
+// sendThrough sends an entry through an integration. A failure is classified, recorded to the
+// circuit breaker of the integration and returned; nil means the email was sent.
+func (w *EmailQueueWorker) sendThrough(workspace *domain.Workspace, entry *domain.EmailQueueEntry, integration *domain.Integration) *emailerror.ClassifiedError {
+	// Build the send request
+	request := entry.Payload.ToSendEmailProviderRequest(
+		workspace.ID,
+		integration.ID,
+		entry.MessageID,
+		entry.ContactEmail,
+		&integration.EmailProvider,
+	)
+
+	err := w.emailService.SendEmail(w.ctx, *request, true) // isMarketing = true
+	if err == nil {
+		// Record success to reset circuit breaker
+		w.circuitBreaker.RecordSuccess(integration.ID)
+		return nil
+	}
+
+	// Classify the error
+	classifiedErr := w.errorClassifier.Classify(err, integration.EmailProvider.Kind)
+
+	// Log the classification for debugging
+	w.logger.WithFields(map[string]interface{}{
+		"entry_id":       entry.ID,
+		"integration_id": integration.ID,
+		"error_type":     classifiedErr.Type,
+		"provider":       classifiedErr.Provider,
+		"http_status":    classifiedErr.HTTPStatus,
+		"retryable":      classifiedErr.Retryable,
+		"original":       err.Error(),
+	}).Debug("Classified send error")
+
+	// Record failure to circuit breaker (only counts provider errors)
+	w.circuitBreaker.RecordFailure(integration.ID, classifiedErr)
+
+	return classifiedErr
+}
+
+// hasAvailableFailover tells whether the workspace has a failover integration for an entry whose circuit is closed
+func (w *EmailQueueWorker) hasAvailableFailover(workspace *domain.Workspace, entry *domain.EmailQueueEntry) bool {
+	for _, integration := range workspace.MarketingFailoverIntegrations(entry.IntegrationID) {
+		if !w.circuitBreaker.IsOpen(integration.ID) {
+			return true
+		}
+	}
+	return false
+}
+
+// sendThroughFailover sends an entry its integration can't send through the failover
+// integrations of the workspace, in order, and returns the integration that sent it, nil when
+// none did along with the last send error, nil when none was tried. Integrations whose circuit is
+// open are skipped, and the failover stops on an error that isn't a provider outage since the next
+// providers would fail the same way.
+func (w *EmailQueueWorker) sendThroughFailover(workspace *domain.Workspace, entry *domain.EmailQueueEntry) (*domain.Integration, *emailerror.ClassifiedError) {
+	var lastErr *emailerror.ClassifiedError
+	for _, integration := range workspace.MarketingFailoverIntegrations(entry.IntegrationID) {
+		if w.circuitBreaker.IsOpen(integration.ID) {
+			continue
+		}
+		if err := w.rateLimiter.Wait(w.ctx, integration.ID, integrationRatePerMinute(integration)); err != nil {
+			return nil, lastErr
+		}
+
+		classifiedErr := w.sendThrough(workspace, entry, integration)
+		if classifiedErr == nil {
+			w.logger.WithFields(map[string]interface{}{
+				"entry_id":                entry.ID,
+				"message_id":              entry.MessageID,
+				"integration_id":          entry.IntegrationID,
+				"failover_integration_id": integration.ID,
+			}).Info("Email sent through failover provider")
+			return integration, nil
+		}
+		lastErr = classifiedErr
+		if !shouldFailover(classifiedErr) {
+			break
+		}
+	}
+	return nil, lastErr
+}
+
+// shouldFailover tells whether a send error is a provider outage another provider may not have
+func shouldFailover(classifiedErr *emailerror.ClassifiedError) bool {
+	return classifiedErr.Retryable && classifiedErr.IsProviderError()
+}
+
+// integrationRatePerMinute returns the send rate limit of an integration
+func integrationRatePerMinute(integration *domain.Integration) int {
+	if integration.EmailProvider.RateLimitPerMinute <= 0 {
+		return 60 // Default to 1 per second if not configured
+	}
+	return integration.EmailProvider.RateLimitPerMinute
+}
+
 // handleError handles a send error, scheduling retry or deleting permanently failed entries
 // classifiedErr may be nil for internal errors (e.g., integration not found)
 func (w *EmailQueueWorker) handleError(workspace *domain.Workspace, entry *domain.EmailQueueEntry, sendErr error, classifiedErr *emailerror.ClassifiedError) {
@@ -398,7 +545,7 @@ func (w *EmailQueueWorker) handleError(workspace *domain.Workspace, entry *domai
 	w.logger.WithFields(logFields).Warn("Failed to send email")
 
 	// Upsert message history with failure info
-	w.upsertMessageHistory(w.ctx, workspace.ID, workspace.Settings.SecretKey, entry, sendErr)
+	w.upsertMessageHistory(w.ctx, workspace.ID, workspace.Settings.SecretKey, entry, "", sendErr)
 
 	if isPermanent {
 		// Permanent failure - delete the queue entry
@@ -438,14 +585,44 @@ func (w *EmailQueueWorker) handleError(workspace *domain.Workspace, entry *domai
 	}
 }
 
+// dropEntry discards an entry that must not be delivered (recipient outside the sandbox
+// allowlist, broadcast send cutoff passed). The message history records the drop so it is
+// visible in the UI, and the entry is reported as a permanent failure so broadcast progress
+// still accounts for it.
+func (w *EmailQueueWorker) dropEntry(workspace *domain.Workspace, entry *domain.EmailQueueEntry, reason error, logMessage string) {
+	w.logger.WithFields(map[string]interface{}{
+		"entry_id":     entry.ID,
+		"message_id":   entry.MessageID,
+		"recipient":    entry.ContactEmail,
+		"source_type":  entry.SourceType,
+		"source_id":    entry.SourceID,
+		"workspace_id": workspace.ID,
+	}).Warn(logMessage)
+
+	w.upsertMessageHistory(w.ctx, workspace.ID, workspace.Settings.SecretKey, entry, "", reason)
+
+	if err := w.queueRepo.Delete(w.ctx, workspace.ID, entry.ID); err != nil {
+		w.logger.WithFields(map[string]interface{}{
+			"entry_id": entry.ID,
+			"error":    err.Error(),
+		}).Error("Failed to delete dropped queue entry")
+	}
+
+	if w.onEmailFailed != nil {
+		w.onEmailFailed(workspace.ID, entry.SourceType, entry.SourceID, entry.MessageID, reason, true)
+	}
+}
+
 // upsertMessageHistory creates or updates a message history record after a send attempt
-// On success: FailedAt and StatusInfo are nil (clears any previous failure)
+// On success: FailedAt and StatusInfo are nil (clears any previous failure) and the channel options
+// record the integration that sent the email
 // On failure: FailedAt is set to now, StatusInfo contains the error
 func (w *EmailQueueWorker) upsertMessageHistory(
 	ctx context.Context,
 	workspaceID string,
 	secretKey string,
 	entry *domain.EmailQueueEntry,
+	sentThroughIntegrationID string,
 	sendErr error,
 ) {
 	now := time.Now().UTC()
@@ -482,6 +659,9 @@ func (w *EmailQueueWorker) upsertMessageHistory(
 		message.StatusInfo = &errStr
 	}
 	// On success: FailedAt and StatusInfo remain nil, clearing any previous failure
+	if sentThroughIntegrationID != "" {
+		message.ChannelOptions = &domain.ChannelOptions{IntegrationID: sentThroughIntegrationID}
+	}
 
 	// Upsert record (log errors but don't fail the send operation)
 	if err := w.messageHistoryRepo.Upsert(ctx, workspaceID, secretKey, message); err != nil {
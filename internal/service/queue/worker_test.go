@@ -522,6 +522,350 @@ func TestEmailQueueWorker_ProcessEntry_IntegrationNotFound(t *testing.T) {
 	worker.processEntry(workspace, entry)
 }
 
+func TestEmailQueueWorker_ProcessEntry_SandboxAllowlist(t *testing.T) {
+	integrationID := "integration-1"
+	entryID := "entry-1"
+	workspaceID := "workspace-1"
+
+	newWorkspace := func() *domain.Workspace {
+		return &domain.Workspace{
+			ID: workspaceID,
+			Settings: domain.WorkspaceSettings{
+				SandboxMode:      true,
+				SandboxAllowlist: []string{"qa@example.com"},
+			},
+			Integrations: []domain.Integration{
+				{
+					ID: integrationID,
+					EmailProvider: domain.EmailProvider{
+						Kind:               domain.EmailProviderKindSMTP,
+						RateLimitPerMinute: 6000,
+					},
+				},
+			},
+		}
+	}
+
+	newEntry := func(recipient string) *domain.EmailQueueEntry {
+		return &domain.EmailQueueEntry{
+			ID:            entryID,
+			Status:        domain.EmailQueueStatusPending,
+			SourceType:    domain.EmailQueueSourceBroadcast,
+			SourceID:      "broadcast-1",
+			IntegrationID: integrationID,
+			ContactEmail:  recipient,
+			MessageID:     "msg-1",
+			Payload: domain.EmailQueuePayload{
+				FromAddress: "sender@example.com",
+				Subject:     "Test Subject",
+				HTMLContent: "<p>Hello</p>",
+			},
+			MaxAttempts: 3,
+		}
+	}
+
+	t.Run("allowlisted recipient is sent", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockQueueRepo := mocks.NewMockEmailQueueRepository(ctrl)
+		mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+		mockEmailService := mocks.NewMockEmailServiceInterface(ctrl)
+		mockMessageHistoryRepo := mocks.NewMockMessageHistoryRepository(ctrl)
+		mockLogger := pkgmocks.NewMockLogger(ctrl)
+
+		mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+		mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
+
+		mockQueueRepo.EXPECT().MarkAsProcessing(gomock.Any(), workspaceID, entryID).Return(nil)
+		mockEmailService.EXPECT().SendEmail(gomock.Any(), gomock.Any(), true).Return(nil)
+		mockMessageHistoryRepo.EXPECT().Upsert(gomock.Any(), workspaceID, gomock.Any(), gomock.Any()).Return(nil)
+		mockQueueRepo.EXPECT().MarkAsSent(gomock.Any(), workspaceID, entryID).Return(nil)
+
+		worker := NewEmailQueueWorker(mockQueueRepo, mockWorkspaceRepo, mockEmailService, mockMessageHistoryRepo, DefaultWorkerConfig(), mockLogger)
+		worker.ctx = context.Background()
+
+		worker.processEntry(newWorkspace(), newEntry("QA@example.com"))
+	})
+
+	t.Run("non-allowlisted recipient is dropped and flagged", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockQueueRepo := mocks.NewMockEmailQueueRepository(ctrl)
+		mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+		mockEmailService := mocks.NewMockEmailServiceInterface(ctrl)
+		mockMessageHistoryRepo := mocks.NewMockMessageHistoryRepository(ctrl)
+		mockLogger := pkgmocks.NewMockLogger(ctrl)
+
+		mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+		mockLogger.EXPECT().Warn(gomock.Any()).Times(1)
+
+		// No SendEmail or MarkAsProcessing: the message is never delivered
+		mockMessageHistoryRepo.EXPECT().Upsert(gomock.Any(), workspaceID, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, _ string, message *domain.MessageHistory) error {
+				require.NotNil(t, message.FailedAt)
+				require.NotNil(t, message.StatusInfo)
+				assert.Equal(t, domain.ErrSandboxRecipientNotAllowed.Error(), *message.StatusInfo)
+				return nil
+			})
+		mockQueueRepo.EXPECT().Delete(gomock.Any(), workspaceID, entryID).Return(nil)
+
+		worker := NewEmailQueueWorker(mockQueueRepo, mockWorkspaceRepo, mockEmailService, mockMessageHistoryRepo, DefaultWorkerConfig(), mockLogger)
+		worker.ctx = context.Background()
+
+		var failedPermanently bool
+		worker.SetCallbacks(nil, func(_ string, _ domain.EmailQueueSourceType, _ string, _ string, err error, isPermanent bool) {
+			assert.ErrorIs(t, err, domain.ErrSandboxRecipientNotAllowed)
+			failedPermanently = isPermanent
+		})
+
+		worker.processEntry(newWorkspace(), newEntry("customer@example.com"))
+		assert.True(t, failedPermanently)
+	})
+}
+
+func TestEmailQueueWorker_ProcessEntry_SendCutoff(t *testing.T) {
+	integrationID := "integration-1"
+	entryID := "entry-1"
+	workspaceID := "workspace-1"
+
+	workspace := &domain.Workspace{
+		ID: workspaceID,
+		Integrations: []domain.Integration{
+			{
+				ID: integrationID,
+				EmailProvider: domain.EmailProvider{
+					Kind:               domain.EmailProviderKindSMTP,
+					RateLimitPerMinute: 6000,
+				},
+			},
+		},
+	}
+
+	newEntry := func(cutoffAt time.Time) *domain.EmailQueueEntry {
+		return &domain.EmailQueueEntry{
+			ID:            entryID,
+			Status:        domain.EmailQueueStatusPending,
+			SourceType:    domain.EmailQueueSourceBroadcast,
+			SourceID:      "broadcast-1",
+			IntegrationID: integrationID,
+			ContactEmail:  "customer@example.com",
+			MessageID:     "msg-1",
+			Payload: domain.EmailQueuePayload{
+				FromAddress:  "sender@example.com",
+				Subject:      "Flash sale",
+				HTMLContent:  "<p>Hello</p>",
+				SendCutoffAt: &cutoffAt,
+			},
+			MaxAttempts: 3,
+		}
+	}
+
+	t.Run("broadcast email before the cutoff is sent", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockQueueRepo := mocks.NewMockEmailQueueRepository(ctrl)
+		mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+		mockEmailService := mocks.NewMockEmailServiceInterface(ctrl)
+		mockMessageHistoryRepo := mocks.NewMockMessageHistoryRepository(ctrl)
+		mockLogger := pkgmocks.NewMockLogger(ctrl)
+
+		mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+		mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
+
+		mockQueueRepo.EXPECT().MarkAsProcessing(gomock.Any(), workspaceID, entryID).Return(nil)
+		mockEmailService.EXPECT().SendEmail(gomock.Any(), gomock.Any(), true).Return(nil)
+		mockMessageHistoryRepo.EXPECT().Upsert(gomock.Any(), workspaceID, gomock.Any(), gomock.Any()).Return(nil)
+		mockQueueRepo.EXPECT().MarkAsSent(gomock.Any(), workspaceID, entryID).Return(nil)
+
+		worker := NewEmailQueueWorker(mockQueueRepo, mockWorkspaceRepo, mockEmailService, mockMessageHistoryRepo, DefaultWorkerConfig(), mockLogger)
+		worker.ctx = context.Background()
+
+		worker.processEntry(workspace, newEntry(time.Now().Add(time.Hour)))
+	})
+
+	t.Run("broadcast email still queued after the cutoff is skipped", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockQueueRepo := mocks.NewMockEmailQueueRepository(ctrl)
+		mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+		mockEmailService := mocks.NewMockEmailServiceInterface(ctrl)
+		mockMessageHistoryRepo := mocks.NewMockMessageHistoryRepository(ctrl)
+		mockLogger := pkgmocks.NewMockLogger(ctrl)
+
+		mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+		mockLogger.EXPECT().Warn(gomock.Any()).Times(1)
+
+		// No SendEmail or MarkAsProcessing: stale content is never delivered
+		mockMessageHistoryRepo.EXPECT().Upsert(gomock.Any(), workspaceID, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, _ string, message *domain.MessageHistory) error {
+				require.NotNil(t, message.StatusInfo)
+				assert.Equal(t, domain.ErrBroadcastSendCutoffReached.Error(), *message.StatusInfo)
+				return nil
+			})
+		mockQueueRepo.EXPECT().Delete(gomock.Any(), workspaceID, entryID).Return(nil)
+
+		worker := NewEmailQueueWorker(mockQueueRepo, mockWorkspaceRepo, mockEmailService, mockMessageHistoryRepo, DefaultWorkerConfig(), mockLogger)
+		worker.ctx = context.Background()
+
+		var failedPermanently bool
+		worker.SetCallbacks(nil, func(_ string, _ domain.EmailQueueSourceType, _ string, _ string, err error, isPermanent bool) {
+			assert.ErrorIs(t, err, domain.ErrBroadcastSendCutoffReached)
+			failedPermanently = isPermanent
+		})
+
+		worker.processEntry(workspace, newEntry(time.Now().Add(-time.Minute)))
+		assert.True(t, failedPermanently)
+	})
+}
+
+func TestEmailQueueWorker_ProcessEntry_QuietHours(t *testing.T) {
+	integrationID := "integration-1"
+	entryID := "entry-1"
+	workspaceID := "workspace-1"
+
+	// Quiet hours window surrounding the current time so the recipient is always inside it
+	now := time.Now().UTC()
+	newWorkspace := func() *domain.Workspace {
+		return &domain.Workspace{
+			ID: workspaceID,
+			Settings: domain.WorkspaceSettings{
+				Timezone: "UTC",
+				QuietHours: &domain.QuietHoursSettings{
+					Enabled: true,
+					Start:   now.Add(-time.Hour).Format("15:04"),
+					End:     now.Add(time.Hour).Format("15:04"),
+				},
+			},
+			Integrations: []domain.Integration{
+				{
+					ID: integrationID,
+					EmailProvider: domain.EmailProvider{
+						Kind:               domain.EmailProviderKindSMTP,
+						RateLimitPerMinute: 6000,
+					},
+				},
+			},
+		}
+	}
+
+	newEntry := func(ignoreQuietHours bool) *domain.EmailQueueEntry {
+		return &domain.EmailQueueEntry{
+			ID:            entryID,
+			Status:        domain.EmailQueueStatusPending,
+			SourceType:    domain.EmailQueueSourceBroadcast,
+			SourceID:      "broadcast-1",
+			IntegrationID: integrationID,
+			ContactEmail:  "night-owl@example.com",
+			MessageID:     "msg-1",
+			Payload: domain.EmailQueuePayload{
+				FromAddress:       "sender@example.com",
+				Subject:           "Test Subject",
+				HTMLContent:       "<p>Hello</p>",
+				IgnoreQuietHours:  ignoreQuietHours,
+				RecipientTimezone: "UTC",
+			},
+			MaxAttempts: 3,
+		}
+	}
+
+	t.Run("normal broadcast is deferred until quiet hours end", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockQueueRepo := mocks.NewMockEmailQueueRepository(ctrl)
+		mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+		mockEmailService := mocks.NewMockEmailServiceInterface(ctrl)
+		mockMessageHistoryRepo := mocks.NewMockMessageHistoryRepository(ctrl)
+		mockLogger := pkgmocks.NewMockLogger(ctrl)
+
+		mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+		mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
+
+		// No MarkAsProcessing or SendEmail: attempts are not consumed
+		mockQueueRepo.EXPECT().SetNextRetry(gomock.Any(), workspaceID, entryID, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, _ string, nextRetry time.Time) error {
+				assert.True(t, nextRetry.After(now))
+				assert.WithinDuration(t, now.Add(time.Hour), nextRetry, time.Minute)
+				return nil
+			})
+
+		worker := NewEmailQueueWorker(mockQueueRepo, mockWorkspaceRepo, mockEmailService, mockMessageHistoryRepo, DefaultWorkerConfig(), mockLogger)
+		worker.ctx = context.Background()
+
+		worker.processEntry(newWorkspace(), newEntry(false))
+	})
+
+	t.Run("broadcast with override is sent immediately", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockQueueRepo := mocks.NewMockEmailQueueRepository(ctrl)
+		mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+		mockEmailService := mocks.NewMockEmailServiceInterface(ctrl)
+		mockMessageHistoryRepo := mocks.NewMockMessageHistoryRepository(ctrl)
+		mockLogger := pkgmocks.NewMockLogger(ctrl)
+
+		mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+		mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
+
+		mockQueueRepo.EXPECT().MarkAsProcessing(gomock.Any(), workspaceID, entryID).Return(nil)
+		mockEmailService.EXPECT().SendEmail(gomock.Any(), gomock.Any(), true).Return(nil)
+		mockMessageHistoryRepo.EXPECT().Upsert(gomock.Any(), workspaceID, gomock.Any(), gomock.Any()).Return(nil)
+		mockQueueRepo.EXPECT().MarkAsSent(gomock.Any(), workspaceID, entryID).Return(nil)
+
+		worker := NewEmailQueueWorker(mockQueueRepo, mockWorkspaceRepo, mockEmailService, mockMessageHistoryRepo, DefaultWorkerConfig(), mockLogger)
+		worker.ctx = context.Background()
+
+		worker.processEntry(newWorkspace(), newEntry(true))
+	})
+}
+
+func TestEmailQueueWorker_ProcessEntry_SendingBlock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockQueueRepo := mocks.NewMockEmailQueueRepository(ctrl)
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	mockEmailService := mocks.NewMockEmailServiceInterface(ctrl)
+	mockMessageHistoryRepo := mocks.NewMockMessageHistoryRepository(ctrl)
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+
+	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
+
+	workspace := &domain.Workspace{
+		ID: "workspace-1",
+		Settings: domain.WorkspaceSettings{
+			SendingBlock: &domain.SendingBlock{Reason: "complaint spike", BlockedAt: time.Now().UTC()},
+		},
+	}
+	entry := &domain.EmailQueueEntry{
+		ID:           "entry-1",
+		Status:       domain.EmailQueueStatusPending,
+		SourceType:   domain.EmailQueueSourceBroadcast,
+		SourceID:     "broadcast-1",
+		ContactEmail: "user@example.com",
+		MaxAttempts:  3,
+	}
+
+	// No MarkAsProcessing or SendEmail: the email is held without consuming attempts
+	now := time.Now()
+	mockQueueRepo.EXPECT().SetNextRetry(gomock.Any(), "workspace-1", "entry-1", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, _ string, nextRetry time.Time) error {
+			assert.WithinDuration(t, now.Add(sendingBlockRecheckInterval), nextRetry, time.Minute)
+			return nil
+		})
+
+	worker := NewEmailQueueWorker(mockQueueRepo, mockWorkspaceRepo, mockEmailService, mockMessageHistoryRepo, DefaultWorkerConfig(), mockLogger)
+	worker.ctx = context.Background()
+
+	worker.processEntry(workspace, entry)
+}
+
 func TestEmailQueueWorker_ProcessEntry_MarkAsProcessingFails(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -1310,3 +1654,205 @@ func TestEmailQueueWorker_DynamicBatchSize(t *testing.T) {
 		}
 	})
 }
+
+func TestEmailQueueWorker_ProcessEntry_ProviderFailover(t *testing.T) {
+	workspaceID := "workspace-1"
+	entryID := "entry-1"
+
+	newWorkspace := func() *domain.Workspace {
+		newIntegration := func(id string, kind domain.EmailProviderKind) domain.Integration {
+			return domain.Integration{
+				ID:            id,
+				Type:          domain.IntegrationTypeEmail,
+				EmailProvider: domain.EmailProvider{Kind: kind, RateLimitPerMinute: 6000},
+			}
+		}
+		return &domain.Workspace{
+			ID: workspaceID,
+			Settings: domain.WorkspaceSettings{
+				MarketingEmailProviderID:     "primary",
+				MarketingFailoverProviderIDs: []string{"removed", "secondary", "tertiary"},
+			},
+			Integrations: []domain.Integration{
+				newIntegration("primary", domain.EmailProviderKindMailgun),
+				newIntegration("secondary", domain.EmailProviderKindPostmark),
+				newIntegration("tertiary", domain.EmailProviderKindSES),
+			},
+		}
+	}
+	newEntry := func() *domain.EmailQueueEntry {
+		return &domain.EmailQueueEntry{
+			ID:            entryID,
+			Status:        domain.EmailQueueStatusPending,
+			SourceType:    domain.EmailQueueSourceBroadcast,
+			SourceID:      "broadcast-1",
+			IntegrationID: "primary",
+			ProviderKind:  domain.EmailProviderKindMailgun,
+			ContactEmail:  "test@example.com",
+			MessageID:     "msg-1",
+			Payload: domain.EmailQueuePayload{
+				FromAddress: "sender@example.com",
+				Subject:     "Test Subject",
+				HTMLContent: "<p>Hello</p>",
+			},
+			MaxAttempts: 3,
+		}
+	}
+	unavailableErr := errors.New("mailgun API error (status code: 503): service unavailable")
+
+	setup := func(t *testing.T) (*gomock.Controller, *mocks.MockEmailQueueRepository, *mocks.MockEmailServiceInterface, *mocks.MockMessageHistoryRepository, *EmailQueueWorker) {
+		ctrl := gomock.NewController(t)
+		mockQueueRepo := mocks.NewMockEmailQueueRepository(ctrl)
+		mockEmailService := mocks.NewMockEmailServiceInterface(ctrl)
+		mockMessageHistoryRepo := mocks.NewMockMessageHistoryRepository(ctrl)
+		mockLogger := pkgmocks.NewMockLogger(ctrl)
+		mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+		mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
+		mockLogger.EXPECT().Info(gomock.Any()).AnyTimes()
+		mockLogger.EXPECT().Warn(gomock.Any()).AnyTimes()
+		mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
+
+		worker := NewEmailQueueWorker(mockQueueRepo, mocks.NewMockWorkspaceRepository(ctrl), mockEmailService, mockMessageHistoryRepo, DefaultWorkerConfig(), mockLogger)
+		worker.ctx = context.Background()
+		return ctrl, mockQueueRepo, mockEmailService, mockMessageHistoryRepo, worker
+	}
+	openCircuit := func(t *testing.T, worker *EmailQueueWorker, integrationID string) {
+		classifiedErr := worker.errorClassifier.Classify(unavailableErr, domain.EmailProviderKindMailgun)
+		for i := 0; i < worker.circuitBreaker.GetConfig().Threshold; i++ {
+			worker.circuitBreaker.RecordFailure(integrationID, classifiedErr)
+		}
+		require.True(t, worker.circuitBreaker.IsOpen(integrationID))
+	}
+
+	t.Run("primary unavailable, secondary delivers", func(t *testing.T) {
+		ctrl, mockQueueRepo, mockEmailService, mockMessageHistoryRepo, worker := setup(t)
+		defer ctrl.Finish()
+		mockQueueRepo.EXPECT().MarkAsProcessing(gomock.Any(), workspaceID, entryID).Return(nil)
+
+		var sentThrough []string
+		mockEmailService.EXPECT().SendEmail(gomock.Any(), gomock.Any(), true).
+			DoAndReturn(func(_ context.Context, request domain.SendEmailProviderRequest, _ bool) error {
+				sentThrough = append(sentThrough, request.IntegrationID)
+				if request.IntegrationID == "primary" {
+					return unavailableErr
+				}
+				assert.Equal(t, domain.EmailProviderKindPostmark, request.Provider.Kind)
+				return nil
+			}).Times(2)
+		mockQueueRepo.EXPECT().MarkAsSent(gomock.Any(), workspaceID, entryID).Return(nil)
+		mockMessageHistoryRepo.EXPECT().Upsert(gomock.Any(), workspaceID, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, _ string, message *domain.MessageHistory) error {
+				assert.Nil(t, message.FailedAt)
+				require.NotNil(t, message.ChannelOptions)
+				assert.Equal(t, "secondary", message.ChannelOptions.IntegrationID)
+				return nil
+			})
+
+		var sentCount int32
+		worker.SetCallbacks(func(string, domain.EmailQueueSourceType, string, string) {
+			atomic.AddInt32(&sentCount, 1)
+		}, func(string, domain.EmailQueueSourceType, string, string, error, bool) {
+			t.Error("the email must not be reported as failed")
+		})
+
+		worker.processEntry(newWorkspace(), newEntry())
+
+		assert.Equal(t, []string{"primary", "secondary"}, sentThrough)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&sentCount))
+	})
+
+	t.Run("every provider unavailable, entry is retried", func(t *testing.T) {
+		ctrl, mockQueueRepo, mockEmailService, mockMessageHistoryRepo, worker := setup(t)
+		defer ctrl.Finish()
+		mockQueueRepo.EXPECT().MarkAsProcessing(gomock.Any(), workspaceID, entryID).Return(nil)
+
+		var sentThrough []string
+		mockEmailService.EXPECT().SendEmail(gomock.Any(), gomock.Any(), true).
+			DoAndReturn(func(_ context.Context, request domain.SendEmailProviderRequest, _ bool) error {
+				sentThrough = append(sentThrough, request.IntegrationID)
+				return unavailableErr
+			}).Times(3)
+		mockMessageHistoryRepo.EXPECT().Upsert(gomock.Any(), workspaceID, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, _ string, message *domain.MessageHistory) error {
+				assert.NotNil(t, message.FailedAt)
+				assert.Nil(t, message.ChannelOptions)
+				return nil
+			})
+		mockQueueRepo.EXPECT().MarkAsFailed(gomock.Any(), workspaceID, entryID, unavailableErr.Error(), gomock.Any()).Return(nil)
+
+		worker.processEntry(newWorkspace(), newEntry())
+
+		assert.Equal(t, []string{"primary", "secondary", "tertiary"}, sentThrough)
+	})
+
+	t.Run("recipient error does not fail over", func(t *testing.T) {
+		ctrl, mockQueueRepo, mockEmailService, mockMessageHistoryRepo, worker := setup(t)
+		defer ctrl.Finish()
+		mockQueueRepo.EXPECT().MarkAsProcessing(gomock.Any(), workspaceID, entryID).Return(nil)
+
+		recipientErr := errors.New("mailgun API error: 550 mailbox unavailable")
+		mockEmailService.EXPECT().SendEmail(gomock.Any(), gomock.Any(), true).Return(recipientErr).Times(1)
+		mockMessageHistoryRepo.EXPECT().Upsert(gomock.Any(), workspaceID, gomock.Any(), gomock.Any()).Return(nil)
+		mockQueueRepo.EXPECT().Delete(gomock.Any(), workspaceID, entryID).Return(nil)
+
+		worker.processEntry(newWorkspace(), newEntry())
+	})
+
+	t.Run("primary circuit open, failover delivers without calling the primary", func(t *testing.T) {
+		ctrl, mockQueueRepo, mockEmailService, mockMessageHistoryRepo, worker := setup(t)
+		defer ctrl.Finish()
+		openCircuit(t, worker, "primary")
+
+		mockQueueRepo.EXPECT().MarkAsProcessing(gomock.Any(), workspaceID, entryID).Return(nil)
+		var sentThrough []string
+		mockEmailService.EXPECT().SendEmail(gomock.Any(), gomock.Any(), true).
+			DoAndReturn(func(_ context.Context, request domain.SendEmailProviderRequest, _ bool) error {
+				sentThrough = append(sentThrough, request.IntegrationID)
+				return nil
+			})
+		mockQueueRepo.EXPECT().MarkAsSent(gomock.Any(), workspaceID, entryID).Return(nil)
+		mockMessageHistoryRepo.EXPECT().Upsert(gomock.Any(), workspaceID, gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, _ string, message *domain.MessageHistory) error {
+				require.NotNil(t, message.ChannelOptions)
+				assert.Equal(t, "secondary", message.ChannelOptions.IntegrationID)
+				return nil
+			})
+
+		worker.processEntry(newWorkspace(), newEntry())
+
+		assert.Equal(t, []string{"secondary"}, sentThrough)
+	})
+
+	t.Run("primary circuit open, failover unavailable, entry is retried", func(t *testing.T) {
+		ctrl, mockQueueRepo, mockEmailService, mockMessageHistoryRepo, worker := setup(t)
+		defer ctrl.Finish()
+		openCircuit(t, worker, "primary")
+
+		mockQueueRepo.EXPECT().MarkAsProcessing(gomock.Any(), workspaceID, entryID).Return(nil)
+		var sentThrough []string
+		mockEmailService.EXPECT().SendEmail(gomock.Any(), gomock.Any(), true).
+			DoAndReturn(func(_ context.Context, request domain.SendEmailProviderRequest, _ bool) error {
+				sentThrough = append(sentThrough, request.IntegrationID)
+				return unavailableErr
+			}).Times(2)
+		mockMessageHistoryRepo.EXPECT().Upsert(gomock.Any(), workspaceID, gomock.Any(), gomock.Any()).Return(nil)
+		mockQueueRepo.EXPECT().MarkAsFailed(gomock.Any(), workspaceID, entryID, unavailableErr.Error(), gomock.Any()).Return(nil)
+
+		worker.processEntry(newWorkspace(), newEntry())
+
+		assert.Equal(t, []string{"secondary", "tertiary"}, sentThrough)
+	})
+
+	t.Run("every circuit open, entry is deferred without an attempt", func(t *testing.T) {
+		ctrl, mockQueueRepo, _, _, worker := setup(t)
+		defer ctrl.Finish()
+		for _, integrationID := range []string{"primary", "secondary", "tertiary"} {
+			openCircuit(t, worker, integrationID)
+		}
+
+		// No MarkAsProcessing nor send, the mocks fail on any other call
+		mockQueueRepo.EXPECT().SetNextRetry(gomock.Any(), workspaceID, entryID, gomock.Any()).Return(nil)
+
+		worker.processEntry(newWorkspace(), newEntry())
+	})
+}
@@ -264,6 +264,9 @@ func (p *SegmentBuildProcessor) processBatch(
 	batchQuery := sqlQuery + " AND email = ANY($" + fmt.Sprintf("%d", len(args)+1) + ")"
 	batchArgs := append(args, pq.Array(emails))
 
+	// Evaluation time orders this result against concurrent delta updates of the same contacts
+	evaluatedAt := time.Now().UTC()
+
 	// Execute query to find matching contacts
 	rows, err := p.executeSegmentQuery(ctx, workspaceID, batchQuery, batchArgs)
 	if err != nil {
@@ -287,7 +290,7 @@ func (p *SegmentBuildProcessor) processBatch(
 
 	// Add matched contacts to segment
 	for email := range matchedEmails {
-		if err := p.segmentRepo.AddContactToSegment(ctx, workspaceID, email, state.SegmentID, state.Version); err != nil {
+		if err := p.segmentRepo.AddContactToSegment(ctx, workspaceID, email, state.SegmentID, state.Version, evaluatedAt); err != nil {
 			p.logger.WithFields(map[string]interface{}{
 				"error": err.Error(),
 				"email": email,
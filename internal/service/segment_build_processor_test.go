@@ -660,7 +660,7 @@ func TestSegmentBuildProcessor_Process_RemoveMembershipsError(t *testing.T) {
 		Return(db, nil)
 
 	mockSegmentRepo.EXPECT().
-		AddContactToSegment(ctx, "workspace1", "test@test.com", "segment1", int64(1)).
+		AddContactToSegment(ctx, "workspace1", "test@test.com", "segment1", int64(1), gomock.Any()).
 		Return(nil)
 
 	mockTaskRepo.EXPECT().
@@ -746,7 +746,7 @@ func TestSegmentBuildProcessor_Process_FinalStatusUpdateError(t *testing.T) {
 		Return(db, nil)
 
 	mockSegmentRepo.EXPECT().
-		AddContactToSegment(ctx, "workspace1", "test@test.com", "segment1", int64(1)).
+		AddContactToSegment(ctx, "workspace1", "test@test.com", "segment1", int64(1), gomock.Any()).
 		Return(nil)
 
 	mockTaskRepo.EXPECT().
@@ -1122,7 +1122,7 @@ func TestSegmentBuildProcessor_Process_SuccessfulCompletion(t *testing.T) {
 		Return(db, nil)
 
 	mockSegmentRepo.EXPECT().
-		AddContactToSegment(ctx, "workspace1", "test@test.com", "segment1", int64(1)).
+		AddContactToSegment(ctx, "workspace1", "test@test.com", "segment1", int64(1), gomock.Any()).
 		Return(nil)
 
 	mockTaskRepo.EXPECT().
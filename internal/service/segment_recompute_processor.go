@@ -0,0 +1,288 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/pkg/logger"
+	"github.com/lib/pq"
+)
+
+// SegmentRecomputeProcessor handles the execution of segment recompute tasks.
+// Unlike a build, a recompute keeps the segment active and only writes the membership
+// changes: contacts that now match are added, contacts that no longer match are removed,
+// and the memberships that still hold get the new version and computed_at.
+type SegmentRecomputeProcessor struct {
+	segmentRepo   domain.SegmentRepository
+	contactRepo   domain.ContactRepository
+	taskRepo      domain.TaskRepository
+	workspaceRepo domain.WorkspaceRepository
+	logger        logger.Logger
+	batchSize     int // Number of contacts to process per batch
+}
+
+// NewSegmentRecomputeProcessor creates a new segment recompute processor
+func NewSegmentRecomputeProcessor(
+	segmentRepo domain.SegmentRepository,
+	contactRepo domain.ContactRepository,
+	taskRepo domain.TaskRepository,
+	workspaceRepo domain.WorkspaceRepository,
+	logger logger.Logger,
+) *SegmentRecomputeProcessor {
+	return &SegmentRecomputeProcessor{
+		segmentRepo:   segmentRepo,
+		contactRepo:   contactRepo,
+		taskRepo:      taskRepo,
+		workspaceRepo: workspaceRepo,
+		logger:        logger,
+		batchSize:     100,
+	}
+}
+
+// CanProcess returns whether this processor can handle the given task type
+func (p *SegmentRecomputeProcessor) CanProcess(taskType string) bool {
+	return taskType == "recompute_segment"
+}
+
+// Process executes or continues a segment recompute task
+func (p *SegmentRecomputeProcessor) Process(ctx context.Context, task *domain.Task, timeoutAt time.Time) (completed bool, err error) {
+	p.logger.WithFields(map[string]interface{}{
+		"task_id":      task.ID,
+		"workspace_id": task.WorkspaceID,
+		"type":         task.Type,
+	}).Info("Processing segment recompute task")
+
+	state := task.State.RecomputeSegment
+	if state == nil {
+		return false, fmt.Errorf("task state missing RecomputeSegment data - task may not have been properly initialized")
+	}
+
+	if state.SegmentID == "" {
+		return false, fmt.Errorf("recompute state missing segment_id")
+	}
+
+	if state.BatchSize == 0 {
+		state.BatchSize = p.batchSize
+	}
+
+	if state.StartedAt == "" {
+		state.StartedAt = time.Now().UTC().Format(time.RFC3339)
+	}
+
+	segment, err := p.segmentRepo.GetSegmentByID(ctx, task.WorkspaceID, state.SegmentID)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch segment: %w", err)
+	}
+
+	// A segment that is not active is being built, the build computes its memberships from scratch
+	if segment.Status != string(domain.SegmentStatusActive) {
+		p.logger.WithFields(map[string]interface{}{
+			"segment_id": state.SegmentID,
+			"status":     segment.Status,
+		}).Info("Segment is not active, skipping recompute")
+		return true, nil
+	}
+
+	if state.Version == 0 {
+		state.Version = segment.Version
+	}
+
+	if segment.GeneratedSQL == nil || *segment.GeneratedSQL == "" {
+		return false, fmt.Errorf("segment has no generated SQL - segment may not have been properly initialized")
+	}
+
+	sqlQuery := *segment.GeneratedSQL
+	args := []interface{}(segment.GeneratedArgs)
+
+	if state.TotalContacts == 0 {
+		totalCount, err := p.contactRepo.Count(ctx, task.WorkspaceID)
+		if err != nil {
+			return false, fmt.Errorf("failed to count contacts: %w", err)
+		}
+		state.TotalContacts = totalCount
+	}
+
+	for {
+		// Check if we're approaching timeout
+		if time.Now().Add(5 * time.Second).After(timeoutAt) {
+			p.logger.Info("Approaching timeout, pausing segment recompute")
+			if err := p.saveProgress(ctx, task, state); err != nil {
+				return false, fmt.Errorf("failed to save progress: %w", err)
+			}
+			return false, nil
+		}
+
+		// The segment was updated meanwhile, the build of the new version replaces this recompute
+		currentSegment, err := p.segmentRepo.GetSegmentByID(ctx, task.WorkspaceID, state.SegmentID)
+		if err != nil {
+			return false, fmt.Errorf("failed to refetch segment: %w", err)
+		}
+		if currentSegment.Version > state.Version {
+			p.logger.WithFields(map[string]interface{}{
+				"task_version":     state.Version,
+				"current_version":  currentSegment.Version,
+				"segment_id":       state.SegmentID,
+				"processed_so_far": state.ProcessedCount,
+			}).Info("Segment was updated, aborting outdated recompute")
+			return true, nil
+		}
+
+		emails, err := p.contactRepo.GetBatchForSegment(ctx, task.WorkspaceID, state.ContactOffset, state.BatchSize)
+		if err != nil {
+			return false, fmt.Errorf("failed to fetch email batch: %w", err)
+		}
+
+		if len(emails) == 0 {
+			break
+		}
+
+		if err := p.processBatch(ctx, task.WorkspaceID, sqlQuery, args, emails, state); err != nil {
+			return false, fmt.Errorf("failed to process batch: %w", err)
+		}
+
+		state.ContactOffset += int64(len(emails))
+		state.ProcessedCount += len(emails)
+
+		if state.TotalContacts > 0 {
+			task.Progress = float64(state.ProcessedCount) / float64(state.TotalContacts)
+		}
+
+		if err := p.saveProgress(ctx, task, state); err != nil {
+			p.logger.WithField("error", err.Error()).Warn("Failed to save progress (non-fatal)")
+		}
+	}
+
+	// Segments with relative date filters are recomputed daily
+	if segment.RecomputeAfter != nil {
+		next5AM, err := calculateNext5AMInTimezone(segment.Timezone)
+		if err != nil {
+			p.logger.WithFields(map[string]interface{}{
+				"error":      err.Error(),
+				"segment_id": state.SegmentID,
+				"timezone":   segment.Timezone,
+			}).Warn("Failed to calculate next 5AM for recompute rescheduling (non-fatal)")
+		} else if err := p.segmentRepo.UpdateRecomputeAfter(ctx, task.WorkspaceID, state.SegmentID, &next5AM); err != nil {
+			p.logger.WithFields(map[string]interface{}{
+				"error":      err.Error(),
+				"segment_id": state.SegmentID,
+			}).Warn("Failed to update recompute_after for segment (non-fatal)")
+		}
+	}
+
+	p.logger.WithFields(map[string]interface{}{
+		"segment_id":     state.SegmentID,
+		"version":        state.Version,
+		"total_contacts": state.TotalContacts,
+		"added_count":    state.AddedCount,
+		"removed_count":  state.RemovedCount,
+	}).Info("Segment recompute completed")
+
+	return true, nil
+}
+
+// processBatch evaluates a batch of emails against the segment criteria and applies the
+// difference with their current memberships
+func (p *SegmentRecomputeProcessor) processBatch(
+	ctx context.Context,
+	workspaceID string,
+	sqlQuery string,
+	args []interface{},
+	emails []string,
+	state *domain.RecomputeSegmentState,
+) error {
+	// Evaluation time orders this result against concurrent delta updates of the same contacts
+	evaluatedAt := time.Now().UTC()
+
+	matched, err := p.matchEmails(ctx, workspaceID, sqlQuery, args, emails)
+	if err != nil {
+		return err
+	}
+
+	members, err := p.segmentRepo.GetSegmentMembers(ctx, workspaceID, state.SegmentID, emails)
+	if err != nil {
+		return fmt.Errorf("failed to fetch segment members: %w", err)
+	}
+	isMember := make(map[string]bool, len(members))
+	for _, email := range members {
+		isMember[email] = true
+	}
+
+	retained := make([]string, 0, len(members))
+	for _, email := range emails {
+		switch {
+		case matched[email] && isMember[email]:
+			retained = append(retained, email)
+		case matched[email]:
+			if err := p.segmentRepo.AddContactToSegment(ctx, workspaceID, email, state.SegmentID, state.Version, evaluatedAt); err != nil {
+				p.logger.WithFields(map[string]interface{}{
+					"error": err.Error(),
+					"email": email,
+				}).Warn("Failed to add contact to segment")
+				continue
+			}
+			state.AddedCount++
+		case isMember[email]:
+			if err := p.segmentRepo.RemoveContactFromSegment(ctx, workspaceID, email, state.SegmentID, state.Version, evaluatedAt); err != nil {
+				p.logger.WithFields(map[string]interface{}{
+					"error": err.Error(),
+					"email": email,
+				}).Warn("Failed to remove contact from segment")
+				continue
+			}
+			state.RemovedCount++
+		}
+	}
+
+	if err := p.segmentRepo.TouchSegmentMemberships(ctx, workspaceID, state.SegmentID, retained, state.Version, evaluatedAt); err != nil {
+		return fmt.Errorf("failed to update retained memberships: %w", err)
+	}
+
+	return nil
+}
+
+// matchEmails returns the emails of the batch that match the segment query
+func (p *SegmentRecomputeProcessor) matchEmails(ctx context.Context, workspaceID string, sqlQuery string, args []interface{}, emails []string) (map[string]bool, error) {
+	batchQuery := sqlQuery + fmt.Sprintf(" AND email = ANY($%d)", len(args)+1)
+	batchArgs := make([]interface{}, 0, len(args)+1)
+	batchArgs = append(batchArgs, args...)
+	batchArgs = append(batchArgs, pq.Array(emails))
+
+	workspaceDB, err := p.workspaceRepo.GetConnection(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get workspace database connection: %w", err)
+	}
+
+	rows, err := workspaceDB.QueryContext(ctx, batchQuery, batchArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute segment query: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	matched := make(map[string]bool)
+	for rows.Next() {
+		var email string
+		if err := rows.Scan(&email); err != nil {
+			return nil, fmt.Errorf("failed to scan email: %w", err)
+		}
+		matched[email] = true
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return matched, nil
+}
+
+// saveProgress saves the current progress of the segment recompute
+func (p *SegmentRecomputeProcessor) saveProgress(ctx context.Context, task *domain.Task, state *domain.RecomputeSegmentState) error {
+	task.State.Message = fmt.Sprintf("Recomputing contacts: %d processed, %d added, %d removed", state.ProcessedCount, state.AddedCount, state.RemovedCount)
+
+	if err := p.taskRepo.SaveState(ctx, task.WorkspaceID, task.ID, task.Progress, task.State); err != nil {
+		return fmt.Errorf("failed to save task state: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,186 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/domain/mocks"
+	pkgmocks "github.com/Notifuse/notifuse/pkg/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type segmentRecomputeTestMocks struct {
+	segmentRepo   *mocks.MockSegmentRepository
+	contactRepo   *mocks.MockContactRepository
+	taskRepo      *mocks.MockTaskRepository
+	workspaceRepo *mocks.MockWorkspaceRepository
+}
+
+func setupSegmentRecomputeProcessorTest(t *testing.T) (*SegmentRecomputeProcessor, segmentRecomputeTestMocks) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	m := segmentRecomputeTestMocks{
+		segmentRepo:   mocks.NewMockSegmentRepository(ctrl),
+		contactRepo:   mocks.NewMockContactRepository(ctrl),
+		taskRepo:      mocks.NewMockTaskRepository(ctrl),
+		workspaceRepo: mocks.NewMockWorkspaceRepository(ctrl),
+	}
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().Info(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Warn(gomock.Any()).AnyTimes()
+
+	processor := NewSegmentRecomputeProcessor(m.segmentRepo, m.contactRepo, m.taskRepo, m.workspaceRepo, mockLogger)
+	return processor, m
+}
+
+func recomputeSegmentTask(state *domain.RecomputeSegmentState) *domain.Task {
+	return &domain.Task{
+		ID:          "task1",
+		WorkspaceID: "workspace1",
+		Type:        "recompute_segment",
+		State:       &domain.TaskState{RecomputeSegment: state},
+	}
+}
+
+func TestSegmentRecomputeProcessor_CanProcess(t *testing.T) {
+	processor, _ := setupSegmentRecomputeProcessorTest(t)
+
+	assert.True(t, processor.CanProcess("recompute_segment"))
+	assert.False(t, processor.CanProcess("build_segment"))
+	assert.False(t, processor.CanProcess("check_segment_recompute"))
+}
+
+func TestSegmentRecomputeProcessor_Process_MissingState(t *testing.T) {
+	processor, _ := setupSegmentRecomputeProcessorTest(t)
+
+	completed, err := processor.Process(context.Background(), recomputeSegmentTask(nil), time.Now().Add(time.Minute))
+	assert.False(t, completed)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "missing RecomputeSegment data")
+
+	completed, err = processor.Process(context.Background(), recomputeSegmentTask(&domain.RecomputeSegmentState{}), time.Now().Add(time.Minute))
+	assert.False(t, completed)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "missing segment_id")
+}
+
+func TestSegmentRecomputeProcessor_Process_AppliesMembershipDiff(t *testing.T) {
+	processor, m := setupSegmentRecomputeProcessorTest(t)
+	ctx := context.Background()
+
+	segment := createTestSegmentWithSQL("segment1", "Test Segment", 2, string(domain.SegmentStatusActive))
+	m.segmentRepo.EXPECT().GetSegmentByID(ctx, "workspace1", "segment1").Return(segment, nil).AnyTimes()
+	m.contactRepo.EXPECT().Count(ctx, "workspace1").Return(3, nil)
+
+	emails := []string{"changed@test.com", "kept@test.com", "left@example.com"}
+	m.contactRepo.EXPECT().GetBatchForSegment(ctx, "workspace1", int64(0), 100).Return(emails, nil)
+	m.contactRepo.EXPECT().GetBatchForSegment(ctx, "workspace1", int64(3), 100).Return([]string{}, nil)
+
+	// changed@test.com now matches, left@example.com no longer does
+	db, sqlMock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer func() { _ = db.Close() }()
+	sqlMock.ExpectQuery(`SELECT email FROM contacts WHERE email LIKE \$1 AND email = ANY\(\$2\)`).
+		WithArgs("%test%", sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"email"}).AddRow("changed@test.com").AddRow("kept@test.com"))
+	m.workspaceRepo.EXPECT().GetConnection(ctx, "workspace1").Return(db, nil)
+
+	m.segmentRepo.EXPECT().GetSegmentMembers(ctx, "workspace1", "segment1", emails).
+		Return([]string{"kept@test.com", "left@example.com"}, nil)
+
+	// Only the difference is written, the kept membership is updated in place
+	m.segmentRepo.EXPECT().AddContactToSegment(ctx, "workspace1", "changed@test.com", "segment1", int64(2), gomock.Any()).Return(nil)
+	m.segmentRepo.EXPECT().RemoveContactFromSegment(ctx, "workspace1", "left@example.com", "segment1", int64(2), gomock.Any()).Return(nil)
+	m.segmentRepo.EXPECT().TouchSegmentMemberships(ctx, "workspace1", "segment1", []string{"kept@test.com"}, int64(2), gomock.Any()).Return(nil)
+	m.segmentRepo.EXPECT().RemoveOldMemberships(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+	m.segmentRepo.EXPECT().UpdateSegment(gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+
+	m.taskRepo.EXPECT().SaveState(ctx, "workspace1", "task1", gomock.Any(), gomock.Any()).Return(nil)
+
+	state := &domain.RecomputeSegmentState{SegmentID: "segment1"}
+	completed, err := processor.Process(ctx, recomputeSegmentTask(state), time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.True(t, completed)
+
+	assert.Equal(t, int64(2), state.Version)
+	assert.Equal(t, 3, state.ProcessedCount)
+	assert.Equal(t, 1, state.AddedCount)
+	assert.Equal(t, 1, state.RemovedCount)
+	assert.NoError(t, sqlMock.ExpectationsWereMet())
+}
+
+func TestSegmentRecomputeProcessor_Process_ResumesFromOffset(t *testing.T) {
+	processor, m := setupSegmentRecomputeProcessorTest(t)
+	ctx := context.Background()
+
+	segment := createTestSegmentWithSQL("segment1", "Test Segment", 2, string(domain.SegmentStatusActive))
+	m.segmentRepo.EXPECT().GetSegmentByID(ctx, "workspace1", "segment1").Return(segment, nil).AnyTimes()
+
+	// The saved state skips counting and the batches already processed
+	m.contactRepo.EXPECT().GetBatchForSegment(ctx, "workspace1", int64(200), 100).Return([]string{}, nil)
+
+	state := &domain.RecomputeSegmentState{
+		SegmentID:      "segment1",
+		Version:        2,
+		TotalContacts:  200,
+		ProcessedCount: 200,
+		AddedCount:     4,
+		ContactOffset:  200,
+		BatchSize:      100,
+	}
+	completed, err := processor.Process(ctx, recomputeSegmentTask(state), time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.True(t, completed)
+	assert.Equal(t, 4, state.AddedCount)
+}
+
+func TestSegmentRecomputeProcessor_Process_Timeout(t *testing.T) {
+	processor, m := setupSegmentRecomputeProcessorTest(t)
+	ctx := context.Background()
+
+	segment := createTestSegmentWithSQL("segment1", "Test Segment", 2, string(domain.SegmentStatusActive))
+	m.segmentRepo.EXPECT().GetSegmentByID(ctx, "workspace1", "segment1").Return(segment, nil)
+	m.contactRepo.EXPECT().Count(ctx, "workspace1").Return(500, nil)
+	m.taskRepo.EXPECT().SaveState(ctx, "workspace1", "task1", gomock.Any(), gomock.Any()).Return(nil)
+
+	state := &domain.RecomputeSegmentState{SegmentID: "segment1"}
+	completed, err := processor.Process(ctx, recomputeSegmentTask(state), time.Now().Add(time.Second))
+	require.NoError(t, err)
+	assert.False(t, completed, "the task resumes from its saved state")
+	assert.Equal(t, 500, state.TotalContacts)
+}
+
+func TestSegmentRecomputeProcessor_Process_SegmentNotActive(t *testing.T) {
+	processor, m := setupSegmentRecomputeProcessorTest(t)
+	ctx := context.Background()
+
+	segment := createTestSegmentWithSQL("segment1", "Test Segment", 2, string(domain.SegmentStatusBuilding))
+	m.segmentRepo.EXPECT().GetSegmentByID(ctx, "workspace1", "segment1").Return(segment, nil)
+
+	completed, err := processor.Process(ctx, recomputeSegmentTask(&domain.RecomputeSegmentState{SegmentID: "segment1"}), time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.True(t, completed)
+}
+
+func TestSegmentRecomputeProcessor_Process_VersionChanged(t *testing.T) {
+	processor, m := setupSegmentRecomputeProcessorTest(t)
+	ctx := context.Background()
+
+	segment := createTestSegmentWithSQL("segment1", "Test Segment", 3, string(domain.SegmentStatusActive))
+	m.segmentRepo.EXPECT().GetSegmentByID(ctx, "workspace1", "segment1").Return(segment, nil).Times(2)
+
+	// The segment was updated since the recompute started, the build of version 3 takes over
+	state := &domain.RecomputeSegmentState{SegmentID: "segment1", Version: 2, TotalContacts: 10}
+	completed, err := processor.Process(ctx, recomputeSegmentTask(state), time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.True(t, completed)
+	assert.Equal(t, 0, state.ProcessedCount)
+}
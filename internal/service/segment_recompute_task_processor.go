@@ -41,7 +41,7 @@ func (p *SegmentRecomputeTaskProcessor) CanProcess(taskType string) bool {
 
 // Process executes the segment recompute checking task
 // This task is permanent and recurring - it always reschedules itself for the next run
-// It checks for segments due for recomputation and creates build tasks for them
+// It checks for segments due for recomputation and creates recompute tasks for them
 func (p *SegmentRecomputeTaskProcessor) Process(ctx context.Context, task *domain.Task, timeoutAt time.Time) (bool, error) {
 	p.logger.WithFields(map[string]interface{}{
 		"task_id":      task.ID,
@@ -60,18 +60,18 @@ func (p *SegmentRecomputeTaskProcessor) Process(ctx context.Context, task *domai
 		return false, nil
 	}
 
-	// Create build tasks for each segment
+	// Create recompute tasks for each segment
 	tasksCreated := 0
 	for _, segment := range segments {
-		// Create a build_segment task
-		buildTask := &domain.Task{
+		// Create a recompute_segment task, it only writes the membership changes
+		recomputeTask := &domain.Task{
 			ID:          uuid.New().String(),
 			WorkspaceID: task.WorkspaceID,
-			Type:        "build_segment",
+			Type:        "recompute_segment",
 			Status:      domain.TaskStatusPending,
 			Progress:    0,
 			State: &domain.TaskState{
-				BuildSegment: &domain.BuildSegmentState{
+				RecomputeSegment: &domain.RecomputeSegmentState{
 					SegmentID: segment.ID,
 					Version:   segment.Version,
 					BatchSize: 100,
@@ -82,19 +82,19 @@ func (p *SegmentRecomputeTaskProcessor) Process(ctx context.Context, task *domai
 			MaxRetries: 3,
 		}
 
-		if err := p.taskService.CreateTask(ctx, task.WorkspaceID, buildTask); err != nil {
+		if err := p.taskService.CreateTask(ctx, task.WorkspaceID, recomputeTask); err != nil {
 			p.logger.WithFields(map[string]interface{}{
 				"error":      err.Error(),
 				"segment_id": segment.ID,
-			}).Warn("Failed to create build task for segment recompute (continuing)")
+			}).Warn("Failed to create recompute task for segment (continuing)")
 			continue
 		}
 
 		tasksCreated++
 		p.logger.WithFields(map[string]interface{}{
 			"segment_id": segment.ID,
-			"task_id":    buildTask.ID,
-		}).Info("Created build task for segment recompute")
+			"task_id":    recomputeTask.ID,
+		}).Info("Created recompute task for segment")
 	}
 
 	p.logger.WithFields(map[string]interface{}{
@@ -87,14 +87,14 @@ func TestSegmentRecomputeTaskProcessor_Process(t *testing.T) {
 			GetSegmentsDueForRecompute(ctx, "workspace1", 100).
 			Return(segments, nil)
 
-		// Expect build tasks to be created for each segment
+		// Expect recompute tasks to be created for each segment
 		mockTaskService.EXPECT().
 			CreateTask(ctx, "workspace1", gomock.Any()).
 			Do(func(ctx context.Context, workspace string, task *domain.Task) {
-				assert.Equal(t, "build_segment", task.Type)
+				assert.Equal(t, "recompute_segment", task.Type)
 				assert.Equal(t, domain.TaskStatusPending, task.Status)
 				assert.NotNil(t, task.State)
-				assert.NotNil(t, task.State.BuildSegment)
+				assert.NotNil(t, task.State.RecomputeSegment)
 			}).
 			Return(nil).
 			Times(2) // Two segments
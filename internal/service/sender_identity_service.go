@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/pkg/logger"
+)
+
+// SenderIdentityService manages the verified sender identities of workspaces.
+// An identity is registered for a sender of an email integration and is verified once the TXT record
+// returned at registration is found on its domain. The identities are stored in the workspace settings,
+// so that broadcasts check their senders without another lookup.
+type SenderIdentityService struct {
+	workspaceRepo domain.WorkspaceRepository
+	authService   domain.AuthService
+	logger        logger.Logger
+	lookupTXT     func(ctx context.Context, name string) ([]string, error)
+}
+
+// NewSenderIdentityService creates a new sender identity service
+func NewSenderIdentityService(
+	workspaceRepo domain.WorkspaceRepository,
+	authService domain.AuthService,
+	logger logger.Logger,
+) *SenderIdentityService {
+	return &SenderIdentityService{
+		workspaceRepo: workspaceRepo,
+		authService:   authService,
+		logger:        logger,
+		lookupTXT:     net.DefaultResolver.LookupTXT,
+	}
+}
+
+// ListSenderIdentities returns the sender identities registered in a workspace
+func (s *SenderIdentityService) ListSenderIdentities(ctx context.Context, workspaceID string) ([]domain.SenderIdentity, error) {
+	ctx, _, _, err := s.authService.AuthenticateUserForWorkspace(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate user: %w", err)
+	}
+
+	workspace, err := s.workspaceRepo.GetByID(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	identities := workspace.Settings.SenderIdentities
+	if identities == nil {
+		identities = []domain.SenderIdentity{}
+	}
+	return identities, nil
+}
+
+// VerifySenderIdentity registers the identity of a sender of an email integration, or checks the DNS records
+// of its domain when it is pending verification. Only owners can change the identities of a workspace.
+func (s *SenderIdentityService) VerifySenderIdentity(ctx context.Context, req domain.VerifySenderIdentityRequest) (*domain.SenderIdentity, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	ctx, _, userWorkspace, err := s.authService.AuthenticateUserForWorkspace(ctx, req.WorkspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate user: %w", err)
+	}
+	if userWorkspace.Role != "owner" {
+		return nil, &domain.ErrUnauthorized{Message: "user is not an owner of the workspace"}
+	}
+
+	workspace, err := s.workspaceRepo.GetByID(ctx, req.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the senders of the email integrations of the workspace can be verified
+	var sender *domain.EmailSender
+	var integration *domain.Integration
+	for i := range workspace.Integrations {
+		if workspace.Integrations[i].Type != domain.IntegrationTypeEmail {
+			continue
+		}
+		for j := range workspace.Integrations[i].EmailProvider.Senders {
+			if strings.EqualFold(workspace.Integrations[i].EmailProvider.Senders[j].Email, req.Email) {
+				sender = &workspace.Integrations[i].EmailProvider.Senders[j]
+				integration = &workspace.Integrations[i]
+				break
+			}
+		}
+		if sender != nil {
+			break
+		}
+	}
+	if sender == nil {
+		return nil, domain.NewValidationError(fmt.Sprintf("%s is not a sender of an email integration of the workspace", req.Email))
+	}
+
+	now := time.Now().UTC()
+	identity := workspace.Settings.GetSenderIdentity(sender.Email)
+	switch {
+	case identity == nil:
+		token, err := GenerateSecureKey(16)
+		if err != nil {
+			return nil, err
+		}
+		identity, err = domain.NewSenderIdentity(*sender, integration.ID, integration.EmailProvider.Kind, token, now)
+		if err != nil {
+			return nil, err
+		}
+		workspace.Settings.SenderIdentities = append(workspace.Settings.SenderIdentities, *identity)
+		identity = &workspace.Settings.SenderIdentities[len(workspace.Settings.SenderIdentities)-1]
+	case identity.IsVerified():
+		return identity, nil
+	default:
+		identity.Name = sender.Name
+		identity.IntegrationID = integration.ID
+		s.checkDomainAuth(ctx, identity, now)
+	}
+
+	workspace.UpdatedAt = now
+	if err := s.workspaceRepo.Update(ctx, workspace); err != nil {
+		s.logger.WithField("workspace_id", req.WorkspaceID).WithField("error", err.Error()).Error("Failed to save sender identity")
+		return nil, err
+	}
+
+	s.logger.WithFields(map[string]interface{}{
+		"workspace_id": req.WorkspaceID,
+		"email":        identity.Email,
+		"status":       identity.Status,
+	}).Info("Sender identity updated")
+
+	return identity, nil
+}
+
+// checkDomainAuth looks up the TXT records of the domain of a pending identity and verifies it once the
+// ownership record is found. A missing SPF mechanism is reported but does not block the verification.
+func (s *SenderIdentityService) checkDomainAuth(ctx context.Context, identity *domain.SenderIdentity, now time.Time) {
+	auth := &identity.DomainAuth
+	auth.LastCheckedAt = &now
+	auth.LastCheckFailure = ""
+
+	if auth.SPFInclude != "" {
+		auth.SPFConfigured = false
+		if records, err := s.lookupTXT(ctx, auth.Domain); err == nil {
+			for _, record := range records {
+				if strings.HasPrefix(record, "v=spf1") && strings.Contains(record, auth.SPFInclude) {
+					auth.SPFConfigured = true
+					break
+				}
+			}
+		}
+	}
+
+	records, err := s.lookupTXT(ctx, auth.TXTRecordName)
+	if err != nil {
+		auth.LastCheckFailure = fmt.Sprintf("DNS lookup failed for %s: %v", auth.TXTRecordName, err)
+		return
+	}
+	for _, record := range records {
+		if strings.TrimSpace(record) == auth.TXTRecordValue {
+			identity.Status = domain.SenderIdentityStatusVerified
+			identity.VerifiedAt = &now
+			return
+		}
+	}
+	auth.LastCheckFailure = fmt.Sprintf("TXT record %s does not contain %s", auth.TXTRecordName, auth.TXTRecordValue)
+}
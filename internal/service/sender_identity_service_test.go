@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/domain/mocks"
+	pkgmocks "github.com/Notifuse/notifuse/pkg/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSenderIdentityService(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := "workspace-123"
+	owner := &domain.User{ID: "owner-user", Type: domain.UserTypeUser}
+	ownerWorkspace := &domain.UserWorkspace{UserID: owner.ID, WorkspaceID: workspaceID, Role: "owner"}
+
+	newWorkspace := func(identities ...domain.SenderIdentity) *domain.Workspace {
+		return &domain.Workspace{
+			ID: workspaceID,
+			Settings: domain.WorkspaceSettings{
+				Timezone:         "UTC",
+				SenderIdentities: identities,
+			},
+			Integrations: []domain.Integration{
+				{ID: "sms-integration", Type: domain.IntegrationTypeSMS},
+				{ID: "ses-integration", Type: domain.IntegrationTypeEmail, EmailProvider: domain.EmailProvider{
+					Kind:    domain.EmailProviderKindSES,
+					Senders: []domain.EmailSender{{ID: "sender-news", Email: "News@Example.com", Name: "News", IsDefault: true}},
+				}},
+			},
+		}
+	}
+
+	setup := func(t *testing.T) (*SenderIdentityService, *mocks.MockWorkspaceRepository, *mocks.MockAuthService) {
+		ctrl := gomock.NewController(t)
+		t.Cleanup(ctrl.Finish)
+
+		mockRepo := mocks.NewMockWorkspaceRepository(ctrl)
+		mockAuthService := mocks.NewMockAuthService(ctrl)
+		mockLogger := pkgmocks.NewMockLogger(ctrl)
+		mockLogger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(mockLogger).AnyTimes()
+		mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+		mockLogger.EXPECT().Info(gomock.Any()).AnyTimes()
+		mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
+
+		return NewSenderIdentityService(mockRepo, mockAuthService, mockLogger), mockRepo, mockAuthService
+	}
+
+	t.Run("list returns the identities of the workspace", func(t *testing.T) {
+		service, mockRepo, mockAuthService := setup(t)
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, owner, ownerWorkspace, nil)
+		mockRepo.EXPECT().GetByID(ctx, workspaceID).Return(newWorkspace(), nil)
+
+		identities, err := service.ListSenderIdentities(ctx, workspaceID)
+		require.NoError(t, err)
+		assert.NotNil(t, identities)
+		assert.Empty(t, identities)
+	})
+
+	t.Run("first verification registers a pending identity", func(t *testing.T) {
+		service, mockRepo, mockAuthService := setup(t)
+		service.lookupTXT = func(context.Context, string) ([]string, error) {
+			t.Fatal("no DNS lookup expected at registration")
+			return nil, nil
+		}
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, owner, ownerWorkspace, nil)
+		mockRepo.EXPECT().GetByID(ctx, workspaceID).Return(newWorkspace(), nil)
+		mockRepo.EXPECT().Update(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, updated *domain.Workspace) error {
+			require.Len(t, updated.Settings.SenderIdentities, 1)
+			assert.Equal(t, "news@example.com", updated.Settings.SenderIdentities[0].Email)
+			return nil
+		})
+
+		identity, err := service.VerifySenderIdentity(ctx, domain.VerifySenderIdentityRequest{WorkspaceID: workspaceID, Email: "news@example.com"})
+		require.NoError(t, err)
+		assert.Equal(t, domain.SenderIdentityStatusPending, identity.Status)
+		assert.Equal(t, "ses-integration", identity.IntegrationID)
+		assert.Equal(t, "News", identity.Name)
+		assert.Equal(t, "_notifuse.example.com", identity.DomainAuth.TXTRecordName)
+		assert.True(t, strings.HasPrefix(identity.DomainAuth.TXTRecordValue, "notifuse-verification="))
+		assert.Equal(t, "include:amazonses.com", identity.DomainAuth.SPFInclude)
+	})
+
+	t.Run("pending identity is verified once the TXT record is found", func(t *testing.T) {
+		service, mockRepo, mockAuthService := setup(t)
+		pending, err := domain.NewSenderIdentity(domain.EmailSender{Email: "news@example.com"}, "ses-integration", domain.EmailProviderKindSES, "token", time.Now().UTC())
+		require.NoError(t, err)
+
+		service.lookupTXT = func(_ context.Context, name string) ([]string, error) {
+			switch name {
+			case "example.com":
+				return []string{"google-site-verification=x", "v=spf1 include:amazonses.com ~all"}, nil
+			case "_notifuse.example.com":
+				return []string{"notifuse-verification=token"}, nil
+			}
+			return nil, errors.New("no such host")
+		}
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, owner, ownerWorkspace, nil)
+		mockRepo.EXPECT().GetByID(ctx, workspaceID).Return(newWorkspace(*pending), nil)
+		mockRepo.EXPECT().Update(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, updated *domain.Workspace) error {
+			assert.True(t, updated.Settings.SenderIdentities[0].IsVerified())
+			return nil
+		})
+
+		identity, err := service.VerifySenderIdentity(ctx, domain.VerifySenderIdentityRequest{WorkspaceID: workspaceID, Email: "news@example.com"})
+		require.NoError(t, err)
+		assert.Equal(t, domain.SenderIdentityStatusVerified, identity.Status)
+		assert.NotNil(t, identity.VerifiedAt)
+		assert.True(t, identity.DomainAuth.SPFConfigured)
+		assert.NotNil(t, identity.DomainAuth.LastCheckedAt)
+		assert.Empty(t, identity.DomainAuth.LastCheckFailure)
+	})
+
+	t.Run("pending identity stays pending without the TXT record", func(t *testing.T) {
+		service, mockRepo, mockAuthService := setup(t)
+		pending, err := domain.NewSenderIdentity(domain.EmailSender{Email: "news@example.com"}, "ses-integration", domain.EmailProviderKindSES, "token", time.Now().UTC())
+		require.NoError(t, err)
+
+		service.lookupTXT = func(context.Context, string) ([]string, error) {
+			return []string{"notifuse-verification=other"}, nil
+		}
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, owner, ownerWorkspace, nil)
+		mockRepo.EXPECT().GetByID(ctx, workspaceID).Return(newWorkspace(*pending), nil)
+		mockRepo.EXPECT().Update(ctx, gomock.Any()).Return(nil)
+
+		identity, err := service.VerifySenderIdentity(ctx, domain.VerifySenderIdentityRequest{WorkspaceID: workspaceID, Email: "news@example.com"})
+		require.NoError(t, err)
+		assert.Equal(t, domain.SenderIdentityStatusPending, identity.Status)
+		assert.Nil(t, identity.VerifiedAt)
+		assert.False(t, identity.DomainAuth.SPFConfigured)
+		assert.Equal(t, "TXT record _notifuse.example.com does not contain notifuse-verification=token", identity.DomainAuth.LastCheckFailure)
+	})
+
+	t.Run("email that is not a sender is rejected", func(t *testing.T) {
+		service, mockRepo, mockAuthService := setup(t)
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, owner, ownerWorkspace, nil)
+		mockRepo.EXPECT().GetByID(ctx, workspaceID).Return(newWorkspace(), nil)
+
+		_, err := service.VerifySenderIdentity(ctx, domain.VerifySenderIdentityRequest{WorkspaceID: workspaceID, Email: "someone@example.com"})
+		require.Error(t, err)
+		assert.IsType(t, domain.ValidationError{}, err)
+	})
+
+	t.Run("members cannot verify identities", func(t *testing.T) {
+		service, _, mockAuthService := setup(t)
+		member := &domain.UserWorkspace{UserID: "member-user", WorkspaceID: workspaceID, Role: "member"}
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, owner, member, nil)
+
+		_, err := service.VerifySenderIdentity(ctx, domain.VerifySenderIdentityRequest{WorkspaceID: workspaceID, Email: "news@example.com"})
+		require.Error(t, err)
+		assert.IsType(t, &domain.ErrUnauthorized{}, err)
+	})
+}
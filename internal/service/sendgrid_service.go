@@ -0,0 +1,227 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/pkg/logger"
+)
+
+// SendGridService implements the domain.EmailProviderService interface for SendGrid
+type SendGridService struct {
+	httpClient  domain.HTTPClient
+	authService domain.AuthService
+	logger      logger.Logger
+}
+
+// NewSendGridService creates a new instance of SendGridService
+func NewSendGridService(httpClient domain.HTTPClient, authService domain.AuthService, logger logger.Logger) *SendGridService {
+	return &SendGridService{
+		httpClient:  httpClient,
+		authService: authService,
+		logger:      logger,
+	}
+}
+
+type sendGridAddress struct {
+	Email string `json:"email"`
+	Name  string `json:"name,omitempty"`
+}
+
+type sendGridPersonalization struct {
+	To         []sendGridAddress `json:"to"`
+	Cc         []sendGridAddress `json:"cc,omitempty"`
+	Bcc        []sendGridAddress `json:"bcc,omitempty"`
+	CustomArgs map[string]string `json:"custom_args,omitempty"`
+}
+
+type sendGridContent struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type sendGridAttachment struct {
+	Content     string `json:"content"` // base64 encoded
+	Type        string `json:"type,omitempty"`
+	Filename    string `json:"filename"`
+	Disposition string `json:"disposition,omitempty"`
+	ContentID   string `json:"content_id,omitempty"`
+}
+
+type sendGridMailSettings struct {
+	SandboxMode struct {
+		Enable bool `json:"enable"`
+	} `json:"sandbox_mode"`
+}
+
+type sendGridMailRequest struct {
+	Personalizations []sendGridPersonalization `json:"personalizations"`
+	From             sendGridAddress           `json:"from"`
+	ReplyTo          *sendGridAddress          `json:"reply_to,omitempty"`
+	Subject          string                    `json:"subject"`
+	Content          []sendGridContent         `json:"content"`
+	Headers          map[string]string         `json:"headers,omitempty"`
+	Attachments      []sendGridAttachment      `json:"attachments,omitempty"`
+	IPPoolName       string                    `json:"ip_pool_name,omitempty"`
+	MailSettings     *sendGridMailSettings     `json:"mail_settings,omitempty"`
+}
+
+// SendEmail sends an email using the SendGrid v3 mail send API
+// Each message is sent as a single personalization so its custom args (notifuse_message_id
+// and provider tags) are returned with every event of the SendGrid Event Webhook
+func (s *SendGridService) SendEmail(ctx context.Context, request domain.SendEmailProviderRequest) error {
+	// Validate the request
+	if err := request.Validate(); err != nil {
+		return fmt.Errorf("invalid request: %w", err)
+	}
+
+	if request.Provider.SendGrid == nil {
+		return fmt.Errorf("sendgrid provider is not configured")
+	}
+
+	if request.Provider.SendGrid.APIKey == "" {
+		s.logger.Error("SendGrid API key is empty")
+		return fmt.Errorf("sendgrid API key is required")
+	}
+
+	// Custom provider tags are sent as custom args alongside the message ID
+	customArgs := map[string]string{}
+	for key, value := range request.EmailOptions.ProviderTags {
+		customArgs[key] = value
+	}
+	customArgs["notifuse_message_id"] = request.MessageID
+
+	personalization := sendGridPersonalization{
+		To:         []sendGridAddress{{Email: request.To}},
+		CustomArgs: customArgs,
+	}
+
+	// Add CC recipients if specified
+	for _, ccAddr := range request.EmailOptions.CC {
+		if ccAddr != "" {
+			personalization.Cc = append(personalization.Cc, sendGridAddress{Email: ccAddr})
+		}
+	}
+
+	// Add BCC recipients if specified
+	for _, bccAddr := range request.EmailOptions.BCC {
+		if bccAddr != "" {
+			personalization.Bcc = append(personalization.Bcc, sendGridAddress{Email: bccAddr})
+		}
+	}
+
+	contentType := "text/html"
+	if request.PlainTextOnly {
+		contentType = "text/plain"
+	}
+
+	mailRequest := sendGridMailRequest{
+		Personalizations: []sendGridPersonalization{personalization},
+		From: sendGridAddress{
+			Email: request.FromAddress,
+			Name:  request.FromName,
+		},
+		Subject: request.Subject,
+		Content: []sendGridContent{
+			{Type: contentType, Value: request.Content},
+		},
+		IPPoolName: request.Provider.SendGrid.IPPoolName,
+	}
+
+	// Add ReplyTo if specified
+	if request.EmailOptions.ReplyTo != "" {
+		mailRequest.ReplyTo = &sendGridAddress{Email: request.EmailOptions.ReplyTo}
+	}
+
+	// Add RFC-8058 List-Unsubscribe headers for one-click unsubscribe
+	if request.EmailOptions.ListUnsubscribeURL != "" {
+		mailRequest.Headers = map[string]string{
+			"List-Unsubscribe":      fmt.Sprintf("<%s>", request.EmailOptions.ListUnsubscribeURL),
+			"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+		}
+	}
+
+	// Add attachments if specified
+	// SendGrid limits the total message size, including attachments, to 30 MB
+	// https://www.twilio.com/docs/sendgrid/api-reference/mail-send/mail-send
+	for i, att := range request.EmailOptions.Attachments {
+		content, err := att.DecodeContent()
+		if err != nil {
+			return fmt.Errorf("attachment %d: failed to decode content: %w", i, err)
+		}
+
+		contentType := att.ContentType
+		if contentType == "" {
+			contentType = "application/octet-stream"
+		}
+
+		attachment := sendGridAttachment{
+			Content:     att.Content, // Already base64 encoded
+			Type:        contentType,
+			Filename:    att.Filename,
+			Disposition: "attachment",
+		}
+
+		// For inline attachments, set content_id for <img src="cid:filename"> references
+		if att.Disposition == "inline" {
+			attachment.Disposition = "inline"
+			attachment.ContentID = att.Filename
+		}
+
+		mailRequest.Attachments = append(mailRequest.Attachments, attachment)
+
+		// Log size for debugging
+		s.logger.WithField("attachment_size", len(content)).
+			WithField("filename", att.Filename).
+			WithField("disposition", att.Disposition).
+			Debug("Added attachment to SendGrid email")
+	}
+
+	if request.Provider.SendGrid.SandboxMode {
+		mailRequest.MailSettings = &sendGridMailSettings{}
+		mailRequest.MailSettings.SandboxMode.Enable = true
+	}
+
+	// Convert to JSON
+	jsonBody, err := json.Marshal(mailRequest)
+	if err != nil {
+		return fmt.Errorf("failed to marshal SendGrid request: %w", err)
+	}
+
+	// Create HTTP request
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://api.sendgrid.com/v3/mail/send", bytes.NewBuffer(jsonBody))
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("Failed to create request for sending SendGrid email: %v", err))
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+request.Provider.SendGrid.APIKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	// Send the request
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		s.logger.Error(fmt.Sprintf("Failed to execute request for sending SendGrid email: %v", err))
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	// SendGrid answers 202 Accepted (200 OK in sandbox mode) with an empty body
+	if resp.StatusCode != http.StatusAccepted && resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		s.logger.Error(fmt.Sprintf("SendGrid API returned non-OK status code %d: %s", resp.StatusCode, string(body)))
+		return fmt.Errorf("API returned non-OK status code %d: %s", resp.StatusCode, string(body))
+	}
+
+	// The SendGrid message ID prefixes the sg_message_id of every webhook event for this message
+	s.logger.WithField("message_id", request.MessageID).
+		WithField("sendgrid_message_id", resp.Header.Get("X-Message-Id")).
+		Debug("Email sent via SendGrid")
+
+	return nil
+}
@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/domain/mocks"
+	"github.com/Notifuse/notifuse/pkg/logger"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSendGridService_SendEmail(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// Create mocks
+	mockHTTPClient := mocks.NewMockHTTPClient(ctrl)
+	mockAuthService := mocks.NewMockAuthService(ctrl)
+	testLogger := logger.NewLogger()
+
+	// Create service with mocks
+	service := NewSendGridService(mockHTTPClient, mockAuthService, testLogger)
+
+	newRequest := func(provider *domain.EmailProvider, options domain.EmailOptions) domain.SendEmailProviderRequest {
+		return domain.SendEmailProviderRequest{
+			WorkspaceID:   "workspace-123",
+			IntegrationID: "integration-123",
+			MessageID:     "message-123",
+			FromAddress:   "sender@example.com",
+			FromName:      "Test Sender",
+			To:            "recipient@example.com",
+			Subject:       "Test Subject",
+			Content:       "<p>Test Email Content</p>",
+			Provider:      provider,
+			EmailOptions:  options,
+		}
+	}
+
+	t.Run("Successfully send email", func(t *testing.T) {
+		provider := &domain.EmailProvider{
+			Kind: domain.EmailProviderKindSendGrid,
+			SendGrid: &domain.SendGridSettings{
+				APIKey:      "test-api-key",
+				IPPoolName:  "marketing",
+				SandboxMode: true,
+			},
+		}
+
+		mockHTTPClient.EXPECT().
+			Do(gomock.Any()).
+			DoAndReturn(func(req *http.Request) (*http.Response, error) {
+				assert.Equal(t, http.MethodPost, req.Method)
+				assert.Equal(t, "https://api.sendgrid.com/v3/mail/send", req.URL.String())
+				assert.Equal(t, "Bearer test-api-key", req.Header.Get("Authorization"))
+				assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+
+				body, err := io.ReadAll(req.Body)
+				require.NoError(t, err)
+
+				var mailReq map[string]interface{}
+				require.NoError(t, json.Unmarshal(body, &mailReq))
+
+				personalizations := mailReq["personalizations"].([]interface{})
+				require.Len(t, personalizations, 1)
+				personalization := personalizations[0].(map[string]interface{})
+				recipients := personalization["to"].([]interface{})
+				assert.Equal(t, "recipient@example.com", recipients[0].(map[string]interface{})["email"])
+				assert.Len(t, personalization["cc"], 1)
+
+				customArgs := personalization["custom_args"].(map[string]interface{})
+				assert.Equal(t, "message-123", customArgs["notifuse_message_id"])
+				assert.Equal(t, "spring", customArgs["campaign"])
+
+				from := mailReq["from"].(map[string]interface{})
+				assert.Equal(t, "sender@example.com", from["email"])
+				assert.Equal(t, "Test Sender", from["name"])
+				assert.Equal(t, "reply@example.com", mailReq["reply_to"].(map[string]interface{})["email"])
+				assert.Equal(t, "Test Subject", mailReq["subject"])
+				assert.Equal(t, "marketing", mailReq["ip_pool_name"])
+
+				contents := mailReq["content"].([]interface{})
+				assert.Equal(t, "text/html", contents[0].(map[string]interface{})["type"])
+
+				headers := mailReq["headers"].(map[string]interface{})
+				assert.Equal(t, "<https://example.com/unsubscribe>", headers["List-Unsubscribe"])
+
+				mailSettings := mailReq["mail_settings"].(map[string]interface{})
+				assert.Equal(t, true, mailSettings["sandbox_mode"].(map[string]interface{})["enable"])
+
+				resp := mockHTTPResponse(t, http.StatusAccepted, nil)
+				resp.Header = http.Header{"X-Message-Id": []string{"sg-message-id"}}
+				return resp, nil
+			})
+
+		err := service.SendEmail(context.Background(), newRequest(provider, domain.EmailOptions{
+			CC:                 []string{"cc@example.com", ""},
+			ReplyTo:            "reply@example.com",
+			ListUnsubscribeURL: "https://example.com/unsubscribe",
+			ProviderTags:       map[string]string{"campaign": "spring"},
+		}))
+		require.NoError(t, err)
+	})
+
+	t.Run("Missing SendGrid configuration", func(t *testing.T) {
+		err := service.SendEmail(context.Background(), newRequest(&domain.EmailProvider{}, domain.EmailOptions{}))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "sendgrid provider is not configured")
+	})
+
+	t.Run("Missing API key", func(t *testing.T) {
+		provider := &domain.EmailProvider{SendGrid: &domain.SendGridSettings{}}
+
+		err := service.SendEmail(context.Background(), newRequest(provider, domain.EmailOptions{}))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "sendgrid API key is required")
+	})
+
+	t.Run("HTTP client error", func(t *testing.T) {
+		provider := &domain.EmailProvider{SendGrid: &domain.SendGridSettings{APIKey: "test-api-key"}}
+
+		mockHTTPClient.EXPECT().
+			Do(gomock.Any()).
+			Return(nil, errors.New("network error"))
+
+		err := service.SendEmail(context.Background(), newRequest(provider, domain.EmailOptions{}))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to execute request")
+	})
+
+	t.Run("API error response", func(t *testing.T) {
+		provider := &domain.EmailProvider{SendGrid: &domain.SendGridSettings{APIKey: "test-api-key"}}
+
+		mockHTTPClient.EXPECT().
+			Do(gomock.Any()).
+			Return(mockHTTPResponse(t, http.StatusBadRequest, map[string]interface{}{
+				"errors": []map[string]string{{"message": "Does not contain a valid address."}},
+			}), nil)
+
+		err := service.SendEmail(context.Background(), newRequest(provider, domain.EmailOptions{}))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "API returned non-OK status code 400")
+		assert.Contains(t, err.Error(), "Does not contain a valid address.")
+	})
+}
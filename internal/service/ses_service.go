@@ -8,6 +8,7 @@ import (
 	"mime"
 	"mime/multipart"
 	"net/textproto"
+	"sort"
 	"strings"
 	"unicode"
 
@@ -145,6 +146,11 @@ func createSession(config domain.AmazonSESSettings) (*session.Session, error) {
 	})
 }
 
+// sesWebhookTopicName returns the name of the SNS topic receiving the SES events of an integration
+func sesWebhookTopicName(integrationID string) string {
+	return fmt.Sprintf("notifuse-ses-%s", integrationID)
+}
+
 // getClients creates AWS session and returns SES and SNS clients
 func (s *SESService) getClients(config domain.AmazonSESSettings) (domain.SESWebhookClient, domain.SNSWebhookClient, error) {
 	if config.AccessKey == "" || config.SecretKey == "" {
@@ -528,7 +534,7 @@ func (s *SESService) RegisterWebhooks(
 
 	// First, create the SNS topic that will receive the events
 	topicConfig := domain.SESTopicConfig{
-		TopicName:            fmt.Sprintf("notifuse-ses-%s", integrationID),
+		TopicName:            sesWebhookTopicName(integrationID),
 		Protocol:             "https",
 		NotificationEndpoint: webhookURL,
 	}
@@ -843,16 +849,22 @@ func (s *SESService) SendEmail(ctx context.Context, request domain.SendEmailProv
 		}
 	}
 
+	body := &ses.Body{}
+	content := &ses.Content{
+		Charset: aws.String("UTF-8"),
+		Data:    aws.String(request.Content),
+	}
+	if request.PlainTextOnly {
+		body.Text = content
+	} else {
+		body.Html = content
+	}
+
 	// Create the email input
 	input := &ses.SendEmailInput{
 		Destination: destination,
 		Message: &ses.Message{
-			Body: &ses.Body{
-				Html: &ses.Content{
-					Charset: aws.String("UTF-8"),
-					Data:    aws.String(request.Content),
-				},
-			},
+			Body: body,
 			Subject: &ses.Content{
 				Charset: aws.String("UTF-8"),
 				Data:    aws.String(request.Subject),
@@ -903,6 +915,7 @@ func (s *SESService) SendEmail(ctx context.Context, request domain.SendEmailProv
 			},
 		}
 	}
+	input.Tags = append(input.Tags, sesProviderTags(request.EmailOptions.ProviderTags)...)
 
 	// Send the email
 	_, err = sesEmailClient.SendEmailWithContext(ctx, input)
@@ -980,9 +993,13 @@ func (s *SESService) sendRawEmail(ctx context.Context, sesClient domain.SESClien
 	boundary := writer.Boundary()
 	buf.WriteString(fmt.Sprintf("Content-Type: multipart/mixed; boundary=\"%s\"\r\n\r\n", boundary))
 
-	// Add HTML body part
+	// Add HTML body part, or the text body part of plain text emails
 	htmlPart := textproto.MIMEHeader{}
-	htmlPart.Set("Content-Type", "text/html; charset=UTF-8")
+	if request.PlainTextOnly {
+		htmlPart.Set("Content-Type", "text/plain; charset=UTF-8")
+	} else {
+		htmlPart.Set("Content-Type", "text/html; charset=UTF-8")
+	}
 	htmlPart.Set("Content-Transfer-Encoding", "quoted-printable")
 
 	htmlWriter, err := writer.CreatePart(htmlPart)
@@ -1067,6 +1084,11 @@ func (s *SESService) sendRawEmail(ctx context.Context, sesClient domain.SESClien
 		rawInput.ConfigurationSetName = aws.String(configSetName)
 	}
 
+	// Add custom provider tags
+	if tags := sesProviderTags(request.EmailOptions.ProviderTags); len(tags) > 0 {
+		rawInput.Tags = tags
+	}
+
 	// Add BCC addresses if provided (not in raw message headers for privacy)
 	if len(request.EmailOptions.BCC) > 0 {
 		var destinations []*string
@@ -1094,3 +1116,21 @@ func (s *SESService) sendRawEmail(ctx context.Context, sesClient domain.SESClien
 
 	return nil
 }
+
+// sesProviderTags converts provider tags to SES message tags, sorted by name
+func sesProviderTags(providerTags map[string]string) []*ses.MessageTag {
+	names := make([]string, 0, len(providerTags))
+	for name := range providerTags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	tags := make([]*ses.MessageTag, 0, len(names))
+	for _, name := range names {
+		tags = append(tags, &ses.MessageTag{
+			Name:  aws.String(name),
+			Value: aws.String(providerTags[name]),
+		})
+	}
+	return tags
+}
@@ -3293,3 +3293,84 @@ func TestSendRawEmail_WithNonASCIISubject(t *testing.T) {
 	err := service.SendEmail(context.Background(), request)
 	assert.NoError(t, err)
 }
+
+// Test SendEmail - provider tags are sent as SES message tags
+func TestSendEmail_WithProviderTags(t *testing.T) {
+	service, mockSESClient, _, _, _ := createMockSESService(t)
+
+	provider := &domain.EmailProvider{
+		Kind: domain.EmailProviderKindSES,
+		SES: &domain.AmazonSESSettings{
+			AccessKey: "test-access-key",
+			SecretKey: "test-secret-key",
+			Region:    "us-east-1",
+		},
+	}
+
+	mockSESClient.EXPECT().
+		ListConfigurationSetsWithContext(gomock.Any(), gomock.Any()).
+		Return(&ses.ListConfigurationSetsOutput{}, nil)
+
+	mockSESClient.EXPECT().
+		SendEmailWithContext(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, input *ses.SendEmailInput, _ ...request.Option) (*ses.SendEmailOutput, error) {
+			assert.Len(t, input.Tags, 3)
+			assert.Equal(t, "notifuse_message_id", *input.Tags[0].Name)
+			assert.Equal(t, "campaign", *input.Tags[1].Name)
+			assert.Equal(t, "spring-sale", *input.Tags[1].Value)
+			assert.Equal(t, "team", *input.Tags[2].Name)
+			assert.Equal(t, "growth", *input.Tags[2].Value)
+
+			return &ses.SendEmailOutput{}, nil
+		})
+
+	request := domain.SendEmailProviderRequest{
+		WorkspaceID:   "workspace",
+		IntegrationID: "test-integration-id",
+		MessageID:     "test-message-123",
+		FromAddress:   "from@example.com",
+		FromName:      "From",
+		To:            "to@example.com",
+		Subject:       "Subject",
+		Content:       "Content",
+		Provider:      provider,
+		EmailOptions: domain.EmailOptions{
+			ProviderTags: map[string]string{"team": "growth", "campaign": "spring-sale"},
+		},
+	}
+	err := service.SendEmail(context.Background(), request)
+
+	assert.NoError(t, err)
+}
+
+// Test SendEmail - provider tags over the SES limits are rejected before calling SES
+func TestSendEmail_WithOversizeProviderTags(t *testing.T) {
+	service, mockSESClient, _, _, _ := createMockSESService(t)
+
+	tags := make(map[string]string)
+	for i := 0; i < 50; i++ {
+		tags[fmt.Sprintf("tag_%d", i)] = "value"
+	}
+
+	mockSESClient.EXPECT().SendEmailWithContext(gomock.Any(), gomock.Any()).Times(0)
+
+	request := domain.SendEmailProviderRequest{
+		WorkspaceID:   "workspace",
+		IntegrationID: "test-integration-id",
+		MessageID:     "test-message-123",
+		FromAddress:   "from@example.com",
+		FromName:      "From",
+		To:            "to@example.com",
+		Subject:       "Subject",
+		Content:       "Content",
+		Provider: &domain.EmailProvider{
+			Kind: domain.EmailProviderKindSES,
+			SES:  &domain.AmazonSESSettings{Region: "us-east-1"},
+		},
+		EmailOptions: domain.EmailOptions{ProviderTags: tags},
+	}
+	err := service.SendEmail(context.Background(), request)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "SES allows at most 49 custom tags")
+}
@@ -0,0 +1,217 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+)
+
+// getSMTPPoolIdleTimeout returns how long a pooled SMTP connection may stay unused before it is closed.
+// Can be overridden via SMTP_POOL_IDLE_TIMEOUT environment variable.
+// Default is 30 seconds, below the idle timeout of most SMTP servers.
+func getSMTPPoolIdleTimeout() time.Duration {
+	if timeout := os.Getenv("SMTP_POOL_IDLE_TIMEOUT"); timeout != "" {
+		if d, err := time.ParseDuration(timeout); err == nil {
+			return d
+		}
+	}
+	return 30 * time.Second
+}
+
+// getSMTPPoolCommandTimeout returns how long a pooled SMTP connection may take to reset or to send a message.
+// Can be overridden via SMTP_POOL_COMMAND_TIMEOUT environment variable.
+// Default is 60 seconds.
+func getSMTPPoolCommandTimeout() time.Duration {
+	if timeout := os.Getenv("SMTP_POOL_COMMAND_TIMEOUT"); timeout != "" {
+		if d, err := time.ParseDuration(timeout); err == nil {
+			return d
+		}
+	}
+	return 60 * time.Second
+}
+
+// smtpPoolKey identifies the SMTP connections that can be reused for each other. The password is part of
+// the key as a hash, so connections authenticated with a replaced password are not reused.
+type smtpPoolKey struct {
+	host         string
+	port         int
+	username     string
+	passwordHash [sha256.Size]byte
+	useTLS       bool
+}
+
+func newSMTPPoolKey(settings *domain.SMTPSettings) smtpPoolKey {
+	return smtpPoolKey{
+		host:         settings.Host,
+		port:         settings.Port,
+		username:     settings.Username,
+		passwordHash: sha256.Sum256([]byte(settings.Password)),
+		useTLS:       settings.UseTLS,
+	}
+}
+
+type pooledSMTPConnection struct {
+	conn     *smtpConnection
+	lastUsed time.Time
+}
+
+// smtpHostPool holds the connections of a key, slots limits how many of them are open at once
+type smtpHostPool struct {
+	slots          chan struct{}
+	maxConnections int
+	idle           []*pooledSMTPConnection
+}
+
+// smtpPool reuses authenticated SMTP connections across emails sent to the same server as the same user
+type smtpPool struct {
+	mu             sync.Mutex
+	hosts          map[smtpPoolKey]*smtpHostPool
+	idleTimeout    time.Duration
+	commandTimeout time.Duration
+	dial           func(host string, port int, username, password string, useTLS bool) (*smtpConnection, error)
+}
+
+func newSMTPPool() *smtpPool {
+	return &smtpPool{
+		hosts:          make(map[smtpPoolKey]*smtpHostPool),
+		idleTimeout:    getSMTPPoolIdleTimeout(),
+		commandTimeout: getSMTPPoolCommandTimeout(),
+		dial:           dialSMTP,
+	}
+}
+
+// send sends a message on an idle connection of the pool, or on a new one when none is idle.
+// At most settings.MaxConnections connections are open at once for the key, further sends
+// wait for one of them to be released.
+func (p *smtpPool) send(ctx context.Context, settings *domain.SMTPSettings, from string, to []string, msg []byte) error {
+	key := newSMTPPoolKey(settings)
+	host := p.hostPool(key, settings.MaxConnections)
+
+	select {
+	case host.slots <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	defer func() { <-host.slots }()
+
+	conn, err := p.acquire(ctx, host, settings)
+	if err != nil {
+		return err
+	}
+
+	// A connection is only reused after a complete transaction, its state is unknown after an error
+	if err := conn.conn.SetDeadline(p.deadline(ctx)); err != nil {
+		_ = conn.Close()
+		return err
+	}
+	if err := conn.sendMessage(from, to, msg); err != nil {
+		_ = conn.Close()
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	// The pool was replaced by a new connection limit meanwhile, the connection is not counted by it
+	if p.hosts[key] != host {
+		_ = conn.Close()
+		return nil
+	}
+	host.idle = append(host.idle, &pooledSMTPConnection{conn: conn, lastUsed: time.Now()})
+	return nil
+}
+
+// deadline returns the deadline of the next commands on a connection, at most the deadline of the context
+func (p *smtpPool) deadline(ctx context.Context) time.Time {
+	deadline := time.Now().Add(p.commandTimeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		return ctxDeadline
+	}
+	return deadline
+}
+
+// hostPool returns the pool of a key. When the connection limit of the integration changed, the pool is
+// replaced by one with the new limit: its idle connections move to the new pool, the connections in use
+// are closed once released.
+func (p *smtpPool) hostPool(key smtpPoolKey, maxConnections int) *smtpHostPool {
+	if maxConnections < 1 {
+		maxConnections = 1
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	host, ok := p.hosts[key]
+	if ok && host.maxConnections == maxConnections {
+		return host
+	}
+
+	replaced := &smtpHostPool{slots: make(chan struct{}, maxConnections), maxConnections: maxConnections}
+	if ok {
+		replaced.idle = host.idle
+		host.idle = nil
+	}
+	p.hosts[key] = replaced
+	return replaced
+}
+
+// acquire returns the most recently used idle connection that is still open, or a new connection
+func (p *smtpPool) acquire(ctx context.Context, host *smtpHostPool, settings *domain.SMTPSettings) (*smtpConnection, error) {
+	for {
+		pooled := p.popIdle(host)
+		if pooled == nil {
+			break
+		}
+		// The server may have closed the connection meanwhile or stopped answering, it is then replaced by a new one
+		if err := pooled.conn.conn.SetDeadline(p.deadline(ctx)); err != nil {
+			_ = pooled.conn.Close()
+			continue
+		}
+		if code, _, err := pooled.conn.sendCommand("RSET"); err == nil && code == 250 {
+			return pooled.conn, nil
+		}
+		_ = pooled.conn.Close()
+	}
+
+	return p.dial(settings.Host, settings.Port, settings.Username, settings.Password, settings.UseTLS)
+}
+
+// popIdle removes the most recently used idle connection from the pool, closing the expired ones
+func (p *smtpPool) popIdle(host *smtpHostPool) *pooledSMTPConnection {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(host.idle) == 0 {
+		return nil
+	}
+	pooled := host.idle[len(host.idle)-1]
+	host.idle = host.idle[:len(host.idle)-1]
+
+	// Idle connections are ordered by last use, the others expired before this one
+	if time.Since(pooled.lastUsed) > p.idleTimeout {
+		for _, expired := range host.idle {
+			_ = expired.conn.Close()
+		}
+		host.idle = nil
+		_ = pooled.conn.Close()
+		return nil
+	}
+	return pooled
+}
+
+// closeIdle closes the idle connections of the pool
+func (p *smtpPool) closeIdle() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, host := range p.hosts {
+		for _, pooled := range host.idle {
+			_, _, _ = pooled.conn.sendCommand("QUIT")
+			_ = pooled.conn.Close()
+		}
+		host.idle = nil
+	}
+}
@@ -0,0 +1,206 @@
+package service
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func pooledSMTPRequest(port, maxConnections int, to string) domain.SendEmailProviderRequest {
+	return domain.SendEmailProviderRequest{
+		WorkspaceID:   "workspace-123",
+		IntegrationID: "integration-123",
+		MessageID:     "message-" + to,
+		FromAddress:   "sender@example.com",
+		FromName:      "Test Sender",
+		To:            to,
+		Subject:       "Test Subject",
+		Content:       "<p>Hello</p>",
+		Provider: &domain.EmailProvider{
+			Kind: domain.EmailProviderKindSMTP,
+			SMTP: &domain.SMTPSettings{
+				Host:           "127.0.0.1",
+				Port:           port,
+				Username:       "user",
+				Password:       "pass",
+				MaxConnections: maxConnections,
+			},
+		},
+	}
+}
+
+func countCommands(commands []string, prefix string) int {
+	count := 0
+	for _, command := range commands {
+		if strings.HasPrefix(command, prefix) {
+			count++
+		}
+	}
+	return count
+}
+
+func TestSMTPService_SendEmail_ReusesPooledConnection(t *testing.T) {
+	server := newMockSMTPServer(t, true)
+	defer server.Close()
+
+	service := NewSMTPService(&noopLogger{})
+	defer service.pool.closeIdle()
+
+	recipients := []string{"one@example.com", "two@example.com", "three@example.com"}
+	for _, to := range recipients {
+		require.NoError(t, service.SendEmail(context.Background(), pooledSMTPRequest(server.Port(), 2, to)))
+	}
+
+	// The messages are sent on a single authenticated connection
+	assert.Equal(t, 1, server.GetConnectionCount())
+	commands := server.GetCommands()
+	assert.Equal(t, 1, countCommands(commands, "AUTH"))
+	assert.Equal(t, 2, countCommands(commands, "RSET"))
+
+	messages := server.GetMessages()
+	require.Len(t, messages, 3)
+	for i, message := range messages {
+		assert.Equal(t, "sender@example.com", message.from)
+		assert.Contains(t, message.recipients, recipients[i])
+	}
+}
+
+func TestSMTPService_SendEmail_WithoutPool(t *testing.T) {
+	server := newMockSMTPServer(t, true)
+	defer server.Close()
+
+	service := NewSMTPService(&noopLogger{})
+	for _, to := range []string{"one@example.com", "two@example.com"} {
+		require.NoError(t, service.SendEmail(context.Background(), pooledSMTPRequest(server.Port(), 0, to)))
+	}
+
+	// Without max connections each email opens its own connection
+	assert.Equal(t, 2, server.GetConnectionCount())
+	assert.Len(t, server.GetMessages(), 2)
+}
+
+func TestSMTPService_SendEmail_ReconnectsClosedConnection(t *testing.T) {
+	server := newMockSMTPServer(t, true)
+	defer server.Close()
+
+	service := NewSMTPService(&noopLogger{})
+	defer service.pool.closeIdle()
+
+	require.NoError(t, service.SendEmail(context.Background(), pooledSMTPRequest(server.Port(), 1, "one@example.com")))
+
+	// The idle connection is closed before it is reused
+	key := newSMTPPoolKey(pooledSMTPRequest(server.Port(), 1, "").Provider.SMTP)
+	host := service.pool.hosts[key]
+	require.Len(t, host.idle, 1)
+	_ = host.idle[0].conn.conn.Close()
+
+	require.NoError(t, service.SendEmail(context.Background(), pooledSMTPRequest(server.Port(), 1, "two@example.com")))
+	assert.Equal(t, 2, server.GetConnectionCount())
+	assert.Len(t, server.GetMessages(), 2)
+}
+
+func TestSMTPService_SendEmail_ClosesExpiredConnection(t *testing.T) {
+	server := newMockSMTPServer(t, true)
+	defer server.Close()
+
+	service := NewSMTPService(&noopLogger{})
+	defer service.pool.closeIdle()
+	service.pool.idleTimeout = time.Millisecond
+
+	require.NoError(t, service.SendEmail(context.Background(), pooledSMTPRequest(server.Port(), 1, "one@example.com")))
+	time.Sleep(5 * time.Millisecond)
+	require.NoError(t, service.SendEmail(context.Background(), pooledSMTPRequest(server.Port(), 1, "two@example.com")))
+
+	assert.Equal(t, 2, server.GetConnectionCount())
+	assert.Equal(t, 0, countCommands(server.GetCommands(), "RSET"))
+}
+
+func TestSMTPService_SendEmail_WaitsForFreeConnection(t *testing.T) {
+	server := newMockSMTPServer(t, true)
+	defer server.Close()
+
+	service := NewSMTPService(&noopLogger{})
+	defer service.pool.closeIdle()
+
+	// The only connection allowed is in use
+	key := newSMTPPoolKey(pooledSMTPRequest(server.Port(), 1, "").Provider.SMTP)
+	host := service.pool.hostPool(key, 1)
+	host.slots <- struct{}{}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err := service.SendEmail(ctx, pooledSMTPRequest(server.Port(), 1, "one@example.com"))
+	require.Error(t, err)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 0, server.GetConnectionCount())
+
+	// Released, the next email is sent
+	<-host.slots
+	require.NoError(t, service.SendEmail(context.Background(), pooledSMTPRequest(server.Port(), 1, "two@example.com")))
+	assert.Len(t, server.GetMessages(), 1)
+}
+
+func TestSMTPService_SendEmail_NewConnectionAfterPasswordChange(t *testing.T) {
+	server := newMockSMTPServer(t, true)
+	defer server.Close()
+
+	service := NewSMTPService(&noopLogger{})
+	defer service.pool.closeIdle()
+
+	require.NoError(t, service.SendEmail(context.Background(), pooledSMTPRequest(server.Port(), 1, "one@example.com")))
+
+	// The connection authenticated with the previous password is not reused
+	request := pooledSMTPRequest(server.Port(), 1, "two@example.com")
+	request.Provider.SMTP.Password = "new-pass"
+	require.NoError(t, service.SendEmail(context.Background(), request))
+
+	assert.Equal(t, 2, server.GetConnectionCount())
+	assert.Equal(t, 2, countCommands(server.GetCommands(), "AUTH"))
+}
+
+func TestSMTPPool_HostPool_MaxConnectionsChange(t *testing.T) {
+	pool := newSMTPPool()
+	key := newSMTPPoolKey(&domain.SMTPSettings{Host: "127.0.0.1", Port: 25, Username: "user", Password: "pass"})
+
+	host := pool.hostPool(key, 1)
+	assert.Equal(t, 1, cap(host.slots))
+	assert.Same(t, host, pool.hostPool(key, 1))
+
+	// A new limit replaces the pool, keeping its idle connections
+	client, server := net.Pipe()
+	defer server.Close()
+	idle := &pooledSMTPConnection{conn: newSMTPConnection(client), lastUsed: time.Now()}
+	host.idle = append(host.idle, idle)
+
+	replaced := pool.hostPool(key, 3)
+	assert.NotSame(t, host, replaced)
+	assert.Equal(t, 3, cap(replaced.slots))
+	assert.Equal(t, []*pooledSMTPConnection{idle}, replaced.idle)
+	assert.Empty(t, host.idle)
+}
+
+func TestSMTPService_SendEmail_ReplacesUnresponsiveConnection(t *testing.T) {
+	server := newMockSMTPServer(t, true)
+	defer server.Close()
+
+	service := NewSMTPService(&noopLogger{})
+	defer service.pool.closeIdle()
+	service.pool.commandTimeout = 20 * time.Millisecond
+
+	// The idle connection leads to a peer that never answers, its RSET times out
+	client, peer := net.Pipe()
+	defer peer.Close()
+	request := pooledSMTPRequest(server.Port(), 1, "one@example.com")
+	host := service.pool.hostPool(newSMTPPoolKey(request.Provider.SMTP), 1)
+	host.idle = append(host.idle, &pooledSMTPConnection{conn: newSMTPConnection(client), lastUsed: time.Now()})
+
+	require.NoError(t, service.SendEmail(context.Background(), request))
+	assert.Equal(t, 1, server.GetConnectionCount())
+	assert.Len(t, server.GetMessages(), 1)
+}
@@ -114,20 +114,46 @@ func (c *smtpConnection) Close() error {
 // these extensions when the server advertises support, so we need to bypass
 // them by sending raw SMTP commands.
 func sendRawEmail(host string, port int, username, password string, useTLS bool, from string, to []string, msg []byte) error {
+	smtpConn, err := dialSMTP(host, port, username, password, useTLS)
+	if err != nil {
+		return err
+	}
+	defer smtpConn.Close()
+
+	if err := smtpConn.sendMessage(from, to, msg); err != nil {
+		return err
+	}
+
+	// QUIT
+	_, _, _ = smtpConn.sendCommand("QUIT")
+
+	return nil
+}
+
+// dialSMTP connects to an SMTP server and runs the greeting, EHLO, STARTTLS and AUTH steps,
+// returning a connection ready to send messages
+func dialSMTP(host string, port int, username, password string, useTLS bool) (*smtpConnection, error) {
 	addr := fmt.Sprintf("%s:%d", host, port)
 
 	// Connect to SMTP server with configurable timeout
 	dialer := &net.Dialer{Timeout: getSMTPDialTimeout()}
 	conn, err := dialer.Dial("tcp", addr)
 	if err != nil {
-		return fmt.Errorf("failed to connect: %w", err)
+		return nil, fmt.Errorf("failed to connect: %w", err)
 	}
 
 	smtpConn := newSMTPConnection(conn)
-	defer smtpConn.Close()
+	if err := smtpConn.handshake(host, username, password, useTLS); err != nil {
+		_ = smtpConn.Close()
+		return nil, err
+	}
+	return smtpConn, nil
+}
 
+// handshake runs the SMTP session setup, upgrading the connection to TLS when useTLS is set
+func (c *smtpConnection) handshake(host, username, password string, useTLS bool) error {
 	// Read greeting (use multiline to handle RFC 5321 multi-line banners - issue #183)
-	code, err := smtpConn.readMultilineResponse()
+	code, err := c.readMultilineResponse()
 	if err != nil {
 		return fmt.Errorf("failed to read greeting: %w", err)
 	}
@@ -137,7 +163,7 @@ func sendRawEmail(host string, port int, username, password string, useTLS bool,
 
 	// Send EHLO
 	hostname := "localhost"
-	code, err = smtpConn.sendCommandMultiline(fmt.Sprintf("EHLO %s", hostname))
+	code, err = c.sendCommandMultiline(fmt.Sprintf("EHLO %s", hostname))
 	if err != nil {
 		return fmt.Errorf("EHLO failed: %w", err)
 	}
@@ -147,7 +173,7 @@ func sendRawEmail(host string, port int, username, password string, useTLS bool,
 
 	// STARTTLS if enabled
 	if useTLS {
-		code, _, err = smtpConn.sendCommand("STARTTLS")
+		code, _, err = c.sendCommand("STARTTLS")
 		if err != nil {
 			return fmt.Errorf("STARTTLS command failed: %w", err)
 		}
@@ -160,17 +186,17 @@ func sendRawEmail(host string, port int, username, password string, useTLS bool,
 			ServerName: host,
 			MinVersion: tls.VersionTLS12,
 		}
-		tlsConn := tls.Client(conn, tlsConfig)
+		tlsConn := tls.Client(c.conn, tlsConfig)
 		if err := tlsConn.Handshake(); err != nil {
 			return fmt.Errorf("TLS handshake failed: %w", err)
 		}
 
-		// Replace connection with TLS connection
-		smtpConn = newSMTPConnection(tlsConn)
-		defer smtpConn.Close()
+		// Replace connection with TLS connection, closing it also closes the underlying connection
+		c.conn = tlsConn
+		c.reader = bufio.NewReader(tlsConn)
 
 		// Send EHLO again after TLS
-		code, err = smtpConn.sendCommandMultiline(fmt.Sprintf("EHLO %s", hostname))
+		code, err = c.sendCommandMultiline(fmt.Sprintf("EHLO %s", hostname))
 		if err != nil {
 			return fmt.Errorf("EHLO after TLS failed: %w", err)
 		}
@@ -184,7 +210,7 @@ func sendRawEmail(host string, port int, username, password string, useTLS bool,
 		// Use AUTH PLAIN
 		authString := fmt.Sprintf("\x00%s\x00%s", username, password)
 		encoded := base64.StdEncoding.EncodeToString([]byte(authString))
-		code, _, err = smtpConn.sendCommand(fmt.Sprintf("AUTH PLAIN %s", encoded))
+		code, _, err = c.sendCommand(fmt.Sprintf("AUTH PLAIN %s", encoded))
 		if err != nil {
 			return fmt.Errorf("AUTH failed: %w", err)
 		}
@@ -193,8 +219,13 @@ func sendRawEmail(host string, port int, username, password string, useTLS bool,
 		}
 	}
 
+	return nil
+}
+
+// sendMessage runs the mail transaction of one message on an established connection
+func (c *smtpConnection) sendMessage(from string, to []string, msg []byte) error {
 	// MAIL FROM - without any extensions (this is the key fix for issue #172)
-	code, _, err = smtpConn.sendCommand(fmt.Sprintf("MAIL FROM:<%s>", from))
+	code, _, err := c.sendCommand(fmt.Sprintf("MAIL FROM:<%s>", from))
 	if err != nil {
 		return fmt.Errorf("MAIL FROM failed: %w", err)
 	}
@@ -207,7 +238,7 @@ func sendRawEmail(host string, port int, username, password string, useTLS bool,
 		if recipient == "" {
 			continue
 		}
-		code, _, err = smtpConn.sendCommand(fmt.Sprintf("RCPT TO:<%s>", recipient))
+		code, _, err = c.sendCommand(fmt.Sprintf("RCPT TO:<%s>", recipient))
 		if err != nil {
 			return fmt.Errorf("RCPT TO failed for %s: %w", recipient, err)
 		}
@@ -217,7 +248,7 @@ func sendRawEmail(host string, port int, username, password string, useTLS bool,
 	}
 
 	// DATA
-	code, _, err = smtpConn.sendCommand("DATA")
+	code, _, err = c.sendCommand("DATA")
 	if err != nil {
 		return fmt.Errorf("DATA command failed: %w", err)
 	}
@@ -227,17 +258,17 @@ func sendRawEmail(host string, port int, username, password string, useTLS bool,
 
 	// Send message body
 	// Ensure proper line endings and dot-stuffing
-	if _, err := smtpConn.conn.Write(msg); err != nil {
+	if _, err := c.conn.Write(msg); err != nil {
 		return fmt.Errorf("failed to write message: %w", err)
 	}
 
 	// End message with CRLF.CRLF
-	if _, err := fmt.Fprintf(smtpConn.conn, "\r\n.\r\n"); err != nil {
+	if _, err := fmt.Fprintf(c.conn, "\r\n.\r\n"); err != nil {
 		return fmt.Errorf("failed to write message terminator: %w", err)
 	}
 
 	// Read response after DATA
-	code, _, err = smtpConn.readResponse()
+	code, _, err = c.readResponse()
 	if err != nil {
 		return fmt.Errorf("failed to read DATA response: %w", err)
 	}
@@ -245,21 +276,21 @@ func sendRawEmail(host string, port int, username, password string, useTLS bool,
 		return fmt.Errorf("message rejected with code: %d", code)
 	}
 
-	// QUIT
-	_, _, _ = smtpConn.sendCommand("QUIT")
-
 	return nil
 }
 
 // SMTPService implements the domain.EmailProviderService interface for SMTP
 type SMTPService struct {
 	logger logger.Logger
+	// pool reuses the connections of SMTP integrations with a max connections setting
+	pool *smtpPool
 }
 
 // NewSMTPService creates a new instance of SMTPService
 func NewSMTPService(logger logger.Logger) *SMTPService {
 	return &SMTPService{
 		logger: logger,
+		pool:   newSMTPPool(),
 	}
 }
 
@@ -338,7 +369,11 @@ func (s *SMTPService) SendEmail(ctx context.Context, request domain.SendEmailPro
 	}
 
 	msg.Subject(request.Subject)
-	msg.SetBodyString(mail.TypeTextHTML, request.Content)
+	if request.PlainTextOnly {
+		msg.SetBodyString(mail.TypeTextPlain, request.Content)
+	} else {
+		msg.SetBodyString(mail.TypeTextHTML, request.Content)
+	}
 
 	// Add attachments if specified
 	for i, att := range request.EmailOptions.Attachments {
@@ -377,6 +412,14 @@ func (s *SMTPService) SendEmail(ctx context.Context, request domain.SendEmailPro
 		return fmt.Errorf("failed to write message: %w", err)
 	}
 
+	// Reuse a pooled connection when the integration allows several emails per connection
+	if smtpSettings.MaxConnections > 0 {
+		if err := s.pool.send(ctx, smtpSettings, request.FromAddress, recipients, buf.Bytes()); err != nil {
+			return fmt.Errorf("failed to send email: %w", err)
+		}
+		return nil
+	}
+
 	// Send using native net/smtp (avoids BODY=8BITMIME extension issues - fix for issue #172)
 	if err := sendRawEmail(
 		smtpSettings.Host,
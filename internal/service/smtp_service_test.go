@@ -26,6 +26,7 @@ type mockSMTPServer struct {
 	wg              sync.WaitGroup
 	mailFromCmd     string // captures the exact MAIL FROM command
 	multilineBanner bool   // send multi-line 220 banner (RFC 5321 compliant)
+	connections     int    // number of connections accepted
 }
 
 type capturedMessage struct {
@@ -92,6 +93,10 @@ func (s *mockSMTPServer) handleConnection(conn net.Conn) {
 	defer s.wg.Done()
 	defer conn.Close()
 
+	s.mu.Lock()
+	s.connections++
+	s.mu.Unlock()
+
 	reader := bufio.NewReader(conn)
 
 	// Send greeting (multi-line or single-line based on configuration)
@@ -229,6 +234,12 @@ func (s *mockSMTPServer) GetMessages() []capturedMessage {
 	return result
 }
 
+func (s *mockSMTPServer) GetConnectionCount() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.connections
+}
+
 func (s *mockSMTPServer) GetCommands() []string {
 	s.mu.Lock()
 	defer s.mu.Unlock()
@@ -607,6 +618,45 @@ func TestSMTPService_SendEmail_WithListUnsubscribe(t *testing.T) {
 	assert.Contains(t, string(messages[0].data), "List-Unsubscribe-Post:")
 }
 
+func TestSMTPService_SendEmail_PlainTextOnly(t *testing.T) {
+	server := newMockSMTPServer(t, true)
+	defer server.Close()
+
+	log := &noopLogger{}
+	service := NewSMTPService(log)
+
+	provider := &domain.EmailProvider{
+		Kind: domain.EmailProviderKindSMTP,
+		SMTP: &domain.SMTPSettings{
+			Host:   "127.0.0.1",
+			Port:   server.Port(),
+			UseTLS: false,
+		},
+	}
+
+	request := domain.SendEmailProviderRequest{
+		WorkspaceID:   "workspace-123",
+		IntegrationID: "integration-123",
+		MessageID:     "message-123",
+		FromAddress:   "sender@example.com",
+		FromName:      "Test Sender",
+		To:            "recipient@example.com",
+		Subject:       "Test Subject",
+		Content:       "Hello in plain text",
+		PlainTextOnly: true,
+		Provider:      provider,
+	}
+
+	err := service.SendEmail(context.Background(), request)
+	require.NoError(t, err)
+
+	messages := server.GetMessages()
+	require.Len(t, messages, 1)
+	assert.Contains(t, string(messages[0].data), "text/plain")
+	assert.NotContains(t, string(messages[0].data), "text/html")
+	assert.Contains(t, string(messages[0].data), "Hello in plain text")
+}
+
 func TestSMTPService_SendEmail_InlineAttachment(t *testing.T) {
 	server := newMockSMTPServer(t, true)
 	defer server.Close()
@@ -809,7 +809,8 @@ func (s *SparkPostService) SendEmail(ctx context.Context, request domain.SendEma
 		From         From              `json:"from"`
 		Subject      string            `json:"subject"`
 		ReplyTo      string            `json:"reply_to,omitempty"`
-		HTML         string            `json:"html"`
+		HTML         string            `json:"html,omitempty"`
+		Text         string            `json:"text,omitempty"`
 		Headers      map[string]string `json:"headers,omitempty"`
 		Attachments  []Attachment      `json:"attachments,omitempty"`
 		InlineImages []InlineImage     `json:"inline_images,omitempty"`
@@ -841,12 +842,21 @@ func (s *SparkPostService) SendEmail(ctx context.Context, request domain.SendEma
 				Email: request.FromAddress,
 			},
 			Subject: request.Subject,
-			HTML:    request.Content,
 		},
 		Metadata: map[string]interface{}{
 			"notifuse_message_id": request.MessageID,
 		},
 	}
+	if request.PlainTextOnly {
+		emailReq.Content.Text = request.Content
+	} else {
+		emailReq.Content.HTML = request.Content
+	}
+
+	// Add custom provider tags as metadata
+	for key, value := range request.EmailOptions.ProviderTags {
+		emailReq.Metadata[key] = value
+	}
 
 	// Tracking should be disabled as we already do it
 	emailReq.Options.OpenTracking = false
@@ -3130,3 +3130,68 @@ func TestSparkPostService_MissingConfig(t *testing.T) {
 		assert.Contains(t, err.Error(), "configuration is missing or invalid")
 	})
 }
+
+func TestSparkPostService_SendEmail_ProviderTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockHTTPClient := mocks.NewMockHTTPClient(ctrl)
+	mockAuthService := mocks.NewMockAuthService(ctrl)
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+	mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Info(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(mockLogger).AnyTimes()
+
+	sparkPostService := service.NewSparkPostService(mockHTTPClient, mockAuthService, mockLogger)
+
+	provider := &domain.EmailProvider{
+		Kind: domain.EmailProviderKindSparkPost,
+		SparkPost: &domain.SparkPostSettings{
+			Endpoint: "https://api.sparkpost.test",
+			APIKey:   "test-api-key",
+		},
+	}
+
+	newRequest := func(tags map[string]string) domain.SendEmailProviderRequest {
+		return domain.SendEmailProviderRequest{
+			WorkspaceID:   "workspace-123",
+			IntegrationID: "test-integration-id",
+			MessageID:     "test-message-id",
+			FromAddress:   "sender@example.com",
+			FromName:      "Sender",
+			To:            "recipient@example.com",
+			Subject:       "Subject",
+			Content:       "Content",
+			Provider:      provider,
+			EmailOptions:  domain.EmailOptions{ProviderTags: tags},
+		}
+	}
+
+	t.Run("Tags are sent as metadata", func(t *testing.T) {
+		mockHTTPClient.EXPECT().
+			Do(gomock.Any()).
+			DoAndReturn(func(req *http.Request) (*http.Response, error) {
+				body, _ := io.ReadAll(req.Body)
+				var emailReq map[string]interface{}
+				assert.NoError(t, json.Unmarshal(body, &emailReq))
+
+				metadata, ok := emailReq["metadata"].(map[string]interface{})
+				assert.True(t, ok)
+				assert.Equal(t, "test-message-id", metadata["notifuse_message_id"])
+				assert.Equal(t, "spring-sale", metadata["campaign"])
+
+				return mockHTTPResponse(http.StatusOK, `{"results":{"id":"test-transmission-id"}}`), nil
+			})
+
+		err := sparkPostService.SendEmail(context.Background(), newRequest(map[string]string{"campaign": "spring-sale"}))
+		assert.NoError(t, err)
+	})
+
+	t.Run("Oversize metadata is rejected", func(t *testing.T) {
+		mockHTTPClient.EXPECT().Do(gomock.Any()).Times(0)
+
+		err := sparkPostService.SendEmail(context.Background(), newRequest(map[string]string{"campaign": string(bytes.Repeat([]byte("a"), 1000))}))
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "exceed 1000 bytes")
+	})
+}
@@ -117,6 +117,40 @@ func (s *SystemNotificationService) HandleCircuitBreakerEvent(ctx context.Contex
 	}
 }
 
+// HandleSendingBlockedEvent notifies the workspace owners that a complaint spike blocked sending
+func (s *SystemNotificationService) HandleSendingBlockedEvent(ctx context.Context, payload domain.EventPayload) {
+	reason, ok := payload.Data["reason"].(string)
+	if !ok || reason == "" {
+		s.logger.WithFields(map[string]interface{}{
+			"event_type":   payload.Type,
+			"workspace_id": payload.WorkspaceID,
+		}).Error("Sending blocked event missing reason")
+		return
+	}
+
+	workspace, err := s.workspaceRepo.GetByID(ctx, payload.WorkspaceID)
+	if err != nil {
+		s.logger.WithFields(map[string]interface{}{
+			"event_type":   payload.Type,
+			"workspace_id": payload.WorkspaceID,
+			"error":        err.Error(),
+		}).Error("Failed to get workspace for sending blocked notification")
+		return
+	}
+
+	err = s.notifyWorkspaceOwners(ctx, payload.WorkspaceID, func(ownerEmail string) error {
+		return s.mailer.SendSendingBlockedAlert(ownerEmail, workspace.Name, reason)
+	})
+
+	if err != nil {
+		s.logger.WithFields(map[string]interface{}{
+			"event_type":   payload.Type,
+			"workspace_id": payload.WorkspaceID,
+			"error":        err.Error(),
+		}).Error("Failed to send sending blocked notifications to workspace owners")
+	}
+}
+
 // HandleBroadcastFailedEvent processes broadcast failure events (placeholder for future use)
 func (s *SystemNotificationService) HandleBroadcastFailedEvent(ctx context.Context, payload domain.EventPayload) {
 	s.logger.WithFields(map[string]interface{}{
@@ -195,6 +229,9 @@ func (s *SystemNotificationService) RegisterWithEventBus(eventBus domain.EventBu
 	// Register for circuit breaker events
 	eventBus.Subscribe(domain.EventBroadcastCircuitBreaker, s.HandleCircuitBreakerEvent)
 
+	// Register for workspace sending blocks raised by the complaint spike monitor
+	eventBus.Subscribe(domain.EventWorkspaceSendingBlocked, s.HandleSendingBlockedEvent)
+
 	// Register for broadcast failure events (for future use)
 	eventBus.Subscribe(domain.EventBroadcastFailed, s.HandleBroadcastFailedEvent)
 
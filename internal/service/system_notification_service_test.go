@@ -239,6 +239,40 @@ func TestSystemNotificationService_HandleCircuitBreakerEvent(t *testing.T) {
 	})
 }
 
+func TestSystemNotificationService_HandleSendingBlockedEvent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	mockMailer := pkgmocks.NewMockMailer(ctrl)
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().Info(gomock.Any()).AnyTimes()
+
+	service := NewSystemNotificationService(mockWorkspaceRepo, mocks.NewMockBroadcastRepository(ctrl), mockMailer, mockLogger)
+	ctx := context.Background()
+
+	t.Run("Success - Owners notified", func(t *testing.T) {
+		block := &domain.SendingBlock{Reason: "Complaint rate 0.80% over the last 1h0m0s exceeds 0.30%", Sent: 1000, Complaints: 8}
+		payload := domain.NewWorkspaceSendingBlockedEvent("workspace-123", block, []string{"broadcast-1"})
+
+		mockWorkspaceRepo.EXPECT().GetByID(ctx, "workspace-123").Return(&domain.Workspace{ID: "workspace-123", Name: "Test Workspace"}, nil)
+		mockWorkspaceRepo.EXPECT().GetWorkspaceUsersWithEmail(ctx, "workspace-123").Return([]*domain.UserWorkspaceWithEmail{
+			{UserWorkspace: domain.UserWorkspace{UserID: "user-1", WorkspaceID: "workspace-123", Role: "owner"}, Email: "owner@example.com"},
+			{UserWorkspace: domain.UserWorkspace{UserID: "user-2", WorkspaceID: "workspace-123", Role: "member"}, Email: "member@example.com"},
+		}, nil)
+		mockMailer.EXPECT().SendSendingBlockedAlert("owner@example.com", "Test Workspace", block.Reason).Return(nil)
+
+		service.HandleSendingBlockedEvent(ctx, payload)
+	})
+
+	t.Run("Error - Missing reason", func(t *testing.T) {
+		mockLogger.EXPECT().Error("Sending blocked event missing reason")
+
+		service.HandleSendingBlockedEvent(ctx, domain.EventPayload{Type: domain.EventWorkspaceSendingBlocked, WorkspaceID: "workspace-123"})
+	})
+}
+
 func TestSystemNotificationService_HandleBroadcastFailedEvent(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -508,6 +542,7 @@ func TestSystemNotificationService_RegisterWithEventBus(t *testing.T) {
 
 	// Expect subscriptions to be registered
 	mockEventBus.EXPECT().Subscribe(domain.EventBroadcastCircuitBreaker, gomock.Any())
+	mockEventBus.EXPECT().Subscribe(domain.EventWorkspaceSendingBlocked, gomock.Any())
 	mockEventBus.EXPECT().Subscribe(domain.EventBroadcastFailed, gomock.Any())
 
 	mockLogger.EXPECT().Info("System notification service registered with event bus")
@@ -100,6 +100,7 @@ func getTaskTypes() []string {
 		"send_broadcast",
 		"generate_report",
 		"build_segment",
+		"recompute_segment",
 		"process_contact_segment_queue",
 		"check_segment_recompute",
 	}
@@ -611,7 +612,11 @@ func (s *TaskService) ExecuteTask(ctx context.Context, workspace, taskID string,
 			tracing.AddAttribute(pendingCtx, "task_id", taskID)
 			tracing.AddAttribute(pendingCtx, "workspace_id", workspace)
 
+			// The processor may defer its next run, e.g. a broadcast waiting for the daily send quota
 			nextRun := time.Now().UTC()
+			if task.NextRunAfter != nil && task.NextRunAfter.After(nextRun) {
+				nextRun = task.NextRunAfter.UTC()
+			}
 			tracing.AddAttribute(pendingCtx, "next_run", nextRun.Format(time.RFC3339))
 			tracing.AddAttribute(pendingCtx, "progress", task.Progress)
 
@@ -766,6 +771,22 @@ func (s *TaskService) handleBroadcastScheduled(ctx context.Context, payload doma
 
 				// Flag for immediate execution after transaction commits
 				shouldExecuteImmediately = true
+			} else if status == string(domain.BroadcastStatusStartingSoon) {
+				// The broadcast starts at the end of the workspace cool-off
+				if nextRunAfter, ok := broadcastStartTime(payload); ok {
+					existingTask.NextRunAfter = &nextRunAfter
+				}
+				existingTask.Status = domain.TaskStatusPending
+
+				if updateErr := s.repo.Update(txCtx, payload.WorkspaceID, existingTask); updateErr != nil {
+					tracing.MarkSpanError(txCtx, updateErr)
+					s.logger.WithFields(map[string]interface{}{
+						"broadcast_id": broadcastID,
+						"task_id":      existingTask.ID,
+						"error":        updateErr.Error(),
+					}).Error("Failed to update task for scheduled broadcast")
+					return updateErr
+				}
 			}
 
 			return nil
@@ -801,21 +822,13 @@ func (s *TaskService) handleBroadcastScheduled(ctx context.Context, payload doma
 		}
 
 		// If the broadcast is set to send immediately, we don't need to set NextRunAfter
-		// If it's scheduled for the future, we should set NextRunAfter based on the schedule
-		if !sendNow && status == string(domain.BroadcastStatusScheduled) {
-
-			// Get broadcast schedule info from payload
-			if scheduledTimeStr, hasTime := payload.Data["scheduled_time"].(string); hasTime {
-
-				// Parse the scheduled time string
-				if scheduledTime, parseErr := time.Parse(time.RFC3339, scheduledTimeStr); parseErr == nil {
-					// Use the actual scheduled time from the broadcast
-					task.NextRunAfter = &scheduledTime
-					tracing.AddAttribute(txCtx, "next_run_after", scheduledTime.Format(time.RFC3339))
-					tracing.AddAttribute(txCtx, "scheduled_time_source", "payload")
-				} else {
-					log.Printf("Failed to parse scheduled_time: %v", parseErr)
-				}
+		// If it's scheduled for the future or in its cool-off, we should set NextRunAfter based on the schedule
+		if (!sendNow && status == string(domain.BroadcastStatusScheduled)) || status == string(domain.BroadcastStatusStartingSoon) {
+			if scheduledTime, ok := broadcastStartTime(payload); ok {
+				// Use the actual scheduled time from the broadcast
+				task.NextRunAfter = &scheduledTime
+				tracing.AddAttribute(txCtx, "next_run_after", scheduledTime.Format(time.RFC3339))
+				tracing.AddAttribute(txCtx, "scheduled_time_source", "payload")
 			}
 		}
 
@@ -864,6 +877,22 @@ func (s *TaskService) handleBroadcastScheduled(ctx context.Context, payload doma
 	}
 }
 
+// broadcastStartTime returns the scheduled_time of a broadcast scheduled event
+func broadcastStartTime(payload domain.EventPayload) (time.Time, bool) {
+	scheduledTimeStr, hasTime := payload.Data["scheduled_time"].(string)
+	if !hasTime {
+		return time.Time{}, false
+	}
+
+	// Parse the scheduled time string
+	scheduledTime, parseErr := time.Parse(time.RFC3339, scheduledTimeStr)
+	if parseErr != nil {
+		log.Printf("Failed to parse scheduled_time: %v", parseErr)
+		return time.Time{}, false
+	}
+	return scheduledTime, true
+}
+
 func (s *TaskService) handleBroadcastPaused(ctx context.Context, payload domain.EventPayload) {
 	ctx, span := tracing.StartServiceSpan(ctx, "TaskService", "handleBroadcastPaused")
 	defer tracing.EndSpan(span, nil)
@@ -1912,6 +1912,47 @@ func TestTaskService_HandleBroadcastScheduledExtended(t *testing.T) {
 		taskService.handleBroadcastScheduled(ctx, payload)
 	})
 
+	t.Run("Creates a task that starts after the send cool-off", func(t *testing.T) {
+		ctx := context.Background()
+		workspaceID := "workspace1"
+		broadcastID := "broadcast-cool-off"
+
+		// A broadcast sent during the cool-off is starting soon until its scheduled time
+		coolOffUntil := time.Now().Add(5 * time.Minute).UTC().Truncate(time.Second)
+		payload := domain.EventPayload{
+			Type:        domain.EventBroadcastScheduled,
+			WorkspaceID: workspaceID,
+			EntityID:    broadcastID,
+			Data: map[string]interface{}{
+				"send_now":       true,
+				"status":         string(domain.BroadcastStatusStartingSoon),
+				"scheduled_time": coolOffUntil.Format(time.RFC3339),
+			},
+		}
+
+		mockRepo.EXPECT().
+			WithTransaction(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, fn func(*sql.Tx) error) error {
+				return fn(nil)
+			})
+		mockRepo.EXPECT().
+			GetTaskByBroadcastID(gomock.Any(), workspaceID, broadcastID).
+			Return(nil, errors.New("not found"))
+
+		// The task only runs at the end of the cool-off
+		mockRepo.EXPECT().
+			Create(gomock.Any(), workspaceID, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, task *domain.Task) error {
+				assert.Equal(t, domain.TaskStatusPending, task.Status)
+				if assert.NotNil(t, task.NextRunAfter) {
+					assert.True(t, task.NextRunAfter.Equal(coolOffUntil))
+				}
+				return nil
+			})
+
+		taskService.handleBroadcastScheduled(ctx, payload)
+	})
+
 	t.Run("Handles task creation error", func(t *testing.T) {
 		// Setup
 		ctx := context.Background()
@@ -30,6 +30,15 @@ type TelemetryMetrics struct {
 	BlogPostsCount     int    `json:"blog_posts_count"`
 	APIEndpoint        string `json:"api_endpoint"`
 
+	// Send performance over the last 24 hours
+	MessagesSentLast24h int     `json:"messages_sent_last_24h"`
+	AvgSendLatencyMs    float64 `json:"avg_send_latency_ms"`
+	BounceRate          float64 `json:"bounce_rate"`
+	ComplaintRate       float64 `json:"complaint_rate"`
+
+	// Number of blocks of each component type across the templates, e.g. "mj-button"
+	BlockUsage map[string]int `json:"block_usage,omitempty"`
+
 	// Integration flags - boolean for each email provider
 	Mailgun   bool `json:"mailgun"`
 	AmazonSES bool `json:"amazonses"`
@@ -37,6 +46,7 @@ type TelemetryMetrics struct {
 	SparkPost bool `json:"sparkpost"`
 	Postmark  bool `json:"postmark"`
 	SMTP      bool `json:"smtp"`
+	SendGrid  bool `json:"sendgrid"`
 	S3        bool `json:"s3"`
 }
 
@@ -135,6 +145,11 @@ func (t *TelemetryService) sendMetricsForWorkspace(ctx context.Context, workspac
 		metrics.UsersCount = telemetryMetrics.UsersCount
 		metrics.BlogPostsCount = telemetryMetrics.BlogPostsCount
 		metrics.LastMessageAt = telemetryMetrics.LastMessageAt
+		metrics.MessagesSentLast24h = telemetryMetrics.MessagesSentLast24h
+		metrics.AvgSendLatencyMs = telemetryMetrics.AvgSendLatencyMs
+		metrics.BounceRate = telemetryMetrics.BounceRate
+		metrics.ComplaintRate = telemetryMetrics.ComplaintRate
+		metrics.BlockUsage = telemetryMetrics.BlockUsage
 	}
 
 	// Send metrics to telemetry endpoint
@@ -159,6 +174,8 @@ func (t *TelemetryService) setIntegrationFlagsFromWorkspace(workspace *domain.Wo
 				metrics.SMTP = true
 			case domain.EmailProviderKindSparkPost:
 				metrics.SparkPost = true
+			case domain.EmailProviderKindSendGrid:
+				metrics.SendGrid = true
 			}
 		}
 	}
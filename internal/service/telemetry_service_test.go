@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -112,6 +113,52 @@ func (t *testTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 	return http.DefaultTransport.RoundTrip(req)
 }
 
+func TestTelemetryService_SendsSendPerformance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	mockTelemetryRepo := mocks.NewMockTelemetryRepository(ctrl)
+
+	var payload map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	service := NewTelemetryService(TelemetryServiceConfig{
+		Enabled:       true,
+		APIEndpoint:   "https://api.example.com",
+		WorkspaceRepo: mockWorkspaceRepo,
+		TelemetryRepo: mockTelemetryRepo,
+		Logger:        logger.NewLoggerWithLevel("debug"),
+		HTTPClient: &http.Client{
+			Timeout:   5 * time.Second,
+			Transport: &testTransport{testServerURL: server.URL, originalURL: TelemetryEndpoint},
+		},
+	})
+
+	mockWorkspaceRepo.EXPECT().List(gomock.Any()).Return([]*domain.Workspace{{ID: "workspace1"}}, nil)
+	mockTelemetryRepo.EXPECT().GetWorkspaceMetrics(gomock.Any(), "workspace1").Return(&domain.TelemetryMetrics{
+		MessagesCount:       1200,
+		MessagesSentLast24h: 400,
+		AvgSendLatencyMs:    1250.5,
+		BounceRate:          0.02,
+		ComplaintRate:       0.0025,
+		BlockUsage:          map[string]int{"mj-button": 3, "product-grid": 1},
+	}, nil)
+
+	require.NoError(t, service.SendMetricsForAllWorkspaces(context.Background()))
+
+	require.NotNil(t, payload)
+	assert.Equal(t, float64(400), payload["messages_sent_last_24h"])
+	assert.Equal(t, 1250.5, payload["avg_send_latency_ms"])
+	assert.Equal(t, 0.02, payload["bounce_rate"])
+	assert.Equal(t, 0.0025, payload["complaint_rate"])
+	assert.Equal(t, map[string]interface{}{"mj-button": float64(3), "product-grid": float64(1)}, payload["block_usage"])
+}
+
 func TestTelemetryService_DisabledService(t *testing.T) {
 	// Create telemetry service with disabled configuration
 	config := TelemetryServiceConfig{
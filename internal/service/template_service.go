@@ -15,6 +15,9 @@ type TemplateService struct {
 	authService domain.AuthService
 	logger      logger.Logger
 	apiEndpoint string
+
+	// workspaceRepo, when set, checks the provider tags of saved templates against the email providers of the workspace
+	workspaceRepo domain.WorkspaceRepository
 }
 
 // updateEmailMetadataBlocks updates mj-title and mj-preview blocks in the email tree
@@ -67,6 +70,30 @@ func NewTemplateService(repo domain.TemplateRepository, authService domain.AuthS
 	}
 }
 
+// SetWorkspaceRepository makes saved templates check their provider tags against the limits of the
+// email providers of the workspace, instead of failing every send once the provider rejects them
+func (s *TemplateService) SetWorkspaceRepository(workspaceRepo domain.WorkspaceRepository) {
+	s.workspaceRepo = workspaceRepo
+}
+
+// validateProviderTags checks the provider tags of a template against the email providers of the workspace
+func (s *TemplateService) validateProviderTags(ctx context.Context, workspaceID string, template *domain.Template) error {
+	if s.workspaceRepo == nil || template.Email == nil {
+		return nil
+	}
+
+	workspace, err := s.workspaceRepo.GetByID(ctx, workspaceID)
+	if err != nil {
+		s.logger.WithField("template_id", template.ID).Error(fmt.Sprintf("Failed to get workspace: %v", err))
+		return fmt.Errorf("failed to get workspace: %w", err)
+	}
+
+	if err := template.Email.ValidateProviderTagsFor(workspace.EmailProviderKinds()...); err != nil {
+		return fmt.Errorf("invalid template: invalid email template: %w", err)
+	}
+	return nil
+}
+
 func (s *TemplateService) CreateTemplate(ctx context.Context, workspaceID string, template *domain.Template) error {
 	// Authenticate user for workspace
 	var err error
@@ -97,6 +124,9 @@ func (s *TemplateService) CreateTemplate(ctx context.Context, workspaceID string
 	if err := template.Validate(); err != nil {
 		return fmt.Errorf("invalid template: %w", err)
 	}
+	if err := s.validateProviderTags(ctx, workspaceID, template); err != nil {
+		return err
+	}
 
 	// Create template in repository
 	if err := s.repo.CreateTemplate(ctx, workspaceID, template); err != nil {
@@ -205,6 +235,9 @@ func (s *TemplateService) UpdateTemplate(ctx context.Context, workspaceID string
 	if err := template.Validate(); err != nil {
 		return fmt.Errorf("invalid template: %w", err)
 	}
+	if err := s.validateProviderTags(ctx, workspaceID, template); err != nil {
+		return err
+	}
 
 	// Preserve creation time from existing template
 	template.CreatedAt = existingTemplate.CreatedAt
@@ -295,3 +328,80 @@ func (s *TemplateService) CompileTemplate(ctx context.Context, payload domain.Co
 
 	return notifuse_mjml.CompileTemplate(payload)
 }
+
+// previewMessageID and previewSecretKey stand in for the message ID and the workspace secret key in
+// the links of a preview, which is never sent
+const (
+	previewMessageID = "preview"
+	previewSecretKey = "preview"
+)
+
+// PreviewTemplate renders a saved email template for a sample contact with the template data and the
+// compilation used to send broadcasts, so that the preview matches the sent emails
+func (s *TemplateService) PreviewTemplate(ctx context.Context, req domain.PreviewTemplateRequest) (*domain.PreviewTemplateResponse, error) {
+	template, err := s.GetTemplateByID(ctx, req.WorkspaceID, req.TemplateID, req.Version)
+	if err != nil {
+		return nil, err
+	}
+	if template.Email == nil || template.Email.VisualEditorTree == nil {
+		return nil, domain.NewValidationError("template has no email content to preview")
+	}
+	template = template.ForContact(req.Contact)
+
+	trackingSettings := notifuse_mjml.TrackingSettings{
+		Endpoint:    s.apiEndpoint,
+		WorkspaceID: req.WorkspaceID,
+		MessageID:   previewMessageID,
+	}
+	data, err := domain.BuildTemplateData(domain.TemplateDataRequest{
+		WorkspaceID:        req.WorkspaceID,
+		WorkspaceSecretKey: previewSecretKey,
+		ContactWithList: domain.ContactWithList{
+			Contact:  req.Contact,
+			ListID:   req.ListID,
+			ListName: req.ListName,
+		},
+		MessageID:        previewMessageID,
+		ProvidedData:     req.Data,
+		TrackingSettings: trackingSettings,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to build template data: %w", err)
+	}
+
+	// Fallbacks render as they would be sent, a contact skipped by the template keeps its empty fields
+	// and they are reported as unresolved
+	if template.Email.Personalization != nil {
+		_, _ = template.Email.Personalization.Apply(data)
+	}
+
+	subject, err := notifuse_mjml.ProcessLiquidTemplate(template.Email.Subject, data, "email_subject")
+	if err != nil {
+		return nil, domain.NewValidationError(fmt.Sprintf("failed to render subject: %v", err))
+	}
+
+	compiled, err := notifuse_mjml.CompileTemplate(notifuse_mjml.CompileTemplateRequest{
+		WorkspaceID:      req.WorkspaceID,
+		MessageID:        previewMessageID,
+		VisualEditorTree: template.Email.VisualEditorTree,
+		TemplateData:     notifuse_mjml.MapOfAny(data),
+		TrackingSettings: trackingSettings,
+		RawMergeFields:   template.Email.RawMergeFields,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to compile template: %w", err)
+	}
+	if !compiled.Success || compiled.HTML == nil {
+		message := "template compilation failed"
+		if compiled.Error != nil {
+			message = compiled.Error.Message
+		}
+		return nil, domain.NewValidationError(message)
+	}
+
+	return &domain.PreviewTemplateResponse{
+		Subject:             subject,
+		HTML:                *compiled.HTML,
+		UnresolvedMergeTags: domain.UnresolvedMergeTags(template.Email.Subject, template.Email.VisualEditorTree, data),
+	}, nil
+}
@@ -193,6 +193,50 @@ func TestTemplateService_CreateTemplate(t *testing.T) {
 		assert.Contains(t, err.Error(), "failed to create template")
 		assert.ErrorIs(t, err, repoErr)
 	})
+
+	t.Run("Provider Tags Beyond The Limits Of A Workspace Provider", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		templateService, mockRepo, mockAuthService, mockLogger := setupTemplateServiceTest(ctrl)
+		mockWorkspaceRepo := domainmocks.NewMockWorkspaceRepository(ctrl)
+		templateService.SetWorkspaceRepository(mockWorkspaceRepo)
+		mockLogger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(mockLogger).AnyTimes()
+		mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
+
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, &domain.User{ID: userID}, &domain.UserWorkspace{
+			UserID:      userID,
+			WorkspaceID: workspaceID,
+			Role:        "member",
+			Permissions: domain.UserPermissions{
+				domain.PermissionResourceTemplates: {Read: true, Write: true},
+			},
+		}, nil).Times(2)
+		mockWorkspaceRepo.EXPECT().GetByID(ctx, workspaceID).Return(&domain.Workspace{
+			ID: workspaceID,
+			Integrations: []domain.Integration{
+				{ID: "smtp", Type: domain.IntegrationTypeEmail, EmailProvider: domain.EmailProvider{Kind: domain.EmailProviderKindSMTP}},
+				{ID: "ses", Type: domain.IntegrationTypeEmail, EmailProvider: domain.EmailProvider{Kind: domain.EmailProviderKindSES}},
+			},
+		}, nil).Times(2)
+
+		// SES tag values are limited to letters, digits, '_' and '-'
+		invalidEmail := *templateToCreate.Email
+		invalidEmail.ProviderTags = map[string]string{"campaign": "spring sale"}
+		invalid := *templateToCreate
+		invalid.Email = &invalidEmail
+
+		err := templateService.CreateTemplate(ctx, workspaceID, &invalid)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `SES tag "campaign" value`)
+
+		validEmail := *templateToCreate.Email
+		validEmail.ProviderTags = map[string]string{"campaign": "spring-sale"}
+		valid := *templateToCreate
+		valid.Email = &validEmail
+		mockRepo.EXPECT().CreateTemplate(ctx, workspaceID, EqTemplateWithVersion1(&valid)).Return(nil)
+
+		require.NoError(t, templateService.CreateTemplate(ctx, workspaceID, &valid))
+	})
 }
 
 func TestTemplateService_GetTemplateByID(t *testing.T) {
@@ -1060,3 +1104,120 @@ func TestCompileTemplate_InvalidTreeData(t *testing.T) {
 	assert.Contains(t, resp.Error.Message, "mjml", "Error message should relate to MJML processing")
 
 }
+
+func TestTemplateService_PreviewTemplate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockAuthService := domainmocks.NewMockAuthService(ctrl)
+	mockRepo := domainmocks.NewMockTemplateRepository(ctrl)
+	svc := service.NewTemplateService(mockRepo, mockAuthService, &MockLogger{}, "https://api.example.com")
+
+	ctx := context.Background()
+	workspaceID := "ws_123"
+	userWorkspace := &domain.UserWorkspace{
+		UserID:      "user_abc",
+		WorkspaceID: workspaceID,
+		Role:        "member",
+		Permissions: domain.UserPermissions{
+			domain.PermissionResourceTemplates: {Read: true, Write: false},
+		},
+	}
+	firstName := &domain.NullableString{String: "John", IsNull: false}
+
+	t.Run("renders the nested blocks for the sample contact", func(t *testing.T) {
+		template := &domain.Template{
+			ID:      "welcome",
+			Version: 2,
+			Email: &domain.EmailTemplate{
+				Subject:          "Welcome {{ contact.first_name }}",
+				VisualEditorTree: createValidTestTree(createTestTextBlock("txt1", "Hello {{ contact.first_name }} from {{ list.name }}")),
+			},
+		}
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(gomock.Any(), workspaceID).Return(ctx, &domain.User{ID: "user_abc"}, userWorkspace, nil)
+		mockRepo.EXPECT().GetTemplateByID(gomock.Any(), workspaceID, "welcome", int64(2)).Return(template, nil)
+
+		resp, err := svc.PreviewTemplate(ctx, domain.PreviewTemplateRequest{
+			WorkspaceID: workspaceID,
+			TemplateID:  "welcome",
+			Version:     2,
+			Contact:     &domain.Contact{Email: "john@example.com", FirstName: firstName},
+			ListID:      "newsletter",
+			ListName:    "Newsletter",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "Welcome John", resp.Subject)
+		assert.Contains(t, resp.HTML, "<html")
+		assert.Contains(t, resp.HTML, "Hello John from Newsletter")
+		assert.Empty(t, resp.UnresolvedMergeTags)
+	})
+
+	t.Run("reports the merge tags without value", func(t *testing.T) {
+		template := &domain.Template{
+			ID: "welcome",
+			Email: &domain.EmailTemplate{
+				Subject:          "Your code {{ offer.code }}",
+				VisualEditorTree: createValidTestTree(createTestTextBlock("txt1", "Hello {{ contact.first_name }} {{ contact.last_name }}")),
+			},
+		}
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(gomock.Any(), workspaceID).Return(ctx, &domain.User{ID: "user_abc"}, userWorkspace, nil)
+		mockRepo.EXPECT().GetTemplateByID(gomock.Any(), workspaceID, "welcome", int64(0)).Return(template, nil)
+
+		resp, err := svc.PreviewTemplate(ctx, domain.PreviewTemplateRequest{
+			WorkspaceID: workspaceID,
+			TemplateID:  "welcome",
+			Contact:     &domain.Contact{Email: "john@example.com", FirstName: firstName},
+		})
+
+		require.NoError(t, err)
+		assert.NotContains(t, resp.Subject, "{{")
+		assert.Contains(t, resp.HTML, "Hello John")
+		assert.Equal(t, []string{"contact.last_name", "offer.code"}, resp.UnresolvedMergeTags)
+	})
+
+	t.Run("provided data resolves the merge tags", func(t *testing.T) {
+		template := &domain.Template{
+			ID: "welcome",
+			Email: &domain.EmailTemplate{
+				Subject:          "Your code {{ offer.code }}",
+				VisualEditorTree: createValidTestTree(createTestTextBlock("txt1", "Hello")),
+			},
+		}
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(gomock.Any(), workspaceID).Return(ctx, &domain.User{ID: "user_abc"}, userWorkspace, nil)
+		mockRepo.EXPECT().GetTemplateByID(gomock.Any(), workspaceID, "welcome", int64(0)).Return(template, nil)
+
+		resp, err := svc.PreviewTemplate(ctx, domain.PreviewTemplateRequest{
+			WorkspaceID: workspaceID,
+			TemplateID:  "welcome",
+			Data:        domain.MapOfAny{"offer": map[string]interface{}{"code": "SPRING"}},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "Your code SPRING", resp.Subject)
+		assert.Empty(t, resp.UnresolvedMergeTags)
+	})
+
+	t.Run("template without email content", func(t *testing.T) {
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(gomock.Any(), workspaceID).Return(ctx, &domain.User{ID: "user_abc"}, userWorkspace, nil)
+		mockRepo.EXPECT().GetTemplateByID(gomock.Any(), workspaceID, "sms", int64(0)).Return(&domain.Template{ID: "sms", Channel: "sms"}, nil)
+
+		resp, err := svc.PreviewTemplate(ctx, domain.PreviewTemplateRequest{WorkspaceID: workspaceID, TemplateID: "sms"})
+
+		require.Error(t, err)
+		assert.Nil(t, resp)
+		var validationErr domain.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+	})
+
+	t.Run("template not found", func(t *testing.T) {
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(gomock.Any(), workspaceID).Return(ctx, &domain.User{ID: "user_abc"}, userWorkspace, nil)
+		mockRepo.EXPECT().GetTemplateByID(gomock.Any(), workspaceID, "missing", int64(0)).Return(nil, &domain.ErrTemplateNotFound{Message: "template not found"})
+
+		resp, err := svc.PreviewTemplate(ctx, domain.PreviewTemplateRequest{WorkspaceID: workspaceID, TemplateID: "missing"})
+
+		require.Error(t, err)
+		assert.Nil(t, resp)
+		assert.IsType(t, &domain.ErrTemplateNotFound{}, err)
+	})
+}
@@ -560,6 +560,37 @@ func (s *TransactionalNotificationService) SendNotification(
 		}
 	}
 
+	// Return the message first sent with the idempotency key while the key is live
+	if params.IdempotencyKey != nil && *params.IdempotencyKey != "" {
+		existingMessage, err := s.messageHistoryRepo.GetByIdempotencyKey(ctx, workspaceID, workspace.Settings.SecretKey, *params.IdempotencyKey)
+		if err != nil && !strings.Contains(err.Error(), "not found") {
+			tracing.MarkSpanError(ctx, err)
+			return "", fmt.Errorf("failed to check for existing message: %w", err)
+		}
+
+		if existingMessage != nil {
+			if time.Since(existingMessage.CreatedAt) < domain.TransactionalIdempotencyKeyTTL {
+				s.logger.WithFields(map[string]interface{}{
+					"workspace":  workspaceID,
+					"message_id": existingMessage.ID,
+				}).Info("Message with idempotency_key already exists, returning existing message")
+
+				span.AddAttributes(
+					trace.StringAttribute("existing_message_id", existingMessage.ID),
+					trace.BoolAttribute("idempotent_response", true),
+				)
+
+				return existingMessage.ID, nil
+			}
+
+			// The key expired, release it so that this send claims it
+			if err := s.messageHistoryRepo.ReleaseIdempotencyKey(ctx, workspaceID, *params.IdempotencyKey, time.Now().Add(-domain.TransactionalIdempotencyKeyTTL)); err != nil {
+				tracing.MarkSpanError(ctx, err)
+				return "", err
+			}
+		}
+	}
+
 	successfulChannels := 0
 
 	span.AddAttributes(
@@ -578,7 +609,7 @@ func (s *TransactionalNotificationService) SendNotification(
 		)
 
 		// Prepare message data with contact and custom data
-		notification.TrackingSettings.EnableTracking = workspace.Settings.EmailTrackingEnabled
+		workspace.Settings.ApplyTrackingDefaults(&notification.TrackingSettings)
 
 		// Use workspace CustomEndpointURL if provided, otherwise use the default API endpoint
 		if workspace.Settings.CustomEndpointURL != nil && *workspace.Settings.CustomEndpointURL != "" {
@@ -643,13 +674,14 @@ func (s *TransactionalNotificationService) SendNotification(
 				trace.StringAttribute("integration_id", integrationID),
 			)
 
-			notification.TrackingSettings.EnableTracking = workspace.Settings.EmailTrackingEnabled
+			workspace.Settings.ApplyTrackingDefaults(&notification.TrackingSettings)
 
 			request := domain.SendEmailRequest{
 				WorkspaceID:      workspaceID,
 				IntegrationID:    integrationID,
 				MessageID:        messageID,
 				ExternalID:       params.ExternalID,
+				IdempotencyKey:   params.IdempotencyKey,
 				Contact:          contact,
 				TemplateConfig:   templateConfig,
 				MessageData:      messageData,
@@ -658,6 +690,22 @@ func (s *TransactionalNotificationService) SendNotification(
 				EmailOptions:     params.EmailOptions,
 			}
 			err = s.emailService.SendEmailForTemplate(childCtx, request)
+			if errors.Is(err, domain.ErrIdempotencyKeyUsed) {
+				// A concurrent retry claimed the key first, return the message it sent
+				childSpan.End()
+				existingMessage, err := s.messageHistoryRepo.GetByIdempotencyKey(ctx, workspaceID, workspace.Settings.SecretKey, *params.IdempotencyKey)
+				if err != nil {
+					tracing.MarkSpanError(ctx, err)
+					return "", fmt.Errorf("failed to get message holding the idempotency key: %w", err)
+				}
+
+				span.AddAttributes(
+					trace.StringAttribute("existing_message_id", existingMessage.ID),
+					trace.BoolAttribute("idempotent_response", true),
+				)
+
+				return existingMessage.ID, nil
+			}
 			if err == nil {
 				successfulChannels++
 				childSpan.End()
@@ -792,6 +840,7 @@ func (s *TransactionalNotificationService) TestTemplate(ctx context.Context, wor
 		VisualEditorTree: template.Email.VisualEditorTree,
 		TemplateData:     notifuse_mjml.MapOfAny(messageData),
 		TrackingSettings: trackingSettings,
+		RawMergeFields:   template.Email.RawMergeFields,
 	})
 
 	if err != nil {
@@ -829,6 +878,7 @@ func (s *TransactionalNotificationService) TestTemplate(ctx context.Context, wor
 		Provider:      emailProvider,
 		EmailOptions:  emailOptions,
 	}
+	template.Email.ApplyProviderTags(&emailRequest.EmailOptions)
 
 	// Allow override of from name via email options
 	if emailOptions.FromName != nil && *emailOptions.FromName != "" {
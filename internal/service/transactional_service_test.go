@@ -3,7 +3,10 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/Notifuse/notifuse/internal/domain"
 	"github.com/Notifuse/notifuse/internal/domain/mocks"
@@ -1824,6 +1827,182 @@ func TestTransactionalNotificationService_SendNotification(t *testing.T) {
 	})
 }
 
+func TestTransactionalNotificationService_SendNotification_IdempotencyKey(t *testing.T) {
+	ctx := context.Background()
+	workspace := "test-workspace"
+	notificationID := "notification-1"
+	idempotencyKey := "order-12345"
+
+	notification := &domain.TransactionalNotification{
+		ID: notificationID,
+		Channels: map[domain.TransactionalChannel]domain.ChannelTemplate{
+			domain.TransactionalChannelEmail: {TemplateID: "template-1"},
+		},
+	}
+	workspaceObj := &domain.Workspace{
+		ID: workspace,
+		Settings: domain.WorkspaceSettings{
+			TransactionalEmailProviderID: "integration-1",
+			SecretKey:                    "test-secret-key",
+		},
+		Integrations: []domain.Integration{
+			{
+				ID:   "integration-1",
+				Type: "email",
+				EmailProvider: domain.EmailProvider{
+					Kind:      domain.EmailProviderKindSparkPost,
+					Senders:   []domain.EmailSender{domain.NewEmailSender("test@example.com", "Test Sender")},
+					SparkPost: &domain.SparkPostSettings{EncryptedAPIKey: "encrypted-api-key"},
+				},
+			},
+		},
+	}
+	contact := &domain.Contact{Email: "test@example.com"}
+	params := domain.TransactionalNotificationSendParams{
+		ID:             notificationID,
+		Contact:        contact,
+		IdempotencyKey: &idempotencyKey,
+	}
+
+	// setup stubs every dependency a send goes through before reaching the message history
+	setup := func(t *testing.T) (*TransactionalNotificationService, *mocks.MockMessageHistoryRepository, *mocks.MockEmailServiceInterface) {
+		ctrl := gomock.NewController(t)
+
+		mockRepo := mocks.NewMockTransactionalNotificationRepository(ctrl)
+		mockMsgHistoryRepo := mocks.NewMockMessageHistoryRepository(ctrl)
+		mockContactService := mocks.NewMockContactService(ctrl)
+		mockEmailService := mocks.NewMockEmailServiceInterface(ctrl)
+		mockLogger := pkgmocks.NewMockLogger(ctrl)
+		mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+		mockAuthService := mocks.NewMockAuthService(ctrl)
+
+		mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+		mockLogger.EXPECT().Info(gomock.Any()).AnyTimes()
+		mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
+		mockAuthService.EXPECT().
+			AuthenticateUserForWorkspace(gomock.Any(), workspace).
+			Return(ctx, &domain.User{ID: "user-123"}, &domain.UserWorkspace{UserID: "user-123", WorkspaceID: workspace}, nil).
+			AnyTimes()
+		mockWorkspaceRepo.EXPECT().GetByID(gomock.Any(), workspace).Return(workspaceObj, nil).AnyTimes()
+		// Sends set their tracking settings on the notification, each gets its own copy
+		mockRepo.EXPECT().
+			Get(gomock.Any(), workspace, notificationID).
+			DoAndReturn(func(_ context.Context, _, _ string) (*domain.TransactionalNotification, error) {
+				copied := *notification
+				return &copied, nil
+			}).
+			AnyTimes()
+		mockContactService.EXPECT().
+			UpsertContact(gomock.Any(), workspace, contact).
+			Return(domain.UpsertContactOperation{Email: contact.Email, Action: domain.UpsertContactOperationUpdate}).
+			AnyTimes()
+		mockContactService.EXPECT().GetContactByEmail(gomock.Any(), workspace, contact.Email).Return(contact, nil).AnyTimes()
+
+		service := &TransactionalNotificationService{
+			transactionalRepo:  mockRepo,
+			messageHistoryRepo: mockMsgHistoryRepo,
+			contactService:     mockContactService,
+			emailService:       mockEmailService,
+			logger:             mockLogger,
+			workspaceRepo:      mockWorkspaceRepo,
+			apiEndpoint:        "https://api.example.com",
+			authService:        mockAuthService,
+		}
+		return service, mockMsgHistoryRepo, mockEmailService
+	}
+
+	t.Run("Live key returns the original message without sending", func(t *testing.T) {
+		service, mockMsgHistoryRepo, _ := setup(t)
+
+		// The email service has no expectations, any send fails the test
+		mockMsgHistoryRepo.EXPECT().
+			GetByIdempotencyKey(gomock.Any(), workspace, "test-secret-key", idempotencyKey).
+			Return(&domain.MessageHistory{ID: "original-msg", CreatedAt: time.Now().Add(-time.Hour)}, nil)
+
+		messageID, err := service.SendNotification(ctx, workspace, params)
+		require.NoError(t, err)
+		assert.Equal(t, "original-msg", messageID)
+	})
+
+	t.Run("Expired key is released and the notification sent again", func(t *testing.T) {
+		service, mockMsgHistoryRepo, mockEmailService := setup(t)
+
+		mockMsgHistoryRepo.EXPECT().
+			GetByIdempotencyKey(gomock.Any(), workspace, "test-secret-key", idempotencyKey).
+			Return(&domain.MessageHistory{ID: "original-msg", CreatedAt: time.Now().Add(-domain.TransactionalIdempotencyKeyTTL - time.Hour)}, nil)
+		mockMsgHistoryRepo.EXPECT().
+			ReleaseIdempotencyKey(gomock.Any(), workspace, idempotencyKey, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _, _ string, createdBefore time.Time) error {
+				assert.WithinDuration(t, time.Now().Add(-domain.TransactionalIdempotencyKeyTTL), createdBefore, time.Minute)
+				return nil
+			})
+		mockEmailService.EXPECT().
+			SendEmailForTemplate(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, request domain.SendEmailRequest) error {
+				require.NotNil(t, request.IdempotencyKey)
+				assert.Equal(t, idempotencyKey, *request.IdempotencyKey)
+				return nil
+			})
+
+		messageID, err := service.SendNotification(ctx, workspace, params)
+		require.NoError(t, err)
+		assert.NotEqual(t, "original-msg", messageID)
+	})
+
+	t.Run("Concurrent retries resolve to a single send", func(t *testing.T) {
+		service, mockMsgHistoryRepo, mockEmailService := setup(t)
+
+		// The message history behaves like the unique index: the first insert claims the key
+		var mu sync.Mutex
+		var holder *domain.MessageHistory
+		sends := 0
+
+		mockMsgHistoryRepo.EXPECT().
+			GetByIdempotencyKey(gomock.Any(), workspace, "test-secret-key", idempotencyKey).
+			DoAndReturn(func(_ context.Context, _, _, key string) (*domain.MessageHistory, error) {
+				mu.Lock()
+				defer mu.Unlock()
+				if holder == nil {
+					return nil, fmt.Errorf("message history with idempotency_key %s not found", key)
+				}
+				return holder, nil
+			}).
+			AnyTimes()
+		mockEmailService.EXPECT().
+			SendEmailForTemplate(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ context.Context, request domain.SendEmailRequest) error {
+				mu.Lock()
+				defer mu.Unlock()
+				if holder != nil {
+					return fmt.Errorf("failed to create message history: %w", domain.ErrIdempotencyKeyUsed)
+				}
+				holder = &domain.MessageHistory{ID: request.MessageID, CreatedAt: time.Now()}
+				sends++
+				return nil
+			}).
+			AnyTimes()
+
+		const retries = 10
+		messageIDs := make([]string, retries)
+		errs := make([]error, retries)
+		var wg sync.WaitGroup
+		for i := 0; i < retries; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				messageIDs[i], errs[i] = service.SendNotification(ctx, workspace, params)
+			}(i)
+		}
+		wg.Wait()
+
+		assert.Equal(t, 1, sends)
+		for i := 0; i < retries; i++ {
+			require.NoError(t, errs[i])
+			assert.Equal(t, holder.ID, messageIDs[i])
+		}
+	})
+}
+
 func TestTransactionalNotificationService_TestTemplate(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/pkg/logger"
+)
+
+// TwilioService implements the domain.SMSProviderService interface for Twilio
+type TwilioService struct {
+	httpClient domain.HTTPClient
+	logger     logger.Logger
+	baseURL    string
+}
+
+// NewTwilioService creates a new instance of TwilioService
+func NewTwilioService(httpClient domain.HTTPClient, logger logger.Logger) *TwilioService {
+	return &TwilioService{
+		httpClient: httpClient,
+		logger:     logger,
+		baseURL:    "https://api.twilio.com",
+	}
+}
+
+// SendSMS sends an SMS through the Twilio Messages API
+func (s *TwilioService) SendSMS(ctx context.Context, request domain.SendSMSRequest) error {
+	if err := request.Validate(); err != nil {
+		return fmt.Errorf("invalid request: %w", err)
+	}
+
+	if request.Provider.Twilio == nil {
+		return fmt.Errorf("twilio provider is not configured")
+	}
+	settings := request.Provider.Twilio
+
+	if settings.AuthToken == "" {
+		s.logger.Error("Twilio auth token is empty")
+		return fmt.Errorf("twilio auth token is required")
+	}
+
+	endpoint := fmt.Sprintf("%s/2010-04-01/Accounts/%s/Messages.json", s.baseURL, url.PathEscape(settings.AccountSID))
+
+	form := url.Values{}
+	form.Set("To", request.To)
+	form.Set("From", settings.FromNumber)
+	form.Set("Body", request.Body)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to create Twilio request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(settings.AccountSID, settings.AuthToken)
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send request to Twilio API: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read Twilio API response: %w", err)
+	}
+
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("twilio API error (%d): %s", resp.StatusCode, string(body))
+	}
+
+	return nil
+}
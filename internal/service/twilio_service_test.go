@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/domain/mocks"
+	pkgmocks "github.com/Notifuse/notifuse/pkg/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func setupTwilioTest(t *testing.T) (*TwilioService, *mocks.MockHTTPClient) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	httpClient := mocks.NewMockHTTPClient(ctrl)
+	logger := pkgmocks.NewMockLogger(ctrl)
+	logger.EXPECT().Error(gomock.Any()).AnyTimes()
+
+	return NewTwilioService(httpClient, logger), httpClient
+}
+
+func twilioSMSRequest() domain.SendSMSRequest {
+	return domain.SendSMSRequest{
+		WorkspaceID:   "workspace-123",
+		IntegrationID: "sms-integration",
+		MessageID:     "message-123",
+		To:            "+33600000000",
+		Body:          "Hello John",
+		Provider: &domain.SMSProvider{
+			Kind: domain.SMSProviderKindTwilio,
+			Twilio: &domain.TwilioSettings{
+				AccountSID: "AC123",
+				AuthToken:  "token",
+				FromNumber: "+15550000000",
+			},
+		},
+	}
+}
+
+func TestTwilioService_SendSMS(t *testing.T) {
+	t.Run("posts the message to the Twilio API", func(t *testing.T) {
+		service, httpClient := setupTwilioTest(t)
+
+		httpClient.EXPECT().Do(gomock.Any()).DoAndReturn(func(req *http.Request) (*http.Response, error) {
+			assert.Equal(t, http.MethodPost, req.Method)
+			assert.Equal(t, "https://api.twilio.com/2010-04-01/Accounts/AC123/Messages.json", req.URL.String())
+			assert.Equal(t, "application/x-www-form-urlencoded", req.Header.Get("Content-Type"))
+
+			username, password, ok := req.BasicAuth()
+			require.True(t, ok)
+			assert.Equal(t, "AC123", username)
+			assert.Equal(t, "token", password)
+
+			body, _ := io.ReadAll(req.Body)
+			form, err := url.ParseQuery(string(body))
+			require.NoError(t, err)
+			assert.Equal(t, "+33600000000", form.Get("To"))
+			assert.Equal(t, "+15550000000", form.Get("From"))
+			assert.Equal(t, "Hello John", form.Get("Body"))
+
+			return createMockResponse(http.StatusCreated, `{"sid":"SM123"}`), nil
+		})
+
+		require.NoError(t, service.SendSMS(context.Background(), twilioSMSRequest()))
+	})
+
+	t.Run("returns the API error", func(t *testing.T) {
+		service, httpClient := setupTwilioTest(t)
+
+		httpClient.EXPECT().Do(gomock.Any()).Return(createMockResponse(http.StatusBadRequest, `{"message":"invalid To"}`), nil)
+
+		err := service.SendSMS(context.Background(), twilioSMSRequest())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "twilio API error (400)")
+	})
+
+	t.Run("rejects a request without recipient", func(t *testing.T) {
+		service, _ := setupTwilioTest(t)
+
+		request := twilioSMSRequest()
+		request.To = ""
+		err := service.SendSMS(context.Background(), request)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "to phone number is required")
+	})
+
+	t.Run("rejects a provider without auth token", func(t *testing.T) {
+		service, _ := setupTwilioTest(t)
+
+		request := twilioSMSRequest()
+		request.Provider.Twilio.AuthToken = ""
+		err := service.SendSMS(context.Background(), request)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "twilio auth token is required")
+	})
+}
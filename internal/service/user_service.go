@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/hmac"
 	"crypto/rand"
+	"errors"
 	"fmt"
 	"time"
 
@@ -16,6 +17,12 @@ import (
 	"go.opencensus.io/trace"
 )
 
+// tokenRefreshWindow is the remaining lifetime under which a user token can be refreshed
+const tokenRefreshWindow = 7 * 24 * time.Hour
+
+// ErrTokenNotRefreshable is returned when refreshing a token that is not a near-expiry user token
+var ErrTokenNotRefreshable = errors.New("token cannot be refreshed")
+
 type UserService struct {
 	repo          domain.UserRepository
 	authService   domain.AuthService
@@ -442,13 +449,88 @@ func (s *UserService) GetUserByEmail(ctx context.Context, email string) (*domain
 	return user, nil
 }
 
-// Logout logs out a user by deleting all their sessions
+// RefreshToken issues a new token for the user session of the request when its token is near
+// expiry. The session is extended and the previous token is revoked.
+func (s *UserService) RefreshToken(ctx context.Context) (*domain.AuthResponse, error) {
+	ctx, span := s.tracer.StartServiceSpan(ctx, "UserService", "RefreshToken")
+	defer span.End()
+
+	userID, _ := ctx.Value(domain.UserIDKey).(string)
+	sessionID, _ := ctx.Value(domain.SessionIDKey).(string)
+	tokenExpiresAt, ok := ctx.Value(domain.TokenExpiresAtKey).(time.Time)
+	if userID == "" || sessionID == "" || !ok {
+		return nil, fmt.Errorf("%w: only user session tokens can be refreshed", ErrTokenNotRefreshable)
+	}
+
+	s.tracer.AddAttribute(ctx, "user.id", userID)
+	s.tracer.AddAttribute(ctx, "session.id", sessionID)
+
+	if time.Until(tokenExpiresAt) > tokenRefreshWindow {
+		return nil, fmt.Errorf("%w: the token expires in more than %s", ErrTokenNotRefreshable, tokenRefreshWindow)
+	}
+
+	user, err := s.VerifyUserSession(ctx, userID, sessionID)
+	if err != nil {
+		s.tracer.MarkSpanError(ctx, err)
+		return nil, err
+	}
+
+	session, err := s.repo.GetSessionByID(ctx, sessionID)
+	if err != nil {
+		s.tracer.MarkSpanError(ctx, err)
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	session.ExpiresAt = time.Now().Add(s.sessionExpiry)
+	if err := s.repo.UpdateSession(ctx, session); err != nil {
+		s.logger.WithField("user_id", userID).WithField("session_id", sessionID).WithField("error", err.Error()).Error("Failed to extend session")
+		s.tracer.MarkSpanError(ctx, err)
+		return nil, fmt.Errorf("failed to extend session: %w", err)
+	}
+
+	token := s.authService.GenerateUserAuthToken(user, session.ID, session.ExpiresAt)
+	if token == "" {
+		err := fmt.Errorf("failed to generate token")
+		s.tracer.MarkSpanError(ctx, err)
+		return nil, err
+	}
+
+	// The previous token stays valid until revoked, the new one is only returned once it is
+	if tokenID, _ := ctx.Value(domain.TokenIDKey).(string); tokenID != "" {
+		if err := s.authService.RevokeToken(ctx, tokenID, tokenExpiresAt); err != nil {
+			s.tracer.MarkSpanError(ctx, err)
+			return nil, fmt.Errorf("failed to revoke previous token: %w", err)
+		}
+	}
+
+	s.logger.WithField("user_id", userID).WithField("session_id", sessionID).Info("User token refreshed")
+
+	return &domain.AuthResponse{
+		Token:     token,
+		User:      *user,
+		ExpiresAt: session.ExpiresAt,
+	}, nil
+}
+
+// Logout logs out a user by deleting all their sessions and revoking the token of the request
 func (s *UserService) Logout(ctx context.Context, userID string) error {
 	ctx, span := s.tracer.StartServiceSpan(ctx, "UserService", "Logout")
 	defer span.End()
 
 	s.tracer.AddAttribute(ctx, "user.id", userID)
 
+	// Deny the token right away, the middleware doesn't look up sessions
+	if tokenID, _ := ctx.Value(domain.TokenIDKey).(string); tokenID != "" {
+		tokenExpiresAt, ok := ctx.Value(domain.TokenExpiresAtKey).(time.Time)
+		if !ok {
+			tokenExpiresAt = time.Now().Add(s.sessionExpiry)
+		}
+		if err := s.authService.RevokeToken(ctx, tokenID, tokenExpiresAt); err != nil {
+			s.tracer.MarkSpanError(ctx, err)
+			return fmt.Errorf("failed to logout: %w", err)
+		}
+	}
+
 	// Delete all sessions for the user
 	err := s.repo.DeleteAllSessionsByUserID(ctx, userID)
 	if err != nil {
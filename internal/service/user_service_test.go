@@ -611,3 +611,92 @@ func TestUserService_Logout(t *testing.T) {
 		require.Contains(t, err.Error(), "failed to logout")
 	})
 }
+
+func TestUserService_Logout_RevokesToken(t *testing.T) {
+	mockRepo, mockAuthService, service, _ := setupUserTest(t)
+
+	tokenExpiresAt := time.Now().Add(time.Hour)
+	ctx := context.WithValue(context.Background(), domain.TokenIDKey, "token-id-1")
+	ctx = context.WithValue(ctx, domain.TokenExpiresAtKey, tokenExpiresAt)
+
+	mockAuthService.EXPECT().RevokeToken(gomock.Any(), "token-id-1", tokenExpiresAt).Return(nil)
+	mockRepo.EXPECT().DeleteAllSessionsByUserID(gomock.Any(), "user123").Return(nil)
+
+	require.NoError(t, service.Logout(ctx, "user123"))
+}
+
+func TestUserService_RefreshToken(t *testing.T) {
+	userID := "user123"
+	sessionID := "session123"
+	user := &domain.User{ID: userID, Email: "test@example.com"}
+
+	tokenContext := func(tokenExpiresAt time.Time) context.Context {
+		ctx := context.WithValue(context.Background(), domain.UserIDKey, userID)
+		ctx = context.WithValue(ctx, domain.SessionIDKey, sessionID)
+		ctx = context.WithValue(ctx, domain.TokenIDKey, "token-id-1")
+		return context.WithValue(ctx, domain.TokenExpiresAtKey, tokenExpiresAt)
+	}
+
+	t.Run("Success - Refreshes a near-expiry token", func(t *testing.T) {
+		mockRepo, mockAuthService, service, _ := setupUserTest(t)
+		service.sessionExpiry = 30 * 24 * time.Hour
+
+		tokenExpiresAt := time.Now().Add(24 * time.Hour)
+		session := &domain.Session{ID: sessionID, UserID: userID, ExpiresAt: tokenExpiresAt}
+		mockRepo.EXPECT().GetSessionByID(gomock.Any(), sessionID).Return(session, nil).Times(2)
+		mockRepo.EXPECT().GetUserByID(gomock.Any(), userID).Return(user, nil)
+		mockRepo.EXPECT().UpdateSession(gomock.Any(), session).Return(nil)
+		mockAuthService.EXPECT().GenerateUserAuthToken(user, sessionID, gomock.Any()).Return("new-token")
+		mockAuthService.EXPECT().RevokeToken(gomock.Any(), "token-id-1", tokenExpiresAt).Return(nil)
+
+		response, err := service.RefreshToken(tokenContext(tokenExpiresAt))
+		require.NoError(t, err)
+		require.Equal(t, "new-token", response.Token)
+		require.Equal(t, userID, response.User.ID)
+		require.True(t, response.ExpiresAt.After(time.Now().Add(29*24*time.Hour)), "the session is extended")
+	})
+
+	t.Run("Error - Token not near expiry", func(t *testing.T) {
+		_, _, service, _ := setupUserTest(t)
+
+		_, err := service.RefreshToken(tokenContext(time.Now().Add(20 * 24 * time.Hour)))
+		require.Error(t, err)
+		require.ErrorIs(t, err, ErrTokenNotRefreshable)
+	})
+
+	t.Run("Error - Not a user session token", func(t *testing.T) {
+		_, _, service, _ := setupUserTest(t)
+
+		ctx := context.WithValue(context.Background(), domain.UserIDKey, userID)
+		_, err := service.RefreshToken(ctx)
+		require.Error(t, err)
+		require.ErrorIs(t, err, ErrTokenNotRefreshable)
+	})
+
+	t.Run("Error - Session expired", func(t *testing.T) {
+		mockRepo, _, service, _ := setupUserTest(t)
+
+		mockRepo.EXPECT().GetSessionByID(gomock.Any(), sessionID).
+			Return(&domain.Session{ID: sessionID, UserID: userID, ExpiresAt: time.Now().Add(-time.Minute)}, nil)
+
+		_, err := service.RefreshToken(tokenContext(time.Now().Add(time.Hour)))
+		require.Error(t, err)
+		require.ErrorIs(t, err, ErrSessionExpired)
+	})
+
+	t.Run("Error - Previous token revocation fails", func(t *testing.T) {
+		mockRepo, mockAuthService, service, _ := setupUserTest(t)
+		service.sessionExpiry = 30 * 24 * time.Hour
+
+		session := &domain.Session{ID: sessionID, UserID: userID, ExpiresAt: time.Now().Add(time.Hour)}
+		mockRepo.EXPECT().GetSessionByID(gomock.Any(), sessionID).Return(session, nil).Times(2)
+		mockRepo.EXPECT().GetUserByID(gomock.Any(), userID).Return(user, nil)
+		mockRepo.EXPECT().UpdateSession(gomock.Any(), session).Return(nil)
+		mockAuthService.EXPECT().GenerateUserAuthToken(user, sessionID, gomock.Any()).Return("new-token")
+		mockAuthService.EXPECT().RevokeToken(gomock.Any(), "token-id-1", gomock.Any()).Return(errors.New("database error"))
+
+		response, err := service.RefreshToken(tokenContext(time.Now().Add(time.Hour)))
+		require.Error(t, err)
+		require.Nil(t, response, "the new token is not handed out")
+	})
+}
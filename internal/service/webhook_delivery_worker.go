@@ -11,6 +11,7 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/Notifuse/notifuse/internal/domain"
@@ -29,8 +30,17 @@ type WebhookDeliveryWorker struct {
 	lastCleanupTime  time.Time
 	cleanupInterval  time.Duration
 	retentionDays    int
+	endpointLimiter  *webhookEndpointLimiter
+	wg               sync.WaitGroup
 }
 
+const (
+	// defaultWebhookMaxConcurrentPerEndpoint is the default number of deliveries sent at once to an endpoint
+	defaultWebhookMaxConcurrentPerEndpoint = 4
+	// defaultWebhookMaxQueuedPerEndpoint is the default number of deliveries waiting for an endpoint
+	defaultWebhookMaxQueuedPerEndpoint = 100
+)
+
 // Aggressive retry delays as per Standard Webhooks spec
 var retryDelays = []time.Duration{
 	30 * time.Second,
@@ -69,9 +79,16 @@ func NewWebhookDeliveryWorker(
 		batchSize:        100,
 		cleanupInterval:  1 * time.Hour,
 		retentionDays:    7,
+		endpointLimiter:  newWebhookEndpointLimiter(defaultWebhookMaxConcurrentPerEndpoint, defaultWebhookMaxQueuedPerEndpoint),
 	}
 }
 
+// SetEndpointLimits sets the maximum number of deliveries sent at once to a webhook endpoint
+// and the maximum number of deliveries waiting for it. It must be called before Start.
+func (w *WebhookDeliveryWorker) SetEndpointLimits(maxConcurrent, maxQueued int) {
+	w.endpointLimiter = newWebhookEndpointLimiter(maxConcurrent, maxQueued)
+}
+
 // Start starts the webhook delivery worker
 func (w *WebhookDeliveryWorker) Start(ctx context.Context) {
 	w.logger.Info("Webhook delivery worker started")
@@ -83,6 +100,8 @@ func (w *WebhookDeliveryWorker) Start(ctx context.Context) {
 		select {
 		case <-ctx.Done():
 			w.logger.Info("Webhook delivery worker stopping...")
+			// Deliveries in flight are aborted by the context, wait for them to record their outcome
+			w.wg.Wait()
 			return
 		case <-ticker.C:
 			w.processDeliveries(ctx)
@@ -137,6 +156,11 @@ func (w *WebhookDeliveryWorker) processWorkspaceDeliveries(ctx context.Context,
 		case <-ctx.Done():
 			return ctx.Err()
 		default:
+			// Deliveries still being sent since an earlier poll are pending until they complete
+			if w.endpointLimiter.inFlight(delivery.ID) {
+				continue
+			}
+
 			// Get or cache subscription
 			sub, ok := subscriptionCache[delivery.SubscriptionID]
 			if !ok {
@@ -157,14 +181,72 @@ func (w *WebhookDeliveryWorker) processWorkspaceDeliveries(ctx context.Context,
 				continue
 			}
 
+			// Activities of a contact are delivered in order: wait for the earlier ones
+			if w.isBlockedByEarlierContactActivity(ctx, workspaceID, delivery) {
+				continue
+			}
+
 			// Process the delivery
-			w.processDelivery(ctx, workspaceID, delivery, sub)
+			w.dispatchDelivery(ctx, workspaceID, delivery, sub)
 		}
 	}
 
 	return nil
 }
 
+// dispatchDelivery sends a delivery in the background once its endpoint has a free slot.
+// A delivery the endpoint has no room for stays pending and is picked up by a later poll.
+func (w *WebhookDeliveryWorker) dispatchDelivery(ctx context.Context, workspaceID string, delivery *domain.WebhookDelivery, sub *domain.WebhookSubscription) {
+	slots, ok := w.endpointLimiter.admit(sub.URL, delivery.ID)
+	if !ok {
+		w.logger.WithFields(map[string]interface{}{
+			"delivery_id":     delivery.ID,
+			"subscription_id": sub.ID,
+		}).Debug("Webhook endpoint is at its delivery limit, delivery deferred")
+		return
+	}
+
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		defer w.endpointLimiter.done(sub.URL, delivery.ID)
+
+		select {
+		case slots <- struct{}{}:
+		case <-ctx.Done():
+			return
+		}
+		defer func() { <-slots }()
+
+		w.processDelivery(ctx, workspaceID, delivery, sub)
+	}()
+}
+
+// isBlockedByEarlierContactActivity reports whether a contact.activity delivery must wait for an earlier
+// activity of the same contact that is still pending for the subscription
+func (w *WebhookDeliveryWorker) isBlockedByEarlierContactActivity(ctx context.Context, workspaceID string, delivery *domain.WebhookDelivery) bool {
+	if delivery.EventType != string(domain.EventContactActivity) {
+		return false
+	}
+
+	activity, _ := delivery.Payload["activity"].(map[string]interface{})
+	email, _ := activity["email"].(string)
+	checkpoint, _ := activity["checkpoint"].(string)
+	if email == "" || checkpoint == "" {
+		return false
+	}
+
+	blocked, err := w.deliveryRepo.HasPendingContactActivityBefore(ctx, workspaceID, delivery.SubscriptionID, email, checkpoint)
+	if err != nil {
+		w.logger.WithFields(map[string]interface{}{
+			"delivery_id": delivery.ID,
+			"error":       err.Error(),
+		}).Error("Failed to check earlier contact activities")
+		return true
+	}
+	return blocked
+}
+
 // cleanupOldDeliveries removes webhook deliveries older than the retention period
 func (w *WebhookDeliveryWorker) cleanupOldDeliveries(ctx context.Context) {
 	// Skip if not enough time has passed since last cleanup
@@ -2,11 +2,15 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -317,6 +321,288 @@ func TestWebhookDeliveryWorker_processWorkspaceDeliveries(t *testing.T) {
 
 		err := worker.processWorkspaceDeliveries(ctx, workspaceID)
 		assert.NoError(t, err)
+		worker.wg.Wait()
+	})
+}
+
+func TestWebhookDeliveryWorker_processWorkspaceDeliveries_ContactActivityOrdering(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSubRepo := mocks.NewMockWebhookSubscriptionRepository(ctrl)
+	mockDeliveryRepo := mocks.NewMockWebhookDeliveryRepository(ctrl)
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+	mockLogger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
+
+	ctx := context.Background()
+	workspaceID := "workspace1"
+
+	var received []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = append(received, r.Header.Get("webhook-id"))
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	activity := func(id, email, checkpoint string) *domain.WebhookDelivery {
+		return &domain.WebhookDelivery{
+			ID:             id,
+			SubscriptionID: "sub1",
+			EventType:      "contact.activity",
+			Payload: map[string]interface{}{"activity": map[string]interface{}{
+				"email":      email,
+				"checkpoint": checkpoint,
+			}},
+			MaxAttempts: 10,
+		}
+	}
+
+	worker := NewWebhookDeliveryWorker(mockSubRepo, mockDeliveryRepo, mockWorkspaceRepo, mockLogger, nil)
+
+	mockDeliveryRepo.EXPECT().GetPendingForWorkspace(ctx, workspaceID, 100).Return([]*domain.WebhookDelivery{
+		activity("delivery1", "john@example.com", "00000000000000000002-b"),
+		activity("delivery2", "jane@example.com", "00000000000000000003-c"),
+	}, nil)
+	mockSubRepo.EXPECT().GetByID(ctx, workspaceID, "sub1").Return(&domain.WebhookSubscription{ID: "sub1", URL: server.URL, Secret: "secret123", Enabled: true}, nil)
+
+	// An earlier activity of john is waiting for a retry: his next one is held back
+	mockDeliveryRepo.EXPECT().HasPendingContactActivityBefore(ctx, workspaceID, "sub1", "john@example.com", "00000000000000000002-b").Return(true, nil)
+	mockDeliveryRepo.EXPECT().HasPendingContactActivityBefore(ctx, workspaceID, "sub1", "jane@example.com", "00000000000000000003-c").Return(false, nil)
+	mockDeliveryRepo.EXPECT().MarkDelivered(ctx, workspaceID, "delivery2", http.StatusOK, gomock.Any()).Return(nil)
+	mockSubRepo.EXPECT().UpdateLastDeliveryAt(ctx, workspaceID, "sub1", gomock.Any()).Return(nil)
+
+	err := worker.processWorkspaceDeliveries(ctx, workspaceID)
+	assert.NoError(t, err)
+	worker.wg.Wait()
+	assert.Equal(t, []string{"delivery2"}, received)
+}
+
+// TestWebhookDeliveryWorker_processWorkspaceDeliveries_ListSubscribed covers the delivery queued by the
+// contact_lists trigger when a contact subscribes to a list
+func TestWebhookDeliveryWorker_processWorkspaceDeliveries_ListSubscribed(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockSubRepo := mocks.NewMockWebhookSubscriptionRepository(ctrl)
+	mockDeliveryRepo := mocks.NewMockWebhookDeliveryRepository(ctrl)
+	mockWorkspaceRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+	mockLogger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
+
+	ctx := context.Background()
+	workspaceID := "workspace1"
+	secret := "secret123"
+
+	var mu sync.Mutex
+	var requests []*http.Request
+	var bodies [][]byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		requests = append(requests, r)
+		bodies = append(bodies, body)
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	worker := NewWebhookDeliveryWorker(mockSubRepo, mockDeliveryRepo, mockWorkspaceRepo, mockLogger, nil)
+
+	// The payload written by webhook_contact_lists_trigger for an insert with the active status
+	mockDeliveryRepo.EXPECT().GetPendingForWorkspace(ctx, workspaceID, 100).Return([]*domain.WebhookDelivery{
+		{
+			ID:             "delivery1",
+			SubscriptionID: "sub1",
+			EventType:      "list.subscribed",
+			Payload: map[string]interface{}{
+				"email":           "john@example.com",
+				"list_id":         "newsletter",
+				"list_name":       "Newsletter",
+				"status":          "active",
+				"previous_status": nil,
+			},
+			MaxAttempts: 10,
+		},
+	}, nil)
+	mockSubRepo.EXPECT().GetByID(ctx, workspaceID, "sub1").Return(&domain.WebhookSubscription{
+		ID:       "sub1",
+		URL:      server.URL,
+		Secret:   secret,
+		Settings: domain.WebhookSubscriptionSettings{EventTypes: []string{"list.subscribed", "list.unsubscribed"}},
+		Enabled:  true,
+	}, nil)
+	mockDeliveryRepo.EXPECT().MarkDelivered(ctx, workspaceID, "delivery1", http.StatusOK, gomock.Any()).Return(nil).Times(1)
+	mockSubRepo.EXPECT().UpdateLastDeliveryAt(ctx, workspaceID, "sub1", gomock.Any()).Return(nil)
+
+	err := worker.processWorkspaceDeliveries(ctx, workspaceID)
+	require.NoError(t, err)
+	worker.wg.Wait()
+
+	require.Len(t, requests, 1)
+	req := requests[0]
+	assert.Equal(t, http.MethodPost, req.Method)
+	assert.Equal(t, "delivery1", req.Header.Get("webhook-id"))
+
+	timestamp, err := strconv.ParseInt(req.Header.Get("webhook-timestamp"), 10, 64)
+	require.NoError(t, err)
+	assert.Equal(t, signPayload("delivery1", timestamp, bodies[0], []byte(secret)), req.Header.Get("webhook-signature"))
+
+	var envelope struct {
+		Type        string                 `json:"type"`
+		WorkspaceID string                 `json:"workspace_id"`
+		Data        map[string]interface{} `json:"data"`
+	}
+	require.NoError(t, json.Unmarshal(bodies[0], &envelope))
+	assert.Equal(t, "list.subscribed", envelope.Type)
+	assert.Equal(t, workspaceID, envelope.WorkspaceID)
+	assert.Equal(t, "john@example.com", envelope.Data["email"])
+	assert.Equal(t, "newsletter", envelope.Data["list_id"])
+}
+
+func TestWebhookDeliveryWorker_processWorkspaceDeliveries_EndpointLimits(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := "workspace1"
+
+	setup := func(t *testing.T) (*WebhookDeliveryWorker, *mocks.MockWebhookSubscriptionRepository, *mocks.MockWebhookDeliveryRepository) {
+		ctrl := gomock.NewController(t)
+		t.Cleanup(ctrl.Finish)
+
+		mockSubRepo := mocks.NewMockWebhookSubscriptionRepository(ctrl)
+		mockDeliveryRepo := mocks.NewMockWebhookDeliveryRepository(ctrl)
+		mockLogger := pkgmocks.NewMockLogger(ctrl)
+		mockLogger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(mockLogger).AnyTimes()
+		mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+		mockLogger.EXPECT().Debug(gomock.Any()).AnyTimes()
+		mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
+
+		worker := NewWebhookDeliveryWorker(mockSubRepo, mockDeliveryRepo, mocks.NewMockWorkspaceRepository(ctrl), mockLogger, nil)
+		return worker, mockSubRepo, mockDeliveryRepo
+	}
+
+	// slowEndpoint holds its requests until release is closed and records the highest concurrency seen
+	type slowEndpoint struct {
+		server    *httptest.Server
+		release   chan struct{}
+		mu        sync.Mutex
+		active    int
+		maxActive int
+		received  int
+	}
+	newSlowEndpoint := func(t *testing.T) *slowEndpoint {
+		endpoint := &slowEndpoint{release: make(chan struct{})}
+		endpoint.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			endpoint.mu.Lock()
+			endpoint.active++
+			endpoint.received++
+			if endpoint.active > endpoint.maxActive {
+				endpoint.maxActive = endpoint.active
+			}
+			endpoint.mu.Unlock()
+
+			<-endpoint.release
+
+			endpoint.mu.Lock()
+			endpoint.active--
+			endpoint.mu.Unlock()
+			w.WriteHeader(http.StatusOK)
+		}))
+		t.Cleanup(endpoint.server.Close)
+		return endpoint
+	}
+
+	deliveriesFor := func(subscriptionID string, count int) []*domain.WebhookDelivery {
+		deliveries := make([]*domain.WebhookDelivery, count)
+		for i := range deliveries {
+			deliveries[i] = &domain.WebhookDelivery{
+				ID:             fmt.Sprintf("%s-delivery%d", subscriptionID, i),
+				SubscriptionID: subscriptionID,
+				EventType:      "contact.created",
+				Payload:        map[string]interface{}{"email": "test@example.com"},
+				MaxAttempts:    10,
+			}
+		}
+		return deliveries
+	}
+
+	t.Run("slow endpoint is capped while fast endpoint proceeds", func(t *testing.T) {
+		worker, mockSubRepo, mockDeliveryRepo := setup(t)
+		worker.SetEndpointLimits(2, 10)
+
+		slow := newSlowEndpoint(t)
+		fastDelivered := make(chan string, 3)
+		fast := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer fast.Close()
+
+		deliveries := append(deliveriesFor("slow", 6), deliveriesFor("fast", 3)...)
+		mockDeliveryRepo.EXPECT().GetPendingForWorkspace(ctx, workspaceID, 100).Return(deliveries, nil)
+		mockSubRepo.EXPECT().GetByID(ctx, workspaceID, "slow").Return(&domain.WebhookSubscription{ID: "slow", URL: slow.server.URL, Secret: "secret", Enabled: true}, nil)
+		mockSubRepo.EXPECT().GetByID(ctx, workspaceID, "fast").Return(&domain.WebhookSubscription{ID: "fast", URL: fast.URL, Secret: "secret", Enabled: true}, nil)
+		mockDeliveryRepo.EXPECT().MarkDelivered(ctx, workspaceID, gomock.Any(), http.StatusOK, gomock.Any()).
+			Do(func(_ context.Context, _, deliveryID string, _ int, _ string) {
+				if strings.HasPrefix(deliveryID, "fast") {
+					fastDelivered <- deliveryID
+				}
+			}).Return(nil).Times(9)
+		mockSubRepo.EXPECT().UpdateLastDeliveryAt(ctx, workspaceID, gomock.Any(), gomock.Any()).Return(nil).Times(9)
+
+		require.NoError(t, worker.processWorkspaceDeliveries(ctx, workspaceID))
+
+		// Every fast delivery completes while the slow endpoint is still holding its requests
+		for i := 0; i < 3; i++ {
+			select {
+			case <-fastDelivered:
+			case <-time.After(2 * time.Second):
+				t.Fatal("fast endpoint deliveries were held back by the slow endpoint")
+			}
+		}
+
+		assert.Eventually(t, func() bool {
+			slow.mu.Lock()
+			defer slow.mu.Unlock()
+			return slow.received == 2
+		}, 2*time.Second, 10*time.Millisecond)
+
+		close(slow.release)
+		worker.wg.Wait()
+
+		assert.Equal(t, 2, slow.maxActive)
+		assert.Equal(t, 6, slow.received)
+	})
+
+	t.Run("deliveries beyond the endpoint queue stay pending", func(t *testing.T) {
+		worker, mockSubRepo, mockDeliveryRepo := setup(t)
+		worker.SetEndpointLimits(1, 1)
+
+		slow := newSlowEndpoint(t)
+		deliveries := deliveriesFor("slow", 4)
+		subscription := &domain.WebhookSubscription{ID: "slow", URL: slow.server.URL, Secret: "secret", Enabled: true}
+
+		mockDeliveryRepo.EXPECT().GetPendingForWorkspace(ctx, workspaceID, 100).Return(deliveries, nil).Times(2)
+		mockSubRepo.EXPECT().GetByID(ctx, workspaceID, "slow").Return(subscription, nil).Times(2)
+		mockDeliveryRepo.EXPECT().MarkDelivered(ctx, workspaceID, "slow-delivery0", http.StatusOK, gomock.Any()).Return(nil)
+		mockDeliveryRepo.EXPECT().MarkDelivered(ctx, workspaceID, "slow-delivery1", http.StatusOK, gomock.Any()).Return(nil)
+		mockSubRepo.EXPECT().UpdateLastDeliveryAt(ctx, workspaceID, "slow", gomock.Any()).Return(nil).Times(2)
+
+		require.NoError(t, worker.processWorkspaceDeliveries(ctx, workspaceID))
+
+		// A later poll does not send the deliveries still in flight again, nor exceed the queue
+		require.NoError(t, worker.processWorkspaceDeliveries(ctx, workspaceID))
+
+		close(slow.release)
+		worker.wg.Wait()
+
+		assert.Equal(t, 1, slow.maxActive)
+		assert.Equal(t, 2, slow.received)
+		assert.False(t, worker.endpointLimiter.inFlight("slow-delivery0"))
 	})
 }
 
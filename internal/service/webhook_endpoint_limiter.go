@@ -0,0 +1,89 @@
+package service
+
+import "sync"
+
+// webhookEndpointLimiter bounds the outbound webhook deliveries of each endpoint. At most maxConcurrent
+// deliveries are sent to an endpoint at once and at most maxQueued more wait for a slot, so a slow
+// endpoint is throttled without holding back the deliveries of the other endpoints.
+type webhookEndpointLimiter struct {
+	maxConcurrent int
+	maxQueued     int
+
+	mu         sync.Mutex
+	endpoints  map[string]*webhookEndpoint
+	deliveries map[string]struct{}
+}
+
+// webhookEndpoint tracks the deliveries admitted for an endpoint
+type webhookEndpoint struct {
+	slots    chan struct{}
+	admitted int
+}
+
+// newWebhookEndpointLimiter creates a limiter, values below 1 for maxConcurrent or below 0 for maxQueued are raised
+func newWebhookEndpointLimiter(maxConcurrent, maxQueued int) *webhookEndpointLimiter {
+	if maxConcurrent < 1 {
+		maxConcurrent = 1
+	}
+	if maxQueued < 0 {
+		maxQueued = 0
+	}
+
+	return &webhookEndpointLimiter{
+		maxConcurrent: maxConcurrent,
+		maxQueued:     maxQueued,
+		endpoints:     make(map[string]*webhookEndpoint),
+		deliveries:    make(map[string]struct{}),
+	}
+}
+
+// inFlight reports whether a delivery has been admitted and is not done yet
+func (l *webhookEndpointLimiter) inFlight(deliveryID string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	_, ok := l.deliveries[deliveryID]
+	return ok
+}
+
+// admit reserves a place for a delivery to an endpoint. It returns the slots of the endpoint, false when the
+// delivery is already in flight or the endpoint has as many deliveries as it can hold.
+func (l *webhookEndpointLimiter) admit(endpointURL, deliveryID string) (chan struct{}, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if _, ok := l.deliveries[deliveryID]; ok {
+		return nil, false
+	}
+
+	endpoint, ok := l.endpoints[endpointURL]
+	if !ok {
+		endpoint = &webhookEndpoint{slots: make(chan struct{}, l.maxConcurrent)}
+		l.endpoints[endpointURL] = endpoint
+	}
+	if endpoint.admitted >= l.maxConcurrent+l.maxQueued {
+		return nil, false
+	}
+
+	endpoint.admitted++
+	l.deliveries[deliveryID] = struct{}{}
+	return endpoint.slots, true
+}
+
+// done releases the place of an admitted delivery
+func (l *webhookEndpointLimiter) done(endpointURL, deliveryID string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.deliveries, deliveryID)
+
+	endpoint, ok := l.endpoints[endpointURL]
+	if !ok {
+		return
+	}
+	endpoint.admitted--
+	// Idle endpoints are forgotten so the map does not grow with every URL ever delivered to
+	if endpoint.admitted <= 0 {
+		delete(l.endpoints, endpointURL)
+	}
+}
@@ -0,0 +1,222 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/pkg/logger"
+)
+
+// webhookHealthRecordInterval bounds how often the sends and the events of an integration are written
+const webhookHealthRecordInterval = time.Minute
+
+// webhookHealthRecord keeps the last sends and events written for an integration
+type webhookHealthRecord struct {
+	sentAt  time.Time
+	eventAt time.Time
+}
+
+// WebhookHealthMonitor tracks the emails sent through the provider integrations and the webhook
+// events the providers send back. An integration that keeps sending without receiving any event
+// for longer than the window raises an integration.webhook_stale event, once per period without events.
+type WebhookHealthMonitor struct {
+	repo          domain.ProviderWebhookHealthRepository
+	workspaceRepo domain.WorkspaceRepository
+	eventBus      domain.EventBus
+	logger        logger.Logger
+	window        time.Duration
+	checkInterval time.Duration
+	now           func() time.Time
+
+	mu      sync.Mutex
+	records map[string]*webhookHealthRecord
+}
+
+// NewWebhookHealthMonitor creates a new provider webhook health monitor
+func NewWebhookHealthMonitor(
+	repo domain.ProviderWebhookHealthRepository,
+	workspaceRepo domain.WorkspaceRepository,
+	eventBus domain.EventBus,
+	logger logger.Logger,
+	window time.Duration,
+) *WebhookHealthMonitor {
+	return &WebhookHealthMonitor{
+		repo:          repo,
+		workspaceRepo: workspaceRepo,
+		eventBus:      eventBus,
+		logger:        logger,
+		window:        window,
+		checkInterval: 5 * time.Minute,
+		now:           time.Now,
+		records:       make(map[string]*webhookHealthRecord),
+	}
+}
+
+// RecordSent records an email sent through an integration. SMTP servers do not send webhooks
+// on their own, so their sends are ignored.
+func (m *WebhookHealthMonitor) RecordSent(ctx context.Context, workspaceID, integrationID string, providerKind domain.EmailProviderKind) {
+	if integrationID == "" || providerKind == "" || providerKind == domain.EmailProviderKindSMTP {
+		return
+	}
+
+	now := m.now().UTC()
+	if !m.shouldRecord(workspaceID, integrationID, now, true) {
+		return
+	}
+
+	if err := m.repo.RecordSent(ctx, workspaceID, integrationID, now); err != nil {
+		m.logger.WithFields(map[string]interface{}{
+			"workspace_id":   workspaceID,
+			"integration_id": integrationID,
+			"error":          err.Error(),
+		}).Warn("Failed to record provider send for webhook health")
+		return
+	}
+	m.recorded(workspaceID, integrationID, now, true)
+}
+
+// RecordEvent records a webhook event received for an integration
+func (m *WebhookHealthMonitor) RecordEvent(ctx context.Context, workspaceID, integrationID string) {
+	if integrationID == "" {
+		return
+	}
+
+	now := m.now().UTC()
+	if !m.shouldRecord(workspaceID, integrationID, now, false) {
+		return
+	}
+
+	if err := m.repo.RecordEvent(ctx, workspaceID, integrationID, now); err != nil {
+		m.logger.WithFields(map[string]interface{}{
+			"workspace_id":   workspaceID,
+			"integration_id": integrationID,
+			"error":          err.Error(),
+		}).Warn("Failed to record provider webhook event for webhook health")
+		return
+	}
+	m.recorded(workspaceID, integrationID, now, false)
+}
+
+// shouldRecord throttles the writes of busy integrations. A send following an event, or an event
+// following a send, is always written so that periods without events start and end on time.
+func (m *WebhookHealthMonitor) shouldRecord(workspaceID, integrationID string, now time.Time, sent bool) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	record, ok := m.records[workspaceID+":"+integrationID]
+	if !ok {
+		return true
+	}
+	if sent {
+		return record.eventAt.After(record.sentAt) || now.Sub(record.sentAt) >= webhookHealthRecordInterval
+	}
+	return record.sentAt.After(record.eventAt) || now.Sub(record.eventAt) >= webhookHealthRecordInterval
+}
+
+// recorded remembers a successful write for throttling
+func (m *WebhookHealthMonitor) recorded(workspaceID, integrationID string, now time.Time, sent bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := workspaceID + ":" + integrationID
+	record, ok := m.records[key]
+	if !ok {
+		record = &webhookHealthRecord{}
+		m.records[key] = record
+	}
+	if sent {
+		record.sentAt = now
+	} else {
+		record.eventAt = now
+	}
+}
+
+// List retrieves the webhook health of the integrations of a workspace, flagging the stale ones
+func (m *WebhookHealthMonitor) List(ctx context.Context, workspaceID string) ([]*domain.ProviderWebhookHealth, error) {
+	healths, err := m.repo.List(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list provider webhook health: %w", err)
+	}
+
+	now := m.now().UTC()
+	for _, health := range healths {
+		health.Stale = health.IsStale(now, m.window)
+	}
+
+	return healths, nil
+}
+
+// Start starts checking the webhook health of every workspace periodically
+func (m *WebhookHealthMonitor) Start(ctx context.Context) {
+	m.logger.Info("Webhook health monitor started")
+
+	ticker := time.NewTicker(m.checkInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			m.logger.Info("Webhook health monitor stopping...")
+			return
+		case <-ticker.C:
+			m.checkWorkspaces(ctx)
+		}
+	}
+}
+
+// checkWorkspaces checks the webhook health of every workspace
+func (m *WebhookHealthMonitor) checkWorkspaces(ctx context.Context) {
+	workspaces, err := m.workspaceRepo.List(ctx)
+	if err != nil {
+		m.logger.WithField("error", err.Error()).Error("Failed to list workspaces for webhook health check")
+		return
+	}
+
+	for _, workspace := range workspaces {
+		if err := m.CheckWorkspace(ctx, workspace); err != nil {
+			m.logger.WithFields(map[string]interface{}{
+				"workspace_id": workspace.ID,
+				"error":        err.Error(),
+			}).Error("Failed to check webhook health for workspace")
+		}
+	}
+}
+
+// CheckWorkspace raises an alert for each integration of the workspace that has been sending
+// without receiving webhook events for longer than the window
+func (m *WebhookHealthMonitor) CheckWorkspace(ctx context.Context, workspace *domain.Workspace) error {
+	healths, err := m.repo.List(ctx, workspace.ID)
+	if err != nil {
+		return fmt.Errorf("failed to list provider webhook health: %w", err)
+	}
+
+	now := m.now().UTC()
+	for _, health := range healths {
+		if !health.NeedsAlert(now, m.window) {
+			continue
+		}
+
+		// Integrations removed since their last send are not alerted on
+		integration := workspace.GetIntegrationByID(health.IntegrationID)
+		if integration == nil {
+			continue
+		}
+
+		m.logger.WithFields(map[string]interface{}{
+			"workspace_id":   workspace.ID,
+			"integration_id": health.IntegrationID,
+			"pending_since":  health.PendingSince,
+		}).Warn("No provider webhook event received despite recent sends")
+
+		m.eventBus.Publish(ctx, domain.NewIntegrationWebhookStaleEvent(workspace.ID, integration, health, m.window))
+
+		if err := m.repo.MarkAlerted(ctx, workspace.ID, health.IntegrationID, now); err != nil {
+			return fmt.Errorf("failed to mark provider webhook alert: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,225 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/domain/mocks"
+	pkgmocks "github.com/Notifuse/notifuse/pkg/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryWebhookHealthRepository mirrors the provider_webhook_health upserts in memory
+type memoryWebhookHealthRepository struct {
+	healths map[string]*domain.ProviderWebhookHealth
+}
+
+func (r *memoryWebhookHealthRepository) get(integrationID string, at time.Time) *domain.ProviderWebhookHealth {
+	health, ok := r.healths[integrationID]
+	if !ok {
+		health = &domain.ProviderWebhookHealth{IntegrationID: integrationID}
+		r.healths[integrationID] = health
+	}
+	health.UpdatedAt = at
+	return health
+}
+
+func (r *memoryWebhookHealthRepository) RecordSent(_ context.Context, _, integrationID string, sentAt time.Time) error {
+	health := r.get(integrationID, sentAt)
+	health.LastSentAt = &sentAt
+	if health.PendingSince == nil {
+		health.PendingSince = &sentAt
+	}
+	return nil
+}
+
+func (r *memoryWebhookHealthRepository) RecordEvent(_ context.Context, _, integrationID string, receivedAt time.Time) error {
+	health := r.get(integrationID, receivedAt)
+	health.LastEventAt = &receivedAt
+	health.PendingSince = nil
+	return nil
+}
+
+func (r *memoryWebhookHealthRepository) List(_ context.Context, _ string) ([]*domain.ProviderWebhookHealth, error) {
+	healths := []*domain.ProviderWebhookHealth{}
+	for _, health := range r.healths {
+		copied := *health
+		healths = append(healths, &copied)
+	}
+	return healths, nil
+}
+
+func (r *memoryWebhookHealthRepository) MarkAlerted(_ context.Context, _, integrationID string, alertedAt time.Time) error {
+	r.healths[integrationID].AlertedAt = &alertedAt
+	return nil
+}
+
+func setupWebhookHealthMonitorTest(t *testing.T, repo domain.ProviderWebhookHealthRepository) (*WebhookHealthMonitor, *mocks.MockEventBus, *time.Time) {
+	ctrl := gomock.NewController(t)
+	t.Cleanup(ctrl.Finish)
+
+	eventBus := mocks.NewMockEventBus(ctrl)
+	log := pkgmocks.NewMockLogger(ctrl)
+	log.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(log).AnyTimes()
+	log.EXPECT().WithFields(gomock.Any()).Return(log).AnyTimes()
+	log.EXPECT().Warn(gomock.Any()).AnyTimes()
+	log.EXPECT().Error(gomock.Any()).AnyTimes()
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	monitor := NewWebhookHealthMonitor(repo, mocks.NewMockWorkspaceRepository(ctrl), eventBus, log, 6*time.Hour)
+	monitor.now = func() time.Time { return now }
+
+	return monitor, eventBus, &now
+}
+
+func webhookHealthWorkspace() *domain.Workspace {
+	return &domain.Workspace{
+		ID: "ws1",
+		Integrations: []domain.Integration{
+			{ID: "ses-1", Name: "SES", Type: domain.IntegrationTypeEmail, EmailProvider: domain.EmailProvider{Kind: domain.EmailProviderKindSES}},
+		},
+	}
+}
+
+func TestWebhookHealthMonitor_CheckWorkspace(t *testing.T) {
+	ctx := context.Background()
+	workspace := webhookHealthWorkspace()
+
+	t.Run("sends without events past the window raise a single alert", func(t *testing.T) {
+		repo := &memoryWebhookHealthRepository{healths: map[string]*domain.ProviderWebhookHealth{}}
+		monitor, eventBus, now := setupWebhookHealthMonitorTest(t, repo)
+		start := *now
+
+		monitor.RecordSent(ctx, "ws1", "ses-1", domain.EmailProviderKindSES)
+		*now = start.Add(2 * time.Hour)
+		monitor.RecordSent(ctx, "ws1", "ses-1", domain.EmailProviderKindSES)
+
+		// Still within the window since the first send
+		*now = start.Add(5 * time.Hour)
+		require.NoError(t, monitor.CheckWorkspace(ctx, workspace))
+
+		*now = start.Add(7 * time.Hour)
+		eventBus.EXPECT().Publish(ctx, gomock.Any()).Do(func(_ context.Context, event domain.EventPayload) {
+			assert.Equal(t, domain.EventIntegrationWebhookStale, event.Type)
+			assert.Equal(t, "ws1", event.WorkspaceID)
+			assert.Equal(t, "ses-1", event.EntityID)
+			assert.Equal(t, "ses", event.Data["provider"])
+			assert.Equal(t, start.Format(time.RFC3339), event.Data["pending_since"])
+			assert.Equal(t, start.Add(2*time.Hour).Format(time.RFC3339), event.Data["last_sent_at"])
+		}).Times(1)
+		require.NoError(t, monitor.CheckWorkspace(ctx, workspace))
+
+		// Already alerted for this period
+		*now = start.Add(8 * time.Hour)
+		require.NoError(t, monitor.CheckWorkspace(ctx, workspace))
+	})
+
+	t.Run("an event ends the period and later sends start a new one", func(t *testing.T) {
+		repo := &memoryWebhookHealthRepository{healths: map[string]*domain.ProviderWebhookHealth{}}
+		monitor, eventBus, now := setupWebhookHealthMonitorTest(t, repo)
+		start := *now
+
+		monitor.RecordSent(ctx, "ws1", "ses-1", domain.EmailProviderKindSES)
+		*now = start.Add(10 * time.Second)
+		monitor.RecordEvent(ctx, "ws1", "ses-1")
+
+		*now = start.Add(7 * time.Hour)
+		require.NoError(t, monitor.CheckWorkspace(ctx, workspace))
+
+		// The send right after the event is written despite throttling
+		monitor.RecordSent(ctx, "ws1", "ses-1", domain.EmailProviderKindSES)
+		*now = start.Add(14 * time.Hour)
+		eventBus.EXPECT().Publish(ctx, gomock.Any()).Times(1)
+		require.NoError(t, monitor.CheckWorkspace(ctx, workspace))
+	})
+
+	t.Run("removed integrations are not alerted on", func(t *testing.T) {
+		repo := &memoryWebhookHealthRepository{healths: map[string]*domain.ProviderWebhookHealth{}}
+		monitor, _, now := setupWebhookHealthMonitorTest(t, repo)
+
+		monitor.RecordSent(ctx, "ws1", "removed", domain.EmailProviderKindSES)
+		*now = now.Add(7 * time.Hour)
+
+		require.NoError(t, monitor.CheckWorkspace(ctx, workspace))
+		assert.Nil(t, repo.healths["removed"].AlertedAt)
+	})
+
+	t.Run("list error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mocks.NewMockProviderWebhookHealthRepository(ctrl)
+		monitor, _, _ := setupWebhookHealthMonitorTest(t, repo)
+		repo.EXPECT().List(ctx, "ws1").Return(nil, errors.New("db error"))
+
+		err := monitor.CheckWorkspace(ctx, workspace)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to list provider webhook health")
+	})
+}
+
+func TestWebhookHealthMonitor_RecordSent(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("throttles the writes of busy integrations", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mocks.NewMockProviderWebhookHealthRepository(ctrl)
+		monitor, _, now := setupWebhookHealthMonitorTest(t, repo)
+		start := *now
+
+		repo.EXPECT().RecordSent(ctx, "ws1", "ses-1", start).Return(nil)
+		repo.EXPECT().RecordSent(ctx, "ws1", "ses-1", start.Add(time.Minute)).Return(nil)
+
+		monitor.RecordSent(ctx, "ws1", "ses-1", domain.EmailProviderKindSES)
+		*now = start.Add(30 * time.Second)
+		monitor.RecordSent(ctx, "ws1", "ses-1", domain.EmailProviderKindSES)
+		*now = start.Add(time.Minute)
+		monitor.RecordSent(ctx, "ws1", "ses-1", domain.EmailProviderKindSES)
+	})
+
+	t.Run("retries after a failed write", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mocks.NewMockProviderWebhookHealthRepository(ctrl)
+		monitor, _, _ := setupWebhookHealthMonitorTest(t, repo)
+
+		repo.EXPECT().RecordSent(ctx, "ws1", "ses-1", gomock.Any()).Return(errors.New("db error"))
+		repo.EXPECT().RecordSent(ctx, "ws1", "ses-1", gomock.Any()).Return(nil)
+
+		monitor.RecordSent(ctx, "ws1", "ses-1", domain.EmailProviderKindSES)
+		monitor.RecordSent(ctx, "ws1", "ses-1", domain.EmailProviderKindSES)
+	})
+
+	t.Run("ignores SMTP sends", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		repo := mocks.NewMockProviderWebhookHealthRepository(ctrl)
+		monitor, _, _ := setupWebhookHealthMonitorTest(t, repo)
+
+		monitor.RecordSent(ctx, "ws1", "smtp-1", domain.EmailProviderKindSMTP)
+	})
+}
+
+func TestWebhookHealthMonitor_List(t *testing.T) {
+	ctx := context.Background()
+	repo := &memoryWebhookHealthRepository{healths: map[string]*domain.ProviderWebhookHealth{}}
+	monitor, _, now := setupWebhookHealthMonitorTest(t, repo)
+
+	monitor.RecordSent(ctx, "ws1", "ses-1", domain.EmailProviderKindSES)
+	*now = now.Add(7 * time.Hour)
+
+	healths, err := monitor.List(ctx, "ws1")
+	require.NoError(t, err)
+	require.Len(t, healths, 1)
+	assert.True(t, healths[0].Stale)
+	assert.Nil(t, healths[0].LastEventAt)
+}
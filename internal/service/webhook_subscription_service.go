@@ -6,6 +6,7 @@ import (
 	"encoding/base64"
 	"fmt"
 	"net/url"
+	"slices"
 	"strings"
 
 	"github.com/Notifuse/notifuse/internal/domain"
@@ -181,8 +182,9 @@ func (s *WebhookSubscriptionService) Update(ctx context.Context, workspaceID str
 	existing.Name = name
 	existing.URL = webhookURL
 	existing.Settings = domain.WebhookSubscriptionSettings{
-		EventTypes:         eventTypes,
-		CustomEventFilters: customEventFilters,
+		EventTypes:                eventTypes,
+		CustomEventFilters:        customEventFilters,
+		ContactActivityCheckpoint: existing.Settings.ContactActivityCheckpoint,
 	}
 	existing.Enabled = enabled
 
@@ -277,3 +279,198 @@ func (s *WebhookSubscriptionService) GetDeliveries(ctx context.Context, workspac
 func (s *WebhookSubscriptionService) GetEventTypes() []string {
 	return domain.WebhookEventTypes
 }
+
+// SubscribeToBroadcastEvents queues outgoing webhooks for broadcast phase changes
+func (s *WebhookSubscriptionService) SubscribeToBroadcastEvents(eventBus domain.EventBus) {
+	eventBus.Subscribe(domain.EventBroadcastPhaseChanged, s.handleBroadcastPhaseChanged)
+}
+
+// handleBroadcastPhaseChanged creates a delivery for each enabled subscription to broadcast.phase_changed
+func (s *WebhookSubscriptionService) handleBroadcastPhaseChanged(ctx context.Context, payload domain.EventPayload) {
+	subs, err := s.repo.List(ctx, payload.WorkspaceID)
+	if err != nil {
+		s.logger.WithFields(map[string]interface{}{
+			"workspace_id": payload.WorkspaceID,
+			"broadcast_id": payload.EntityID,
+			"error":        err.Error(),
+		}).Error("Failed to list webhook subscriptions for broadcast phase change")
+		return
+	}
+
+	eventType := string(domain.EventBroadcastPhaseChanged)
+	for _, sub := range subs {
+		if !sub.Enabled || !slices.Contains(sub.Settings.EventTypes, eventType) {
+			continue
+		}
+
+		delivery := &domain.WebhookDelivery{
+			ID:             uuid.New().String(),
+			SubscriptionID: sub.ID,
+			EventType:      eventType,
+			Payload:        map[string]interface{}{"broadcast": payload.Data},
+			Status:         domain.WebhookDeliveryStatusPending,
+			MaxAttempts:    10,
+		}
+		if err := s.deliveryRepo.Create(ctx, payload.WorkspaceID, delivery); err != nil {
+			s.logger.WithFields(map[string]interface{}{
+				"workspace_id":    payload.WorkspaceID,
+				"subscription_id": sub.ID,
+				"broadcast_id":    payload.EntityID,
+				"error":           err.Error(),
+			}).Error("Failed to queue broadcast phase change webhook")
+		}
+	}
+}
+
+// SubscribeToIntegrationEvents queues outgoing webhooks for integration health alerts
+func (s *WebhookSubscriptionService) SubscribeToIntegrationEvents(eventBus domain.EventBus) {
+	eventBus.Subscribe(domain.EventIntegrationWebhookStale, s.handleIntegrationWebhookStale)
+}
+
+// handleIntegrationWebhookStale creates a delivery for each enabled subscription to integration.webhook_stale
+func (s *WebhookSubscriptionService) handleIntegrationWebhookStale(ctx context.Context, payload domain.EventPayload) {
+	subs, err := s.repo.List(ctx, payload.WorkspaceID)
+	if err != nil {
+		s.logger.WithFields(map[string]interface{}{
+			"workspace_id":   payload.WorkspaceID,
+			"integration_id": payload.EntityID,
+			"error":          err.Error(),
+		}).Error("Failed to list webhook subscriptions for integration webhook alert")
+		return
+	}
+
+	eventType := string(domain.EventIntegrationWebhookStale)
+	for _, sub := range subs {
+		if !sub.Enabled || !slices.Contains(sub.Settings.EventTypes, eventType) {
+			continue
+		}
+
+		delivery := &domain.WebhookDelivery{
+			ID:             uuid.New().String(),
+			SubscriptionID: sub.ID,
+			EventType:      eventType,
+			Payload:        map[string]interface{}{"integration": payload.Data},
+			Status:         domain.WebhookDeliveryStatusPending,
+			MaxAttempts:    10,
+		}
+		if err := s.deliveryRepo.Create(ctx, payload.WorkspaceID, delivery); err != nil {
+			s.logger.WithFields(map[string]interface{}{
+				"workspace_id":    payload.WorkspaceID,
+				"subscription_id": sub.ID,
+				"integration_id":  payload.EntityID,
+				"error":           err.Error(),
+			}).Error("Failed to queue integration webhook alert")
+		}
+	}
+}
+
+// ReplayContactActivity rewinds the contact activity checkpoint of a subscription so that the
+// activities recorded after it are delivered again
+func (s *WebhookSubscriptionService) ReplayContactActivity(ctx context.Context, workspaceID, id, checkpoint string) (*domain.WebhookSubscription, error) {
+	if _, _, err := domain.ParseContactActivityCheckpoint(checkpoint); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.GetByID(ctx, workspaceID, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook subscription: %w", err)
+	}
+
+	if !slices.Contains(existing.Settings.EventTypes, string(domain.EventContactActivity)) {
+		return nil, fmt.Errorf("webhook subscription is not subscribed to %s", domain.EventContactActivity)
+	}
+
+	if err := s.repo.UpdateContactActivityCheckpoint(ctx, workspaceID, id, checkpoint); err != nil {
+		return nil, fmt.Errorf("failed to replay contact activity: %w", err)
+	}
+	existing.Settings.ContactActivityCheckpoint = checkpoint
+
+	s.logger.WithFields(map[string]interface{}{
+		"workspace_id":    workspaceID,
+		"subscription_id": id,
+		"checkpoint":      checkpoint,
+	}).Info("Replaying contact activity webhooks")
+
+	return existing, nil
+}
+
+// SubscribeToContactActivity queues outgoing webhooks for the activities published by the ContactActivityWorker
+func (s *WebhookSubscriptionService) SubscribeToContactActivity(eventBus domain.EventBus) {
+	eventBus.Subscribe(domain.EventContactActivity, s.handleContactActivity)
+}
+
+// handleContactActivity creates a delivery, in order, for each activity a contact.activity subscription has
+// not received yet, then advances the subscription checkpoint to the end of the batch
+func (s *WebhookSubscriptionService) handleContactActivity(ctx context.Context, payload domain.EventPayload) {
+	activities, _ := payload.Data["activities"].([]*domain.ContactActivity)
+	batchCheckpoint, _ := payload.Data["checkpoint"].(string)
+	if batchCheckpoint == "" {
+		return
+	}
+
+	subs, err := s.repo.List(ctx, payload.WorkspaceID)
+	if err != nil {
+		s.logger.WithFields(map[string]interface{}{
+			"workspace_id": payload.WorkspaceID,
+			"error":        err.Error(),
+		}).Error("Failed to list webhook subscriptions for contact activity")
+		return
+	}
+
+	eventType := string(domain.EventContactActivity)
+	for _, sub := range subs {
+		checkpoint := sub.Settings.ContactActivityCheckpoint
+		if !sub.Enabled || !slices.Contains(sub.Settings.EventTypes, eventType) || checkpoint == "" || checkpoint >= batchCheckpoint {
+			continue
+		}
+
+		advanceTo := batchCheckpoint
+		queued, err := s.queueContactActivities(ctx, payload.WorkspaceID, sub, activities)
+		if err != nil {
+			s.logger.WithFields(map[string]interface{}{
+				"workspace_id":    payload.WorkspaceID,
+				"subscription_id": sub.ID,
+				"error":           err.Error(),
+			}).Error("Failed to queue contact activity webhooks")
+			if queued == "" {
+				continue
+			}
+			// Keep the queued activities from being queued again
+			advanceTo = queued
+		}
+
+		if err := s.repo.UpdateContactActivityCheckpoint(ctx, payload.WorkspaceID, sub.ID, advanceTo); err != nil {
+			s.logger.WithFields(map[string]interface{}{
+				"workspace_id":    payload.WorkspaceID,
+				"subscription_id": sub.ID,
+				"error":           err.Error(),
+			}).Error("Failed to advance contact activity checkpoint")
+		}
+	}
+}
+
+// queueContactActivities creates the deliveries of the activities after the subscription checkpoint
+// and returns the checkpoint of the last queued one. It stops at the first failure so the
+// checkpoint is not advanced past activities that were not queued.
+func (s *WebhookSubscriptionService) queueContactActivities(ctx context.Context, workspaceID string, sub *domain.WebhookSubscription, activities []*domain.ContactActivity) (string, error) {
+	queued := ""
+	for _, activity := range activities {
+		if activity.Checkpoint <= sub.Settings.ContactActivityCheckpoint {
+			continue
+		}
+
+		delivery := &domain.WebhookDelivery{
+			ID:             uuid.New().String(),
+			SubscriptionID: sub.ID,
+			EventType:      string(domain.EventContactActivity),
+			Payload:        map[string]interface{}{"activity": activity},
+			Status:         domain.WebhookDeliveryStatusPending,
+			MaxAttempts:    10,
+		}
+		if err := s.deliveryRepo.Create(ctx, workspaceID, delivery); err != nil {
+			return queued, err
+		}
+		queued = activity.Checkpoint
+	}
+	return queued, nil
+}
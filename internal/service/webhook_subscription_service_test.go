@@ -1321,3 +1321,188 @@ func TestWebhookSubscriptionService_Update_PreservesSecret(t *testing.T) {
 	)
 	require.NoError(t, err)
 }
+
+func TestWebhookSubscriptionService_HandleBroadcastPhaseChanged(t *testing.T) {
+	mockRepo, mockDeliveryRepo, _, service, ctrl := setupWebhookSubscriptionTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	event := domain.NewBroadcastPhaseChangedEvent(&domain.Broadcast{
+		ID:          "b1",
+		WorkspaceID: "ws1",
+		Name:        "Spring sale",
+		Status:      domain.BroadcastStatusTesting,
+	}, domain.BroadcastStatusProcessing, time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC))
+
+	mockRepo.EXPECT().List(ctx, "ws1").Return([]*domain.WebhookSubscription{
+		{ID: "sub1", Enabled: true, Settings: domain.WebhookSubscriptionSettings{EventTypes: []string{"broadcast.phase_changed"}}},
+		{ID: "sub2", Enabled: false, Settings: domain.WebhookSubscriptionSettings{EventTypes: []string{"broadcast.phase_changed"}}},
+		{ID: "sub3", Enabled: true, Settings: domain.WebhookSubscriptionSettings{EventTypes: []string{"contact.created"}}},
+	}, nil)
+	mockDeliveryRepo.EXPECT().Create(ctx, "ws1", gomock.Any()).DoAndReturn(func(_ context.Context, _ string, delivery *domain.WebhookDelivery) error {
+		assert.Equal(t, "sub1", delivery.SubscriptionID)
+		assert.Equal(t, "broadcast.phase_changed", delivery.EventType)
+		assert.Equal(t, domain.WebhookDeliveryStatusPending, delivery.Status)
+		data := delivery.Payload["broadcast"].(map[string]interface{})
+		assert.Equal(t, "sending", data["old_phase"])
+		assert.Equal(t, "testing", data["phase"])
+		return nil
+	})
+
+	service.handleBroadcastPhaseChanged(ctx, event)
+}
+
+func TestWebhookSubscriptionService_HandleIntegrationWebhookStale(t *testing.T) {
+	mockRepo, mockDeliveryRepo, _, service, ctrl := setupWebhookSubscriptionTest(t)
+	defer ctrl.Finish()
+
+	ctx := context.Background()
+	pendingSince := time.Date(2026, 3, 1, 6, 0, 0, 0, time.UTC)
+	integration := &domain.Integration{
+		ID:            "integration-1",
+		Name:          "SES",
+		EmailProvider: domain.EmailProvider{Kind: domain.EmailProviderKindSES},
+	}
+	event := domain.NewIntegrationWebhookStaleEvent("ws1", integration, &domain.ProviderWebhookHealth{
+		IntegrationID: "integration-1",
+		PendingSince:  &pendingSince,
+		LastSentAt:    &pendingSince,
+	}, 6*time.Hour)
+
+	mockRepo.EXPECT().List(ctx, "ws1").Return([]*domain.WebhookSubscription{
+		{ID: "sub1", Enabled: true, Settings: domain.WebhookSubscriptionSettings{EventTypes: []string{"integration.webhook_stale"}}},
+		{ID: "sub2", Enabled: true, Settings: domain.WebhookSubscriptionSettings{EventTypes: []string{"email.sent"}}},
+	}, nil)
+	mockDeliveryRepo.EXPECT().Create(ctx, "ws1", gomock.Any()).DoAndReturn(func(_ context.Context, _ string, delivery *domain.WebhookDelivery) error {
+		assert.Equal(t, "sub1", delivery.SubscriptionID)
+		assert.Equal(t, "integration.webhook_stale", delivery.EventType)
+		data := delivery.Payload["integration"].(map[string]interface{})
+		assert.Equal(t, "integration-1", data["integration_id"])
+		assert.Equal(t, "SES", data["integration_name"])
+		assert.Equal(t, "ses", data["provider"])
+		assert.Equal(t, "2026-03-01T06:00:00Z", data["pending_since"])
+		assert.Equal(t, "6h0m0s", data["window"])
+		return nil
+	})
+
+	service.handleIntegrationWebhookStale(ctx, event)
+}
+
+func TestWebhookSubscriptionService_HandleContactActivity(t *testing.T) {
+	ctx := context.Background()
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	listID := "newsletter"
+
+	fieldUpdate, ok := domain.NewContactActivity(&domain.ContactTimelineEntry{
+		ID: "00000000-0000-0000-0000-000000000001", Email: "john@example.com", Operation: "update", EntityType: "contact",
+		Kind: "update_contact", Changes: map[string]interface{}{"first_name": map[string]interface{}{"old": "Jon", "new": "John"}},
+		CreatedAt: at, DBCreatedAt: at,
+	})
+	require.True(t, ok)
+	listChange, ok := domain.NewContactActivity(&domain.ContactTimelineEntry{
+		ID: "00000000-0000-0000-0000-000000000002", Email: "john@example.com", Operation: "insert", EntityType: "contact_list",
+		Kind: "insert_contact_list", EntityID: &listID, Changes: map[string]interface{}{"status": map[string]interface{}{"new": "active"}},
+		CreatedAt: at.Add(time.Second), DBCreatedAt: at.Add(time.Second),
+	})
+	require.True(t, ok)
+	batchCheckpoint := domain.NewContactActivityCheckpoint(at.Add(2*time.Second), "00000000-0000-0000-0000-000000000003")
+
+	event := domain.EventPayload{
+		Type:        domain.EventContactActivity,
+		WorkspaceID: "ws1",
+		EntityID:    batchCheckpoint,
+		Data: map[string]interface{}{
+			"activities": []*domain.ContactActivity{fieldUpdate, listChange},
+			"checkpoint": batchCheckpoint,
+		},
+	}
+	subscribed := func(id, checkpoint string) *domain.WebhookSubscription {
+		return &domain.WebhookSubscription{ID: id, Enabled: true, Settings: domain.WebhookSubscriptionSettings{
+			EventTypes:                []string{"contact.activity"},
+			ContactActivityCheckpoint: checkpoint,
+		}}
+	}
+
+	t.Run("queues activities after each subscription checkpoint in order", func(t *testing.T) {
+		mockRepo, mockDeliveryRepo, _, service, ctrl := setupWebhookSubscriptionTest(t)
+		defer ctrl.Finish()
+
+		mockRepo.EXPECT().List(ctx, "ws1").Return([]*domain.WebhookSubscription{
+			subscribed("sub1", domain.NewContactActivityCheckpoint(at.Add(-time.Minute), "")),
+			subscribed("sub2", fieldUpdate.Checkpoint),  // already received the field update
+			subscribed("sub3", batchCheckpoint),         // up to date
+			{ID: "sub4", Enabled: true, Settings: domain.WebhookSubscriptionSettings{EventTypes: []string{"contact.updated"}}},
+		}, nil)
+
+		var queued []string
+		mockDeliveryRepo.EXPECT().Create(ctx, "ws1", gomock.Any()).DoAndReturn(func(_ context.Context, _ string, delivery *domain.WebhookDelivery) error {
+			assert.Equal(t, "contact.activity", delivery.EventType)
+			activity := delivery.Payload["activity"].(*domain.ContactActivity)
+			queued = append(queued, delivery.SubscriptionID+":"+string(activity.Type))
+			return nil
+		}).Times(3)
+		mockRepo.EXPECT().UpdateContactActivityCheckpoint(ctx, "ws1", "sub1", batchCheckpoint).Return(nil)
+		mockRepo.EXPECT().UpdateContactActivityCheckpoint(ctx, "ws1", "sub2", batchCheckpoint).Return(nil)
+
+		service.handleContactActivity(ctx, event)
+
+		assert.Equal(t, []string{"sub1:field_update", "sub1:list_change", "sub2:list_change"}, queued)
+	})
+
+	t.Run("advances only past the queued activities on failure", func(t *testing.T) {
+		mockRepo, mockDeliveryRepo, _, service, ctrl := setupWebhookSubscriptionTest(t)
+		defer ctrl.Finish()
+
+		mockRepo.EXPECT().List(ctx, "ws1").Return([]*domain.WebhookSubscription{
+			subscribed("sub1", domain.NewContactActivityCheckpoint(at.Add(-time.Minute), "")),
+		}, nil)
+		gomock.InOrder(
+			mockDeliveryRepo.EXPECT().Create(ctx, "ws1", gomock.Any()).Return(nil),
+			mockDeliveryRepo.EXPECT().Create(ctx, "ws1", gomock.Any()).Return(errors.New("db error")),
+		)
+		mockRepo.EXPECT().UpdateContactActivityCheckpoint(ctx, "ws1", "sub1", fieldUpdate.Checkpoint).Return(nil)
+
+		service.handleContactActivity(ctx, event)
+	})
+}
+
+func TestWebhookSubscriptionService_ReplayContactActivity(t *testing.T) {
+	ctx := context.Background()
+	checkpoint := domain.NewContactActivityCheckpoint(time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC), "")
+
+	t.Run("rewinds the checkpoint", func(t *testing.T) {
+		mockRepo, _, _, service, ctrl := setupWebhookSubscriptionTest(t)
+		defer ctrl.Finish()
+
+		mockRepo.EXPECT().GetByID(ctx, "ws1", "sub1").Return(&domain.WebhookSubscription{
+			ID:       "sub1",
+			Settings: domain.WebhookSubscriptionSettings{EventTypes: []string{"contact.activity"}, ContactActivityCheckpoint: "99999999999999999999-"},
+		}, nil)
+		mockRepo.EXPECT().UpdateContactActivityCheckpoint(ctx, "ws1", "sub1", checkpoint).Return(nil)
+
+		sub, err := service.ReplayContactActivity(ctx, "ws1", "sub1", checkpoint)
+		require.NoError(t, err)
+		assert.Equal(t, checkpoint, sub.Settings.ContactActivityCheckpoint)
+	})
+
+	t.Run("rejects subscriptions without contact.activity", func(t *testing.T) {
+		mockRepo, _, _, service, ctrl := setupWebhookSubscriptionTest(t)
+		defer ctrl.Finish()
+
+		mockRepo.EXPECT().GetByID(ctx, "ws1", "sub1").Return(&domain.WebhookSubscription{
+			ID:       "sub1",
+			Settings: domain.WebhookSubscriptionSettings{EventTypes: []string{"contact.updated"}},
+		}, nil)
+
+		_, err := service.ReplayContactActivity(ctx, "ws1", "sub1", checkpoint)
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects invalid checkpoints", func(t *testing.T) {
+		_, _, _, service, ctrl := setupWebhookSubscriptionTest(t)
+		defer ctrl.Finish()
+
+		_, err := service.ReplayContactActivity(ctx, "ws1", "sub1", "not-a-checkpoint")
+		assert.Error(t, err)
+	})
+}
@@ -35,6 +35,7 @@ type WorkspaceService struct {
 	secretKey              string
 	dnsVerificationService *DNSVerificationService
 	blogService            *BlogService
+	fileStorageService     *FileStorageService
 }
 
 func NewWorkspaceService(
@@ -77,6 +78,11 @@ func NewWorkspaceService(
 	}
 }
 
+// SetFileStorageService enables the connectivity check of file manager settings on update
+func (s *WorkspaceService) SetFileStorageService(fileStorageService *FileStorageService) {
+	s.fileStorageService = fileStorageService
+}
+
 // ListWorkspaces returns all workspaces for a user
 func (s *WorkspaceService) ListWorkspaces(ctx context.Context) ([]*domain.Workspace, error) {
 	user, err := s.authService.AuthenticateUserFromContext(ctx)
@@ -316,10 +322,18 @@ func (s *WorkspaceService) UpdateWorkspace(ctx context.Context, id string, name
 	existingWorkspace.Settings.LogoURL = settings.LogoURL
 	existingWorkspace.Settings.CoverURL = settings.CoverURL
 	existingWorkspace.Settings.Timezone = settings.Timezone
+	if err := s.verifyFileManager(ctx, id, existingWorkspace.Settings.FileManager, settings.FileManager); err != nil {
+		return nil, err
+	}
+
 	existingWorkspace.Settings.FileManager = settings.FileManager
 	existingWorkspace.Settings.TransactionalEmailProviderID = settings.TransactionalEmailProviderID
 	existingWorkspace.Settings.MarketingEmailProviderID = settings.MarketingEmailProviderID
+	existingWorkspace.Settings.MarketingFailoverProviderIDs = settings.MarketingFailoverProviderIDs
+	existingWorkspace.Settings.SMSProviderID = settings.SMSProviderID
 	existingWorkspace.Settings.EmailTrackingEnabled = settings.EmailTrackingEnabled
+	existingWorkspace.Settings.OpenTrackingDefault = settings.OpenTrackingDefault
+	existingWorkspace.Settings.ClickTrackingDefault = settings.ClickTrackingDefault
 
 	// Verify DNS ownership if custom endpoint URL is being set or changed
 	if settings.CustomEndpointURL != nil && *settings.CustomEndpointURL != "" {
@@ -358,6 +372,24 @@ func (s *WorkspaceService) UpdateWorkspace(ctx context.Context, id string, name
 	existingWorkspace.Settings.CustomFieldLabels = settings.CustomFieldLabels
 	existingWorkspace.Settings.BlogEnabled = settings.BlogEnabled
 	existingWorkspace.Settings.BlogSettings = settings.BlogSettings
+	existingWorkspace.Settings.SandboxMode = settings.SandboxMode
+	existingWorkspace.Settings.SandboxAllowlist = settings.SandboxAllowlist
+	existingWorkspace.Settings.QuietHours = settings.QuietHours
+	existingWorkspace.Settings.ComplaintSpike = settings.ComplaintSpike
+	existingWorkspace.Settings.SendCoolOff = settings.SendCoolOff
+	existingWorkspace.Settings.DailySendQuota = settings.DailySendQuota
+	existingWorkspace.Settings.RequireUnsubscribeLink = settings.RequireUnsubscribeLink
+	existingWorkspace.Settings.TrackingDomain = settings.TrackingDomain
+	existingWorkspace.Settings.RequireVerifiedSenders = settings.RequireVerifiedSenders
+	existingWorkspace.Settings.DeliverableAudience = settings.DeliverableAudience
+	existingWorkspace.Settings.LinkShortening = settings.LinkShortening
+	existingWorkspace.Settings.SendConfirmation = settings.SendConfirmation
+	// Rate limits protect the instance from noisy workspaces, owners can't raise their own
+	if user.Email == s.config.RootEmail {
+		existingWorkspace.Settings.RateLimit = settings.RateLimit
+	}
+	// The sending block is set by the complaint spike monitor and only removed by ClearSendingBlock
+	// Sender identities are only changed by the sender identity service
 
 	// Handle template blocks - preserve existing blocks if not provided in update
 	// Note: Template blocks should be managed via dedicated /api/templateBlocks.* endpoints
@@ -400,6 +432,53 @@ func (s *WorkspaceService) UpdateWorkspace(ctx context.Context, id string, name
 	return existingWorkspace, nil
 }
 
+// verifyFileManager checks that the S3-compatible storage can be reached when the connection
+// settings of the file manager change. Unchanged settings are not checked again, so that a storage
+// outage does not block unrelated workspace updates.
+func (s *WorkspaceService) verifyFileManager(ctx context.Context, workspaceID string, previous, next domain.FileManagerSettings) error {
+	if s.fileStorageService == nil || next.Endpoint == "" || next.Bucket == "" {
+		return nil
+	}
+
+	// The secret key is only sent when it changes
+	if next.SecretKey == "" {
+		next.SecretKey = previous.SecretKey
+		if next.SecretKey == "" && next.EncryptedSecretKey != "" {
+			if err := next.DecryptSecretKey(s.secretKey); err != nil {
+				return err
+			}
+		}
+	}
+
+	if !fileManagerConnectionChanged(previous, next) {
+		return nil
+	}
+
+	if err := s.fileStorageService.VerifyConnection(ctx, next); err != nil {
+		s.logger.WithField("workspace_id", workspaceID).WithField("error", err.Error()).Warn("File manager connection check failed")
+		return domain.ValidationError{Message: fmt.Sprintf("file storage is not reachable: %v", err)}
+	}
+
+	return nil
+}
+
+// fileManagerConnectionChanged reports whether the settings used to reach the storage differ
+func fileManagerConnectionChanged(previous, next domain.FileManagerSettings) bool {
+	region := func(f domain.FileManagerSettings) string {
+		if f.Region == nil {
+			return ""
+		}
+		return *f.Region
+	}
+
+	return previous.Endpoint != next.Endpoint ||
+		previous.Bucket != next.Bucket ||
+		previous.AccessKey != next.AccessKey ||
+		previous.SecretKey != next.SecretKey ||
+		region(previous) != region(next) ||
+		previous.ForcePathStyle != next.ForcePathStyle
+}
+
 // DeleteWorkspace deletes a workspace if the user is an owner
 func (s *WorkspaceService) DeleteWorkspace(ctx context.Context, id string) error {
 	// Check if user can access this workspace and is the owner
@@ -834,6 +913,21 @@ func (s *WorkspaceService) GetWorkspaceMembersWithEmail(ctx context.Context, id
 	return members, nil
 }
 
+// apiEmailDomain extracts the domain of the API endpoint by removing any protocol prefix and path suffix,
+// API users get an email on this domain
+func apiEmailDomain(apiEndpoint string) string {
+	domainName := apiEndpoint
+	if strings.HasPrefix(domainName, "http://") {
+		domainName = strings.TrimPrefix(domainName, "http://")
+	} else if strings.HasPrefix(domainName, "https://") {
+		domainName = strings.TrimPrefix(domainName, "https://")
+	}
+	if idx := strings.Index(domainName, "/"); idx != -1 {
+		domainName = domainName[:idx]
+	}
+	return domainName
+}
+
 // CreateAPIKey creates an API key for a workspace
 func (s *WorkspaceService) CreateAPIKey(ctx context.Context, workspaceID string, emailPrefix string) (string, string, error) {
 	// Validate user is a member of the workspace and has owner role
@@ -857,17 +951,7 @@ func (s *WorkspaceService) CreateAPIKey(ctx context.Context, workspaceID string,
 	}
 
 	// Generate an API email using the prefix
-	// Extract domainName from API endpoint by removing any protocol prefix and path suffix
-	domainName := s.config.APIEndpoint
-	if strings.HasPrefix(domainName, "http://") {
-		domainName = strings.TrimPrefix(domainName, "http://")
-	} else if strings.HasPrefix(domainName, "https://") {
-		domainName = strings.TrimPrefix(domainName, "https://")
-	}
-	if idx := strings.Index(domainName, "/"); idx != -1 {
-		domainName = domainName[:idx]
-	}
-	apiEmail := emailPrefix + "@" + domainName
+	apiEmail := emailPrefix + "@" + apiEmailDomain(s.config.APIEndpoint)
 
 	// Create a user object for the API key
 	apiUser := &domain.User{
@@ -1104,6 +1188,50 @@ func (s *WorkspaceService) RemoveMember(ctx context.Context, workspaceID string,
 	return nil
 }
 
+// ClearSendingBlock lifts the sending block set by the complaint spike monitor if the user is an owner.
+// Paused broadcasts are not resumed: each one has to be resumed once the cause of the spike is fixed.
+func (s *WorkspaceService) ClearSendingBlock(ctx context.Context, workspaceID string) (*domain.Workspace, error) {
+	var user *domain.User
+	var err error
+	ctx, user, _, err = s.authService.AuthenticateUserForWorkspace(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate user: %w", err)
+	}
+
+	userWorkspace, err := s.repo.GetUserWorkspace(ctx, user.ID, workspaceID)
+	if err != nil {
+		s.logger.WithField("workspace_id", workspaceID).WithField("user_id", user.ID).WithField("error", err.Error()).Error("Failed to get user workspace")
+		return nil, err
+	}
+
+	if userWorkspace.Role != "owner" {
+		s.logger.WithField("workspace_id", workspaceID).WithField("user_id", user.ID).WithField("role", userWorkspace.Role).Error("User is not an owner of the workspace")
+		return nil, &domain.ErrUnauthorized{Message: "user is not an owner of the workspace"}
+	}
+
+	workspace, err := s.repo.GetByID(ctx, workspaceID)
+	if err != nil {
+		s.logger.WithField("workspace_id", workspaceID).WithField("error", err.Error()).Error("Failed to get workspace")
+		return nil, err
+	}
+
+	if workspace.Settings.SendingBlock == nil {
+		return workspace, nil
+	}
+
+	workspace.Settings.SendingBlock = nil
+	workspace.UpdatedAt = time.Now().UTC()
+
+	if err := s.repo.Update(ctx, workspace); err != nil {
+		s.logger.WithField("workspace_id", workspaceID).WithField("error", err.Error()).Error("Failed to clear workspace sending block")
+		return nil, err
+	}
+
+	s.logger.WithField("workspace_id", workspaceID).WithField("user_id", user.ID).Info("Workspace sending block cleared")
+
+	return workspace, nil
+}
+
 // CreateIntegration creates a new integration for a workspace
 func (s *WorkspaceService) CreateIntegration(ctx context.Context, req domain.CreateIntegrationRequest) (string, error) {
 	// Authenticate user and verify they are an owner of the workspace
@@ -1152,6 +1280,8 @@ func (s *WorkspaceService) CreateIntegration(ctx context.Context, req domain.Cre
 		integration.LLMProvider = req.LLMProvider
 	case domain.IntegrationTypeFirecrawl:
 		integration.FirecrawlSettings = req.FirecrawlSettings
+	case domain.IntegrationTypeSMS:
+		integration.SMSProvider = req.SMSProvider
 	}
 
 	// Validate the integration
@@ -1326,6 +1456,24 @@ func (s *WorkspaceService) UpdateIntegration(ctx context.Context, req domain.Upd
 			// If no settings provided, preserve existing
 			updatedIntegration.FirecrawlSettings = existingIntegration.FirecrawlSettings
 		}
+	case domain.IntegrationTypeSMS:
+		// Preserve existing encrypted auth token if new token is not provided
+		if req.SMSProvider != nil {
+			updatedIntegration.SMSProvider = req.SMSProvider
+
+			// Preserve encrypted auth token if not provided in update
+			if req.SMSProvider.Twilio != nil &&
+				req.SMSProvider.Twilio.AuthToken == "" &&
+				req.SMSProvider.Twilio.EncryptedAuthToken == "" &&
+				existingIntegration.SMSProvider != nil &&
+				existingIntegration.SMSProvider.Twilio != nil {
+				updatedIntegration.SMSProvider.Twilio.EncryptedAuthToken =
+					existingIntegration.SMSProvider.Twilio.EncryptedAuthToken
+			}
+		} else {
+			// If no settings provided, preserve existing
+			updatedIntegration.SMSProvider = existingIntegration.SMSProvider
+		}
 	}
 
 	// Validate the updated integration
@@ -1434,6 +1582,16 @@ func (s *WorkspaceService) DeleteIntegration(ctx context.Context, workspaceID, i
 	if workspace.Settings.MarketingEmailProviderID == integrationID {
 		workspace.Settings.MarketingEmailProviderID = ""
 	}
+	failoverProviderIDs := workspace.Settings.MarketingFailoverProviderIDs[:0]
+	for _, failoverProviderID := range workspace.Settings.MarketingFailoverProviderIDs {
+		if failoverProviderID != integrationID {
+			failoverProviderIDs = append(failoverProviderIDs, failoverProviderID)
+		}
+	}
+	workspace.Settings.MarketingFailoverProviderIDs = failoverProviderIDs
+	if workspace.Settings.SMSProviderID == integrationID {
+		workspace.Settings.SMSProviderID = ""
+	}
 
 	// Save the updated workspace
 	if err := s.repo.Update(ctx, workspace); err != nil {
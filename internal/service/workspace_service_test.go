@@ -5,6 +5,9 @@ import (
 	"encoding/hex"
 	"errors"
 	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -879,6 +882,216 @@ func TestWorkspaceService_UpdateWorkspace(t *testing.T) {
 		assert.Len(t, workspace.Settings.TemplateBlocks, 1)
 		assert.Equal(t, "New Block", workspace.Settings.TemplateBlocks[0].Name)
 	})
+
+	t.Run("persists the deliverable audience settings", func(t *testing.T) {
+		expectedUser := &domain.User{ID: userID}
+		expectedUserWorkspace := &domain.UserWorkspace{
+			UserID:      userID,
+			WorkspaceID: workspaceID,
+			Role:        "owner",
+		}
+
+		existingWorkspace := &domain.Workspace{
+			ID:       workspaceID,
+			Name:     "Original Workspace",
+			Settings: domain.WorkspaceSettings{Timezone: "UTC"},
+		}
+
+		settings := domain.WorkspaceSettings{
+			Timezone:            "UTC",
+			DeliverableAudience: &domain.DeliverableAudienceSettings{Enabled: true, MinRatio: 0.8, Block: true},
+		}
+
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, expectedUser, nil, nil)
+		mockRepo.EXPECT().GetUserWorkspace(ctx, userID, workspaceID).Return(expectedUserWorkspace, nil)
+		mockRepo.EXPECT().GetByID(ctx, workspaceID).Return(existingWorkspace, nil)
+		mockRepo.EXPECT().Update(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, workspace *domain.Workspace) error {
+			assert.Equal(t, settings.DeliverableAudience, workspace.Settings.DeliverableAudience)
+			return nil
+		})
+
+		workspace, err := service.UpdateWorkspace(ctx, workspaceID, "Updated Workspace", settings)
+		require.NoError(t, err)
+		require.NotNil(t, workspace.Settings.DeliverableAudience)
+		assert.Equal(t, 0.8, workspace.Settings.DeliverableAudience.MinRatio)
+		assert.True(t, workspace.Settings.DeliverableAudience.Block)
+	})
+
+	t.Run("persists the link shortening settings", func(t *testing.T) {
+		expectedUser := &domain.User{ID: userID}
+		expectedUserWorkspace := &domain.UserWorkspace{
+			UserID:      userID,
+			WorkspaceID: workspaceID,
+			Role:        "owner",
+		}
+
+		existingWorkspace := &domain.Workspace{
+			ID:       workspaceID,
+			Name:     "Original Workspace",
+			Settings: domain.WorkspaceSettings{Timezone: "UTC"},
+		}
+
+		settings := domain.WorkspaceSettings{
+			Timezone:       "UTC",
+			LinkShortening: &domain.LinkShorteningSettings{Enabled: true, Domain: "https://go.example.com"},
+		}
+
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, expectedUser, nil, nil)
+		mockRepo.EXPECT().GetUserWorkspace(ctx, userID, workspaceID).Return(expectedUserWorkspace, nil)
+		mockRepo.EXPECT().GetByID(ctx, workspaceID).Return(existingWorkspace, nil)
+		mockRepo.EXPECT().Update(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, workspace *domain.Workspace) error {
+			assert.Equal(t, settings.LinkShortening, workspace.Settings.LinkShortening)
+			return nil
+		})
+
+		workspace, err := service.UpdateWorkspace(ctx, workspaceID, "Updated Workspace", settings)
+		require.NoError(t, err)
+		require.NotNil(t, workspace.Settings.LinkShortening)
+		assert.True(t, workspace.Settings.LinkShortening.Enabled)
+		assert.Equal(t, "https://go.example.com", workspace.Settings.LinkShortening.Domain)
+	})
+
+	t.Run("persists the send confirmation settings", func(t *testing.T) {
+		expectedUser := &domain.User{ID: userID}
+		expectedUserWorkspace := &domain.UserWorkspace{
+			UserID:      userID,
+			WorkspaceID: workspaceID,
+			Role:        "owner",
+		}
+
+		existingWorkspace := &domain.Workspace{
+			ID:       workspaceID,
+			Name:     "Original Workspace",
+			Settings: domain.WorkspaceSettings{Timezone: "UTC"},
+		}
+
+		settings := domain.WorkspaceSettings{
+			Timezone:         "UTC",
+			SendConfirmation: &domain.SendConfirmationSettings{Enabled: true, TTLSeconds: 600},
+		}
+
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, expectedUser, nil, nil)
+		mockRepo.EXPECT().GetUserWorkspace(ctx, userID, workspaceID).Return(expectedUserWorkspace, nil)
+		mockRepo.EXPECT().GetByID(ctx, workspaceID).Return(existingWorkspace, nil)
+		mockRepo.EXPECT().Update(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, workspace *domain.Workspace) error {
+			assert.Equal(t, settings.SendConfirmation, workspace.Settings.SendConfirmation)
+			return nil
+		})
+
+		workspace, err := service.UpdateWorkspace(ctx, workspaceID, "Updated Workspace", settings)
+		require.NoError(t, err)
+		require.NotNil(t, workspace.Settings.SendConfirmation)
+		assert.True(t, workspace.Settings.SendConfirmation.Enabled)
+		assert.Equal(t, 600, workspace.Settings.SendConfirmation.TTLSeconds)
+	})
+}
+
+func TestWorkspaceService_UpdateWorkspace_FileManagerConnection(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	mockAuthService := mocks.NewMockAuthService(ctrl)
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+	mockLogger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().WithFields(gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().Warn(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
+
+	service := NewWorkspaceService(
+		mockRepo,
+		mocks.NewMockUserRepository(ctrl),
+		mocks.NewMockTaskRepository(ctrl),
+		mockLogger,
+		mocks.NewMockUserServiceInterface(ctrl),
+		mockAuthService,
+		pkgmocks.NewMockMailer(ctrl),
+		&config.Config{RootEmail: "test@example.com"},
+		mocks.NewMockContactService(ctrl),
+		mocks.NewMockListService(ctrl),
+		mocks.NewMockContactListService(ctrl),
+		mocks.NewMockTemplateService(ctrl),
+		mocks.NewMockWebhookRegistrationService(ctrl),
+		"secret_key",
+		&SupabaseService{},
+		&DNSVerificationService{},
+		&BlogService{},
+	)
+	service.SetFileStorageService(NewFileStorageService(mockLogger))
+
+	// MinIO-like store that only accepts path-style requests with the right secret
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/xml")
+		if r.URL.Path != "/assets" || !strings.Contains(r.Header.Get("Authorization"), "Credential=minio/") {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><Error><Code>AccessDenied</Code><Message>Access Denied</Message></Error>`))
+			return
+		}
+		_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><ListBucketResult><Name>assets</Name></ListBucketResult>`))
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	workspaceID := "testworkspace"
+	region := "us-east-1"
+	fileManager := domain.FileManagerSettings{
+		Endpoint:       server.URL,
+		Bucket:         "assets",
+		AccessKey:      "minio",
+		SecretKey:      "minio-secret",
+		Region:         &region,
+		ForcePathStyle: true,
+	}
+
+	expectUpdate := func(existing domain.FileManagerSettings) {
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, &domain.User{ID: "user1"}, nil, nil)
+		mockRepo.EXPECT().GetUserWorkspace(ctx, "user1", workspaceID).Return(&domain.UserWorkspace{UserID: "user1", WorkspaceID: workspaceID, Role: "owner"}, nil)
+		mockRepo.EXPECT().GetByID(ctx, workspaceID).Return(&domain.Workspace{
+			ID:       workspaceID,
+			Name:     "Workspace",
+			Settings: domain.WorkspaceSettings{Timezone: "UTC", FileManager: existing},
+		}, nil)
+	}
+
+	t.Run("new path-style settings are checked against the custom endpoint", func(t *testing.T) {
+		requests = 0
+		expectUpdate(domain.FileManagerSettings{})
+		mockRepo.EXPECT().Update(ctx, gomock.Any()).Return(nil)
+
+		_, err := service.UpdateWorkspace(ctx, workspaceID, "Workspace", domain.WorkspaceSettings{Timezone: "UTC", FileManager: fileManager})
+		require.NoError(t, err)
+		assert.Equal(t, 1, requests)
+	})
+
+	t.Run("unreachable storage is rejected", func(t *testing.T) {
+		requests = 0
+		expectUpdate(domain.FileManagerSettings{})
+
+		wrongCredentials := fileManager
+		wrongCredentials.AccessKey = "other"
+
+		_, err := service.UpdateWorkspace(ctx, workspaceID, "Workspace", domain.WorkspaceSettings{Timezone: "UTC", FileManager: wrongCredentials})
+		require.Error(t, err)
+		var validationErr domain.ValidationError
+		assert.True(t, errors.As(err, &validationErr))
+		assert.Contains(t, err.Error(), "AccessDenied")
+	})
+
+	t.Run("unchanged settings are not checked again", func(t *testing.T) {
+		requests = 0
+		expectUpdate(fileManager)
+		mockRepo.EXPECT().Update(ctx, gomock.Any()).Return(nil)
+
+		// The secret key is not sent back when it does not change
+		unchanged := fileManager
+		unchanged.SecretKey = ""
+
+		_, err := service.UpdateWorkspace(ctx, workspaceID, "Renamed", domain.WorkspaceSettings{Timezone: "UTC", FileManager: unchanged})
+		require.NoError(t, err)
+		assert.Equal(t, 0, requests)
+	})
 }
 
 func TestWorkspaceService_DeleteWorkspace(t *testing.T) {
@@ -2095,6 +2308,87 @@ func TestWorkspaceService_RemoveMember(t *testing.T) {
 	})
 }
 
+func TestWorkspaceService_ClearSendingBlock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mocks.NewMockWorkspaceRepository(ctrl)
+	mockLogger := pkgmocks.NewMockLogger(ctrl)
+	mockAuthService := mocks.NewMockAuthService(ctrl)
+	mockLogger.EXPECT().WithField(gomock.Any(), gomock.Any()).Return(mockLogger).AnyTimes()
+	mockLogger.EXPECT().Info(gomock.Any()).AnyTimes()
+	mockLogger.EXPECT().Error(gomock.Any()).AnyTimes()
+
+	service := NewWorkspaceService(
+		mockRepo,
+		mocks.NewMockUserRepository(ctrl),
+		mocks.NewMockTaskRepository(ctrl),
+		mockLogger,
+		mocks.NewMockUserServiceInterface(ctrl),
+		mockAuthService,
+		pkgmocks.NewMockMailer(ctrl),
+		&config.Config{RootEmail: "test@example.com"},
+		mocks.NewMockContactService(ctrl),
+		mocks.NewMockListService(ctrl),
+		mocks.NewMockContactListService(ctrl),
+		mocks.NewMockTemplateService(ctrl),
+		mocks.NewMockWebhookRegistrationService(ctrl),
+		"secret_key",
+		&SupabaseService{},
+		&DNSVerificationService{},
+		&BlogService{},
+	)
+
+	ctx := context.Background()
+	workspaceID := "test-workspace"
+	owner := &domain.User{ID: "owner-user", Type: domain.UserTypeUser}
+
+	t.Run("owner clears the block", func(t *testing.T) {
+		workspace := &domain.Workspace{
+			ID: workspaceID,
+			Settings: domain.WorkspaceSettings{
+				Timezone:       "UTC",
+				ComplaintSpike: &domain.ComplaintSpikeSettings{Enabled: true, MaxRate: 0.003},
+				SendingBlock:   &domain.SendingBlock{Reason: "complaint spike", BlockedAt: time.Now().UTC()},
+			},
+		}
+
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, owner, nil, nil)
+		mockRepo.EXPECT().GetUserWorkspace(ctx, owner.ID, workspaceID).Return(&domain.UserWorkspace{UserID: owner.ID, WorkspaceID: workspaceID, Role: "owner"}, nil)
+		mockRepo.EXPECT().GetByID(ctx, workspaceID).Return(workspace, nil)
+		mockRepo.EXPECT().Update(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, updated *domain.Workspace) error {
+			assert.Nil(t, updated.Settings.SendingBlock)
+			assert.NotNil(t, updated.Settings.ComplaintSpike)
+			return nil
+		})
+
+		result, err := service.ClearSendingBlock(ctx, workspaceID)
+		require.NoError(t, err)
+		assert.Nil(t, result.Settings.SendingBlock)
+		assert.NoError(t, result.Settings.CheckSendingAllowed())
+	})
+
+	t.Run("nothing to clear", func(t *testing.T) {
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, owner, nil, nil)
+		mockRepo.EXPECT().GetUserWorkspace(ctx, owner.ID, workspaceID).Return(&domain.UserWorkspace{UserID: owner.ID, WorkspaceID: workspaceID, Role: "owner"}, nil)
+		mockRepo.EXPECT().GetByID(ctx, workspaceID).Return(&domain.Workspace{ID: workspaceID}, nil)
+
+		result, err := service.ClearSendingBlock(ctx, workspaceID)
+		require.NoError(t, err)
+		assert.Equal(t, workspaceID, result.ID)
+	})
+
+	t.Run("members cannot clear the block", func(t *testing.T) {
+		member := &domain.User{ID: "member-user", Type: domain.UserTypeUser}
+		mockAuthService.EXPECT().AuthenticateUserForWorkspace(ctx, workspaceID).Return(ctx, member, nil, nil)
+		mockRepo.EXPECT().GetUserWorkspace(ctx, member.ID, workspaceID).Return(&domain.UserWorkspace{UserID: member.ID, WorkspaceID: workspaceID, Role: "member"}, nil)
+
+		_, err := service.ClearSendingBlock(ctx, workspaceID)
+		require.Error(t, err)
+		assert.IsType(t, &domain.ErrUnauthorized{}, err)
+	})
+}
+
 func TestGenerateSecureKey(t *testing.T) {
 	t.Run("generates key of expected length", func(t *testing.T) {
 		// Test with different byte lengths
@@ -3030,7 +3324,7 @@ func TestWorkspaceService_deleteSupabaseIntegrationResources(t *testing.T) {
 		mockRepo,
 		nil, // emailService
 		mockContactService,
-		mockListService,
+		mocks.NewMockListRepository(ctrl),
 		mockContactListRepo,
 		mockTemplateRepo,
 		mockTemplateService,
@@ -3,6 +3,7 @@ package crypto
 import (
 	"crypto/aes"
 	"crypto/cipher"
+	"crypto/hkdf"
 	"crypto/hmac"
 	"crypto/rand"
 	"crypto/sha256"
@@ -147,6 +148,29 @@ func DecryptFromHexString(str string, passphrase string) (string, error) {
 	return string(decodedBytes), nil
 }
 
+// Key derivation purposes, used as HKDF info labels so each use of the workspace secret key
+// gets an independent key
+const (
+	KeyPurposeMessageData      = "notifuse/message-data/v1"
+	KeyPurposeSendConfirmation = "notifuse/send-confirmation/v1"
+)
+
+// DeriveKey derives a 256-bit key from the secret for a single purpose with HKDF-SHA256,
+// so the raw secret is never reused across cryptographic purposes.
+// The key is hex encoded to be used as the passphrase of EncryptString or ComputeHMAC256.
+func DeriveKey(secret string, purpose string) (string, error) {
+	if secret == "" {
+		return "", fmt.Errorf("DeriveKey empty secret")
+	}
+
+	key, err := hkdf.Key(sha256.New, []byte(secret), nil, purpose, 32)
+	if err != nil {
+		return "", fmt.Errorf("DeriveKey error: %w", err)
+	}
+
+	return hex.EncodeToString(key), nil
+}
+
 // HashMagicCode creates an HMAC-SHA256 hash of the magic code with the provided secret key.
 // This prevents plain-text storage of authentication codes in the database.
 // Returns a 64-character hexadecimal string.
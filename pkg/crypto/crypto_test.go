@@ -440,3 +440,40 @@ func TestMagicCodeIntegration(t *testing.T) {
 		t.Error("VerifyMagicCode() incorrectly verified with wrong secret key")
 	}
 }
+
+func TestDeriveKey(t *testing.T) {
+	secret := "workspace-secret-key"
+
+	messageKey, err := DeriveKey(secret, KeyPurposeMessageData)
+	if err != nil {
+		t.Fatalf("DeriveKey() error = %v", err)
+	}
+	if len(messageKey) != 64 {
+		t.Errorf("DeriveKey() length = %d, want 64", len(messageKey))
+	}
+
+	// Deterministic for the same secret and purpose
+	again, _ := DeriveKey(secret, KeyPurposeMessageData)
+	if again != messageKey {
+		t.Error("DeriveKey() is not deterministic")
+	}
+
+	// Distinct per purpose and never the raw secret
+	sendConfirmationKey, _ := DeriveKey(secret, KeyPurposeSendConfirmation)
+	if messageKey == sendConfirmationKey {
+		t.Error("DeriveKey() returned the same key for different purposes")
+	}
+	if messageKey == secret {
+		t.Error("DeriveKey() returned the raw secret")
+	}
+
+	// Distinct per secret
+	otherKey, _ := DeriveKey("other-secret-key", KeyPurposeMessageData)
+	if otherKey == messageKey {
+		t.Error("DeriveKey() returned the same key for different secrets")
+	}
+
+	if _, err := DeriveKey("", KeyPurposeMessageData); err == nil {
+		t.Error("DeriveKey() expected error for empty secret")
+	}
+}
@@ -34,6 +34,8 @@ func (c *Classifier) Classify(err error, provider domain.EmailProviderKind) *Cla
 		return c.classifyMailgunError(err, errStr, httpStatus)
 	case domain.EmailProviderKindMailjet:
 		return c.classifyMailjetError(err, errStr, httpStatus)
+	case domain.EmailProviderKindSendGrid:
+		return c.classifySendGridError(err, errStr, httpStatus)
 	case domain.EmailProviderKindSparkPost:
 		return c.classifySparkPostError(err, errStr, httpStatus)
 	case domain.EmailProviderKindSMTP:
@@ -236,6 +236,51 @@ func TestClassifier_ClassifyMailjet(t *testing.T) {
 	}
 }
 
+func TestClassifier_ClassifySendGrid(t *testing.T) {
+	classifier := NewClassifier()
+
+	tests := []struct {
+		name         string
+		err          error
+		expectedType ErrorType
+		retryable    bool
+	}{
+		{
+			name:         "recipient error - invalid address",
+			err:          errors.New(`API returned non-OK status code 400: {"errors":[{"message":"Does not contain a valid address.","field":"personalizations.0.to.0.email"}]}`),
+			expectedType: ErrorTypeRecipient,
+			retryable:    false,
+		},
+		{
+			name:         "provider error - unauthorized",
+			err:          errors.New(`API returned non-OK status code 401: {"errors":[{"message":"The provided authorization grant is invalid, expired, or revoked"}]}`),
+			expectedType: ErrorTypeProvider,
+			retryable:    false,
+		},
+		{
+			name:         "provider error - rate limit",
+			err:          errors.New("status code: 429 too many requests"),
+			expectedType: ErrorTypeProvider,
+			retryable:    true,
+		},
+		{
+			name:         "provider error - server error",
+			err:          errors.New("status code: 503 service unavailable"),
+			expectedType: ErrorTypeProvider,
+			retryable:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := classifier.Classify(tt.err, domain.EmailProviderKindSendGrid)
+			assert.Equal(t, tt.expectedType, result.Type)
+			assert.Equal(t, tt.retryable, result.Retryable)
+			assert.Equal(t, "sendgrid", result.Provider)
+		})
+	}
+}
+
 func TestClassifier_ClassifySparkPost(t *testing.T) {
 	classifier := NewClassifier()
 
@@ -0,0 +1,76 @@
+package emailerror
+
+// SendGrid error classification
+//
+// RECIPIENT ERRORS (should NOT trigger circuit breaker):
+// - Invalid email address in personalizations
+// - Recipient on a suppression list (bounce, spam report, unsubscribe)
+//
+// PROVIDER ERRORS (SHOULD trigger circuit breaker):
+// - HTTP 401/403: Invalid API key or missing permissions
+// - HTTP 413: Payload too large
+// - HTTP 429: Rate limit exceeded
+// - HTTP 500/502/503: Server errors
+
+// SendGrid recipient error patterns
+var sendGridRecipientPatterns = []string{
+	"does not contain a valid address",
+	"invalid email",
+	"invalid address",
+	"suppressed",
+	"suppression",
+	"bounced address",
+	"spam report",
+	"unsubscribed",
+}
+
+// SendGrid provider error patterns
+var sendGridProviderPatterns = []string{
+	"unauthorized",
+	"authorization required",
+	"access forbidden",
+	"permission",
+	"api key",
+	"rate limit",
+	"too many requests",
+	"payload too large",
+	"internal server error",
+	"bad gateway",
+	"service unavailable",
+}
+
+func (c *Classifier) classifySendGridError(err error, errStr string, httpStatus int) *ClassifiedError {
+	result := &ClassifiedError{
+		Original:   err,
+		Provider:   "sendgrid",
+		HTTPStatus: httpStatus,
+		Retryable:  true,
+	}
+
+	// Check for recipient-specific errors
+	if containsAny(errStr, sendGridRecipientPatterns) {
+		result.Type = ErrorTypeRecipient
+		result.Retryable = false
+		return result
+	}
+
+	// Check for provider errors
+	if containsAny(errStr, sendGridProviderPatterns) {
+		result.Type = ErrorTypeProvider
+		// Rate limit and server errors are retryable
+		result.Retryable = httpStatus >= 500 || httpStatus == 429 || containsAny(errStr, []string{"rate limit", "too many"})
+		return result
+	}
+
+	// Fallback to HTTP status classification
+	if httpStatus > 0 {
+		result.Type = classifyByHTTPStatus(httpStatus)
+		result.Retryable = httpStatus >= 500 || httpStatus == 429
+		return result
+	}
+
+	// Unknown error - treat as provider error for safety
+	result.Type = ErrorTypeUnknown
+	result.Retryable = true
+	return result
+}
@@ -0,0 +1,275 @@
+package export
+
+import (
+	"archive/zip"
+	"compress/gzip"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// MaxPartSize is the largest number of rows allowed in a single part
+const MaxPartSize = 1000000
+
+// ManifestFilename is the name of the manifest entry in split exports
+const ManifestFilename = "manifest.json"
+
+// Options controls how exported rows are encoded
+type Options struct {
+	// Gzip compresses the output; when splitting, each part is compressed individually
+	Gzip bool
+	// PartSize splits the export into parts of at most PartSize rows, bundled in a zip
+	// archive together with a manifest. 0 disables splitting.
+	PartSize int
+}
+
+// OptionsFromQuery parses export options from the "compress" and "part_size" query parameters
+func OptionsFromQuery(query url.Values) (Options, error) {
+	var opts Options
+
+	switch query.Get("compress") {
+	case "", "none":
+	case "gzip":
+		opts.Gzip = true
+	default:
+		return opts, fmt.Errorf("invalid compress value: %s (supported: gzip, none)", query.Get("compress"))
+	}
+
+	if partSize := query.Get("part_size"); partSize != "" {
+		size, err := strconv.Atoi(partSize)
+		if err != nil {
+			return opts, fmt.Errorf("invalid part_size: %w", err)
+		}
+		if size < 1 || size > MaxPartSize {
+			return opts, fmt.Errorf("part_size must be between 1 and %d", MaxPartSize)
+		}
+		opts.PartSize = size
+	}
+
+	return opts, nil
+}
+
+// ContentType returns the MIME type of the produced output
+func (o Options) ContentType() string {
+	switch {
+	case o.PartSize > 0:
+		return "application/zip"
+	case o.Gzip:
+		return "application/gzip"
+	default:
+		return "text/csv; charset=utf-8"
+	}
+}
+
+// Filename returns the download filename for an export with the given base name
+func (o Options) Filename(base string) string {
+	switch {
+	case o.PartSize > 0:
+		return base + ".zip"
+	case o.Gzip:
+		return base + ".csv.gz"
+	default:
+		return base + ".csv"
+	}
+}
+
+// Manifest describes the parts of a split export
+type Manifest struct {
+	Name       string         `json:"name"`
+	Columns    []string       `json:"columns"`
+	TotalRows  int            `json:"total_rows"`
+	PartSize   int            `json:"part_size"`
+	Compressed bool           `json:"compressed"`
+	Parts      []ManifestPart `json:"parts"`
+	CreatedAt  time.Time      `json:"created_at"`
+}
+
+// ManifestPart describes a single part file of a split export
+type ManifestPart struct {
+	File string `json:"file"`
+	Rows int    `json:"rows"`
+}
+
+// Writer streams CSV rows to an io.Writer. Rows are never buffered beyond the
+// current part's encoder buffers, so memory usage stays bounded regardless of
+// the export size.
+type Writer struct {
+	out    io.Writer
+	name   string
+	header []string
+	opts   Options
+
+	zip      *zip.Writer
+	gzip     *gzip.Writer
+	csv      *csv.Writer
+	partRows int
+	manifest Manifest
+	closed   bool
+}
+
+// NewWriter creates a Writer producing an export called name with the given CSV header
+func NewWriter(out io.Writer, name string, header []string, opts Options) (*Writer, error) {
+	if opts.PartSize < 0 {
+		return nil, fmt.Errorf("part size cannot be negative")
+	}
+
+	w := &Writer{
+		out:    out,
+		name:   name,
+		header: header,
+		opts:   opts,
+		manifest: Manifest{
+			Name:       name,
+			Columns:    header,
+			PartSize:   opts.PartSize,
+			Compressed: opts.Gzip,
+			Parts:      []ManifestPart{},
+			CreatedAt:  time.Now().UTC(),
+		},
+	}
+
+	if opts.PartSize > 0 {
+		w.zip = zip.NewWriter(out)
+		return w, nil
+	}
+
+	if err := w.startStream(out); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// startStream sets up the (optionally gzipped) CSV encoder on dst and writes the header
+func (w *Writer) startStream(dst io.Writer) error {
+	if w.opts.Gzip {
+		w.gzip = gzip.NewWriter(dst)
+		dst = w.gzip
+	}
+	w.csv = csv.NewWriter(dst)
+	w.partRows = 0
+	return w.csv.Write(w.header)
+}
+
+// finishStream flushes the current CSV encoder and closes the gzip stream if any
+func (w *Writer) finishStream() error {
+	if w.csv == nil {
+		return nil
+	}
+	w.csv.Flush()
+	if err := w.csv.Error(); err != nil {
+		return err
+	}
+	w.csv = nil
+	if w.gzip != nil {
+		if err := w.gzip.Close(); err != nil {
+			return err
+		}
+		w.gzip = nil
+	}
+	return nil
+}
+
+// startPart opens the next part file inside the zip archive
+func (w *Writer) startPart() error {
+	filename := fmt.Sprintf("%s-part-%04d.csv", w.name, len(w.manifest.Parts)+1)
+	method := zip.Deflate
+	if w.opts.Gzip {
+		// Parts are already compressed, store them as-is
+		filename += ".gz"
+		method = zip.Store
+	}
+
+	entry, err := w.zip.CreateHeader(&zip.FileHeader{
+		Name:     filename,
+		Method:   method,
+		Modified: w.manifest.CreatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create part %s: %w", filename, err)
+	}
+
+	w.manifest.Parts = append(w.manifest.Parts, ManifestPart{File: filename})
+	return w.startStream(entry)
+}
+
+// WriteRow writes a single CSV row, starting a new part when the current one is full
+func (w *Writer) WriteRow(row []string) error {
+	if w.closed {
+		return fmt.Errorf("export writer is closed")
+	}
+
+	if w.zip != nil && (w.csv == nil || w.partRows >= w.opts.PartSize) {
+		if err := w.finishStream(); err != nil {
+			return err
+		}
+		if err := w.startPart(); err != nil {
+			return err
+		}
+	}
+
+	if err := w.csv.Write(row); err != nil {
+		return err
+	}
+	w.partRows++
+	w.manifest.TotalRows++
+	if w.zip != nil {
+		w.manifest.Parts[len(w.manifest.Parts)-1].Rows++
+	}
+	return nil
+}
+
+// Flush pushes buffered rows to the underlying writer
+func (w *Writer) Flush() error {
+	if w.csv == nil {
+		return nil
+	}
+	w.csv.Flush()
+	if err := w.csv.Error(); err != nil {
+		return err
+	}
+	if w.gzip != nil {
+		return w.gzip.Flush()
+	}
+	return nil
+}
+
+// Close finishes the export. For split exports it writes the manifest and closes the archive.
+func (w *Writer) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+
+	if err := w.finishStream(); err != nil {
+		return err
+	}
+
+	if w.zip == nil {
+		return nil
+	}
+
+	entry, err := w.zip.CreateHeader(&zip.FileHeader{
+		Name:     ManifestFilename,
+		Method:   zip.Deflate,
+		Modified: w.manifest.CreatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create manifest: %w", err)
+	}
+	encoder := json.NewEncoder(entry)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(w.manifest); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+
+	return w.zip.Close()
+}
+
+// Manifest returns the manifest describing what has been written so far
+func (w *Writer) Manifest() Manifest {
+	return w.manifest
+}
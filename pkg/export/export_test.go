@@ -0,0 +1,194 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testHeader = []string{"email", "first_name"}
+
+func testRows(n int) [][]string {
+	rows := make([][]string, n)
+	for i := range rows {
+		rows[i] = []string{fmt.Sprintf("user%d@example.com", i), fmt.Sprintf("User, %d", i)}
+	}
+	return rows
+}
+
+func writeAll(t *testing.T, opts Options, rows [][]string) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, "contacts", testHeader, opts)
+	require.NoError(t, err)
+	for _, row := range rows {
+		require.NoError(t, w.WriteRow(row))
+	}
+	require.NoError(t, w.Close())
+	return &buf
+}
+
+func readCSV(t *testing.T, r io.Reader) [][]string {
+	t.Helper()
+	records, err := csv.NewReader(r).ReadAll()
+	require.NoError(t, err)
+	return records
+}
+
+func TestWriter_PlainCSV(t *testing.T) {
+	rows := testRows(3)
+	buf := writeAll(t, Options{}, rows)
+
+	records := readCSV(t, buf)
+	assert.Equal(t, append([][]string{testHeader}, rows...), records)
+}
+
+func TestWriter_Gzip(t *testing.T) {
+	rows := testRows(250)
+	buf := writeAll(t, Options{Gzip: true}, rows)
+
+	gz, err := gzip.NewReader(buf)
+	require.NoError(t, err)
+	defer gz.Close()
+
+	records := readCSV(t, gz)
+	require.Len(t, records, 251)
+	assert.Equal(t, testHeader, records[0])
+	assert.Equal(t, rows, records[1:])
+}
+
+func TestWriter_SplitParts(t *testing.T) {
+	testCases := []struct {
+		name          string
+		opts          Options
+		rowCount      int
+		expectedParts []int
+	}{
+		{
+			name:          "uneven split",
+			opts:          Options{PartSize: 10},
+			rowCount:      25,
+			expectedParts: []int{10, 10, 5},
+		},
+		{
+			name:          "even split",
+			opts:          Options{PartSize: 5},
+			rowCount:      20,
+			expectedParts: []int{5, 5, 5, 5},
+		},
+		{
+			name:          "gzip parts",
+			opts:          Options{PartSize: 100, Gzip: true},
+			rowCount:      250,
+			expectedParts: []int{100, 100, 50},
+		},
+		{
+			name:          "no rows",
+			opts:          Options{PartSize: 10},
+			rowCount:      0,
+			expectedParts: []int{},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rows := testRows(tc.rowCount)
+			buf := writeAll(t, tc.opts, rows)
+
+			archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			require.NoError(t, err)
+			require.Len(t, archive.File, len(tc.expectedParts)+1)
+
+			// Manifest is written last
+			manifestFile := archive.File[len(archive.File)-1]
+			require.Equal(t, ManifestFilename, manifestFile.Name)
+			mr, err := manifestFile.Open()
+			require.NoError(t, err)
+			var manifest Manifest
+			require.NoError(t, json.NewDecoder(mr).Decode(&manifest))
+			mr.Close()
+
+			assert.Equal(t, tc.rowCount, manifest.TotalRows)
+			assert.Equal(t, tc.opts.Gzip, manifest.Compressed)
+			require.Len(t, manifest.Parts, len(tc.expectedParts))
+
+			var allRows [][]string
+			for i, part := range manifest.Parts {
+				assert.Equal(t, tc.expectedParts[i], part.Rows)
+				assert.Equal(t, archive.File[i].Name, part.File)
+
+				rc, err := archive.File[i].Open()
+				require.NoError(t, err)
+				var r io.Reader = rc
+				if tc.opts.Gzip {
+					gz, err := gzip.NewReader(rc)
+					require.NoError(t, err)
+					r = gz
+				}
+				records := readCSV(t, r)
+				rc.Close()
+
+				// Every part carries its own header
+				assert.Equal(t, testHeader, records[0])
+				assert.Len(t, records[1:], part.Rows)
+				allRows = append(allRows, records[1:]...)
+			}
+			if tc.rowCount > 0 {
+				assert.Equal(t, rows, allRows)
+			}
+		})
+	}
+}
+
+func TestWriter_WriteAfterClose(t *testing.T) {
+	w, err := NewWriter(io.Discard, "contacts", testHeader, Options{})
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	assert.Error(t, w.WriteRow([]string{"a", "b"}))
+}
+
+func TestOptionsFromQuery(t *testing.T) {
+	testCases := []struct {
+		name      string
+		query     url.Values
+		expected  Options
+		expectErr bool
+	}{
+		{name: "defaults", query: url.Values{}, expected: Options{}},
+		{name: "gzip", query: url.Values{"compress": {"gzip"}}, expected: Options{Gzip: true}},
+		{name: "split", query: url.Values{"part_size": {"500"}}, expected: Options{PartSize: 500}},
+		{name: "invalid compress", query: url.Values{"compress": {"brotli"}}, expectErr: true},
+		{name: "invalid part size", query: url.Values{"part_size": {"abc"}}, expectErr: true},
+		{name: "zero part size", query: url.Values{"part_size": {"0"}}, expectErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			opts, err := OptionsFromQuery(tc.query)
+			if tc.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, opts)
+		})
+	}
+}
+
+func TestOptions_ContentTypeAndFilename(t *testing.T) {
+	assert.Equal(t, "text/csv; charset=utf-8", Options{}.ContentType())
+	assert.Equal(t, "contacts.csv", Options{}.Filename("contacts"))
+	assert.Equal(t, "application/gzip", Options{Gzip: true}.ContentType())
+	assert.Equal(t, "contacts.csv.gz", Options{Gzip: true}.Filename("contacts"))
+	assert.Equal(t, "application/zip", Options{PartSize: 10, Gzip: true}.ContentType())
+	assert.Equal(t, "contacts.zip", Options{PartSize: 10}.Filename("contacts"))
+}
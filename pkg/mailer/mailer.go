@@ -18,6 +18,8 @@ type Mailer interface {
 	SendMagicCode(email, code string) error
 	// SendCircuitBreakerAlert sends a notification when a broadcast is paused due to circuit breaker
 	SendCircuitBreakerAlert(email, workspaceName, broadcastName, reason string) error
+	// SendSendingBlockedAlert sends a notification when the broadcasts of a workspace are blocked
+	SendSendingBlockedAlert(email, workspaceName, reason string) error
 }
 
 // Config holds the configuration for the mailer
@@ -263,6 +265,81 @@ The Notifuse Team`, broadcastName, workspaceName, reason)
 	return nil
 }
 
+// SendSendingBlockedAlert sends a notification when the broadcasts of a workspace are blocked
+func (m *SMTPMailer) SendSendingBlockedAlert(email, workspaceName, reason string) error {
+	// Create a new message
+	msg := mail.NewMsg(mail.WithNoDefaultUserAgent())
+
+	// Set sender and recipient
+	if err := msg.FromFormat(m.config.FromName, m.config.FromEmail); err != nil {
+		return fmt.Errorf("failed to set email from address: %w", err)
+	}
+
+	if err := msg.To(email); err != nil {
+		return fmt.Errorf("failed to set email recipient: %w", err)
+	}
+
+	// Set subject
+	subject := fmt.Sprintf("🚨 Sending Blocked - %s", workspaceName)
+	msg.Subject(subject)
+
+	// Create HTML content
+	htmlBody := fmt.Sprintf(`
+	<html>
+		<body>
+			<h1 style="color: #d32f2f;">🚨 Workspace Sending Blocked</h1>
+			<p>Hello,</p>
+			<p>All active broadcasts of workspace <strong>%s</strong> have been paused and no broadcast can be sent until the block is cleared by a workspace owner.</p>
+
+			<div style="background-color: #fff3cd; border: 1px solid #ffeaa7; padding: 15px; border-radius: 5px; margin: 20px 0;">
+				<h3 style="color: #856404; margin-top: 0;">Reason:</h3>
+				<p style="margin-bottom: 0; color: #856404;"><strong>%s</strong></p>
+			</div>
+
+			<p>Best regards,<br>The Notifuse Team</p>
+		</body>
+	</html>`, workspaceName, reason)
+
+	// Set alternative body parts
+	plainBody := fmt.Sprintf(`
+🚨 WORKSPACE SENDING BLOCKED
+
+Hello,
+
+All active broadcasts of workspace %s have been paused and no broadcast can be sent until the block is cleared by a workspace owner.
+
+REASON: %s
+
+Best regards,
+The Notifuse Team`, workspaceName, reason)
+
+	msg.SetBodyString(mail.TypeTextHTML, htmlBody)
+	msg.AddAlternativeString(mail.TypeTextPlain, plainBody)
+
+	// Create SMTP client
+	client, err := m.createSMTPClient()
+	if err != nil {
+		return err
+	}
+
+	// For testing - log information if client is nil
+	if client == nil {
+		log.Printf("Sending sending blocked alert to: %s", email)
+		log.Printf("From: %s <%s>", m.config.FromName, m.config.FromEmail)
+		log.Printf("Subject: %s", subject)
+		log.Printf("Workspace: %s", workspaceName)
+		log.Printf("Reason: %s", reason)
+		return nil
+	}
+
+	// Send the email
+	if err := client.DialAndSend(msg); err != nil {
+		return fmt.Errorf("failed to send sending blocked alert email: %w", err)
+	}
+
+	return nil
+}
+
 // createSMTPClient creates and configures a new SMTP client
 func (m *SMTPMailer) createSMTPClient() (*mail.Client, error) {
 	// In test mode, return nil client to avoid SMTP connections
@@ -359,3 +436,21 @@ func (m *ConsoleMailer) SendCircuitBreakerAlert(email, workspaceName, broadcastN
 
 	return nil
 }
+
+// SendSendingBlockedAlert logs the sending blocked alert details to console
+func (m *ConsoleMailer) SendSendingBlockedAlert(email, workspaceName, reason string) error {
+	fmt.Println("==============================================================")
+	fmt.Println("                 SENDING BLOCKED ALERT EMAIL                  ")
+	fmt.Println("==============================================================")
+	fmt.Printf("To: %s\n", email)
+	fmt.Printf("Subject: 🚨 Sending Blocked - %s\n\n", workspaceName)
+	fmt.Println("Email Content:")
+	fmt.Printf("🚨 WORKSPACE SENDING BLOCKED\n\n")
+	fmt.Printf("Hello,\n\n")
+	fmt.Printf("All active broadcasts of workspace %s have been paused and no broadcast can be sent until the block is cleared by a workspace owner.\n\n", workspaceName)
+	fmt.Printf("REASON: %s\n\n", reason)
+	fmt.Printf("Best regards,\nThe Notifuse Team\n\n")
+	fmt.Println("==============================================================")
+
+	return nil
+}
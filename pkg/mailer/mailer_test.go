@@ -74,6 +74,13 @@ func (m *MockMailer) SendCircuitBreakerAlert(email, workspaceName, broadcastName
 	return nil
 }
 
+func (m *MockMailer) SendSendingBlockedAlert(email, workspaceName, reason string) error {
+	if m.shouldFail {
+		return errors.New("mock mailer error")
+	}
+	return nil
+}
+
 // ValidatingMailer is a mock implementation that validates inputs
 type ValidatingMailer struct {
 	config *Config
@@ -664,6 +671,47 @@ func TestSMTPMailer_SendCircuitBreakerAlert(t *testing.T) {
 	}
 }
 
+func TestSendingBlockedAlert(t *testing.T) {
+	email := "owner@example.com"
+	workspaceName := "Test Workspace"
+	reason := "Complaint rate 0.80% over the last 1h0m0s exceeds 0.30%"
+
+	t.Run("console mailer", func(t *testing.T) {
+		output := captureOutput(func() {
+			if err := NewConsoleMailer().SendSendingBlockedAlert(email, workspaceName, reason); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+		})
+
+		for _, expected := range []string{"SENDING BLOCKED ALERT EMAIL", "To: " + email, "Subject: 🚨 Sending Blocked - " + workspaceName, "REASON: " + reason} {
+			if !strings.Contains(output, expected) {
+				t.Errorf("Expected output to contain '%s', but it didn't. Output: %s", expected, output)
+			}
+		}
+	})
+
+	t.Run("smtp mailer", func(t *testing.T) {
+		config := &Config{
+			SMTPHost:  "smtp.example.com",
+			SMTPPort:  587,
+			FromEmail: "noreply@example.com",
+			FromName:  "Notifuse",
+		}
+
+		logOutput := captureLog(func() {
+			if err := NewTestSMTPMailer(config).SendSendingBlockedAlert(email, workspaceName, reason); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+		})
+
+		for _, expected := range []string{"Sending sending blocked alert to: " + email, "Subject: 🚨 Sending Blocked - " + workspaceName, "Workspace: " + workspaceName, "Reason: " + reason} {
+			if !strings.Contains(logOutput, expected) {
+				t.Errorf("Expected log to contain '%s', but it didn't. Log: %s", expected, logOutput)
+			}
+		}
+	})
+}
+
 func TestSMTPMailer_SendCircuitBreakerAlert_EdgeCases(t *testing.T) {
 	testCases := []struct {
 		name          string
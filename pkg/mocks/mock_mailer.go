@@ -61,6 +61,20 @@ func (mr *MockMailerMockRecorder) SendMagicCode(arg0, arg1 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendMagicCode", reflect.TypeOf((*MockMailer)(nil).SendMagicCode), arg0, arg1)
 }
 
+// SendSendingBlockedAlert mocks base method.
+func (m *MockMailer) SendSendingBlockedAlert(arg0, arg1, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendSendingBlockedAlert", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendSendingBlockedAlert indicates an expected call of SendSendingBlockedAlert.
+func (mr *MockMailerMockRecorder) SendSendingBlockedAlert(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendSendingBlockedAlert", reflect.TypeOf((*MockMailer)(nil).SendSendingBlockedAlert), arg0, arg1, arg2)
+}
+
 // SendWorkspaceInvitation mocks base method.
 func (m *MockMailer) SendWorkspaceInvitation(arg0, arg1, arg2, arg3 string) error {
 	m.ctrl.T.Helper()
@@ -0,0 +1,123 @@
+package notifuse_mjml
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// conditionTokenRegex matches the tokens of a condition: strings, numbers, comparison operators and
+// words (variables, keywords and literals). Anything else, like Liquid delimiters, is rejected.
+var conditionTokenRegex = regexp.MustCompile(`^(?:'[^']*'|"[^"]*"|-?[0-9]+(?:\.[0-9]+)?|==|!=|<>|<=|>=|<|>|[a-zA-Z_][a-zA-Z0-9_-]*(?:\.[a-zA-Z_][a-zA-Z0-9_-]*|\[[0-9]+\])*)`)
+
+var conditionOperators = map[string]bool{"==": true, "!=": true, "<>": true, "<": true, ">": true, "<=": true, ">=": true, "contains": true}
+
+// ParseCondition checks that a condition is a boolean Liquid expression, i.e. comparisons like
+// contact.custom_number_1 > 100 or plain values, joined with and/or
+func ParseCondition(condition string) error {
+	var tokens []string
+	rest := strings.TrimSpace(condition)
+	for rest != "" {
+		token := conditionTokenRegex.FindString(rest)
+		if token == "" {
+			return fmt.Errorf("invalid condition %q: unexpected %q", condition, rest)
+		}
+		tokens = append(tokens, token)
+		rest = strings.TrimSpace(rest[len(token):])
+	}
+	if len(tokens) == 0 {
+		return fmt.Errorf("condition is empty")
+	}
+
+	// Alternate operands and operators: operand [operator operand] {and|or operand [operator operand]}
+	expectOperand := true
+	inComparison := false
+	for _, token := range tokens {
+		isOperator := conditionOperators[token]
+		isJoin := token == "and" || token == "or"
+		switch {
+		case expectOperand:
+			if isOperator || isJoin {
+				return fmt.Errorf("invalid condition %q: expected a value before %q", condition, token)
+			}
+			expectOperand = false
+		case isOperator:
+			if inComparison {
+				return fmt.Errorf("invalid condition %q: comparisons can't be chained, join them with and/or", condition)
+			}
+			inComparison = true
+			expectOperand = true
+		case isJoin:
+			inComparison = false
+			expectOperand = true
+		default:
+			return fmt.Errorf("invalid condition %q: expected an operator before %q", condition, token)
+		}
+	}
+	if expectOperand {
+		return fmt.Errorf("invalid condition %q: missing value after %q", condition, tokens[len(tokens)-1])
+	}
+	return nil
+}
+
+// ConditionalCondition returns the condition of a conditional block
+func ConditionalCondition(block EmailBlock) string {
+	condition, _ := block.GetAttributes()["condition"].(string)
+	return strings.TrimSpace(condition)
+}
+
+// ConditionalConditions returns the condition of every conditional block of the tree, in tree order
+func ConditionalConditions(tree EmailBlock) []string {
+	var conditions []string
+	var walk func(block EmailBlock)
+	walk = func(block EmailBlock) {
+		if block == nil {
+			return
+		}
+		if block.GetType() == MJMLComponentConditional {
+			conditions = append(conditions, ConditionalCondition(block))
+		}
+		for _, child := range block.GetChildren() {
+			walk(child)
+		}
+	}
+	walk(tree)
+	return conditions
+}
+
+// EvaluateCondition evaluates a condition against the template data with the Liquid if tag,
+// so that it behaves like the {% if %} tags of the template content
+func EvaluateCondition(condition string, data map[string]interface{}, blockID string) (bool, error) {
+	if err := ParseCondition(condition); err != nil {
+		return false, err
+	}
+	result, err := processLiquidContent("{% if "+condition+" %}true{% endif %}", data, blockID)
+	if err != nil {
+		return false, err
+	}
+	return result == "true", nil
+}
+
+// convertConditionalToMJML renders the children of a conditional block in its place when its condition
+// holds for the recipient, and nothing otherwise
+func convertConditionalToMJML(block EmailBlock, indentLevel int, templateData string, parsedData map[string]interface{}) (string, error) {
+	visible, err := EvaluateCondition(ConditionalCondition(block), parsedData, block.GetID())
+	if err != nil {
+		return "", fmt.Errorf("condition evaluation failed for block %s: %v", block.GetID(), err)
+	}
+	if !visible {
+		return "", nil
+	}
+
+	var childrenMJML []string
+	for _, child := range block.GetChildren() {
+		if child != nil {
+			childMJML, err := convertBlockToMJMLWithErrorAndParsedData(child, indentLevel, templateData, parsedData)
+			if err != nil {
+				return "", err
+			}
+			childrenMJML = append(childrenMJML, childMJML)
+		}
+	}
+	return strings.Join(childrenMJML, "\n"), nil
+}
@@ -0,0 +1,150 @@
+package notifuse_mjml
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newConditionalEmail returns an email with a section always shown and a section shown when condition holds
+func newConditionalEmail(condition string) EmailBlock {
+	newSection := func(id, text string) EmailBlock {
+		textBase := NewBaseBlock(id+"-text", MJMLComponentMjText)
+		textBase.Content = &text
+		columnBase := NewBaseBlock(id+"-column", MJMLComponentMjColumn)
+		columnBase.Children = []EmailBlock{&MJTextBlock{BaseBlock: textBase}}
+		sectionBase := NewBaseBlock(id, MJMLComponentMjSection)
+		sectionBase.Children = []EmailBlock{&MJColumnBlock{BaseBlock: columnBase}}
+		return &MJSectionBlock{BaseBlock: sectionBase}
+	}
+
+	conditionalBase := NewBaseBlock("vip", MJMLComponentConditional)
+	conditionalBase.Attributes = map[string]interface{}{"condition": condition}
+	conditionalBase.Children = []EmailBlock{newSection("vip-offer", "Your VIP offer, {{ contact.first_name }}")}
+
+	bodyBase := NewBaseBlock("body", MJMLComponentMjBody)
+	bodyBase.Children = []EmailBlock{
+		newSection("news", "This month's news"),
+		&MJConditionalBlock{BaseBlock: conditionalBase},
+	}
+	rootBase := NewBaseBlock("root", MJMLComponentMjml)
+	rootBase.Children = []EmailBlock{&MJBodyBlock{BaseBlock: bodyBase}}
+	return &MJMLBlock{BaseBlock: rootBase}
+}
+
+func TestConditional_RendersPerRecipient(t *testing.T) {
+	email := newConditionalEmail("contact.custom_number_1 > 100")
+	require.NoError(t, ValidateEmailStructure(email))
+
+	t.Run("high value contact", func(t *testing.T) {
+		mjml, err := ConvertJSONToMJMLWithData(email, `{"contact":{"first_name":"Ada","custom_number_1":250}}`)
+		require.NoError(t, err)
+
+		assert.Contains(t, mjml, "This month's news")
+		assert.Contains(t, mjml, "Your VIP offer, Ada")
+		assert.Equal(t, 2, strings.Count(mjml, "<mj-section"))
+		assert.NotContains(t, mjml, "conditional")
+	})
+
+	t.Run("low value contact", func(t *testing.T) {
+		mjml, err := ConvertJSONToMJMLWithData(email, `{"contact":{"first_name":"Bob","custom_number_1":20}}`)
+		require.NoError(t, err)
+
+		assert.Contains(t, mjml, "This month's news")
+		assert.NotContains(t, mjml, "VIP offer")
+		assert.Equal(t, 1, strings.Count(mjml, "<mj-section"))
+	})
+
+	t.Run("contact without the field", func(t *testing.T) {
+		mjml, err := ConvertJSONToMJMLWithData(email, `{"contact":{"first_name":"Cy"}}`)
+		require.NoError(t, err)
+		assert.NotContains(t, mjml, "VIP offer")
+	})
+
+	t.Run("malformed condition", func(t *testing.T) {
+		_, err := ConvertJSONToMJMLWithData(newConditionalEmail("contact.custom_number_1 >"), `{"contact":{}}`)
+		assert.Error(t, err)
+
+		// Without error handling the block is hidden
+		assert.NotContains(t, ConvertJSONToMJML(newConditionalEmail("contact.custom_number_1 >")), "VIP offer")
+	})
+}
+
+func TestConditional_Hierarchy(t *testing.T) {
+	// The children of a conditional block must fit in its parent
+	textBase := NewBaseBlock("text", MJMLComponentMjText)
+	conditionalBase := NewBaseBlock("conditional", MJMLComponentConditional)
+	conditionalBase.Attributes = map[string]interface{}{"condition": "contact.custom_string_1 == 'vip'"}
+	conditionalBase.Children = []EmailBlock{&MJTextBlock{BaseBlock: textBase}}
+
+	columnBase := NewBaseBlock("column", MJMLComponentMjColumn)
+	columnBase.Children = []EmailBlock{&MJConditionalBlock{BaseBlock: conditionalBase}}
+	assert.NoError(t, ValidateComponentHierarchy(&MJColumnBlock{BaseBlock: columnBase}))
+
+	bodyBase := NewBaseBlock("body", MJMLComponentMjBody)
+	bodyBase.Children = []EmailBlock{&MJConditionalBlock{BaseBlock: conditionalBase}}
+	err := ValidateComponentHierarchy(&MJBodyBlock{BaseBlock: bodyBase})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "component mj-text cannot be a child of mj-body")
+
+	block, err := UnmarshalEmailBlock([]byte(`{"id":"c","type":"conditional","attributes":{"condition":"contact.country == 'FR'"}}`))
+	require.NoError(t, err)
+	assert.IsType(t, &MJConditionalBlock{}, block)
+	assert.Equal(t, "contact.country == 'FR'", ConditionalCondition(block))
+}
+
+func TestParseCondition(t *testing.T) {
+	valid := []string{
+		"contact.custom_number_1 > 100",
+		"contact.custom_number_1 >= 100.5 and contact.country == 'FR'",
+		`contact.custom_string_1 contains "vip" or contact.custom_json_1.tags contains 'vip'`,
+		"contact.first_name",
+		"contact.custom_json_1.items.size > 0",
+		"contact.custom_number_2 != nil",
+		"contact.custom_number_1 <= -1",
+	}
+	for _, condition := range valid {
+		assert.NoError(t, ParseCondition(condition), condition)
+	}
+
+	invalid := []string{
+		"",
+		"   ",
+		"contact.custom_number_1 >",
+		"> 100",
+		"contact.custom_number_1 > 100 and",
+		"contact.custom_number_1 100",
+		"1 < contact.custom_number_1 < 10",
+		"contact.first_name %} hacked {% if true",
+		"contact.country == 'FR",
+		"and contact.first_name",
+		"contact.first_name == {{ x }}",
+	}
+	for _, condition := range invalid {
+		assert.Error(t, ParseCondition(condition), condition)
+	}
+}
+
+func TestEvaluateCondition(t *testing.T) {
+	data := map[string]interface{}{
+		"contact": map[string]interface{}{"custom_number_1": 150.0, "country": "FR", "custom_string_1": "gold vip"},
+	}
+
+	tests := []struct {
+		condition string
+		want      bool
+	}{
+		{"contact.custom_number_1 > 100", true},
+		{"contact.custom_number_1 > 200", false},
+		{"contact.country == 'FR' and contact.custom_number_1 > 100", true},
+		{"contact.country == 'US' or contact.custom_string_1 contains 'vip'", true},
+		{"contact.custom_string_2", false},
+	}
+	for _, tt := range tests {
+		got, err := EvaluateCondition(tt.condition, data, "block")
+		require.NoError(t, err, tt.condition)
+		assert.Equal(t, tt.want, got, tt.condition)
+	}
+}
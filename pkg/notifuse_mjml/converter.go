@@ -24,6 +24,15 @@ func ConvertJSONToMJMLWithData(tree EmailBlock, templateData string) (string, er
 
 // convertBlockToMJMLWithErrorAndParsedData recursively converts a single EmailBlock to MJML string with error handling and pre-parsed data
 func convertBlockToMJMLWithErrorAndParsedData(block EmailBlock, indentLevel int, templateData string, parsedData map[string]interface{}) (string, error) {
+	// Product grids are expanded into MJML sections for the recipient
+	if block.GetType() == MJMLComponentProductGrid {
+		return convertProductGridToMJML(block, indentLevel, parsedData)
+	}
+	// Conditional blocks are replaced by their children, or removed for the recipient
+	if block.GetType() == MJMLComponentConditional {
+		return convertConditionalToMJML(block, indentLevel, templateData, parsedData)
+	}
+
 	indent := strings.Repeat("  ", indentLevel)
 	tagName := string(block.GetType())
 	children := block.GetChildren()
@@ -107,6 +116,37 @@ func convertBlockToMJMLWithParsedData(block EmailBlock, indentLevel int, templat
 		return ""
 	}
 
+	// Product grids are expanded into MJML sections for the recipient
+	if blockType == MJMLComponentProductGrid {
+		gridMJML, err := convertProductGridToMJML(block, indentLevel, parsedData)
+		if err != nil {
+			// Log error but continue without the grid
+			fmt.Printf("Warning: %v\n", err)
+			return ""
+		}
+		return gridMJML
+	}
+
+	// Conditional blocks are replaced by their children, or removed for the recipient
+	if blockType == MJMLComponentConditional {
+		visible, err := EvaluateCondition(ConditionalCondition(block), parsedData, block.GetID())
+		if err != nil {
+			// Log error and hide the block, its content may not be meant for everyone
+			fmt.Printf("Warning: Condition evaluation failed for block %s: %v\n", block.GetID(), err)
+			return ""
+		}
+		if !visible {
+			return ""
+		}
+		var childrenMJML []string
+		for _, child := range block.GetChildren() {
+			if child != nil {
+				childrenMJML = append(childrenMJML, convertBlockToMJMLWithParsedData(child, indentLevel, templateData, parsedData))
+			}
+		}
+		return strings.Join(childrenMJML, "\n")
+	}
+
 	indent := strings.Repeat("  ", indentLevel)
 	tagName := string(blockType)
 	children := block.GetChildren()
@@ -335,14 +375,11 @@ func formatSingleAttributeWithLiquid(key string, value interface{}, templateData
 // processAttributeValue processes attribute values through liquid templating if applicable
 func processAttributeValue(value, attributeKey string, templateData map[string]interface{}, blockID string) string {
 	// Process liquid templates for URL-related attributes and alt text
-	isURLAttribute := attributeKey == "href" || attributeKey == "src" || attributeKey == "action" ||
-		attributeKey == "background-url" || strings.HasSuffix(attributeKey, "-url")
-
 	// Alt attribute for images - users commonly personalize this
 	isAltAttribute := attributeKey == "alt"
 
 	// If templateData is nil or this isn't a processable attribute, return as-is
-	if templateData == nil || (!isURLAttribute && !isAltAttribute) {
+	if templateData == nil || (!isURLAttribute(attributeKey) && !isAltAttribute) {
 		return value
 	}
 
@@ -357,6 +394,12 @@ func processAttributeValue(value, attributeKey string, templateData map[string]i
 	return processedValue
 }
 
+// isURLAttribute reports whether an attribute holds a URL, its Liquid markup is then rendered
+func isURLAttribute(attributeKey string) bool {
+	return attributeKey == "href" || attributeKey == "src" || attributeKey == "action" ||
+		attributeKey == "background-url" || strings.HasSuffix(attributeKey, "-url")
+}
+
 // camelToKebab converts camelCase to kebab-case
 func camelToKebab(str string) string {
 	// Use regex to find capital letters and replace them with hyphen + lowercase
@@ -0,0 +1,65 @@
+package notifuse_mjml
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+)
+
+// EscapeContactMergeData returns a copy of the template data whose contact values are HTML-escaped,
+// so that markup stored in contact fields (e.g. by an enrichment provider) renders as text instead of
+// being injected into the email. Contact fields listed in rawFields are trusted and kept as is.
+// Nested values of JSON fields are escaped as well, the other template data is left untouched.
+func EscapeContactMergeData(data MapOfAny, rawFields []string) (MapOfAny, error) {
+	contact, ok := data["contact"]
+	if !ok || contact == nil {
+		return data, nil
+	}
+
+	// The contact map comes in the caller's own map type, normalize it through JSON
+	contactJSON, err := json.Marshal(contact)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal contact data: %w", err)
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal(contactJSON, &fields); err != nil {
+		return nil, fmt.Errorf("contact data must be an object: %w", err)
+	}
+
+	raw := make(map[string]bool, len(rawFields))
+	for _, field := range rawFields {
+		raw[field] = true
+	}
+	for key, value := range fields {
+		if !raw[key] {
+			fields[key] = escapeMergeValue(value)
+		}
+	}
+
+	escaped := make(MapOfAny, len(data))
+	for key, value := range data {
+		escaped[key] = value
+	}
+	escaped["contact"] = fields
+
+	return escaped, nil
+}
+
+// escapeMergeValue escapes the strings of a JSON decoded value
+func escapeMergeValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		return html.EscapeString(v)
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = escapeMergeValue(item)
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = escapeMergeValue(item)
+		}
+		return v
+	}
+	return value
+}
@@ -0,0 +1,106 @@
+package notifuse_mjml
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEscapeContactMergeData(t *testing.T) {
+	data := MapOfAny{
+		"contact": map[string]interface{}{
+			"email":           "john@example.com",
+			"first_name":      "<script>alert(1)</script>",
+			"custom_string_1": "<b>VIP</b>",
+			"custom_number_1": 42,
+			"custom_json_1":   map[string]interface{}{"tags": []interface{}{"<i>new</i>"}},
+		},
+		"unsubscribe_url": "https://example.com/unsubscribe?a=1&b=2",
+	}
+
+	t.Run("escapes contact values by default", func(t *testing.T) {
+		escaped, err := EscapeContactMergeData(data, nil)
+		require.NoError(t, err)
+
+		contact := escaped["contact"].(map[string]interface{})
+		assert.Equal(t, "&lt;script&gt;alert(1)&lt;/script&gt;", contact["first_name"])
+		assert.Equal(t, "&lt;b&gt;VIP&lt;/b&gt;", contact["custom_string_1"])
+		assert.Equal(t, "john@example.com", contact["email"])
+		assert.Equal(t, float64(42), contact["custom_number_1"])
+		assert.Equal(t, map[string]interface{}{"tags": []interface{}{"&lt;i&gt;new&lt;/i&gt;"}}, contact["custom_json_1"])
+
+		// Other template data and the original data are left untouched
+		assert.Equal(t, "https://example.com/unsubscribe?a=1&b=2", escaped["unsubscribe_url"])
+		assert.Equal(t, "<b>VIP</b>", data["contact"].(map[string]interface{})["custom_string_1"])
+	})
+
+	t.Run("keeps raw fields", func(t *testing.T) {
+		escaped, err := EscapeContactMergeData(data, []string{"custom_string_1"})
+		require.NoError(t, err)
+
+		contact := escaped["contact"].(map[string]interface{})
+		assert.Equal(t, "<b>VIP</b>", contact["custom_string_1"])
+		assert.Equal(t, "&lt;script&gt;alert(1)&lt;/script&gt;", contact["first_name"])
+	})
+
+	t.Run("without contact", func(t *testing.T) {
+		escaped, err := EscapeContactMergeData(MapOfAny{"name": "<b>Acme</b>"}, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "<b>Acme</b>", escaped["name"])
+	})
+
+	t.Run("contact is not an object", func(t *testing.T) {
+		_, err := EscapeContactMergeData(MapOfAny{"contact": "john@example.com"}, nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestCompileTemplate_EscapesContactMergeData(t *testing.T) {
+	textBase := NewBaseBlock("text-1", MJMLComponentMjText)
+	textBase.Content = stringPtr("<p>Hello {{ contact.first_name }}, {{ contact.custom_string_1 }}</p>")
+	textBlock := &MJTextBlock{BaseBlock: textBase}
+
+	columnBlock := &MJColumnBlock{BaseBlock: NewBaseBlock("column-1", MJMLComponentMjColumn)}
+	columnBlock.Children = []EmailBlock{textBlock}
+	sectionBlock := &MJSectionBlock{BaseBlock: NewBaseBlock("section-1", MJMLComponentMjSection)}
+	sectionBlock.Children = []EmailBlock{columnBlock}
+	bodyBlock := &MJBodyBlock{BaseBlock: NewBaseBlock("body-1", MJMLComponentMjBody)}
+	bodyBlock.Children = []EmailBlock{sectionBlock}
+	mjml := &MJMLBlock{BaseBlock: NewBaseBlock("mjml-1", MJMLComponentMjml)}
+	mjml.Children = []EmailBlock{bodyBlock}
+
+	req := CompileTemplateRequest{
+		WorkspaceID:      "test-workspace",
+		MessageID:        "test-message",
+		VisualEditorTree: mjml,
+		TemplateData: MapOfAny{
+			"contact": map[string]interface{}{
+				"first_name":      "<script>alert(1)</script>",
+				"custom_string_1": "<b>VIP</b>",
+			},
+		},
+	}
+
+	t.Run("contact field is escaped by default", func(t *testing.T) {
+		resp, err := CompileTemplate(req)
+		require.NoError(t, err)
+		require.True(t, resp.Success)
+
+		assert.NotContains(t, *resp.HTML, "<script>alert(1)</script>")
+		assert.Contains(t, *resp.HTML, "&lt;script&gt;alert(1)&lt;/script&gt;")
+		assert.Contains(t, *resp.HTML, "&lt;b&gt;VIP&lt;/b&gt;")
+	})
+
+	t.Run("raw field passes through", func(t *testing.T) {
+		rawReq := req
+		rawReq.RawMergeFields = []string{"custom_string_1"}
+
+		resp, err := CompileTemplate(rawReq)
+		require.NoError(t, err)
+		require.True(t, resp.Success)
+
+		assert.Contains(t, *resp.HTML, "<b>VIP</b>")
+		assert.NotContains(t, *resp.HTML, "<script>alert(1)</script>")
+	})
+}
@@ -33,6 +33,10 @@ const (
 	MJMLComponentMjStyle          MJMLComponentType = "mj-style"
 	MJMLComponentMjTitle          MJMLComponentType = "mj-title"
 	MJMLComponentMjRaw            MJMLComponentType = "mj-raw"
+	// MJMLComponentProductGrid isn't an MJML tag, it is expanded into sections of product cards when rendering
+	MJMLComponentProductGrid MJMLComponentType = "product-grid"
+	// MJMLComponentConditional isn't an MJML tag either, it is replaced by its children when its condition holds
+	MJMLComponentConditional MJMLComponentType = "conditional"
 )
 
 // Common attribute interfaces
@@ -273,6 +277,18 @@ type MJRawAttributes struct {
 	CommonAttributes
 }
 
+// MJProductGridAttributes configure a product grid, the other attributes style the text of its cards
+type MJProductGridAttributes struct {
+	Source       *string `json:"source,omitempty"`       // Merge tag of the array of products, e.g. contact.custom_json_1.products
+	Columns      *int    `json:"columns,omitempty"`      // Cards per row, 1 to 4
+	CardTemplate *string `json:"cardTemplate,omitempty"` // Liquid HTML of a card, the item is available as product
+}
+
+// MJConditionalAttributes configure a conditional block
+type MJConditionalAttributes struct {
+	Condition *string `json:"condition,omitempty"` // Liquid boolean expression, e.g. contact.custom_number_1 > 100
+}
+
 type MJBreakpointAttributes struct {
 	Width *string `json:"width,omitempty"`
 }
@@ -379,6 +395,14 @@ type MJRawBlock struct {
 	*BaseBlock
 }
 
+type MJProductGridBlock struct {
+	*BaseBlock
+}
+
+type MJConditionalBlock struct {
+	*BaseBlock
+}
+
 // Email builder state types
 type EmailBuilderState struct {
 	SelectedBlockID *string      `json:"selectedBlockId,omitempty"`
@@ -645,6 +669,10 @@ func createTypedBlock(base *BaseBlock) EmailBlock {
 		return &MJStyleBlock{BaseBlock: base}
 	case MJMLComponentMjTitle:
 		return &MJTitleBlock{BaseBlock: base}
+	case MJMLComponentProductGrid:
+		return &MJProductGridBlock{BaseBlock: base}
+	case MJMLComponentConditional:
+		return &MJConditionalBlock{BaseBlock: base}
 	default:
 		// For unknown types, return the base block itself
 		return base
@@ -680,15 +708,20 @@ var ValidChildrenMap = map[MJMLComponentType][]MJMLComponentType{
 		MJMLComponentMjWrapper,
 		MJMLComponentMjSection,
 		MJMLComponentMjRaw,
+		MJMLComponentProductGrid,
+		MJMLComponentConditional,
 	},
 	MJMLComponentMjWrapper: {
 		MJMLComponentMjSection,
 		MJMLComponentMjRaw,
+		MJMLComponentProductGrid,
+		MJMLComponentConditional,
 	},
 	MJMLComponentMjSection: {
 		MJMLComponentMjColumn,
 		MJMLComponentMjGroup,
 		MJMLComponentMjRaw,
+		MJMLComponentConditional,
 	},
 	MJMLComponentMjColumn: {
 		MJMLComponentMjText,
@@ -698,6 +731,7 @@ var ValidChildrenMap = map[MJMLComponentType][]MJMLComponentType{
 		MJMLComponentMjSpacer,
 		MJMLComponentMjSocial,
 		MJMLComponentMjRaw,
+		MJMLComponentConditional,
 	},
 	MJMLComponentMjGroup: {
 		MJMLComponentMjColumn,
@@ -715,6 +749,7 @@ var ValidChildrenMap = map[MJMLComponentType][]MJMLComponentType{
 		MJMLComponentMjTitle,
 		MJMLComponentMjRaw,
 	},
+	// Conditional blocks take the children of their parent, see validateChildComponents
 	// Leaf components (no children allowed)
 	MJMLComponentMjText:           {},
 	MJMLComponentMjButton:         {},
@@ -730,6 +765,7 @@ var ValidChildrenMap = map[MJMLComponentType][]MJMLComponentType{
 	MJMLComponentMjPreview:        {},
 	MJMLComponentMjStyle:          {},
 	MJMLComponentMjTitle:          {},
+	MJMLComponentProductGrid:      {},
 }
 
 // CanDropCheck validates if a drag type can be dropped into a drop type
@@ -763,15 +799,27 @@ func ValidateComponentHierarchy(block EmailBlock) error {
 		return fmt.Errorf("component %s cannot have children", blockType)
 	}
 
-	// Validate each child
+	return validateChildComponents(blockType, children)
+}
+
+// validateChildComponents validates the children of a component of type parentType.
+// The children of a conditional block replace it when rendering, so they must be valid children of parentType.
+func validateChildComponents(parentType MJMLComponentType, children []EmailBlock) error {
 	for _, child := range children {
 		if child == nil {
 			continue
 		}
 
 		childType := child.GetType()
-		if !CanDropCheck(childType, blockType) {
-			return fmt.Errorf("component %s cannot be a child of %s", childType, blockType)
+		if !CanDropCheck(childType, parentType) {
+			return fmt.Errorf("component %s cannot be a child of %s", childType, parentType)
+		}
+
+		if childType == MJMLComponentConditional {
+			if err := validateChildComponents(parentType, child.GetChildren()); err != nil {
+				return err
+			}
+			continue
 		}
 
 		// Recursively validate children
@@ -830,6 +878,10 @@ func GetComponentDisplayName(componentType MJMLComponentType) string {
 		return "Title"
 	case MJMLComponentMjRaw:
 		return "Raw HTML"
+	case MJMLComponentProductGrid:
+		return "Product Grid"
+	case MJMLComponentConditional:
+		return "Conditional"
 	default:
 		// Convert kebab-case to Title Case
 		parts := strings.Split(string(componentType), "-")
@@ -860,6 +912,8 @@ func GetComponentCategory(componentType MJMLComponentType) string {
 		return "Head"
 	case MJMLComponentMjRaw:
 		return "Raw"
+	case MJMLComponentProductGrid, MJMLComponentConditional:
+		return "Dynamic"
 	default:
 		return "Other"
 	}
@@ -985,6 +1039,8 @@ func GetDefaultAttributes(componentType MJMLComponentType) map[string]interface{
 		defaults["width"] = "100%"
 	case MJMLComponentMjSpacer:
 		defaults["height"] = "20px"
+	case MJMLComponentProductGrid:
+		defaults["columns"] = DefaultProductGridColumns
 	case MJMLComponentMjSection:
 		// defaults["padding"] = "20px 0"
 		defaults["paddingTop"] = "20px"
@@ -0,0 +1,149 @@
+package notifuse_mjml
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DefaultProductGridColumns is the number of cards per row of a product grid without columns attribute
+	DefaultProductGridColumns = 3
+	// MaxProductGridColumns is the largest number of cards per row, wider rows get unreadable on desktop
+	MaxProductGridColumns = 4
+	// ProductGridItemVariable is the Liquid variable holding the item of a card
+	ProductGridItemVariable = "product"
+)
+
+// DefaultProductCardTemplate renders the image, name, price and link of a product
+const DefaultProductCardTemplate = `{% if product.image_url %}<img src="{{ product.image_url }}" alt="{{ product.name }}" width="100%" style="display:block;width:100%;height:auto;border:0;" />{% endif %}` +
+	`<p style="margin:8px 0 4px;font-weight:bold;">{{ product.name }}</p>` +
+	`{% if product.price %}<p style="margin:0 0 8px;">{{ product.price }}</p>{% endif %}` +
+	`{% if product.url %}<a href="{{ product.url }}">View product</a>{% endif %}`
+
+// productGridAttributes are the attributes configuring the grid itself, they aren't passed to the cards
+var productGridAttributes = map[string]bool{"source": true, "columns": true, "cardTemplate": true}
+
+// ProductGridSource returns the merge tag of the array a product grid iterates,
+// e.g. contact.custom_json_1.products, with the Liquid braces removed if the editor kept them
+func ProductGridSource(block EmailBlock) string {
+	source, _ := block.GetAttributes()["source"].(string)
+	source = strings.TrimSpace(source)
+	if strings.HasPrefix(source, "{{") && strings.HasSuffix(source, "}}") {
+		source = strings.TrimSpace(source[2 : len(source)-2])
+	}
+	return source
+}
+
+// ProductGridSources returns the source of every product grid of the tree, in tree order
+func ProductGridSources(tree EmailBlock) []string {
+	var sources []string
+	var walk func(block EmailBlock)
+	walk = func(block EmailBlock) {
+		if block == nil {
+			return
+		}
+		if block.GetType() == MJMLComponentProductGrid {
+			sources = append(sources, ProductGridSource(block))
+		}
+		for _, child := range block.GetChildren() {
+			walk(child)
+		}
+	}
+	walk(tree)
+	return sources
+}
+
+// productGridColumns returns the cards per row of a product grid, the default when the attribute is invalid
+func productGridColumns(attributes map[string]interface{}) int {
+	columns := DefaultProductGridColumns
+	switch v := attributes["columns"].(type) {
+	case int:
+		columns = v
+	case float64:
+		columns = int(v)
+	case string:
+		if parsed, err := strconv.Atoi(strings.TrimSpace(v)); err == nil {
+			columns = parsed
+		}
+	}
+	if columns < 1 || columns > MaxProductGridColumns {
+		return DefaultProductGridColumns
+	}
+	return columns
+}
+
+// lookupTemplateArray returns the array at a dotted path of the template data
+func lookupTemplateArray(data map[string]interface{}, path string) []interface{} {
+	if path == "" {
+		return nil
+	}
+	var current interface{} = data
+	for _, key := range strings.Split(path, ".") {
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		current = m[key]
+	}
+	items, _ := current.([]interface{})
+	return items
+}
+
+// convertProductGridToMJML expands a product grid into sections of columns holding one card per item of
+// its source array, rendered with the card template for the recipient. Sections stack their columns on
+// mobile, which keeps the grid responsive. Nothing is rendered when the array is missing or empty.
+func convertProductGridToMJML(block EmailBlock, indentLevel int, parsedData map[string]interface{}) (string, error) {
+	attributes := block.GetAttributes()
+	items := lookupTemplateArray(parsedData, ProductGridSource(block))
+	if len(items) == 0 {
+		return "", nil
+	}
+
+	cardTemplate, _ := attributes["cardTemplate"].(string)
+	if strings.TrimSpace(cardTemplate) == "" {
+		cardTemplate = DefaultProductCardTemplate
+	}
+
+	textAttributes := make(map[string]interface{})
+	for key, value := range attributes {
+		if !productGridAttributes[key] {
+			textAttributes[key] = value
+		}
+	}
+	attributeString := formatAttributesWithLiquid(textAttributes, parsedData, block.GetID())
+
+	sectionIndent := strings.Repeat("  ", indentLevel)
+	columnIndent := sectionIndent + "  "
+	textIndent := columnIndent + "  "
+	columns := productGridColumns(attributes)
+
+	var sections []string
+	for start := 0; start < len(items); start += columns {
+		var cards []string
+		for i := start; i < start+columns; i++ {
+			// Empty columns keep the cards of the last row as wide as the others
+			if i >= len(items) {
+				cards = append(cards, columnIndent+"<mj-column />")
+				continue
+			}
+
+			// The card sees the whole template data, plus its item
+			cardData := make(map[string]interface{}, len(parsedData)+1)
+			for key, value := range parsedData {
+				cardData[key] = value
+			}
+			cardData[ProductGridItemVariable] = items[i]
+
+			card, err := processLiquidContent(cardTemplate, cardData, fmt.Sprintf("%s[%d]", block.GetID(), i))
+			if err != nil {
+				return "", fmt.Errorf("liquid processing failed for product grid %s: %v", block.GetID(), err)
+			}
+			cards = append(cards, fmt.Sprintf("%s<mj-column>\n%s<mj-text%s>%s</mj-text>\n%s</mj-column>",
+				columnIndent, textIndent, attributeString, card, columnIndent))
+		}
+		sections = append(sections, fmt.Sprintf("%s<mj-section>\n%s\n%s</mj-section>", sectionIndent, strings.Join(cards, "\n"), sectionIndent))
+	}
+
+	return strings.Join(sections, "\n"), nil
+}
@@ -0,0 +1,144 @@
+package notifuse_mjml
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newProductGridEmail returns an email whose body holds a single product grid
+func newProductGridEmail(attributes map[string]interface{}) EmailBlock {
+	gridBase := NewBaseBlock("grid", MJMLComponentProductGrid)
+	gridBase.Attributes = attributes
+	bodyBase := NewBaseBlock("body", MJMLComponentMjBody)
+	bodyBase.Children = []EmailBlock{&MJProductGridBlock{BaseBlock: gridBase}}
+	rootBase := NewBaseBlock("root", MJMLComponentMjml)
+	rootBase.Children = []EmailBlock{&MJBodyBlock{BaseBlock: bodyBase}}
+	return &MJMLBlock{BaseBlock: rootBase}
+}
+
+func productGridTemplateData(t *testing.T, products ...string) string {
+	items := make([]map[string]interface{}, len(products))
+	for i, name := range products {
+		items[i] = map[string]interface{}{"name": name, "price": "$" + strings.Repeat("9", i+1), "url": "https://shop.example.com/" + name}
+	}
+	data, err := json.Marshal(map[string]interface{}{
+		"contact": map[string]interface{}{
+			"email":         "john@example.com",
+			"custom_json_1": map[string]interface{}{"recommended_products": items},
+		},
+	})
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestProductGrid_RendersOneCardPerItem(t *testing.T) {
+	email := newProductGridEmail(map[string]interface{}{
+		"source":       "contact.custom_json_1.recommended_products",
+		"columns":      3,
+		"cardTemplate": `<a href="{{ product.url }}">{{ product.name }}</a> {{ product.price }} for {{ contact.email }}`,
+	})
+	require.NoError(t, ValidateEmailStructure(email))
+
+	mjml, err := ConvertJSONToMJMLWithData(email, productGridTemplateData(t, "lamp", "chair", "desk"))
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, strings.Count(mjml, "<mj-section>"))
+	assert.Equal(t, 3, strings.Count(mjml, "<mj-column>"))
+	assert.Equal(t, 3, strings.Count(mjml, "<mj-text>"))
+	assert.Contains(t, mjml, `<mj-text><a href="https://shop.example.com/lamp">lamp</a> $9 for john@example.com</mj-text>`)
+	assert.Contains(t, mjml, `<mj-text><a href="https://shop.example.com/chair">chair</a> $99 for john@example.com</mj-text>`)
+	assert.Contains(t, mjml, `<mj-text><a href="https://shop.example.com/desk">desk</a> $999 for john@example.com</mj-text>`)
+	assert.NotContains(t, mjml, "product-grid")
+}
+
+func TestProductGrid_Rows(t *testing.T) {
+	email := newProductGridEmail(map[string]interface{}{
+		"source":     "{{ contact.custom_json_1.recommended_products }}",
+		"columns":    "2",
+		"fontSize":   "16px",
+		"paddingTop": "4px",
+	})
+
+	mjml, err := ConvertJSONToMJMLWithData(email, productGridTemplateData(t, "lamp", "chair", "desk"))
+	require.NoError(t, err)
+
+	// The last row is completed with an empty column
+	assert.Equal(t, 2, strings.Count(mjml, "<mj-section>"))
+	assert.Equal(t, 3, strings.Count(mjml, "<mj-column>"))
+	assert.Equal(t, 1, strings.Count(mjml, "<mj-column />"))
+
+	// The default card is used, styled by the other attributes of the grid
+	assert.Equal(t, 3, strings.Count(mjml, `font-size="16px"`))
+	assert.Equal(t, 3, strings.Count(mjml, `padding-top="4px"`))
+	assert.Contains(t, mjml, `<a href="https://shop.example.com/desk">View product</a>`)
+	assert.NotContains(t, mjml, "columns=")
+	assert.NotContains(t, mjml, "source=")
+}
+
+func TestProductGrid_WithoutItems(t *testing.T) {
+	email := newProductGridEmail(map[string]interface{}{"source": "contact.custom_json_1.recommended_products"})
+
+	for _, data := range []string{
+		productGridTemplateData(t),
+		`{"contact":{"custom_json_1":{"recommended_products":"lamp"}}}`,
+		`{"contact":{}}`,
+	} {
+		mjml, err := ConvertJSONToMJMLWithData(email, data)
+		require.NoError(t, err)
+		assert.NotContains(t, mjml, "<mj-section>", data)
+	}
+
+	// Without template data, e.g. in the editor preview
+	assert.NotContains(t, ConvertJSONToMJML(email), "<mj-section>")
+}
+
+func TestProductGrid_EscapesContactValues(t *testing.T) {
+	email := newProductGridEmail(map[string]interface{}{
+		"source":       "contact.custom_json_1.recommended_products",
+		"cardTemplate": "<p>{{ product.name }}</p>",
+	})
+
+	data, err := EscapeContactMergeData(MapOfAny{
+		"contact": map[string]interface{}{
+			"custom_json_1": map[string]interface{}{
+				"recommended_products": []interface{}{map[string]interface{}{"name": "<script>alert(1)</script>"}},
+			},
+		},
+	}, nil)
+	require.NoError(t, err)
+	dataJSON, err := json.Marshal(data)
+	require.NoError(t, err)
+
+	mjml, err := ConvertJSONToMJMLWithData(email, string(dataJSON))
+	require.NoError(t, err)
+	assert.Contains(t, mjml, "<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>")
+}
+
+func TestProductGrid_Unmarshal(t *testing.T) {
+	block, err := UnmarshalEmailBlock([]byte(`{"id":"grid","type":"product-grid","attributes":{"source":"contact.custom_json_1.products"}}`))
+	require.NoError(t, err)
+
+	grid, ok := block.(*MJProductGridBlock)
+	require.True(t, ok)
+	assert.Equal(t, DefaultProductGridColumns, productGridColumns(grid.GetAttributes()))
+	assert.Equal(t, "contact.custom_json_1.products", ProductGridSource(grid))
+
+	assert.True(t, CanDropCheck(MJMLComponentProductGrid, MJMLComponentMjBody))
+	assert.True(t, CanDropCheck(MJMLComponentProductGrid, MJMLComponentMjWrapper))
+	assert.False(t, CanDropCheck(MJMLComponentProductGrid, MJMLComponentMjColumn))
+	assert.True(t, IsLeafComponent(MJMLComponentProductGrid))
+}
+
+func TestProductGridColumns(t *testing.T) {
+	assert.Equal(t, 1, productGridColumns(map[string]interface{}{"columns": 1}))
+	assert.Equal(t, 4, productGridColumns(map[string]interface{}{"columns": float64(4)}))
+	assert.Equal(t, 2, productGridColumns(map[string]interface{}{"columns": "2"}))
+	assert.Equal(t, DefaultProductGridColumns, productGridColumns(map[string]interface{}{}))
+	assert.Equal(t, DefaultProductGridColumns, productGridColumns(map[string]interface{}{"columns": 0}))
+	assert.Equal(t, DefaultProductGridColumns, productGridColumns(map[string]interface{}{"columns": 12}))
+	assert.Equal(t, DefaultProductGridColumns, productGridColumns(map[string]interface{}{"columns": "wide"}))
+}
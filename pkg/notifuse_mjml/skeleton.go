@@ -0,0 +1,110 @@
+package notifuse_mjml
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	mjmlgo "github.com/Boostport/mjml-go"
+)
+
+// CompileSkeleton compiles a visual editor tree to HTML once for all recipients, leaving its Liquid
+// markup unrendered, so that each recipient only costs a Liquid render with RenderSkeleton.
+// It returns false when the tree has Liquid markup that CompileTemplate escapes once rendered or
+// does not render at all, such a tree must be compiled per recipient with CompileTemplate.
+func CompileSkeleton(tree EmailBlock) (skeleton string, ok bool, err error) {
+	if !hasOnlyRawLiquid(tree) {
+		return "", false, nil
+	}
+
+	// Without template data the Liquid markup is kept as is
+	mjmlString := convertBlockToMJMLWithParsedData(tree, 0, "", nil)
+
+	htmlResult, err := mjmlgo.ToHTML(context.Background(), mjmlString)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to compile skeleton: %w", err)
+	}
+
+	return decodeHTMLEntitiesInURLAttributes(htmlResult), true, nil
+}
+
+// RenderSkeleton renders a skeleton compiled by CompileSkeleton with the template data of a recipient
+// and applies its link tracking, as CompileTemplate does for the email channel
+func RenderSkeleton(skeleton string, templateData MapOfAny, rawMergeFields []string, trackingSettings TrackingSettings) (string, error) {
+	data := make(map[string]interface{})
+	if len(templateData) > 0 {
+		escaped, err := EscapeContactMergeData(templateData, rawMergeFields)
+		if err != nil {
+			return "", fmt.Errorf("failed to escape template data: %w", err)
+		}
+
+		// Template data goes through JSON as in CompileTemplate, so that values render the same
+		jsonData, err := json.Marshal(escaped)
+		if err != nil {
+			return "", fmt.Errorf("failed to marshal template data: %w", err)
+		}
+		data, err = parseTemplateDataString(string(jsonData))
+		if err != nil {
+			return "", err
+		}
+	}
+
+	// The skeleton is a whole HTML document, the size limit applies to its Liquid markup only
+	engine := NewSecureLiquidEngineWithOptions(DefaultRenderTimeout, len(skeleton)+DefaultMaxTemplateSize)
+	htmlResult, err := engine.RenderWithTimeout(cleanLiquidTemplate(skeleton), data)
+	if err != nil {
+		return "", fmt.Errorf("liquid rendering error in skeleton: %w", err)
+	}
+
+	return TrackLinks(htmlResult, trackingSettings)
+}
+
+// hasOnlyRawLiquid reports whether the Liquid markup of a tree is only in the content of blocks
+// rendered without escaping (mj-text, mj-button, mj-raw) and in URL attributes.
+// Conditional blocks and product grids are expanded per recipient, a single skeleton can't hold
+// every expansion, e.g. a product grid renders nothing for a recipient without products.
+func hasOnlyRawLiquid(block EmailBlock) bool {
+	if block == nil || block.GetType() == "" {
+		return true
+	}
+	if block.GetType() == MJMLComponentConditional || block.GetType() == MJMLComponentProductGrid {
+		return false
+	}
+
+	for key, value := range block.GetAttributes() {
+		var str string
+		switch v := value.(type) {
+		case string:
+			str = v
+		case *string:
+			if v != nil {
+				str = *v
+			}
+		default:
+			str = fmt.Sprintf("%v", v)
+		}
+		if hasLiquidMarkup(str) && !isURLAttribute(camelToKebab(key)) {
+			return false
+		}
+	}
+
+	children := block.GetChildren()
+	if len(children) == 0 {
+		blockType := block.GetType()
+		rawContent := blockType == MJMLComponentMjText || blockType == MJMLComponentMjButton || blockType == MJMLComponentMjRaw
+		return rawContent || !hasLiquidMarkup(getBlockContent(block))
+	}
+
+	for _, child := range children {
+		if !hasOnlyRawLiquid(child) {
+			return false
+		}
+	}
+	return true
+}
+
+// hasLiquidMarkup reports whether content contains Liquid output or tag markup
+func hasLiquidMarkup(content string) bool {
+	return strings.Contains(content, "{{") || strings.Contains(content, "{%")
+}
@@ -0,0 +1,143 @@
+package notifuse_mjml
+
+import (
+	"strings"
+	"testing"
+)
+
+// skeletonTestBlock builds a block of the given type with its content, attributes and children
+func skeletonTestBlock(id string, blockType MJMLComponentType, content string, attributes map[string]interface{}, children ...EmailBlock) EmailBlock {
+	base := NewBaseBlock(id, blockType)
+	if content != "" {
+		base.Content = &content
+	}
+	if attributes != nil {
+		base.Attributes = attributes
+	}
+	base.Children = children
+	return createTypedBlock(base)
+}
+
+// skeletonTestTree wraps the leaf blocks in an mjml, body, section and column tree
+func skeletonTestTree(head EmailBlock, leaves ...EmailBlock) EmailBlock {
+	column := skeletonTestBlock("col1", MJMLComponentMjColumn, "", nil, leaves...)
+	section := skeletonTestBlock("sec1", MJMLComponentMjSection, "", nil, column)
+	body := skeletonTestBlock("body1", MJMLComponentMjBody, "", nil, section)
+	if head == nil {
+		return skeletonTestBlock("root", MJMLComponentMjml, "", nil, body)
+	}
+	return skeletonTestBlock("root", MJMLComponentMjml, "", nil, head, body)
+}
+
+func TestCompileSkeleton_RendersLikeCompileTemplate(t *testing.T) {
+	tree := skeletonTestTree(nil,
+		skeletonTestBlock("txt1", MJMLComponentMjText, `<p>Hello {{ contact.first_name }}{% if contact.last_name %} {{ contact.last_name }}{% endif %}</p>`, nil),
+		skeletonTestBlock("btn1", MJMLComponentMjButton, "Shop now", map[string]interface{}{"href": "https://example.com/shop?ref={{ contact.email }}&src=email"}),
+	)
+
+	skeleton, ok, err := CompileSkeleton(tree)
+	if err != nil {
+		t.Fatalf("CompileSkeleton() error = %v", err)
+	}
+	if !ok {
+		t.Fatal("CompileSkeleton() ok = false, want true")
+	}
+
+	recipients := []MapOfAny{
+		{"contact": map[string]interface{}{"email": "jane@example.com", "first_name": "Jane", "last_name": "Doe"}},
+		{"contact": map[string]interface{}{"email": "tom@example.com", "first_name": "Tom & <b>Jerry</b>"}},
+		{},
+	}
+	for _, data := range recipients {
+		expected, err := CompileTemplate(CompileTemplateRequest{
+			WorkspaceID:      "workspace-1",
+			MessageID:        "message-1",
+			VisualEditorTree: tree,
+			TemplateData:     data,
+		})
+		if err != nil || !expected.Success {
+			t.Fatalf("CompileTemplate() failed: %v", err)
+		}
+
+		rendered, err := RenderSkeleton(skeleton, data, nil, TrackingSettings{})
+		if err != nil {
+			t.Fatalf("RenderSkeleton() error = %v", err)
+		}
+		if rendered != *expected.HTML {
+			t.Errorf("RenderSkeleton() for %v differs from CompileTemplate()\ngot:  %s\nwant: %s", data, rendered, *expected.HTML)
+		}
+	}
+}
+
+func TestCompileSkeleton_NotCacheable(t *testing.T) {
+	tests := []struct {
+		name string
+		tree EmailBlock
+	}{
+		{
+			name: "liquid in an escaped title",
+			tree: skeletonTestTree(
+				skeletonTestBlock("head1", MJMLComponentMjHead, "", nil,
+					skeletonTestBlock("title1", MJMLComponentMjTitle, "News for {{ contact.first_name }}", nil)),
+				skeletonTestBlock("txt1", MJMLComponentMjText, "Hello", nil),
+			),
+		},
+		{
+			name: "liquid in an alt attribute",
+			tree: skeletonTestTree(nil,
+				skeletonTestBlock("img1", MJMLComponentMjImage, "", map[string]interface{}{"src": "https://example.com/a.png", "alt": "For {{ contact.first_name }}"}),
+			),
+		},
+		{
+			name: "liquid in an attribute that is not rendered",
+			tree: skeletonTestTree(nil,
+				skeletonTestBlock("txt1", MJMLComponentMjText, "Hello", map[string]interface{}{"cssClass": "{{ contact.first_name }}"}),
+			),
+		},
+		{
+			name: "conditional block",
+			tree: skeletonTestTree(nil,
+				skeletonTestBlock("vip", MJMLComponentConditional, "", map[string]interface{}{"condition": "contact.custom_number_1 > 100"},
+					skeletonTestBlock("txt1", MJMLComponentMjText, "Your VIP offer", nil)),
+			),
+		},
+		{
+			name: "product grid",
+			tree: skeletonTestBlock("root", MJMLComponentMjml, "", nil,
+				skeletonTestBlock("body1", MJMLComponentMjBody, "", nil,
+					skeletonTestBlock("grid", MJMLComponentProductGrid, "", map[string]interface{}{"source": "contact.custom_json_1.recommended_products"}))),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, ok, err := CompileSkeleton(tt.tree)
+			if err != nil {
+				t.Fatalf("CompileSkeleton() error = %v", err)
+			}
+			if ok {
+				t.Error("CompileSkeleton() ok = true, want false")
+			}
+		})
+	}
+}
+
+func TestRenderSkeleton_AppliesTracking(t *testing.T) {
+	skeleton := `<html><body><a href="https://example.com">Hi {{ contact.first_name }}</a></body></html>`
+
+	html, err := RenderSkeleton(skeleton, MapOfAny{"contact": map[string]interface{}{"first_name": "Jane"}}, nil, TrackingSettings{
+		Endpoint:       "https://api.example.com",
+		EnableTracking: true,
+		WorkspaceID:    "workspace-1",
+		MessageID:      "message-1",
+	})
+	if err != nil {
+		t.Fatalf("RenderSkeleton() error = %v", err)
+	}
+
+	for _, want := range []string{"Hi Jane", "https://api.example.com/visit?mid=message-1", "https://api.example.com/opens?mid=message-1"} {
+		if !strings.Contains(html, want) {
+			t.Errorf("RenderSkeleton() = %s, want it to contain %s", html, want)
+		}
+	}
+}
@@ -17,15 +17,54 @@ import (
 type MapOfAny map[string]any
 
 type TrackingSettings struct {
-	EnableTracking bool   `json:"enable_tracking"`
-	Endpoint       string `json:"endpoint,omitempty"`
-	UTMSource      string `json:"utm_source,omitempty"`
-	UTMMedium      string `json:"utm_medium,omitempty"`
-	UTMCampaign    string `json:"utm_campaign,omitempty"`
-	UTMContent     string `json:"utm_content,omitempty"`
-	UTMTerm        string `json:"utm_term,omitempty"`
-	WorkspaceID    string `json:"workspace_id,omitempty"`
-	MessageID      string `json:"message_id,omitempty"`
+	EnableTracking bool `json:"enable_tracking"`
+	// DisableOpenTracking and DisableClickTracking turn off one kind of tracking
+	// while EnableTracking is on
+	DisableOpenTracking  bool   `json:"disable_open_tracking,omitempty"`
+	DisableClickTracking bool   `json:"disable_click_tracking,omitempty"`
+	Endpoint             string `json:"endpoint,omitempty"`
+	TrackingEndpoint     string `json:"tracking_endpoint,omitempty"` // Replaces Endpoint in the open pixel and click redirection URLs when set
+	UTMSource            string `json:"utm_source,omitempty"`
+	UTMMedium            string `json:"utm_medium,omitempty"`
+	UTMCampaign          string `json:"utm_campaign,omitempty"`
+	UTMContent           string `json:"utm_content,omitempty"`
+	UTMTerm              string `json:"utm_term,omitempty"`
+	WorkspaceID          string `json:"workspace_id,omitempty"`
+	MessageID            string `json:"message_id,omitempty"`
+	// LinkShortener, when set, replaces click-tracked links with short links
+	LinkShortener LinkShortener `json:"-"`
+}
+
+// LinkShortener maps a tracked link to a short link that records the click
+// and redirects to destinationURL
+type LinkShortener interface {
+	ShortenLink(workspaceID, messageID, destinationURL string, sentTimestamp int64) (string, error)
+}
+
+// OpenTrackingEnabled reports whether the open tracking pixel should be injected
+func (t TrackingSettings) OpenTrackingEnabled() bool {
+	return t.EnableTracking && !t.DisableOpenTracking
+}
+
+// ClickTrackingEnabled reports whether links should be redirected through the tracking endpoint
+func (t TrackingSettings) ClickTrackingEnabled() bool {
+	return t.EnableTracking && !t.DisableClickTracking
+}
+
+// OpenClickEndpoint returns the base URL of the open pixel and click redirection URLs
+func (t TrackingSettings) OpenClickEndpoint() string {
+	if t.TrackingEndpoint != "" {
+		return t.TrackingEndpoint
+	}
+	return t.Endpoint
+}
+
+// SetTracking enables open and click tracking independently.
+// The disable flags are only set when the other kind of tracking stays on.
+func (t *TrackingSettings) SetTracking(open, click bool) {
+	t.EnableTracking = open || click
+	t.DisableOpenTracking = click && !open
+	t.DisableClickTracking = open && !click
 }
 
 // Value implements the driver.Valuer interface for database storage
@@ -92,6 +131,29 @@ func (t *TrackingSettings) GetTrackingURL(sourceURL string) string {
 		return sourceURL
 	}
 
+	if _, err := url.Parse(sourceURL); err != nil {
+		return sourceURL
+	}
+	destinationURL := t.DestinationURL(sourceURL)
+
+	if !t.ClickTrackingEnabled() {
+		return destinationURL
+	}
+
+	// parse endpoint and add url to the query params
+	parsedEndpoint, err := url.Parse(t.Endpoint)
+	if err != nil {
+		return sourceURL
+	}
+	endpointParams := parsedEndpoint.Query()
+	endpointParams.Add("url", destinationURL) // Use the URL with UTM parameters
+	parsedEndpoint.RawQuery = endpointParams.Encode()
+
+	return parsedEndpoint.String()
+}
+
+// DestinationURL returns sourceURL with the UTM parameters added, unless it already has some
+func (t *TrackingSettings) DestinationURL(sourceURL string) string {
 	// parse sourceURL to get the domain
 	parsedURL, err := url.Parse(sourceURL)
 	if err != nil {
@@ -130,20 +192,7 @@ func (t *TrackingSettings) GetTrackingURL(sourceURL string) string {
 		parsedURL.RawQuery = queryParams.Encode()
 	}
 
-	if !t.EnableTracking {
-		return parsedURL.String()
-	}
-
-	// parse endpoint and add url to the query params
-	parsedEndpoint, err := url.Parse(t.Endpoint)
-	if err != nil {
-		return sourceURL
-	}
-	endpointParams := parsedEndpoint.Query()
-	endpointParams.Add("url", parsedURL.String()) // Use the URL with UTM parameters
-	parsedEndpoint.RawQuery = endpointParams.Encode()
-
-	return parsedEndpoint.String()
+	return parsedURL.String()
 }
 
 // CompileTemplateRequest represents the request for compiling a template
@@ -154,6 +203,8 @@ type CompileTemplateRequest struct {
 	TemplateData     MapOfAny         `json:"test_data,omitempty"`
 	TrackingSettings TrackingSettings `json:"tracking_settings,omitempty"`
 	Channel          string           `json:"channel,omitempty"` // "email" or "web" - filters blocks by visibility
+	// RawMergeFields lists the contact fields rendered as trusted HTML, all other contact values are escaped
+	RawMergeFields []string `json:"raw_merge_fields,omitempty"`
 }
 
 // UnmarshalJSON implements custom JSON unmarshaling for CompileTemplateRequest
@@ -241,7 +292,19 @@ func CompileTemplate(req CompileTemplateRequest) (resp *CompileTemplateResponse,
 	// Note: Web channel doesn't use template data (no contact personalization)
 	var templateDataStr string
 	if len(req.TemplateData) > 0 && req.Channel != "web" {
-		jsonDataBytes, err := json.Marshal(req.TemplateData)
+		templateData, err := EscapeContactMergeData(req.TemplateData, req.RawMergeFields)
+		if err != nil {
+			return &CompileTemplateResponse{
+				Success: false,
+				MJML:    nil,
+				HTML:    nil,
+				Error: &mjmlgo.Error{
+					Message: fmt.Sprintf("failed to escape template data: %v", err),
+				},
+			}, nil
+		}
+
+		jsonDataBytes, err := json.Marshal(templateData)
 		if err != nil {
 			return &CompileTemplateResponse{
 				Success: false,
@@ -381,21 +444,30 @@ func TrackLinks(htmlString string, trackingSettings TrackingSettings) (updatedHT
 		// Apply tracking to the URL
 		trackedURL := trackingSettings.GetTrackingURL(originalURL)
 
-		if trackingSettings.EnableTracking {
+		if trackingSettings.ClickTrackingEnabled() {
 			// Use current Unix timestamp (seconds) for bot detection
 			sentTimestamp := time.Now().Unix()
-			trackedURL = GenerateEmailRedirectionEndpoint(trackingSettings.WorkspaceID, trackingSettings.MessageID, trackingSettings.Endpoint, originalURL, sentTimestamp)
+			trackedURL = GenerateEmailRedirectionEndpoint(trackingSettings.WorkspaceID, trackingSettings.MessageID, trackingSettings.OpenClickEndpoint(), originalURL, sentTimestamp)
+
+			// Short links keep the UTM parameters in the stored destination;
+			// fall back to the full redirection URL if shortening fails
+			if trackingSettings.LinkShortener != nil {
+				shortURL, err := trackingSettings.LinkShortener.ShortenLink(trackingSettings.WorkspaceID, trackingSettings.MessageID, trackingSettings.DestinationURL(originalURL), sentTimestamp)
+				if err == nil {
+					trackedURL = shortURL
+				}
+			}
 		}
 
 		// Return the updated tag
 		return beforeURL + trackedURL + afterURL
 	})
 
-	if trackingSettings.EnableTracking {
+	if trackingSettings.OpenTrackingEnabled() {
 		// Insert tracking pixel at the end of the body tag
 		// Use current Unix timestamp (seconds) for bot detection
 		sentTimestamp := time.Now().Unix()
-		trackingPixel := GenerateHTMLOpenTrackingPixel(trackingSettings.WorkspaceID, trackingSettings.MessageID, trackingSettings.Endpoint, sentTimestamp)
+		trackingPixel := GenerateHTMLOpenTrackingPixel(trackingSettings.WorkspaceID, trackingSettings.MessageID, trackingSettings.OpenClickEndpoint(), sentTimestamp)
 
 		// Find the closing </body> tag and insert the pixel before it
 		bodyCloseRegex := regexp.MustCompile(`(?i)(<\/body>)`)
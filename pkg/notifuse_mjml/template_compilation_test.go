@@ -2,6 +2,7 @@ package notifuse_mjml
 
 import (
 	"encoding/json"
+	"errors"
 	"strings"
 	"testing"
 )
@@ -76,6 +77,43 @@ func TestTrackLinks(t *testing.T) {
 			},
 			shouldError: false,
 		},
+		{
+			name: "Click tracking only does not inject the open pixel",
+			htmlInput: `<html><body><a href="https://example.com">Link</a></body></html>`,
+			trackingSettings: TrackingSettings{
+				EnableTracking:      true,
+				DisableOpenTracking: true,
+				Endpoint:            "https://track.example.com",
+				WorkspaceID:         "test-workspace",
+				MessageID:           "test-message",
+			},
+			expectedContains: []string{
+				"https://track.example.com/visit?mid=test-message&wid=test-workspace&ts=",
+			},
+			expectedNotContains: []string{
+				"https://track.example.com/opens?",
+			},
+			shouldError: false,
+		},
+		{
+			name: "Open tracking only keeps links untouched",
+			htmlInput: `<html><body><a href="https://example.com">Link</a></body></html>`,
+			trackingSettings: TrackingSettings{
+				EnableTracking:       true,
+				DisableClickTracking: true,
+				Endpoint:             "https://track.example.com",
+				WorkspaceID:          "test-workspace",
+				MessageID:            "test-message",
+			},
+			expectedContains: []string{
+				`<a href="https://example.com">`,
+				`<img src="https://track.example.com/opens?mid=test-message&wid=test-workspace&ts=`,
+			},
+			expectedNotContains: []string{
+				"/visit?",
+			},
+			shouldError: false,
+		},
 		{
 			name: "Skip mailto and tel links with tracking disabled",
 			htmlInput: `<a href="mailto:test@example.com">Email</a>
@@ -272,6 +310,63 @@ func TestTrackLinks(t *testing.T) {
 	}
 }
 
+func TestTrackingSettings_SetTracking(t *testing.T) {
+	tests := []struct {
+		open, click bool
+	}{
+		{open: true, click: true},
+		{open: false, click: true},
+		{open: true, click: false},
+		{open: false, click: false},
+	}
+
+	for _, tt := range tests {
+		settings := TrackingSettings{}
+		settings.SetTracking(tt.open, tt.click)
+
+		if settings.OpenTrackingEnabled() != tt.open {
+			t.Errorf("SetTracking(%v, %v): OpenTrackingEnabled() = %v", tt.open, tt.click, settings.OpenTrackingEnabled())
+		}
+		if settings.ClickTrackingEnabled() != tt.click {
+			t.Errorf("SetTracking(%v, %v): ClickTrackingEnabled() = %v", tt.open, tt.click, settings.ClickTrackingEnabled())
+		}
+		if settings.EnableTracking != (tt.open || tt.click) {
+			t.Errorf("SetTracking(%v, %v): EnableTracking = %v", tt.open, tt.click, settings.EnableTracking)
+		}
+	}
+}
+
+func TestTrackLinksWithTrackingEndpoint(t *testing.T) {
+	html := `<html><body><a href="https://example.com/offer">Offer</a></body></html>`
+	trackingSettings := TrackingSettings{
+		EnableTracking:   true,
+		Endpoint:         "https://api.example.com",
+		TrackingEndpoint: "https://track.brand.com",
+		WorkspaceID:      "test-workspace",
+		MessageID:        "test-message",
+	}
+
+	result, err := TrackLinks(html, trackingSettings)
+	if err != nil {
+		t.Fatalf("TrackLinks returned an error: %v", err)
+	}
+
+	if !strings.Contains(result, `href="https://track.brand.com/visit?mid=test-message`) {
+		t.Errorf("Expected the click redirection on the tracking endpoint, got %s", result)
+	}
+	if !strings.Contains(result, `<img src="https://track.brand.com/opens?mid=test-message`) {
+		t.Errorf("Expected the open pixel on the tracking endpoint, got %s", result)
+	}
+	if strings.Contains(result, "api.example.com") {
+		t.Errorf("Expected no tracking URL on the API endpoint, got %s", result)
+	}
+
+	trackingSettings.TrackingEndpoint = ""
+	if trackingSettings.OpenClickEndpoint() != "https://api.example.com" {
+		t.Errorf("Expected OpenClickEndpoint to fall back to Endpoint, got %s", trackingSettings.OpenClickEndpoint())
+	}
+}
+
 func TestTrackLinksInvalidHTML(t *testing.T) {
 	// Test with malformed HTML - should still work with regex approach
 	invalidHTML := `<a href="https://example.com">Link without closing tag`
@@ -572,6 +667,82 @@ func TestTrackingPixelWithoutBodyTag(t *testing.T) {
 	}
 }
 
+// stubLinkShortener records shortened destinations and returns a fixed short URL
+type stubLinkShortener struct {
+	destinations []string
+	err          error
+}
+
+func (s *stubLinkShortener) ShortenLink(workspaceID, messageID, destinationURL string, sentTimestamp int64) (string, error) {
+	if s.err != nil {
+		return "", s.err
+	}
+	s.destinations = append(s.destinations, destinationURL)
+	return "https://go.example.com/s/" + workspaceID + "/abc12345", nil
+}
+
+func TestTrackLinksWithLinkShortener(t *testing.T) {
+	htmlString := `<a href="https://example.com/page?ref=1">Link</a><a href="mailto:a@example.com">Mail</a>`
+
+	shortener := &stubLinkShortener{}
+	trackingSettings := TrackingSettings{
+		EnableTracking: true,
+		Endpoint:       "https://track.example.com",
+		UTMSource:      "newsletter",
+		WorkspaceID:    "ws1",
+		MessageID:      "msg1",
+		LinkShortener:  shortener,
+	}
+
+	result, err := TrackLinks(htmlString, trackingSettings)
+	if err != nil {
+		t.Fatalf("TrackLinks failed: %v", err)
+	}
+
+	if !strings.Contains(result, `href="https://go.example.com/s/ws1/abc12345"`) {
+		t.Errorf("Expected the tracked link to be shortened. Result: %s", result)
+	}
+	if !strings.Contains(result, `href="mailto:a@example.com"`) {
+		t.Errorf("Expected mailto link to be left untouched. Result: %s", result)
+	}
+
+	// The stored destination keeps the original parameters and the UTM parameters
+	if len(shortener.destinations) != 1 || shortener.destinations[0] != "https://example.com/page?ref=1&utm_source=newsletter" {
+		t.Errorf("Unexpected shortened destinations: %v", shortener.destinations)
+	}
+
+	// Click tracking disabled: links are not shortened
+	shortener.destinations = nil
+	trackingSettings.DisableClickTracking = true
+	result, err = TrackLinks(htmlString, trackingSettings)
+	if err != nil {
+		t.Fatalf("TrackLinks failed: %v", err)
+	}
+	if len(shortener.destinations) != 0 || strings.Contains(result, "go.example.com") {
+		t.Errorf("Expected no short link without click tracking. Result: %s", result)
+	}
+}
+
+func TestTrackLinksWithFailingLinkShortener(t *testing.T) {
+	trackingSettings := TrackingSettings{
+		EnableTracking: true,
+		Endpoint:       "https://track.example.com",
+		WorkspaceID:    "ws1",
+		MessageID:      "msg1",
+		LinkShortener:  &stubLinkShortener{err: errors.New("storage unavailable")},
+	}
+
+	result, err := TrackLinks(`<a href="https://example.com">Link</a>`, trackingSettings)
+	if err != nil {
+		t.Fatalf("TrackLinks failed: %v", err)
+	}
+
+	// Falls back to the full redirection URL
+	if !strings.Contains(result, `href="https://track.example.com/visit?mid=msg1&wid=ws1&ts=`) {
+		t.Errorf("Expected the full tracking URL as fallback. Result: %s", result)
+	}
+}
+
 func TestIsNonTrackableURL(t *testing.T) {
 	tests := []struct {
 		name     string
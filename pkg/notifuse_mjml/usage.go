@@ -0,0 +1,22 @@
+package notifuse_mjml
+
+// UnknownBlockType is the key under which CountBlockTypes tallies the blocks of an unknown type
+const UnknownBlockType = "unknown"
+
+// CountBlockTypes adds the number of blocks of each component type of a tree to counts, e.g. counts["mj-button"].
+// Only the component types are read: blocks of an unknown type, whose type is free-form, are tallied under
+// UnknownBlockType so that nothing else from the template ends up in counts.
+func CountBlockTypes(tree EmailBlock, counts map[string]int) {
+	if tree == nil {
+		return
+	}
+	// Unmarshaled blocks of an unknown type are left as a BaseBlock
+	if _, unknown := tree.(*BaseBlock); unknown {
+		counts[UnknownBlockType]++
+	} else {
+		counts[string(tree.GetType())]++
+	}
+	for _, child := range tree.GetChildren() {
+		CountBlockTypes(child, counts)
+	}
+}
@@ -0,0 +1,49 @@
+package notifuse_mjml
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCountBlockTypes(t *testing.T) {
+	tree, err := UnmarshalEmailBlock([]byte(`{
+		"id": "root", "type": "mjml", "children": [
+			{"id": "body", "type": "mj-body", "children": [
+				{"id": "s1", "type": "mj-section", "children": [
+					{"id": "c1", "type": "mj-column", "children": [
+						{"id": "t1", "type": "mj-text", "content": "Hello {{ contact.first_name }}"},
+						{"id": "b1", "type": "mj-button", "attributes": {"href": "https://example.com/offer"}, "content": "Shop"}
+					]},
+					{"id": "c2", "type": "mj-column", "children": [
+						{"id": "b2", "type": "mj-button", "content": "Unsubscribe"}
+					]}
+				]},
+				{"id": "grid", "type": "product-grid", "attributes": {"items": "products"}},
+				{"id": "custom", "type": "jane@example.com"}
+			]}
+		]
+	}`))
+	require.NoError(t, err)
+
+	counts := map[string]int{}
+	CountBlockTypes(tree, counts)
+
+	assert.Equal(t, map[string]int{
+		"mjml":           1,
+		"mj-body":        1,
+		"mj-section":     1,
+		"mj-column":      2,
+		"mj-text":        1,
+		"mj-button":      2,
+		"product-grid":   1,
+		UnknownBlockType: 1,
+	}, counts)
+
+	// Counts add up across trees and nothing is counted for a missing tree
+	CountBlockTypes(tree, counts)
+	CountBlockTypes(nil, counts)
+	assert.Equal(t, 4, counts["mj-button"])
+	assert.Equal(t, 2, counts[UnknownBlockType])
+}
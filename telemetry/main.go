@@ -29,6 +29,15 @@ type TelemetryMetrics struct {
 	BlogPostsCount     int    `json:"blog_posts_count"`
 	APIEndpoint        string `json:"api_endpoint"`
 
+	// Send performance over the last 24 hours
+	MessagesSentLast24h int     `json:"messages_sent_last_24h"`
+	AvgSendLatencyMs    float64 `json:"avg_send_latency_ms"`
+	BounceRate          float64 `json:"bounce_rate"`
+	ComplaintRate       float64 `json:"complaint_rate"`
+
+	// Number of blocks of each component type across the templates, e.g. "mj-button"
+	BlockUsage map[string]int `json:"block_usage,omitempty"`
+
 	// Integration flags - boolean for each email provider
 	Mailgun   bool `json:"mailgun"`
 	AmazonSES bool `json:"amazonses"`
@@ -36,6 +45,7 @@ type TelemetryMetrics struct {
 	SparkPost bool `json:"sparkpost"`
 	Postmark  bool `json:"postmark"`
 	SMTP      bool `json:"smtp"`
+	SendGrid  bool `json:"sendgrid"`
 	S3        bool `json:"s3"`
 }
 
@@ -58,6 +68,15 @@ type LogEntry struct {
 	Source             string    `json:"source"`
 	EventType          string    `json:"event_type"`
 
+	// Send performance over the last 24 hours
+	MessagesSentLast24h int     `json:"messages_sent_last_24h"`
+	AvgSendLatencyMs    float64 `json:"avg_send_latency_ms"`
+	BounceRate          float64 `json:"bounce_rate"`
+	ComplaintRate       float64 `json:"complaint_rate"`
+
+	// Number of blocks of each component type across the templates, e.g. "mj-button"
+	BlockUsage map[string]int `json:"block_usage,omitempty"`
+
 	// Integration flags - boolean for each email provider
 	Mailgun   bool `json:"mailgun"`
 	AmazonSES bool `json:"amazonses"`
@@ -65,6 +84,7 @@ type LogEntry struct {
 	SparkPost bool `json:"sparkpost"`
 	Postmark  bool `json:"postmark"`
 	SMTP      bool `json:"smtp"`
+	SendGrid  bool `json:"sendgrid"`
 	S3        bool `json:"s3"`
 }
 
@@ -156,7 +176,17 @@ func receiveTelemetry(w http.ResponseWriter, r *http.Request) {
 		SparkPost:          metrics.SparkPost,
 		Postmark:           metrics.Postmark,
 		SMTP:               metrics.SMTP,
+		SendGrid:           metrics.SendGrid,
 		S3:                 metrics.S3,
+
+		// Send performance over the last 24 hours
+		MessagesSentLast24h: metrics.MessagesSentLast24h,
+		AvgSendLatencyMs:    metrics.AvgSendLatencyMs,
+		BounceRate:          metrics.BounceRate,
+		ComplaintRate:       metrics.ComplaintRate,
+
+		// Number of blocks of each component type across the templates
+		BlockUsage: metrics.BlockUsage,
 	}
 
 	// Log to Google Cloud Logging with structured data
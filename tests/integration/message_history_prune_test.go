@@ -0,0 +1,127 @@
+package integration
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Notifuse/notifuse/config"
+	"github.com/Notifuse/notifuse/internal/app"
+	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/tests/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestMessageHistoryPrune runs a prune_message_history task and checks that the old messages are
+// deleted while the old messages with a retained status and the recent ones survive
+func TestMessageHistoryPrune(t *testing.T) {
+	testutil.SkipIfShort(t)
+	testutil.SetupTestEnvironment()
+	defer testutil.CleanupTestEnvironment()
+
+	suite := testutil.NewIntegrationTestSuite(t, func(cfg *config.Config) testutil.AppInterface {
+		return app.NewApp(cfg)
+	})
+	defer func() { suite.Cleanup() }()
+
+	client := suite.APIClient
+	factory := suite.DataFactory
+
+	user, err := factory.CreateUser()
+	require.NoError(t, err)
+	workspace, err := factory.CreateWorkspace()
+	require.NoError(t, err)
+	err = factory.AddUserToWorkspace(user.ID, workspace.ID, "owner")
+	require.NoError(t, err)
+	err = client.Login(user.Email, "password")
+	require.NoError(t, err)
+	client.SetWorkspaceID(workspace.ID)
+
+	// sentOn backdates a message, created and sent at the given time
+	sentOn := func(at time.Time) testutil.MessageHistoryOption {
+		return func(m *domain.MessageHistory) {
+			m.CreatedAt = at
+			m.UpdatedAt = at
+			m.SentAt = at
+		}
+	}
+	old := time.Now().UTC().AddDate(-1, 0, 0)
+
+	// Old delivered messages are pruned
+	oldDelivered := make([]string, 0, 3)
+	for i := 0; i < 3; i++ {
+		message, err := factory.CreateMessageHistory(workspace.ID, sentOn(old), testutil.WithMessageDelivered(true))
+		require.NoError(t, err)
+		oldDelivered = append(oldDelivered, message.ID)
+	}
+
+	// An old complaint is retained for compliance
+	oldComplaint, err := factory.CreateMessageHistory(workspace.ID, sentOn(old), func(m *domain.MessageHistory) {
+		m.DeliveredAt = &old
+		m.ComplainedAt = &old
+	})
+	require.NoError(t, err)
+
+	// A recent message is newer than the cutoff
+	recent, err := factory.CreateMessageHistory(workspace.ID, testutil.WithMessageDelivered(true))
+	require.NoError(t, err)
+
+	// A batch size of 2 prunes the old messages over several batches
+	resp, err := client.CreateTask(map[string]interface{}{
+		"workspace_id": workspace.ID,
+		"type":         "prune_message_history",
+		"state": map[string]interface{}{
+			"prune_message_history": map[string]interface{}{
+				"cutoff":        time.Now().UTC().AddDate(0, -6, 0).Format(time.RFC3339),
+				"keep_statuses": []string{"complained"},
+				"batch_size":    2,
+			},
+		},
+	})
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var created struct {
+		Task domain.Task `json:"task"`
+	}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&created))
+
+	execResp, err := client.ExecuteTask(map[string]interface{}{
+		"workspace_id": workspace.ID,
+		"id":           created.Task.ID,
+	})
+	require.NoError(t, err)
+	defer func() { _ = execResp.Body.Close() }()
+	require.Equal(t, http.StatusOK, execResp.StatusCode)
+
+	db, err := factory.GetWorkspaceDB(workspace.ID)
+	require.NoError(t, err)
+	exists := func(id string) bool {
+		var count int
+		require.NoError(t, db.QueryRow(`SELECT COUNT(*) FROM message_history WHERE id = $1`, id).Scan(&count))
+		return count == 1
+	}
+
+	for _, id := range oldDelivered {
+		assert.False(t, exists(id), "old delivered message should be pruned")
+	}
+	assert.True(t, exists(oldComplaint.ID), "old complaint should be retained")
+	assert.True(t, exists(recent.ID), "recent message should be kept")
+
+	getResp, err := client.GetTask(workspace.ID, created.Task.ID)
+	require.NoError(t, err)
+	defer func() { _ = getResp.Body.Close() }()
+	require.Equal(t, http.StatusOK, getResp.StatusCode)
+
+	var fetched struct {
+		Task domain.Task `json:"task"`
+	}
+	require.NoError(t, json.NewDecoder(getResp.Body).Decode(&fetched))
+	assert.Equal(t, domain.TaskStatusCompleted, fetched.Task.Status)
+	require.NotNil(t, fetched.Task.State)
+	require.NotNil(t, fetched.Task.State.PruneMessageHistory)
+	assert.Equal(t, int64(3), fetched.Task.State.PruneMessageHistory.DeletedCount)
+}
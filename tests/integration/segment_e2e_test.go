@@ -1,15 +1,18 @@
 package integration
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/Notifuse/notifuse/config"
 	"github.com/Notifuse/notifuse/internal/app"
 	"github.com/Notifuse/notifuse/internal/domain"
+	"github.com/Notifuse/notifuse/internal/repository"
 	"github.com/Notifuse/notifuse/tests/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -82,6 +85,11 @@ func TestSegmentE2E(t *testing.T) {
 		testSegmentWithContactTimeline(t, client, factory, workspace.ID)
 	})
 
+	t.Run("Segment with Message Activity", func(t *testing.T) {
+		t.Cleanup(func() { testutil.CleanupAllTasks(t, client, workspace.ID) })
+		testSegmentWithMessageActivity(t, client, factory, workspace.ID)
+	})
+
 	t.Run("Segment Rebuild and Membership Updates", func(t *testing.T) {
 		t.Cleanup(func() { testutil.CleanupAllTasks(t, client, workspace.ID) })
 		testSegmentRebuild(t, client, factory, workspace.ID)
@@ -116,6 +124,11 @@ func TestSegmentE2E(t *testing.T) {
 		t.Cleanup(func() { testutil.CleanupAllTasks(t, client, workspace.ID) })
 		testCustomEventsGoalsSegmentation(t, client, factory, workspace.ID)
 	})
+
+	t.Run("Concurrent Recompute and Delta Membership Events", func(t *testing.T) {
+		segmentRepo := repository.NewSegmentRepository(suite.ServerManager.GetApp().GetWorkspaceRepository())
+		testConcurrentSegmentMembershipEvents(t, segmentRepo, factory, workspace.ID)
+	})
 }
 
 // testSimpleContactSegment tests creating a simple segment with contact filters
@@ -575,6 +588,107 @@ func testSegmentWithContactTimeline(t *testing.T, client *testutil.APIClient, fa
 	})
 }
 
+// testSegmentWithMessageActivity tests filtering contacts by the activity of their messages in message_history
+func testSegmentWithMessageActivity(t *testing.T, client *testutil.APIClient, factory *testutil.TestDataFactory, workspaceID string) {
+	t.Run("should filter contacts by opens in the last N days", func(t *testing.T) {
+		recentOpener, err := factory.CreateContact(workspaceID,
+			testutil.WithContactEmail("msgactivity-recent@example.com"))
+		require.NoError(t, err)
+
+		oldOpener, err := factory.CreateContact(workspaceID,
+			testutil.WithContactEmail("msgactivity-old@example.com"))
+		require.NoError(t, err)
+
+		neverOpened, err := factory.CreateContact(workspaceID,
+			testutil.WithContactEmail("msgactivity-never@example.com"))
+		require.NoError(t, err)
+
+		// Opened today
+		_, err = factory.CreateMessageHistory(workspaceID,
+			testutil.WithMessageContact(recentOpener.Email),
+			testutil.WithMessageOpened(true))
+		require.NoError(t, err)
+
+		// Opened 60 days ago, outside of the window
+		_, err = factory.CreateMessageHistory(workspaceID,
+			testutil.WithMessageContact(oldOpener.Email),
+			testutil.WithMessageSentAt(time.Now().UTC().AddDate(0, 0, -60)),
+			func(m *domain.MessageHistory) {
+				openedAt := time.Now().UTC().AddDate(0, 0, -60)
+				m.DeliveredAt = &openedAt
+				m.OpenedAt = &openedAt
+			})
+		require.NoError(t, err)
+
+		// Delivered but never opened
+		_, err = factory.CreateMessageHistory(workspaceID,
+			testutil.WithMessageContact(neverOpened.Email),
+			testutil.WithMessageDelivered(true))
+		require.NoError(t, err)
+
+		// Only the contacts of this test
+		activityTree := func(operator string) map[string]interface{} {
+			return map[string]interface{}{
+				"kind": "branch",
+				"branch": map[string]interface{}{
+					"operator": "and",
+					"leaves": []interface{}{
+						map[string]interface{}{
+							"kind": "leaf",
+							"leaf": map[string]interface{}{
+								"source": "contacts",
+								"contact": map[string]interface{}{
+									"filters": []interface{}{
+										map[string]interface{}{
+											"field_name":    "email",
+											"field_type":    "string",
+											"operator":      "contains",
+											"string_values": []string{"msgactivity-"},
+										},
+									},
+								},
+							},
+						},
+						map[string]interface{}{
+							"kind": "leaf",
+							"leaf": map[string]interface{}{
+								"source": "message_activity",
+								"message_activity": map[string]interface{}{
+									"event":    "opened",
+									"operator": operator,
+									"days":     30,
+								},
+							},
+						},
+					},
+				},
+			}
+		}
+
+		previewCount := func(tree map[string]interface{}) int {
+			previewResp, err := client.Post("/api/segments.preview", map[string]interface{}{
+				"workspace_id": workspaceID,
+				"tree":         tree,
+				"limit":        20,
+			})
+			require.NoError(t, err)
+			defer func() { _ = previewResp.Body.Close() }()
+			require.Equal(t, http.StatusOK, previewResp.StatusCode)
+
+			var previewResult map[string]interface{}
+			err = json.NewDecoder(previewResp.Body).Decode(&previewResult)
+			require.NoError(t, err)
+			return int(previewResult["total_count"].(float64))
+		}
+
+		// Only the recent opener opened a message in the last 30 days
+		assert.Equal(t, 1, previewCount(activityTree("any")), "Expected only the contact with an open in the window")
+
+		// The old opener and the contact who never opened are excluded from the openers
+		assert.Equal(t, 2, previewCount(activityTree("none")), "Expected the contacts without an open in the window")
+	})
+}
+
 // testSegmentRebuild tests rebuilding segments and membership updates
 func testSegmentRebuild(t *testing.T, client *testutil.APIClient, factory *testutil.TestDataFactory, workspaceID string) {
 	t.Run("should rebuild segment and update memberships", func(t *testing.T) {
@@ -2441,3 +2555,83 @@ func testCustomEventsGoalsSegmentation(t *testing.T, client *testutil.APIClient,
 		}
 	})
 }
+
+// testConcurrentSegmentMembershipEvents tests that a segment recompute and a contact delta update
+// observing the same transition emit a single membership event
+func testConcurrentSegmentMembershipEvents(t *testing.T, segmentRepo domain.SegmentRepository, factory *testutil.TestDataFactory, workspaceID string) {
+	ctx := context.Background()
+
+	workspaceDB, err := factory.GetWorkspaceDB(workspaceID)
+	require.NoError(t, err)
+
+	countEvents := func(t *testing.T, email, segmentID, operation string) int {
+		var count int
+		err := workspaceDB.QueryRowContext(ctx,
+			`SELECT COUNT(*) FROM contact_timeline WHERE email = $1 AND entity_type = 'contact_segment' AND entity_id = $2 AND operation = $3`,
+			email, segmentID, operation,
+		).Scan(&count)
+		require.NoError(t, err)
+		return count
+	}
+
+	t.Run("should emit one entry event when recompute and delta add concurrently", func(t *testing.T) {
+		contact, err := factory.CreateContact(workspaceID, testutil.WithContactEmail("concurrent-entry@example.com"))
+		require.NoError(t, err)
+		segmentID := fmt.Sprintf("concentry%d", time.Now().UnixNano()%1000000)
+
+		// Recompute and delta evaluate the same contact at slightly different times, repeatedly
+		recomputeAt := time.Now().UTC()
+		deltaAt := recomputeAt.Add(time.Millisecond)
+
+		var wg sync.WaitGroup
+		errs := make(chan error, 20)
+		for i := 0; i < 10; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				errs <- segmentRepo.AddContactToSegment(ctx, workspaceID, contact.Email, segmentID, 1, recomputeAt)
+			}()
+			go func() {
+				defer wg.Done()
+				errs <- segmentRepo.AddContactToSegment(ctx, workspaceID, contact.Email, segmentID, 1, deltaAt)
+			}()
+		}
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			require.NoError(t, err)
+		}
+
+		assert.Equal(t, 1, countEvents(t, contact.Email, segmentID, "insert"), "Expected exactly one segment entry event")
+		assert.Equal(t, 0, countEvents(t, contact.Email, segmentID, "delete"))
+	})
+
+	t.Run("should ignore a stale removal racing a newer entry", func(t *testing.T) {
+		contact, err := factory.CreateContact(workspaceID, testutil.WithContactEmail("concurrent-stale@example.com"))
+		require.NoError(t, err)
+		segmentID := fmt.Sprintf("constale%d", time.Now().UnixNano()%1000000)
+
+		// The recompute evaluated the contact before its update, the delta after it
+		recomputeAt := time.Now().UTC()
+		deltaAt := recomputeAt.Add(time.Second)
+
+		require.NoError(t, segmentRepo.AddContactToSegment(ctx, workspaceID, contact.Email, segmentID, 1, deltaAt))
+		require.NoError(t, segmentRepo.RemoveContactFromSegment(ctx, workspaceID, contact.Email, segmentID, 1, recomputeAt))
+		require.NoError(t, segmentRepo.AddContactToSegment(ctx, workspaceID, contact.Email, segmentID, 1, deltaAt))
+
+		var members int
+		err = workspaceDB.QueryRowContext(ctx,
+			`SELECT COUNT(*) FROM contact_segments WHERE email = $1 AND segment_id = $2`,
+			contact.Email, segmentID,
+		).Scan(&members)
+		require.NoError(t, err)
+		assert.Equal(t, 1, members)
+		assert.Equal(t, 1, countEvents(t, contact.Email, segmentID, "insert"))
+		assert.Equal(t, 0, countEvents(t, contact.Email, segmentID, "delete"))
+
+		// A newer removal still exits the contact exactly once
+		require.NoError(t, segmentRepo.RemoveContactFromSegment(ctx, workspaceID, contact.Email, segmentID, 1, deltaAt.Add(time.Second)))
+		require.NoError(t, segmentRepo.RemoveContactFromSegment(ctx, workspaceID, contact.Email, segmentID, 1, deltaAt))
+		assert.Equal(t, 1, countEvents(t, contact.Email, segmentID, "delete"))
+	})
+}